@@ -0,0 +1,113 @@
+package alias
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+// Replace the command in the arguments with its expansion if it's one of the user's aliases from the aliases section of the settings.
+// Built-in commands always take precedence over aliases, and aliases aren't expanded recursively.
+func ExpandAliases(app *cli.App, args []string, aliases map[string]string) ([]string, error) {
+	if len(aliases) == 0 {
+		return args, nil
+	}
+
+	// Find the command, skipping the global flags and their values
+	index := getCommandIndex(app, args)
+	if index == -1 {
+		return args, nil
+	}
+	name := args[index]
+	expansion, exists := aliases[name]
+	if !exists {
+		return args, nil
+	}
+	if app.Command(name) != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: alias '%s' has the same name as a built-in command, so it will be ignored.\n", name)
+		return args, nil
+	}
+
+	expandedArgs, err := splitCommandLine(expansion)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing alias '%s': %w", name, err)
+	}
+	if len(expandedArgs) == 0 {
+		return nil, fmt.Errorf("alias '%s' is empty", name)
+	}
+
+	newArgs := make([]string, 0, len(args)+len(expandedArgs))
+	newArgs = append(newArgs, args[:index]...)
+	newArgs = append(newArgs, expandedArgs...)
+	newArgs = append(newArgs, args[index+1:]...)
+	return newArgs, nil
+}
+
+// Get the index of the command in the arguments, or -1 if there isn't one
+func getCommandIndex(app *cli.App, args []string) int {
+	// Get the global flags that take a value
+	valueFlags := map[string]bool{}
+	for _, flag := range app.Flags {
+		switch flag.(type) {
+		case cli.BoolFlag, cli.BoolTFlag:
+			continue
+		}
+		for _, name := range strings.Split(flag.GetName(), ",") {
+			valueFlags[strings.TrimSpace(name)] = true
+		}
+	}
+
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return -1
+		}
+		if !strings.HasPrefix(arg, "-") {
+			return i
+		}
+		name := strings.TrimLeft(arg, "-")
+		if !strings.Contains(name, "=") && valueFlags[name] {
+			i++
+		}
+	}
+	return -1
+}
+
+// Split a command line into its arguments, respecting single and double quotes
+func splitCommandLine(commandLine string) ([]string, error) {
+	args := []string{}
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	for _, char := range commandLine {
+		switch {
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			} else {
+				current.WriteRune(char)
+			}
+		case char == '"' || char == '\'':
+			quote = char
+			inArg = true
+		case char == ' ' || char == '\t' || char == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(char)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -74,7 +74,7 @@ func bidOnLot(c *cli.Context) error {
 		for li, lot := range openLots {
 			options[li] = fmt.Sprintf("lot %d (%.6f RPL available @ %.6f ETH per RPL)", lot.Details.Index, math.RoundDown(eth.WeiToEth(lot.Details.RemainingRPLAmount), 6), math.RoundDown(eth.WeiToEth(lot.Details.CurrentPrice), 6))
 		}
-		selected, _ := cliutils.Select("Please select a lot to bid on:", options)
+		selected, _ := cliutils.Select("Please select a lot to bid on:", options, "ROCKETPOOL_LOT")
 		selectedLot = openLots[selected]
 
 	}
@@ -113,7 +113,7 @@ func bidOnLot(c *cli.Context) error {
 		} else {
 
 			// Prompt for custom amount
-			inputAmount := cliutils.Prompt("Please enter an amount of ETH to bid:", "^\\d+(\\.\\d+)?$", "Invalid amount")
+			inputAmount := cliutils.Prompt("Please enter an amount of ETH to bid:", "^\\d+(\\.\\d+)?$", "Invalid amount", "ROCKETPOOL_BID_AMOUNT")
 			bidAmount, err := strconv.ParseFloat(inputAmount, 64)
 			if err != nil {
 				return fmt.Errorf("Invalid bid amount '%s': %w", inputAmount, err)
@@ -144,7 +144,7 @@ func bidOnLot(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to bid %.6f ETH on lot %d? Bids are final and non-refundable.", math.RoundDown(eth.WeiToEth(amountWei), 6), selectedLot.Details.Index))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to bid %.6f ETH on lot %d? Bids are final and non-refundable.", math.RoundDown(eth.WeiToEth(amountWei), 6), selectedLot.Details.Index))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -156,7 +156,9 @@ func bidOnLot(c *cli.Context) error {
 	}
 
 	fmt.Printf("Bidding on lot...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
@@ -80,7 +80,7 @@ func claimFromLot(c *cli.Context) error {
 		for li, lot := range claimableLots {
 			options[li+1] = fmt.Sprintf("lot %d (%.6f ETH bid @ %.6f ETH per RPL)", lot.Details.Index, math.RoundDown(eth.WeiToEth(lot.Details.AddressBidAmount), 6), math.RoundDown(eth.WeiToEth(lot.Details.CurrentPrice), 6))
 		}
-		selected, _ := cliutils.Select("Please select a lot to claim RPL from:", options)
+		selected, _ := cliutils.Select("Please select a lot to claim RPL from:", options, "ROCKETPOOL_LOT")
 
 		// Get lots
 		if selected == 0 {
@@ -114,7 +114,7 @@ func claimFromLot(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to claim %d lots?", len(selectedLots)))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to claim %d lots?", len(selectedLots)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -128,7 +128,9 @@ func claimFromLot(c *cli.Context) error {
 		}
 
 		fmt.Printf("Claiming from lot %d...\n", lot.Details.Index)
-		cliutils.PrintTransactionHash(rp, response.TxHash)
+		if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+			return err
+		}
 		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 			fmt.Printf("Could not claim RPL from lot %d: %s.\n", lot.Details.Index, err)
 		} else {
@@ -42,7 +42,7 @@ func createLot(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to create this lot?")) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction("Are you sure you want to create this lot?")) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -54,7 +54,9 @@ func createLot(c *cli.Context) error {
 	}
 
 	fmt.Printf("Creating lot...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
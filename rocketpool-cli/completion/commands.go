@@ -0,0 +1,31 @@
+package completion
+
+import (
+	"github.com/urfave/cli"
+
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:      name,
+		Aliases:   aliases,
+		Usage:     "Print a shell completion script for the Rocket Pool CLI (bash, zsh or fish)",
+		UsageText: "rocketpool completion shell\n\n   Load it in your shell's profile, e.g. for bash:\n   source <(rocketpool completion bash)",
+		BashComplete: func(c *cli.Context) {
+			printShells(c)
+		},
+		Action: func(c *cli.Context) error {
+
+			// Validate args
+			if err := cliutils.ValidateArgCount(c, 1); err != nil {
+				return err
+			}
+
+			// Run
+			return printCompletionScript(c.Args().Get(0))
+
+		},
+	})
+}
@@ -0,0 +1,90 @@
+package completion
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+)
+
+// The completion scripts ask the CLI itself for the completions at each position, so they always match this version's
+// commands and flags, and can include values that come from the daemon (such as the node's minipool addresses)
+const bashScript string = `# bash completion for rocketpool
+_rocketpool_complete() {
+    local cur opts
+    COMPREPLY=()
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    if [[ "$cur" == "-"* ]]; then
+        opts=$( "${COMP_WORDS[@]:0:$COMP_CWORD}" "$cur" --generate-bash-completion 2>/dev/null )
+    else
+        opts=$( "${COMP_WORDS[@]:0:$COMP_CWORD}" --generate-bash-completion 2>/dev/null )
+    fi
+    COMPREPLY=( $(compgen -W "${opts}" -- "${cur}") )
+    return 0
+}
+
+complete -o bashdefault -o default -F _rocketpool_complete rocketpool
+`
+
+const zshScript string = `#compdef rocketpool
+
+_rocketpool() {
+    local -a opts
+    local cur
+    cur=${words[-1]}
+    if [[ "$cur" == "-"* ]]; then
+        opts=("${(@f)$(_CLI_ZSH_AUTOCOMPLETE_HACK=1 ${words[@]:0:#words[@]-1} ${cur} --generate-bash-completion 2>/dev/null)}")
+    else
+        opts=("${(@f)$(_CLI_ZSH_AUTOCOMPLETE_HACK=1 ${words[@]:0:#words[@]-1} --generate-bash-completion 2>/dev/null)}")
+    fi
+
+    if [[ "${opts[1]}" != "" ]]; then
+        _describe 'values' opts
+    else
+        _files
+    fi
+}
+
+if [ "$funcstack[1]" = "_rocketpool" ]; then
+    _rocketpool "$@"
+else
+    compdef _rocketpool rocketpool
+fi
+`
+
+const fishScript string = `# fish completion for rocketpool
+function __rocketpool_complete
+    set -l tokens (commandline -opc)
+    set -l current (commandline -ct)
+    if string match -q -- '-*' $current
+        $tokens $current --generate-bash-completion 2>/dev/null
+    else
+        $tokens --generate-bash-completion 2>/dev/null
+    end
+end
+
+complete -c rocketpool -f -a '(__rocketpool_complete)'
+`
+
+// The shells there are completion scripts for
+var scripts = map[string]string{
+	"bash": bashScript,
+	"zsh":  zshScript,
+	"fish": fishScript,
+}
+
+// Print the completion script for a shell
+func printCompletionScript(shell string) error {
+	script, exists := scripts[shell]
+	if !exists {
+		return fmt.Errorf("Unsupported shell '%s'; the supported shells are bash, zsh and fish.", shell)
+	}
+	fmt.Print(script)
+	return nil
+}
+
+// Complete the shell argument
+func printShells(c *cli.Context) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		fmt.Fprintln(c.App.Writer, shell)
+	}
+}
@@ -47,7 +47,7 @@ func withdrawRpl(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to withdraw legacy RPL from the faucet?")) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction("Are you sure you want to withdraw legacy RPL from the faucet?")) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -59,7 +59,9 @@ func withdrawRpl(c *cli.Context) error {
 	}
 
 	fmt.Printf("Withdrawing legacy RPL...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
@@ -0,0 +1,99 @@
+package fleet
+
+import (
+	"strings"
+
+	"github.com/urfave/cli"
+
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string, configPath string) {
+	nodesFlag := cli.StringFlag{
+		Name:  "nodes, n",
+		Usage: "A comma-separated list of the nodes to include, by their names in " + FleetFile + " (default: all of them)",
+	}
+
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "View several nodes at once, as listed in " + FleetFile + " in the config directory",
+		Subcommands: []cli.Command{
+
+			{
+				Name:      "status",
+				Aliases:   []string{"s"},
+				Usage:     "Get the status of every node in the fleet, with totals",
+				UsageText: "rocketpool fleet status [options]",
+				Flags:     []cli.Flag{nodesFlag},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getStatus(c, configPath)
+
+				},
+			},
+
+			{
+				Name:      "rewards",
+				Aliases:   []string{"r"},
+				Usage:     "Get the rewards of every node in the fleet, with totals",
+				UsageText: "rocketpool fleet rewards [options]",
+				Flags:     []cli.Flag{nodesFlag},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getRewards(c, configPath)
+
+				},
+			},
+
+			{
+				Name:      "minipools",
+				Aliases:   []string{"m"},
+				Usage:     "List the minipools of every node in the fleet",
+				UsageText: "rocketpool fleet minipools [options]",
+				Flags: []cli.Flag{
+					nodesFlag,
+					cli.BoolFlag{
+						Name:  "include-finalized, f",
+						Usage: "Include finalized minipools in the list",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getMinipools(c, configPath)
+
+				},
+			},
+		},
+	})
+}
+
+// Get the names of the nodes selected with the --nodes flag
+func getNodeNames(c *cli.Context) []string {
+	names := []string{}
+	for _, name := range strings.Split(c.String("nodes"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,214 @@
+package fleet
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+const (
+	// The file in the CLI's config directory that lists the nodes in the user's fleet
+	FleetFile string = "fleet.yml"
+
+	// How long to wait for an SSH tunnel to a node's API socket to come up
+	sshTunnelTimeout time.Duration = 15 * time.Second
+)
+
+// A node in the fleet, reached in one of three ways:
+//   - url (and token or tokenFile): the daemon's HTTP API port, authenticated with an API token
+//   - ssh and socket: the daemon's HTTP API socket on the remote machine, forwarded over SSH
+//   - socket: the daemon's HTTP API socket on this machine
+type fleetNode struct {
+	Name      string `yaml:"name"`
+	Url       string `yaml:"url"`
+	Token     string `yaml:"token"`
+	TokenFile string `yaml:"tokenFile"`
+	Ssh       string `yaml:"ssh"`
+	Socket    string `yaml:"socket"`
+}
+
+type fleetFile struct {
+	Nodes []fleetNode `yaml:"nodes"`
+}
+
+// Load the nodes in the fleet from the CLI's config directory, keeping only the named ones if any are provided
+func loadFleet(configPath string, names []string) ([]fleetNode, error) {
+	fleetPath, err := homedir.Expand(filepath.Join(configPath, FleetFile))
+	if err != nil {
+		return nil, fmt.Errorf("error expanding fleet file path: %w", err)
+	}
+	bytes, err := os.ReadFile(fleetPath)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("No fleet has been set up. Please list your nodes in %s first.", fleetPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading fleet file [%s]: %w", fleetPath, err)
+	}
+
+	var file fleetFile
+	if err := yaml.Unmarshal(bytes, &file); err != nil {
+		return nil, fmt.Errorf("error parsing fleet file [%s]: %w", fleetPath, err)
+	}
+	seen := map[string]bool{}
+	for _, node := range file.Nodes {
+		if err := node.validate(); err != nil {
+			return nil, fmt.Errorf("error in fleet file [%s]: %w", fleetPath, err)
+		}
+		if seen[node.Name] {
+			return nil, fmt.Errorf("error in fleet file [%s]: there is more than one node named '%s'", fleetPath, node.Name)
+		}
+		seen[node.Name] = true
+	}
+	if len(names) == 0 {
+		if len(file.Nodes) == 0 {
+			return nil, fmt.Errorf("There are no nodes in %s.", fleetPath)
+		}
+		return file.Nodes, nil
+	}
+
+	nodes := []fleetNode{}
+	for _, name := range names {
+		if !seen[name] {
+			return nil, fmt.Errorf("There is no node named '%s' in %s.", name, fleetPath)
+		}
+		for _, node := range file.Nodes {
+			if node.Name == name {
+				nodes = append(nodes, node)
+			}
+		}
+	}
+	return nodes, nil
+}
+
+// Check that a node has a name and exactly one way to reach it
+func (n fleetNode) validate() error {
+	if n.Name == "" {
+		return fmt.Errorf("every node needs a name")
+	}
+	if n.Url != "" && (n.Ssh != "" || n.Socket != "") {
+		return fmt.Errorf("node '%s' can have a url or a socket, but not both", n.Name)
+	}
+	if n.Url == "" && n.Socket == "" {
+		return fmt.Errorf("node '%s' needs a url, or the path of its API socket", n.Name)
+	}
+	if n.Url != "" && n.Token == "" && n.TokenFile == "" {
+		return fmt.Errorf("node '%s' needs an API token (token or tokenFile) to use its url", n.Name)
+	}
+	if n.Ssh != "" && !filepath.IsAbs(n.Socket) {
+		return fmt.Errorf("node '%s' needs the absolute path of its API socket on the remote machine", n.Name)
+	}
+	return nil
+}
+
+// Connect to a node's HTTP API, and get the function that closes the connection
+func (n fleetNode) connect() (*rocketpool.HttpApiClient, func(), error) {
+	switch {
+	case n.Url != "":
+		token := n.Token
+		if token == "" {
+			tokenPath, err := homedir.Expand(n.TokenFile)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error expanding token file path: %w", err)
+			}
+			tokenBytes, err := os.ReadFile(tokenPath)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error reading token file [%s]: %w", tokenPath, err)
+			}
+			token = strings.TrimSpace(string(tokenBytes))
+		}
+		return rocketpool.NewHttpApiClient(n.Url, token), func() {}, nil
+
+	case n.Ssh != "":
+		localSocket, closeTunnel, err := openSshTunnel(n.Ssh, n.Socket)
+		if err != nil {
+			return nil, nil, err
+		}
+		return rocketpool.NewHttpApiSocketClient(localSocket), closeTunnel, nil
+
+	default:
+		socketPath, err := homedir.Expand(n.Socket)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error expanding socket path: %w", err)
+		}
+		return rocketpool.NewHttpApiSocketClient(socketPath), func() {}, nil
+	}
+}
+
+// Forward a remote API socket to a local one with SSH, and get the local socket and the function that closes the tunnel.
+// SSH runs in batch mode, so the remote machine has to accept a key from the user's agent or SSH config.
+func openSshTunnel(destination string, remoteSocket string) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "rocketpool-fleet-")
+	if err != nil {
+		return "", nil, fmt.Errorf("error creating the SSH tunnel's socket folder: %w", err)
+	}
+	localSocket := filepath.Join(dir, "api.sock")
+
+	cmd := exec.Command("ssh", "-N",
+		"-o", "BatchMode=yes",
+		"-o", "ExitOnForwardFailure=yes",
+		"-L", localSocket+":"+remoteSocket,
+		destination)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(dir)
+		return "", nil, fmt.Errorf("error starting SSH: %w", err)
+	}
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+	closeTunnel := func() {
+		cmd.Process.Kill()
+		<-exited
+		os.RemoveAll(dir)
+	}
+
+	// Wait for SSH to create the local socket
+	timeout := time.After(sshTunnelTimeout)
+	for {
+		if _, err := os.Stat(localSocket); err == nil {
+			return localSocket, closeTunnel, nil
+		}
+		select {
+		case err := <-exited:
+			os.RemoveAll(dir)
+			return "", nil, fmt.Errorf("SSH tunnel to %s failed: %w: %s", destination, err, strings.TrimSpace(stderr.String()))
+		case <-timeout:
+			closeTunnel()
+			return "", nil, fmt.Errorf("timed out waiting for the SSH tunnel to %s", destination)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// Run a query against every node in parallel, and get each node's error (or nil) in the same order as the nodes
+func queryFleet(nodes []fleetNode, query func(index int, node fleetNode, client *rocketpool.HttpApiClient) error) []error {
+	errs := make([]error, len(nodes))
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(i int, node fleetNode) {
+			defer wg.Done()
+			client, closeClient, err := node.connect()
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer closeClient()
+			errs[i] = query(i, node, client)
+		}(i, node)
+	}
+	wg.Wait()
+	return errs
+}
@@ -0,0 +1,91 @@
+package fleet
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/types/output"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getMinipools(c *cli.Context, configPath string) error {
+
+	includeFinalized := c.Bool("include-finalized")
+	nodes, err := loadFleet(configPath, getNodeNames(c))
+	if err != nil {
+		return err
+	}
+
+	// Get the minipools of each node
+	minipools := make([]output.FleetNodeMinipools, len(nodes))
+	errs := queryFleet(nodes, func(index int, node fleetNode, client *rocketpool.HttpApiClient) error {
+		var response api.MinipoolStatusResponse
+		if err := client.Get("minipool", "status", &response); err != nil {
+			return err
+		}
+		list := output.NewMinipoolList(&response, includeFinalized)
+		minipools[index].Minipools = &list
+		return nil
+	})
+	for i, node := range nodes {
+		minipools[i].Name = node.Name
+		if errs[i] != nil {
+			minipools[i].Error = errs[i].Error()
+		}
+	}
+	fleetMinipools := output.NewFleetMinipools(minipools)
+	if cliutils.IsJsonOutput(c) {
+		return cliutils.PrintJson(fleetMinipools)
+	}
+
+	// Print the minipools
+	fmt.Printf("%-20s %-42s %-14s %-10s %12s %12s\n", "Node", "Minipool", "Status", "Validator", "Balance", "Node Share")
+	for _, node := range fleetMinipools.Nodes {
+		if node.Minipools == nil {
+			fmt.Printf("%-20s %s%s%s\n", node.Name, colorRed, node.Error, colorReset)
+			continue
+		}
+		for _, mp := range node.Minipools.Minipools {
+			validatorIndex := mp.Validator.Index
+			if !mp.Validator.Exists {
+				validatorIndex = "-"
+			}
+			fmt.Printf("%-20s %-42s %-14s %-10s %12.6f %12.6f\n",
+				node.Name,
+				mp.Address.Hex(),
+				mp.Status,
+				validatorIndex,
+				mp.Validator.Balance,
+				mp.Validator.NodeBalance)
+		}
+	}
+
+	// Print the totals
+	totals := fleetMinipools.Totals
+	fmt.Println()
+	fmt.Printf("%d minipool(s) across %d node(s)", totals.Minipools, totals.Nodes-totals.Unreachable)
+	statuses := make([]string, 0, len(totals.Statuses))
+	for status := range totals.Statuses {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for i, status := range statuses {
+		if i == 0 {
+			fmt.Print(": ")
+		} else {
+			fmt.Print(", ")
+		}
+		fmt.Printf("%d %s", totals.Statuses[status], status)
+	}
+	fmt.Println()
+	fmt.Printf("Total validator balance: %.6f ETH (%.6f ETH belongs to the nodes)\n", totals.Balance, totals.NodeShareOfBalance)
+	if totals.Unreachable > 0 {
+		fmt.Printf("%s%d of %d node(s) could not be queried, so their minipools are not listed.%s\n", colorYellow, totals.Unreachable, totals.Nodes, colorReset)
+	}
+	return nil
+
+}
@@ -0,0 +1,73 @@
+package fleet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/types/output"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getRewards(c *cli.Context, configPath string) error {
+
+	nodes, err := loadFleet(configPath, getNodeNames(c))
+	if err != nil {
+		return err
+	}
+
+	// Get the rewards of each node
+	rewards := make([]output.FleetNodeRewards, len(nodes))
+	errs := queryFleet(nodes, func(index int, node fleetNode, client *rocketpool.HttpApiClient) error {
+		var response api.NodeRewardsResponse
+		if err := client.Get("node", "rewards", &response); err != nil {
+			return err
+		}
+		nodeRewards := output.NewNodeRewards(&response, nil)
+		rewards[index].Rewards = &nodeRewards
+		return nil
+	})
+	for i, node := range nodes {
+		rewards[i].Name = node.Name
+		if errs[i] != nil {
+			rewards[i].Error = errs[i].Error()
+		}
+	}
+	fleetRewards := output.NewFleetRewards(rewards)
+	if cliutils.IsJsonOutput(c) {
+		return cliutils.PrintJson(fleetRewards)
+	}
+
+	// Print the nodes
+	fmt.Printf("%-20s %14s %14s %14s %14s %14s\n", "Node", "Unclaimed RPL", "Estimated RPL", "Claimed RPL", "Unclaimed ETH", "Claimed ETH")
+	for _, node := range fleetRewards.Nodes {
+		if node.Rewards == nil {
+			fmt.Printf("%-20s %s%s%s\n", node.Name, colorRed, node.Error, colorReset)
+			continue
+		}
+		if !node.Rewards.Registered {
+			fmt.Printf("%-20s %sNot registered%s\n", node.Name, colorYellow, colorReset)
+			continue
+		}
+		fmt.Printf("%-20s %14.6f %14.6f %14.6f %14.6f %14.6f\n",
+			node.Name,
+			node.Rewards.Rpl.Unclaimed,
+			node.Rewards.Rpl.EstimatedRewards,
+			node.Rewards.Rpl.Cumulative,
+			node.Rewards.Eth.UnclaimedSmoothingPool,
+			node.Rewards.Eth.ClaimedSmoothingPool)
+	}
+
+	// Print the totals
+	totals := fleetRewards.Totals
+	fmt.Println()
+	fmt.Printf("%-20s %14.6f %14.6f %14.6f %14.6f %14.6f\n", "Total", totals.UnclaimedRpl, totals.EstimatedRpl, totals.CumulativeRpl, totals.UnclaimedSmoothingPool, totals.ClaimedSmoothingPool)
+	fmt.Printf("The fleet's validators have earned %.6f ETH on the Beacon Chain.\n", totals.BeaconRewards)
+	if totals.Unreachable > 0 {
+		fmt.Printf("%s%d of %d node(s) could not be queried, so they are not included in the totals.%s\n", colorYellow, totals.Unreachable, totals.Nodes, colorReset)
+	}
+	return nil
+
+}
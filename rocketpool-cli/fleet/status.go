@@ -0,0 +1,86 @@
+package fleet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/types/output"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+const colorReset string = "\033[0m"
+const colorRed string = "\033[31m"
+const colorYellow string = "\033[33m"
+
+func getStatus(c *cli.Context, configPath string) error {
+
+	nodes, err := loadFleet(configPath, getNodeNames(c))
+	if err != nil {
+		return err
+	}
+
+	// Get the status of each node
+	statuses := make([]output.FleetNodeStatus, len(nodes))
+	errs := queryFleet(nodes, func(index int, node fleetNode, client *rocketpool.HttpApiClient) error {
+		var response api.NodeStatusResponse
+		if err := client.Get("node", "status", &response); err != nil {
+			return err
+		}
+		status := output.NewNodeStatus(&response, "")
+		statuses[index].Status = &status
+		return nil
+	})
+	for i, node := range nodes {
+		statuses[i].Name = node.Name
+		if errs[i] != nil {
+			statuses[i].Error = errs[i].Error()
+		}
+	}
+	fleetStatus := output.NewFleetStatus(statuses)
+	if cliutils.IsJsonOutput(c) {
+		return cliutils.PrintJson(fleetStatus)
+	}
+
+	// Print the nodes
+	fmt.Printf("%-20s %-42s %9s %9s %12s %12s %12s %10s\n", "Node", "Address", "Minipools", "Staking", "ETH", "RPL Stake", "Effective", "Collateral")
+	for _, node := range fleetStatus.Nodes {
+		if node.Status == nil {
+			fmt.Printf("%-20s %s%s%s\n", node.Name, colorRed, node.Error, colorReset)
+			continue
+		}
+		status := node.Status
+		if !status.Registered {
+			fmt.Printf("%-20s %-42s %sNot registered%s\n", node.Name, status.AccountAddress.Hex(), colorYellow, colorReset)
+			continue
+		}
+		collateral := fmt.Sprintf("%.2f%%", status.RplStake.BorrowedCollateralRatio*100)
+		if status.RplStake.Undercollateralized {
+			collateral = colorRed + collateral + colorReset
+		}
+		fmt.Printf("%-20s %-42s %9d %9d %12.6f %12.6f %12.6f %10s\n",
+			node.Name,
+			status.AccountAddress.Hex(),
+			status.MinipoolCounts.Active,
+			status.MinipoolCounts.Staking,
+			status.AccountBalances.Eth,
+			status.RplStake.Total,
+			status.RplStake.Effective,
+			collateral)
+	}
+
+	// Print the totals
+	totals := fleetStatus.Totals
+	fmt.Println()
+	fmt.Printf("%-20s %-42s %9d %9d %12.6f %12.6f %12.6f\n", "Total", "", totals.ActiveMinipools, totals.StakingMinipools, totals.EthBalance, totals.RplStake, totals.EffectiveRplStake)
+	if totals.Undercollateralized > 0 {
+		fmt.Printf("%s%d node(s) are below the minimum RPL stake and are not earning RPL rewards.%s\n", colorRed, totals.Undercollateralized, colorReset)
+	}
+	if totals.Unreachable > 0 {
+		fmt.Printf("%s%d of %d node(s) could not be queried, so they are not included in the totals.%s\n", colorYellow, totals.Unreachable, totals.Nodes, colorReset)
+	}
+	return nil
+
+}
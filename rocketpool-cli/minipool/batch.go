@@ -0,0 +1,164 @@
+package minipool
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The messages printed while running a batch of minipool transactions; each one is formatted with the minipool address
+type batchMessages struct {
+	submitting string
+	failed     string
+	succeeded  string
+}
+
+// Submits a transaction for each minipool and waits for them.
+// If there are several minipools, all of the transactions are submitted up front with consecutive nonces so they can be
+// included in the same block instead of waiting for each one in turn.
+func runMinipoolBatch(rp *rocketpool.Client, addresses []common.Address, submit func(common.Address) (common.Hash, error), messages batchMessages) error {
+
+	// Transactions that aren't submitted can't be waited for
+	if rp.IsUnsigned() || rp.IsDryRun() || rp.IsDeferred() {
+		return runUnsubmittedMinipoolBatch(rp, addresses, submit, messages)
+	}
+
+	// Safe proposals can't be pipelined, so run them one at a time
+	sequential, err := isSequentialBatch(rp)
+	if err != nil {
+		return err
+	}
+	if sequential || len(addresses) < 2 {
+		for _, address := range addresses {
+			hash, err := submit(address)
+			if err != nil {
+				fmt.Printf(messages.failed+": %s.\n", address.Hex(), err.Error())
+				continue
+			}
+
+			fmt.Printf(messages.submitting+"...\n", address.Hex())
+			if err = cliutils.PrintTransactionHash(rp, hash); err != nil {
+				return err
+			}
+			if _, err = rp.WaitForTransaction(hash); err != nil {
+				fmt.Printf(messages.failed+": %s.\n", address.Hex(), err.Error())
+			} else {
+				fmt.Printf(messages.succeeded+".\n", address.Hex())
+			}
+		}
+		return nil
+	}
+
+	// Start from the custom nonce if one was provided, otherwise from the node's next pending nonce
+	if rp.HasCustomNonce() {
+		cliutils.PrintMultiTransactionNonceWarning()
+	} else {
+		nonceResponse, err := rp.GetNodeNonce()
+		if err != nil {
+			return err
+		}
+		rp.SetCustomNonce(nonceResponse.Nonce)
+	}
+
+	// Submit all of the transactions
+	submittedAddresses := []common.Address{}
+	hashes := []common.Hash{}
+	for _, address := range addresses {
+		fmt.Printf(messages.submitting+"...\n", address.Hex())
+		hash, err := submit(address)
+		if err != nil {
+			// Nothing was broadcast, so the nonce can be reused by the next minipool
+			fmt.Printf(messages.failed+": %s.\n", address.Hex(), err.Error())
+			continue
+		}
+		fmt.Printf("Transaction has been submitted with hash %s.\n", hash.Hex())
+		submittedAddresses = append(submittedAddresses, address)
+		hashes = append(hashes, hash)
+		rp.IncrementCustomNonce()
+	}
+	if len(hashes) == 0 {
+		return fmt.Errorf("None of the %d transactions could be submitted.", len(addresses))
+	}
+
+	// Wait for them to be included
+	fmt.Printf("\nWaiting for %d transactions to be included in a block... you may wait here for them, or press CTRL+C to exit and return to the terminal.\n\n", len(hashes))
+	succeeded := 0
+	for i, hash := range hashes {
+		address := submittedAddresses[i]
+		if _, err := rp.WaitForTransaction(hash); err != nil {
+			fmt.Printf(messages.failed+": %s.\n", address.Hex(), err.Error())
+		} else {
+			fmt.Printf(messages.succeeded+".\n", address.Hex())
+			succeeded++
+		}
+	}
+	fmt.Printf("\n%d of %d transactions completed successfully.\n", succeeded, len(addresses))
+	return nil
+
+}
+
+// Saves, simulates or defers a transaction for each minipool without waiting for any of them.
+// Unsigned transactions are given consecutive nonces so they can all be signed and broadcast; deferred ones are already queued after each other.
+func runUnsubmittedMinipoolBatch(rp *rocketpool.Client, addresses []common.Address, submit func(common.Address) (common.Hash, error), messages batchMessages) error {
+
+	// Start from the custom nonce if one was provided, otherwise from the node's next pending nonce
+	if rp.IsUnsigned() && len(addresses) > 1 {
+		if rp.HasCustomNonce() {
+			cliutils.PrintMultiTransactionNonceWarning()
+		} else {
+			nonceResponse, err := rp.GetNodeNonce()
+			if err != nil {
+				return err
+			}
+			rp.SetCustomNonce(nonceResponse.Nonce)
+		}
+	}
+
+	// Create all of the transactions
+	created := 0
+	for _, address := range addresses {
+		fmt.Printf(messages.submitting+"...\n", address.Hex())
+		hash, err := submit(address)
+		if err != nil {
+			fmt.Printf(messages.failed+": %s.\n", address.Hex(), err.Error())
+			continue
+		}
+		err = cliutils.PrintTransactionHash(rp, hash)
+		if err != nil && !errors.Is(err, cliutils.ErrTransactionNotSubmitted) {
+			return err
+		}
+		fmt.Println()
+		created++
+		if rp.IsUnsigned() {
+			rp.IncrementCustomNonce()
+		}
+	}
+	if created == 0 {
+		return fmt.Errorf("None of the %d transactions could be created.", len(addresses))
+	}
+	fmt.Printf("%d of %d transactions were created.\n", created, len(addresses))
+	return nil
+
+}
+
+// Checks if the node's transactions have to be run one at a time
+func isSequentialBatch(rp *rocketpool.Client) (bool, error) {
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return false, err
+	}
+	return cfg.Smartnode.SafeAddress.Value.(string) != "", nil
+}
+
+// Gets the minipool selection, treating the --all flag like `--minipool all`
+func getMinipoolSelection(c *cli.Context) string {
+	if c.Bool("all") {
+		return "all"
+	}
+	return c.String("minipool")
+}
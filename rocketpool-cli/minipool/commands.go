@@ -1,14 +1,17 @@
 package minipool
 
 import (
+	"fmt"
+
 	"github.com/urfave/cli"
 
+	clinode "github.com/rocket-pool/smartnode/rocketpool-cli/node"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
 // Register commands
 func RegisterCommands(app *cli.App, name string, aliases []string) {
-	app.Commands = append(app.Commands, cli.Command{
+	command := cli.Command{
 		Name:    name,
 		Aliases: aliases,
 		Usage:   "Manage the node's minipools",
@@ -18,13 +21,13 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Name:      "status",
 				Aliases:   []string{"s"},
 				Usage:     "Get a list of the node's minipools",
-				UsageText: "rocketpool minipool status",
-				Flags: []cli.Flag{
+				UsageText: "rocketpool minipool status [options]",
+				Flags: append([]cli.Flag{
 					cli.BoolFlag{
 						Name:  "include-finalized, f",
 						Usage: "Include finalized minipools in the list (default is to hide them).",
 					},
-				},
+				}, cliutils.WatchFlags...),
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -38,6 +41,38 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "export",
+				Usage:     "Export the balances, statuses, commissions, penalties and validator indices of the node's minipools from the latest network state",
+				UsageText: "rocketpool minipool export [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "format, f",
+						Usage: "The format to export in ('csv' or 'json')",
+						Value: "csv",
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file to write the export to; if this isn't set, it will be printed to the terminal",
+					},
+					cli.BoolFlag{
+						Name:  "include-finalized",
+						Usage: "Include finalized minipools in the export (default is to leave them out)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return exportMinipools(c)
+
+				},
+			},
+
 			{
 				Name:      "stake",
 				Aliases:   []string{"t"},
@@ -48,6 +83,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "minipool, m",
 						Usage: "The minipool/s to stake (address or 'all')",
 					},
+					cli.BoolFlag{
+						Name:  "all, a",
+						Usage: "Select all eligible minipools; equivalent to '--minipool all'",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm staking the minipools",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -63,12 +106,40 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						}
 					}
 
+					if c.Bool("all") && c.String("minipool") != "" {
+						return fmt.Errorf("The --all and --minipool flags cannot be used together.")
+					}
+
 					// Run
 					return stakeMinipools(c)
 
 				},
 			},
 
+			clinode.NewCreateVacantMinipoolCommand("create-vacant", []string{"cv"}, "rocketpool minipool create-vacant pubkey [options]"),
+
+			{
+				Name:      "migration-status",
+				Aliases:   []string{"ms"},
+				Usage:     "Track a solo validator's migration into a vacant minipool: its withdrawal credentials change and its promotion window",
+				UsageText: "rocketpool minipool migration-status minipool-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					address, err := cliutils.ValidateAddress("minipool-address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return getMigrationStatus(c, address)
+
+				},
+			},
+
 			{
 				Name:      "set-withdrawal-creds",
 				Aliases:   []string{"swc"},
@@ -172,6 +243,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "minipool, m",
 						Usage: "The minipool/s to refund from (address or 'all')",
 					},
+					cli.BoolFlag{
+						Name:  "all, a",
+						Usage: "Select all eligible minipools; equivalent to '--minipool all'",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm refunding the minipools",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -187,6 +266,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						}
 					}
 
+					if c.Bool("all") && c.String("minipool") != "" {
+						return fmt.Errorf("The --all and --minipool flags cannot be used together.")
+					}
+
 					// Run
 					return refundMinipools(c)
 
@@ -265,6 +348,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "minipool, m",
 						Usage: "The minipool/s to distribute the balance of (address or 'all')",
 					},
+					cli.BoolFlag{
+						Name:  "all, a",
+						Usage: "Select all eligible minipools; equivalent to '--minipool all'",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm distributing the minipools",
+					},
 					cli.Float64Flag{
 						Name:  "threshold, t",
 						Usage: "Filter on a minimum amount of ETH that can be distributed - minipools below this amount won't be shown",
@@ -284,6 +375,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						}
 					}
 
+					if c.Bool("all") && c.String("minipool") != "" {
+						return fmt.Errorf("The --all and --minipool flags cannot be used together.")
+					}
+
 					// Run
 					return distributeBalance(c)
 
@@ -337,6 +432,22 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "minipool, m",
 						Usage: "The minipool/s to exit (address or 'all')",
 					},
+					cli.BoolFlag{
+						Name:  "schedule, s",
+						Usage: "Schedule the exits for the daemon to broadcast later, instead of exiting now",
+					},
+					cli.Uint64Flag{
+						Name:  "epoch",
+						Usage: "With --schedule, the earliest epoch to broadcast the (first) exit in",
+					},
+					cli.Uint64Flag{
+						Name:  "max-exit-queue",
+						Usage: "With --schedule, wait until the Beacon chain's exit queue has no more than this many validators in it",
+					},
+					cli.Uint64Flag{
+						Name:  "stagger",
+						Usage: "With --schedule, the number of epochs to wait between each minipool's exit",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -351,12 +462,130 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
+					if !c.Bool("schedule") && (c.IsSet("epoch") || c.IsSet("max-exit-queue") || c.IsSet("stagger")) {
+						return fmt.Errorf("--epoch, --max-exit-queue, and --stagger can only be used with --schedule")
+					}
 
 					// Run
 					return exitMinipools(c)
 
 				},
 			},
+			{
+				Name:      "scheduled-exits",
+				Aliases:   []string{"se"},
+				Usage:     "View the node's scheduled minipool exits and their progress",
+				UsageText: "rocketpool minipool scheduled-exits",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getScheduledExits(c)
+
+				},
+			},
+			{
+				Name:      "cancel-exit",
+				Aliases:   []string{"ce"},
+				Usage:     "Cancel a minipool's scheduled exit before the daemon broadcasts it",
+				UsageText: "rocketpool minipool cancel-exit minipool-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return cancelScheduledExit(c, minipoolAddress)
+
+				},
+			},
+			{
+				Name:      "presign-exits",
+				Aliases:   []string{"pe"},
+				Usage:     "Sign voluntary exits for all of your validators ahead of time and save them in an encrypted vault",
+				UsageText: "rocketpool minipool presign-exits [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "password, p",
+						Usage: "The password to encrypt the vault with",
+					},
+					cli.BoolFlag{
+						Name:  "print",
+						Usage: "Print the signed exit messages without encryption, for cold storage",
+					},
+					cli.StringFlag{
+						Name:  "escrow-url",
+						Usage: "An https:// address of a trusted third party to send the encrypted vault to",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm sending the vault to the escrow",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return presignExits(c)
+
+				},
+			},
+			{
+				Name:      "broadcast-exits",
+				Aliases:   []string{"be"},
+				Usage:     "Broadcast exits from a vault of pre-signed exits; the node wallet isn't needed",
+				UsageText: "rocketpool minipool broadcast-exits [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "vault",
+						Usage: "The path of the exit vault (defaults to the one in the Smartnode's data folder)",
+					},
+					cli.StringFlag{
+						Name:  "password, p",
+						Usage: "The vault's password",
+					},
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The minipool to exit (address or 'all')",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm broadcasting the exits",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" && c.String("minipool") != "all" {
+						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return broadcastPresignedExits(c)
+
+				},
+			},
 
 			{
 				Name:      "close",
@@ -567,5 +796,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 		},
-	})
+	}
+	addMinipoolCompletions(&command)
+	app.Commands = append(app.Commands, command)
 }
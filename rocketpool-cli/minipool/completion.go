@@ -0,0 +1,59 @@
+package minipool
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Set up shell completion of the node's minipool addresses for every command with a --minipool flag
+func addMinipoolCompletions(command *cli.Command) {
+	for i, subcommand := range command.Subcommands {
+		for _, flag := range subcommand.Flags {
+			minipoolFlag, ok := flag.(cli.StringFlag)
+			if !ok || minipoolFlag.Name != "minipool, m" {
+				continue
+			}
+			command.Subcommands[i].BashComplete = getMinipoolFlagCompleter(strings.Contains(minipoolFlag.Usage, "'all'"))
+		}
+	}
+}
+
+// Get a completion function that suggests the node's active minipools as values for the --minipool flag
+func getMinipoolFlagCompleter(allowAll bool) cli.BashCompleteFunc {
+	return func(c *cli.Context) {
+
+		// Use the default completions unless the --minipool flag is waiting for a value
+		if len(os.Args) < 3 {
+			cli.DefaultCompleteWithFlags(&c.Command)(c)
+			return
+		}
+		lastArg := os.Args[len(os.Args)-2]
+		if lastArg != "--minipool" && lastArg != "-m" {
+			cli.DefaultCompleteWithFlags(&c.Command)(c)
+			return
+		}
+
+		// Get the minipools from the daemon; there's nothing to suggest if it isn't available
+		rp := rocketpool.NewClientFromCtx(c)
+		defer rp.Close()
+		status, err := rp.MinipoolStatus()
+		if err != nil {
+			return
+		}
+
+		if allowAll {
+			fmt.Fprintln(c.App.Writer, "all")
+		}
+		for _, mp := range status.Minipools {
+			if !mp.Finalised {
+				fmt.Fprintln(c.App.Writer, mp.Address.Hex())
+			}
+		}
+
+	}
+}
@@ -59,7 +59,7 @@ func delegateUpgradeMinipools(c *cli.Context) error {
 			for mi, minipool := range minipools {
 				options[mi+1] = fmt.Sprintf("%s (using delegate %s)", minipool.Address.Hex(), minipool.Delegate.Hex())
 			}
-			selected, _ := cliutils.Select("Please select a minipool to upgrade:", options)
+			selected, _ := cliutils.Select("Please select a minipool to upgrade:", options, "ROCKETPOOL_MINIPOOL")
 
 			// Get minipools
 			if selected == 0 {
@@ -105,7 +105,7 @@ func delegateUpgradeMinipools(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to upgrade %d minipools?", len(selectedMinipools)))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to upgrade %d minipools?", len(selectedMinipools)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -119,7 +119,9 @@ func delegateUpgradeMinipools(c *cli.Context) error {
 		}
 
 		fmt.Printf("Upgrading minipool %s...\n", minipool.Hex())
-		cliutils.PrintTransactionHash(rp, response.TxHash)
+		if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+			return err
+		}
 		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 			fmt.Printf("Could not upgrade minipool %s: %s.\n", minipool.Hex(), err)
 		} else {
@@ -166,7 +168,7 @@ func delegateRollbackMinipools(c *cli.Context) error {
 			for mi, minipool := range minipools {
 				options[mi+1] = fmt.Sprintf("%s (using delegate %s)", minipool.Address.Hex(), minipool.Delegate.Hex())
 			}
-			selected, _ := cliutils.Select("Please select a minipool to rollback the delegate for:", options)
+			selected, _ := cliutils.Select("Please select a minipool to rollback the delegate for:", options, "ROCKETPOOL_MINIPOOL")
 
 			// Get minipools
 			if selected == 0 {
@@ -211,7 +213,7 @@ func delegateRollbackMinipools(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to rollback %d minipools?", len(selectedMinipools)))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to rollback %d minipools?", len(selectedMinipools)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -225,7 +227,9 @@ func delegateRollbackMinipools(c *cli.Context) error {
 		}
 
 		fmt.Printf("Rolling back minipool %s...\n", minipool.Hex())
-		cliutils.PrintTransactionHash(rp, response.TxHash)
+		if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+			return err
+		}
 		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 			fmt.Printf("Could not rollback minipool %s: %s.\n", minipool.Hex(), err)
 		} else {
@@ -283,7 +287,7 @@ func setUseLatestDelegateMinipools(c *cli.Context, setting bool) error {
 		} else {
 			action = "disable"
 		}
-		selected, _ := cliutils.Select(fmt.Sprintf("Please select a minipool to %s the flag for:", action), options)
+		selected, _ := cliutils.Select(fmt.Sprintf("Please select a minipool to %s the flag for:", action), options, "ROCKETPOOL_MINIPOOL")
 
 		// Get minipools
 		if selected == 0 {
@@ -337,7 +341,7 @@ func setUseLatestDelegateMinipools(c *cli.Context, setting bool) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to change the auto-upgrade setting for %d minipools to %t?", len(selectedMinipools), setting))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to change the auto-upgrade setting for %d minipools to %t?", len(selectedMinipools), setting))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -351,7 +355,9 @@ func setUseLatestDelegateMinipools(c *cli.Context, setting bool) error {
 		}
 
 		fmt.Printf("Updating the auto-upgrade setting for minipool %s...\n", minipool.Hex())
-		cliutils.PrintTransactionHash(rp, response.TxHash)
+		if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+			return err
+		}
 		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 			fmt.Printf("Could not update the auto-upgrade setting for minipool %s: %s.\n", minipool.Hex(), err)
 		} else {
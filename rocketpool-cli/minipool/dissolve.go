@@ -56,7 +56,7 @@ func dissolveMinipools(c *cli.Context) error {
 		for mi, minipool := range initializedMinipools {
 			options[mi+1] = fmt.Sprintf("%s (%.6f ETH deposited)", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(minipool.Node.DepositBalance), 6))
 		}
-		selected, _ := cliutils.Select("Please select a minipool to dissolve:", options)
+		selected, _ := cliutils.Select("Please select a minipool to dissolve:", options, "ROCKETPOOL_MINIPOOL")
 
 		// Get minipools
 		if selected == 0 {
@@ -110,7 +110,7 @@ func dissolveMinipools(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to dissolve %d minipool(s)? This action cannot be undone!", len(selectedMinipools)))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to dissolve %d minipool(s)? This action cannot be undone!", len(selectedMinipools)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -124,7 +124,9 @@ func dissolveMinipools(c *cli.Context) error {
 		}
 
 		fmt.Printf("Dissolving minipool %s...\n", minipool.Address.Hex())
-		cliutils.PrintTransactionHash(rp, response.TxHash)
+		if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+			return err
+		}
 		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 			fmt.Printf("Could not dissolve minipool %s: %s.\n", minipool.Address.Hex(), err)
 			continue
@@ -139,7 +141,9 @@ func dissolveMinipools(c *cli.Context) error {
 		}
 
 		fmt.Printf("Closing minipool %s...\n", minipool.Address.Hex())
-		cliutils.PrintTransactionHash(rp, closeResponse.TxHash)
+		if err = cliutils.PrintTransactionHash(rp, closeResponse.TxHash); err != nil {
+			return err
+		}
 		if _, err = rp.WaitForTransaction(closeResponse.TxHash); err != nil {
 			fmt.Printf("Could not close minipool %s: %s.\n", minipool.Address.Hex(), err)
 		} else {
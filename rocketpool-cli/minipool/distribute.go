@@ -140,7 +140,8 @@ func distributeBalance(c *cli.Context) error {
 
 	// Get selected minipools
 	var selectedMinipools []api.MinipoolBalanceDistributionDetails
-	if c.String("minipool") == "" {
+	minipoolSelection := getMinipoolSelection(c)
+	if minipoolSelection == "" {
 
 		// Get total rewards
 		totalEthAvailable := big.NewInt(0)
@@ -168,7 +169,7 @@ func distributeBalance(c *cli.Context) error {
 				options[mi+1] = fmt.Sprintf("%s (%.6f ETH available, %.6f ETH goes to you plus a refund of %.6f ETH)", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(minipool.Balance), 6), math.RoundDown(eth.WeiToEth(minipool.NodeShareOfBalance), 6), math.RoundDown(eth.WeiToEth(minipool.Refund), 6))
 			}
 		}
-		selected, _ := cliutils.Select("Please select a minipool to distribute the balance of:", options)
+		selected, _ := cliutils.Select("Please select a minipool to distribute the balance of:", options, "ROCKETPOOL_MINIPOOL")
 
 		// Get minipools
 		if selected == 0 {
@@ -180,10 +181,10 @@ func distributeBalance(c *cli.Context) error {
 	} else {
 
 		// Get matching minipools
-		if c.String("minipool") == "all" {
+		if minipoolSelection == "all" {
 			selectedMinipools = eligibleMinipools
 		} else {
-			selectedAddress := common.HexToAddress(c.String("minipool"))
+			selectedAddress := common.HexToAddress(minipoolSelection)
 			for _, minipool := range eligibleMinipools {
 				if bytes.Equal(minipool.Address.Bytes(), selectedAddress.Bytes()) {
 					selectedMinipools = []api.MinipoolBalanceDistributionDetails{minipool}
@@ -216,30 +217,23 @@ func distributeBalance(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to distribute the ETH balance of %d minipools?", len(selectedMinipools)))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to distribute the ETH balance of %d minipools?", len(selectedMinipools)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
 
 	// Distribute minipool balances
-	for _, minipool := range selectedMinipools {
-
-		response, err := rp.DistributeBalance(minipool.Address)
-		if err != nil {
-			fmt.Printf("Could not distribute the ETH balance of minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
-			continue
-		}
-
-		fmt.Printf("Distributing balance of minipool %s...\n", minipool.Address.Hex())
-		cliutils.PrintTransactionHash(rp, response.TxHash)
-		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-			fmt.Printf("Could not distribute the ETH balance of minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
-		} else {
-			fmt.Printf("Successfully distributed the ETH balance of minipool %s.\n", minipool.Address.Hex())
-		}
+	addresses := make([]common.Address, len(selectedMinipools))
+	for i, minipool := range selectedMinipools {
+		addresses[i] = minipool.Address
 	}
-
-	// Return
-	return nil
+	return runMinipoolBatch(rp, addresses, func(address common.Address) (common.Hash, error) {
+		response, err := rp.DistributeBalance(address)
+		return response.TxHash, err
+	}, batchMessages{
+		submitting: "Distributing balance of minipool %s",
+		failed:     "Could not distribute the ETH balance of minipool %s",
+		succeeded:  "Successfully distributed the ETH balance of minipool %s",
+	})
 
 }
@@ -56,7 +56,7 @@ func exitMinipools(c *cli.Context) error {
 				options[mi+1] = fmt.Sprintf("%s (dissolved since %s)", minipool.Address.Hex(), minipool.Status.StatusTime.Format(TimeFormat))
 			}
 		}
-		selected, _ := cliutils.Select("Please select a minipool to exit:", options)
+		selected, _ := cliutils.Select("Please select a minipool to exit:", options, "ROCKETPOOL_MINIPOOL")
 
 		// Get minipools
 		if selected == 0 {
@@ -85,6 +85,11 @@ func exitMinipools(c *cli.Context) error {
 
 	}
 
+	// Leave scheduled exits to the daemon
+	if c.Bool("schedule") {
+		return scheduleMinipoolExits(c, rp, selectedMinipools)
+	}
+
 	// Show a warning message
 	fmt.Printf("%sNOTE:\n", colorYellow)
 	fmt.Println("You are about to exit your minipool. This will tell each one's validator to stop all activities on the Beacon Chain.")
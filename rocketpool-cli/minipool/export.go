@@ -0,0 +1,165 @@
+package minipool
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// The columns of the minipool export
+var minipoolExportHeader = []string{
+	"Address",
+	"Validator Pubkey",
+	"Validator Index",
+	"Status",
+	"Status Time (UTC)",
+	"Deposit Type",
+	"Delegate Version",
+	"Finalised",
+	"Vacant",
+	"Commission (%)",
+	"Node Deposit (ETH)",
+	"User Deposit (ETH)",
+	"Penalties",
+	"Penalty Rate (%)",
+	"Contract Balance (ETH)",
+	"Node Share of Contract Balance (ETH)",
+	"Node Refund (ETH)",
+	"Validator Status",
+	"Beacon Balance (ETH)",
+	"Effective Balance (ETH)",
+	"Node Share of Beacon Balance (ETH)",
+	"Slashed",
+	"Delegate",
+	"Use Latest Delegate",
+	"Block",
+	"Slot",
+}
+
+func exportMinipools(c *cli.Context) error {
+
+	// Check the flags before doing any work
+	format := strings.ToLower(c.String("format"))
+	if format != "csv" && format != "json" {
+		return fmt.Errorf("Invalid format '%s'; supported formats are 'csv' and 'json'.", c.String("format"))
+	}
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the node's data
+	fmt.Fprintln(os.Stderr, "Getting the details of your minipools, this may take a moment...")
+	data, err := rp.NodeExportData()
+	if err != nil {
+		return err
+	}
+	if !data.Registered {
+		fmt.Println("This node is not currently registered.")
+		return nil
+	}
+
+	// Filter out finalized minipools unless requested
+	minipools := []api.MinipoolExportDetails{}
+	for _, minipool := range data.Minipools {
+		if c.Bool("include-finalized") || !minipool.Finalised {
+			minipools = append(minipools, minipool)
+		}
+	}
+
+	// Get the output
+	var output io.Writer = os.Stdout
+	path := c.String("output")
+	if path != "" {
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("Error creating %s: %w", path, err)
+		}
+		defer file.Close()
+		output = file
+	}
+
+	// Write the minipools
+	switch format {
+	case "json":
+		minipoolBytes, err := json.MarshalIndent(minipools, "", "  ")
+		if err != nil {
+			return fmt.Errorf("Error serializing minipool details: %w", err)
+		}
+		_, err = fmt.Fprintln(output, string(minipoolBytes))
+		if err != nil {
+			return fmt.Errorf("Error writing minipool details: %w", err)
+		}
+	case "csv":
+		err = writeMinipoolCsv(output, minipools, data.ElBlockNumber, data.BeaconSlotNumber)
+		if err != nil {
+			return fmt.Errorf("Error writing minipool details: %w", err)
+		}
+	}
+
+	if path != "" {
+		fmt.Printf("Exported %d minipools as of block %d to %s.\n", len(minipools), data.ElBlockNumber, path)
+	}
+	return nil
+
+}
+
+// Writes the minipool details as a CSV table
+func writeMinipoolCsv(output io.Writer, minipools []api.MinipoolExportDetails, block uint64, slot uint64) error {
+	writer := csv.NewWriter(output)
+	err := writer.Write(minipoolExportHeader)
+	if err != nil {
+		return err
+	}
+
+	for _, minipool := range minipools {
+		err = writer.Write([]string{
+			minipool.Address.Hex(),
+			minipool.Pubkey.Hex(),
+			minipool.ValidatorIndex,
+			minipool.Status.String(),
+			minipool.StatusTime.UTC().Format(time.RFC3339),
+			minipool.DepositType.String(),
+			fmt.Sprint(minipool.Version),
+			strconv.FormatBool(minipool.Finalised),
+			strconv.FormatBool(minipool.IsVacant),
+			math.WeiToPercentString(minipool.NodeFee),
+			math.WeiToDecimalString(minipool.NodeDepositBalance),
+			math.WeiToDecimalString(minipool.UserDepositBalance),
+			fmt.Sprint(minipool.PenaltyCount),
+			math.WeiToPercentString(minipool.PenaltyRate),
+			math.WeiToDecimalString(minipool.Balance),
+			math.WeiToDecimalString(minipool.NodeShareOfBalance),
+			math.WeiToDecimalString(minipool.NodeRefundBalance),
+			minipool.ValidatorStatus,
+			math.WeiToDecimalString(minipool.BeaconBalance),
+			math.WeiToDecimalString(minipool.EffectiveBalance),
+			math.WeiToDecimalString(minipool.NodeShareOfBeaconBalance),
+			strconv.FormatBool(minipool.Slashed),
+			minipool.Delegate.Hex(),
+			strconv.FormatBool(minipool.UseLatestDelegate),
+			fmt.Sprint(block),
+			fmt.Sprint(slot),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,73 @@
+package minipool
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+const colorGreen string = "\033[32m"
+
+func getMigrationStatus(c *cli.Context, minipoolAddress common.Address) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the migration status
+	status, err := rp.GetMinipoolMigrationStatus(minipoolAddress)
+	if err != nil {
+		return err
+	}
+	if !status.IsVacant {
+		if status.MinipoolStatus == types.Dissolved {
+			fmt.Printf("Minipool %s was dissolved.\n", minipoolAddress.Hex())
+		} else {
+			fmt.Printf("Minipool %s isn't vacant, so its migration is complete or it wasn't created for one.\n", minipoolAddress.Hex())
+		}
+		return nil
+	}
+	if status.MinipoolStatus == types.Dissolved {
+		fmt.Printf("%sThe Oracle DAO scrubbed the migration of minipool %s, so it was dissolved.%s\n", colorRed, minipoolAddress.Hex(), colorReset)
+		return nil
+	}
+
+	// Validator
+	fmt.Printf("Minipool %s was created for validator %s at %s.\n\n", minipoolAddress.Hex(), status.Pubkey.Hex(), status.CreationTime.Format(time.RFC822))
+	if !status.ValidatorExists {
+		fmt.Printf("%sThe validator doesn't exist on the Beacon chain; the Oracle DAO will scrub this minipool.%s\n", colorRed, colorReset)
+		return nil
+	}
+	fmt.Printf("Validator:   %s, %s, %.6f ETH\n", status.ValidatorIndex, status.ValidatorStatus, float64(status.Balance)/1e9)
+
+	// Withdrawal credentials
+	remaining := status.CredentialsDeadline.Sub(status.LatestBlockTime).Round(time.Minute)
+	switch {
+	case status.CredentialsChanged:
+		fmt.Printf("Credentials: %schanged to the minipool%s (%s)\n", colorGreen, colorReset, status.WithdrawalCredentials.Hex())
+	case status.WithdrawalCredentials[0] == 0x00 && remaining > 0:
+		fmt.Printf("Credentials: %sstill BLS (0x00)%s; they must be changed to %s by %s (in %s)\n", colorYellow, colorReset, status.ExpectedWithdrawalCredentials.Hex(), status.CredentialsDeadline.Format(time.RFC822), remaining)
+		fmt.Printf("             Use `rocketpool minipool set-withdrawal-creds %s`, or a tool such as `ethdo` if you keep the key outside the Smartnode.\n", minipoolAddress.Hex())
+	case status.WithdrawalCredentials[0] == 0x00:
+		fmt.Printf("Credentials: %sstill BLS (0x00) past the deadline of %s; the Oracle DAO will scrub this minipool%s\n", colorRed, status.CredentialsDeadline.Format(time.RFC822), colorReset)
+	default:
+		fmt.Printf("Credentials: %s%s don't match the minipool's %s; the Oracle DAO will scrub this minipool%s\n", colorRed, status.WithdrawalCredentials.Hex(), status.ExpectedWithdrawalCredentials.Hex(), colorReset)
+	}
+
+	// Promotion
+	if status.CanPromote {
+		fmt.Printf("Promotion:   %sready%s; the node will promote it automatically, or you can run `rocketpool minipool promote`\n", colorGreen, colorReset)
+	} else {
+		fmt.Printf("Promotion:   available at %s (in %s)\n", status.PromotionTime.Format(time.RFC822), status.PromotionTime.Sub(status.LatestBlockTime).Round(time.Minute))
+	}
+	return nil
+
+}
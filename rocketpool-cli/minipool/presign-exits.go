@@ -0,0 +1,244 @@
+package minipool
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Settings
+const (
+	exitVaultPasswordEnvVar = "ROCKETPOOL_EXIT_VAULT_PASSWORD"
+	escrowTimeout           = 30 * time.Second
+)
+
+func presignExits(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check the escrow URL before signing anything
+	escrowUrl := c.String("escrow-url")
+	if escrowUrl != "" {
+		parsedUrl, err := url.Parse(escrowUrl)
+		if err != nil || parsedUrl.Scheme != "https" || parsedUrl.Host == "" {
+			return fmt.Errorf("Invalid escrow URL '%s': it must be an https:// address.", escrowUrl)
+		}
+	}
+
+	// Explain the vault
+	fmt.Println("This will sign a voluntary exit for each of your validators and save them in a vault, encrypted with a password of your choice.")
+	fmt.Println("Anyone with the vault and its password can exit your validators without your node wallet, for example if this machine is lost.")
+	fmt.Printf("%sThe exits are signed for the Capella fork, which the Beacon Chain accepts for all later upgrades (EIP-7044), so they don't expire. Keep the vault and its password somewhere safe.%s\n\n", colorYellow, colorReset)
+
+	// Get the vault password
+	password := c.String("password")
+	if password == "" {
+		for {
+			password = cliutils.PromptPassword(
+				"Please enter a password to encrypt the exit vault with:",
+				fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
+				fmt.Sprintf("Your password must be at least %d characters long. Please try again:", passwords.MinPasswordLength),
+				exitVaultPasswordEnvVar,
+			)
+			confirmation := cliutils.PromptPassword("Please confirm your password:", "^.*$", "", exitVaultPasswordEnvVar)
+			if password == confirmation {
+				break
+			}
+			fmt.Println("Password confirmation does not match.")
+			fmt.Println("")
+		}
+	}
+
+	// Sign the exits
+	response, err := rp.PresignExits(password)
+	if err != nil {
+		return err
+	}
+	vaultPath, err := getExitVaultPath(rp, "")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%sSigned exits for %d validator(s) at epoch %d.%s\n", colorGreen, response.Count, response.Epoch, colorReset)
+	for _, minipoolAddress := range response.Skipped {
+		fmt.Printf("Skipped minipool %s: it's run by a distributed validator, so its exit has to be signed by the cluster.\n", minipoolAddress.Hex())
+	}
+	fmt.Printf("The encrypted vault has been saved to:\n\n\t%s\n\n", vaultPath)
+
+	// Load the vault for printing or escrow
+	if !c.Bool("print") && escrowUrl == "" {
+		fmt.Println("Keep a copy of the vault and its password somewhere safe, away from this machine.")
+		return nil
+	}
+	vault, err := validator.LoadExitVault(vaultPath)
+	if err != nil {
+		return err
+	}
+
+	// Print the plaintext messages for cold storage
+	if c.Bool("print") {
+		exits, err := vault.Decrypt(password)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%sWARNING: the following messages are NOT encrypted. Anyone who sees them can exit your validators.\nStore the printout somewhere safe and don't leave it in your terminal history or logs.%s\n\n", colorRed, colorReset)
+		output, err := json.MarshalIndent(exits, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error serializing pre-signed exits: %w", err)
+		}
+		fmt.Println(string(output))
+		fmt.Println()
+	}
+
+	// Escrow the encrypted vault with a third party
+	if escrowUrl != "" {
+		if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to send the encrypted vault to %s? They'll be able to exit your validators if they ever learn its password.", escrowUrl))) {
+			fmt.Println("Cancelled escrow.")
+			return nil
+		}
+		if err := escrowExitVault(escrowUrl, vault); err != nil {
+			return err
+		}
+		fmt.Printf("%sSent the encrypted vault to %s.%s\nShare its password with them separately, and only if they need to be able to exit your validators.\n", colorGreen, escrowUrl, colorReset)
+	}
+
+	// Return
+	return nil
+
+}
+
+func broadcastPresignedExits(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Load the vault
+	vaultPath, err := getExitVaultPath(rp, c.String("vault"))
+	if err != nil {
+		return err
+	}
+	vault, err := validator.LoadExitVault(vaultPath)
+	if err != nil {
+		return err
+	}
+	password := c.String("password")
+	if password == "" {
+		password = cliutils.PromptPassword("Please enter the exit vault's password:", "^.*$", "", exitVaultPasswordEnvVar)
+	}
+	exits, err := vault.Decrypt(password)
+	if err != nil {
+		return err
+	}
+
+	// Get the selected exits
+	selectedExits := []api.PresignedExit{}
+	if c.String("minipool") == "" || c.String("minipool") == "all" {
+		selectedExits = exits
+	} else {
+		minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.String("minipool"))
+		if err != nil {
+			return err
+		}
+		for _, exit := range exits {
+			if exit.Minipool == minipoolAddress {
+				selectedExits = append(selectedExits, exit)
+			}
+		}
+		if len(selectedExits) == 0 {
+			return fmt.Errorf("The vault doesn't have an exit for minipool %s.", minipoolAddress.Hex())
+		}
+	}
+
+	// Prompt for confirmation
+	fmt.Printf("The vault was signed for node %s at epoch %d.\n", vault.NodeAddress.Hex(), vault.Epoch)
+	for _, exit := range selectedExits {
+		fmt.Printf("Minipool %s (validator %s)\n", exit.Minipool.Hex(), exit.Message.ValidatorIndex)
+	}
+	fmt.Println()
+	if !(c.Bool("yes") || cliutils.ConfirmWithIAgree(fmt.Sprintf("Are you sure you want to broadcast the exits of %d minipool(s)? This action cannot be undone!", len(selectedExits)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Broadcast the exits
+	for _, exit := range selectedExits {
+		epoch, err := strconv.ParseUint(exit.Message.Epoch, 10, 64)
+		if err != nil {
+			fmt.Printf("Could not exit minipool %s: invalid epoch '%s' in the vault.\n", exit.Minipool.Hex(), exit.Message.Epoch)
+			continue
+		}
+		signature, err := cliutils.ValidateValidatorSignature("signature", exit.Signature)
+		if err != nil {
+			fmt.Printf("Could not exit minipool %s: %s.\n", exit.Minipool.Hex(), err)
+			continue
+		}
+		if _, err := rp.BroadcastPresignedExit(exit.Message.ValidatorIndex, epoch, signature); err != nil {
+			fmt.Printf("Could not exit minipool %s: %s.\n", exit.Minipool.Hex(), err)
+		} else {
+			fmt.Printf("Successfully exited minipool %s.\n", exit.Minipool.Hex())
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the path of the exit vault, defaulting to the one in the Smartnode's data folder
+func getExitVaultPath(rp *rocketpool.Client, path string) (string, error) {
+	if path == "" {
+		cfg, _, err := rp.LoadConfig()
+		if err != nil {
+			return "", err
+		}
+		path = cfg.Smartnode.GetExitVaultPathInCLI()
+	}
+	expandedPath, err := homedir.Expand(path)
+	if err != nil {
+		return "", fmt.Errorf("error expanding exit vault path: %w", err)
+	}
+	if _, err := os.Stat(expandedPath); err != nil {
+		return "", fmt.Errorf("error finding exit vault [%s]: %w", expandedPath, err)
+	}
+	return expandedPath, nil
+}
+
+// Send an encrypted exit vault to a third party for safekeeping
+func escrowExitVault(escrowUrl string, vault *validator.ExitVault) error {
+	body, err := json.Marshal(vault)
+	if err != nil {
+		return fmt.Errorf("error serializing exit vault: %w", err)
+	}
+	client := http.Client{Timeout: escrowTimeout}
+	response, err := client.Post(escrowUrl, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error sending exit vault to %s: %w", escrowUrl, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("%s rejected the exit vault with status %s", escrowUrl, response.Status)
+	}
+	return nil
+}
@@ -53,7 +53,7 @@ func promoteMinipools(c *cli.Context) error {
 		for mi, minipool := range promotableMinipools {
 			options[mi+1] = fmt.Sprintf("%s (%s until dissolved)", minipool.Address.Hex(), minipool.TimeUntilDissolve)
 		}
-		selected, _ := cliutils.Select("Please select a minipool to promote:", options)
+		selected, _ := cliutils.Select("Please select a minipool to promote:", options, "ROCKETPOOL_MINIPOOL")
 
 		// Get minipools
 		if selected == 0 {
@@ -107,7 +107,7 @@ func promoteMinipools(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to promote %d minipools?", len(selectedMinipools)))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to promote %d minipools?", len(selectedMinipools)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -121,7 +121,9 @@ func promoteMinipools(c *cli.Context) error {
 		}
 
 		fmt.Printf("Promoting minipool %s...\n", minipool.Address.Hex())
-		cliutils.PrintTransactionHash(rp, response.TxHash)
+		if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+			return err
+		}
 		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 			fmt.Printf("Could not promote minipool %s: %s.\n", minipool.Address.Hex(), err)
 		} else {
@@ -54,7 +54,7 @@ func beginReduceBondAmount(c *cli.Context) error {
 	newBondAmount := eth.EthToWei(8)
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Do you understand how the bond reduction process will work?")) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction("Do you understand how the bond reduction process will work?")) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -103,7 +103,7 @@ func beginReduceBondAmount(c *cli.Context) error {
 		for mi, minipool := range reduceableMinipools {
 			options[mi+1] = fmt.Sprintf("%s (Current bond: %d ETH, commission: %.2f%%)", minipool.Address.Hex(), int(eth.WeiToEth(minipool.Node.DepositBalance)), minipool.Node.Fee*100)
 		}
-		selected, _ := cliutils.Select("Please select a minipool to begin the ETH bond reduction for:", options)
+		selected, _ := cliutils.Select("Please select a minipool to begin the ETH bond reduction for:", options, "ROCKETPOOL_MINIPOOL")
 
 		// Get minipools
 		if selected == 0 {
@@ -186,7 +186,7 @@ func beginReduceBondAmount(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to begin bond reduction for %d minipools from 16 ETH to 8 ETH?", len(selectedMinipools)))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to begin bond reduction for %d minipools from 16 ETH to 8 ETH?", len(selectedMinipools)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -200,7 +200,9 @@ func beginReduceBondAmount(c *cli.Context) error {
 		}
 
 		fmt.Printf("Beginning bond reduction for minipool %s...\n", minipool.Address.Hex())
-		cliutils.PrintTransactionHash(rp, response.TxHash)
+		if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+			return err
+		}
 		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 			fmt.Printf("Could not begin bond reduction for minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
 		} else {
@@ -267,7 +269,7 @@ func reduceBondAmount(c *cli.Context) error {
 		for mi, minipool := range reduceableMinipools {
 			options[mi+1] = fmt.Sprintf("%s (Current bond: %d ETH)", minipool.Address.Hex(), int(eth.WeiToEth(minipool.Node.DepositBalance)))
 		}
-		selected, _ := cliutils.Select("Please select a minipool to reduce the ETH bond for:", options)
+		selected, _ := cliutils.Select("Please select a minipool to reduce the ETH bond for:", options, "ROCKETPOOL_MINIPOOL")
 
 		// Get minipools
 		if selected == 0 {
@@ -324,7 +326,7 @@ func reduceBondAmount(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to reduce the bond for %d minipools from 16 ETH to 8 ETH?", len(selectedMinipools)))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to reduce the bond for %d minipools from 16 ETH to 8 ETH?", len(selectedMinipools)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -338,7 +340,9 @@ func reduceBondAmount(c *cli.Context) error {
 		}
 
 		fmt.Printf("Reducing bond for minipool %s...\n", minipool.Address.Hex())
-		cliutils.PrintTransactionHash(rp, response.TxHash)
+		if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+			return err
+		}
 		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 			fmt.Printf("Could not reduce bond for minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
 		} else {
@@ -378,7 +382,7 @@ func forceFeeDistribution(c *cli.Context, rp *rocketpool.Client) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to distribute the ETH from your node's fee distributor?")) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction("Are you sure you want to distribute the ETH from your node's fee distributor?")) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -390,7 +394,9 @@ func forceFeeDistribution(c *cli.Context, rp *rocketpool.Client) error {
 	}
 
 	fmt.Printf("Distributing rewards...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
@@ -47,7 +47,8 @@ func refundMinipools(c *cli.Context) error {
 
 	// Get selected minipools
 	var selectedMinipools []api.MinipoolDetails
-	if c.String("minipool") == "" {
+	minipoolSelection := getMinipoolSelection(c)
+	if minipoolSelection == "" {
 
 		// Prompt for minipool selection
 		options := make([]string, len(refundableMinipools)+1)
@@ -55,7 +56,7 @@ func refundMinipools(c *cli.Context) error {
 		for mi, minipool := range refundableMinipools {
 			options[mi+1] = fmt.Sprintf("%s (%.6f ETH to claim)", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(minipool.Node.RefundBalance), 6))
 		}
-		selected, _ := cliutils.Select("Please select a minipool to refund ETH from:", options)
+		selected, _ := cliutils.Select("Please select a minipool to refund ETH from:", options, "ROCKETPOOL_MINIPOOL")
 
 		// Get minipools
 		if selected == 0 {
@@ -67,10 +68,10 @@ func refundMinipools(c *cli.Context) error {
 	} else {
 
 		// Get matching minipools
-		if c.String("minipool") == "all" {
+		if minipoolSelection == "all" {
 			selectedMinipools = refundableMinipools
 		} else {
-			selectedAddress := common.HexToAddress(c.String("minipool"))
+			selectedAddress := common.HexToAddress(minipoolSelection)
 			for _, minipool := range refundableMinipools {
 				if bytes.Equal(minipool.Address.Bytes(), selectedAddress.Bytes()) {
 					selectedMinipools = []api.MinipoolDetails{minipool}
@@ -109,29 +110,23 @@ func refundMinipools(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to refund %d minipools?", len(selectedMinipools)))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to refund %d minipools?", len(selectedMinipools)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
 
 	// Refund minipools
-	for _, minipool := range selectedMinipools {
-		response, err := rp.RefundMinipool(minipool.Address)
-		if err != nil {
-			fmt.Printf("Could not refund ETH from minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
-			continue
-		}
-
-		fmt.Printf("Refunding minipool %s...\n", minipool.Address.Hex())
-		cliutils.PrintTransactionHash(rp, response.TxHash)
-		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-			fmt.Printf("Could not refund ETH from minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
-		} else {
-			fmt.Printf("Successfully refunded ETH from minipool %s.\n", minipool.Address.Hex())
-		}
+	addresses := make([]common.Address, len(selectedMinipools))
+	for i, minipool := range selectedMinipools {
+		addresses[i] = minipool.Address
 	}
-
-	// Return
-	return nil
+	return runMinipoolBatch(rp, addresses, func(address common.Address) (common.Hash, error) {
+		response, err := rp.RefundMinipool(address)
+		return response.TxHash, err
+	}, batchMessages{
+		submitting: "Refunding minipool %s",
+		failed:     "Could not refund ETH from minipool %s",
+		succeeded:  "Successfully refunded ETH from minipool %s",
+	})
 
 }
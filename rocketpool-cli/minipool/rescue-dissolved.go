@@ -130,7 +130,7 @@ func rescueDissolved(c *cli.Context) error {
 			rescueAmountFloats[mi] = math.RoundDown(eth.WeiToEth(localRescueAmount), 6)
 			options[mi] = fmt.Sprintf("%s (requires %.6f more ETH)", minipool.Address.Hex(), rescueAmountFloats[mi])
 		}
-		selected, _ := cliutils.Select("Please select a minipool to refund ETH from:", options)
+		selected, _ := cliutils.Select("Please select a minipool to refund ETH from:", options, "ROCKETPOOL_MINIPOOL")
 
 		// Get minipool
 		selectedMinipool = &rescuableMinipools[selected]
@@ -168,7 +168,7 @@ func rescueDissolved(c *cli.Context) error {
 			"1 ETH",
 			"A custom amount",
 		}
-		selected, _ := cliutils.Select("Please select an amount of ETH to deposit:", options)
+		selected, _ := cliutils.Select("Please select an amount of ETH to deposit:", options, "ROCKETPOOL_DEPOSIT_OPTION")
 
 		switch selected {
 		case 0:
@@ -183,7 +183,7 @@ func rescueDissolved(c *cli.Context) error {
 
 	// Prompt for custom amount
 	if depositAmount == nil {
-		inputAmount := cliutils.Prompt("Please enter an amount of ETH to deposit:", "^\\d+(\\.\\d+)?$", "Invalid amount")
+		inputAmount := cliutils.Prompt("Please enter an amount of ETH to deposit:", "^\\d+(\\.\\d+)?$", "Invalid amount", "ROCKETPOOL_DEPOSIT_AMOUNT")
 		depositAmountEth, err := strconv.ParseFloat(inputAmount, 64)
 		if err != nil {
 			return fmt.Errorf("Invalid deposit amount '%s': %w", inputAmount, err)
@@ -202,7 +202,7 @@ func rescueDissolved(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to deposit %.6f ETH to rescue minipool %s?", math.RoundDown(depositAmountFloat, 6), selectedMinipool.Address.Hex()))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to deposit %.6f ETH to rescue minipool %s?", math.RoundDown(depositAmountFloat, 6), selectedMinipool.Address.Hex()))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -214,7 +214,9 @@ func rescueDissolved(c *cli.Context) error {
 	}
 
 	fmt.Printf("Depositing ETH to rescue minipool %s...\n", selectedMinipool.Address.Hex())
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return fmt.Errorf("Could not rescue minipool %s: %s.\n", selectedMinipool.Address.Hex(), err.Error())
 	} else {
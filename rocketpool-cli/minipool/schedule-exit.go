@@ -0,0 +1,139 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func scheduleMinipoolExits(c *cli.Context, rp *rocketpool.Client, minipools []api.MinipoolDetails) error {
+
+	// Get the schedule
+	epoch := c.Uint64("epoch")
+	maxExitQueue := c.Uint64("max-exit-queue")
+	stagger := c.Uint64("stagger")
+	if epoch == 0 && maxExitQueue == 0 && stagger == 0 {
+		return fmt.Errorf("Please specify when the exits should be broadcast with --epoch, --max-exit-queue, and/or --stagger.")
+	}
+
+	// Show the schedule
+	fmt.Printf("%sNOTE:\n", colorYellow)
+	fmt.Println("The Smartnode daemon will exit these minipools for you once their conditions are met; it has to keep running until then.")
+	fmt.Println("Once an exit has been broadcast it can't be undone. Please continue to run your validators until each one has been processed by the exit queue.")
+	fmt.Printf("You can follow their progress with `rocketpool minipool scheduled-exits`, and cancel the ones that haven't been broadcast yet with `rocketpool minipool cancel-exit`.%s\n\n", colorReset)
+	for i, minipool := range minipools {
+		conditions := []string{}
+		minipoolEpoch := epoch + uint64(i)*stagger
+		if minipoolEpoch > 0 {
+			conditions = append(conditions, fmt.Sprintf("at epoch %d or later", minipoolEpoch))
+		}
+		if maxExitQueue > 0 {
+			conditions = append(conditions, fmt.Sprintf("once the exit queue has %d or fewer validators", maxExitQueue))
+		}
+		fmt.Printf("%s: %s\n", minipool.Address.Hex(), joinConditions(conditions))
+	}
+	fmt.Println()
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.ConfirmWithIAgree(fmt.Sprintf("Are you sure you want to schedule the exit of %d minipool(s)? Once broadcast, exits cannot be undone!", len(minipools)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Schedule the exits
+	for i, minipool := range minipools {
+		if _, err := rp.ScheduleMinipoolExit(minipool.Address, epoch+uint64(i)*stagger, maxExitQueue); err != nil {
+			fmt.Printf("Could not schedule the exit of minipool %s: %s.\n", minipool.Address.Hex(), err)
+		} else {
+			fmt.Printf("Successfully scheduled the exit of minipool %s.\n", minipool.Address.Hex())
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+func getScheduledExits(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the scheduled exits
+	response, err := rp.GetScheduledExits()
+	if err != nil {
+		return err
+	}
+	if len(response.Exits) == 0 {
+		fmt.Println("The node doesn't have any scheduled exits.")
+		return nil
+	}
+	fmt.Printf("The current epoch is %d, and the exit queue has %d validators in it.\n\n", response.CurrentEpoch, response.ExitQueue)
+
+	for _, exit := range response.Exits {
+		fmt.Printf("Minipool %s:\n", exit.Minipool.Hex())
+		switch {
+		case exit.Withdrawn:
+			fmt.Printf("\t%sWithdrawn%s; run `rocketpool minipool close` to close it.\n", colorGreen, colorReset)
+		case exit.Broadcast && exit.ExitEpoch > 0:
+			fmt.Printf("\tExit broadcast in epoch %d; it exits in epoch %d and will be withdrawable in epoch %d.\n", exit.BroadcastEpoch, exit.ExitEpoch, exit.WithdrawableEpoch)
+		case exit.Broadcast:
+			fmt.Printf("\tExit broadcast in epoch %d; waiting for it to be processed.\n", exit.BroadcastEpoch)
+		default:
+			conditions := []string{}
+			if exit.Epoch > 0 {
+				conditions = append(conditions, fmt.Sprintf("at epoch %d or later", exit.Epoch))
+			}
+			if exit.MaxExitQueue > 0 {
+				conditions = append(conditions, fmt.Sprintf("once the exit queue has %d or fewer validators", exit.MaxExitQueue))
+			}
+			fmt.Printf("\tScheduled: %s.\n", joinConditions(conditions))
+		}
+		if exit.Error != "" {
+			fmt.Printf("\t%sThe last attempt to exit it failed: %s%s\n", colorRed, exit.Error, colorReset)
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+func cancelScheduledExit(c *cli.Context, minipoolAddress common.Address) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Cancel the exit
+	if _, err := rp.CancelScheduledExit(minipoolAddress); err != nil {
+		return err
+	}
+	fmt.Printf("Cancelled the scheduled exit of minipool %s.\n", minipoolAddress.Hex())
+	return nil
+
+}
+
+// Describe an exit's conditions
+func joinConditions(conditions []string) string {
+	switch len(conditions) {
+	case 0:
+		return "as soon as possible"
+	case 1:
+		return conditions[0]
+	default:
+		return conditions[0] + " and " + conditions[1]
+	}
+}
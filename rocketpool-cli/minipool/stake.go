@@ -45,7 +45,8 @@ func stakeMinipools(c *cli.Context) error {
 
 	// Get selected minipools
 	var selectedMinipools []api.MinipoolDetails
-	if c.String("minipool") == "" {
+	minipoolSelection := getMinipoolSelection(c)
+	if minipoolSelection == "" {
 
 		// Prompt for minipool selection
 		options := make([]string, len(stakeableMinipools)+1)
@@ -53,7 +54,7 @@ func stakeMinipools(c *cli.Context) error {
 		for mi, minipool := range stakeableMinipools {
 			options[mi+1] = fmt.Sprintf("%s (%s until dissolved)", minipool.Address.Hex(), minipool.TimeUntilDissolve)
 		}
-		selected, _ := cliutils.Select("Please select a minipool to stake:", options)
+		selected, _ := cliutils.Select("Please select a minipool to stake:", options, "ROCKETPOOL_MINIPOOL")
 
 		// Get minipools
 		if selected == 0 {
@@ -65,10 +66,10 @@ func stakeMinipools(c *cli.Context) error {
 	} else {
 
 		// Get matching minipools
-		if c.String("minipool") == "all" {
+		if minipoolSelection == "all" {
 			selectedMinipools = stakeableMinipools
 		} else {
-			selectedAddress := common.HexToAddress(c.String("minipool"))
+			selectedAddress := common.HexToAddress(minipoolSelection)
 			for _, minipool := range stakeableMinipools {
 				if bytes.Equal(minipool.Address.Bytes(), selectedAddress.Bytes()) {
 					selectedMinipools = []api.MinipoolDetails{minipool}
@@ -109,29 +110,23 @@ func stakeMinipools(c *cli.Context) error {
 	fmt.Println("\nNOTE: Your validator container will be restarted after this process so it loads the new validator key.\n")
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to stake %d minipools?", len(selectedMinipools)))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to stake %d minipools?", len(selectedMinipools)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
 
 	// Stake minipools
-	for _, minipool := range selectedMinipools {
-		response, err := rp.StakeMinipool(minipool.Address)
-		if err != nil {
-			fmt.Printf("Could not stake minipool %s: %s.\n", minipool.Address.Hex(), err)
-			continue
-		}
-
-		fmt.Printf("Staking minipool %s...\n", minipool.Address.Hex())
-		cliutils.PrintTransactionHash(rp, response.TxHash)
-		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-			fmt.Printf("Could not stake minipool %s: %s.\n", minipool.Address.Hex(), err)
-		} else {
-			fmt.Printf("Successfully staked minipool %s.\n", minipool.Address.Hex())
-		}
+	addresses := make([]common.Address, len(selectedMinipools))
+	for i, minipool := range selectedMinipools {
+		addresses[i] = minipool.Address
 	}
-
-	// Return
-	return nil
+	return runMinipoolBatch(rp, addresses, func(address common.Address) (common.Hash, error) {
+		response, err := rp.StakeMinipool(address)
+		return response.TxHash, err
+	}, batchMessages{
+		submitting: "Staking minipool %s",
+		failed:     "Could not stake minipool %s",
+		succeeded:  "Successfully staked minipool %s",
+	})
 
 }
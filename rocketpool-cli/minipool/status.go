@@ -11,6 +11,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/types/output"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/hex"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
@@ -22,6 +23,38 @@ const colorYellow string = "\033[33m"
 
 func getStatus(c *cli.Context) error {
 
+	includeFinalized := c.Bool("include-finalized")
+	watchInterval, err := cliutils.GetWatchInterval(c)
+	if err != nil {
+		return err
+	}
+
+	// Keep re-rendering the minipools with the clients' sync progress; this shows the sync status itself, so it doesn't need the clients to be ready
+	if watchInterval != 0 {
+		rp := rocketpool.NewClientFromCtx(c)
+		defer rp.Close()
+		return cliutils.Watch(watchInterval, func() error {
+			rp.SetClientStatusFlags(false, false)
+			syncStatus, err := rp.NodeSync()
+			if err != nil {
+				return err
+			}
+			cliutils.PrintSyncProgressBars(syncStatus)
+
+			// The sync status was just checked, so the status call can skip checking it again
+			if !rp.SetSyncedClients(syncStatus.EcStatus, syncStatus.BcStatus) {
+				fmt.Println("Waiting for the clients to sync before showing the node's minipools...")
+				return nil
+			}
+			status, err := rp.MinipoolStatus()
+			if err != nil {
+				return err
+			}
+			printMinipoolStatus(&status, includeFinalized)
+			return nil
+		})
+	}
+
 	// Get RP client
 	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
 	if err != nil {
@@ -34,6 +67,17 @@ func getStatus(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if cliutils.IsJsonOutput(c) {
+		return cliutils.PrintJson(output.NewMinipoolList(&status, includeFinalized))
+	}
+
+	printMinipoolStatus(&status, includeFinalized)
+	return nil
+
+}
+
+// Print the details of the node's minipools, grouped by status
+func printMinipoolStatus(status *api.MinipoolStatusResponse, includeFinalized bool) {
 
 	// Get minipools by status
 	statusMinipools := map[string][]api.MinipoolDetails{}
@@ -66,13 +110,13 @@ func getStatus(c *cli.Context) error {
 	// Return if there aren't any minipools
 	if len(status.Minipools) == 0 {
 		fmt.Println("The node does not have any minipools yet.")
-		return nil
+		return
 	}
 
 	// Return if all minipools are finalized and they are hidden
-	if len(status.Minipools) == len(finalisedMinipools) && !c.Bool("include-finalized") {
+	if len(status.Minipools) == len(finalisedMinipools) && !includeFinalized {
 		fmt.Println("All of this node's minipools have been finalized.\nTo show finalized minipools, re-run this command with the `-f` flag.")
-		return nil
+		return
 	}
 
 	// Print minipool details by status
@@ -90,7 +134,7 @@ func getStatus(c *cli.Context) error {
 
 		// Minipools
 		for _, minipool := range minipools {
-			if !minipool.Finalised || c.Bool("include-finalized") {
+			if !minipool.Finalised || includeFinalized {
 				printMinipoolDetails(minipool, status.LatestDelegate)
 			}
 		}
@@ -99,7 +143,7 @@ func getStatus(c *cli.Context) error {
 	}
 
 	// Handle finalized minipools
-	if c.Bool("include-finalized") {
+	if includeFinalized {
 		fmt.Printf("%d finalized minipool(s):\n", len(finalisedMinipools))
 		fmt.Println("")
 
@@ -130,9 +174,6 @@ func getStatus(c *cli.Context) error {
 		fmt.Println("")
 	}
 
-	// Return
-	return nil
-
 }
 
 func printMinipoolDetails(minipool api.MinipoolDetails, latestDelegate common.Address) {
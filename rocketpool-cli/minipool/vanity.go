@@ -30,7 +30,7 @@ func findVanitySalt(c *cli.Context) error {
 	// Get the target prefix
 	prefix := c.String("prefix")
 	if prefix == "" {
-		prefix = cliutils.Prompt("Please specify the address prefix you would like to search for (must start with 0x):", "^0x[0-9a-fA-F]+$", "Invalid hex string")
+		prefix = cliutils.Prompt("Please specify the address prefix you would like to search for (must start with 0x):", "^0x[0-9a-fA-F]+$", "Invalid hex string", "ROCKETPOOL_VANITY_PREFIX")
 	}
 	if !strings.HasPrefix(prefix, "0x") {
 		return fmt.Errorf("Prefix must start with 0x.")
@@ -83,7 +83,7 @@ func findVanitySalt(c *cli.Context) error {
 		}
 
 		// Prompt for amount
-		selected, _ := cliutils.Select("Please choose a deposit type to search for:", amountOptions)
+		selected, _ := cliutils.Select("Please choose a deposit type to search for:", amountOptions, "ROCKETPOOL_DEPOSIT_OPTION")
 		switch selected {
 		case 0:
 			amount = 8
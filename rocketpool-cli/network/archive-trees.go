@@ -0,0 +1,85 @@
+package network
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/urfave/cli"
+)
+
+func archiveRewardsTrees(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+
+	// Print archive node info
+	archiveEcUrl := cfg.Smartnode.ArchiveECUrl.Value.(string)
+	if archiveEcUrl == "" {
+		fmt.Printf("%sNOTE: in order to generate Merkle rewards trees for past rewards intervals, you will likely need to have access to an Execution client with archival state.\nBy default, your Smartnode's Execution client will not provide this.\n\nPlease specify the URL of an archive-capable EC in the Smartnode section of the `rocketpool service config` Terminal UI.%s\n\n", colorYellow, colorReset)
+	} else {
+		fmt.Printf("%sYou have an archive EC specified at [%s]. This will be used for tree generation.%s\n\n", colorGreen, archiveEcUrl, colorReset)
+	}
+
+	// Get the start index
+	var startIndex uint64
+	if c.IsSet("start") {
+		startIndex = c.Uint64("start")
+	} else {
+		indexString := cliutils.Prompt("Which interval would you like to start from?", "^\\d+$", "Invalid interval. Please provide a number.", "ROCKETPOOL_START_INTERVAL")
+		startIndex, err = strconv.ParseUint(indexString, 0, 64)
+		if err != nil {
+			return fmt.Errorf("'%s' is not a valid interval: %w.\n", indexString, err)
+		}
+	}
+
+	// Check which intervals have finished
+	canResponse, err := rp.CanGenerateRewardsTree(startIndex)
+	if err != nil {
+		return err
+	}
+	if canResponse.CurrentIndex == 0 {
+		return fmt.Errorf("No rewards intervals have finished yet.")
+	}
+	endIndex := canResponse.CurrentIndex - 1
+	if c.IsSet("end") {
+		endIndex = c.Uint64("end")
+	}
+	if endIndex >= canResponse.CurrentIndex {
+		return fmt.Errorf("The current active rewards period is interval %d. You cannot generate the tree for interval %d until the active interval is past it.", canResponse.CurrentIndex, endIndex)
+	}
+	if startIndex > endIndex {
+		return fmt.Errorf("The start interval (%d) must not be after the end interval (%d).", startIndex, endIndex)
+	}
+	if endIndex-startIndex+1 > config.ArchiveRewardsTreeMaxSpan {
+		return fmt.Errorf("You can regenerate at most %d intervals at once, but %d through %d is %d intervals. Please split the range into smaller batches.", config.ArchiveRewardsTreeMaxSpan, startIndex, endIndex, endIndex-startIndex+1)
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Regenerating %d intervals (%d through %d) will take a long time, since each one is a full tree generation. Are you sure you want to continue?", endIndex-startIndex+1, startIndex, endIndex))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Create the requests
+	_, err = rp.ArchiveRewardsTrees(startIndex, endIndex)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Your request to regenerate the rewards trees for intervals %d through %d has been applied. Your `watchtower` container will generate one of them during each duty check (typically every 5 minutes), and save it in the `%s` folder of your rewards trees directory.\nYou can follow its progress with %s`rocketpool service logs watchtower`%s.\n", startIndex, endIndex, config.RewardsTreesArchiveFolder, colorGreen, colorReset)
+	return nil
+
+}
@@ -0,0 +1,73 @@
+package network
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/urfave/cli"
+)
+
+func auditSmoothingPool(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the index
+	var index uint64
+	if c.IsSet("index") {
+		index = c.Uint64("index")
+	} else {
+		indexString := cliutils.Prompt("Which interval would you like to audit the Smoothing Pool for?", "^\\d+$", "Invalid interval. Please provide a number.", "ROCKETPOOL_INTERVAL")
+		index, err = strconv.ParseUint(indexString, 0, 64)
+		if err != nil {
+			return fmt.Errorf("'%s' is not a valid interval: %w.\n", indexString, err)
+		}
+	}
+
+	// Run the audit
+	fmt.Println("Building the network state for the end of the interval, this may take a moment...")
+	response, err := rp.AuditSmoothingPool(index)
+	if err != nil {
+		return err
+	}
+	audit := response.Audit
+
+	// Print the summary
+	fmt.Printf("Interval %d (ruleset v%d): %d nodes received %.6f ETH from the Smoothing Pool.\n", audit.Index, audit.RulesetVersion, len(audit.Nodes), eth.WeiToEth(audit.TotalEth))
+	fmt.Printf("Each node's share of the ETH is compared against its share of the %s.\n", audit.ScoreBasis)
+	fmt.Printf("Nodes are flagged when their ratio is far from the pool's median ratio of %.4f (scaled MAD %.4f).\n\n", audit.MedianShareRatio, audit.ShareRatioMad)
+	if len(audit.UnknownMinipools) > 0 {
+		fmt.Printf("%sWARNING: %d minipools in the performance file could not be found in the network state, so they were left out of the audit.%s\n\n", colorYellow, len(audit.UnknownMinipools), colorReset)
+	}
+
+	showAll := c.Bool("all")
+	for _, node := range audit.Nodes {
+		if !showAll && len(node.Flags) == 0 {
+			continue
+		}
+		fmt.Printf("Node %s:\n", node.Address.Hex())
+		fmt.Printf("\tMinipools:          %d (%d heavily penalized)\n", node.MinipoolCount, node.PenalizedMinipoolCount)
+		fmt.Printf("\tAttestations:       %d successful, %d missed (%.2f%% participation)\n", node.SuccessfulAttestations, node.MissedAttestations, node.ParticipationRate*100)
+		fmt.Printf("\tSmoothing Pool ETH: %.6f (%.4f%% of the pool)\n", eth.WeiToEth(node.SmoothingPoolEth), node.EthShare*100)
+		fmt.Printf("\tScore share:        %.4f%% (ratio %.4f)\n", node.ScoreShare*100, node.ShareRatio)
+		for _, flag := range node.Flags {
+			fmt.Printf("\t%sFLAG: %s%s\n", colorRed, flag, colorReset)
+		}
+		fmt.Println()
+	}
+
+	if audit.FlaggedNodes == 0 {
+		fmt.Printf("%sNo outliers were found; every node's share of the Smoothing Pool matches its performance.%s\n", colorGreen, colorReset)
+	} else {
+		fmt.Printf("%s%d of %d nodes were flagged.%s\n", colorYellow, audit.FlaggedNodes, len(audit.Nodes), colorReset)
+	}
+	return nil
+
+}
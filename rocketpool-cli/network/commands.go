@@ -104,6 +104,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "yes, y",
 						Usage: "Automatically confirm any questions about tree generation",
 					},
+					cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Run the full generation pipeline without saving anything, and log how long each stage takes along with the client calls and memory it uses",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -118,6 +122,126 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "archive-rewards-trees",
+				Aliases:   []string{"a"},
+				Usage:     "Regenerate the rewards trees for a range of past intervals and archive them under filenames that include the ruleset version used for each one.\nAt most 24 intervals can be regenerated at once, and the files used for claiming are not replaced. This is an asynchronous process; follow its progress with `rocketpool service logs watchtower`.",
+				UsageText: "rocketpool network archive-rewards-trees --start index --end index",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "start, s",
+						Usage: "The first rewards interval to regenerate",
+					},
+					cli.Uint64Flag{
+						Name:  "end, e",
+						Usage: "The last rewards interval to regenerate (defaults to the latest finished interval)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm any questions about tree generation",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return archiveRewardsTrees(c)
+
+				},
+			},
+
+			{
+				Name:      "verify-rewards",
+				Aliases:   []string{"v"},
+				Usage:     "Regenerate the rewards tree for the provided interval and compare it against the canonical tree submitted by the Oracle DAO.\nThis runs the full tree generation, so it can take a very long time to finish.",
+				UsageText: "rocketpool network verify-rewards",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "index",
+						Usage: "The index of the rewards interval you want to verify",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the verification",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return verifyRewardsTree(c)
+
+				},
+			},
+
+			{
+				Name:      "audit-smoothing-pool",
+				Aliases:   []string{"u"},
+				Usage:     "Compare each node's attestation performance against the share of the Smoothing Pool it received for the provided interval, flagging outliers.\nThis needs the interval's minipool performance file, which is created when you generate the tree locally.",
+				UsageText: "rocketpool network audit-smoothing-pool [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "index, i",
+						Usage: "The index of the rewards interval you want to audit",
+					},
+					cli.BoolFlag{
+						Name:  "all, a",
+						Usage: "Show every node in the Smoothing Pool instead of only the flagged ones",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return auditSmoothingPool(c)
+
+				},
+			},
+
+			{
+				Name:      "merkle-proof",
+				Aliases:   []string{"m"},
+				Usage:     "Generate the Merkle proof an address needs to claim its rewards for an interval.\nThe proof is built from a local rewards file and printed as JSON.",
+				UsageText: "rocketpool network merkle-proof [options] address",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "index, i",
+						Usage: "The index of the rewards interval to use the Smartnode's copy of the rewards file for",
+					},
+					cli.StringFlag{
+						Name:  "file, f",
+						Usage: "The path of the rewards file to use instead of the Smartnode's copy (can be zstd-compressed)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					address, err := cliutils.ValidateAddress("address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return getMerkleProof(c, address)
+
+				},
+			},
+
 			{
 				Name:      "dao-proposals",
 				Aliases:   []string{"d"},
@@ -43,7 +43,7 @@ func generateRewardsTree(c *cli.Context) error {
 	if c.IsSet("index") {
 		index = c.Uint64("index")
 	} else {
-		indexString := cliutils.Prompt("Which interval would you like to generate the Merkle rewards tree for?", "^\\d+$", "Invalid interval. Please provide a number.")
+		indexString := cliutils.Prompt("Which interval would you like to generate the Merkle rewards tree for?", "^\\d+$", "Invalid interval. Please provide a number.", "ROCKETPOOL_INTERVAL")
 		index, err = strconv.ParseUint(indexString, 0, 64)
 		if err != nil {
 			return fmt.Errorf("'%s' is not a valid interval: %w.\n", indexString, err)
@@ -60,7 +60,8 @@ func generateRewardsTree(c *cli.Context) error {
 	}
 
 	// Confirm file overwrite
-	if canResponse.TreeFileExists {
+	dryRun := c.Bool("dry-run")
+	if canResponse.TreeFileExists && !dryRun {
 		if c.Bool("yes") {
 			fmt.Println("Overwriting existing rewards file.")
 		} else if !cliutils.Confirm("You already have a rewards file for this interval. Would you like to overwrite it?") {
@@ -70,13 +71,20 @@ func generateRewardsTree(c *cli.Context) error {
 	}
 
 	// Create the generation request
-	_, err = rp.GenerateRewardsTree(index)
-	if err != nil {
-		return err
+	if dryRun {
+		_, err = rp.DryRunRewardsTree(index)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Your request to do a dry run of rewards tree generation for interval %d has been applied, and your `watchtower` container will begin the process during its next duty check (typically 5 minutes).\nNo files will be saved. The timing, client call, and memory profile will be printed in %s`rocketpool service logs watchtower`%s when it finishes.\n\n", index, colorGreen, colorReset)
+	} else {
+		_, err = rp.GenerateRewardsTree(index)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Your request to generate the rewards tree for interval %d has been applied, and your `watchtower` container will begin the process during its next duty check (typically 5 minutes).\nYou can follow its progress with %s`rocketpool service logs watchtower`%s.\n\n", index, colorGreen, colorReset)
 	}
 
-	fmt.Printf("Your request to generate the rewards tree for interval %d has been applied, and your `watchtower` container will begin the process during its next duty check (typically 5 minutes).\nYou can follow its progress with %s`rocketpool service logs watchtower`%s.\n\n", index, colorGreen, colorReset)
-
 	if c.Bool("yes") || cliutils.Confirm("Would you like to restart the watchtower container now, so it starts generating the file immediately?") {
 		container := fmt.Sprintf("%s_watchtower", cfg.Smartnode.ProjectName.Value.(string))
 		response, err := rp.RestartContainer(container)
@@ -0,0 +1,73 @@
+package network
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getMerkleProof(c *cli.Context, address common.Address) error {
+
+	// Get the path of the rewards file
+	path := c.String("file")
+	if path == "" {
+		rp := rocketpool.NewClientFromCtx(c)
+		defer rp.Close()
+
+		// Get config
+		cfg, _, err := rp.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("Error loading configuration: %w", err)
+		}
+
+		// Get the index
+		var index uint64
+		if c.IsSet("index") {
+			index = c.Uint64("index")
+		} else {
+			indexString := cliutils.Prompt("Which interval would you like to generate the Merkle proof for?", "^\\d+$", "Invalid interval. Please provide a number.", "ROCKETPOOL_INTERVAL")
+			index, err = strconv.ParseUint(indexString, 0, 64)
+			if err != nil {
+				return fmt.Errorf("'%s' is not a valid interval: %w.\n", indexString, err)
+			}
+		}
+
+		// Prefer the SSZ copy if there is one
+		path = cfg.Smartnode.GetRewardsTreeSszPath(index, false)
+		_, err = os.Stat(path)
+		if os.IsNotExist(err) {
+			path = cfg.Smartnode.GetRewardsTreePath(index, false)
+			_, err = os.Stat(path)
+		}
+		if os.IsNotExist(err) {
+			return fmt.Errorf("You don't have the rewards file for interval %d. Please download it with `rocketpool node rewards` or provide one with the --file flag.", index)
+		}
+	}
+
+	// Load the file and build the proof
+	rewardsFile, err := rprewards.LoadRewardsFile(path)
+	if err != nil {
+		return err
+	}
+	bundle, err := rprewards.GenerateMerkleProofBundle(rewardsFile, address)
+	if err != nil {
+		return err
+	}
+
+	// Print it
+	bundleBytes, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Error serializing Merkle proof: %w", err)
+	}
+	fmt.Println(string(bundleBytes))
+	return nil
+
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/output"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
 const (
@@ -26,6 +28,9 @@ func getStats(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if cliutils.IsJsonOutput(c) {
+		return cliutils.PrintJson(output.NewNetworkStats(&response))
+	}
 	activeMinipools := response.InitializedMinipoolCount +
 		response.PrelaunchMinipoolCount +
 		response.StakingMinipoolCount +
@@ -0,0 +1,90 @@
+package network
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/urfave/cli"
+)
+
+const (
+	colorRed string = "\033[31m"
+)
+
+func verifyRewardsTree(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the index
+	var index uint64
+	if c.IsSet("index") {
+		index = c.Uint64("index")
+	} else {
+		indexString := cliutils.Prompt("Which interval would you like to verify the Merkle rewards tree for?", "^\\d+$", "Invalid interval. Please provide a number.", "ROCKETPOOL_INTERVAL")
+		index, err = strconv.ParseUint(indexString, 0, 64)
+		if err != nil {
+			return fmt.Errorf("'%s' is not a valid interval: %w.\n", indexString, err)
+		}
+	}
+
+	// Confirm the long-running generation
+	fmt.Printf("%sNOTE: verifying a rewards tree regenerates it from scratch, which can take a very long time and puts extra load on your clients.\nIf the interval is not recent, you will also need an archive-capable Execution client specified in the Smartnode section of the `rocketpool service config` Terminal UI.%s\n\n", colorYellow, colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to regenerate and verify the tree for interval %d?", index))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Run the verification
+	fmt.Println("Generating the tree, please wait...")
+	response, err := rp.VerifyRewardsTree(index)
+	if err != nil {
+		return err
+	}
+
+	// Print the results
+	fmt.Printf("Canonical Merkle root: %s\n", response.CanonicalMerkleRoot.Hex())
+	fmt.Printf("Local Merkle root:     %s\n\n", response.LocalMerkleRoot.Hex())
+	if response.RootsMatch {
+		fmt.Printf("%sThe tree you generated matches the canonical tree for interval %d!%s\n", colorGreen, index, colorReset)
+		return nil
+	}
+
+	fmt.Printf("%sThe tree you generated does NOT match the canonical tree for interval %d.%s\n", colorRed, index, colorReset)
+	if len(response.NodeDiffs) == 0 {
+		fmt.Println("Every node's rewards are identical, so the difference is in the tree's metadata rather than its rewards.")
+		return nil
+	}
+	fmt.Printf("%d nodes have different rewards:\n\n", len(response.NodeDiffs))
+	for _, diff := range response.NodeDiffs {
+		fmt.Printf("Node %s:\n", diff.Address.Hex())
+		if diff.MissingFromLocal {
+			fmt.Println("\tOnly present in the canonical tree")
+		}
+		if diff.MissingFromCanonical {
+			fmt.Println("\tOnly present in your tree")
+		}
+		if diff.LocalRewardNetwork != diff.CanonicalRewardNetwork {
+			fmt.Printf("\tReward network:     local %d, canonical %d\n", diff.LocalRewardNetwork, diff.CanonicalRewardNetwork)
+		}
+		if diff.LocalCollateralRpl.Cmp(diff.CanonicalCollateralRpl) != 0 {
+			fmt.Printf("\tCollateral RPL:     local %.6f, canonical %.6f\n", eth.WeiToEth(diff.LocalCollateralRpl), eth.WeiToEth(diff.CanonicalCollateralRpl))
+		}
+		if diff.LocalOracleDaoRpl.Cmp(diff.CanonicalOracleDaoRpl) != 0 {
+			fmt.Printf("\tOracle DAO RPL:     local %.6f, canonical %.6f\n", eth.WeiToEth(diff.LocalOracleDaoRpl), eth.WeiToEth(diff.CanonicalOracleDaoRpl))
+		}
+		if diff.LocalSmoothingPoolEth.Cmp(diff.CanonicalSmoothingPoolEth) != 0 {
+			fmt.Printf("\tSmoothing Pool ETH: local %.6f, canonical %.6f\n", eth.WeiToEth(diff.LocalSmoothingPoolEth), eth.WeiToEth(diff.CanonicalSmoothingPoolEth))
+		}
+	}
+
+	return nil
+
+}
@@ -47,7 +47,7 @@ func nodeBurn(c *cli.Context, amount float64, token string) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to burn %.6f %s for ETH?", math.RoundDown(eth.WeiToEth(amountWei), 6), token))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to burn %.6f %s for ETH?", math.RoundDown(eth.WeiToEth(amountWei), 6), token))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -59,7 +59,9 @@ func nodeBurn(c *cli.Context, amount float64, token string) error {
 	}
 
 	fmt.Printf("Burning tokens...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
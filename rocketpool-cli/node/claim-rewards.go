@@ -75,7 +75,7 @@ func nodeClaimRewards(c *cli.Context) error {
 		// Download the files
 		for _, missingInterval := range missingIntervals {
 			fmt.Printf("Downloading interval %d file... ", missingInterval.Index)
-			err := rprewards.DownloadRewardsFile(cfg, missingInterval.Index, missingInterval.CID, false)
+			err := rprewards.DownloadRewardsFile(cfg, missingInterval.Index, missingInterval.CID, missingInterval.MerkleRoot, false)
 			if err != nil {
 				fmt.Println()
 				return err
@@ -84,7 +84,7 @@ func nodeClaimRewards(c *cli.Context) error {
 		}
 		for _, invalidInterval := range invalidIntervals {
 			fmt.Printf("Downloading interval %d file... ", invalidInterval.Index)
-			err := rprewards.DownloadRewardsFile(cfg, invalidInterval.Index, invalidInterval.CID, false)
+			err := rprewards.DownloadRewardsFile(cfg, invalidInterval.Index, invalidInterval.CID, invalidInterval.MerkleRoot, false)
 			if err != nil {
 				fmt.Println()
 				return err
@@ -134,7 +134,7 @@ func nodeClaimRewards(c *cli.Context) error {
 	for {
 		indexSelection := ""
 		if !c.Bool("yes") {
-			indexSelection = cliutils.Prompt("Which intervals would you like to claim? Use a comma separated list (such as '1,2,3') or leave it blank to claim all intervals at once.", "^$|^\\d+(,\\d+)*$", "Invalid index selection")
+			indexSelection = cliutils.Prompt("Which intervals would you like to claim? Use a comma separated list (such as '1,2,3') or leave it blank to claim all intervals at once.", "^$|^\\d+(,\\d+)*$", "Invalid index selection", "ROCKETPOOL_CLAIM_INTERVALS")
 		}
 
 		indices = []uint64{}
@@ -227,7 +227,7 @@ func nodeClaimRewards(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to claim your rewards?")) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction("Are you sure you want to claim your rewards?")) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -249,7 +249,9 @@ func nodeClaimRewards(c *cli.Context) error {
 	}
 
 	fmt.Printf("Claiming Rewards...\n")
-	cliutils.PrintTransactionHash(rp, txHash)
+	if err = cliutils.PrintTransactionHash(rp, txHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(txHash); err != nil {
 		return err
 	}
@@ -352,7 +354,7 @@ func getRestakeAmount(c *cli.Context, rewardsInfoResponse api.NodeGetRewardsInfo
 				collateralString,
 				"A custom amount",
 			}
-			selected, _ := cliutils.Select("Please choose an amount to restake here:", amountOptions)
+			selected, _ := cliutils.Select("Please choose an amount to restake here:", amountOptions, "ROCKETPOOL_RESTAKE_OPTION")
 			switch selected {
 			case 0:
 				restakeAmountWei = nil
@@ -360,7 +362,7 @@ func getRestakeAmount(c *cli.Context, rewardsInfoResponse api.NodeGetRewardsInfo
 				restakeAmountWei = claimRpl
 			case 2:
 				for {
-					inputAmount := cliutils.Prompt("Please enter an amount of RPL to stake:", "^\\d+(\\.\\d+)?$", "Invalid amount")
+					inputAmount := cliutils.Prompt("Please enter an amount of RPL to stake:", "^\\d+(\\.\\d+)?$", "Invalid amount", "ROCKETPOOL_RESTAKE_AMOUNT")
 					stakeAmount, err := strconv.ParseFloat(inputAmount, 64)
 					if err != nil {
 						fmt.Printf("Invalid stake amount '%s': %s\n", inputAmount, err.Error())
@@ -386,7 +388,7 @@ func getRestakeAmount(c *cli.Context, rewardsInfoResponse api.NodeGetRewardsInfo
 				collateralString,
 				"A custom amount",
 			}
-			selected, _ := cliutils.Select("Please choose an amount to restake here:", amountOptions)
+			selected, _ := cliutils.Select("Please choose an amount to restake here:", amountOptions, "ROCKETPOOL_RESTAKE_OPTION")
 			switch selected {
 			case 0:
 				restakeAmountWei = nil
@@ -396,7 +398,7 @@ func getRestakeAmount(c *cli.Context, rewardsInfoResponse api.NodeGetRewardsInfo
 				restakeAmountWei = claimRpl
 			case 3:
 				for {
-					inputAmount := cliutils.Prompt("Please enter an amount of RPL to stake:", "^\\d+(\\.\\d+)?$", "Invalid amount")
+					inputAmount := cliutils.Prompt("Please enter an amount of RPL to stake:", "^\\d+(\\.\\d+)?$", "Invalid amount", "ROCKETPOOL_RESTAKE_AMOUNT")
 					stakeAmount, err := strconv.ParseFloat(inputAmount, 64)
 					if err != nil {
 						fmt.Printf("Invalid stake amount '%s': %s\n", inputAmount, err.Error())
@@ -18,7 +18,8 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Name:      "status",
 				Aliases:   []string{"s"},
 				Usage:     "Get the node's status",
-				UsageText: "rocketpool node status",
+				UsageText: "rocketpool node status [options]",
+				Flags:     cliutils.WatchFlags,
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -32,6 +33,82 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "export",
+				Usage:     "Export the node's balances, RPL stake and commission from the latest network state",
+				UsageText: "rocketpool node export [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "format, f",
+						Usage: "The format to export in ('csv' or 'json')",
+						Value: "csv",
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file to write the export to; if this isn't set, it will be printed to the terminal",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return exportNode(c)
+
+				},
+			},
+
+			{
+				Name:      "export-state",
+				Usage:     "Export a snapshot of the latest network state for your node, which read-only commands can be run against elsewhere with --state-file",
+				UsageText: "rocketpool node export-state [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file to write the snapshot to",
+						Value: "state.json.zst",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return exportState(c)
+
+				},
+			},
+
+			{
+				Name:      "dashboard",
+				Usage:     "Show a live dashboard of the node's sync status, minipools, RPL stake, duties, rewards and resource usage",
+				UsageText: "rocketpool node dashboard [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "refresh-interval, r",
+						Usage: "How often to refresh the dashboard, in seconds",
+						Value: 30,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return showDashboard(c)
+
+				},
+			},
+
 			{
 				Name:      "sync",
 				Aliases:   []string{"y"},
@@ -85,7 +162,13 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Name:      "rewards",
 				Aliases:   []string{"e"},
 				Usage:     "Get the time and your expected RPL rewards of the next checkpoint",
-				UsageText: "rocketpool node rewards",
+				UsageText: "rocketpool node rewards [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "projected, p",
+						Usage: "Estimate your RPL and Smoothing Pool rewards for the current interval from the latest network state",
+					},
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -94,9 +177,47 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Run
+					if c.Bool("projected") {
+						return getProjectedRewards(c)
+					}
 					return getRewards(c)
 
 				},
+				Subcommands: []cli.Command{
+					{
+						Name:      "export",
+						Aliases:   []string{"x"},
+						Usage:     "Export your rewards and claim history for past intervals, for tax reporting or record keeping",
+						UsageText: "rocketpool node rewards export [options]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "format, f",
+								Usage: "The format to export in ('csv' or 'json')",
+								Value: "csv",
+							},
+							cli.StringFlag{
+								Name:  "intervals, i",
+								Usage: "The intervals to export: 'all', or a comma separated list of intervals and ranges (such as '0,3,5-8')",
+								Value: "all",
+							},
+							cli.StringFlag{
+								Name:  "output, o",
+								Usage: "The file to write the export to; if this isn't set, it will be printed to the terminal",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return exportRewards(c)
+
+						},
+					},
+				},
 			},
 
 			{
@@ -383,6 +504,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "salt, l",
 						Usage: "An optional seed to use when generating the new minipool's address. Use this if you want it to have a custom vanity address.",
 					},
+					cli.StringFlag{
+						Name:  "dv-deposit-data",
+						Usage: "The deposit data file of a distributed validator cluster whose validator should be used for the minipool, instead of a new key from the node wallet. Requires --salt.",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -415,58 +540,23 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			},
 
 			{
-				Name:      "create-vacant-minipool",
-				Aliases:   []string{"cvm"},
-				Usage:     "Create an empty minipool, which can be used to migrate an existing solo staking validator as part of the 0x00 to 0x01 withdrawal credentials upgrade",
-				UsageText: "rocketpool node create-vacant-minipool pubkey [options]",
+				Name:      "get-minipool-address",
+				Usage:     "Get the address a new minipool will have, so a distributed validator cluster can use it as its withdrawal address",
+				UsageText: "rocketpool node get-minipool-address [options]",
 				Flags: []cli.Flag{
-					cli.StringFlag{
-						Name:  "amount, a",
-						Usage: "The amount of ETH to deposit (8 or 16)",
-					},
-					cli.StringFlag{
-						Name:  "max-slippage, s",
-						Usage: "The maximum acceptable slippage in node commission rate for the deposit (or 'auto'). Only relevant when the commission rate is not fixed.",
-					},
-					cli.BoolFlag{
-						Name:  "yes, y",
-						Usage: "Automatically confirm all interactive questions",
-					},
 					cli.StringFlag{
 						Name:  "salt, l",
-						Usage: "An optional seed to use when generating the new minipool's address. Use this if you want it to have a custom vanity address.",
-					},
-					cli.StringFlag{
-						Name:  "mnemonic, m",
-						Usage: "Use this flag if you want to recreate your validator's private key within the Smartnode's VC instead of running it via your own VC, and have the Smartnode reassign your validator's withdrawal credentials to the new minipool address automatically.",
-					},
-					cli.BoolFlag{
-						Name:  "no-restart",
-						Usage: "Don't restart the Validator Client after importing the key. Note that the key won't be loaded (and won't attest) until you restart the VC to load it.",
+						Usage: "The salt to use for the minipool's address. A random one will be generated if this isn't provided.",
 					},
 				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-					pubkey, err := cliutils.ValidatePubkey("pubkey", c.Args().Get(0))
-					if err != nil {
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
 						return err
 					}
 
 					// Validate flags
-					if c.String("amount") != "" {
-						if _, err := cliutils.ValidatePositiveEthAmount("deposit amount", c.String("amount")); err != nil {
-							return err
-						}
-					}
-					if c.String("max-slippage") != "" && c.String("max-slippage") != "auto" {
-						if _, err := cliutils.ValidatePercentage("maximum commission rate slippage", c.String("max-slippage")); err != nil {
-							return err
-						}
-					}
 					if c.String("salt") != "" {
 						if _, err := cliutils.ValidateBigInt("salt", c.String("salt")); err != nil {
 							return err
@@ -474,11 +564,13 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Run
-					return createVacantMinipool(c, pubkey)
+					return getMinipoolAddress(c)
 
 				},
 			},
 
+			NewCreateVacantMinipoolCommand("create-vacant-minipool", []string{"cvm"}, "rocketpool node create-vacant-minipool pubkey [options]"),
+
 			{
 				Name:      "send",
 				Aliases:   []string{"n"},
@@ -698,6 +790,160 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "pending-txs",
+				Aliases:   []string{"pt"},
+				Usage:     "List the node's transactions that are waiting to be included in a block",
+				UsageText: "rocketpool node pending-txs",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return listPendingTransactions(c)
+
+				},
+			},
+
+			{
+				Name:      "tx-history",
+				Aliases:   []string{"th"},
+				Usage:     "List the node's most recent transactions that the daemon saw included in a block",
+				UsageText: "rocketpool node tx-history [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "limit, l",
+						Usage: "The most transactions to list",
+						Value: 25,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getTransactionHistory(c)
+
+				},
+			},
+
+			{
+				Name:      "alert-history",
+				Aliases:   []string{"ah"},
+				Usage:     "Review the alerts and notifications the daemon sent recently",
+				UsageText: "rocketpool node alert-history [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "days, d",
+						Usage: "How many days back to look",
+						Value: 7,
+					},
+					cli.Uint64Flag{
+						Name:  "limit, l",
+						Usage: "The most alerts to list",
+						Value: 100,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getAlertHistory(c)
+
+				},
+			},
+
+			{
+				Name:      "duty-history",
+				Aliases:   []string{"dh"},
+				Usage:     "List the attestations and proposals of the node's validators in the most recent epochs the daemon checked",
+				UsageText: "rocketpool node duty-history [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "limit, l",
+						Usage: "The most epochs to list",
+						Value: 32,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getDutyHistory(c)
+
+				},
+			},
+
+			{
+				Name:      "speed-up-tx",
+				Aliases:   []string{"su"},
+				Usage:     "Rebroadcast a stuck transaction with higher fees so it gets included sooner. Use the global --maxFee and --maxPrioFee flags to choose the new fees.",
+				UsageText: "rocketpool node speed-up-tx [options] nonce",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm replacing the transaction",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					nonce, err := cliutils.ValidateUint("nonce", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return replaceTransaction(c, nonce, false)
+
+				},
+			},
+
+			{
+				Name:      "cancel-tx",
+				Aliases:   []string{"ct"},
+				Usage:     "Cancel a stuck transaction by replacing it with an empty transfer to the node account that pays higher fees",
+				UsageText: "rocketpool node cancel-tx [options] nonce",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm cancelling the transaction",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					nonce, err := cliutils.ValidateUint("nonce", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return replaceTransaction(c, nonce, true)
+
+				},
+			},
 		},
 	})
 }
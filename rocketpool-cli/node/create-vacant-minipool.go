@@ -5,18 +5,90 @@ import (
 	"fmt"
 	"math/big"
 	"strconv"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/cli/migration"
 	"github.com/urfave/cli"
 )
 
+// Get the command that walks through a solo validator migration, so it can be registered under more than one parent command
+func NewCreateVacantMinipoolCommand(name string, aliases []string, usageText string) cli.Command {
+	return cli.Command{
+		Name:      name,
+		Aliases:   aliases,
+		Usage:     "Migrate an existing solo staking validator into a new vacant minipool, walking through the validator checks, the minipool creation, the 0x00 to 0x01 withdrawal credentials upgrade and the promotion",
+		UsageText: usageText,
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "amount, a",
+				Usage: "The amount of ETH to deposit (8 or 16)",
+			},
+			cli.StringFlag{
+				Name:  "max-slippage, s",
+				Usage: "The maximum acceptable slippage in node commission rate for the deposit (or 'auto'). Only relevant when the commission rate is not fixed.",
+			},
+			cli.BoolFlag{
+				Name:  "yes, y",
+				Usage: "Automatically confirm all interactive questions",
+			},
+			cli.StringFlag{
+				Name:  "salt, l",
+				Usage: "An optional seed to use when generating the new minipool's address. Use this if you want it to have a custom vanity address.",
+			},
+			cli.StringFlag{
+				Name:  "mnemonic, m",
+				Usage: "Use this flag if you want to recreate your validator's private key within the Smartnode's VC instead of running it via your own VC, and have the Smartnode reassign your validator's withdrawal credentials to the new minipool address automatically.",
+			},
+			cli.BoolFlag{
+				Name:  "no-restart",
+				Usage: "Don't restart the Validator Client after importing the key. Note that the key won't be loaded (and won't attest) until you restart the VC to load it.",
+			},
+		},
+		Action: func(c *cli.Context) error {
+
+			// Validate args
+			if err := cliutils.ValidateArgCount(c, 1); err != nil {
+				return err
+			}
+			pubkey, err := cliutils.ValidatePubkey("pubkey", c.Args().Get(0))
+			if err != nil {
+				return err
+			}
+
+			// Validate flags
+			if c.String("amount") != "" {
+				if _, err := cliutils.ValidatePositiveEthAmount("deposit amount", c.String("amount")); err != nil {
+					return err
+				}
+			}
+			if c.String("max-slippage") != "" && c.String("max-slippage") != "auto" {
+				if _, err := cliutils.ValidatePercentage("maximum commission rate slippage", c.String("max-slippage")); err != nil {
+					return err
+				}
+			}
+			if c.String("salt") != "" {
+				if _, err := cliutils.ValidateBigInt("salt", c.String("salt")); err != nil {
+					return err
+				}
+			}
+
+			// Run
+			return createVacantMinipool(c, pubkey)
+
+		},
+	}
+}
+
+// Walk through migrating a solo validator into a new vacant minipool
 func createVacantMinipool(c *cli.Context, pubkey types.ValidatorPubkey) error {
 
 	// Get RP client
@@ -43,6 +115,19 @@ func createVacantMinipool(c *cli.Context, pubkey types.ValidatorPubkey) error {
 
 	fmt.Println("Your eth2 client is on the correct network.\n")
 
+	// Make sure the validator can be migrated before asking anything else
+	fmt.Printf("%sStep 1: checking validator %s...%s\n", colorGreen, pubkey.Hex(), colorReset)
+	canMigrate, err := rp.CanMigrateSoloValidator(pubkey)
+	if err != nil {
+		return err
+	}
+	if !canMigrate.CanMigrate {
+		fmt.Println("This validator can't be migrated to a minipool:")
+		printSoloValidatorProblems(pubkey, canMigrate.Validator)
+		return nil
+	}
+	fmt.Printf("Validator %s is active with a balance of %.6f ETH and BLS (0x00) withdrawal credentials, so it can be migrated.\n\n", canMigrate.Validator.ValidatorIndex, float64(canMigrate.Validator.Balance)/1e9)
+
 	// Check if the fee distributor has been initialized
 	isInitializedResponse, err := rp.IsFeeDistributorInitialized()
 	if err != nil {
@@ -54,7 +139,7 @@ func createVacantMinipool(c *cli.Context, pubkey types.ValidatorPubkey) error {
 	}
 
 	// Post a warning about fee distribution
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%sNOTE: by creating a new minipool, your node will automatically claim and distribute any balance you have in your fee distributor contract. If you don't want to claim your balance at this time, you should not create a new minipool.%s\nWould you like to continue?", colorYellow, colorReset))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("%sNOTE: by creating a new minipool, your node will automatically claim and distribute any balance you have in your fee distributor contract. If you don't want to claim your balance at this time, you should not create a new minipool.%s\nWould you like to continue?", colorYellow, colorReset))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -63,6 +148,7 @@ func createVacantMinipool(c *cli.Context, pubkey types.ValidatorPubkey) error {
 	fmt.Printf("You are about to convert the solo staker %s into a Rocket Pool minipool. This will convert your 32 ETH deposit into either an 8 ETH or 16 ETH deposit (your choice), and convert the remaining 24 or 16 ETH into a deposit from the Rocket Pool staking pool. The staking pool portion will be credited to your node's account, allowing you to create more validators without depositing additional ETH onto the Beacon Chain. Your excess balance (your existing Beacon rewards) will be preserved and not shared with the pool stakers.\n\nPlease thoroughly read our documentation at https://docs.rocketpool.net/guides/atlas/solo-staker-migration.html to learn about the process and its implications.\n\n1. First, we'll create the new minipool.\n2. Next, we'll ask whether you want to import the validator's private key into your Smartnode's Validator Client, or keep running your own externally-managed validator.\n3. Finally, we'll help you migrate your validator's withdrawal credentials to the minipool address.\n\n%sNOTE: If you intend to use the credit balance to create additional validators, you will need to have enough RPL staked to support them.%s\n\n", pubkey.Hex(), colorYellow, colorReset)
 
 	// Get deposit amount
+	fmt.Printf("%sStep 2: creating the vacant minipool%s\n", colorGreen, colorReset)
 	var amount float64
 	if c.String("amount") != "" {
 
@@ -82,7 +168,7 @@ func createVacantMinipool(c *cli.Context, pubkey types.ValidatorPubkey) error {
 		}
 
 		// Prompt for amount
-		selected, _ := cliutils.Select("Please choose an amount of ETH you want to use as your deposit for the new minipool (this will become your share of the balance, and the remainder will become the pool stakers' share):", amountOptions)
+		selected, _ := cliutils.Select("Please choose an amount of ETH you want to use as your deposit for the new minipool (this will become your share of the balance, and the remainder will become the pool stakers' share):", amountOptions, "ROCKETPOOL_DEPOSIT_OPTION")
 		switch selected {
 		case 0:
 			amount = 8
@@ -170,6 +256,7 @@ func createVacantMinipool(c *cli.Context, pubkey types.ValidatorPubkey) error {
 		if canDeposit.DepositDisabled {
 			fmt.Println("Vacant minipool deposits are currently disabled.")
 		}
+		printSoloValidatorProblems(pubkey, canDeposit.Validator)
 		return nil
 	}
 
@@ -207,7 +294,7 @@ func createVacantMinipool(c *cli.Context, pubkey types.ValidatorPubkey) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf(
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf(
 		"You are about to create a new, vacant minipool with a minimum possible commission rate of %f%%. Once created, you will be able to migrate your existing validator into this minipool.\n"+
 			"%sAre you sure you want to do this?%s",
 		minNodeFee*100,
@@ -225,7 +312,9 @@ func createVacantMinipool(c *cli.Context, pubkey types.ValidatorPubkey) error {
 
 	// Log and wait for the minipool address
 	fmt.Printf("Creating minipool...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	_, err = rp.WaitForTransaction(response.TxHash)
 	if err != nil {
 		return err
@@ -236,6 +325,9 @@ func createVacantMinipool(c *cli.Context, pubkey types.ValidatorPubkey) error {
 	fmt.Printf("Your new minipool's address is: %s\n\n", response.MinipoolAddress)
 
 	// Get the mnemonic if importing
+	fmt.Printf("%sStep 3: changing the withdrawal credentials%s\n", colorGreen, colorReset)
+	credentialsDeadline := time.Now().Add(time.Duration(response.ScrubPeriod.Seconds()*config.SoloMigrationCheckThreshold) * time.Second)
+	fmt.Printf("Your validator's withdrawal credentials must be changed from 0x00 to the minipool's 0x01 credentials by %s (in %s), or the Oracle DAO will scrub the minipool.\n\n", credentialsDeadline.Format(time.RFC822), time.Until(credentialsDeadline).Round(time.Minute))
 	mnemonic := ""
 	if c.IsSet("mnemonic") {
 		mnemonic = c.String("mnemonic")
@@ -252,10 +344,12 @@ func createVacantMinipool(c *cli.Context, pubkey types.ValidatorPubkey) error {
 		// Ignore importing / it errored out
 		fmt.Println("Since you're not importing your validator key, you will still be responsible for running and maintaining your own Validator Client with the validator's private key loaded, just as you are today.\n\n")
 		fmt.Printf("You must now upgrade your validator's withdrawal credentials manually, using as tool such as `ethdo` (https://github.com/wealdtech/ethdo), to the following minipool address:\n\n\t%s\n\n", response.MinipoolAddress)
+		fmt.Printf("Once it's done, your validator's withdrawal credentials will be %s.\n\n", response.WithdrawalCredentials.Hex())
 	}
 
-	fmt.Printf("The minipool is now in the scrub check, where it will hold for %s.\n", response.ScrubPeriod)
-	fmt.Println("You can watch its progress using `rocketpool service logs node`.")
+	fmt.Printf("%sStep 4: waiting for promotion%s\n", colorGreen, colorReset)
+	fmt.Printf("The minipool is now in the scrub check, where it will hold for %s (until %s).\n", response.ScrubPeriod, time.Now().Add(response.ScrubPeriod).Format(time.RFC822))
+	fmt.Printf("You can track the withdrawal credentials change and the promotion window with `rocketpool minipool migration-status %s`.\n", response.MinipoolAddress.Hex())
 	fmt.Println("Once the scrub check period has passed, your node will automatically promote it to an active minipool.")
 
 	return nil
@@ -278,3 +372,20 @@ func handleImport(c *cli.Context, rp *rocketpool.Client, minipoolAddress common.
 		return
 	}
 }
+
+// Print the reasons a solo validator can't be migrated
+func printSoloValidatorProblems(pubkey types.ValidatorPubkey, validator api.SoloValidatorDetails) {
+	if validator.ValidatorNotFound {
+		fmt.Printf("Validator %s does not exist on the Beacon chain. If you recently created it, please wait until the Consensus layer has processed your deposit.\n", pubkey.Hex())
+		return
+	}
+	if validator.ValidatorNotActive {
+		fmt.Printf("The validator must be in the active_ongoing state to be migrated, but it is currently %s.\n", validator.ValidatorStatus)
+	}
+	if validator.InvalidWithdrawalCredentials {
+		fmt.Printf("The validator already has withdrawal credentials %s, which are not BLS (0x00) credentials.\n", validator.WithdrawalCredentials.Hex())
+	}
+	if validator.InsufficientBalance {
+		fmt.Printf("The validator's balance is %.6f ETH, but it needs at least 32 ETH to be migrated.\n", float64(validator.Balance)/1e9)
+	}
+}
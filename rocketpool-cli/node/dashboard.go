@@ -0,0 +1,385 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The number of rewards intervals to show on the dashboard
+const dashboardRewardsIntervals int = 3
+
+// A live view of the node's health that refreshes itself from the daemon
+type dashboard struct {
+	app             *tview.Application
+	rp              *rocketpool.Client
+	cfg             *config.RocketPoolConfig
+	refreshInterval time.Duration
+	syncView        *tview.TextView
+	nodeView        *tview.TextView
+	minipoolView    *tview.TextView
+	dutiesView      *tview.TextView
+	rewardsView     *tview.TextView
+	resourcesView   *tview.TextView
+	footer          *tview.TextView
+}
+
+func showDashboard(c *cli.Context) error {
+
+	// Get RP client; the dashboard shows the sync status itself, so it doesn't need the clients to be ready
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	refreshInterval := time.Duration(c.Uint64("refresh-interval")) * time.Second
+	if refreshInterval < time.Second {
+		return fmt.Errorf("The refresh interval must be at least 1 second.")
+	}
+
+	d := newDashboard(rp, cfg, refreshInterval)
+	return d.run()
+
+}
+
+// Create the dashboard's layout
+func newDashboard(rp *rocketpool.Client, cfg *config.RocketPoolConfig, refreshInterval time.Duration) *dashboard {
+
+	d := &dashboard{
+		app:             tview.NewApplication(),
+		rp:              rp,
+		cfg:             cfg,
+		refreshInterval: refreshInterval,
+		syncView:        newDashboardPanel("Sync Status"),
+		nodeView:        newDashboardPanel("Node and RPL Stake"),
+		minipoolView:    newDashboardPanel("Minipools"),
+		dutiesView:      newDashboardPanel("Upcoming Duties"),
+		rewardsView:     newDashboardPanel("Rewards"),
+		resourcesView:   newDashboardPanel("Resource Usage"),
+		footer:          tview.NewTextView().SetDynamicColors(true),
+	}
+
+	grid := tview.NewGrid().
+		SetRows(0, 0, 0, 0, 1).
+		SetColumns(0, 0)
+	grid.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Rocket Pool Smartnode %s Dashboard ", shared.RocketPoolVersion)).
+		SetBorderColor(tcell.ColorOrange).
+		SetTitleColor(tcell.ColorOrange)
+	grid.AddItem(d.syncView, 0, 0, 1, 1, 0, 0, false)
+	grid.AddItem(d.nodeView, 0, 1, 1, 1, 0, 0, false)
+	grid.AddItem(d.minipoolView, 1, 0, 1, 2, 0, 0, true)
+	grid.AddItem(d.dutiesView, 2, 0, 1, 1, 0, 0, false)
+	grid.AddItem(d.rewardsView, 2, 1, 1, 1, 0, 0, false)
+	grid.AddItem(d.resourcesView, 3, 0, 1, 2, 0, 0, false)
+	grid.AddItem(d.footer, 4, 0, 1, 2, 0, 0, false)
+
+	d.app.SetRoot(grid, true)
+	d.app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc || event.Key() == tcell.KeyCtrlC || event.Rune() == 'q' {
+			d.app.Stop()
+			return nil
+		}
+		return event
+	})
+
+	return d
+
+}
+
+// Create a panel for one section of the dashboard
+func newDashboardPanel(title string) *tview.TextView {
+	panel := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true).
+		SetText("[gray]Loading...[-]")
+	panel.SetBorder(true).
+		SetTitle(fmt.Sprintf(" %s ", title))
+	return panel
+}
+
+// Run the dashboard until the user quits
+func (d *dashboard) run() error {
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(d.refreshInterval)
+		defer ticker.Stop()
+		for {
+			d.refresh()
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	err := d.app.Run()
+	close(done)
+	return err
+
+}
+
+// Update all of the panels with the latest data from the daemon
+func (d *dashboard) refresh() {
+
+	d.setText(d.footer, "[yellow]Refreshing...[-]  Press q to quit.")
+
+	d.setText(d.syncView, d.getSyncText())
+	d.setText(d.nodeView, d.getNodeText())
+	d.setText(d.minipoolView, d.getMinipoolText())
+	d.setText(d.dutiesView, d.getDutiesText())
+	d.setText(d.rewardsView, d.getRewardsText())
+	d.setText(d.resourcesView, d.getResourcesText())
+
+	d.setText(d.footer, fmt.Sprintf("Last updated %s, refreshing every %s.  Press q to quit.", time.Now().Format("15:04:05"), d.refreshInterval))
+
+}
+
+// Set a panel's text from outside of the UI goroutine
+func (d *dashboard) setText(view *tview.TextView, text string) {
+	d.app.QueueUpdateDraw(func() {
+		view.SetText(text)
+	})
+}
+
+func (d *dashboard) getSyncText() string {
+	status, err := d.rp.NodeSync()
+	if err != nil {
+		return getDashboardError(err)
+	}
+
+	var sb strings.Builder
+	network := d.cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	fmt.Fprintf(&sb, "Network:            %s\n\n", network)
+	fmt.Fprintf(&sb, "Execution client:   %s\n", getDashboardClientStatus(&status.EcStatus.PrimaryClientStatus))
+	if status.EcStatus.FallbackEnabled {
+		fmt.Fprintf(&sb, "Fallback execution: %s\n", getDashboardClientStatus(&status.EcStatus.FallbackClientStatus))
+	}
+	fmt.Fprintf(&sb, "Consensus client:   %s\n", getDashboardClientStatus(&status.BcStatus.PrimaryClientStatus))
+	if status.BcStatus.FallbackEnabled {
+		fmt.Fprintf(&sb, "Fallback consensus: %s\n", getDashboardClientStatus(&status.BcStatus.FallbackClientStatus))
+	}
+	return sb.String()
+}
+
+func (d *dashboard) getNodeText() string {
+	status, err := d.rp.NodeStatus()
+	if err != nil {
+		return getDashboardError(err)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Node:                %s\n", status.AccountAddress.Hex())
+	fmt.Fprintf(&sb, "Balance:             %.6f ETH, %.6f RPL\n", getDashboardEth(status.AccountBalances.ETH), getDashboardEth(status.AccountBalances.RPL))
+	if !status.Registered {
+		sb.WriteString("\n[yellow]The node is not registered with Rocket Pool.[-]\n")
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "Credit balance:      %.6f ETH\n\n", getDashboardEth(status.CreditBalance))
+
+	fmt.Fprintf(&sb, "RPL stake:           %.6f RPL\n", getDashboardEth(status.RplStake))
+	fmt.Fprintf(&sb, "Effective stake:     %.6f RPL\n", getDashboardEth(status.EffectiveRplStake))
+	if status.BorrowedCollateralRatio > 0 {
+		ratio := fmt.Sprintf("%.2f%% of borrowed ETH, %.2f%% of bonded ETH", status.BorrowedCollateralRatio*100, status.BondedCollateralRatio*100)
+		if status.RplStake != nil && status.MinimumRplStake != nil && status.RplStake.Cmp(status.MinimumRplStake) < 0 {
+			fmt.Fprintf(&sb, "Collateral ratio:    [red]%s (undercollateralized)[-]\n", ratio)
+		} else {
+			fmt.Fprintf(&sb, "Collateral ratio:    %s\n", ratio)
+		}
+		fmt.Fprintf(&sb, "Minimum stake:       %.6f RPL\n", getDashboardEth(status.MinimumRplStake))
+		fmt.Fprintf(&sb, "Maximum stake:       %.6f RPL\n", getDashboardEth(status.MaximumRplStake))
+	}
+
+	sb.WriteString("\n")
+	if status.FeeRecipientInfo.IsInSmoothingPool {
+		sb.WriteString("Smoothing Pool:      [green]opted in[-]\n")
+	} else if status.FeeRecipientInfo.IsInOptOutCooldown {
+		fmt.Fprintf(&sb, "Smoothing Pool:      [yellow]opting out (until epoch %d)[-]\n", status.FeeRecipientInfo.OptOutEpoch)
+	} else {
+		sb.WriteString("Smoothing Pool:      not opted in\n")
+	}
+	return sb.String()
+}
+
+func (d *dashboard) getMinipoolText() string {
+	status, err := d.rp.MinipoolStatus()
+	if err != nil {
+		return getDashboardError(err)
+	}
+
+	var sb strings.Builder
+	finalized := 0
+	for _, mp := range status.Minipools {
+		if mp.Finalised {
+			finalized++
+			continue
+		}
+		fmt.Fprintf(&sb, "%s  %-12s %s\n", mp.Address.Hex(), mp.Status.Status.String(), getDashboardMinipoolHealth(&mp))
+	}
+	if sb.Len() == 0 {
+		sb.WriteString("The node does not have any active minipools.\n")
+	}
+	if finalized > 0 {
+		fmt.Fprintf(&sb, "\n[gray]%d finalized minipool(s) hidden.[-]\n", finalized)
+	}
+	return sb.String()
+}
+
+func (d *dashboard) getDutiesText() string {
+	duties, err := d.rp.NodeDuties()
+	if err != nil {
+		return getDashboardError(err)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Current epoch: %d\n\n", duties.Epoch)
+	if len(duties.Proposals) == 0 {
+		sb.WriteString("No block proposals this epoch.\n")
+	}
+	for _, duty := range duties.Proposals {
+		fmt.Fprintf(&sb, "[green]Proposing %d block(s) this epoch[-] with validator %s (%s)\n", duty.Count, duty.ValidatorIndex, duty.Minipool.Hex())
+	}
+	for _, duty := range duties.CurrentSyncCommittee {
+		fmt.Fprintf(&sb, "[green]In the current sync committee[-] with validator %s (%s)\n", duty.ValidatorIndex, duty.Minipool.Hex())
+	}
+	for _, duty := range duties.NextSyncCommittee {
+		fmt.Fprintf(&sb, "[yellow]In the next sync committee[-] (from epoch %d) with validator %s (%s)\n", duties.NextSyncPeriodEpoch, duty.ValidatorIndex, duty.Minipool.Hex())
+	}
+	if len(duties.CurrentSyncCommittee) == 0 && len(duties.NextSyncCommittee) == 0 {
+		sb.WriteString("No sync committee duties in this period or the next one.\n")
+	}
+	return sb.String()
+}
+
+func (d *dashboard) getRewardsText() string {
+	rewards, err := d.rp.NodeRewards()
+	if err != nil {
+		return getDashboardError(err)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Unclaimed RPL:         %.6f RPL\n", rewards.UnclaimedRplRewards)
+	fmt.Fprintf(&sb, "Unclaimed ETH:         %.6f ETH\n", rewards.UnclaimedEthRewards)
+	fmt.Fprintf(&sb, "Estimated this cycle:  %.6f RPL\n", rewards.EstimatedRewards)
+	fmt.Fprintf(&sb, "Cycle ends:            %s\n", cliutils.GetDateTimeString(uint64(rewards.LastCheckpoint.Add(rewards.RewardsInterval).Unix())))
+
+	history, err := d.rp.NodeRewardsHistory()
+	if err != nil {
+		fmt.Fprintf(&sb, "\n%s", getDashboardError(err))
+		return sb.String()
+	}
+	if len(history.Intervals) > 0 {
+		sb.WriteString("\nRecent intervals:\n")
+	}
+	for i := len(history.Intervals) - 1; i >= 0 && i >= len(history.Intervals)-dashboardRewardsIntervals; i-- {
+		interval := history.Intervals[i]
+		claimed := "unclaimed"
+		if interval.Claimed {
+			claimed = "claimed"
+		}
+		rpl := big.NewInt(0)
+		if interval.CollateralRpl != nil {
+			rpl.Add(rpl, interval.CollateralRpl)
+		}
+		if interval.OracleDaoRpl != nil {
+			rpl.Add(rpl, interval.OracleDaoRpl)
+		}
+		fmt.Fprintf(&sb, "  #%d (ended %s): %.6f RPL, %.6f ETH, %s\n", interval.Index, interval.EndTime.Format("2006-01-02"), eth.WeiToEth(rpl), getDashboardEth(interval.SmoothingPoolEth), claimed)
+	}
+	return sb.String()
+}
+
+func (d *dashboard) getResourcesText() string {
+	if d.cfg.IsNativeMode {
+		return "[gray]Resource usage is not available in Native Mode.[-]"
+	}
+	stats, err := d.rp.GetServiceStats(nil)
+	if err != nil {
+		return getDashboardError(err)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-30s %-8s %-24s %-8s %s\n", "CONTAINER", "CPU", "MEMORY", "MEM %", "NET I/O")
+	for _, container := range stats {
+		fmt.Fprintf(&sb, "%-30s %-8s %-24s %-8s %s\n", container.Name, container.CPUPerc, container.MemUsage, container.MemPerc, container.NetIO)
+	}
+	return sb.String()
+}
+
+// Get a one-line summary of a minipool's health
+func getDashboardMinipoolHealth(mp *api.MinipoolDetails) string {
+	notes := []string{}
+	switch mp.Status.Status {
+	case types.Staking:
+		if !mp.Validator.Exists {
+			notes = append(notes, "[yellow]validator not seen on Beacon chain[-]")
+		} else if !mp.Validator.Active {
+			notes = append(notes, fmt.Sprintf("[yellow]validator %s not active[-]", mp.Validator.Index))
+		} else {
+			notes = append(notes, fmt.Sprintf("[green]validator %s active[-], %.4f ETH", mp.Validator.Index, getDashboardEth(mp.Validator.Balance)))
+		}
+	case types.Prelaunch:
+		notes = append(notes, "[yellow]waiting to stake[-]")
+	case types.Initialized:
+		notes = append(notes, fmt.Sprintf("[yellow]in queue (position %d)[-]", mp.Queue.Position))
+	case types.Dissolved:
+		notes = append(notes, "[red]dissolved[-]")
+	}
+	if mp.Penalties > 0 {
+		notes = append(notes, fmt.Sprintf("[red]%d penalties[-]", mp.Penalties))
+	}
+	if mp.RefundAvailable {
+		notes = append(notes, "refund available")
+	}
+	if mp.CloseAvailable {
+		notes = append(notes, "can be closed")
+	}
+	return strings.Join(notes, ", ")
+}
+
+// Get the dashboard's description of a client's status
+func getDashboardClientStatus(status *api.ClientStatus) string {
+	if status.Error != "" {
+		return fmt.Sprintf("[red]unavailable[-] (%s)", tview.Escape(status.Error))
+	}
+	if status.IsSynced {
+		return "[green]synced[-]"
+	}
+	return fmt.Sprintf("[yellow]syncing (%0.2f%%)[-]", rocketpool.SyncRatioToPercent(status.SyncProgress))
+}
+
+// Format an error for a dashboard panel
+func getDashboardError(err error) string {
+	return fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error()))
+}
+
+// Convert a wei amount to ETH for the dashboard, treating a missing amount as zero
+func getDashboardEth(wei *big.Int) float64 {
+	if wei == nil {
+		return 0
+	}
+	return eth.WeiToEth(wei)
+}
@@ -1,11 +1,11 @@
 package node
 
 import (
-	"crypto/rand"
 	"fmt"
 	"math/big"
 	"strconv"
 
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
@@ -55,7 +55,7 @@ func nodeDeposit(c *cli.Context) error {
 	}
 
 	// Post a warning about fee distribution
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%sNOTE: by creating a new minipool, your node will automatically claim and distribute any balance you have in your fee distributor contract. If you don't want to claim your balance at this time, you should not create a new minipool.%s\nWould you like to continue?", colorYellow, colorReset))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("%sNOTE: by creating a new minipool, your node will automatically claim and distribute any balance you have in your fee distributor contract. If you don't want to claim your balance at this time, you should not create a new minipool.%s\nWould you like to continue?", colorYellow, colorReset))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -77,7 +77,7 @@ func nodeDeposit(c *cli.Context) error {
 		}
 
 		// Prompt for amount
-		selected, _ := cliutils.Select("Please choose an amount of ETH to deposit:", amountOptions)
+		selected, _ := cliutils.Select("Please choose an amount of ETH to deposit:", amountOptions, "ROCKETPOOL_DEPOSIT_OPTION")
 		switch selected {
 		case 0:
 			amount = 8
@@ -132,24 +132,30 @@ func nodeDeposit(c *cli.Context) error {
 	}
 
 	// Get minipool salt
-	var salt *big.Int
-	if c.String("salt") != "" {
-		var success bool
-		salt, success = big.NewInt(0).SetString(c.String("salt"), 0)
-		if !success {
-			return fmt.Errorf("Invalid minipool salt: %s", c.String("salt"))
+	salt, err := getMinipoolSalt(c)
+	if err != nil {
+		return err
+	}
+
+	// Use a distributed validator instead of a new key from the node wallet if requested
+	var dvPubkey *types.ValidatorPubkey
+	if c.String("dv-deposit-data") != "" {
+		if c.String("salt") == "" {
+			return fmt.Errorf("The minipool's address depends on its salt, so you must provide the same --salt you used with `rocketpool node get-minipool-address` when your cluster generated its key.")
 		}
-	} else {
-		buffer := make([]byte, 32)
-		_, err = rand.Read(buffer)
+		cfg, _, err := rp.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("Error loading configuration: %w", err)
+		}
+		dvPubkey, err = importDistributedDepositData(cfg, c.String("dv-deposit-data"))
 		if err != nil {
-			return fmt.Errorf("Error generating random salt: %w", err)
+			return err
 		}
-		salt = big.NewInt(0).SetBytes(buffer)
+		fmt.Printf("This minipool will use distributed validator %s instead of a new key from your node wallet.\n\n", dvPubkey.Hex())
 	}
 
 	// Check deposit can be made
-	canDeposit, err := rp.CanNodeDeposit(amountWei, minNodeFee, salt)
+	canDeposit, err := rp.CanNodeDeposit(amountWei, minNodeFee, salt, dvPubkey)
 	if err != nil {
 		return err
 	}
@@ -195,7 +201,7 @@ func nodeDeposit(c *cli.Context) error {
 			fmt.Printf("%sNOTE: Your credit balance *cannot* currently be used to create a new minipool; there is not enough ETH in the staking pool to cover the initial deposit on your behalf (it needs at least 1 ETH but only has %.2f ETH).%s\nIf you want to continue creating this minipool now, you will have to pay for the full bond amount.\n\n", colorYellow, eth.WeiToEth(canDeposit.DepositBalance), colorReset)
 
 			// Prompt for confirmation
-			if !(c.Bool("yes") || cliutils.Confirm("Would you like to continue?")) {
+			if !(c.Bool("yes") || cliutils.ConfirmTransaction("Would you like to continue?")) {
 				fmt.Println("Cancelled.")
 				return nil
 			}
@@ -235,7 +241,7 @@ func nodeDeposit(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf(
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf(
 		"You are about to deposit %.6f ETH to create a minipool with a minimum possible commission rate of %f%%.\n"+
 			"%sARE YOU SURE YOU WANT TO DO THIS? Exiting this minipool and retrieving your capital cannot be done until your minipool has been *active* on the Beacon Chain for 256 epochs (approx. 27 hours).%s\n",
 		math.RoundDown(eth.WeiToEth(amountWei), 6),
@@ -247,14 +253,16 @@ func nodeDeposit(c *cli.Context) error {
 	}
 
 	// Make deposit
-	response, err := rp.NodeDeposit(amountWei, minNodeFee, salt, useCreditBalance, true)
+	response, err := rp.NodeDeposit(amountWei, minNodeFee, salt, dvPubkey, useCreditBalance, true)
 	if err != nil {
 		return err
 	}
 
 	// Log and wait for the minipool address
 	fmt.Printf("Creating minipool...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	_, err = rp.WaitForTransaction(response.TxHash)
 	if err != nil {
 		return err
@@ -0,0 +1,134 @@
+package node
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// The deposit amounts a distributed validator cluster has to sign for a new minipool
+const (
+	dvPrestakeAmountGwei uint64 = 1e9  // 1 ETH
+	dvStakeAmountGwei    uint64 = 31e9 // 31 ETH
+)
+
+func getMinipoolAddress(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the salt
+	salt, err := getMinipoolSalt(c)
+	if err != nil {
+		return err
+	}
+
+	// Get the address
+	response, err := rp.GetExpectedMinipoolAddress(salt)
+	if err != nil {
+		return err
+	}
+
+	// Print it
+	fmt.Printf("Salt:                   %s\n", salt.String())
+	fmt.Printf("Minipool address:       %s\n", response.MinipoolAddress.Hex())
+	fmt.Printf("Withdrawal credentials: %s\n\n", response.WithdrawalCredentials.Hex())
+	fmt.Println("To run this minipool's validator as a distributed validator, use the minipool address as the withdrawal address when your cluster generates its key.")
+	fmt.Println("Have the cluster sign deposit data for both 1 ETH and 31 ETH, then create the minipool with:")
+	fmt.Printf("\trocketpool node deposit --salt %s --dv-deposit-data <path to the deposit data file>\n", salt.String())
+	return nil
+
+}
+
+// Get the salt for a new minipool from the command line, or make a random one
+func getMinipoolSalt(c *cli.Context) (*big.Int, error) {
+	if c.String("salt") != "" {
+		salt, success := big.NewInt(0).SetString(c.String("salt"), 0)
+		if !success {
+			return nil, fmt.Errorf("Invalid minipool salt: %s", c.String("salt"))
+		}
+		return salt, nil
+	}
+	buffer := make([]byte, 32)
+	_, err := rand.Read(buffer)
+	if err != nil {
+		return nil, fmt.Errorf("Error generating random salt: %w", err)
+	}
+	return big.NewInt(0).SetBytes(buffer), nil
+}
+
+// Check a distributed validator cluster's deposit data file and save it where the daemons can find it, returning the validator's pubkey
+func importDistributedDepositData(cfg *config.RocketPoolConfig, path string) (*types.ValidatorPubkey, error) {
+
+	if cfg.EnableDistributedValidators.Value != true {
+		return nil, fmt.Errorf("Distributed validators are not enabled. Please enable them in the `rocketpool service config` TUI and set up your cluster before creating a minipool for one.")
+	}
+
+	// Read the file
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error expanding deposit data path: %w", err)
+	}
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading deposit data file [%s]: %w", path, err)
+	}
+	entries, err := validator.ParseDistributedDepositData(bytes)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("The deposit data file [%s] is empty.", path)
+	}
+
+	// Make sure it's for a single validator and has both of the deposits a minipool makes
+	pubkey, err := entries[0].GetPubkey()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid pubkey in the deposit data file: %w", err)
+	}
+	hasPrestake := false
+	hasStake := false
+	for _, entry := range entries {
+		entryPubkey, err := entry.GetPubkey()
+		if err != nil {
+			return nil, fmt.Errorf("Invalid pubkey in the deposit data file: %w", err)
+		}
+		if entryPubkey != pubkey {
+			return nil, fmt.Errorf("The deposit data file has entries for more than one validator (%s and %s). A minipool can only use one, so please provide a file with just its deposit data.", pubkey.Hex(), entryPubkey.Hex())
+		}
+		switch entry.Amount {
+		case dvPrestakeAmountGwei:
+			hasPrestake = true
+		case dvStakeAmountGwei:
+			hasStake = true
+		}
+	}
+	if !hasPrestake || !hasStake {
+		return nil, fmt.Errorf("The deposit data file needs deposits of both 1 ETH (for creating the minipool) and 31 ETH (for staking it), but it's missing at least one of them. Please have your cluster sign deposit data for both amounts.")
+	}
+
+	// Save it to the data folder; the signatures and withdrawal credentials are checked by the daemon against the minipool
+	dvPath, err := homedir.Expand(cfg.Smartnode.GetDistributedValidatorsPathInCLI())
+	if err != nil {
+		return nil, fmt.Errorf("Error expanding distributed validators path: %w", err)
+	}
+	err = validator.SaveDistributedDepositData(dvPath, pubkey, entries)
+	if err != nil {
+		return nil, err
+	}
+	return &pubkey, nil
+
+}
@@ -47,7 +47,7 @@ func initializeFeeDistributor(c *cli.Context) error {
 	fmt.Printf("Your node's fee distributor contract will be created at address %s.\n", gasResponse.Distributor.Hex())
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to initialize your fee distributor contract?")) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction("Are you sure you want to initialize your fee distributor contract?")) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -59,7 +59,9 @@ func initializeFeeDistributor(c *cli.Context) error {
 	}
 
 	fmt.Printf("Initializing fee distributor contract...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
@@ -114,7 +116,7 @@ func distribute(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to distribute the ETH from your node's fee distributor?")) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction("Are you sure you want to distribute the ETH from your node's fee distributor?")) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -126,7 +128,9 @@ func distribute(c *cli.Context) error {
 	}
 
 	fmt.Printf("Distributing rewards...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
@@ -0,0 +1,187 @@
+package node
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// The columns of the rewards history export
+var rewardsExportHeader = []string{
+	"Interval",
+	"Start Time (UTC)",
+	"End Time (UTC)",
+	"Staking RPL",
+	"Oracle DAO RPL",
+	"Total RPL",
+	"Smoothing Pool ETH",
+	"Claimed",
+	"Claim Transaction",
+	"Claim Block",
+	"Claim Time (UTC)",
+	"RPL Price at Claim (ETH)",
+	"RPL Value at Claim (ETH)",
+}
+
+func exportRewards(c *cli.Context) error {
+
+	// Check the flags before doing any work
+	format := strings.ToLower(c.String("format"))
+	if format != "csv" && format != "json" {
+		return fmt.Errorf("Invalid format '%s'; supported formats are 'csv' and 'json'.", c.String("format"))
+	}
+	selectedIntervals, err := parseIntervalSelection(c.String("intervals"))
+	if err != nil {
+		return err
+	}
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the history
+	fmt.Fprintln(os.Stderr, "Getting your rewards history, this may take a moment...")
+	history, err := rp.NodeRewardsHistory()
+	if err != nil {
+		return err
+	}
+	if !history.Registered {
+		fmt.Println("This node is not currently registered.")
+		return nil
+	}
+	intervals := []api.NodeRewardsHistoryInterval{}
+	for _, interval := range history.Intervals {
+		if selectedIntervals == nil || selectedIntervals[interval.Index] {
+			intervals = append(intervals, interval)
+		}
+	}
+
+	// Get the output
+	var output io.Writer = os.Stdout
+	path := c.String("output")
+	if path != "" {
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("Error creating %s: %w", path, err)
+		}
+		defer file.Close()
+		output = file
+	}
+
+	// Write the history
+	switch format {
+	case "json":
+		historyBytes, err := json.MarshalIndent(intervals, "", "  ")
+		if err != nil {
+			return fmt.Errorf("Error serializing rewards history: %w", err)
+		}
+		_, err = fmt.Fprintln(output, string(historyBytes))
+		if err != nil {
+			return fmt.Errorf("Error writing rewards history: %w", err)
+		}
+	case "csv":
+		err = writeRewardsCsv(output, intervals)
+		if err != nil {
+			return fmt.Errorf("Error writing rewards history: %w", err)
+		}
+	}
+
+	if path != "" {
+		fmt.Printf("Exported %d intervals to %s.\n", len(intervals), path)
+	}
+	return nil
+
+}
+
+// Writes the rewards history as a CSV table
+func writeRewardsCsv(output io.Writer, intervals []api.NodeRewardsHistoryInterval) error {
+	writer := csv.NewWriter(output)
+	err := writer.Write(rewardsExportHeader)
+	if err != nil {
+		return err
+	}
+
+	for _, interval := range intervals {
+		record := make([]string, len(rewardsExportHeader))
+		record[0] = fmt.Sprint(interval.Index)
+		record[1] = interval.StartTime.UTC().Format(time.RFC3339)
+		record[2] = interval.EndTime.UTC().Format(time.RFC3339)
+
+		// Leave the amounts blank if the rewards file for the interval isn't available
+		totalRpl := big.NewInt(0).Add(interval.CollateralRpl, interval.OracleDaoRpl)
+		if interval.RewardsAvailable {
+			record[3] = math.WeiToDecimalString(interval.CollateralRpl)
+			record[4] = math.WeiToDecimalString(interval.OracleDaoRpl)
+			record[5] = math.WeiToDecimalString(totalRpl)
+			record[6] = math.WeiToDecimalString(interval.SmoothingPoolEth)
+		}
+
+		record[7] = strconv.FormatBool(interval.Claimed)
+		if interval.ClaimTxHash != (common.Hash{}) {
+			record[8] = interval.ClaimTxHash.Hex()
+			record[9] = fmt.Sprint(interval.ClaimBlock)
+			record[10] = interval.ClaimTime.UTC().Format(time.RFC3339)
+			if interval.RplPriceAtClaim != nil {
+				record[11] = math.WeiToDecimalString(interval.RplPriceAtClaim)
+				if interval.RewardsAvailable {
+					value := big.NewInt(0).Mul(totalRpl, interval.RplPriceAtClaim)
+					value.Div(value, big.NewInt(1e18))
+					record[12] = math.WeiToDecimalString(value)
+				}
+			}
+		}
+
+		err = writer.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// Parses an interval selection such as "all" or "0,3,5-8" into a set of interval indices; nil means all intervals
+func parseIntervalSelection(selection string) (map[uint64]bool, error) {
+	selection = strings.TrimSpace(selection)
+	if selection == "" || strings.ToLower(selection) == "all" {
+		return nil, nil
+	}
+
+	selected := map[uint64]bool{}
+	for _, element := range strings.Split(selection, ",") {
+		element = strings.TrimSpace(element)
+		bounds := strings.SplitN(element, "-", 2)
+		start, err := strconv.ParseUint(bounds[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a valid interval.", element)
+		}
+		end := start
+		if len(bounds) == 2 {
+			end, err = strconv.ParseUint(bounds[1], 10, 64)
+			if err != nil || end < start {
+				return nil, fmt.Errorf("'%s' is not a valid interval range.", element)
+			}
+		}
+		for i := start; i <= end; i++ {
+			selected[i] = true
+		}
+	}
+	return selected, nil
+}
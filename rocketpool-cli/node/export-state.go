@@ -0,0 +1,48 @@
+package node
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+func exportState(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the snapshot
+	fmt.Fprintln(os.Stderr, "Getting the latest network state for your node, this may take a moment...")
+	response, err := rp.NodeStateSnapshot()
+	if err != nil {
+		return err
+	}
+	if !response.Registered {
+		fmt.Println("This node is not currently registered.")
+		return nil
+	}
+
+	// Save it
+	path := c.String("output")
+	if path == "" {
+		path = state.StateSnapshotFilename
+	}
+	err = response.Snapshot.Save(path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported the state of your node as of block %d to %s.\n", response.Snapshot.ElBlockNumber, path)
+	fmt.Printf("Read-only commands can be run against it on any machine, without clients, with `rocketpool --state-file %s <command>`.\n", path)
+	fmt.Println("Note that it contains your node's address, balances and validator details, so only share it with people you trust.")
+	return nil
+
+}
@@ -0,0 +1,148 @@
+package node
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// The columns of the node export
+var nodeExportHeader = []string{
+	"Address",
+	"Withdrawal Address",
+	"Timezone",
+	"Registration Time (UTC)",
+	"Fee Distributor",
+	"Fee Distributor Balance (ETH)",
+	"Smoothing Pool",
+	"ETH Balance",
+	"rETH Balance",
+	"RPL Balance",
+	"RPL Stake",
+	"Effective RPL Stake",
+	"Minimum RPL Stake",
+	"Maximum RPL Stake",
+	"ETH Matched",
+	"ETH Matched Limit",
+	"Deposit Credit (ETH)",
+	"Minipools",
+	"Average Commission (%)",
+	"Collateral Ratio",
+	"Block",
+	"Slot",
+}
+
+func exportNode(c *cli.Context) error {
+
+	// Check the flags before doing any work
+	format := strings.ToLower(c.String("format"))
+	if format != "csv" && format != "json" {
+		return fmt.Errorf("Invalid format '%s'; supported formats are 'csv' and 'json'.", c.String("format"))
+	}
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the node's data
+	fmt.Fprintln(os.Stderr, "Getting the node's details, this may take a moment...")
+	data, err := rp.NodeExportData()
+	if err != nil {
+		return err
+	}
+	if !data.Registered {
+		fmt.Println("This node is not currently registered.")
+		return nil
+	}
+
+	// Get the output
+	var output io.Writer = os.Stdout
+	path := c.String("output")
+	if path != "" {
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("Error creating %s: %w", path, err)
+		}
+		defer file.Close()
+		output = file
+	}
+
+	// Write the node details
+	switch format {
+	case "json":
+		nodeBytes, err := json.MarshalIndent(data.Node, "", "  ")
+		if err != nil {
+			return fmt.Errorf("Error serializing node details: %w", err)
+		}
+		_, err = fmt.Fprintln(output, string(nodeBytes))
+		if err != nil {
+			return fmt.Errorf("Error writing node details: %w", err)
+		}
+	case "csv":
+		err = writeNodeCsv(output, data)
+		if err != nil {
+			return fmt.Errorf("Error writing node details: %w", err)
+		}
+	}
+
+	if path != "" {
+		fmt.Printf("Exported the node's details as of block %d to %s.\n", data.ElBlockNumber, path)
+	}
+	return nil
+
+}
+
+// Writes the node details as a single-row CSV table
+func writeNodeCsv(output io.Writer, data api.NodeExportResponse) error {
+	writer := csv.NewWriter(output)
+	err := writer.Write(nodeExportHeader)
+	if err != nil {
+		return err
+	}
+
+	node := data.Node
+	err = writer.Write([]string{
+		node.Address.Hex(),
+		node.WithdrawalAddress.Hex(),
+		node.TimezoneLocation,
+		node.RegistrationTime.UTC().Format(time.RFC3339),
+		node.FeeDistributorAddress.Hex(),
+		math.WeiToDecimalString(node.FeeDistributorBalance),
+		strconv.FormatBool(node.SmoothingPoolRegistered),
+		math.WeiToDecimalString(node.EthBalance),
+		math.WeiToDecimalString(node.RethBalance),
+		math.WeiToDecimalString(node.RplBalance),
+		math.WeiToDecimalString(node.RplStake),
+		math.WeiToDecimalString(node.EffectiveRplStake),
+		math.WeiToDecimalString(node.MinimumRplStake),
+		math.WeiToDecimalString(node.MaximumRplStake),
+		math.WeiToDecimalString(node.EthMatched),
+		math.WeiToDecimalString(node.EthMatchedLimit),
+		math.WeiToDecimalString(node.DepositCreditBalance),
+		fmt.Sprint(node.MinipoolCount),
+		math.WeiToPercentString(node.AverageNodeFee),
+		math.WeiToDecimalString(node.CollateralisationRatio),
+		fmt.Sprint(data.ElBlockNumber),
+		fmt.Sprint(data.BeaconSlotNumber),
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
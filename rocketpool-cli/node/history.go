@@ -0,0 +1,127 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func getTransactionHistory(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the transactions
+	response, err := rp.NodeTransactionHistory(c.Uint64("limit"))
+	if err != nil {
+		return err
+	}
+	if len(response.Transactions) == 0 {
+		fmt.Println("The daemon hasn't recorded any of the node's transactions yet. Transactions are recorded as they're included in a block while the daemon is running.")
+		return nil
+	}
+
+	// Print them
+	for _, tx := range response.Transactions {
+		fmt.Printf("Nonce %d: included in block %d at %s\n", tx.Nonce, tx.ElBlockNumber, tx.Time.Local().Format(time.RFC1123))
+		if tx.TxHash != nil {
+			fmt.Printf("\tHash:   %s\n", tx.TxHash.Hex())
+		}
+		switch tx.TxStatus {
+		case "succeeded":
+			fmt.Printf("\tStatus: %ssucceeded%s\n", colorGreen, colorReset)
+		case "failed":
+			fmt.Printf("\tStatus: %sfailed%s\n", colorRed, colorReset)
+		default:
+			fmt.Println("\tStatus: unknown (it wasn't seen in the transaction pool first)")
+		}
+	}
+	return nil
+
+}
+
+func getAlertHistory(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the alerts
+	days := c.Uint64("days")
+	response, err := rp.NodeAlertHistory(days, c.Uint64("limit"))
+	if err != nil {
+		return err
+	}
+	if len(response.Alerts) == 0 {
+		fmt.Printf("The daemon hasn't sent any alerts or notifications in the last %d day(s).\n", days)
+		return nil
+	}
+
+	// Print them
+	for _, alert := range response.Alerts {
+		timestamp := alert.Time.Local().Format(time.RFC1123)
+		switch {
+		case alert.Severity == "info":
+			fmt.Printf("%s  EVENT    [%s] %s\n", timestamp, alert.Rule, alert.Summary)
+		case alert.Resolved:
+			fmt.Printf("%s  %sRESOLVED%s [%s] %s (firing since %s)\n", timestamp, colorGreen, colorReset, alert.Rule, alert.Summary, alert.StartTime.Local().Format(time.RFC1123))
+		case alert.Severity == "critical":
+			fmt.Printf("%s  %sCRITICAL%s [%s] %s\n", timestamp, colorRed, colorReset, alert.Rule, alert.Summary)
+		default:
+			fmt.Printf("%s  %sWARNING%s  [%s] %s\n", timestamp, colorYellow, colorReset, alert.Rule, alert.Summary)
+		}
+	}
+	return nil
+
+}
+
+func getDutyHistory(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the epochs
+	response, err := rp.NodeDutyHistory(c.Uint64("limit"))
+	if err != nil {
+		return err
+	}
+	if len(response.Epochs) == 0 {
+		fmt.Println("The daemon hasn't checked any epochs yet. Validator duties are checked a few epochs behind the head while the daemon is running.")
+		return nil
+	}
+
+	// Print them
+	totalAttestations := uint64(0)
+	totalMissed := uint64(0)
+	for _, epoch := range response.Epochs {
+		totalAttestations += epoch.Validators
+		totalMissed += epoch.MissedAttestations
+		line := fmt.Sprintf("Epoch %d: %d/%d attestations", epoch.Epoch, epoch.Validators-epoch.MissedAttestations, epoch.Validators)
+		if epoch.Proposals > 0 {
+			line += fmt.Sprintf(", %d/%d proposals", epoch.Proposals-epoch.MissedProposals, epoch.Proposals)
+		}
+		if epoch.MissedAttestations > 0 || epoch.MissedProposals > 0 {
+			line = colorYellow + line + colorReset
+		}
+		fmt.Println(line)
+	}
+	if totalAttestations > 0 {
+		fmt.Printf("\nThe node's validators made %.2f%% of their attestations in these epochs.\n", float64(totalAttestations-totalMissed)/float64(totalAttestations)*100)
+	}
+	return nil
+
+}
@@ -0,0 +1,141 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func listPendingTransactions(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the pending transactions
+	response, err := rp.GetPendingTransactions()
+	if err != nil {
+		return err
+	}
+	if response.IsSafe {
+		fmt.Println("The node account is a Safe, so its transactions wait in the Safe's transaction queue instead. Check them from the Safe's web interface.")
+		return nil
+	}
+
+	fmt.Printf("The node's latest included nonce is %d, and its next transaction will use nonce %d.\n", response.LatestNonce, response.PendingNonce)
+	fmt.Printf("The current base fee is %.6f gwei.\n\n", eth.WeiToGwei(response.BaseFee))
+	if response.TxPoolError != "" {
+		fmt.Printf("%sYour Execution client's transaction pool couldn't be read (%s), so the pending transactions can't be listed.%s\n", colorYellow, response.TxPoolError, colorReset)
+		if response.PendingNonce > response.LatestNonce {
+			fmt.Printf("The node has %d transaction(s) waiting to be included, starting at nonce %d.\n", response.PendingNonce-response.LatestNonce, response.LatestNonce)
+		}
+		return nil
+	}
+	if len(response.Transactions) == 0 {
+		fmt.Println("The node doesn't have any pending transactions.")
+		return nil
+	}
+
+	// Print them
+	for _, tx := range response.Transactions {
+		fmt.Printf("Nonce %d: %s\n", tx.Nonce, tx.Hash.Hex())
+		if tx.To != nil {
+			fmt.Printf("\tTo:               %s\n", tx.To.Hex())
+		}
+		fmt.Printf("\tValue:            %.6f ETH\n", eth.WeiToEth(tx.Value))
+		fmt.Printf("\tMax fee:          %.6f gwei\n", eth.WeiToGwei(tx.MaxFeePerGas))
+		fmt.Printf("\tMax priority fee: %.6f gwei\n", eth.WeiToGwei(tx.MaxPriorityFeePerGas))
+		if tx.Queued {
+			fmt.Printf("\t%sQueued: it's waiting for a transaction with a lower nonce.%s\n", colorYellow, colorReset)
+		} else if tx.MaxFeePerGas.Cmp(response.BaseFee) < 0 {
+			fmt.Printf("\t%sStuck: its max fee is below the current base fee.%s\n", colorYellow, colorReset)
+		}
+		fmt.Println()
+	}
+	fmt.Println("Use `rocketpool node speed-up-tx <nonce>` to rebroadcast a transaction with higher fees, or `rocketpool node cancel-tx <nonce>` to cancel it.")
+	return nil
+
+}
+
+func replaceTransaction(c *cli.Context, nonce uint64, cancel bool) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check the transaction can be replaced
+	var canReplace func(uint64) (api.CanReplaceTransactionResponse, error)
+	var replace func(uint64) (api.ReplaceTransactionResponse, error)
+	var action string
+	if cancel {
+		canReplace, replace, action = rp.CanCancelTransaction, rp.CancelTransaction, "cancel"
+	} else {
+		canReplace, replace, action = rp.CanReplaceTransaction, rp.ReplaceTransaction, "speed up"
+	}
+	canResponse, err := canReplace(nonce)
+	if err != nil {
+		return err
+	}
+	if !canResponse.CanReplace {
+		fmt.Printf("Cannot %s the transaction:\n", action)
+		if canResponse.IsSafe {
+			fmt.Println("The node account is a Safe, so its transactions must be replaced from the Safe's transaction queue.")
+		}
+		if canResponse.NotFound {
+			fmt.Printf("The node doesn't have a pending transaction with nonce %d in your Execution client's transaction pool.\n", nonce)
+		}
+		return nil
+	}
+
+	// Print the transaction and the lowest fees that can replace it
+	tx := canResponse.Transaction
+	fmt.Printf("Transaction %s (nonce %d) currently pays a max fee of %.6f gwei and a max priority fee of %.6f gwei.\n", tx.Hash.Hex(), tx.Nonce, eth.WeiToGwei(tx.MaxFeePerGas), eth.WeiToGwei(tx.MaxPriorityFeePerGas))
+	fmt.Printf("To replace it, the new transaction must pay at least %.6f gwei and %.6f gwei; lower fees will be raised to these.\n\n", eth.WeiToGwei(canResponse.MinMaxFeePerGas), eth.WeiToGwei(canResponse.MinMaxPriorityFeePerGas))
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canResponse.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to %s the transaction with nonce %d?", action, nonce))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Replace the transaction
+	response, err := replace(nonce)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Replacing the transaction with nonce %d (max fee %.6f gwei, max priority fee %.6f gwei)...\n", nonce, eth.WeiToGwei(response.MaxFeePerGas), eth.WeiToGwei(response.MaxPriorityFeePerGas))
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	if cancel {
+		fmt.Printf("Successfully cancelled the transaction with nonce %d.\n", nonce)
+	} else {
+		fmt.Printf("Successfully sped up the transaction with nonce %d.\n", nonce)
+	}
+	return nil
+
+}
@@ -0,0 +1,63 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func getProjectedRewards(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the projection
+	fmt.Println("Building the latest network state, this may take a moment...")
+	projection, err := rp.NodeProjectedRewards()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%sNOTE: These figures are estimates. They assume the network keeps accumulating rewards at its current rate and that every eligible minipool attests perfectly for the rest of the interval.%s\n\n", colorYellow, colorReset)
+
+	fmt.Printf("Interval %d started on %s and will end on %s (%s from now).\n",
+		projection.Index,
+		cliutils.GetDateTimeString(uint64(projection.IntervalStart.Unix())),
+		cliutils.GetDateTimeString(uint64(projection.IntervalEnd.Unix())),
+		time.Until(projection.IntervalEnd).Round(time.Second).String())
+	fmt.Printf("This projection is based on the network state at %s (%.2f%% of the way through the interval).\n", cliutils.GetDateTimeString(uint64(projection.SnapshotTime.Unix())), projection.ElapsedFraction*100)
+
+	fmt.Println("\n=== RPL ===")
+	fmt.Printf("The network has accumulated %.6f RPL in rewards so far, and should reach about %.6f RPL by the end of the interval.\n", math.RoundDown(eth.WeiToEth(projection.PendingRpl), 6), math.RoundDown(eth.WeiToEth(projection.ProjectedTotalRpl), 6))
+	if projection.TotalEffectiveStake.Sign() > 0 {
+		stakeShare := eth.WeiToEth(projection.NodeEffectiveStake) / eth.WeiToEth(projection.TotalEffectiveStake) * 100
+		fmt.Printf("Your effective stake is %.6f RPL, which is %.4f%% of the network's total.\n", math.RoundDown(eth.WeiToEth(projection.NodeEffectiveStake), 6), stakeShare)
+	}
+	fmt.Printf("Your projected RPL staking rewards: %.6f RPL\n", math.RoundDown(eth.WeiToEth(projection.ProjectedCollateralRpl), 6))
+	if projection.ProjectedOracleDaoRpl.Sign() > 0 {
+		fmt.Printf("Your projected Oracle DAO rewards: %.6f RPL\n", math.RoundDown(eth.WeiToEth(projection.ProjectedOracleDaoRpl), 6))
+	}
+
+	fmt.Println("\n=== Smoothing Pool ===")
+	if !projection.IsOptedIntoSmoothingPool {
+		fmt.Println("Your node is not opted into the Smoothing Pool, so it will not receive any Smoothing Pool rewards this interval.")
+		return nil
+	}
+	fmt.Printf("The Smoothing Pool currently holds %.6f ETH, and should reach about %.6f ETH by the end of the interval.\n", math.RoundDown(eth.WeiToEth(projection.SmoothingPoolBalance), 6), math.RoundDown(eth.WeiToEth(projection.ProjectedSmoothingBalance), 6))
+	fmt.Printf("There are %d eligible minipools in the Smoothing Pool.\n", projection.EligibleMinipoolCount)
+	fmt.Printf("Your projected Smoothing Pool rewards: %.6f ETH\n", math.RoundDown(eth.WeiToEth(projection.ProjectedSmoothingPoolEth), 6))
+
+	// Return
+	return nil
+
+}
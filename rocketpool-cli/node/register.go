@@ -50,7 +50,7 @@ func registerNode(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to register this node?")) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction("Are you sure you want to register this node?")) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -62,7 +62,9 @@ func registerNode(c *cli.Context) error {
 	}
 
 	fmt.Printf("Registering node...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
@@ -8,6 +8,7 @@ import (
 
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/output"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -26,11 +27,30 @@ func getRewards(c *cli.Context) error {
 		return fmt.Errorf("error getting rewards info: %w", err)
 	}
 
+	jsonOutput := cliutils.IsJsonOutput(c)
 	if !rewardsInfoResponse.Registered {
+		if jsonOutput {
+			return cliutils.PrintJson(output.NodeRewards{IntervalsMissingTrees: []uint64{}})
+		}
 		fmt.Printf("This node is not currently registered.\n")
 		return nil
 	}
 
+	// Scripts can't answer prompts, so just report the intervals that are missing tree files
+	if jsonOutput {
+		missingIntervals := []uint64{}
+		for _, intervalInfo := range rewardsInfoResponse.InvalidIntervals {
+			if !intervalInfo.TreeFileExists || !intervalInfo.MerkleRootValid {
+				missingIntervals = append(missingIntervals, intervalInfo.Index)
+			}
+		}
+		rewards, err := rp.NodeRewards()
+		if err != nil {
+			return err
+		}
+		return cliutils.PrintJson(output.NewNodeRewards(&rewards, missingIntervals))
+	}
+
 	// Check for missing Merkle trees with rewards available
 	missingIntervals := []rprewards.IntervalInfo{}
 	invalidIntervals := []rprewards.IntervalInfo{}
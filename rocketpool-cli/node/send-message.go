@@ -51,7 +51,7 @@ func sendMessage(c *cli.Context, toAddressOrENS string, message []byte) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to send a message to %s?", toAddressString))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to send a message to %s?", toAddressString))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -63,7 +63,9 @@ func sendMessage(c *cli.Context, toAddressOrENS string, message []byte) error {
 	}
 
 	fmt.Printf("Sending message to %s...\n", toAddressString)
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
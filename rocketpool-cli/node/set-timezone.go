@@ -40,7 +40,7 @@ func setTimezoneLocation(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to set your timezone?")) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction("Are you sure you want to set your timezone?")) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -52,7 +52,9 @@ func setTimezoneLocation(c *cli.Context) error {
 	}
 
 	fmt.Printf("Setting timezone...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
@@ -39,7 +39,7 @@ func signMessage(c *cli.Context) error {
 
 	message := c.String("message")
 	for message == "" {
-		message = cliutils.Prompt("Please enter the message you want to sign: (EIP-191 personal_sign)", "^.+$", "Please enter the message you want to sign: (EIP-191 personal_sign)")
+		message = cliutils.Prompt("Please enter the message you want to sign: (EIP-191 personal_sign)", "^.+$", "Please enter the message you want to sign: (EIP-191 personal_sign)", "ROCKETPOOL_SIGN_MESSAGE")
 	}
 
 	response, err := rp.SignMessage(message)
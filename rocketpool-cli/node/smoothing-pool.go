@@ -53,7 +53,7 @@ func joinSmoothingPool(c *cli.Context) error {
 	fmt.Printf("%sNOTE: This process will restart your node's validator client.\nYou may miss an attestation if you are currently scheduled to produce one.%s\n\n", colorYellow, colorReset)
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to join the Smoothing Pool?")) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction("Are you sure you want to join the Smoothing Pool?")) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -65,7 +65,9 @@ func joinSmoothingPool(c *cli.Context) error {
 	}
 
 	fmt.Printf("Joining the Smoothing Pool...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return fmt.Errorf("%w\nYour fee recipient will be automatically reset to your node's distributor in a few minutes, and your validator client will restart.", err)
 	}
@@ -117,7 +119,7 @@ func leaveSmoothingPool(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to leave the Smoothing Pool?")) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction("Are you sure you want to leave the Smoothing Pool?")) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -129,7 +131,9 @@ func leaveSmoothingPool(c *cli.Context) error {
 	}
 
 	fmt.Printf("Leaving the Smoothing Pool...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
@@ -51,7 +51,7 @@ func addAddressToStakeRplWhitelist(c *cli.Context, addressOrENS string) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to allow %s to stake RPL for your node?", addressString))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to allow %s to stake RPL for your node?", addressString))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -63,7 +63,9 @@ func addAddressToStakeRplWhitelist(c *cli.Context, addressOrENS string) error {
 	}
 
 	fmt.Printf("Adding address to RPL stake whitelist...\n")
-	cliutils.PrintTransactionHash(rp, response.SetTxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.SetTxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.SetTxHash); err != nil {
 		return err
 	}
@@ -112,7 +114,7 @@ func removeAddressFromStakeRplWhitelist(c *cli.Context, addressOrENS string) err
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to remove %s from your RPL staking whitelist?", addressString))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to remove %s from your RPL staking whitelist?", addressString))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -124,7 +126,9 @@ func removeAddressFromStakeRplWhitelist(c *cli.Context, addressOrENS string) err
 	}
 
 	fmt.Printf("Removing address from RPL stake whitelist...\n")
-	cliutils.PrintTransactionHash(rp, response.SetTxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.SetTxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.SetTxHash); err != nil {
 		return err
 	}
@@ -73,7 +73,7 @@ func nodeStakeRpl(c *cli.Context) error {
 				}
 
 				// Prompt for confirmation
-				if !(c.Bool("yes") || cliutils.Confirm("Do you want to let the new RPL contract interact with your legacy RPL?")) {
+				if !(c.Bool("yes") || cliutils.ConfirmTransaction("Do you want to let the new RPL contract interact with your legacy RPL?")) {
 					fmt.Println("Cancelled.")
 					return nil
 				}
@@ -85,7 +85,9 @@ func nodeStakeRpl(c *cli.Context) error {
 				}
 				hash := response.ApproveTxHash
 				fmt.Printf("Approving legacy RPL for swapping...\n")
-				cliutils.PrintTransactionHash(rp, hash)
+				if err = cliutils.PrintTransactionHash(rp, hash); err != nil {
+					return err
+				}
 				if _, err = rp.WaitForTransaction(hash); err != nil {
 					return err
 				}
@@ -117,7 +119,7 @@ func nodeStakeRpl(c *cli.Context) error {
 			}
 
 			// Prompt for confirmation
-			if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to swap %.6f old RPL for new RPL?", math.RoundDown(eth.WeiToEth(status.AccountBalances.FixedSupplyRPL), 6)))) {
+			if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to swap %.6f old RPL for new RPL?", math.RoundDown(eth.WeiToEth(status.AccountBalances.FixedSupplyRPL), 6)))) {
 				fmt.Println("Cancelled.")
 				return nil
 			}
@@ -129,7 +131,9 @@ func nodeStakeRpl(c *cli.Context) error {
 			}
 
 			fmt.Printf("Swapping old RPL for new RPL...\n")
-			cliutils.PrintTransactionHash(rp, swapResponse.SwapTxHash)
+			if err = cliutils.PrintTransactionHash(rp, swapResponse.SwapTxHash); err != nil {
+				return err
+			}
 			if _, err = rp.WaitForTransaction(swapResponse.SwapTxHash); err != nil {
 				return err
 			}
@@ -223,7 +227,7 @@ func nodeStakeRpl(c *cli.Context) error {
 			fmt.Sprintf("Your entire RPL balance (%.6f RPL)?", math.RoundDown(eth.WeiToEth(&rplBalance), 6)),
 			"A custom amount",
 		}
-		selected, _ := cliutils.Select("Please choose an amount of RPL to stake:", amountOptions)
+		selected, _ := cliutils.Select("Please choose an amount of RPL to stake:", amountOptions, "ROCKETPOOL_STAKE_RPL_OPTION")
 		switch selected {
 		case 0:
 			amountWei = minAmount8
@@ -239,7 +243,7 @@ func nodeStakeRpl(c *cli.Context) error {
 
 		// Prompt for custom amount
 		if amountWei == nil {
-			inputAmount := cliutils.Prompt("Please enter an amount of RPL to stake:", "^\\d+(\\.\\d+)?$", "Invalid amount")
+			inputAmount := cliutils.Prompt("Please enter an amount of RPL to stake:", "^\\d+(\\.\\d+)?$", "Invalid amount", "ROCKETPOOL_STAKE_RPL_AMOUNT")
 			stakeAmount, err := strconv.ParseFloat(inputAmount, 64)
 			if err != nil {
 				return fmt.Errorf("Invalid stake amount '%s': %w", inputAmount, err)
@@ -281,7 +285,7 @@ func nodeStakeRpl(c *cli.Context) error {
 		}
 
 		// Prompt for confirmation
-		if !(c.Bool("yes") || cliutils.Confirm("Do you want to let the staking contract interact with your RPL?")) {
+		if !(c.Bool("yes") || cliutils.ConfirmTransaction("Do you want to let the staking contract interact with your RPL?")) {
 			fmt.Println("Cancelled.")
 			return nil
 		}
@@ -293,7 +297,9 @@ func nodeStakeRpl(c *cli.Context) error {
 		}
 		hash := response.ApproveTxHash
 		fmt.Printf("Approving RPL for staking...\n")
-		cliutils.PrintTransactionHash(rp, hash)
+		if err = cliutils.PrintTransactionHash(rp, hash); err != nil {
+			return err
+		}
 		if _, err = rp.WaitForTransaction(hash); err != nil {
 			return err
 		}
@@ -326,7 +332,7 @@ func nodeStakeRpl(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to stake %.6f RPL? You will not be able to unstake this RPL until you exit your validators and close your minipools, or reach over 150%% collateral!", math.RoundDown(eth.WeiToEth(amountWei), 6)))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to stake %.6f RPL? You will not be able to unstake this RPL until you exit your validators and close your minipools, or reach over 150%% collateral!", math.RoundDown(eth.WeiToEth(amountWei), 6)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -338,7 +344,9 @@ func nodeStakeRpl(c *cli.Context) error {
 	}
 
 	fmt.Printf("Staking RPL...\n")
-	cliutils.PrintTransactionHash(rp, stakeResponse.StakeTxHash)
+	if err = cliutils.PrintTransactionHash(rp, stakeResponse.StakeTxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(stakeResponse.StakeTxHash); err != nil {
 		return err
 	}
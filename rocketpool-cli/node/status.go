@@ -10,7 +10,11 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/types/output"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
@@ -29,16 +33,18 @@ func getStatus(c *cli.Context) error {
 	rp := rocketpool.NewClientFromCtx(c)
 	defer rp.Close()
 
-	// Print what network we're on
-	err := cliutils.PrintNetwork(rp)
+	watchInterval, err := cliutils.GetWatchInterval(c)
 	if err != nil {
 		return err
 	}
 
-	// Get node status
-	status, err := rp.NodeStatus()
-	if err != nil {
-		return err
+	// Print what network we're on
+	jsonOutput := cliutils.IsJsonOutput(c)
+	if !jsonOutput && watchInterval == 0 {
+		err := cliutils.PrintNetwork(rp)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Get the config
@@ -47,6 +53,49 @@ func getStatus(c *cli.Context) error {
 		return fmt.Errorf("Error loading configuration: %w", err)
 	}
 
+	// Keep re-rendering the status with the clients' sync progress
+	if watchInterval != 0 {
+		return cliutils.Watch(watchInterval, func() error {
+			rp.SetClientStatusFlags(false, false)
+			syncStatus, err := rp.NodeSync()
+			if err != nil {
+				return err
+			}
+			cliutils.PrintSyncProgressBars(syncStatus)
+
+			// The sync status was just checked, so the status call can skip checking it again
+			if !rp.SetSyncedClients(syncStatus.EcStatus, syncStatus.BcStatus) {
+				fmt.Println("Waiting for the clients to sync before showing the node's status...")
+				return nil
+			}
+			status, err := rp.NodeStatus()
+			if err != nil {
+				return err
+			}
+			printStatus(cfg, &status)
+			return nil
+		})
+	}
+
+	// Get node status
+	status, err := rp.NodeStatus()
+	if err != nil {
+		return err
+	}
+
+	// Print the status for scripts
+	if jsonOutput {
+		return cliutils.PrintJson(output.NewNodeStatus(&status, cfg.Smartnode.Network.Value.(cfgtypes.Network)))
+	}
+
+	printStatus(cfg, &status)
+	return nil
+
+}
+
+// Print the node's status
+func printStatus(cfg *config.RocketPoolConfig, status *api.NodeStatusResponse) {
+
 	// Account address & balances
 	fmt.Printf("%s=== Account and Balances ===%s\n", colorGreen, colorReset)
 	fmt.Printf(
@@ -289,7 +338,4 @@ func getStatus(c *cli.Context) error {
 		fmt.Println("The node is not registered with Rocket Pool.")
 	}
 
-	// Return
-	return nil
-
 }
@@ -58,7 +58,7 @@ func nodeSwapRpl(c *cli.Context) error {
 		} else {
 
 			// Prompt for custom amount
-			inputAmount := cliutils.Prompt("Please enter an amount of old RPL to swap:", "^\\d+(\\.\\d+)?$", "Invalid amount")
+			inputAmount := cliutils.Prompt("Please enter an amount of old RPL to swap:", "^\\d+(\\.\\d+)?$", "Invalid amount", "ROCKETPOOL_SWAP_AMOUNT")
 			swapAmount, err := strconv.ParseFloat(inputAmount, 64)
 			if err != nil {
 				return fmt.Errorf("Invalid swap amount '%s': %w", inputAmount, err)
@@ -101,7 +101,7 @@ func nodeSwapRpl(c *cli.Context) error {
 		}
 
 		// Prompt for confirmation
-		if !(c.Bool("yes") || cliutils.Confirm("Do you want to let the new RPL contract interact with your legacy RPL?")) {
+		if !(c.Bool("yes") || cliutils.ConfirmTransaction("Do you want to let the new RPL contract interact with your legacy RPL?")) {
 			fmt.Println("Cancelled.")
 			return nil
 		}
@@ -113,7 +113,9 @@ func nodeSwapRpl(c *cli.Context) error {
 		}
 		hash := response.ApproveTxHash
 		fmt.Printf("Approving legacy RPL for swapping...\n")
-		cliutils.PrintTransactionHash(rp, hash)
+		if err = cliutils.PrintTransactionHash(rp, hash); err != nil {
+			return err
+		}
 		if _, err = rp.WaitForTransaction(hash); err != nil {
 			return err
 		}
@@ -145,7 +147,7 @@ func nodeSwapRpl(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to swap %.6f old RPL for new RPL?", math.RoundDown(eth.WeiToEth(amountWei), 6)))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to swap %.6f old RPL for new RPL?", math.RoundDown(eth.WeiToEth(amountWei), 6)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -157,7 +159,9 @@ func nodeSwapRpl(c *cli.Context) error {
 	}
 
 	fmt.Printf("Swapping old RPL for new RPL...\n")
-	cliutils.PrintTransactionHash(rp, swapResponse.SwapTxHash)
+	if err = cliutils.PrintTransactionHash(rp, swapResponse.SwapTxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(swapResponse.SwapTxHash); err != nil {
 		return err
 	}
@@ -17,6 +17,11 @@ var ethClientRecentBlockThreshold, _ = time.ParseDuration("5m")
 
 func printClientStatus(status *api.ClientStatus, name string) {
 
+	// Warn if the client has been failing often enough to trip its circuit breaker
+	if status.Health.CircuitState != "" && status.Health.CircuitState != "closed" {
+		fmt.Printf("Your %s has failed %d times in a row and is being skipped for now (circuit %s, %0.0f%% of the last %d calls failed).\n", name, status.Health.ConsecutiveFailures, status.Health.CircuitState, status.Health.FailureRate*100, status.Health.Requests)
+	}
+
 	if status.Error != "" {
 		fmt.Printf("Your %s is unavailable (%s).\n", name, status.Error)
 		return
@@ -45,6 +50,11 @@ func printSyncProgress(status *api.ClientManagerStatus, name string) {
 
 	// A fallback is enabled, so print fallback client status
 	printClientStatus(&status.FallbackClientStatus, fmt.Sprintf("fallback %s client", name))
+
+	// Print the status of any additional fallbacks
+	for i := range status.AdditionalFallbackStatuses {
+		printClientStatus(&status.AdditionalFallbackStatuses[i], fmt.Sprintf("additional fallback %s client %d", name, i+1))
+	}
 }
 
 func getSyncProgress(c *cli.Context) error {
@@ -163,7 +163,7 @@ func promptTimezone() string {
 	// Handle situations where we couldn't parse any timezone info from the OS
 	if len(countryNames) == 0 {
 		for timezone == "" {
-			timezone = cliutils.Prompt("Please enter a timezone to register with in the format 'Country/City' (use Etc/UTC if you prefer not to answer):", "^([a-zA-Z_]{2,}\\/)+[a-zA-Z_]{2,}$", "Please enter a timezone in the format 'Country/City' (use Etc/UTC if you prefer not to answer)")
+			timezone = cliutils.Prompt("Please enter a timezone to register with in the format 'Country/City' (use Etc/UTC if you prefer not to answer):", "^([a-zA-Z_]{2,}\\/)+[a-zA-Z_]{2,}$", "Please enter a timezone in the format 'Country/City' (use Etc/UTC if you prefer not to answer)", "ROCKETPOOL_TIMEZONE")
 			if !cliutils.Confirm(fmt.Sprintf("You have chosen to register with the timezone '%s', is this correct?", timezone)) {
 				timezone = ""
 			}
@@ -186,7 +186,7 @@ func promptTimezone() string {
 	for {
 		time.Now().Zone()
 		timezone = ""
-		country = cliutils.Prompt("Please enter a country / continent from the list above:", "^.+$", "Please enter a country / continent from the list above:")
+		country = cliutils.Prompt("Please enter a country / continent from the list above:", "^.+$", "Please enter a country / continent from the list above:", "ROCKETPOOL_TIMEZONE_COUNTRY")
 
 		exists := false
 		for _, candidate := range countryNames {
@@ -247,7 +247,7 @@ func promptTimezone() string {
 	for {
 		time.Now().Zone()
 		timezone = ""
-		region = cliutils.Prompt("Please enter a region from the list above:", "^.+$", "Please enter a region from the list above:")
+		region = cliutils.Prompt("Please enter a region from the list above:", "^.+$", "Please enter a region from the list above:", "ROCKETPOOL_TIMEZONE_REGION")
 
 		exists := false
 		for _, candidate := range regionNames {
@@ -291,7 +291,7 @@ func promptMinNodeFee(networkCurrentNodeFee, networkMinNodeFee float64) float64
 	for {
 
 		// Get max slippage
-		maxNodeFeeSlippagePercStr := cliutils.Prompt("Please enter a maximum commission rate slippage % for your deposit:", "^\\d+(\\.\\d+)?$", "Invalid maximum commission rate slippage")
+		maxNodeFeeSlippagePercStr := cliutils.Prompt("Please enter a maximum commission rate slippage % for your deposit:", "^\\d+(\\.\\d+)?$", "Invalid maximum commission rate slippage", "ROCKETPOOL_MAX_NODE_FEE_SLIPPAGE")
 		maxNodeFeeSlippagePerc, _ := strconv.ParseFloat(maxNodeFeeSlippagePercStr, 64)
 		maxNodeFeeSlippage := maxNodeFeeSlippagePerc / 100
 		if maxNodeFeeSlippage < 0 || maxNodeFeeSlippage > 1 {
@@ -358,6 +358,7 @@ func promptForSoloKeyPassword(rp *rocketpool.Client, cfg *config.RocketPoolConfi
 			// Found it, prompt for the password
 			password := cliutils.PromptPassword(
 				fmt.Sprintf("Please enter the password that the keystore for %s was encrypted with:", pubkey.Hex()), "^.*$", "",
+				"ROCKETPOOL_KEYSTORE_PASSWORD",
 			)
 
 			formattedPubkey := strings.ToUpper(hexutils.RemovePrefix(pubkey.Hex()))
@@ -50,7 +50,7 @@ func nodeSetVotingDelegate(c *cli.Context, nameOrAddress string) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want %s to represent your node in Rocket Pool governance proposals?", addressString))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want %s to represent your node in Rocket Pool governance proposals?", addressString))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -62,7 +62,9 @@ func nodeSetVotingDelegate(c *cli.Context, nameOrAddress string) error {
 	}
 
 	fmt.Printf("Setting delegate...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
@@ -95,7 +97,7 @@ func nodeClearVotingDelegate(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you remove your node's current delegate address for voting on governance proposals?")) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction("Are you sure you remove your node's current delegate address for voting on governance proposals?")) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -107,7 +109,9 @@ func nodeClearVotingDelegate(c *cli.Context) error {
 	}
 
 	fmt.Printf("Removing delegate...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
@@ -67,7 +67,7 @@ func nodeWithdrawRpl(c *cli.Context) error {
 			} else {
 
 				// Prompt for custom amount
-				inputAmount := cliutils.Prompt("Please enter an amount of staked RPL to withdraw:", "^\\d+(\\.\\d+)?$", "Invalid amount")
+				inputAmount := cliutils.Prompt("Please enter an amount of staked RPL to withdraw:", "^\\d+(\\.\\d+)?$", "Invalid amount", "ROCKETPOOL_WITHDRAW_AMOUNT")
 				withdrawalAmount, err := strconv.ParseFloat(inputAmount, 64)
 				if err != nil {
 					return fmt.Errorf("Invalid withdrawal amount '%s': %w", inputAmount, err)
@@ -110,7 +110,7 @@ func nodeWithdrawRpl(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to withdraw %.6f staked RPL? This may decrease your node's RPL rewards.", math.RoundDown(eth.WeiToEth(amountWei), 6)))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to withdraw %.6f staked RPL? This may decrease your node's RPL rewards.", math.RoundDown(eth.WeiToEth(amountWei), 6)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -122,7 +122,9 @@ func nodeWithdrawRpl(c *cli.Context) error {
 	}
 
 	fmt.Printf("Withdrawing RPL...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
@@ -67,7 +67,7 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddressOrENS string) error {
 	if confirm {
 		// Prompt for a test transaction
 		if cliutils.Confirm("Would you like to send a test transaction to make sure you have the correct address?") {
-			inputAmount := cliutils.Prompt(fmt.Sprintf("Please enter an amount of ETH to send to %s:", withdrawalAddressString), "^\\d+(\\.\\d+)?$", "Invalid amount")
+			inputAmount := cliutils.Prompt(fmt.Sprintf("Please enter an amount of ETH to send to %s:", withdrawalAddressString), "^\\d+(\\.\\d+)?$", "Invalid amount", "ROCKETPOOL_TEST_AMOUNT")
 			testAmount, err := strconv.ParseFloat(inputAmount, 64)
 			if err != nil {
 				return fmt.Errorf("Invalid test amount '%s': %w\n", inputAmount, err)
@@ -84,7 +84,7 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddressOrENS string) error {
 				return err
 			}
 
-			if !cliutils.Confirm(fmt.Sprintf("Please confirm you want to send %f ETH to %s.", testAmount, withdrawalAddressString)) {
+			if !cliutils.ConfirmTransaction(fmt.Sprintf("Please confirm you want to send %f ETH to %s.", testAmount, withdrawalAddressString)) {
 				fmt.Println("Cancelled.")
 				return nil
 			}
@@ -95,7 +95,9 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddressOrENS string) error {
 			}
 
 			fmt.Printf("Sending ETH to %s...\n", withdrawalAddressString)
-			cliutils.PrintTransactionHash(rp, sendResponse.TxHash)
+			if err = cliutils.PrintTransactionHash(rp, sendResponse.TxHash); err != nil {
+				return err
+			}
 			if _, err = rp.WaitForTransaction(sendResponse.TxHash); err != nil {
 				return err
 			}
@@ -111,7 +113,7 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddressOrENS string) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to set your node's withdrawal address to %s?", withdrawalAddressString))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to set your node's withdrawal address to %s?", withdrawalAddressString))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -123,7 +125,9 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddressOrENS string) error {
 	}
 
 	fmt.Printf("Setting withdrawal address...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
@@ -171,7 +175,7 @@ func confirmWithdrawalAddress(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to confirm your node's address as the new withdrawal address?")) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction("Are you sure you want to confirm your node's address as the new withdrawal address?")) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -183,7 +187,9 @@ func confirmWithdrawalAddress(c *cli.Context) error {
 	}
 
 	fmt.Printf("Confirming new withdrawal address...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
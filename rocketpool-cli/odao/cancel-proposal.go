@@ -79,7 +79,7 @@ func cancelProposal(c *cli.Context) error {
 		for pi, proposal := range cancelableProposals {
 			options[pi] = fmt.Sprintf("proposal %d (message: '%s', payload: %s)", proposal.ID, proposal.Message, proposal.PayloadStr)
 		}
-		selected, _ := cliutils.Select("Please select a proposal to cancel:", options)
+		selected, _ := cliutils.Select("Please select a proposal to cancel:", options, "ROCKETPOOL_PROPOSAL")
 		selectedProposal = cancelableProposals[selected]
 
 	}
@@ -96,7 +96,7 @@ func cancelProposal(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to cancel proposal %d?", selectedProposal.ID))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to cancel proposal %d?", selectedProposal.ID))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -108,7 +108,9 @@ func cancelProposal(c *cli.Context) error {
 	}
 
 	fmt.Printf("Canceling proposal...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
@@ -79,7 +79,7 @@ func executeProposal(c *cli.Context) error {
 		for pi, proposal := range executableProposals {
 			options[pi+1] = fmt.Sprintf("proposal %d (message: '%s', payload: %s)", proposal.ID, proposal.Message, proposal.PayloadStr)
 		}
-		selected, _ := cliutils.Select("Please select a proposal to execute:", options)
+		selected, _ := cliutils.Select("Please select a proposal to execute:", options, "ROCKETPOOL_PROPOSAL")
 
 		// Get proposals
 		if selected == 0 {
@@ -115,7 +115,7 @@ func executeProposal(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to execute %d proposals?", len(selectedProposals)))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to execute %d proposals?", len(selectedProposals)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -129,7 +129,9 @@ func executeProposal(c *cli.Context) error {
 		}
 
 		fmt.Printf("Executing proposal...\n")
-		cliutils.PrintTransactionHash(rp, response.TxHash)
+		if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+			return err
+		}
 		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 			fmt.Printf("Could not execute proposal %d: %s.\n", proposal.ID, err)
 		} else {
@@ -71,7 +71,7 @@ func join(c *cli.Context) error {
 				}
 
 				// Prompt for confirmation
-				if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Do you want to let the new RPL contract interact with your legacy RPL?"))) {
+				if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Do you want to let the new RPL contract interact with your legacy RPL?"))) {
 					fmt.Println("Cancelled.")
 					return nil
 				}
@@ -83,7 +83,9 @@ func join(c *cli.Context) error {
 				}
 				hash := response.ApproveTxHash
 				fmt.Printf("Approving legacy RPL for swapping...\n")
-				cliutils.PrintTransactionHash(rp, hash)
+				if err = cliutils.PrintTransactionHash(rp, hash); err != nil {
+					return err
+				}
 				if _, err = rp.WaitForTransaction(hash); err != nil {
 					return err
 				}
@@ -115,7 +117,7 @@ func join(c *cli.Context) error {
 			}
 
 			// Prompt for confirmation
-			if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to swap %.6f old RPL for new RPL?", math.RoundDown(eth.WeiToEth(status.AccountBalances.FixedSupplyRPL), 6)))) {
+			if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to swap %.6f old RPL for new RPL?", math.RoundDown(eth.WeiToEth(status.AccountBalances.FixedSupplyRPL), 6)))) {
 				fmt.Println("Cancelled.")
 				return nil
 			}
@@ -127,7 +129,9 @@ func join(c *cli.Context) error {
 			}
 
 			fmt.Printf("Swapping old RPL for new RPL...\n")
-			cliutils.PrintTransactionHash(rp, swapResponse.SwapTxHash)
+			if err = cliutils.PrintTransactionHash(rp, swapResponse.SwapTxHash); err != nil {
+				return err
+			}
 			if _, err = rp.WaitForTransaction(swapResponse.SwapTxHash); err != nil {
 				return err
 			}
@@ -171,7 +175,7 @@ func join(c *cli.Context) error {
 	rp.PrintMultiTxWarning()
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to join the oracle DAO? Your RPL bond will be locked until you leave.")) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction("Are you sure you want to join the oracle DAO? Your RPL bond will be locked until you leave.")) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -183,7 +187,9 @@ func join(c *cli.Context) error {
 	}
 	hash := response.ApproveTxHash
 	fmt.Printf("Approving RPL for joining the Oracle DAO...\n")
-	cliutils.PrintTransactionHashNoCancel(rp, hash)
+	if err = cliutils.PrintTransactionHashNoCancel(rp, hash); err != nil {
+		return err
+	}
 
 	// If a custom nonce is set, increment it for the next transaction
 	if c.GlobalUint64("nonce") != 0 {
@@ -196,7 +202,9 @@ func join(c *cli.Context) error {
 		return err
 	}
 	fmt.Printf("Joining the ODAO...\n")
-	cliutils.PrintTransactionHash(rp, joinResponse.JoinTxHash)
+	if err = cliutils.PrintTransactionHash(rp, joinResponse.JoinTxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(joinResponse.JoinTxHash); err != nil {
 		return err
 	}
@@ -50,7 +50,7 @@ func leave(c *cli.Context) error {
 		} else {
 
 			// Prompt for custom address
-			inputAddress := cliutils.Prompt("Please enter the address to refund your RPL bond to:", "^0x[0-9a-fA-F]{40}$", "Invalid address")
+			inputAddress := cliutils.Prompt("Please enter the address to refund your RPL bond to:", "^0x[0-9a-fA-F]{40}$", "Invalid address", "ROCKETPOOL_REFUND_ADDRESS")
 			bondRefundAddress = common.HexToAddress(inputAddress)
 
 		}
@@ -80,7 +80,7 @@ func leave(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to leave the oracle DAO and refund your RPL bond to %s? This action cannot be undone!", bondRefundAddress.Hex()))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to leave the oracle DAO and refund your RPL bond to %s? This action cannot be undone!", bondRefundAddress.Hex()))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -92,7 +92,9 @@ func leave(c *cli.Context) error {
 	}
 
 	fmt.Printf("Leaving oracle DAO...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
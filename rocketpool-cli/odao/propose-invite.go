@@ -43,7 +43,7 @@ func proposeInvite(c *cli.Context, memberAddress common.Address, memberId, membe
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction("Are you sure you want to submit this proposal?")) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -55,7 +55,9 @@ func proposeInvite(c *cli.Context, memberAddress common.Address, memberId, membe
 	}
 
 	fmt.Printf("Inviting %s to the oracle DAO...\n", memberAddress.Hex())
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
@@ -55,7 +55,7 @@ func proposeKick(c *cli.Context) error {
 		for mi, member := range members.Members {
 			options[mi] = fmt.Sprintf("%s (URL: %s, node: %s)", member.ID, member.Url, member.Address)
 		}
-		selected, _ := cliutils.Select("Please select a member to propose kicking:", options)
+		selected, _ := cliutils.Select("Please select a member to propose kicking:", options, "ROCKETPOOL_MEMBER")
 		selectedMember = members.Members[selected]
 
 	}
@@ -79,7 +79,7 @@ func proposeKick(c *cli.Context) error {
 	} else {
 
 		// Prompt for custom amount
-		inputAmount := cliutils.Prompt(fmt.Sprintf("Please enter an RPL fine amount to propose (max %.6f RPL):", math.RoundDown(eth.WeiToEth(selectedMember.RPLBondAmount), 6)), "^\\d+(\\.\\d+)?$", "Invalid amount")
+		inputAmount := cliutils.Prompt(fmt.Sprintf("Please enter an RPL fine amount to propose (max %.6f RPL):", math.RoundDown(eth.WeiToEth(selectedMember.RPLBondAmount), 6)), "^\\d+(\\.\\d+)?$", "Invalid amount", "ROCKETPOOL_FINE_AMOUNT")
 		fineAmount, err := strconv.ParseFloat(inputAmount, 64)
 		if err != nil {
 			return fmt.Errorf("Invalid fine amount '%s': %w", inputAmount, err)
@@ -111,7 +111,7 @@ func proposeKick(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction("Are you sure you want to submit this proposal?")) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -123,7 +123,9 @@ func proposeKick(c *cli.Context) error {
 	}
 
 	fmt.Printf("Kicking %s from the oracle DAO...\n", selectedMember.Address.Hex())
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
@@ -95,7 +95,7 @@ func voteOnProposal(c *cli.Context) error {
 				memberID,
 				proposal.ProposerAddress)
 		}
-		selected, _ := cliutils.Select("Please select a proposal to vote on:", options)
+		selected, _ := cliutils.Select("Please select a proposal to vote on:", options, "ROCKETPOOL_PROPOSAL")
 		selectedProposal = votableProposals[selected]
 
 	}
@@ -144,7 +144,7 @@ func voteOnProposal(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to vote %s proposal %d? Your vote cannot be changed later.", supportLabel, selectedProposal.ID))) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction(fmt.Sprintf("Are you sure you want to vote %s proposal %d? Your vote cannot be changed later.", supportLabel, selectedProposal.ID))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -156,7 +156,9 @@ func voteOnProposal(c *cli.Context) error {
 	}
 
 	fmt.Printf("Submitting vote...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
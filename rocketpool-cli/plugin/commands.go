@@ -0,0 +1,42 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/urfave/cli"
+)
+
+// Register a command for each plugin that doesn't clash with one of the CLI's own commands
+func RegisterCommands(app *cli.App, configPath string) {
+	plugins, err := findPlugins(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: couldn't load CLI plugins: %s\n", err.Error())
+		return
+	}
+
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if app.Command(name) != nil {
+			continue
+		}
+		path := plugins[name]
+		app.Commands = append(app.Commands, cli.Command{
+			Name:            name,
+			Category:        "Plugins",
+			Usage:           fmt.Sprintf("Run the %s plugin (%s)", name, path),
+			UsageText:       fmt.Sprintf("rocketpool %s [plugin arguments...]", name),
+			Description:     fmt.Sprintf("The plugin's role in %s limits the daemon API commands it can run through the socket it's given. It still runs as your user, so it can do anything you can; the role is not a sandbox, so only install plugins you trust.", PluginsFile),
+			SkipFlagParsing: true,
+			Action: func(c *cli.Context) error {
+				return runPlugin(c, name, path, configPath)
+			},
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/apiauth"
+)
+
+const (
+	// The file in the CLI's config directory that registers plugins which aren't on the PATH
+	PluginsFile string = "plugins.yml"
+
+	// The prefix of plugin executables; the rest of the name is the command they're run with
+	pluginPrefix string = "rocketpool-"
+)
+
+// Plugins can only be run with simple command names
+var pluginNamePattern = regexp.MustCompile("^[a-z0-9][a-z0-9_-]*$")
+
+// The Smartnode's own binaries use the plugin prefix, so they're never treated as plugins
+var reservedPrefixes = []string{"cli-", "daemon-"}
+
+// The role plugins get for the daemon API unless the plugins file grants them another one.
+// Roles only limit the API commands a plugin is served; plugins run as the user, so they aren't sandboxed.
+const defaultPluginRole apiauth.Role = apiauth.Role_ReadOnly
+
+// The plugins registered in the config directory, keyed by command name, and the daemon API roles granted to plugins
+type pluginsFile struct {
+	Plugins map[string]string       `yaml:"plugins"`
+	Roles   map[string]apiauth.Role `yaml:"roles"`
+}
+
+// Find the plugins on the PATH and in the plugins file, keyed by command name.
+// Registered plugins take precedence over the PATH, and earlier PATH entries take precedence over later ones.
+func findPlugins(configPath string) (map[string]string, error) {
+	plugins := map[string]string{}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, isPlugin := getPluginName(entry.Name())
+			if !isPlugin {
+				continue
+			}
+			if _, exists := plugins[name]; exists {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if isExecutable(path) {
+				plugins[name] = path
+			}
+		}
+	}
+
+	registered, err := loadRegisteredPlugins(configPath)
+	if err != nil {
+		return nil, err
+	}
+	for name, path := range registered {
+		plugins[name] = path
+	}
+	return plugins, nil
+}
+
+// Get the command name for an executable, if it's a plugin
+func getPluginName(filename string) (string, bool) {
+	if !strings.HasPrefix(filename, pluginPrefix) {
+		return "", false
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(filename, pluginPrefix), ".exe")
+	for _, prefix := range reservedPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return "", false
+		}
+	}
+	return name, pluginNamePattern.MatchString(name)
+}
+
+// Check if a file is a regular file that can be executed
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	return info.Mode().Perm()&0111 != 0 || strings.HasSuffix(path, ".exe")
+}
+
+// Load the plugins file from the CLI's config directory, returning its path; a missing file is empty
+func loadPluginsFile(configPath string) (pluginsFile, string, error) {
+	pluginsPath, err := homedir.Expand(filepath.Join(configPath, PluginsFile))
+	if err != nil {
+		return pluginsFile{}, "", fmt.Errorf("error expanding plugins file path: %w", err)
+	}
+	bytes, err := os.ReadFile(pluginsPath)
+	if os.IsNotExist(err) {
+		return pluginsFile{}, pluginsPath, nil
+	}
+	if err != nil {
+		return pluginsFile{}, "", fmt.Errorf("error reading plugins file [%s]: %w", pluginsPath, err)
+	}
+
+	var file pluginsFile
+	if err := yaml.Unmarshal(bytes, &file); err != nil {
+		return pluginsFile{}, "", fmt.Errorf("error parsing plugins file [%s]: %w", pluginsPath, err)
+	}
+	return file, pluginsPath, nil
+}
+
+// Get the daemon API role a plugin has been granted in the plugins file, which defaults to read-only
+func getPluginRole(configPath string, name string) (apiauth.Role, error) {
+	file, pluginsPath, err := loadPluginsFile(configPath)
+	if err != nil {
+		return "", err
+	}
+	role, exists := file.Roles[name]
+	if !exists {
+		return defaultPluginRole, nil
+	}
+	if !role.IsValid() {
+		return "", fmt.Errorf("invalid role [%s] for plugin [%s] in %s; it must be %s, %s, or %s", role, name, pluginsPath, apiauth.Role_ReadOnly, apiauth.Role_Operator, apiauth.Role_Admin)
+	}
+	return role, nil
+}
+
+// Load the plugins registered in the CLI's config directory; a missing file means there aren't any
+func loadRegisteredPlugins(configPath string) (map[string]string, error) {
+	file, pluginsPath, err := loadPluginsFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	for name, path := range file.Plugins {
+		if !pluginNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid plugin name [%s] in %s", name, pluginsPath)
+		}
+		expandedPath, err := homedir.Expand(path)
+		if err != nil {
+			return nil, fmt.Errorf("error expanding path of plugin [%s]: %w", name, err)
+		}
+		if !isExecutable(expandedPath) {
+			return nil, fmt.Errorf("plugin [%s] in %s is not an executable file: %s", name, pluginsPath, expandedPath)
+		}
+		file.Plugins[name] = expandedPath
+	}
+	return file.Plugins, nil
+}
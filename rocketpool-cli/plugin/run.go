@@ -0,0 +1,174 @@
+package plugin
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/apiauth"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// The environment variables that give plugins access to the daemon API.
+// Plugins are meant to run API commands through the socket, where their role is enforced, rather than through the CLI.
+const (
+	ApiSocketEnvVar  string = "ROCKETPOOL_API_SOCKET"
+	ApiTokenEnvVar   string = "ROCKETPOOL_API_TOKEN"
+	ConfigPathEnvVar string = "ROCKETPOOL_CONFIG_PATH"
+)
+
+// The request a plugin sends to run an API command, e.g. ["node", "status"]
+type apiRequest struct {
+	Args []string `json:"args"`
+}
+
+// The response sent to the plugin if the command couldn't be run at all
+type apiErrorResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+// Run a plugin with the remaining arguments, serving the daemon API to it for as long as it runs
+func runPlugin(c *cli.Context, name string, path string, configPath string) error {
+
+	// Get the API commands the plugin is allowed to run
+	role, err := getPluginRole(configPath, name)
+	if err != nil {
+		return err
+	}
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Create a private directory for the API socket, and a token that only the plugin gets
+	socketDir, err := os.MkdirTemp("", "rocketpool-plugin-")
+	if err != nil {
+		return fmt.Errorf("Error creating plugin API directory: %w", err)
+	}
+	defer os.RemoveAll(socketDir)
+	socketPath := filepath.Join(socketDir, "api.sock")
+	token, err := createToken()
+	if err != nil {
+		return err
+	}
+
+	// Serve the API
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("Error creating plugin API socket: %w", err)
+	}
+	server := &http.Server{
+		Handler: newApiHandler(rp, token, role),
+	}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Shutdown(context.Background())
+
+	// Run the plugin
+	cmd := exec.Command(path, c.Args()...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("%s=%s", ApiSocketEnvVar, socketPath),
+		fmt.Sprintf("%s=%s", ApiTokenEnvVar, token),
+		fmt.Sprintf("%s=%s", ConfigPathEnvVar, os.ExpandEnv(c.GlobalString("config-path"))),
+	)
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return fmt.Errorf("Plugin %s exited with code %d", filepath.Base(path), exitErr.ExitCode())
+	}
+	if err != nil {
+		return fmt.Errorf("Error running plugin %s: %w", path, err)
+	}
+	return nil
+
+}
+
+// Create a random token for authenticating a plugin's API requests
+func createToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("Error creating plugin API token: %w", err)
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// Create the handler that runs a plugin's API commands, one at a time, through the CLI's own client.
+// Commands are only run if the plugin's role allows them, using the same roles as the daemon's API tokens.
+func newApiHandler(rp *rocketpool.Client, token string, role apiauth.Role) http.Handler {
+	var lock sync.Mutex
+	expectedAuth := []byte("Bearer " + token)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expectedAuth) != 1 {
+			http.Error(w, "invalid API token", http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodPost {
+			http.Error(w, "API commands must be sent with POST", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var request apiRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeApiError(w, fmt.Errorf("error decoding request: %w", err))
+			return
+		}
+		if len(request.Args) < 2 {
+			writeApiError(w, fmt.Errorf("API commands must include a command group and a command, e.g. [\"node\", \"status\"]"))
+			return
+		}
+
+		// The group and command must be plain words, so the role check covers the command that actually runs
+		group, command := request.Args[0], request.Args[1]
+		if strings.HasPrefix(group, "-") || strings.HasPrefix(command, "-") || strings.ContainsAny(group+command, " \t\n") {
+			writeApiError(w, fmt.Errorf("invalid API command [%s %s]", group, command))
+			return
+		}
+		requiredRole := apiauth.GetCommandRole(group, command)
+		if !role.Allows(requiredRole) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			writeApiError(w, fmt.Errorf("the '%s %s' command requires the %s role, but this plugin only has the %s role; grant it a role in the roles section of %s to allow it", group, command, requiredRole, role, PluginsFile))
+			return
+		}
+
+		lock.Lock()
+		response, err := rp.CallAPI(request.Args)
+		lock.Unlock()
+		if err != nil {
+			writeApiError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(response)
+	})
+	return mux
+}
+
+// Write a response in the same format as the daemon's errors
+func writeApiError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(apiErrorResponse{
+		Status: "error",
+		Error:  err.Error(),
+	})
+}
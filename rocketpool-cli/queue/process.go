@@ -39,7 +39,7 @@ func processQueue(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Do you accept this gas fee?")) {
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction("Do you accept this gas fee?")) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -51,7 +51,9 @@ func processQueue(c *cli.Context) error {
 	}
 
 	fmt.Printf("Processing queue...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
@@ -8,12 +9,16 @@ import (
 	"github.com/mitchellh/go-homedir"
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/rocketpool-cli/alias"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/auction"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/completion"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/faucet"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/fleet"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/minipool"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/network"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/node"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/odao"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/plugin"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/queue"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/service"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
@@ -43,6 +48,7 @@ ______           _        _    ______           _
 	// Set application info
 	app.Name = "rocketpool"
 	app.Usage = "Rocket Pool CLI"
+	app.EnableBashCompletion = true
 	app.Version = shared.RocketPoolVersion
 	app.Authors = []cli.Author{
 		{
@@ -78,6 +84,11 @@ ______           _        _    ______           _
 			Usage: "Rocket Pool config asset `path`",
 			Value: "~/.rocketpool",
 		},
+		cli.StringFlag{
+			Name:   "profile",
+			Usage:  "Use the named config `profile` (such as 'holesky'), which has its own config and data directory under the config path, its own containers, and defaults to the network it's named after",
+			EnvVar: "ROCKETPOOL_PROFILE",
+		},
 		cli.StringFlag{
 			Name:  "daemon-path, d",
 			Usage: "Interact with a Rocket Pool service daemon at a `path` on the host OS, running outside of docker",
@@ -98,10 +109,35 @@ ______           _        _    ______           _
 			Name:  "nonce",
 			Usage: "Use this flag to explicitly specify the nonce that this transaction should use, so it can override an existing 'stuck' transaction",
 		},
+		cli.BoolFlag{
+			Name:  "unsigned",
+			Usage: "Create transactions without signing or submitting them, so they can be signed on an offline machine with 'rocketpool wallet sign-tx' and submitted with 'rocketpool wallet send-raw'",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Simulate transactions against the latest block and print their effects and gas usage, without prompting to confirm them or submitting them; other prompts are still asked",
+		},
+		cli.BoolFlag{
+			Name:   "non-interactive, yes",
+			Usage:  "Never prompt for input: confirmations are accepted automatically, and any other answers are read from their ROCKETPOOL_* environment variables",
+			EnvVar: "ROCKETPOOL_NON_INTERACTIVE",
+		},
+		cli.StringFlag{
+			Name:  "state-file",
+			Usage: "Run read-only commands against a state snapshot exported with 'rocketpool node export-state' instead of the node's clients",
+		},
+		cli.Float64Flag{
+			Name:  "submit-below",
+			Usage: "Sign transactions with this max fee (in gwei) and have the node daemon submit them once the network's fees drop low enough for it, instead of submitting them now",
+		},
 		cli.BoolFlag{
 			Name:  "debug",
 			Usage: "Enable debug printing of API commands",
 		},
+		cli.BoolFlag{
+			Name:  "json",
+			Usage: "Print the output of status commands (node status, node rewards, minipool status, network stats) as JSON for scripts; notices are printed to stderr",
+		},
 		cli.BoolFlag{
 			Name: "secure-session, s",
 			Usage: "Some commands may print sensitive information to your terminal. " +
@@ -111,6 +147,7 @@ ______           _        _    ______           _
 
 	// Register commands
 	auction.RegisterCommands(app, "auction", []string{"a"})
+	completion.RegisterCommands(app, "completion", []string{})
 
 	// Get the config path from the arguments (or use the default)
 	configPath := "~/.rocketpool"
@@ -124,6 +161,26 @@ ______           _        _    ______           _
 		}
 	}
 
+	// Profiles have their own config directory under the base one
+	profile := os.Getenv("ROCKETPOOL_PROFILE")
+	for index, arg := range os.Args {
+		if arg == "--profile" {
+			if len(os.Args)-1 == index {
+				fmt.Fprintf(os.Stderr, "Expected profile name after %s but none was given.\n", arg)
+				os.Exit(1)
+			}
+			profile = os.Args[index+1]
+		}
+	}
+	if profile != "" {
+		profileConfigPath, err := rocketpool.GetProfileConfigPath(configPath, profile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		configPath = profileConfigPath
+	}
+
 	// Get and parse the config file
 	configFile := fmt.Sprintf("%s/%s", configPath, rocketpool.SettingsFile)
 	expandedPath, err := homedir.Expand(configFile)
@@ -132,6 +189,7 @@ ______           _        _    ______           _
 		os.Exit(1)
 	}
 	// Stop if the config file doesn't exist yet
+	var aliases map[string]string
 	_, err = os.Stat(expandedPath)
 	if !os.IsNotExist(err) {
 		cfg, err := rp.LoadConfigFromFile(expandedPath)
@@ -144,6 +202,7 @@ ______           _        _    ______           _
 		if cfg.Smartnode.GetRplFaucetAddress() != "" {
 			faucet.RegisterCommands(app, "faucet", []string{"f"})
 		}
+		aliases = cfg.Aliases
 	}
 
 	minipool.RegisterCommands(app, "minipool", []string{"m"})
@@ -153,6 +212,17 @@ ______           _        _    ______           _
 	queue.RegisterCommands(app, "queue", []string{"q"})
 	service.RegisterCommands(app, "service", []string{"s"})
 	wallet.RegisterCommands(app, "wallet", []string{"w"})
+	fleet.RegisterCommands(app, "fleet", []string{"l"}, configPath)
+
+	// Plugins are registered last so they can't replace any of the built-in commands
+	plugin.RegisterCommands(app, configPath)
+
+	// Expand the user's command aliases before dispatching
+	args, err := alias.ExpandAliases(app, os.Args, aliases)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to expand command aliases: %s\n", err.Error())
+		os.Exit(1)
+	}
 
 	app.Before = func(c *cli.Context) error {
 		// Check user ID
@@ -162,6 +232,18 @@ ______           _        _    ______           _
 			os.Exit(1)
 		}
 
+		// Point the config path at the profile's directory so everything that uses it picks up the profile
+		if profile := c.GlobalString("profile"); profile != "" {
+			profileConfigPath, err := rocketpool.GetProfileConfigPath(c.GlobalString("config-path"), profile)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			if err := c.GlobalSet("config-path", profileConfigPath); err != nil {
+				return fmt.Errorf("error setting the config path for profile %s: %w", profile, err)
+			}
+		}
+
 		// If set, validate custom nonce
 		customNonce := c.GlobalString("nonce")
 		if customNonce != "" {
@@ -175,12 +257,45 @@ ______           _        _    ______           _
 			c.App.Metadata["nonce"] = nonce
 		}
 
+		// Deferred transactions are signed now and submitted by the daemon, so they can't be combined with the other transaction modes
+		if c.GlobalFloat64("submit-below") < 0 {
+			fmt.Fprintln(os.Stderr, "The --submit-below fee must be greater than zero.")
+			os.Exit(1)
+		}
+		if c.GlobalFloat64("submit-below") > 0 && (c.GlobalBool("unsigned") || c.GlobalBool("dry-run") || c.GlobalFloat64("maxFee") != 0) {
+			fmt.Fprintln(os.Stderr, "--submit-below can't be used with --unsigned, --dry-run or --maxFee.")
+			os.Exit(1)
+		}
+
+		if c.GlobalString("state-file") != "" && (c.GlobalBool("unsigned") || c.GlobalBool("dry-run") || c.GlobalFloat64("submit-below") != 0) {
+			fmt.Fprintln(os.Stderr, "--state-file is for read-only commands, so it can't be used with --unsigned, --dry-run or --submit-below.")
+			os.Exit(1)
+		}
+
+		// Dry runs don't submit anything, so their transactions don't need to be confirmed
+		cliutils.SetDryRun(c.GlobalBool("dry-run"))
+		cliutils.SetNonInteractive(c.GlobalBool("non-interactive"))
+
 		return nil
 	}
 
-	// Run application
+	// Shell completions are printed as-is, one per line
+	if len(os.Args) > 0 && os.Args[len(os.Args)-1] == "--generate-bash-completion" {
+		_ = app.Run(os.Args)
+		return
+	}
+
+	// Run application; JSON output is printed as-is, so scripts can parse it.
+	// Commands stop at transactions that weren't submitted, which isn't an error.
+	if cliutils.IsJsonOutputRequested(args) {
+		if err := app.Run(args); err != nil && !errors.Is(err, cliutils.ErrTransactionNotSubmitted) {
+			cliutils.PrintJsonError(err)
+			os.Exit(1)
+		}
+		return
+	}
 	fmt.Println("")
-	if err := app.Run(os.Args); err != nil {
+	if err := app.Run(args); err != nil && !errors.Is(err, cliutils.ErrTransactionNotSubmitted) {
 		cliutils.PrettyPrintError(err)
 	}
 	fmt.Println("")
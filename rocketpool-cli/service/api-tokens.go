@@ -0,0 +1,127 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/apiauth"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Get the path of the API tokens file in the data folder
+func getApiTokensPath(c *cli.Context) (string, error) {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	if isNew {
+		return "", fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	dataPath, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return "", fmt.Errorf("error expanding data path: %w", err)
+	}
+	return filepath.Join(dataPath, config.ApiTokensFilename), nil
+
+}
+
+// Create a new API token with the provided role
+func createApiToken(c *cli.Context, name string) error {
+
+	role := apiauth.Role(c.String("role"))
+	if !role.IsValid() {
+		return fmt.Errorf("Invalid role '%s'; supported roles are '%s', '%s' and '%s'.", role, apiauth.Role_ReadOnly, apiauth.Role_Operator, apiauth.Role_Admin)
+	}
+
+	path, err := getApiTokensPath(c)
+	if err != nil {
+		return err
+	}
+	tokens, err := apiauth.LoadTokens(path)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if token.Name == name {
+			return fmt.Errorf("There is already an API token named '%s'.", name)
+		}
+	}
+
+	token, secret, err := apiauth.NewToken(name, role)
+	if err != nil {
+		return err
+	}
+	if err := apiauth.SaveTokens(path, append(tokens, token)); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created the %s API token '%s':\n\n%s\n\n", role, name, secret)
+	fmt.Printf("%sThis is the only time the token will be shown, so store it somewhere safe. Send it as a bearer token to the HTTP or gRPC API ports.%s\n", colorYellow, colorReset)
+	return nil
+
+}
+
+// List the API tokens
+func listApiTokens(c *cli.Context) error {
+
+	path, err := getApiTokensPath(c)
+	if err != nil {
+		return err
+	}
+	tokens, err := apiauth.LoadTokens(path)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		fmt.Println("There are no API tokens. Create one with `rocketpool service api-tokens create`.")
+		return nil
+	}
+
+	fmt.Printf("%-24s %-10s %s\n", "Name", "Role", "Created")
+	for _, token := range tokens {
+		fmt.Printf("%-24s %-10s %s\n", token.Name, token.Role, token.Created.Format("2006-01-02 15:04:05 MST"))
+	}
+	return nil
+
+}
+
+// Revoke an API token
+func revokeApiToken(c *cli.Context, name string) error {
+
+	path, err := getApiTokensPath(c)
+	if err != nil {
+		return err
+	}
+	tokens, err := apiauth.LoadTokens(path)
+	if err != nil {
+		return err
+	}
+
+	remainingTokens := []apiauth.Token{}
+	for _, token := range tokens {
+		if token.Name != name {
+			remainingTokens = append(remainingTokens, token)
+		}
+	}
+	if len(remainingTokens) == len(tokens) {
+		return fmt.Errorf("There is no API token named '%s'.", name)
+	}
+	if err := apiauth.SaveTokens(path, remainingTokens); err != nil {
+		return err
+	}
+
+	fmt.Printf("Revoked the API token '%s'.\n", name)
+	return nil
+
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alessio/shellescape"
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Settings
+const (
+	ageBin                 string = "age"
+	gpgBin                 string = "gpg"
+	ageBackupExtension     string = ".age"
+	gpgBackupExtension     string = ".gpg"
+	backupFilenamePattern  string = "rocketpool-backup-%d.tar.gz%s"
+	downloadedBackupPrefix string = "rocketpool-backup-*"
+)
+
+// The files in the data folder that make up a backup, if they exist
+var backupDataFiles = []string{
+	"wallet",
+	"password",
+	"validators",
+	config.WalletsFolder,
+}
+
+// Create an encrypted backup of the node wallet, validator keys, slashing protection and user settings
+func backupNode(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Get the encryption tool
+	extension, err := checkEncryptionTool(c)
+	if err != nil {
+		return err
+	}
+
+	// Find the files to back up
+	dataPath, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return fmt.Errorf("error expanding data path: %w", err)
+	}
+	dataFiles := []string{}
+	for _, file := range backupDataFiles {
+		if _, err := os.Stat(filepath.Join(dataPath, file)); err == nil {
+			dataFiles = append(dataFiles, file)
+		}
+	}
+	if len(dataFiles) == 0 || dataFiles[0] != "wallet" {
+		return fmt.Errorf("The node wallet hasn't been initialized yet, so there is nothing to back up.")
+	}
+	if cfg.Smartnode.UsePasswordKeychain.Value == true {
+		fmt.Printf("%sYour node wallet's password is stored in your OS keychain, so it won't be included in the backup. You will need it to restore the backup.%s\n\n", colorYellow, colorReset)
+	}
+
+	// Export the slashing protection for the node's validators
+	if managesValidatorClient(cfg) {
+		fmt.Println("This will briefly stop your Validator client to export its slashing protection database into the backup.")
+		fmt.Printf("%sYour validators will miss attestations while the Validator client is stopped.%s\n\n", colorYellow, colorReset)
+		if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to create the backup?")) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+	protectionPath, err := exportSlashingProtectionFile(rp, cfg, true)
+	if err != nil {
+		return err
+	}
+	if protectionPath == "" {
+		if !(c.Bool("yes") || cliutils.Confirm("Would you like to create the backup without slashing protection?")) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	} else {
+		protectionFile, err := filepath.Rel(dataPath, protectionPath)
+		if err != nil {
+			return fmt.Errorf("error getting slashing protection path: %w", err)
+		}
+		dataFiles = append(dataFiles, protectionFile)
+	}
+
+	// Get the output path
+	outputPath := c.String("output")
+	if outputPath == "" {
+		outputPath = fmt.Sprintf(backupFilenamePattern, time.Now().Unix(), extension)
+	}
+	outputPath, err = filepath.Abs(outputPath)
+	if err != nil {
+		return fmt.Errorf("error getting backup path: %w", err)
+	}
+
+	// Create the backup
+	encryptCmd := getEncryptCommand(c, outputPath)
+	fmt.Printf("Backing up %s and %s...\n", rocketpool.SettingsFile, strings.Join(dataFiles, ", "))
+	err = rp.CreateBackup(dataPath, dataFiles, encryptCmd)
+	if err != nil {
+		return fmt.Errorf("error creating backup: %w", err)
+	}
+	fmt.Printf("%sYour encrypted backup has been saved to %s.%s\n", colorGreen, outputPath, colorReset)
+
+	// Upload it
+	uploadUrl := c.String("upload-url")
+	if uploadUrl != "" {
+		fmt.Println("Uploading backup...")
+		err = rocketpool.UploadBackup(outputPath, uploadUrl)
+		if err != nil {
+			return fmt.Errorf("the backup was saved locally but could not be uploaded: %w", err)
+		}
+		fmt.Printf("%sYour backup has been uploaded.%s\n", colorGreen, colorReset)
+	}
+
+	fmt.Printf("\n%sAnyone who can decrypt this backup has full control of your node wallet and validators. Keep your decryption key or passphrase somewhere safe and separate from the backup.%s\n", colorYellow, colorReset)
+	return nil
+
+}
+
+// Restore an encrypted backup onto this machine
+func restoreNode(c *cli.Context, source string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Don't overwrite an existing node
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if !isNew {
+		if _, err := os.Stat(cfg.Smartnode.GetWalletPathInCLI()); err == nil {
+			return fmt.Errorf("This machine already has a node wallet. Backups can only be restored onto a machine without one.")
+		}
+		fmt.Printf("%sThis machine already has Smartnode settings; they will be replaced with the settings in the backup.%s\n", colorYellow, colorReset)
+	}
+
+	// Download the backup if necessary
+	backupPath, cleanup, err := getBackupFile(source)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	// Build the decryption command
+	decryptCmd, err := getDecryptCommand(c, source, backupPath)
+	if err != nil {
+		return err
+	}
+
+	// Extract it
+	fmt.Println("Decrypting backup...")
+	backupCfg, err := rp.ExtractBackup(decryptCmd)
+	if err != nil {
+		return fmt.Errorf("error extracting backup: %w", err)
+	}
+	dataPath := backupCfg.Smartnode.DataPath.Value.(string)
+
+	fmt.Printf("The backup will restore your settings and put your node wallet and validator keys in %s.\n", dataPath)
+	fmt.Printf("%sMake sure your old machine is shut down and will never be started again before you start your Smartnode here, or your validators will be slashed!%s\n\n", colorRed, colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm("Would you like to restore the backup?")) {
+		fmt.Println("Cancelled.")
+		return rp.DeleteBackupStaging()
+	}
+
+	// Restore it
+	err = rp.RestoreBackupFiles(dataPath)
+	if err != nil {
+		return fmt.Errorf("error restoring backup: %w", err)
+	}
+
+	// The password isn't in the backup if it was stored in the OS keychain
+	err = restoreKeychainPassword(rp, backupCfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%sYour backup has been restored.%s\n", colorGreen, colorReset)
+	fmt.Printf("The slashing protection exported with the backup is in %s. Import it into your Validator client before it starts validating if your client supports it.\n", filepath.Join(dataPath, config.KeyExportsFolder))
+	fmt.Println("Please run `rocketpool service start` to start your Smartnode.")
+	return nil
+
+}
+
+// Make sure the selected encryption tool is installed, and get the extension for files it encrypts
+func checkEncryptionTool(c *cli.Context) (string, error) {
+	encryptionBin := ageBin
+	extension := ageBackupExtension
+	if c.Bool("gpg") {
+		encryptionBin = gpgBin
+		extension = gpgBackupExtension
+	}
+	if _, err := exec.LookPath(encryptionBin); err != nil {
+		return "", fmt.Errorf("`%s` is required to encrypt the backup but it isn't installed. Please install it and try again.", encryptionBin)
+	}
+	return extension, nil
+}
+
+// Build the command that encrypts an archive to the given path, using a passphrase if there's no recipient
+func getEncryptCommand(c *cli.Context, outputPath string) string {
+	recipient := c.String("recipient")
+	switch {
+	case !c.Bool("gpg") && recipient != "":
+		return fmt.Sprintf("%s -r %s -o %s", ageBin, shellescape.Quote(recipient), shellescape.Quote(outputPath))
+	case !c.Bool("gpg"):
+		fmt.Println("No recipient was provided, so you'll be asked for a passphrase to encrypt the backup with.")
+		return fmt.Sprintf("%s -p -o %s", ageBin, shellescape.Quote(outputPath))
+	case recipient != "":
+		return fmt.Sprintf("%s --encrypt --recipient %s --output %s", gpgBin, shellescape.Quote(recipient), shellescape.Quote(outputPath))
+	default:
+		fmt.Println("No recipient was provided, so you'll be asked for a passphrase to encrypt the backup with.")
+		return fmt.Sprintf("%s --symmetric --cipher-algo AES256 --output %s", gpgBin, shellescape.Quote(outputPath))
+	}
+}
+
+// Get a local copy of a backup, downloading it if the source is a URL. The returned function deletes any downloaded copy.
+func getBackupFile(source string) (string, func(), error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return source, func() {}, nil
+	}
+
+	file, err := os.CreateTemp("", downloadedBackupPrefix)
+	if err != nil {
+		return "", nil, fmt.Errorf("error creating temporary file: %w", err)
+	}
+	file.Close()
+	backupPath := file.Name()
+	cleanup := func() {
+		os.Remove(backupPath)
+	}
+
+	fmt.Println("Downloading backup...")
+	err = rocketpool.DownloadBackup(source, backupPath)
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return backupPath, cleanup, nil
+}
+
+// Build the command that decrypts a backup, using GPG if it was requested or the backup's name suggests it
+func getDecryptCommand(c *cli.Context, source string, backupPath string) (string, error) {
+	if strings.HasSuffix(source, gpgBackupExtension) || c.Bool("gpg") {
+		if _, err := exec.LookPath(gpgBin); err != nil {
+			return "", fmt.Errorf("`%s` is required to decrypt the backup but it isn't installed. Please install it and try again.", gpgBin)
+		}
+		return fmt.Sprintf("%s --decrypt %s", gpgBin, shellescape.Quote(backupPath)), nil
+	}
+
+	if _, err := exec.LookPath(ageBin); err != nil {
+		return "", fmt.Errorf("`%s` is required to decrypt the backup but it isn't installed. Please install it and try again.", ageBin)
+	}
+	decryptCmd := fmt.Sprintf("%s -d", ageBin)
+	if c.String("identity") != "" {
+		decryptCmd += fmt.Sprintf(" -i %s", shellescape.Quote(c.String("identity")))
+	}
+	return decryptCmd + " " + shellescape.Quote(backupPath), nil
+}
+
+// Ask for the node wallet's password if it was stored in the old machine's OS keychain, since it isn't part of backups then
+func restoreKeychainPassword(rp *rocketpool.Client, backupCfg *config.RocketPoolConfig) error {
+	if backupCfg.Smartnode.UsePasswordKeychain.Value != true {
+		return nil
+	}
+	fmt.Println("Your node wallet's password was stored in your old machine's OS keychain, so it isn't part of the backup.")
+	password := cliutils.PromptPassword(
+		"Please enter your node wallet's password:",
+		fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
+		fmt.Sprintf("Your password must be at least %d characters long. Please try again:", passwords.MinPasswordLength),
+		"ROCKETPOOL_PASSWORD",
+	)
+	if _, err := rp.SetPassword(password); err != nil {
+		return fmt.Errorf("error saving node wallet password: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon/client"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Returned when the checkpoint sync provider disagrees with the verification node
+var errCheckpointMismatch = errors.New("checkpoint sync provider does not match the verification node")
+
+// Cross-check the checkpoint sync provider against a second Beacon node before the locally-managed Consensus client syncs from it.
+// Returns an error if the client shouldn't be started.
+func verifyCheckpointSyncProvider(c *cli.Context, cfg *config.RocketPoolConfig) error {
+
+	providerUrl := cfg.ConsensusCommon.CheckpointSyncProvider.Value.(string)
+	if providerUrl == "" {
+		return nil
+	}
+
+	verificationUrl := cfg.GetCheckpointSyncVerificationUrl()
+	if verificationUrl == "" {
+		fmt.Printf("%sWARNING: You don't have a Checkpoint Sync Verification URL or a fallback Consensus client configured, so the state served by your checkpoint sync provider can't be verified.\nYour Consensus client will trust it as-is.%s\n\n", colorYellow, colorReset)
+		return nil
+	}
+
+	fmt.Printf("Verifying your checkpoint sync provider against %s...\n", verificationUrl)
+	slot, err := checkCheckpointSyncProvider(providerUrl, verificationUrl, cfg.Smartnode.GetCallPolicies())
+	if errors.Is(err, errCheckpointMismatch) {
+		fmt.Printf("%sThe finalized state served by your checkpoint sync provider does NOT match the one served by your verification node:\n\t%s\n", colorRed, err.Error())
+		fmt.Printf("Your checkpoint sync provider may be compromised. Your Consensus client will not be started.\nPlease choose a different provider with `rocketpool service config`.%s\n", colorReset)
+		return err
+	}
+	if err != nil {
+		fmt.Printf("%sWARNING: Couldn't verify your checkpoint sync provider:\n\t%s%s\n", colorYellow, err.Error(), colorReset)
+		if !(c.Bool("yes") || cliutils.Confirm("Would you like to start your Consensus client with the unverified checkpoint sync provider anyway?")) {
+			return fmt.Errorf("checkpoint sync provider couldn't be verified: %w", err)
+		}
+		return nil
+	}
+
+	fmt.Printf("%sYour checkpoint sync provider's finalized block (slot %d) matches your verification node.%s\n\n", colorGreen, slot, colorReset)
+	return nil
+
+}
+
+// Compare the finalized block header served by the checkpoint sync provider with the one the verification node has at the same slot.
+// Returns the slot that was checked.
+func checkCheckpointSyncProvider(providerUrl string, verificationUrl string, policies map[cfgtypes.CallClass]cfgtypes.CallPolicy) (uint64, error) {
+
+	provider := client.NewStandardHttpClient(providerUrl)
+	provider.SetCallPolicies(policies)
+	verifier := client.NewStandardHttpClient(verificationUrl)
+	verifier.SetCallPolicies(policies)
+
+	// Get the provider's finalized header
+	providerHeader, exists, err := provider.GetBeaconBlockHeader("finalized")
+	if err != nil {
+		return 0, fmt.Errorf("error getting finalized block header from the checkpoint sync provider: %w", err)
+	}
+	if !exists {
+		return 0, fmt.Errorf("the checkpoint sync provider did not return a finalized block header")
+	}
+	slot := uint64(providerHeader.Data.Header.Message.Slot)
+
+	// Get the verifier's header at the same slot
+	verifierHeader, exists, err := verifier.GetBeaconBlockHeader(strconv.FormatUint(slot, 10))
+	if err != nil {
+		return 0, fmt.Errorf("error getting block header for slot %d from the verification node: %w", slot, err)
+	}
+	if !exists {
+		// The slot is either empty on the verifier's chain, or the verifier hasn't finalized it yet
+		verifierFinalized, finalizedExists, err := verifier.GetBeaconBlockHeader("finalized")
+		if err != nil {
+			return 0, fmt.Errorf("error getting finalized block header from the verification node: %w", err)
+		}
+		if !finalizedExists || uint64(verifierFinalized.Data.Header.Message.Slot) < slot {
+			return 0, fmt.Errorf("the verification node has not finalized slot %d yet", slot)
+		}
+		return 0, fmt.Errorf("%w: the provider has a finalized block at slot %d but the verification node has none", errCheckpointMismatch, slot)
+	}
+
+	// Compare the block and state roots
+	if !bytes.Equal(providerHeader.Data.Root, verifierHeader.Data.Root) {
+		return 0, fmt.Errorf("%w: block root at slot %d is %x on the provider but %x on the verification node", errCheckpointMismatch, slot, []byte(providerHeader.Data.Root), []byte(verifierHeader.Data.Root))
+	}
+	if !bytes.Equal(providerHeader.Data.Header.Message.StateRoot, verifierHeader.Data.Header.Message.StateRoot) {
+		return 0, fmt.Errorf("%w: state root at slot %d is %x on the provider but %x on the verification node", errCheckpointMismatch, slot, []byte(providerHeader.Data.Header.Message.StateRoot), []byte(verifierHeader.Data.Header.Message.StateRoot))
+	}
+
+	return slot, nil
+
+}
@@ -126,13 +126,26 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					},
 					cli.StringFlag{
 						Name:  "path, p",
-						Usage: "A custom path to install Rocket Pool to",
+						Usage: "A custom path to install Rocket Pool to (or to write the native services to, with --native)",
 					},
 					cli.StringFlag{
 						Name:  "version, v",
 						Usage: "The smart node package version to install",
 						Value: fmt.Sprintf("v%s", shared.RocketPoolVersion),
 					},
+					cli.BoolFlag{
+						Name:  "native",
+						Usage: "Generate systemd units, an environment file, start scripts, and folders for running the node daemon and clients without Docker, using your current settings",
+					},
+					cli.StringFlag{
+						Name:  "daemon-bin",
+						Usage: "The path the native services run rocketpoold from (with --native)",
+						Value: defaultNativeDaemonBin,
+					},
+					cli.StringFlag{
+						Name:  "user",
+						Usage: "The account the native services run as (with --native); defaults to the current user",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -150,9 +163,12 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			{
 				Name:      "config",
 				Aliases:   []string{"c"},
-				Usage:     "Configure the Rocket Pool service",
-				UsageText: "rocketpool service config",
-				Flags:     configFlags,
+				Usage:     "Configure the Rocket Pool service; every setting can also be overridden with a " + config.SettingEnvVarPrefix + "<SECTION>_<SETTING> environment variable",
+				UsageText: "rocketpool service config [options]",
+				Flags: append(configFlags, cli.BoolFlag{
+					Name:  "check",
+					Usage: "Print the effective configuration (defaults, then the settings file, then environment variables, then flags) and validate it without saving anything",
+				}),
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -166,6 +182,57 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "check-config",
+				Usage:     "Check your settings for problems (such as port conflicts, incompatible clients, or an incorrect fee recipient) and make sure your clients can be reached, before restarting anything",
+				UsageText: "rocketpool service check-config",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return diagnoseConfig(c)
+
+				},
+			},
+
+			{
+				Name:      "upgrade-config",
+				Usage:     "Upgrade your settings file to this Smartnode version, showing exactly what changes, or roll back the last upgrade",
+				UsageText: "rocketpool service upgrade-config [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Show what would change without modifying the settings file",
+					},
+					cli.BoolFlag{
+						Name:  "rollback",
+						Usage: "Restore the settings file from before the last upgrade",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the upgrade or rollback",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if c.Bool("dry-run") && c.Bool("rollback") {
+						return fmt.Errorf("--dry-run and --rollback can't be used together")
+					}
+
+					// Run command
+					return upgradeConfig(c)
+
+				},
+			},
+
 			{
 				Name:      "status",
 				Aliases:   []string{"u"},
@@ -262,14 +329,26 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			{
 				Name:      "logs",
 				Aliases:   []string{"l"},
-				Usage:     "View the Rocket Pool service logs",
+				Usage:     "View the Rocket Pool service logs, interleaving the logs of several services",
 				UsageText: "rocketpool service logs [options] [services...]",
 				Flags: []cli.Flag{
 					cli.StringFlag{
 						Name:  "tail, t",
-						Usage: "The number of lines to show from the end of the logs (number or \"all\")",
+						Usage: "The number of lines to show from the end of each service's logs (number or \"all\")",
 						Value: "100",
 					},
+					cli.StringFlag{
+						Name:  "since, s",
+						Usage: "Only show lines after a timestamp (e.g. 2023-10-01T12:00:00Z) or a relative time (e.g. 30m)",
+					},
+					cli.StringFlag{
+						Name:  "filter, f",
+						Usage: "Only show lines that match this regular expression (e.g. \"(?i)error|warn\")",
+					},
+					cli.BoolFlag{
+						Name:  "no-follow, n",
+						Usage: "Print the logs and exit instead of waiting for new lines",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -468,6 +547,277 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "detect-external-clients",
+				Usage:     "Probe your externally-managed Execution and Consensus clients, identify them, and update your hybrid mode settings to match",
+				UsageText: "rocketpool service detect-external-clients [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "ec-url",
+						Usage: "The URL of your Execution client's HTTP API (defaults to the configured URL)",
+					},
+					cli.StringFlag{
+						Name:  "cc-url",
+						Usage: "The URL of your Consensus client's HTTP API (defaults to the configured URL)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically save the detected settings",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return detectExternalClients(c)
+
+				},
+			},
+
+			{
+				Name:      "backup",
+				Usage:     "Create an encrypted backup of your node wallet, validator keys, slashing protection and settings",
+				UsageText: "rocketpool service backup [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The path to save the backup to (default: rocketpool-backup-<timestamp>.tar.gz.age in the current folder)",
+					},
+					cli.StringFlag{
+						Name:  "recipient, r",
+						Usage: "The age public key or GPG key ID to encrypt the backup for (default: prompt for a passphrase)",
+					},
+					cli.BoolFlag{
+						Name:  "gpg",
+						Usage: "Encrypt the backup with GPG instead of age",
+					},
+					cli.StringFlag{
+						Name:  "upload-url",
+						Usage: "A pre-signed PUT URL for S3-compatible storage to upload the backup to",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm stopping the Validator client to export its slashing protection, and creating the backup without slashing protection if it can't be exported",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return backupNode(c)
+
+				},
+			},
+
+			{
+				Name:      "restore",
+				Usage:     "Restore an encrypted backup created with `rocketpool service backup` onto this machine",
+				UsageText: "rocketpool service restore [options] backup-path-or-url",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "identity, i",
+						Usage: "The age identity file to decrypt the backup with (default: prompt for a passphrase)",
+					},
+					cli.BoolFlag{
+						Name:  "gpg",
+						Usage: "Decrypt the backup with GPG instead of age (default: use GPG if the backup's name ends in .gpg)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the restore",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run command
+					return restoreNode(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "export-node",
+				Usage:     "Export your node wallet, validator keys, slashing protection, settings and custom overrides into a single encrypted archive for moving your node to a new machine",
+				UsageText: "rocketpool service export-node [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The path to save the export to (default: rocketpool-node-export-<timestamp>.tar.gz.age in the current folder)",
+					},
+					cli.StringFlag{
+						Name:  "recipient, r",
+						Usage: "The age public key or GPG key ID to encrypt the export for (default: prompt for a passphrase)",
+					},
+					cli.BoolFlag{
+						Name:  "gpg",
+						Usage: "Encrypt the export with GPG instead of age",
+					},
+					cli.StringFlag{
+						Name:  "upload-url",
+						Usage: "A pre-signed PUT URL for S3-compatible storage to upload the export to",
+					},
+					cli.BoolFlag{
+						Name:  "keep-running",
+						Usage: "Start the Validator client again after exporting its slashing protection instead of leaving it stopped",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm stopping the Validator client and exporting without slashing protection if it can't be exported",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return exportNode(c)
+
+				},
+			},
+
+			{
+				Name:      "import-node",
+				Usage:     "Import a node created with `rocketpool service export-node` onto this machine, verifying its files and loading its slashing protection before the Validator client starts",
+				UsageText: "rocketpool service import-node [options] export-path-or-url",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "identity, i",
+						Usage: "The age identity file to decrypt the export with (default: prompt for a passphrase)",
+					},
+					cli.BoolFlag{
+						Name:  "gpg",
+						Usage: "Decrypt the export with GPG instead of age (default: use GPG if the export's name ends in .gpg)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the import",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run command
+					return importNode(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "export-slashing-protection",
+				Usage:     "Export your node's slashing protection in the EIP-3076 interchange format, from the Validator client's database if the Smartnode manages it",
+				UsageText: "rocketpool service export-slashing-protection [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm stopping the Validator client",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return exportSlashingProtection(c)
+
+				},
+			},
+
+			{
+				Name:      "import-slashing-protection",
+				Usage:     "Import an EIP-3076 slashing protection file into your Validator client's database",
+				UsageText: "rocketpool service import-slashing-protection [options] file",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm stopping the Validator client",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run command
+					return importSlashingProtection(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "rotate-jwt-secret",
+				Usage:     "Replace the JWT secret your Execution and Consensus clients use to authenticate with each other, and restart them",
+				UsageText: "rocketpool service rotate-jwt-secret [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the rotation",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return rotateJwtSecret(c)
+
+				},
+			},
+
+			{
+				Name:      "provision-dashboards",
+				Usage:     "Push the Smartnode's dashboards and alert rules to Grafana, replacing older versions of them",
+				UsageText: "rocketpool service provision-dashboards [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "token, t",
+						Usage: "The Grafana service account token to use instead of the one in the Smartnode's settings",
+					},
+					cli.BoolFlag{
+						Name:  "force, f",
+						Usage: "Provision the dashboards even if they're already at the current version",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return provisionDashboards(c)
+
+				},
+			},
+
 			{
 				Name:      "resync-eth1",
 				Usage:     fmt.Sprintf("%sDeletes the main ETH1 client's chain data and resyncs it from scratch. Only use this as a last resort!%s", colorRed, colorReset),
@@ -502,6 +852,74 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:  "api-tokens",
+				Usage: "Manage the tokens that can call the node daemon's HTTP and gRPC API ports",
+				Subcommands: []cli.Command{
+
+					{
+						Name:      "create",
+						Aliases:   []string{"c"},
+						Usage:     "Create an API token with a role: read-only tokens can only view the node's status, operator tokens can also send routine transactions, and admin tokens can do anything, including exits, withdrawals and wallet management",
+						UsageText: "rocketpool service api-tokens create name [options]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "role, r",
+								Usage: "The token's role (read-only, operator or admin)",
+								Value: "read-only",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run command
+							return createApiToken(c, c.Args().Get(0))
+
+						},
+					},
+
+					{
+						Name:      "list",
+						Aliases:   []string{"l"},
+						Usage:     "List the API tokens",
+						UsageText: "rocketpool service api-tokens list",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return listApiTokens(c)
+
+						},
+					},
+
+					{
+						Name:      "revoke",
+						Aliases:   []string{"r"},
+						Usage:     "Revoke an API token",
+						UsageText: "rocketpool service api-tokens revoke name",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run command
+							return revokeApiToken(c, c.Args().Get(0))
+
+						},
+					},
+				},
+			},
+
 			{
 				Name:      "terminate",
 				Aliases:   []string{"t"},
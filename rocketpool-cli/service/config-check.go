@@ -0,0 +1,151 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Setting IDs containing these are masked when the effective config is printed
+var sensitiveSettingKeywords = []string{"secret", "password", "apikey", "token"}
+
+// Print the effective configuration after the defaults, settings file, environment variables, and flags have been merged, and validate it
+func checkConfig(c *cli.Context, cfg *config.RocketPoolConfig, isNew bool) error {
+
+	// Apply the flags on top of the file and environment variables, without saving them
+	if err := configureHeadless(c, cfg); err != nil {
+		return fmt.Errorf("error applying the provided arguments: %w", err)
+	}
+	network := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+
+	// Print the root settings, then each section in order
+	fmt.Printf("Effective configuration (sources: default, file, env, flag):\n\n")
+	fmt.Println("[root]")
+	printEffectiveSettings(c, cfg, "", cfg.GetParameters(), network, isNew)
+	subconfigs := cfg.GetSubconfigs()
+	sections := make([]string, 0, len(subconfigs))
+	for section := range subconfigs {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+	for _, section := range sections {
+		fmt.Printf("\n[%s]\n", section)
+		printEffectiveSettings(c, cfg, section, subconfigs[section].GetParameters(), network, isNew)
+	}
+	fmt.Println()
+
+	// Validate it
+	errors := cfg.Validate()
+	if len(errors) > 0 {
+		fmt.Printf("%sThe configuration has the following problems:%s\n", colorRed, colorReset)
+		for _, err := range errors {
+			fmt.Printf("\t%s\n", err)
+		}
+		return fmt.Errorf("the configuration is invalid")
+	}
+	fmt.Printf("%sThe configuration is valid.%s\n", colorGreen, colorReset)
+	return nil
+
+}
+
+// Print a section's settings and where each value came from
+func printEffectiveSettings(c *cli.Context, cfg *config.RocketPoolConfig, section string, params []*cfgtypes.Parameter, network cfgtypes.Network, isNew bool) {
+	for _, param := range params {
+		flagName := param.ID
+		if section != "" {
+			flagName = fmt.Sprintf("%s-%s", section, param.ID)
+		}
+
+		source := "file"
+		if c.IsSet(flagName) {
+			source = "flag"
+		} else if _, overridden := cfg.GetSettingOverride(section, param.ID); overridden {
+			source = "env"
+		} else if defaultValue, err := param.GetDefault(network); isNew || (err == nil && defaultValue == param.Value) {
+			source = "default"
+		}
+
+		value := fmt.Sprint(param.Value)
+		if param.Value == nil {
+			value = ""
+		}
+		if value != "" && isSensitiveSetting(param.ID) {
+			value = "********"
+		}
+		fmt.Printf("%s = %s (%s)\n", param.ID, value, source)
+	}
+}
+
+// Check if a setting holds a secret
+func isSensitiveSetting(paramID string) bool {
+	id := strings.ToLower(paramID)
+	for _, keyword := range sensitiveSettingKeywords {
+		if strings.Contains(id, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check the settings and the clients' connectivity, and explain how to fix any problems before the service is restarted
+func diagnoseConfig(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("No configuration detected. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+
+	// Check the settings themselves
+	problems := []api.ConfigProblem{}
+	for _, message := range cfg.Validate() {
+		problems = append(problems, api.ConfigProblem{IsError: true, Message: message})
+	}
+
+	// Check the clients and the settings that depend on the node's on-chain state
+	fmt.Println("Checking your settings and clients...")
+	response, err := rp.CheckConfig()
+	if err != nil {
+		problems = append(problems, api.ConfigProblem{
+			IsError: false,
+			Message: fmt.Sprintf("The connectivity, fee recipient, and Oracle DAO checks couldn't be run, probably because the Smartnode isn't running: %s", err.Error()),
+		})
+	} else {
+		problems = append(problems, response.Problems...)
+	}
+	fmt.Println()
+
+	// Print the results
+	errorCount := 0
+	for _, problem := range problems {
+		if problem.IsError {
+			errorCount++
+			fmt.Printf("%sERROR: %s%s\n\n", colorRed, problem.Message, colorReset)
+		}
+	}
+	for _, problem := range problems {
+		if !problem.IsError {
+			fmt.Printf("%sWARNING: %s%s\n\n", colorYellow, problem.Message, colorReset)
+		}
+	}
+	if errorCount > 0 {
+		return fmt.Errorf("found %d problem(s) with your configuration; please fix them before restarting the Smartnode", errorCount)
+	}
+	fmt.Printf("%sNo problems were found with your configuration.%s\n", colorGreen, colorReset)
+	return nil
+
+}
@@ -16,6 +16,7 @@ type ConsensusConfigPage struct {
 	ccDropdown              *parameterizedFormItem
 	externalCcDropdown      *parameterizedFormItem
 	ccCommonItems           []*parameterizedFormItem
+	grandineItems           []*parameterizedFormItem
 	lighthouseItems         []*parameterizedFormItem
 	lodestarItems           []*parameterizedFormItem
 	nimbusItems             []*parameterizedFormItem
@@ -85,6 +86,7 @@ func (configPage *ConsensusConfigPage) createContent() {
 	configPage.ccDropdown = createParameterizedDropDown(&configPage.masterConfig.ConsensusClient, configPage.layout.descriptionBox)
 	configPage.externalCcDropdown = createParameterizedDropDown(&configPage.masterConfig.ExternalConsensusClient, configPage.layout.descriptionBox)
 	configPage.ccCommonItems = createParameterizedFormItems(configPage.masterConfig.ConsensusCommon.GetParameters(), configPage.layout.descriptionBox)
+	configPage.grandineItems = createParameterizedFormItems(configPage.masterConfig.Grandine.GetParameters(), configPage.layout.descriptionBox)
 	configPage.lighthouseItems = createParameterizedFormItems(configPage.masterConfig.Lighthouse.GetParameters(), configPage.layout.descriptionBox)
 	configPage.lodestarItems = createParameterizedFormItems(configPage.masterConfig.Lodestar.GetParameters(), configPage.layout.descriptionBox)
 	configPage.nimbusItems = createParameterizedFormItems(configPage.masterConfig.Nimbus.GetParameters(), configPage.layout.descriptionBox)
@@ -99,6 +101,7 @@ func (configPage *ConsensusConfigPage) createContent() {
 	// Map the parameters to the form items in the layout
 	configPage.layout.mapParameterizedFormItems(configPage.ccModeDropdown, configPage.ccDropdown, configPage.externalCcDropdown)
 	configPage.layout.mapParameterizedFormItems(configPage.ccCommonItems...)
+	configPage.layout.mapParameterizedFormItems(configPage.grandineItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.lighthouseItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.lodestarItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.nimbusItems...)
@@ -164,6 +167,8 @@ func (configPage *ConsensusConfigPage) handleLocalCcChanged() {
 	selectedCc := configPage.masterConfig.ConsensusClient.Value.(cfgtypes.ConsensusClient)
 
 	switch selectedCc {
+	case cfgtypes.ConsensusClient_Grandine:
+		configPage.layout.addFormItemsWithCommonParams(configPage.ccCommonItems, configPage.grandineItems, configPage.masterConfig.Grandine.UnsupportedCommonParams)
 	case cfgtypes.ConsensusClient_Lighthouse:
 		configPage.layout.addFormItemsWithCommonParams(configPage.ccCommonItems, configPage.lighthouseItems, configPage.masterConfig.Lighthouse.UnsupportedCommonParams)
 	case cfgtypes.ConsensusClient_Lodestar:
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The page wrapper for the distributed validator config
+type DistributedValidatorConfigPage struct {
+	home         *settingsHome
+	page         *page
+	layout       *standardLayout
+	masterConfig *config.RocketPoolConfig
+	enableBox    *parameterizedFormItem
+	modeBox      *parameterizedFormItem
+	charonItems  []*parameterizedFormItem
+	ssvItems     []*parameterizedFormItem
+}
+
+// Creates a new page for the distributed validator settings
+func NewDistributedValidatorConfigPage(home *settingsHome) *DistributedValidatorConfigPage {
+
+	configPage := &DistributedValidatorConfigPage{
+		home:         home,
+		masterConfig: home.md.Config,
+	}
+	configPage.createContent()
+
+	configPage.page = newPage(
+		home.homePage,
+		"settings-distributed-validators",
+		"Distributed Validators",
+		"Select this to run the Obol or SSV middleware, so your minipools' validators can be distributed validators run by a cluster of operators instead of keys in your node wallet.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *DistributedValidatorConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the distributed validator settings page
+func (configPage *DistributedValidatorConfigPage) createContent() {
+
+	// Create the layout
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "Distributed Validator Settings")
+
+	// Return to the home page after pressing Escape
+	configPage.layout.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			// Close all dropdowns and break if one was open
+			for _, param := range configPage.layout.parameters {
+				dropDown, ok := param.item.(*DropDown)
+				if ok && dropDown.open {
+					dropDown.CloseList(configPage.home.md.app)
+					return nil
+				}
+			}
+
+			// Return to the home page
+			configPage.home.md.setPage(configPage.home.homePage)
+			return nil
+		}
+		return event
+	})
+
+	// Set up the form items
+	dvConfig := configPage.masterConfig.DistributedValidator
+	configPage.enableBox = createParameterizedCheckbox(&configPage.masterConfig.EnableDistributedValidators)
+	configPage.modeBox = createParameterizedDropDown(&dvConfig.Mode, configPage.layout.descriptionBox)
+
+	charonParams := []*cfgtypes.Parameter{
+		&dvConfig.CharonContainerTag,
+		&dvConfig.CharonP2pPort,
+		&dvConfig.CharonAdditionalFlags,
+	}
+	ssvParams := []*cfgtypes.Parameter{
+		&dvConfig.SsvContainerTag,
+		&dvConfig.SsvP2pTcpPort,
+		&dvConfig.SsvP2pUdpPort,
+		&dvConfig.SsvAdditionalFlags,
+	}
+	configPage.charonItems = createParameterizedFormItems(charonParams, configPage.layout.descriptionBox)
+	configPage.ssvItems = createParameterizedFormItems(ssvParams, configPage.layout.descriptionBox)
+
+	// Map the parameters to the form items in the layout
+	configPage.layout.mapParameterizedFormItems(configPage.enableBox, configPage.modeBox)
+	configPage.layout.mapParameterizedFormItems(configPage.charonItems...)
+	configPage.layout.mapParameterizedFormItems(configPage.ssvItems...)
+
+	// Set up the setting callbacks
+	configPage.enableBox.item.(*tview.Checkbox).SetChangedFunc(func(checked bool) {
+		if configPage.masterConfig.EnableDistributedValidators.Value == checked {
+			return
+		}
+		configPage.masterConfig.EnableDistributedValidators.Value = checked
+		configPage.handleModeChanged()
+	})
+	configPage.modeBox.item.(*DropDown).SetSelectedFunc(func(text string, index int) {
+		if dvConfig.Mode.Value == dvConfig.Mode.Options[index].Value {
+			return
+		}
+		dvConfig.Mode.Value = dvConfig.Mode.Options[index].Value
+		configPage.handleModeChanged()
+	})
+
+	// Do the initial draw
+	configPage.handleModeChanged()
+}
+
+// Handle all of the form changes when the enable box or the middleware has changed
+func (configPage *DistributedValidatorConfigPage) handleModeChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.form.AddFormItem(configPage.enableBox.item)
+
+	// Only add the supporting stuff if distributed validators are enabled
+	if configPage.masterConfig.EnableDistributedValidators.Value == true {
+		configPage.layout.form.AddFormItem(configPage.modeBox.item)
+
+		selectedMode := configPage.masterConfig.DistributedValidator.Mode.Value.(cfgtypes.DistributedValidatorMode)
+		switch selectedMode {
+		case cfgtypes.DistributedValidatorMode_Obol:
+			configPage.layout.addFormItems(configPage.charonItems)
+		case cfgtypes.DistributedValidatorMode_Ssv:
+			configPage.layout.addFormItems(configPage.ssvItems)
+		}
+	}
+
+	configPage.layout.refresh()
+}
+
+// Handle a bulk redraw request
+func (configPage *DistributedValidatorConfigPage) handleLayoutChanged() {
+	configPage.handleModeChanged()
+}
@@ -18,6 +18,7 @@ type ExecutionConfigPage struct {
 	gethItems       []*parameterizedFormItem
 	nethermindItems []*parameterizedFormItem
 	besuItems       []*parameterizedFormItem
+	rethItems       []*parameterizedFormItem
 	externalEcItems []*parameterizedFormItem
 }
 
@@ -80,6 +81,7 @@ func (configPage *ExecutionConfigPage) createContent() {
 	configPage.gethItems = createParameterizedFormItems(configPage.masterConfig.Geth.GetParameters(), configPage.layout.descriptionBox)
 	configPage.nethermindItems = createParameterizedFormItems(configPage.masterConfig.Nethermind.GetParameters(), configPage.layout.descriptionBox)
 	configPage.besuItems = createParameterizedFormItems(configPage.masterConfig.Besu.GetParameters(), configPage.layout.descriptionBox)
+	configPage.rethItems = createParameterizedFormItems(configPage.masterConfig.Reth.GetParameters(), configPage.layout.descriptionBox)
 	configPage.externalEcItems = createParameterizedFormItems(configPage.masterConfig.ExternalExecution.GetParameters(), configPage.layout.descriptionBox)
 
 	// Map the parameters to the form items in the layout
@@ -88,6 +90,7 @@ func (configPage *ExecutionConfigPage) createContent() {
 	configPage.layout.mapParameterizedFormItems(configPage.gethItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.nethermindItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.besuItems...)
+	configPage.layout.mapParameterizedFormItems(configPage.rethItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.externalEcItems...)
 
 	// Set up the setting callbacks
@@ -146,6 +149,8 @@ func (configPage *ExecutionConfigPage) handleLocalEcChanged() {
 		configPage.layout.addFormItemsWithCommonParams(configPage.ecCommonItems, configPage.nethermindItems, configPage.masterConfig.Nethermind.UnsupportedCommonParams)
 	case cfgtypes.ExecutionClient_Besu:
 		configPage.layout.addFormItemsWithCommonParams(configPage.ecCommonItems, configPage.besuItems, configPage.masterConfig.Besu.UnsupportedCommonParams)
+	case cfgtypes.ExecutionClient_Reth:
+		configPage.layout.addFormItemsWithCommonParams(configPage.ecCommonItems, configPage.rethItems, configPage.masterConfig.Reth.UnsupportedCommonParams)
 	}
 
 	configPage.layout.refresh()
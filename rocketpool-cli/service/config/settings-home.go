@@ -20,6 +20,8 @@ type settingsHome struct {
 	fallbackPage     *FallbackConfigPage
 	ccPage           *ConsensusConfigPage
 	mevBoostPage     *MevBoostConfigPage
+	web3SignerPage   *Web3SignerConfigPage
+	dvPage           *DistributedValidatorConfigPage
 	metricsPage      *MetricsConfigPage
 	addonsPage       *AddonsPage
 	categoryList     *tview.List
@@ -45,6 +47,8 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 	home.ccPage = NewConsensusConfigPage(home)
 	home.fallbackPage = NewFallbackConfigPage(home)
 	home.mevBoostPage = NewMevBoostConfigPage(home)
+	home.web3SignerPage = NewWeb3SignerConfigPage(home)
+	home.dvPage = NewDistributedValidatorConfigPage(home)
 	home.metricsPage = NewMetricsConfigPage(home)
 	home.addonsPage = NewAddonsPage(home)
 	settingsSubpages := []settingsPage{
@@ -53,6 +57,8 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 		home.ccPage,
 		home.fallbackPage,
 		home.mevBoostPage,
+		home.web3SignerPage,
+		home.dvPage,
 		home.metricsPage,
 		home.addonsPage,
 	}
@@ -86,6 +86,9 @@ func (configPage *MevBoostConfigPage) createContent() {
 	configPage.selectionModeBox = createParameterizedDropDown(&configPage.masterConfig.MevBoost.SelectionMode, configPage.layout.descriptionBox)
 
 	localParams := []*cfgtypes.Parameter{
+		&configPage.masterConfig.MevBoost.RelayManagement,
+		&configPage.masterConfig.MevBoost.RelayMaxFailures,
+		&configPage.masterConfig.MevBoost.RelayMaxLatency,
 		&configPage.masterConfig.MevBoost.Port,
 		&configPage.masterConfig.MevBoost.OpenRpcPort,
 		&configPage.masterConfig.MevBoost.ContainerTag,
@@ -64,8 +64,8 @@ func (configPage *NativeSmartnodeConfigPage) createContent() {
 	// Set up the form items
 	formItems := createParameterizedFormItems(masterConfig.Smartnode.GetParameters(), layout.descriptionBox)
 	for _, formItem := range formItems {
-		if formItem.parameter.ID == config.ProjectNameID {
-			// Ignore the project name ID since it doesn't apply to native mode
+		if formItem.parameter.ID == config.ProjectNameID || formItem.parameter.ID == config.ContainerRuntimeID || formItem.parameter.ID == config.ContainerSocketPathID {
+			// Ignore the container settings since they don't apply to native mode
 			continue
 		}
 
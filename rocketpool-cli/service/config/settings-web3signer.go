@@ -0,0 +1,109 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The page wrapper for the Web3Signer config
+type Web3SignerConfigPage struct {
+	home            *settingsHome
+	page            *page
+	layout          *standardLayout
+	masterConfig    *config.RocketPoolConfig
+	enableBox       *parameterizedFormItem
+	web3SignerItems []*parameterizedFormItem
+}
+
+// Creates a new page for the Web3Signer settings
+func NewWeb3SignerConfigPage(home *settingsHome) *Web3SignerConfigPage {
+
+	configPage := &Web3SignerConfigPage{
+		home:         home,
+		masterConfig: home.md.Config,
+	}
+	configPage.createContent()
+
+	configPage.page = newPage(
+		home.homePage,
+		"settings-web3signer",
+		"Web3Signer",
+		"Select this to have an external Web3Signer instance hold your validator keys and sign for your Validator client, so your keystores don't have to be stored on this machine.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *Web3SignerConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the Web3Signer settings page
+func (configPage *Web3SignerConfigPage) createContent() {
+
+	// Create the layout
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "Web3Signer Settings")
+
+	// Return to the home page after pressing Escape
+	configPage.layout.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			// Close all dropdowns and break if one was open
+			for _, param := range configPage.layout.parameters {
+				dropDown, ok := param.item.(*DropDown)
+				if ok && dropDown.open {
+					dropDown.CloseList(configPage.home.md.app)
+					return nil
+				}
+			}
+
+			// Return to the home page
+			configPage.home.md.setPage(configPage.home.homePage)
+			return nil
+		}
+		return event
+	})
+
+	// Set up the form items
+	configPage.enableBox = createParameterizedCheckbox(&configPage.masterConfig.EnableWeb3Signer)
+	configPage.web3SignerItems = createParameterizedFormItems(configPage.masterConfig.Web3Signer.GetParameters(), configPage.layout.descriptionBox)
+
+	// Map the parameters to the form items in the layout
+	configPage.layout.mapParameterizedFormItems(configPage.enableBox)
+	configPage.layout.mapParameterizedFormItems(configPage.web3SignerItems...)
+
+	// Set up the setting callbacks
+	configPage.enableBox.item.(*tview.Checkbox).SetChangedFunc(func(checked bool) {
+		if configPage.masterConfig.EnableWeb3Signer.Value == checked {
+			return
+		}
+		configPage.masterConfig.EnableWeb3Signer.Value = checked
+		configPage.handleEnableChanged()
+	})
+
+	// Do the initial draw
+	configPage.handleEnableChanged()
+}
+
+// Handle all of the form changes when the Enable Web3Signer box has changed
+func (configPage *Web3SignerConfigPage) handleEnableChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.form.AddFormItem(configPage.enableBox.item)
+
+	// Only add the supporting stuff if Web3Signer is enabled
+	if configPage.masterConfig.EnableWeb3Signer.Value == false {
+		return
+	}
+	configPage.layout.addFormItems(configPage.web3SignerItems)
+
+	configPage.layout.refresh()
+}
+
+// Handle a bulk redraw request
+func (configPage *Web3SignerConfigPage) handleLayoutChanged() {
+	configPage.handleEnableChanged()
+}
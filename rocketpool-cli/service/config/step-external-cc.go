@@ -13,7 +13,7 @@ func createExternalCcStep(wiz *wizard, currentStep int, totalSteps int) *choiceW
 		clientNames = append(clientNames, client.Name)
 	}
 
-	helperText := "Which Consensus client are you externally managing? Each of them has small behavioral differences, so we'll need to know which one you're using in order to connect to it properly."
+	helperText := "Which Consensus client are you externally managing? Each of them has small behavioral differences, so we'll need to know which one you're using in order to connect to it properly.\n\nIf you aren't sure, pick any of them; once you've finished, `rocketpool service detect-external-clients` can identify your clients and correct it for you."
 
 	show := func(modal *choiceModalLayout) {
 		wiz.md.setPage(modal.page)
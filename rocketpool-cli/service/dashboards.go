@@ -0,0 +1,70 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/grafana"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// How long to wait for Grafana to come up after the service starts
+const grafanaStartTimeout time.Duration = 60 * time.Second
+
+// Push the Smartnode's dashboards and alert rules to the bundled Grafana
+func provisionDashboards(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Sanity checks
+	if cfg.IsNativeMode {
+		fmt.Println("You are using Native Mode.\nThe Smartnode doesn't run Grafana in Native Mode, so there's nothing to provision.")
+		return nil
+	}
+	if !cfg.EnableMetrics.Value.(bool) {
+		fmt.Println("Metrics are disabled, so Grafana isn't running. Please enable them in `rocketpool service config` first.")
+		return nil
+	}
+	token := c.String("token")
+	if token == "" {
+		token = cfg.Grafana.ApiToken.Value.(string)
+	}
+	if token == "" {
+		return fmt.Errorf("No Grafana service account token is set. Please create one in Grafana under Administration > Service accounts, and set it in the Metrics section of `rocketpool service config` or with --token.")
+	}
+
+	updated, err := provisionGrafana(cfg, token, c.Bool("force"))
+	if err != nil {
+		return err
+	}
+	if updated {
+		fmt.Printf("%sProvisioned the Smartnode's dashboards and alert rules (v%d) in Grafana's Rocket Pool folder.%s\n", colorGreen, grafana.DashboardsVersion, colorReset)
+	} else {
+		fmt.Printf("The dashboards in Grafana are already at v%d. Use --force to provision them again anyway.\n", grafana.DashboardsVersion)
+	}
+	return nil
+
+}
+
+// Provision the bundled Grafana with the provided token once it's up, and get whether anything was updated
+func provisionGrafana(cfg *config.RocketPoolConfig, token string, force bool) (bool, error) {
+	client := grafana.NewClient(fmt.Sprintf("http://localhost:%d", cfg.Grafana.Port.Value.(uint16)), token)
+	if err := client.WaitUntilReady(grafanaStartTimeout); err != nil {
+		return false, err
+	}
+	return grafana.Provision(client, force)
+}
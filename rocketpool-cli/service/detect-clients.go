@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon/client"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Settings
+const (
+	clientDetectionTimeout time.Duration = 10 * time.Second
+	prysmDefaultGrpcPort   string        = "4000"
+)
+
+// The client version prefixes reported by each Execution client's web3_clientVersion
+var executionClientPrefixes = map[string]string{
+	"geth":       "Geth",
+	"nethermind": "Nethermind",
+	"besu":       "Besu",
+	"reth":       "Reth",
+	"erigon":     "Erigon",
+}
+
+// The client version prefixes reported by each Consensus client's /eth/v1/node/version, and the matching config option
+var consensusClientPrefixes = map[string]cfgtypes.ConsensusClient{
+	"grandine":   cfgtypes.ConsensusClient_Grandine,
+	"lighthouse": cfgtypes.ConsensusClient_Lighthouse,
+	"lodestar":   cfgtypes.ConsensusClient_Lodestar,
+	"nimbus":     cfgtypes.ConsensusClient_Nimbus,
+	"prysm":      cfgtypes.ConsensusClient_Prysm,
+	"teku":       cfgtypes.ConsensusClient_Teku,
+}
+
+// Probe the external clients used in hybrid mode, identify them, and configure the Smartnode to match
+func detectExternalClients(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) != cfgtypes.Mode_External || cfg.ConsensusClientMode.Value.(cfgtypes.Mode) != cfgtypes.Mode_External {
+		fmt.Println("You aren't using externally-managed clients (hybrid mode), so there's nothing to detect.")
+		return nil
+	}
+
+	// Get the URLs to probe
+	ecUrl := c.String("ec-url")
+	if ecUrl == "" {
+		ecUrl = cfg.ExternalExecution.HttpUrl.Value.(string)
+	}
+	ccUrl := c.String("cc-url")
+	if ccUrl == "" {
+		selectedCcConfig, err := cfg.GetSelectedConsensusClientConfig()
+		if err != nil {
+			return fmt.Errorf("error getting selected consensus client config: %w", err)
+		}
+		ccUrl = selectedCcConfig.(cfgtypes.ExternalConsensusConfig).GetApiUrl()
+	}
+	if ecUrl == "" || ccUrl == "" {
+		return fmt.Errorf("Please provide the URLs of both your Execution client and Consensus client, either with `rocketpool service config` or with the --ec-url and --cc-url flags.")
+	}
+	expectedChainId := uint64(cfg.Smartnode.GetChainID())
+
+	// Identify the Execution client
+	fmt.Printf("Probing Execution client at %s...\n", ecUrl)
+	ecName, ecVersion, err := detectExecutionClient(ecUrl, expectedChainId)
+	if err != nil {
+		return fmt.Errorf("error detecting Execution client: %w", err)
+	}
+	fmt.Printf("Found %s%s%s (%s).\n\n", colorGreen, ecName, colorReset, ecVersion)
+
+	// Identify the Consensus client
+	fmt.Printf("Probing Consensus client at %s...\n", ccUrl)
+	cc, ccVersion, supportsSsz, err := detectConsensusClient(ccUrl, expectedChainId)
+	if err != nil {
+		return fmt.Errorf("error detecting Consensus client: %w", err)
+	}
+	fmt.Printf("Found %s%s%s (%s).\n", colorGreen, getConsensusClientName(cfg, cc), colorReset, ccVersion)
+	if supportsSsz {
+		fmt.Println("It serves SSZ-encoded responses.")
+	} else {
+		fmt.Println("It only serves JSON responses, so SSZ requests will be disabled.")
+	}
+	fmt.Println()
+
+	// Grandine's Beacon node is served by Lighthouse's Validator client
+	if cc == cfgtypes.ConsensusClient_Grandine {
+		fmt.Printf("%sGrandine is only supported as a locally-managed client, but its API is compatible with Lighthouse's Validator client.\nYour node will be configured to use Lighthouse as its external client.%s\n\n", colorYellow, colorReset)
+		cc = cfgtypes.ConsensusClient_Lighthouse
+	}
+
+	// Build the new settings
+	newCfg := cfg.CreateCopy()
+	newCfg.ExternalExecution.HttpUrl.Value = ecUrl
+	newCfg.ExternalConsensusClient.Value = cc
+	newCfg.Smartnode.BeaconSszSupport.Value = supportsSsz
+	switch cc {
+	case cfgtypes.ConsensusClient_Lighthouse:
+		newCfg.ExternalLighthouse.HttpUrl.Value = ccUrl
+	case cfgtypes.ConsensusClient_Lodestar:
+		newCfg.ExternalLodestar.HttpUrl.Value = ccUrl
+	case cfgtypes.ConsensusClient_Nimbus:
+		newCfg.ExternalNimbus.HttpUrl.Value = ccUrl
+	case cfgtypes.ConsensusClient_Teku:
+		newCfg.ExternalTeku.HttpUrl.Value = ccUrl
+	case cfgtypes.ConsensusClient_Prysm:
+		// Prysm's Validator client connects over gRPC, which runs on a separate port
+		newCfg.ExternalPrysm.HttpUrl.Value = ccUrl
+		if newCfg.ExternalPrysm.JsonRpcUrl.Value.(string) == "" {
+			grpcUrl, err := getPrysmGrpcUrl(ccUrl)
+			if err != nil {
+				return fmt.Errorf("error determining Prysm's gRPC URL: %w", err)
+			}
+			newCfg.ExternalPrysm.JsonRpcUrl.Value = grpcUrl
+			fmt.Printf("%sPrysm's Validator client connects to its Beacon node over gRPC, which can't be detected automatically.\nYour node will use %s, Prysm's default gRPC address; change it with `rocketpool service config` if yours is different.%s\n\n", colorYellow, grpcUrl, colorReset)
+		}
+	}
+
+	// Show the changes
+	changedSettings, affectedContainers, _ := newCfg.GetChanges(cfg)
+	changeCount := 0
+	for _, changedSettingsList := range changedSettings {
+		for _, pair := range changedSettingsList {
+			if changeCount == 0 {
+				fmt.Println("The following settings will be updated:")
+			}
+			fmt.Printf("\t%s: %s => %s\n", pair.Name, pair.OldValue, pair.NewValue)
+			changeCount++
+		}
+	}
+	if changeCount == 0 {
+		fmt.Println("Your settings already match your external clients.")
+		return nil
+	}
+	fmt.Println()
+
+	// Save them
+	if !(c.Bool("yes") || cliutils.Confirm("Would you like to save these settings?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	err = rp.SaveConfig(newCfg)
+	if err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+	fmt.Println("Your changes have been saved!")
+	if len(affectedContainers) > 0 {
+		fmt.Println("Please run `rocketpool service start` to apply them.")
+	}
+	return nil
+
+}
+
+// Get the name and version of an Execution client, making sure it's on the expected chain
+func detectExecutionClient(ecUrl string, expectedChainId uint64) (string, string, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), clientDetectionTimeout)
+	defer cancel()
+
+	ec, err := rpc.DialContext(ctx, ecUrl)
+	if err != nil {
+		return "", "", fmt.Errorf("error connecting to %s: %w", ecUrl, err)
+	}
+	defer ec.Close()
+
+	// Check the chain
+	var chainId string
+	err = ec.CallContext(ctx, &chainId, "eth_chainId")
+	if err != nil {
+		return "", "", fmt.Errorf("error getting chain ID: %w", err)
+	}
+	if chainId != fmt.Sprintf("0x%x", expectedChainId) {
+		return "", "", fmt.Errorf("client is on chain %s but your node is configured for chain 0x%x", chainId, expectedChainId)
+	}
+
+	// Get the client version
+	var clientVersion string
+	err = ec.CallContext(ctx, &clientVersion, "web3_clientVersion")
+	if err != nil {
+		return "", "", fmt.Errorf("error getting client version: %w", err)
+	}
+	clientType, _, _ := strings.Cut(clientVersion, "/")
+	name, exists := executionClientPrefixes[strings.ToLower(clientType)]
+	if !exists {
+		return "", "", fmt.Errorf("unknown Execution client [%s]", clientVersion)
+	}
+	return name, clientVersion, nil
+
+}
+
+// Get the type and version of a Consensus client and whether it serves SSZ, making sure it's on the expected chain
+func detectConsensusClient(ccUrl string, expectedChainId uint64) (cfgtypes.ConsensusClient, string, bool, error) {
+
+	// Bound every request so an unresponsive client can't hang the probe
+	bc := client.NewStandardHttpClient(ccUrl)
+	policy := cfgtypes.CallPolicy{Timeout: clientDetectionTimeout}
+	bc.SetCallPolicies(map[cfgtypes.CallClass]cfgtypes.CallPolicy{
+		cfgtypes.CallClass_Fast:   policy,
+		cfgtypes.CallClass_Heavy:  policy,
+		cfgtypes.CallClass_Submit: policy,
+	})
+
+	// Check the chain
+	depositContract, err := bc.GetEth2DepositContract()
+	if err != nil {
+		return cfgtypes.ConsensusClient_Unknown, "", false, fmt.Errorf("error connecting to %s: %w", ccUrl, err)
+	}
+	if depositContract.ChainID != expectedChainId {
+		return cfgtypes.ConsensusClient_Unknown, "", false, fmt.Errorf("client is on chain %d but your node is configured for chain %d", depositContract.ChainID, expectedChainId)
+	}
+
+	// Get the client version
+	nodeVersion, err := bc.GetNodeVersion()
+	if err != nil {
+		return cfgtypes.ConsensusClient_Unknown, "", false, fmt.Errorf("error getting client version: %w", err)
+	}
+	clientType, _, _ := strings.Cut(nodeVersion.Version, "/")
+	cc, exists := consensusClientPrefixes[strings.ToLower(clientType)]
+	if !exists {
+		return cfgtypes.ConsensusClient_Unknown, "", false, fmt.Errorf("unknown Consensus client [%s]", nodeVersion.Version)
+	}
+
+	// Check which encodings it serves
+	supportsSsz, err := bc.SupportsSsz()
+	if err != nil {
+		return cfgtypes.ConsensusClient_Unknown, "", false, fmt.Errorf("error checking for SSZ support: %w", err)
+	}
+	return cc, nodeVersion.Version, supportsSsz, nil
+
+}
+
+// Get the display name of a Consensus client
+func getConsensusClientName(cfg *config.RocketPoolConfig, cc cfgtypes.ConsensusClient) string {
+	for _, option := range cfg.ConsensusClient.Options {
+		if option.Value == cc {
+			return option.Name
+		}
+	}
+	return string(cc)
+}
+
+// Get Prysm's default gRPC address on the same host as its HTTP API
+func getPrysmGrpcUrl(httpUrl string) (string, error) {
+	parsedUrl, err := url.Parse(httpUrl)
+	if err != nil {
+		return "", err
+	}
+	if parsedUrl.Hostname() == "" {
+		return "", fmt.Errorf("[%s] does not contain a hostname", httpUrl)
+	}
+	return net.JoinHostPort(parsedUrl.Hostname(), prysmDefaultGrpcPort), nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Replace the Engine API JWT secret and restart the Execution and Consensus clients so they pick it up together
+func rotateJwtSecret(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Sanity checks
+	if cfg.IsNativeMode {
+		fmt.Println("You are using Native Mode.\nThe Smartnode doesn't manage your clients' JWT secret; generate a new one (for example with `openssl rand -hex 32`), write it to the file both clients use, and restart them.")
+		return nil
+	}
+	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_External {
+		fmt.Println("You are using externally managed clients.\nThe Smartnode doesn't manage their JWT secret; please rotate it on the machine that runs them.")
+		return nil
+	}
+
+	fmt.Println("This will replace the JWT secret your Execution and Consensus clients use to authenticate with each other, then restart both of them.")
+	if cfg.UseFallbackClients.Value == false {
+		fmt.Printf("%sYou do not have fallback clients configured, so your validators may miss attestations while your clients restart.%s\n", colorYellow, colorReset)
+	} else {
+		fmt.Println("You have fallback clients enabled. Rocket Pool (and your Validator client) will use them while your main clients restart.")
+	}
+	fmt.Println()
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to rotate the JWT secret?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Rotate it in the daemon, which restores the previous secret if the clients can't be restarted
+	fmt.Println("Rotating JWT secret and restarting your clients...")
+	_, err = rp.RotateJwtSecret()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n%sDone! Your clients are now using the new JWT secret.%s\n", colorGreen, colorReset)
+	return nil
+
+}
@@ -0,0 +1,613 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+)
+
+// Native mode layout
+const (
+	nativeFolder           string = "native"
+	nativeBinFolder        string = "bin"
+	nativeSystemdFolder    string = "systemd"
+	nativeDataFolder       string = "data"
+	nativeSecretsFolder    string = "secrets"
+	nativeEnvFile          string = "rocketpool.env"
+	nativeJwtSecretFile    string = "jwtsecret"
+	nativePasswordCredFile string = "wallet-password.cred"
+	nativeRestartVcScript  string = "restart-vc.sh"
+	nativeStopVcScript     string = "stop-validator.sh"
+	nativeUnitPrefix       string = "rp-"
+	defaultNativeDaemonBin string = "/usr/local/bin/rocketpoold"
+)
+
+// A systemd service for one of the native processes
+type nativeUnit struct {
+	name             string
+	description      string
+	after            []string
+	execStart        string
+	envFiles         []string
+	optionalEnvFiles []string
+	credentials      map[string]string
+}
+
+// The script that starts the Execution client
+const startEcScript string = `#!/bin/sh
+# Starts the Execution client with the Smartnode's settings from rocketpool.env.
+# Generated by 'rocketpool service install --native'; run it again after changing your settings instead of editing this file.
+set -e
+
+CLIENT_NETWORK=$NETWORK
+if [ "$NETWORK" = "devnet" ]; then
+    CLIENT_NETWORK=holesky
+fi
+
+case "$EC_CLIENT" in
+geth)
+    set -- geth --"$CLIENT_NETWORK" --datadir "$EC_DATA_DIR" --port "$EC_P2P_PORT" --maxpeers "$EC_MAX_PEERS" \
+        --http --http.addr 127.0.0.1 --http.port "$EC_HTTP_PORT" --http.api eth,net,web3 \
+        --ws --ws.addr 127.0.0.1 --ws.port "$EC_WS_PORT" --ws.api eth,net,web3 \
+        --authrpc.addr 127.0.0.1 --authrpc.port "$EC_ENGINE_PORT" --authrpc.jwtsecret "$JWT_SECRET_FILE"
+    if [ "$ENABLE_METRICS" = "true" ]; then
+        set -- "$@" --metrics --metrics.addr 127.0.0.1 --metrics.port "$EC_METRICS_PORT"
+    fi
+    ;;
+nethermind)
+    set -- nethermind --config "$CLIENT_NETWORK" --datadir "$EC_DATA_DIR" \
+        --Network.P2PPort "$EC_P2P_PORT" --Network.DiscoveryPort "$EC_P2P_PORT" --Network.MaxActivePeers "$EC_MAX_PEERS" \
+        --JsonRpc.Enabled true --JsonRpc.Host 127.0.0.1 --JsonRpc.Port "$EC_HTTP_PORT" --JsonRpc.WebSocketsPort "$EC_WS_PORT" \
+        --JsonRpc.EngineHost 127.0.0.1 --JsonRpc.EnginePort "$EC_ENGINE_PORT" --JsonRpc.JwtSecretFile "$JWT_SECRET_FILE"
+    if [ "$ENABLE_METRICS" = "true" ]; then
+        set -- "$@" --Metrics.Enabled true --Metrics.ExposePort "$EC_METRICS_PORT"
+    fi
+    ;;
+besu)
+    set -- besu --network="$CLIENT_NETWORK" --data-path="$EC_DATA_DIR" --p2p-port="$EC_P2P_PORT" --max-peers="$EC_MAX_PEERS" \
+        --rpc-http-enabled --rpc-http-host=127.0.0.1 --rpc-http-port="$EC_HTTP_PORT" \
+        --rpc-ws-enabled --rpc-ws-host=127.0.0.1 --rpc-ws-port="$EC_WS_PORT" \
+        --engine-rpc-port="$EC_ENGINE_PORT" --engine-jwt-secret="$JWT_SECRET_FILE"
+    if [ "$ENABLE_METRICS" = "true" ]; then
+        set -- "$@" --metrics-enabled --metrics-host=127.0.0.1 --metrics-port="$EC_METRICS_PORT"
+    fi
+    ;;
+reth)
+    set -- reth node --chain "$CLIENT_NETWORK" --datadir "$EC_DATA_DIR" --port "$EC_P2P_PORT" --max-outbound-peers "$EC_MAX_PEERS" \
+        --http --http.addr 127.0.0.1 --http.port "$EC_HTTP_PORT" --http.api eth,net,web3 \
+        --ws --ws.addr 127.0.0.1 --ws.port "$EC_WS_PORT" --ws.api eth,net,web3 \
+        --authrpc.addr 127.0.0.1 --authrpc.port "$EC_ENGINE_PORT" --authrpc.jwtsecret "$JWT_SECRET_FILE"
+    if [ "$ENABLE_METRICS" = "true" ]; then
+        set -- "$@" --metrics 127.0.0.1:"$EC_METRICS_PORT"
+    fi
+    ;;
+*)
+    echo "Unknown Execution client [$EC_CLIENT]"
+    exit 1
+    ;;
+esac
+
+exec "$@" $EC_ADDITIONAL_FLAGS
+`
+
+// The script that starts the Beacon Node
+const startBnScript string = `#!/bin/sh
+# Starts the Beacon Node with the Smartnode's settings from rocketpool.env.
+# Generated by 'rocketpool service install --native'; run it again after changing your settings instead of editing this file.
+set -e
+
+CLIENT_NETWORK=$NETWORK
+if [ "$NETWORK" = "devnet" ]; then
+    CLIENT_NETWORK=holesky
+fi
+USE_MEV_BOOST=false
+if [ "$ENABLE_MEV_BOOST" = "true" ] && [ -n "$MEV_BOOST_URL" ]; then
+    USE_MEV_BOOST=true
+fi
+
+case "$CC_CLIENT" in
+lighthouse)
+    set -- lighthouse beacon_node --network "$CLIENT_NETWORK" --datadir "$BN_DATA_DIR" --port "$BN_P2P_PORT" --target-peers "$BN_MAX_PEERS" \
+        --http --http-address 127.0.0.1 --http-port "$BN_API_PORT" \
+        --execution-endpoint "$EC_ENGINE_ENDPOINT" --execution-jwt "$JWT_SECRET_FILE"
+    if [ -n "$CHECKPOINT_SYNC_URL" ]; then
+        set -- "$@" --checkpoint-sync-url "$CHECKPOINT_SYNC_URL"
+    fi
+    if [ "$USE_MEV_BOOST" = "true" ]; then
+        set -- "$@" --builder "$MEV_BOOST_URL"
+    fi
+    if [ "$ENABLE_METRICS" = "true" ]; then
+        set -- "$@" --metrics --metrics-address 127.0.0.1 --metrics-port "$BN_METRICS_PORT"
+    fi
+    ;;
+lodestar)
+    set -- lodestar beacon --network "$CLIENT_NETWORK" --dataDir "$BN_DATA_DIR" --port "$BN_P2P_PORT" --targetPeers "$BN_MAX_PEERS" \
+        --rest --rest.address 127.0.0.1 --rest.port "$BN_API_PORT" \
+        --execution.urls "$EC_ENGINE_ENDPOINT" --jwt-secret "$JWT_SECRET_FILE"
+    if [ -n "$CHECKPOINT_SYNC_URL" ]; then
+        set -- "$@" --checkpointSyncUrl "$CHECKPOINT_SYNC_URL"
+    fi
+    if [ "$USE_MEV_BOOST" = "true" ]; then
+        set -- "$@" --builder --builder.urls "$MEV_BOOST_URL"
+    fi
+    if [ "$ENABLE_METRICS" = "true" ]; then
+        set -- "$@" --metrics --metrics.address 127.0.0.1 --metrics.port "$BN_METRICS_PORT"
+    fi
+    ;;
+nimbus)
+    if [ -n "$CHECKPOINT_SYNC_URL" ] && [ ! -d "$BN_DATA_DIR/db" ]; then
+        nimbus_beacon_node trustedNodeSync --network="$CLIENT_NETWORK" --data-dir="$BN_DATA_DIR" --trusted-node-url="$CHECKPOINT_SYNC_URL" --backfill=false
+    fi
+    set -- nimbus_beacon_node --network="$CLIENT_NETWORK" --data-dir="$BN_DATA_DIR" --tcp-port="$BN_P2P_PORT" --udp-port="$BN_P2P_PORT" --max-peers="$BN_MAX_PEERS" \
+        --rest --rest-address=127.0.0.1 --rest-port="$BN_API_PORT" \
+        --el="$EC_ENGINE_ENDPOINT" --jwt-secret="$JWT_SECRET_FILE"
+    if [ "$USE_MEV_BOOST" = "true" ]; then
+        set -- "$@" --payload-builder=true --payload-builder-url="$MEV_BOOST_URL"
+    fi
+    if [ "$ENABLE_METRICS" = "true" ]; then
+        set -- "$@" --metrics --metrics-address=127.0.0.1 --metrics-port="$BN_METRICS_PORT"
+    fi
+    ;;
+prysm)
+    set -- beacon-chain --accept-terms-of-use --"$CLIENT_NETWORK" --datadir "$BN_DATA_DIR" \
+        --p2p-tcp-port "$BN_P2P_PORT" --p2p-udp-port "$BN_P2P_PORT" --p2p-max-peers "$BN_MAX_PEERS" \
+        --rpc-host 127.0.0.1 --rpc-port "$BN_RPC_PORT" --grpc-gateway-host 127.0.0.1 --grpc-gateway-port "$BN_API_PORT" \
+        --execution-endpoint "$EC_ENGINE_ENDPOINT" --jwt-secret "$JWT_SECRET_FILE"
+    if [ -n "$CHECKPOINT_SYNC_URL" ]; then
+        set -- "$@" --checkpoint-sync-url "$CHECKPOINT_SYNC_URL" --genesis-beacon-api-url "$CHECKPOINT_SYNC_URL"
+    fi
+    if [ "$USE_MEV_BOOST" = "true" ]; then
+        set -- "$@" --http-mev-relay "$MEV_BOOST_URL"
+    fi
+    if [ "$ENABLE_METRICS" = "true" ]; then
+        set -- "$@" --monitoring-host 127.0.0.1 --monitoring-port "$BN_METRICS_PORT"
+    else
+        set -- "$@" --disable-monitoring
+    fi
+    ;;
+teku)
+    set -- teku --network="$CLIENT_NETWORK" --data-path="$BN_DATA_DIR" --p2p-port="$BN_P2P_PORT" --p2p-peer-upper-bound="$BN_MAX_PEERS" \
+        --rest-api-enabled --rest-api-interface=127.0.0.1 --rest-api-port="$BN_API_PORT" \
+        --ee-endpoint="$EC_ENGINE_ENDPOINT" --ee-jwt-secret-file="$JWT_SECRET_FILE"
+    if [ -n "$CHECKPOINT_SYNC_URL" ]; then
+        set -- "$@" --checkpoint-sync-url="$CHECKPOINT_SYNC_URL"
+    fi
+    if [ "$USE_MEV_BOOST" = "true" ]; then
+        set -- "$@" --builder-endpoint="$MEV_BOOST_URL"
+    fi
+    if [ "$ENABLE_METRICS" = "true" ]; then
+        set -- "$@" --metrics-enabled --metrics-interface=127.0.0.1 --metrics-port="$BN_METRICS_PORT"
+    fi
+    ;;
+grandine)
+    set -- grandine --network "$CLIENT_NETWORK" --data-dir "$BN_DATA_DIR" --libp2p-port "$BN_P2P_PORT" --target-peers "$BN_MAX_PEERS" \
+        --http-address 127.0.0.1 --http-port "$BN_API_PORT" \
+        --eth1-rpc-urls "$EC_ENGINE_ENDPOINT" --jwt-secret "$JWT_SECRET_FILE"
+    if [ -n "$CHECKPOINT_SYNC_URL" ]; then
+        set -- "$@" --checkpoint-sync-url "$CHECKPOINT_SYNC_URL"
+    fi
+    if [ "$USE_MEV_BOOST" = "true" ]; then
+        set -- "$@" --builder-url "$MEV_BOOST_URL"
+    fi
+    if [ "$ENABLE_METRICS" = "true" ]; then
+        set -- "$@" --metrics --metrics-address 127.0.0.1 --metrics-port "$BN_METRICS_PORT"
+    fi
+    ;;
+*)
+    echo "Unknown Consensus client [$CC_CLIENT]"
+    exit 1
+    ;;
+esac
+
+exec "$@" $BN_ADDITIONAL_FLAGS
+`
+
+// The script that starts the Validator Client
+const startVcScript string = `#!/bin/sh
+# Starts the Validator Client with the Smartnode's settings from rocketpool.env and the fee recipient the node daemon maintains.
+# Generated by 'rocketpool service install --native'; run it again after changing your settings instead of editing this file.
+set -e
+
+CLIENT_NETWORK=$NETWORK
+if [ "$NETWORK" = "devnet" ]; then
+    CLIENT_NETWORK=holesky
+fi
+if [ -z "$FEE_RECIPIENT" ]; then
+    echo "The fee recipient file hasn't been created yet; make sure the node daemon (rp-node) is running."
+    exit 1
+fi
+
+case "$VC_CLIENT" in
+lighthouse)
+    set -- lighthouse validator_client --network "$CLIENT_NETWORK" --datadir "$VALIDATORS_DIR/lighthouse" --init-slashing-protection \
+        --beacon-nodes "$VC_CC_API_ENDPOINT" --suggested-fee-recipient "$FEE_RECIPIENT" --graffiti "$GRAFFITI"
+    if [ "$ENABLE_MEV_BOOST" = "true" ]; then
+        set -- "$@" --builder-proposals
+    fi
+    if [ "$DOPPELGANGER_DETECTION" = "true" ]; then
+        set -- "$@" --enable-doppelganger-protection
+    fi
+    if [ "$ENABLE_METRICS" = "true" ]; then
+        set -- "$@" --metrics --metrics-address 127.0.0.1 --metrics-port "$VC_METRICS_PORT"
+    fi
+    ;;
+lodestar)
+    set -- lodestar validator --network "$CLIENT_NETWORK" --dataDir "$VALIDATORS_DIR/lodestar" \
+        --keystoresDir "$VALIDATORS_DIR/lodestar/validators" --secretsDir "$VALIDATORS_DIR/lodestar/secrets" \
+        --beaconNodes "$VC_CC_API_ENDPOINT" --suggestedFeeRecipient "$FEE_RECIPIENT" --graffiti "$GRAFFITI"
+    if [ "$ENABLE_MEV_BOOST" = "true" ]; then
+        set -- "$@" --builder
+    fi
+    if [ "$DOPPELGANGER_DETECTION" = "true" ]; then
+        set -- "$@" --doppelgangerProtection
+    fi
+    if [ "$ENABLE_METRICS" = "true" ]; then
+        set -- "$@" --metrics --metrics.address 127.0.0.1 --metrics.port "$VC_METRICS_PORT"
+    fi
+    ;;
+nimbus)
+    set -- nimbus_validator_client --data-dir="$VALIDATORS_DIR/nimbus" \
+        --validators-dir="$VALIDATORS_DIR/nimbus/validators" --secrets-dir="$VALIDATORS_DIR/nimbus/secrets" \
+        --beacon-node="$VC_CC_API_ENDPOINT" --suggested-fee-recipient="$FEE_RECIPIENT" --graffiti="$GRAFFITI" \
+        --doppelganger-detection="$DOPPELGANGER_DETECTION"
+    if [ "$ENABLE_MEV_BOOST" = "true" ]; then
+        set -- "$@" --payload-builder=true
+    fi
+    if [ "$ENABLE_METRICS" = "true" ]; then
+        set -- "$@" --metrics --metrics-address=127.0.0.1 --metrics-port="$VC_METRICS_PORT"
+    fi
+    ;;
+prysm)
+    set -- validator --accept-terms-of-use --"$CLIENT_NETWORK" \
+        --wallet-dir "$VALIDATORS_DIR/prysm-non-hd/direct" --wallet-password-file "$VALIDATORS_DIR/prysm-non-hd/direct/accounts/secret" \
+        --beacon-rpc-provider "${CC_RPC_ENDPOINT#http://}" --suggested-fee-recipient "$FEE_RECIPIENT" --graffiti "$GRAFFITI"
+    if [ "$ENABLE_MEV_BOOST" = "true" ]; then
+        set -- "$@" --enable-builder
+    fi
+    if [ "$DOPPELGANGER_DETECTION" = "true" ]; then
+        set -- "$@" --enable-doppelganger
+    fi
+    if [ "$ENABLE_METRICS" = "true" ]; then
+        set -- "$@" --monitoring-host 127.0.0.1 --monitoring-port "$VC_METRICS_PORT"
+    else
+        set -- "$@" --disable-account-metrics
+    fi
+    ;;
+teku)
+    set -- teku validator-client --network="$CLIENT_NETWORK" --data-path="$VALIDATORS_DIR/teku" \
+        --validator-keys="$VALIDATORS_DIR/teku/keys:$VALIDATORS_DIR/teku/passwords" --validators-keystore-locking-enabled=false \
+        --beacon-node-api-endpoint="$VC_CC_API_ENDPOINT" --validators-proposer-default-fee-recipient="$FEE_RECIPIENT" --validators-graffiti="$GRAFFITI"
+    if [ "$ENABLE_MEV_BOOST" = "true" ]; then
+        set -- "$@" --validators-builder-registration-default-enabled=true
+    fi
+    if [ "$DOPPELGANGER_DETECTION" = "true" ]; then
+        set -- "$@" --doppelganger-detection-enabled=true
+    fi
+    if [ "$ENABLE_METRICS" = "true" ]; then
+        set -- "$@" --metrics-enabled --metrics-interface=127.0.0.1 --metrics-port="$VC_METRICS_PORT"
+    fi
+    ;;
+*)
+    echo "Unknown Validator Client [$VC_CLIENT]"
+    exit 1
+    ;;
+esac
+
+exec "$@" $VC_ADDITIONAL_FLAGS
+`
+
+// The script that starts MEV-Boost
+const startMevBoostScript string = `#!/bin/sh
+# Starts MEV-Boost with the Smartnode's settings from rocketpool.env.
+# Generated by 'rocketpool service install --native'; run it again after changing your settings instead of editing this file.
+set -e
+
+CLIENT_NETWORK=$NETWORK
+if [ "$NETWORK" = "devnet" ]; then
+    CLIENT_NETWORK=holesky
+fi
+
+exec mev-boost -"$CLIENT_NETWORK" -addr 127.0.0.1:"$MEV_BOOST_PORT" -relay-check -relays "$MEV_BOOST_RELAYS" $MEV_BOOST_ADDITIONAL_FLAGS
+`
+
+// Generate the systemd units, environment file, start scripts, and directories for running the Smartnode and its clients without Docker
+func installNativeService(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("No configuration detected. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) != cfgtypes.Mode_Local || cfg.ConsensusClientMode.Value.(cfgtypes.Mode) != cfgtypes.Mode_Local {
+		return fmt.Errorf("Native services can only be generated for locally managed clients. Please select them in `rocketpool service config` first.")
+	}
+	if cfg.EnableDistributedValidators.Value == true {
+		return fmt.Errorf("Distributed validators aren't supported by the native services. Please disable them in `rocketpool service config` first.")
+	}
+
+	// Get the paths
+	configPath, err := homedir.Expand(os.ExpandEnv(c.GlobalString("config-path")))
+	if err != nil {
+		return fmt.Errorf("error expanding config path: %w", err)
+	}
+	outputPath := c.String("path")
+	if outputPath == "" {
+		outputPath = filepath.Join(configPath, nativeFolder)
+	}
+	outputPath, err = homedir.Expand(outputPath)
+	if err != nil {
+		return fmt.Errorf("error expanding output path: %w", err)
+	}
+	outputPath, err = filepath.Abs(outputPath)
+	if err != nil {
+		return fmt.Errorf("error getting absolute output path: %w", err)
+	}
+	dataPath, err := homedir.Expand(os.ExpandEnv(cfg.Smartnode.DataPath.Value.(string)))
+	if err != nil {
+		return fmt.Errorf("error expanding data path: %w", err)
+	}
+	daemonPath := c.String("daemon-bin")
+	binPath := filepath.Join(outputPath, nativeBinFolder)
+	systemdPath := filepath.Join(outputPath, nativeSystemdFolder)
+	envFilePath := filepath.Join(outputPath, nativeEnvFile)
+	jwtSecretPath := filepath.Join(outputPath, nativeSecretsFolder, nativeJwtSecretFile)
+	passwordCredPath := filepath.Join(outputPath, nativeSecretsFolder, nativePasswordCredFile)
+
+	// Get the account the services run as
+	serviceUser := c.String("user")
+	if serviceUser == "" {
+		currentUser, err := user.Current()
+		if err != nil {
+			return fmt.Errorf("error getting the current user: %w", err)
+		}
+		serviceUser = currentUser.Username
+	}
+
+	// Prompt for confirmation
+	fmt.Printf("This will generate the files for running the Smartnode and its clients as systemd services instead of Docker containers in %s.\n", outputPath)
+	fmt.Printf("%sYour settings will be switched to Native Mode so the node daemon uses them. You'll need to install the client binaries yourself.%s\n\n", colorYellow, colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Create the directory layout
+	folders := []string{
+		binPath,
+		systemdPath,
+		filepath.Join(outputPath, nativeSecretsFolder),
+		filepath.Join(outputPath, nativeDataFolder, "execution"),
+		filepath.Join(outputPath, nativeDataFolder, "consensus"),
+		filepath.Join(dataPath, "validators"),
+	}
+	for _, folder := range folders {
+		err = os.MkdirAll(folder, 0750)
+		if err != nil {
+			return fmt.Errorf("error creating folder [%s]: %w", folder, err)
+		}
+	}
+
+	// Create the JWT secret the EC and BN share, keeping an existing one
+	if _, err := os.Stat(jwtSecretPath); os.IsNotExist(err) {
+		secret, err := eth2.GenerateJwtSecret()
+		if err != nil {
+			return err
+		}
+		err = os.WriteFile(jwtSecretPath, secret, 0600)
+		if err != nil {
+			return fmt.Errorf("error writing JWT secret: %w", err)
+		}
+	}
+
+	// Switch the config to Native Mode, pointing it at the native services
+	cfg.IsNativeMode = true
+	cfg.Native.EcHttpUrl.Value = fmt.Sprintf("http://127.0.0.1:%d", cfg.ExecutionCommon.HttpPort.Value)
+	cfg.Native.EcWsUrl.Value = fmt.Sprintf("ws://127.0.0.1:%d", cfg.ExecutionCommon.WsPort.Value)
+	cfg.Native.CcHttpUrl.Value = fmt.Sprintf("http://127.0.0.1:%d", cfg.ConsensusCommon.ApiPort.Value)
+	consensusClient := cfg.ConsensusClient.Value.(cfgtypes.ConsensusClient)
+	if consensusClient == cfgtypes.ConsensusClient_Grandine {
+		// Grandine uses Lighthouse's Validator Client
+		consensusClient = cfgtypes.ConsensusClient_Lighthouse
+	}
+	cfg.Native.ConsensusClient.Value = consensusClient
+	cfg.Native.ValidatorRestartCommand.Value = filepath.Join(binPath, nativeRestartVcScript)
+	cfg.Native.ValidatorStopCommand.Value = filepath.Join(binPath, nativeStopVcScript)
+
+	// Write the environment file
+	envVars := cfg.GenerateNativeEnvironmentVariables()
+	envVars["ROCKETPOOL_DATA_FOLDER"] = dataPath
+	envVars["EC_DATA_DIR"] = filepath.Join(outputPath, nativeDataFolder, "execution")
+	envVars["BN_DATA_DIR"] = filepath.Join(outputPath, nativeDataFolder, "consensus")
+	envVars["VALIDATORS_DIR"] = filepath.Join(dataPath, "validators")
+	envVars["JWT_SECRET_FILE"] = jwtSecretPath
+	err = os.WriteFile(envFilePath, []byte(getNativeEnvFile(envVars)), 0640)
+	if err != nil {
+		return fmt.Errorf("error writing environment file: %w", err)
+	}
+
+	// Write the start scripts
+	useMevBoost := envVars["ENABLE_MEV_BOOST"] == "true" && cfg.MevBoost.Mode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local
+	scripts := map[string]string{
+		"start-ec.sh":         startEcScript,
+		"start-bn.sh":         startBnScript,
+		"start-vc.sh":         startVcScript,
+		nativeRestartVcScript: fmt.Sprintf("#!/bin/sh\nsudo systemctl restart %svalidator\n", nativeUnitPrefix),
+		nativeStopVcScript:    fmt.Sprintf("#!/bin/sh\nsudo systemctl stop %svalidator\n", nativeUnitPrefix),
+	}
+	if useMevBoost {
+		scripts["start-mev-boost.sh"] = startMevBoostScript
+	}
+	for filename, contents := range scripts {
+		scriptPath := filepath.Join(binPath, filename)
+		err = os.WriteFile(scriptPath, []byte(contents), 0755)
+		if err != nil {
+			return fmt.Errorf("error writing script [%s]: %w", scriptPath, err)
+		}
+	}
+
+	// Write the systemd units
+	settingsPath := filepath.Join(configPath, rocketpool.SettingsFile)
+	units := []nativeUnit{
+		{
+			name:        "eth1",
+			description: "Rocket Pool Execution client",
+			execStart:   filepath.Join(binPath, "start-ec.sh"),
+			envFiles:    []string{envFilePath},
+		},
+		{
+			name:        "eth2",
+			description: "Rocket Pool Beacon Node",
+			after:       []string{nativeUnitPrefix + "eth1.service"},
+			execStart:   filepath.Join(binPath, "start-bn.sh"),
+			envFiles:    []string{envFilePath},
+		},
+		{
+			name:             "validator",
+			description:      "Rocket Pool Validator Client",
+			after:            []string{nativeUnitPrefix + "eth2.service"},
+			execStart:        filepath.Join(binPath, "start-vc.sh"),
+			envFiles:         []string{envFilePath},
+			optionalEnvFiles: []string{filepath.Join(dataPath, "validators", config.NativeFeeRecipientFilename)},
+		},
+		{
+			name:        "node",
+			description: "Rocket Pool node daemon",
+			after:       []string{nativeUnitPrefix + "eth1.service", nativeUnitPrefix + "eth2.service"},
+			execStart:   fmt.Sprintf("%s --settings %s node", daemonPath, settingsPath),
+		},
+		{
+			name:        "watchtower",
+			description: "Rocket Pool watchtower daemon (Oracle DAO members only)",
+			after:       []string{nativeUnitPrefix + "eth1.service", nativeUnitPrefix + "eth2.service"},
+			execStart:   fmt.Sprintf("%s --settings %s watchtower", daemonPath, settingsPath),
+		},
+	}
+	// The daemons can't reach the OS keychain without a D-Bus session, so they load the password from an encrypted credential instead
+	useKeychain := cfg.Smartnode.UsePasswordKeychain.Value == true
+	if useKeychain {
+		for i := range units {
+			if units[i].name == "node" || units[i].name == "watchtower" {
+				units[i].credentials = map[string]string{passwords.SystemdCredentialName: passwordCredPath}
+			}
+		}
+	}
+	if useMevBoost {
+		units = append(units, nativeUnit{
+			name:        "mev-boost",
+			description: "Rocket Pool MEV-Boost",
+			execStart:   filepath.Join(binPath, "start-mev-boost.sh"),
+			envFiles:    []string{envFilePath},
+		})
+	}
+	unitNames := []string{}
+	for _, unit := range units {
+		unitName := nativeUnitPrefix + unit.name + ".service"
+		unitPath := filepath.Join(systemdPath, unitName)
+		err = os.WriteFile(unitPath, []byte(getSystemdUnit(unit, serviceUser)), 0644)
+		if err != nil {
+			return fmt.Errorf("error writing systemd unit [%s]: %w", unitPath, err)
+		}
+		if unit.name != "watchtower" {
+			unitNames = append(unitNames, unitName)
+		}
+	}
+
+	// Save the config now that everything it points to exists
+	err = rp.SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error saving Native Mode settings: %w", err)
+	}
+
+	// Report next steps
+	fmt.Printf("\n%sThe native services were generated in %s.%s\n", colorGreen, outputPath, colorReset)
+	fmt.Printf("%s\n=== Next Steps ===%s\n", colorLightBlue, colorReset)
+	fmt.Printf("1. Install rocketpoold to %s, and the %s, %s, and %s binaries somewhere on the PATH of the '%s' account.\n", daemonPath, envVars["EC_CLIENT"], envVars["CC_CLIENT"], envVars["VC_CLIENT"], serviceUser)
+	fmt.Printf("2. Let '%s' restart and stop the Validator Client without a password (for example with a sudoers entry for `systemctl restart %svalidator` and `systemctl stop %svalidator`).\n", serviceUser, nativeUnitPrefix, nativeUnitPrefix)
+	fmt.Printf("3. Install and start the services:\n\n")
+	fmt.Printf("\tsudo cp %s/*.service /etc/systemd/system/\n", systemdPath)
+	fmt.Println("\tsudo systemctl daemon-reload")
+	if useKeychain {
+		fmt.Printf("\n%sYour wallet password is stored in your OS keychain, which the daemons can't reach when systemd runs them.%s\n", colorYellow, colorReset)
+		fmt.Println("Before starting the services, save the password as an encrypted credential that only systemd can decrypt:")
+		fmt.Printf("\n\tsystemd-ask-password -n \"Node wallet password:\" | sudo systemd-creds encrypt --name=%s - %s\n\n", passwords.SystemdCredentialName, passwordCredPath)
+	}
+	fmt.Printf("\tsudo systemctl enable --now %s\n\n", strings.Join(unitNames, " "))
+	fmt.Printf("Oracle DAO members should enable %swatchtower.service as well.\n", nativeUnitPrefix)
+	fmt.Printf("Use `rocketpool --daemon-path %s` to run commands against the native node daemon.\n", daemonPath)
+	fmt.Println("Run this command again whenever you change your settings so the services pick up the changes.")
+	return nil
+
+}
+
+// Get the contents of the environment file the native services load, in systemd's EnvironmentFile format
+func getNativeEnvFile(envVars map[string]string) string {
+	names := make([]string, 0, len(envVars))
+	for name := range envVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var builder strings.Builder
+	builder.WriteString("# Generated by 'rocketpool service install --native' from your Smartnode settings; changes will be overwritten.\n")
+	for _, name := range names {
+		value := strings.ReplaceAll(envVars[name], `\`, `\\`)
+		value = strings.ReplaceAll(value, `"`, `\"`)
+		builder.WriteString(fmt.Sprintf("%s=\"%s\"\n", name, value))
+	}
+	return builder.String()
+}
+
+// Get the contents of a systemd unit for a native service
+func getSystemdUnit(unit nativeUnit, serviceUser string) string {
+	var builder strings.Builder
+	builder.WriteString("[Unit]\n")
+	builder.WriteString(fmt.Sprintf("Description=%s\n", unit.description))
+	builder.WriteString(fmt.Sprintf("After=%s\n", strings.Join(append([]string{"network-online.target"}, unit.after...), " ")))
+	builder.WriteString("Wants=network-online.target\n\n")
+
+	builder.WriteString("[Service]\n")
+	builder.WriteString("Type=simple\n")
+	builder.WriteString(fmt.Sprintf("User=%s\n", serviceUser))
+	for _, envFile := range unit.envFiles {
+		builder.WriteString(fmt.Sprintf("EnvironmentFile=%s\n", envFile))
+	}
+	for _, envFile := range unit.optionalEnvFiles {
+		builder.WriteString(fmt.Sprintf("EnvironmentFile=-%s\n", envFile))
+	}
+	credentialNames := make([]string, 0, len(unit.credentials))
+	for name := range unit.credentials {
+		credentialNames = append(credentialNames, name)
+	}
+	sort.Strings(credentialNames)
+	for _, name := range credentialNames {
+		builder.WriteString(fmt.Sprintf("LoadCredentialEncrypted=%s:%s\n", name, unit.credentials[name]))
+	}
+	builder.WriteString(fmt.Sprintf("ExecStart=%s\n", unit.execStart))
+	builder.WriteString("Restart=always\n")
+	builder.WriteString("RestartSec=5\n")
+	builder.WriteString("TimeoutStopSec=300\n\n")
+
+	builder.WriteString("[Install]\n")
+	builder.WriteString("WantedBy=multi-user.target\n")
+	return builder.String()
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Settings
+const (
+	nodeExportFilenamePattern        string = "rocketpool-node-export-%d.tar.gz%s"
+	nodeImportSlashingProtectionFile string = "node-import-slashing-protection-%d.json"
+)
+
+// Export everything needed to run the node on another machine into a single encrypted archive
+func exportNode(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Get the encryption tool
+	extension, err := checkEncryptionTool(c)
+	if err != nil {
+		return err
+	}
+
+	// Find the files to export
+	dataPath, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return fmt.Errorf("error expanding data path: %w", err)
+	}
+	dataFiles := []string{}
+	for _, file := range backupDataFiles {
+		if _, err := os.Stat(filepath.Join(dataPath, file)); err == nil {
+			dataFiles = append(dataFiles, file)
+		}
+	}
+	if len(dataFiles) == 0 || dataFiles[0] != "wallet" {
+		return fmt.Errorf("The node wallet hasn't been initialized yet, so there is nothing to export.")
+	}
+	if cfg.Smartnode.UsePasswordKeychain.Value == true {
+		fmt.Printf("%sYour node wallet's password is stored in your OS keychain, so it won't be included in the export. You will need it to import the node.%s\n\n", colorYellow, colorReset)
+	}
+
+	// Get the node address so the import can be checked against it
+	manifest := rocketpool.NodeExportManifest{
+		SmartnodeVersion: shared.RocketPoolVersion,
+		Network:          string(cfg.Smartnode.Network.Value.(cfgtypes.Network)),
+		CreatedAt:        time.Now().UTC(),
+	}
+	status, err := rp.WalletStatus()
+	if err != nil {
+		fmt.Printf("%sCouldn't get the node address, so it won't be checked when the node is imported: %s%s\n\n", colorYellow, err.Error(), colorReset)
+	} else {
+		manifest.NodeAddress = status.AccountAddress.Hex()
+	}
+
+	// Export the slashing protection
+	keepRunning := c.Bool("keep-running")
+	managesValidator := managesValidatorClient(cfg)
+	if managesValidator {
+		fmt.Println("This will stop your Validator client and export its slashing protection database along with your node wallet, validator keys and settings.")
+		if keepRunning {
+			fmt.Printf("%sThe Validator client will be started again afterwards. Any attestations it makes after the export won't be in the exported slashing protection.%s\n\n", colorYellow, colorReset)
+		} else {
+			fmt.Printf("%sThe Validator client will be left stopped so your validators aren't running on two machines at once. Use --keep-running if you only want a copy of the node.%s\n\n", colorYellow, colorReset)
+		}
+	} else {
+		fmt.Printf("%sThe Smartnode doesn't manage your Validator client. Please stop it before exporting so the exported slashing protection is complete.%s\n\n", colorYellow, colorReset)
+	}
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to export your node?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	slashingProtectionPath, err := exportSlashingProtectionFile(rp, cfg, keepRunning)
+	if err != nil {
+		return err
+	}
+	if slashingProtectionPath == "" && !(c.Bool("yes") || cliutils.Confirm("Would you like to export the node without slashing protection?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	manifest.HasSlashingProtection = (slashingProtectionPath != "")
+	err = rp.PrepareNodeExport(slashingProtectionPath)
+	if err != nil {
+		return err
+	}
+
+	// Get the output path
+	outputPath := c.String("output")
+	if outputPath == "" {
+		outputPath = fmt.Sprintf(nodeExportFilenamePattern, time.Now().Unix(), extension)
+	}
+	outputPath, err = filepath.Abs(outputPath)
+	if err != nil {
+		return fmt.Errorf("error getting export path: %w", err)
+	}
+
+	// Create the export
+	encryptCmd := getEncryptCommand(c, outputPath)
+	fmt.Println("Exporting your settings, custom overrides, node wallet and validator keys...")
+	err = rp.CreateNodeExport(dataPath, dataFiles, manifest, encryptCmd)
+	if err != nil {
+		return fmt.Errorf("error creating node export: %w", err)
+	}
+	fmt.Printf("%sYour encrypted node export has been saved to %s.%s\n", colorGreen, outputPath, colorReset)
+
+	// Upload it
+	uploadUrl := c.String("upload-url")
+	if uploadUrl != "" {
+		fmt.Println("Uploading node export...")
+		err = rocketpool.UploadBackup(outputPath, uploadUrl)
+		if err != nil {
+			return fmt.Errorf("the node export was saved locally but could not be uploaded: %w", err)
+		}
+		fmt.Printf("%sYour node export has been uploaded.%s\n", colorGreen, colorReset)
+	}
+
+	fmt.Println("Run `rocketpool service install` on your new machine, then `rocketpool service import-node` with this file to move your node there.")
+	if managesValidator && !keepRunning {
+		fmt.Printf("%sYour Validator client has been left stopped. Don't start it on this machine again once the node has been imported on another one, or your validators will be slashed!%s\n", colorYellow, colorReset)
+	}
+	fmt.Printf("\n%sAnyone who can decrypt this export has full control of your node wallet and validators. Keep your decryption key or passphrase somewhere safe and separate from the export.%s\n", colorYellow, colorReset)
+	return nil
+
+}
+
+// Import a node export onto this machine, verifying its contents and loading its slashing protection before anything can sign
+func importNode(c *cli.Context, source string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Don't overwrite an existing node
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if !isNew {
+		if _, err := os.Stat(cfg.Smartnode.GetWalletPathInCLI()); err == nil {
+			return fmt.Errorf("This machine already has a node wallet. Nodes can only be imported onto a machine without one.")
+		}
+		fmt.Printf("%sThis machine already has Smartnode settings; they will be replaced with the settings in the export.%s\n", colorYellow, colorReset)
+	}
+
+	// Download the export if necessary
+	exportPath, cleanup, err := getBackupFile(source)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	// Build the decryption command
+	decryptCmd, err := getDecryptCommand(c, source, exportPath)
+	if err != nil {
+		return err
+	}
+
+	// Extract and verify it
+	fmt.Println("Decrypting and verifying node export...")
+	export, err := rp.ExtractNodeExport(decryptCmd)
+	if err != nil {
+		_ = rp.DeleteNodeExportStaging()
+		return fmt.Errorf("error extracting node export: %w", err)
+	}
+	manifest := export.Manifest
+	dataPath := export.Config.Smartnode.DataPath.Value.(string)
+
+	fmt.Printf("%sAll %d files in the export were verified.%s\n\n", colorGreen, manifest.FileCount, colorReset)
+	fmt.Printf("Created:             %s\n", manifest.CreatedAt.Local().Format(time.RFC1123))
+	fmt.Printf("Network:             %s\n", manifest.Network)
+	fmt.Printf("Smartnode version:   %s\n", manifest.SmartnodeVersion)
+	if manifest.NodeAddress != "" {
+		fmt.Printf("Node address:        %s\n", manifest.NodeAddress)
+	}
+	fmt.Printf("Slashing protection: %t\n\n", manifest.HasSlashingProtection)
+	if manifest.SmartnodeVersion != shared.RocketPoolVersion {
+		fmt.Printf("%sThe export was created with Smartnode v%s, but this machine has v%s. Its settings will be upgraded when you start the Smartnode.%s\n\n", colorYellow, manifest.SmartnodeVersion, shared.RocketPoolVersion, colorReset)
+	}
+
+	fmt.Printf("The import will restore your settings and custom overrides, and put your node wallet and validator keys in %s.\n", dataPath)
+	fmt.Printf("%sMake sure your old machine's Validator client is stopped and will never be started again before you start your Smartnode here, or your validators will be slashed!%s\n\n", colorRed, colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm("Would you like to import the node?")) {
+		fmt.Println("Cancelled.")
+		return rp.DeleteNodeExportStaging()
+	}
+
+	// Restore the files
+	err = rp.RestoreNodeExportFiles(dataPath)
+	if err != nil {
+		return fmt.Errorf("error restoring node export: %w", err)
+	}
+
+	// The password isn't in the export if it was stored in the OS keychain
+	err = restoreKeychainPassword(rp, export.Config)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%sYour node's files have been restored.%s\n\n", colorGreen, colorReset)
+
+	// Create the containers without starting them, so nothing can sign until the slashing protection is loaded
+	if !export.Config.IsNativeMode {
+		fmt.Println("Creating the Smartnode's containers...")
+		err = rp.CreateService(getComposeFiles(c))
+		if err != nil {
+			return fmt.Errorf("error creating the Smartnode's containers: %w", err)
+		}
+		fmt.Println()
+	}
+
+	// Load the slashing protection into the Validator client before it ever starts
+	managesValidator := !export.Config.IsNativeMode && export.Config.EnableWeb3Signer.Value != true
+	if manifest.HasSlashingProtection {
+		if managesValidator {
+			err = importNodeSlashingProtection(rp, export)
+			if err != nil {
+				return err
+			}
+		} else {
+			protectionPath, err := saveNodeSlashingProtection(export)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("The Smartnode doesn't manage your Validator client, so the exported slashing protection has been saved to %s. Import it into your Validator client before it starts validating.\n\n", protectionPath)
+		}
+	}
+
+	// Check that the restored wallet is the exported one
+	if manifest.NodeAddress != "" && !export.Config.IsNativeMode {
+		prefix, err := getContainerPrefix(rp)
+		if err != nil {
+			return fmt.Errorf("Error getting container prefix: %w", err)
+		}
+		_, err = rp.StartContainer(prefix + ApiContainerSuffix)
+		if err != nil {
+			return fmt.Errorf("Error starting API container: %w", err)
+		}
+	}
+	if manifest.NodeAddress != "" {
+		status, err := rp.WalletStatus()
+		if err != nil {
+			fmt.Printf("%sCouldn't check the restored node wallet: %s%s\n\n", colorYellow, err.Error(), colorReset)
+		} else if status.AccountAddress.Hex() != manifest.NodeAddress {
+			return fmt.Errorf("the restored node wallet has address %s, but the export was created for %s", status.AccountAddress.Hex(), manifest.NodeAddress)
+		} else {
+			fmt.Printf("%sThe restored node wallet matches the exported node address %s.%s\n\n", colorGreen, manifest.NodeAddress, colorReset)
+		}
+	}
+
+	fmt.Printf("%sYour node has been imported.%s\n", colorGreen, colorReset)
+	fmt.Println("Please run `rocketpool service start` to start your Smartnode.")
+	return nil
+
+}
+
+// Import the exported slashing protection into the Validator client without starting it, saving it for a manual import if that fails
+func importNodeSlashingProtection(rp *rocketpool.Client, export *rocketpool.NodeExport) error {
+	fmt.Println("Importing slashing protection into the Validator client...")
+	err := importSlashingProtectionFile(rp, export.Config, export.SlashingProtection, false)
+	if err == nil {
+		fmt.Printf("%sThe exported slashing protection has been imported.%s\n\n", colorGreen, colorReset)
+		return nil
+	}
+
+	// Keep the file around so it can be imported by hand
+	protectionPath, saveErr := saveNodeSlashingProtection(export)
+	if saveErr != nil {
+		return fmt.Errorf("error importing slashing protection (%s), and it couldn't be saved: %w", err.Error(), saveErr)
+	}
+	return fmt.Errorf("error importing slashing protection: %w\nThe exported slashing protection has been saved to %s. Please import it with `rocketpool service import-slashing-protection %s` before starting the Smartnode.", err, protectionPath, protectionPath)
+}
+
+// Save the exported slashing protection to the key exports folder
+func saveNodeSlashingProtection(export *rocketpool.NodeExport) (string, error) {
+	exportsPath, err := homedir.Expand(export.Config.Smartnode.GetKeyExportsPathInCLI())
+	if err != nil {
+		return "", fmt.Errorf("error expanding key exports path: %w", err)
+	}
+	err = os.MkdirAll(exportsPath, slashingProtectionDirMode)
+	if err != nil {
+		return "", fmt.Errorf("error creating key exports folder [%s]: %w", exportsPath, err)
+	}
+	protectionPath := filepath.Join(exportsPath, fmt.Sprintf(nodeImportSlashingProtectionFile, time.Now().Unix()))
+	err = os.WriteFile(protectionPath, export.SlashingProtection, slashingProtectionFileMode)
+	if err != nil {
+		return "", fmt.Errorf("error saving slashing protection to [%s]: %w", protectionPath, err)
+	}
+	return protectionPath, nil
+}
@@ -19,6 +19,7 @@ import (
 	cliconfig "github.com/rocket-pool/smartnode/rocketpool-cli/service/config"
 	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/grafana"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -55,6 +56,11 @@ const (
 func installService(c *cli.Context) error {
 	dataPath := ""
 
+	// Native services are generated from the current settings instead of installed
+	if c.Bool("native") {
+		return installNativeService(c)
+	}
+
 	// Prompt for confirmation
 	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf(
 		"The Rocket Pool service will be installed --Version: %s\n\n%sIf you're upgrading, your existing configuration will be backed up and preserved.\nAll of your previous settings will be migrated automatically.%s\nAre you sure you want to continue?",
@@ -81,8 +87,14 @@ func installService(c *cli.Context) error {
 		}
 	}
 
+	// Profiles are installed into their own config directory unless a path was provided
+	installPath := c.String("path")
+	if installPath == "" && c.GlobalString("profile") != "" {
+		installPath = c.GlobalString("config-path")
+	}
+
 	// Install service
-	err = rp.InstallService(c.Bool("verbose"), c.Bool("no-deps"), c.String("version"), c.String("path"), dataPath)
+	err = rp.InstallService(c.Bool("verbose"), c.Bool("no-deps"), c.String("version"), installPath, dataPath)
 	if err != nil {
 		return err
 	}
@@ -203,6 +215,10 @@ func configureService(c *cli.Context) error {
 		return fmt.Errorf("error expanding config path [%s]: %w", configPath, err)
 	}
 	_, err = os.Stat(path)
+	if os.IsNotExist(err) && c.GlobalString("profile") != "" {
+		fmt.Printf("%sThe directory for profile '%s' [%s] does not exist yet.\nPlease run `rocketpool --profile %s service install` to set it up first.%s\n", colorYellow, c.GlobalString("profile"), path, c.GlobalString("profile"), colorReset)
+		return nil
+	}
 	if os.IsNotExist(err) {
 		fmt.Printf("%sYour configured Rocket Pool directory of [%s] does not exist.\nPlease follow the instructions at https://docs.rocketpool.net/guides/node/docker.html to install the Smartnode.%s\n", colorYellow, path, colorReset)
 		return nil
@@ -235,6 +251,11 @@ func configureService(c *cli.Context) error {
 		}
 	}
 
+	// Print the effective config without saving it
+	if c.Bool("check") {
+		return checkConfig(c, cfg, isNew)
+	}
+
 	// Save the config and exit in headless mode
 	if c.NumFlags() > 0 {
 		err := configureHeadless(c, cfg)
@@ -293,6 +314,11 @@ func configureService(c *cli.Context) error {
 			return nil
 		}
 
+		// Explain how to move to Reth, since it can't reuse another client's chain data
+		if !isNew {
+			printRethMigrationNotice(md.PreviousConfig, md.Config)
+		}
+
 		// Query for service start if this is a new installation
 		if isNew {
 			if !cliutils.Confirm("Would you like to start the Smartnode services automatically now?") {
@@ -333,6 +359,22 @@ func configureService(c *cli.Context) error {
 	return err
 }
 
+// Print guidance for switching from another locally-managed Execution client to Reth
+func printRethMigrationNotice(oldCfg *config.RocketPoolConfig, newCfg *config.RocketPoolConfig) {
+	if newCfg.ExecutionClientMode.Value.(cfgtypes.Mode) != cfgtypes.Mode_Local || newCfg.ExecutionClient.Value.(cfgtypes.ExecutionClient) != cfgtypes.ExecutionClient_Reth {
+		return
+	}
+	if oldCfg.ExecutionClientMode.Value.(cfgtypes.Mode) != cfgtypes.Mode_Local || oldCfg.ExecutionClient.Value.(cfgtypes.ExecutionClient) == cfgtypes.ExecutionClient_Reth {
+		return
+	}
+
+	fmt.Printf("%sNOTE: You have switched your Execution client to Reth.\n", colorYellow)
+	fmt.Println("Reth cannot read the chain data from your previous client (such as Geth's data directory), so it must sync from scratch.")
+	fmt.Println("Once your changes have been applied, run `rocketpool service resync-eth1` to remove the old chain data and start syncing Reth.")
+	fmt.Println("If you have fallback clients enabled, your node will use them while Reth syncs; otherwise your validators will be offline until it finishes.")
+	fmt.Printf("If you want to keep your old chain data, export it first with `rocketpool service export-eth1-data <target-folder>` so you can switch back later.%s\n\n", colorReset)
+}
+
 // Updates a configuration from the provided CLI arguments headlessly
 func configureHeadless(c *cli.Context, cfg *config.RocketPoolConfig) error {
 
@@ -604,6 +646,18 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		}
 	}
 
+	// Verify the checkpoint sync provider if the Consensus client is about to be created and sync from it
+	if !cfg.IsNativeMode && cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		beaconContainerName := cfg.Smartnode.ProjectName.Value.(string) + BeaconContainerSuffix
+		_, err := rp.GetDockerStatus(beaconContainerName)
+		if err != nil {
+			err = verifyCheckpointSyncProvider(c, cfg)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	// Write a note on doppelganger protection
 	doppelgangerEnabled, err := cfg.IsDoppelgangerEnabled()
 	if err != nil {
@@ -618,6 +672,17 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		return err
 	}
 
+	// Update the dashboards in Grafana if they're out of date
+	grafanaToken := cfg.Grafana.ApiToken.Value.(string)
+	if metricsEnabled && !cfg.IsNativeMode && cfg.Grafana.ProvisionDashboards.Value.(bool) && grafanaToken != "" {
+		updated, err := provisionGrafana(cfg, grafanaToken, false)
+		if err != nil {
+			fmt.Printf("%sWARNING: couldn't provision the Grafana dashboards: %s\nYou can try again with `rocketpool service provision-dashboards`.%s\n", colorYellow, err.Error(), colorReset)
+		} else if updated {
+			fmt.Printf("Updated the Grafana dashboards to v%d.\n", grafana.DashboardsVersion)
+		}
+	}
+
 	// Remove the upgrade flag if it's there
 	return rp.RemoveUpgradeFlagFile()
 
@@ -1003,6 +1068,9 @@ func pruneExecutionClient(c *cli.Context) error {
 	case cfgtypes.ExecutionClient_Besu:
 		fmt.Println("You are using Besu as your Execution client.\nBesu does not need pruning.")
 		return nil
+	case cfgtypes.ExecutionClient_Reth:
+		fmt.Println("You are using Reth as your Execution client.\nReth prunes itself continuously and does not need manual pruning.")
+		return nil
 	case cfgtypes.ExecutionClient_Geth:
 		if cfg.Geth.EnablePbss.Value == true {
 			fmt.Println("You have PBSS enabled for Geth. Pruning is no longer required when using PBSS.")
@@ -1183,12 +1251,26 @@ func serviceLogs(c *cli.Context, aliasedNames ...string) error {
 		serviceNames = append(serviceNames, trueName)
 	}
 
+	// Get the options
+	options := rocketpool.LogOptions{
+		Tail:   c.String("tail"),
+		Since:  c.String("since"),
+		Follow: !c.Bool("no-follow"),
+	}
+	if c.String("filter") != "" {
+		filter, err := regexp.Compile(c.String("filter"))
+		if err != nil {
+			return fmt.Errorf("Invalid filter '%s': %w", c.String("filter"), err)
+		}
+		options.Filter = filter
+	}
+
 	// Get RP client
 	rp := rocketpool.NewClientFromCtx(c)
 	defer rp.Close()
 
 	// Print service logs
-	return rp.PrintServiceLogs(getComposeFiles(c), c.String("tail"), serviceNames...)
+	return rp.PrintServiceLogs(options, serviceNames...)
 
 }
 
@@ -1266,6 +1348,8 @@ func serviceVersion(c *cli.Context) error {
 			eth1ClientString = fmt.Sprintf(format, "Nethermind", cfg.Nethermind.ContainerTag.Value.(string))
 		case cfgtypes.ExecutionClient_Besu:
 			eth1ClientString = fmt.Sprintf(format, "Besu", cfg.Besu.ContainerTag.Value.(string))
+		case cfgtypes.ExecutionClient_Reth:
+			eth1ClientString = fmt.Sprintf(format, "Reth", cfg.Reth.ContainerTag.Value.(string))
 		default:
 			return fmt.Errorf("unknown local execution client [%v]", eth1Client)
 		}
@@ -1285,6 +1369,8 @@ func serviceVersion(c *cli.Context) error {
 		eth2Client := cfg.ConsensusClient.Value.(cfgtypes.ConsensusClient)
 		format := "%s (Locally managed)\n\tImage: %s"
 		switch eth2Client {
+		case cfgtypes.ConsensusClient_Grandine:
+			eth2ClientString = fmt.Sprintf(format+"\n\tVC image: %s", "Grandine", cfg.Grandine.BnContainerTag.Value.(string), cfg.Grandine.VcContainerTag.Value.(string))
 		case cfgtypes.ConsensusClient_Lighthouse:
 			eth2ClientString = fmt.Sprintf(format, "Lighthouse", cfg.Lighthouse.ContainerTag.Value.(string))
 		case cfgtypes.ConsensusClient_Lodestar:
@@ -1407,7 +1493,7 @@ func resyncEth1(c *cli.Context) error {
 	fmt.Printf("Rebuilding %s and restarting Rocket Pool...\n", executionContainerName)
 	err = startService(c, true)
 	if err != nil {
-		return fmt.Errorf("Error starting Rocket Pool: %s", err)
+		return fmt.Errorf("Error starting Rocket Pool: %w", err)
 	}
 
 	fmt.Printf("\nDone! Your main ETH1 client is now resyncing. You can follow its progress with `rocketpool service logs eth1`.\n")
@@ -1471,7 +1557,7 @@ func resyncEth2(c *cli.Context) error {
 		if checkpointSyncUrl == "" {
 			fmt.Printf("%sYou do not have a checkpoint sync provider configured.\nIf you have active validators, they %swill be considered offline and will lose ETH%s%s until your ETH2 client finishes syncing.\nWe strongly recommend you configure a checkpoint sync provider with `rocketpool service config` so it syncs instantly before running this.%s\n\n", colorRed, colorBold, colorReset, colorRed, colorReset)
 		} else {
-			fmt.Printf("You have a checkpoint sync provider configured (%s).\nYour ETH2 client will use it to sync to the head of the Beacon Chain instantly after being rebuilt.\n\n", checkpointSyncUrl)
+			fmt.Printf("You have a checkpoint sync provider configured (%s).\nYour ETH2 client will use it to sync to the head of the Beacon Chain instantly after being rebuilt.\nIt will be cross-checked against your Checkpoint Sync Verification URL or fallback Consensus client first.\n\n", checkpointSyncUrl)
 		}
 	}
 
@@ -1528,7 +1614,7 @@ func resyncEth2(c *cli.Context) error {
 	fmt.Printf("Rebuilding %s and restarting Rocket Pool...\n", beaconContainerName)
 	err = startService(c, true)
 	if err != nil {
-		return fmt.Errorf("Error starting Rocket Pool: %s", err)
+		return fmt.Errorf("Error starting Rocket Pool: %w", err)
 	}
 
 	fmt.Printf("\nDone! Your ETH2 client is now resyncing. You can follow its progress with `rocketpool service logs eth2`.\n")
@@ -1709,6 +1795,11 @@ func importEcData(c *cli.Context, sourceDir string) error {
 	fmt.Println("If your execution client is running, it will be shut down.")
 	fmt.Println("Once the import is complete, your execution client will restart automatically.\n")
 
+	// Reth has its own database format
+	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local && cfg.ExecutionClient.Value.(cfgtypes.ExecutionClient) == cfgtypes.ExecutionClient_Reth {
+		fmt.Printf("%sNOTE: You are using Reth, which cannot read chain data from Geth, Nethermind, or Besu.\nOnly import data that was previously exported from Reth.%s\n\n", colorYellow, colorReset)
+	}
+
 	// Get the volume to import into
 	executionContainerName := prefix + ExecutionContainerSuffix
 	volume, err := rp.GetClientVolumeName(executionContainerName, clientDataVolumeName)
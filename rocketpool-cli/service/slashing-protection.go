@@ -0,0 +1,334 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Settings
+const (
+	slashingProtectionFilename      string = "slashing_protection.json"
+	slashingProtectionExportPattern string = "slashing-protection-%d"
+	slashingProtectionImportPattern string = "slashing-protection-import-%d"
+	slashingProtectionFormatVersion string = "5"
+	slashingProtectionDirMode              = 0755
+	slashingProtectionFileMode             = 0644
+	validatorDataPath               string = "/validators"
+	nimbusValidatorClientImageName  string = "nimbus-validator-client"
+	nimbusBeaconNodeImageName       string = "nimbus-eth2"
+	dockerNetworkSuffix             string = "_net"
+)
+
+// The parts of an EIP-3076 interchange file that are checked before importing it
+type slashingProtectionFile struct {
+	Metadata struct {
+		InterchangeFormatVersion string `json:"interchange_format_version"`
+		GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+	} `json:"metadata"`
+	Data []struct {
+		Pubkey string `json:"pubkey"`
+	} `json:"data"`
+}
+
+// Export the node's slashing protection in the EIP-3076 interchange format
+func exportSlashingProtection(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	if managesValidatorClient(cfg) {
+		fmt.Println("This will stop your Validator client, export its slashing protection database in the EIP-3076 interchange format, and start it again.")
+		fmt.Printf("%sYour validators will miss attestations while the Validator client is stopped.%s\n\n", colorYellow, colorReset)
+	} else {
+		fmt.Println("The Smartnode doesn't manage your Validator client's slashing protection database, so this will export an EIP-3076 slashing protection file built from the chain instead.")
+		fmt.Printf("%sIt blocks your validators from signing anything up to the current epoch rather than listing what they actually signed.%s\n\n", colorYellow, colorReset)
+	}
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to export your slashing protection?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Run the export
+	exportFile, err := exportSlashingProtectionFile(rp, cfg, true)
+	if err != nil {
+		return err
+	}
+	if exportFile == "" {
+		return fmt.Errorf("your slashing protection could not be exported")
+	}
+	fmt.Printf("\n%sDone! Your slashing protection has been exported to %s.%s\n", colorGreen, exportFile, colorReset)
+	fmt.Println("You can import it into any Validator client that supports EIP-3076 before it starts attesting with these keys.")
+	return nil
+
+}
+
+// Export the slashing protection for the node's validators into the key exports folder, returning the path of the exported file.
+// The Validator client's own database has every record it signed, so it's used if the Smartnode manages the Validator client; otherwise the file is built from the chain.
+// Returns an empty string if the slashing protection couldn't be exported.
+func exportSlashingProtectionFile(rp *rocketpool.Client, cfg *config.RocketPoolConfig, startValidator bool) (string, error) {
+	exportsPath, err := homedir.Expand(cfg.Smartnode.GetKeyExportsPathInCLI())
+	if err != nil {
+		return "", fmt.Errorf("error expanding key exports path: %w", err)
+	}
+
+	if managesValidatorClient(cfg) {
+		exportDir := filepath.Join(exportsPath, fmt.Sprintf(slashingProtectionExportPattern, time.Now().Unix()))
+		err = os.MkdirAll(exportDir, slashingProtectionDirMode)
+		if err != nil {
+			return "", fmt.Errorf("error creating export folder [%s]: %w", exportDir, err)
+		}
+		err = runSlashingProtectionTool(rp, cfg, exportDir, true, startValidator)
+		exportFile := filepath.Join(exportDir, slashingProtectionFilename)
+		if err == nil {
+			if _, err = os.Stat(exportFile); err == nil {
+				return exportFile, nil
+			}
+		}
+		fmt.Printf("%sCouldn't export the Validator client's slashing protection database: %s%s\n", colorYellow, err.Error(), colorReset)
+		fmt.Println("Falling back to the Smartnode's own slashing protection export...")
+	}
+
+	// Build it from the chain
+	fmt.Println("Exporting slashing protection...")
+	response, err := rp.ExportSlashingProtection()
+	if err != nil {
+		fmt.Printf("%sCouldn't export slashing protection: %s%s\n", colorYellow, err.Error(), colorReset)
+		return "", nil
+	}
+	fmt.Printf("Exported slashing protection for %d validators up to epoch %d.\n\n", response.ValidatorCount, response.ProtectionEpoch)
+	return filepath.Join(exportsPath, response.ExportFile), nil
+}
+
+// Check if the Smartnode manages a Validator client whose slashing protection database it can export from or import to
+func managesValidatorClient(cfg *config.RocketPoolConfig) bool {
+	return !cfg.IsNativeMode && cfg.EnableWeb3Signer.Value != true
+}
+
+// Import an EIP-3076 slashing protection file into the Validator client's database
+func importSlashingProtection(c *cli.Context, sourceFile string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, err := getSlashingProtectionConfig(rp)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return nil
+	}
+
+	// Check the file before touching the Validator client
+	sourceFile, err = homedir.Expand(sourceFile)
+	if err != nil {
+		return fmt.Errorf("error expanding file path: %w", err)
+	}
+	fileBytes, err := os.ReadFile(sourceFile)
+	if err != nil {
+		return fmt.Errorf("error reading slashing protection file [%s]: %w", sourceFile, err)
+	}
+	var protection slashingProtectionFile
+	err = json.Unmarshal(fileBytes, &protection)
+	if err != nil {
+		return fmt.Errorf("[%s] is not a valid EIP-3076 slashing protection file: %w", sourceFile, err)
+	}
+	if protection.Metadata.InterchangeFormatVersion != slashingProtectionFormatVersion {
+		return fmt.Errorf("[%s] uses interchange format version [%s], but only version %s is supported", sourceFile, protection.Metadata.InterchangeFormatVersion, slashingProtectionFormatVersion)
+	}
+
+	fmt.Printf("%s contains slashing protection records for %d validator(s).\n", sourceFile, len(protection.Data))
+	fmt.Println("This will stop your Validator client, merge these records into its slashing protection database, and start it again.")
+	fmt.Printf("%sYour validators will miss attestations while the Validator client is stopped.%s\n\n", colorYellow, colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to import this slashing protection file?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Run the import
+	err = importSlashingProtectionFile(rp, cfg, fileBytes, true)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%sDone! Your Validator client's slashing protection database now includes the records from %s.%s\n", colorGreen, sourceFile, colorReset)
+	return nil
+
+}
+
+// Import the contents of an EIP-3076 slashing protection file into the Validator client's database, optionally starting the Validator client afterwards
+func importSlashingProtectionFile(rp *rocketpool.Client, cfg *config.RocketPoolConfig, fileBytes []byte, startValidator bool) error {
+
+	// Copy the file somewhere the tool's container can read it
+	exportsPath, err := homedir.Expand(cfg.Smartnode.GetKeyExportsPathInCLI())
+	if err != nil {
+		return fmt.Errorf("error expanding key exports path: %w", err)
+	}
+	importDir := filepath.Join(exportsPath, fmt.Sprintf(slashingProtectionImportPattern, time.Now().Unix()))
+	err = os.MkdirAll(importDir, slashingProtectionDirMode)
+	if err != nil {
+		return fmt.Errorf("error creating import folder [%s]: %w", importDir, err)
+	}
+	defer os.RemoveAll(importDir)
+	err = os.WriteFile(filepath.Join(importDir, slashingProtectionFilename), fileBytes, slashingProtectionFileMode)
+	if err != nil {
+		return fmt.Errorf("error copying slashing protection file: %w", err)
+	}
+
+	// Run the import
+	return runSlashingProtectionTool(rp, cfg, importDir, false, startValidator)
+
+}
+
+// Load the config and make sure the Smartnode manages a Validator client it can import to.
+// Returns nil if the command shouldn't continue.
+func getSlashingProtectionConfig(rp *rocketpool.Client) (*config.RocketPoolConfig, error) {
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if isNew {
+		return nil, fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	if cfg.IsNativeMode {
+		fmt.Println("You are using Native Mode.\nThe Smartnode doesn't manage your Validator client; please use its own slashing protection tools to import into its database.")
+		return nil, nil
+	}
+	if cfg.EnableWeb3Signer.Value == true {
+		fmt.Println("You are using Web3Signer for remote signing, so your slashing protection database is kept by Web3Signer rather than your Validator client.\nPlease use Web3Signer's own tools to import into it.")
+		return nil, nil
+	}
+	return cfg, nil
+}
+
+// Stop the Validator client, run its slashing protection tool against the given host folder, and optionally start it again
+func runSlashingProtectionTool(rp *rocketpool.Client, cfg *config.RocketPoolConfig, hostDir string, export bool, startValidator bool) error {
+
+	// Get the Validator client's container details
+	prefix, err := getContainerPrefix(rp)
+	if err != nil {
+		return fmt.Errorf("Error getting container prefix: %w", err)
+	}
+	validatorContainerName := prefix + ValidatorContainerSuffix
+	image, err := rp.GetDockerImage(validatorContainerName)
+	if err != nil {
+		return fmt.Errorf("error getting Validator client image: %w", err)
+	}
+	user, err := rp.GetContainerUser(validatorContainerName)
+	if err != nil {
+		return fmt.Errorf("error getting Validator client user: %w", err)
+	}
+
+	// Get the tool's arguments
+	cc, _ := cfg.GetSelectedConsensusClient()
+	file := path.Join(rocketpool.ValidatorToolMountPath, slashingProtectionFilename)
+	var args []string
+	switch cc {
+	case cfgtypes.ConsensusClient_Grandine, cfgtypes.ConsensusClient_Lighthouse:
+		// Grandine's Beacon node is served by Lighthouse's Validator client
+		args = []string{"lighthouse", "account", "validator", "slashing-protection", getSlashingProtectionOperation(export), file, "--datadir", path.Join(validatorDataPath, "lighthouse"), "--network", getValidatorNetworkName(cfg)}
+
+	case cfgtypes.ConsensusClient_Lodestar:
+		args = []string{"validator", "slashing-protection", getSlashingProtectionOperation(export), "--file", file, "--dataDir", path.Join(validatorDataPath, "lodestar"), "--network", getValidatorNetworkName(cfg), "--beaconNodes", getLodestarBeaconUrl(cfg)}
+
+	case cfgtypes.ConsensusClient_Nimbus:
+		// The slashing protection tool is only shipped with the Beacon node image
+		image = strings.Replace(image, nimbusValidatorClientImageName, nimbusBeaconNodeImageName, 1)
+		args = []string{"slashingdb", getSlashingProtectionOperation(export), file, fmt.Sprintf("--data-dir=%s", path.Join(validatorDataPath, "nimbus"))}
+
+	case cfgtypes.ConsensusClient_Prysm:
+		args = []string{"slashing-protection-history", getSlashingProtectionOperation(export), "--accept-terms-of-use", fmt.Sprintf("--datadir=%s", path.Join(validatorDataPath, "prysm-non-hd"))}
+		if export {
+			args = append(args, fmt.Sprintf("--slashing-protection-export-dir=%s", rocketpool.ValidatorToolMountPath))
+		} else {
+			args = append(args, fmt.Sprintf("--slashing-protection-json-file=%s", file))
+		}
+
+	case cfgtypes.ConsensusClient_Teku:
+		args = []string{"slashing-protection", getSlashingProtectionOperation(export), fmt.Sprintf("--data-path=%s", path.Join(validatorDataPath, "teku"))}
+		if export {
+			args = append(args, fmt.Sprintf("--to=%s", file))
+		} else {
+			args = append(args, fmt.Sprintf("--from=%s", file))
+		}
+
+	default:
+		return fmt.Errorf("unknown Consensus client [%v]", cc)
+	}
+
+	// Stop the VC so the database isn't being written to while the tool runs
+	fmt.Printf("Stopping %s...\n", validatorContainerName)
+	_, err = rp.StopContainer(validatorContainerName)
+	if err != nil {
+		return fmt.Errorf("Error stopping validator container: %w", err)
+	}
+
+	toolErr := rp.RunValidatorTool(validatorContainerName, image, user, prefix+dockerNetworkSuffix, hostDir, args)
+
+	err = nil
+	if startValidator {
+		fmt.Printf("Starting %s...\n", validatorContainerName)
+		_, err = rp.StartContainer(validatorContainerName)
+	}
+	if toolErr != nil {
+		return fmt.Errorf("error running the Validator client's slashing protection tool: %w", toolErr)
+	}
+	if err != nil {
+		return fmt.Errorf("Error starting validator container: %w", err)
+	}
+	return nil
+
+}
+
+// Get the subcommand name for the slashing protection tools
+func getSlashingProtectionOperation(export bool) string {
+	if export {
+		return "export"
+	}
+	return "import"
+}
+
+// Get the network name the Validator clients' tools expect
+func getValidatorNetworkName(cfg *config.RocketPoolConfig) string {
+	switch cfg.Smartnode.Network.Value.(cfgtypes.Network) {
+	case cfgtypes.Network_Prater, cfgtypes.Network_Devnet:
+		return "prater"
+	case cfgtypes.Network_Holesky:
+		return "holesky"
+	default:
+		return "mainnet"
+	}
+}
+
+// Get the URL Lodestar's tool uses to fetch the genesis validators root
+func getLodestarBeaconUrl(cfg *config.RocketPoolConfig) string {
+	_, mode := cfg.GetSelectedConsensusClient()
+	if mode == cfgtypes.Mode_External {
+		return cfg.ExternalLodestar.HttpUrl.Value.(string)
+	}
+	return fmt.Sprintf("http://eth2:%d", cfg.ConsensusCommon.ApiPort.Value.(uint16))
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/config/migration"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Upgrade the settings file to the current Smartnode version, showing exactly what changes
+func upgradeConfig(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Restore the previous settings file
+	if c.Bool("rollback") {
+		if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to replace your settings with the ones from before your last upgrade (%s)? Any changes you've made since then will be lost.", rputils.RollbackSettingsFile))) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		previousVersion, err := rp.RollbackConfig()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%sRestored the settings from Smartnode %s.%s\n", colorGreen, previousVersion, colorReset)
+		fmt.Println("They will be upgraded again the next time this version of the Smartnode loads them, so only use them with the version that made them.")
+		return nil
+	}
+
+	// Get the upgrade
+	report, err := rp.PlanConfigUpgrade()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Your settings were saved by Smartnode v%s; this is Smartnode v%s.\n\n", report.FromVersion, shared.RocketPoolVersion)
+	if report.IsEmpty() {
+		fmt.Println("None of your settings need to be changed to upgrade them.")
+		return nil
+	}
+	printUpgradeReport(report)
+
+	// Stop on dry runs
+	if c.Bool("dry-run") {
+		fmt.Println("This was a dry run, so your settings file hasn't been changed.")
+		return nil
+	}
+	if !(c.Bool("yes") || cliutils.Confirm("Would you like to apply these changes to your settings file?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Upgrade the settings; loading applies the upgrade, and saving keeps the old file for rollbacks
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("error saving upgraded settings: %w", err)
+	}
+	fmt.Printf("%sYour settings have been upgraded.%s\n", colorGreen, colorReset)
+	fmt.Printf("The previous settings were saved to %s; run `rocketpool service upgrade-config --rollback` to restore them.\n", rputils.RollbackSettingsFile)
+	return nil
+
+}
+
+// Print the changes an upgrade makes, step by step
+func printUpgradeReport(report *migration.UpgradeReport) {
+	for _, step := range report.Steps {
+		if len(step.Changes) == 0 {
+			continue
+		}
+		fmt.Printf("%sUpgrade from v%s: %s%s\n", colorYellow, step.Version, step.Description, colorReset)
+		for _, change := range step.Changes {
+			switch {
+			case change.Added:
+				fmt.Printf("\t+ %s.%s = %s\n", change.Section, change.Setting, change.NewValue)
+			case change.Removed:
+				fmt.Printf("\t- %s.%s (was %s)\n", change.Section, change.Setting, change.OldValue)
+			default:
+				fmt.Printf("\t~ %s.%s: %s -> %s\n", change.Section, change.Setting, change.OldValue, change.NewValue)
+			}
+		}
+		fmt.Println()
+	}
+}
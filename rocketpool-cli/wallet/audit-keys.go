@@ -0,0 +1,128 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func auditValidatorKeys(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Run the audit
+	fmt.Println("Checking your Validator client's keys against your minipools and the Beacon chain...")
+	response, err := rp.AuditValidatorKeys()
+	if err != nil {
+		return err
+	}
+
+	// Sort the keys by problem
+	ok := []api.ValidatorKeyAuditEntry{}
+	distributed := []api.ValidatorKeyAuditEntry{}
+	missing := []api.ValidatorKeyAuditEntry{}
+	broken := []api.ValidatorKeyAuditEntry{}
+	extra := []api.ValidatorKeyAuditEntry{}
+	for _, key := range response.Keys {
+		switch {
+		case !key.IsMinipool:
+			extra = append(extra, key)
+		case key.IsDistributed:
+			distributed = append(distributed, key)
+		case !key.IsLoaded:
+			missing = append(missing, key)
+		case key.LoadError != "":
+			broken = append(broken, key)
+		default:
+			ok = append(ok, key)
+		}
+	}
+	fmt.Printf("Your Validator client loads its keys from the %s keystore.\n\n", response.Keystore)
+
+	if len(ok) > 0 {
+		fmt.Printf("%s%d minipool validator key(s) are loaded correctly.%s\n\n", colorGreen, len(ok), colorReset)
+	}
+
+	if len(distributed) > 0 {
+		fmt.Printf("%d minipool validator(s) are distributed validators run by their cluster, so their keys aren't checked:\n", len(distributed))
+		for _, key := range distributed {
+			printAuditedKey(key)
+		}
+		fmt.Println()
+	}
+
+	if len(missing) > 0 {
+		fmt.Printf("%s%d minipool validator key(s) are MISSING from the Validator client:%s\n", colorRed, len(missing), colorReset)
+		for _, key := range missing {
+			printAuditedKey(key)
+		}
+		fmt.Println()
+	}
+
+	if len(broken) > 0 {
+		fmt.Printf("%s%d minipool validator key(s) are present but can't be loaded, or don't match their pubkey:%s\n", colorRed, len(broken), colorReset)
+		for _, key := range broken {
+			printAuditedKey(key)
+			fmt.Printf("\t%s\n", key.LoadError)
+		}
+		fmt.Println()
+	}
+
+	if len(extra) > 0 {
+		fmt.Printf("%s%d key(s) are loaded that don't belong to any of your minipools:%s\n", colorYellow, len(extra), colorReset)
+		for _, key := range extra {
+			printAuditedKey(key)
+		}
+		fmt.Println("These may be solo validators, keys from another node, or your shares of an Obol cluster's distributed validator keys. Make sure none of them are also running on another machine, or they will be slashed.")
+		fmt.Println()
+	}
+
+	// Guide the user through fixing their minipool keys
+	if len(missing) == 0 && len(broken) == 0 {
+		if len(extra) == 0 {
+			fmt.Printf("%sAll of your validator keys are in order.%s\n", colorGreen, colorReset)
+		}
+		return nil
+	}
+	if response.MissingKeysRecoverable {
+		fmt.Println("All of these keys can be regenerated from your node wallet.")
+		fmt.Println("Run `rocketpool wallet rebuild` to restore them, then restart your Validator client so it loads them.")
+	} else {
+		fmt.Printf("%sSome of these keys could not be regenerated from your node wallet: %s%s\n", colorYellow, response.RecoveryError, colorReset)
+		fmt.Println("If they were created at a non-standard derivation path, recover them with `rocketpool wallet recover` and its --validator-key-path or --validator-key-path-overrides flags.")
+		fmt.Println("If they were imported from elsewhere, import them again with `rocketpool wallet import-keys`.")
+	}
+	return nil
+
+}
+
+// Print a key from the audit along with its Beacon chain status
+func printAuditedKey(key api.ValidatorKeyAuditEntry) {
+	if !key.ExistsOnBeacon {
+		fmt.Printf("\t%s (not on the Beacon chain yet)\n", key.Pubkey.Hex())
+		return
+	}
+	duties := ""
+	if key.HasDuties {
+		duties = ", has duties"
+	}
+	fmt.Printf("\t%s (index %s, %s%s)\n", key.Pubkey.Hex(), key.BeaconIndex, key.BeaconStatus, duties)
+}
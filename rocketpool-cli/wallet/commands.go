@@ -52,6 +52,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "derivation-path, d",
 						Usage: "Specify the derivation path for the wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
 					},
+					cli.BoolFlag{
+						Name:  "use-mnemonic-passphrase, u",
+						Usage: "Protect the new mnemonic with a BIP-39 passphrase (the \"25th word\"); you will be prompted for it",
+					},
+					cli.StringFlag{
+						Name:  "mnemonic-passphrase",
+						Usage: "The BIP-39 passphrase to use with the mnemonic, instead of being prompted for it",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -91,6 +99,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "skip-validator-key-recovery, k",
 						Usage: "Recover the node wallet, but do not regenerate its validator keys",
 					},
+					cli.StringFlag{
+						Name:  "validator-key-path",
+						Usage: "If your validator keys were created by another tool at a non-standard derivation path, enter that path here with %d in place of the key index (for example: \"m/12381/3600/%d/0\"). Keys at the standard path of \"m/12381/3600/%d/0/0\" will still be recovered.",
+					},
+					cli.StringFlag{
+						Name:  "validator-key-path-overrides",
+						Usage: "The path to a YAML file that maps individual validator pubkeys to the exact derivation path of their key, for keys that don't follow any single pattern",
+					},
 					cli.StringFlag{
 						Name:  "derivation-path, d",
 						Usage: "Specify the derivation path for the wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
@@ -104,6 +120,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "address, a",
 						Usage: "If you are recovering a wallet that was not generated by the Smartnode and don't know the derivation path or index of it, enter the address here. The Smartnode will search through its library of paths and indices to try to find it.",
 					},
+					cli.BoolFlag{
+						Name:  "use-mnemonic-passphrase, u",
+						Usage: "Use the BIP-39 passphrase (the \"25th word\") your mnemonic was created with; you will be prompted for it",
+					},
+					cli.StringFlag{
+						Name:  "mnemonic-passphrase",
+						Usage: "The BIP-39 passphrase to use with the mnemonic, instead of being prompted for it",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -148,6 +172,77 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "audit-keys",
+				Usage:     "Check that the Validator client has the keys for all of your minipools, and find missing, extra, or broken keys",
+				UsageText: "rocketpool wallet audit-keys",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return auditValidatorKeys(c)
+
+				},
+			},
+
+			{
+				Name:      "export-keys",
+				Usage:     "Export your minipools' validator keys as EIP-2335 keystores, along with an EIP-3076 slashing protection file, to migrate them to another staking setup",
+				UsageText: "rocketpool wallet export-keys [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "pubkeys, k",
+						Usage: "A comma-separated list of the validator pubkeys to export (default: all of your minipools)",
+					},
+					cli.StringFlag{
+						Name:  "password, p",
+						Usage: "The password to encrypt the exported keystores with",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return exportKeys(c)
+
+				},
+			},
+
+			{
+				Name:      "import-keys",
+				Usage:     "Import externally generated EIP-2335 validator keystores (e.g. from a solo staking setup) for your minipools into the Validator Client",
+				UsageText: "rocketpool wallet import-keys [options] directory",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "password, p",
+						Usage: "The password every keystore was encrypted with; you will be prompted for each one if this isn't set",
+					},
+					cli.BoolFlag{
+						Name:  "no-restart",
+						Usage: "Don't restart the Validator Client after importing the keys",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return importKeys(c, c.Args().Get(0))
+
+				},
+			},
+
 			{
 				Name:      "test-recovery",
 				Aliases:   []string{"t"},
@@ -162,6 +257,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "skip-validator-key-recovery, k",
 						Usage: "Recover the node wallet, but do not regenerate its validator keys",
 					},
+					cli.StringFlag{
+						Name:  "validator-key-path",
+						Usage: "If your validator keys were created by another tool at a non-standard derivation path, enter that path here with %d in place of the key index (for example: \"m/12381/3600/%d/0\"). Keys at the standard path of \"m/12381/3600/%d/0/0\" will still be recovered.",
+					},
+					cli.StringFlag{
+						Name:  "validator-key-path-overrides",
+						Usage: "The path to a YAML file that maps individual validator pubkeys to the exact derivation path of their key, for keys that don't follow any single pattern",
+					},
 					cli.StringFlag{
 						Name:  "derivation-path, d",
 						Usage: "Specify the derivation path for the wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
@@ -175,6 +278,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "address, a",
 						Usage: "If you are recovering a wallet that was not generated by the Smartnode and don't know the derivation path or index of it, enter the address here. The Smartnode will search through its library of paths and indices to try to find it.",
 					},
+					cli.BoolFlag{
+						Name:  "use-mnemonic-passphrase, u",
+						Usage: "Use the BIP-39 passphrase (the \"25th word\") your mnemonic was created with; you will be prompted for it",
+					},
+					cli.StringFlag{
+						Name:  "mnemonic-passphrase",
+						Usage: "The BIP-39 passphrase to use with the mnemonic, instead of being prompted for it",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -231,6 +342,96 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "sign-tx",
+				Usage:     "Sign a transaction that was created with --unsigned, so it can be submitted from another machine",
+				UsageText: "rocketpool wallet sign-tx [options] file-or-serialized-tx",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm signing the transaction",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return signTransaction(c, c.Args().Get(0))
+
+				},
+			},
+			{
+				Name:      "send-raw",
+				Usage:     "Submit a transaction that was signed with `rocketpool wallet sign-tx`",
+				UsageText: "rocketpool wallet send-raw [options] file-or-signed-tx",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm submitting the transaction",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return sendRawTransaction(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "list",
+				Aliases:   []string{"l"},
+				Usage:     "List your stored node wallets",
+				UsageText: "rocketpool wallet list",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return listWallets(c)
+
+				},
+			},
+
+			{
+				Name:      "switch",
+				Usage:     "Store the active node wallet (with its password and validator keys) and switch to another one, or to a new empty one",
+				UsageText: "rocketpool wallet switch [options] name",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the switch",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					name, err := cliutils.ValidateWalletName("wallet name", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return switchWallet(c, name)
+
+				},
+			},
+
 			{
 				Name:      "purge",
 				Usage:     fmt.Sprintf("%sDeletes your node wallet, your validator keys, and restarts your Validator Client while preserving your chain data. WARNING: Only use this if you want to stop validating with this machine!%s", colorRed, colorReset),
@@ -32,7 +32,7 @@ func setEnsName(c *cli.Context, name string) error {
 		return err
 	}
 
-	if !cliutils.Confirm("Are you sure you want to confirm your node's ENS name?") {
+	if !cliutils.ConfirmTransaction("Are you sure you want to confirm your node's ENS name?") {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -44,7 +44,9 @@ func setEnsName(c *cli.Context, name string) error {
 	}
 
 	fmt.Printf("Setting ENS name...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
 		return err
 	}
@@ -0,0 +1,112 @@
+package wallet
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func exportKeys(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Load the config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Get the selected pubkeys
+	pubkeys := []types.ValidatorPubkey{}
+	if c.String("pubkeys") != "" {
+		pubkeys, err = cliutils.ValidatePubkeys("pubkeys", c.String("pubkeys"))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Explain the migration
+	fmt.Printf("%sWARNING:\nThis is meant for moving your validators to another machine or staking setup.\nYour Validator Client will be stopped before the keys are exported and it MUST NOT be started again with these keys.\nIf the same keys are ever running in two places at once, YOUR VALIDATORS WILL BE SLASHED.%s\n\n", colorRed, colorReset)
+	fmt.Println("Along with the keystores, a slashing protection file will be created that prevents your new setup from signing anything for the current epoch or earlier. Import it into your new Validator Client together with the keys.")
+	fmt.Println()
+	if cfg.IsNativeMode {
+		if !cliutils.Confirm("You are in Native Mode. Have you stopped your Validator Client?") {
+			fmt.Println("Please stop your Validator Client before exporting its keys.")
+			return nil
+		}
+	} else if !cliutils.Confirm("Are you sure you want to stop your Validator Client and export your validator keys?") {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Get the keystore password
+	password := c.String("password")
+	if password == "" {
+		for {
+			password = cliutils.PromptPassword(
+				"Please enter a password to encrypt the exported keystores with:",
+				fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
+				fmt.Sprintf("Your password must be at least %d characters long. Please try again:", passwords.MinPasswordLength),
+				"ROCKETPOOL_EXPORT_PASSWORD",
+			)
+			confirmation := cliutils.PromptPassword("Please confirm your password:", "^.*$", "", "ROCKETPOOL_EXPORT_PASSWORD")
+			if password == confirmation {
+				break
+			}
+			fmt.Println("Password confirmation does not match.")
+			fmt.Println("")
+		}
+	}
+
+	// Stop the VC so nothing else gets signed after the slashing protection file is made
+	if !cfg.IsNativeMode {
+		fmt.Println("Stopping Validator Client...")
+		_, err = rp.StopContainer(cfg.Smartnode.ProjectName.Value.(string) + validatorContainerSuffix)
+		if err != nil {
+			return fmt.Errorf("error stopping Validator Client: %w", err)
+		}
+	}
+
+	// Export
+	fmt.Println("Exporting validator keys...")
+	response, err := rp.ExportKeys(password, pubkeys)
+	if err != nil {
+		return err
+	}
+	exportsPath, err := homedir.Expand(cfg.Smartnode.GetKeyExportsPathInCLI())
+	if err != nil {
+		return fmt.Errorf("error expanding key exports folder: %w", err)
+	}
+
+	// Log & return
+	fmt.Printf("%sExported %d validator keys:%s\n", colorGreen, len(response.ExportedKeys), colorReset)
+	for _, pubkey := range response.ExportedKeys {
+		fmt.Println(pubkey.Hex())
+	}
+	fmt.Printf("\nThe keystores and slashing protection file (covering up to epoch %d) have been saved to:\n\n\t%s\n\n", response.ProtectionEpoch, filepath.Join(exportsPath, response.ExportFolder))
+	fmt.Printf("%sYour Validator Client has been left stopped. Do NOT start it again (for example with `rocketpool service start`) while these keys are running elsewhere.\nOnce you've moved the files, delete them from this machine so nobody else can read them.%s\n", colorYellow, colorReset)
+	return nil
+
+}
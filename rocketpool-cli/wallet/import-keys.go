@@ -0,0 +1,229 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// An external keystore to import
+type externalKeystore struct {
+	filename string
+	bytes    []byte
+	keystore api.ValidatorKeystore
+}
+
+func importKeys(c *cli.Context, keystoreDir string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Load the config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Load the keystores
+	keystoreDir, err = homedir.Expand(keystoreDir)
+	if err != nil {
+		return fmt.Errorf("error expanding keystore directory: %w", err)
+	}
+	keystores, err := loadExternalKeystores(keystoreDir)
+	if err != nil {
+		return err
+	}
+	if len(keystores) == 0 {
+		fmt.Printf("No validator keystores were found in %s.\n", keystoreDir)
+		return nil
+	}
+
+	// Match them to the node's minipools
+	minipools, err := rp.MinipoolStatus()
+	if err != nil {
+		return err
+	}
+	minipoolsByPubkey := map[types.ValidatorPubkey]api.MinipoolDetails{}
+	for _, mp := range minipools.Minipools {
+		minipoolsByPubkey[mp.ValidatorPubkey] = mp
+	}
+	unmatched := []string{}
+	for _, ks := range keystores {
+		mp, exists := minipoolsByPubkey[ks.keystore.Pubkey]
+		if !exists {
+			unmatched = append(unmatched, fmt.Sprintf("%s (%s)", ks.keystore.Pubkey.Hex(), ks.filename))
+			continue
+		}
+		fmt.Printf("%s => minipool %s\n", ks.keystore.Pubkey.Hex(), mp.Address.Hex())
+	}
+	fmt.Println()
+	if len(unmatched) > 0 {
+		return fmt.Errorf("The following keystores are not for any of your node's minipools, so they can't be imported:\n\t%s", strings.Join(unmatched, "\n\t"))
+	}
+
+	// Slashing protection
+	fmt.Printf("%sWARNING:\nBefore importing these keys, you **MUST** do the following:\n1. Remove them from the Validator Client they're currently running on (for example, your solo staking setup)\n2. Restart it so that it is no longer validating with them\n3. Wait for 15 minutes so each validator has missed at least two attestations\nFailure to do this **will result in your validators being SLASHED**.%s\n\n", colorRed, colorReset)
+	if !cfg.IsNativeMode {
+		doppelgangerEnabled, err := cfg.IsDoppelgangerEnabled()
+		if err == nil && !doppelgangerEnabled {
+			fmt.Printf("%sYour Validator Client does not have doppelganger protection enabled, so it will not check whether these keys are still active elsewhere before it starts attesting with them. We strongly recommend enabling it with `rocketpool service config` before continuing.%s\n\n", colorYellow, colorReset)
+		}
+	}
+	if !cliutils.Confirm("Have you removed these keys from every other Validator Client, restarted them, and waited long enough for your validators to miss at least two attestations?") {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	fmt.Println()
+
+	// Get and verify the password for each keystore
+	if err := eth2types.InitBLS(); err != nil {
+		return fmt.Errorf("error initializing BLS: %w", err)
+	}
+	pubkeyPasswords := map[string]string{}
+	pubkeys := []types.ValidatorPubkey{}
+	for _, ks := range keystores {
+		for {
+			password := c.String("password")
+			if password == "" {
+				password = cliutils.PromptPassword(
+					fmt.Sprintf("Please enter the password that the keystore for %s was encrypted with:", ks.keystore.Pubkey.Hex()), "^.*$", "",
+					"ROCKETPOOL_KEYSTORE_PASSWORD",
+				)
+			}
+			_, err := keystore.DecryptValidatorKey(ks.keystore.Pubkey, ks.keystore.Crypto, password)
+			if err == nil {
+				formattedPubkey := strings.ToUpper(hexutils.RemovePrefix(ks.keystore.Pubkey.Hex()))
+				pubkeyPasswords[formattedPubkey] = password
+				pubkeys = append(pubkeys, ks.keystore.Pubkey)
+				break
+			}
+			if c.String("password") != "" {
+				return fmt.Errorf("error decrypting keystore %s with the provided password: %w", ks.filename, err)
+			}
+			fmt.Printf("%sError decrypting keystore: %s\nPlease try again.%s\n\n", colorRed, err.Error(), colorReset)
+		}
+	}
+
+	// Copy the keystores into the custom keys folder
+	datapath, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return fmt.Errorf("error expanding data directory: %w", err)
+	}
+	customKeyDir := filepath.Join(datapath, "custom-keys")
+	if err := os.MkdirAll(customKeyDir, 0775); err != nil {
+		return fmt.Errorf("error creating custom keys folder: %w", err)
+	}
+	for _, ks := range keystores {
+		keystorePath := filepath.Join(customKeyDir, ks.filename)
+		if err := os.WriteFile(keystorePath, ks.bytes, 0600); err != nil {
+			return fmt.Errorf("error copying keystore %s: %w", ks.filename, err)
+		}
+	}
+
+	// Write the passwords for the daemon, and make sure they're deleted afterwards
+	passwordFile := filepath.Join(datapath, "custom-key-passwords")
+	fileBytes, err := yaml.Marshal(pubkeyPasswords)
+	if err != nil {
+		return fmt.Errorf("error serializing keystore passwords file: %w", err)
+	}
+	if err := os.WriteFile(passwordFile, fileBytes, 0600); err != nil {
+		return fmt.Errorf("error writing keystore passwords file: %w", err)
+	}
+	defer func() {
+		err := deleteCustomKeyPasswordFile(passwordFile)
+		if err != nil {
+			fmt.Printf("*** WARNING ***\nAn error occurred while removing the custom keystore password file: %s\n\nThis file contains the passwords to your custom validator keys.\nYou *must* delete it manually as soon as possible so nobody can read it.\n\nThe file is located here:\n\n\t%s\n\n", err.Error(), passwordFile)
+		}
+	}()
+
+	// Import them
+	fmt.Println("Importing validator keys...")
+	response, err := rp.ImportKeys(pubkeys)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%sImported %d validator keys.%s\n\n", colorGreen, len(response.ImportedKeys), colorReset)
+
+	// Restart the VC so it loads them
+	if c.Bool("no-restart") {
+		fmt.Println("Please restart your Validator Client so it loads the new keys.")
+		return nil
+	}
+	fmt.Print("Restarting Validator Client... ")
+	_, err = rp.RestartVc()
+	if err != nil {
+		fmt.Printf("failed!\n%sWARNING: error restarting validator client: %s\n\nPlease restart it manually so it loads the new keys.%s\n", colorYellow, err.Error(), colorReset)
+		return nil
+	}
+	fmt.Println("done!")
+	return nil
+
+}
+
+// Load the EIP-2335 keystores in a directory, skipping any other JSON files (such as deposit data)
+func loadExternalKeystores(keystoreDir string) ([]externalKeystore, error) {
+	files, err := os.ReadDir(keystoreDir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading keystore directory: %w", err)
+	}
+
+	keystores := []externalKeystore{}
+	seen := map[types.ValidatorPubkey]string{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		// Read and deserialize it
+		bytes, err := os.ReadFile(filepath.Join(keystoreDir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading keystore %s: %w", file.Name(), err)
+		}
+		ks := api.ValidatorKeystore{}
+		if err := json.Unmarshal(bytes, &ks); err != nil || ks.Crypto == nil || ks.Pubkey == (types.ValidatorPubkey{}) {
+			fmt.Printf("Skipping %s because it isn't a validator keystore.\n", file.Name())
+			continue
+		}
+
+		// Make sure each key only shows up once
+		if existing, exists := seen[ks.Pubkey]; exists {
+			return nil, fmt.Errorf("keystores %s and %s are both for validator %s", existing, file.Name(), ks.Pubkey.Hex())
+		}
+		seen[ks.Pubkey] = file.Name()
+
+		keystores = append(keystores, externalKeystore{
+			filename: file.Name(),
+			bytes:    bytes,
+			keystore: ks,
+		})
+	}
+	return keystores, nil
+}
@@ -51,8 +51,14 @@ func initWallet(c *cli.Context) error {
 		fmt.Printf("Using a custom derivation path (%s).\n\n", derivationPath)
 	}
 
+	// Get the mnemonic passphrase
+	passphrase := getMnemonicPassphrase(c, true)
+	if passphrase != "" {
+		fmt.Printf("%sYour wallet will be derived from your mnemonic AND this passphrase, so you will need both of them to recover it.\nThe mnemonic on its own, or with a different passphrase, recovers a completely different (empty) wallet. Record your passphrase somewhere secure, separately from your mnemonic.%s\n\n", colorYellow, colorReset)
+	}
+
 	// Initialize wallet
-	response, err := rp.InitWallet(derivationPath)
+	response, err := rp.InitWallet(derivationPath, passphrase)
 	if err != nil {
 		return err
 	}
@@ -66,6 +72,9 @@ func initWallet(c *cli.Context) error {
 	fmt.Println("")
 	fmt.Println("==============================================================================================================================================")
 	fmt.Println("")
+	if passphrase != "" {
+		fmt.Printf("%sRemember: this mnemonic is protected by your passphrase. You must provide both to recover your wallet.%s\n\n", colorYellow, colorReset)
+	}
 
 	// Confirm mnemonic
 	if !c.Bool("confirm-mnemonic") {
@@ -73,7 +82,7 @@ func initWallet(c *cli.Context) error {
 	}
 
 	// Do a recover to save the wallet
-	recoverResponse, err := rp.RecoverWallet(response.Mnemonic, true, derivationPath, 0)
+	recoverResponse, err := rp.RecoverWallet(response.Mnemonic, passphrase, true, "", "", derivationPath, 0)
 	if err != nil {
 		return fmt.Errorf("error saving wallet: %w", err)
 	}
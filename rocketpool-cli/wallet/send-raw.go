@@ -0,0 +1,62 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func sendRawTransaction(c *cli.Context, input string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Read the transaction and make sure it's signed
+	signedTx, _, _, err := readTransactionInput(input)
+	if err != nil {
+		return err
+	}
+	tx, err := cliutils.DecodeTransaction(signedTx)
+	if err != nil {
+		return err
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("The transaction isn't signed; please sign it with `rocketpool wallet sign-tx` first.")
+	}
+
+	// Confirm it
+	fmt.Println("This will submit the following transaction:")
+	cliutils.PrintTransactionSummary(tx, &from)
+	fmt.Println()
+	if !(c.Bool("yes") || cliutils.ConfirmTransaction("Are you sure you want to submit this transaction?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Submit it
+	response, err := rp.SendRawTransaction(signedTx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Submitting transaction...\n")
+	if err = cliutils.PrintTransactionHash(rp, response.TxHash); err != nil {
+		return err
+	}
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	fmt.Println("The transaction was successfully included in a block.")
+	return nil
+
+}
@@ -0,0 +1,78 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Settings
+const (
+	signedTxFileSuffix string = ".signed"
+	signedTxFileMode          = 0644
+)
+
+func signTransaction(c *cli.Context, input string) error {
+
+	// Get RP client; this is meant to run on an offline machine, so the clients aren't checked
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Read the transaction
+	serializedTx, from, path, err := readTransactionInput(input)
+	if err != nil {
+		return err
+	}
+	tx, err := cliutils.DecodeTransaction(serializedTx)
+	if err != nil {
+		return err
+	}
+	if from != nil && *from != status.AccountAddress {
+		return fmt.Errorf("The transaction was created for node account %s, but this node wallet's account is %s.", from.Hex(), status.AccountAddress.Hex())
+	}
+
+	// Confirm it
+	fmt.Println("This will sign the following transaction with your node account:")
+	cliutils.PrintTransactionSummary(tx, &status.AccountAddress)
+	fmt.Println()
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to sign this transaction?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Sign it
+	response, err := rp.SignTransaction(serializedTx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\nThe transaction has been signed. Once submitted, its hash will be %s.\n", response.TxHash.Hex())
+	fmt.Printf("Signed transaction:\n%s\n\n", response.SignedTx)
+
+	// Save it next to the unsigned transaction
+	if path != "" {
+		signedPath := strings.TrimSuffix(path, ".json") + signedTxFileSuffix
+		err = os.WriteFile(signedPath, []byte(response.SignedTx), signedTxFileMode)
+		if err != nil {
+			return fmt.Errorf("error saving signed transaction [%s]: %w", signedPath, err)
+		}
+		fmt.Printf("It has been saved to %s.\n", signedPath)
+	}
+	fmt.Println("Copy it to your node and submit it with `rocketpool wallet send-raw`.")
+	return nil
+
+}
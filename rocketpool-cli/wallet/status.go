@@ -31,6 +31,9 @@ func getStatus(c *cli.Context) error {
 	if status.WalletInitialized {
 		fmt.Println("The node wallet is initialized.")
 		fmt.Printf("Node account: %s\n", status.AccountAddress.Hex())
+	} else if status.IsWatchOnly {
+		fmt.Println("The node wallet is not on this machine, so its transactions can only be saved with --unsigned for signing elsewhere.")
+		fmt.Printf("Watch-only node account: %s\n", status.AccountAddress.Hex())
 	} else {
 		fmt.Println("The node wallet has not been initialized.")
 	}
@@ -0,0 +1,132 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Containers that hold the node wallet or validator keys
+const (
+	nodeContainerSuffix       string = "_node"
+	watchtowerContainerSuffix string = "_watchtower"
+	validatorContainerSuffix  string = "_validator"
+)
+
+func listWallets(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the wallets
+	response, err := rp.ListWallets()
+	if err != nil {
+		return err
+	}
+
+	// Print them
+	fmt.Printf("%s%s%s (active)\n", colorGreen, response.ActiveWallet, colorReset)
+	for _, name := range response.Wallets {
+		fmt.Println(name)
+	}
+	return nil
+
+}
+
+func switchWallet(c *cli.Context, name string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Get the wallets
+	wallets, err := rp.ListWallets()
+	if err != nil {
+		return err
+	}
+	if name == wallets.ActiveWallet {
+		fmt.Printf("Wallet '%s' is already active.\n", name)
+		return nil
+	}
+	isNewWallet := true
+	for _, storedName := range wallets.Wallets {
+		if storedName == name {
+			isNewWallet = false
+			break
+		}
+	}
+
+	// Explain what will happen
+	fmt.Printf("Your active wallet '%s' will be stored alongside its password and validator keys, so you can switch back to it later.\n", wallets.ActiveWallet)
+	if isNewWallet {
+		fmt.Printf("There is no stored wallet named '%s', so your node will be left without a wallet; create one with `rocketpool wallet init` or `rocketpool wallet recover`. It will have its own password.\n", name)
+	} else {
+		fmt.Printf("Wallet '%s' will be restored with its own password and validator keys.\n", name)
+		fmt.Printf("%sWARNING: If the validators for wallet '%s' are running on another machine, you MUST stop them there and wait at least fifteen minutes before switching, or they WILL BE SLASHED.%s\n", colorRed, name, colorReset)
+	}
+	fmt.Println()
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to switch to wallet '%s'?", name))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Stop the Validator client so it lets go of the keys before they're moved
+	prefix := cfg.Smartnode.ProjectName.Value.(string)
+	fmt.Println("Stopping Validator client...")
+	if cfg.IsNativeMode {
+		cmd := exec.Command(os.ExpandEnv(cfg.Native.ValidatorStopCommand.Value.(string)))
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		err = cmd.Run()
+	} else {
+		_, err = rp.StopContainer(prefix + validatorContainerSuffix)
+	}
+	if err != nil {
+		return fmt.Errorf("error stopping Validator client: %w", err)
+	}
+
+	// Switch
+	response, err := rp.SwitchWallet(name)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Stored wallet '%s' and switched to wallet '%s'.\n", response.PreviousWallet, name)
+
+	// Restart everything that uses the wallet
+	if cfg.IsNativeMode {
+		fmt.Printf("%sPlease restart your node and watchtower daemons and your Validator client so they load the new wallet.%s\n", colorYellow, colorReset)
+	} else {
+		fmt.Println("Restarting containers...")
+		for _, suffix := range []string{nodeContainerSuffix, watchtowerContainerSuffix} {
+			_, err = rp.RestartContainer(prefix + suffix)
+			if err != nil {
+				return fmt.Errorf("error restarting %s: %w", prefix+suffix, err)
+			}
+		}
+		_, err = rp.StartContainer(prefix + validatorContainerSuffix)
+		if err != nil {
+			return fmt.Errorf("error starting Validator client: %w", err)
+		}
+	}
+
+	if response.NewWallet {
+		fmt.Println("\nPlease run `rocketpool wallet init` or `rocketpool wallet recover` to set up this wallet.")
+	}
+	return nil
+
+}
@@ -45,9 +45,22 @@ func testRecovery(c *cli.Context) error {
 	}
 	mnemonic = strings.TrimSpace(mnemonic)
 
+	// Get the mnemonic passphrase
+	passphrase := getMnemonicPassphrase(c, false)
+	if passphrase != "" {
+		fmt.Printf("%sNOTE: A wrong mnemonic passphrase doesn't cause an error; it recovers a different wallet. If the node account below isn't the one you expected, check your passphrase.%s\n\n", colorYellow, colorReset)
+	}
+
 	// Handle validator key recovery skipping
 	skipValidatorKeyRecovery := c.Bool("skip-validator-key-recovery")
 
+	// Get any non-standard validator key paths
+	validatorKeyPath := c.String("validator-key-path")
+	validatorKeyPathOverrides, err := getValidatorKeyPathOverrides(c)
+	if err != nil {
+		return err
+	}
+
 	// Check for custom keys
 	if !skipValidatorKeyRecovery {
 		customKeyPasswordFile, err := promptForCustomKeyPasswords(rp, cfg, true)
@@ -88,7 +101,7 @@ func testRecovery(c *cli.Context) error {
 		}
 
 		// Test recover wallet
-		response, err := rp.TestSearchAndRecoverWallet(mnemonic, address, skipValidatorKeyRecovery)
+		response, err := rp.TestSearchAndRecoverWallet(mnemonic, passphrase, address, skipValidatorKeyRecovery, validatorKeyPath, validatorKeyPathOverrides)
 		if err != nil {
 			return err
 		}
@@ -135,7 +148,7 @@ func testRecovery(c *cli.Context) error {
 		}
 
 		// Test recover wallet
-		response, err := rp.TestRecoverWallet(mnemonic, skipValidatorKeyRecovery, derivationPath, walletIndex)
+		response, err := rp.TestRecoverWallet(mnemonic, passphrase, skipValidatorKeyRecovery, validatorKeyPath, validatorKeyPathOverrides, derivationPath, walletIndex)
 		if err != nil {
 			return err
 		}
@@ -7,8 +7,10 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
 	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
 	"gopkg.in/yaml.v2"
 
 	"github.com/rocket-pool/rocketpool-go/types"
@@ -24,6 +26,35 @@ import (
 const bold string = "\033[1m"
 const unbold string = "\033[0m"
 
+// The environment variable with the recovery mnemonic phrase for non-interactive provisioning
+const mnemonicEnvVar string = "ROCKETPOOL_MNEMONIC"
+
+// Read a serialized transaction from a hex string, a file with the hex string, or a file saved by a command run with --unsigned.
+// Returns the transaction's sender if the file recorded it, and the path of the file if there was one.
+func readTransactionInput(input string) (string, *common.Address, string, error) {
+	path, err := homedir.Expand(input)
+	if err != nil {
+		return "", nil, "", fmt.Errorf("error expanding file path: %w", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		// Not a file, so it must be the transaction itself
+		return strings.TrimSpace(input), nil, "", nil
+	}
+
+	if filepath.Ext(path) == ".json" {
+		unsignedTx, err := cliutils.LoadUnsignedTransaction(path)
+		if err != nil {
+			return "", nil, "", err
+		}
+		return unsignedTx.SerializedTx, &unsignedTx.From, path, nil
+	}
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, "", fmt.Errorf("error reading transaction file [%s]: %w", path, err)
+	}
+	return strings.TrimSpace(string(bytes)), nil, path, nil
+}
+
 // Prompt for a wallet password
 func promptPassword() string {
 	for {
@@ -31,8 +62,9 @@ func promptPassword() string {
 			"Please enter a password to secure your wallet with:",
 			fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
 			fmt.Sprintf("Your password must be at least %d characters long. Please try again:", passwords.MinPasswordLength),
+			"ROCKETPOOL_PASSWORD",
 		)
-		confirmation := cliutils.PromptPassword("Please confirm your password:", "^.*$", "")
+		confirmation := cliutils.PromptPassword("Please confirm your password:", "^.*$", "", "ROCKETPOOL_PASSWORD")
 		if password == confirmation {
 			return password
 		}
@@ -41,13 +73,94 @@ func promptPassword() string {
 	}
 }
 
+// Get the BIP-39 passphrase to use with the mnemonic from the command flags, prompting for it if requested
+func getMnemonicPassphrase(c *cli.Context, confirm bool) string {
+	if c.String("mnemonic-passphrase") != "" {
+		return c.String("mnemonic-passphrase")
+	}
+	if !c.Bool("use-mnemonic-passphrase") {
+		return ""
+	}
+	for {
+		passphrase := cliutils.PromptPassword("Please enter your mnemonic passphrase:", "^.+$", "The passphrase can't be blank. Please try again:", "ROCKETPOOL_MNEMONIC_PASSPHRASE")
+		if !confirm {
+			return passphrase
+		}
+		confirmation := cliutils.PromptPassword("Please confirm your mnemonic passphrase:", "^.*$", "", "ROCKETPOOL_MNEMONIC_PASSPHRASE")
+		if passphrase == confirmation {
+			return passphrase
+		}
+		fmt.Println("Passphrase confirmation does not match.")
+		fmt.Println("")
+	}
+}
+
+// Load the validator key derivation path overrides file from the command flags, formatted as pubkey=path pairs for the API
+func getValidatorKeyPathOverrides(c *cli.Context) (string, error) {
+	overridesFile := c.String("validator-key-path-overrides")
+	if overridesFile == "" {
+		return "", nil
+	}
+	overridesFile, err := homedir.Expand(overridesFile)
+	if err != nil {
+		return "", fmt.Errorf("error expanding validator key path overrides file path: %w", err)
+	}
+	fileBytes, err := os.ReadFile(overridesFile)
+	if err != nil {
+		return "", fmt.Errorf("error reading validator key path overrides file: %w", err)
+	}
+	overrides := map[string]string{}
+	err = yaml.Unmarshal(fileBytes, &overrides)
+	if err != nil {
+		return "", fmt.Errorf("error parsing validator key path overrides file: %w", err)
+	}
+
+	pairs := []string{}
+	for pubkeyString, derivationPath := range overrides {
+		pubkey, err := cliutils.ValidatePubkey("validator pubkey", pubkeyString)
+		if err != nil {
+			return "", err
+		}
+		pairs = append(pairs, fmt.Sprintf("%s=%s", pubkey.Hex(), strings.TrimSpace(derivationPath)))
+	}
+	if len(pairs) > 0 {
+		fmt.Printf("Using custom derivation paths for %d validator key(s).\n", len(pairs))
+	}
+	return strings.Join(pairs, ","), nil
+}
+
 // Prompt for a recovery mnemonic phrase
 func PromptMnemonic() string {
+
+	// Use the whole phrase from the environment if it's set, since the words can't be prompted for one at a time
+	if _, isSet := os.LookupEnv(mnemonicEnvVar); isSet || cliutils.IsNonInteractive() {
+		phrase := cliutils.Prompt("Please enter your mnemonic phrase:", "^\\s*[a-zA-Z]+(\\s+[a-zA-Z]+)*\\s*$", "", mnemonicEnvVar)
+		words := strings.Fields(strings.ToLower(phrase))
+		mv := bip39.Create(len(words))
+		if mv == nil {
+			fmt.Fprintf(os.Stderr, "The mnemonic in %s has an invalid number of words (%d).\n", mnemonicEnvVar, len(words))
+			os.Exit(1)
+		}
+		for _, word := range words {
+			if err := mv.AddWord(word); err != nil {
+				fmt.Fprintf(os.Stderr, "The mnemonic in %s has an invalid word: %s\n", mnemonicEnvVar, err.Error())
+				os.Exit(1)
+			}
+		}
+		mnemonic, err := mv.Finalize()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "The mnemonic in %s is invalid: %s\n", mnemonicEnvVar, err.Error())
+			os.Exit(1)
+		}
+		return mnemonic
+	}
+
 	for {
 		lengthInput := cliutils.Prompt(
 			"Please enter the "+bold+"number"+unbold+" of words in your mnemonic phrase (24 by default):",
 			"^[1-9][0-9]*$",
-			"Please enter a valid number.")
+			"Please enter a valid number.",
+			"")
 
 		length, err := strconv.Atoi(lengthInput)
 		if err != nil {
@@ -64,7 +177,7 @@ func PromptMnemonic() string {
 		i := 0
 		for mv.Filled() == false {
 			prompt := fmt.Sprintf("Enter %sWord Number %d%s of your mnemonic:", bold, i+1, unbold)
-			word := cliutils.PromptPassword(prompt, "^[a-zA-Z]+$", "Please enter a single word only.")
+			word := cliutils.PromptPassword(prompt, "^[a-zA-Z]+$", "Please enter a single word only.", "")
 
 			if err := mv.AddWord(strings.ToLower(word)); err != nil {
 				fmt.Println("Inputted word not valid, please retry.")
@@ -159,6 +272,7 @@ func promptForCustomKeyPasswords(rp *rocketpool.Client, cfg *config.RocketPoolCo
 	for _, pubkey := range customPubkeys {
 		password := cliutils.PromptPassword(
 			fmt.Sprintf("Please enter the password that the keystore for %s was encrypted with:", pubkey.Hex()), "^.*$", "",
+			"ROCKETPOOL_KEYSTORE_PASSWORD",
 		)
 
 		formattedPubkey := strings.ToUpper(hexutils.RemovePrefix(pubkey.Hex()))
@@ -35,6 +35,22 @@ func waitForTransaction(c *cli.Context, hash common.Hash) (*apitypes.APIResponse
 		return nil, err
 	}
 
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the transaction was proposed to the node's Safe, wait for the transaction that executes it
+	if w.IsSafe() {
+		executionHash, proposed, err := w.WaitForSafeTransaction(hash)
+		if err != nil {
+			return nil, err
+		}
+		if proposed {
+			hash = executionHash
+		}
+	}
+
 	// Response
 	response := apitypes.APIResponse{}
 	_, err = utils.WaitForTransaction(rp.Client, hash)
@@ -78,6 +78,27 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "get-migration-status",
+				Usage:     "Get the progress of a solo validator's migration into a vacant minipool",
+				UsageText: "rocketpool api minipool get-migration-status minipool-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getMigrationStatus(c, minipoolAddress))
+					return nil
+
+				},
+			},
 			{
 				Name:      "can-promote",
 				Usage:     "Check whether a vacant minipool is ready to be promoted",
@@ -252,6 +273,122 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "presign-exits",
+				Usage:     "Sign voluntary exits for all of the node's validators ahead of time, and save them in an encrypted vault",
+				UsageText: "rocketpool api minipool presign-exits password",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					password, err := cliutils.ValidateNodePassword("password", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(presignExits(c, password))
+					return nil
+
+				},
+			},
+			{
+				Name:      "broadcast-presigned-exit",
+				Usage:     "Broadcast a voluntary exit that was signed ahead of time",
+				UsageText: "rocketpool api minipool broadcast-presigned-exit validator-index epoch signature",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					if _, err := cliutils.ValidateUint("validator index", c.Args().Get(0)); err != nil {
+						return err
+					}
+					epoch, err := cliutils.ValidateUint("epoch", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					signature, err := cliutils.ValidateValidatorSignature("signature", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(broadcastPresignedExit(c, c.Args().Get(0), epoch, signature))
+					return nil
+
+				},
+			},
+			{
+				Name:      "schedule-exit",
+				Usage:     "Schedule a staking minipool's exit for the daemon to broadcast at a future epoch, or when the exit queue is short enough",
+				UsageText: "rocketpool api minipool schedule-exit minipool-address epoch max-exit-queue",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					epoch, err := cliutils.ValidateUint("epoch", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					maxExitQueue, err := cliutils.ValidateUint("max exit queue", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(scheduleMinipoolExit(c, minipoolAddress, epoch, maxExitQueue))
+					return nil
+
+				},
+			},
+			{
+				Name:      "get-scheduled-exits",
+				Usage:     "Get the node's scheduled minipool exits and their progress",
+				UsageText: "rocketpool api minipool get-scheduled-exits",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getScheduledExits(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "cancel-scheduled-exit",
+				Usage:     "Cancel a minipool's scheduled exit before it's broadcast",
+				UsageText: "rocketpool api minipool cancel-scheduled-exit minipool-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(cancelScheduledExit(c, minipoolAddress))
+					return nil
+
+				},
+			},
 
 			{
 				Name:      "get-minipool-close-details-for-node",
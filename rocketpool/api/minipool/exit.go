@@ -1,11 +1,12 @@
 package minipool
 
 import (
+	"fmt"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
-	eth2types "github.com/wealdtech/go-eth2-types/v2"
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
@@ -67,6 +68,10 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 	if err := services.RequireBeaconClientSynced(c); err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -89,38 +94,23 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 		return nil, err
 	}
 
-	// Get validator private key
-	validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
+	// Distributed validators can only be exited by their cluster
+	isDistributed, err := validator.IsDistributedValidator(cfg.Smartnode.GetDistributedValidatorsPath(), validatorPubkey)
 	if err != nil {
 		return nil, err
 	}
-
-	// Get beacon head
-	head, err := bc.GetBeaconHead()
-	if err != nil {
-		return nil, err
+	if isDistributed {
+		return nil, fmt.Errorf("minipool %s is run by a distributed validator, so its key isn't in the node wallet. Use your cluster's tools to exit it.", minipoolAddress.Hex())
 	}
 
-	// Get voluntary exit signature domain
-	signatureDomain, err := bc.GetDomainData(eth2types.DomainVoluntaryExit[:], head.Epoch, false)
-	if err != nil {
-		return nil, err
-	}
-
-	// Get validator index
-	validatorIndex, err := bc.GetValidatorIndex(validatorPubkey)
-	if err != nil {
-		return nil, err
-	}
-
-	// Get signed voluntary exit message
-	signature, err := validator.GetSignedExitMessage(validatorKey, validatorIndex, head.Epoch, signatureDomain)
+	// Get validator private key
+	validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
 	if err != nil {
 		return nil, err
 	}
 
-	// Broadcast voluntary exit message
-	if err := bc.ExitValidator(validatorIndex, head.Epoch, signature); err != nil {
+	// Sign and broadcast the voluntary exit message
+	if _, err := validator.BroadcastExitMessage(bc, validatorKey, validatorPubkey); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,113 @@
+package minipool
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getMigrationStatus(c *cli.Context, minipoolAddress common.Address) (*api.MinipoolMigrationStatusResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolMigrationStatusResponse{}
+
+	// Create minipool
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate minipool owner
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+		return nil, err
+	}
+
+	// Get the minipool's status
+	status, err := mp.GetStatusDetails(nil)
+	if err != nil {
+		return nil, err
+	}
+	response.IsVacant = status.IsVacant
+	response.MinipoolStatus = status.Status
+	response.CreationTime = status.StatusTime
+	if !status.IsVacant {
+		return &response, nil
+	}
+
+	// Get the migration windows
+	scrubPeriodSeconds, err := trustednode.GetPromotionScrubPeriod(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	scrubPeriod := time.Duration(scrubPeriodSeconds) * time.Second
+	credentialsPeriod := time.Duration(scrubPeriod.Seconds()*config.SoloMigrationCheckThreshold) * time.Second
+	response.PromotionTime = status.StatusTime.Add(scrubPeriod)
+	response.CredentialsDeadline = status.StatusTime.Add(credentialsPeriod)
+
+	// Get the time of the latest block
+	latestEth1Block, err := rp.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("Can't get the latest block time: %w", err)
+	}
+	response.LatestBlockTime = time.Unix(int64(latestEth1Block.Time), 0)
+
+	// Get the validator's credentials
+	response.Pubkey, err = minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.ExpectedWithdrawalCredentials, err = minipool.GetMinipoolWithdrawalCredentials(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	validatorStatus, err := bc.GetValidatorStatus(response.Pubkey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error checking status of validator %s: %w", response.Pubkey.Hex(), err)
+	}
+	response.ValidatorExists = validatorStatus.Exists
+	if validatorStatus.Exists {
+		response.ValidatorStatus = validatorStatus.Status
+		response.ValidatorIndex = validatorStatus.Index
+		response.Balance = validatorStatus.Balance
+		response.WithdrawalCredentials = validatorStatus.WithdrawalCredentials
+		response.CredentialsChanged = (validatorStatus.WithdrawalCredentials == response.ExpectedWithdrawalCredentials)
+	}
+
+	// The minipool can be promoted once the scrub period is over, as long as the Oracle DAO hasn't dissolved it
+	response.CanPromote = (status.Status == types.Prelaunch && response.LatestBlockTime.After(response.PromotionTime))
+
+	// Return response
+	return &response, nil
+
+}
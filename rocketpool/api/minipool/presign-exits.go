@@ -0,0 +1,167 @@
+package minipool
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+func presignExits(c *cli.Context, password string) (*api.PresignExitsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PresignExitsResponse{
+		Skipped: []common.Address{},
+	}
+
+	// Get the node's validators
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get voluntary exit signature domain; it's pinned to the Capella fork, so the exits don't expire at later upgrades
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, err
+	}
+	signatureDomain, err := validator.GetVoluntaryExitDomain(bc)
+	if err != nil {
+		return nil, err
+	}
+	response.Epoch = head.Epoch
+
+	// Sign an exit for each validator that's on the Beacon chain and hasn't exited yet
+	exits := []api.PresignedExit{}
+	for _, pubkey := range pubkeys {
+		status := statuses[pubkey]
+		if !status.Exists || !canPresignExit(status.Status) {
+			continue
+		}
+		minipoolAddress, err := minipool.GetMinipoolByPubkey(rp, pubkey, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// Distributed validators can only be exited by their cluster
+		isDistributed, err := validator.IsDistributedValidator(cfg.Smartnode.GetDistributedValidatorsPath(), pubkey)
+		if err != nil {
+			return nil, err
+		}
+		if isDistributed {
+			response.Skipped = append(response.Skipped, minipoolAddress)
+			continue
+		}
+
+		// Get signed voluntary exit message
+		validatorKey, err := w.GetValidatorKeyByPubkey(pubkey)
+		if err != nil {
+			return nil, err
+		}
+		signature, err := validator.GetSignedExitMessage(validatorKey, status.Index, head.Epoch, signatureDomain)
+		if err != nil {
+			return nil, err
+		}
+		exits = append(exits, api.PresignedExit{
+			Minipool: minipoolAddress,
+			Pubkey:   pubkey,
+			Message: api.PresignedExitMessage{
+				Epoch:          fmt.Sprint(head.Epoch),
+				ValidatorIndex: status.Index,
+			},
+			Signature: "0x" + signature.Hex(),
+		})
+	}
+	if len(exits) == 0 {
+		return nil, fmt.Errorf("none of the node's validators can be exited")
+	}
+
+	// Encrypt and save the vault
+	vault, err := validator.NewExitVault(nodeAccount.Address, head.Epoch, exits, password)
+	if err != nil {
+		return nil, err
+	}
+	vaultPath := os.ExpandEnv(cfg.Smartnode.GetExitVaultPath())
+	if err := vault.Save(vaultPath); err != nil {
+		return nil, err
+	}
+	response.Count = len(exits)
+	response.VaultFile = vaultPath
+
+	// Return response
+	return &response, nil
+
+}
+
+func broadcastPresignedExit(c *cli.Context, validatorIndex string, epoch uint64, signature types.ValidatorSignature) (*api.BroadcastPresignedExitResponse, error) {
+
+	// Get services; the validator keys aren't needed, so this works even if they're lost
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.BroadcastPresignedExitResponse{}
+
+	// Broadcast voluntary exit message
+	if err := bc.ExitValidator(validatorIndex, epoch, signature); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Check if a validator in the provided state can still be exited
+func canPresignExit(state beacon.ValidatorState) bool {
+	switch state {
+	case beacon.ValidatorState_PendingInitialized, beacon.ValidatorState_PendingQueued, beacon.ValidatorState_ActiveOngoing:
+		return true
+	}
+	return false
+}
@@ -16,11 +16,12 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
-	"github.com/rocket-pool/smartnode/shared/utils/validator"
+	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
 )
 
 func getMinipoolRescueDissolvedDetailsForNode(c *cli.Context) (*api.GetMinipoolRescueDissolvedDetailsForNodeResponse, error) {
@@ -29,6 +30,10 @@ func getMinipoolRescueDissolvedDetailsForNode(c *cli.Context) (*api.GetMinipoolR
 	if err := services.RequireNodeRegistered(c); err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -73,7 +78,7 @@ func getMinipoolRescueDissolvedDetailsForNode(c *cli.Context) (*api.GetMinipoolR
 			mi := mi
 			wg.Go(func() error {
 				address := addresses[mi]
-				mpDetails, err := getMinipoolRescueDissolvedDetails(rp, w, bc, address, nodeAccount.Address)
+				mpDetails, err := getMinipoolRescueDissolvedDetails(cfg, rp, w, bc, address, nodeAccount.Address)
 				if err == nil {
 					details[mi] = mpDetails
 				}
@@ -91,7 +96,7 @@ func getMinipoolRescueDissolvedDetailsForNode(c *cli.Context) (*api.GetMinipoolR
 
 }
 
-func getMinipoolRescueDissolvedDetails(rp *rocketpool.RocketPool, w *wallet.Wallet, bc beacon.Client, minipoolAddress common.Address, nodeAddress common.Address) (api.MinipoolRescueDissolvedDetails, error) {
+func getMinipoolRescueDissolvedDetails(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, w *wallet.Wallet, bc beacon.Client, minipoolAddress common.Address, nodeAddress common.Address) (api.MinipoolRescueDissolvedDetails, error) {
 
 	// Create minipool
 	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
@@ -193,7 +198,7 @@ func getMinipoolRescueDissolvedDetails(rp *rocketpool.RocketPool, w *wallet.Wall
 	opts.GasLimit = 0
 
 	// Get the gas info for depositing
-	tx, err := getDepositTx(rp, w, bc, minipoolAddress, one, opts)
+	tx, err := getDepositTx(cfg, rp, w, bc, minipoolAddress, one, opts)
 	if err != nil {
 		return api.MinipoolRescueDissolvedDetails{}, fmt.Errorf("error estimating gas for rescue deposit on minipool %s: %w", minipoolAddress.Hex(), err)
 	}
@@ -216,7 +221,7 @@ func getMinipoolRescueDissolvedDetails(rp *rocketpool.RocketPool, w *wallet.Wall
 }
 
 // Create a transaction for submitting a rescue deposit, optionally simulating it only for gas estimation
-func getDepositTx(rp *rocketpool.RocketPool, w *wallet.Wallet, bc beacon.Client, minipoolAddress common.Address, amount *big.Int, opts *bind.TransactOpts) (*types.Transaction, error) {
+func getDepositTx(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, w *wallet.Wallet, bc beacon.Client, minipoolAddress common.Address, amount *big.Int, opts *bind.TransactOpts) (*types.Transaction, error) {
 
 	blankAddress := common.Address{}
 	casperAddress, err := rp.GetAddress("casperDeposit", nil)
@@ -250,21 +255,17 @@ func getDepositTx(rp *rocketpool.RocketPool, w *wallet.Wallet, bc beacon.Client,
 		return nil, err
 	}
 
-	// Get the validator key for the minipool
+	// Get the validator pubkey for the minipool
 	validatorPubkey, err := minipool.GetMinipoolPubkey(rp, mp.GetAddress(), nil)
 	if err != nil {
 		return nil, err
 	}
-	validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
-	if err != nil {
-		return nil, err
-	}
 
 	// Get the deposit amount in gwei
 	amountGwei := big.NewInt(0).Div(amount, big.NewInt(1e9)).Uint64()
 
 	// Get validator deposit data
-	depositData, depositDataRoot, err := validator.GetDepositData(validatorKey, withdrawalCredentials, eth2Config, amountGwei)
+	depositData, depositDataRoot, err := walletutils.GetMinipoolDepositData(cfg, w, validatorPubkey, withdrawalCredentials, eth2Config, amountGwei)
 	if err != nil {
 		return nil, err
 	}
@@ -287,6 +288,10 @@ func rescueDissolvedMinipool(c *cli.Context, minipoolAddress common.Address, amo
 	if err := services.RequireNodeRegistered(c); err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -317,7 +322,7 @@ func rescueDissolvedMinipool(c *cli.Context, minipoolAddress common.Address, amo
 	}
 
 	// Submit the rescue deposit
-	tx, err := getDepositTx(rp, w, bc, minipoolAddress, amount, opts)
+	tx, err := getDepositTx(cfg, rp, w, bc, minipoolAddress, amount, opts)
 	if err != nil {
 		return nil, fmt.Errorf("error submitting rescue deposit: %w", err)
 	}
@@ -0,0 +1,186 @@
+package minipool
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+func scheduleMinipoolExit(c *cli.Context, minipoolAddress common.Address, epoch uint64, maxExitQueue uint64) (*api.ScheduleMinipoolExitResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ScheduleMinipoolExitResponse{}
+
+	// Create minipool
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate minipool owner
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+		return nil, err
+	}
+
+	// Check minipool status
+	status, err := mp.GetStatus(nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != types.Staking {
+		return nil, fmt.Errorf("minipool %s is not staking, so it can't be exited.", minipoolAddress.Hex())
+	}
+
+	// The daemon needs the validator key to sign the exit when it's time
+	validatorPubkey, err := minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	isDistributed, err := validator.IsDistributedValidator(cfg.Smartnode.GetDistributedValidatorsPath(), validatorPubkey)
+	if err != nil {
+		return nil, err
+	}
+	if isDistributed {
+		return nil, fmt.Errorf("minipool %s is run by a distributed validator, so its key isn't in the node wallet. Use your cluster's tools to exit it.", minipoolAddress.Hex())
+	}
+	if _, err := w.GetValidatorKeyByPubkey(validatorPubkey); err != nil {
+		return nil, err
+	}
+
+	// Don't replace an exit that was already broadcast
+	exitsPath := os.ExpandEnv(cfg.Smartnode.GetScheduledExitsPath())
+	exits, err := validator.LoadScheduledExits(exitsPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, exit := range exits {
+		if exit.Minipool == minipoolAddress && exit.Broadcast {
+			return nil, fmt.Errorf("the exit of minipool %s was already broadcast in epoch %d.", minipoolAddress.Hex(), exit.BroadcastEpoch)
+		}
+	}
+
+	// Schedule the exit
+	err = validator.SaveScheduledExit(exitsPath, api.ScheduledExit{
+		Minipool:     minipoolAddress,
+		Pubkey:       validatorPubkey,
+		Epoch:        epoch,
+		MaxExitQueue: maxExitQueue,
+		Created:      time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func getScheduledExits(c *cli.Context) (*api.GetScheduledExitsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GetScheduledExitsResponse{}
+
+	// Get the scheduled exits
+	exits, err := validator.LoadScheduledExits(os.ExpandEnv(cfg.Smartnode.GetScheduledExitsPath()))
+	if err != nil {
+		return nil, err
+	}
+	response.Exits = exits
+
+	// Get the current epoch and exit queue
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, err
+	}
+	response.CurrentEpoch = head.Epoch
+	response.ExitQueue, err = bc.GetExitQueueLength()
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func cancelScheduledExit(c *cli.Context, minipoolAddress common.Address) (*api.CancelScheduledExitResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CancelScheduledExitResponse{}
+
+	// Find the exit
+	exitsPath := os.ExpandEnv(cfg.Smartnode.GetScheduledExitsPath())
+	exits, err := validator.LoadScheduledExits(exitsPath)
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, exit := range exits {
+		if exit.Minipool != minipoolAddress {
+			continue
+		}
+		if exit.Broadcast && !exit.Withdrawn {
+			return nil, fmt.Errorf("the exit of minipool %s was already broadcast in epoch %d, so it can't be cancelled.", minipoolAddress.Hex(), exit.BroadcastEpoch)
+		}
+		found = true
+	}
+	if !found {
+		return nil, fmt.Errorf("minipool %s doesn't have a scheduled exit.", minipoolAddress.Hex())
+	}
+
+	// Cancel it
+	if err := validator.RemoveScheduledExit(exitsPath, minipoolAddress); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
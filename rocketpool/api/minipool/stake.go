@@ -14,7 +14,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
-	"github.com/rocket-pool/smartnode/shared/utils/validator"
+	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
 )
 
 func canStakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanStakeMinipoolResponse, error) {
@@ -23,6 +23,10 @@ func canStakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanS
 	if err := services.RequireNodeRegistered(c); err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -98,15 +102,11 @@ func canStakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanS
 			return nil, err
 		}
 
-		// Get the validator key for the minipool
+		// Get the validator pubkey for the minipool
 		validatorPubkey, err := minipool.GetMinipoolPubkey(rp, mp.GetAddress(), nil)
 		if err != nil {
 			return nil, err
 		}
-		validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
-		if err != nil {
-			return nil, err
-		}
 
 		// Get the minipool type
 		depositType, err := minipool.GetMinipoolDepositType(rp, mp.GetAddress(), nil)
@@ -125,7 +125,7 @@ func canStakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanS
 		}
 
 		// Get validator deposit data
-		depositData, depositDataRoot, err := validator.GetDepositData(validatorKey, withdrawalCredentials, eth2Config, depositAmount)
+		depositData, depositDataRoot, err := walletutils.GetMinipoolDepositData(cfg, w, validatorPubkey, withdrawalCredentials, eth2Config, depositAmount)
 		if err != nil {
 			return nil, err
 		}
@@ -155,6 +155,10 @@ func stakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.StakeMi
 	if err := services.RequireNodeRegistered(c); err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -201,15 +205,11 @@ func stakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.StakeMi
 		return nil, err
 	}
 
-	// Get the validator key for the minipool
+	// Get the validator pubkey for the minipool
 	validatorPubkey, err := minipool.GetMinipoolPubkey(rp, mp.GetAddress(), nil)
 	if err != nil {
 		return nil, err
 	}
-	validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
-	if err != nil {
-		return nil, err
-	}
 
 	// Get the minipool type
 	depositType, err := minipool.GetMinipoolDepositType(rp, mp.GetAddress(), nil)
@@ -228,7 +228,7 @@ func stakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.StakeMi
 	}
 
 	// Get validator deposit data
-	depositData, depositDataRoot, err := validator.GetDepositData(validatorKey, withdrawalCredentials, eth2Config, depositAmount)
+	depositData, depositDataRoot, err := walletutils.GetMinipoolDepositData(cfg, w, validatorPubkey, withdrawalCredentials, eth2Config, depositAmount)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,135 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+func auditSmoothingPool(c *cli.Context, index uint64) (*api.NetworkSmoothingPoolAuditResponse, error) {
+
+	// Get services
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkSmoothingPoolAuditResponse{}
+
+	// Make sure the interval has been submitted
+	currentIndexBig, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	if index >= currentIndexBig.Uint64() {
+		return nil, fmt.Errorf("interval %d has not been submitted yet; the current active interval is %d", index, currentIndexBig.Uint64())
+	}
+
+	// Load the rewards and minipool performance files
+	rewardsFilePath := getExistingPath(cfg.Smartnode.GetRewardsTreeSszPath(index, true), cfg.Smartnode.GetRewardsTreePath(index, true))
+	if rewardsFilePath == "" {
+		return nil, fmt.Errorf("you don't have the rewards file for interval %d", index)
+	}
+	performanceFilePath := getExistingPath(cfg.Smartnode.GetMinipoolPerformanceSszPath(index, true), cfg.Smartnode.GetMinipoolPerformancePath(index, true))
+	if performanceFilePath == "" {
+		return nil, fmt.Errorf("you don't have the minipool performance file for interval %d; it is only created when the tree is generated locally, so please generate it with `rocketpool network generate-rewards-tree`", index)
+	}
+	rewardsFile, err := rprewards.LoadRewardsFile(rewardsFilePath)
+	if err != nil {
+		return nil, err
+	}
+	performanceFile, err := rprewards.LoadMinipoolPerformanceFile(performanceFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the local file is the canonical one
+	rewardsEvent, err := rprewards.GetRewardSnapshotEvent(rp, cfg, index, nil)
+	if err != nil {
+		return nil, err
+	}
+	if common.HexToHash(rewardsFile.GetHeader().MerkleRoot) != rewardsEvent.MerkleRoot {
+		return nil, fmt.Errorf("your rewards file for interval %d has a Merkle root of %s, but the canonical root is %s", index, rewardsFile.GetHeader().MerkleRoot, rewardsEvent.MerkleRoot.Hex())
+	}
+
+	// Get the EL block
+	elBlockHeader, err := rp.Client.HeaderByNumber(context.Background(), rewardsEvent.ExecutionBlock)
+	if err != nil {
+		return nil, fmt.Errorf("error getting execution block %s: %w", rewardsEvent.ExecutionBlock.String(), err)
+	}
+
+	// Use the archive EC if the primary one doesn't have the state for the target block
+	opts := &bind.CallOpts{
+		BlockNumber: elBlockHeader.Number,
+	}
+	_, err = rp.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.addressrocketTokenRETH")))
+	if err != nil {
+		archiveEcUrl := cfg.Smartnode.ArchiveECUrl.Value.(string)
+		if archiveEcUrl == "" {
+			return nil, fmt.Errorf("your Execution client cannot retrieve the state for historical block %d and the Archive EC is not specified", elBlockHeader.Number.Uint64())
+		}
+		ec, err := ethclient.Dial(archiveEcUrl)
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to archive EC: %w", err)
+		}
+		rp, err = rocketpool.NewRocketPool(ec, common.HexToAddress(cfg.Smartnode.GetStorageAddress()))
+		if err != nil {
+			return nil, fmt.Errorf("error creating Rocket Pool client connected to archive EC: %w", err)
+		}
+	}
+
+	// Get the state at the end of the interval
+	logger := log.NewColorLogger(NormalLogger)
+	mgr, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &logger)
+	if err != nil {
+		return nil, fmt.Errorf("error creating network state manager: %w", err)
+	}
+	networkState, err := mgr.GetStateForSlot(rewardsEvent.ConsensusBlock.Uint64())
+	if err != nil {
+		return nil, fmt.Errorf("error getting state for Beacon slot %d: %w", rewardsEvent.ConsensusBlock.Uint64(), err)
+	}
+
+	// Run the audit
+	response.Audit, err = rprewards.AuditSmoothingPool(rewardsFile, performanceFile, networkState)
+	if err != nil {
+		return nil, fmt.Errorf("error auditing the Smoothing Pool for interval %d: %w", index, err)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the first of the provided paths that exists, or an empty string if none of them do
+func getExistingPath(paths ...string) string {
+	for _, path := range paths {
+		_, err := os.Stat(path)
+		if err == nil {
+			return path
+		}
+	}
+	return ""
+}
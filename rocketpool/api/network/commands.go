@@ -91,6 +91,28 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "fee-history",
+				Usage:     "Get the base fees of the latest blocks",
+				UsageText: "rocketpool api network fee-history block-count",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					blockCount, err := cliutils.ValidateUint("block count", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getFeeHistory(c, blockCount))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-generate-rewards-tree",
 				Usage:     "Check if the rewards tree for the provided interval can be generated",
@@ -137,6 +159,102 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "dry-run-rewards-tree",
+				Usage:     "Set a request marker for the watchtower to profile a dry run of rewards tree generation for the given interval",
+				UsageText: "rocketpool api network dry-run-rewards-tree index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(dryRunRewardsTree(c, index))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "archive-rewards-trees",
+				Usage:     "Set request markers for the watchtower to regenerate and archive the rewards trees for a range of intervals",
+				UsageText: "rocketpool api network archive-rewards-trees start-index end-index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					startIndex, err := cliutils.ValidateUint("start index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					endIndex, err := cliutils.ValidateUint("end index", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(archiveRewardsTrees(c, startIndex, endIndex))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "audit-smoothing-pool",
+				Usage:     "Compare each node's attestation performance against the share of the Smoothing Pool it received for the given interval",
+				UsageText: "rocketpool api network audit-smoothing-pool index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(auditSmoothingPool(c, index))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "verify-rewards-tree",
+				Usage:     "Regenerate the rewards tree for the provided interval and compare it against the canonical tree",
+				UsageText: "rocketpool api network verify-rewards-tree index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(verifyRewardsTree(c, index))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "dao-proposals",
 				Aliases:   []string{"d"},
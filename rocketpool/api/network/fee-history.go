@@ -0,0 +1,39 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getFeeHistory(c *cli.Context, blockCount uint64) (*api.NetworkFeeHistoryResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkFeeHistoryResponse{}
+
+	// Get the fee history of the latest blocks
+	history, err := ec.FeeHistory(context.Background(), blockCount, nil, []float64{})
+	if err != nil {
+		return nil, fmt.Errorf("Error getting fee history: %w", err)
+	}
+	response.OldestBlock = history.OldestBlock
+	response.BaseFees = history.BaseFee
+	response.GasUsedRatios = history.GasUsedRatio
+
+	// Return response
+	return &response, nil
+
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/urfave/cli"
 )
@@ -75,3 +76,63 @@ func generateRewardsTree(c *cli.Context, index uint64) (*api.NetworkGenerateRewa
 	return &response, nil
 
 }
+
+func dryRunRewardsTree(c *cli.Context, index uint64) (*api.NetworkGenerateRewardsTreeResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkGenerateRewardsTreeResponse{}
+
+	// Create the dry run request
+	requestPath := cfg.Smartnode.GetDryRunRewardsTreeRequestPath(index, true)
+	requestFile, err := os.Create(requestPath)
+	if requestFile != nil {
+		requestFile.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error creating request marker: %w", err)
+	}
+
+	return &response, nil
+
+}
+
+func archiveRewardsTrees(c *cli.Context, startIndex uint64, endIndex uint64) (*api.NetworkGenerateRewardsTreeResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkGenerateRewardsTreeResponse{}
+
+	// Limit how many intervals can be queued at once, since each one is a full tree generation
+	if startIndex > endIndex {
+		return nil, fmt.Errorf("the start interval (%d) must not be after the end interval (%d)", startIndex, endIndex)
+	}
+	if endIndex-startIndex+1 > config.ArchiveRewardsTreeMaxSpan {
+		return nil, fmt.Errorf("cannot regenerate more than %d intervals at once (requested %d)", config.ArchiveRewardsTreeMaxSpan, endIndex-startIndex+1)
+	}
+
+	// Create a request for each interval; the watchtower handles them one at a time
+	for index := startIndex; index <= endIndex; index++ {
+		requestPath := cfg.Smartnode.GetArchiveRewardsTreeRequestPath(index, true)
+		requestFile, err := os.Create(requestPath)
+		if requestFile != nil {
+			requestFile.Close()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Error creating request marker for interval %d: %w", index, err)
+		}
+	}
+
+	return &response, nil
+
+}
@@ -0,0 +1,123 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/urfave/cli"
+)
+
+func verifyRewardsTree(c *cli.Context, index uint64) (*api.NetworkVerifyRewardsTreeResponse, error) {
+
+	// Get services
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkVerifyRewardsTreeResponse{}
+
+	// Make sure the interval has been submitted
+	currentIndexBig, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	if index >= currentIndexBig.Uint64() {
+		return nil, fmt.Errorf("interval %d has not been submitted yet; the current active interval is %d", index, currentIndexBig.Uint64())
+	}
+
+	// Find the event for this interval
+	rewardsEvent, err := rprewards.GetRewardSnapshotEvent(rp, cfg, index, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.CanonicalMerkleRoot = rewardsEvent.MerkleRoot
+
+	// Get the EL block
+	elBlockHeader, err := rp.Client.HeaderByNumber(context.Background(), rewardsEvent.ExecutionBlock)
+	if err != nil {
+		return nil, fmt.Errorf("error getting execution block %s: %w", rewardsEvent.ExecutionBlock.String(), err)
+	}
+
+	// Use the archive EC if the primary one doesn't have the state for the target block
+	opts := &bind.CallOpts{
+		BlockNumber: elBlockHeader.Number,
+	}
+	_, err = rp.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.addressrocketTokenRETH")))
+	if err != nil {
+		archiveEcUrl := cfg.Smartnode.ArchiveECUrl.Value.(string)
+		if archiveEcUrl == "" {
+			return nil, fmt.Errorf("your Execution client cannot retrieve the state for historical block %d and the Archive EC is not specified", elBlockHeader.Number.Uint64())
+		}
+		ec, err := ethclient.Dial(archiveEcUrl)
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to archive EC: %w", err)
+		}
+		rp, err = rocketpool.NewRocketPool(ec, common.HexToAddress(cfg.Smartnode.GetStorageAddress()))
+		if err != nil {
+			return nil, fmt.Errorf("error creating Rocket Pool client connected to archive EC: %w", err)
+		}
+	}
+
+	// Get the state for the target slot
+	logger := log.NewColorLogger(NormalLogger)
+	mgr, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &logger)
+	if err != nil {
+		return nil, fmt.Errorf("error creating network state manager: %w", err)
+	}
+	networkState, err := mgr.GetTreegenStateForSlot(rewardsEvent.ConsensusBlock.Uint64())
+	if err != nil {
+		return nil, fmt.Errorf("error getting state for Beacon slot %d: %w", rewardsEvent.ConsensusBlock.Uint64(), err)
+	}
+
+	// Generate the tree
+	generationPrefix := fmt.Sprintf("[Interval %d Verification]", index)
+	treegen, err := rprewards.NewTreeGenerator(&logger, generationPrefix, rprewards.GenerationFlow_Verification, rp, cfg, bc, index, rewardsEvent.IntervalStartTime, rewardsEvent.IntervalEndTime, rewardsEvent.ConsensusBlock.Uint64(), elBlockHeader, rewardsEvent.IntervalsPassed.Uint64(), networkState, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Merkle tree generator: %w", err)
+	}
+	localFile, err := treegen.GenerateTree()
+	if err != nil {
+		return nil, fmt.Errorf("error generating Merkle tree: %w", err)
+	}
+	response.LocalMerkleRoot = common.BytesToHash(localFile.GetHeader().MerkleTree.Root())
+	response.RootsMatch = (response.LocalMerkleRoot == response.CanonicalMerkleRoot)
+	if response.RootsMatch {
+		return &response, nil
+	}
+
+	// Get the canonical file so the individual nodes can be compared
+	canonicalBytes, err := rprewards.FetchRewardsFile(cfg, index, rewardsEvent.MerkleTreeCID, rewardsEvent.MerkleRoot)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading canonical rewards file for interval %d: %w", index, err)
+	}
+	canonicalFile, err := rprewards.DeserializeRewardsFile(canonicalBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing canonical rewards file for interval %d: %w", index, err)
+	}
+	response.NodeDiffs = rprewards.CompareRewardsFiles(localFile, canonicalFile)
+
+	// Return response
+	return &response, nil
+
+}
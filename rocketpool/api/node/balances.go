@@ -39,3 +39,34 @@ func getNodeEthBalance(c *cli.Context) (*api.NodeEthBalanceResponse, error) {
 
 	return &response, nil
 }
+
+func getNodeNonce(c *cli.Context) (*api.NodeGetNonceResponse, error) {
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeGetNonceResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	response.Nonce, err = ec.PendingNonceAt(context.Background(), nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error getting nonce of node %s: %w", nodeAccount.Address.Hex(), err)
+	}
+
+	return &response, nil
+}
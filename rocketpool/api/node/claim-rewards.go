@@ -76,6 +76,10 @@ func getRewardsInfo(c *cli.Context) (*api.NodeGetRewardsInfoResponse, error) {
 		if err != nil {
 			return nil, err
 		}
+		if intervalInfo.Pruned {
+			// Intervals are only pruned once there's nothing left to claim
+			continue
+		}
 		if !intervalInfo.TreeFileExists || !intervalInfo.MerkleRootValid {
 			response.InvalidIntervals = append(response.InvalidIntervals, intervalInfo)
 			continue
@@ -53,6 +53,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "get-duties",
+				Usage:     "Get the upcoming block proposals and sync committee duties of the node's validators",
+				UsageText: "rocketpool api node get-duties",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDuties(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-register",
 				Usage:     "Check whether the node can be registered with Rocket Pool",
@@ -598,6 +616,12 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				Name:      "can-deposit",
 				Usage:     "Check whether the node can make a deposit",
 				UsageText: "rocketpool api node can-deposit amount min-fee salt",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "dv-pubkey",
+						Usage: "The pubkey of a distributed validator to use for the minipool, whose deposit data has been saved to the distributed validators folder",
+					},
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -616,9 +640,13 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					if err != nil {
 						return err
 					}
+					dvPubkey, err := getDistributedValidatorPubkey(c)
+					if err != nil {
+						return err
+					}
 
 					// Run
-					api.PrintResponse(canNodeDeposit(c, amountWei, minNodeFee, salt))
+					api.PrintResponse(canNodeDeposit(c, amountWei, minNodeFee, salt, dvPubkey))
 					return nil
 
 				},
@@ -628,6 +656,12 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				Aliases:   []string{"d"},
 				Usage:     "Make a deposit and create a minipool, or just make and sign the transaction (when submit = false)",
 				UsageText: "rocketpool api node deposit amount min-fee salt use-credit-balance submit",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "dv-pubkey",
+						Usage: "The pubkey of a distributed validator to use for the minipool, whose deposit data has been saved to the distributed validators folder",
+					},
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -654,9 +688,13 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					if err != nil {
 						return err
 					}
+					dvPubkey, err := getDistributedValidatorPubkey(c)
+					if err != nil {
+						return err
+					}
 
 					// Run
-					response, err := nodeDeposit(c, amountWei, minNodeFee, salt, useCreditBalance, submit)
+					response, err := nodeDeposit(c, amountWei, minNodeFee, salt, dvPubkey, useCreditBalance, submit)
 					if submit {
 						api.PrintResponse(response, err)
 					} // else nodeDeposit already printed the encoded transaction
@@ -665,6 +703,28 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "get-minipool-address",
+				Usage:     "Get the address and withdrawal credentials a new minipool will have with the given salt",
+				UsageText: "rocketpool api node get-minipool-address salt",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					salt, err := cliutils.ValidateBigInt("salt", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getExpectedMinipoolAddress(c, salt))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-send",
 				Usage:     "Check whether the node can send ETH or tokens to an address",
@@ -830,6 +890,148 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "get-projected-rewards",
+				Usage:     "Estimate the node's rewards for the current interval",
+				UsageText: "rocketpool api node get-projected-rewards",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getProjectedRewards(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-rewards-history",
+				Usage:     "Get the node's rewards and claim transactions for every past interval",
+				UsageText: "rocketpool api node get-rewards-history",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRewardsHistory(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-tx-history",
+				Usage:     "Get the node's most recent transactions that were included in a block while the daemon was running",
+				UsageText: "rocketpool api node get-tx-history limit",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					limit, err := cliutils.ValidatePositiveUint("limit", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getTransactionHistory(c, limit))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-alert-history",
+				Usage:     "Get the alerts and notifications the daemon sent in the given number of days",
+				UsageText: "rocketpool api node get-alert-history days limit",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					days, err := cliutils.ValidatePositiveUint("days", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					limit, err := cliutils.ValidatePositiveUint("limit", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getAlertHistory(c, days, limit))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-duty-history",
+				Usage:     "Get the attestations and proposals of the node's validators in the most recent epochs the daemon checked",
+				UsageText: "rocketpool api node get-duty-history limit",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					limit, err := cliutils.ValidatePositiveUint("limit", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDutyHistory(c, limit))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-export-data",
+				Usage:     "Get the node's balances and the details of all of its minipools from the latest network state, for exporting",
+				UsageText: "rocketpool api node get-export-data",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getExportData(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-state-snapshot",
+				Usage:     "Get a snapshot of the latest network state for the node, which read-only commands can be run against without any clients",
+				UsageText: "rocketpool api node get-state-snapshot",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getStateSnapshot(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "deposit-contract-info",
 				Usage:     "Get information about the deposit contract specified by Rocket Pool and the Beacon Chain client",
@@ -1314,6 +1516,27 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "can-migrate-solo-validator",
+				Usage:     "Check whether a solo validator can be migrated into a vacant minipool",
+				UsageText: "rocketpool api node can-migrate-solo-validator pubkey",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					pubkey, err := cliutils.ValidatePubkey("pubkey", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canMigrateSoloValidator(c, pubkey))
+					return nil
+
+				},
+			},
 			{
 				Name:      "can-create-vacant-minipool",
 				Usage:     "Check whether a vacant minipool can be created for solo staker migration",
@@ -1417,6 +1640,128 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "get-nonce",
+				Usage:     "Get the nonce the node's next transaction will use, including its pending transactions",
+				UsageText: "rocketpool api node get-nonce",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getNodeNonce(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-pending-txs",
+				Usage:     "Get the node's transactions that are waiting in the Execution client's transaction pool",
+				UsageText: "rocketpool api node get-pending-txs",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getPendingTransactions(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-replace-tx",
+				Usage:     "Check whether the node's pending transaction with the given nonce can be replaced",
+				UsageText: "rocketpool api node can-replace-tx nonce",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					nonce, err := cliutils.ValidateUint("nonce", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canReplaceTransaction(c, nonce, false))
+					return nil
+
+				},
+			},
+			{
+				Name:      "replace-tx",
+				Usage:     "Replace the node's pending transaction with the given nonce with a copy that pays higher fees",
+				UsageText: "rocketpool api node replace-tx nonce",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					nonce, err := cliutils.ValidateUint("nonce", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(replaceTransaction(c, nonce, false))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-cancel-tx",
+				Usage:     "Check whether the node's pending transaction with the given nonce can be cancelled",
+				UsageText: "rocketpool api node can-cancel-tx nonce",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					nonce, err := cliutils.ValidateUint("nonce", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canReplaceTransaction(c, nonce, true))
+					return nil
+
+				},
+			},
+			{
+				Name:      "cancel-tx",
+				Usage:     "Cancel the node's pending transaction with the given nonce by replacing it with an empty transfer to itself that pays higher fees",
+				UsageText: "rocketpool api node cancel-tx nonce",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					nonce, err := cliutils.ValidateUint("nonce", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(replaceTransaction(c, nonce, true))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-send-message",
 				Usage:     "Estimates the gas for sending a zero-value message with a payload",
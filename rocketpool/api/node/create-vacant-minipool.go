@@ -14,6 +14,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -21,6 +22,39 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+// The Beacon balance a solo validator needs to be migrated, in gwei
+const soloMigrationMinBalance uint64 = 32e9
+
+func canMigrateSoloValidator(c *cli.Context, pubkey rptypes.ValidatorPubkey) (*api.CanMigrateSoloValidatorResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanMigrateSoloValidatorResponse{}
+
+	// Check the validator
+	response.Validator, err = getSoloValidatorDetails(bc, cfg, pubkey)
+	if err != nil {
+		return nil, err
+	}
+	response.CanMigrate = isSoloValidatorMigratable(response.Validator)
+
+	// Return response
+	return &response, nil
+
+}
+
 func canCreateVacantMinipool(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *big.Int, pubkey rptypes.ValidatorPubkey) (*api.CanCreateVacantMinipoolResponse, error) {
 
 	// Get services
@@ -111,8 +145,14 @@ func canCreateVacantMinipool(c *cli.Context, amountWei *big.Int, minNodeFee floa
 	response.InsufficientRplStake = (availableToMatch.Cmp(matchRequest) == -1)
 	response.MinipoolAddress = minipoolAddress
 
+	// Check the validator being migrated
+	response.Validator, err = getSoloValidatorDetails(bc, cfg, pubkey)
+	if err != nil {
+		return nil, err
+	}
+
 	// Update response
-	response.CanDeposit = !(response.InsufficientRplStake || response.InvalidAmount || response.DepositDisabled)
+	response.CanDeposit = !(response.InsufficientRplStake || response.InvalidAmount || response.DepositDisabled || !isSoloValidatorMigratable(response.Validator))
 	if !response.CanDeposit {
 		return &response, nil
 	}
@@ -123,23 +163,8 @@ func canCreateVacantMinipool(c *cli.Context, amountWei *big.Int, minNodeFee floa
 		return nil, err
 	}
 
-	// Check if the pubkey is for an existing active_ongoing validator
-	validatorStatus, err := bc.GetValidatorStatus(pubkey, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error checking status of existing validator: %w", err)
-	}
-	if !validatorStatus.Exists {
-		return nil, fmt.Errorf("validator %s does not exist on the Beacon chain. If you recently created it, please wait until the Consensus layer has processed your deposits.", pubkey.Hex())
-	}
-	if validatorStatus.Status != beacon.ValidatorState_ActiveOngoing {
-		return nil, fmt.Errorf("validator %s must be in the active_ongoing state to be migrated, but it is currently in %s.", pubkey.Hex(), string(validatorStatus.Status))
-	}
-	if cfg.Smartnode.Network.Value.(cfgtypes.Network) != cfgtypes.Network_Devnet && validatorStatus.WithdrawalCredentials[0] != 0x00 {
-		return nil, fmt.Errorf("validator %s already has withdrawal credentials [%s], which are not BLS credentials.", pubkey.Hex(), validatorStatus.WithdrawalCredentials.Hex())
-	}
-
 	// Convert the existing balance from gwei to wei
-	balanceWei := big.NewInt(0).SetUint64(validatorStatus.Balance)
+	balanceWei := big.NewInt(0).SetUint64(response.Validator.Balance)
 	balanceWei.Mul(balanceWei, big.NewInt(1e9))
 
 	// Run the deposit gas estimator
@@ -238,22 +263,25 @@ func createVacantMinipool(c *cli.Context, amountWei *big.Int, minNodeFee float64
 	response.WithdrawalCredentials = withdrawalCredentials
 
 	// Check if the pubkey is for an existing active_ongoing validator
-	validatorStatus, err := bc.GetValidatorStatus(pubkey, nil)
+	validator, err := getSoloValidatorDetails(bc, cfg, pubkey)
 	if err != nil {
-		return nil, fmt.Errorf("error checking status of existing validator: %w", err)
+		return nil, err
 	}
-	if !validatorStatus.Exists {
+	if validator.ValidatorNotFound {
 		return nil, fmt.Errorf("validator %s does not exist.", pubkey.Hex())
 	}
-	if validatorStatus.Status != beacon.ValidatorState_ActiveOngoing {
-		return nil, fmt.Errorf("validator %s must be in the active_ongoing state to be migrated, but it is currently in %s.", pubkey.Hex(), string(validatorStatus.Status))
+	if validator.ValidatorNotActive {
+		return nil, fmt.Errorf("validator %s must be in the active_ongoing state to be migrated, but it is currently in %s.", pubkey.Hex(), string(validator.ValidatorStatus))
 	}
-	if cfg.Smartnode.Network.Value.(cfgtypes.Network) != cfgtypes.Network_Devnet && validatorStatus.WithdrawalCredentials[0] != 0x00 {
-		return nil, fmt.Errorf("validator %s already has withdrawal credentials [%s], which are not BLS credentials.", pubkey.Hex(), validatorStatus.WithdrawalCredentials.Hex())
+	if validator.InvalidWithdrawalCredentials {
+		return nil, fmt.Errorf("validator %s already has withdrawal credentials [%s], which are not BLS credentials.", pubkey.Hex(), validator.WithdrawalCredentials.Hex())
+	}
+	if validator.InsufficientBalance {
+		return nil, fmt.Errorf("validator %s has a balance of %.6f ETH, but it needs at least 32 ETH to be migrated.", pubkey.Hex(), float64(validator.Balance)/1e9)
 	}
 
 	// Convert the existing balance from gwei to wei
-	balanceWei := big.NewInt(0).SetUint64(validatorStatus.Balance)
+	balanceWei := big.NewInt(0).SetUint64(validator.Balance)
 	balanceWei.Mul(balanceWei, big.NewInt(1e9))
 
 	// Override the provided pending TX if requested
@@ -275,3 +303,30 @@ func createVacantMinipool(c *cli.Context, amountWei *big.Int, minNodeFee float64
 	return &response, nil
 
 }
+
+// Check whether a solo validator is in a state that lets it be migrated into a vacant minipool
+func getSoloValidatorDetails(bc *services.BeaconClientManager, cfg *config.RocketPoolConfig, pubkey rptypes.ValidatorPubkey) (api.SoloValidatorDetails, error) {
+	details := api.SoloValidatorDetails{}
+	validatorStatus, err := bc.GetValidatorStatus(pubkey, nil)
+	if err != nil {
+		return details, fmt.Errorf("error checking status of existing validator: %w", err)
+	}
+	if !validatorStatus.Exists {
+		details.ValidatorNotFound = true
+		return details, nil
+	}
+
+	details.ValidatorStatus = validatorStatus.Status
+	details.ValidatorIndex = validatorStatus.Index
+	details.Balance = validatorStatus.Balance
+	details.WithdrawalCredentials = validatorStatus.WithdrawalCredentials
+	details.ValidatorNotActive = (validatorStatus.Status != beacon.ValidatorState_ActiveOngoing)
+	details.InvalidWithdrawalCredentials = (cfg.Smartnode.Network.Value.(cfgtypes.Network) != cfgtypes.Network_Devnet && validatorStatus.WithdrawalCredentials[0] != 0x00)
+	details.InsufficientBalance = (validatorStatus.Balance < soloMigrationMinBalance)
+	return details, nil
+}
+
+// Check if a solo validator passed all of the migration checks
+func isSoloValidatorMigratable(details api.SoloValidatorDetails) bool {
+	return !(details.ValidatorNotFound || details.ValidatorNotActive || details.InvalidWithdrawalCredentials || details.InsufficientBalance)
+}
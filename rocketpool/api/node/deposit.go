@@ -25,6 +25,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/types/eth2"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
@@ -36,12 +37,16 @@ const (
 	ValidatorEth          float64 = 32.0
 )
 
-func canNodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *big.Int) (*api.CanNodeDepositResponse, error) {
+func canNodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *big.Int, dvPubkey *rptypes.ValidatorPubkey) (*api.CanNodeDepositResponse, error) {
 
 	// Get services
 	if err := services.RequireNodeRegistered(c); err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -188,12 +193,6 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt
 		opts.Value = amountWei
 	}
 
-	// Get the next validator key
-	validatorKey, err := w.GetNextValidatorKey()
-	if err != nil {
-		return nil, err
-	}
-
 	// Get the next minipool address and withdrawal credentials
 	minipoolAddress, err = minipool.GetExpectedAddress(rp, nodeAccount.Address, salt, nil)
 	if err != nil {
@@ -207,7 +206,19 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt
 
 	// Get validator deposit data and associated parameters
 	depositAmount := uint64(1e9) // 1 ETH in gwei
-	depositData, depositDataRoot, err := validator.GetDepositData(validatorKey, withdrawalCredentials, eth2Config, depositAmount)
+	var depositData eth2.DepositData
+	var depositDataRoot common.Hash
+	if dvPubkey != nil {
+		// Distributed validators were signed by their cluster, so there's no key to generate
+		depositData, depositDataRoot, err = validator.GetDistributedDepositData(cfg.Smartnode.GetDistributedValidatorsPath(), *dvPubkey, withdrawalCredentials, eth2Config, depositAmount)
+	} else {
+		var validatorKey *eth2types.BLSPrivateKey
+		validatorKey, err = w.GetNextValidatorKey()
+		if err != nil {
+			return nil, err
+		}
+		depositData, depositDataRoot, err = validator.GetDepositData(validatorKey, withdrawalCredentials, eth2Config, depositAmount)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -257,12 +268,16 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt
 
 }
 
-func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *big.Int, useCreditBalance bool, submit bool) (*api.NodeDepositResponse, error) {
+func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *big.Int, dvPubkey *rptypes.ValidatorPubkey, useCreditBalance bool, submit bool) (*api.NodeDepositResponse, error) {
 
 	// Get services
 	if err := services.RequireNodeRegistered(c); err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -349,12 +364,6 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 		opts.Value = amountWei
 	}
 
-	// Create and save a new validator key
-	validatorKey, err := w.CreateValidatorKey()
-	if err != nil {
-		return nil, err
-	}
-
 	// Get the next minipool address and withdrawal credentials
 	minipoolAddress, err := minipool.GetExpectedAddress(rp, nodeAccount.Address, salt, nil)
 	if err != nil {
@@ -367,7 +376,20 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 
 	// Get validator deposit data and associated parameters
 	depositAmount := uint64(1e9) // 1 ETH in gwei
-	depositData, depositDataRoot, err := validator.GetDepositData(validatorKey, withdrawalCredentials, eth2Config, depositAmount)
+	var depositData eth2.DepositData
+	var depositDataRoot common.Hash
+	if dvPubkey != nil {
+		// Distributed validators were signed by their cluster, so there's no key to generate
+		depositData, depositDataRoot, err = validator.GetDistributedDepositData(cfg.Smartnode.GetDistributedValidatorsPath(), *dvPubkey, withdrawalCredentials, eth2Config, depositAmount)
+	} else {
+		// Create and save a new validator key
+		var validatorKey *eth2types.BLSPrivateKey
+		validatorKey, err = w.CreateValidatorKey()
+		if err != nil {
+			return nil, err
+		}
+		depositData, depositDataRoot, err = validator.GetDepositData(validatorKey, withdrawalCredentials, eth2Config, depositAmount)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -419,7 +441,7 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 	}
 
 	// Do not send transaction unless requested
-	opts.NoSend = !submit
+	opts.NoSend = opts.NoSend || !submit
 
 	// Deposit
 	var tx *types.Transaction
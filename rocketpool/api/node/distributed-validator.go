@@ -0,0 +1,65 @@
+package node
+
+import (
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Get the address a new minipool will have with the given salt, so a distributed validator cluster can use it for its withdrawal credentials
+func getExpectedMinipoolAddress(c *cli.Context, salt *big.Int) (*api.GetExpectedMinipoolAddressResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GetExpectedMinipoolAddressResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the minipool address and withdrawal credentials
+	response.MinipoolAddress, err = minipool.GetExpectedAddress(rp, nodeAccount.Address, salt, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.WithdrawalCredentials, err = minipool.GetMinipoolWithdrawalCredentials(rp, response.MinipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the distributed validator pubkey a deposit should use, or nil if it should use a new key from the node wallet
+func getDistributedValidatorPubkey(c *cli.Context) (*rptypes.ValidatorPubkey, error) {
+	if c.String("dv-pubkey") == "" {
+		return nil, nil
+	}
+	pubkey, err := cliutils.ValidatePubkey("distributed validator pubkey", c.String("dv-pubkey"))
+	if err != nil {
+		return nil, err
+	}
+	return &pubkey, nil
+}
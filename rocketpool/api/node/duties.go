@@ -0,0 +1,142 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getDuties(c *cli.Context) (*api.NodeDutiesResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeDutiesResponse{
+		Proposals:            []api.MinipoolDuty{},
+		CurrentSyncCommittee: []api.MinipoolDuty{},
+		NextSyncCommittee:    []api.MinipoolDuty{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the current epoch and the first epoch of the next sync committee period
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon chain head: %w", err)
+	}
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon config: %w", err)
+	}
+	response.Epoch = head.Epoch
+	response.NextSyncPeriodEpoch = (head.Epoch/eth2Config.EpochsPerSyncCommitteePeriod + 1) * eth2Config.EpochsPerSyncCommitteePeriod
+
+	// Get the node's minipools and their validator pubkeys
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool addresses: %w", err)
+	}
+	pubkeys := make([]types.ValidatorPubkey, len(addresses))
+	var wg errgroup.Group
+	for i, address := range addresses {
+		i, address := i, address
+		wg.Go(func() error {
+			pubkey, err := minipool.GetMinipoolPubkey(rp, address, nil)
+			if err != nil {
+				return fmt.Errorf("error getting pubkey for minipool %s: %w", address.Hex(), err)
+			}
+			pubkeys[i] = pubkey
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Get the indices of the validators that are on the Beacon chain
+	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+	indices := []string{}
+	minipoolsByIndex := map[string]common.Address{}
+	for i, pubkey := range pubkeys {
+		status, exists := statuses[pubkey]
+		if !exists || !status.Exists {
+			continue
+		}
+		indices = append(indices, status.Index)
+		minipoolsByIndex[status.Index] = addresses[i]
+	}
+	if len(indices) == 0 {
+		return &response, nil
+	}
+
+	// Get the duties; proposals are only known for the current epoch
+	var proposals map[string]uint64
+	var currentSync map[string]bool
+	var nextSync map[string]bool
+	wg.Go(func() error {
+		var err error
+		proposals, err = bc.GetValidatorProposerDuties(indices, head.Epoch)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		currentSync, err = bc.GetValidatorSyncDuties(indices, head.Epoch)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		nextSync, err = bc.GetValidatorSyncDuties(indices, response.NextSyncPeriodEpoch)
+		return err
+	})
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	for _, index := range indices {
+		if proposals[index] > 0 {
+			response.Proposals = append(response.Proposals, api.MinipoolDuty{Minipool: minipoolsByIndex[index], ValidatorIndex: index, Count: proposals[index]})
+		}
+		if currentSync[index] {
+			response.CurrentSyncCommittee = append(response.CurrentSyncCommittee, api.MinipoolDuty{Minipool: minipoolsByIndex[index], ValidatorIndex: index, Count: 1})
+		}
+		if nextSync[index] {
+			response.NextSyncCommittee = append(response.NextSyncCommittee, api.MinipoolDuty{Minipool: minipoolsByIndex[index], ValidatorIndex: index, Count: 1})
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
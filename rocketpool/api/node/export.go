@@ -0,0 +1,109 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fatih/color"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/offline"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+func getExportData(c *cli.Context) (*api.NodeExportResponse, error) {
+
+	// Get the latest state for the node
+	nodeState, nodeAddress, err := getNodeState(c)
+	if err != nil {
+		return nil, err
+	}
+	if nodeState == nil {
+		return &api.NodeExportResponse{
+			Minipools: []api.MinipoolExportDetails{},
+		}, nil
+	}
+
+	// Get the node and minipool details
+	return offline.GetNodeExportData(nodeState, nodeAddress)
+
+}
+
+func getStateSnapshot(c *cli.Context) (*api.NodeStateSnapshotResponse, error) {
+
+	// Response
+	response := api.NodeStateSnapshotResponse{}
+
+	// Get the latest state for the node
+	nodeState, nodeAddress, err := getNodeState(c)
+	if err != nil {
+		return nil, err
+	}
+	if nodeState == nil {
+		return &response, nil
+	}
+	response.Registered = true
+	response.Snapshot = state.NewStateSnapshot(nodeState, nodeAddress)
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the latest network state for the node, or nil if the node isn't registered
+func getNodeState(c *cli.Context) (*state.NetworkState, common.Address, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, common.Address{}, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, common.Address{}, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	registered, err := node.GetNodeExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	if !registered {
+		return nil, nodeAccount.Address, nil
+	}
+
+	// Get the state
+	logger := log.NewColorLogger(color.FgWhite)
+	mgr, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &logger)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("error creating network state manager: %w", err)
+	}
+	nodeState, _, err := mgr.GetHeadStateForNode(nodeAccount.Address, false)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("error getting network state: %w", err)
+	}
+	return nodeState, nodeAccount.Address, nil
+
+}
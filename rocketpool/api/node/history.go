@@ -0,0 +1,91 @@
+package node
+
+import (
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getTransactionHistory(c *cli.Context, limit uint64) (*api.NodeTransactionHistoryResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	db, err := services.GetStore(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the transactions
+	response := api.NodeTransactionHistoryResponse{}
+	response.Transactions, err = db.GetTransactions(nodeAccount.Address, int(limit))
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+
+}
+
+func getAlertHistory(c *cli.Context, days uint64, limit uint64) (*api.NodeAlertHistoryResponse, error) {
+
+	// Get services
+	db, err := services.GetStore(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the alerts
+	response := api.NodeAlertHistoryResponse{}
+	since := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+	response.Alerts, err = db.GetAlerts(since, int(limit))
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+
+}
+
+func getDutyHistory(c *cli.Context, limit uint64) (*api.NodeDutyHistoryResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	db, err := services.GetStore(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the epochs
+	response := api.NodeDutyHistoryResponse{}
+	response.Epochs, err = db.GetDutyEpochs(nodeAccount.Address, int(limit))
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+
+}
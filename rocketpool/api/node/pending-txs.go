@@ -0,0 +1,259 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+// The gas used by a plain ETH transfer, which is what cancelling a transaction sends
+const cancelTransactionGas uint64 = 21000
+
+func getPendingTransactions(c *cli.Context) (*api.NodePendingTransactionsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodePendingTransactionsResponse{
+		Transactions: []api.PendingTransaction{},
+	}
+
+	// Transactions from a Safe are queued in the Safe, not in the transaction pool
+	response.IsSafe = w.IsSafe()
+	if response.IsSafe {
+		return &response, nil
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the nonces and the current base fee
+	response.LatestNonce, err = ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest nonce of node %s: %w", nodeAccount.Address.Hex(), err)
+	}
+	response.PendingNonce, err = ec.PendingNonceAt(context.Background(), nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pending nonce of node %s: %w", nodeAccount.Address.Hex(), err)
+	}
+	header, err := ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest block header: %w", err)
+	}
+	response.BaseFee = header.BaseFee
+
+	// Get the node's transactions from the pool; not every client exposes it
+	pending, queued, err := ec.TxPoolContentFrom(context.Background(), nodeAccount.Address)
+	if err != nil {
+		response.TxPoolError = err.Error()
+		return &response, nil
+	}
+	for _, tx := range pending {
+		response.Transactions = append(response.Transactions, getPendingTransaction(tx, false))
+	}
+	for _, tx := range queued {
+		response.Transactions = append(response.Transactions, getPendingTransaction(tx, true))
+	}
+	sort.Slice(response.Transactions, func(i, j int) bool {
+		return response.Transactions[i].Nonce < response.Transactions[j].Nonce
+	})
+
+	// Return response
+	return &response, nil
+
+}
+
+func canReplaceTransaction(c *cli.Context, nonce uint64, cancel bool) (*api.CanReplaceTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanReplaceTransactionResponse{}
+
+	// Safe transactions are replaced from the Safe's own queue
+	response.IsSafe = w.IsSafe()
+	if response.IsSafe {
+		return &response, nil
+	}
+
+	// Find the transaction
+	tx, err := getNodeTransactionByNonce(c, nonce)
+	if err != nil {
+		return nil, err
+	}
+	response.NotFound = (tx == nil)
+	response.CanReplace = !response.NotFound
+	if !response.CanReplace {
+		return &response, nil
+	}
+	response.Transaction = getPendingTransaction(tx, false)
+	response.MinMaxFeePerGas, response.MinMaxPriorityFeePerGas = eth1.GetMinimumReplacementFees(tx)
+
+	// The replacement uses the same gas limit, or the gas of a plain transfer when cancelling
+	gasLimit := tx.Gas()
+	if cancel {
+		gasLimit = cancelTransactionGas
+	}
+	response.GasInfo.EstGasLimit = gasLimit
+	response.GasInfo.SafeGasLimit = gasLimit
+	return &response, nil
+
+}
+
+func replaceTransaction(c *cli.Context, nonce uint64, cancel bool) (*api.ReplaceTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ReplaceTransactionResponse{}
+
+	// Safe transactions are replaced from the Safe's own queue
+	if w.IsSafe() {
+		return nil, fmt.Errorf("The node account is a Safe, so its transactions must be replaced from the Safe's transaction queue")
+	}
+
+	// Find the transaction
+	tx, err := getNodeTransactionByNonce(c, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("The node doesn't have a pending transaction with nonce %d.", nonce)
+	}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Use the requested fees, raised to the minimum the transaction pool accepts as a replacement
+	minMaxFee, minMaxPriorityFee := eth1.GetMinimumReplacementFees(tx)
+	maxFee := minMaxFee
+	if opts.GasFeeCap != nil && opts.GasFeeCap.Cmp(maxFee) > 0 {
+		maxFee = opts.GasFeeCap
+	}
+	maxPriorityFee := minMaxPriorityFee
+	if opts.GasTipCap != nil && opts.GasTipCap.Cmp(maxPriorityFee) > 0 {
+		maxPriorityFee = opts.GasTipCap
+	}
+	if maxPriorityFee.Cmp(maxFee) > 0 {
+		maxFee = maxPriorityFee
+	}
+
+	// Build the replacement; a cancellation sends nothing to the node account itself
+	replacement := &types.DynamicFeeTx{
+		ChainID:   w.GetChainID(),
+		Nonce:     nonce,
+		GasTipCap: maxPriorityFee,
+		GasFeeCap: maxFee,
+		Gas:       tx.Gas(),
+		To:        tx.To(),
+		Value:     tx.Value(),
+		Data:      tx.Data(),
+	}
+	if cancel {
+		to := opts.From
+		replacement.Gas = cancelTransactionGas
+		replacement.To = &to
+		replacement.Value = big.NewInt(0)
+		replacement.Data = nil
+	}
+
+	// Sign and send it
+	signedTx, err := opts.Signer(opts.From, types.NewTx(replacement))
+	if err != nil {
+		return nil, fmt.Errorf("error signing replacement transaction: %w", err)
+	}
+	if !opts.NoSend {
+		err = ec.SendTransaction(context.Background(), signedTx)
+		if err != nil {
+			return nil, fmt.Errorf("error sending replacement transaction: %w", err)
+		}
+	}
+	response.TxHash = signedTx.Hash()
+	response.MaxFeePerGas = maxFee
+	response.MaxPriorityFeePerGas = maxPriorityFee
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the node's transaction with the given nonce from the transaction pool, or nil if there isn't one
+func getNodeTransactionByNonce(c *cli.Context, nonce uint64) (*types.Transaction, error) {
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	pending, queued, err := ec.TxPoolContentFrom(context.Background(), nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	if tx, exists := pending[nonce]; exists {
+		return tx, nil
+	}
+	return queued[nonce], nil
+}
+
+// Describe a transaction waiting in the transaction pool
+func getPendingTransaction(tx *types.Transaction, queued bool) api.PendingTransaction {
+	return api.PendingTransaction{
+		Hash:                 tx.Hash(),
+		Nonce:                tx.Nonce(),
+		To:                   tx.To(),
+		Value:                tx.Value(),
+		GasLimit:             tx.Gas(),
+		MaxFeePerGas:         tx.GasFeeCap(),
+		MaxPriorityFeePerGas: tx.GasTipCap(),
+		Queued:               queued,
+	}
+}
@@ -0,0 +1,87 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+func getProjectedRewards(c *cli.Context) (*api.NodeProjectedRewardsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeProjectedRewardsResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the latest network state
+	logger := log.NewColorLogger(color.FgWhite)
+	mgr, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &logger)
+	if err != nil {
+		return nil, fmt.Errorf("error creating network state manager: %w", err)
+	}
+	networkState, err := mgr.GetHeadState()
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state: %w", err)
+	}
+
+	// Project the node's rewards
+	projection, err := rprewards.ProjectNodeRewards(networkState, nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error projecting rewards: %w", err)
+	}
+	response.Index = projection.Index
+	response.IntervalStart = projection.IntervalStart
+	response.IntervalEnd = projection.IntervalEnd
+	response.SnapshotTime = projection.SnapshotTime
+	response.ElapsedFraction = projection.ElapsedFraction
+	response.PendingRpl = projection.PendingRpl
+	response.ProjectedTotalRpl = projection.ProjectedTotalRpl
+	response.NodeEffectiveStake = projection.NodeEffectiveStake
+	response.TotalEffectiveStake = projection.TotalEffectiveStake
+	response.ProjectedCollateralRpl = projection.ProjectedCollateralRpl
+	response.ProjectedOracleDaoRpl = projection.ProjectedOracleDaoRpl
+	response.IsOptedIntoSmoothingPool = projection.IsOptedIntoSmoothingPool
+	response.SmoothingPoolBalance = projection.SmoothingPoolBalance
+	response.ProjectedSmoothingBalance = projection.ProjectedSmoothingBalance
+	response.EligibleMinipoolCount = projection.EligibleMinipoolCount
+	response.ProjectedSmoothingPoolEth = projection.ProjectedSmoothingPoolEth
+
+	// Return response
+	return &response, nil
+
+}
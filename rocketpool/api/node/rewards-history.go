@@ -0,0 +1,195 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/store"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getRewardsHistory(c *cli.Context) (*api.NodeRewardsHistoryResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	db, err := services.GetStore(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeRewardsHistoryResponse{
+		Intervals: []api.NodeRewardsHistoryInterval{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.Registered, err = node.GetNodeExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !response.Registered {
+		return &response, nil
+	}
+
+	// Get the claimed and unclaimed intervals
+	unclaimed, claimed, err := rprewards.GetClaimStatus(rp, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	isClaimed := map[uint64]bool{}
+	for _, interval := range claimed {
+		isClaimed[interval] = true
+	}
+
+	// Get the claim transactions that were recorded before
+	claims, err := db.GetRewardsClaims(nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	unrecorded := []uint64{}
+	for _, interval := range claimed {
+		if _, exists := claims[interval]; !exists {
+			unrecorded = append(unrecorded, interval)
+		}
+	}
+
+	// Search for the rest, starting from the oldest of them since claims can't come before it
+	if len(unrecorded) > 0 {
+		oldestEvent, err := rprewards.GetRewardSnapshotEvent(rp, cfg, unrecorded[0], nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting event for interval %d: %w", unrecorded[0], err)
+		}
+		eventLogInterval, err := cfg.GetEventLogInterval()
+		if err != nil {
+			return nil, err
+		}
+		foundClaims, err := rprewards.GetNodeRewardsClaims(rp, nodeAccount.Address, oldestEvent.ExecutionBlock, big.NewInt(int64(eventLogInterval)))
+		if err != nil {
+			return nil, err
+		}
+
+		claimTimes := map[uint64]time.Time{}
+		for _, interval := range unrecorded {
+			foundClaim, exists := foundClaims[interval]
+			if !exists {
+				continue
+			}
+			claimTime, exists := claimTimes[foundClaim.BlockNumber]
+			if !exists {
+				header, err := rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(foundClaim.BlockNumber))
+				if err != nil {
+					return nil, fmt.Errorf("error getting header for block %d: %w", foundClaim.BlockNumber, err)
+				}
+				claimTime = time.Unix(int64(header.Time), 0)
+				claimTimes[foundClaim.BlockNumber] = claimTime
+			}
+			claim := store.RewardsClaim{
+				Interval:    interval,
+				TxHash:      foundClaim.TxHash,
+				BlockNumber: foundClaim.BlockNumber,
+				Time:        claimTime,
+			}
+			claims[interval] = claim
+
+			// Claims never change, so they don't have to be searched for again
+			if err := db.AddRewardsClaim(nodeAccount.Address, claim); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Get the details of each interval
+	rplPrices := map[uint64]*big.Int{}
+	intervals := make([]uint64, 0, len(claimed)+len(unclaimed))
+	intervals = append(intervals, claimed...)
+	intervals = append(intervals, unclaimed...)
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i] < intervals[j]
+	})
+	for _, interval := range intervals {
+		intervalInfo, err := rprewards.GetIntervalInfo(rp, cfg, nodeAccount.Address, interval, nil)
+		if err != nil {
+			return nil, err
+		}
+		history := api.NodeRewardsHistoryInterval{
+			Index:            interval,
+			StartTime:        intervalInfo.StartTime,
+			EndTime:          intervalInfo.EndTime,
+			RewardsAvailable: (intervalInfo.TreeFileExists && intervalInfo.MerkleRootValid) || intervalInfo.Pruned,
+			CollateralRpl:    big.NewInt(0),
+			OracleDaoRpl:     big.NewInt(0),
+			SmoothingPoolEth: big.NewInt(0),
+			Claimed:          isClaimed[interval],
+		}
+		if intervalInfo.NodeExists {
+			if intervalInfo.CollateralRplAmount != nil {
+				history.CollateralRpl.Set(&intervalInfo.CollateralRplAmount.Int)
+			}
+			if intervalInfo.ODaoRplAmount != nil {
+				history.OracleDaoRpl.Set(&intervalInfo.ODaoRplAmount.Int)
+			}
+			if intervalInfo.SmoothingPoolEthAmount != nil {
+				history.SmoothingPoolEth.Set(&intervalInfo.SmoothingPoolEthAmount.Int)
+			}
+		}
+
+		// Get the claim details
+		claim, exists := claims[interval]
+		if exists {
+			history.ClaimTxHash = claim.TxHash
+			history.ClaimBlock = claim.BlockNumber
+			history.ClaimTime = claim.Time
+
+			// Getting the price at the claim block requires an archive node, so leave it empty if it isn't available
+			rplPrice, exists := rplPrices[claim.BlockNumber]
+			if !exists {
+				opts := &bind.CallOpts{
+					BlockNumber: big.NewInt(0).SetUint64(claim.BlockNumber),
+				}
+				rplPrice, err = network.GetRPLPrice(rp, opts)
+				if err != nil {
+					rplPrice = nil
+				}
+				rplPrices[claim.BlockNumber] = rplPrice
+			}
+			history.RplPriceAtClaim = rplPrice
+		}
+
+		response.Intervals = append(response.Intervals, history)
+	}
+
+	// Return response
+	return &response, nil
+
+}
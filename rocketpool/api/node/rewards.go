@@ -140,7 +140,7 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 			if err != nil {
 				return err
 			}
-			if !intervalInfo.TreeFileExists {
+			if !intervalInfo.TreeFileExists && !intervalInfo.Pruned {
 				return fmt.Errorf("Error calculating lifetime node rewards: rewards file %s doesn't exist but interval %d was claimed", intervalInfo.TreeFilePath, claimedInterval)
 			}
 			rplRewards.Add(rplRewards, &intervalInfo.CollateralRplAmount.Int)
@@ -153,7 +153,7 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 			if err != nil {
 				return err
 			}
-			if !intervalInfo.TreeFileExists {
+			if !intervalInfo.TreeFileExists && !intervalInfo.Pruned {
 				return fmt.Errorf("Error calculating lifetime node rewards: rewards file %s doesn't exist and interval %d is unclaimed", intervalInfo.TreeFilePath, unclaimedInterval)
 			}
 			if intervalInfo.NodeExists {
@@ -324,7 +324,7 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 				if err != nil {
 					return err
 				}
-				if !intervalInfo.TreeFileExists {
+				if !intervalInfo.TreeFileExists && !intervalInfo.Pruned {
 					return fmt.Errorf("Error calculating lifetime node rewards: rewards file %s doesn't exist but interval %d was claimed", intervalInfo.TreeFilePath, claimedInterval)
 				}
 				rplRewards.Add(rplRewards, &intervalInfo.ODaoRplAmount.Int)
@@ -336,7 +336,7 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 				if err != nil {
 					return err
 				}
-				if !intervalInfo.TreeFileExists {
+				if !intervalInfo.TreeFileExists && !intervalInfo.Pruned {
 					return fmt.Errorf("Error calculating lifetime node rewards: rewards file %s doesn't exist and interval %d is unclaimed", intervalInfo.TreeFilePath, unclaimedInterval)
 				}
 				if intervalInfo.NodeExists {
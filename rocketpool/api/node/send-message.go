@@ -78,7 +78,12 @@ func sendMessage(c *cli.Context, address common.Address, message []byte) (*api.N
 	}
 
 	// Send the message
-	hash, err := eth.SendTransaction(ec, address, w.GetChainID(), message, true, opts)
+	var hash common.Hash
+	if w.IsSafe() && !w.IsDryRun() {
+		hash, err = w.ProposeSafeTransaction(address, nil, message)
+	} else {
+		hash, err = eth1.SendTransaction(ec, address, w.GetChainID(), message, true, opts)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error sending message: %w", err)
 	}
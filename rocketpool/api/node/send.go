@@ -250,7 +250,12 @@ func nodeSend(c *cli.Context, amountWei *big.Int, token string, to common.Addres
 
 			// Transfer ETH
 			opts.Value = amountWei
-			hash, err := eth.SendTransaction(ec, to, w.GetChainID(), nil, false, opts)
+			var hash common.Hash
+			if w.IsSafe() && !w.IsDryRun() {
+				hash, err = w.ProposeSafeTransaction(to, amountWei, nil)
+			} else {
+				hash, err = eth1.SendTransaction(ec, to, w.GetChainID(), nil, false, opts)
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,12 +12,15 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/goccy/go-json"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -343,6 +347,7 @@ func GetSnapshotProposals(apiDomain string, space string, state string) (*api.Sn
 	proposals(where: {space: "%s"%s}, orderBy: "created", orderDirection: desc) {
 	    id
 	    title
+	    type
 	    choices
 	    start
 	    end
@@ -381,3 +386,88 @@ func GetSnapshotProposals(apiDomain string, space string, state string) (*api.Sn
 
 	return &snapshotResponse, nil
 }
+
+// Cast a vote on a single-choice Snapshot proposal; choice is the 1-based index of the proposal's choice
+func SubmitSnapshotVote(apiDomain string, space string, w *wallet.Wallet, proposalId string, choice int, reason string) error {
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	timestamp := time.Now().Unix()
+
+	// Sign the vote as EIP-712 typed data, the way Snapshot's own client does
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+			},
+			"Vote": {
+				{Name: "from", Type: "address"},
+				{Name: "space", Type: "string"},
+				{Name: "timestamp", Type: "uint64"},
+				{Name: "proposal", Type: "bytes32"},
+				{Name: "choice", Type: "uint32"},
+				{Name: "reason", Type: "string"},
+				{Name: "app", Type: "string"},
+				{Name: "metadata", Type: "string"},
+			},
+		},
+		PrimaryType: "Vote",
+		Domain: apitypes.TypedDataDomain{
+			Name:    "snapshot",
+			Version: "0.1.4",
+		},
+		Message: apitypes.TypedDataMessage{
+			"from":      nodeAccount.Address.Hex(),
+			"space":     space,
+			"timestamp": fmt.Sprint(timestamp),
+			"proposal":  proposalId,
+			"choice":    fmt.Sprint(choice),
+			"reason":    reason,
+			"app":       "smartnode",
+			"metadata":  "{}",
+		},
+	}
+	signature, err := w.SignTypedData(typedData)
+	if err != nil {
+		return fmt.Errorf("could not sign the vote: %w", err)
+	}
+
+	// Send it to the hub
+	envelope := map[string]interface{}{
+		"address": nodeAccount.Address.Hex(),
+		"sig":     hexutil.Encode(signature),
+		"data": map[string]interface{}{
+			"domain": typedData.Domain.Map(),
+			"types": map[string]interface{}{
+				"Vote": typedData.Types["Vote"],
+			},
+			"message": map[string]interface{}{
+				"from":      nodeAccount.Address.Hex(),
+				"space":     space,
+				"timestamp": timestamp,
+				"proposal":  proposalId,
+				"choice":    choice,
+				"reason":    reason,
+				"app":       "smartnode",
+				"metadata":  "{}",
+			},
+		},
+	}
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("could not encode the vote: %w", err)
+	}
+	client := getHttpClientWithTimeout()
+	resp, err := client.Post(fmt.Sprintf("https://%s/api/msg", apiDomain), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		response, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("vote was rejected with code %d: %s", resp.StatusCode, string(response))
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// How far back the Oracle DAO needs historical state for, roughly one rewards interval
+const odaoArchiveBlockRange uint64 = 201600
+
+// Checks the clients' connectivity and the settings that depend on the node's on-chain state
+func checkConfig(c *cli.Context) (*api.CheckConfigResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CheckConfigResponse{
+		Problems: []api.ConfigProblem{},
+	}
+	addError := func(message string) {
+		response.Problems = append(response.Problems, api.ConfigProblem{IsError: true, Message: message})
+	}
+	addWarning := func(message string) {
+		response.Problems = append(response.Problems, api.ConfigProblem{IsError: false, Message: message})
+	}
+
+	// Check the clients
+	ecStatus := ec.CheckStatus(cfg)
+	ecReady := checkClientStatus("Execution client", ecStatus, addError, addWarning)
+	bcStatus := bc.CheckStatus()
+	bcReady := checkClientStatus("Beacon Node", bcStatus, addError, addWarning)
+	if !ecReady || !bcReady {
+		addWarning("The fee recipient and Oracle DAO checks were skipped because the clients aren't ready.")
+		return &response, nil
+	}
+
+	// Get the node account
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		addWarning("The node wallet isn't initialized, so the fee recipient and Oracle DAO checks were skipped.")
+		return &response, nil
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	exists, err := node.GetNodeExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		addWarning("The node isn't registered with Rocket Pool yet, so the fee recipient and Oracle DAO checks were skipped.")
+		return &response, nil
+	}
+
+	// Check the fee recipient the Validator Client uses
+	feeRecipientInfo, err := rputils.GetFeeRecipientInfoWithoutState(rp, bc, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	correctFeeRecipient := feeRecipientInfo.FeeDistributorAddress
+	if feeRecipientInfo.IsInSmoothingPool || feeRecipientInfo.IsInOptOutCooldown {
+		correctFeeRecipient = feeRecipientInfo.SmoothingPoolAddress
+	}
+	fileExists, correctAddress, err := rpsvc.CheckFeeRecipientFile(correctFeeRecipient, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if !fileExists {
+		addError(fmt.Sprintf("The fee recipient file doesn't exist yet. The node daemon creates it with your correct fee recipient (%s); make sure it's running.", correctFeeRecipient.Hex()))
+	} else if !correctAddress {
+		addError(fmt.Sprintf("The fee recipient file doesn't have your correct fee recipient (%s), so proposals could be penalized. The node daemon will correct it and restart your Validator Client; make sure it's running.", correctFeeRecipient.Hex()))
+	}
+
+	// Oracle DAO members need historical state for their duties
+	isMember, err := trustednode.GetMemberExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if isMember {
+		if err := checkArchiveAccess(rp, cfg); err != nil {
+			addError(fmt.Sprintf("You're an Oracle DAO member, but neither your Execution client nor your Archive-Mode EC URL can provide the historical state needed to build the rewards tree: %s", err.Error()))
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Report the problems with a client manager's clients, and return whether one of them is ready to use
+func checkClientStatus(name string, status *api.ClientManagerStatus, addError func(string), addWarning func(string)) bool {
+	primary := status.PrimaryClientStatus
+	fallback := status.FallbackClientStatus
+
+	primaryReady := primary.IsWorking && primary.IsSynced
+	fallbackReady := status.FallbackEnabled && fallback.IsWorking && fallback.IsSynced
+	switch {
+	case !primary.IsWorking:
+		message := fmt.Sprintf("Your primary %s can't be reached: %s", name, primary.Error)
+		if fallbackReady {
+			addWarning(message + " Your fallback client is being used instead.")
+		} else {
+			addError(message)
+		}
+	case !primary.IsSynced:
+		addWarning(fmt.Sprintf("Your primary %s is still syncing (%0.2f%%).", name, rpsvc.SyncRatioToPercent(primary.SyncProgress)))
+	}
+
+	if status.FallbackEnabled && !fallback.IsWorking {
+		addWarning(fmt.Sprintf("Your fallback %s can't be reached: %s", name, fallback.Error))
+	}
+	return primaryReady || fallbackReady
+}
+
+// Check that a client with historical state is available for the Oracle DAO's rewards tree
+func checkArchiveAccess(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig) error {
+	latestBlock, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return fmt.Errorf("error getting the latest block: %w", err)
+	}
+	if latestBlock <= odaoArchiveBlockRange {
+		return nil
+	}
+	blockNumber := big.NewInt(0).SetUint64(latestBlock - odaoArchiveBlockRange)
+	_, err = eth1.GetBestApiClient(rp, cfg, func(string) {}, blockNumber)
+	return err
+}
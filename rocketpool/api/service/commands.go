@@ -52,6 +52,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "check-config",
+				Usage:     "Checks the clients' connectivity and the settings that depend on the node's on-chain state",
+				UsageText: "rocketpool api service check-config",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(checkConfig(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "restart-vc",
 				Usage:     "Restarts the validator client",
@@ -69,6 +87,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "rotate-jwt-secret",
+				Usage:     "Replaces the Engine API JWT secret and restarts the Execution and Consensus clients",
+				UsageText: "rocketpool api service rotate-jwt-secret",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(rotateJwtSecret(c))
+					return nil
+
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Settings
+const (
+	jwtSecretPath            string = "/.rocketpool/secrets/jwtsecret"
+	executionContainerSuffix string = "_eth1"
+)
+
+// Replace the Engine API JWT secret and restart the Execution and Consensus clients so they pick it up together.
+// If the clients can't be restarted with the new secret, the old one is put back.
+func rotateJwtSecret(c *cli.Context) (*api.RotateJwtSecretResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RotateJwtSecretResponse{}
+
+	// Sanity checks
+	if cfg.IsNativeMode {
+		return nil, fmt.Errorf("the Smartnode doesn't manage your clients' JWT secret in Native Mode")
+	}
+	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_External || cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_External {
+		return nil, fmt.Errorf("the Smartnode doesn't manage the JWT secret of externally managed clients")
+	}
+
+	// Make sure there's an existing secret to restore if something goes wrong
+	oldSecret, err := os.ReadFile(jwtSecretPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading JWT secret: %w", err)
+	}
+	info, err := os.Stat(jwtSecretPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading JWT secret permissions: %w", err)
+	}
+	mode := info.Mode().Perm()
+
+	// Generate the new secret
+	newSecret, err := eth2.GenerateJwtSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	// Stop the CC so it doesn't spam authentication errors while the EC restarts; MEV-Boost doesn't use the Engine API
+	projectName := cfg.Smartnode.ProjectName.Value.(string)
+	executionContainerName := projectName + executionContainerSuffix
+	beaconContainerName := projectName + validator.BeaconContainerSuffix
+	err = d.ContainerStop(context.Background(), beaconContainerName, container.StopOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error stopping %s: %w", beaconContainerName, err)
+	}
+
+	// Swap the secret and restart both clients, putting the old secret back if they can't be restarted
+	err = saveJwtSecret(newSecret, mode)
+	if err == nil {
+		err = restartEngineApiClients(d, executionContainerName, beaconContainerName)
+		if err == nil {
+			return &response, nil
+		}
+	}
+	restoreErr := saveJwtSecret(oldSecret, mode)
+	if restoreErr != nil {
+		return nil, fmt.Errorf("error rotating JWT secret (%s), then error restoring the previous one: %w", err.Error(), restoreErr)
+	}
+	restoreErr = restartEngineApiClients(d, executionContainerName, beaconContainerName)
+	if restoreErr != nil {
+		return nil, fmt.Errorf("error rotating JWT secret (%s), then error restarting clients with the previous one: %w", err.Error(), restoreErr)
+	}
+	return nil, fmt.Errorf("the JWT secret was not rotated: %w", err)
+
+}
+
+// Replace the JWT secret, writing it to a temporary file first so the clients never see a partial secret
+func saveJwtSecret(secret []byte, mode os.FileMode) error {
+	tempPath := jwtSecretPath + ".tmp"
+	err := os.WriteFile(tempPath, secret, mode)
+	if err != nil {
+		return fmt.Errorf("error writing JWT secret: %w", err)
+	}
+	err = os.Rename(tempPath, jwtSecretPath)
+	if err != nil {
+		return fmt.Errorf("error replacing JWT secret: %w", err)
+	}
+	return nil
+}
+
+// Restart the EC and start the CC, so they both load the current JWT secret
+func restartEngineApiClients(d *client.Client, executionContainerName string, beaconContainerName string) error {
+	err := d.ContainerRestart(context.Background(), executionContainerName, container.StopOptions{})
+	if err != nil {
+		return fmt.Errorf("error restarting %s: %w", executionContainerName, err)
+	}
+	err = d.ContainerStart(context.Background(), beaconContainerName, types.ContainerStartOptions{})
+	if err != nil {
+		return fmt.Errorf("error starting %s: %w", beaconContainerName, err)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package wallet
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
+)
+
+// The name of the Web3Signer keystore in the wallet
+const web3SignerKeystore string = "web3signer"
+
+func auditValidatorKeys(c *cli.Context) (*api.AuditValidatorKeysResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.AuditValidatorKeysResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the keys the Validator client loads
+	response.Keystore, err = getValidatorKeystoreName(cfg)
+	if err != nil {
+		return nil, err
+	}
+	loadedPubkeys, err := w.GetKeystoreValidatorPubkeys(response.Keystore)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the validator keys in the %s keystore: %w", response.Keystore, err)
+	}
+
+	// Get the node's minipool pubkeys, skipping minipools that haven't been assigned one
+	minipoolPubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	entries := map[types.ValidatorPubkey]*api.ValidatorKeyAuditEntry{}
+	zeroPubkey := types.ValidatorPubkey{}
+	for _, pubkey := range minipoolPubkeys {
+		if bytes.Equal(pubkey[:], zeroPubkey[:]) {
+			continue
+		}
+		isDistributed, err := validator.IsDistributedValidator(cfg.Smartnode.GetDistributedValidatorsPath(), pubkey)
+		if err != nil {
+			return nil, err
+		}
+		entries[pubkey] = &api.ValidatorKeyAuditEntry{
+			Pubkey:        pubkey,
+			IsMinipool:    true,
+			IsDistributed: isDistributed,
+		}
+	}
+	for _, pubkey := range loadedPubkeys {
+		entry, exists := entries[pubkey]
+		if !exists {
+			entry = &api.ValidatorKeyAuditEntry{
+				Pubkey: pubkey,
+			}
+			entries[pubkey] = entry
+		}
+		entry.IsLoaded = true
+	}
+
+	// Make sure each loaded key can be decrypted and matches its pubkey; Web3Signer never gives keys back, so those can't be checked
+	if response.Keystore != web3SignerKeystore {
+		for _, entry := range entries {
+			if !entry.IsLoaded {
+				continue
+			}
+			key, err := w.LoadValidatorKeyFromKeystore(response.Keystore, entry.Pubkey)
+			if err != nil {
+				entry.LoadError = err.Error()
+			} else if key == nil {
+				entry.LoadError = "the keystore or its password file is missing"
+			}
+		}
+	}
+
+	// Get the validators' status on the Beacon chain
+	pubkeys := make([]types.ValidatorPubkey, 0, len(entries))
+	for pubkey := range entries {
+		pubkeys = append(pubkeys, pubkey)
+	}
+	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+	needsRecovery := false
+	for pubkey, entry := range entries {
+		status := statuses[pubkey]
+		entry.ExistsOnBeacon = status.Exists
+		if status.Exists {
+			entry.BeaconIndex = status.Index
+			entry.BeaconStatus = status.Status
+			entry.HasDuties = hasValidatorDuties(status.Status)
+		}
+		if entry.IsMinipool && !entry.IsDistributed && (!entry.IsLoaded || entry.LoadError != "") {
+			needsRecovery = true
+		}
+	}
+
+	// Check if the missing or broken minipool keys can be regenerated from the node wallet without saving anything
+	if needsRecovery {
+		_, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, true)
+		if err != nil {
+			response.RecoveryError = err.Error()
+		} else {
+			response.MissingKeysRecoverable = true
+		}
+	}
+
+	// Sort by pubkey so the output is stable
+	response.Keys = make([]api.ValidatorKeyAuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		response.Keys = append(response.Keys, *entry)
+	}
+	sort.Slice(response.Keys, func(i, j int) bool {
+		return response.Keys[i].Pubkey.Hex() < response.Keys[j].Pubkey.Hex()
+	})
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the name of the wallet keystore that the Validator client loads its keys from
+func getValidatorKeystoreName(cfg *config.RocketPoolConfig) (string, error) {
+	if cfg.EnableWeb3Signer.Value == true {
+		return web3SignerKeystore, nil
+	}
+	cc, _ := cfg.GetSelectedConsensusClient()
+	switch cc {
+	case cfgtypes.ConsensusClient_Grandine:
+		// Grandine's Beacon node is served by Lighthouse's Validator client
+		return string(cfgtypes.ConsensusClient_Lighthouse), nil
+	case cfgtypes.ConsensusClient_Lighthouse, cfgtypes.ConsensusClient_Lodestar, cfgtypes.ConsensusClient_Nimbus, cfgtypes.ConsensusClient_Prysm, cfgtypes.ConsensusClient_Teku:
+		return string(cc), nil
+	default:
+		return "", fmt.Errorf("unknown Consensus client [%v]", cc)
+	}
+}
+
+// Check if a validator in the given state has to attest or propose, or will soon
+func hasValidatorDuties(state beacon.ValidatorState) bool {
+	switch state {
+	case beacon.ValidatorState_PendingInitialized,
+		beacon.ValidatorState_PendingQueued,
+		beacon.ValidatorState_ActiveOngoing,
+		beacon.ValidatorState_ActiveExiting,
+		beacon.ValidatorState_ActiveSlashed:
+		return true
+	default:
+		return false
+	}
+}
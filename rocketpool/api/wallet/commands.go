@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -57,12 +58,56 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "list",
+				Usage:     "List the stored node wallets",
+				UsageText: "rocketpool api wallet list",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(listWallets(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "switch",
+				Usage:     "Store the active node wallet and switch to another one",
+				UsageText: "rocketpool api wallet switch name",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					name, err := cliutils.ValidateWalletName("wallet name", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(switchWallet(c, name))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "init",
 				Aliases:   []string{"i"},
 				Usage:     "Initialize the node wallet",
 				UsageText: "rocketpool api wallet init",
 				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "mnemonic-passphrase",
+						Usage: "The optional BIP-39 passphrase (the \"25th word\") to use with the mnemonic",
+					},
 					cli.StringFlag{
 						Name:  "derivation-path, d",
 						Usage: "Specify the derivation path for the wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
@@ -88,10 +133,22 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				Usage:     "Recover a node wallet from a mnemonic phrase",
 				UsageText: "rocketpool api wallet recover mnemonic",
 				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "mnemonic-passphrase",
+						Usage: "The optional BIP-39 passphrase (the \"25th word\") to use with the mnemonic",
+					},
 					cli.BoolFlag{
 						Name:  "skip-validator-key-recovery, k",
 						Usage: "Recover the node wallet, but do not regenerate its validator keys",
 					},
+					cli.StringFlag{
+						Name:  "validator-key-path",
+						Usage: "A non-standard derivation path to search for validator keys, with %d for the key index",
+					},
+					cli.StringFlag{
+						Name:  "validator-key-path-overrides",
+						Usage: "A comma-separated list of pubkey=path pairs giving the exact derivation path of specific validator keys",
+					},
 					cli.StringFlag{
 						Name:  "derivation-path, d",
 						Usage: "Specify the derivation path for the wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
@@ -126,10 +183,22 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				Usage:     "Search for and recover a node wallet's derivation key and index using a mnemonic phrase and a well-known address.",
 				UsageText: "rocketpool api wallet search-and-recover mnemonic address",
 				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "mnemonic-passphrase",
+						Usage: "The optional BIP-39 passphrase (the \"25th word\") to use with the mnemonic",
+					},
 					cli.BoolFlag{
 						Name:  "skip-validator-key-recovery, k",
 						Usage: "Recover the node wallet, but do not regenerate its validator keys",
 					},
+					cli.StringFlag{
+						Name:  "validator-key-path",
+						Usage: "A non-standard derivation path to search for validator keys, with %d for the key index",
+					},
+					cli.StringFlag{
+						Name:  "validator-key-path-overrides",
+						Usage: "A comma-separated list of pubkey=path pairs giving the exact derivation path of specific validator keys",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -172,16 +241,121 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "audit-keys",
+				Usage:     "Check the Validator client's keys against the node's minipools and the Beacon chain",
+				UsageText: "rocketpool api wallet audit-keys",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(auditValidatorKeys(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "export-keys",
+				Usage:     "Export validator keys as EIP-2335 keystores along with an EIP-3076 slashing protection file",
+				UsageText: "rocketpool api wallet export-keys password",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "pubkeys, k",
+						Usage: "A comma-separated list of the validator pubkeys to export (default: all of the node's minipools)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					password, err := cliutils.ValidateNodePassword("keystore password", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					pubkeys := []types.ValidatorPubkey{}
+					if c.String("pubkeys") != "" {
+						pubkeys, err = cliutils.ValidatePubkeys("pubkeys", c.String("pubkeys"))
+						if err != nil {
+							return err
+						}
+					}
+
+					// Run
+					api.PrintResponse(exportKeys(c, password, pubkeys))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "export-slashing-protection",
+				Usage:     "Export an EIP-3076 slashing protection file for all of the node's validators",
+				UsageText: "rocketpool api wallet export-slashing-protection",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(exportSlashingProtection(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "import-keys",
+				Usage:     "Load the validator keys for the given pubkeys from the custom keystores folder",
+				UsageText: "rocketpool api wallet import-keys pubkeys",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					pubkeys, err := cliutils.ValidatePubkeys("pubkeys", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(importKeys(c, pubkeys))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "test-recovery",
 				Aliases:   []string{"r"},
 				Usage:     "Test recovery of a node wallet and its validator keys without actually saving the recovered files",
 				UsageText: "rocketpool api wallet test-recovery mnemonic",
 				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "mnemonic-passphrase",
+						Usage: "The optional BIP-39 passphrase (the \"25th word\") to use with the mnemonic",
+					},
 					cli.BoolFlag{
 						Name:  "skip-validator-key-recovery, k",
 						Usage: "Recover the node wallet, but do not regenerate its validator keys",
 					},
+					cli.StringFlag{
+						Name:  "validator-key-path",
+						Usage: "A non-standard derivation path to search for validator keys, with %d for the key index",
+					},
+					cli.StringFlag{
+						Name:  "validator-key-path-overrides",
+						Usage: "A comma-separated list of pubkey=path pairs giving the exact derivation path of specific validator keys",
+					},
 					cli.StringFlag{
 						Name:  "derivation-path, d",
 						Usage: "Specify the derivation path for the wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
@@ -216,10 +390,22 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				Usage:     "Test searching for and recovery of a node wallet's derivation key, index, and validator keys using a mnemonic phrase and a well-known address.",
 				UsageText: "rocketpool api wallet test-search-and-recover mnemonic address",
 				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "mnemonic-passphrase",
+						Usage: "The optional BIP-39 passphrase (the \"25th word\") to use with the mnemonic",
+					},
 					cli.BoolFlag{
 						Name:  "skip-validator-key-recovery, k",
 						Usage: "Recover the node wallet, but do not regenerate its validator keys",
 					},
+					cli.StringFlag{
+						Name:  "validator-key-path",
+						Usage: "A non-standard derivation path to search for validator keys, with %d for the key index",
+					},
+					cli.StringFlag{
+						Name:  "validator-key-path-overrides",
+						Usage: "A comma-separated list of pubkey=path pairs giving the exact derivation path of specific validator keys",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -296,6 +482,41 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "sign-tx",
+				Usage:     "Sign a serialized transaction with the node account",
+				UsageText: "rocketpool api wallet sign-tx serialized-tx",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(signTransaction(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
+			{
+				Name:      "send-raw",
+				Usage:     "Submit a signed, serialized transaction to the network",
+				UsageText: "rocketpool api wallet send-raw signed-tx",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(sendRawTransaction(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
 		},
 	})
 }
@@ -3,6 +3,8 @@ package wallet
 import (
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
@@ -61,7 +63,15 @@ func setEnsName(c *cli.Context, name string, onlyEstimateGas bool) (*api.SetEnsN
 	}
 
 	// If onlyEstimateGas is set, then don't send the tx, only simulates and returns the gas estimate
-	opts.NoSend = onlyEstimateGas
+	if onlyEstimateGas {
+		opts.NoSend = true
+		if w.IsSafe() {
+			// Don't propose the transaction to the Safe when only simulating it
+			opts.Signer = func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return tx, nil
+			}
+		}
+	}
 
 	registrar, err := ens.NewReverseRegistrar(rp.Client)
 	if err != nil {
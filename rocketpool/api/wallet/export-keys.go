@@ -0,0 +1,178 @@
+package wallet
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const (
+	keyExportDirMode             = 0700
+	keyExportFileMode            = 0600
+	slashingProtectionFilename   = "slashing_protection.json"
+	keyExportKeystoreFilePattern = "keystore-%s.json"
+)
+
+func exportKeys(c *cli.Context, password string, pubkeys []types.ValidatorPubkey) (*api.ExportKeysResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ExportKeysResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Export every minipool's key if none were selected, otherwise make sure the selected ones belong to the node
+	minipoolPubkeys, err := getNodeValidatorPubkeys(rp, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	isMinipoolPubkey := map[types.ValidatorPubkey]bool{}
+	for _, pubkey := range minipoolPubkeys {
+		isMinipoolPubkey[pubkey] = true
+	}
+	if len(pubkeys) == 0 {
+		pubkeys = minipoolPubkeys
+	} else {
+		for _, pubkey := range pubkeys {
+			if !isMinipoolPubkey[pubkey] {
+				return nil, fmt.Errorf("validator %s does not belong to any of this node's minipools", pubkey.Hex())
+			}
+		}
+	}
+	if len(pubkeys) == 0 {
+		return nil, fmt.Errorf("this node doesn't have any validator keys to export")
+	}
+
+	// Get the keys derived from the wallet, which know their derivation paths
+	keyCount, err := w.GetValidatorKeyCount()
+	if err != nil {
+		return nil, err
+	}
+	derivedKeys, err := w.GetValidatorKeys(0, keyCount)
+	if err != nil {
+		return nil, err
+	}
+	derivationPaths := map[types.ValidatorPubkey]string{}
+	privateKeys := map[types.ValidatorPubkey]*eth2types.BLSPrivateKey{}
+	for _, key := range derivedKeys {
+		derivationPaths[key.PublicKey] = key.DerivationPath
+		privateKeys[key.PublicKey] = key.PrivateKey
+	}
+
+	// Create the export folder
+	response.ExportFolder = fmt.Sprintf("keys-%d", time.Now().Unix())
+	exportPath := filepath.Join(cfg.Smartnode.GetKeyExportsPath(), response.ExportFolder)
+	if err := os.MkdirAll(exportPath, keyExportDirMode); err != nil {
+		return nil, fmt.Errorf("error creating export folder: %w", err)
+	}
+
+	// Export each key
+	encryptor := eth2ks.New(eth2ks.WithCipher("scrypt"))
+	for _, pubkey := range pubkeys {
+
+		// Get the private key; custom keys aren't derived from the wallet so they only exist in the keystores
+		privateKey, exists := privateKeys[pubkey]
+		if !exists {
+			privateKey, err = w.LoadValidatorKey(pubkey)
+			if err != nil {
+				return nil, err
+			}
+			if privateKey == nil {
+				return nil, fmt.Errorf("couldn't find the key for validator %s", pubkey.Hex())
+			}
+		}
+
+		// Encrypt it
+		encryptedKey, err := encryptor.Encrypt(privateKey.Marshal(), password)
+		if err != nil {
+			return nil, fmt.Errorf("error encrypting key for validator %s: %w", pubkey.Hex(), err)
+		}
+		keystoreBytes, err := json.Marshal(api.ValidatorKeystore{
+			Crypto:  encryptedKey,
+			Version: encryptor.Version(),
+			UUID:    uuid.New(),
+			Path:    derivationPaths[pubkey],
+			Pubkey:  pubkey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error serializing keystore for validator %s: %w", pubkey.Hex(), err)
+		}
+		keystorePath := filepath.Join(exportPath, fmt.Sprintf(keyExportKeystoreFilePattern, pubkey.Hex()))
+		if err := os.WriteFile(keystorePath, keystoreBytes, keyExportFileMode); err != nil {
+			return nil, fmt.Errorf("error saving keystore for validator %s: %w", pubkey.Hex(), err)
+		}
+		response.ExportedKeys = append(response.ExportedKeys, pubkey)
+	}
+
+	// Block everything up to the current epoch, since the Validator client has been stopped and may have signed for it
+	response.ProtectionEpoch, err = exportChainSlashingProtection(bc, pubkeys, filepath.Join(exportPath, slashingProtectionFilename))
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the validator pubkeys of the node's minipools, skipping ones that haven't been assigned a validator yet
+func getNodeValidatorPubkeys(rp *rocketpool.RocketPool, nodeAddress common.Address) ([]types.ValidatorPubkey, error) {
+	minipoolPubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	zeroPubkey := types.ValidatorPubkey{}
+	pubkeys := []types.ValidatorPubkey{}
+	for _, pubkey := range minipoolPubkeys {
+		if !bytes.Equal(pubkey[:], zeroPubkey[:]) {
+			pubkeys = append(pubkeys, pubkey)
+		}
+	}
+	return pubkeys, nil
+}
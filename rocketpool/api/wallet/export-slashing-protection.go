@@ -0,0 +1,161 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Settings
+const interchangeFormatVersion = "5"
+
+// EIP-3076 slashing protection interchange file
+type slashingProtectionInterchange struct {
+	Metadata struct {
+		InterchangeFormatVersion string `json:"interchange_format_version"`
+		GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+	} `json:"metadata"`
+	Data []slashingProtectionRecord `json:"data"`
+}
+type slashingProtectionRecord struct {
+	Pubkey             string                    `json:"pubkey"`
+	SignedBlocks       []signedBlockRecord       `json:"signed_blocks"`
+	SignedAttestations []signedAttestationRecord `json:"signed_attestations"`
+}
+type signedBlockRecord struct {
+	Slot string `json:"slot"`
+}
+type signedAttestationRecord struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+}
+
+func exportSlashingProtection(c *cli.Context) (*api.ExportSlashingProtectionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ExportSlashingProtectionResponse{}
+
+	// Get the node's validators
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	pubkeys, err := getNodeValidatorPubkeys(rp, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	// Block everything up to the current epoch for each validator
+	if err := os.MkdirAll(cfg.Smartnode.GetKeyExportsPath(), keyExportDirMode); err != nil {
+		return nil, fmt.Errorf("error creating export folder: %w", err)
+	}
+	response.ExportFile = fmt.Sprintf("slashing-protection-%d.json", time.Now().Unix())
+	response.ProtectionEpoch, err = exportChainSlashingProtection(bc, pubkeys, filepath.Join(cfg.Smartnode.GetKeyExportsPath(), response.ExportFile))
+	if err != nil {
+		return nil, err
+	}
+	response.ValidatorCount = len(pubkeys)
+
+	// Return response
+	return &response, nil
+
+}
+
+// Save a slashing protection file built from the chain that blocks the given validators from signing anything up to the end of the head epoch.
+// Returns the epoch the validators are protected up to.
+func exportChainSlashingProtection(bc beacon.Client, pubkeys []types.ValidatorPubkey, path string) (uint64, error) {
+
+	// Get the Beacon chain details
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return 0, fmt.Errorf("error getting Beacon chain config: %w", err)
+	}
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return 0, fmt.Errorf("error getting Beacon chain head: %w", err)
+	}
+
+	// Block everything up to the current epoch for each validator
+	protection := newSlashingProtectionInterchange(eth2Config)
+	for _, pubkey := range pubkeys {
+		protection.Data = append(protection.Data, newSlashingProtectionRecord(pubkey, eth2Config, head))
+	}
+	if err := saveSlashingProtection(protection, path); err != nil {
+		return 0, err
+	}
+	return head.Epoch, nil
+
+}
+
+// Create an empty slashing protection interchange for the current chain
+func newSlashingProtectionInterchange(eth2Config beacon.Eth2Config) slashingProtectionInterchange {
+	protection := slashingProtectionInterchange{}
+	protection.Metadata.InterchangeFormatVersion = interchangeFormatVersion
+	protection.Metadata.GenesisValidatorsRoot = hexutils.AddPrefix(hex.EncodeToString(eth2Config.GenesisValidatorsRoot))
+	return protection
+}
+
+// Create a slashing protection record that blocks a validator from signing anything up to the end of the head epoch
+func newSlashingProtectionRecord(pubkey types.ValidatorPubkey, eth2Config beacon.Eth2Config, head beacon.BeaconHead) slashingProtectionRecord {
+	return slashingProtectionRecord{
+		Pubkey: hexutils.AddPrefix(pubkey.Hex()),
+		SignedBlocks: []signedBlockRecord{{
+			Slot: strconv.FormatUint((head.Epoch+1)*eth2Config.SlotsPerEpoch-1, 10),
+		}},
+		SignedAttestations: []signedAttestationRecord{{
+			SourceEpoch: strconv.FormatUint(head.JustifiedEpoch, 10),
+			TargetEpoch: strconv.FormatUint(head.Epoch, 10),
+		}},
+	}
+}
+
+// Save a slashing protection interchange file
+func saveSlashingProtection(protection slashingProtectionInterchange, path string) error {
+	protectionBytes, err := json.MarshalIndent(protection, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing slashing protection file: %w", err)
+	}
+	if err := os.WriteFile(path, protectionBytes, keyExportFileMode); err != nil {
+		return fmt.Errorf("error saving slashing protection file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
+)
+
+func importKeys(c *cli.Context, pubkeys []types.ValidatorPubkey) (*api.ImportKeysResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ImportKeysResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure every key belongs to one of the node's minipools
+	minipoolPubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	isMinipoolPubkey := map[types.ValidatorPubkey]bool{}
+	for _, pubkey := range minipoolPubkeys {
+		isMinipoolPubkey[pubkey] = true
+	}
+	pubkeyMap := map[types.ValidatorPubkey]bool{}
+	for _, pubkey := range pubkeys {
+		if !isMinipoolPubkey[pubkey] {
+			return nil, fmt.Errorf("validator %s does not belong to any of this node's minipools", pubkey.Hex())
+		}
+		pubkeyMap[pubkey] = true
+	}
+
+	// Load the keys from the custom keystores
+	remainingPubkeys, err := walletutils.CheckForAndRecoverCustomMinipoolKeys(cfg, pubkeyMap, w, false)
+	if err != nil {
+		return nil, err
+	}
+	for pubkey := range remainingPubkeys {
+		return nil, fmt.Errorf("no custom keystore was found for validator %s", pubkey.Hex())
+	}
+	response.ImportedKeys = pubkeys
+
+	// Return response
+	return &response, nil
+
+}
@@ -6,11 +6,13 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
 )
 
@@ -62,7 +64,10 @@ func recoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletResponse,
 	walletIndex := c.Uint("wallet-index")
 
 	// Recover wallet
-	if err := w.Recover(path, walletIndex, mnemonic); err != nil {
+	if err := w.Recover(path, walletIndex, mnemonic, c.String("mnemonic-passphrase")); err != nil {
+		return nil, err
+	}
+	if err := setValidatorKeyPaths(c, w); err != nil {
 		return nil, err
 	}
 
@@ -132,7 +137,7 @@ func searchAndRecoverWallet(c *cli.Context, mnemonic string, address common.Addr
 			if err != nil {
 				return nil, fmt.Errorf("error generating new wallet: %w", err)
 			}
-			err = recoveredWallet.TestRecovery(derivationPath, i, mnemonic)
+			err = recoveredWallet.TestRecovery(derivationPath, i, mnemonic, c.String("mnemonic-passphrase"))
 			if err != nil {
 				return nil, fmt.Errorf("error recovering wallet with path [%s], index [%d]: %w", derivationPath, i, err)
 			}
@@ -160,7 +165,10 @@ func searchAndRecoverWallet(c *cli.Context, mnemonic string, address common.Addr
 	}
 
 	// Recover wallet
-	if err := w.Recover(response.DerivationPath, response.Index, mnemonic); err != nil {
+	if err := w.Recover(response.DerivationPath, response.Index, mnemonic, c.String("mnemonic-passphrase")); err != nil {
+		return nil, err
+	}
+	if err := setValidatorKeyPaths(c, w); err != nil {
 		return nil, err
 	}
 
@@ -187,3 +195,20 @@ func searchAndRecoverWallet(c *cli.Context, mnemonic string, address common.Addr
 	return &response, nil
 
 }
+
+// Save any non-standard validator key derivation paths so the wallet searches them when recovering keys
+func setValidatorKeyPaths(c *cli.Context, w *wallet.Wallet) error {
+	keyPath := c.String("validator-key-path")
+	overrides := map[types.ValidatorPubkey]string{}
+	if c.String("validator-key-path-overrides") != "" {
+		var err error
+		overrides, err = cliutils.ValidateValidatorKeyPathOverrides("validator key path overrides", c.String("validator-key-path-overrides"))
+		if err != nil {
+			return err
+		}
+	}
+	if keyPath == "" && len(overrides) == 0 {
+		return nil
+	}
+	return w.SetValidatorKeyPaths(keyPath, overrides)
+}
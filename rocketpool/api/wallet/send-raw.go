@@ -0,0 +1,53 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func sendRawTransaction(c *cli.Context, signedTx string) (*api.SendRawTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SendRawTransactionResponse{}
+
+	// Decode the transaction
+	txBytes, err := hexutil.Decode(signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing transaction bytes [%s]: %w", signedTx, err)
+	}
+	var tx types.Transaction
+	err = tx.UnmarshalBinary(txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding transaction: %w", err)
+	}
+	if _, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), &tx); err != nil {
+		return nil, fmt.Errorf("The transaction isn't signed: %w", err)
+	}
+
+	// Submit it
+	err = ec.SendTransaction(context.Background(), &tx)
+	if err != nil {
+		return nil, fmt.Errorf("Error submitting transaction: %w", err)
+	}
+	response.TxHash = tx.Hash()
+
+	// Return response
+	return &response, nil
+
+}
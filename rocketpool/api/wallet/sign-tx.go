@@ -0,0 +1,62 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func signTransaction(c *cli.Context, serializedTx string) (*api.SignTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SignTransactionResponse{}
+
+	// Make sure the transaction is for this node's network
+	txBytes, err := hexutil.Decode(serializedTx)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing transaction bytes [%s]: %w", serializedTx, err)
+	}
+	var tx types.Transaction
+	err = tx.UnmarshalBinary(txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding transaction: %w", err)
+	}
+	if tx.ChainId().Cmp(w.GetChainID()) != 0 {
+		return nil, fmt.Errorf("The transaction is for chain %s, but the node wallet is configured for chain %s", tx.ChainId().String(), w.GetChainID().String())
+	}
+
+	// Sign it
+	signedBytes, err := w.Sign(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	var signedTx types.Transaction
+	err = signedTx.UnmarshalBinary(signedBytes)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding signed transaction: %w", err)
+	}
+	response.From, err = types.Sender(types.LatestSignerForChainID(signedTx.ChainId()), &signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting signed transaction's sender: %w", err)
+	}
+	response.SignedTx = hexutil.Encode(signedBytes)
+	response.TxHash = signedTx.Hash()
+
+	// Return response
+	return &response, nil
+
+}
@@ -25,9 +25,10 @@ func getStatus(c *cli.Context) (*api.WalletStatusResponse, error) {
 	// Get wallet status
 	response.PasswordSet = pm.IsPasswordSet()
 	response.WalletInitialized = w.IsInitialized()
+	response.IsWatchOnly = w.IsWatchOnly()
 
 	// Get accounts if initialized
-	if response.WalletInitialized {
+	if response.WalletInitialized || response.IsWatchOnly {
 
 		// Get node account
 		nodeAccount, err := w.GetNodeAccount()
@@ -0,0 +1,281 @@
+package wallet
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Permissions for stored wallets
+const (
+	storedWalletDirMode  = 0700
+	activeWalletFileMode = 0600
+)
+
+func listWallets(c *cli.Context) (*api.ListWalletsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ListWalletsResponse{}
+
+	// Get the active wallet
+	walletsPath := cfg.Smartnode.GetWalletsPath()
+	response.ActiveWallet, err = getActiveWalletName(walletsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the stored wallets
+	response.Wallets, err = getStoredWalletNames(walletsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func switchWallet(c *cli.Context, name string) (*api.SwitchWalletResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SwitchWalletResponse{}
+
+	// Get the active wallet
+	walletsPath := cfg.Smartnode.GetWalletsPath()
+	activeWallet, err := getActiveWalletName(walletsPath)
+	if err != nil {
+		return nil, err
+	}
+	if name == activeWallet {
+		return nil, fmt.Errorf("Wallet '%s' is already active", name)
+	}
+	response.PreviousWallet = activeWallet
+
+	// Check the target wallet
+	activeWalletPath := filepath.Join(walletsPath, activeWallet)
+	targetWalletPath := filepath.Join(walletsPath, name)
+	_, err = os.Stat(activeWalletPath)
+	if err == nil {
+		return nil, fmt.Errorf("A stored wallet named '%s' already exists; please remove or rename it before switching", activeWallet)
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("Error checking stored wallet '%s': %w", activeWallet, err)
+	}
+	_, err = os.Stat(targetWalletPath)
+	if os.IsNotExist(err) {
+		response.NewWallet = true
+	} else if err != nil {
+		return nil, fmt.Errorf("Error checking stored wallet '%s': %w", name, err)
+	}
+
+	// Store the active wallet, along with its password and validator keys
+	if err := os.MkdirAll(activeWalletPath, storedWalletDirMode); err != nil {
+		return nil, fmt.Errorf("Error creating folder for wallet '%s': %w", activeWallet, err)
+	}
+	if err := moveWalletFiles(cfg, activeWalletPath, true); err != nil {
+		if rollbackErr := restoreActiveWallet(cfg, activeWalletPath); rollbackErr != nil {
+			return nil, fmt.Errorf("Error storing wallet '%s': %w\nError moving it back into place: %s\nIts files are split between %s and the active wallet location; please move them back manually", activeWallet, err, rollbackErr.Error(), activeWalletPath)
+		}
+		return nil, fmt.Errorf("Error storing wallet '%s': %w", activeWallet, err)
+	}
+
+	// Restore the target wallet; a new one just starts out empty
+	if !response.NewWallet {
+		if err := moveWalletFiles(cfg, targetWalletPath, false); err != nil {
+			// Put any of the target's files that were already restored back in its folder, then bring the original wallet back
+			rollbackErr := moveWalletFiles(cfg, targetWalletPath, true)
+			if rollbackErr == nil {
+				rollbackErr = restoreActiveWallet(cfg, activeWalletPath)
+			}
+			if rollbackErr != nil {
+				return nil, fmt.Errorf("Error restoring wallet '%s': %w\nError putting wallet '%s' back into place: %s\nPlease check %s and %s and move the files back manually", name, err, activeWallet, rollbackErr.Error(), activeWalletPath, targetWalletPath)
+			}
+			return nil, fmt.Errorf("Error restoring wallet '%s': %w; wallet '%s' is still active", name, err, activeWallet)
+		}
+		if err := os.RemoveAll(targetWalletPath); err != nil {
+			return nil, fmt.Errorf("Error removing the stored copy of wallet '%s': %w", name, err)
+		}
+	}
+
+	// Mark the target as active
+	if err := os.WriteFile(filepath.Join(walletsPath, config.ActiveWalletFilename), []byte(name), activeWalletFileMode); err != nil {
+		return nil, fmt.Errorf("Error saving the active wallet name: %w", err)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Move a wallet that was just stored back into the active location and remove its stored folder
+func restoreActiveWallet(cfg *config.RocketPoolConfig, storedWalletPath string) error {
+	if err := moveWalletFiles(cfg, storedWalletPath, false); err != nil {
+		return err
+	}
+	return os.RemoveAll(storedWalletPath)
+}
+
+// Get the name of the active wallet
+func getActiveWalletName(walletsPath string) (string, error) {
+	bytes, err := os.ReadFile(filepath.Join(walletsPath, config.ActiveWalletFilename))
+	if os.IsNotExist(err) {
+		return config.DefaultWalletName, nil
+	} else if err != nil {
+		return "", fmt.Errorf("Error reading the active wallet name: %w", err)
+	}
+	return strings.TrimSpace(string(bytes)), nil
+}
+
+// Get the names of the stored (inactive) wallets
+func getStoredWalletNames(walletsPath string) ([]string, error) {
+	entries, err := os.ReadDir(walletsPath)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Error reading stored wallets: %w", err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Move the wallet, password and validator keys between their active locations and a stored wallet folder
+func moveWalletFiles(cfg *config.RocketPoolConfig, storedWalletPath string, store bool) error {
+
+	// Get the source and destination of an active path
+	getPaths := func(activePath string) (string, string) {
+		storedPath := filepath.Join(storedWalletPath, filepath.Base(activePath))
+		if store {
+			return activePath, storedPath
+		}
+		return storedPath, activePath
+	}
+
+	// The keychain entry is tied to the active password path, so it can't be moved with the wallet
+	if cfg.Smartnode.UsePasswordKeychain.Value == true {
+		return fmt.Errorf("switching wallets isn't supported while your wallet password is stored in the OS keychain; please disable OS keychain password storage in the Smartnode settings first")
+	}
+
+	// Move the wallet and password
+	for _, activePath := range []string{cfg.Smartnode.GetWalletPath(), cfg.Smartnode.GetPasswordPath()} {
+		from, to := getPaths(activePath)
+		err := moveWalletPath(from, to)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	// Move the contents of the validators folder rather than the folder itself, since it may be mounted separately
+	from, to := getPaths(cfg.Smartnode.GetValidatorKeychainPath())
+	entries, err := os.ReadDir(from)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(to, storedWalletDirMode); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := moveWalletPath(filepath.Join(from, entry.Name()), filepath.Join(to, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+
+}
+
+// Move a file or folder without ever leaving a partial copy: each file is copied to a temporary file next to its destination,
+// synced to disk and renamed into place before the original is removed, so an interruption leaves a complete copy behind.
+// This also works when the destination is on a different filesystem, such as a separately mounted validators folder.
+func moveWalletPath(from string, to string) error {
+	info, err := os.Lstat(from)
+	if err != nil {
+		return err
+	}
+
+	// Move folders one entry at a time
+	if info.IsDir() {
+		if err := os.MkdirAll(to, info.Mode().Perm()); err != nil {
+			return err
+		}
+		entries, err := os.ReadDir(from)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := moveWalletPath(filepath.Join(from, entry.Name()), filepath.Join(to, entry.Name())); err != nil {
+				return err
+			}
+		}
+		return os.Remove(from)
+	}
+
+	// Copy the file to a temporary file and make sure it's on disk
+	source, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	tempPath := to + ".tmp"
+	dest, err := os.OpenFile(tempPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dest, source)
+	if err == nil {
+		err = dest.Sync()
+	}
+	closeErr := dest.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("error copying %s: %w", from, err)
+	}
+
+	// Rename it into place, persist the rename, then remove the original
+	if err := os.Rename(tempPath, to); err != nil {
+		return err
+	}
+	if err := syncDir(filepath.Dir(to)); err != nil {
+		return err
+	}
+	return os.Remove(from)
+}
+
+// Sync a folder so the renames in it are persisted
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
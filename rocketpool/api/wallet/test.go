@@ -56,7 +56,10 @@ func testRecoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletRespo
 	walletIndex := c.Uint("wallet-index")
 
 	// Recover wallet
-	if err := w.TestRecovery(path, walletIndex, mnemonic); err != nil {
+	if err := w.TestRecovery(path, walletIndex, mnemonic, c.String("mnemonic-passphrase")); err != nil {
+		return nil, err
+	}
+	if err := setValidatorKeyPaths(c, w); err != nil {
 		return nil, err
 	}
 
@@ -120,7 +123,7 @@ func testSearchAndRecoverWallet(c *cli.Context, mnemonic string, address common.
 			if err != nil {
 				return nil, fmt.Errorf("error generating new wallet: %w", err)
 			}
-			err = recoveredWallet.TestRecovery(derivationPath, i, mnemonic)
+			err = recoveredWallet.TestRecovery(derivationPath, i, mnemonic, c.String("mnemonic-passphrase"))
 			if err != nil {
 				return nil, fmt.Errorf("error recovering wallet with path [%s], index [%d]: %w", derivationPath, i, err)
 			}
@@ -148,7 +151,10 @@ func testSearchAndRecoverWallet(c *cli.Context, mnemonic string, address common.
 	}
 
 	// Recover wallet
-	if err := w.TestRecovery(response.DerivationPath, response.Index, mnemonic); err != nil {
+	if err := w.TestRecovery(response.DerivationPath, response.Index, mnemonic, c.String("mnemonic-passphrase")); err != nil {
+		return nil, err
+	}
+	if err := setValidatorKeyPaths(c, w); err != nil {
 		return nil, err
 	}
 
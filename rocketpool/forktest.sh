@@ -0,0 +1,18 @@
+#!/bin/bash
+
+# Runs the fork tests, which run the daemon's tasks end-to-end against an anvil fork of mainnet.
+# Requires anvil (from Foundry) on the PATH and FORKTEST_FORK_URL set to a mainnet Execution client, preferably an archive node.
+# Set FORKTEST_FORK_BLOCK to pin the fork to a block so results are reproducible; see shared/services/forktest for the other
+# settings. Any arguments are passed to go test, e.g. -run TestAutoClaimRewards.
+
+set -o errexit
+
+if ! command -v anvil &> /dev/null; then
+    echo "anvil is required; see https://book.getfoundry.sh/getting-started/installation"; exit 1
+fi
+if [ -z "$FORKTEST_FORK_URL" ]; then
+    echo "FORKTEST_FORK_URL must be set to the RPC endpoint of a mainnet Execution client"; exit 1
+fi
+
+cd "$(dirname "$0")/.."
+CGO_ENABLED=1 CGO_CFLAGS="-O -D__BLST_PORTABLE__" go test -tags forktest -count 1 -v "$@" ./shared/services/forktest/...
@@ -0,0 +1,118 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Commands that build the full network state or a rewards tree, which only run one at a time
+var expensiveApiCommands = map[string]bool{
+	"node/get-export-data":          true,
+	"node/get-state-snapshot":       true,
+	"node/rewards":                  true,
+	"node/get-projected-rewards":    true,
+	"node/get-rewards-history":      true,
+	"node/get-rewards-info":         true,
+	"network/generate-rewards-tree": true,
+	"network/dry-run-rewards-tree":  true,
+	"network/verify-rewards-tree":   true,
+	"debug/export-validators":       true,
+}
+
+// How long a client's rate limit is kept after its last request
+const apiClientIdleTimeout time.Duration = 10 * time.Minute
+
+var errApiRateLimited = errors.New("too many requests; please slow down")
+var errApiQueueFull = errors.New("too many expensive requests are already waiting; please try again later")
+
+// The rate limit of a single client, as a token bucket
+type apiClientBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// Limits how often each client can call the API, and queues the expensive commands so they run one at a time and
+// can't starve the daemon's own tasks
+type apiLimiter struct {
+	requestsPerMinute float64
+	lock              sync.Mutex
+	clients           map[string]*apiClientBucket
+	lastPruned        time.Time
+
+	// Expensive commands hold the running slot while they run, and a queue slot while they run or wait
+	running chan struct{}
+	queue   chan struct{}
+}
+
+// Create a limiter; a rate of 0 disables the rate limit
+func newApiLimiter(requestsPerMinute uint64, maxQueued uint64) *apiLimiter {
+	return &apiLimiter{
+		requestsPerMinute: float64(requestsPerMinute),
+		clients:           map[string]*apiClientBucket{},
+		lastPruned:        time.Now(),
+		running:           make(chan struct{}, 1),
+		queue:             make(chan struct{}, maxQueued+1),
+	}
+}
+
+// Take one request from the client's rate limit
+func (l *apiLimiter) allow(client string) error {
+	if l.requestsPerMinute == 0 {
+		return nil
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := time.Now()
+	if now.Sub(l.lastPruned) > apiClientIdleTimeout {
+		for key, bucket := range l.clients {
+			if now.Sub(bucket.lastSeen) > apiClientIdleTimeout {
+				delete(l.clients, key)
+			}
+		}
+		l.lastPruned = now
+	}
+
+	// Refill the client's bucket for the time since its last request, up to a minute's worth
+	bucket, exists := l.clients[client]
+	if !exists {
+		bucket = &apiClientBucket{tokens: l.requestsPerMinute, lastSeen: now}
+		l.clients[client] = bucket
+	}
+	bucket.tokens += now.Sub(bucket.lastSeen).Minutes() * l.requestsPerMinute
+	if bucket.tokens > l.requestsPerMinute {
+		bucket.tokens = l.requestsPerMinute
+	}
+	bucket.lastSeen = now
+
+	if bucket.tokens < 1 {
+		return errApiRateLimited
+	}
+	bucket.tokens--
+	return nil
+}
+
+// Wait for the running slot for an expensive command, and get the function that releases it
+func (l *apiLimiter) acquire(ctx context.Context, route string) (func(), error) {
+	if !expensiveApiCommands[route] {
+		return func() {}, nil
+	}
+
+	select {
+	case l.queue <- struct{}{}:
+	default:
+		return nil, errApiQueueFull
+	}
+	select {
+	case l.running <- struct{}{}:
+	case <-ctx.Done():
+		<-l.queue
+		return nil, ctx.Err()
+	}
+	return func() {
+		<-l.running
+		<-l.queue
+	}, nil
+}
@@ -0,0 +1,174 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/offline"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The gas and transaction settings for an API command
+type apiTxOptions struct {
+	MaxFee     float64 `json:"maxFee,omitempty"`
+	MaxPrioFee float64 `json:"maxPrioFee,omitempty"`
+	GasLimit   uint64  `json:"gasLimit,omitempty"`
+	Nonce      string  `json:"nonce,omitempty"`
+	Mode       string  `json:"mode,omitempty"`
+}
+
+// The context key for the address of the client calling an API command
+type apiClientKey struct{}
+
+// Runs API commands in their own process, the same way the CLI does; it's shared by the HTTP and gRPC APIs so they
+// have the same limits
+type apiRunner struct {
+	executable   string
+	settingsPath string
+	limiter      *apiLimiter
+
+	// The state the daemon persisted before it last stopped, which read-only commands are answered from until a fresh one is built
+	warmStart     *state.StateSnapshot
+	warmStartLock sync.RWMutex
+}
+
+// Create a runner for the daemon's own API commands
+func newApiRunner(c *cli.Context) (*apiRunner, error) {
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the daemon's path: %w", err)
+	}
+	return &apiRunner{
+		executable:   executable,
+		settingsPath: c.GlobalString("settings"),
+		limiter:      newApiLimiter(cfg.Smartnode.ApiRateLimit.Value.(uint64), cfg.Smartnode.ApiMaxQueuedRequests.Value.(uint64)),
+	}, nil
+}
+
+// Add the address of the client calling an API command to its context, for its rate limit
+func withApiClient(ctx context.Context, client string) context.Context {
+	return context.WithValue(ctx, apiClientKey{}, client)
+}
+
+// Run an API command and get its response; commands that fail still return a response with the error
+func (r *apiRunner) run(ctx context.Context, options apiTxOptions, args ...string) ([]byte, error) {
+	globalArgs, err := options.getGlobalArgs(r.settingsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply the client's rate limit, and wait for a turn if the command is expensive
+	client, _ := ctx.Value(apiClientKey{}).(string)
+	if err := r.limiter.allow(client); err != nil {
+		return nil, err
+	}
+
+	// Answer what it can from the persisted state while the daemon is still starting up
+	if response, served := r.runFromWarmStart(args); served {
+		return response, nil
+	}
+	route := strings.Join(args[:2], "/")
+	release, err := r.limiter.acquire(ctx, route)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	cmd := exec.CommandContext(ctx, r.executable, append(append(globalArgs, "api"), args...)...)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil && stdout.Len() == 0 {
+		return nil, fmt.Errorf("error running API command: %w", err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// Answer read-only commands from the persisted state until endWarmStart is called
+func (r *apiRunner) startWarmStart(snapshot *state.StateSnapshot) {
+	r.warmStartLock.Lock()
+	defer r.warmStartLock.Unlock()
+	r.warmStart = snapshot
+}
+
+// Stop answering commands from the persisted state, once a fresh one has been built
+func (r *apiRunner) endWarmStart() {
+	r.warmStartLock.Lock()
+	defer r.warmStartLock.Unlock()
+	r.warmStart = nil
+}
+
+// Answer an API command from the persisted state if the daemon is warm-starting and the command only needs the state
+func (r *apiRunner) runFromWarmStart(args []string) ([]byte, bool) {
+	r.warmStartLock.RLock()
+	snapshot := r.warmStart
+	r.warmStartLock.RUnlock()
+
+	command := strings.Join(args, " ")
+	if snapshot == nil || !offline.IsSnapshotCommand(command) {
+		return nil, false
+	}
+	response, err := offline.CallAPI(snapshot, command)
+	if err != nil {
+		return nil, false
+	}
+	return response, true
+}
+
+// Run an API command and deserialize its response, returning its error if it failed
+func (r *apiRunner) runInto(ctx context.Context, options apiTxOptions, response interface{}, args ...string) error {
+	responseBytes, err := r.run(ctx, options, args...)
+	if err != nil {
+		return err
+	}
+	var apiResponse api.APIResponse
+	if err := json.Unmarshal(responseBytes, &apiResponse); err != nil {
+		return fmt.Errorf("error decoding API response: %w", err)
+	}
+	if apiResponse.Error != "" {
+		return fmt.Errorf("%s", apiResponse.Error)
+	}
+	if err := json.Unmarshal(responseBytes, response); err != nil {
+		return fmt.Errorf("error decoding API response: %w", err)
+	}
+	return nil
+}
+
+// Get the daemon's global arguments for the gas and transaction settings
+func (o apiTxOptions) getGlobalArgs(settingsPath string) ([]string, error) {
+	args := []string{"--settings", settingsPath}
+	if o.MaxFee != 0 {
+		args = append(args, "--maxFee", fmt.Sprint(o.MaxFee))
+	}
+	if o.MaxPrioFee != 0 {
+		args = append(args, "--maxPrioFee", fmt.Sprint(o.MaxPrioFee))
+	}
+	if o.GasLimit != 0 {
+		args = append(args, "--gasLimit", fmt.Sprint(o.GasLimit))
+	}
+	if o.Nonce != "" {
+		args = append(args, "--nonce", o.Nonce)
+	}
+	switch o.Mode {
+	case "":
+	case "unsigned", "dry-run", "deferred":
+		args = append(args, "--"+o.Mode)
+	default:
+		return nil, fmt.Errorf("unknown transaction mode '%s'", o.Mode)
+	}
+	return args, nil
+}
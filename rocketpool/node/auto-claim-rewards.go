@@ -0,0 +1,413 @@
+package node
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The rewards that can be claimed for the node's unclaimed intervals
+type claimableRewards struct {
+	indices      []*big.Int
+	amountRpl    []*big.Int
+	amountEth    []*big.Int
+	merkleProofs [][]common.Hash
+	totalRpl     *big.Int
+	totalEth     *big.Int
+}
+
+// Auto-claim rewards task
+type autoClaimRewards struct {
+	c                *cli.Context
+	log              log.ColorLogger
+	cfg              *config.RocketPoolConfig
+	w                *wallet.Wallet
+	rp               *rocketpool.RocketPool
+	ec               *services.ExecutionClientManager
+	gasThreshold     float64
+	disabled         bool
+	restakeMode      cfgtypes.RestakeMode
+	restakePercent   float64
+	targetCollateral float64
+	sweepAddress     *common.Address
+	maxFee           *big.Int
+	maxPriorityFee   *big.Int
+	gasLimit         uint64
+}
+
+// Create auto-claim rewards task
+func newAutoClaimRewards(c *cli.Context, logger log.ColorLogger) (*autoClaimRewards, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	task := createAutoClaimRewards(logger, cfg, w, rp, ec)
+	task.c = c
+	return task, nil
+
+}
+
+// Create auto-claim rewards task with the services it uses, applying the claim policy from the config
+func createAutoClaimRewards(logger log.ColorLogger, cfg *config.RocketPoolConfig, w *wallet.Wallet, rp *rocketpool.RocketPool, ec *services.ExecutionClientManager) *autoClaimRewards {
+
+	// Check if auto-claiming is disabled
+	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+	disabled := !cfg.Smartnode.AutoClaimRewards.Value.(bool)
+	if !disabled && gasThreshold == 0 {
+		logger.Println("Automatic tx gas threshold is 0, disabling auto-claim.")
+		disabled = true
+	}
+
+	// Use the max fee for claims instead of the automatic tx gas threshold if there is one
+	autoClaimMaxFee := cfg.Smartnode.AutoClaimMaxFee.Value.(float64)
+	if !disabled && autoClaimMaxFee > 0 {
+		gasThreshold = autoClaimMaxFee
+	}
+
+	// Get the restaking policy
+	restakePercent := cfg.Smartnode.AutoClaimRestakePercent.Value.(float64)
+	if restakePercent < 0 || restakePercent > 100 {
+		logger.Printlnf("WARNING: Auto-claim restake percent must be between 0 and 100 (%.2f), clamping it.", restakePercent)
+		restakePercent = math.Min(math.Max(restakePercent, 0), 100)
+	}
+	var sweepAddress *common.Address
+	if sweepAddressString := cfg.Smartnode.AutoClaimSweepAddress.Value.(string); sweepAddressString != "" {
+		address := common.HexToAddress(sweepAddressString)
+		sweepAddress = &address
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested priority fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	return &autoClaimRewards{
+		log:              logger,
+		cfg:              cfg,
+		w:                w,
+		rp:               rp,
+		ec:               ec,
+		gasThreshold:     gasThreshold,
+		disabled:         disabled,
+		restakeMode:      cfg.Smartnode.AutoClaimRestakeMode.Value.(cfgtypes.RestakeMode),
+		restakePercent:   restakePercent,
+		targetCollateral: cfg.Smartnode.AutoClaimTargetCollateral.Value.(float64) / 100,
+		sweepAddress:     sweepAddress,
+		maxFee:           maxFee,
+		maxPriorityFee:   priorityFee,
+		gasLimit:         0,
+	}
+
+}
+
+// Claim the node's unclaimed rewards, restaking and sweeping them according to the policy
+func (t *autoClaimRewards) run(state *state.NetworkState) error {
+
+	// Check if auto-claim is disabled
+	if t.disabled {
+		return nil
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	nd, exists := state.NodeDetailsByAddress[nodeAccount.Address]
+	if !exists || !nd.Exists {
+		return nil
+	}
+
+	// Get the rewards that haven't been claimed yet
+	claimable, err := t.getClaimableRewards(nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	if len(claimable.indices) == 0 {
+		return nil
+	}
+
+	// Log
+	t.log.Printlnf("%d interval(s) have rewards to claim (%.6f RPL and %.6f ETH)...", len(claimable.indices), eth.WeiToEth(claimable.totalRpl), eth.WeiToEth(claimable.totalEth))
+
+	// Claim the rewards
+	restakeAmount := t.getRestakeAmount(state, nd, claimable.totalRpl)
+	success, err := t.claimRewards(nodeAccount.Address, claimable, restakeAmount)
+	if err != nil {
+		return fmt.Errorf("could not claim rewards: %w", err)
+	}
+	if !success {
+		return nil
+	}
+
+	// Sweep the claimed ETH
+	if t.sweepAddress == nil || claimable.totalEth.Sign() == 0 {
+		return nil
+	}
+	if nd.WithdrawalAddress != nodeAccount.Address {
+		t.log.Printlnf("The claimed ETH was sent to your withdrawal address (%s), so it can't be swept from the node wallet.", nd.WithdrawalAddress.Hex())
+		return nil
+	}
+	if t.w.IsSafe() {
+		t.log.Println("The claim was proposed to your Safe, so the claimed ETH can't be swept until it's executed.")
+		return nil
+	}
+	if err := t.sweepEth(claimable.totalEth); err != nil {
+		return fmt.Errorf("could not sweep claimed ETH to %s: %w", t.sweepAddress.Hex(), err)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the rewards for the node's unclaimed intervals that have a valid tree file
+func (t *autoClaimRewards) getClaimableRewards(nodeAddress common.Address) (*claimableRewards, error) {
+
+	claimable := &claimableRewards{
+		indices:      []*big.Int{},
+		amountRpl:    []*big.Int{},
+		amountEth:    []*big.Int{},
+		merkleProofs: [][]common.Hash{},
+		totalRpl:     big.NewInt(0),
+		totalEth:     big.NewInt(0),
+	}
+	unclaimed, _, err := rprewards.GetClaimStatus(t.rp, nodeAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rewards claim status: %w", err)
+	}
+
+	for _, index := range unclaimed {
+		intervalInfo, err := rprewards.GetIntervalInfo(t.rp, t.cfg, nodeAddress, index, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting info for interval %d: %w", index, err)
+		}
+		if !intervalInfo.TreeFileExists {
+			t.log.Printlnf("The rewards tree file for interval %d hasn't been downloaded yet, skipping it.", index)
+			continue
+		}
+		if !intervalInfo.MerkleRootValid {
+			t.log.Printlnf("WARNING: The rewards tree file for interval %d doesn't match the canonical merkle root, skipping it.", index)
+			continue
+		}
+		if !intervalInfo.NodeExists {
+			continue
+		}
+
+		rplForInterval := big.NewInt(0)
+		rplForInterval.Add(rplForInterval, &intervalInfo.CollateralRplAmount.Int)
+		rplForInterval.Add(rplForInterval, &intervalInfo.ODaoRplAmount.Int)
+		ethForInterval := big.NewInt(0).Set(&intervalInfo.SmoothingPoolEthAmount.Int)
+
+		claimable.indices = append(claimable.indices, big.NewInt(0).SetUint64(index))
+		claimable.amountRpl = append(claimable.amountRpl, rplForInterval)
+		claimable.amountEth = append(claimable.amountEth, ethForInterval)
+		claimable.merkleProofs = append(claimable.merkleProofs, intervalInfo.MerkleProof)
+		claimable.totalRpl.Add(claimable.totalRpl, rplForInterval)
+		claimable.totalEth.Add(claimable.totalEth, ethForInterval)
+	}
+
+	return claimable, nil
+
+}
+
+// Get how much of the claimed RPL to restake according to the policy
+func (t *autoClaimRewards) getRestakeAmount(state *state.NetworkState, nd *rpstate.NativeNodeDetails, claimRpl *big.Int) *big.Int {
+
+	switch t.restakeMode {
+	case cfgtypes.RestakeMode_Percent:
+		restakeAmount := big.NewInt(0).Mul(claimRpl, big.NewInt(int64(t.restakePercent*100)))
+		return restakeAmount.Div(restakeAmount, big.NewInt(10000))
+
+	case cfgtypes.RestakeMode_Collateral:
+		// Get the stake that brings the node to the target ratio, without going past the max that earns rewards
+		rplPrice := state.NetworkDetails.RplPrice
+		if rplPrice == nil || rplPrice.Sign() == 0 || nd.EthMatched.Sign() == 0 {
+			return big.NewInt(0)
+		}
+		targetStake := eth.EthToWei(eth.WeiToEth(nd.EthMatched) * t.targetCollateral / eth.WeiToEth(rplPrice))
+		if targetStake.Cmp(nd.MaximumRPLStake) > 0 {
+			targetStake.Set(nd.MaximumRPLStake)
+		}
+		restakeAmount := big.NewInt(0).Sub(targetStake, nd.RplStake)
+		if restakeAmount.Sign() <= 0 {
+			return big.NewInt(0)
+		}
+		if restakeAmount.Cmp(claimRpl) > 0 {
+			restakeAmount.Set(claimRpl)
+		}
+		return restakeAmount
+	}
+
+	return big.NewInt(0)
+
+}
+
+// Claim the rewards, restaking the provided amount of RPL
+func (t *autoClaimRewards) claimRewards(nodeAddress common.Address, claimable *claimableRewards, restakeAmount *big.Int) (bool, error) {
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return false, err
+	}
+
+	// Get the gas limit
+	var gasInfo rocketpool.GasInfo
+	if restakeAmount.Sign() > 0 {
+		t.log.Printlnf("Claiming rewards and restaking %.6f RPL...", eth.WeiToEth(restakeAmount))
+		gasInfo, err = rewards.EstimateClaimAndStakeGas(t.rp, nodeAddress, claimable.indices, claimable.amountRpl, claimable.amountEth, claimable.merkleProofs, restakeAmount, opts)
+	} else {
+		t.log.Println("Claiming rewards...")
+		gasInfo, err = rewards.EstimateClaimGas(t.rp, nodeAddress, claimable.indices, claimable.amountRpl, claimable.amountEth, claimable.merkleProofs, opts)
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not estimate the gas required to claim rewards: %w", err)
+	}
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Print the gas info
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+		return false, nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gas.Uint64()
+
+	// Claim rewards
+	var hash common.Hash
+	if restakeAmount.Sign() > 0 {
+		hash, err = rewards.ClaimAndStake(t.rp, nodeAddress, claimable.indices, claimable.amountRpl, claimable.amountEth, claimable.merkleProofs, restakeAmount, opts)
+	} else {
+		hash, err = rewards.Claim(t.rp, nodeAddress, claimable.indices, claimable.amountRpl, claimable.amountEth, claimable.merkleProofs, opts)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	if err != nil {
+		return false, err
+	}
+
+	// Log; the node events task sends the claim notification once it sees the claim on chain
+	t.log.Printlnf("Successfully claimed %.6f RPL and %.6f ETH of rewards for %d interval(s), restaking %.6f RPL.", eth.WeiToEth(claimable.totalRpl), eth.WeiToEth(claimable.totalEth), len(claimable.indices), eth.WeiToEth(restakeAmount))
+
+	// Return
+	return true, nil
+
+}
+
+// Send the claimed ETH from the node wallet to the sweep address
+func (t *autoClaimRewards) sweepEth(amount *big.Int) error {
+
+	// Log
+	t.log.Printlnf("Sweeping %.6f ETH to %s...", eth.WeiToEth(amount), t.sweepAddress.Hex())
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+	opts.Value = amount
+
+	// Get the gas limit
+	gasInfo, err := eth.EstimateSendTransactionGas(t.ec, *t.sweepAddress, nil, false, opts)
+	if err != nil {
+		return fmt.Errorf("could not estimate the gas required to sweep ETH: %w", err)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
+		if err != nil {
+			return err
+		}
+	}
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gasInfo.SafeGasLimit
+
+	// Send the ETH; the claim already went through, so this doesn't wait for gas to be under the threshold
+	hash, err := eth1.SendTransaction(t.ec, *t.sweepAddress, t.w.GetChainID(), nil, false, opts)
+	if err != nil {
+		return err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	if err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Printlnf("Successfully swept %.6f ETH to %s.", eth.WeiToEth(amount), t.sweepAddress.Hex())
+
+	// Return
+	return nil
+
+}
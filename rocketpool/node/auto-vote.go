@@ -0,0 +1,304 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	apinode "github.com/rocket-pool/smartnode/rocketpool/api/node"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// A keyword rule for voting on proposals in rules mode
+type voteRule struct {
+	keyword string
+	choice  string
+}
+
+// Auto vote task
+type autoVote struct {
+	c         *cli.Context
+	log       log.ColorLogger
+	cfg       *config.RocketPoolConfig
+	w         *wallet.Wallet
+	s         *contracts.SnapshotDelegation
+	alerts    *alerting.Manager
+	policy    cfgtypes.VotePolicy
+	followed  common.Address
+	rules     []voteRule
+	disabled  bool
+	cantSign  bool
+	apiDomain string
+	space     string
+}
+
+// Create auto vote task
+func newAutoVote(c *cli.Context, logger log.ColorLogger) (*autoVote, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	s, err := services.GetSnapshotDelegation(c)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := services.GetAlertManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if automatic voting is disabled
+	policy := cfg.Smartnode.AutoVotePolicy.Value.(cfgtypes.VotePolicy)
+	disabled := policy == cfgtypes.VotePolicy_None || policy == cfgtypes.VotePolicy_Unknown
+	apiDomain := cfg.Smartnode.GetSnapshotApiDomain()
+	if !disabled && apiDomain == "" {
+		logger.Printlnf("Network [%v] doesn't have a Snapshot space, disabling automatic voting.", cfg.Smartnode.Network.Value)
+		disabled = true
+	}
+
+	// Get the policy's settings
+	var followed common.Address
+	var rules []voteRule
+	if !disabled {
+		switch policy {
+		case cfgtypes.VotePolicy_Follow:
+			delegate := cfg.Smartnode.AutoVoteDelegate.Value.(string)
+			if delegate == "" {
+				logger.Println("The Auto-Vote Delegate isn't set, disabling automatic voting.")
+				disabled = true
+			}
+			followed = common.HexToAddress(delegate)
+		case cfgtypes.VotePolicy_Rules:
+			rules, err = parseVoteRules(cfg.Smartnode.AutoVoteRules.Value.(string))
+			if err != nil {
+				logger.Printlnf("Invalid Auto-Vote Rules (%s), disabling automatic voting.", err.Error())
+				disabled = true
+			} else if len(rules) == 0 {
+				logger.Println("There aren't any Auto-Vote Rules, disabling automatic voting.")
+				disabled = true
+			}
+		}
+	}
+
+	// Return task
+	return &autoVote{
+		c:         c,
+		log:       logger,
+		cfg:       cfg,
+		w:         w,
+		s:         s,
+		alerts:    alerts,
+		policy:    policy,
+		followed:  followed,
+		rules:     rules,
+		disabled:  disabled,
+		apiDomain: apiDomain,
+		space:     cfg.Smartnode.GetSnapshotID(),
+	}, nil
+
+}
+
+// Vote on the active Protocol DAO proposals according to the node's policy
+func (t *autoVote) run(state *state.NetworkState) error {
+
+	// Check if automatic voting is disabled
+	if t.disabled {
+		return nil
+	}
+
+	// Votes are signed messages, which Safes and hardware wallets can't produce here
+	if t.w.IsSafe() || t.w.IsHardwareWallet() {
+		if !t.cantSign {
+			t.log.Println("The node account can't sign Snapshot votes, so automatic voting is paused.")
+			t.cantSign = true
+		}
+		return nil
+	}
+	t.cantSign = false
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	nd, exists := state.NodeDetailsByAddress[nodeAccount.Address]
+	if !exists || !nd.Exists {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking for Protocol DAO proposals to vote on...")
+
+	// Get the active proposals
+	proposals, err := apinode.GetSnapshotProposals(t.apiDomain, t.space, "active")
+	if err != nil {
+		return fmt.Errorf("error getting active proposals: %w", err)
+	}
+	if len(proposals.Data.Proposals) == 0 {
+		return nil
+	}
+
+	// Make sure the node can vote
+	votingPower, err := apinode.GetSnapshotVotingPower(t.apiDomain, t.space, nodeAccount.Address)
+	if err != nil {
+		return fmt.Errorf("error getting voting power: %w", err)
+	}
+	if votingPower.Data.Vp.Vp == 0 {
+		t.log.Println("The node doesn't have any voting power, so it can't vote.")
+		return nil
+	}
+
+	// Get the votes of the node and its on-chain delegate
+	var delegate common.Address
+	if t.s != nil {
+		delegate, err = t.s.Delegation(nil, nodeAccount.Address, t.cfg.Smartnode.GetVotingSnapshotID())
+		if err != nil {
+			return fmt.Errorf("error getting node delegate: %w", err)
+		}
+	}
+	votes, err := apinode.GetSnapshotVotedProposals(t.apiDomain, t.space, nodeAccount.Address, delegate)
+	if err != nil {
+		return fmt.Errorf("error getting the node's votes: %w", err)
+	}
+	nodeVotes := getVoterChoices(votes.Data.Votes, nodeAccount.Address)
+	delegateVotes := getVoterChoices(votes.Data.Votes, delegate)
+
+	// Get the votes of the followed voter
+	var followedVotes map[string]int
+	if t.policy == cfgtypes.VotePolicy_Follow {
+		votes, err := apinode.GetSnapshotVotedProposals(t.apiDomain, t.space, t.followed, t.followed)
+		if err != nil {
+			return fmt.Errorf("error getting the votes of %s: %w", t.followed.Hex(), err)
+		}
+		followedVotes = getVoterChoices(votes.Data.Votes, t.followed)
+	}
+
+	for _, proposal := range proposals.Data.Proposals {
+		if _, voted := nodeVotes[proposal.Id]; voted {
+			continue
+		}
+		if proposal.Type != "single-choice" && proposal.Type != "basic" {
+			continue
+		}
+		choice, reason := t.getChoice(proposal, followedVotes)
+		if choice == 0 {
+			continue
+		}
+		choiceName := proposal.Choices[choice-1]
+
+		// Vote
+		t.log.Printlnf("Voting %s on proposal '%s' (%s)...", choiceName, proposal.Title, reason)
+		if err := apinode.SubmitSnapshotVote(t.apiDomain, t.space, t.w, proposal.Id, choice, reason); err != nil {
+			t.log.Println(fmt.Errorf("could not vote on proposal '%s': %w", proposal.Title, err))
+			continue
+		}
+		t.log.Printlnf("Successfully voted %s on proposal '%s'.", choiceName, proposal.Title)
+		if err := t.alerts.Notify("pdao-voted", fmt.Sprintf("Voted %s on Protocol DAO proposal '%s' (%s).", choiceName, proposal.Title, reason)); err != nil {
+			t.log.Printlnf("Could not send the vote notification: %s", err.Error())
+		}
+
+		// Let the operator know when the vote replaces a different one from their delegate
+		delegateChoice, delegateVoted := delegateVotes[proposal.Id]
+		if delegateVoted && delegateChoice != choice && delegateChoice > 0 && delegateChoice <= len(proposal.Choices) {
+			message := fmt.Sprintf("Your node's %s vote on Protocol DAO proposal '%s' overrides the %s vote of your delegate %s.", choiceName, proposal.Title, proposal.Choices[delegateChoice-1], delegate.Hex())
+			t.log.Println(message)
+			if err := t.alerts.Notify("pdao-vote-override", message); err != nil {
+				t.log.Printlnf("Could not send the vote override notification: %s", err.Error())
+			}
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the 1-based choice the policy picks for a proposal and the reason for it, or 0 if it shouldn't be voted on yet
+func (t *autoVote) getChoice(proposal api.SnapshotProposal, followedVotes map[string]int) (int, string) {
+	switch t.policy {
+	case cfgtypes.VotePolicy_Follow:
+		choice, voted := followedVotes[proposal.Id]
+		if !voted || choice < 1 || choice > len(proposal.Choices) {
+			return 0, ""
+		}
+		return choice, fmt.Sprintf("following %s", t.followed.Hex())
+
+	case cfgtypes.VotePolicy_Abstain:
+		choice := getChoiceIndex(proposal, "Abstain")
+		return choice, "abstaining automatically"
+
+	case cfgtypes.VotePolicy_Rules:
+		title := strings.ToLower(proposal.Title)
+		for _, rule := range t.rules {
+			if !strings.Contains(title, rule.keyword) {
+				continue
+			}
+			choice := getChoiceIndex(proposal, rule.choice)
+			if choice == 0 {
+				t.log.Printlnf("Proposal '%s' matches the '%s' rule, but it doesn't have a '%s' choice.", proposal.Title, rule.keyword, rule.choice)
+			}
+			return choice, fmt.Sprintf("matched the '%s' rule", rule.keyword)
+		}
+	}
+	return 0, ""
+}
+
+// Get the 1-based index of a proposal's choice by name, ignoring case, or 0 if it doesn't have that choice
+func getChoiceIndex(proposal api.SnapshotProposal, name string) int {
+	for i, choice := range proposal.Choices {
+		if strings.EqualFold(strings.TrimSpace(choice), name) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Get the single choices a voter made, by proposal ID
+func getVoterChoices(votes []api.SnapshotProposalVote, voter common.Address) map[string]int {
+	choices := map[string]int{}
+	for _, vote := range votes {
+		if vote.Voter != voter {
+			continue
+		}
+		choice, isSingle := vote.Choice.(float64)
+		if !isSingle {
+			choice = 0
+		}
+		choices[vote.Proposal.Id] = int(choice)
+	}
+	return choices
+}
+
+// Parse the auto-vote rules, in the form keyword=choice;keyword=choice
+func parseVoteRules(value string) ([]voteRule, error) {
+	rules := []voteRule{}
+	for _, entry := range strings.Split(value, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		keyword, choice, found := strings.Cut(entry, "=")
+		keyword = strings.ToLower(strings.TrimSpace(keyword))
+		choice = strings.TrimSpace(choice)
+		if !found || keyword == "" || choice == "" {
+			return nil, fmt.Errorf("'%s' isn't in the form keyword=choice", entry)
+		}
+		rules = append(rules, voteRule{keyword: keyword, choice: choice})
+	}
+	return rules, nil
+}
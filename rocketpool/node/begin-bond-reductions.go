@@ -0,0 +1,310 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// The bond 16-ETH minipools are reduced to
+	reducedBondEth float64 = 8
+
+	// The Beacon balance a validator needs for its bond to be reduced, in gwei
+	bondReductionMinBalance uint64 = 32e9
+
+	alertRule_BondReductionScrubbed = "bond-reduction-scrubbed"
+	alertRule_BondReductionTimedOut = "bond-reduction-timed-out"
+)
+
+// Begin bond reductions task
+type beginBondReductions struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	rp             *rocketpool.RocketPool
+	alerts         *alerting.Manager
+	gasThreshold   float64
+	disabled       bool
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+	gasLimit       uint64
+}
+
+// Create begin bond reductions task
+func newBeginBondReductions(c *cli.Context, logger log.ColorLogger) (*beginBondReductions, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := services.GetAlertManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if automatic bond reductions are disabled
+	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+	disabled := !cfg.Smartnode.AutoBeginBondReduction.Value.(bool)
+	if !disabled && gasThreshold == 0 {
+		logger.Println("Automatic tx gas threshold is 0, disabling automatic bond reductions.")
+		disabled = true
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested priority fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &beginBondReductions{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		rp:             rp,
+		alerts:         alerts,
+		gasThreshold:   gasThreshold,
+		disabled:       disabled,
+		maxFee:         maxFee,
+		maxPriorityFee: priorityFee,
+		gasLimit:       0,
+	}, nil
+
+}
+
+// Begin reducing the bonds of eligible minipools
+func (t *beginBondReductions) run(state *state.NetworkState) error {
+
+	// Check if automatic bond reductions are disabled
+	if t.disabled {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking for minipool bonds that can be reduced...")
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	nd, exists := state.NodeDetailsByAddress[nodeAccount.Address]
+	if !exists || !nd.Exists {
+		return nil
+	}
+
+	// Check if bond reduction is enabled
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+	bondReductionEnabled, err := protocol.GetBondReductionEnabled(t.rp, opts)
+	if err != nil {
+		return fmt.Errorf("error checking if bond reduction is enabled: %w", err)
+	}
+	if !bondReductionEnabled {
+		return nil
+	}
+
+	// Get the time of the latest block
+	latestEth1Block, err := t.rp.Client.HeaderByNumber(context.Background(), opts.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("can't get the latest block time: %w", err)
+	}
+	latestBlockTime := time.Unix(int64(latestEth1Block.Time), 0)
+
+	// Get the minipools to begin reducing, and alert on the reductions that didn't go through
+	minipools, scrubbed, timedOut := t.getEligibleMinipools(state, nd, latestBlockTime)
+	if err := t.alerts.Update(alertRule_BondReductionScrubbed, scrubbed); err != nil {
+		t.log.Printlnf("Could not send the scrubbed bond reduction alerts: %s", err.Error())
+	}
+	if err := t.alerts.Update(alertRule_BondReductionTimedOut, timedOut); err != nil {
+		t.log.Printlnf("Could not send the timed out bond reduction alerts: %s", err.Error())
+	}
+	if len(minipools) == 0 {
+		return nil
+	}
+
+	// Log
+	t.log.Printlnf("%d minipool(s) are eligible to begin bond reduction...", len(minipools))
+
+	// Begin the reductions
+	for _, mpd := range minipools {
+		if _, err := t.beginBondReduction(mpd, state.NetworkDetails.BondReductionWindowStart); err != nil {
+			t.log.Println(fmt.Errorf("could not begin bond reduction for minipool %s: %w", mpd.MinipoolAddress.Hex(), err))
+			return err
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the node's minipools whose bonds can be reduced, as long as the node has enough RPL staked for the ETH they'll
+// borrow, along with alerts for the reductions that were scrubbed or timed out
+func (t *beginBondReductions) getEligibleMinipools(state *state.NetworkState, nd *rpstate.NativeNodeDetails, latestBlockTime time.Time) ([]*rpstate.NativeMinipoolDetails, []alerting.Alert, []alerting.Alert) {
+
+	windowStart := state.NetworkDetails.BondReductionWindowStart
+	windowLength := state.NetworkDetails.BondReductionWindowLength
+	newBond := eth.EthToWei(reducedBondEth)
+	matchRequest := big.NewInt(0).Sub(eth.EthToWei(16), newBond)
+	ethMatched := big.NewInt(0).Set(nd.EthMatched)
+
+	eligible := []*rpstate.NativeMinipoolDetails{}
+	scrubbed := []alerting.Alert{}
+	timedOut := []alerting.Alert{}
+	for _, mpd := range state.MinipoolDetailsByNode[nd.NodeAddress] {
+		if eth.WeiToEth(mpd.NodeDepositBalance) != 16 || mpd.Status != types.Staking || mpd.Version < 3 {
+			continue
+		}
+
+		// Skip minipools with a reduction in progress, and leave the ones the Oracle DAO scrubbed to the operator
+		if mpd.ReduceBondTime.Sign() > 0 {
+			reduceBondTime := time.Unix(mpd.ReduceBondTime.Int64(), 0)
+			if mpd.ReduceBondCancelled {
+				scrubbed = append(scrubbed, alerting.Alert{
+					Key:      mpd.MinipoolAddress.Hex(),
+					Severity: alerting.Severity_Warning,
+					Summary:  fmt.Sprintf("The Oracle DAO scrubbed the bond reduction of minipool %s that began at %s. It won't be reduced automatically again; please check its validator before trying again manually.", mpd.MinipoolAddress.Hex(), reduceBondTime.Format(time.RFC822)),
+				})
+				continue
+			}
+			if latestBlockTime.Sub(reduceBondTime) < windowStart+windowLength {
+				continue
+			}
+			timedOut = append(timedOut, alerting.Alert{
+				Key:      mpd.MinipoolAddress.Hex(),
+				Severity: alerting.Severity_Warning,
+				Summary:  fmt.Sprintf("The bond reduction of minipool %s that began at %s timed out before it was completed. It will be started again automatically if it's still eligible.", mpd.MinipoolAddress.Hex(), reduceBondTime.Format(time.RFC822)),
+			})
+		}
+
+		// The validator has to be active with a full balance
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || validator.Status != beacon.ValidatorState_ActiveOngoing || validator.Balance < bondReductionMinBalance {
+			continue
+		}
+
+		// The node has to have enough RPL staked to borrow the extra ETH
+		ethMatched.Add(ethMatched, matchRequest)
+		if ethMatched.Cmp(nd.EthMatchedLimit) > 0 {
+			t.log.Printlnf("Minipool %s is eligible for bond reduction, but your node doesn't have enough RPL staked to borrow another %.0f ETH.", mpd.MinipoolAddress.Hex(), eth.WeiToEth(matchRequest))
+			break
+		}
+		eligible = append(eligible, mpd)
+	}
+
+	return eligible, scrubbed, timedOut
+
+}
+
+// Begin reducing a minipool's bond
+func (t *beginBondReductions) beginBondReduction(mpd *rpstate.NativeMinipoolDetails, windowStart time.Duration) (bool, error) {
+
+	// Log
+	t.log.Printlnf("Beginning bond reduction for minipool %s...", mpd.MinipoolAddress.Hex())
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return false, err
+	}
+
+	// Get the gas limit
+	newBond := eth.EthToWei(reducedBondEth)
+	gasInfo, err := minipool.EstimateBeginReduceBondAmountGas(t.rp, mpd.MinipoolAddress, newBond, opts)
+	if err != nil {
+		return false, fmt.Errorf("could not estimate the gas required to begin bond reduction: %w", err)
+	}
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Print the gas info
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+		return false, nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gas.Uint64()
+
+	// Begin the reduction
+	hash, err := minipool.BeginReduceBondAmount(t.rp, mpd.MinipoolAddress, newBond, opts)
+	if err != nil {
+		return false, err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	if err != nil {
+		return false, err
+	}
+
+	// Log
+	t.log.Printlnf("Successfully began bond reduction for minipool %s; it can be completed in %s.", mpd.MinipoolAddress.Hex(), windowStart)
+	if err := t.alerts.Notify("bond-reduction-begun", fmt.Sprintf("Began reducing the bond of minipool %s to %.0f ETH. It will be completed automatically in %s unless the Oracle DAO scrubs it.", mpd.MinipoolAddress.Hex(), reducedBondEth, windowStart)); err != nil {
+		t.log.Printlnf("Could not send the bond reduction notification: %s", err.Error())
+	}
+
+	// Return
+	return true, nil
+
+}
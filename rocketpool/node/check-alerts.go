@@ -0,0 +1,378 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/sys"
+)
+
+// The alert rules
+const (
+	alertRule_ValidatorOffline = "validator-offline"
+	alertRule_LowCollateral    = "low-collateral"
+	alertRule_LowWalletBalance = "low-wallet-balance"
+	alertRule_ClientOutOfSync  = "client-out-of-sync"
+	alertRule_LowDiskSpace     = "low-disk-space"
+	alertRule_BalanceDrop      = "balance-drop"
+	alertRule_Slashed          = "validator-slashed"
+	alertRule_MinipoolPenalty  = "minipool-penalty"
+)
+
+// The last time a validator's balance went up
+type validatorProgress struct {
+	epoch   uint64
+	balance uint64
+}
+
+// A validator's highest balance since its last withdrawal
+type validatorPeak struct {
+	epoch   uint64
+	balance uint64
+	latest  uint64
+}
+
+// Check alerts task
+type checkAlerts struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	w           *wallet.Wallet
+	ec          *services.ExecutionClientManager
+	bc          *services.BeaconClientManager
+	stateLocker *collectors.StateLocker
+	alerts      *alerting.Manager
+
+	// Settings
+	enabled            bool
+	offlineEpochs      uint64
+	minCollateralRatio float64
+	minWalletBalance   float64
+	minFreeDiskSpace   float64
+	balanceDropGwei    uint64
+
+	// What was seen on previous runs
+	lastStateSlot     uint64
+	validatorProgress map[types.ValidatorPubkey]validatorProgress
+	validatorPeaks    map[types.ValidatorPubkey]validatorPeak
+}
+
+// Create check alerts task
+func newCheckAlerts(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker) (*checkAlerts, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := services.GetAlertManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkAlerts{
+		c:                  c,
+		log:                logger,
+		cfg:                cfg,
+		w:                  w,
+		ec:                 ec,
+		bc:                 bc,
+		stateLocker:        stateLocker,
+		alerts:             alerts,
+		enabled:            cfg.Smartnode.EnableAlerts.Value.(bool),
+		offlineEpochs:      cfg.Smartnode.AlertValidatorOfflineEpochs.Value.(uint64),
+		minCollateralRatio: cfg.Smartnode.AlertMinCollateralRatio.Value.(float64) / 100,
+		minWalletBalance:   cfg.Smartnode.AlertMinWalletBalance.Value.(float64),
+		minFreeDiskSpace:   cfg.Smartnode.AlertMinFreeDiskSpace.Value.(float64),
+		balanceDropGwei:    uint64(cfg.Smartnode.AlertBalanceDrop.Value.(float64) * 1e9),
+		validatorProgress:  map[types.ValidatorPubkey]validatorProgress{},
+		validatorPeaks:     map[types.ValidatorPubkey]validatorPeak{},
+	}, nil
+
+}
+
+// Check every alert rule; the clients and the disk are checked on every run, and the rules that use the network
+// state are checked whenever it has been updated
+func (t *checkAlerts) run() error {
+
+	if !t.enabled {
+		return nil
+	}
+
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	update := func(rule string, alerts []alerting.Alert) {
+		if err := t.alerts.Update(rule, alerts); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	update(alertRule_ClientOutOfSync, t.checkClients())
+	diskAlerts, err := t.checkDiskSpace()
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		update(alertRule_LowDiskSpace, diskAlerts)
+	}
+
+	state := t.stateLocker.GetState()
+	if state != nil && state.BeaconSlotNumber != t.lastStateSlot {
+		t.lastStateSlot = state.BeaconSlotNumber
+		update(alertRule_ValidatorOffline, t.checkValidators(state, nodeAccount.Address))
+		update(alertRule_BalanceDrop, t.checkBalanceDrops(state, nodeAccount.Address))
+		update(alertRule_Slashed, t.checkSlashings(state, nodeAccount.Address))
+		update(alertRule_MinipoolPenalty, t.checkPenalties(state, nodeAccount.Address))
+		nd, exists := state.NodeDetailsByAddress[nodeAccount.Address]
+		if exists && nd != nil {
+			update(alertRule_LowCollateral, t.checkCollateral(state, nd.RplStake, nd.EthMatched))
+			update(alertRule_LowWalletBalance, t.checkWalletBalance(nd.BalanceETH))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("error checking alerts: %w", errs[0])
+	}
+	return nil
+
+}
+
+// Check that at least one Execution client and one Beacon client are synced
+func (t *checkAlerts) checkClients() []alerting.Alert {
+	alerts := []alerting.Alert{}
+	if !isAnyClientSynced(t.ec.CheckStatus(t.cfg)) {
+		alerts = append(alerts, alerting.Alert{
+			Key:      "execution",
+			Severity: alerting.Severity_Critical,
+			Summary:  "None of your Execution clients are synced, so your node can't perform its duties.",
+		})
+	}
+	if !isAnyClientSynced(t.bc.CheckStatus()) {
+		alerts = append(alerts, alerting.Alert{
+			Key:      "beacon",
+			Severity: alerting.Severity_Critical,
+			Summary:  "None of your Beacon clients are synced, so your validators can't attest or propose blocks.",
+		})
+	}
+	return alerts
+}
+
+// Check the free space on the disk holding the data folder
+func (t *checkAlerts) checkDiskSpace() ([]alerting.Alert, error) {
+	if t.minFreeDiskSpace == 0 {
+		return nil, nil
+	}
+	free, total, err := sys.GetDiskSpace(t.cfg.Smartnode.GetDaemonDataPath())
+	if err != nil {
+		return nil, err
+	}
+	if total == 0 {
+		return nil, nil
+	}
+	freePercent := float64(free) / float64(total) * 100
+	if freePercent >= t.minFreeDiskSpace {
+		return nil, nil
+	}
+	return []alerting.Alert{{
+		Severity: alerting.Severity_Warning,
+		Summary:  fmt.Sprintf("The disk holding your data folder only has %.1f%% of its space free (%.1f GB).", freePercent, float64(free)/1e9),
+	}}, nil
+}
+
+// Check for active validators whose balance hasn't gone up in the configured number of epochs
+func (t *checkAlerts) checkValidators(state *state.NetworkState, nodeAddress common.Address) []alerting.Alert {
+	if t.offlineEpochs == 0 {
+		return nil
+	}
+	epoch := state.BeaconSlotNumber / state.BeaconConfig.SlotsPerEpoch
+	alerts := []alerting.Alert{}
+	seen := map[types.ValidatorPubkey]bool{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !validator.Exists || validator.Status != beacon.ValidatorState_ActiveOngoing {
+			continue
+		}
+		seen[mpd.Pubkey] = true
+
+		// A sweep lowers the balance, so it starts the count over as well
+		progress, exists := t.validatorProgress[mpd.Pubkey]
+		wasSwept := progress.balance > sweptBalanceGwei+sweepToleranceGwei && validator.Balance < sweptBalanceGwei+sweepToleranceGwei
+		if !exists || validator.Balance > progress.balance || wasSwept {
+			t.validatorProgress[mpd.Pubkey] = validatorProgress{epoch: epoch, balance: validator.Balance}
+			continue
+		}
+		progress.balance = validator.Balance
+		t.validatorProgress[mpd.Pubkey] = progress
+		if epoch-progress.epoch >= t.offlineEpochs {
+			alerts = append(alerts, alerting.Alert{
+				Key:      mpd.MinipoolAddress.Hex(),
+				Severity: alerting.Severity_Critical,
+				Summary:  fmt.Sprintf("The validator for minipool %s (index %s) hasn't earned anything in %d epochs; it's probably offline.", mpd.MinipoolAddress.Hex(), validator.Index, epoch-progress.epoch),
+			})
+		}
+	}
+	for pubkey := range t.validatorProgress {
+		if !seen[pubkey] {
+			delete(t.validatorProgress, pubkey)
+		}
+	}
+	return alerts
+}
+
+// Check for active validators whose balance has fallen too far below its highest point since the last withdrawal
+func (t *checkAlerts) checkBalanceDrops(state *state.NetworkState, nodeAddress common.Address) []alerting.Alert {
+	if t.balanceDropGwei == 0 {
+		return nil
+	}
+	epoch := state.BeaconSlotNumber / state.BeaconConfig.SlotsPerEpoch
+	alerts := []alerting.Alert{}
+	seen := map[types.ValidatorPubkey]bool{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !validator.Exists || validator.ActivationEpoch > epoch || validator.ExitEpoch <= epoch {
+			continue
+		}
+		seen[mpd.Pubkey] = true
+
+		// A sweep lowers the balance without anything going wrong, so it starts a new peak
+		peak, exists := t.validatorPeaks[mpd.Pubkey]
+		wasSwept := peak.latest > sweptBalanceGwei+sweepToleranceGwei && validator.Balance < sweptBalanceGwei+sweepToleranceGwei
+		if !exists || validator.Balance >= peak.balance || wasSwept {
+			t.validatorPeaks[mpd.Pubkey] = validatorPeak{epoch: epoch, balance: validator.Balance, latest: validator.Balance}
+			continue
+		}
+		peak.latest = validator.Balance
+		t.validatorPeaks[mpd.Pubkey] = peak
+
+		drop := peak.balance - validator.Balance
+		if drop >= t.balanceDropGwei {
+			alerts = append(alerts, alerting.Alert{
+				Key:      mpd.MinipoolAddress.Hex(),
+				Severity: alerting.Severity_Critical,
+				Summary:  fmt.Sprintf("The validator for minipool %s (index %s) has lost %.6f ETH since epoch %d; it's being penalized.", mpd.MinipoolAddress.Hex(), validator.Index, float64(drop)/1e9, peak.epoch),
+			})
+		}
+	}
+	for pubkey := range t.validatorPeaks {
+		if !seen[pubkey] {
+			delete(t.validatorPeaks, pubkey)
+		}
+	}
+	return alerts
+}
+
+// Check for validators that have been slashed
+func (t *checkAlerts) checkSlashings(state *state.NetworkState, nodeAddress common.Address) []alerting.Alert {
+	alerts := []alerting.Alert{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !validator.Exists || !validator.Slashed {
+			continue
+		}
+		alerts = append(alerts, alerting.Alert{
+			Key:      mpd.MinipoolAddress.Hex(),
+			Severity: alerting.Severity_Critical,
+			Summary:  fmt.Sprintf("The validator for minipool %s (index %s) has been slashed and will be exited.", mpd.MinipoolAddress.Hex(), validator.Index),
+		})
+	}
+	return alerts
+}
+
+// Check for minipools with an on-chain penalty, which is taken from the node's share when they're distributed
+func (t *checkAlerts) checkPenalties(state *state.NetworkState, nodeAddress common.Address) []alerting.Alert {
+	alerts := []alerting.Alert{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
+		if mpd.PenaltyRate == nil || mpd.PenaltyRate.Sign() == 0 {
+			continue
+		}
+		alerts = append(alerts, alerting.Alert{
+			Key:      mpd.MinipoolAddress.Hex(),
+			Severity: alerting.Severity_Critical,
+			Summary:  fmt.Sprintf("Minipool %s has been penalized by the Oracle DAO; %.2f%% of its balance will be taken from your share when it's distributed.", mpd.MinipoolAddress.Hex(), eth.WeiToEth(mpd.PenaltyRate)*100),
+		})
+	}
+	return alerts
+}
+
+// Check the value of the node's RPL stake against the ETH it has borrowed
+func (t *checkAlerts) checkCollateral(state *state.NetworkState, rplStake *big.Int, borrowedEth *big.Int) []alerting.Alert {
+	if t.minCollateralRatio == 0 || borrowedEth == nil || borrowedEth.Sign() == 0 {
+		return nil
+	}
+	ratio := eth.WeiToEth(state.NetworkDetails.RplPrice) * eth.WeiToEth(rplStake) / eth.WeiToEth(borrowedEth)
+	if ratio >= t.minCollateralRatio {
+		return nil
+	}
+	severity := alerting.Severity_Warning
+	minimumRatio := eth.WeiToEth(state.NetworkDetails.MinCollateralFraction)
+	if ratio < minimumRatio {
+		severity = alerting.Severity_Critical
+	}
+	return []alerting.Alert{{
+		Severity: severity,
+		Summary:  fmt.Sprintf("Your node's RPL collateral is %.2f%% of its borrowed ETH; it stops earning RPL rewards below %.0f%%.", ratio*100, minimumRatio*100),
+	}}
+}
+
+// Check that the node wallet has enough ETH for transactions
+func (t *checkAlerts) checkWalletBalance(balance *big.Int) []alerting.Alert {
+	if t.minWalletBalance == 0 || balance == nil {
+		return nil
+	}
+	balanceEth := eth.WeiToEth(balance)
+	if balanceEth >= t.minWalletBalance {
+		return nil
+	}
+	return []alerting.Alert{{
+		Severity: alerting.Severity_Warning,
+		Summary:  fmt.Sprintf("Your node wallet only has %.6f ETH left, which may not be enough to pay for its transactions.", balanceEth),
+	}}
+}
+
+// Check if the primary or any of the fallback clients are working and synced
+func isAnyClientSynced(status *api.ClientManagerStatus) bool {
+	if status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced {
+		return true
+	}
+	if !status.FallbackEnabled {
+		return false
+	}
+	if status.FallbackClientStatus.IsWorking && status.FallbackClientStatus.IsSynced {
+		return true
+	}
+	for _, clientStatus := range status.AdditionalFallbackStatuses {
+		if clientStatus.IsWorking && clientStatus.IsSynced {
+			return true
+		}
+	}
+	return false
+}
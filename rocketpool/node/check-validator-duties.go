@@ -0,0 +1,327 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/store"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How many epochs behind the head the duties are checked; attestations can be included until the end of the
+	// epoch after theirs, so that one has to be over
+	dutiesCheckDelay uint64 = 2
+
+	// The most epochs checked in one run, so a daemon that fell behind doesn't spend forever catching up
+	maxDutiesEpochsPerRun uint64 = 8
+
+	alertRule_MissedAttestations = "missed-attestations"
+	alertRule_MissedProposal     = "missed-proposal"
+)
+
+// One of the node's validators during the epoch being checked
+type dutyValidator struct {
+	minipoolAddress common.Address
+	attested        bool
+}
+
+// A block, or a missed slot, that was retrieved from the Beacon client
+type cachedBlock struct {
+	block beacon.BeaconBlock
+	found bool
+}
+
+// Check validator duties task
+type checkValidatorDuties struct {
+	c       *cli.Context
+	log     log.ColorLogger
+	w       *wallet.Wallet
+	bc      *services.BeaconClientManager
+	alerts  *alerting.Manager
+	db      *store.Store
+	record  *collectors.DutyRecord
+	enabled bool
+
+	// What was seen on previous runs
+	lastCheckedEpoch uint64
+	started          bool
+	blocks           map[uint64]cachedBlock
+	proposerDuties   map[uint64]map[string]uint64
+	missedStreaks    map[string]uint64
+}
+
+// Create check validator duties task
+func newCheckValidatorDuties(c *cli.Context, logger log.ColorLogger, record *collectors.DutyRecord) (*checkValidatorDuties, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := services.GetAlertManager(c)
+	if err != nil {
+		return nil, err
+	}
+	db, err := services.GetStore(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkValidatorDuties{
+		c:              c,
+		log:            logger,
+		w:              w,
+		bc:             bc,
+		alerts:         alerts,
+		db:             db,
+		record:         record,
+		enabled:        cfg.Smartnode.EnableAlerts.Value.(bool),
+		blocks:         map[uint64]cachedBlock{},
+		proposerDuties: map[uint64]map[string]uint64{},
+		missedStreaks:  map[string]uint64{},
+	}, nil
+
+}
+
+// Check the attestations and proposals of the node's validators in the epochs that are old enough
+func (t *checkValidatorDuties) run(state *state.NetworkState) error {
+
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	slotsPerEpoch := state.BeaconConfig.SlotsPerEpoch
+	currentEpoch := state.BeaconSlotNumber / slotsPerEpoch
+
+	// Beacon clients generally only provide proposer duties for the current epoch, so save them for when it's checked
+	currentValidators := getDutyValidators(state, nodeAccount.Address, currentEpoch)
+	if len(currentValidators) > 0 {
+		duties, err := t.bc.GetValidatorProposerDuties(getDutyIndices(currentValidators), currentEpoch)
+		if err != nil {
+			return fmt.Errorf("error getting proposer duties for epoch %d: %w", currentEpoch, err)
+		}
+		t.proposerDuties[currentEpoch] = duties
+	}
+
+	if currentEpoch < dutiesCheckDelay {
+		return nil
+	}
+	targetEpoch := currentEpoch - dutiesCheckDelay
+	firstEpoch := t.lastCheckedEpoch + 1
+	if !t.started {
+		firstEpoch = targetEpoch
+	}
+	if firstEpoch+maxDutiesEpochsPerRun <= targetEpoch {
+		firstEpoch = targetEpoch - maxDutiesEpochsPerRun + 1
+	}
+
+	for epoch := firstEpoch; epoch <= targetEpoch; epoch++ {
+		if err := t.checkEpoch(state, nodeAccount.Address, epoch); err != nil {
+			return err
+		}
+		t.lastCheckedEpoch = epoch
+		t.started = true
+	}
+
+	// Forget the blocks and duties of the epochs that have been checked
+	for slot := range t.blocks {
+		if slot < (t.lastCheckedEpoch+1)*slotsPerEpoch {
+			delete(t.blocks, slot)
+		}
+	}
+	for epoch := range t.proposerDuties {
+		if epoch <= t.lastCheckedEpoch {
+			delete(t.proposerDuties, epoch)
+		}
+	}
+	return nil
+
+}
+
+// Add an epoch's duties to the metrics and the database
+func (t *checkValidatorDuties) addEpoch(nodeAddress common.Address, epoch api.NodeDutyEpochRecord) {
+	t.record.AddEpoch(epoch.Epoch, epoch.Validators, epoch.MissedAttestations, epoch.Proposals, epoch.MissedProposals)
+	if err := t.db.AddDutyEpoch(nodeAddress, epoch); err != nil {
+		t.log.Printlnf("Could not record the duties for epoch %d: %s", epoch.Epoch, err.Error())
+	}
+}
+
+// Check the duties of the node's validators in an epoch
+func (t *checkValidatorDuties) checkEpoch(state *state.NetworkState, nodeAddress common.Address, epoch uint64) error {
+
+	validators := getDutyValidators(state, nodeAddress, epoch)
+	if len(validators) == 0 {
+		t.addEpoch(nodeAddress, api.NodeDutyEpochRecord{Epoch: epoch})
+		return nil
+	}
+	slotsPerEpoch := state.BeaconConfig.SlotsPerEpoch
+
+	// Find the committee positions of the node's validators
+	committees, err := t.bc.GetCommitteesForEpoch(&epoch)
+	if err != nil {
+		return fmt.Errorf("error getting committees for epoch %d: %w", epoch, err)
+	}
+	positions := map[uint64]map[uint64]map[int]string{}
+	for i := 0; i < committees.Count(); i++ {
+		for position, index := range committees.Validators(i) {
+			if _, exists := validators[index]; !exists {
+				continue
+			}
+			slot := committees.Slot(i)
+			if positions[slot] == nil {
+				positions[slot] = map[uint64]map[int]string{}
+			}
+			committeeIndex := committees.Index(i)
+			if positions[slot][committeeIndex] == nil {
+				positions[slot][committeeIndex] = map[int]string{}
+			}
+			positions[slot][committeeIndex][position] = index
+		}
+	}
+	committees.Release()
+
+	// Look for the attestations in every block they could have been included in, and count the proposals
+	proposed := map[string]uint64{}
+	for slot := epoch * slotsPerEpoch; slot < (epoch+2)*slotsPerEpoch; slot++ {
+		block, err := t.getBlock(slot)
+		if err != nil {
+			return err
+		}
+		if !block.found {
+			continue
+		}
+		if slot < (epoch+1)*slotsPerEpoch {
+			if _, exists := validators[block.block.ProposerIndex]; exists {
+				proposed[block.block.ProposerIndex]++
+			}
+		}
+		for _, attestation := range block.block.Attestations {
+			for position, index := range positions[attestation.SlotIndex][attestation.CommitteeIndex] {
+				if attestation.AggregationBits.BitAt(uint64(position)) {
+					validators[index].attested = true
+				}
+			}
+		}
+	}
+
+	// Tally the attestations; a validator's alert lasts until it attests again
+	attestationAlerts := []alerting.Alert{}
+	missedAttestations := uint64(0)
+	for index, validator := range validators {
+		if validator.attested {
+			delete(t.missedStreaks, index)
+			continue
+		}
+		missedAttestations++
+		t.missedStreaks[index]++
+		attestationAlerts = append(attestationAlerts, alerting.Alert{
+			Key:      validator.minipoolAddress.Hex(),
+			Severity: alerting.Severity_Warning,
+			Summary:  fmt.Sprintf("The validator for minipool %s (index %s) missed its attestation in epoch %d (%d in a row).", validator.minipoolAddress.Hex(), index, epoch, t.missedStreaks[index]),
+		})
+	}
+	for index := range t.missedStreaks {
+		if _, exists := validators[index]; !exists {
+			delete(t.missedStreaks, index)
+		}
+	}
+
+	// Tally the proposals, if the epoch's duties were seen while it was current
+	proposals := uint64(0)
+	missedProposals := uint64(0)
+	for index, count := range t.proposerDuties[epoch] {
+		validator, exists := validators[index]
+		if !exists || count == 0 {
+			continue
+		}
+		proposals += count
+		if proposed[index] >= count {
+			t.log.Printlnf("The validator for minipool %s (index %s) proposed a block in epoch %d.", validator.minipoolAddress.Hex(), index, epoch)
+			continue
+		}
+		missed := count - proposed[index]
+		missedProposals += missed
+		message := fmt.Sprintf("The validator for minipool %s (index %s) missed its block proposal in epoch %d.", validator.minipoolAddress.Hex(), index, epoch)
+		t.log.Println(message)
+		if t.enabled {
+			if err := t.alerts.Report(alertRule_MissedProposal, alerting.Severity_Critical, message); err != nil {
+				t.log.Printlnf("Could not send the missed proposal alert: %s", err.Error())
+			}
+		}
+	}
+
+	t.addEpoch(nodeAddress, api.NodeDutyEpochRecord{
+		Epoch:              epoch,
+		Validators:         uint64(len(validators)),
+		MissedAttestations: missedAttestations,
+		Proposals:          proposals,
+		MissedProposals:    missedProposals,
+	})
+	if missedAttestations > 0 {
+		t.log.Printlnf("%d of the node's %d validators missed their attestations in epoch %d.", missedAttestations, len(validators), epoch)
+	}
+	if t.enabled {
+		if err := t.alerts.Update(alertRule_MissedAttestations, attestationAlerts); err != nil {
+			t.log.Printlnf("Could not send the missed attestation alerts: %s", err.Error())
+		}
+	}
+	return nil
+
+}
+
+// Get a block from the cache, or from the Beacon client if it hasn't been retrieved yet
+func (t *checkValidatorDuties) getBlock(slot uint64) (cachedBlock, error) {
+	if block, exists := t.blocks[slot]; exists {
+		return block, nil
+	}
+	block, found, err := t.bc.GetBeaconBlock(fmt.Sprint(slot))
+	if err != nil {
+		return cachedBlock{}, fmt.Errorf("error getting block for slot %d: %w", slot, err)
+	}
+	cached := cachedBlock{block: block, found: found}
+	t.blocks[slot] = cached
+	return cached, nil
+}
+
+// Get the node's validators that were active in an epoch, by index
+func getDutyValidators(state *state.NetworkState, nodeAddress common.Address, epoch uint64) map[string]*dutyValidator {
+	validators := map[string]*dutyValidator{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !validator.Exists || validator.ActivationEpoch > epoch || validator.ExitEpoch <= epoch {
+			continue
+		}
+		validators[validator.Index] = &dutyValidator{minipoolAddress: mpd.MinipoolAddress}
+	}
+	return validators
+}
+
+// Get the indices of a set of validators
+func getDutyIndices(validators map[string]*dutyValidator) []string {
+	indices := make([]string, 0, len(validators))
+	for index := range validators {
+		indices = append(indices, index)
+	}
+	return indices
+}
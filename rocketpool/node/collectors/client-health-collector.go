@@ -0,0 +1,85 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/smartnode/shared/services"
+)
+
+// Represents the collector for the health of the Execution and Beacon clients
+type ClientHealthCollector struct {
+	// The fraction of recent calls to each client that failed
+	failureRate *prometheus.Desc
+
+	// The average latency of recent calls to each client
+	latency *prometheus.Desc
+
+	// The number of recent calls each client's health is measured over
+	requests *prometheus.Desc
+
+	// Whether each client's circuit breaker is open
+	circuitOpen *prometheus.Desc
+
+	// The Execution client manager
+	ec *services.ExecutionClientManager
+
+	// The Beacon client manager
+	bc *services.BeaconClientManager
+}
+
+// Create a new ClientHealthCollector instance
+func NewClientHealthCollector(ec *services.ExecutionClientManager, bc *services.BeaconClientManager) *ClientHealthCollector {
+	subsystem := "client_health"
+	labels := []string{"layer", "client"}
+	return &ClientHealthCollector{
+		failureRate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "failure_rate"),
+			"The fraction of recent calls to the client that failed",
+			labels, nil,
+		),
+		latency: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "latency_ms"),
+			"The average latency of recent calls to the client, in milliseconds",
+			labels, nil,
+		),
+		requests: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "requests"),
+			"The number of recent calls the client's health is measured over",
+			labels, nil,
+		),
+		circuitOpen: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "circuit_open"),
+			"1 if the client's circuit breaker is open and calls are being sent elsewhere, 0 otherwise",
+			labels, nil,
+		),
+		ec: ec,
+		bc: bc,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ClientHealthCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.failureRate
+	channel <- collector.latency
+	channel <- collector.requests
+	channel <- collector.circuitOpen
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ClientHealthCollector) Collect(channel chan<- prometheus.Metric) {
+	collector.collectReports(channel, "execution", collector.ec.GetClientHealth())
+	collector.collectReports(channel, "beacon", collector.bc.GetClientHealth())
+}
+
+// Send the metrics for a set of clients
+func (collector *ClientHealthCollector) collectReports(channel chan<- prometheus.Metric, layer string, reports []services.ClientHealthReport) {
+	for _, report := range reports {
+		circuitOpen := float64(0)
+		if report.Status.CircuitState == services.CircuitState_Open {
+			circuitOpen = 1
+		}
+		channel <- prometheus.MustNewConstMetric(
+			collector.failureRate, prometheus.GaugeValue, report.Status.FailureRate, layer, report.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.latency, prometheus.GaugeValue, report.Status.AverageLatencyMs, layer, report.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.requests, prometheus.GaugeValue, float64(report.Status.Requests), layer, report.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.circuitOpen, prometheus.GaugeValue, circuitOpen, layer, report.Name)
+	}
+}
@@ -0,0 +1,118 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The attestations and proposals of the node's validators that have been checked since the daemon started
+type DutyCounts struct {
+	LastCheckedEpoch   uint64
+	Attestations       uint64
+	MissedAttestations uint64
+	Proposals          uint64
+	MissedProposals    uint64
+}
+
+// Records the node's duties as the daemon checks them, so the collector can report them
+type DutyRecord struct {
+	counts DutyCounts
+	lock   sync.Mutex
+}
+
+// Create a new, empty duty record
+func NewDutyRecord() *DutyRecord {
+	return &DutyRecord{}
+}
+
+// Add the duties of a checked epoch to the record
+func (r *DutyRecord) AddEpoch(epoch uint64, attestations uint64, missedAttestations uint64, proposals uint64, missedProposals uint64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.counts.LastCheckedEpoch = epoch
+	r.counts.Attestations += attestations
+	r.counts.MissedAttestations += missedAttestations
+	r.counts.Proposals += proposals
+	r.counts.MissedProposals += missedProposals
+}
+
+// Get the current counts
+func (r *DutyRecord) GetCounts() DutyCounts {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.counts
+}
+
+// Represents the collector for the duties of the node's validators
+type DutiesCollector struct {
+	// The number of attestations the node's validators were assigned
+	attestations *prometheus.Desc
+
+	// The number of those attestations that weren't included on chain
+	missedAttestations *prometheus.Desc
+
+	// The number of blocks the node's validators were assigned to propose
+	proposals *prometheus.Desc
+
+	// The number of those blocks that weren't proposed
+	missedProposals *prometheus.Desc
+
+	// The latest epoch whose duties have been checked
+	lastCheckedEpoch *prometheus.Desc
+
+	// The record of the node's duties
+	record *DutyRecord
+}
+
+// Create a new DutiesCollector instance
+func NewDutiesCollector(record *DutyRecord) *DutiesCollector {
+	subsystem := "duties"
+	return &DutiesCollector{
+		attestations: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "attestations_total"),
+			"The number of attestations the node's validators were assigned since the daemon started",
+			nil, nil,
+		),
+		missedAttestations: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "missed_attestations_total"),
+			"The number of attestations by the node's validators that weren't included on chain since the daemon started",
+			nil, nil,
+		),
+		proposals: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "proposals_total"),
+			"The number of blocks the node's validators were assigned to propose since the daemon started",
+			nil, nil,
+		),
+		missedProposals: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "missed_proposals_total"),
+			"The number of blocks the node's validators were assigned but didn't propose since the daemon started",
+			nil, nil,
+		),
+		lastCheckedEpoch: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_checked_epoch"),
+			"The latest epoch whose duties have been checked",
+			nil, nil,
+		),
+		record: record,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *DutiesCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.attestations
+	channel <- collector.missedAttestations
+	channel <- collector.proposals
+	channel <- collector.missedProposals
+	channel <- collector.lastCheckedEpoch
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *DutiesCollector) Collect(channel chan<- prometheus.Metric) {
+	counts := collector.record.GetCounts()
+	channel <- prometheus.MustNewConstMetric(
+		collector.attestations, prometheus.CounterValue, float64(counts.Attestations))
+	channel <- prometheus.MustNewConstMetric(
+		collector.missedAttestations, prometheus.CounterValue, float64(counts.MissedAttestations))
+	channel <- prometheus.MustNewConstMetric(
+		collector.proposals, prometheus.CounterValue, float64(counts.Proposals))
+	channel <- prometheus.MustNewConstMetric(
+		collector.missedProposals, prometheus.CounterValue, float64(counts.MissedProposals))
+	channel <- prometheus.MustNewConstMetric(
+		collector.lastCheckedEpoch, prometheus.GaugeValue, float64(counts.LastCheckedEpoch))
+}
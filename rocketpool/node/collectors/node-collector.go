@@ -13,6 +13,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
@@ -85,6 +86,9 @@ type NodeCollector struct {
 	// The beacon client
 	bc beacon.Client
 
+	// The watcher for new Execution blocks
+	headWatcher *services.ExecutionHeadWatcher
+
 	// The node's address
 	nodeAddress common.Address
 
@@ -114,7 +118,7 @@ type NodeCollector struct {
 }
 
 // Create a new NodeCollector instance
-func NewNodeCollector(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *NodeCollector {
+func NewNodeCollector(rp *rocketpool.RocketPool, bc beacon.Client, headWatcher *services.ExecutionHeadWatcher, nodeAddress common.Address, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *NodeCollector {
 
 	// Get the event log interval
 	eventLogInterval, err := cfg.GetEventLogInterval()
@@ -203,6 +207,7 @@ func NewNodeCollector(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress c
 		),
 		rp:               rp,
 		bc:               bc,
+		headWatcher:      headWatcher,
 		nodeAddress:      nodeAddress,
 		eventLogInterval: big.NewInt(int64(eventLogInterval)),
 		handledIntervals: map[uint64]bool{},
@@ -324,10 +329,13 @@ func (collector *NodeCollector) Collect(channel chan<- prometheus.Metric) {
 			}
 		}
 
-		// Get the block for the next rewards checkpoint
-		header, err := collector.rp.Client.HeaderByNumber(context.Background(), nil)
-		if err != nil {
-			return fmt.Errorf("Error getting latest block header: %w", err)
+		// Get the block for the next rewards checkpoint, using the head watcher's latest block if it has one
+		header := collector.headWatcher.LatestHeader()
+		if header == nil {
+			header, err = collector.rp.Client.HeaderByNumber(context.Background(), nil)
+			if err != nil {
+				return fmt.Errorf("Error getting latest block header: %w", err)
+			}
 		}
 
 		collector.cumulativeRewards += eth.WeiToEth(newRewards)
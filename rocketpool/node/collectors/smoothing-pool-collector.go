@@ -2,11 +2,15 @@ package collectors
 
 import (
 	"fmt"
+	"math"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 )
 
 // Represents the collector for Smoothing Pool metrics
@@ -14,12 +18,33 @@ type SmoothingPoolCollector struct {
 	// the ETH balance on the smoothing pool
 	ethBalanceOnSmoothingPool *prometheus.Desc
 
+	// The number of nodes opted into the smoothing pool
+	optedInNodes *prometheus.Desc
+
+	// Whether the node is opted into the smoothing pool
+	nodeOptedIn *prometheus.Desc
+
+	// The time the node last opted in or out of the smoothing pool
+	registrationChanged *prometheus.Desc
+
+	// How long until the node can opt in or out again
+	cooldownRemaining *prometheus.Desc
+
+	// The node's estimated share of the smoothing pool at the end of the interval
+	estimatedNodeShare *prometheus.Desc
+
+	// The smoothing pool's estimated balance at the end of the interval
+	projectedBalance *prometheus.Desc
+
 	// The Rocket Pool contract manager
 	rp *rocketpool.RocketPool
 
 	// The EC client
 	ec *services.ExecutionClientManager
 
+	// The node's address
+	nodeAddress common.Address
+
 	// The thread-safe locker for the network state
 	stateLocker *StateLocker
 
@@ -28,15 +53,40 @@ type SmoothingPoolCollector struct {
 }
 
 // Create a new SmoothingPoolCollector instance
-func NewSmoothingPoolCollector(rp *rocketpool.RocketPool, ec *services.ExecutionClientManager, stateLocker *StateLocker) *SmoothingPoolCollector {
+func NewSmoothingPoolCollector(rp *rocketpool.RocketPool, ec *services.ExecutionClientManager, nodeAddress common.Address, stateLocker *StateLocker) *SmoothingPoolCollector {
 	subsystem := "smoothing_pool"
 	return &SmoothingPoolCollector{
 		ethBalanceOnSmoothingPool: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "eth_balance"),
 			"The ETH balance on the smoothing pool",
 			nil, nil,
 		),
+		optedInNodes: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "opted_in_nodes"),
+			"The number of nodes opted into the smoothing pool",
+			nil, nil,
+		),
+		nodeOptedIn: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_opted_in"),
+			"Whether the node is opted into the smoothing pool (1) or not (0)",
+			nil, nil,
+		),
+		registrationChanged: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_registration_changed_timestamp"),
+			"The time the node last opted in or out of the smoothing pool, as a Unix timestamp",
+			nil, nil,
+		),
+		cooldownRemaining: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_cooldown_remaining_seconds"),
+			"How long until the node can opt in or out of the smoothing pool again",
+			nil, nil,
+		),
+		estimatedNodeShare: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_estimated_share"),
+			"The node's estimated share of the smoothing pool at the end of the current interval, in ETH",
+			nil, nil,
+		),
+		projectedBalance: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "projected_eth_balance"),
+			"The smoothing pool's ETH balance at the end of the current interval, extrapolated from the balance so far",
+			nil, nil,
+		),
 		rp:          rp,
 		ec:          ec,
+		nodeAddress: nodeAddress,
 		stateLocker: stateLocker,
 		logPrefix:   "SP Collector",
 	}
@@ -45,6 +95,12 @@ func NewSmoothingPoolCollector(rp *rocketpool.RocketPool, ec *services.Execution
 // Write metric descriptions to the Prometheus channel
 func (collector *SmoothingPoolCollector) Describe(channel chan<- *prometheus.Desc) {
 	channel <- collector.ethBalanceOnSmoothingPool
+	channel <- collector.optedInNodes
+	channel <- collector.nodeOptedIn
+	channel <- collector.registrationChanged
+	channel <- collector.cooldownRemaining
+	channel <- collector.estimatedNodeShare
+	channel <- collector.projectedBalance
 }
 
 // Collect the latest metric values and pass them to Prometheus
@@ -56,9 +112,62 @@ func (collector *SmoothingPoolCollector) Collect(channel chan<- prometheus.Metri
 	}
 
 	ethBalanceOnSmoothingPool := eth.WeiToEth(state.NetworkDetails.SmoothingPoolBalance)
+	optedInNodes := float64(0)
+	for _, nd := range state.NodeDetails {
+		if nd.SmoothingPoolRegistrationState {
+			optedInNodes++
+		}
+	}
 
 	channel <- prometheus.MustNewConstMetric(
 		collector.ethBalanceOnSmoothingPool, prometheus.GaugeValue, ethBalanceOnSmoothingPool)
+	channel <- prometheus.MustNewConstMetric(
+		collector.optedInNodes, prometheus.GaugeValue, optedInNodes)
+
+	// The rest only applies to registered nodes
+	nd, exists := state.NodeDetailsByAddress[collector.nodeAddress]
+	if !exists || !nd.Exists {
+		return
+	}
+
+	// Opting in or out is only allowed once per rewards interval
+	nodeOptedIn := float64(0)
+	if nd.SmoothingPoolRegistrationState {
+		nodeOptedIn = 1
+	}
+	registrationChanged := float64(0)
+	cooldownRemaining := float64(0)
+	if nd.SmoothingPoolRegistrationChanged != nil && nd.SmoothingPoolRegistrationChanged.Sign() > 0 {
+		changeTime := time.Unix(nd.SmoothingPoolRegistrationChanged.Int64(), 0)
+		registrationChanged = float64(changeTime.Unix())
+		genesisTime := time.Unix(int64(state.BeaconConfig.GenesisTime), 0)
+		stateTime := genesisTime.Add(time.Duration(state.BeaconSlotNumber*state.BeaconConfig.SecondsPerSlot) * time.Second)
+		cooldownRemaining = math.Max(changeTime.Add(state.NetworkDetails.IntervalDuration).Sub(stateTime).Seconds(), 0)
+	}
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.nodeOptedIn, prometheus.GaugeValue, nodeOptedIn)
+	channel <- prometheus.MustNewConstMetric(
+		collector.registrationChanged, prometheus.GaugeValue, registrationChanged)
+	channel <- prometheus.MustNewConstMetric(
+		collector.cooldownRemaining, prometheus.GaugeValue, cooldownRemaining)
+
+	// Estimate the node's share at the end of the interval; nodes that aren't opted in don't get one
+	if !nd.SmoothingPoolRegistrationState {
+		channel <- prometheus.MustNewConstMetric(
+			collector.estimatedNodeShare, prometheus.GaugeValue, 0)
+		return
+	}
+	projection, err := rprewards.ProjectNodeRewards(state, collector.nodeAddress)
+	if err != nil {
+		collector.logError(fmt.Errorf("error projecting smoothing pool rewards: %w", err))
+		return
+	}
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.estimatedNodeShare, prometheus.GaugeValue, eth.WeiToEth(projection.ProjectedSmoothingPoolEth))
+	channel <- prometheus.MustNewConstMetric(
+		collector.projectedBalance, prometheus.GaugeValue, eth.WeiToEth(projection.ProjectedSmoothingBalance))
 }
 
 // Log error messages
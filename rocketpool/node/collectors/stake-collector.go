@@ -0,0 +1,135 @@
+package collectors
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Represents the collector for the node's RPL stake metrics, which only use the network state so they're available
+// even when the node's rewards can't be calculated
+type StakeCollector struct {
+	// The effective amount of RPL staked on the node
+	effectiveStake *prometheus.Desc
+
+	// The node's share of the network's total effective RPL stake, which its RPL rewards are weighted by
+	nodeWeight *prometheus.Desc
+
+	// The value of the node's RPL stake as a fraction of its borrowed ETH
+	collateralRatio *prometheus.Desc
+
+	// The minimum amount of RPL the node needs to stake to earn RPL rewards
+	minimumStake *prometheus.Desc
+
+	// The maximum amount of RPL that will earn RPL rewards for the node
+	maximumStake *prometheus.Desc
+
+	// How much RPL the node has staked above the minimum (negative if it's below it)
+	distanceToMinimum *prometheus.Desc
+
+	// How much more RPL the node can stake before reaching the maximum (negative if it's above it)
+	distanceToMaximum *prometheus.Desc
+
+	// The node's address
+	nodeAddress common.Address
+
+	// The thread-safe locker for the network state
+	stateLocker *StateLocker
+}
+
+// Create a new StakeCollector instance
+func NewStakeCollector(nodeAddress common.Address, stateLocker *StateLocker) *StakeCollector {
+	subsystem := "stake"
+	return &StakeCollector{
+		effectiveStake: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "effective_rpl"),
+			"The effective amount of RPL staked on the node",
+			nil, nil,
+		),
+		nodeWeight: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_weight"),
+			"The node's share of the network's total effective RPL stake, which its RPL rewards are weighted by",
+			nil, nil,
+		),
+		collateralRatio: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "collateral_ratio"),
+			"The value of the node's RPL stake as a fraction of its borrowed ETH",
+			nil, nil,
+		),
+		minimumStake: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "minimum_rpl"),
+			"The minimum amount of RPL the node needs to stake to earn RPL rewards",
+			nil, nil,
+		),
+		maximumStake: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "maximum_rpl"),
+			"The maximum amount of RPL that will earn RPL rewards for the node",
+			nil, nil,
+		),
+		distanceToMinimum: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rpl_above_minimum"),
+			"How much RPL the node has staked above the minimum (negative if it's below it)",
+			nil, nil,
+		),
+		distanceToMaximum: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rpl_below_maximum"),
+			"How much more RPL the node can stake before reaching the maximum (negative if it's above it)",
+			nil, nil,
+		),
+		nodeAddress: nodeAddress,
+		stateLocker: stateLocker,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *StakeCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.effectiveStake
+	channel <- collector.nodeWeight
+	channel <- collector.collateralRatio
+	channel <- collector.minimumStake
+	channel <- collector.maximumStake
+	channel <- collector.distanceToMinimum
+	channel <- collector.distanceToMaximum
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *StakeCollector) Collect(channel chan<- prometheus.Metric) {
+	// Get the latest state
+	state := collector.stateLocker.GetState()
+	if state == nil {
+		return
+	}
+	nd, exists := state.NodeDetailsByAddress[collector.nodeAddress]
+	if !exists || nd == nil || !nd.Exists {
+		return
+	}
+
+	stake := eth.WeiToEth(nd.RplStake)
+	effectiveStake := eth.WeiToEth(nd.EffectiveRPLStake)
+	minimumStake := eth.WeiToEth(nd.MinimumRPLStake)
+	maximumStake := eth.WeiToEth(nd.MaximumRPLStake)
+
+	// The node's weight is only known once the network's total effective stake has been calculated
+	nodeWeight := float64(0)
+	totalEffectiveStake := collector.stateLocker.GetTotalEffectiveRPLStake()
+	if totalEffectiveStake != nil && totalEffectiveStake.Cmp(big.NewInt(0)) > 0 {
+		nodeWeight = effectiveStake / eth.WeiToEth(totalEffectiveStake)
+	}
+
+	// The ETH matched to the node is the ETH it has borrowed from the deposit pool
+	collateralRatio := float64(0)
+	borrowedEth := eth.WeiToEth(nd.EthMatched)
+	if borrowedEth > 0 {
+		collateralRatio = eth.WeiToEth(state.NetworkDetails.RplPrice) * stake / borrowedEth
+	}
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.effectiveStake, prometheus.GaugeValue, effectiveStake)
+	channel <- prometheus.MustNewConstMetric(
+		collector.nodeWeight, prometheus.GaugeValue, nodeWeight)
+	channel <- prometheus.MustNewConstMetric(
+		collector.collateralRatio, prometheus.GaugeValue, collateralRatio)
+	channel <- prometheus.MustNewConstMetric(
+		collector.minimumStake, prometheus.GaugeValue, minimumStake)
+	channel <- prometheus.MustNewConstMetric(
+		collector.maximumStake, prometheus.GaugeValue, maximumStake)
+	channel <- prometheus.MustNewConstMetric(
+		collector.distanceToMinimum, prometheus.GaugeValue, stake-minimumStake)
+	channel <- prometheus.MustNewConstMetric(
+		collector.distanceToMaximum, prometheus.GaugeValue, maximumStake-stake)
+}
@@ -0,0 +1,342 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/store"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// A validator's balance is swept down to this many gwei, which isn't a sign of it being offline
+	sweptBalanceGwei uint64 = 32e9
+
+	// How far above the swept balance a validator can be right after a sweep, in gwei
+	sweepToleranceGwei uint64 = 1e6
+)
+
+// Detect node events task
+type detectNodeEvents struct {
+	c      *cli.Context
+	log    log.ColorLogger
+	cfg    *config.RocketPoolConfig
+	w      *wallet.Wallet
+	ec     *services.ExecutionClientManager
+	rp     *rocketpool.RocketPool
+	db     *store.Store
+	events *eventHub
+	alerts *alerting.Manager
+
+	// What was seen on the previous run
+	previousState     *state.NetworkState
+	offlineValidators map[rptypes.ValidatorPubkey]bool
+	confirmedNonce    uint64
+	nonceKnown        bool
+	pendingTxs        map[uint64]common.Hash
+	claimedIntervals  map[uint64]bool
+	claimsKnown       bool
+}
+
+// Create detect node events task
+func newDetectNodeEvents(c *cli.Context, logger log.ColorLogger, events *eventHub) (*detectNodeEvents, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	db, err := services.GetStore(c)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := services.GetAlertManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &detectNodeEvents{
+		c:                 c,
+		log:               logger,
+		cfg:               cfg,
+		w:                 w,
+		ec:                ec,
+		rp:                rp,
+		db:                db,
+		events:            events,
+		alerts:            alerts,
+		offlineValidators: map[rptypes.ValidatorPubkey]bool{},
+		pendingTxs:        map[uint64]common.Hash{},
+		claimedIntervals:  map[uint64]bool{},
+	}, nil
+
+}
+
+// Compare the new network state with the previous one and publish the node's events
+func (t *detectNodeEvents) run(state *state.NetworkState) error {
+
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	previousState := t.previousState
+	t.previousState = state
+	if previousState != nil {
+		t.detectMinipoolEvents(previousState, state, nodeAccount.Address)
+		t.detectRewardsIntervalEvents(previousState, state)
+	}
+	if err := t.detectTransactionEvents(state, nodeAccount.Address); err != nil {
+		return err
+	}
+	return t.detectRewardsClaimEvents(previousState, state, nodeAccount.Address)
+
+}
+
+// Publish an event, logging it as well
+func (t *detectNodeEvents) publish(event api.NodeEvent, message string) {
+	event.Time = time.Now().UTC()
+	t.log.Println(message)
+	t.events.publish(event)
+}
+
+// Detect minipool status changes and validators going offline or coming back online
+func (t *detectNodeEvents) detectMinipoolEvents(previousState *state.NetworkState, newState *state.NetworkState, nodeAddress common.Address) {
+	for _, mpd := range newState.MinipoolDetailsByNode[nodeAddress] {
+		minipoolAddress := mpd.MinipoolAddress
+		pubkey := mpd.Pubkey
+
+		// Minipool status
+		previousMpd, exists := previousState.MinipoolDetailsByAddress[minipoolAddress]
+		if exists && previousMpd.Status != mpd.Status {
+			t.publish(api.NodeEvent{
+				Type:           api.NodeEventType_MinipoolStatusChanged,
+				ElBlockNumber:  newState.ElBlockNumber,
+				Minipool:       &minipoolAddress,
+				Pubkey:         &pubkey,
+				PreviousStatus: previousMpd.Status.String(),
+				NewStatus:      mpd.Status.String(),
+			}, fmt.Sprintf("Minipool %s changed from %s to %s.", minipoolAddress.Hex(), previousMpd.Status.String(), mpd.Status.String()))
+		}
+
+		// Validator liveness, judged by whether its balance went down while it was active
+		previousValidator := previousState.ValidatorDetails[pubkey]
+		validator := newState.ValidatorDetails[pubkey]
+		if !previousValidator.Exists || !validator.Exists ||
+			previousValidator.Status != beacon.ValidatorState_ActiveOngoing || validator.Status != beacon.ValidatorState_ActiveOngoing {
+			delete(t.offlineValidators, pubkey)
+			continue
+		}
+		wasSwept := previousValidator.Balance > sweptBalanceGwei && validator.Balance < sweptBalanceGwei+sweepToleranceGwei
+		if validator.Balance < previousValidator.Balance && !wasSwept {
+			if !t.offlineValidators[pubkey] {
+				t.offlineValidators[pubkey] = true
+				t.publish(api.NodeEvent{
+					Type:          api.NodeEventType_ValidatorOffline,
+					ElBlockNumber: newState.ElBlockNumber,
+					Minipool:      &minipoolAddress,
+					Pubkey:        &pubkey,
+					BalanceGwei:   validator.Balance,
+				}, fmt.Sprintf("The validator for minipool %s appears to be offline; its balance went down from %d to %d gwei.", minipoolAddress.Hex(), previousValidator.Balance, validator.Balance))
+			}
+		} else if validator.Balance > previousValidator.Balance && t.offlineValidators[pubkey] {
+			delete(t.offlineValidators, pubkey)
+			t.publish(api.NodeEvent{
+				Type:          api.NodeEventType_ValidatorOnline,
+				ElBlockNumber: newState.ElBlockNumber,
+				Minipool:      &minipoolAddress,
+				Pubkey:        &pubkey,
+				BalanceGwei:   validator.Balance,
+			}, fmt.Sprintf("The validator for minipool %s is back online.", minipoolAddress.Hex()))
+		}
+	}
+}
+
+// Detect the end of a rewards interval
+func (t *detectNodeEvents) detectRewardsIntervalEvents(previousState *state.NetworkState, newState *state.NetworkState) {
+	previousIndex := previousState.NetworkDetails.RewardIndex
+	newIndex := newState.NetworkDetails.RewardIndex
+	if newIndex > previousIndex {
+		t.publish(api.NodeEvent{
+			Type:          api.NodeEventType_RewardsIntervalEnded,
+			ElBlockNumber: newState.ElBlockNumber,
+			RewardIndex:   previousIndex,
+		}, fmt.Sprintf("Rewards interval %d has ended.", previousIndex))
+	}
+}
+
+// Detect the node's rewards being claimed, and send them as notifications as well
+func (t *detectNodeEvents) detectRewardsClaimEvents(previousState *state.NetworkState, state *state.NetworkState, nodeAddress common.Address) error {
+
+	_, claimed, err := rprewards.GetClaimStatus(t.rp, nodeAddress)
+	if err != nil {
+		return fmt.Errorf("error getting rewards claim status: %w", err)
+	}
+
+	// The intervals claimed before the daemon started aren't news
+	newClaims := []uint64{}
+	for _, index := range claimed {
+		if t.claimedIntervals[index] {
+			continue
+		}
+		t.claimedIntervals[index] = true
+		if !t.claimsKnown {
+			continue
+		}
+		newClaims = append(newClaims, index)
+		message := fmt.Sprintf("The node's rewards for interval %d were claimed.", index)
+		t.publish(api.NodeEvent{
+			Type:          api.NodeEventType_RewardsClaimed,
+			ElBlockNumber: state.ElBlockNumber,
+			RewardIndex:   index,
+		}, message)
+		if err := t.alerts.Notify("rewards-claimed", message); err != nil {
+			t.log.Printlnf("Could not send the rewards claim notification: %s", err.Error())
+		}
+	}
+	t.claimsKnown = true
+
+	if len(newClaims) > 0 && previousState != nil {
+		if err := t.recordRewardsClaims(nodeAddress, previousState.ElBlockNumber, newClaims); err != nil {
+			t.log.Printlnf("Could not record the rewards claims: %s", err.Error())
+		}
+	}
+	return nil
+
+}
+
+// Record the transactions that claimed the given intervals, which were all sent after the provided block
+func (t *detectNodeEvents) recordRewardsClaims(nodeAddress common.Address, fromBlock uint64, intervals []uint64) error {
+
+	eventLogInterval, err := t.cfg.GetEventLogInterval()
+	if err != nil {
+		return err
+	}
+	claims, err := rprewards.GetNodeRewardsClaims(t.rp, nodeAddress, big.NewInt(0).SetUint64(fromBlock), big.NewInt(int64(eventLogInterval)))
+	if err != nil {
+		return err
+	}
+
+	for _, interval := range intervals {
+		claim, exists := claims[interval]
+		if !exists {
+			continue
+		}
+		header, err := t.ec.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(claim.BlockNumber))
+		if err != nil {
+			return fmt.Errorf("error getting header for block %d: %w", claim.BlockNumber, err)
+		}
+		err = t.db.AddRewardsClaim(nodeAddress, store.RewardsClaim{
+			Interval:    interval,
+			TxHash:      claim.TxHash,
+			BlockNumber: claim.BlockNumber,
+			Time:        time.Unix(int64(header.Time), 0),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+
+}
+
+// Detect the node's transactions being included in a block
+func (t *detectNodeEvents) detectTransactionEvents(state *state.NetworkState, nodeAddress common.Address) error {
+
+	// Transactions from a Safe aren't sent from the node's address
+	if t.w.IsSafe() {
+		return nil
+	}
+
+	confirmedNonce, err := t.ec.NonceAt(context.Background(), nodeAddress, nil)
+	if err != nil {
+		return fmt.Errorf("error getting latest nonce: %w", err)
+	}
+
+	// Every nonce below the latest one has been used by a transaction in a block
+	if t.nonceKnown {
+		for nonce := t.confirmedNonce; nonce < confirmedNonce; nonce++ {
+			event := api.NodeEvent{
+				Type:          api.NodeEventType_TransactionConfirmed,
+				ElBlockNumber: state.ElBlockNumber,
+			}
+			message := fmt.Sprintf("The node's transaction with nonce %d was included in a block.", nonce)
+
+			// Add the details if the transaction was seen in the pool first
+			hash, exists := t.pendingTxs[nonce]
+			if exists {
+				receipt, err := t.ec.TransactionReceipt(context.Background(), hash)
+				if err == nil {
+					event.TxHash = &receipt.TxHash
+					event.ElBlockNumber = receipt.BlockNumber.Uint64()
+					if receipt.Status == 1 {
+						event.TxStatus = "succeeded"
+					} else {
+						event.TxStatus = "failed"
+					}
+					message = fmt.Sprintf("The node's transaction %s (nonce %d) was included in block %d and %s.", hash.Hex(), nonce, event.ElBlockNumber, event.TxStatus)
+				}
+			}
+
+			nonce := nonce
+			event.Nonce = &nonce
+			t.publish(event, message)
+			err := t.db.AddTransaction(nodeAddress, api.NodeTransactionRecord{
+				Nonce:         nonce,
+				TxHash:        event.TxHash,
+				TxStatus:      event.TxStatus,
+				ElBlockNumber: event.ElBlockNumber,
+				Time:          time.Now().UTC(),
+			})
+			if err != nil {
+				t.log.Printlnf("Could not record the transaction: %s", err.Error())
+			}
+		}
+	}
+	t.confirmedNonce = confirmedNonce
+	t.nonceKnown = true
+
+	// Remember the pending transactions so their hashes can be reported once they're included
+	pending, _, err := t.ec.TxPoolContentFrom(context.Background(), nodeAddress)
+	if err == nil {
+		for nonce, tx := range pending {
+			t.pendingTxs[nonce] = tx.Hash()
+		}
+	}
+	for nonce := range t.pendingTxs {
+		if nonce < confirmedNonce {
+			delete(t.pendingTxs, nonce)
+		}
+	}
+	return nil
+
+}
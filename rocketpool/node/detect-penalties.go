@@ -0,0 +1,412 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Settings
+const (
+	// How many blocks to look back for penalty submissions when the daemon starts, about a week
+	penaltyScanLookback uint64 = 50400
+
+	alertRule_MinipoolPenalized = "minipool-penalized"
+)
+
+// An Oracle DAO member's submission of a penalty against a minipool
+type penaltySubmission struct {
+	Member      common.Address `json:"member"`
+	TxHash      common.Hash    `json:"txHash"`
+	BlockNumber uint64         `json:"blockNumber"`
+	Time        time.Time      `json:"time"`
+}
+
+// The evidence for disputing the penalties submitted against a minipool for one block
+type penaltyReport struct {
+	Minipool             common.Address            `json:"minipool"`
+	Pubkey               string                    `json:"pubkey"`
+	ValidatorIndex       string                    `json:"validatorIndex"`
+	PenalizedBlock       uint64                    `json:"penalizedBlock"`
+	PenalizedBlockTime   time.Time                 `json:"penalizedBlockTime"`
+	Slot                 uint64                    `json:"slot"`
+	Proposer             string                    `json:"proposer"`
+	ProposedByMinipool   bool                      `json:"proposedByMinipool"`
+	FeeRecipient         common.Address            `json:"feeRecipient"`
+	ExpectedFeeRecipient *rputils.FeeRecipientInfo `json:"expectedFeeRecipient"`
+	FeeRecipientCorrect  bool                      `json:"feeRecipientCorrect"`
+	LocalFeeRecipient    string                    `json:"localFeeRecipient"`
+	Submissions          []penaltySubmission       `json:"submissions"`
+	PenaltyCount         uint64                    `json:"penaltyCount"`
+	PenaltyRate          float64                   `json:"penaltyRate"`
+	EvidenceErrors       []string                  `json:"evidenceErrors,omitempty"`
+	Updated              time.Time                 `json:"updated"`
+}
+
+// Detect penalties task
+type detectPenalties struct {
+	c      *cli.Context
+	log    log.ColorLogger
+	cfg    *config.RocketPoolConfig
+	w      *wallet.Wallet
+	rp     *rocketpool.RocketPool
+	bc     *services.BeaconClientManager
+	alerts *alerting.Manager
+
+	// What was seen on the previous run
+	nextBlock      uint64
+	penaltyCounts  map[common.Address]uint64
+	countsRecorded bool
+}
+
+// Create detect penalties task
+func newDetectPenalties(c *cli.Context, logger log.ColorLogger) (*detectPenalties, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := services.GetAlertManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &detectPenalties{
+		c:             c,
+		log:           logger,
+		cfg:           cfg,
+		w:             w,
+		rp:            rp,
+		bc:            bc,
+		alerts:        alerts,
+		penaltyCounts: map[common.Address]uint64{},
+	}, nil
+
+}
+
+// Check for penalties submitted against the node's minipools and write a dispute report for each one
+func (t *detectPenalties) run(state *state.NetworkState) error {
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	nd, exists := state.NodeDetailsByAddress[nodeAccount.Address]
+	if !exists || !nd.Exists {
+		return nil
+	}
+	minipools := map[common.Address]*rpstate.NativeMinipoolDetails{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		minipools[mpd.MinipoolAddress] = mpd
+	}
+	if len(minipools) == 0 {
+		return nil
+	}
+
+	// Alert when penalties are applied to a minipool
+	t.checkPenaltyCounts(minipools)
+
+	// Get the range of blocks to scan
+	fromBlock := t.nextBlock
+	if fromBlock == 0 && state.ElBlockNumber > penaltyScanLookback {
+		fromBlock = state.ElBlockNumber - penaltyScanLookback
+	}
+	if fromBlock > state.ElBlockNumber {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking for penalties submitted against the node's minipools...")
+
+	// Get the penalty submissions for the node's minipools, grouped by minipool and block
+	submissions, err := t.getPenaltySubmissions(fromBlock, state.ElBlockNumber, minipools)
+	if err != nil {
+		return err
+	}
+	t.nextBlock = state.ElBlockNumber + 1
+	if len(submissions) == 0 {
+		return nil
+	}
+
+	// Write the reports
+	reportsPath := os.ExpandEnv(t.cfg.Smartnode.GetPenaltyReportsPath())
+	if err := os.MkdirAll(reportsPath, 0755); err != nil {
+		return fmt.Errorf("error creating penalty reports folder: %w", err)
+	}
+	for key, caseSubmissions := range submissions {
+		mpd := minipools[key.minipool]
+		if err := t.updateReport(state, reportsPath, mpd, key.block, caseSubmissions); err != nil {
+			t.log.Println(fmt.Errorf("could not write the penalty report for minipool %s at block %d: %w", key.minipool.Hex(), key.block, err))
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Alert on any minipool whose penalty count went up since the last run
+func (t *detectPenalties) checkPenaltyCounts(minipools map[common.Address]*rpstate.NativeMinipoolDetails) {
+	for address, mpd := range minipools {
+		if mpd.PenaltyCount == nil {
+			continue
+		}
+		count := mpd.PenaltyCount.Uint64()
+		previousCount := t.penaltyCounts[address]
+		t.penaltyCounts[address] = count
+		if !t.countsRecorded || count <= previousCount {
+			continue
+		}
+		message := fmt.Sprintf("Minipool %s was penalized by the Oracle DAO; it now has %d penalties and a penalty rate of %.2f%%. See the reports in %s for the evidence to dispute it.", address.Hex(), count, eth.WeiToEth(mpd.PenaltyRate)*100, t.cfg.Smartnode.GetPenaltyReportsPath())
+		t.log.Println(message)
+		if err := t.alerts.Report(alertRule_MinipoolPenalized, alerting.Severity_Critical, message); err != nil {
+			t.log.Printlnf("Could not send the penalty alert: %s", err.Error())
+		}
+	}
+	t.countsRecorded = true
+}
+
+// A penalty case, identified by the minipool and the block it was penalized for
+type penaltyKey struct {
+	minipool common.Address
+	block    uint64
+}
+
+// Get the penalty submissions made against the node's minipools in a range of blocks
+func (t *detectPenalties) getPenaltySubmissions(fromBlock uint64, toBlock uint64, minipools map[common.Address]*rpstate.NativeMinipoolDetails) (map[penaltyKey][]penaltySubmission, error) {
+	rocketNetworkPenalties, err := t.rp.GetContract("rocketNetworkPenalties", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network penalties contract: %w", err)
+	}
+	penaltyEvent, exists := rocketNetworkPenalties.ABI.Events["PenaltySubmitted"]
+	if !exists {
+		return nil, fmt.Errorf("network penalties ABI does not have a PenaltySubmitted event")
+	}
+	eventLogInterval, err := t.cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := eth.FilterContractLogs(t.rp, "rocketNetworkPenalties", eth.FilterQuery{
+		Topics:    [][]common.Hash{{penaltyEvent.ID}},
+		FromBlock: big.NewInt(0).SetUint64(fromBlock),
+		ToBlock:   big.NewInt(0).SetUint64(toBlock),
+	}, big.NewInt(int64(eventLogInterval)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting penalty submission events: %w", err)
+	}
+
+	submissions := map[penaltyKey][]penaltySubmission{}
+	for _, log := range logs {
+		minipool, block, submissionTime, err := decodePenaltySubmission(penaltyEvent, log.Data)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding penalty submission event in transaction %s: %w", log.TxHash.Hex(), err)
+		}
+		if _, exists := minipools[minipool]; !exists || len(log.Topics) < 2 {
+			continue
+		}
+		key := penaltyKey{minipool: minipool, block: block}
+		submissions[key] = append(submissions[key], penaltySubmission{
+			Member:      common.BytesToAddress(log.Topics[1].Bytes()),
+			TxHash:      log.TxHash,
+			BlockNumber: log.BlockNumber,
+			Time:        submissionTime,
+		})
+	}
+	return submissions, nil
+}
+
+// Decode the non-indexed values of a PenaltySubmitted event
+func decodePenaltySubmission(event abi.Event, data []byte) (common.Address, uint64, time.Time, error) {
+	values := make(map[string]interface{})
+	if err := event.Inputs.UnpackIntoMap(values, data); err != nil {
+		return common.Address{}, 0, time.Time{}, err
+	}
+	minipool, ok := values["minipoolAddress"].(common.Address)
+	if !ok {
+		return common.Address{}, 0, time.Time{}, fmt.Errorf("event does not have a minipool address")
+	}
+	block, ok := values["block"].(*big.Int)
+	if !ok {
+		return common.Address{}, 0, time.Time{}, fmt.Errorf("event does not have a block number")
+	}
+	submissionTime, ok := values["time"].(*big.Int)
+	if !ok {
+		return common.Address{}, 0, time.Time{}, fmt.Errorf("event does not have a time")
+	}
+	return minipool, block.Uint64(), time.Unix(submissionTime.Int64(), 0).UTC(), nil
+}
+
+// Add new submissions to a penalty case's report, gathering its evidence the first time it's seen
+func (t *detectPenalties) updateReport(state *state.NetworkState, reportsPath string, mpd *rpstate.NativeMinipoolDetails, block uint64, submissions []penaltySubmission) error {
+
+	// Load the existing report so restarts don't report the same submissions twice
+	reportPath := filepath.Join(reportsPath, fmt.Sprintf("%s-%d.json", mpd.MinipoolAddress.Hex(), block))
+	report := &penaltyReport{}
+	isNew := false
+	bytes, err := os.ReadFile(reportPath)
+	if errors.Is(err, os.ErrNotExist) {
+		isNew = true
+		report = t.gatherEvidence(state, mpd, block)
+	} else if err != nil {
+		return fmt.Errorf("error reading report: %w", err)
+	} else if err := json.Unmarshal(bytes, report); err != nil {
+		return fmt.Errorf("error decoding report: %w", err)
+	}
+
+	// Add the submissions that aren't in the report yet
+	known := map[common.Hash]bool{}
+	for _, submission := range report.Submissions {
+		known[submission.TxHash] = true
+	}
+	newSubmissions := 0
+	for _, submission := range submissions {
+		if !known[submission.TxHash] {
+			report.Submissions = append(report.Submissions, submission)
+			newSubmissions++
+		}
+	}
+	if newSubmissions == 0 {
+		return nil
+	}
+	sort.Slice(report.Submissions, func(i, j int) bool {
+		return report.Submissions[i].BlockNumber < report.Submissions[j].BlockNumber
+	})
+	if mpd.PenaltyCount != nil {
+		report.PenaltyCount = mpd.PenaltyCount.Uint64()
+	}
+	if mpd.PenaltyRate != nil {
+		report.PenaltyRate = eth.WeiToEth(mpd.PenaltyRate)
+	}
+	report.Updated = time.Now().UTC()
+
+	// Save it
+	bytes, err = json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding report: %w", err)
+	}
+	if err := os.WriteFile(reportPath, bytes, 0644); err != nil {
+		return fmt.Errorf("error saving report: %w", err)
+	}
+
+	// Notify
+	var message string
+	if isNew {
+		verdict := "but the evidence couldn't be checked"
+		if len(report.EvidenceErrors) == 0 && report.FeeRecipientCorrect {
+			verdict = fmt.Sprintf("but its block used the correct fee recipient (%s), so it can be disputed", report.FeeRecipient.Hex())
+		} else if len(report.EvidenceErrors) == 0 {
+			verdict = fmt.Sprintf("and its block used the fee recipient %s", report.FeeRecipient.Hex())
+		}
+		message = fmt.Sprintf("An Oracle DAO member submitted a penalty against minipool %s for block %d, %s. The dispute report is in %s.", mpd.MinipoolAddress.Hex(), block, verdict, reportPath)
+	} else {
+		message = fmt.Sprintf("%d more Oracle DAO member(s) submitted a penalty against minipool %s for block %d; %d have submitted it so far.", newSubmissions, mpd.MinipoolAddress.Hex(), block, len(report.Submissions))
+	}
+	t.log.Println(message)
+	if err := t.alerts.Notify("penalty-submitted", message); err != nil {
+		t.log.Printlnf("Could not send the penalty notification: %s", err.Error())
+	}
+	return nil
+
+}
+
+// Gather the evidence of which fee recipient a penalized block used and which one the node was supposed to use
+func (t *detectPenalties) gatherEvidence(state *state.NetworkState, mpd *rpstate.NativeMinipoolDetails, block uint64) *penaltyReport {
+	report := &penaltyReport{
+		Minipool:       mpd.MinipoolAddress,
+		Pubkey:         mpd.Pubkey.Hex(),
+		PenalizedBlock: block,
+		Submissions:    []penaltySubmission{},
+	}
+	validator, exists := state.ValidatorDetails[mpd.Pubkey]
+	if exists {
+		report.ValidatorIndex = validator.Index
+	}
+
+	// Get the local fee recipient file the validator client uses
+	feeRecipientBytes, err := os.ReadFile(os.ExpandEnv(t.cfg.Smartnode.GetFeeRecipientFilePath()))
+	if err != nil {
+		report.EvidenceErrors = append(report.EvidenceErrors, fmt.Sprintf("error reading the local fee recipient file: %s", err.Error()))
+	} else {
+		report.LocalFeeRecipient = strings.TrimSpace(string(feeRecipientBytes))
+	}
+
+	// Get the penalized block's slot from its time
+	header, err := t.rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(block))
+	if err != nil {
+		report.EvidenceErrors = append(report.EvidenceErrors, fmt.Sprintf("error getting the header of block %d: %s", block, err.Error()))
+		return report
+	}
+	beaconConfig := state.BeaconConfig
+	report.PenalizedBlockTime = time.Unix(int64(header.Time), 0).UTC()
+	report.Slot = (header.Time - beaconConfig.GenesisTime) / beaconConfig.SecondsPerSlot
+
+	// Get the fee recipient the block actually used
+	beaconBlock, found, err := t.bc.GetBeaconBlock(fmt.Sprint(report.Slot))
+	if err != nil {
+		report.EvidenceErrors = append(report.EvidenceErrors, fmt.Sprintf("error getting the Beacon block for slot %d: %s", report.Slot, err.Error()))
+		return report
+	}
+	if !found || beaconBlock.ExecutionBlockNumber != block {
+		report.EvidenceErrors = append(report.EvidenceErrors, fmt.Sprintf("slot %d doesn't have a block for execution block %d", report.Slot, block))
+		return report
+	}
+	report.Proposer = beaconBlock.ProposerIndex
+	report.ProposedByMinipool = exists && beaconBlock.ProposerIndex == validator.Index
+	report.FeeRecipient = beaconBlock.FeeRecipient
+
+	// Get the fee recipient the node was supposed to use at that block
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(block),
+	}
+	expected, err := rputils.GetFeeRecipientInfoWithoutState(t.rp, t.bc, mpd.NodeAddress, opts)
+	if err != nil {
+		report.EvidenceErrors = append(report.EvidenceErrors, fmt.Sprintf("error getting the node's fee recipient at block %d (this needs an archive Execution client): %s", block, err.Error()))
+		return report
+	}
+	report.ExpectedFeeRecipient = expected
+	if expected.IsInSmoothingPool || expected.IsInOptOutCooldown {
+		report.FeeRecipientCorrect = beaconBlock.FeeRecipient == expected.SmoothingPoolAddress
+	} else {
+		report.FeeRecipientCorrect = beaconBlock.FeeRecipient == expected.FeeDistributorAddress
+	}
+	return report
+}
@@ -0,0 +1,120 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How long the node's lowest pending transaction can wait before it's reported as stuck
+var stuckTransactionThreshold, _ = time.ParseDuration("15m")
+
+// Detect stuck transactions task
+type detectStuckTransactions struct {
+	c   *cli.Context
+	log log.ColorLogger
+	w   *wallet.Wallet
+	ec  *services.ExecutionClientManager
+
+	// The lowest pending transaction, and when it was first seen
+	waitingNonce uint64
+	waitingHash  common.Hash
+	waitingSince time.Time
+	isWaiting    bool
+	reported     bool
+}
+
+// Create detect stuck transactions task
+func newDetectStuckTransactions(c *cli.Context, logger log.ColorLogger) (*detectStuckTransactions, error) {
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &detectStuckTransactions{
+		c:   c,
+		log: logger,
+		w:   w,
+		ec:  ec,
+	}, nil
+
+}
+
+// Check if the node's lowest pending transaction has been waiting too long to be included, and report it once if it has
+func (t *detectStuckTransactions) run() error {
+
+	// Transactions from a Safe wait in the Safe's queue, not in the transaction pool
+	if t.w.IsSafe() {
+		return nil
+	}
+
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	latestNonce, err := t.ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		return fmt.Errorf("error getting latest nonce: %w", err)
+	}
+	pendingNonce, err := t.ec.PendingNonceAt(context.Background(), nodeAccount.Address)
+	if err != nil {
+		return fmt.Errorf("error getting pending nonce: %w", err)
+	}
+	if pendingNonce <= latestNonce {
+		t.isWaiting = false
+		return nil
+	}
+
+	// Get the waiting transaction if the client exposes its transaction pool; a replacement restarts the timer
+	pending, _, poolErr := t.ec.TxPoolContentFrom(context.Background(), nodeAccount.Address)
+	tx := pending[latestNonce]
+	var hash common.Hash
+	if tx != nil {
+		hash = tx.Hash()
+	}
+	if !t.isWaiting || t.waitingNonce != latestNonce || t.waitingHash != hash {
+		t.waitingNonce = latestNonce
+		t.waitingHash = hash
+		t.waitingSince = time.Now()
+		t.isWaiting = true
+		t.reported = false
+		return nil
+	}
+	if t.reported || time.Since(t.waitingSince) < stuckTransactionThreshold {
+		return nil
+	}
+
+	// Report it
+	t.reported = true
+	waitTime := time.Since(t.waitingSince).Round(time.Minute)
+	if poolErr != nil || tx == nil {
+		t.log.Printlnf("WARNING: the node's transaction with nonce %d has been waiting to be included for over %s.", latestNonce, waitTime)
+	} else {
+		t.log.Printlnf("WARNING: the node's transaction %s (nonce %d) has been waiting to be included for over %s.", hash.Hex(), latestNonce, waitTime)
+		header, err := t.ec.HeaderByNumber(context.Background(), nil)
+		if err != nil {
+			return fmt.Errorf("error getting latest block header: %w", err)
+		}
+		if header.BaseFee != nil && tx.GasFeeCap().Cmp(header.BaseFee) < 0 {
+			t.log.Printlnf("Its max fee of %.6f gwei is below the current base fee of %.6f gwei.", eth.WeiToGwei(tx.GasFeeCap()), eth.WeiToGwei(header.BaseFee))
+		}
+	}
+	t.log.Printlnf("Transactions with higher nonces, including the ones this daemon sends, will wait behind it. Use `rocketpool node speed-up-tx %d` to rebroadcast it with higher fees, or `rocketpool node cancel-tx %d` to cancel it.", latestNonce, latestNonce)
+	return nil
+
+}
@@ -3,11 +3,14 @@ package node
 import (
 	"fmt"
 	"math/big"
+	"strings"
+	"time"
 
 	"github.com/docker/docker/client"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
@@ -26,20 +29,29 @@ import (
 
 // Distribute minipools task
 type distributeMinipools struct {
-	c                   *cli.Context
-	log                 log.ColorLogger
-	cfg                 *config.RocketPoolConfig
-	w                   *wallet.Wallet
-	rp                  *rocketpool.RocketPool
-	bc                  beacon.Client
-	d                   *client.Client
-	gasThreshold        float64
-	distributeThreshold *big.Int
-	disabled            bool
-	eight               *big.Int
-	maxFee              *big.Int
-	maxPriorityFee      *big.Int
-	gasLimit            uint64
+	c                       *cli.Context
+	log                     log.ColorLogger
+	cfg                     *config.RocketPoolConfig
+	w                       *wallet.Wallet
+	rp                      *rocketpool.RocketPool
+	bc                      beacon.Client
+	d                       *client.Client
+	gasThreshold            float64
+	distributeThreshold     *big.Int
+	feeDistributorThreshold *big.Int
+	schedule                distributeSchedule
+	disabled                bool
+	eight                   *big.Int
+	maxFee                  *big.Int
+	maxPriorityFee          *big.Int
+	gasLimit                uint64
+}
+
+// The daily window, as offsets from midnight UTC, that automatic distributions are limited to
+type distributeSchedule struct {
+	start   time.Duration
+	end     time.Duration
+	enabled bool
 }
 
 // Create distribute minipools task
@@ -70,6 +82,7 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 	// Check if auto-distributing is disabled
 	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
 	distributeThreshold := cfg.Smartnode.DistributeThreshold.Value.(float64)
+	feeDistributorThreshold := cfg.Smartnode.FeeDistributorThreshold.Value.(float64)
 	disabled := false
 	if gasThreshold == 0 {
 		logger.Println("Automatic tx gas threshold is 0, disabling auto-distribute.")
@@ -79,12 +92,25 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 		if distributeThreshold >= 8 {
 			logger.Printlnf("WARNING: Auto-distribute threshold is more than 8 ETH (%.6f ETH), reducing to 7.5 ETH for safety", distributeThreshold)
 			distributeThreshold = 7.5
-		} else if distributeThreshold == 0 {
-			logger.Println("Auto-distribute threshold is 0, disabling auto-distribute.")
+		} else if distributeThreshold == 0 && feeDistributorThreshold == 0 {
+			logger.Println("Auto-distribute thresholds are 0, disabling auto-distribute.")
 			disabled = true
 		}
 	}
 
+	// Use the max fee for distributions instead of the automatic tx gas threshold if there is one
+	distributeMaxFee := cfg.Smartnode.DistributeMaxFee.Value.(float64)
+	if !disabled && distributeMaxFee > 0 {
+		gasThreshold = distributeMaxFee
+	}
+
+	// Get the window distributions are limited to
+	schedule, err := parseDistributeSchedule(cfg.Smartnode.DistributeSchedule.Value.(string))
+	if err != nil {
+		logger.Printlnf("WARNING: %s, disabling auto-distribute.", err.Error())
+		disabled = true
+	}
+
 	// Get the user-requested max fee
 	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
 	var maxFee *big.Int
@@ -106,20 +132,22 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 
 	// Return task
 	return &distributeMinipools{
-		c:                   c,
-		log:                 logger,
-		cfg:                 cfg,
-		w:                   w,
-		rp:                  rp,
-		bc:                  bc,
-		d:                   d,
-		gasThreshold:        gasThreshold,
-		distributeThreshold: eth.EthToWei(distributeThreshold),
-		disabled:            disabled,
-		eight:               eth.EthToWei(8),
-		maxFee:              maxFee,
-		maxPriorityFee:      priorityFee,
-		gasLimit:            0,
+		c:                       c,
+		log:                     logger,
+		cfg:                     cfg,
+		w:                       w,
+		rp:                      rp,
+		bc:                      bc,
+		d:                       d,
+		gasThreshold:            gasThreshold,
+		distributeThreshold:     eth.EthToWei(distributeThreshold),
+		feeDistributorThreshold: eth.EthToWei(feeDistributorThreshold),
+		schedule:                schedule,
+		disabled:                disabled,
+		eight:                   eth.EthToWei(8),
+		maxFee:                  maxFee,
+		maxPriorityFee:          priorityFee,
+		gasLimit:                0,
 	}, nil
 
 }
@@ -132,6 +160,19 @@ func (t *distributeMinipools) run(state *state.NetworkState) error {
 		return nil
 	}
 
+	// Only distribute during the scheduled window
+	if !t.schedule.contains(time.Now().UTC()) {
+		return nil
+	}
+
+	// Distribute the fee distributor
+	if err := t.distributeFeeDistributor(state); err != nil {
+		return err
+	}
+	if t.distributeThreshold.Sign() == 0 {
+		return nil
+	}
+
 	// Log
 	t.log.Println("Checking for minipools to distribute...")
 
@@ -240,7 +281,7 @@ func (t *distributeMinipools) distributeMinipool(mpd *rpstate.NativeMinipoolDeta
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return false, err
 		}
@@ -274,3 +315,120 @@ func (t *distributeMinipools) distributeMinipool(mpd *rpstate.NativeMinipoolDeta
 	return true, nil
 
 }
+
+// Distribute the node's fee distributor if its balance is over the threshold
+func (t *distributeMinipools) distributeFeeDistributor(state *state.NetworkState) error {
+
+	if t.feeDistributorThreshold.Sign() == 0 {
+		return nil
+	}
+
+	// Get the node's fee distributor
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	nd, exists := state.NodeDetailsByAddress[nodeAccount.Address]
+	if !exists || !nd.FeeDistributorInitialised || nd.DistributorBalance.Cmp(t.feeDistributorThreshold) < 0 {
+		return nil
+	}
+
+	// Log
+	t.log.Printlnf("Distributing fee distributor %s (balance of %.6f ETH)...", nd.FeeDistributorAddress.Hex(), eth.WeiToEth(nd.DistributorBalance))
+
+	distributor, err := node.NewDistributor(t.rp, nd.FeeDistributorAddress, nil)
+	if err != nil {
+		return fmt.Errorf("cannot create binding for fee distributor %s: %w", nd.FeeDistributorAddress.Hex(), err)
+	}
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+
+	// Get the gas limit
+	gasInfo, err := distributor.EstimateDistributeGas(opts)
+	if err != nil {
+		return fmt.Errorf("Could not estimate the gas required to distribute fee distributor %s: %w", nd.FeeDistributorAddress.Hex(), err)
+	}
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Print the gas info
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+		return nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gas.Uint64()
+
+	// Distribute fee distributor
+	hash, err := distributor.Distribute(opts)
+	if err != nil {
+		return err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	if err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Printlnf("Successfully distributed balance of fee distributor %s.", nd.FeeDistributorAddress.Hex())
+
+	// Return
+	return nil
+
+}
+
+// Parse a daily window in the form HH:MM-HH:MM (UTC); a blank one allows distributions at any time
+func parseDistributeSchedule(value string) (distributeSchedule, error) {
+	if value == "" {
+		return distributeSchedule{}, nil
+	}
+	bounds := strings.Split(value, "-")
+	if len(bounds) != 2 {
+		return distributeSchedule{}, fmt.Errorf("auto-distribute schedule [%s] is not in the form HH:MM-HH:MM", value)
+	}
+	start, err := time.Parse("15:04", strings.TrimSpace(bounds[0]))
+	if err != nil {
+		return distributeSchedule{}, fmt.Errorf("auto-distribute schedule [%s] has an invalid start time", value)
+	}
+	end, err := time.Parse("15:04", strings.TrimSpace(bounds[1]))
+	if err != nil {
+		return distributeSchedule{}, fmt.Errorf("auto-distribute schedule [%s] has an invalid end time", value)
+	}
+	return distributeSchedule{
+		start:   time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute,
+		end:     time.Duration(end.Hour())*time.Hour + time.Duration(end.Minute())*time.Minute,
+		enabled: true,
+	}, nil
+}
+
+// Check if a time falls within the window, which can wrap around midnight
+func (s distributeSchedule) contains(now time.Time) bool {
+	if !s.enabled {
+		return true
+	}
+	offset := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute
+	if s.start <= s.end {
+		return offset >= s.start && offset < s.end
+	}
+	return offset >= s.start || offset < s.end
+}
@@ -95,6 +95,12 @@ func (d *downloadRewardsTrees) run(state *state.NetworkState) error {
 	// Check for missing intervals
 	missingIntervals := []uint64{}
 	for i := uint64(0); i < currentIndex; i++ {
+		// Skip intervals whose files were pruned
+		_, err = os.Stat(d.cfg.Smartnode.GetPrunedRewardsFileMarkerPath(i, true))
+		if err == nil {
+			continue
+		}
+
 		// Check if the tree file exists
 		treeFilePath := d.cfg.Smartnode.GetRewardsTreePath(i, true)
 		_, err = os.Stat(treeFilePath)
@@ -117,7 +123,7 @@ func (d *downloadRewardsTrees) run(state *state.NetworkState) error {
 		if err != nil {
 			return fmt.Errorf("error getting interval %d info: %w", missingInterval, err)
 		}
-		err = rprewards.DownloadRewardsFile(d.cfg, missingInterval, intervalInfo.CID, true)
+		err = rprewards.DownloadRewardsFile(d.cfg, missingInterval, intervalInfo.CID, intervalInfo.MerkleRoot, true)
 		if err != nil {
 			fmt.Println()
 			return err
@@ -0,0 +1,48 @@
+package node
+
+import (
+	"sync"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Sends the node's events to everyone subscribed to them
+type eventHub struct {
+	lock        sync.Mutex
+	subscribers map[chan api.NodeEvent]struct{}
+}
+
+// Create a new event hub with no subscribers
+func newEventHub() *eventHub {
+	return &eventHub{
+		subscribers: map[chan api.NodeEvent]struct{}{},
+	}
+}
+
+// Send an event to the subscribers; subscribers that fall behind miss events instead of blocking the daemon
+func (h *eventHub) publish(event api.NodeEvent) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for subscriber := range h.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
+
+// Start receiving events
+func (h *eventHub) subscribe() chan api.NodeEvent {
+	subscriber := make(chan api.NodeEvent, 64)
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.subscribers[subscriber] = struct{}{}
+	return subscriber
+}
+
+// Stop receiving events
+func (h *eventHub) unsubscribe(subscriber chan api.NodeEvent) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	delete(h.subscribers, subscriber)
+}
@@ -0,0 +1,81 @@
+package node
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const (
+	eventSocketPingInterval time.Duration = 30 * time.Second
+	eventSocketWriteTimeout time.Duration = 10 * time.Second
+)
+
+// Upgrades HTTP API requests to WebSockets; access is already controlled by the socket permissions or the API token
+var eventSocketUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+// Push the node's events to a WebSocket client as JSON messages, optionally filtered with a comma-separated `types`
+// query parameter
+func serveEventSocket(events *eventHub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Get the event types to send
+		types := map[api.NodeEventType]bool{}
+		for _, eventType := range strings.Split(r.URL.Query().Get("types"), ",") {
+			if eventType != "" {
+				types[api.NodeEventType(eventType)] = true
+			}
+		}
+
+		conn, err := eventSocketUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			// The upgrader has already responded with the error
+			return
+		}
+		defer conn.Close()
+
+		// Read from the client so its close and pong messages are handled, and stop when it disconnects
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		subscriber := events.subscribe()
+		defer events.unsubscribe(subscriber)
+		ticker := time.NewTicker(eventSocketPingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-closed:
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(eventSocketWriteTimeout)); err != nil {
+					return
+				}
+			case event := <-subscriber:
+				if len(types) > 0 && !types[event.Type] {
+					continue
+				}
+				_ = conn.SetWriteDeadline(time.Now().Add(eventSocketWriteTimeout))
+				if err := conn.WriteJSON(event); err != nil {
+					return
+				}
+			}
+		}
+
+	}
+}
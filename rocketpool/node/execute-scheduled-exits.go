@@ -0,0 +1,207 @@
+package node
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Execute scheduled exits task
+type executeScheduledExits struct {
+	c      *cli.Context
+	log    log.ColorLogger
+	cfg    *config.RocketPoolConfig
+	w      *wallet.Wallet
+	bc     *services.BeaconClientManager
+	alerts *alerting.Manager
+}
+
+// Create execute scheduled exits task
+func newExecuteScheduledExits(c *cli.Context, logger log.ColorLogger) (*executeScheduledExits, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := services.GetAlertManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &executeScheduledExits{
+		c:      c,
+		log:    logger,
+		cfg:    cfg,
+		w:      w,
+		bc:     bc,
+		alerts: alerts,
+	}, nil
+
+}
+
+// Broadcast the scheduled exits whose conditions are met, and track the broadcast ones until their validators are withdrawn
+func (t *executeScheduledExits) run(state *state.NetworkState) error {
+
+	exitsPath := os.ExpandEnv(t.cfg.Smartnode.GetScheduledExitsPath())
+	exits, err := validator.LoadScheduledExits(exitsPath)
+	if err != nil {
+		return err
+	}
+	if len(exits) == 0 {
+		return nil
+	}
+
+	// Log
+	t.log.Printlnf("Checking %d scheduled exit(s)...", len(exits))
+
+	// The exit queue is only needed for exits that wait on it
+	currentEpoch := state.BeaconSlotNumber / state.BeaconConfig.SlotsPerEpoch
+	exitQueue := uint64(0)
+	for _, exit := range exits {
+		if !exit.Broadcast && exit.MaxExitQueue > 0 {
+			exitQueue, err = t.bc.GetExitQueueLength()
+			if err != nil {
+				return fmt.Errorf("error getting the exit queue length: %w", err)
+			}
+			break
+		}
+	}
+
+	for _, exit := range exits {
+		mpd, exists := state.MinipoolDetailsByAddress[exit.Minipool]
+		validatorDetails := state.ValidatorDetails[exit.Pubkey]
+
+		// Stop tracking exits once their minipools are closed
+		if !exists || mpd.Finalised {
+			t.log.Printlnf("Minipool %s has been closed; removing its scheduled exit.", exit.Minipool.Hex())
+			if err := validator.RemoveScheduledExit(exitsPath, exit.Minipool); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if exit.Broadcast {
+			if err := t.trackExit(exitsPath, exit, validatorDetails); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Wait for the exit's conditions
+		if currentEpoch < exit.Epoch {
+			continue
+		}
+		if exit.MaxExitQueue > 0 && exitQueue > exit.MaxExitQueue {
+			t.log.Printlnf("Waiting to exit minipool %s: the exit queue has %d validators, which is more than its limit of %d.", exit.Minipool.Hex(), exitQueue, exit.MaxExitQueue)
+			continue
+		}
+
+		// The validator may have been exited some other way
+		if validatorDetails.Exists && validatorDetails.Status != beacon.ValidatorState_ActiveOngoing {
+			t.log.Printlnf("The validator for minipool %s is already %s; tracking it without broadcasting another exit.", exit.Minipool.Hex(), validatorDetails.Status)
+			exit.Broadcast = true
+			exit.BroadcastEpoch = currentEpoch
+			if err := validator.SaveScheduledExit(exitsPath, exit); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Exit it
+		if err := t.broadcastExit(exitsPath, exit); err != nil {
+			t.log.Println(fmt.Errorf("could not exit minipool %s: %w", exit.Minipool.Hex(), err))
+			continue
+		}
+		exitQueue++
+	}
+
+	// Return
+	return nil
+
+}
+
+// Sign and broadcast a scheduled exit
+func (t *executeScheduledExits) broadcastExit(exitsPath string, exit api.ScheduledExit) error {
+
+	// Log
+	t.log.Printlnf("Exiting minipool %s...", exit.Minipool.Hex())
+
+	validatorKey, err := t.w.GetValidatorKeyByPubkey(exit.Pubkey)
+	if err != nil {
+		exit.Error = err.Error()
+		validator.SaveScheduledExit(exitsPath, exit)
+		return err
+	}
+	epoch, err := validator.BroadcastExitMessage(t.bc, validatorKey, exit.Pubkey)
+	if err != nil {
+		exit.Error = err.Error()
+		validator.SaveScheduledExit(exitsPath, exit)
+		return err
+	}
+
+	exit.Broadcast = true
+	exit.BroadcastEpoch = epoch
+	exit.Error = ""
+	if err := validator.SaveScheduledExit(exitsPath, exit); err != nil {
+		return err
+	}
+
+	// Log
+	message := fmt.Sprintf("Broadcast the scheduled exit of minipool %s in epoch %d. Keep its validator running until it has left the exit queue.", exit.Minipool.Hex(), epoch)
+	t.log.Println(message)
+	if err := t.alerts.Notify("scheduled-exit-broadcast", message); err != nil {
+		t.log.Printlnf("Could not send the exit notification: %s", err.Error())
+	}
+	return nil
+
+}
+
+// Record the progress of a broadcast exit, and let the operator know once the validator has been withdrawn
+func (t *executeScheduledExits) trackExit(exitsPath string, exit api.ScheduledExit, validatorDetails beacon.ValidatorStatus) error {
+	if exit.Withdrawn || !validatorDetails.Exists {
+		return nil
+	}
+
+	withdrawn := validatorDetails.Status == beacon.ValidatorState_WithdrawalDone
+	if validatorDetails.ExitEpoch == exit.ExitEpoch && validatorDetails.WithdrawableEpoch == exit.WithdrawableEpoch && !withdrawn {
+		return nil
+	}
+	exit.ExitEpoch = validatorDetails.ExitEpoch
+	exit.WithdrawableEpoch = validatorDetails.WithdrawableEpoch
+	exit.Withdrawn = withdrawn
+	if err := validator.SaveScheduledExit(exitsPath, exit); err != nil {
+		return err
+	}
+	if !withdrawn {
+		t.log.Printlnf("The validator for minipool %s will exit in epoch %d and be withdrawable in epoch %d.", exit.Minipool.Hex(), exit.ExitEpoch, exit.WithdrawableEpoch)
+		return nil
+	}
+
+	message := fmt.Sprintf("The validator for minipool %s has been withdrawn. Run `rocketpool minipool close` to distribute its balance and close it.", exit.Minipool.Hex())
+	t.log.Println(message)
+	if err := t.alerts.Notify("scheduled-exit-withdrawn", message); err != nil {
+		t.log.Printlnf("Could not send the withdrawal notification: %s", err.Error())
+	}
+	return nil
+}
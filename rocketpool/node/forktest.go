@@ -0,0 +1,17 @@
+//go:build forktest
+
+package node
+
+import (
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Run the auto-claim rewards task once with the given services, so the fork tests can run it without the daemon
+func RunAutoClaimRewards(logger log.ColorLogger, cfg *config.RocketPoolConfig, w *wallet.Wallet, rp *rocketpool.RocketPool, state *state.NetworkState) error {
+	return createAutoClaimRewards(logger, cfg, w, rp, nil).run(state)
+}
@@ -0,0 +1,486 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/rocket-pool/smartnode/shared/rpc"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/apiauth"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	defaultWatchStateInterval uint32 = 60
+	minWatchStateInterval     uint32 = 12
+)
+
+// The role required to call each gRPC method
+var grpcMethodRoles = map[string]apiauth.Role{
+	"GetNodeStatus":             apiauth.Role_ReadOnly,
+	"GetMinipools":              apiauth.Role_ReadOnly,
+	"GetWalletStatus":           apiauth.Role_ReadOnly,
+	"GetStateSnapshot":          apiauth.Role_ReadOnly,
+	"WatchState":                apiauth.Role_ReadOnly,
+	"StreamLogs":                apiauth.Role_ReadOnly,
+	"StakeMinipool":             apiauth.Role_Operator,
+	"RefundMinipool":            apiauth.Role_Operator,
+	"DistributeMinipoolBalance": apiauth.Role_Operator,
+	"CloseMinipool":             apiauth.Role_Admin,
+	"ExitMinipool":              apiauth.Role_Admin,
+	"RebuildWallet":             apiauth.Role_Admin,
+}
+
+// Implements the gRPC service by running the API commands
+type grpcApiServer struct {
+	rpc.UnimplementedSmartnodeServer
+	runner *apiRunner
+	logs   *logStream
+}
+
+// Serve the gRPC API on the socket in the data folder, and on a TCP port if one is configured
+func runGrpcApiServer(c *cli.Context, logger log.ColorLogger, runner *apiRunner, logs *logStream) error {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	if !cfg.Smartnode.EnableGrpcApi.Value.(bool) {
+		return nil
+	}
+	server := &grpcApiServer{
+		runner: runner,
+		logs:   logs,
+	}
+
+	// Serve the socket; access to it is controlled by its file permissions
+	errs := make(chan error, 2)
+	socketPath := cfg.Smartnode.GetGrpcApiSocketPath()
+	err = os.Remove(socketPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Error removing old gRPC API socket: %w", err)
+	}
+	socketListener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("Error creating gRPC API socket: %w", err)
+	}
+	err = os.Chmod(socketPath, 0660)
+	if err != nil {
+		return fmt.Errorf("Error setting gRPC API socket permissions: %w", err)
+	}
+	socketServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(limitGrpcApiCall),
+		grpc.ChainStreamInterceptor(limitGrpcApiStream),
+	)
+	rpc.RegisterSmartnodeServer(socketServer, server)
+	logger.Printlnf("Starting gRPC API on %s.", socketPath)
+	go func() {
+		errs <- socketServer.Serve(socketListener)
+	}()
+
+	// Serve the TCP port, which requires an API token with the method's role
+	port := cfg.Smartnode.GrpcApiPort.Value.(uint16)
+	if port != 0 {
+		authenticator, err := newApiAuthenticator(cfg)
+		if err != nil {
+			return err
+		}
+		tcpListener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+		if err != nil {
+			return fmt.Errorf("Error listening on gRPC API port %d: %w", port, err)
+		}
+		tcpServer := grpc.NewServer(
+			grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				if err := checkGrpcApiToken(ctx, authenticator, info.FullMethod); err != nil {
+					return nil, err
+				}
+				return handler(ctx, req)
+			}, limitGrpcApiCall),
+			grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				if err := checkGrpcApiToken(ss.Context(), authenticator, info.FullMethod); err != nil {
+					return err
+				}
+				return handler(srv, ss)
+			}, limitGrpcApiStream),
+		)
+		rpc.RegisterSmartnodeServer(tcpServer, server)
+		logger.Printlnf("Starting gRPC API on port %d.", port)
+		go func() {
+			errs <- tcpServer.Serve(tcpListener)
+		}()
+	}
+
+	err = <-errs
+	return fmt.Errorf("Error running gRPC API server: %w", err)
+
+}
+
+// Reject calls that don't have a valid API token as their bearer token, or whose token doesn't have the method's role
+func checkGrpcApiToken(ctx context.Context, authenticator *apiauth.Authenticator, fullMethod string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, auth := range md.Get("authorization") {
+		role, valid := authenticator.Authenticate(strings.TrimPrefix(auth, "Bearer "))
+		if !valid {
+			continue
+		}
+		method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+		requiredRole, exists := grpcMethodRoles[method]
+		if !exists {
+			requiredRole = apiauth.Role_Admin
+		}
+		if !role.Allows(requiredRole) {
+			return status.Errorf(codes.PermissionDenied, "%s requires the %s role", method, requiredRole)
+		}
+		return nil
+	}
+	return status.Error(codes.Unauthenticated, "invalid API token")
+}
+
+// A server stream with the client's address in its context
+type grpcApiClientStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Get the stream's context
+func (s *grpcApiClientStream) Context() context.Context {
+	return s.ctx
+}
+
+// Add the client's address to a call's context for its rate limit, and report the limits with the matching codes
+func limitGrpcApiCall(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	response, err := handler(withApiClient(ctx, getGrpcApiClient(ctx)), req)
+	return response, getGrpcApiError(err)
+}
+
+// Add the client's address to a stream's context for its rate limit, and report the limits with the matching codes
+func limitGrpcApiStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := withApiClient(ss.Context(), getGrpcApiClient(ss.Context()))
+	return getGrpcApiError(handler(srv, &grpcApiClientStream{ServerStream: ss, ctx: ctx}))
+}
+
+// Get the address of the client making a call
+func getGrpcApiClient(ctx context.Context) string {
+	p, exists := peer.FromContext(ctx)
+	if !exists || p.Addr == nil {
+		return "socket"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		// Calls over the socket don't have an address
+		return "socket"
+	}
+	return host
+}
+
+// Convert the API limit errors to their gRPC codes
+func getGrpcApiError(err error) error {
+	if errors.Is(err, errApiRateLimited) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if errors.Is(err, errApiQueueFull) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return err
+}
+
+// Get the node's registration details, balances and stakes
+func (s *grpcApiServer) GetNodeStatus(ctx context.Context, request *rpc.GetNodeStatusRequest) (*rpc.NodeStatus, error) {
+	var response api.NodeExportResponse
+	if err := s.runner.runInto(ctx, apiTxOptions{}, &response, "node", "get-export-data"); err != nil {
+		return nil, err
+	}
+	return getGrpcNodeStatus(&response), nil
+}
+
+// Get the node's minipools
+func (s *grpcApiServer) GetMinipools(ctx context.Context, request *rpc.GetMinipoolsRequest) (*rpc.MinipoolList, error) {
+	var response api.NodeExportResponse
+	if err := s.runner.runInto(ctx, apiTxOptions{}, &response, "node", "get-export-data"); err != nil {
+		return nil, err
+	}
+	return &rpc.MinipoolList{
+		ElBlockNumber:    response.ElBlockNumber,
+		BeaconSlotNumber: response.BeaconSlotNumber,
+		Minipools:        getGrpcMinipools(response.Minipools, request.IncludeFinalized),
+	}, nil
+}
+
+// Stake a prelaunch minipool
+func (s *grpcApiServer) StakeMinipool(ctx context.Context, request *rpc.MinipoolRequest) (*rpc.TransactionResult, error) {
+	var response api.StakeMinipoolResponse
+	if err := s.runner.runInto(ctx, getApiTxOptions(request.Options), &response, "minipool", "stake", request.Address); err != nil {
+		return nil, err
+	}
+	return &rpc.TransactionResult{TxHash: response.TxHash.Hex()}, nil
+}
+
+// Refund the node's ETH from a minipool
+func (s *grpcApiServer) RefundMinipool(ctx context.Context, request *rpc.MinipoolRequest) (*rpc.TransactionResult, error) {
+	var response api.RefundMinipoolResponse
+	if err := s.runner.runInto(ctx, getApiTxOptions(request.Options), &response, "minipool", "refund", request.Address); err != nil {
+		return nil, err
+	}
+	return &rpc.TransactionResult{TxHash: response.TxHash.Hex()}, nil
+}
+
+// Distribute a minipool's balance
+func (s *grpcApiServer) DistributeMinipoolBalance(ctx context.Context, request *rpc.MinipoolRequest) (*rpc.TransactionResult, error) {
+	var response api.DistributeBalanceResponse
+	if err := s.runner.runInto(ctx, getApiTxOptions(request.Options), &response, "minipool", "distribute-balance", request.Address); err != nil {
+		return nil, err
+	}
+	return &rpc.TransactionResult{TxHash: response.TxHash.Hex()}, nil
+}
+
+// Close a dissolved or finalised minipool
+func (s *grpcApiServer) CloseMinipool(ctx context.Context, request *rpc.MinipoolRequest) (*rpc.TransactionResult, error) {
+	var response api.CloseMinipoolResponse
+	if err := s.runner.runInto(ctx, getApiTxOptions(request.Options), &response, "minipool", "close", request.Address); err != nil {
+		return nil, err
+	}
+	return &rpc.TransactionResult{TxHash: response.TxHash.Hex()}, nil
+}
+
+// Submit a voluntary exit for a minipool's validator
+func (s *grpcApiServer) ExitMinipool(ctx context.Context, request *rpc.MinipoolRequest) (*rpc.ExitMinipoolResult, error) {
+	var response api.ExitMinipoolResponse
+	if err := s.runner.runInto(ctx, apiTxOptions{}, &response, "minipool", "exit", request.Address); err != nil {
+		return nil, err
+	}
+	return &rpc.ExitMinipoolResult{}, nil
+}
+
+// Get the status of the node wallet
+func (s *grpcApiServer) GetWalletStatus(ctx context.Context, request *rpc.GetWalletStatusRequest) (*rpc.WalletStatus, error) {
+	var response api.WalletStatusResponse
+	if err := s.runner.runInto(ctx, apiTxOptions{}, &response, "wallet", "status"); err != nil {
+		return nil, err
+	}
+	return &rpc.WalletStatus{
+		PasswordSet:       response.PasswordSet,
+		WalletInitialized: response.WalletInitialized,
+		AccountAddress:    response.AccountAddress.Hex(),
+	}, nil
+}
+
+// Rebuild the validator keys of the node wallet
+func (s *grpcApiServer) RebuildWallet(ctx context.Context, request *rpc.RebuildWalletRequest) (*rpc.RebuildWalletResult, error) {
+	var response api.RebuildWalletResponse
+	if err := s.runner.runInto(ctx, apiTxOptions{}, &response, "wallet", "rebuild"); err != nil {
+		return nil, err
+	}
+	result := &rpc.RebuildWalletResult{}
+	for _, key := range response.ValidatorKeys {
+		result.ValidatorKeys = append(result.ValidatorKeys, key.Hex())
+	}
+	return result, nil
+}
+
+// Get a zstd-compressed JSON snapshot of the node's network state
+func (s *grpcApiServer) GetStateSnapshot(ctx context.Context, request *rpc.GetStateSnapshotRequest) (*rpc.StateSnapshot, error) {
+	var response api.NodeStateSnapshotResponse
+	if err := s.runner.runInto(ctx, apiTxOptions{}, &response, "node", "get-state-snapshot"); err != nil {
+		return nil, err
+	}
+	if !response.Registered || response.Snapshot == nil {
+		return &rpc.StateSnapshot{Registered: false}, nil
+	}
+
+	// Compress it the same way as an exported snapshot file
+	file, err := os.CreateTemp("", state.StateSnapshotFilename)
+	if err != nil {
+		return nil, fmt.Errorf("error creating temporary snapshot file: %w", err)
+	}
+	path := file.Name()
+	file.Close()
+	defer os.Remove(path)
+	if err := response.Snapshot.Save(path); err != nil {
+		return nil, err
+	}
+	snapshotBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading snapshot: %w", err)
+	}
+	return &rpc.StateSnapshot{
+		Registered: true,
+		Snapshot:   snapshotBytes,
+	}, nil
+}
+
+// Stream the node's state each time it changes
+func (s *grpcApiServer) WatchState(request *rpc.WatchStateRequest, stream rpc.Smartnode_WatchStateServer) error {
+	interval := request.IntervalSeconds
+	if interval == 0 {
+		interval = defaultWatchStateInterval
+	} else if interval < minWatchStateInterval {
+		interval = minWatchStateInterval
+	}
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	var lastBlock uint64
+	for {
+		var response api.NodeExportResponse
+		if err := s.runner.runInto(stream.Context(), apiTxOptions{}, &response, "node", "get-export-data"); err != nil {
+			return err
+		}
+		if response.ElBlockNumber != lastBlock {
+			lastBlock = response.ElBlockNumber
+			err := stream.Send(&rpc.NodeState{
+				Status:    getGrpcNodeStatus(&response),
+				Minipools: getGrpcMinipools(response.Minipools, request.IncludeFinalized),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stream the daemon's log lines
+func (s *grpcApiServer) StreamLogs(request *rpc.StreamLogsRequest, stream rpc.Smartnode_StreamLogsServer) error {
+	subscriber := s.logs.subscribe()
+	defer s.logs.unsubscribe(subscriber)
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case line := <-subscriber:
+			err := stream.Send(&rpc.LogLine{
+				Time: line.time.Unix(),
+				Line: line.line,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Convert a call's transaction options to the API's
+func getApiTxOptions(options *rpc.TransactionOptions) apiTxOptions {
+	if options == nil {
+		return apiTxOptions{}
+	}
+	txOptions := apiTxOptions{
+		MaxFee:     options.MaxFeeGwei,
+		MaxPrioFee: options.MaxPriorityFeeGwei,
+		GasLimit:   options.GasLimit,
+		Nonce:      options.Nonce,
+	}
+	switch options.Mode {
+	case rpc.TransactionMode_TRANSACTION_MODE_UNSIGNED:
+		txOptions.Mode = "unsigned"
+	case rpc.TransactionMode_TRANSACTION_MODE_DRY_RUN:
+		txOptions.Mode = "dry-run"
+	case rpc.TransactionMode_TRANSACTION_MODE_DEFERRED:
+		txOptions.Mode = "deferred"
+	}
+	return txOptions
+}
+
+// Convert the node's export data to its gRPC status
+func getGrpcNodeStatus(response *api.NodeExportResponse) *rpc.NodeStatus {
+	status := &rpc.NodeStatus{
+		Registered:       response.Registered,
+		ElBlockNumber:    response.ElBlockNumber,
+		BeaconSlotNumber: response.BeaconSlotNumber,
+	}
+	if !response.Registered {
+		return status
+	}
+
+	node := response.Node
+	status.Node = &rpc.NodeDetails{
+		Address:                 node.Address.Hex(),
+		WithdrawalAddress:       node.WithdrawalAddress.Hex(),
+		TimezoneLocation:        node.TimezoneLocation,
+		RegistrationTime:        node.RegistrationTime.Unix(),
+		FeeDistributorAddress:   node.FeeDistributorAddress.Hex(),
+		FeeDistributorBalance:   getGrpcAmount(node.FeeDistributorBalance),
+		SmoothingPoolRegistered: node.SmoothingPoolRegistered,
+		EthBalance:              getGrpcAmount(node.EthBalance),
+		RethBalance:             getGrpcAmount(node.RethBalance),
+		RplBalance:              getGrpcAmount(node.RplBalance),
+		RplStake:                getGrpcAmount(node.RplStake),
+		EffectiveRplStake:       getGrpcAmount(node.EffectiveRplStake),
+		MinimumRplStake:         getGrpcAmount(node.MinimumRplStake),
+		MaximumRplStake:         getGrpcAmount(node.MaximumRplStake),
+		EthMatched:              getGrpcAmount(node.EthMatched),
+		EthMatchedLimit:         getGrpcAmount(node.EthMatchedLimit),
+		DepositCreditBalance:    getGrpcAmount(node.DepositCreditBalance),
+		MinipoolCount:           node.MinipoolCount,
+		AverageNodeFee:          getGrpcAmount(node.AverageNodeFee),
+		CollateralisationRatio:  getGrpcAmount(node.CollateralisationRatio),
+	}
+	return status
+}
+
+// Convert the node's minipools to their gRPC messages
+func getGrpcMinipools(minipools []api.MinipoolExportDetails, includeFinalized bool) []*rpc.Minipool {
+	grpcMinipools := []*rpc.Minipool{}
+	for _, mp := range minipools {
+		if mp.Finalised && !includeFinalized {
+			continue
+		}
+		grpcMinipools = append(grpcMinipools, &rpc.Minipool{
+			Address:                  mp.Address.Hex(),
+			Pubkey:                   mp.Pubkey.Hex(),
+			ValidatorIndex:           mp.ValidatorIndex,
+			Status:                   mp.Status.String(),
+			StatusTime:               mp.StatusTime.Unix(),
+			DepositType:              mp.DepositType.String(),
+			Version:                  uint32(mp.Version),
+			Finalised:                mp.Finalised,
+			Vacant:                   mp.IsVacant,
+			NodeFee:                  getGrpcAmount(mp.NodeFee),
+			NodeDepositBalance:       getGrpcAmount(mp.NodeDepositBalance),
+			UserDepositBalance:       getGrpcAmount(mp.UserDepositBalance),
+			PenaltyCount:             mp.PenaltyCount,
+			PenaltyRate:              getGrpcAmount(mp.PenaltyRate),
+			Balance:                  getGrpcAmount(mp.Balance),
+			NodeShareOfBalance:       getGrpcAmount(mp.NodeShareOfBalance),
+			NodeRefundBalance:        getGrpcAmount(mp.NodeRefundBalance),
+			ValidatorStatus:          mp.ValidatorStatus,
+			BeaconBalance:            getGrpcAmount(mp.BeaconBalance),
+			EffectiveBalance:         getGrpcAmount(mp.EffectiveBalance),
+			NodeShareOfBeaconBalance: getGrpcAmount(mp.NodeShareOfBeaconBalance),
+			Slashed:                  mp.Slashed,
+			Delegate:                 mp.Delegate.Hex(),
+			UseLatestDelegate:        mp.UseLatestDelegate,
+		})
+	}
+	return grpcMinipools
+}
+
+// Convert an amount in wei to its decimal string
+func getGrpcAmount(amount *big.Int) string {
+	if amount == nil {
+		return "0"
+	}
+	return amount.String()
+}
@@ -0,0 +1,390 @@
+package node
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/gorilla/websocket"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/apiauth"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	netutils "github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+// The prefix of every HTTP API route
+const httpApiPrefix string = "/api/v1/"
+
+// The context key for the role of a request's caller
+type httpApiRoleKey struct{}
+
+// The body of a POST request to the HTTP API
+type httpApiRequest struct {
+	apiTxOptions
+	Args []string `json:"args"`
+}
+
+// Serve the API commands over HTTP on the socket in the data folder, and on a TCP port if one is configured
+func runHttpApiServer(c *cli.Context, logger log.ColorLogger, runner *apiRunner, events *eventHub) error {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	if !cfg.Smartnode.EnableHttpApi.Value.(bool) {
+		return nil
+	}
+
+	// Get the API commands
+	apiCommand := c.App.Command("api")
+	if apiCommand == nil {
+		return fmt.Errorf("The API commands are not available.")
+	}
+	handler, err := newHttpApiHandler(apiCommand, runner, events)
+	if err != nil {
+		return err
+	}
+
+	// Serve the socket; access to it is controlled by its file permissions
+	errs := make(chan error, 2)
+	socketPath := cfg.Smartnode.GetHttpApiSocketPath()
+	err = os.Remove(socketPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Error removing old HTTP API socket: %w", err)
+	}
+	socketListener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("Error creating HTTP API socket: %w", err)
+	}
+	err = os.Chmod(socketPath, 0660)
+	if err != nil {
+		return fmt.Errorf("Error setting HTTP API socket permissions: %w", err)
+	}
+	logger.Printlnf("Starting HTTP API on %s.", socketPath)
+	go func() {
+		errs <- http.Serve(socketListener, withHttpApiRole(handler, apiauth.Role_Admin))
+	}()
+
+	// Serve the TCP port, which requires an API token; it has to use TLS unless it's only reachable from this machine
+	port := cfg.Smartnode.HttpApiPort.Value.(uint16)
+	if port != 0 {
+		authenticator, err := newApiAuthenticator(cfg)
+		if err != nil {
+			return err
+		}
+		address := cfg.Smartnode.HttpApiAddress.Value.(string)
+		server := &http.Server{
+			Addr:    net.JoinHostPort(address, fmt.Sprint(port)),
+			Handler: requireHttpApiToken(handler, authenticator),
+		}
+		if netutils.IsLoopbackHost(address) {
+			logger.Printlnf("Starting HTTP API on %s.", server.Addr)
+			go func() {
+				errs <- server.ListenAndServe()
+			}()
+		} else {
+			certPath := cfg.Smartnode.GetHttpApiTlsCertPath()
+			keyPath := cfg.Smartnode.GetHttpApiTlsKeyPath()
+			for _, path := range []string{certPath, keyPath} {
+				if _, err := os.Stat(path); err != nil {
+					return fmt.Errorf("The HTTP API is bound to %s, which isn't a loopback address, so it requires TLS, but [%s] could not be read: %w", address, path, err)
+				}
+			}
+			logger.Printlnf("Starting HTTP API with TLS on %s.", server.Addr)
+			go func() {
+				errs <- server.ListenAndServeTLS(certPath, keyPath)
+			}()
+		}
+	}
+
+	err = <-errs
+	return fmt.Errorf("Error running HTTP API server: %w", err)
+
+}
+
+// Create the authenticator for the API TCP ports, which accepts the admin token and the tokens created with
+// `rocketpool service api-tokens create`
+func newApiAuthenticator(cfg *config.RocketPoolConfig) (*apiauth.Authenticator, error) {
+	adminToken, err := loadOrCreateApiToken(cfg.Smartnode.GetHttpApiTokenPath())
+	if err != nil {
+		return nil, err
+	}
+	return apiauth.NewAuthenticator(cfg.Smartnode.GetApiTokensPath(), adminToken), nil
+}
+
+// Load the admin token for the API TCP ports, creating it if it doesn't exist yet
+func loadOrCreateApiToken(path string) (string, error) {
+	bytes, err := os.ReadFile(path)
+	if err == nil {
+		return strings.TrimSpace(string(bytes)), nil
+	}
+	if !os.IsNotExist(err) {
+		return "", fmt.Errorf("Error reading HTTP API token: %w", err)
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", fmt.Errorf("Error creating HTTP API token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+	if err := os.WriteFile(path, []byte(token), 0600); err != nil {
+		return "", fmt.Errorf("Error saving HTTP API token: %w", err)
+	}
+	return token, nil
+}
+
+// Reject requests that don't have a valid API token as their bearer token, and give the others the token's role.
+// Browsers can't set headers on WebSockets, so the events socket can also take it as the `token` query parameter;
+// everywhere else it has to be in the header, so it doesn't end up in proxy and access logs.
+func requireHttpApiToken(handler http.Handler, authenticator *apiauth.Authenticator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" && r.URL.Path == httpApiPrefix+"events" && websocket.IsWebSocketUpgrade(r) {
+			token = r.URL.Query().Get("token")
+		}
+		role, valid := authenticator.Authenticate(token)
+		if !valid {
+			writeHttpApiError(w, http.StatusUnauthorized, errors.New("invalid API token"))
+			return
+		}
+		withHttpApiRole(handler, role).ServeHTTP(w, r)
+	})
+}
+
+// Give every request to a handler the provided role
+func withHttpApiRole(handler http.Handler, role apiauth.Role) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), httpApiRoleKey{}, role)))
+	})
+}
+
+// Create the handler for the HTTP API routes, which run each command the same way the CLI does
+func newHttpApiHandler(apiCommand *cli.Command, runner *apiRunner, events *eventHub) (http.Handler, error) {
+
+	// Get the commands and build the spec
+	commands := map[string]cli.Command{}
+	for _, group := range apiCommand.Subcommands {
+		for _, command := range group.Subcommands {
+			commands[group.Name+"/"+command.Name] = command
+		}
+	}
+	spec, err := json.MarshalIndent(getOpenApiSpec(apiCommand), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("Error serializing OpenAPI spec: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(httpApiPrefix+"openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(spec)
+	})
+	mux.HandleFunc(httpApiPrefix+"events", serveEventSocket(events))
+	mux.HandleFunc(httpApiPrefix, func(w http.ResponseWriter, r *http.Request) {
+		route := strings.TrimPrefix(r.URL.Path, httpApiPrefix)
+		command, exists := commands[route]
+		if !exists {
+			writeHttpApiError(w, http.StatusNotFound, fmt.Errorf("unknown API command '%s'", route))
+			return
+		}
+		role, _ := r.Context().Value(httpApiRoleKey{}).(apiauth.Role)
+		requiredRole := apiauth.GetCommandRole(strings.Split(route, "/")[0], command.Name)
+		if !role.Allows(requiredRole) {
+			writeHttpApiError(w, http.StatusForbidden, fmt.Errorf("%s requires the %s role", route, requiredRole))
+			return
+		}
+
+		// Get the request
+		var request httpApiRequest
+		switch r.Method {
+		case http.MethodGet:
+			if !apiauth.IsReadOnlyCommand(command.Name) {
+				writeHttpApiError(w, http.StatusMethodNotAllowed, fmt.Errorf("%s must be called with POST", route))
+				return
+			}
+			request.Args = r.URL.Query()["arg"]
+		case http.MethodPost:
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				writeHttpApiError(w, http.StatusBadRequest, fmt.Errorf("error decoding request: %w", err))
+				return
+			}
+		default:
+			writeHttpApiError(w, http.StatusMethodNotAllowed, fmt.Errorf("unsupported method %s", r.Method))
+			return
+		}
+
+		// Run the command
+		ctx := withApiClient(r.Context(), getHttpApiClient(r))
+		responseBytes, err := runner.run(ctx, request.apiTxOptions, append(strings.Split(route, "/"), request.Args...)...)
+		if errors.Is(err, errApiRateLimited) {
+			writeHttpApiError(w, http.StatusTooManyRequests, err)
+			return
+		}
+		if errors.Is(err, errApiQueueFull) {
+			writeHttpApiError(w, http.StatusServiceUnavailable, err)
+			return
+		}
+		if err != nil {
+			writeHttpApiError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		// Commands that fail still print a response with the error
+		var response api.APIResponse
+		status := http.StatusOK
+		if err := json.Unmarshal(responseBytes, &response); err != nil {
+			writeHttpApiError(w, http.StatusInternalServerError, fmt.Errorf("error decoding API response: %w", err))
+			return
+		}
+		if response.Error != "" {
+			status = http.StatusBadRequest
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write(responseBytes)
+	})
+	return mux, nil
+}
+
+// Get the address of the client making a request, for its rate limit
+func getHttpApiClient(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		// Requests over the socket don't have an address
+		return "socket"
+	}
+	return host
+}
+
+// Write an error in the same format as the API's own errors
+func writeHttpApiError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(api.APIResponse{
+		Status: "error",
+		Error:  err.Error(),
+	})
+}
+
+// Build the OpenAPI spec for the API commands
+func getOpenApiSpec(apiCommand *cli.Command) map[string]interface{} {
+	responseSchema := map[string]interface{}{
+		"$ref": "#/components/schemas/Response",
+	}
+	responses := map[string]interface{}{
+		"200": map[string]interface{}{
+			"description": "The command's response",
+			"content":     map[string]interface{}{"application/json": map[string]interface{}{"schema": responseSchema}},
+		},
+		"400": map[string]interface{}{
+			"description": "The command failed; the error is in the response",
+			"content":     map[string]interface{}{"application/json": map[string]interface{}{"schema": responseSchema}},
+		},
+	}
+
+	paths := map[string]interface{}{}
+	for _, group := range apiCommand.Subcommands {
+		for _, command := range group.Subcommands {
+			role := apiauth.GetCommandRole(group.Name, command.Name)
+			operations := map[string]interface{}{
+				"post": map[string]interface{}{
+					"operationId":     fmt.Sprintf("%s-%s", group.Name, command.Name),
+					"tags":            []string{group.Name},
+					"summary":         command.Usage,
+					"description":     fmt.Sprintf("Arguments: %s", command.UsageText),
+					"x-required-role": role,
+					"requestBody": map[string]interface{}{
+						"content": map[string]interface{}{"application/json": map[string]interface{}{
+							"schema": map[string]interface{}{"$ref": "#/components/schemas/Request"},
+						}},
+					},
+					"responses": responses,
+				},
+			}
+			if apiauth.IsReadOnlyCommand(command.Name) {
+				operations["get"] = map[string]interface{}{
+					"operationId":     fmt.Sprintf("get-%s-%s", group.Name, command.Name),
+					"tags":            []string{group.Name},
+					"summary":         command.Usage,
+					"description":     fmt.Sprintf("Arguments: %s", command.UsageText),
+					"x-required-role": role,
+					"parameters": []interface{}{map[string]interface{}{
+						"name":        "arg",
+						"in":          "query",
+						"description": "The command's arguments, in order",
+						"schema":      map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+						"style":       "form",
+						"explode":     true,
+					}},
+					"responses": responses,
+				}
+			}
+			paths[fmt.Sprintf("%s%s/%s", httpApiPrefix, group.Name, command.Name)] = operations
+		}
+	}
+	paths[httpApiPrefix+"events"] = map[string]interface{}{
+		"get": map[string]interface{}{
+			"operationId": "events",
+			"tags":        []string{"events"},
+			"summary":     "Push the node's events as JSON messages over a WebSocket",
+			"description": "Events are sent for minipool status changes, validators going offline or coming back online, the end of a rewards interval, and the node's transactions being included in a block.",
+			"parameters": []interface{}{map[string]interface{}{
+				"name":        "types",
+				"in":          "query",
+				"description": "A comma-separated list of the event types to send; all of them are sent if it's empty",
+				"schema":      map[string]interface{}{"type": "string"},
+			}},
+			"responses": map[string]interface{}{
+				"101": map[string]interface{}{"description": "Switching to the WebSocket protocol"},
+			},
+		},
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "Rocket Pool Smartnode API",
+			"version": shared.RocketPoolVersion,
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"securitySchemes": map[string]interface{}{
+				"token": map[string]interface{}{"type": "http", "scheme": "bearer"},
+			},
+			"schemas": map[string]interface{}{
+				"Request": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"args":       map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+						"maxFee":     map[string]interface{}{"type": "number", "description": "Max fee in gwei"},
+						"maxPrioFee": map[string]interface{}{"type": "number", "description": "Max priority fee in gwei"},
+						"gasLimit":   map[string]interface{}{"type": "integer"},
+						"nonce":      map[string]interface{}{"type": "string"},
+						"mode":       map[string]interface{}{"type": "string", "enum": []string{"unsigned", "dry-run", "deferred"}},
+					},
+				},
+				"Response": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"status": map[string]interface{}{"type": "string", "enum": []string{"success", "error"}},
+						"error":  map[string]interface{}{"type": "string"},
+					},
+					"additionalProperties": true,
+				},
+			},
+		},
+		"security": []interface{}{map[string]interface{}{"token": []string{}}},
+	}
+}
@@ -0,0 +1,68 @@
+package node
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Matches the ANSI color codes added by the color loggers
+var ansiColorPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// A line written to the daemon's log
+type logStreamLine struct {
+	time time.Time
+	line string
+}
+
+// Copies the daemon's log lines to any subscribers, so they can be streamed to API clients
+type logStream struct {
+	lock        sync.Mutex
+	subscribers map[chan logStreamLine]struct{}
+}
+
+// Create a new log stream with no subscribers
+func newLogStream() *logStream {
+	return &logStream{
+		subscribers: map[chan logStreamLine]struct{}{},
+	}
+}
+
+// Send the written lines to the subscribers, without their colors; subscribers that fall behind miss lines instead of
+// blocking the daemon
+func (s *logStream) Write(p []byte) (int, error) {
+	now := time.Now()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(s.subscribers) == 0 {
+		return len(p), nil
+	}
+
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		line = ansiColorPattern.ReplaceAllString(line, "")
+		for subscriber := range s.subscribers {
+			select {
+			case subscriber <- logStreamLine{time: now, line: line}:
+			default:
+			}
+		}
+	}
+	return len(p), nil
+}
+
+// Start receiving log lines
+func (s *logStream) subscribe() chan logStreamLine {
+	subscriber := make(chan logStreamLine, 256)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.subscribers[subscriber] = struct{}{}
+	return subscriber
+}
+
+// Stop receiving log lines
+func (s *logStream) unsubscribe(subscriber chan logStreamLine) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.subscribers, subscriber)
+}
@@ -0,0 +1,286 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/mev"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How many of a relay's latest delivered payloads to look through for the node's proposals
+	relayPayloadsLimit int = 100
+
+	// How long to wait for a relay's data API
+	relayDataTimeout time.Duration = 10 * time.Second
+
+	// How many slots to wait before checking if a delivered payload made it into a block
+	relayPayloadSlotDelay uint64 = 2
+
+	alertRule_MevRelayUnhealthy = "mev-relay-unhealthy"
+)
+
+// Manage MEV relays task
+type manageMevRelays struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	w           *wallet.Wallet
+	bc          *services.BeaconClientManager
+	alerts      *alerting.Manager
+	management  cfgtypes.MevRelayManagement
+	maxFailures uint64
+	maxLatency  time.Duration
+	disabled    bool
+}
+
+// Create manage MEV relays task
+func newManageMevRelays(c *cli.Context, logger log.ColorLogger) (*manageMevRelays, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := services.GetAlertManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if relay management is disabled
+	management := cfg.MevBoost.RelayManagement.Value.(cfgtypes.MevRelayManagement)
+	disabled := management == cfgtypes.MevRelayManagement_Off || management == cfgtypes.MevRelayManagement_Unknown
+	if !disabled && (cfg.EnableMevBoost.Value != true || cfg.MevBoost.Mode.Value.(cfgtypes.Mode) != cfgtypes.Mode_Local) {
+		logger.Println("MEV-Boost isn't managed by the Smartnode, disabling relay management.")
+		disabled = true
+	}
+	maxFailures := uint64(cfg.MevBoost.RelayMaxFailures.Value.(uint16))
+	if maxFailures == 0 {
+		maxFailures = 1
+	}
+
+	// Return task
+	return &manageMevRelays{
+		c:           c,
+		log:         logger,
+		cfg:         cfg,
+		w:           w,
+		bc:          bc,
+		alerts:      alerts,
+		management:  management,
+		maxFailures: maxFailures,
+		maxLatency:  time.Duration(cfg.MevBoost.RelayMaxLatency.Value.(uint16)) * time.Millisecond,
+		disabled:    disabled,
+	}, nil
+
+}
+
+// Check the performance of the enabled relays, and drop or restore them according to the relay management policy
+func (t *manageMevRelays) run(state *state.NetworkState) error {
+
+	// Check if relay management is disabled
+	if t.disabled {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking the performance of the MEV-Boost relays...")
+
+	// Load the record, forgetting relays that aren't enabled anymore
+	recordPath := os.ExpandEnv(t.cfg.Smartnode.GetMevRelayRecordPath())
+	record, err := mev.LoadRelayRecord(recordPath)
+	if err != nil {
+		return err
+	}
+	network := t.cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	relays := t.cfg.MevBoost.GetEnabledMevRelays()
+	enabled := map[cfgtypes.MevRelayID]bool{}
+	for _, relay := range relays {
+		enabled[relay.ID] = true
+		if _, exists := record.Relays[relay.ID]; !exists {
+			record.Relays[relay.ID] = &mev.RelayStats{Name: relay.Name}
+		}
+	}
+	for id := range record.Relays {
+		if !enabled[id] {
+			delete(record.Relays, id)
+		}
+	}
+
+	// Check that each relay is up and fast enough
+	for _, relay := range relays {
+		stats := record.Relays[relay.ID]
+		latency, err := mev.CheckRelayStatus(relay.Urls[network], t.maxLatency*2)
+		if err == nil && latency > t.maxLatency {
+			err = fmt.Errorf("responded in %d ms, slower than the %d ms limit", latency.Milliseconds(), t.maxLatency.Milliseconds())
+		}
+		stats.RecordCheck(latency, err)
+		if err != nil {
+			t.log.Printlnf("Relay %s failed its check (%d in a row): %s", relay.Name, stats.ConsecutiveFailures, err.Error())
+		}
+	}
+
+	// Credit the relays with the node's blocks they delivered, and blame them for the ones that were missed
+	if err := t.checkDeliveredPayloads(state, record, relays, network); err != nil {
+		t.log.Printlnf("Could not check the relays' delivered payloads: %s", err.Error())
+	}
+
+	// Apply the policy
+	unhealthy := []alerting.Alert{}
+	for _, relay := range relays {
+		stats := record.Relays[relay.ID]
+		if stats.Dropped {
+			if t.management == cfgtypes.MevRelayManagement_Automatic && stats.ConsecutiveSuccesses >= t.maxFailures {
+				t.restoreRelay(relay, stats)
+			}
+			continue
+		}
+		if stats.ConsecutiveFailures < t.maxFailures {
+			continue
+		}
+		if t.management == cfgtypes.MevRelayManagement_Automatic && t.dropRelay(record, relay, stats) {
+			continue
+		}
+		unhealthy = append(unhealthy, alerting.Alert{
+			Key:      string(relay.ID),
+			Severity: alerting.Severity_Warning,
+			Summary:  fmt.Sprintf("MEV-Boost relay %s has failed %d checks in a row (%s).", relay.Name, stats.ConsecutiveFailures, stats.LastError),
+		})
+	}
+	if err := t.alerts.Update(alertRule_MevRelayUnhealthy, unhealthy); err != nil {
+		t.log.Printlnf("Could not send the unhealthy relay alerts: %s", err.Error())
+	}
+
+	// Log the relays' performance
+	for _, relay := range relays {
+		stats := record.Relays[relay.ID]
+		status := "enabled"
+		if stats.Dropped {
+			status = "dropped"
+		}
+		t.log.Printlnf("%s (%s): %.0f ms average latency, %d/%d checks failed, %.0f%% win rate, %d missed slots", relay.Name, status, stats.LatencyMs, stats.FailedChecks, stats.Checks, record.GetWinRate(relay.ID)*100, stats.MissedSlots)
+	}
+
+	// Save the record
+	return record.Save(recordPath)
+
+}
+
+// Look through the relays' delivered payloads for the node's proposals since the last check
+func (t *manageMevRelays) checkDeliveredPayloads(state *state.NetworkState, record *mev.RelayRecord, relays []cfgtypes.MevRelay, network cfgtypes.Network) error {
+
+	// Get the node's validators by pubkey
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	validators := map[string]rptypes.ValidatorPubkey{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		validators[mpd.Pubkey.Hex()] = mpd.Pubkey
+	}
+	if len(validators) == 0 || state.BeaconSlotNumber < relayPayloadSlotDelay {
+		return nil
+	}
+	lastSlot := state.BeaconSlotNumber - relayPayloadSlotDelay
+
+	// Find the payloads each relay delivered to the node's validators
+	deliveries := map[uint64][]cfgtypes.MevRelay{}
+	proposers := map[uint64]rptypes.ValidatorPubkey{}
+	for _, relay := range relays {
+		payloads, err := mev.GetDeliveredPayloads(relay.Urls[network], relayPayloadsLimit, relayDataTimeout)
+		if err != nil {
+			t.log.Printlnf("Could not get the payloads relay %s delivered: %s", relay.Name, err.Error())
+			continue
+		}
+		for _, payload := range payloads {
+			pubkey, isNodeValidator := validators[strings.TrimPrefix(strings.ToLower(payload.ProposerPubkey), "0x")]
+			if !isNodeValidator || payload.Slot <= record.LastSlot || payload.Slot > lastSlot {
+				continue
+			}
+			deliveries[payload.Slot] = append(deliveries[payload.Slot], relay)
+			proposers[payload.Slot] = pubkey
+		}
+	}
+
+	// Check if each delivered payload made it into a block
+	for slot, slotRelays := range deliveries {
+		record.NodeProposals++
+		validator := state.ValidatorDetails[proposers[slot]]
+		block, found, err := t.bc.GetBeaconBlock(fmt.Sprint(slot))
+		if err != nil {
+			return fmt.Errorf("error getting the block for slot %d: %w", slot, err)
+		}
+		proposed := found && validator.Exists && block.ProposerIndex == validator.Index
+		for _, relay := range slotRelays {
+			stats := record.Relays[relay.ID]
+			if proposed {
+				stats.Wins++
+				t.log.Printlnf("Relay %s delivered the block for slot %d.", relay.Name, slot)
+				continue
+			}
+			stats.MissedSlots++
+			stats.RecordFailure(fmt.Sprintf("delivered a payload for slot %d that was missed", slot))
+			t.log.Printlnf("Relay %s delivered a payload for slot %d, but the slot was missed.", relay.Name, slot)
+		}
+	}
+	record.LastSlot = lastSlot
+	return nil
+
+}
+
+// Drop a relay that keeps failing, unless it's the last one left; returns true if it was dropped
+func (t *manageMevRelays) dropRelay(record *mev.RelayRecord, relay cfgtypes.MevRelay, stats *mev.RelayStats) bool {
+	remaining := 0
+	for _, other := range record.Relays {
+		if !other.Dropped {
+			remaining++
+		}
+	}
+	if remaining <= 1 {
+		t.log.Printlnf("Relay %s keeps failing, but it's the only relay left so it won't be dropped.", relay.Name)
+		return false
+	}
+
+	stats.Dropped = true
+	stats.DroppedReason = stats.LastError
+	stats.DroppedTime = time.Now().UTC()
+	message := fmt.Sprintf("Dropped MEV-Boost relay %s after it failed %d checks in a row (%s). Run 'rocketpool service start' to restart MEV-Boost without it.", relay.Name, stats.ConsecutiveFailures, stats.LastError)
+	t.log.Println(message)
+	if err := t.alerts.Notify("mev-relay-dropped", message); err != nil {
+		t.log.Printlnf("Could not send the relay notification: %s", err.Error())
+	}
+	return true
+}
+
+// Restore a dropped relay once it's been healthy for long enough
+func (t *manageMevRelays) restoreRelay(relay cfgtypes.MevRelay, stats *mev.RelayStats) {
+	stats.Dropped = false
+	stats.DroppedReason = ""
+	stats.DroppedTime = time.Time{}
+	message := fmt.Sprintf("Restored MEV-Boost relay %s after it passed %d checks in a row. Run 'rocketpool service start' to restart MEV-Boost with it.", relay.Name, stats.ConsecutiveSuccesses)
+	t.log.Println(message)
+	if err := t.alerts.Notify("mev-relay-restored", message); err != nil {
+		t.log.Printlnf("Could not send the relay notification: %s", err.Error())
+	}
+}
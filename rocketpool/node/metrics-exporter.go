@@ -14,7 +14,7 @@ import (
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker) error {
+func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker, dutyRecord *collectors.DutyRecord) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -41,6 +41,10 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	if err != nil {
 		return err
 	}
+	headWatcher, err := services.GetExecutionHeadWatcher(c)
+	if err != nil {
+		return err
+	}
 
 	// Return if metrics are disabled
 	if cfg.EnableMetrics.Value == false {
@@ -62,10 +66,14 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	supplyCollector := collectors.NewSupplyCollector(rp, stateLocker)
 	rplCollector := collectors.NewRplCollector(rp, cfg, stateLocker)
 	odaoCollector := collectors.NewOdaoCollector(rp, stateLocker)
-	nodeCollector := collectors.NewNodeCollector(rp, bc, nodeAccount.Address, cfg, stateLocker)
+	headWatcher.Start()
+	nodeCollector := collectors.NewNodeCollector(rp, bc, headWatcher, nodeAccount.Address, cfg, stateLocker)
+	stakeCollector := collectors.NewStakeCollector(nodeAccount.Address, stateLocker)
 	trustedNodeCollector := collectors.NewTrustedNodeCollector(rp, bc, nodeAccount.Address, cfg, stateLocker)
 	beaconCollector := collectors.NewBeaconCollector(rp, bc, ec, nodeAccount.Address, stateLocker)
-	smoothingPoolCollector := collectors.NewSmoothingPoolCollector(rp, ec, stateLocker)
+	smoothingPoolCollector := collectors.NewSmoothingPoolCollector(rp, ec, nodeAccount.Address, stateLocker)
+	clientHealthCollector := collectors.NewClientHealthCollector(ec, bc)
+	dutiesCollector := collectors.NewDutiesCollector(dutyRecord)
 
 	// Set up Prometheus
 	registry := prometheus.NewRegistry()
@@ -75,9 +83,13 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	registry.MustRegister(rplCollector)
 	registry.MustRegister(odaoCollector)
 	registry.MustRegister(nodeCollector)
+	registry.MustRegister(stakeCollector)
 	registry.MustRegister(trustedNodeCollector)
 	registry.MustRegister(beaconCollector)
 	registry.MustRegister(smoothingPoolCollector)
+	registry.MustRegister(clientHealthCollector)
+	registry.MustRegister(dutiesCollector)
+	registry.MustRegister(services.GetClientRequestMetrics())
 
 	// Set up snapshot checking if enabled
 	votingId := cfg.Smartnode.GetVotingSnapshotID()
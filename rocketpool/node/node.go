@@ -2,6 +2,8 @@ package node
 
 import (
 	"fmt"
+	"io"
+	stdlog "log"
 	"math/big"
 	"net/http"
 	"os"
@@ -16,7 +18,9 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/heartbeat"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/store"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
@@ -28,20 +32,36 @@ import (
 var tasksInterval, _ = time.ParseDuration("5m")
 var taskCooldown, _ = time.ParseDuration("10s")
 var totalEffectiveStakeCooldown, _ = time.ParseDuration("1h")
+var alertsInterval, _ = time.ParseDuration("1m")
 
 const (
 	MaxConcurrentEth1Requests = 200
 
 	StakePrelaunchMinipoolsColor = color.FgBlue
 	DownloadRewardsTreesColor    = color.FgGreen
+	PruneRewardsFilesColor       = color.FgCyan
 	MetricsColor                 = color.FgHiYellow
 	ManageFeeRecipientColor      = color.FgHiCyan
 	PromoteMinipoolsColor        = color.FgMagenta
 	ReduceBondAmountColor        = color.FgHiBlue
+	BeginBondReductionsColor     = color.FgHiBlue
+	AutoClaimRewardsColor        = color.FgHiGreen
+	AutoVoteColor                = color.FgMagenta
 	DistributeMinipoolsColor     = color.FgHiGreen
+	TrackSafeTransactionsColor   = color.FgHiMagenta
+	DetectStuckTransactionsColor = color.FgYellow
+	SubmitDeferredTxsColor       = color.FgHiCyan
+	DetectNodeEventsColor        = color.FgHiRed
+	DetectPenaltiesColor         = color.FgHiRed
+	ManageMevRelaysColor         = color.FgHiMagenta
+	ScheduledExitsColor          = color.FgHiYellow
 	ErrorColor                   = color.FgRed
 	WarningColor                 = color.FgYellow
 	UpdateColor                  = color.FgHiWhite
+	HttpApiColor                 = color.FgWhite
+	GrpcApiColor                 = color.FgHiBlack
+	CheckAlertsColor             = color.FgYellow
+	CheckValidatorDutiesColor    = color.FgGreen
 )
 
 // Register node command
@@ -96,6 +116,17 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	db, err := services.GetStore(c)
+	if err != nil {
+		return err
+	}
+	alerts, err := services.GetAlertManager(c)
+	if err != nil {
+		return err
+	}
+
+	// Keep a record of every alert and notification so they can be reviewed later
+	alerts.AddSink(store.NewAlertSink(db))
 
 	// Print the current mode
 	if cfg.IsNativeMode {
@@ -113,12 +144,31 @@ func run(c *cli.Context) error {
 	errorLog := log.NewColorLogger(ErrorColor)
 	updateLog := log.NewColorLogger(UpdateColor)
 
+	// Copy the log to the gRPC API's log streams
+	logs := newLogStream()
+	stdlog.SetOutput(io.MultiWriter(os.Stderr, logs))
+
+	// Automatic transactions can't be approved on a hardware wallet while nobody is watching it
+	autoTxEnabled := !w.IsHardwareWallet()
+	if !autoTxEnabled {
+		warningLog := log.NewColorLogger(WarningColor)
+		warningLog.Println("Your node account is on a hardware wallet, so automatic transactions (staking prelaunch minipools, distributing balances, reducing bonds and promoting minipools) are disabled. Please run them manually with the `rocketpool minipool` commands.")
+	}
+
 	// Create the state manager
 	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &updateLog)
 	if err != nil {
 		return err
 	}
 	stateLocker := collectors.NewStateLocker()
+	dutyRecord := collectors.NewDutyRecord()
+
+	// Subscribe to new epochs so the tasks run as soon as one starts
+	eventStream, err := services.GetBeaconEventStream(c)
+	if err != nil {
+		return err
+	}
+	epochEvents := eventStream.SubscribeEpochTransitions()
 
 	// Initialize tasks
 	manageFeeRecipient, err := newManageFeeRecipient(c, log.NewColorLogger(ManageFeeRecipientColor))
@@ -141,14 +191,79 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	pruneRewardsFiles, err := newPruneRewardsFiles(c, log.NewColorLogger(PruneRewardsFilesColor))
+	if err != nil {
+		return err
+	}
 	reduceBonds, err := newReduceBonds(c, log.NewColorLogger(ReduceBondAmountColor))
 	if err != nil {
 		return err
 	}
+	beginBondReductions, err := newBeginBondReductions(c, log.NewColorLogger(BeginBondReductionsColor))
+	if err != nil {
+		return err
+	}
+	autoClaimRewards, err := newAutoClaimRewards(c, log.NewColorLogger(AutoClaimRewardsColor))
+	if err != nil {
+		return err
+	}
+	autoVote, err := newAutoVote(c, log.NewColorLogger(AutoVoteColor))
+	if err != nil {
+		return err
+	}
+	trackSafeTransactions, err := newTrackSafeTransactions(c, log.NewColorLogger(TrackSafeTransactionsColor))
+	if err != nil {
+		return err
+	}
+	detectStuckTransactions, err := newDetectStuckTransactions(c, log.NewColorLogger(DetectStuckTransactionsColor))
+	if err != nil {
+		return err
+	}
+	submitDeferredTransactions, err := newSubmitDeferredTransactions(c, log.NewColorLogger(SubmitDeferredTxsColor))
+	if err != nil {
+		return err
+	}
+	events := newEventHub()
+	runner, err := newApiRunner(c)
+	if err != nil {
+		return err
+	}
+	if snapshot := loadWarmStartState(cfg, nodeAccount.Address, &updateLog); snapshot != nil {
+		runner.startWarmStart(snapshot)
+	}
+	detectNodeEvents, err := newDetectNodeEvents(c, log.NewColorLogger(DetectNodeEventsColor), events)
+	if err != nil {
+		return err
+	}
+	detectPenalties, err := newDetectPenalties(c, log.NewColorLogger(DetectPenaltiesColor))
+	if err != nil {
+		return err
+	}
+	manageMevRelays, err := newManageMevRelays(c, log.NewColorLogger(ManageMevRelaysColor))
+	if err != nil {
+		return err
+	}
+	executeScheduledExits, err := newExecuteScheduledExits(c, log.NewColorLogger(ScheduledExitsColor))
+	if err != nil {
+		return err
+	}
+	checkAlerts, err := newCheckAlerts(c, log.NewColorLogger(CheckAlertsColor), stateLocker)
+	if err != nil {
+		return err
+	}
+	checkValidatorDuties, err := newCheckValidatorDuties(c, log.NewColorLogger(CheckValidatorDutiesColor), dutyRecord)
+	if err != nil {
+		return err
+	}
+
+	// Tell the operator's dead man's switches when a duty cycle finishes, and when all of its tasks succeed
+	heartbeatLog := log.NewColorLogger(WarningColor)
+	daemonHeartbeat := heartbeat.NewHeartbeat("daemon", cfg.Smartnode.HeartbeatUrl.Value.(string), &heartbeatLog)
+	tasksHeartbeat := heartbeat.NewHeartbeat("node tasks", cfg.Smartnode.NodeTasksHeartbeatUrl.Value.(string), &heartbeatLog)
 
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
-	wg.Add(2)
+	wg.Add(5)
 
 	// Timestamp for caching total effective RPL stake
 	lastTotalEffectiveStakeTime := time.Unix(0, 0)
@@ -172,6 +287,14 @@ func run(c *cli.Context) error {
 				continue
 			}
 
+			// Defer the tasks if every client's circuit breaker is open
+			err = services.CheckClientsAvailable(c)
+			if err != nil {
+				errorLog.Println(err)
+				time.Sleep(taskCooldown)
+				continue
+			}
+
 			// Update the network state
 			updateTotalEffectiveStake := false
 			if time.Since(lastTotalEffectiveStakeTime) > totalEffectiveStakeCooldown {
@@ -185,57 +308,182 @@ func run(c *cli.Context) error {
 				continue
 			}
 			stateLocker.UpdateState(state, totalEffectiveStake)
+			runner.endWarmStart()
+			if err := saveWarmStartState(cfg, state, nodeAccount.Address); err != nil {
+				errorLog.Println(err)
+			}
+
+			// Publish the node's events for the event stream
+			if err := detectNodeEvents.run(state); err != nil {
+				errorLog.Println(err)
+				tasksHeartbeat.Fail()
+			}
+
+			// Check for penalties against the node's minipools and write dispute reports for them
+			if err := detectPenalties.run(state); err != nil {
+				errorLog.Println(err)
+				tasksHeartbeat.Fail()
+			}
+
+			// Check the validators' attestations and proposals in the epochs that have passed
+			if err := checkValidatorDuties.run(state); err != nil {
+				errorLog.Println(err)
+				tasksHeartbeat.Fail()
+			}
 
 			// Manage the fee recipient for the node
 			if err := manageFeeRecipient.run(state); err != nil {
 				errorLog.Println(err)
+				tasksHeartbeat.Fail()
 			}
 			time.Sleep(taskCooldown)
 
 			// Run the rewards download check
 			if err := downloadRewardsTrees.run(state); err != nil {
 				errorLog.Println(err)
+				tasksHeartbeat.Fail()
+			}
+			time.Sleep(taskCooldown)
+
+			// Run the rewards file pruning check
+			if err := pruneRewardsFiles.run(state); err != nil {
+				errorLog.Println(err)
+				tasksHeartbeat.Fail()
+			}
+			time.Sleep(taskCooldown)
+
+			// Check on transactions proposed to the node's Safe
+			if err := trackSafeTransactions.run(); err != nil {
+				errorLog.Println(err)
+				tasksHeartbeat.Fail()
 			}
 			time.Sleep(taskCooldown)
 
-			// Run the minipool stake check
-			if err := stakePrelaunchMinipools.run(state); err != nil {
+			// Check for node transactions that are stuck in the transaction pool
+			if err := detectStuckTransactions.run(); err != nil {
 				errorLog.Println(err)
+				tasksHeartbeat.Fail()
 			}
 			time.Sleep(taskCooldown)
 
-			// Run the balance distribution check
-			if err := distributeMinipools.run(state); err != nil {
+			// Submit deferred transactions once fees are low enough for them
+			if err := submitDeferredTransactions.run(); err != nil {
 				errorLog.Println(err)
+				tasksHeartbeat.Fail()
 			}
 			time.Sleep(taskCooldown)
 
-			// Run the reduce bond check
-			if err := reduceBonds.run(state); err != nil {
+			// Broadcast the scheduled minipool exits that are due, and track the broadcast ones
+			if err := executeScheduledExits.run(state); err != nil {
 				errorLog.Println(err)
+				tasksHeartbeat.Fail()
 			}
 			time.Sleep(taskCooldown)
 
-			// Run the minipool promotion check
-			if err := promoteMinipools.run(state); err != nil {
+			// Track the MEV-Boost relays' performance and apply the relay management policy
+			if err := manageMevRelays.run(state); err != nil {
 				errorLog.Println(err)
+				tasksHeartbeat.Fail()
+			}
+			time.Sleep(taskCooldown)
+
+			// Vote on Protocol DAO proposals according to the auto-vote policy
+			if err := autoVote.run(state); err != nil {
+				errorLog.Println(err)
+				tasksHeartbeat.Fail()
+			}
+			time.Sleep(taskCooldown)
+
+			if autoTxEnabled {
+				// Run the minipool stake check
+				if err := stakePrelaunchMinipools.run(state); err != nil {
+					errorLog.Println(err)
+					tasksHeartbeat.Fail()
+				}
+				time.Sleep(taskCooldown)
+
+				// Run the balance distribution check
+				if err := distributeMinipools.run(state); err != nil {
+					errorLog.Println(err)
+					tasksHeartbeat.Fail()
+				}
+				time.Sleep(taskCooldown)
+
+				// Run the reduce bond check
+				if err := reduceBonds.run(state); err != nil {
+					errorLog.Println(err)
+					tasksHeartbeat.Fail()
+				}
+				time.Sleep(taskCooldown)
+
+				// Begin reducing the bonds of eligible minipools
+				if err := beginBondReductions.run(state); err != nil {
+					errorLog.Println(err)
+					tasksHeartbeat.Fail()
+				}
+				time.Sleep(taskCooldown)
+
+				// Claim rewards according to the auto-claim policy
+				if err := autoClaimRewards.run(state); err != nil {
+					errorLog.Println(err)
+					tasksHeartbeat.Fail()
+				}
+				time.Sleep(taskCooldown)
+
+				// Run the minipool promotion check
+				if err := promoteMinipools.run(state); err != nil {
+					errorLog.Println(err)
+					tasksHeartbeat.Fail()
+				}
 			}
+			daemonHeartbeat.Ping()
+			tasksHeartbeat.Ping()
 
-			time.Sleep(tasksInterval)
+			// Wait for the next epoch, falling back to the timer if the event stream is unavailable
+			services.WaitForBeaconEvent(epochEvents, tasksInterval)
 		}
 		wg.Done()
 	}()
 
+	// Run the alerts loop separately, so alerts still go out when the task loop is stuck waiting for the clients
+	go func() {
+		defer wg.Done()
+		for {
+			if err := checkAlerts.run(); err != nil {
+				errorLog.Println(err)
+			}
+			time.Sleep(alertsInterval)
+		}
+	}()
+
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), stateLocker)
+		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), stateLocker, dutyRecord)
+		if err != nil {
+			errorLog.Println(err)
+		}
+		wg.Done()
+	}()
+
+	// Run the HTTP API server
+	go func() {
+		err := runHttpApiServer(c, log.NewColorLogger(HttpApiColor), runner, events)
+		if err != nil {
+			errorLog.Println(err)
+		}
+		wg.Done()
+	}()
+
+	// Run the gRPC API server
+	go func() {
+		err := runGrpcApiServer(c, log.NewColorLogger(GrpcApiColor), runner, logs)
 		if err != nil {
 			errorLog.Println(err)
 		}
 		wg.Done()
 	}()
 
-	// Wait for both threads to stop
+	// Wait for all threads to stop
 	wg.Wait()
 	return nil
 
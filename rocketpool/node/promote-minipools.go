@@ -17,6 +17,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
@@ -33,6 +34,7 @@ type promoteMinipools struct {
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
 	d              *client.Client
+	alerts         *alerting.Manager
 	gasThreshold   float64
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
@@ -59,6 +61,10 @@ func newPromoteMinipools(c *cli.Context, logger log.ColorLogger) (*promoteMinipo
 	if err != nil {
 		return nil, err
 	}
+	alerts, err := services.GetAlertManager(c)
+	if err != nil {
+		return nil, err
+	}
 
 	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
 
@@ -89,6 +95,7 @@ func newPromoteMinipools(c *cli.Context, logger log.ColorLogger) (*promoteMinipo
 		w:              w,
 		rp:             rp,
 		d:              d,
+		alerts:         alerts,
 		gasThreshold:   gasThreshold,
 		maxFee:         maxFee,
 		maxPriorityFee: priorityFee,
@@ -211,7 +218,7 @@ func (t *promoteMinipools) promoteMinipool(mpd *rpstate.NativeMinipoolDetails, c
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return false, err
 		}
@@ -251,6 +258,9 @@ func (t *promoteMinipools) promoteMinipool(mpd *rpstate.NativeMinipoolDetails, c
 
 	// Log
 	t.log.Printlnf("Successfully promoted minipool %s.", mpd.MinipoolAddress.Hex())
+	if err := t.alerts.Notify("minipool-promoted", fmt.Sprintf("Minipool %s was promoted.", mpd.MinipoolAddress.Hex())); err != nil {
+		t.log.Printlnf("Could not send the promotion notification: %s", err.Error())
+	}
 
 	// Return
 	return true, nil
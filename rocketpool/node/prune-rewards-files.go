@@ -0,0 +1,169 @@
+package node
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Prune rewards files task
+type pruneRewardsFiles struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	w   *wallet.Wallet
+	rp  *rocketpool.RocketPool
+}
+
+// Create prune rewards files task
+func newPruneRewardsFiles(c *cli.Context, logger log.ColorLogger) (*pruneRewardsFiles, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &pruneRewardsFiles{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		w:   w,
+		rp:  rp,
+	}, nil
+
+}
+
+// Delete the files of old intervals that the node doesn't need anymore
+func (p *pruneRewardsFiles) run(state *state.NetworkState) error {
+
+	// Check if pruning is enabled
+	retentionCount := p.cfg.Smartnode.RewardsFileRetentionCount.Value.(uint64)
+	if retentionCount == 0 {
+		return nil
+	}
+
+	// Get the intervals outside of the retention window
+	currentIndex := state.NetworkDetails.RewardIndex
+	if currentIndex <= retentionCount {
+		return nil
+	}
+	lastPrunableIndex := currentIndex - retentionCount
+
+	// Get node account
+	nodeAccount, err := p.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the intervals the node has claimed
+	_, claimed, err := rprewards.GetClaimStatus(p.rp, nodeAccount.Address)
+	if err != nil {
+		return fmt.Errorf("error getting rewards claim status: %w", err)
+	}
+	claimedIntervals := map[uint64]bool{}
+	for _, interval := range claimed {
+		claimedIntervals[interval] = true
+	}
+
+	for i := uint64(0); i < lastPrunableIndex; i++ {
+		// Skip intervals that were already pruned
+		markerPath := p.cfg.Smartnode.GetPrunedRewardsFileMarkerPath(i, true)
+		_, err = os.Stat(markerPath)
+		if err == nil {
+			continue
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("error checking if rewards interval %d was pruned: %w", i, err)
+		}
+
+		// Make sure the interval's file is present and valid, so its rewards can be recorded before pruning
+		intervalInfo, err := rprewards.GetIntervalInfo(p.rp, p.cfg, nodeAccount.Address, i, nil)
+		if err != nil {
+			return fmt.Errorf("error getting interval %d info: %w", i, err)
+		}
+		if !intervalInfo.TreeFileExists || !intervalInfo.MerkleRootValid {
+			continue
+		}
+
+		// Keep intervals that still have rewards to claim
+		if !claimedIntervals[i] && intervalInfo.NodeExists && hasRewards(intervalInfo) {
+			continue
+		}
+
+		// Record the node's rewards so they're still available after the files are gone
+		err = rprewards.SavePrunedIntervalRecord(p.cfg, intervalInfo, nodeAccount.Address)
+		if err != nil {
+			return err
+		}
+
+		// Delete the files
+		deleted, err := p.deleteIntervalFiles(i)
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			p.log.Printlnf("Pruned %d rewards files for interval %d.", deleted, i)
+		}
+	}
+
+	return nil
+
+}
+
+// Delete every file belonging to an interval, returning how many were removed
+func (p *pruneRewardsFiles) deleteIntervalFiles(interval uint64) (int, error) {
+	rewardsTreePath := p.cfg.Smartnode.GetRewardsTreePath(interval, true)
+	minipoolPerformancePath := p.cfg.Smartnode.GetMinipoolPerformancePath(interval, true)
+	paths := []string{
+		rewardsTreePath,
+		rewardsTreePath + config.RewardsTreeIpfsExtension,
+		p.cfg.Smartnode.GetRewardsTreeSszPath(interval, true),
+		minipoolPerformancePath,
+		minipoolPerformancePath + config.RewardsTreeIpfsExtension,
+		p.cfg.Smartnode.GetMinipoolPerformanceSszPath(interval, true),
+		p.cfg.Smartnode.GetRewardsPinRecordPath(interval, true),
+		p.cfg.Smartnode.GetRewardsArweaveRecordPath(interval, true),
+		p.cfg.Smartnode.GetMinipoolAttributionPath(interval, true),
+	}
+
+	deleted := 0
+	for _, path := range paths {
+		err := os.Remove(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return deleted, fmt.Errorf("error deleting %s: %w", path, err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// Check if an interval has any rewards for the node
+func hasRewards(info rprewards.IntervalInfo) bool {
+	for _, amount := range []*rprewards.QuotedBigInt{info.CollateralRplAmount, info.ODaoRplAmount, info.SmoothingPoolEthAmount} {
+		if amount != nil && amount.Sign() > 0 {
+			return true
+		}
+	}
+	return false
+}
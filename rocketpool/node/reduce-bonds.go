@@ -19,6 +19,7 @@ import (
 
 	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
@@ -35,6 +36,7 @@ type reduceBonds struct {
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
 	d              *client.Client
+	alerts         *alerting.Manager
 	gasThreshold   float64
 	disabled       bool
 	maxFee         *big.Int
@@ -71,6 +73,10 @@ func newReduceBonds(c *cli.Context, logger log.ColorLogger) (*reduceBonds, error
 	if err != nil {
 		return nil, err
 	}
+	alerts, err := services.GetAlertManager(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Check if auto-bond-reduction is disabled
 	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
@@ -107,6 +113,7 @@ func newReduceBonds(c *cli.Context, logger log.ColorLogger) (*reduceBonds, error
 		w:              w,
 		rp:             rp,
 		d:              d,
+		alerts:         alerts,
 		gasThreshold:   gasThreshold,
 		disabled:       disabled,
 		maxFee:         maxFee,
@@ -267,7 +274,7 @@ func (t *reduceBonds) forceFeeDistribution() (bool, error) {
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return false, err
 		}
@@ -377,7 +384,7 @@ func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, windowStart
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return false, err
 		}
@@ -415,6 +422,9 @@ func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, windowStart
 
 	// Log
 	t.log.Printlnf("Successfully reduced bond for minipool %s.", mpd.MinipoolAddress.Hex())
+	if err := t.alerts.Notify("bond-reduced", fmt.Sprintf("The bond of minipool %s was reduced.", mpd.MinipoolAddress.Hex())); err != nil {
+		t.log.Printlnf("Could not send the bond reduction notification: %s", err.Error())
+	}
 
 	// Return
 	return true, nil
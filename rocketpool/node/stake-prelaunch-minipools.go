@@ -25,6 +25,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
+	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
 )
 
 // Stake prelaunch minipools task
@@ -214,12 +215,8 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetai
 	// Get minipool withdrawal credentials
 	withdrawalCredentials := mpd.WithdrawalCredentials
 
-	// Get the validator key for the minipool
+	// Get the validator pubkey for the minipool
 	validatorPubkey := mpd.Pubkey
-	validatorKey, err := t.w.GetValidatorKeyByPubkey(validatorPubkey)
-	if err != nil {
-		return false, err
-	}
 
 	// Get the minipool type
 	depositType := mpd.DepositType
@@ -235,13 +232,13 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetai
 	}
 
 	// Get validator deposit data
-	depositData, depositDataRoot, err := validator.GetDepositData(validatorKey, withdrawalCredentials, state.BeaconConfig, depositAmount)
+	depositData, depositDataRoot, err := walletutils.GetMinipoolDepositData(t.cfg, t.w, validatorPubkey, withdrawalCredentials, state.BeaconConfig, depositAmount)
 	if err != nil {
 		return false, err
 	}
 
-	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	// Get transactor; staking can't wait behind deferred transactions, so it takes the next nonce even if one of them holds it
+	opts, err := t.w.GetUrgentNodeAccountTransactor()
 	if err != nil {
 		return false, err
 	}
@@ -262,7 +259,7 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetai
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return false, err
 		}
@@ -0,0 +1,182 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	alertRule_DeferredTransactionDropped = "deferred-transaction-dropped"
+)
+
+// Submit deferred transactions task
+type submitDeferredTransactions struct {
+	c      *cli.Context
+	log    log.ColorLogger
+	cfg    *config.RocketPoolConfig
+	w      *wallet.Wallet
+	ec     *services.ExecutionClientManager
+	alerts *alerting.Manager
+}
+
+// Create submit deferred transactions task
+func newSubmitDeferredTransactions(c *cli.Context, logger log.ColorLogger) (*submitDeferredTransactions, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := services.GetAlertManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &submitDeferredTransactions{
+		c:      c,
+		log:    logger,
+		cfg:    cfg,
+		w:      w,
+		ec:     ec,
+		alerts: alerts,
+	}, nil
+
+}
+
+// Submit the node's deferred transactions, in nonce order, once the network's fees are low enough for their max fees
+func (t *submitDeferredTransactions) run() error {
+
+	deferredTxsPath := os.ExpandEnv(t.cfg.Smartnode.GetDeferredTxsPath())
+	deferredTxs, err := wallet.LoadDeferredTransactions(deferredTxsPath)
+	if err != nil {
+		return err
+	}
+	if len(deferredTxs) == 0 {
+		return nil
+	}
+	sort.Slice(deferredTxs, func(i, j int) bool {
+		return deferredTxs[i].Nonce < deferredTxs[j].Nonce
+	})
+
+	// Get the node's nonces and the current base fee
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	latestNonce, err := t.ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		return fmt.Errorf("error getting latest nonce: %w", err)
+	}
+	pendingNonce, err := t.ec.PendingNonceAt(context.Background(), nodeAccount.Address)
+	if err != nil {
+		return fmt.Errorf("error getting pending nonce: %w", err)
+	}
+	header, err := t.ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("error getting latest block header: %w", err)
+	}
+	baseFee := header.BaseFee
+	if baseFee == nil {
+		baseFee = big.NewInt(0)
+	}
+
+	// Once a transaction is dropped, the ones queued behind it can never be submitted either
+	var droppedNonce *uint64
+	now := time.Now()
+	for _, deferredTx := range deferredTxs {
+		if deferredTx.From != nodeAccount.Address {
+			t.log.Printlnf("Deferred transaction %s is from %s, which isn't the node account; skipping it.", deferredTx.Hash.Hex(), deferredTx.From.Hex())
+			continue
+		}
+
+		// Another transaction was included with this nonce, so this one can never be
+		if deferredTx.Nonce < latestNonce {
+			message := fmt.Sprintf("Discarded deferred transaction %s because another transaction with its nonce (%d) has already been included; it was never submitted, so sign it again if it's still needed.", deferredTx.Hash.Hex(), deferredTx.Nonce)
+			if err := t.dropDeferredTransaction(deferredTxsPath, deferredTx.Hash, message); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Stop waiting for the fees once the transaction's deadline has passed, so it releases its nonce
+		if droppedNonce == nil && !now.Before(wallet.GetDeferredTransactionDeadline(deferredTx)) {
+			message := fmt.Sprintf("Dropped deferred transaction %s because the fees didn't drop low enough for its max fee of %.2f gwei before its deadline; it was never submitted, so sign it again if it's still needed.", deferredTx.Hash.Hex(), eth.WeiToGwei(deferredTx.MaxFeePerGas))
+			if err := t.dropDeferredTransaction(deferredTxsPath, deferredTx.Hash, message); err != nil {
+				return err
+			}
+			nonce := deferredTx.Nonce
+			droppedNonce = &nonce
+			continue
+		}
+		if droppedNonce != nil {
+			message := fmt.Sprintf("Dropped deferred transaction %s because it was queued behind deferred transaction nonce %d, which passed its deadline; it was never submitted, so sign it again if it's still needed.", deferredTx.Hash.Hex(), *droppedNonce)
+			if err := t.dropDeferredTransaction(deferredTxsPath, deferredTx.Hash, message); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Wait for the transactions before this one
+		if deferredTx.Nonce != pendingNonce {
+			return nil
+		}
+
+		// Wait for the fees to drop low enough
+		requiredFee := big.NewInt(0).Add(baseFee, deferredTx.MaxPriorityFee)
+		if requiredFee.Cmp(deferredTx.MaxFeePerGas) > 0 {
+			t.log.Printlnf("Waiting to submit deferred transaction %s: the base fee is %.2f gwei, which is too high for its max fee of %.2f gwei.", deferredTx.Hash.Hex(), eth.WeiToGwei(baseFee), eth.WeiToGwei(deferredTx.MaxFeePerGas))
+			return nil
+		}
+
+		// Submit it
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(deferredTx.SignedTx); err != nil {
+			return fmt.Errorf("error deserializing deferred transaction %s: %w", deferredTx.Hash.Hex(), err)
+		}
+		if err := t.ec.SendTransaction(context.Background(), &tx); err != nil {
+			return fmt.Errorf("error submitting deferred transaction %s: %w", deferredTx.Hash.Hex(), err)
+		}
+		t.log.Printlnf("Submitted deferred transaction %s with the base fee at %.2f gwei.", deferredTx.Hash.Hex(), eth.WeiToGwei(baseFee))
+		if err := wallet.RemoveDeferredTransaction(deferredTxsPath, deferredTx.Hash); err != nil {
+			return err
+		}
+		pendingNonce++
+	}
+
+	return nil
+
+}
+
+// Remove a deferred transaction that will never be submitted and alert the node operator
+func (t *submitDeferredTransactions) dropDeferredTransaction(deferredTxsPath string, hash common.Hash, message string) error {
+	t.log.Println(message)
+	if err := t.alerts.Report(alertRule_DeferredTransactionDropped, alerting.Severity_Warning, message); err != nil {
+		t.log.Printlnf("Could not send the discarded deferred transaction alert: %s", err.Error())
+	}
+	return wallet.RemoveDeferredTransaction(deferredTxsPath, hash)
+}
@@ -0,0 +1,108 @@
+package node
+
+import (
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/safe"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Track Safe transactions task
+type trackSafeTransactions struct {
+	c             *cli.Context
+	log           log.ColorLogger
+	cfg           *config.RocketPoolConfig
+	client        *safe.Client
+	proposalsPath string
+}
+
+// Create track Safe transactions task
+func newTrackSafeTransactions(c *cli.Context, logger log.ColorLogger) (*trackSafeTransactions, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only track proposals if the node account is a Safe
+	var client *safe.Client
+	safeAddress := cfg.Smartnode.SafeAddress.Value.(string)
+	if safeAddress != "" {
+		client = safe.NewClient(cfg.Smartnode.SafeTransactionServiceUrl.Value.(string), common.HexToAddress(safeAddress), w.GetChainID())
+	}
+
+	// Return task
+	return &trackSafeTransactions{
+		c:             c,
+		log:           logger,
+		cfg:           cfg,
+		client:        client,
+		proposalsPath: os.ExpandEnv(cfg.Smartnode.GetSafeProposalsPath()),
+	}, nil
+
+}
+
+// Check on the transactions that were proposed to the Safe, and stop tracking the ones that were executed or replaced
+func (t *trackSafeTransactions) run() error {
+
+	if t.client == nil {
+		return nil
+	}
+
+	proposals, err := safe.LoadProposals(t.proposalsPath)
+	if err != nil {
+		return err
+	}
+	if len(proposals) == 0 {
+		return nil
+	}
+
+	// Log
+	t.log.Printlnf("Checking %d transaction(s) proposed to the Safe...", len(proposals))
+
+	info, err := t.client.GetSafeInfo()
+	if err != nil {
+		return err
+	}
+	for _, proposal := range proposals {
+		tx, err := t.client.GetTransaction(proposal.SafeTxHash)
+		if err != nil {
+			t.log.Printlnf("WARNING: couldn't check Safe transaction %s: %s", proposal.SafeTxHash.Hex(), err.Error())
+			continue
+		}
+
+		switch {
+		case tx.IsExecuted && tx.TransactionHash != nil:
+			if tx.IsSuccessful != nil && !*tx.IsSuccessful {
+				t.log.Printlnf("Safe transaction %s (nonce %d) was executed in transaction %s, but it reverted.", proposal.SafeTxHash.Hex(), proposal.Nonce, tx.TransactionHash.Hex())
+			} else {
+				t.log.Printlnf("Safe transaction %s (nonce %d) was successfully executed in transaction %s.", proposal.SafeTxHash.Hex(), proposal.Nonce, tx.TransactionHash.Hex())
+			}
+
+		case info.Nonce > proposal.Nonce:
+			t.log.Printlnf("Safe transaction %s (nonce %d) was replaced by another transaction with the same nonce and will not be executed.", proposal.SafeTxHash.Hex(), proposal.Nonce)
+
+		default:
+			t.log.Printlnf("Safe transaction %s (nonce %d) is waiting for approval (%d of %d confirmations).", proposal.SafeTxHash.Hex(), proposal.Nonce, len(tx.Confirmations), tx.ConfirmationsRequired)
+			continue
+		}
+
+		err = safe.RemoveProposal(t.proposalsPath, proposal.SafeTxHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+
+}
@@ -0,0 +1,46 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The oldest persisted state the daemon will answer API commands from while it builds a fresh one
+var warmStartMaxAge, _ = time.ParseDuration("24h")
+
+// Load the state the daemon persisted before it last stopped, if it belongs to this node and is recent enough to be useful
+func loadWarmStartState(cfg *config.RocketPoolConfig, nodeAddress common.Address, logger *log.ColorLogger) *state.StateSnapshot {
+	path := cfg.Smartnode.GetWarmStartStatePath()
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	snapshot, err := state.LoadStateSnapshot(path)
+	if err != nil {
+		logger.Printlnf("WARNING: Couldn't load the persisted network state, so API commands will wait for a fresh one: %s", err.Error())
+		return nil
+	}
+	if snapshot.NodeAddress != nodeAddress || snapshot.SmartnodeVersion != shared.RocketPoolVersion || time.Since(snapshot.Created) > warmStartMaxAge {
+		return nil
+	}
+
+	logger.Printlnf("Answering read-only API commands from the persisted network state of block %d (created %s) until a fresh one is built.", snapshot.ElBlockNumber, snapshot.Created.Local().Format(time.RFC1123))
+	return snapshot
+}
+
+// Persist the latest network state so the next time the daemon starts, it can answer API commands right away
+func saveWarmStartState(cfg *config.RocketPoolConfig, networkState *state.NetworkState, nodeAddress common.Address) error {
+	err := state.NewStateSnapshot(networkState, nodeAddress).Save(cfg.Smartnode.GetWarmStartStatePath())
+	if err != nil {
+		return fmt.Errorf("error persisting network state for the next warm start: %w", err)
+	}
+	return nil
+}
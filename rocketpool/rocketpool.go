@@ -66,6 +66,18 @@ func main() {
 			Name:  "nonce",
 			Usage: "Use this flag to explicitly specify the nonce that this transaction should use, so it can override an existing 'stuck' transaction",
 		},
+		cli.BoolFlag{
+			Name:  "unsigned",
+			Usage: "Save transactions unsigned instead of signing and submitting them",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Simulate transactions and save the results instead of signing and submitting them",
+		},
+		cli.BoolFlag{
+			Name:  "deferred",
+			Usage: "Sign transactions and save them for the node daemon to submit once fees are low enough instead of submitting them",
+		},
 		cli.StringFlag{
 			Name:  "metricsAddress, m",
 			Usage: "Address to serve metrics on if enabled",
@@ -17,6 +17,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/lease"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -40,10 +41,11 @@ type cancelBondReductions struct {
 	lock             *sync.Mutex
 	isRunning        bool
 	generationPrefix string
+	dutyLease        *lease.Lease
 }
 
 // Create cancel bond reductions task
-func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.BondReductionCollector) (*cancelBondReductions, error) {
+func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.BondReductionCollector, dutyLease *lease.Lease) (*cancelBondReductions, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -66,6 +68,7 @@ func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger
 	// Return task
 	lock := &sync.Mutex{}
 	return &cancelBondReductions{
+		dutyLease:        dutyLease,
 		c:                c,
 		log:              logger,
 		errLog:           errorLogger,
@@ -220,7 +223,7 @@ func (t *cancelBondReductions) cancelBondReduction(address common.Address, reaso
 	t.printMessage("=================================")
 
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := utils.GetWatchtowerTransactor(t.w, t.dutyLease)
 	if err != nil {
 		t.printMessage(fmt.Sprintf("error getting node account transactor: %s", err.Error()))
 		return
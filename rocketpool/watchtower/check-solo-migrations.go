@@ -17,6 +17,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/lease"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -25,10 +26,9 @@ import (
 )
 
 const (
-	soloMigrationCheckThreshold float64 = 0.85 // Fraction of PromotionStakePeriod that can go before a minipool gets scrubbed for not having changed to 0x01
-	blsPrefix                   byte    = 0x00
-	elPrefix                    byte    = 0x01
-	migrationBalanceBuffer      float64 = 0.01
+	blsPrefix              byte    = 0x00
+	elPrefix               byte    = 0x01
+	migrationBalanceBuffer float64 = 0.01
 )
 
 type checkSoloMigrations struct {
@@ -44,10 +44,11 @@ type checkSoloMigrations struct {
 	lock             *sync.Mutex
 	isRunning        bool
 	generationPrefix string
+	dutyLease        *lease.Lease
 }
 
 // Create check solo migrations task
-func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.SoloMigrationCollector) (*checkSoloMigrations, error) {
+func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.SoloMigrationCollector, dutyLease *lease.Lease) (*checkSoloMigrations, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -74,6 +75,7 @@ func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger
 	// Return task
 	lock := &sync.Mutex{}
 	return &checkSoloMigrations{
+		dutyLease:        dutyLease,
 		c:                c,
 		log:              logger,
 		errLog:           errorLogger,
@@ -141,7 +143,7 @@ func (t *checkSoloMigrations) checkSoloMigrations(state *state.NetworkState) err
 
 	t.printMessage(fmt.Sprintf("Checking for Beacon slot %d (EL block %d)", state.BeaconSlotNumber, state.ElBlockNumber))
 	oneGwei := eth.GweiToWei(1)
-	scrubThreshold := time.Duration(state.NetworkDetails.PromotionScrubPeriod.Seconds()*soloMigrationCheckThreshold) * time.Second
+	scrubThreshold := time.Duration(state.NetworkDetails.PromotionScrubPeriod.Seconds()*config.SoloMigrationCheckThreshold) * time.Second
 
 	genesisTime := time.Unix(int64(state.BeaconConfig.GenesisTime), 0)
 	secondsForSlot := time.Duration(state.BeaconSlotNumber*state.BeaconConfig.SecondsPerSlot) * time.Second
@@ -272,7 +274,7 @@ func (t *checkSoloMigrations) scrubVacantMinipool(address common.Address, reason
 	}
 
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := utils.GetWatchtowerTransactor(t.w, t.dutyLease)
 	if err != nil {
 		t.printMessage(fmt.Sprintf("error getting node account transactor: %s", err.Error()))
 		return
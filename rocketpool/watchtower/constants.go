@@ -4,3 +4,13 @@ const (
 	enableSubmissionAfterConsensus_Balances    bool = true
 	enableSubmissionAfterConsensus_RewardsTree bool = true
 )
+
+// The duties whose progress is kept in the database
+const (
+	watchtowerDuty_NetworkBalances = "network-balances"
+	watchtowerDuty_RplPrice        = "rpl-price"
+	watchtowerDuty_RewardsTree     = "rewards-tree"
+	watchtowerDuty_ScrubMinipool   = "scrub-minipool"
+	watchtowerDuty_Penalty         = "penalty"
+	watchtowerDuty_PenaltyScan     = "penalty-scan"
+)
@@ -15,6 +15,7 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/lease"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -26,16 +27,17 @@ const MinipoolStatusBatchSize = 20
 
 // Dissolve timed out minipools task
 type dissolveTimedOutMinipools struct {
-	c   *cli.Context
-	log log.ColorLogger
-	cfg *config.RocketPoolConfig
-	w   *wallet.Wallet
-	ec  rocketpool.ExecutionClient
-	rp  *rocketpool.RocketPool
+	c         *cli.Context
+	log       log.ColorLogger
+	cfg       *config.RocketPoolConfig
+	w         *wallet.Wallet
+	ec        rocketpool.ExecutionClient
+	rp        *rocketpool.RocketPool
+	dutyLease *lease.Lease
 }
 
 // Create dissolve timed out minipools task
-func newDissolveTimedOutMinipools(c *cli.Context, logger log.ColorLogger) (*dissolveTimedOutMinipools, error) {
+func newDissolveTimedOutMinipools(c *cli.Context, logger log.ColorLogger, dutyLease *lease.Lease) (*dissolveTimedOutMinipools, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -57,12 +59,13 @@ func newDissolveTimedOutMinipools(c *cli.Context, logger log.ColorLogger) (*diss
 
 	// Return task
 	return &dissolveTimedOutMinipools{
-		c:   c,
-		log: logger,
-		cfg: cfg,
-		w:   w,
-		ec:  ec,
-		rp:  rp,
+		dutyLease: dutyLease,
+		c:         c,
+		log:       logger,
+		cfg:       cfg,
+		w:         w,
+		ec:        ec,
+		rp:        rp,
 	}, nil
 
 }
@@ -139,7 +142,7 @@ func (t *dissolveTimedOutMinipools) dissolveMinipool(mp minipool.Minipool) error
 	t.log.Printlnf("Dissolving minipool %s...", mp.GetAddress().Hex())
 
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := utils.GetWatchtowerTransactor(t.w, t.dutyLease)
 	if err != nil {
 		return err
 	}
@@ -106,9 +106,11 @@ func (t *generateRewardsTree) run() error {
 
 	for _, file := range files {
 		filename := file.Name()
-		if strings.HasSuffix(filename, config.RegenerateRewardsTreeRequestSuffix) && !file.IsDir() {
+		isDryRun := strings.HasSuffix(filename, config.DryRunRewardsTreeRequestSuffix)
+		isArchive := strings.HasSuffix(filename, config.ArchiveRewardsTreeRequestSuffix)
+		if (strings.HasSuffix(filename, config.RegenerateRewardsTreeRequestSuffix) || isDryRun || isArchive) && !file.IsDir() {
 			// Get the index
-			indexString := strings.TrimSuffix(filename, config.RegenerateRewardsTreeRequestSuffix)
+			indexString := strings.TrimSuffix(filename, filepath.Ext(filename))
 			index, err := strconv.ParseUint(indexString, 0, 64)
 			if err != nil {
 				return fmt.Errorf("Error parsing index from [%s]: %w", filename, err)
@@ -125,7 +127,11 @@ func (t *generateRewardsTree) run() error {
 			t.lock.Lock()
 			t.isRunning = true
 			t.lock.Unlock()
-			go t.generateRewardsTree(index)
+			if isDryRun {
+				go t.dryRunRewardsTree(index)
+			} else {
+				go t.generateRewardsTree(index, isArchive)
+			}
 
 			// Return after the first request, do others at other intervals
 			return nil
@@ -135,7 +141,9 @@ func (t *generateRewardsTree) run() error {
 	return nil
 }
 
-func (t *generateRewardsTree) generateRewardsTree(index uint64) {
+// Generate the rewards tree for an interval. Archived trees are saved under filenames that include the ruleset version,
+// so they don't replace the files used for claiming.
+func (t *generateRewardsTree) generateRewardsTree(index uint64, isArchive bool) {
 
 	// Begin generation of the tree
 	generationPrefix := fmt.Sprintf("[Interval %d Tree]", index)
@@ -156,72 +164,30 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 		return
 	}
 
-	// Try getting the rETH address as a canary to see if the block is available
-	client := t.rp
-	opts := &bind.CallOpts{
-		BlockNumber: elBlockHeader.Number,
-	}
-	address, err := client.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.addressrocketTokenRETH")))
+	// Get a client that can provide the state for the EL block
+	client, err := t.getClientForBlock(generationPrefix, t.rp, elBlockHeader, nil)
 	if err != nil {
-		errMessage := err.Error()
-		t.log.Printlnf("%s Error getting state for block %d: %s", generationPrefix, elBlockHeader.Number.Uint64(), errMessage)
-		if strings.Contains(errMessage, "missing trie node") || // Geth
-			strings.Contains(errMessage, "No state available for block") || // Nethermind
-			strings.Contains(errMessage, "Internal error") { // Besu
-
-			// The state was missing so fall back to the archive node
-			archiveEcUrl := t.cfg.Smartnode.ArchiveECUrl.Value.(string)
-			if archiveEcUrl != "" {
-				t.log.Printlnf("%s Primary EC cannot retrieve state for historical block %d, using archive EC [%s]", generationPrefix, elBlockHeader.Number.Uint64(), archiveEcUrl)
-				ec, err := ethclient.Dial(archiveEcUrl)
-				if err != nil {
-					t.handleError(fmt.Errorf("Error connecting to archive EC: %w", err))
-					return
-				}
-				client, err = rocketpool.NewRocketPool(ec, common.HexToAddress(t.cfg.Smartnode.GetStorageAddress()))
-				if err != nil {
-					t.handleError(fmt.Errorf("%s Error creating Rocket Pool client connected to archive EC: %w", err))
-					return
-				}
-
-				// Get the rETH address from the archive EC
-				address, err = client.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.addressrocketTokenRETH")))
-				if err != nil {
-					t.handleError(fmt.Errorf("%s Error verifying rETH address with Archive EC: %w", err))
-					return
-				}
-			} else {
-				// No archive node specified
-				t.handleError(fmt.Errorf("***ERROR*** Primary EC cannot retrieve state for historical block %d and the Archive EC is not specified.", elBlockHeader.Number.Uint64()))
-				return
-			}
-
-		}
-	}
-
-	// Sanity check the rETH address to make sure the client is working right
-	if address != t.cfg.Smartnode.GetRethAddress() {
-		t.handleError(fmt.Errorf("***ERROR*** Your Primary EC provided %s as the rETH address, but it should have been %s!", address.Hex(), t.cfg.Smartnode.GetRethAddress().Hex()))
+		t.handleError(err)
 		return
 	}
 
 	// Get the state for the target slot
-	state, err := t.m.GetStateForSlot(rewardsEvent.ConsensusBlock.Uint64())
+	state, err := t.m.GetTreegenStateForSlot(rewardsEvent.ConsensusBlock.Uint64())
 	if err != nil {
 		t.handleError(fmt.Errorf("%s error getting state for beacon slot %d: %w", generationPrefix, rewardsEvent.ConsensusBlock.Uint64(), err))
 		return
 	}
 
 	// Generate the tree
-	t.generateRewardsTreeImpl(client, index, generationPrefix, rewardsEvent, elBlockHeader, state)
+	t.generateRewardsTreeImpl(client, index, generationPrefix, rewardsEvent, elBlockHeader, state, isArchive)
 }
 
 // Implementation for rewards tree generation using a viable EC
-func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool, index uint64, generationPrefix string, rewardsEvent rewards.RewardsEvent, elBlockHeader *types.Header, state *state.NetworkState) {
+func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool, index uint64, generationPrefix string, rewardsEvent rewards.RewardsEvent, elBlockHeader *types.Header, state *state.NetworkState, isArchive bool) {
 
 	// Generate the rewards file
 	start := time.Now()
-	treegen, err := rprewards.NewTreeGenerator(&t.log, generationPrefix, rp, t.cfg, t.bc, index, rewardsEvent.IntervalStartTime, rewardsEvent.IntervalEndTime, rewardsEvent.ConsensusBlock.Uint64(), elBlockHeader, rewardsEvent.IntervalsPassed.Uint64(), state, nil)
+	treegen, err := rprewards.NewTreeGenerator(&t.log, generationPrefix, rprewards.GenerationFlow_Regeneration, rp, t.cfg, t.bc, index, rewardsEvent.IntervalStartTime, rewardsEvent.IntervalEndTime, rewardsEvent.ConsensusBlock.Uint64(), elBlockHeader, rewardsEvent.IntervalsPassed.Uint64(), state, nil)
 	if err != nil {
 		t.handleError(fmt.Errorf("%s Error creating Merkle tree generator: %w", generationPrefix, err))
 		return
@@ -262,6 +228,15 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 	// Write the files
 	path := t.cfg.Smartnode.GetRewardsTreePath(index, true)
 	minipoolPerformancePath := t.cfg.Smartnode.GetMinipoolPerformancePath(index, true)
+	if isArchive {
+		err = os.MkdirAll(t.cfg.Smartnode.GetRewardsTreeArchiveFolder(true), 0755)
+		if err != nil {
+			t.handleError(fmt.Errorf("%s Error creating rewards tree archive folder: %w", generationPrefix, err))
+			return
+		}
+		path = t.cfg.Smartnode.GetArchivedRewardsTreePath(index, header.RulesetVersion, true)
+		minipoolPerformancePath = t.cfg.Smartnode.GetArchivedMinipoolPerformancePath(index, header.RulesetVersion, true)
+	}
 	err = os.WriteFile(minipoolPerformancePath, minipoolPerformanceBytes, 0644)
 	if err != nil {
 		t.handleError(fmt.Errorf("%s Error saving minipool performance file to %s: %w", generationPrefix, minipoolPerformancePath, err))
@@ -273,6 +248,31 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 		return
 	}
 
+	// Archived trees are only kept as records, so they don't need the extra files used for claiming
+	if isArchive {
+		t.log.Printlnf("%s Archived the ruleset v%d rewards tree to %s.", generationPrefix, header.RulesetVersion, path)
+		t.lock.Lock()
+		t.isRunning = false
+		t.lock.Unlock()
+		return
+	}
+
+	// Save SSZ copies if enabled
+	if t.cfg.Smartnode.SaveSszRewardsFiles.Value.(bool) {
+		err = rprewards.SaveSszRewardsFiles(t.cfg, index, rewardsFile, true, true)
+		if err != nil {
+			t.log.Printlnf("%s WARNING: couldn't save SSZ copies of the rewards files: %s", generationPrefix, err.Error())
+		}
+	}
+
+	// Save the per-minipool breakdown if enabled
+	if t.cfg.Smartnode.GenerateMinipoolAttribution.Value.(bool) {
+		err = rprewards.SaveMinipoolAttributionReport(t.cfg, rewardsFile, state, true)
+		if err != nil {
+			t.log.Printlnf("%s WARNING: couldn't save the minipool attribution report: %s", generationPrefix, err.Error())
+		}
+	}
+
 	t.log.Printlnf("%s Merkle tree generation complete!", generationPrefix)
 	t.lock.Lock()
 	t.isRunning = false
@@ -280,6 +280,163 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 
 }
 
+// Get a client that can provide the state of the given EL block, falling back to the archive EC if the primary one can't.
+// If a profiler is provided, the archive EC's calls will be counted by it.
+func (t *generateRewardsTree) getClientForBlock(generationPrefix string, primary *rocketpool.RocketPool, elBlockHeader *types.Header, profiler *rprewards.TreegenProfiler) (*rocketpool.RocketPool, error) {
+
+	// Try getting the rETH address as a canary to see if the block is available
+	client := primary
+	opts := &bind.CallOpts{
+		BlockNumber: elBlockHeader.Number,
+	}
+	address, err := client.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.addressrocketTokenRETH")))
+	if err != nil {
+		errMessage := err.Error()
+		t.log.Printlnf("%s Error getting state for block %d: %s", generationPrefix, elBlockHeader.Number.Uint64(), errMessage)
+		if strings.Contains(errMessage, "missing trie node") || // Geth
+			strings.Contains(errMessage, "No state available for block") || // Nethermind
+			strings.Contains(errMessage, "Internal error") || // Besu
+			strings.Contains(errMessage, "is pruned") { // Reth
+
+			// The state was missing so fall back to the archive node
+			archiveEcUrl := t.cfg.Smartnode.ArchiveECUrl.Value.(string)
+			if archiveEcUrl != "" {
+				t.log.Printlnf("%s Primary EC cannot retrieve state for historical block %d, using archive EC [%s]", generationPrefix, elBlockHeader.Number.Uint64(), archiveEcUrl)
+				var ec rocketpool.ExecutionClient
+				ec, err = ethclient.Dial(archiveEcUrl)
+				if err != nil {
+					return nil, fmt.Errorf("Error connecting to archive EC: %w", err)
+				}
+				if profiler != nil {
+					ec = profiler.WrapExecutionClient(ec)
+				}
+				client, err = rocketpool.NewRocketPool(ec, common.HexToAddress(t.cfg.Smartnode.GetStorageAddress()))
+				if err != nil {
+					return nil, fmt.Errorf("%s Error creating Rocket Pool client connected to archive EC: %w", generationPrefix, err)
+				}
+
+				// Get the rETH address from the archive EC
+				address, err = client.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.addressrocketTokenRETH")))
+				if err != nil {
+					return nil, fmt.Errorf("%s Error verifying rETH address with Archive EC: %w", generationPrefix, err)
+				}
+			} else {
+				// No archive node specified
+				return nil, fmt.Errorf("***ERROR*** Primary EC cannot retrieve state for historical block %d and the Archive EC is not specified.", elBlockHeader.Number.Uint64())
+			}
+
+		}
+	}
+
+	// Sanity check the rETH address to make sure the client is working right
+	if address != t.cfg.Smartnode.GetRethAddress() {
+		return nil, fmt.Errorf("***ERROR*** Your Primary EC provided %s as the rETH address, but it should have been %s!", address.Hex(), t.cfg.Smartnode.GetRethAddress().Hex())
+	}
+
+	return client, nil
+
+}
+
+// Run the full tree generation pipeline for an interval without saving or submitting anything, logging how long each
+// stage took along with the client calls and memory it used
+func (t *generateRewardsTree) dryRunRewardsTree(index uint64) {
+
+	generationPrefix := fmt.Sprintf("[Interval %d Dry Run]", index)
+	t.log.Printlnf("%s Starting a dry run of Merkle rewards tree generation for interval %d. Nothing will be saved or submitted.", generationPrefix, index)
+
+	// Count all of the calls made during generation
+	profiler := rprewards.NewTreegenProfiler()
+	defer profiler.Stop()
+	ec := profiler.WrapExecutionClient(t.ec)
+	bc := profiler.WrapBeaconClient(t.bc)
+	rp, err := rocketpool.NewRocketPool(ec, common.HexToAddress(t.cfg.Smartnode.GetStorageAddress()))
+	if err != nil {
+		t.handleError(fmt.Errorf("%s Error creating Rocket Pool client: %w", generationPrefix, err))
+		return
+	}
+
+	// Find the event for this interval
+	profiler.StartStage("Find rewards event")
+	rewardsEvent, err := rprewards.GetRewardSnapshotEvent(rp, t.cfg, index, nil)
+	if err != nil {
+		t.handleError(fmt.Errorf("%s Error getting event for interval %d: %w", generationPrefix, index, err))
+		return
+	}
+
+	// Get the EL block
+	profiler.StartStage("Get execution block")
+	elBlockHeader, err := ec.HeaderByNumber(context.Background(), rewardsEvent.ExecutionBlock)
+	if err != nil {
+		t.handleError(fmt.Errorf("%s Error getting execution block: %w", generationPrefix, err))
+		return
+	}
+	profiler.StartStage("Check historical state")
+	client, err := t.getClientForBlock(generationPrefix, rp, elBlockHeader, profiler)
+	if err != nil {
+		t.handleError(err)
+		return
+	}
+
+	// Get the state for the target slot
+	profiler.StartStage("Build network state")
+	m, err := state.NewNetworkStateManager(rp, t.cfg, ec, bc, &t.log)
+	if err != nil {
+		t.handleError(fmt.Errorf("%s Error creating network state manager: %w", generationPrefix, err))
+		return
+	}
+	networkState, err := m.GetTreegenStateForSlot(rewardsEvent.ConsensusBlock.Uint64())
+	if err != nil {
+		t.handleError(fmt.Errorf("%s error getting state for beacon slot %d: %w", generationPrefix, rewardsEvent.ConsensusBlock.Uint64(), err))
+		return
+	}
+
+	// Generate the tree
+	profiler.StartStage("Generate tree")
+	treegen, err := rprewards.NewTreeGenerator(&t.log, generationPrefix, rprewards.GenerationFlow_DryRun, client, t.cfg, bc, index, rewardsEvent.IntervalStartTime, rewardsEvent.IntervalEndTime, rewardsEvent.ConsensusBlock.Uint64(), elBlockHeader, rewardsEvent.IntervalsPassed.Uint64(), networkState, nil)
+	if err != nil {
+		t.handleError(fmt.Errorf("%s Error creating Merkle tree generator: %w", generationPrefix, err))
+		return
+	}
+	rewardsFile, err := treegen.GenerateTree()
+	if err != nil {
+		t.handleError(fmt.Errorf("%s Error generating Merkle tree: %w", generationPrefix, err))
+		return
+	}
+
+	// Serialize the files without writing them, since that's part of the normal pipeline's cost
+	profiler.StartStage("Serialize files")
+	rewardsFile.SetMinipoolPerformanceFileCID("---")
+	_, err = rewardsFile.GetMinipoolPerformanceFile().Serialize()
+	if err != nil {
+		t.handleError(fmt.Errorf("%s Error serializing minipool performance file into JSON: %w", generationPrefix, err))
+		return
+	}
+	_, err = rewardsFile.Serialize()
+	if err != nil {
+		t.handleError(fmt.Errorf("%s Error serializing proof wrapper into JSON: %w", generationPrefix, err))
+		return
+	}
+	profiler.Stop()
+
+	// Report the results
+	root := common.BytesToHash(rewardsFile.GetHeader().MerkleTree.Root())
+	if root != rewardsEvent.MerkleRoot {
+		t.log.Printlnf("%s WARNING: the generated Merkle tree had a root of %s, but the canonical Merkle tree's root was %s.", generationPrefix, root.Hex(), rewardsEvent.MerkleRoot.Hex())
+	} else {
+		t.log.Printlnf("%s The generated Merkle tree's root of %s matches the canonical root.", generationPrefix, root.Hex())
+	}
+	t.log.Printlnf("%s Generation profile (ruleset v%d):", generationPrefix, treegen.GetGeneratorRulesetVersion())
+	for _, line := range profiler.GetReport() {
+		t.log.Printlnf("%s   %s", generationPrefix, line)
+	}
+
+	t.log.Printlnf("%s Dry run complete.", generationPrefix)
+	t.lock.Lock()
+	t.isRunning = false
+	t.lock.Unlock()
+
+}
+
 func (t *generateRewardsTree) handleError(err error) {
 	t.errLog.Println(err)
 	t.errLog.Println("*** Rewards tree generation failed. ***")
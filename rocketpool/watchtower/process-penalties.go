@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"math/big"
 	"os"
-	"path/filepath"
 	"strconv"
 	"sync"
 	"time"
@@ -23,10 +22,13 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/store"
 	"github.com/urfave/cli"
 	"gopkg.in/yaml.v2"
 
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/lease"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -45,6 +47,8 @@ type processPenalties struct {
 	rp             *rocketpool.RocketPool
 	ec             rocketpool.ExecutionClient
 	bc             beacon.Client
+	db             *store.Store
+	submissions    *utils.SubmissionTracker
 	lock           *sync.Mutex
 	isRunning      bool
 	maxFee         *big.Int
@@ -53,6 +57,7 @@ type processPenalties struct {
 	beaconConfig   beacon.Eth2Config
 	m              *state.NetworkStateManager
 	s              *state.NetworkState
+	dutyLease      *lease.Lease
 }
 
 type penaltyState struct {
@@ -60,7 +65,7 @@ type penaltyState struct {
 }
 
 // Create process penalties task
-func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager) (*processPenalties, error) {
+func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager, dutyLease *lease.Lease) (*processPenalties, error) {
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
@@ -82,6 +87,10 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 	if err != nil {
 		return nil, err
 	}
+	db, err := services.GetStore(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get the user-requested max fee
 	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
@@ -111,6 +120,7 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 	// Return task
 	lock := &sync.Mutex{}
 	return &processPenalties{
+		dutyLease:      dutyLease,
 		c:              c,
 		log:            logger,
 		errLog:         errorLogger,
@@ -119,6 +129,8 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 		ec:             ec,
 		bc:             bc,
 		rp:             rp,
+		db:             db,
+		submissions:    utils.NewSubmissionTracker(db, ec, watchtowerDuty_Penalty),
 		lock:           lock,
 		isRunning:      false,
 		maxFee:         maxFee,
@@ -159,21 +171,31 @@ func (s *penaltyState) loadState(path string) (*penaltyState, error) {
 	return s, nil
 }
 
-func (s *penaltyState) saveState(path string) error {
-	// Marshal state object
-	data, err := yaml.Marshal(s)
-
+// Get the first slot the penalty scan hasn't processed yet, taking it from the old state file if the scan's progress
+// hasn't been saved in the database yet
+func (t *processPenalties) getPenaltyScanSlot(currentSlot uint64) (uint64, error) {
+	slot, exists, err := t.db.GetWatchtowerProgress(watchtowerDuty_PenaltyScan)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	if exists {
+		return slot, nil
 	}
 
-	// Write to disk
-	watchtowerDir := filepath.Dir(path)
-	err = os.MkdirAll(watchtowerDir, 0755)
-	if err != nil {
-		return fmt.Errorf("error creating watchtower directory: %w", err)
+	watchtowerStatePath := t.cfg.Smartnode.GetWatchtowerStatePath()
+	if stateFileExists(watchtowerStatePath) {
+		var s penaltyState
+		if _, err := s.loadState(watchtowerStatePath); err != nil {
+			return 0, fmt.Errorf("error loading watchtower state: %w", err)
+		}
+		return s.LatestPenaltySlot, nil
 	}
-	return os.WriteFile(path, data, 0644)
+
+	// No state so start from NewPenaltyScanBuffer slots ago
+	if currentSlot > NewPenaltyScanBuffer {
+		return currentSlot - NewPenaltyScanBuffer, nil
+	}
+	return 0, nil
 }
 
 // Process penalties
@@ -238,24 +260,14 @@ func (t *processPenalties) run() error {
 
 		currentSlot := head.Slot
 
-		// Read state from file or create if this is the first run
-		watchtowerStatePath := t.cfg.Smartnode.GetWatchtowerStatePath()
-		var s penaltyState
-
-		if stateFileExists(watchtowerStatePath) {
-			_, err := s.loadState(watchtowerStatePath)
-			if err != nil {
-				t.handleError(fmt.Errorf("%s Error loading watchtower state: %w", checkPrefix, err))
-				return
-			}
-		} else {
-			// No state file so start from NewPenaltyScanBuffer slots ago
-			if currentSlot > NewPenaltyScanBuffer {
-				s.LatestPenaltySlot = currentSlot - NewPenaltyScanBuffer
-			}
+		// Get the first slot that hasn't been scanned yet
+		startSlot, err := t.getPenaltyScanSlot(currentSlot)
+		if err != nil {
+			t.handleError(fmt.Errorf("%s Error loading penalty scan progress: %w", checkPrefix, err))
+			return
 		}
 
-		if currentSlot <= s.LatestPenaltySlot {
+		if currentSlot <= startSlot {
 			// Nothing to do
 			t.log.Printlnf("%s Finished checking for illegal fee recipients.", checkPrefix)
 			t.lock.Lock()
@@ -264,11 +276,12 @@ func (t *processPenalties) run() error {
 			return
 		}
 
-		t.log.Printlnf("Starting check in a separate thread at block %d", s.LatestPenaltySlot)
+		t.log.Printlnf("Starting check in a separate thread at block %d", startSlot)
 
-		// Loop over unprocessed slots
+		// Loop over unprocessed slots; the progress only moves past a slot once it's been fully processed, so a restart
+		// never skips one
 		slotsSinceUpdate := 0
-		for i := s.LatestPenaltySlot; i < currentSlot; i++ {
+		for i := startSlot; i < currentSlot; i++ {
 			block, exists, err := t.bc.GetBeaconBlock(strconv.FormatUint(i, 10))
 			if err != nil {
 				t.handleError(fmt.Errorf("%s Error getting beacon block: %w", checkPrefix, err))
@@ -276,28 +289,24 @@ func (t *processPenalties) run() error {
 			}
 			if exists {
 				illegalFeeRecipientFound, err := t.processBlock(&block, smoothingPoolAddress)
-				if illegalFeeRecipientFound {
-					s.LatestPenaltySlot = block.Slot
-					saveErr := s.saveState(watchtowerStatePath)
-					if saveErr != nil {
-						t.handleError(fmt.Errorf("%s Error saving watchtower state file: %w", checkPrefix, saveErr))
-						return
-					}
-				}
 				if err != nil {
 					t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
 					return
 				}
+				if illegalFeeRecipientFound {
+					if err := t.db.SetWatchtowerProgress(watchtowerDuty_PenaltyScan, i+1); err != nil {
+						t.handleError(fmt.Errorf("%s Error saving penalty scan progress: %w", checkPrefix, err))
+						return
+					}
+				}
 			}
 
 			slotsSinceUpdate++
 			if slotsSinceUpdate >= 10000 {
 				t.log.Printlnf("\t%s At block %d of %d...", checkPrefix, i, currentSlot)
 				slotsSinceUpdate = 0
-				s.LatestPenaltySlot = block.Slot
-				err = s.saveState(watchtowerStatePath)
-				if err != nil {
-					t.handleError(fmt.Errorf("%s Error saving watchtower state file: %w", checkPrefix, err))
+				if err := t.db.SetWatchtowerProgress(watchtowerDuty_PenaltyScan, i+1); err != nil {
+					t.handleError(fmt.Errorf("%s Error saving penalty scan progress: %w", checkPrefix, err))
 					return
 				}
 			}
@@ -312,10 +321,9 @@ func (t *processPenalties) run() error {
 		}
 
 		// Update latest slot in state
-		s.LatestPenaltySlot = currentSlot
-		err = s.saveState(watchtowerStatePath)
+		err = t.db.SetWatchtowerProgress(watchtowerDuty_PenaltyScan, currentSlot)
 		if err != nil {
-			t.handleError(fmt.Errorf("%s Error saving watchtower state file: %w", checkPrefix, err))
+			t.handleError(fmt.Errorf("%s Error saving penalty scan progress: %w", checkPrefix, err))
 			return
 		}
 
@@ -483,8 +491,19 @@ func (t *processPenalties) submitPenalty(minipoolAddress common.Address, block *
 		return nil
 	}
 
+	// Check if this node already submitted it, including before a restart
+	target := fmt.Sprintf("%s/%d", minipoolAddress.Hex(), block.Slot)
+	status, err := t.submissions.GetStatus(target)
+	if err != nil {
+		return err
+	}
+	if status == store.SubmissionStatus_Pending || status == store.SubmissionStatus_Succeeded {
+		t.log.Printlnf("NOTE: Already submitted the penalty against %s for block %d, skipping...", minipoolAddress.Hex(), block.Slot)
+		return nil
+	}
+
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := utils.GetWatchtowerTransactor(t.w, t.dutyLease)
 	if err != nil {
 		return err
 	}
@@ -504,7 +523,7 @@ func (t *processPenalties) submitPenalty(minipoolAddress common.Address, block *
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return err
 		}
@@ -523,6 +542,9 @@ func (t *processPenalties) submitPenalty(minipoolAddress common.Address, block *
 	if err != nil {
 		return fmt.Errorf("Error submitting penalty against %s for block %d: %w", minipoolAddress.Hex(), block.Slot, err)
 	}
+	if err := t.submissions.Sent(target, hash); err != nil {
+		t.log.Printlnf("WARNING: Couldn't record the penalty submission: %s", err.Error())
+	}
 
 	// Print TX info and wait for it to be included in a block
 	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
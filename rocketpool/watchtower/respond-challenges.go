@@ -11,6 +11,7 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/lease"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -19,16 +20,17 @@ import (
 
 // Respond to challenges task
 type respondChallenges struct {
-	c   *cli.Context
-	log log.ColorLogger
-	cfg *config.RocketPoolConfig
-	w   *wallet.Wallet
-	rp  *rocketpool.RocketPool
-	m   *state.NetworkStateManager
+	c         *cli.Context
+	log       log.ColorLogger
+	cfg       *config.RocketPoolConfig
+	w         *wallet.Wallet
+	rp        *rocketpool.RocketPool
+	m         *state.NetworkStateManager
+	dutyLease *lease.Lease
 }
 
 // Create respond to challenges task
-func newRespondChallenges(c *cli.Context, logger log.ColorLogger, m *state.NetworkStateManager) (*respondChallenges, error) {
+func newRespondChallenges(c *cli.Context, logger log.ColorLogger, m *state.NetworkStateManager, dutyLease *lease.Lease) (*respondChallenges, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -46,12 +48,13 @@ func newRespondChallenges(c *cli.Context, logger log.ColorLogger, m *state.Netwo
 
 	// Return task
 	return &respondChallenges{
-		c:   c,
-		log: logger,
-		cfg: cfg,
-		w:   w,
-		rp:  rp,
-		m:   m,
+		dutyLease: dutyLease,
+		c:         c,
+		log:       logger,
+		cfg:       cfg,
+		w:         w,
+		rp:        rp,
+		m:         m,
 	}, nil
 
 }
@@ -86,7 +89,7 @@ func (t *respondChallenges) run() error {
 	t.log.Printlnf("Node %s has an active challenge against it, responding...", nodeAccount.Address.Hex())
 
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := utils.GetWatchtowerTransactor(t.w, t.dutyLease)
 	if err != nil {
 		return err
 	}
@@ -23,8 +23,10 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/lease"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/store"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -37,16 +39,18 @@ const (
 
 // Submit network balances task
 type submitNetworkBalances struct {
-	c         *cli.Context
-	log       *log.ColorLogger
-	errLog    *log.ColorLogger
-	cfg       *config.RocketPoolConfig
-	w         *wallet.Wallet
-	ec        rocketpool.ExecutionClient
-	rp        *rocketpool.RocketPool
-	bc        beacon.Client
-	lock      *sync.Mutex
-	isRunning bool
+	c           *cli.Context
+	log         *log.ColorLogger
+	errLog      *log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	w           *wallet.Wallet
+	ec          rocketpool.ExecutionClient
+	rp          *rocketpool.RocketPool
+	bc          beacon.Client
+	submissions *utils.SubmissionTracker
+	lock        *sync.Mutex
+	isRunning   bool
+	dutyLease   *lease.Lease
 }
 
 // Network balance info
@@ -67,7 +71,7 @@ type minipoolBalanceDetails struct {
 }
 
 // Create submit network balances task
-func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*submitNetworkBalances, error) {
+func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, dutyLease *lease.Lease) (*submitNetworkBalances, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -90,20 +94,26 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogge
 	if err != nil {
 		return nil, err
 	}
+	db, err := services.GetStore(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Return task
 	lock := &sync.Mutex{}
 	return &submitNetworkBalances{
-		c:         c,
-		log:       &logger,
-		errLog:    &errorLogger,
-		cfg:       cfg,
-		w:         w,
-		ec:        ec,
-		rp:        rp,
-		bc:        bc,
-		lock:      lock,
-		isRunning: false,
+		dutyLease:   dutyLease,
+		c:           c,
+		log:         &logger,
+		errLog:      &errorLogger,
+		cfg:         cfg,
+		w:           w,
+		ec:          ec,
+		rp:          rp,
+		bc:          bc,
+		submissions: utils.NewSubmissionTracker(db, ec, watchtowerDuty_NetworkBalances),
+		lock:        lock,
+		isRunning:   false,
 	}, nil
 
 }
@@ -176,6 +186,16 @@ func (t *submitNetworkBalances) run(state *state.NetworkState) error {
 	}
 	t.lock.Unlock()
 
+	// Don't submit again if the transaction sent before a restart is still waiting to be included
+	status, err := t.submissions.GetStatus(fmt.Sprint(blockNumber))
+	if err != nil {
+		return err
+	}
+	if status == store.SubmissionStatus_Pending {
+		t.log.Printlnf("Balances for block %d were already submitted, waiting for the transaction to be included.", blockNumber)
+		return nil
+	}
+
 	go func() {
 		t.lock.Lock()
 		t.isRunning = true
@@ -365,7 +385,7 @@ func (t *submitNetworkBalances) getNetworkBalances(elBlockHeader *types.Header,
 
 		// Approximate the staker's share of the smoothing pool balance
 		// NOTE: this will use the "vanilla" variant of treegen, without rolling records, to retain parity with other Oracle DAO nodes that aren't using rolling records
-		treegen, err := rprewards.NewTreeGenerator(t.log, "[Balances]", client, t.cfg, t.bc, currentIndex, startTime, endTime, beaconBlock, elBlockHeader, uint64(intervalsPassed), state, nil)
+		treegen, err := rprewards.NewTreeGenerator(t.log, "[Balances]", rprewards.GenerationFlow_Balances, client, t.cfg, t.bc, currentIndex, startTime, endTime, beaconBlock, elBlockHeader, uint64(intervalsPassed), state, nil)
 		if err != nil {
 			return fmt.Errorf("error creating merkle tree generator to approximate share of smoothing pool: %w", err)
 		}
@@ -505,7 +525,7 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 	t.log.Printlnf("Submitting network balances for block %d...", balances.Block)
 
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := utils.GetWatchtowerTransactor(t.w, t.dutyLease)
 	if err != nil {
 		return fmt.Errorf("error getting node transactor: %w", err)
 	}
@@ -541,6 +561,9 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 	if err != nil {
 		return fmt.Errorf("error submitting balances: %w", err)
 	}
+	if err := t.submissions.Sent(fmt.Sprint(balances.Block), hash); err != nil {
+		t.log.Printlnf("WARNING: Couldn't record the balance submission: %s", err.Error())
+	}
 
 	// Print TX info and wait for it to be included in a block
 	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
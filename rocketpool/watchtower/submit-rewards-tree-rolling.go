@@ -22,10 +22,15 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/arweave"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/ipfs"
+	"github.com/rocket-pool/smartnode/shared/services/lease"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/store"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -45,6 +50,8 @@ type submitRewardsTree_Rolling struct {
 	ec          rocketpool.ExecutionClient
 	rp          *rocketpool.RocketPool
 	bc          beacon.Client
+	alerts      *alerting.Manager
+	submissions *utils.SubmissionTracker
 	genesisTime time.Time
 	recordMgr   *rprewards.RollingRecordManager
 	stateMgr    *state.NetworkStateManager
@@ -52,10 +59,11 @@ type submitRewardsTree_Rolling struct {
 
 	lock      *sync.Mutex
 	isRunning bool
+	dutyLease *lease.Lease
 }
 
 // Create submit rewards tree with rolling record support
-func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, stateMgr *state.NetworkStateManager) (*submitRewardsTree_Rolling, error) {
+func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, stateMgr *state.NetworkStateManager, dutyLease *lease.Lease) (*submitRewardsTree_Rolling, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -78,6 +86,14 @@ func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorL
 	if err != nil {
 		return nil, err
 	}
+	alerts, err := services.GetAlertManager(c)
+	if err != nil {
+		return nil, err
+	}
+	db, err := services.GetStore(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get the beacon config
 	beaconCfg, err := bc.GetEth2Config()
@@ -120,6 +136,7 @@ func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorL
 	lock := &sync.Mutex{}
 	logPrefix := "[Rolling Record]"
 	task := &submitRewardsTree_Rolling{
+		dutyLease:   dutyLease,
 		c:           c,
 		log:         logger,
 		errLog:      errorLogger,
@@ -128,6 +145,8 @@ func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorL
 		w:           w,
 		rp:          rp,
 		bc:          bc,
+		alerts:      alerts,
+		submissions: utils.NewSubmissionTracker(db, ec, watchtowerDuty_RewardsTree),
 		stateMgr:    stateMgr,
 		genesisTime: genesisTime,
 		logPrefix:   logPrefix,
@@ -250,6 +269,22 @@ func (t *submitRewardsTree_Rolling) run(headState *state.NetworkState) error {
 
 		// Run updates and submissions as required
 		if isRewardsReadyForReport {
+			// Don't submit again if the transaction sent before a restart is still waiting to be included
+			if isInOdao {
+				status, err := t.submissions.GetStatus(fmt.Sprint(headState.NetworkDetails.RewardIndex))
+				if err != nil {
+					t.handleError(fmt.Errorf("error checking the previous rewards tree submission: %w", err))
+					return
+				}
+				if status == store.SubmissionStatus_Pending {
+					t.log.Printlnf("%s Rewards tree for interval %d was already submitted, waiting for the transaction to be included.", t.logPrefix, headState.NetworkDetails.RewardIndex)
+					t.lock.Lock()
+					t.isRunning = false
+					t.lock.Unlock()
+					return
+				}
+			}
+
 			// Check if there's an existing file for this interval, and try submitting that
 			rewardsTreePath := t.cfg.Smartnode.GetRewardsTreePath(headState.NetworkDetails.RewardIndex, true)
 			existingRewardsFile, fileBytes, valid, mustRegenerate := t.isExistingFileValid(rewardsTreePath, intervalsPassed, nodeAddress, isInOdao)
@@ -322,6 +357,14 @@ func (t *submitRewardsTree_Rolling) printMessage(message string) {
 	t.log.Printlnf("%s %s", t.logPrefix, message)
 }
 
+// Notify the configured chat services that the tree for an interval was submitted
+func (t *submitRewardsTree_Rolling) notifyTreeSubmitted(index uint64) {
+	err := t.alerts.Notify("rewards-tree-submitted", fmt.Sprintf("Submitted the rewards tree for interval %d.", index))
+	if err != nil {
+		t.printMessage(fmt.Sprintf("Could not send the rewards tree notification: %s", err.Error()))
+	}
+}
+
 // Print an error and unlock the mutex
 func (t *submitRewardsTree_Rolling) handleError(err error) {
 	t.errLog.Printlnf("%s %s", t.logPrefix, err.Error())
@@ -506,6 +549,14 @@ func (t *submitRewardsTree_Rolling) runRewardsIntervalReport(client *rocketpool.
 
 		t.log.Printlnf("%s Merkle rewards tree for interval %d already exists at %s, attempting to resubmit...", t.logPrefix, currentIndex, rewardsTreePath)
 
+		// Strip the local provenance so the uploaded file matches the other Oracle DAO members
+		if existingRewardsFile.GetHeader().Provenance != nil {
+			fileBytes, err = rprewards.SerializeCanonicalRewardsFile(existingRewardsFile)
+			if err != nil {
+				return fmt.Errorf("error serializing canonical rewards tree file: %w", err)
+			}
+		}
+
 		// Upload the file
 		cid, err := t.uploadFileToWeb3Storage(fileBytes, compressedRewardsTreePath, "compressed rewards tree")
 		if err != nil {
@@ -520,6 +571,7 @@ func (t *submitRewardsTree_Rolling) runRewardsIntervalReport(client *rocketpool.
 		}
 
 		t.log.Printlnf("%s Successfully submitted rewards snapshot for interval %d.", t.logPrefix, currentIndex)
+		t.notifyTreeSubmitted(currentIndex)
 		return nil
 	}
 
@@ -542,7 +594,7 @@ func (t *submitRewardsTree_Rolling) generateTree(rp *rocketpool.RocketPool, stat
 	t.log.Printlnf("Rewards checkpoint has passed, starting Merkle tree generation for interval %d in the background.\n%s Snapshot Beacon block = %d, EL block = %d, running from %s to %s", currentIndex, t.logPrefix, snapshotBeaconBlock, elBlockIndex, startTime, endTime)
 
 	// Generate the rewards file
-	treegen, err := rprewards.NewTreeGenerator(&t.log, t.logPrefix, rp, t.cfg, t.bc, currentIndex, startTime, endTime, snapshotBeaconBlock, snapshotElBlockHeader, uint64(intervalsPassed), state, t.recordMgr.Record)
+	treegen, err := rprewards.NewTreeGenerator(&t.log, t.logPrefix, rprewards.GenerationFlow_Submission, rp, t.cfg, t.bc, currentIndex, startTime, endTime, snapshotBeaconBlock, snapshotElBlockHeader, uint64(intervalsPassed), state, t.recordMgr.Record)
 	if err != nil {
 		return fmt.Errorf("Error creating Merkle tree generator: %w", err)
 	}
@@ -580,11 +632,15 @@ func (t *submitRewardsTree_Rolling) generateTree(rp *rocketpool.RocketPool, stat
 		rewardsFile.SetMinipoolPerformanceFileCID("---")
 	}
 
-	// Serialize the rewards tree to JSON
+	// Serialize the rewards tree to JSON, leaving the provenance out of the copy that gets shared
 	wrapperBytes, err := rewardsFile.Serialize()
 	if err != nil {
 		return fmt.Errorf("Error serializing proof wrapper into JSON: %w", err)
 	}
+	canonicalBytes, err := rprewards.SerializeCanonicalRewardsFile(rewardsFile)
+	if err != nil {
+		return fmt.Errorf("Error serializing canonical proof wrapper into JSON: %w", err)
+	}
 	t.printMessage("Generation complete! Saving tree...")
 
 	// Write the rewards tree to disk
@@ -593,11 +649,33 @@ func (t *submitRewardsTree_Rolling) generateTree(rp *rocketpool.RocketPool, stat
 		return fmt.Errorf("Error saving rewards tree file to %s: %w", rewardsTreePath, err)
 	}
 
+	// Save SSZ copies if enabled
+	if t.cfg.Smartnode.SaveSszRewardsFiles.Value.(bool) {
+		err = rprewards.SaveSszRewardsFiles(t.cfg, currentIndex, rewardsFile, true, true)
+		if err != nil {
+			t.printMessage(fmt.Sprintf("WARNING: couldn't save SSZ copies of the rewards files: %s", err.Error()))
+		}
+	}
+
+	// Save the per-minipool breakdown if enabled
+	if t.cfg.Smartnode.GenerateMinipoolAttribution.Value.(bool) {
+		err = rprewards.SaveMinipoolAttributionReport(t.cfg, rewardsFile, state, true)
+		if err != nil {
+			t.printMessage(fmt.Sprintf("WARNING: couldn't save the minipool attribution report: %s", err.Error()))
+		}
+	}
+
+	// Pin the files to IPFS if enabled
+	t.pinRewardsFiles(currentIndex, canonicalBytes, minipoolPerformanceBytes)
+
+	// Mirror the files to Arweave if enabled
+	t.uploadRewardsFilesToArweave(currentIndex, canonicalBytes, minipoolPerformanceBytes)
+
 	// Only do the upload and submission process if this is an Oracle DAO node
 	if nodeTrusted {
 		// Upload the rewards tree file
 		t.printMessage("Uploading to Web3.Storage and submitting results to the contracts...")
-		cid, err := t.uploadFileToWeb3Storage(wrapperBytes, compressedRewardsTreePath, "compressed rewards tree")
+		cid, err := t.uploadFileToWeb3Storage(canonicalBytes, compressedRewardsTreePath, "compressed rewards tree")
 		if err != nil {
 			return fmt.Errorf("Error uploading Merkle tree to Web3.Storage: %w", err)
 		}
@@ -610,6 +688,7 @@ func (t *submitRewardsTree_Rolling) generateTree(rp *rocketpool.RocketPool, stat
 		}
 
 		t.printMessage(fmt.Sprintf("Successfully submitted rewards snapshot for interval %d.", currentIndex))
+		t.notifyTreeSubmitted(currentIndex)
 	} else {
 		t.printMessage(fmt.Sprintf("Successfully generated rewards snapshot for interval %d.", currentIndex))
 	}
@@ -648,7 +727,7 @@ func (t *submitRewardsTree_Rolling) submitRewardsSnapshot(index *big.Int, consen
 	}
 
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := utils.GetWatchtowerTransactor(t.w, t.dutyLease)
 	if err != nil {
 		return err
 	}
@@ -698,6 +777,9 @@ func (t *submitRewardsTree_Rolling) submitRewardsSnapshot(index *big.Int, consen
 	if err != nil {
 		return err
 	}
+	if err := t.submissions.Sent(index.String(), hash); err != nil {
+		t.log.Printlnf("WARNING: Couldn't record the rewards tree submission: %s", err.Error())
+	}
 
 	// Print TX info and wait for it to be included in a block
 	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
@@ -709,6 +791,47 @@ func (t *submitRewardsTree_Rolling) submitRewardsSnapshot(index *big.Int, consen
 	return nil
 }
 
+// Pins the rewards files to the configured IPFS service; failures are logged but don't stop the submission
+func (t *submitRewardsTree_Rolling) pinRewardsFiles(index uint64, wrapperBytes []byte, minipoolPerformanceBytes []byte) {
+	pinner, err := ipfs.NewPinner(t.cfg)
+	if err != nil {
+		t.printMessage(fmt.Sprintf("WARNING: couldn't set up IPFS pinning: %s", err.Error()))
+		return
+	}
+	if pinner == nil {
+		return
+	}
+
+	t.printMessage(fmt.Sprintf("Pinning rewards files to %s...", pinner.GetName()))
+	record, err := ipfs.PinRewardsFiles(pinner, t.cfg, index, wrapperBytes, minipoolPerformanceBytes)
+	if err != nil {
+		t.printMessage(fmt.Sprintf("WARNING: couldn't pin rewards files to %s: %s", pinner.GetName(), err.Error()))
+		return
+	}
+	t.printMessage(fmt.Sprintf("Pinned rewards tree with CID %s and minipool performance file with CID %s", record.RewardsFileCID, record.MinipoolPerformanceFileCID))
+}
+
+// Uploads the rewards files to Arweave through Bundlr; failures are logged but don't stop the submission
+func (t *submitRewardsTree_Rolling) uploadRewardsFilesToArweave(index uint64, wrapperBytes []byte, minipoolPerformanceBytes []byte) {
+	if !t.cfg.Smartnode.EnableArweaveUpload.Value.(bool) {
+		return
+	}
+
+	uploader, err := arweave.NewBundlrUploader(t.cfg.Smartnode.BundlrNodeUrl.Value.(string), t.cfg.Smartnode.BundlrPrivateKey.Value.(string))
+	if err != nil {
+		t.printMessage(fmt.Sprintf("WARNING: couldn't set up Arweave uploads: %s", err.Error()))
+		return
+	}
+
+	t.printMessage(fmt.Sprintf("Uploading rewards files to Arweave using Bundlr account %s...", uploader.GetAddress()))
+	record, err := arweave.UploadRewardsFiles(uploader, t.cfg, index, wrapperBytes, minipoolPerformanceBytes)
+	if err != nil {
+		t.printMessage(fmt.Sprintf("WARNING: couldn't upload rewards files to Arweave: %s", err.Error()))
+		return
+	}
+	t.printMessage(fmt.Sprintf("Uploaded rewards tree to Arweave with transaction %s and minipool performance file with transaction %s", record.RewardsFileTxID, record.MinipoolPerformanceFileTxID))
+}
+
 // Compress and upload a file to Web3.Storage and get the CID for it
 func (t *submitRewardsTree_Rolling) uploadFileToWeb3Storage(wrapperBytes []byte, compressedPath string, description string) (string, error) {
 
@@ -22,10 +22,15 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/arweave"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/ipfs"
+	"github.com/rocket-pool/smartnode/shared/services/lease"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/store"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -46,14 +51,17 @@ type submitRewardsTree_Stateless struct {
 	rp               *rocketpool.RocketPool
 	ec               rocketpool.ExecutionClient
 	bc               beacon.Client
+	alerts           *alerting.Manager
+	submissions      *utils.SubmissionTracker
 	lock             *sync.Mutex
 	isRunning        bool
 	generationPrefix string
 	m                *state.NetworkStateManager
+	dutyLease        *lease.Lease
 }
 
 // Create submit rewards Merkle Tree task
-func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager) (*submitRewardsTree_Stateless, error) {
+func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager, dutyLease *lease.Lease) (*submitRewardsTree_Stateless, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -76,15 +84,26 @@ func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, erro
 	if err != nil {
 		return nil, err
 	}
+	alerts, err := services.GetAlertManager(c)
+	if err != nil {
+		return nil, err
+	}
+	db, err := services.GetStore(c)
+	if err != nil {
+		return nil, err
+	}
 
 	lock := &sync.Mutex{}
 	generator := &submitRewardsTree_Stateless{
+		dutyLease:        dutyLease,
 		c:                c,
 		log:              &logger,
 		errLog:           &errorLogger,
 		cfg:              cfg,
 		ec:               ec,
 		bc:               bc,
+		alerts:           alerts,
+		submissions:      utils.NewSubmissionTracker(db, ec, watchtowerDuty_RewardsTree),
 		w:                w,
 		rp:               rp,
 		lock:             lock,
@@ -182,6 +201,18 @@ func (t *submitRewardsTree_Stateless) Run(nodeTrusted bool, state *state.Network
 	}
 	t.lock.Unlock()
 
+	// Don't submit again if the transaction sent before a restart is still waiting to be included
+	if nodeTrusted {
+		status, err := t.submissions.GetStatus(fmt.Sprint(currentIndex))
+		if err != nil {
+			return err
+		}
+		if status == store.SubmissionStatus_Pending {
+			t.log.Printlnf("The rewards tree for interval %d was already submitted, waiting for the transaction to be included.", currentIndex)
+			return nil
+		}
+	}
+
 	// Get the expected file paths
 	rewardsTreePath := t.cfg.Smartnode.GetRewardsTreePath(currentIndex, true)
 	compressedRewardsTreePath := rewardsTreePath + config.RewardsTreeIpfsExtension
@@ -217,6 +248,14 @@ func (t *submitRewardsTree_Stateless) Run(nodeTrusted bool, state *state.Network
 			return fmt.Errorf("Error deserializing rewards tree file: %w", err)
 		}
 
+		// Strip the local provenance so the uploaded file matches the other Oracle DAO members
+		if proofWrapper.GetHeader().Provenance != nil {
+			wrapperBytes, err = rprewards.SerializeCanonicalRewardsFile(proofWrapper)
+			if err != nil {
+				return fmt.Errorf("Error serializing canonical rewards tree file: %w", err)
+			}
+		}
+
 		// Upload the file
 		cid, err := t.uploadFileToWeb3Storage(wrapperBytes, compressedRewardsTreePath, "compressed rewards tree")
 		if err != nil {
@@ -231,6 +270,7 @@ func (t *submitRewardsTree_Stateless) Run(nodeTrusted bool, state *state.Network
 		}
 
 		t.log.Printlnf("Successfully submitted rewards snapshot for interval %d.", currentIndex)
+		t.notifyTreeSubmitted(currentIndex)
 		return nil
 	}
 
@@ -255,6 +295,14 @@ func (t *submitRewardsTree_Stateless) printMessage(message string) {
 	t.log.Printlnf("%s %s", t.generationPrefix, message)
 }
 
+// Notify the configured chat services that the tree for an interval was submitted
+func (t *submitRewardsTree_Stateless) notifyTreeSubmitted(index uint64) {
+	err := t.alerts.Notify("rewards-tree-submitted", fmt.Sprintf("Submitted the rewards tree for interval %d.", index))
+	if err != nil {
+		t.printMessage(fmt.Sprintf("Could not send the rewards tree notification: %s", err.Error()))
+	}
+}
+
 // Checks to see if an existing rewards file is still valid
 func (t *submitRewardsTree_Stateless) isExistingFileValid(rewardsTreePath string, intervalsPassed uint64) bool {
 
@@ -332,13 +380,13 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 	}
 
 	// Create a new state for the target block
-	state, err := mgr.GetStateForSlot(snapshotBeaconBlock)
+	state, err := mgr.GetTreegenStateForSlot(snapshotBeaconBlock)
 	if err != nil {
 		return fmt.Errorf("couldn't get network state for EL block %d, Beacon slot %d: %w", elBlockIndex, snapshotBeaconBlock, err)
 	}
 
 	// Generate the rewards file
-	treegen, err := rprewards.NewTreeGenerator(t.log, t.generationPrefix, rp, t.cfg, t.bc, currentIndex, startTime, endTime, snapshotBeaconBlock, snapshotElBlockHeader, uint64(intervalsPassed), state, nil)
+	treegen, err := rprewards.NewTreeGenerator(t.log, t.generationPrefix, rprewards.GenerationFlow_Submission, rp, t.cfg, t.bc, currentIndex, startTime, endTime, snapshotBeaconBlock, snapshotElBlockHeader, uint64(intervalsPassed), state, nil)
 	if err != nil {
 		return fmt.Errorf("Error creating Merkle tree generator: %w", err)
 	}
@@ -376,11 +424,15 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 		rewardsFile.SetMinipoolPerformanceFileCID("---")
 	}
 
-	// Serialize the rewards tree to JSON
+	// Serialize the rewards tree to JSON, leaving the provenance out of the copy that gets shared
 	wrapperBytes, err := rewardsFile.Serialize()
 	if err != nil {
 		return fmt.Errorf("Error serializing proof wrapper into JSON: %w", err)
 	}
+	canonicalBytes, err := rprewards.SerializeCanonicalRewardsFile(rewardsFile)
+	if err != nil {
+		return fmt.Errorf("Error serializing canonical proof wrapper into JSON: %w", err)
+	}
 	t.printMessage("Generation complete! Saving tree...")
 
 	// Write the rewards tree to disk
@@ -389,11 +441,33 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 		return fmt.Errorf("Error saving rewards tree file to %s: %w", rewardsTreePath, err)
 	}
 
+	// Save SSZ copies if enabled
+	if t.cfg.Smartnode.SaveSszRewardsFiles.Value.(bool) {
+		err = rprewards.SaveSszRewardsFiles(t.cfg, currentIndex, rewardsFile, true, true)
+		if err != nil {
+			t.printMessage(fmt.Sprintf("WARNING: couldn't save SSZ copies of the rewards files: %s", err.Error()))
+		}
+	}
+
+	// Save the per-minipool breakdown if enabled
+	if t.cfg.Smartnode.GenerateMinipoolAttribution.Value.(bool) {
+		err = rprewards.SaveMinipoolAttributionReport(t.cfg, rewardsFile, state, true)
+		if err != nil {
+			t.printMessage(fmt.Sprintf("WARNING: couldn't save the minipool attribution report: %s", err.Error()))
+		}
+	}
+
+	// Pin the files to IPFS if enabled
+	t.pinRewardsFiles(currentIndex, canonicalBytes, minipoolPerformanceBytes)
+
+	// Mirror the files to Arweave if enabled
+	t.uploadRewardsFilesToArweave(currentIndex, canonicalBytes, minipoolPerformanceBytes)
+
 	// Only do the upload and submission process if this is an Oracle DAO node
 	if nodeTrusted {
 		// Upload the rewards tree file
 		t.printMessage("Uploading to Web3.Storage and submitting results to the contracts...")
-		cid, err := t.uploadFileToWeb3Storage(wrapperBytes, compressedRewardsTreePath, "compressed rewards tree")
+		cid, err := t.uploadFileToWeb3Storage(canonicalBytes, compressedRewardsTreePath, "compressed rewards tree")
 		if err != nil {
 			return fmt.Errorf("Error uploading Merkle tree to Web3.Storage: %w", err)
 		}
@@ -406,6 +480,7 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 		}
 
 		t.printMessage(fmt.Sprintf("Successfully submitted rewards snapshot for interval %d.", currentIndex))
+		t.notifyTreeSubmitted(currentIndex)
 	} else {
 		t.printMessage(fmt.Sprintf("Successfully generated rewards snapshot for interval %d.", currentIndex))
 	}
@@ -444,7 +519,7 @@ func (t *submitRewardsTree_Stateless) submitRewardsSnapshot(index *big.Int, cons
 	}
 
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := utils.GetWatchtowerTransactor(t.w, t.dutyLease)
 	if err != nil {
 		return err
 	}
@@ -494,6 +569,9 @@ func (t *submitRewardsTree_Stateless) submitRewardsSnapshot(index *big.Int, cons
 	if err != nil {
 		return err
 	}
+	if err := t.submissions.Sent(index.String(), hash); err != nil {
+		t.log.Printlnf("WARNING: Couldn't record the rewards tree submission: %s", err.Error())
+	}
 
 	// Print TX info and wait for it to be included in a block
 	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
@@ -505,6 +583,47 @@ func (t *submitRewardsTree_Stateless) submitRewardsSnapshot(index *big.Int, cons
 	return nil
 }
 
+// Pins the rewards files to the configured IPFS service; failures are logged but don't stop the submission
+func (t *submitRewardsTree_Stateless) pinRewardsFiles(index uint64, wrapperBytes []byte, minipoolPerformanceBytes []byte) {
+	pinner, err := ipfs.NewPinner(t.cfg)
+	if err != nil {
+		t.printMessage(fmt.Sprintf("WARNING: couldn't set up IPFS pinning: %s", err.Error()))
+		return
+	}
+	if pinner == nil {
+		return
+	}
+
+	t.printMessage(fmt.Sprintf("Pinning rewards files to %s...", pinner.GetName()))
+	record, err := ipfs.PinRewardsFiles(pinner, t.cfg, index, wrapperBytes, minipoolPerformanceBytes)
+	if err != nil {
+		t.printMessage(fmt.Sprintf("WARNING: couldn't pin rewards files to %s: %s", pinner.GetName(), err.Error()))
+		return
+	}
+	t.printMessage(fmt.Sprintf("Pinned rewards tree with CID %s and minipool performance file with CID %s", record.RewardsFileCID, record.MinipoolPerformanceFileCID))
+}
+
+// Uploads the rewards files to Arweave through Bundlr; failures are logged but don't stop the submission
+func (t *submitRewardsTree_Stateless) uploadRewardsFilesToArweave(index uint64, wrapperBytes []byte, minipoolPerformanceBytes []byte) {
+	if !t.cfg.Smartnode.EnableArweaveUpload.Value.(bool) {
+		return
+	}
+
+	uploader, err := arweave.NewBundlrUploader(t.cfg.Smartnode.BundlrNodeUrl.Value.(string), t.cfg.Smartnode.BundlrPrivateKey.Value.(string))
+	if err != nil {
+		t.printMessage(fmt.Sprintf("WARNING: couldn't set up Arweave uploads: %s", err.Error()))
+		return
+	}
+
+	t.printMessage(fmt.Sprintf("Uploading rewards files to Arweave using Bundlr account %s...", uploader.GetAddress()))
+	record, err := arweave.UploadRewardsFiles(uploader, t.cfg, index, wrapperBytes, minipoolPerformanceBytes)
+	if err != nil {
+		t.printMessage(fmt.Sprintf("WARNING: couldn't upload rewards files to Arweave: %s", err.Error()))
+		return
+	}
+	t.printMessage(fmt.Sprintf("Uploaded rewards tree to Arweave with transaction %s and minipool performance file with transaction %s", record.RewardsFileTxID, record.MinipoolPerformanceFileTxID))
+}
+
 // Compress and upload a file to Web3.Storage and get the CID for it
 func (t *submitRewardsTree_Stateless) uploadFileToWeb3Storage(wrapperBytes []byte, compressedPath string, description string) (string, error) {
 
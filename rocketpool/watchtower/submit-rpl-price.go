@@ -26,7 +26,9 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/lease"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/store"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -192,20 +194,22 @@ type poolObserveResponse struct {
 
 // Submit RPL price task
 type submitRplPrice struct {
-	c         *cli.Context
-	log       log.ColorLogger
-	errLog    log.ColorLogger
-	cfg       *config.RocketPoolConfig
-	ec        rocketpool.ExecutionClient
-	w         *wallet.Wallet
-	rp        *rocketpool.RocketPool
-	bc        beacon.Client
-	lock      *sync.Mutex
-	isRunning bool
+	c           *cli.Context
+	log         log.ColorLogger
+	errLog      log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	ec          rocketpool.ExecutionClient
+	w           *wallet.Wallet
+	rp          *rocketpool.RocketPool
+	bc          beacon.Client
+	submissions *utils.SubmissionTracker
+	lock        *sync.Mutex
+	isRunning   bool
+	dutyLease   *lease.Lease
 }
 
 // Create submit RPL price task
-func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*submitRplPrice, error) {
+func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, dutyLease *lease.Lease) (*submitRplPrice, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -228,19 +232,25 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.C
 	if err != nil {
 		return nil, err
 	}
+	db, err := services.GetStore(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Return task
 	lock := &sync.Mutex{}
 	return &submitRplPrice{
-		c:      c,
-		log:    logger,
-		errLog: errorLogger,
-		cfg:    cfg,
-		ec:     ec,
-		w:      w,
-		rp:     rp,
-		bc:     bc,
-		lock:   lock,
+		dutyLease:   dutyLease,
+		c:           c,
+		log:         logger,
+		errLog:      errorLogger,
+		cfg:         cfg,
+		ec:          ec,
+		w:           w,
+		rp:          rp,
+		bc:          bc,
+		submissions: utils.NewSubmissionTracker(db, ec, watchtowerDuty_RplPrice),
+		lock:        lock,
 	}, nil
 
 }
@@ -345,6 +355,16 @@ func (t *submitRplPrice) run(state *state.NetworkState) error {
 	}
 	t.lock.Unlock()
 
+	// Don't submit again if the transaction sent before a restart is still waiting to be included
+	status, err := t.submissions.GetStatus(fmt.Sprint(blockNumber))
+	if err != nil {
+		return err
+	}
+	if status == store.SubmissionStatus_Pending {
+		t.log.Printlnf("Prices for block %d were already submitted, waiting for the transaction to be included.", blockNumber)
+		return nil
+	}
+
 	go func() {
 		t.lock.Lock()
 		t.isRunning = true
@@ -514,7 +534,7 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice *big.Int) e
 	t.log.Printlnf("Submitting RPL price for block %d...", blockNumber)
 
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := utils.GetWatchtowerTransactor(t.w, t.dutyLease)
 	if err != nil {
 		return err
 	}
@@ -541,6 +561,9 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice *big.Int) e
 	if err != nil {
 		return err
 	}
+	if err := t.submissions.Sent(fmt.Sprint(blockNumber), hash); err != nil {
+		t.log.Printlnf("WARNING: Couldn't record the price submission: %s", err.Error())
+	}
 
 	// Print TX info and wait for it to be included in a block
 	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
@@ -566,7 +589,7 @@ func (t *submitRplPrice) submitOptimismPrice() error {
 	}
 
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := utils.GetWatchtowerTransactor(t.w, t.dutyLease)
 	if err != nil {
 		return fmt.Errorf("Failed getting transactor: %q", err)
 	}
@@ -706,7 +729,7 @@ func (t *submitRplPrice) submitPolygonPrice() error {
 	}
 
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := utils.GetWatchtowerTransactor(t.w, t.dutyLease)
 	if err != nil {
 		return fmt.Errorf("Failed getting transactor: %q", err)
 	}
@@ -846,7 +869,7 @@ func (t *submitRplPrice) submitArbitrumPrice() error {
 	}
 
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := utils.GetWatchtowerTransactor(t.w, t.dutyLease)
 	if err != nil {
 		return fmt.Errorf("Failed getting transactor: %q", err)
 	}
@@ -913,7 +936,7 @@ func (t *submitRplPrice) submitArbitrumPrice() error {
 	if index == indexToSubmit {
 
 		// Get the current network recommended max fee
-		suggestedMaxFee, err := rpgas.GetHeadlessMaxFeeWei()
+		suggestedMaxFee, err := rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return fmt.Errorf("error getting recommended base fee from the network for Arbitrum price submission: %w", err)
 		}
@@ -1011,7 +1034,7 @@ func (t *submitRplPrice) submitZkSyncEraPrice() error {
 	}
 
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := utils.GetWatchtowerTransactor(t.w, t.dutyLease)
 	if err != nil {
 		return fmt.Errorf("Failed getting transactor: %q", err)
 	}
@@ -1169,7 +1192,7 @@ func (t *submitRplPrice) submitBasePrice() error {
 	}
 
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := utils.GetWatchtowerTransactor(t.w, t.dutyLease)
 	if err != nil {
 		return fmt.Errorf("Failed getting transactor: %q", err)
 	}
@@ -25,7 +25,9 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/lease"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/store"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -40,18 +42,20 @@ const MinScrubSafetyTime = time.Duration(0) * time.Hour
 
 // Submit scrub minipools task
 type submitScrubMinipools struct {
-	c         *cli.Context
-	log       log.ColorLogger
-	errLog    log.ColorLogger
-	cfg       *config.RocketPoolConfig
-	w         *wallet.Wallet
-	rp        *rocketpool.RocketPool
-	ec        rocketpool.ExecutionClient
-	bc        beacon.Client
-	it        *iterationData
-	coll      *collectors.ScrubCollector
-	lock      *sync.Mutex
-	isRunning bool
+	c           *cli.Context
+	log         log.ColorLogger
+	errLog      log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	w           *wallet.Wallet
+	rp          *rocketpool.RocketPool
+	ec          rocketpool.ExecutionClient
+	bc          beacon.Client
+	it          *iterationData
+	coll        *collectors.ScrubCollector
+	submissions *utils.SubmissionTracker
+	lock        *sync.Mutex
+	isRunning   bool
+	dutyLease   *lease.Lease
 }
 
 type iterationData struct {
@@ -83,7 +87,7 @@ type minipoolDetails struct {
 }
 
 // Create submit scrub minipools task
-func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.ScrubCollector) (*submitScrubMinipools, error) {
+func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.ScrubCollector, dutyLease *lease.Lease) (*submitScrubMinipools, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -106,21 +110,27 @@ func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger
 	if err != nil {
 		return nil, err
 	}
+	db, err := services.GetStore(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Return task
 	lock := &sync.Mutex{}
 	return &submitScrubMinipools{
-		c:         c,
-		log:       logger,
-		errLog:    errorLogger,
-		cfg:       cfg,
-		w:         w,
-		rp:        rp,
-		ec:        ec,
-		bc:        bc,
-		coll:      coll,
-		lock:      lock,
-		isRunning: false,
+		dutyLease:   dutyLease,
+		c:           c,
+		log:         logger,
+		errLog:      errorLogger,
+		cfg:         cfg,
+		w:           w,
+		rp:          rp,
+		ec:          ec,
+		bc:          bc,
+		coll:        coll,
+		submissions: utils.NewSubmissionTracker(db, ec, watchtowerDuty_ScrubMinipool),
+		lock:        lock,
+		isRunning:   false,
 	}, nil
 
 }
@@ -561,11 +571,22 @@ func (t *submitScrubMinipools) checkSafetyScrub(state *state.NetworkState) error
 // Submit minipool scrub status
 func (t *submitScrubMinipools) submitVoteScrubMinipool(mp minipool.Minipool) error {
 
+	// Each member only votes once, so don't vote again if the vote sent before a restart is still waiting to be included
+	target := mp.GetAddress().Hex()
+	status, err := t.submissions.GetStatus(target)
+	if err != nil {
+		return err
+	}
+	if status == store.SubmissionStatus_Pending || status == store.SubmissionStatus_Succeeded {
+		t.log.Printlnf("Already voted to scrub minipool %s.", target)
+		return nil
+	}
+
 	// Log
 	t.log.Printlnf("Voting to scrub minipool %s...", mp.GetAddress().Hex())
 
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := utils.GetWatchtowerTransactor(t.w, t.dutyLease)
 	if err != nil {
 		return err
 	}
@@ -592,6 +613,9 @@ func (t *submitScrubMinipools) submitVoteScrubMinipool(mp minipool.Minipool) err
 	if err != nil {
 		return fmt.Errorf("error voting to scrub minipool %s: %w", mp.GetAddress().Hex(), err)
 	}
+	if err := t.submissions.Sent(target, hash); err != nil {
+		t.log.Printlnf("WARNING: Couldn't record the scrub vote: %s", err.Error())
+	}
 
 	// Print TX info and wait for it to be included in a block
 	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/store"
+)
+
+// Tracks the transactions the watchtower sends for one of its duties, so a restart while one is still waiting to be
+// included in a block doesn't send it again
+type SubmissionTracker struct {
+	db   *store.Store
+	ec   rocketpool.ExecutionClient
+	duty string
+}
+
+// Create a tracker for a duty's submissions
+func NewSubmissionTracker(db *store.Store, ec rocketpool.ExecutionClient, duty string) *SubmissionTracker {
+	return &SubmissionTracker{
+		db:   db,
+		ec:   ec,
+		duty: duty,
+	}
+}
+
+// Get the status of the latest submission for a target, checking on the ones that were still pending when they were last seen.
+// The status is empty if nothing has been submitted for the target.
+func (t *SubmissionTracker) GetStatus(target string) (store.SubmissionStatus, error) {
+	submission, err := t.db.GetWatchtowerSubmission(t.duty, target)
+	if err != nil || submission == nil {
+		return "", err
+	}
+	if submission.Status != store.SubmissionStatus_Pending {
+		return submission.Status, nil
+	}
+
+	// Check if it's been included since
+	status := store.SubmissionStatus_Pending
+	receipt, err := t.ec.TransactionReceipt(context.Background(), submission.TxHash)
+	if err == nil {
+		if receipt.Status == types.ReceiptStatusSuccessful {
+			status = store.SubmissionStatus_Succeeded
+		} else {
+			status = store.SubmissionStatus_Failed
+		}
+	} else if errors.Is(err, ethereum.NotFound) {
+		// It's only still pending if the client knows about it
+		_, _, err = t.ec.TransactionByHash(context.Background(), submission.TxHash)
+		if errors.Is(err, ethereum.NotFound) {
+			status = store.SubmissionStatus_Dropped
+		} else if err != nil {
+			return "", fmt.Errorf("error getting %s transaction %s: %w", t.duty, submission.TxHash.Hex(), err)
+		}
+	} else {
+		return "", fmt.Errorf("error getting receipt for %s transaction %s: %w", t.duty, submission.TxHash.Hex(), err)
+	}
+
+	if status != store.SubmissionStatus_Pending {
+		if err := t.db.SetWatchtowerSubmissionStatus(t.duty, target, status); err != nil {
+			return "", err
+		}
+	}
+	return status, nil
+}
+
+// Record a transaction that was just sent for a target
+func (t *SubmissionTracker) Sent(target string, txHash common.Hash) error {
+	return t.db.AddWatchtowerSubmission(t.duty, target, txHash)
+}
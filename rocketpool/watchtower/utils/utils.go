@@ -1,6 +1,15 @@
 package utils
 
-import "github.com/rocket-pool/smartnode/shared/services/config"
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/lease"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+)
 
 const (
 	MinWatchtowerMaxFee        float64 = 200
@@ -26,3 +35,22 @@ func GetWatchtowerPrioFee(cfg *config.RocketPoolConfig) float64 {
 	}
 	return setting
 }
+
+// Get a transactor for the node account that only signs transactions while this watchtower holds the duty lease.
+// A standby watchtower takes over the duties once this one stops renewing the lease, so a task that was already running at
+// that point must not submit anything afterwards; checking in the signer covers every submission path, including the L2
+// messenger contracts that are called directly.
+func GetWatchtowerTransactor(w *wallet.Wallet, dutyLease *lease.Lease) (*bind.TransactOpts, error) {
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil || dutyLease == nil || opts.Signer == nil {
+		return opts, err
+	}
+	signer := opts.Signer
+	opts.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if !dutyLease.IsHeld() {
+			return nil, fmt.Errorf("the duty lease is no longer held by this watchtower, skipping the submission")
+		}
+		return signer(address, tx)
+	}
+	return opts, nil
+}
@@ -18,6 +18,8 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/heartbeat"
+	"github.com/rocket-pool/smartnode/shared/services/lease"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
@@ -26,6 +28,7 @@ import (
 var minTasksInterval, _ = time.ParseDuration("4m")
 var maxTasksInterval, _ = time.ParseDuration("6m")
 var taskCooldown, _ = time.ParseDuration("5s")
+var maxDutyDuration, _ = time.ParseDuration("6m")
 
 const (
 	MaxConcurrentEth1Requests = 200
@@ -43,6 +46,7 @@ const (
 	ProcessPenaltiesColor          = color.FgHiMagenta
 	CancelBondsColor               = color.FgGreen
 	CheckSoloMigrationsColor       = color.FgCyan
+	DutyLeaseColor                 = color.FgHiBlue
 	UpdateColor                    = color.FgHiWhite
 )
 
@@ -115,47 +119,74 @@ func run(c *cli.Context) error {
 		return err
 	}
 
+	// Subscribe to new finalized checkpoints so the tasks run as soon as an epoch is finalized
+	eventStream, err := services.GetBeaconEventStream(c)
+	if err != nil {
+		return err
+	}
+	finalizedEvents := eventStream.Subscribe(func(event beacon.Event) bool {
+		return event.FinalizedCheckpoint != nil
+	})
+
 	// Get the node address
 	nodeAccount, err := w.GetNodeAccount()
 	if err != nil {
 		return fmt.Errorf("error getting node account: %w", err)
 	}
 
+	// Coordinate with the other watchtowers for this node, if there are any, so only one of them does the duties
+	var dutyLease *lease.Lease
+	leasePath := cfg.Smartnode.WatchtowerLeasePath.Value.(string)
+	if leasePath != "" {
+		leaseLog := log.NewColorLogger(DutyLeaseColor)
+		leaseTimeout := time.Duration(cfg.Smartnode.WatchtowerLeaseTimeout.Value.(float64) * float64(time.Minute))
+		minLeaseTimeout := maxTasksInterval + maxDutyDuration
+		if leaseTimeout < minLeaseTimeout {
+			return fmt.Errorf("the watchtower lease timeout (%s) must be at least %s so a standby can't take over while the primary is still running a duty", leaseTimeout, minLeaseTimeout)
+		}
+		dutyLease, err = lease.NewLease(leasePath, leaseTimeout, &leaseLog)
+		if err != nil {
+			return fmt.Errorf("error creating duty lease: %w", err)
+		}
+		fmt.Printf("Watchtower redundancy is enabled; this instance is %s.\n", dutyLease.Instance())
+		go dutyLease.Run()
+	}
+
 	// Initialize tasks
-	respondChallenges, err := newRespondChallenges(c, log.NewColorLogger(RespondChallengesColor), m)
+	respondChallenges, err := newRespondChallenges(c, log.NewColorLogger(RespondChallengesColor), m, dutyLease)
 	if err != nil {
 		return fmt.Errorf("error during respond-to-challenges check: %w", err)
 	}
-	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor), errorLog)
+	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor), errorLog, dutyLease)
 	if err != nil {
 		return fmt.Errorf("error during rpl price check: %w", err)
 	}
-	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor), errorLog)
+	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor), errorLog, dutyLease)
 	if err != nil {
 		return fmt.Errorf("error during network balances check: %w", err)
 	}
-	dissolveTimedOutMinipools, err := newDissolveTimedOutMinipools(c, log.NewColorLogger(DissolveTimedOutMinipoolsColor))
+	dissolveTimedOutMinipools, err := newDissolveTimedOutMinipools(c, log.NewColorLogger(DissolveTimedOutMinipoolsColor), dutyLease)
 	if err != nil {
 		return fmt.Errorf("error during timed-out minipools check: %w", err)
 	}
-	submitScrubMinipools, err := newSubmitScrubMinipools(c, log.NewColorLogger(SubmitScrubMinipoolsColor), errorLog, scrubCollector)
+	submitScrubMinipools, err := newSubmitScrubMinipools(c, log.NewColorLogger(SubmitScrubMinipoolsColor), errorLog, scrubCollector, dutyLease)
 	if err != nil {
 		return fmt.Errorf("error during scrub check: %w", err)
 	}
 	var submitRewardsTree_Stateless *submitRewardsTree_Stateless
 	var submitRewardsTree_Rolling *submitRewardsTree_Rolling
 	if !useRollingRecords {
-		submitRewardsTree_Stateless, err = newSubmitRewardsTree_Stateless(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+		submitRewardsTree_Stateless, err = newSubmitRewardsTree_Stateless(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m, dutyLease)
 		if err != nil {
 			return fmt.Errorf("error during stateless rewards tree check: %w", err)
 		}
 	} else {
-		submitRewardsTree_Rolling, err = newSubmitRewardsTree_Rolling(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+		submitRewardsTree_Rolling, err = newSubmitRewardsTree_Rolling(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m, dutyLease)
 		if err != nil {
 			return fmt.Errorf("error during rolling rewards tree check: %w", err)
 		}
 	}
-	/*processPenalties, err := newProcessPenalties(c, log.NewColorLogger(ProcessPenaltiesColor), errorLog)
+	/*processPenalties, err := newProcessPenalties(c, log.NewColorLogger(ProcessPenaltiesColor), errorLog, dutyLease)
 	if err != nil {
 		return fmt.Errorf("error during penalties check: %w", err)
 	}*/
@@ -163,11 +194,11 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error during manual tree generation check: %w", err)
 	}
-	cancelBondReductions, err := newCancelBondReductions(c, log.NewColorLogger(CancelBondsColor), errorLog, bondReductionCollector)
+	cancelBondReductions, err := newCancelBondReductions(c, log.NewColorLogger(CancelBondsColor), errorLog, bondReductionCollector, dutyLease)
 	if err != nil {
 		return fmt.Errorf("error during bond reduction cancel check: %w", err)
 	}
-	checkSoloMigrations, err := newCheckSoloMigrations(c, log.NewColorLogger(CheckSoloMigrationsColor), errorLog, soloMigrationCollector)
+	checkSoloMigrations, err := newCheckSoloMigrations(c, log.NewColorLogger(CheckSoloMigrationsColor), errorLog, soloMigrationCollector, dutyLease)
 	if err != nil {
 		return fmt.Errorf("error during solo migration check: %w", err)
 	}
@@ -175,6 +206,10 @@ func run(c *cli.Context) error {
 	intervalDelta := maxTasksInterval - minTasksInterval
 	secondsDelta := intervalDelta.Seconds()
 
+	// Tell the operator's dead man's switch when a duty cycle succeeds
+	warningLog := log.NewColorLogger(WarningColor)
+	tasksHeartbeat := heartbeat.NewHeartbeat("watchtower", cfg.Smartnode.WatchtowerHeartbeatUrl.Value.(string), &warningLog)
+
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
 	wg.Add(2)
@@ -186,6 +221,11 @@ func run(c *cli.Context) error {
 			randomSeconds := rand.Intn(int(secondsDelta))
 			interval := time.Duration(randomSeconds)*time.Second + minTasksInterval
 
+			// Let the duty lease know this loop is still alive
+			if dutyLease != nil {
+				dutyLease.CheckIn()
+			}
+
 			// Check the EC status
 			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
 			if err != nil {
@@ -202,6 +242,14 @@ func run(c *cli.Context) error {
 				continue
 			}
 
+			// Defer the tasks if every client's circuit breaker is open
+			err = services.CheckClientsAvailable(c)
+			if err != nil {
+				errorLog.Println(err)
+				time.Sleep(taskCooldown)
+				continue
+			}
+
 			// Get the Beacon block
 			//latestBlock, err := m.GetLatestFinalizedBeaconBlock()
 			latestBlock, err := m.GetLatestBeaconBlock()
@@ -219,9 +267,15 @@ func run(c *cli.Context) error {
 				continue
 			}
 
+			// Standby instances act like regular nodes and leave the Oracle DAO duties to the one holding the lease
+			if isOnOdao && dutyLease != nil && !dutyLease.IsHeld() {
+				isOnOdao = false
+			}
+
 			// Run the manual rewards tree generation
 			if err := generateRewardsTree.run(); err != nil {
 				errorLog.Println(err)
+				tasksHeartbeat.Fail()
 			}
 			time.Sleep(taskCooldown)
 
@@ -229,6 +283,7 @@ func run(c *cli.Context) error {
 				// Run the challenge check
 				if err := respondChallenges.run(); err != nil {
 					errorLog.Println(err)
+					tasksHeartbeat.Fail()
 				}
 				time.Sleep(taskCooldown)
 
@@ -243,6 +298,7 @@ func run(c *cli.Context) error {
 				// Run the network balance submission check
 				if err := submitNetworkBalances.run(state); err != nil {
 					errorLog.Println(err)
+					tasksHeartbeat.Fail()
 				}
 				time.Sleep(taskCooldown)
 
@@ -250,12 +306,14 @@ func run(c *cli.Context) error {
 					// Run the rewards tree submission check
 					if err := submitRewardsTree_Stateless.Run(isOnOdao, state, latestBlock.Slot); err != nil {
 						errorLog.Println(err)
+						tasksHeartbeat.Fail()
 					}
 					time.Sleep(taskCooldown)
 				} else {
 					// Run the network balance and rewards tree submission check
 					if err := submitRewardsTree_Rolling.run(state); err != nil {
 						errorLog.Println(err)
+						tasksHeartbeat.Fail()
 					}
 					time.Sleep(taskCooldown)
 				}
@@ -263,36 +321,42 @@ func run(c *cli.Context) error {
 				// Run the price submission check
 				if err := submitRplPrice.run(state); err != nil {
 					errorLog.Println(err)
+					tasksHeartbeat.Fail()
 				}
 				time.Sleep(taskCooldown)
 
 				// Run the minipool dissolve check
 				if err := dissolveTimedOutMinipools.run(state); err != nil {
 					errorLog.Println(err)
+					tasksHeartbeat.Fail()
 				}
 				time.Sleep(taskCooldown)
 
 				// Run the minipool scrub check
 				if err := submitScrubMinipools.run(state); err != nil {
 					errorLog.Println(err)
+					tasksHeartbeat.Fail()
 				}
 				time.Sleep(taskCooldown)
 
 				// Run the bond cancel check
 				if err := cancelBondReductions.run(state); err != nil {
 					errorLog.Println(err)
+					tasksHeartbeat.Fail()
 				}
 				time.Sleep(taskCooldown)
 
 				// Run the solo migration check
 				if err := checkSoloMigrations.run(state); err != nil {
 					errorLog.Println(err)
+					tasksHeartbeat.Fail()
 				}
 				/*time.Sleep(taskCooldown)
 
 				// Run the fee recipient penalty check
 				if err := processPenalties.run(); err != nil {
 					errorLog.Println(err)
+					tasksHeartbeat.Fail()
 				}*/
 				// DISABLED until MEV-Boost can support it
 			} else {
@@ -302,16 +366,20 @@ func run(c *cli.Context) error {
 					// Run the rewards tree submission check
 					if err := submitRewardsTree_Stateless.Run(isOnOdao, nil, latestBlock.Slot); err != nil {
 						errorLog.Println(err)
+						tasksHeartbeat.Fail()
 					}
 				} else {
 					// Run the network balance and rewards tree submission check
 					if err := submitRewardsTree_Rolling.run(nil); err != nil {
 						errorLog.Println(err)
+						tasksHeartbeat.Fail()
 					}
 				}
 			}
+			tasksHeartbeat.Ping()
 
-			time.Sleep(interval)
+			// Wait for the next finalized checkpoint, falling back to the timer if the event stream is unavailable
+			services.WaitForBeaconEvent(finalizedEvents, interval)
 		}
 		wg.Done()
 	}()
@@ -0,0 +1,4 @@
+// Package rpc contains the node daemon's gRPC service, generated from smartnode.proto.
+package rpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative smartnode.proto
@@ -0,0 +1,2054 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
+// source: smartnode.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// How a transaction should be handled
+type TransactionMode int32
+
+const (
+	TransactionMode_TRANSACTION_MODE_SUBMIT   TransactionMode = 0
+	TransactionMode_TRANSACTION_MODE_UNSIGNED TransactionMode = 1
+	TransactionMode_TRANSACTION_MODE_DRY_RUN  TransactionMode = 2
+	TransactionMode_TRANSACTION_MODE_DEFERRED TransactionMode = 3
+)
+
+// Enum value maps for TransactionMode.
+var (
+	TransactionMode_name = map[int32]string{
+		0: "TRANSACTION_MODE_SUBMIT",
+		1: "TRANSACTION_MODE_UNSIGNED",
+		2: "TRANSACTION_MODE_DRY_RUN",
+		3: "TRANSACTION_MODE_DEFERRED",
+	}
+	TransactionMode_value = map[string]int32{
+		"TRANSACTION_MODE_SUBMIT":   0,
+		"TRANSACTION_MODE_UNSIGNED": 1,
+		"TRANSACTION_MODE_DRY_RUN":  2,
+		"TRANSACTION_MODE_DEFERRED": 3,
+	}
+)
+
+func (x TransactionMode) Enum() *TransactionMode {
+	p := new(TransactionMode)
+	*p = x
+	return p
+}
+
+func (x TransactionMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransactionMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_smartnode_proto_enumTypes[0].Descriptor()
+}
+
+func (TransactionMode) Type() protoreflect.EnumType {
+	return &file_smartnode_proto_enumTypes[0]
+}
+
+func (x TransactionMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransactionMode.Descriptor instead.
+func (TransactionMode) EnumDescriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{0}
+}
+
+// The gas and nonce settings for a transaction; unset values use the node's defaults
+type TransactionOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxFeeGwei         float64         `protobuf:"fixed64,1,opt,name=max_fee_gwei,json=maxFeeGwei,proto3" json:"max_fee_gwei,omitempty"`
+	MaxPriorityFeeGwei float64         `protobuf:"fixed64,2,opt,name=max_priority_fee_gwei,json=maxPriorityFeeGwei,proto3" json:"max_priority_fee_gwei,omitempty"`
+	GasLimit           uint64          `protobuf:"varint,3,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	Nonce              string          `protobuf:"bytes,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Mode               TransactionMode `protobuf:"varint,5,opt,name=mode,proto3,enum=smartnode.v1.TransactionMode" json:"mode,omitempty"`
+}
+
+func (x *TransactionOptions) Reset() {
+	*x = TransactionOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactionOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionOptions) ProtoMessage() {}
+
+func (x *TransactionOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionOptions.ProtoReflect.Descriptor instead.
+func (*TransactionOptions) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TransactionOptions) GetMaxFeeGwei() float64 {
+	if x != nil {
+		return x.MaxFeeGwei
+	}
+	return 0
+}
+
+func (x *TransactionOptions) GetMaxPriorityFeeGwei() float64 {
+	if x != nil {
+		return x.MaxPriorityFeeGwei
+	}
+	return 0
+}
+
+func (x *TransactionOptions) GetGasLimit() uint64 {
+	if x != nil {
+		return x.GasLimit
+	}
+	return 0
+}
+
+func (x *TransactionOptions) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *TransactionOptions) GetMode() TransactionMode {
+	if x != nil {
+		return x.Mode
+	}
+	return TransactionMode_TRANSACTION_MODE_SUBMIT
+}
+
+type GetNodeStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetNodeStatusRequest) Reset() {
+	*x = GetNodeStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNodeStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNodeStatusRequest) ProtoMessage() {}
+
+func (x *GetNodeStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNodeStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetNodeStatusRequest) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{1}
+}
+
+type NodeStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Registered       bool         `protobuf:"varint,1,opt,name=registered,proto3" json:"registered,omitempty"`
+	ElBlockNumber    uint64       `protobuf:"varint,2,opt,name=el_block_number,json=elBlockNumber,proto3" json:"el_block_number,omitempty"`
+	BeaconSlotNumber uint64       `protobuf:"varint,3,opt,name=beacon_slot_number,json=beaconSlotNumber,proto3" json:"beacon_slot_number,omitempty"`
+	Node             *NodeDetails `protobuf:"bytes,4,opt,name=node,proto3" json:"node,omitempty"`
+}
+
+func (x *NodeStatus) Reset() {
+	*x = NodeStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeStatus) ProtoMessage() {}
+
+func (x *NodeStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeStatus.ProtoReflect.Descriptor instead.
+func (*NodeStatus) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NodeStatus) GetRegistered() bool {
+	if x != nil {
+		return x.Registered
+	}
+	return false
+}
+
+func (x *NodeStatus) GetElBlockNumber() uint64 {
+	if x != nil {
+		return x.ElBlockNumber
+	}
+	return 0
+}
+
+func (x *NodeStatus) GetBeaconSlotNumber() uint64 {
+	if x != nil {
+		return x.BeaconSlotNumber
+	}
+	return 0
+}
+
+func (x *NodeStatus) GetNode() *NodeDetails {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+type NodeDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address                 string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	WithdrawalAddress       string `protobuf:"bytes,2,opt,name=withdrawal_address,json=withdrawalAddress,proto3" json:"withdrawal_address,omitempty"`
+	TimezoneLocation        string `protobuf:"bytes,3,opt,name=timezone_location,json=timezoneLocation,proto3" json:"timezone_location,omitempty"`
+	RegistrationTime        int64  `protobuf:"varint,4,opt,name=registration_time,json=registrationTime,proto3" json:"registration_time,omitempty"`
+	FeeDistributorAddress   string `protobuf:"bytes,5,opt,name=fee_distributor_address,json=feeDistributorAddress,proto3" json:"fee_distributor_address,omitempty"`
+	FeeDistributorBalance   string `protobuf:"bytes,6,opt,name=fee_distributor_balance,json=feeDistributorBalance,proto3" json:"fee_distributor_balance,omitempty"`
+	SmoothingPoolRegistered bool   `protobuf:"varint,7,opt,name=smoothing_pool_registered,json=smoothingPoolRegistered,proto3" json:"smoothing_pool_registered,omitempty"`
+	EthBalance              string `protobuf:"bytes,8,opt,name=eth_balance,json=ethBalance,proto3" json:"eth_balance,omitempty"`
+	RethBalance             string `protobuf:"bytes,9,opt,name=reth_balance,json=rethBalance,proto3" json:"reth_balance,omitempty"`
+	RplBalance              string `protobuf:"bytes,10,opt,name=rpl_balance,json=rplBalance,proto3" json:"rpl_balance,omitempty"`
+	RplStake                string `protobuf:"bytes,11,opt,name=rpl_stake,json=rplStake,proto3" json:"rpl_stake,omitempty"`
+	EffectiveRplStake       string `protobuf:"bytes,12,opt,name=effective_rpl_stake,json=effectiveRplStake,proto3" json:"effective_rpl_stake,omitempty"`
+	MinimumRplStake         string `protobuf:"bytes,13,opt,name=minimum_rpl_stake,json=minimumRplStake,proto3" json:"minimum_rpl_stake,omitempty"`
+	MaximumRplStake         string `protobuf:"bytes,14,opt,name=maximum_rpl_stake,json=maximumRplStake,proto3" json:"maximum_rpl_stake,omitempty"`
+	EthMatched              string `protobuf:"bytes,15,opt,name=eth_matched,json=ethMatched,proto3" json:"eth_matched,omitempty"`
+	EthMatchedLimit         string `protobuf:"bytes,16,opt,name=eth_matched_limit,json=ethMatchedLimit,proto3" json:"eth_matched_limit,omitempty"`
+	DepositCreditBalance    string `protobuf:"bytes,17,opt,name=deposit_credit_balance,json=depositCreditBalance,proto3" json:"deposit_credit_balance,omitempty"`
+	MinipoolCount           uint64 `protobuf:"varint,18,opt,name=minipool_count,json=minipoolCount,proto3" json:"minipool_count,omitempty"`
+	AverageNodeFee          string `protobuf:"bytes,19,opt,name=average_node_fee,json=averageNodeFee,proto3" json:"average_node_fee,omitempty"`
+	CollateralisationRatio  string `protobuf:"bytes,20,opt,name=collateralisation_ratio,json=collateralisationRatio,proto3" json:"collateralisation_ratio,omitempty"`
+}
+
+func (x *NodeDetails) Reset() {
+	*x = NodeDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeDetails) ProtoMessage() {}
+
+func (x *NodeDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeDetails.ProtoReflect.Descriptor instead.
+func (*NodeDetails) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *NodeDetails) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetWithdrawalAddress() string {
+	if x != nil {
+		return x.WithdrawalAddress
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetTimezoneLocation() string {
+	if x != nil {
+		return x.TimezoneLocation
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetRegistrationTime() int64 {
+	if x != nil {
+		return x.RegistrationTime
+	}
+	return 0
+}
+
+func (x *NodeDetails) GetFeeDistributorAddress() string {
+	if x != nil {
+		return x.FeeDistributorAddress
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetFeeDistributorBalance() string {
+	if x != nil {
+		return x.FeeDistributorBalance
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetSmoothingPoolRegistered() bool {
+	if x != nil {
+		return x.SmoothingPoolRegistered
+	}
+	return false
+}
+
+func (x *NodeDetails) GetEthBalance() string {
+	if x != nil {
+		return x.EthBalance
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetRethBalance() string {
+	if x != nil {
+		return x.RethBalance
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetRplBalance() string {
+	if x != nil {
+		return x.RplBalance
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetRplStake() string {
+	if x != nil {
+		return x.RplStake
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetEffectiveRplStake() string {
+	if x != nil {
+		return x.EffectiveRplStake
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetMinimumRplStake() string {
+	if x != nil {
+		return x.MinimumRplStake
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetMaximumRplStake() string {
+	if x != nil {
+		return x.MaximumRplStake
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetEthMatched() string {
+	if x != nil {
+		return x.EthMatched
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetEthMatchedLimit() string {
+	if x != nil {
+		return x.EthMatchedLimit
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetDepositCreditBalance() string {
+	if x != nil {
+		return x.DepositCreditBalance
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetMinipoolCount() uint64 {
+	if x != nil {
+		return x.MinipoolCount
+	}
+	return 0
+}
+
+func (x *NodeDetails) GetAverageNodeFee() string {
+	if x != nil {
+		return x.AverageNodeFee
+	}
+	return ""
+}
+
+func (x *NodeDetails) GetCollateralisationRatio() string {
+	if x != nil {
+		return x.CollateralisationRatio
+	}
+	return ""
+}
+
+type GetMinipoolsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IncludeFinalized bool `protobuf:"varint,1,opt,name=include_finalized,json=includeFinalized,proto3" json:"include_finalized,omitempty"`
+}
+
+func (x *GetMinipoolsRequest) Reset() {
+	*x = GetMinipoolsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMinipoolsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMinipoolsRequest) ProtoMessage() {}
+
+func (x *GetMinipoolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMinipoolsRequest.ProtoReflect.Descriptor instead.
+func (*GetMinipoolsRequest) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetMinipoolsRequest) GetIncludeFinalized() bool {
+	if x != nil {
+		return x.IncludeFinalized
+	}
+	return false
+}
+
+type MinipoolList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ElBlockNumber    uint64      `protobuf:"varint,1,opt,name=el_block_number,json=elBlockNumber,proto3" json:"el_block_number,omitempty"`
+	BeaconSlotNumber uint64      `protobuf:"varint,2,opt,name=beacon_slot_number,json=beaconSlotNumber,proto3" json:"beacon_slot_number,omitempty"`
+	Minipools        []*Minipool `protobuf:"bytes,3,rep,name=minipools,proto3" json:"minipools,omitempty"`
+}
+
+func (x *MinipoolList) Reset() {
+	*x = MinipoolList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MinipoolList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinipoolList) ProtoMessage() {}
+
+func (x *MinipoolList) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinipoolList.ProtoReflect.Descriptor instead.
+func (*MinipoolList) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MinipoolList) GetElBlockNumber() uint64 {
+	if x != nil {
+		return x.ElBlockNumber
+	}
+	return 0
+}
+
+func (x *MinipoolList) GetBeaconSlotNumber() uint64 {
+	if x != nil {
+		return x.BeaconSlotNumber
+	}
+	return 0
+}
+
+func (x *MinipoolList) GetMinipools() []*Minipool {
+	if x != nil {
+		return x.Minipools
+	}
+	return nil
+}
+
+type Minipool struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address                  string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Pubkey                   string `protobuf:"bytes,2,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	ValidatorIndex           string `protobuf:"bytes,3,opt,name=validator_index,json=validatorIndex,proto3" json:"validator_index,omitempty"`
+	Status                   string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	StatusTime               int64  `protobuf:"varint,5,opt,name=status_time,json=statusTime,proto3" json:"status_time,omitempty"`
+	DepositType              string `protobuf:"bytes,6,opt,name=deposit_type,json=depositType,proto3" json:"deposit_type,omitempty"`
+	Version                  uint32 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	Finalised                bool   `protobuf:"varint,8,opt,name=finalised,proto3" json:"finalised,omitempty"`
+	Vacant                   bool   `protobuf:"varint,9,opt,name=vacant,proto3" json:"vacant,omitempty"`
+	NodeFee                  string `protobuf:"bytes,10,opt,name=node_fee,json=nodeFee,proto3" json:"node_fee,omitempty"`
+	NodeDepositBalance       string `protobuf:"bytes,11,opt,name=node_deposit_balance,json=nodeDepositBalance,proto3" json:"node_deposit_balance,omitempty"`
+	UserDepositBalance       string `protobuf:"bytes,12,opt,name=user_deposit_balance,json=userDepositBalance,proto3" json:"user_deposit_balance,omitempty"`
+	PenaltyCount             uint64 `protobuf:"varint,13,opt,name=penalty_count,json=penaltyCount,proto3" json:"penalty_count,omitempty"`
+	PenaltyRate              string `protobuf:"bytes,14,opt,name=penalty_rate,json=penaltyRate,proto3" json:"penalty_rate,omitempty"`
+	Balance                  string `protobuf:"bytes,15,opt,name=balance,proto3" json:"balance,omitempty"`
+	NodeShareOfBalance       string `protobuf:"bytes,16,opt,name=node_share_of_balance,json=nodeShareOfBalance,proto3" json:"node_share_of_balance,omitempty"`
+	NodeRefundBalance        string `protobuf:"bytes,17,opt,name=node_refund_balance,json=nodeRefundBalance,proto3" json:"node_refund_balance,omitempty"`
+	ValidatorStatus          string `protobuf:"bytes,18,opt,name=validator_status,json=validatorStatus,proto3" json:"validator_status,omitempty"`
+	BeaconBalance            string `protobuf:"bytes,19,opt,name=beacon_balance,json=beaconBalance,proto3" json:"beacon_balance,omitempty"`
+	EffectiveBalance         string `protobuf:"bytes,20,opt,name=effective_balance,json=effectiveBalance,proto3" json:"effective_balance,omitempty"`
+	NodeShareOfBeaconBalance string `protobuf:"bytes,21,opt,name=node_share_of_beacon_balance,json=nodeShareOfBeaconBalance,proto3" json:"node_share_of_beacon_balance,omitempty"`
+	Slashed                  bool   `protobuf:"varint,22,opt,name=slashed,proto3" json:"slashed,omitempty"`
+	Delegate                 string `protobuf:"bytes,23,opt,name=delegate,proto3" json:"delegate,omitempty"`
+	UseLatestDelegate        bool   `protobuf:"varint,24,opt,name=use_latest_delegate,json=useLatestDelegate,proto3" json:"use_latest_delegate,omitempty"`
+}
+
+func (x *Minipool) Reset() {
+	*x = Minipool{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Minipool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Minipool) ProtoMessage() {}
+
+func (x *Minipool) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Minipool.ProtoReflect.Descriptor instead.
+func (*Minipool) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Minipool) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Minipool) GetPubkey() string {
+	if x != nil {
+		return x.Pubkey
+	}
+	return ""
+}
+
+func (x *Minipool) GetValidatorIndex() string {
+	if x != nil {
+		return x.ValidatorIndex
+	}
+	return ""
+}
+
+func (x *Minipool) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Minipool) GetStatusTime() int64 {
+	if x != nil {
+		return x.StatusTime
+	}
+	return 0
+}
+
+func (x *Minipool) GetDepositType() string {
+	if x != nil {
+		return x.DepositType
+	}
+	return ""
+}
+
+func (x *Minipool) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Minipool) GetFinalised() bool {
+	if x != nil {
+		return x.Finalised
+	}
+	return false
+}
+
+func (x *Minipool) GetVacant() bool {
+	if x != nil {
+		return x.Vacant
+	}
+	return false
+}
+
+func (x *Minipool) GetNodeFee() string {
+	if x != nil {
+		return x.NodeFee
+	}
+	return ""
+}
+
+func (x *Minipool) GetNodeDepositBalance() string {
+	if x != nil {
+		return x.NodeDepositBalance
+	}
+	return ""
+}
+
+func (x *Minipool) GetUserDepositBalance() string {
+	if x != nil {
+		return x.UserDepositBalance
+	}
+	return ""
+}
+
+func (x *Minipool) GetPenaltyCount() uint64 {
+	if x != nil {
+		return x.PenaltyCount
+	}
+	return 0
+}
+
+func (x *Minipool) GetPenaltyRate() string {
+	if x != nil {
+		return x.PenaltyRate
+	}
+	return ""
+}
+
+func (x *Minipool) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *Minipool) GetNodeShareOfBalance() string {
+	if x != nil {
+		return x.NodeShareOfBalance
+	}
+	return ""
+}
+
+func (x *Minipool) GetNodeRefundBalance() string {
+	if x != nil {
+		return x.NodeRefundBalance
+	}
+	return ""
+}
+
+func (x *Minipool) GetValidatorStatus() string {
+	if x != nil {
+		return x.ValidatorStatus
+	}
+	return ""
+}
+
+func (x *Minipool) GetBeaconBalance() string {
+	if x != nil {
+		return x.BeaconBalance
+	}
+	return ""
+}
+
+func (x *Minipool) GetEffectiveBalance() string {
+	if x != nil {
+		return x.EffectiveBalance
+	}
+	return ""
+}
+
+func (x *Minipool) GetNodeShareOfBeaconBalance() string {
+	if x != nil {
+		return x.NodeShareOfBeaconBalance
+	}
+	return ""
+}
+
+func (x *Minipool) GetSlashed() bool {
+	if x != nil {
+		return x.Slashed
+	}
+	return false
+}
+
+func (x *Minipool) GetDelegate() string {
+	if x != nil {
+		return x.Delegate
+	}
+	return ""
+}
+
+func (x *Minipool) GetUseLatestDelegate() bool {
+	if x != nil {
+		return x.UseLatestDelegate
+	}
+	return false
+}
+
+type MinipoolRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string              `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Options *TransactionOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (x *MinipoolRequest) Reset() {
+	*x = MinipoolRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MinipoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinipoolRequest) ProtoMessage() {}
+
+func (x *MinipoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinipoolRequest.ProtoReflect.Descriptor instead.
+func (*MinipoolRequest) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MinipoolRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *MinipoolRequest) GetOptions() *TransactionOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type TransactionResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxHash string `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+}
+
+func (x *TransactionResult) Reset() {
+	*x = TransactionResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionResult) ProtoMessage() {}
+
+func (x *TransactionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionResult.ProtoReflect.Descriptor instead.
+func (*TransactionResult) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TransactionResult) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+type ExitMinipoolResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ExitMinipoolResult) Reset() {
+	*x = ExitMinipoolResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExitMinipoolResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExitMinipoolResult) ProtoMessage() {}
+
+func (x *ExitMinipoolResult) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExitMinipoolResult.ProtoReflect.Descriptor instead.
+func (*ExitMinipoolResult) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{9}
+}
+
+type GetWalletStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetWalletStatusRequest) Reset() {
+	*x = GetWalletStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetWalletStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletStatusRequest) ProtoMessage() {}
+
+func (x *GetWalletStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetWalletStatusRequest) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{10}
+}
+
+type WalletStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PasswordSet       bool   `protobuf:"varint,1,opt,name=password_set,json=passwordSet,proto3" json:"password_set,omitempty"`
+	WalletInitialized bool   `protobuf:"varint,2,opt,name=wallet_initialized,json=walletInitialized,proto3" json:"wallet_initialized,omitempty"`
+	AccountAddress    string `protobuf:"bytes,3,opt,name=account_address,json=accountAddress,proto3" json:"account_address,omitempty"`
+}
+
+func (x *WalletStatus) Reset() {
+	*x = WalletStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WalletStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletStatus) ProtoMessage() {}
+
+func (x *WalletStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletStatus.ProtoReflect.Descriptor instead.
+func (*WalletStatus) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WalletStatus) GetPasswordSet() bool {
+	if x != nil {
+		return x.PasswordSet
+	}
+	return false
+}
+
+func (x *WalletStatus) GetWalletInitialized() bool {
+	if x != nil {
+		return x.WalletInitialized
+	}
+	return false
+}
+
+func (x *WalletStatus) GetAccountAddress() string {
+	if x != nil {
+		return x.AccountAddress
+	}
+	return ""
+}
+
+type RebuildWalletRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RebuildWalletRequest) Reset() {
+	*x = RebuildWalletRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RebuildWalletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildWalletRequest) ProtoMessage() {}
+
+func (x *RebuildWalletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildWalletRequest.ProtoReflect.Descriptor instead.
+func (*RebuildWalletRequest) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{12}
+}
+
+type RebuildWalletResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValidatorKeys []string `protobuf:"bytes,1,rep,name=validator_keys,json=validatorKeys,proto3" json:"validator_keys,omitempty"`
+}
+
+func (x *RebuildWalletResult) Reset() {
+	*x = RebuildWalletResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RebuildWalletResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildWalletResult) ProtoMessage() {}
+
+func (x *RebuildWalletResult) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildWalletResult.ProtoReflect.Descriptor instead.
+func (*RebuildWalletResult) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RebuildWalletResult) GetValidatorKeys() []string {
+	if x != nil {
+		return x.ValidatorKeys
+	}
+	return nil
+}
+
+type GetStateSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetStateSnapshotRequest) Reset() {
+	*x = GetStateSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStateSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStateSnapshotRequest) ProtoMessage() {}
+
+func (x *GetStateSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStateSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*GetStateSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{14}
+}
+
+type StateSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Registered bool   `protobuf:"varint,1,opt,name=registered,proto3" json:"registered,omitempty"`
+	Snapshot   []byte `protobuf:"bytes,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+}
+
+func (x *StateSnapshot) Reset() {
+	*x = StateSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StateSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateSnapshot) ProtoMessage() {}
+
+func (x *StateSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateSnapshot.ProtoReflect.Descriptor instead.
+func (*StateSnapshot) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StateSnapshot) GetRegistered() bool {
+	if x != nil {
+		return x.Registered
+	}
+	return false
+}
+
+func (x *StateSnapshot) GetSnapshot() []byte {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+type WatchStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// How often to check for changes, in seconds; the minimum is 12
+	IntervalSeconds  uint32 `protobuf:"varint,1,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	IncludeFinalized bool   `protobuf:"varint,2,opt,name=include_finalized,json=includeFinalized,proto3" json:"include_finalized,omitempty"`
+}
+
+func (x *WatchStateRequest) Reset() {
+	*x = WatchStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchStateRequest) ProtoMessage() {}
+
+func (x *WatchStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchStateRequest.ProtoReflect.Descriptor instead.
+func (*WatchStateRequest) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WatchStateRequest) GetIntervalSeconds() uint32 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *WatchStateRequest) GetIncludeFinalized() bool {
+	if x != nil {
+		return x.IncludeFinalized
+	}
+	return false
+}
+
+type NodeState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status    *NodeStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Minipools []*Minipool `protobuf:"bytes,2,rep,name=minipools,proto3" json:"minipools,omitempty"`
+}
+
+func (x *NodeState) Reset() {
+	*x = NodeState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeState) ProtoMessage() {}
+
+func (x *NodeState) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeState.ProtoReflect.Descriptor instead.
+func (*NodeState) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *NodeState) GetStatus() *NodeStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *NodeState) GetMinipools() []*Minipool {
+	if x != nil {
+		return x.Minipools
+	}
+	return nil
+}
+
+type StreamLogsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StreamLogsRequest) Reset() {
+	*x = StreamLogsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamLogsRequest) ProtoMessage() {}
+
+func (x *StreamLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamLogsRequest.ProtoReflect.Descriptor instead.
+func (*StreamLogsRequest) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{18}
+}
+
+type LogLine struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time int64  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Line string `protobuf:"bytes,2,opt,name=line,proto3" json:"line,omitempty"`
+}
+
+func (x *LogLine) Reset() {
+	*x = LogLine{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_smartnode_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLine) ProtoMessage() {}
+
+func (x *LogLine) ProtoReflect() protoreflect.Message {
+	mi := &file_smartnode_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLine.ProtoReflect.Descriptor instead.
+func (*LogLine) Descriptor() ([]byte, []int) {
+	return file_smartnode_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LogLine) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *LogLine) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+var File_smartnode_proto protoreflect.FileDescriptor
+
+var file_smartnode_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x0c, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x22,
+	0xcf, 0x01, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x20, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x66, 0x65,
+	0x65, 0x5f, 0x67, 0x77, 0x65, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x6d, 0x61,
+	0x78, 0x46, 0x65, 0x65, 0x47, 0x77, 0x65, 0x69, 0x12, 0x31, 0x0a, 0x15, 0x6d, 0x61, 0x78, 0x5f,
+	0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x67, 0x77, 0x65,
+	0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x69, 0x6f,
+	0x72, 0x69, 0x74, 0x79, 0x46, 0x65, 0x65, 0x47, 0x77, 0x65, 0x69, 0x12, 0x1b, 0x0a, 0x09, 0x67,
+	0x61, 0x73, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
+	0x67, 0x61, 0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x31,
+	0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x73,
+	0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64,
+	0x65, 0x22, 0x16, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xb1, 0x01, 0x0a, 0x0a, 0x4e, 0x6f,
+	0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x65, 0x6c, 0x5f, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0d, 0x65, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x2c, 0x0a, 0x12, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x5f, 0x73, 0x6c, 0x6f, 0x74, 0x5f,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x62, 0x65,
+	0x61, 0x63, 0x6f, 0x6e, 0x53, 0x6c, 0x6f, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2d,
+	0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73,
+	0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0xf3, 0x06,
+	0x0a, 0x0b, 0x4e, 0x6f, 0x64, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x77, 0x69, 0x74, 0x68, 0x64,
+	0x72, 0x61, 0x77, 0x61, 0x6c, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x11, 0x77, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x61, 0x6c, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f,
+	0x6e, 0x65, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x10, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x36, 0x0a, 0x17, 0x66, 0x65, 0x65, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x6f, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x15, 0x66, 0x65, 0x65, 0x44, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f,
+	0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x66, 0x65, 0x65, 0x5f,
+	0x64, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x5f, 0x62, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x66, 0x65, 0x65, 0x44, 0x69,
+	0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x3a, 0x0a, 0x19, 0x73, 0x6d, 0x6f, 0x6f, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x6f,
+	0x6f, 0x6c, 0x5f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x17, 0x73, 0x6d, 0x6f, 0x6f, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x50, 0x6f,
+	0x6f, 0x6c, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b,
+	0x65, 0x74, 0x68, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x65, 0x74, 0x68, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x21, 0x0a,
+	0x0c, 0x72, 0x65, 0x74, 0x68, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x74, 0x68, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x70, 0x6c, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x70, 0x6c, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x70, 0x6c, 0x5f, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x70, 0x6c, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x12, 0x2e,
+	0x0a, 0x13, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x72, 0x70, 0x6c, 0x5f,
+	0x73, 0x74, 0x61, 0x6b, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x65, 0x66, 0x66,
+	0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x52, 0x70, 0x6c, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x12, 0x2a,
+	0x0a, 0x11, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x72, 0x70, 0x6c, 0x5f, 0x73, 0x74,
+	0x61, 0x6b, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6d, 0x69, 0x6e, 0x69, 0x6d,
+	0x75, 0x6d, 0x52, 0x70, 0x6c, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x61,
+	0x78, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x72, 0x70, 0x6c, 0x5f, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x52, 0x70,
+	0x6c, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x74, 0x68, 0x5f, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x74, 0x68,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x65, 0x74, 0x68, 0x5f, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x65, 0x74, 0x68, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x12, 0x34, 0x0a, 0x16, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x5f, 0x63,
+	0x72, 0x65, 0x64, 0x69, 0x74, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x11, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x14, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x43, 0x72, 0x65, 0x64,
+	0x69, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x69, 0x6e,
+	0x69, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x69, 0x70, 0x6f, 0x6f, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x28, 0x0a, 0x10, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x6f, 0x64, 0x65,
+	0x5f, 0x66, 0x65, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x76, 0x65, 0x72,
+	0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x46, 0x65, 0x65, 0x12, 0x37, 0x0a, 0x17, 0x63, 0x6f,
+	0x6c, 0x6c, 0x61, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x69, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x63, 0x6f, 0x6c,
+	0x6c, 0x61, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x69, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x61,
+	0x74, 0x69, 0x6f, 0x22, 0x42, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x4d, 0x69, 0x6e, 0x69, 0x70, 0x6f,
+	0x6f, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x46, 0x69,
+	0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x22, 0x9a, 0x01, 0x0a, 0x0c, 0x4d, 0x69, 0x6e, 0x69,
+	0x70, 0x6f, 0x6f, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x65, 0x6c, 0x5f, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0d, 0x65, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x2c, 0x0a, 0x12, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x5f, 0x73, 0x6c, 0x6f, 0x74, 0x5f,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x62, 0x65,
+	0x61, 0x63, 0x6f, 0x6e, 0x53, 0x6c, 0x6f, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x34,
+	0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x69, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x70, 0x6f, 0x6f, 0x6c, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x69, 0x70,
+	0x6f, 0x6f, 0x6c, 0x73, 0x22, 0xfa, 0x06, 0x0a, 0x08, 0x4d, 0x69, 0x6e, 0x69, 0x70, 0x6f, 0x6f,
+	0x6c, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x75, 0x62,
+	0x6b, 0x65, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x73, 0x65, 0x64, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x73, 0x65, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x63, 0x61, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x76, 0x61, 0x63, 0x61, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x64, 0x65,
+	0x5f, 0x66, 0x65, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x6f, 0x64, 0x65,
+	0x46, 0x65, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x64, 0x65, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x12, 0x6e, 0x6f, 0x64, 0x65, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x42, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x12, 0x75, 0x73, 0x65, 0x72, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x6e, 0x61, 0x6c,
+	0x74, 0x79, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c,
+	0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c,
+	0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x52, 0x61, 0x74, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x31, 0x0a, 0x15, 0x6e, 0x6f, 0x64,
+	0x65, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x5f, 0x6f, 0x66, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e,
+	0x63, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6e, 0x6f, 0x64, 0x65, 0x53, 0x68,
+	0x61, 0x72, 0x65, 0x4f, 0x66, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x2e, 0x0a, 0x13,
+	0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x62, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6e, 0x6f, 0x64, 0x65, 0x52,
+	0x65, 0x66, 0x75, 0x6e, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x29, 0x0a, 0x10,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x65, 0x61, 0x63, 0x6f,
+	0x6e, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x2b,
+	0x0a, 0x11, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x62, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x65, 0x66, 0x66, 0x65, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x3e, 0x0a, 0x1c, 0x6e,
+	0x6f, 0x64, 0x65, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x5f, 0x6f, 0x66, 0x5f, 0x62, 0x65, 0x61,
+	0x63, 0x6f, 0x6e, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x18, 0x6e, 0x6f, 0x64, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x4f, 0x66, 0x42, 0x65,
+	0x61, 0x63, 0x6f, 0x6e, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x6c, 0x61, 0x73, 0x68, 0x65, 0x64, 0x18, 0x16, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x6c,
+	0x61, 0x73, 0x68, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x12, 0x2e, 0x0a, 0x13, 0x75, 0x73, 0x65, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f,
+	0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x18, 0x18, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11,
+	0x75, 0x73, 0x65, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x22, 0x67, 0x0a, 0x0f, 0x4d, 0x69, 0x6e, 0x69, 0x70, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x3a,
+	0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x2c, 0x0a, 0x11, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x17, 0x0a, 0x07, 0x74, 0x78, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x22, 0x14, 0x0a, 0x12, 0x45, 0x78, 0x69, 0x74,
+	0x4d, 0x69, 0x6e, 0x69, 0x70, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x18,
+	0x0a, 0x16, 0x47, 0x65, 0x74, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x89, 0x01, 0x0a, 0x0c, 0x57, 0x61, 0x6c,
+	0x6c, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x74, 0x12, 0x2d, 0x0a, 0x12,
+	0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x5f, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74,
+	0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x22, 0x16, 0x0a, 0x14, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x57,
+	0x61, 0x6c, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3c, 0x0a, 0x13,
+	0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x19, 0x0a, 0x17, 0x47, 0x65,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x4b, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x22, 0x6b, 0x0a, 0x11, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x66, 0x69,
+	0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x69,
+	0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x22,
+	0x73, 0x0a, 0x09, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x30, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73,
+	0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x34,
+	0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x69, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x70, 0x6f, 0x6f, 0x6c, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x69, 0x70,
+	0x6f, 0x6f, 0x6c, 0x73, 0x22, 0x13, 0x0a, 0x11, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4c, 0x6f,
+	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x31, 0x0a, 0x07, 0x4c, 0x6f, 0x67,
+	0x4c, 0x69, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x2a, 0x8a, 0x01, 0x0a,
+	0x0f, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65,
+	0x12, 0x1b, 0x0a, 0x17, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x55, 0x42, 0x4d, 0x49, 0x54, 0x10, 0x00, 0x12, 0x1d, 0x0a,
+	0x19, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44,
+	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x49, 0x47, 0x4e, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18,
+	0x54, 0x52, 0x41, 0x4e, 0x53, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
+	0x5f, 0x44, 0x52, 0x59, 0x5f, 0x52, 0x55, 0x4e, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x54, 0x52,
+	0x41, 0x4e, 0x53, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x44,
+	0x45, 0x46, 0x45, 0x52, 0x52, 0x45, 0x44, 0x10, 0x03, 0x32, 0xe2, 0x07, 0x0a, 0x09, 0x53, 0x6d,
+	0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x4d, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4e, 0x6f,
+	0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x22, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74,
+	0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x73,
+	0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4d, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4d, 0x69, 0x6e,
+	0x69, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x12, 0x21, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f,
+	0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x69, 0x6e, 0x69, 0x70, 0x6f, 0x6f,
+	0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x73, 0x6d, 0x61, 0x72,
+	0x74, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x70, 0x6f, 0x6f,
+	0x6c, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x4f, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x4d, 0x69,
+	0x6e, 0x69, 0x70, 0x6f, 0x6f, 0x6c, 0x12, 0x1d, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f,
+	0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x70, 0x6f, 0x6f, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x50, 0x0a, 0x0e, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64,
+	0x4d, 0x69, 0x6e, 0x69, 0x70, 0x6f, 0x6f, 0x6c, 0x12, 0x1d, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74,
+	0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x70, 0x6f, 0x6f, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e,
+	0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x5b, 0x0a, 0x19, 0x44, 0x69, 0x73, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x4d, 0x69, 0x6e, 0x69, 0x70, 0x6f, 0x6f, 0x6c, 0x42, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1d, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x70, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x4f, 0x0a, 0x0d, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x4d, 0x69,
+	0x6e, 0x69, 0x70, 0x6f, 0x6f, 0x6c, 0x12, 0x1d, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f,
+	0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x70, 0x6f, 0x6f, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x4f, 0x0a, 0x0c, 0x45, 0x78, 0x69, 0x74, 0x4d, 0x69,
+	0x6e, 0x69, 0x70, 0x6f, 0x6f, 0x6c, 0x12, 0x1d, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f,
+	0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x70, 0x6f, 0x6f, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x69, 0x74, 0x4d, 0x69, 0x6e, 0x69, 0x70, 0x6f, 0x6f,
+	0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x53, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x57, 0x61,
+	0x6c, 0x6c, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x24, 0x2e, 0x73, 0x6d, 0x61,
+	0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x57, 0x61, 0x6c,
+	0x6c, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1a, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x56, 0x0a, 0x0d,
+	0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x12, 0x22, 0x2e,
+	0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x21, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x56, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x25, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74,
+	0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1b, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x48, 0x0a, 0x0a,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x2e, 0x73, 0x6d, 0x61,
+	0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73, 0x6d,
+	0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x30, 0x01, 0x12, 0x46, 0x0a, 0x0a, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x4c, 0x6f, 0x67, 0x73, 0x12, 0x1f, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f, 0x64,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x30, 0x01, 0x42, 0x2d,
+	0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x2d, 0x70, 0x6f, 0x6f, 0x6c, 0x2f, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6e, 0x6f,
+	0x64, 0x65, 0x2f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_smartnode_proto_rawDescOnce sync.Once
+	file_smartnode_proto_rawDescData = file_smartnode_proto_rawDesc
+)
+
+func file_smartnode_proto_rawDescGZIP() []byte {
+	file_smartnode_proto_rawDescOnce.Do(func() {
+		file_smartnode_proto_rawDescData = protoimpl.X.CompressGZIP(file_smartnode_proto_rawDescData)
+	})
+	return file_smartnode_proto_rawDescData
+}
+
+var file_smartnode_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_smartnode_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_smartnode_proto_goTypes = []interface{}{
+	(TransactionMode)(0),            // 0: smartnode.v1.TransactionMode
+	(*TransactionOptions)(nil),      // 1: smartnode.v1.TransactionOptions
+	(*GetNodeStatusRequest)(nil),    // 2: smartnode.v1.GetNodeStatusRequest
+	(*NodeStatus)(nil),              // 3: smartnode.v1.NodeStatus
+	(*NodeDetails)(nil),             // 4: smartnode.v1.NodeDetails
+	(*GetMinipoolsRequest)(nil),     // 5: smartnode.v1.GetMinipoolsRequest
+	(*MinipoolList)(nil),            // 6: smartnode.v1.MinipoolList
+	(*Minipool)(nil),                // 7: smartnode.v1.Minipool
+	(*MinipoolRequest)(nil),         // 8: smartnode.v1.MinipoolRequest
+	(*TransactionResult)(nil),       // 9: smartnode.v1.TransactionResult
+	(*ExitMinipoolResult)(nil),      // 10: smartnode.v1.ExitMinipoolResult
+	(*GetWalletStatusRequest)(nil),  // 11: smartnode.v1.GetWalletStatusRequest
+	(*WalletStatus)(nil),            // 12: smartnode.v1.WalletStatus
+	(*RebuildWalletRequest)(nil),    // 13: smartnode.v1.RebuildWalletRequest
+	(*RebuildWalletResult)(nil),     // 14: smartnode.v1.RebuildWalletResult
+	(*GetStateSnapshotRequest)(nil), // 15: smartnode.v1.GetStateSnapshotRequest
+	(*StateSnapshot)(nil),           // 16: smartnode.v1.StateSnapshot
+	(*WatchStateRequest)(nil),       // 17: smartnode.v1.WatchStateRequest
+	(*NodeState)(nil),               // 18: smartnode.v1.NodeState
+	(*StreamLogsRequest)(nil),       // 19: smartnode.v1.StreamLogsRequest
+	(*LogLine)(nil),                 // 20: smartnode.v1.LogLine
+}
+var file_smartnode_proto_depIdxs = []int32{
+	0,  // 0: smartnode.v1.TransactionOptions.mode:type_name -> smartnode.v1.TransactionMode
+	4,  // 1: smartnode.v1.NodeStatus.node:type_name -> smartnode.v1.NodeDetails
+	7,  // 2: smartnode.v1.MinipoolList.minipools:type_name -> smartnode.v1.Minipool
+	1,  // 3: smartnode.v1.MinipoolRequest.options:type_name -> smartnode.v1.TransactionOptions
+	3,  // 4: smartnode.v1.NodeState.status:type_name -> smartnode.v1.NodeStatus
+	7,  // 5: smartnode.v1.NodeState.minipools:type_name -> smartnode.v1.Minipool
+	2,  // 6: smartnode.v1.Smartnode.GetNodeStatus:input_type -> smartnode.v1.GetNodeStatusRequest
+	5,  // 7: smartnode.v1.Smartnode.GetMinipools:input_type -> smartnode.v1.GetMinipoolsRequest
+	8,  // 8: smartnode.v1.Smartnode.StakeMinipool:input_type -> smartnode.v1.MinipoolRequest
+	8,  // 9: smartnode.v1.Smartnode.RefundMinipool:input_type -> smartnode.v1.MinipoolRequest
+	8,  // 10: smartnode.v1.Smartnode.DistributeMinipoolBalance:input_type -> smartnode.v1.MinipoolRequest
+	8,  // 11: smartnode.v1.Smartnode.CloseMinipool:input_type -> smartnode.v1.MinipoolRequest
+	8,  // 12: smartnode.v1.Smartnode.ExitMinipool:input_type -> smartnode.v1.MinipoolRequest
+	11, // 13: smartnode.v1.Smartnode.GetWalletStatus:input_type -> smartnode.v1.GetWalletStatusRequest
+	13, // 14: smartnode.v1.Smartnode.RebuildWallet:input_type -> smartnode.v1.RebuildWalletRequest
+	15, // 15: smartnode.v1.Smartnode.GetStateSnapshot:input_type -> smartnode.v1.GetStateSnapshotRequest
+	17, // 16: smartnode.v1.Smartnode.WatchState:input_type -> smartnode.v1.WatchStateRequest
+	19, // 17: smartnode.v1.Smartnode.StreamLogs:input_type -> smartnode.v1.StreamLogsRequest
+	3,  // 18: smartnode.v1.Smartnode.GetNodeStatus:output_type -> smartnode.v1.NodeStatus
+	6,  // 19: smartnode.v1.Smartnode.GetMinipools:output_type -> smartnode.v1.MinipoolList
+	9,  // 20: smartnode.v1.Smartnode.StakeMinipool:output_type -> smartnode.v1.TransactionResult
+	9,  // 21: smartnode.v1.Smartnode.RefundMinipool:output_type -> smartnode.v1.TransactionResult
+	9,  // 22: smartnode.v1.Smartnode.DistributeMinipoolBalance:output_type -> smartnode.v1.TransactionResult
+	9,  // 23: smartnode.v1.Smartnode.CloseMinipool:output_type -> smartnode.v1.TransactionResult
+	10, // 24: smartnode.v1.Smartnode.ExitMinipool:output_type -> smartnode.v1.ExitMinipoolResult
+	12, // 25: smartnode.v1.Smartnode.GetWalletStatus:output_type -> smartnode.v1.WalletStatus
+	14, // 26: smartnode.v1.Smartnode.RebuildWallet:output_type -> smartnode.v1.RebuildWalletResult
+	16, // 27: smartnode.v1.Smartnode.GetStateSnapshot:output_type -> smartnode.v1.StateSnapshot
+	18, // 28: smartnode.v1.Smartnode.WatchState:output_type -> smartnode.v1.NodeState
+	20, // 29: smartnode.v1.Smartnode.StreamLogs:output_type -> smartnode.v1.LogLine
+	18, // [18:30] is the sub-list for method output_type
+	6,  // [6:18] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_smartnode_proto_init() }
+func file_smartnode_proto_init() {
+	if File_smartnode_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_smartnode_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNodeStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMinipoolsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MinipoolList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Minipool); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MinipoolRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExitMinipoolResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetWalletStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WalletStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RebuildWalletRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RebuildWalletResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStateSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StateSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamLogsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_smartnode_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogLine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_smartnode_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_smartnode_proto_goTypes,
+		DependencyIndexes: file_smartnode_proto_depIdxs,
+		EnumInfos:         file_smartnode_proto_enumTypes,
+		MessageInfos:      file_smartnode_proto_msgTypes,
+	}.Build()
+	File_smartnode_proto = out.File
+	file_smartnode_proto_rawDesc = nil
+	file_smartnode_proto_goTypes = nil
+	file_smartnode_proto_depIdxs = nil
+}
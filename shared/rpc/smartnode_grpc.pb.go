@@ -0,0 +1,580 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: smartnode.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SmartnodeClient is the client API for Smartnode service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SmartnodeClient interface {
+	// Get the node's registration details, balances and stakes
+	GetNodeStatus(ctx context.Context, in *GetNodeStatusRequest, opts ...grpc.CallOption) (*NodeStatus, error)
+	// Get the node's minipools
+	GetMinipools(ctx context.Context, in *GetMinipoolsRequest, opts ...grpc.CallOption) (*MinipoolList, error)
+	// Stake a prelaunch minipool
+	StakeMinipool(ctx context.Context, in *MinipoolRequest, opts ...grpc.CallOption) (*TransactionResult, error)
+	// Refund the node's ETH from a minipool
+	RefundMinipool(ctx context.Context, in *MinipoolRequest, opts ...grpc.CallOption) (*TransactionResult, error)
+	// Distribute a minipool's balance
+	DistributeMinipoolBalance(ctx context.Context, in *MinipoolRequest, opts ...grpc.CallOption) (*TransactionResult, error)
+	// Close a dissolved or finalised minipool
+	CloseMinipool(ctx context.Context, in *MinipoolRequest, opts ...grpc.CallOption) (*TransactionResult, error)
+	// Submit a voluntary exit for a minipool's validator
+	ExitMinipool(ctx context.Context, in *MinipoolRequest, opts ...grpc.CallOption) (*ExitMinipoolResult, error)
+	// Get the status of the node wallet
+	GetWalletStatus(ctx context.Context, in *GetWalletStatusRequest, opts ...grpc.CallOption) (*WalletStatus, error)
+	// Rebuild the validator keys of the node wallet
+	RebuildWallet(ctx context.Context, in *RebuildWalletRequest, opts ...grpc.CallOption) (*RebuildWalletResult, error)
+	// Get a zstd-compressed JSON snapshot of the node's network state, as used by --state-file
+	GetStateSnapshot(ctx context.Context, in *GetStateSnapshotRequest, opts ...grpc.CallOption) (*StateSnapshot, error)
+	// Stream the node's state each time it changes
+	WatchState(ctx context.Context, in *WatchStateRequest, opts ...grpc.CallOption) (Smartnode_WatchStateClient, error)
+	// Stream the daemon's log lines
+	StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (Smartnode_StreamLogsClient, error)
+}
+
+type smartnodeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSmartnodeClient(cc grpc.ClientConnInterface) SmartnodeClient {
+	return &smartnodeClient{cc}
+}
+
+func (c *smartnodeClient) GetNodeStatus(ctx context.Context, in *GetNodeStatusRequest, opts ...grpc.CallOption) (*NodeStatus, error) {
+	out := new(NodeStatus)
+	err := c.cc.Invoke(ctx, "/smartnode.v1.Smartnode/GetNodeStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smartnodeClient) GetMinipools(ctx context.Context, in *GetMinipoolsRequest, opts ...grpc.CallOption) (*MinipoolList, error) {
+	out := new(MinipoolList)
+	err := c.cc.Invoke(ctx, "/smartnode.v1.Smartnode/GetMinipools", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smartnodeClient) StakeMinipool(ctx context.Context, in *MinipoolRequest, opts ...grpc.CallOption) (*TransactionResult, error) {
+	out := new(TransactionResult)
+	err := c.cc.Invoke(ctx, "/smartnode.v1.Smartnode/StakeMinipool", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smartnodeClient) RefundMinipool(ctx context.Context, in *MinipoolRequest, opts ...grpc.CallOption) (*TransactionResult, error) {
+	out := new(TransactionResult)
+	err := c.cc.Invoke(ctx, "/smartnode.v1.Smartnode/RefundMinipool", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smartnodeClient) DistributeMinipoolBalance(ctx context.Context, in *MinipoolRequest, opts ...grpc.CallOption) (*TransactionResult, error) {
+	out := new(TransactionResult)
+	err := c.cc.Invoke(ctx, "/smartnode.v1.Smartnode/DistributeMinipoolBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smartnodeClient) CloseMinipool(ctx context.Context, in *MinipoolRequest, opts ...grpc.CallOption) (*TransactionResult, error) {
+	out := new(TransactionResult)
+	err := c.cc.Invoke(ctx, "/smartnode.v1.Smartnode/CloseMinipool", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smartnodeClient) ExitMinipool(ctx context.Context, in *MinipoolRequest, opts ...grpc.CallOption) (*ExitMinipoolResult, error) {
+	out := new(ExitMinipoolResult)
+	err := c.cc.Invoke(ctx, "/smartnode.v1.Smartnode/ExitMinipool", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smartnodeClient) GetWalletStatus(ctx context.Context, in *GetWalletStatusRequest, opts ...grpc.CallOption) (*WalletStatus, error) {
+	out := new(WalletStatus)
+	err := c.cc.Invoke(ctx, "/smartnode.v1.Smartnode/GetWalletStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smartnodeClient) RebuildWallet(ctx context.Context, in *RebuildWalletRequest, opts ...grpc.CallOption) (*RebuildWalletResult, error) {
+	out := new(RebuildWalletResult)
+	err := c.cc.Invoke(ctx, "/smartnode.v1.Smartnode/RebuildWallet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smartnodeClient) GetStateSnapshot(ctx context.Context, in *GetStateSnapshotRequest, opts ...grpc.CallOption) (*StateSnapshot, error) {
+	out := new(StateSnapshot)
+	err := c.cc.Invoke(ctx, "/smartnode.v1.Smartnode/GetStateSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smartnodeClient) WatchState(ctx context.Context, in *WatchStateRequest, opts ...grpc.CallOption) (Smartnode_WatchStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Smartnode_ServiceDesc.Streams[0], "/smartnode.v1.Smartnode/WatchState", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &smartnodeWatchStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Smartnode_WatchStateClient interface {
+	Recv() (*NodeState, error)
+	grpc.ClientStream
+}
+
+type smartnodeWatchStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *smartnodeWatchStateClient) Recv() (*NodeState, error) {
+	m := new(NodeState)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *smartnodeClient) StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (Smartnode_StreamLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Smartnode_ServiceDesc.Streams[1], "/smartnode.v1.Smartnode/StreamLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &smartnodeStreamLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Smartnode_StreamLogsClient interface {
+	Recv() (*LogLine, error)
+	grpc.ClientStream
+}
+
+type smartnodeStreamLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *smartnodeStreamLogsClient) Recv() (*LogLine, error) {
+	m := new(LogLine)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SmartnodeServer is the server API for Smartnode service.
+// All implementations must embed UnimplementedSmartnodeServer
+// for forward compatibility
+type SmartnodeServer interface {
+	// Get the node's registration details, balances and stakes
+	GetNodeStatus(context.Context, *GetNodeStatusRequest) (*NodeStatus, error)
+	// Get the node's minipools
+	GetMinipools(context.Context, *GetMinipoolsRequest) (*MinipoolList, error)
+	// Stake a prelaunch minipool
+	StakeMinipool(context.Context, *MinipoolRequest) (*TransactionResult, error)
+	// Refund the node's ETH from a minipool
+	RefundMinipool(context.Context, *MinipoolRequest) (*TransactionResult, error)
+	// Distribute a minipool's balance
+	DistributeMinipoolBalance(context.Context, *MinipoolRequest) (*TransactionResult, error)
+	// Close a dissolved or finalised minipool
+	CloseMinipool(context.Context, *MinipoolRequest) (*TransactionResult, error)
+	// Submit a voluntary exit for a minipool's validator
+	ExitMinipool(context.Context, *MinipoolRequest) (*ExitMinipoolResult, error)
+	// Get the status of the node wallet
+	GetWalletStatus(context.Context, *GetWalletStatusRequest) (*WalletStatus, error)
+	// Rebuild the validator keys of the node wallet
+	RebuildWallet(context.Context, *RebuildWalletRequest) (*RebuildWalletResult, error)
+	// Get a zstd-compressed JSON snapshot of the node's network state, as used by --state-file
+	GetStateSnapshot(context.Context, *GetStateSnapshotRequest) (*StateSnapshot, error)
+	// Stream the node's state each time it changes
+	WatchState(*WatchStateRequest, Smartnode_WatchStateServer) error
+	// Stream the daemon's log lines
+	StreamLogs(*StreamLogsRequest, Smartnode_StreamLogsServer) error
+	mustEmbedUnimplementedSmartnodeServer()
+}
+
+// UnimplementedSmartnodeServer must be embedded to have forward compatible implementations.
+type UnimplementedSmartnodeServer struct {
+}
+
+func (UnimplementedSmartnodeServer) GetNodeStatus(context.Context, *GetNodeStatusRequest) (*NodeStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeStatus not implemented")
+}
+func (UnimplementedSmartnodeServer) GetMinipools(context.Context, *GetMinipoolsRequest) (*MinipoolList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMinipools not implemented")
+}
+func (UnimplementedSmartnodeServer) StakeMinipool(context.Context, *MinipoolRequest) (*TransactionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StakeMinipool not implemented")
+}
+func (UnimplementedSmartnodeServer) RefundMinipool(context.Context, *MinipoolRequest) (*TransactionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundMinipool not implemented")
+}
+func (UnimplementedSmartnodeServer) DistributeMinipoolBalance(context.Context, *MinipoolRequest) (*TransactionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DistributeMinipoolBalance not implemented")
+}
+func (UnimplementedSmartnodeServer) CloseMinipool(context.Context, *MinipoolRequest) (*TransactionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseMinipool not implemented")
+}
+func (UnimplementedSmartnodeServer) ExitMinipool(context.Context, *MinipoolRequest) (*ExitMinipoolResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExitMinipool not implemented")
+}
+func (UnimplementedSmartnodeServer) GetWalletStatus(context.Context, *GetWalletStatusRequest) (*WalletStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWalletStatus not implemented")
+}
+func (UnimplementedSmartnodeServer) RebuildWallet(context.Context, *RebuildWalletRequest) (*RebuildWalletResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebuildWallet not implemented")
+}
+func (UnimplementedSmartnodeServer) GetStateSnapshot(context.Context, *GetStateSnapshotRequest) (*StateSnapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStateSnapshot not implemented")
+}
+func (UnimplementedSmartnodeServer) WatchState(*WatchStateRequest, Smartnode_WatchStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchState not implemented")
+}
+func (UnimplementedSmartnodeServer) StreamLogs(*StreamLogsRequest, Smartnode_StreamLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLogs not implemented")
+}
+func (UnimplementedSmartnodeServer) mustEmbedUnimplementedSmartnodeServer() {}
+
+// UnsafeSmartnodeServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SmartnodeServer will
+// result in compilation errors.
+type UnsafeSmartnodeServer interface {
+	mustEmbedUnimplementedSmartnodeServer()
+}
+
+func RegisterSmartnodeServer(s grpc.ServiceRegistrar, srv SmartnodeServer) {
+	s.RegisterService(&Smartnode_ServiceDesc, srv)
+}
+
+func _Smartnode_GetNodeStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmartnodeServer).GetNodeStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/smartnode.v1.Smartnode/GetNodeStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmartnodeServer).GetNodeStatus(ctx, req.(*GetNodeStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Smartnode_GetMinipools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMinipoolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmartnodeServer).GetMinipools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/smartnode.v1.Smartnode/GetMinipools",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmartnodeServer).GetMinipools(ctx, req.(*GetMinipoolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Smartnode_StakeMinipool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinipoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmartnodeServer).StakeMinipool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/smartnode.v1.Smartnode/StakeMinipool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmartnodeServer).StakeMinipool(ctx, req.(*MinipoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Smartnode_RefundMinipool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinipoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmartnodeServer).RefundMinipool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/smartnode.v1.Smartnode/RefundMinipool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmartnodeServer).RefundMinipool(ctx, req.(*MinipoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Smartnode_DistributeMinipoolBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinipoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmartnodeServer).DistributeMinipoolBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/smartnode.v1.Smartnode/DistributeMinipoolBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmartnodeServer).DistributeMinipoolBalance(ctx, req.(*MinipoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Smartnode_CloseMinipool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinipoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmartnodeServer).CloseMinipool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/smartnode.v1.Smartnode/CloseMinipool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmartnodeServer).CloseMinipool(ctx, req.(*MinipoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Smartnode_ExitMinipool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinipoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmartnodeServer).ExitMinipool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/smartnode.v1.Smartnode/ExitMinipool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmartnodeServer).ExitMinipool(ctx, req.(*MinipoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Smartnode_GetWalletStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWalletStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmartnodeServer).GetWalletStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/smartnode.v1.Smartnode/GetWalletStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmartnodeServer).GetWalletStatus(ctx, req.(*GetWalletStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Smartnode_RebuildWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebuildWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmartnodeServer).RebuildWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/smartnode.v1.Smartnode/RebuildWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmartnodeServer).RebuildWallet(ctx, req.(*RebuildWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Smartnode_GetStateSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmartnodeServer).GetStateSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/smartnode.v1.Smartnode/GetStateSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmartnodeServer).GetStateSnapshot(ctx, req.(*GetStateSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Smartnode_WatchState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SmartnodeServer).WatchState(m, &smartnodeWatchStateServer{stream})
+}
+
+type Smartnode_WatchStateServer interface {
+	Send(*NodeState) error
+	grpc.ServerStream
+}
+
+type smartnodeWatchStateServer struct {
+	grpc.ServerStream
+}
+
+func (x *smartnodeWatchStateServer) Send(m *NodeState) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Smartnode_StreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SmartnodeServer).StreamLogs(m, &smartnodeStreamLogsServer{stream})
+}
+
+type Smartnode_StreamLogsServer interface {
+	Send(*LogLine) error
+	grpc.ServerStream
+}
+
+type smartnodeStreamLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *smartnodeStreamLogsServer) Send(m *LogLine) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Smartnode_ServiceDesc is the grpc.ServiceDesc for Smartnode service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Smartnode_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "smartnode.v1.Smartnode",
+	HandlerType: (*SmartnodeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetNodeStatus",
+			Handler:    _Smartnode_GetNodeStatus_Handler,
+		},
+		{
+			MethodName: "GetMinipools",
+			Handler:    _Smartnode_GetMinipools_Handler,
+		},
+		{
+			MethodName: "StakeMinipool",
+			Handler:    _Smartnode_StakeMinipool_Handler,
+		},
+		{
+			MethodName: "RefundMinipool",
+			Handler:    _Smartnode_RefundMinipool_Handler,
+		},
+		{
+			MethodName: "DistributeMinipoolBalance",
+			Handler:    _Smartnode_DistributeMinipoolBalance_Handler,
+		},
+		{
+			MethodName: "CloseMinipool",
+			Handler:    _Smartnode_CloseMinipool_Handler,
+		},
+		{
+			MethodName: "ExitMinipool",
+			Handler:    _Smartnode_ExitMinipool_Handler,
+		},
+		{
+			MethodName: "GetWalletStatus",
+			Handler:    _Smartnode_GetWalletStatus_Handler,
+		},
+		{
+			MethodName: "RebuildWallet",
+			Handler:    _Smartnode_RebuildWallet_Handler,
+		},
+		{
+			MethodName: "GetStateSnapshot",
+			Handler:    _Smartnode_GetStateSnapshot_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchState",
+			Handler:       _Smartnode_WatchState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamLogs",
+			Handler:       _Smartnode_StreamLogs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "smartnode.proto",
+}
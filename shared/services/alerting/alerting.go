@@ -0,0 +1,179 @@
+package alerting
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// How bad an alert is
+type Severity string
+
+const (
+	Severity_Info     Severity = "info"
+	Severity_Warning  Severity = "warning"
+	Severity_Critical Severity = "critical"
+)
+
+// A problem with the node, raised when a rule starts failing and sent again when it's resolved. Notable events are
+// sent the same way, with the info severity and their event as the rule.
+type Alert struct {
+	Rule      string    `json:"rule"`
+	Key       string    `json:"key,omitempty"`
+	Severity  Severity  `json:"severity"`
+	Summary   string    `json:"summary"`
+	Resolved  bool      `json:"resolved"`
+	StartTime time.Time `json:"startTime"`
+	Time      time.Time `json:"time"`
+}
+
+// A destination for alerts, such as the daemon's logs or a webhook
+type Sink interface {
+	// The sink's name, for errors
+	Name() string
+
+	// Deliver an alert
+	Send(alert Alert) error
+}
+
+// Tracks which alerts are firing and sends them to the sinks when they start and when they're resolved, so a rule
+// that keeps failing doesn't send the same alert on every check
+type Manager struct {
+	lock         sync.Mutex
+	sinks        []Sink
+	firing       map[string]Alert
+	notifyEvents bool
+}
+
+// Create a manager that sends alerts to the provided sinks
+func NewManager(sinks ...Sink) *Manager {
+	return &Manager{
+		sinks:        sinks,
+		firing:       map[string]Alert{},
+		notifyEvents: true,
+	}
+}
+
+// Set whether notable events are sent to the sinks along with the alerts
+func (m *Manager) SetNotifyEvents(notifyEvents bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.notifyEvents = notifyEvents
+}
+
+// Add a sink to send alerts to
+func (m *Manager) AddSink(sink Sink) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.sinks = append(m.sinks, sink)
+}
+
+// Report the alerts that a rule currently has firing; new ones are sent to the sinks, and the rule's previous alerts
+// that aren't reported anymore are resolved. Errors from the sinks are returned together.
+func (m *Manager) Update(rule string, alerts []Alert) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	now := time.Now().UTC()
+
+	toSend := []Alert{}
+	current := map[string]bool{}
+	for _, alert := range alerts {
+		alert.Rule = rule
+		id := getAlertId(rule, alert.Key)
+		current[id] = true
+		if _, exists := m.firing[id]; exists {
+			continue
+		}
+		alert.StartTime = now
+		alert.Time = now
+		m.firing[id] = alert
+		toSend = append(toSend, alert)
+	}
+	for id, alert := range m.firing {
+		if alert.Rule != rule || current[id] {
+			continue
+		}
+		delete(m.firing, id)
+		alert.Resolved = true
+		alert.Time = now
+		toSend = append(toSend, alert)
+	}
+
+	return m.send(toSend)
+}
+
+// Send a notable event, such as rewards being claimed, to the sinks
+func (m *Manager) Notify(event string, summary string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if !m.notifyEvents {
+		return nil
+	}
+	now := time.Now().UTC()
+	return m.send([]Alert{{
+		Rule:      event,
+		Severity:  Severity_Info,
+		Summary:   summary,
+		StartTime: now,
+		Time:      now,
+	}})
+}
+
+// Send a one-off alert that has nothing to resolve, such as a missed block proposal, to the sinks
+func (m *Manager) Report(rule string, severity Severity, summary string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	now := time.Now().UTC()
+	return m.send([]Alert{{
+		Rule:      rule,
+		Severity:  severity,
+		Summary:   summary,
+		StartTime: now,
+		Time:      now,
+	}})
+}
+
+// Get the alerts that are currently firing, oldest first
+func (m *Manager) GetFiringAlerts() []Alert {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	alerts := make([]Alert, 0, len(m.firing))
+	for _, alert := range m.firing {
+		alerts = append(alerts, alert)
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].StartTime.Before(alerts[j].StartTime)
+	})
+	return alerts
+}
+
+// Send alerts to every sink, returning their errors together
+func (m *Manager) send(alerts []Alert) error {
+	var errs []error
+	for _, alert := range alerts {
+		for _, sink := range m.sinks {
+			if err := sink.Send(alert); err != nil {
+				errs = append(errs, fmt.Errorf("error sending %s to %s: %w", alert.Rule, sink.Name(), err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return joinErrors(errs)
+	}
+	return nil
+}
+
+// Get the ID that identifies an alert across checks
+func getAlertId(rule string, key string) string {
+	return rule + "/" + key
+}
+
+// Combine several errors into one
+func joinErrors(errs []error) error {
+	err := errs[0]
+	for _, next := range errs[1:] {
+		err = fmt.Errorf("%w; %s", err, next.Error())
+	}
+	return err
+}
@@ -0,0 +1,127 @@
+package alerting
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"text/template"
+)
+
+// Formats alerts and events into chat messages with a template
+type messageFormatter struct {
+	template *template.Template
+}
+
+// Parse a message template; it's executed with an Alert
+func newMessageFormatter(text string) (*messageFormatter, error) {
+	tmpl, err := template.New("message").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing notification template: %w", err)
+	}
+	return &messageFormatter{template: tmpl}, nil
+}
+
+// Format an alert, falling back to its summary if the template fails
+func (f *messageFormatter) format(alert Alert) string {
+	var message bytes.Buffer
+	if err := f.template.Execute(&message, alert); err != nil || message.Len() == 0 {
+		return alert.Summary
+	}
+	return message.String()
+}
+
+// Sends alerts to a Discord channel through a webhook
+type DiscordSink struct {
+	webhookUrl string
+	formatter  *messageFormatter
+	client     *http.Client
+}
+
+// Create a sink for a Discord webhook
+func NewDiscordSink(webhookUrl string, messageTemplate string) (*DiscordSink, error) {
+	formatter, err := newMessageFormatter(messageTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return &DiscordSink{
+		webhookUrl: webhookUrl,
+		formatter:  formatter,
+		client:     &http.Client{Timeout: webhookTimeout},
+	}, nil
+}
+
+func (s *DiscordSink) Name() string {
+	return "Discord"
+}
+
+func (s *DiscordSink) Send(alert Alert) error {
+	return postJson(s.client, s.webhookUrl, map[string]string{
+		"content": s.formatter.format(alert),
+	})
+}
+
+// Sends alerts to a Slack channel through an incoming webhook
+type SlackSink struct {
+	webhookUrl string
+	formatter  *messageFormatter
+	client     *http.Client
+}
+
+// Create a sink for a Slack incoming webhook
+func NewSlackSink(webhookUrl string, messageTemplate string) (*SlackSink, error) {
+	formatter, err := newMessageFormatter(messageTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return &SlackSink{
+		webhookUrl: webhookUrl,
+		formatter:  formatter,
+		client:     &http.Client{Timeout: webhookTimeout},
+	}, nil
+}
+
+func (s *SlackSink) Name() string {
+	return "Slack"
+}
+
+func (s *SlackSink) Send(alert Alert) error {
+	return postJson(s.client, s.webhookUrl, map[string]string{
+		"text": s.formatter.format(alert),
+	})
+}
+
+// Sends alerts to a Telegram chat with a bot
+type TelegramSink struct {
+	botToken  string
+	chatId    string
+	formatter *messageFormatter
+	client    *http.Client
+}
+
+// Create a sink for a Telegram bot and the chat it posts in
+func NewTelegramSink(botToken string, chatId string, messageTemplate string) (*TelegramSink, error) {
+	if chatId == "" {
+		return nil, fmt.Errorf("a Telegram chat ID is required to send notifications with a Telegram bot")
+	}
+	formatter, err := newMessageFormatter(messageTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return &TelegramSink{
+		botToken:  botToken,
+		chatId:    chatId,
+		formatter: formatter,
+		client:    &http.Client{Timeout: webhookTimeout},
+	}, nil
+}
+
+func (s *TelegramSink) Name() string {
+	return "Telegram"
+}
+
+func (s *TelegramSink) Send(alert Alert) error {
+	return postJson(s.client, fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", s.botToken), map[string]string{
+		"chat_id": s.chatId,
+		"text":    s.formatter.format(alert),
+	})
+}
@@ -0,0 +1,45 @@
+package alerting
+
+import (
+	"github.com/fatih/color"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The color of alerts and events in the daemon's logs
+const logColor = color.FgYellow
+
+// Create a manager with the sinks set up in the config; alerts and events are always written to the logs
+func NewManagerFromConfig(cfg *config.RocketPoolConfig) (*Manager, error) {
+	logger := log.NewColorLogger(logColor)
+	manager := NewManager(NewLogSink(&logger))
+	manager.SetNotifyEvents(cfg.Smartnode.EnableEventNotifications.Value.(bool))
+	messageTemplate := cfg.Smartnode.NotificationTemplate.Value.(string)
+
+	if webhookUrl := cfg.Smartnode.AlertWebhookUrl.Value.(string); webhookUrl != "" {
+		manager.AddSink(NewWebhookSink(webhookUrl))
+	}
+	if discordUrl := cfg.Smartnode.DiscordWebhookUrl.Value.(string); discordUrl != "" {
+		sink, err := NewDiscordSink(discordUrl, messageTemplate)
+		if err != nil {
+			return nil, err
+		}
+		manager.AddSink(sink)
+	}
+	if slackUrl := cfg.Smartnode.SlackWebhookUrl.Value.(string); slackUrl != "" {
+		sink, err := NewSlackSink(slackUrl, messageTemplate)
+		if err != nil {
+			return nil, err
+		}
+		manager.AddSink(sink)
+	}
+	if botToken := cfg.Smartnode.TelegramBotToken.Value.(string); botToken != "" {
+		sink, err := NewTelegramSink(botToken, cfg.Smartnode.TelegramChatId.Value.(string), messageTemplate)
+		if err != nil {
+			return nil, err
+		}
+		manager.AddSink(sink)
+	}
+	return manager, nil
+}
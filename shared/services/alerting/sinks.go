@@ -0,0 +1,86 @@
+package alerting
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How long to wait for a webhook to accept an alert
+const webhookTimeout time.Duration = 10 * time.Second
+
+// Writes alerts to the daemon's logs
+type LogSink struct {
+	log *log.ColorLogger
+}
+
+// Create a sink that writes alerts to a logger
+func NewLogSink(logger *log.ColorLogger) *LogSink {
+	return &LogSink{log: logger}
+}
+
+func (s *LogSink) Name() string {
+	return "log"
+}
+
+func (s *LogSink) Send(alert Alert) error {
+	if alert.Severity == Severity_Info {
+		s.log.Printlnf("EVENT [%s] %s", alert.Rule, alert.Summary)
+	} else if alert.Resolved {
+		s.log.Printlnf("RESOLVED [%s] %s", alert.Rule, alert.Summary)
+	} else {
+		s.log.Printlnf("ALERT (%s) [%s] %s", alert.Severity, alert.Rule, alert.Summary)
+	}
+	return nil
+}
+
+// Posts alerts as JSON to a URL
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// Create a sink that posts alerts to a URL
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+func (s *WebhookSink) Send(alert Alert) error {
+	return postJson(s.client, s.url, alert)
+}
+
+// Post a JSON body to a URL, treating any status other than 2xx as an error
+func postJson(client *http.Client, target string, body interface{}) error {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("error serializing request: %w", err)
+	}
+	response, err := client.Post(target, "application/json", bytes.NewReader(bodyBytes))
+	if err != nil {
+		// Leave the URL out of the error, since chat services put their secrets in it
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("error sending request: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", response.Request.URL.Host, response.Status)
+	}
+	return nil
+}
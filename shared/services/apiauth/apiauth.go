@@ -0,0 +1,202 @@
+package apiauth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// The roles an API token can have, from least to most privileged
+type Role string
+
+const (
+	Role_ReadOnly Role = "read-only"
+	Role_Operator Role = "operator"
+	Role_Admin    Role = "admin"
+)
+
+// The rank of each role, for comparing them
+var roleRanks = map[Role]int{
+	Role_ReadOnly: 1,
+	Role_Operator: 2,
+	Role_Admin:    3,
+}
+
+// Commands with these prefixes (or names) only read data
+var readOnlyCommandPrefixes = []string{"can-", "get-", "estimate-"}
+var readOnlyCommandNames = []string{"status", "sync", "list", "rewards", "check-collateral", "deposit-contract-info", "is-fee-distributor-initialized", "resolve-ens-name", "reverse-resolve-ens-name"}
+
+// Commands that can move funds out of the node's control, exit validators, or manage its keys, which require the admin
+// role; other commands that send transactions only require the operator role
+var adminCommands = map[string][]string{
+	"node":     {"set-withdrawal-address", "confirm-withdrawal-address", "withdraw-rpl", "send", "burn", "sign", "sign-message"},
+	"minipool": {"exit", "close", "dissolve", "change-withdrawal-creds", "import-key", "rescue-dissolved", "schedule-exit", "cancel-scheduled-exit", "presign-exits", "broadcast-presigned-exit"},
+	"service":  {"terminate-data-folder"},
+}
+
+// Groups whose commands all require the admin role, apart from the read-only ones
+var adminGroups = []string{"wallet", "debug"}
+
+// A token that can call the daemon API; only the hash of its secret is stored
+type Token struct {
+	Name    string    `yaml:"name"`
+	Role    Role      `yaml:"role"`
+	Hash    string    `yaml:"hash"`
+	Created time.Time `yaml:"created"`
+}
+
+// The file the tokens are stored in
+type tokensFile struct {
+	Tokens []Token `yaml:"tokens"`
+}
+
+// Check if a role is one of the supported ones
+func (r Role) IsValid() bool {
+	_, exists := roleRanks[r]
+	return exists
+}
+
+// Check if this role has at least the privileges of the required one
+func (r Role) Allows(required Role) bool {
+	return roleRanks[r] >= roleRanks[required]
+}
+
+// Check if an API command only reads data
+func IsReadOnlyCommand(command string) bool {
+	for _, prefix := range readOnlyCommandPrefixes {
+		if strings.HasPrefix(command, prefix) {
+			return true
+		}
+	}
+	for _, name := range readOnlyCommandNames {
+		if command == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the role required to run an API command
+func GetCommandRole(group string, command string) Role {
+	if IsReadOnlyCommand(command) {
+		return Role_ReadOnly
+	}
+	for _, adminGroup := range adminGroups {
+		if group == adminGroup {
+			return Role_Admin
+		}
+	}
+	for _, adminCommand := range adminCommands[group] {
+		if command == adminCommand {
+			return Role_Admin
+		}
+	}
+	return Role_Operator
+}
+
+// Create a new token with a random secret, which is only returned here
+func NewToken(name string, role Role) (Token, string, error) {
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return Token{}, "", fmt.Errorf("error generating token: %w", err)
+	}
+	secret := hex.EncodeToString(secretBytes)
+	return Token{
+		Name:    name,
+		Role:    role,
+		Hash:    hashSecret(secret),
+		Created: time.Now().UTC(),
+	}, secret, nil
+}
+
+// Load the tokens from a file; a missing file has no tokens
+func LoadTokens(path string) ([]Token, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []Token{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading API tokens from %s: %w", path, err)
+	}
+	var file tokensFile
+	if err := yaml.Unmarshal(bytes, &file); err != nil {
+		return nil, fmt.Errorf("error parsing API tokens from %s: %w", path, err)
+	}
+	return file.Tokens, nil
+}
+
+// Save the tokens to a file that only the owner can read
+func SaveTokens(path string, tokens []Token) error {
+	bytes, err := yaml.Marshal(tokensFile{Tokens: tokens})
+	if err != nil {
+		return fmt.Errorf("error serializing API tokens: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0600); err != nil {
+		return fmt.Errorf("error saving API tokens to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Hash a token's secret for storage
+func hashSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+// Authenticates API callers with the tokens file, reloading it when it changes so tokens can be created and revoked
+// without restarting the daemon
+type Authenticator struct {
+	path        string
+	adminSecret string
+	lock        sync.Mutex
+	tokens      []Token
+	modTime     time.Time
+}
+
+// Create an authenticator for the tokens in the provided file; the admin secret is always accepted as well
+func NewAuthenticator(path string, adminSecret string) *Authenticator {
+	return &Authenticator{
+		path:        path,
+		adminSecret: adminSecret,
+	}
+}
+
+// Get the role of the token with the provided secret, if there is one
+func (a *Authenticator) Authenticate(secret string) (Role, bool) {
+	if secret == "" {
+		return "", false
+	}
+	if a.adminSecret != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(a.adminSecret)) == 1 {
+		return Role_Admin, true
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	info, err := os.Stat(a.path)
+	if err != nil {
+		a.tokens = nil
+		a.modTime = time.Time{}
+	} else if !info.ModTime().Equal(a.modTime) {
+		tokens, err := LoadTokens(a.path)
+		if err == nil {
+			a.tokens = tokens
+			a.modTime = info.ModTime()
+		}
+	}
+
+	hash := hashSecret(secret)
+	for _, token := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(hash), []byte(token.Hash)) == 1 && token.Role.IsValid() {
+			return token.Role, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,98 @@
+package arweave
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/goccy/go-json"
+)
+
+// Settings
+const (
+	uploadRequestTimeout time.Duration = 5 * time.Minute
+)
+
+// Uploads data to Arweave through a Bundlr node, paying for it with the balance of an Ethereum key
+type BundlrUploader struct {
+	nodeUrl string
+	key     *ecdsa.PrivateKey
+	client  *http.Client
+}
+
+// The response of the upload route
+type bundlrUploadResponse struct {
+	ID string `json:"id"`
+}
+
+// Creates a new uploader for the Bundlr node at the given URL, using the hex-encoded Ethereum private key to sign uploads
+func NewBundlrUploader(nodeUrl string, privateKey string) (*BundlrUploader, error) {
+	keyBytes, err := hexutil.Decode(ensureHexPrefix(strings.TrimSpace(privateKey)))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Bundlr private key: %w", err)
+	}
+	key, err := crypto.ToECDSA(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Bundlr private key: %w", err)
+	}
+	return &BundlrUploader{
+		nodeUrl: strings.TrimSuffix(nodeUrl, "/"),
+		key:     key,
+		client: &http.Client{
+			Timeout: uploadRequestTimeout,
+		},
+	}, nil
+}
+
+// Get the address of the key that pays for uploads
+func (u *BundlrUploader) GetAddress() string {
+	return crypto.PubkeyToAddress(u.key.PublicKey).Hex()
+}
+
+// Signs the data as a data item and uploads it, returning its Arweave transaction ID
+func (u *BundlrUploader) Upload(data []byte, tags []Tag) (string, error) {
+	item, err := NewDataItem(u.key, data, tags)
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/tx/ethereum", u.nodeUrl)
+	response, err := u.client.Post(url, "application/octet-stream", bytes.NewReader(item.Bytes))
+	if err != nil {
+		return "", fmt.Errorf("error sending upload request to Bundlr: %w", err)
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading Bundlr response: %w", err)
+	}
+	if response.StatusCode == http.StatusPaymentRequired {
+		return "", fmt.Errorf("Bundlr account %s does not have enough balance to pay for the upload", u.GetAddress())
+	}
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("Bundlr responded to upload request with status %s: %s", response.Status, string(responseBody))
+	}
+
+	var uploadResponse bundlrUploadResponse
+	err = json.Unmarshal(responseBody, &uploadResponse)
+	if err != nil {
+		return "", fmt.Errorf("error decoding Bundlr response: %w", err)
+	}
+	if uploadResponse.ID != item.ID {
+		return "", fmt.Errorf("Bundlr returned transaction ID %s but the uploaded item's ID was %s", uploadResponse.ID, item.ID)
+	}
+	return uploadResponse.ID, nil
+}
+
+func ensureHexPrefix(value string) string {
+	if !strings.HasPrefix(value, "0x") {
+		return "0x" + value
+	}
+	return value
+}
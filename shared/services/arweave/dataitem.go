@@ -0,0 +1,114 @@
+package arweave
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Settings
+const (
+	// ANS-104 signature type for secp256k1 keys signing with Ethereum's personal message format
+	ethereumSignatureType uint16 = 3
+	ethereumSignatureSize int    = 65
+	ethereumOwnerSize     int    = 65
+)
+
+// A name / value tag attached to a data item
+type Tag struct {
+	Name  string
+	Value string
+}
+
+// A signed ANS-104 data item, ready to be posted to a bundler
+type DataItem struct {
+	ID    string
+	Bytes []byte
+}
+
+// Creates and signs an ANS-104 data item holding the data with the given tags
+func NewDataItem(key *ecdsa.PrivateKey, data []byte, tags []Tag) (*DataItem, error) {
+	owner := crypto.FromECDSAPub(&key.PublicKey)
+	if len(owner) != ethereumOwnerSize {
+		return nil, fmt.Errorf("unexpected public key size %d", len(owner))
+	}
+	rawTags := encodeTags(tags)
+
+	// Sign the deep hash of the item's fields
+	message := deepHash([][]byte{
+		[]byte("dataitem"),
+		[]byte("1"),
+		[]byte(strconv.FormatUint(uint64(ethereumSignatureType), 10)),
+		owner,
+		{},
+		{},
+		rawTags,
+		data,
+	})
+	signature, err := crypto.Sign(accounts.TextHash(message), key)
+	if err != nil {
+		return nil, fmt.Errorf("error signing data item: %w", err)
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+
+	// Serialize the item
+	itemBytes := make([]byte, 0, 2+ethereumSignatureSize+ethereumOwnerSize+2+16+len(rawTags)+len(data))
+	itemBytes = binary.LittleEndian.AppendUint16(itemBytes, ethereumSignatureType)
+	itemBytes = append(itemBytes, signature...)
+	itemBytes = append(itemBytes, owner...)
+	itemBytes = append(itemBytes, 0) // No target
+	itemBytes = append(itemBytes, 0) // No anchor
+	itemBytes = binary.LittleEndian.AppendUint64(itemBytes, uint64(len(tags)))
+	itemBytes = binary.LittleEndian.AppendUint64(itemBytes, uint64(len(rawTags)))
+	itemBytes = append(itemBytes, rawTags...)
+	itemBytes = append(itemBytes, data...)
+
+	id := sha256.Sum256(signature)
+	return &DataItem{
+		ID:    base64.RawURLEncoding.EncodeToString(id[:]),
+		Bytes: itemBytes,
+	}, nil
+}
+
+// Arweave's deep hash algorithm over a list of blobs
+func deepHash(chunks [][]byte) []byte {
+	acc := sha384([]byte("list" + strconv.Itoa(len(chunks))))
+	for _, chunk := range chunks {
+		tag := sha384([]byte("blob" + strconv.Itoa(len(chunk))))
+		chunkHash := sha384(append(tag, sha384(chunk)...))
+		acc = sha384(append(acc, chunkHash...))
+	}
+	return acc
+}
+
+func sha384(data []byte) []byte {
+	hash := sha512.Sum384(data)
+	return hash[:]
+}
+
+// Encodes the tags as an Avro array of {name: bytes, value: bytes} records
+func encodeTags(tags []Tag) []byte {
+	if len(tags) == 0 {
+		return []byte{}
+	}
+	encoded := appendAvroLong([]byte{}, int64(len(tags)))
+	for _, tag := range tags {
+		encoded = appendAvroLong(encoded, int64(len(tag.Name)))
+		encoded = append(encoded, tag.Name...)
+		encoded = appendAvroLong(encoded, int64(len(tag.Value)))
+		encoded = append(encoded, tag.Value...)
+	}
+	return appendAvroLong(encoded, 0)
+}
+
+// Appends a zigzag varint, which is how Avro encodes longs
+func appendAvroLong(buffer []byte, value int64) []byte {
+	return binary.AppendUvarint(buffer, uint64((value<<1)^(value>>63)))
+}
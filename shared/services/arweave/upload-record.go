@@ -0,0 +1,78 @@
+package arweave
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/klauspost/compress/zstd"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// A record of the Arweave transactions that mirror a rewards interval's files
+type UploadRecord struct {
+	Index                       uint64    `json:"index"`
+	RewardsFileTxID             string    `json:"rewardsFileTxId"`
+	MinipoolPerformanceFileTxID string    `json:"minipoolPerformanceFileTxId"`
+	UploadTime                  time.Time `json:"uploadTime"`
+}
+
+// Compresses and uploads the rewards tree and minipool performance files for an interval to Arweave, then saves a
+// record of the transaction IDs next to them.
+func UploadRewardsFiles(uploader *BundlrUploader, cfg *config.RocketPoolConfig, index uint64, rewardsFileBytes []byte, minipoolPerformanceBytes []byte) (*UploadRecord, error) {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBestCompression))
+	if err != nil {
+		return nil, fmt.Errorf("error creating zstd compressor: %w", err)
+	}
+	defer encoder.Close()
+
+	record := &UploadRecord{
+		Index: index,
+	}
+	network := fmt.Sprint(cfg.Smartnode.Network.Value)
+
+	// Upload the minipool performance file
+	if minipoolPerformanceBytes != nil {
+		filename := filepath.Base(cfg.Smartnode.GetMinipoolPerformancePath(index, true)) + config.RewardsTreeIpfsExtension
+		compressedBytes := encoder.EncodeAll(minipoolPerformanceBytes, make([]byte, 0, len(minipoolPerformanceBytes)))
+		record.MinipoolPerformanceFileTxID, err = uploader.Upload(compressedBytes, getRewardsFileTags(filename, network, index))
+		if err != nil {
+			return nil, fmt.Errorf("error uploading minipool performance file: %w", err)
+		}
+	}
+
+	// Upload the rewards tree
+	filename := filepath.Base(cfg.Smartnode.GetRewardsTreePath(index, true)) + config.RewardsTreeIpfsExtension
+	compressedBytes := encoder.EncodeAll(rewardsFileBytes, make([]byte, 0, len(rewardsFileBytes)))
+	record.RewardsFileTxID, err = uploader.Upload(compressedBytes, getRewardsFileTags(filename, network, index))
+	if err != nil {
+		return nil, fmt.Errorf("error uploading rewards tree file: %w", err)
+	}
+	record.UploadTime = time.Now().UTC()
+
+	// Save the record
+	recordBytes, err := json.MarshalIndent(record, "", "\t")
+	if err != nil {
+		return nil, fmt.Errorf("error serializing Arweave upload record: %w", err)
+	}
+	recordPath := cfg.Smartnode.GetRewardsArweaveRecordPath(index, true)
+	err = os.WriteFile(recordPath, recordBytes, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error saving Arweave upload record to %s: %w", recordPath, err)
+	}
+
+	return record, nil
+}
+
+// Get the tags that make rewards files discoverable through Arweave's GraphQL queries
+func getRewardsFileTags(filename string, network string, index uint64) []Tag {
+	return []Tag{
+		{Name: "Content-Type", Value: "application/zstd"},
+		{Name: "App-Name", Value: "Rocket Pool Smartnode"},
+		{Name: "Filename", Value: filename},
+		{Name: "Network", Value: network},
+		{Name: "Rewards-Interval", Value: fmt.Sprint(index)},
+	}
+}
@@ -1,8 +1,11 @@
 package services
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/fatih/color"
@@ -15,14 +18,29 @@ import (
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
+// How long to wait before checking whether an unavailable Beacon client has recovered
+const bcRecheckInterval time.Duration = time.Minute
+
 // This is a proxy for multiple Beacon clients, providing natural fallback support if one of them fails.
+// Clients are tried in priority order: the primary, the fallback, and then any additional fallbacks.
 type BeaconClientManager struct {
-	primaryBc       beacon.Client
-	fallbackBc      beacon.Client
+	clients         []*managedBeaconClient
 	logger          log.ColorLogger
-	primaryReady    bool
-	fallbackReady   bool
 	ignoreSyncCheck bool
+	forceFallbacks  bool
+	verifier        *LightClientVerifier
+	metadataCache   *chainMetadataCache
+	lock            sync.Mutex
+}
+
+// A Beacon client being managed by the BeaconClientManager, along with its health
+type managedBeaconClient struct {
+	client    beacon.Client
+	url       string
+	name      string
+	ready     bool
+	lastCheck time.Time
+	health    *clientHealth
 }
 
 // This is a signature for a wrapped Beacon client function that only returns an error
@@ -57,38 +75,100 @@ func NewBeaconClientManager(cfg *config.RocketPoolConfig) (*BeaconClientManager,
 		return nil, fmt.Errorf("Unknown Consensus client mode '%v'", cfg.ConsensusClientMode.Value)
 	}
 
-	// Fallback CC
+	// Fallback CCs
 	var fallbackProvider string
+	var additionalProviders string
 	if cfg.UseFallbackClients.Value == true {
 		if cfg.IsNativeMode {
 			fallbackProvider = cfg.FallbackNormal.CcHttpUrl.Value.(string)
+			additionalProviders = cfg.FallbackNormal.AdditionalCcHttpUrls.Value.(string)
 		} else {
 			switch selectedCC {
 			case cfgtypes.ConsensusClient_Prysm:
 				fallbackProvider = cfg.FallbackPrysm.CcHttpUrl.Value.(string)
+				additionalProviders = cfg.FallbackPrysm.AdditionalCcHttpUrls.Value.(string)
 			default:
 				fallbackProvider = cfg.FallbackNormal.CcHttpUrl.Value.(string)
+				additionalProviders = cfg.FallbackNormal.AdditionalCcHttpUrls.Value.(string)
 			}
 		}
 	}
 
-	var primaryBc beacon.Client
-	var fallbackBc beacon.Client
-	primaryBc = client.NewStandardHttpClient(primaryProvider)
+	clients := []*managedBeaconClient{
+		{
+			client: newStandardBeaconClient(cfg, primaryProvider, "Primary"),
+			url:    primaryProvider,
+			name:   "Primary",
+			ready:  true,
+			health: newClientHealth(),
+		},
+	}
 	if fallbackProvider != "" {
-		fallbackBc = client.NewStandardHttpClient(fallbackProvider)
+		clients = append(clients, &managedBeaconClient{
+			client: newStandardBeaconClient(cfg, fallbackProvider, "Fallback"),
+			url:    fallbackProvider,
+			name:   "Fallback",
+			ready:  true,
+			health: newClientHealth(),
+		})
+	}
+	for _, provider := range strings.Split(additionalProviders, ",") {
+		provider = strings.TrimSpace(provider)
+		if provider == "" {
+			continue
+		}
+		name := fmt.Sprintf("Additional fallback %d", len(clients)-1)
+		clients = append(clients, &managedBeaconClient{
+			client: newStandardBeaconClient(cfg, provider, name),
+			url:    provider,
+			name:   name,
+			ready:  true,
+			health: newClientHealth(),
+		})
+	}
+
+	// Every client but the last can hand failed requests to the next one, so only the last retries them itself
+	for _, managedClient := range clients[:len(clients)-1] {
+		managedClient.client.(*client.StandardHttpClient).SetHasFallback(true)
+	}
+
+	// Set up light client verification if it's enabled
+	var verifier *LightClientVerifier
+	if cfg.Smartnode.VerifyBeaconWithLightClient.Value == true {
+		var err error
+		verifier, err = NewLightClientVerifier(cfg.Smartnode.LightClientTrustedRoot.Value.(string))
+		if err != nil {
+			return nil, fmt.Errorf("error creating light client verifier: %w", err)
+		}
+
+		// Validators are only read from states the light client has proven
+		for _, managedClient := range clients {
+			managedClient.client.(*client.StandardHttpClient).SetStateVerifier(verifier)
+		}
 	}
 
 	return &BeaconClientManager{
-		primaryBc:     primaryBc,
-		fallbackBc:    fallbackBc,
-		logger:        log.NewColorLogger(color.FgHiBlue),
-		primaryReady:  true,
-		fallbackReady: fallbackBc != nil,
+		clients:  clients,
+		logger:   log.NewColorLogger(color.FgHiBlue),
+		verifier: verifier,
 	}, nil
 
 }
 
+// Creates a Beacon client for the provided URL, using the validator request limits and SSZ support from the config and recording its
+// requests in the client request metrics under the provided name
+func newStandardBeaconClient(cfg *config.RocketPoolConfig, providerAddress string, name string) *client.StandardHttpClient {
+	bc := client.NewStandardHttpClient(providerAddress)
+	bc.SetValidatorRequestLimits(
+		int(cfg.Smartnode.BeaconValidatorChunkSize.Value.(uint64)),
+		int(cfg.Smartnode.BeaconValidatorConcurrency.Value.(uint64)),
+	)
+	bc.SetSszEnabled(cfg.Smartnode.BeaconSszSupport.Value == true)
+	bc.SetCallPolicies(cfg.Smartnode.GetCallPolicies())
+	bc.SetTransport(newBeaconClientTransport(name))
+	return bc
+}
+
 /// ======================
 /// BeaconClient Functions
 /// ======================
@@ -115,25 +195,56 @@ func (m *BeaconClientManager) GetSyncStatus() (beacon.SyncStatus, error) {
 	return result.(beacon.SyncStatus), nil
 }
 
+// Get the client's name and version
+func (m *BeaconClientManager) GetNodeVersion() (beacon.NodeVersion, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetNodeVersion()
+	})
+	if err != nil {
+		return beacon.NodeVersion{}, err
+	}
+	return result.(beacon.NodeVersion), nil
+}
+
 // Get the Beacon configuration
 func (m *BeaconClientManager) GetEth2Config() (beacon.Eth2Config, error) {
+	// It never changes for a network, so it only has to be fetched once
+	if m.metadataCache != nil {
+		if cached, exists := m.metadataCache.getEth2Config(); exists {
+			return cached, nil
+		}
+	}
+
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
 		return client.GetEth2Config()
 	})
 	if err != nil {
 		return beacon.Eth2Config{}, err
 	}
+	if m.metadataCache != nil {
+		m.metadataCache.setEth2Config(result.(beacon.Eth2Config))
+	}
 	return result.(beacon.Eth2Config), nil
 }
 
-// Get the Beacon configuration
+// Get the deposit contract
 func (m *BeaconClientManager) GetEth2DepositContract() (beacon.Eth2DepositContract, error) {
+	// It never changes for a network, so it only has to be fetched once
+	if m.metadataCache != nil {
+		if cached, exists := m.metadataCache.getDepositContract(); exists {
+			return cached, nil
+		}
+	}
+
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
 		return client.GetEth2DepositContract()
 	})
 	if err != nil {
 		return beacon.Eth2DepositContract{}, err
 	}
+	if m.metadataCache != nil {
+		m.metadataCache.setDepositContract(result.(beacon.Eth2DepositContract))
+	}
 	return result.(beacon.Eth2DepositContract), nil
 }
 
@@ -162,7 +273,14 @@ func (m *BeaconClientManager) GetBeaconBlock(blockId string) (beacon.BeaconBlock
 // Get the Beacon chain's head information
 func (m *BeaconClientManager) GetBeaconHead() (beacon.BeaconHead, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
-		return client.GetBeaconHead()
+		if err := m.verifyClient(client); err != nil {
+			return nil, err
+		}
+		head, err := client.GetBeaconHead()
+		if err != nil {
+			return nil, err
+		}
+		return m.limitToVerifiedFinality(client, head)
 	})
 	if err != nil {
 		return beacon.BeaconHead{}, err
@@ -173,6 +291,9 @@ func (m *BeaconClientManager) GetBeaconHead() (beacon.BeaconHead, error) {
 // Get a validator's status by its index
 func (m *BeaconClientManager) GetValidatorStatusByIndex(index string, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		if err := m.verifyClient(client); err != nil {
+			return nil, err
+		}
 		return client.GetValidatorStatusByIndex(index, opts)
 	})
 	if err != nil {
@@ -184,6 +305,9 @@ func (m *BeaconClientManager) GetValidatorStatusByIndex(index string, opts *beac
 // Get a validator's status by its pubkey
 func (m *BeaconClientManager) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		if err := m.verifyClient(client); err != nil {
+			return nil, err
+		}
 		return client.GetValidatorStatus(pubkey, opts)
 	})
 	if err != nil {
@@ -195,6 +319,9 @@ func (m *BeaconClientManager) GetValidatorStatus(pubkey types.ValidatorPubkey, o
 // Get the statuses of multiple validators by their pubkeys
 func (m *BeaconClientManager) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		if err := m.verifyClient(client); err != nil {
+			return nil, err
+		}
 		return client.GetValidatorStatuses(pubkeys, opts)
 	})
 	if err != nil {
@@ -206,6 +333,9 @@ func (m *BeaconClientManager) GetValidatorStatuses(pubkeys []types.ValidatorPubk
 // Get a validator's index
 func (m *BeaconClientManager) GetValidatorIndex(pubkey types.ValidatorPubkey) (string, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		if err := m.verifyClient(client); err != nil {
+			return nil, err
+		}
 		return client.GetValidatorIndex(pubkey)
 	})
 	if err != nil {
@@ -255,6 +385,17 @@ func (m *BeaconClientManager) ExitValidator(validatorIndex string, epoch uint64,
 	return err
 }
 
+// Get the number of validators waiting in the exit queue
+func (m *BeaconClientManager) GetExitQueueLength() (uint64, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetExitQueueLength()
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(uint64), nil
+}
+
 // Close the connection to the Beacon client
 func (m *BeaconClientManager) Close() error {
 	err := m.runFunction0(func(client beacon.Client) error {
@@ -300,39 +441,99 @@ func (m *BeaconClientManager) ChangeWithdrawalCredentials(validatorIndex string,
 /// Internal Functions
 /// ==================
 
+// Get the URL of the client that requests are currently being sent to
+func (m *BeaconClientManager) GetActiveUrl() string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, managedClient := range m.clients {
+		if managedClient.ready {
+			return managedClient.url
+		}
+	}
+	return m.clients[0].url
+}
+
+// Stop using the primary client, so requests go to the fallbacks instead
+func (m *BeaconClientManager) ForceFallbacks() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.forceFallbacks = true
+	m.clients[0].ready = false
+}
+
+// Check if the primary client is ready
+func (m *BeaconClientManager) isPrimaryReady() bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.clients[0].ready
+}
+
+// Check if any of the fallback clients are ready
+func (m *BeaconClientManager) isFallbackReady() bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, managedClient := range m.clients[1:] {
+		if managedClient.ready {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *BeaconClientManager) CheckStatus() *api.ClientManagerStatus {
 
 	status := &api.ClientManagerStatus{
-		FallbackEnabled: m.fallbackBc != nil,
+		FallbackEnabled: len(m.clients) > 1,
 	}
 
 	// Ignore the sync check and just use the predefined settings if requested
 	if m.ignoreSyncCheck {
-		status.PrimaryClientStatus.IsWorking = m.primaryReady
-		status.PrimaryClientStatus.IsSynced = m.primaryReady
-		if status.FallbackEnabled {
-			status.FallbackClientStatus.IsWorking = m.fallbackReady
-			status.FallbackClientStatus.IsSynced = m.fallbackReady
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		for i, managedClient := range m.clients {
+			clientStatus := api.ClientStatus{
+				IsWorking: managedClient.ready,
+				IsSynced:  managedClient.ready,
+				Health:    managedClient.health.getStatus(),
+			}
+			m.setStatus(status, i, clientStatus)
 		}
 		return status
 	}
 
-	// Get the primary BC status
-	status.PrimaryClientStatus = checkBcStatus(m.primaryBc)
-
-	// Get the fallback BC status if applicable
-	if status.FallbackEnabled {
-		status.FallbackClientStatus = checkBcStatus(m.fallbackBc)
+	// Get the status of each client and flag the ready ones
+	for i, managedClient := range m.clients {
+		start := time.Now()
+		clientStatus := checkBcStatus(managedClient.client)
+		managedClient.health.recordProbe(clientStatus.IsWorking, time.Since(start))
+		clientStatus.Health = managedClient.health.getStatus()
+		m.setStatus(status, i, clientStatus)
+
+		m.lock.Lock()
+		managedClient.ready = (clientStatus.IsWorking && clientStatus.IsSynced)
+		if i == 0 && m.forceFallbacks {
+			managedClient.ready = false
+		}
+		managedClient.lastCheck = time.Now()
+		m.lock.Unlock()
 	}
 
-	// Flag the ready clients
-	m.primaryReady = (status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
-	m.fallbackReady = (status.FallbackEnabled && status.FallbackClientStatus.IsWorking && status.FallbackClientStatus.IsSynced)
-
 	return status
 
 }
 
+// Store a client's status in the appropriate slot of the manager status
+func (m *BeaconClientManager) setStatus(status *api.ClientManagerStatus, index int, clientStatus api.ClientStatus) {
+	switch index {
+	case 0:
+		status.PrimaryClientStatus = clientStatus
+	case 1:
+		status.FallbackClientStatus = clientStatus
+	default:
+		status.AdditionalFallbackStatuses = append(status.AdditionalFallbackStatuses, clientStatus)
+	}
+}
+
 // Check the client status
 func checkBcStatus(client beacon.Client) api.ClientStatus {
 
@@ -361,77 +562,108 @@ func checkBcStatus(client beacon.Client) api.ClientStatus {
 
 }
 
-// Attempts to run a function progressively through each client until one succeeds or they all fail.
-func (m *BeaconClientManager) runFunction0(function bcFunction0) error {
+// Check if any client can currently be used, so callers can defer heavy work instead of sending it to clients that are down
+func (m *BeaconClientManager) HasAvailableClient() bool {
+	for _, managedClient := range m.clients {
+		m.lock.Lock()
+		ready := managedClient.ready
+		m.lock.Unlock()
+		if ready && managedClient.health.isAvailable() {
+			return true
+		}
+	}
+	return false
+}
 
-	// Check if we can use the primary
-	if m.primaryReady {
-		// Try to run the function on the primary
-		err := function(m.primaryBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction0(function)
-			}
-			// If it's a different error, just return it
-			return err
+// Get the health of each client, in priority order
+func (m *BeaconClientManager) GetClientHealth() []ClientHealthReport {
+	reports := make([]ClientHealthReport, len(m.clients))
+	for i, managedClient := range m.clients {
+		reports[i] = ClientHealthReport{
+			Name:   managedClient.name,
+			Status: managedClient.health.getStatus(),
 		}
-		// If there's no error, return the result
-		return nil
 	}
+	return reports
+}
 
-	if m.fallbackReady {
-		// Try to run the function on the fallback
-		err := function(m.fallbackBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
-				return fmt.Errorf("all Beacon clients failed")
+// Get the clients that can currently be used, in priority order.
+// Clients that went down are re-checked periodically so the manager switches back to them once they've recovered.
+func (m *BeaconClientManager) getReadyClients() []*managedBeaconClient {
+	readyClients := []*managedBeaconClient{}
+	for i, managedClient := range m.clients {
+		m.lock.Lock()
+		ready := managedClient.ready
+		recheck := !ready && !m.ignoreSyncCheck && !(i == 0 && m.forceFallbacks) && time.Since(managedClient.lastCheck) >= bcRecheckInterval
+		if recheck {
+			managedClient.lastCheck = time.Now()
+		}
+		m.lock.Unlock()
+
+		if recheck {
+			status := checkBcStatus(managedClient.client)
+			if status.IsWorking && status.IsSynced {
+				m.logger.Printlnf("%s Beacon client is available again, resuming its use.", managedClient.name)
+				m.lock.Lock()
+				managedClient.ready = true
+				m.lock.Unlock()
+				ready = true
 			}
-
-			// If it's a different error, just return it
-			return err
 		}
-		// If there's no error, return the result
-		return nil
+		if ready && managedClient.health.isAvailable() {
+			readyClients = append(readyClients, managedClient)
+		}
 	}
+	return readyClients
+}
 
-	return fmt.Errorf("no Beacon clients were ready")
+// Attempts to run a function progressively through each client until one succeeds or they all fail.
+func (m *BeaconClientManager) runFunction0(function bcFunction0) error {
+	_, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return nil, function(client)
+	})
+	return err
 }
 
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
 func (m *BeaconClientManager) runFunction1(function bcFunction1) (interface{}, error) {
 
-	// Check if we can use the primary
-	if m.primaryReady {
-		// Try to run the function on the primary
-		result, err := function(m.primaryBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction1(function)
-			}
-			// If it's a different error, just return it
-			return nil, err
-		}
-		// If there's no error, return the result
-		return result, nil
+	readyClients := m.getReadyClients()
+	if len(readyClients) == 0 {
+		return nil, fmt.Errorf("no Beacon clients were ready (clients that keep failing are paused for %s)", clientBreakerCooldown)
 	}
 
-	if m.fallbackReady {
-		// Try to run the function on the fallback
-		result, err := function(m.fallbackBc)
+	for i, managedClient := range readyClients {
+		// Skip the client if its circuit breaker has tripped since the list was built
+		if !managedClient.health.allow() {
+			continue
+		}
+
+		// Try to run the function on the client
+		start := time.Now()
+		result, err := function(managedClient.client)
+		managedClient.health.record(err, time.Since(start))
 		if err != nil {
+			if errors.Is(err, ErrBeaconVerificationFailed) {
+				// If it couldn't be verified, don't trust it and try the next client
+				if i < len(readyClients)-1 {
+					m.logger.Printlnf("WARNING: %s (%s), using %s Beacon client...", err.Error(), managedClient.name, strings.ToLower(readyClients[i+1].name))
+					continue
+				}
+				m.logger.Printlnf("WARNING: %s (%s)", err.Error(), managedClient.name)
+				return nil, fmt.Errorf("no Beacon clients passed light client verification")
+			}
 			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
+				// If it's disconnected, log it and try the next client
+				m.lock.Lock()
+				managedClient.ready = false
+				managedClient.lastCheck = time.Now()
+				m.lock.Unlock()
+				if i < len(readyClients)-1 {
+					m.logger.Printlnf("WARNING: %s Beacon client disconnected (%s), using %s Beacon client...", managedClient.name, err.Error(), strings.ToLower(readyClients[i+1].name))
+					continue
+				}
+				m.logger.Printlnf("WARNING: %s Beacon client disconnected (%s)", managedClient.name, err.Error())
 				return nil, fmt.Errorf("all Beacon clients failed")
 			}
 			// If it's a different error, just return it
@@ -441,50 +673,45 @@ func (m *BeaconClientManager) runFunction1(function bcFunction1) (interface{}, e
 		return result, nil
 	}
 
-	return nil, fmt.Errorf("no Beacon clients were ready")
+	return nil, fmt.Errorf("all Beacon clients failed")
 
 }
 
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
 func (m *BeaconClientManager) runFunction2(function bcFunction2) (interface{}, interface{}, error) {
-
-	// Check if we can use the primary
-	if m.primaryReady {
-		// Try to run the function on the primary
-		result1, result2, err := function(m.primaryBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction2(function)
-			}
-			// If it's a different error, just return it
-			return nil, nil, err
-		}
-		// If there's no error, return the result
-		return result1, result2, nil
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		result1, result2, err := function(client)
+		return []interface{}{result1, result2}, err
+	})
+	if err != nil {
+		return nil, nil, err
 	}
+	results := result.([]interface{})
+	return results[0], results[1], nil
+}
 
-	if m.fallbackReady {
-		// Try to run the function on the fallback
-		result1, result2, err := function(m.fallbackBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
-				return nil, nil, fmt.Errorf("all Beacon clients failed")
-			}
-			// If it's a different error, just return it
-			return nil, nil, err
-		}
-		// If there's no error, return the result
-		return result1, result2, nil
+// Check that a client is following the chain the light client has verified, if light client verification is enabled
+func (m *BeaconClientManager) verifyClient(bc beacon.Client) error {
+	if m.verifier == nil {
+		return nil
 	}
+	standardClient, ok := bc.(*client.StandardHttpClient)
+	if !ok {
+		return fmt.Errorf("%w: light client verification isn't supported for this client", ErrBeaconVerificationFailed)
+	}
+	return m.verifier.VerifyClient(standardClient)
+}
 
-	return nil, nil, fmt.Errorf("no Beacon clients were ready")
-
+// Limit a Beacon head's finalized epoch to what the light client has verified, if light client verification is enabled
+func (m *BeaconClientManager) limitToVerifiedFinality(bc beacon.Client, head beacon.BeaconHead) (beacon.BeaconHead, error) {
+	if m.verifier == nil {
+		return head, nil
+	}
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return beacon.BeaconHead{}, err
+	}
+	return m.verifier.VerifyBeaconHead(head, eth2Config.SlotsPerEpoch), nil
 }
 
 // Returns true if the error was a connection failure and a backup client is available
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/beacon/client"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How long to wait before reconnecting to the event stream after it drops, doubling on each consecutive failure
+const (
+	bcEventStreamRetryDelay    time.Duration = 5 * time.Second
+	bcEventStreamMaxRetryDelay time.Duration = 2 * time.Minute
+)
+
+// The topics the event stream subscribes to
+var bcEventStreamTopics = []beacon.EventTopic{
+	beacon.EventTopic_Head,
+	beacon.EventTopic_FinalizedCheckpoint,
+	beacon.EventTopic_Block,
+}
+
+// Decides whether a subscriber wants an event
+type BeaconEventFilter func(beacon.Event) bool
+
+// Subscribes to the Beacon node's event stream and fans the events out to daemon tasks.
+// The stream follows the Beacon client manager, so it reconnects to whichever client is active after a failover.
+type BeaconEventStream struct {
+	bcManager   *BeaconClientManager
+	logger      log.ColorLogger
+	subscribers []*beaconEventSubscriber
+	connected   bool
+	lock        sync.Mutex
+	startOnce   sync.Once
+}
+
+// A single subscriber to the event stream
+type beaconEventSubscriber struct {
+	filter  BeaconEventFilter
+	channel chan beacon.Event
+}
+
+// Creates a new event stream on top of a Beacon client manager; it doesn't connect until the first subscription
+func NewBeaconEventStream(bcManager *BeaconClientManager) *BeaconEventStream {
+	return &BeaconEventStream{
+		bcManager: bcManager,
+		logger:    log.NewColorLogger(color.FgHiBlue),
+	}
+}
+
+// Subscribe to events that match the filter (or all events if it's nil).
+// The channel only holds the latest matching event, so slow subscribers never block the stream or each other.
+func (s *BeaconEventStream) Subscribe(filter BeaconEventFilter) <-chan beacon.Event {
+	subscriber := &beaconEventSubscriber{
+		filter:  filter,
+		channel: make(chan beacon.Event, 1),
+	}
+	s.lock.Lock()
+	s.subscribers = append(s.subscribers, subscriber)
+	s.lock.Unlock()
+
+	s.startOnce.Do(func() {
+		go s.run()
+	})
+	return subscriber.channel
+}
+
+// Subscribe to head events that start a new epoch
+func (s *BeaconEventStream) SubscribeEpochTransitions() <-chan beacon.Event {
+	return s.Subscribe(func(event beacon.Event) bool {
+		return event.Head != nil && event.Head.EpochTransition
+	})
+}
+
+// Check if the stream is currently connected to a Beacon node
+func (s *BeaconEventStream) IsConnected() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.connected
+}
+
+// Keep the stream connected, reconnecting with a backoff whenever it drops
+func (s *BeaconEventStream) run() {
+	retryDelay := bcEventStreamRetryDelay
+	for {
+		url := s.bcManager.GetActiveUrl()
+		streamClient := client.NewStandardHttpClient(url)
+		connectTime := time.Now()
+		err := streamClient.StreamEvents(context.Background(), bcEventStreamTopics, func(event beacon.Event) {
+			s.setConnected(true)
+			s.publish(event)
+		})
+		s.setConnected(false)
+
+		// Reset the backoff if the stream was up for a while before it dropped
+		if time.Since(connectTime) > bcEventStreamMaxRetryDelay {
+			retryDelay = bcEventStreamRetryDelay
+		}
+		s.logger.Printlnf("WARNING: Beacon event stream disconnected (%s), reconnecting in %s. Tasks will fall back to their timers until then.", err.Error(), retryDelay)
+		time.Sleep(retryDelay)
+		retryDelay *= 2
+		if retryDelay > bcEventStreamMaxRetryDelay {
+			retryDelay = bcEventStreamMaxRetryDelay
+		}
+	}
+}
+
+// Send an event to every subscriber that wants it, replacing any event they haven't picked up yet
+func (s *BeaconEventStream) publish(event beacon.Event) {
+	s.lock.Lock()
+	subscribers := s.subscribers
+	s.lock.Unlock()
+
+	for _, subscriber := range subscribers {
+		if subscriber.filter != nil && !subscriber.filter(event) {
+			continue
+		}
+		select {
+		case subscriber.channel <- event:
+		default:
+			select {
+			case <-subscriber.channel:
+			default:
+			}
+			select {
+			case subscriber.channel <- event:
+			default:
+			}
+		}
+	}
+}
+
+// Set the connection status
+func (s *BeaconEventStream) setConnected(connected bool) {
+	s.lock.Lock()
+	s.connected = connected
+	s.lock.Unlock()
+}
+
+// Wait for the next event on a subscription, or until the timeout passes if none arrives.
+// Returns true if it was woken up by an event.
+func WaitForBeaconEvent(events <-chan beacon.Event, timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-events:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
@@ -17,6 +17,9 @@ type SyncStatus struct {
 	Syncing  bool
 	Progress float64
 }
+type NodeVersion struct {
+	Version string
+}
 type Eth2Config struct {
 	GenesisForkVersion           []byte
 	GenesisValidatorsRoot        []byte
@@ -26,6 +29,7 @@ type Eth2Config struct {
 	SlotsPerEpoch                uint64
 	SecondsPerEpoch              uint64
 	EpochsPerSyncCommitteePeriod uint64
+	CapellaForkVersion           []byte
 }
 type Eth2DepositContract struct {
 	ChainID uint64
@@ -130,6 +134,7 @@ const (
 type Client interface {
 	GetClientType() (BeaconClientType, error)
 	GetSyncStatus() (SyncStatus, error)
+	GetNodeVersion() (NodeVersion, error)
 	GetEth2Config() (Eth2Config, error)
 	GetEth2DepositContract() (Eth2DepositContract, error)
 	GetAttestations(blockId string) ([]AttestationInfo, bool, error)
@@ -143,6 +148,7 @@ type Client interface {
 	GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error)
 	GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error)
 	ExitValidator(validatorIndex string, epoch uint64, signature types.ValidatorSignature) error
+	GetExitQueueLength() (uint64, error)
 	Close() error
 	GetEth1DataForEth2Block(blockId string) (Eth1Data, bool, error)
 	GetCommitteesForEpoch(epoch *uint64) (Committees, error)
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	blockCacheSize        int           = 8192
+	validatorCacheSize    int           = 131072
+	finalityCheckInterval time.Duration = time.Minute
+)
+
+// An in-memory cache for Beacon responses that can never change once they've been served:
+// the chain spec, genesis, and blocks, attestations and validator states at finalized slots.
+type responseCache struct {
+	blocks       *lru.Cache
+	attestations *lru.Cache
+	validators   *lru.Cache
+
+	eth2Config *Eth2ConfigResponse
+	genesis    *GenesisResponse
+
+	finalizedSlot      uint64
+	lastFinalizedCheck time.Time
+	lock               sync.Mutex
+}
+
+// A cached block lookup, which includes blocks that don't exist because their slot was missed.
+// Only the parts of the block the Smartnode uses are kept, since full blocks with their execution payloads are large.
+type cachedBlock struct {
+	block    beacon.BeaconBlock
+	eth1Data beacon.Eth1Data
+	exists   bool
+}
+
+// A cached attestations lookup, keeping only the attestation info the Smartnode uses
+type cachedAttestations struct {
+	attestations []beacon.AttestationInfo
+	exists       bool
+}
+
+// Create a new response cache
+func newResponseCache() *responseCache {
+	// These only fail on a non-positive size
+	blocks, _ := lru.New(blockCacheSize)
+	attestations, _ := lru.New(blockCacheSize)
+	validators, _ := lru.New(validatorCacheSize)
+	return &responseCache{
+		blocks:       blocks,
+		attestations: attestations,
+		validators:   validators,
+	}
+}
+
+// Check if a block ID refers to a block that can't change anymore.
+// Block roots always can; slot numbers can once they've been finalized. Named IDs like "head" never can.
+func (c *StandardHttpClient) isImmutableBlockId(blockId string) bool {
+	if strings.HasPrefix(blockId, "0x") {
+		return true
+	}
+	return c.isImmutableStateId(blockId)
+}
+
+// Check if a state ID refers to a finalized slot
+func (c *StandardHttpClient) isImmutableStateId(stateId string) bool {
+	slot, err := strconv.ParseUint(stateId, 10, 64)
+	if err != nil {
+		return false
+	}
+	return slot <= c.getFinalizedSlot()
+}
+
+// Get the latest finalized slot, refreshing it from the Beacon node periodically
+func (c *StandardHttpClient) getFinalizedSlot() uint64 {
+	c.cache.lock.Lock()
+	if time.Since(c.cache.lastFinalizedCheck) < finalityCheckInterval {
+		slot := c.cache.finalizedSlot
+		c.cache.lock.Unlock()
+		return slot
+	}
+	c.cache.lastFinalizedCheck = time.Now()
+	c.cache.lock.Unlock()
+
+	eth2Config, err := c.getEth2Config()
+	if err != nil {
+		return c.getCachedFinalizedSlot()
+	}
+	checkpoints, err := c.getFinalityCheckpoints("head")
+	if err != nil {
+		return c.getCachedFinalizedSlot()
+	}
+
+	slot := uint64(checkpoints.Data.Finalized.Epoch) * uint64(eth2Config.Data.SlotsPerEpoch)
+	c.cache.lock.Lock()
+	if slot > c.cache.finalizedSlot {
+		c.cache.finalizedSlot = slot
+	}
+	slot = c.cache.finalizedSlot
+	c.cache.lock.Unlock()
+	return slot
+}
+
+// Get the last known finalized slot without refreshing it
+func (c *StandardHttpClient) getCachedFinalizedSlot() uint64 {
+	c.cache.lock.Lock()
+	defer c.cache.lock.Unlock()
+	return c.cache.finalizedSlot
+}
+
+// Get the cache key for a validator at a state
+func getValidatorCacheKey(stateId string, pubkeyOrIndex string) string {
+	return fmt.Sprintf("%s/%s", stateId, strings.ToLower(pubkeyOrIndex))
+}
+
+// Split a validator lookup into the validators that are already cached and the IDs that still need to be requested
+func (c *StandardHttpClient) getCachedValidators(stateId string, pubkeysOrIndices []string) ([]Validator, []string) {
+	cached := []Validator{}
+	missing := []string{}
+	for _, id := range pubkeysOrIndices {
+		validator, exists := c.cache.validators.Get(getValidatorCacheKey(stateId, id))
+		if exists {
+			cached = append(cached, validator.(Validator))
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return cached, missing
+}
+
+// Store validators at a finalized state, so they can be found by either their pubkey or their index
+func (c *StandardHttpClient) cacheValidators(stateId string, validators []Validator) {
+	for _, validator := range validators {
+		c.cache.validators.Add(getValidatorCacheKey(stateId, validator.Index), validator)
+		c.cache.validators.Add(getValidatorCacheKey(stateId, hexutil.AddPrefix(fmt.Sprintf("%x", []byte(validator.Validator.Pubkey)))), validator)
+	}
+}
+
+// Get the parts of a block response that get cached
+func getCachedBlock(blockId string, response BeaconBlockResponse) (cachedBlock, error) {
+	message := response.Data.Message
+	block := cachedBlock{
+		block: beacon.BeaconBlock{
+			Slot:          uint64(message.Slot),
+			ProposerIndex: message.ProposerIndex,
+		},
+		eth1Data: beacon.Eth1Data{
+			DepositRoot:  common.BytesToHash(message.Body.Eth1Data.DepositRoot),
+			DepositCount: uint64(message.Body.Eth1Data.DepositCount),
+			BlockHash:    common.BytesToHash(message.Body.Eth1Data.BlockHash),
+		},
+		exists: true,
+	}
+
+	// Execution payload only exists after the merge, so check for its existence
+	if message.Body.ExecutionPayload != nil {
+		block.block.HasExecutionPayload = true
+		block.block.FeeRecipient = common.BytesToAddress(message.Body.ExecutionPayload.FeeRecipient)
+		block.block.ExecutionBlockNumber = uint64(message.Body.ExecutionPayload.BlockNumber)
+	}
+
+	attestations, err := getAttestationInfo(blockId, message.Body.Attestations)
+	if err != nil {
+		return cachedBlock{}, err
+	}
+	block.block.Attestations = attestations
+	return block, nil
+}
+
+// Get the attestation info from a block's attestations
+func getAttestationInfo(blockId string, attestations []Attestation) ([]beacon.AttestationInfo, error) {
+	attestationInfo := make([]beacon.AttestationInfo, len(attestations))
+	for i, attestation := range attestations {
+		bitString := hexutil.RemovePrefix(attestation.AggregationBits)
+		attestationInfo[i].SlotIndex = uint64(attestation.Data.Slot)
+		attestationInfo[i].CommitteeIndex = uint64(attestation.Data.Index)
+		aggregationBits, err := hex.DecodeString(bitString)
+		if err != nil {
+			return nil, fmt.Errorf("Error decoding aggregation bits for attestation %d of block %s: %w", i, blockId, err)
+		}
+		attestationInfo[i].AggregationBits = aggregationBits
+	}
+	return attestationInfo, nil
+}
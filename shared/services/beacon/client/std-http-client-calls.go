@@ -0,0 +1,92 @@
+package client
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	netutils "github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+// How long to wait before retrying a failed request, multiplied by the attempt number
+const callRetryDelay time.Duration = time.Second
+
+// Request paths that return large parts of the Beacon state, which get the heavy call policy
+var heavyRequestPaths = []string{
+	"/validators",
+	"/committees",
+	"/debug/",
+	"/blocks/",
+	"/duties/",
+	"/light_client/updates",
+}
+
+// Set the timeout and number of retries for each class of request.
+// Classes that aren't provided keep no timeout and no retries. Every class shares the same connection pool.
+func (c *StandardHttpClient) SetCallPolicies(policies map[cfgtypes.CallClass]cfgtypes.CallPolicy) {
+	c.callPolicies = policies
+	c.httpClients = map[cfgtypes.CallClass]*http.Client{}
+	for class, policy := range policies {
+		c.httpClients[class] = c.newHttpClient(policy.Timeout)
+	}
+}
+
+// Send requests through the provided transport instead of the shared connection pool directly, for example to record them
+func (c *StandardHttpClient) SetTransport(transport http.RoundTripper) {
+	c.transport = transport
+	for class, policy := range c.callPolicies {
+		c.httpClients[class] = c.newHttpClient(policy.Timeout)
+	}
+}
+
+// Create an HTTP client with the provided timeout (or none if it's 0) on the client's transport
+func (c *StandardHttpClient) newHttpClient(timeout time.Duration) *http.Client {
+	httpClient := netutils.NewHttpClient(timeout)
+	if c.transport != nil {
+		httpClient.Transport = c.transport
+	}
+	return httpClient
+}
+
+// Get the class of a request from its method and path
+func getCallClass(method string, requestPath string) cfgtypes.CallClass {
+	if method == http.MethodPost && strings.Contains(requestPath, "/pool/") {
+		return cfgtypes.CallClass_Submit
+	}
+	for _, heavyPath := range heavyRequestPaths {
+		if strings.Contains(requestPath, heavyPath) {
+			return cfgtypes.CallClass_Heavy
+		}
+	}
+	return cfgtypes.CallClass_Fast
+}
+
+// Send a request with the timeout and retries of its class.
+// Requests are retried if the client couldn't be reached, timed out, or returned a server error; the last attempt's result is returned.
+// Clients with a fallback don't retry, so the fallback gets the request right away.
+func (c *StandardHttpClient) sendRequest(class cfgtypes.CallClass, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	httpClient, exists := c.httpClients[class]
+	if !exists {
+		httpClient = c.newHttpClient(0)
+	}
+	retries := c.callPolicies[class].Retries
+	if c.hasFallback {
+		retries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		request, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		response, err := httpClient.Do(request)
+		if (err == nil && response.StatusCode < http.StatusInternalServerError) || attempt >= retries {
+			return response, err
+		}
+		if err == nil {
+			_ = response.Body.Close()
+		}
+		time.Sleep(time.Duration(attempt+1) * callRetryDelay)
+	}
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	netutils "github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+// Config
+const (
+	RequestEventsPath       = "/eth/v1/events?topics=%s"
+	RequestEventContentType = "text/event-stream"
+)
+
+// Event stream payloads
+type headEventData struct {
+	Slot            uinteger    `json:"slot"`
+	Block           common.Hash `json:"block"`
+	State           common.Hash `json:"state"`
+	EpochTransition bool        `json:"epoch_transition"`
+}
+type finalizedCheckpointEventData struct {
+	Block common.Hash `json:"block"`
+	State common.Hash `json:"state"`
+	Epoch uinteger    `json:"epoch"`
+}
+type blockEventData struct {
+	Slot  uinteger    `json:"slot"`
+	Block common.Hash `json:"block"`
+}
+
+// Subscribe to the Beacon node's event stream, calling the handler for each event that arrives.
+// This blocks until the context is cancelled or the connection drops.
+func (c *StandardHttpClient) StreamEvents(ctx context.Context, topics []beacon.EventTopic, handler func(beacon.Event)) error {
+
+	// Build the request
+	topicStrings := make([]string, len(topics))
+	for i, topic := range topics {
+		topicStrings[i] = string(topic)
+	}
+	requestUrl := fmt.Sprintf(RequestUrlFormat, c.providerAddress, fmt.Sprintf(RequestEventsPath, strings.Join(topicStrings, ",")))
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl, nil)
+	if err != nil {
+		return fmt.Errorf("error creating event stream request: %w", err)
+	}
+	request.Header.Set("Accept", RequestEventContentType)
+
+	// Connect
+	response, err := netutils.NewHttpClient(0).Do(request)
+	if err != nil {
+		return fmt.Errorf("error connecting to event stream: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("error connecting to event stream: HTTP status %d", response.StatusCode)
+	}
+
+	// Read events as they come in; each one is an "event:" line and one or more "data:" lines, ending with a blank line
+	scanner := bufio.NewScanner(response.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var eventName string
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if eventName != "" && data.Len() > 0 {
+				event, err := parseEvent(beacon.EventTopic(eventName), []byte(data.String()))
+				if err != nil {
+					return err
+				}
+				if event != nil {
+					handler(*event)
+				}
+			}
+			eventName = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// Comment / keepalive
+		case strings.HasPrefix(line, "event:"):
+			eventName = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("error reading event stream: %w", err)
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return fmt.Errorf("event stream was closed by the Beacon node")
+
+}
+
+// Parse the data of an event stream message; unknown topics are ignored
+func parseEvent(topic beacon.EventTopic, data []byte) (*beacon.Event, error) {
+	event := beacon.Event{
+		Topic: topic,
+	}
+	switch topic {
+	case beacon.EventTopic_Head:
+		var head headEventData
+		if err := json.Unmarshal(data, &head); err != nil {
+			return nil, fmt.Errorf("error decoding head event: %w", err)
+		}
+		event.Head = &beacon.HeadEvent{
+			Slot:            uint64(head.Slot),
+			Block:           head.Block,
+			State:           head.State,
+			EpochTransition: head.EpochTransition,
+		}
+	case beacon.EventTopic_FinalizedCheckpoint:
+		var checkpoint finalizedCheckpointEventData
+		if err := json.Unmarshal(data, &checkpoint); err != nil {
+			return nil, fmt.Errorf("error decoding finalized checkpoint event: %w", err)
+		}
+		event.FinalizedCheckpoint = &beacon.FinalizedCheckpointEvent{
+			Block: checkpoint.Block,
+			State: checkpoint.State,
+			Epoch: uint64(checkpoint.Epoch),
+		}
+	case beacon.EventTopic_Block:
+		var block blockEventData
+		if err := json.Unmarshal(data, &block); err != nil {
+			return nil, fmt.Errorf("error decoding block event: %w", err)
+		}
+		event.Block = &beacon.BlockEvent{
+			Slot:  uint64(block.Slot),
+			Block: block.Block,
+		}
+	default:
+		return nil, nil
+	}
+	return &event, nil
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/goccy/go-json"
+)
+
+// Light client config
+const (
+	RequestLightClientBootstrapPath      = "/eth/v1/beacon/light_client/bootstrap/%s"
+	RequestLightClientUpdatesPath        = "/eth/v1/beacon/light_client/updates?start_period=%d&count=%d"
+	RequestLightClientFinalityUpdatePath = "/eth/v1/beacon/light_client/finality_update"
+	RequestStateRootPath                 = "/eth/v1/beacon/states/%s/root"
+)
+
+// Proves the Beacon states that validators are read from against state roots that were verified independently of the client
+type StateVerifier interface {
+	// Get the SSZ-encoded state for a state ID once its hash tree root has been checked against a verified state root
+	GetVerifiedStateSsz(c *StandardHttpClient, stateId string) ([]byte, error)
+}
+
+// Get validators by pubkey or index from a state the verifier has proven
+func (c *StandardHttpClient) getVerifiedValidators(stateId string, pubkeysOrIndices []string) (ValidatorsResponse, error) {
+	state, err := c.stateVerifier.GetVerifiedStateSsz(c, stateId)
+	if err != nil {
+		return ValidatorsResponse{}, err
+	}
+	eth2Config, err := c.getEth2Config()
+	if err != nil {
+		return ValidatorsResponse{}, err
+	}
+	return GetValidatorsFromSszState(state, pubkeysOrIndices, uint64(eth2Config.Data.SlotsPerEpoch))
+}
+
+// Get the light client bootstrap data for a block root, which includes the sync committee at that block
+func (c *StandardHttpClient) GetLightClientBootstrap(blockRoot string) (LightClientBootstrapResponse, error) {
+	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestLightClientBootstrapPath, blockRoot))
+	if err != nil {
+		return LightClientBootstrapResponse{}, fmt.Errorf("Could not get light client bootstrap: %w", err)
+	}
+	if status != http.StatusOK {
+		return LightClientBootstrapResponse{}, fmt.Errorf("Could not get light client bootstrap: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var bootstrap LightClientBootstrapResponse
+	if err := json.Unmarshal(responseBody, &bootstrap); err != nil {
+		return LightClientBootstrapResponse{}, fmt.Errorf("Could not decode light client bootstrap: %w", err)
+	}
+	return bootstrap, nil
+}
+
+// Get the light client updates for a range of sync committee periods, which hand over from each committee to the next
+func (c *StandardHttpClient) GetLightClientUpdates(startPeriod uint64, count uint64) ([]LightClientUpdateResponse, error) {
+	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestLightClientUpdatesPath, startPeriod, count))
+	if err != nil {
+		return nil, fmt.Errorf("Could not get light client updates: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not get light client updates: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var updates []LightClientUpdateResponse
+	if err := json.Unmarshal(responseBody, &updates); err != nil {
+		return nil, fmt.Errorf("Could not decode light client updates: %w", err)
+	}
+	return updates, nil
+}
+
+// Get the latest light client finality update
+func (c *StandardHttpClient) GetLightClientFinalityUpdate() (LightClientUpdateResponse, error) {
+	responseBody, status, err := c.getRequest(RequestLightClientFinalityUpdatePath)
+	if err != nil {
+		return LightClientUpdateResponse{}, fmt.Errorf("Could not get light client finality update: %w", err)
+	}
+	if status != http.StatusOK {
+		return LightClientUpdateResponse{}, fmt.Errorf("Could not get light client finality update: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var update LightClientUpdateResponse
+	if err := json.Unmarshal(responseBody, &update); err != nil {
+		return LightClientUpdateResponse{}, fmt.Errorf("Could not decode light client finality update: %w", err)
+	}
+	return update, nil
+}
+
+// Get the root of a Beacon state
+func (c *StandardHttpClient) GetStateRoot(stateId string) ([]byte, error) {
+	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestStateRootPath, stateId))
+	if err != nil {
+		return nil, fmt.Errorf("Could not get state root: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not get state root: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var stateRoot StateRootResponse
+	if err := json.Unmarshal(responseBody, &stateRoot); err != nil {
+		return nil, fmt.Errorf("Could not decode state root: %w", err)
+	}
+	return stateRoot.Data.Root, nil
+}
@@ -0,0 +1,440 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// SSZ config
+const (
+	RequestSszContentType  = "application/octet-stream"
+	RequestSszAcceptHeader = "application/octet-stream;q=1.0,application/json;q=0.9"
+	RequestDebugStatePath  = "/eth/v2/debug/beacon/states/%s"
+	ConsensusVersionHeader = "Eth-Consensus-Version"
+
+	// The number of validators to request before it's cheaper to stream them out of the SSZ-encoded state than to decode them from JSON
+	SszStateValidatorsThreshold int = 2000
+)
+
+// SSZ layout of the parts of the Beacon state and blocks that are read directly.
+// These assume the mainnet preset (SLOTS_PER_HISTORICAL_ROOT = 8192, EPOCHS_PER_HISTORICAL_VECTOR = 65536,
+// EPOCHS_PER_SLASHINGS_VECTOR = 8192), which all of the supported networks use.
+const (
+	sszOffsetSize int = 4
+
+	sszRootSize                  int = 32
+	sszSlotsPerHistoricalRoot    int = 8192
+	sszEpochsPerHistoricalVector int = 65536
+	sszEpochsPerSlashingsVector  int = 8192
+	sszForkSize                  int = 16                 // previous_version, current_version, epoch
+	sszBeaconBlockHeaderSize     int = 16 + 3*sszRootSize // slot, proposer_index, parent_root, state_root, body_root
+	sszEth1DataSize              int = 2*sszRootSize + 8  // deposit_root, deposit_count, block_hash
+
+	// Beacon state: genesis_time, genesis_validators_root, slot, fork, latest_block_header, block_roots, state_roots,
+	// historical_roots (offset), eth1_data, eth1_data_votes (offset), eth1_deposit_index, validators (offset),
+	// balances (offset), randao_mixes, slashings, and then the offset of the next variable-size field
+	sszStateSlotPosition                  int    = 8 + sszRootSize
+	sszStateBlockRootsPosition            int    = sszStateSlotPosition + 8 + sszForkSize + sszBeaconBlockHeaderSize
+	sszStateHistoricalRootsOffsetPosition int    = sszStateBlockRootsPosition + 2*sszSlotsPerHistoricalRoot*sszRootSize
+	sszStateEth1DataPosition              int    = sszStateHistoricalRootsOffsetPosition + sszOffsetSize
+	sszStateValidatorsOffsetPosition      int    = sszStateEth1DataPosition + sszEth1DataSize + sszOffsetSize + 8
+	sszStateBalancesOffsetPosition        int    = sszStateValidatorsOffsetPosition + sszOffsetSize
+	sszStateRandaoMixesPosition           int    = sszStateBalancesOffsetPosition + sszOffsetSize
+	sszStateNextFieldOffsetPosition       int    = sszStateRandaoMixesPosition + sszEpochsPerHistoricalVector*sszRootSize + sszEpochsPerSlashingsVector*8
+	sszStateFixedPrefixSize               int    = sszStateNextFieldOffsetPosition + sszOffsetSize
+	sszValidatorSize                      int    = 121
+	sszBalanceSize                        int    = 8
+	sszFarFutureEpoch                     uint64 = ^uint64(0)
+
+	sszSignedBlockSignatureSize       int = 96
+	sszBlockBodyOffsetPosition        int = 80
+	sszBodyEth1DataPosition           int = 96
+	sszBodyAttestationsOffsetPosition int = 208
+	sszBodyDepositsOffsetPosition     int = 212
+	sszBodyPayloadOffsetPosition      int = 380
+	sszAttestationFixedSize           int = 228
+	sszPayloadFeeRecipientPosition    int = 32
+	sszPayloadBlockNumberPosition     int = 404
+)
+
+// Forks whose block layouts can be decoded from SSZ; anything else falls back to JSON
+var sszBlockForks = map[string]bool{
+	"phase0":    true,
+	"altair":    true,
+	"bellatrix": true,
+	"capella":   true,
+	"deneb":     true,
+}
+
+// Make a GET request that prefers an SSZ response, falling back to JSON if the client doesn't support it
+func (c *StandardHttpClient) getSszRequest(requestPath string) (*http.Response, error) {
+	return c.sendRequest(cfgtypes.CallClass_Heavy, func() (*http.Request, error) {
+		request, err := http.NewRequest(http.MethodGet, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), nil)
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("Accept", RequestSszAcceptHeader)
+		return request, nil
+	})
+}
+
+// Check if the client serves SSZ-encoded responses by requesting the head block with SSZ
+func (c *StandardHttpClient) SupportsSsz() (bool, error) {
+	response, err := c.getSszRequest(fmt.Sprintf(RequestBeaconBlockPath, "head"))
+	if err != nil {
+		return false, fmt.Errorf("Could not get beacon block data: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	return response.StatusCode == http.StatusOK && isSszResponse(response), nil
+}
+
+// Check if a response was encoded with SSZ
+func isSszResponse(response *http.Response) bool {
+	return strings.HasPrefix(response.Header.Get("Content-Type"), RequestSszContentType)
+}
+
+// Get a Beacon block, using SSZ if the client supports it.
+// Returns false for handled if the client responded with JSON or a fork that can't be decoded, in which case the JSON route should be used.
+func (c *StandardHttpClient) getBeaconBlockSsz(blockId string) (block BeaconBlockResponse, exists bool, handled bool, err error) {
+	response, err := c.getSszRequest(fmt.Sprintf(RequestBeaconBlockPath, blockId))
+	if err != nil {
+		return BeaconBlockResponse{}, false, true, fmt.Errorf("Could not get beacon block data: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	if response.StatusCode == http.StatusNotFound {
+		return BeaconBlockResponse{}, false, true, nil
+	}
+	if response.StatusCode != http.StatusOK || !isSszResponse(response) || !sszBlockForks[strings.ToLower(response.Header.Get(ConsensusVersionHeader))] {
+		return BeaconBlockResponse{}, false, false, nil
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return BeaconBlockResponse{}, false, true, fmt.Errorf("Could not read beacon block data: %w", err)
+	}
+	block, err = decodeSszSignedBeaconBlock(strings.ToLower(response.Header.Get(ConsensusVersionHeader)), body)
+	if err != nil {
+		return BeaconBlockResponse{}, false, true, fmt.Errorf("Could not decode SSZ beacon block data: %w", err)
+	}
+	return block, true, true, nil
+}
+
+// Decode the fields of a signed Beacon block that the Smartnode uses from its SSZ encoding
+func decodeSszSignedBeaconBlock(fork string, data []byte) (BeaconBlockResponse, error) {
+	block := BeaconBlockResponse{}
+
+	// Signed block: message offset, signature
+	messageStart, err := readSszOffset(data, 0)
+	if err != nil {
+		return block, fmt.Errorf("error reading message offset: %w", err)
+	}
+	if messageStart != sszOffsetSize+sszSignedBlockSignatureSize || messageStart > len(data) {
+		return block, fmt.Errorf("invalid message offset %d", messageStart)
+	}
+	message := data[messageStart:]
+
+	// Block: slot, proposer index, parent root, state root, body offset
+	if len(message) < sszBlockBodyOffsetPosition+sszOffsetSize {
+		return block, fmt.Errorf("block message is too short (%d bytes)", len(message))
+	}
+	block.Data.Message.Slot = uinteger(binary.LittleEndian.Uint64(message[0:8]))
+	block.Data.Message.ProposerIndex = strconv.FormatUint(binary.LittleEndian.Uint64(message[8:16]), 10)
+	bodyStart, err := readSszOffset(message, sszBlockBodyOffsetPosition)
+	if err != nil {
+		return block, fmt.Errorf("error reading body offset: %w", err)
+	}
+	if bodyStart > len(message) {
+		return block, fmt.Errorf("invalid body offset %d", bodyStart)
+	}
+	body := message[bodyStart:]
+
+	// Eth1 data
+	if len(body) < sszBodyDepositsOffsetPosition+sszOffsetSize {
+		return block, fmt.Errorf("block body is too short (%d bytes)", len(body))
+	}
+	eth1Data := body[sszBodyEth1DataPosition:]
+	block.Data.Message.Body.Eth1Data.DepositRoot = byteArray(eth1Data[0:32])
+	block.Data.Message.Body.Eth1Data.DepositCount = uinteger(binary.LittleEndian.Uint64(eth1Data[32:40]))
+	block.Data.Message.Body.Eth1Data.BlockHash = byteArray(eth1Data[40:72])
+
+	// Attestations
+	attestationsStart, err := readSszOffset(body, sszBodyAttestationsOffsetPosition)
+	if err != nil {
+		return block, fmt.Errorf("error reading attestations offset: %w", err)
+	}
+	attestationsEnd, err := readSszOffset(body, sszBodyDepositsOffsetPosition)
+	if err != nil {
+		return block, fmt.Errorf("error reading deposits offset: %w", err)
+	}
+	if attestationsStart > attestationsEnd || attestationsEnd > len(body) {
+		return block, fmt.Errorf("invalid attestations range [%d, %d)", attestationsStart, attestationsEnd)
+	}
+	elements, err := splitSszVariableList(body[attestationsStart:attestationsEnd])
+	if err != nil {
+		return block, fmt.Errorf("error splitting attestations: %w", err)
+	}
+	for i, element := range elements {
+		if len(element) < sszAttestationFixedSize {
+			return block, fmt.Errorf("attestation %d is too short (%d bytes)", i, len(element))
+		}
+		bitsStart, err := readSszOffset(element, 0)
+		if err != nil || bitsStart < sszAttestationFixedSize || bitsStart > len(element) {
+			return block, fmt.Errorf("invalid aggregation bits offset for attestation %d", i)
+		}
+		attestation := Attestation{
+			AggregationBits: hexutil.AddPrefix(hex.EncodeToString(element[bitsStart:])),
+		}
+		attestation.Data.Slot = uinteger(binary.LittleEndian.Uint64(element[4:12]))
+		attestation.Data.Index = uinteger(binary.LittleEndian.Uint64(element[12:20]))
+		block.Data.Message.Body.Attestations = append(block.Data.Message.Body.Attestations, attestation)
+	}
+
+	// The execution payload only exists after the merge
+	if fork == "phase0" || fork == "altair" {
+		return block, nil
+	}
+	payloadStart, err := readSszOffset(body, sszBodyPayloadOffsetPosition)
+	if err != nil {
+		return block, fmt.Errorf("error reading execution payload offset: %w", err)
+	}
+	if payloadStart+sszPayloadBlockNumberPosition+8 > len(body) {
+		return block, fmt.Errorf("invalid execution payload offset %d", payloadStart)
+	}
+	payload := body[payloadStart:]
+	block.Data.Message.Body.ExecutionPayload = &struct {
+		FeeRecipient byteArray `json:"fee_recipient"`
+		BlockNumber  uinteger  `json:"block_number"`
+	}{
+		FeeRecipient: byteArray(payload[sszPayloadFeeRecipientPosition : sszPayloadFeeRecipientPosition+20]),
+		BlockNumber:  uinteger(binary.LittleEndian.Uint64(payload[sszPayloadBlockNumberPosition : sszPayloadBlockNumberPosition+8])),
+	}
+
+	return block, nil
+}
+
+// Get a whole SSZ-encoded Beacon state, along with the fork it's from
+func (c *StandardHttpClient) GetBeaconStateSsz(stateId string) (string, []byte, error) {
+	response, err := c.getSszRequest(fmt.Sprintf(RequestDebugStatePath, stateId))
+	if err != nil {
+		return "", nil, fmt.Errorf("Could not get beacon state: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if response.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("Could not get beacon state: HTTP status %d", response.StatusCode)
+	}
+	if !isSszResponse(response) {
+		return "", nil, fmt.Errorf("client did not respond with SSZ")
+	}
+	state, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", nil, fmt.Errorf("Could not read beacon state: %w", err)
+	}
+	return strings.ToLower(response.Header.Get(ConsensusVersionHeader)), state, nil
+}
+
+// Get validators by pubkey or index by streaming them out of the SSZ-encoded Beacon state.
+// The Beacon API doesn't offer SSZ for the validators route, so this uses the debug state route and only keeps the requested validators in memory.
+func (c *StandardHttpClient) getValidatorsFromSszState(stateId string, pubkeysOrIndices []string) (ValidatorsResponse, error) {
+
+	// Get the epoch length so the statuses can be derived
+	eth2Config, err := c.getEth2Config()
+	if err != nil {
+		return ValidatorsResponse{}, err
+	}
+
+	// Request the state
+	response, err := c.getSszRequest(fmt.Sprintf(RequestDebugStatePath, stateId))
+	if err != nil {
+		return ValidatorsResponse{}, fmt.Errorf("Could not get beacon state: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if response.StatusCode != http.StatusOK {
+		return ValidatorsResponse{}, fmt.Errorf("Could not get beacon state: HTTP status %d", response.StatusCode)
+	}
+	if !isSszResponse(response) {
+		return ValidatorsResponse{}, fmt.Errorf("client did not respond with SSZ")
+	}
+	return readSszStateValidators(bufio.NewReaderSize(response.Body, 1<<20), pubkeysOrIndices, uint64(eth2Config.Data.SlotsPerEpoch))
+
+}
+
+// Get validators by pubkey or index from an SSZ-encoded Beacon state that has already been downloaded
+func GetValidatorsFromSszState(state []byte, pubkeysOrIndices []string, slotsPerEpoch uint64) (ValidatorsResponse, error) {
+	return readSszStateValidators(bufio.NewReader(bytes.NewReader(state)), pubkeysOrIndices, slotsPerEpoch)
+}
+
+// Read validators by pubkey or index out of an SSZ-encoded Beacon state, only keeping the requested validators in memory
+func readSszStateValidators(reader *bufio.Reader, pubkeysOrIndices []string, slotsPerEpoch uint64) (ValidatorsResponse, error) {
+
+	// Sort the requested validators into pubkeys and indices
+	wantedPubkeys := map[string]bool{}
+	wantedIndices := map[uint64]bool{}
+	for _, id := range pubkeysOrIndices {
+		if strings.HasPrefix(id, "0x") {
+			wantedPubkeys[strings.ToLower(hexutil.RemovePrefix(id))] = true
+		} else {
+			index, err := strconv.ParseUint(id, 10, 64)
+			if err != nil {
+				return ValidatorsResponse{}, fmt.Errorf("invalid validator index '%s'", id)
+			}
+			wantedIndices[index] = true
+		}
+	}
+
+	// Read the fixed fields up to the validators and balances offsets
+	prefix := make([]byte, sszStateFixedPrefixSize)
+	_, err := io.ReadFull(reader, prefix)
+	if err != nil {
+		return ValidatorsResponse{}, fmt.Errorf("error reading state prefix: %w", err)
+	}
+	epoch := binary.LittleEndian.Uint64(prefix[sszStateSlotPosition:sszStateSlotPosition+8]) / slotsPerEpoch
+	validatorsStart, _ := readSszOffset(prefix, sszStateValidatorsOffsetPosition)
+	balancesStart, _ := readSszOffset(prefix, sszStateBalancesOffsetPosition)
+	balancesEnd, _ := readSszOffset(prefix, sszStateNextFieldOffsetPosition)
+	if validatorsStart < sszStateFixedPrefixSize || balancesStart < validatorsStart || balancesEnd < balancesStart ||
+		(balancesStart-validatorsStart)%sszValidatorSize != 0 || (balancesEnd-balancesStart)%sszBalanceSize != 0 {
+		return ValidatorsResponse{}, fmt.Errorf("invalid validator offsets in state")
+	}
+
+	// Skip to the validators and pull out the requested ones
+	_, err = reader.Discard(validatorsStart - sszStateFixedPrefixSize)
+	if err != nil {
+		return ValidatorsResponse{}, fmt.Errorf("error skipping to validators: %w", err)
+	}
+	validatorCount := (balancesStart - validatorsStart) / sszValidatorSize
+	found := map[uint64]*Validator{}
+	order := []uint64{}
+	record := make([]byte, sszValidatorSize)
+	for index := uint64(0); index < uint64(validatorCount); index++ {
+		_, err = io.ReadFull(reader, record)
+		if err != nil {
+			return ValidatorsResponse{}, fmt.Errorf("error reading validator %d: %w", index, err)
+		}
+		pubkey := record[0:48]
+		if !wantedIndices[index] && !wantedPubkeys[hex.EncodeToString(pubkey)] {
+			continue
+		}
+		validator := &Validator{
+			Index: strconv.FormatUint(index, 10),
+		}
+		validator.Validator.Pubkey = append(byteArray{}, pubkey...)
+		validator.Validator.WithdrawalCredentials = append(byteArray{}, record[48:80]...)
+		validator.Validator.EffectiveBalance = uinteger(binary.LittleEndian.Uint64(record[80:88]))
+		validator.Validator.Slashed = record[88] != 0
+		validator.Validator.ActivationEligibilityEpoch = uinteger(binary.LittleEndian.Uint64(record[89:97]))
+		validator.Validator.ActivationEpoch = uinteger(binary.LittleEndian.Uint64(record[97:105]))
+		validator.Validator.ExitEpoch = uinteger(binary.LittleEndian.Uint64(record[105:113]))
+		validator.Validator.WithdrawableEpoch = uinteger(binary.LittleEndian.Uint64(record[113:121]))
+		found[index] = validator
+		order = append(order, index)
+	}
+
+	// Read the balances of the requested validators
+	balance := make([]byte, sszBalanceSize)
+	balanceCount := (balancesEnd - balancesStart) / sszBalanceSize
+	for index := uint64(0); index < uint64(balanceCount) && len(found) > 0; index++ {
+		_, err = io.ReadFull(reader, balance)
+		if err != nil {
+			return ValidatorsResponse{}, fmt.Errorf("error reading balance %d: %w", index, err)
+		}
+		if validator, exists := found[index]; exists {
+			validator.Balance = uinteger(binary.LittleEndian.Uint64(balance))
+		}
+	}
+
+	// Build the response
+	validators := ValidatorsResponse{
+		Data: make([]Validator, 0, len(order)),
+	}
+	for _, index := range order {
+		validator := found[index]
+		validator.Status = string(getSszValidatorState(validator, epoch))
+		validators.Data = append(validators.Data, *validator)
+	}
+	return validators, nil
+
+}
+
+// Derive a validator's status at an epoch, following the rules the Beacon API uses
+func getSszValidatorState(validator *Validator, epoch uint64) beacon.ValidatorState {
+	details := validator.Validator
+	switch {
+	case uint64(details.ActivationEligibilityEpoch) == sszFarFutureEpoch:
+		return beacon.ValidatorState_PendingInitialized
+	case epoch < uint64(details.ActivationEpoch):
+		return beacon.ValidatorState_PendingQueued
+	case epoch < uint64(details.ExitEpoch):
+		if uint64(details.ExitEpoch) == sszFarFutureEpoch {
+			return beacon.ValidatorState_ActiveOngoing
+		}
+		if details.Slashed {
+			return beacon.ValidatorState_ActiveSlashed
+		}
+		return beacon.ValidatorState_ActiveExiting
+	case epoch < uint64(details.WithdrawableEpoch):
+		if details.Slashed {
+			return beacon.ValidatorState_ExitedSlashed
+		}
+		return beacon.ValidatorState_ExitedUnslashed
+	case validator.Balance != 0:
+		return beacon.ValidatorState_WithdrawalPossible
+	default:
+		return beacon.ValidatorState_WithdrawalDone
+	}
+}
+
+// Read a 4-byte SSZ offset at the given position
+func readSszOffset(data []byte, position int) (int, error) {
+	if position+sszOffsetSize > len(data) {
+		return 0, fmt.Errorf("offset at %d is out of range", position)
+	}
+	return int(binary.LittleEndian.Uint32(data[position : position+sszOffsetSize])), nil
+}
+
+// Split an SSZ list of variable-size elements into its elements
+func splitSszVariableList(data []byte) ([][]byte, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	firstOffset, err := readSszOffset(data, 0)
+	if err != nil {
+		return nil, err
+	}
+	if firstOffset == 0 || firstOffset%sszOffsetSize != 0 || firstOffset > len(data) {
+		return nil, fmt.Errorf("invalid first offset %d", firstOffset)
+	}
+	count := firstOffset / sszOffsetSize
+	elements := make([][]byte, count)
+	for i := 0; i < count; i++ {
+		start, _ := readSszOffset(data, i*sszOffsetSize)
+		end := len(data)
+		if i < count-1 {
+			end, _ = readSszOffset(data, (i+1)*sszOffsetSize)
+		}
+		if start > end || end > len(data) {
+			return nil, fmt.Errorf("invalid range [%d, %d) for element %d", start, end, i)
+		}
+		elements[i] = data[start:end]
+	}
+	return elements, nil
+}
@@ -2,7 +2,6 @@ package client
 
 import (
 	"bytes"
-	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
@@ -19,6 +18,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/eth2"
 	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
 )
@@ -29,6 +29,7 @@ const (
 	RequestContentType = "application/json"
 
 	RequestSyncStatusPath                  = "/eth/v1/node/syncing"
+	RequestNodeVersionPath                 = "/eth/v1/node/version"
 	RequestEth2ConfigPath                  = "/eth/v1/config/spec"
 	RequestEth2DepositContractMethod       = "/eth/v1/config/deposit_contract"
 	RequestGenesisPath                     = "/eth/v1/beacon/genesis"
@@ -39,6 +40,7 @@ const (
 	RequestVoluntaryExitPath               = "/eth/v1/beacon/pool/voluntary_exits"
 	RequestAttestationsPath                = "/eth/v1/beacon/blocks/%s/attestations"
 	RequestBeaconBlockPath                 = "/eth/v2/beacon/blocks/%s"
+	RequestBeaconBlockHeaderPath           = "/eth/v1/beacon/headers/%s"
 	RequestValidatorSyncDuties             = "/eth/v1/validator/duties/sync/%s"
 	RequestValidatorProposerDuties         = "/eth/v1/validator/duties/proposer/%s"
 	RequestWithdrawalCredentialsChangePath = "/eth/v1/beacon/pool/bls_to_execution_changes"
@@ -50,13 +52,64 @@ const (
 // Beacon client using the standard Beacon HTTP REST API (https://ethereum.github.io/beacon-APIs/)
 type StandardHttpClient struct {
 	providerAddress string
+
+	// Limits for large validator lookups
+	validatorChunkSize   int
+	validatorConcurrency int
+
+	// Whether the client serves SSZ-encoded responses; if not, requests go straight to JSON
+	sszEnabled bool
+
+	// Whether another client can take over if this one fails, in which case failed requests aren't retried here
+	hasFallback bool
+
+	// Cache for responses that can't change
+	cache *responseCache
+
+	// Provides the states that validators are read from once they've been proven, if verification is enabled
+	stateVerifier StateVerifier
+
+	// Timeouts and retries for each class of request
+	callPolicies map[cfgtypes.CallClass]cfgtypes.CallPolicy
+	httpClients  map[cfgtypes.CallClass]*http.Client
+	transport    http.RoundTripper
 }
 
 // Create a new client instance
 func NewStandardHttpClient(providerAddress string) *StandardHttpClient {
 	return &StandardHttpClient{
-		providerAddress: providerAddress,
+		providerAddress:      providerAddress,
+		validatorChunkSize:   MaxRequestValidatorsCount,
+		validatorConcurrency: threadLimit,
+		sszEnabled:           true,
+		cache:                newResponseCache(),
+	}
+}
+
+// Set how large validator lookups are split up: the number of validators per request and how many requests can run at once.
+// A chunk size or concurrency of 0 keeps the default. Failed requests are retried with the heavy call policy.
+func (c *StandardHttpClient) SetValidatorRequestLimits(chunkSize int, concurrency int) {
+	if chunkSize > 0 {
+		c.validatorChunkSize = chunkSize
 	}
+	if concurrency > 0 {
+		c.validatorConcurrency = concurrency
+	}
+}
+
+// Set whether SSZ-encoded responses should be requested from the client
+func (c *StandardHttpClient) SetSszEnabled(enabled bool) {
+	c.sszEnabled = enabled
+}
+
+// Set whether another client will be tried if this one fails, so failed requests go to it right away instead of being retried here
+func (c *StandardHttpClient) SetHasFallback(hasFallback bool) {
+	c.hasFallback = hasFallback
+}
+
+// Set the verifier that proves the states validators are read from, instead of trusting the client's validator responses
+func (c *StandardHttpClient) SetStateVerifier(verifier StateVerifier) {
+	c.stateVerifier = verifier
 }
 
 // Close the client connection
@@ -89,6 +142,22 @@ func (c *StandardHttpClient) GetSyncStatus() (beacon.SyncStatus, error) {
 
 }
 
+// Get the client's name and version
+func (c *StandardHttpClient) GetNodeVersion() (beacon.NodeVersion, error) {
+
+	// Get the node version
+	nodeVersion, err := c.getNodeVersion()
+	if err != nil {
+		return beacon.NodeVersion{}, err
+	}
+
+	// Return response
+	return beacon.NodeVersion{
+		Version: nodeVersion.Data.Version,
+	}, nil
+
+}
+
 // Get the eth2 config
 func (c *StandardHttpClient) GetEth2Config() (beacon.Eth2Config, error) {
 
@@ -126,6 +195,7 @@ func (c *StandardHttpClient) GetEth2Config() (beacon.Eth2Config, error) {
 		SlotsPerEpoch:                uint64(eth2Config.Data.SlotsPerEpoch),
 		SecondsPerEpoch:              uint64(eth2Config.Data.SecondsPerSlot * eth2Config.Data.SlotsPerEpoch),
 		EpochsPerSyncCommitteePeriod: uint64(eth2Config.Data.EpochsPerSyncCommitteePeriod),
+		CapellaForkVersion:           eth2Config.Data.CapellaForkVersion,
 	}, nil
 
 }
@@ -441,89 +511,64 @@ func (c *StandardHttpClient) ExitValidator(validatorIndex string, epoch uint64,
 	})
 }
 
+// Get the number of validators waiting in the exit queue
+func (c *StandardHttpClient) GetExitQueueLength() (uint64, error) {
+	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestValidatorsPath, "head") + "?status=active_exiting")
+	if err != nil {
+		return 0, fmt.Errorf("Could not get exiting validators: %w", err)
+	}
+	if status != http.StatusOK {
+		return 0, fmt.Errorf("Could not get exiting validators: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var validators ValidatorsResponse
+	if err := json.Unmarshal(responseBody, &validators); err != nil {
+		return 0, fmt.Errorf("Could not decode exiting validators: %w", err)
+	}
+	return uint64(len(validators.Data)), nil
+}
+
 // Get the ETH1 data for the target beacon block
 func (c *StandardHttpClient) GetEth1DataForEth2Block(blockId string) (beacon.Eth1Data, bool, error) {
-
-	// Get the Beacon block
-	block, exists, err := c.getBeaconBlock(blockId)
+	block, err := c.getBeaconBlock(blockId)
 	if err != nil {
 		return beacon.Eth1Data{}, false, err
 	}
-	if !exists {
-		return beacon.Eth1Data{}, false, nil
-	}
-
-	// Convert the response to the eth1 data struct
-	return beacon.Eth1Data{
-		DepositRoot:  common.BytesToHash(block.Data.Message.Body.Eth1Data.DepositRoot),
-		DepositCount: uint64(block.Data.Message.Body.Eth1Data.DepositCount),
-		BlockHash:    common.BytesToHash(block.Data.Message.Body.Eth1Data.BlockHash),
-	}, true, nil
-
+	return block.eth1Data, block.exists, nil
 }
 
 func (c *StandardHttpClient) GetAttestations(blockId string) ([]beacon.AttestationInfo, bool, error) {
-	attestations, exists, err := c.getAttestations(blockId)
+	attestations, err := c.getAttestations(blockId)
 	if err != nil {
 		return nil, false, err
 	}
-	if !exists {
-		return nil, false, nil
-	}
-
-	// Add attestation info
-	attestationInfo := make([]beacon.AttestationInfo, len(attestations.Data))
-	for i, attestation := range attestations.Data {
-		bitString := hexutil.RemovePrefix(attestation.AggregationBits)
-		attestationInfo[i].SlotIndex = uint64(attestation.Data.Slot)
-		attestationInfo[i].CommitteeIndex = uint64(attestation.Data.Index)
-		attestationInfo[i].AggregationBits, err = hex.DecodeString(bitString)
-		if err != nil {
-			return nil, false, fmt.Errorf("Error decoding aggregation bits for attestation %d of block %s: %w", i, blockId, err)
-		}
-	}
-
-	return attestationInfo, true, nil
+	return attestations.attestations, attestations.exists, nil
 }
 
 func (c *StandardHttpClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
-	block, exists, err := c.getBeaconBlock(blockId)
+	block, err := c.getBeaconBlock(blockId)
 	if err != nil {
 		return beacon.BeaconBlock{}, false, err
 	}
-	if !exists {
-		return beacon.BeaconBlock{}, false, nil
-	}
+	return block.block, block.exists, nil
+}
 
-	beaconBlock := beacon.BeaconBlock{
-		Slot:          uint64(block.Data.Message.Slot),
-		ProposerIndex: block.Data.Message.ProposerIndex,
+// Get the header of a beacon block, and whether it exists
+func (c *StandardHttpClient) GetBeaconBlockHeader(blockId string) (BeaconBlockHeaderResponse, bool, error) {
+	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestBeaconBlockHeaderPath, blockId))
+	if err != nil {
+		return BeaconBlockHeaderResponse{}, false, fmt.Errorf("Could not get beacon block header: %w", err)
 	}
-
-	// Execution payload only exists after the merge, so check for its existence
-	if block.Data.Message.Body.ExecutionPayload == nil {
-		beaconBlock.HasExecutionPayload = false
-	} else {
-		beaconBlock.HasExecutionPayload = true
-		beaconBlock.FeeRecipient = common.BytesToAddress(block.Data.Message.Body.ExecutionPayload.FeeRecipient)
-		beaconBlock.ExecutionBlockNumber = uint64(block.Data.Message.Body.ExecutionPayload.BlockNumber)
+	if status == http.StatusNotFound {
+		return BeaconBlockHeaderResponse{}, false, nil
 	}
-
-	// Add attestation info
-	for i, attestation := range block.Data.Message.Body.Attestations {
-		bitString := hexutil.RemovePrefix(attestation.AggregationBits)
-		info := beacon.AttestationInfo{
-			SlotIndex:      uint64(attestation.Data.Slot),
-			CommitteeIndex: uint64(attestation.Data.Index),
-		}
-		info.AggregationBits, err = hex.DecodeString(bitString)
-		if err != nil {
-			return beacon.BeaconBlock{}, false, fmt.Errorf("Error decoding aggregation bits for attestation %d of block %s: %w", i, blockId, err)
-		}
-		beaconBlock.Attestations = append(beaconBlock.Attestations, info)
+	if status != http.StatusOK {
+		return BeaconBlockHeaderResponse{}, false, fmt.Errorf("Could not get beacon block header: HTTP status %d; response body: '%s'", status, string(responseBody))
 	}
-
-	return beaconBlock, true, nil
+	var header BeaconBlockHeaderResponse
+	if err := json.Unmarshal(responseBody, &header); err != nil {
+		return BeaconBlockHeaderResponse{}, false, fmt.Errorf("Could not decode beacon block header: %w", err)
+	}
+	return header, true, nil
 }
 
 // Get the attestation committees for the given epoch, or the current epoch if nil
@@ -564,8 +609,31 @@ func (c *StandardHttpClient) getSyncStatus() (SyncStatusResponse, error) {
 	return syncStatus, nil
 }
 
+// Get the node version
+func (c *StandardHttpClient) getNodeVersion() (NodeVersionResponse, error) {
+	responseBody, status, err := c.getRequest(RequestNodeVersionPath)
+	if err != nil {
+		return NodeVersionResponse{}, fmt.Errorf("Could not get node version: %w", err)
+	}
+	if status != http.StatusOK {
+		return NodeVersionResponse{}, fmt.Errorf("Could not get node version: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var nodeVersion NodeVersionResponse
+	if err := json.Unmarshal(responseBody, &nodeVersion); err != nil {
+		return NodeVersionResponse{}, fmt.Errorf("Could not decode node version: %w", err)
+	}
+	return nodeVersion, nil
+}
+
 // Get the eth2 config
 func (c *StandardHttpClient) getEth2Config() (Eth2ConfigResponse, error) {
+	c.cache.lock.Lock()
+	cachedConfig := c.cache.eth2Config
+	c.cache.lock.Unlock()
+	if cachedConfig != nil {
+		return *cachedConfig, nil
+	}
+
 	responseBody, status, err := c.getRequest(RequestEth2ConfigPath)
 	if err != nil {
 		return Eth2ConfigResponse{}, fmt.Errorf("Could not get eth2 config: %w", err)
@@ -577,6 +645,9 @@ func (c *StandardHttpClient) getEth2Config() (Eth2ConfigResponse, error) {
 	if err := json.Unmarshal(responseBody, &eth2Config); err != nil {
 		return Eth2ConfigResponse{}, fmt.Errorf("Could not decode eth2 config: %w", err)
 	}
+	c.cache.lock.Lock()
+	c.cache.eth2Config = &eth2Config
+	c.cache.lock.Unlock()
 	return eth2Config, nil
 }
 
@@ -598,6 +669,13 @@ func (c *StandardHttpClient) getEth2DepositContract() (Eth2DepositContractRespon
 
 // Get genesis information
 func (c *StandardHttpClient) getGenesis() (GenesisResponse, error) {
+	c.cache.lock.Lock()
+	cachedGenesis := c.cache.genesis
+	c.cache.lock.Unlock()
+	if cachedGenesis != nil {
+		return *cachedGenesis, nil
+	}
+
 	responseBody, status, err := c.getRequest(RequestGenesisPath)
 	if err != nil {
 		return GenesisResponse{}, fmt.Errorf("Could not get genesis data: %w", err)
@@ -609,6 +687,9 @@ func (c *StandardHttpClient) getGenesis() (GenesisResponse, error) {
 	if err := json.Unmarshal(responseBody, &genesis); err != nil {
 		return GenesisResponse{}, fmt.Errorf("Could not decode genesis: %w", err)
 	}
+	c.cache.lock.Lock()
+	c.cache.genesis = &genesis
+	c.cache.lock.Unlock()
 	return genesis, nil
 }
 
@@ -689,14 +770,47 @@ func (c *StandardHttpClient) getValidatorsByOpts(pubkeysOrIndices []string, opts
 		return ValidatorsResponse{}, fmt.Errorf("must specify a slot or epoch when calling getValidatorsByOpts")
 	}
 
+	// Only read validators from proven states if verification is enabled
+	if c.stateVerifier != nil {
+		return c.getVerifiedValidators(stateId, pubkeysOrIndices)
+	}
+
+	// Validators at finalized slots can't change, so only request the ones that aren't cached yet
+	if !c.isImmutableStateId(stateId) {
+		return c.getValidatorsAtState(stateId, pubkeysOrIndices)
+	}
+	cached, missing := c.getCachedValidators(stateId, pubkeysOrIndices)
+	if len(missing) == 0 {
+		return ValidatorsResponse{Data: cached}, nil
+	}
+	validators, err := c.getValidatorsAtState(stateId, missing)
+	if err != nil {
+		return ValidatorsResponse{}, err
+	}
+	c.cacheValidators(stateId, validators.Data)
+	validators.Data = append(cached, validators.Data...)
+	return validators, nil
+}
+
+// Get validators by pubkeys or indices at the given state from the Beacon node
+func (c *StandardHttpClient) getValidatorsAtState(stateId string, pubkeysOrIndices []string) (ValidatorsResponse, error) {
+
+	// Large requests are cheaper to pull out of the SSZ-encoded state if the client supports it
 	count := len(pubkeysOrIndices)
+	if c.sszEnabled && count >= SszStateValidatorsThreshold {
+		validators, err := c.getValidatorsFromSszState(stateId, pubkeysOrIndices)
+		if err == nil {
+			return validators, nil
+		}
+	}
+
 	data := make([]Validator, count)
 	validFlags := make([]bool, count)
 	var wg errgroup.Group
-	wg.SetLimit(threadLimit)
-	for i := 0; i < count; i += MaxRequestValidatorsCount {
+	wg.SetLimit(c.validatorConcurrency)
+	for i := 0; i < count; i += c.validatorChunkSize {
 		i := i
-		max := i + MaxRequestValidatorsCount
+		max := i + c.validatorChunkSize
 		if max > count {
 			max = count
 		}
@@ -743,8 +857,33 @@ func (c *StandardHttpClient) postVoluntaryExit(request VoluntaryExitRequest) err
 	return nil
 }
 
-// Get the target beacon block
-func (c *StandardHttpClient) getAttestations(blockId string) (AttestationsResponse, bool, error) {
+// Get the attestations in the target beacon block, using the cache for finalized blocks
+func (c *StandardHttpClient) getAttestations(blockId string) (cachedAttestations, error) {
+	immutable := c.isImmutableBlockId(blockId)
+	if immutable {
+		if cached, exists := c.cache.attestations.Get(blockId); exists {
+			return cached.(cachedAttestations), nil
+		}
+	}
+	response, exists, err := c.getAttestationsFromNode(blockId)
+	if err != nil {
+		return cachedAttestations{}, err
+	}
+	attestations := cachedAttestations{exists: exists}
+	if exists {
+		attestations.attestations, err = getAttestationInfo(blockId, response.Data)
+		if err != nil {
+			return cachedAttestations{}, err
+		}
+	}
+	if immutable {
+		c.cache.attestations.Add(blockId, attestations)
+	}
+	return attestations, nil
+}
+
+// Get the attestations in the target beacon block from the Beacon node
+func (c *StandardHttpClient) getAttestationsFromNode(blockId string) (AttestationsResponse, bool, error) {
 	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestAttestationsPath, blockId))
 	if err != nil {
 		return AttestationsResponse{}, false, fmt.Errorf("Could not get attestations data for slot %s: %w", blockId, err)
@@ -762,8 +901,41 @@ func (c *StandardHttpClient) getAttestations(blockId string) (AttestationsRespon
 	return attestations, true, nil
 }
 
-// Get the target beacon block
-func (c *StandardHttpClient) getBeaconBlock(blockId string) (BeaconBlockResponse, bool, error) {
+// Get the target beacon block, using the cache for finalized blocks
+func (c *StandardHttpClient) getBeaconBlock(blockId string) (cachedBlock, error) {
+	immutable := c.isImmutableBlockId(blockId)
+	if immutable {
+		if cached, exists := c.cache.blocks.Get(blockId); exists {
+			return cached.(cachedBlock), nil
+		}
+	}
+	response, exists, err := c.getBeaconBlockFromNode(blockId)
+	if err != nil {
+		return cachedBlock{}, err
+	}
+	block := cachedBlock{exists: exists}
+	if exists {
+		block, err = getCachedBlock(blockId, response)
+		if err != nil {
+			return cachedBlock{}, err
+		}
+	}
+	if immutable {
+		c.cache.blocks.Add(blockId, block)
+	}
+	return block, nil
+}
+
+// Get the target beacon block from the Beacon node
+func (c *StandardHttpClient) getBeaconBlockFromNode(blockId string) (BeaconBlockResponse, bool, error) {
+	// Try SSZ first since it's much cheaper to decode
+	if c.sszEnabled {
+		block, exists, handled, err := c.getBeaconBlockSsz(blockId)
+		if handled {
+			return block, exists, err
+		}
+	}
+
 	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestBeaconBlockPath, blockId))
 	if err != nil {
 		return BeaconBlockResponse{}, false, fmt.Errorf("Could not get beacon block data: %w", err)
@@ -868,7 +1040,9 @@ func (c *StandardHttpClient) postWithdrawalCredentialsChange(request BLSToExecut
 func (c *StandardHttpClient) getRequestReader(requestPath string) (io.ReadCloser, int, error) {
 
 	// Send request
-	response, err := http.Get(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath))
+	response, err := c.sendRequest(getCallClass(http.MethodGet, requestPath), func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), nil)
+	})
 	if err != nil {
 		return nil, 0, err
 	}
@@ -906,10 +1080,16 @@ func (c *StandardHttpClient) postRequest(requestPath string, requestBody interfa
 	if err != nil {
 		return []byte{}, 0, err
 	}
-	requestBodyReader := bytes.NewReader(requestBodyBytes)
 
 	// Send request
-	response, err := http.Post(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), RequestContentType, requestBodyReader)
+	response, err := c.sendRequest(getCallClass(http.MethodPost, requestPath), func() (*http.Request, error) {
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), bytes.NewReader(requestBodyBytes))
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("Content-Type", RequestContentType)
+		return request, nil
+	})
 	if err != nil {
 		return []byte{}, 0, err
 	}
@@ -37,11 +37,17 @@ type SyncStatusResponse struct {
 		SyncDistance uinteger `json:"sync_distance"`
 	} `json:"data"`
 }
+type NodeVersionResponse struct {
+	Data struct {
+		Version string `json:"version"`
+	} `json:"data"`
+}
 type Eth2ConfigResponse struct {
 	Data struct {
-		SecondsPerSlot               uinteger `json:"SECONDS_PER_SLOT"`
-		SlotsPerEpoch                uinteger `json:"SLOTS_PER_EPOCH"`
-		EpochsPerSyncCommitteePeriod uinteger `json:"EPOCHS_PER_SYNC_COMMITTEE_PERIOD"`
+		SecondsPerSlot               uinteger  `json:"SECONDS_PER_SLOT"`
+		SlotsPerEpoch                uinteger  `json:"SLOTS_PER_EPOCH"`
+		EpochsPerSyncCommitteePeriod uinteger  `json:"EPOCHS_PER_SYNC_COMMITTEE_PERIOD"`
+		CapellaForkVersion           byteArray `json:"CAPELLA_FORK_VERSION"`
 	} `json:"data"`
 }
 type Eth2DepositContractResponse struct {
@@ -144,6 +150,90 @@ type Attestation struct {
 		Index uinteger `json:"index"`
 	} `json:"data"`
 }
+type StateRootResponse struct {
+	Data struct {
+		Root byteArray `json:"root"`
+	} `json:"data"`
+}
+type BeaconBlockHeaderResponse struct {
+	Data struct {
+		Root   byteArray `json:"root"`
+		Header struct {
+			Message LightClientBeaconHeader `json:"message"`
+		} `json:"header"`
+	} `json:"data"`
+}
+type LightClientBootstrapResponse struct {
+	Version string `json:"version"`
+	Data    struct {
+		Header                     LightClientHeader `json:"header"`
+		CurrentSyncCommittee       SyncCommittee     `json:"current_sync_committee"`
+		CurrentSyncCommitteeBranch MerkleBranch      `json:"current_sync_committee_branch"`
+	} `json:"data"`
+}
+type LightClientUpdateResponse struct {
+	Version string            `json:"version"`
+	Data    LightClientUpdate `json:"data"`
+}
+type LightClientUpdate struct {
+	AttestedHeader          LightClientHeader `json:"attested_header"`
+	NextSyncCommittee       *SyncCommittee    `json:"next_sync_committee"`
+	NextSyncCommitteeBranch MerkleBranch      `json:"next_sync_committee_branch"`
+	FinalizedHeader         LightClientHeader `json:"finalized_header"`
+	FinalityBranch          MerkleBranch      `json:"finality_branch"`
+	SyncAggregate           struct {
+		SyncCommitteeBits      byteArray `json:"sync_committee_bits"`
+		SyncCommitteeSignature byteArray `json:"sync_committee_signature"`
+	} `json:"sync_aggregate"`
+	SignatureSlot uinteger `json:"signature_slot"`
+}
+type SyncCommittee struct {
+	Pubkeys         []byteArray `json:"pubkeys"`
+	AggregatePubkey byteArray   `json:"aggregate_pubkey"`
+}
+type LightClientBeaconHeader struct {
+	Slot          uinteger  `json:"slot"`
+	ProposerIndex uinteger  `json:"proposer_index"`
+	ParentRoot    byteArray `json:"parent_root"`
+	StateRoot     byteArray `json:"state_root"`
+	BodyRoot      byteArray `json:"body_root"`
+}
+
+// Light client header type; since Capella the Beacon header is wrapped in a "beacon" field alongside the execution header
+type LightClientHeader struct {
+	Beacon LightClientBeaconHeader
+}
+
+func (h *LightClientHeader) UnmarshalJSON(data []byte) error {
+
+	// Try the wrapped form first
+	var wrapped struct {
+		Beacon *LightClientBeaconHeader `json:"beacon"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return err
+	}
+	if wrapped.Beacon != nil {
+		h.Beacon = *wrapped.Beacon
+		return nil
+	}
+
+	// Fall back to the Altair form, where the header is the Beacon header itself
+	return json.Unmarshal(data, &h.Beacon)
+
+}
+
+// Merkle branch type
+type MerkleBranch []byteArray
+
+// Get the nodes of the branch as 32-byte chunks
+func (b MerkleBranch) Nodes() [][32]byte {
+	nodes := make([][32]byte, len(b))
+	for i, node := range b {
+		copy(nodes[i][:], node)
+	}
+	return nodes
+}
 
 // Unsigned integer type
 type uinteger uint64
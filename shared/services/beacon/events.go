@@ -0,0 +1,43 @@
+package beacon
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// A topic on the Beacon node's event stream
+type EventTopic string
+
+const (
+	EventTopic_Head                EventTopic = "head"
+	EventTopic_FinalizedCheckpoint EventTopic = "finalized_checkpoint"
+	EventTopic_Block               EventTopic = "block"
+)
+
+// Sent when the chain's head changes
+type HeadEvent struct {
+	Slot            uint64
+	Block           common.Hash
+	State           common.Hash
+	EpochTransition bool
+}
+
+// Sent when a new checkpoint is finalized
+type FinalizedCheckpointEvent struct {
+	Block common.Hash
+	State common.Hash
+	Epoch uint64
+}
+
+// Sent when the Beacon node imports a new block
+type BlockEvent struct {
+	Slot  uint64
+	Block common.Hash
+}
+
+// An event from the Beacon node's event stream; only the field matching the topic is set
+type Event struct {
+	Topic               EventTopic
+	Head                *HeadEvent
+	FinalizedCheckpoint *FinalizedCheckpointEvent
+	Block               *BlockEvent
+}
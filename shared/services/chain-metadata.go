@@ -0,0 +1,222 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// How long RocketStorage lookups (contract addresses and ABIs) are trusted for; this matches rocketpool-go's own cache
+const storageLookupTTL time.Duration = time.Duration(rocketpool.CacheTTL) * time.Second
+
+// How long to wait before saving new lookups, so the burst of them when a process starts is written once
+const chainMetadataSaveDelay time.Duration = time.Second
+
+// The RocketStorage functions whose results are cached
+var (
+	getAddressSelector = crypto.Keccak256([]byte("getAddress(bytes32)"))[:4]
+	getStringSelector  = crypto.Keccak256([]byte("getString(bytes32)"))[:4]
+)
+
+// Chain metadata that's slow to derive, persisted so the daemons and API commands don't have to query it again every time they start.
+// The Beacon config and deposit contract never change for a network; contract addresses and ABIs are only trusted for as long as
+// rocketpool-go would keep them in memory, so a restart doesn't serve them any longer than a running process would.
+type chainMetadataCache struct {
+	path          string
+	metadata      chainMetadata
+	saveScheduled bool
+	lock          sync.Mutex
+}
+
+// The persisted metadata; it's discarded if it was saved by a different Smartnode version or for a different network
+type chainMetadata struct {
+	SmartnodeVersion string                      `json:"smartnodeVersion"`
+	Network          cfgtypes.Network            `json:"network"`
+	StorageAddress   common.Address              `json:"storageAddress"`
+	Eth2Config       *beacon.Eth2Config          `json:"eth2Config,omitempty"`
+	DepositContract  *beacon.Eth2DepositContract `json:"depositContract,omitempty"`
+	StorageLookups   map[string]storageLookup    `json:"storageLookups"`
+}
+
+// The result of a RocketStorage lookup, and when it was made
+type storageLookup struct {
+	Result  hexutil.Bytes `json:"result"`
+	Updated time.Time     `json:"updated"`
+}
+
+// Load the chain metadata cache for the configured network, starting a fresh one if there isn't a usable one on disk
+func newChainMetadataCache(cfg *config.RocketPoolConfig) *chainMetadataCache {
+	cache := &chainMetadataCache{
+		path: cfg.Smartnode.GetChainMetadataCachePath(),
+		metadata: chainMetadata{
+			SmartnodeVersion: shared.RocketPoolVersion,
+			Network:          cfg.Smartnode.Network.Value.(cfgtypes.Network),
+			StorageAddress:   common.HexToAddress(cfg.Smartnode.GetStorageAddress()),
+			StorageLookups:   map[string]storageLookup{},
+		},
+	}
+
+	data, err := os.ReadFile(cache.path)
+	if err != nil {
+		return cache
+	}
+	var saved chainMetadata
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return cache
+	}
+	if saved.SmartnodeVersion != cache.metadata.SmartnodeVersion || saved.Network != cache.metadata.Network || saved.StorageAddress != cache.metadata.StorageAddress {
+		return cache
+	}
+	if saved.StorageLookups == nil {
+		saved.StorageLookups = map[string]storageLookup{}
+	}
+	cache.metadata = saved
+	return cache
+}
+
+// Get the cached Beacon config
+func (c *chainMetadataCache) getEth2Config() (beacon.Eth2Config, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	// Configs cached before the Capella fork version was tracked need to be fetched again
+	if c.metadata.Eth2Config == nil || len(c.metadata.Eth2Config.CapellaForkVersion) == 0 {
+		return beacon.Eth2Config{}, false
+	}
+	return *c.metadata.Eth2Config, true
+}
+
+// Cache the Beacon config
+func (c *chainMetadataCache) setEth2Config(eth2Config beacon.Eth2Config) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.metadata.Eth2Config = &eth2Config
+	c.scheduleSave()
+}
+
+// Get the cached deposit contract
+func (c *chainMetadataCache) getDepositContract() (beacon.Eth2DepositContract, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.metadata.DepositContract == nil {
+		return beacon.Eth2DepositContract{}, false
+	}
+	return *c.metadata.DepositContract, true
+}
+
+// Cache the deposit contract
+func (c *chainMetadataCache) setDepositContract(depositContract beacon.Eth2DepositContract) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.metadata.DepositContract = &depositContract
+	c.scheduleSave()
+}
+
+// Get the cached result of a contract call if it's a recent RocketStorage lookup at the latest block
+func (c *chainMetadataCache) getStorageLookup(call ethereum.CallMsg, blockNumber *big.Int) ([]byte, bool) {
+	if !c.isStorageLookup(call, blockNumber) {
+		return nil, false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	lookup, exists := c.metadata.StorageLookups[hexutil.Encode(call.Data)]
+	if !exists || time.Since(lookup.Updated) > storageLookupTTL {
+		return nil, false
+	}
+	return lookup.Result, true
+}
+
+// Cache the result of a contract call if it's a RocketStorage lookup at the latest block that found something
+func (c *chainMetadataCache) setStorageLookup(call ethereum.CallMsg, blockNumber *big.Int, result []byte) {
+	if !c.isStorageLookup(call, blockNumber) || isEmptyStorageValue(call.Data, result) {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.metadata.StorageLookups[hexutil.Encode(call.Data)] = storageLookup{
+		Result:  result,
+		Updated: time.Now(),
+	}
+	c.scheduleSave()
+}
+
+// Check if a contract call is a lookup of a contract address or ABI in RocketStorage at the latest block
+func (c *chainMetadataCache) isStorageLookup(call ethereum.CallMsg, blockNumber *big.Int) bool {
+	if blockNumber != nil || call.To == nil || *call.To != c.metadata.StorageAddress || len(call.Data) != 36 {
+		return false
+	}
+	selector := call.Data[:4]
+	return bytes.Equal(selector, getAddressSelector) || bytes.Equal(selector, getStringSelector)
+}
+
+// Check if a RocketStorage lookup came back empty, which happens for contracts that don't exist (yet)
+func isEmptyStorageValue(callData []byte, result []byte) bool {
+	if bytes.Equal(callData[:4], getAddressSelector) {
+		return len(result) == 0 || new(big.Int).SetBytes(result).Sign() == 0
+	}
+
+	// Strings are encoded as an offset, a length, and the padded contents
+	return len(result) <= 64
+}
+
+// Save the cache shortly, unless a save is already scheduled; the caller must hold the lock
+func (c *chainMetadataCache) scheduleSave() {
+	if c.saveScheduled {
+		return
+	}
+	c.saveScheduled = true
+	time.AfterFunc(chainMetadataSaveDelay, func() {
+		// The cache is best-effort, so it's fine if the process doesn't have permission to write it
+		_ = c.save()
+	})
+}
+
+// Save the cache to disk, replacing the old file in one step so other processes never read a partial one
+func (c *chainMetadataCache) save() error {
+	c.lock.Lock()
+	c.saveScheduled = false
+	data, err := json.Marshal(c.metadata)
+	c.lock.Unlock()
+	if err != nil {
+		return fmt.Errorf("error serializing chain metadata: %w", err)
+	}
+
+	folder := filepath.Dir(c.path)
+	err = os.MkdirAll(folder, 0755)
+	if err != nil {
+		return fmt.Errorf("error creating chain metadata folder: %w", err)
+	}
+	file, err := os.CreateTemp(folder, filepath.Base(c.path)+".*")
+	if err != nil {
+		return fmt.Errorf("error creating temporary chain metadata file: %w", err)
+	}
+	tempPath := file.Name()
+	_, err = file.Write(data)
+	file.Close()
+	if err == nil {
+		err = os.Chmod(tempPath, 0644)
+	}
+	if err == nil {
+		err = os.Rename(tempPath, c.path)
+	}
+	if err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("error saving chain metadata to %s: %w", c.path, err)
+	}
+	return nil
+}
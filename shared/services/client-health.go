@@ -0,0 +1,190 @@
+package services
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings for client health tracking
+const (
+	// The number of recent calls that failure rates and latencies are measured over
+	clientHealthWindowSize int = 100
+
+	// The number of consecutive failures that open a client's circuit breaker
+	clientBreakerFailureThreshold int = 5
+
+	// How long a client's circuit breaker stays open before a trial call is let through
+	clientBreakerCooldown time.Duration = time.Minute
+)
+
+// The state of a client's circuit breaker
+const (
+	CircuitState_Closed   string = "closed"
+	CircuitState_Open     string = "open"
+	CircuitState_HalfOpen string = "half-open"
+)
+
+// Tracks the failure rate and latency of calls to a single client endpoint, and trips a circuit breaker
+// when it fails repeatedly so callers stop sending it work until it has had time to recover.
+type clientHealth struct {
+	samples             []clientHealthSample
+	next                int
+	consecutiveFailures int
+	state               string
+	openedAt            time.Time
+	trialInFlight       bool
+	lock                sync.Mutex
+}
+
+// The health of a single client, for reporting
+type ClientHealthReport struct {
+	Name   string
+	Status api.ClientHealthStatus
+}
+
+// The result of a single call
+type clientHealthSample struct {
+	failed  bool
+	latency time.Duration
+}
+
+// Creates a new health tracker with a closed circuit
+func newClientHealth() *clientHealth {
+	return &clientHealth{
+		samples: make([]clientHealthSample, 0, clientHealthWindowSize),
+		state:   CircuitState_Closed,
+	}
+}
+
+// Check if a call can be sent to the client.
+// When the breaker is open this refuses calls until the cooldown has passed, then lets a single trial call through.
+func (h *clientHealth) allow() bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	switch h.state {
+	case CircuitState_Open:
+		if time.Since(h.openedAt) < clientBreakerCooldown {
+			return false
+		}
+		h.state = CircuitState_HalfOpen
+		h.trialInFlight = true
+		return true
+	case CircuitState_HalfOpen:
+		if h.trialInFlight {
+			return false
+		}
+		h.trialInFlight = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Check if the breaker is letting calls through, without claiming the trial call
+func (h *clientHealth) isAvailable() bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	switch h.state {
+	case CircuitState_Open:
+		return time.Since(h.openedAt) >= clientBreakerCooldown
+	case CircuitState_HalfOpen:
+		return !h.trialInFlight
+	default:
+		return true
+	}
+}
+
+// Record the result of a call.
+// Only errors that mean the endpoint itself is unhealthy count as failures; errors the client returned on purpose don't.
+func (h *clientHealth) record(err error, latency time.Duration) {
+	h.addSample(err != nil && isEndpointFailure(err), latency)
+}
+
+// Record the result of an explicit status check
+func (h *clientHealth) recordProbe(working bool, latency time.Duration) {
+	h.addSample(!working, latency)
+}
+
+// Add a sample and update the breaker
+func (h *clientHealth) addSample(failed bool, latency time.Duration) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	sample := clientHealthSample{
+		failed:  failed,
+		latency: latency,
+	}
+	if len(h.samples) < clientHealthWindowSize {
+		h.samples = append(h.samples, sample)
+	} else {
+		h.samples[h.next] = sample
+	}
+	h.next = (h.next + 1) % clientHealthWindowSize
+	h.trialInFlight = false
+
+	if !failed {
+		h.consecutiveFailures = 0
+		h.state = CircuitState_Closed
+		return
+	}
+
+	h.consecutiveFailures++
+	if h.state == CircuitState_HalfOpen || h.consecutiveFailures >= clientBreakerFailureThreshold {
+		h.state = CircuitState_Open
+		h.openedAt = time.Now()
+	}
+}
+
+// Get a summary of the client's health
+func (h *clientHealth) getStatus() api.ClientHealthStatus {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	status := api.ClientHealthStatus{
+		Requests:            len(h.samples),
+		ConsecutiveFailures: h.consecutiveFailures,
+		CircuitState:        h.state,
+	}
+	if h.state == CircuitState_Open && time.Since(h.openedAt) >= clientBreakerCooldown {
+		status.CircuitState = CircuitState_HalfOpen
+	}
+	if len(h.samples) == 0 {
+		return status
+	}
+
+	failures := 0
+	var totalLatency time.Duration
+	for _, sample := range h.samples {
+		if sample.failed {
+			failures++
+		}
+		totalLatency += sample.latency
+	}
+	status.FailureRate = float64(failures) / float64(len(h.samples))
+	status.AverageLatencyMs = float64(totalLatency.Milliseconds()) / float64(len(h.samples))
+	return status
+}
+
+// Check if an error means the endpoint itself is unreachable or failing, rather than rejecting a specific request
+func isEndpointFailure(err error) bool {
+	message := err.Error()
+	for _, marker := range []string{
+		"dial tcp",
+		"connection refused",
+		"connection reset",
+		"context deadline exceeded",
+		"Client.Timeout exceeded",
+		"i/o timeout",
+		"EOF",
+		"HTTP status 5",
+	} {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
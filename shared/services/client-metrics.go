@@ -0,0 +1,161 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	netutils "github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+// The latency buckets of client requests, in seconds
+var clientRequestBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// Path segments that identify a specific object (slots, validator indices, roots and pubkeys), which are left out of
+// the method label so it only names the endpoint
+var clientPathIdPattern = regexp.MustCompile(`^(\d+|0x[0-9a-fA-F]+)$`)
+
+// Records every request sent to the Execution and Beacon clients, by layer, client and method
+type ClientRequestMetrics struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+}
+
+var clientRequestMetrics = newClientRequestMetrics()
+
+// Create the request metrics
+func newClientRequestMetrics() *ClientRequestMetrics {
+	labels := []string{"layer", "client", "method"}
+	return &ClientRequestMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "rocketpool",
+			Subsystem: "client",
+			Name:      "requests_total",
+			Help:      "The number of requests sent to the client",
+		}, labels),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "rocketpool",
+			Subsystem: "client",
+			Name:      "request_errors_total",
+			Help:      "The number of requests to the client that couldn't be sent or got a server error",
+		}, labels),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "rocketpool",
+			Subsystem: "client",
+			Name:      "request_duration_seconds",
+			Help:      "How long the client took to respond to requests",
+			Buckets:   clientRequestBuckets,
+		}, labels),
+	}
+}
+
+// Get the metrics of the requests sent to the clients, so the daemons can export them
+func GetClientRequestMetrics() *ClientRequestMetrics {
+	return clientRequestMetrics
+}
+
+// Write metric descriptions to the Prometheus channel
+func (m *ClientRequestMetrics) Describe(channel chan<- *prometheus.Desc) {
+	m.requests.Describe(channel)
+	m.errors.Describe(channel)
+	m.latency.Describe(channel)
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (m *ClientRequestMetrics) Collect(channel chan<- prometheus.Metric) {
+	m.requests.Collect(channel)
+	m.errors.Collect(channel)
+	m.latency.Collect(channel)
+}
+
+// Record a request
+func (m *ClientRequestMetrics) observe(layer string, client string, method string, latency time.Duration, failed bool) {
+	m.requests.WithLabelValues(layer, client, method).Inc()
+	if failed {
+		m.errors.WithLabelValues(layer, client, method).Inc()
+	}
+	m.latency.WithLabelValues(layer, client, method).Observe(latency.Seconds())
+}
+
+// An HTTP transport that records the requests sent through it
+type instrumentedTransport struct {
+	layer     string
+	client    string
+	getMethod func(request *http.Request) string
+	next      http.RoundTripper
+}
+
+// Create a transport on the shared connection pool that records the requests to an Execution client
+func newExecutionClientTransport(client string) http.RoundTripper {
+	return &instrumentedTransport{
+		layer:     "execution",
+		client:    client,
+		getMethod: getJsonRpcMethod,
+		next:      netutils.GetClientTransport(),
+	}
+}
+
+// Create a transport on the shared connection pool that records the requests to a Beacon client
+func newBeaconClientTransport(client string) http.RoundTripper {
+	return &instrumentedTransport{
+		layer:     "beacon",
+		client:    client,
+		getMethod: getBeaconApiMethod,
+		next:      netutils.GetClientTransport(),
+	}
+}
+
+// Send a request and record it
+func (t *instrumentedTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	method := t.getMethod(request)
+	start := time.Now()
+	response, err := t.next.RoundTrip(request)
+	failed := err != nil || response.StatusCode >= http.StatusInternalServerError
+	clientRequestMetrics.observe(t.layer, t.client, method, time.Since(start), failed)
+	return response, err
+}
+
+// Get the JSON-RPC method of an Execution client request
+func getJsonRpcMethod(request *http.Request) string {
+	if request.GetBody == nil {
+		return "unknown"
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return "unknown"
+	}
+	defer body.Close()
+	bodyBytes, err := io.ReadAll(body)
+	if err != nil {
+		return "unknown"
+	}
+	bodyBytes = bytes.TrimSpace(bodyBytes)
+	if len(bodyBytes) > 0 && bodyBytes[0] == '[' {
+		return "batch"
+	}
+	var message struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal(bodyBytes, &message); err != nil || message.Method == "" {
+		return "unknown"
+	}
+	return message.Method
+}
+
+// Get the Beacon API endpoint of a Beacon client request, without the IDs in its path
+func getBeaconApiMethod(request *http.Request) string {
+	segments := strings.Split(strings.Trim(request.URL.Path, "/"), "/")
+	for i, segment := range segments {
+		if clientPathIdPattern.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return request.Method + " /" + strings.Join(segments, "/")
+}
@@ -50,6 +50,7 @@ func NewBesuConfig(cfg *RocketPoolConfig) *BesuConfig {
 		UnsupportedCommonParams: []string{},
 
 		CompatibleConsensusClients: []config.ConsensusClient{
+			config.ConsensusClient_Grandine,
 			config.ConsensusClient_Lighthouse,
 			config.ConsensusClient_Lodestar,
 			config.ConsensusClient_Nimbus,
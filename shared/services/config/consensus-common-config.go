@@ -7,6 +7,7 @@ import (
 // Param IDs
 const GraffitiID string = "graffiti"
 const CheckpointSyncUrlID string = "checkpointSyncUrl"
+const CheckpointSyncVerificationUrlID string = "checkpointSyncVerificationUrl"
 const P2pPortID string = "p2pPort"
 const ApiPortID string = "apiPort"
 const OpenApiPortID string = "openApiPort"
@@ -15,6 +16,7 @@ const DoppelgangerDetectionID string = "doppelgangerDetection"
 // Defaults
 const defaultGraffiti string = ""
 const defaultCheckpointSyncProvider string = ""
+const defaultCheckpointSyncVerificationUrl string = ""
 const defaultP2pPort uint16 = 9001
 const defaultBnApiPort uint16 = 5052
 const defaultOpenBnApiPort string = string(config.RPC_Closed)
@@ -33,6 +35,9 @@ type ConsensusCommonConfig struct {
 	// The checkpoint sync URL if used
 	CheckpointSyncProvider config.Parameter `yaml:"checkpointSyncProvider,omitempty"`
 
+	// A second Beacon node used to cross-check the checkpoint sync provider
+	CheckpointSyncVerificationUrl config.Parameter `yaml:"checkpointSyncVerificationUrl,omitempty"`
+
 	// The port to use for gossip traffic
 	P2pPort config.Parameter `yaml:"p2pPort,omitempty"`
 
@@ -80,6 +85,20 @@ func NewConsensusCommonConfig(cfg *RocketPoolConfig) *ConsensusCommonConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		CheckpointSyncVerificationUrl: config.Parameter{
+			ID:   CheckpointSyncVerificationUrlID,
+			Name: "Checkpoint Sync Verification URL",
+			Description: "The URL of a second Beacon node to cross-check your Checkpoint Sync URL against before your client syncs from it.\n" +
+				"The finalized state served by your checkpoint sync provider must match the one served by this node, which protects you from a compromised provider.\n" +
+				"Leave this blank to use your fallback Consensus client if you have one configured.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultCheckpointSyncVerificationUrl},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
 		P2pPort: config.Parameter{
 			ID:                   P2pPortID,
 			Name:                 "P2P Port",
@@ -136,6 +155,7 @@ func (cfg *ConsensusCommonConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.Graffiti,
 		&cfg.CheckpointSyncProvider,
+		&cfg.CheckpointSyncVerificationUrl,
 		&cfg.P2pPort,
 		&cfg.ApiPort,
 		&cfg.OpenApiPort,
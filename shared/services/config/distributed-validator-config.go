@@ -0,0 +1,178 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Constants
+const (
+	charonTag              string = "obolnetwork/charon:v1.1.1"
+	ssvTag                 string = "ssvlabs/ssv-node:v2.0.0"
+	charonValidatorApiPort uint16 = 3600
+	vcApiEndpointEnvVar    string = "VC_CC_API_ENDPOINT"
+)
+
+// Configuration for running minipool validators as distributed validators
+type DistributedValidatorConfig struct {
+	Title string `yaml:"-"`
+
+	// The middleware that runs the distributed validators
+	Mode config.Parameter `yaml:"mode,omitempty"`
+
+	// The Docker Hub tag for Charon
+	CharonContainerTag config.Parameter `yaml:"charonContainerTag,omitempty"`
+
+	// Charon's P2P port
+	CharonP2pPort config.Parameter `yaml:"charonP2pPort,omitempty"`
+
+	// Custom command line flags for Charon
+	CharonAdditionalFlags config.Parameter `yaml:"charonAdditionalFlags,omitempty"`
+
+	// The Docker Hub tag for the SSV node
+	SsvContainerTag config.Parameter `yaml:"ssvContainerTag,omitempty"`
+
+	// The SSV node's P2P TCP port
+	SsvP2pTcpPort config.Parameter `yaml:"ssvP2pTcpPort,omitempty"`
+
+	// The SSV node's P2P UDP port
+	SsvP2pUdpPort config.Parameter `yaml:"ssvP2pUdpPort,omitempty"`
+
+	// Custom command line flags for the SSV node
+	SsvAdditionalFlags config.Parameter `yaml:"ssvAdditionalFlags,omitempty"`
+
+	// The parent config
+	parentConfig *RocketPoolConfig `yaml:"-"`
+}
+
+// Generates a new distributed validator configuration
+func NewDistributedValidatorConfig(cfg *RocketPoolConfig) *DistributedValidatorConfig {
+	return &DistributedValidatorConfig{
+		Title: "Distributed Validator Settings",
+
+		Mode: config.Parameter{
+			ID:                   "mode",
+			Name:                 "Middleware",
+			Description:          "Choose the distributed validator network that runs your distributed validators.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.DistributedValidatorMode_Obol},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator, config.ContainerID_Charon, config.ContainerID_Ssv},
+			EnvironmentVariables: []string{"DV_MODE"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Obol",
+				Description: "Run a Charon node as part of an Obol cluster. Your Validator client signs with its share of each distributed validator key, and Charon coordinates the signatures with the other members of the cluster.\nPut your cluster's `.charon` folder into the `distributed-validators/charon` folder in your Smartnode's data folder.",
+				Value:       config.DistributedValidatorMode_Obol,
+			}, {
+				Name:        "SSV",
+				Description: "Run an SSV node as an operator on the SSV network. The SSV node signs with its share of each distributed validator key on its own, so your Validator client doesn't attest for them.\nPut your operator's encrypted private key and its password into the `distributed-validators/ssv` folder in your Smartnode's data folder.",
+				Value:       config.DistributedValidatorMode_Ssv,
+			}},
+		},
+
+		CharonContainerTag: config.Parameter{
+			ID:                   "charonContainerTag",
+			Name:                 "Charon Container Tag",
+			Description:          "The tag name of the Charon container you want to use on Docker Hub.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: charonTag},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Charon},
+			EnvironmentVariables: []string{"CHARON_CONTAINER_TAG"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
+
+		CharonP2pPort: config.Parameter{
+			ID:                   "charonP2pPort",
+			Name:                 "Charon P2P Port",
+			Description:          "The port Charon uses to communicate with the other members of your cluster. It must be reachable from the internet.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: uint16(3610)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Charon},
+			EnvironmentVariables: []string{"CHARON_P2P_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		CharonAdditionalFlags: config.Parameter{
+			ID:                   "charonAdditionalFlags",
+			Name:                 "Charon Additional Flags",
+			Description:          "Additional custom command line flags you want to pass to Charon, to take advantage of other settings that the Smartnode's configuration doesn't cover.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Charon},
+			EnvironmentVariables: []string{"CHARON_ADDITIONAL_FLAGS"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SsvContainerTag: config.Parameter{
+			ID:                   "ssvContainerTag",
+			Name:                 "SSV Container Tag",
+			Description:          "The tag name of the SSV node container you want to use on Docker Hub.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ssvTag},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Ssv},
+			EnvironmentVariables: []string{"SSV_CONTAINER_TAG"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
+
+		SsvP2pTcpPort: config.Parameter{
+			ID:                   "ssvP2pTcpPort",
+			Name:                 "SSV P2P TCP Port",
+			Description:          "The TCP port the SSV node uses to communicate with other operators. It must be reachable from the internet.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: uint16(13001)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Ssv},
+			EnvironmentVariables: []string{"SSV_P2P_TCP_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SsvP2pUdpPort: config.Parameter{
+			ID:                   "ssvP2pUdpPort",
+			Name:                 "SSV P2P UDP Port",
+			Description:          "The UDP port the SSV node uses to discover other operators. It must be reachable from the internet.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: uint16(12001)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Ssv},
+			EnvironmentVariables: []string{"SSV_P2P_UDP_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SsvAdditionalFlags: config.Parameter{
+			ID:                   "ssvAdditionalFlags",
+			Name:                 "SSV Additional Flags",
+			Description:          "Additional custom command line flags you want to pass to the SSV node, to take advantage of other settings that the Smartnode's configuration doesn't cover.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Ssv},
+			EnvironmentVariables: []string{"SSV_ADDITIONAL_FLAGS"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		parentConfig: cfg,
+	}
+}
+
+// Get the parameters for this config
+func (cfg *DistributedValidatorConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Mode,
+		&cfg.CharonContainerTag,
+		&cfg.CharonP2pPort,
+		&cfg.CharonAdditionalFlags,
+		&cfg.SsvContainerTag,
+		&cfg.SsvP2pTcpPort,
+		&cfg.SsvP2pUdpPort,
+		&cfg.SsvAdditionalFlags,
+	}
+}
+
+// The the title for the config
+func (cfg *DistributedValidatorConfig) GetConfigTitle() string {
+	return cfg.Title
+}
@@ -13,6 +13,9 @@ type FallbackNormalConfig struct {
 
 	// The URL of the Beacon Node HTTP endpoint
 	CcHttpUrl config.Parameter `yaml:"ccHttpUrl,omitempty"`
+
+	// The URLs of any extra Beacon Node HTTP endpoints to try after the fallback, in priority order
+	AdditionalCcHttpUrls config.Parameter `yaml:"additionalCcHttpUrls,omitempty"`
 }
 
 // Configuration for fallback Prysm
@@ -25,6 +28,9 @@ type FallbackPrysmConfig struct {
 	// The URL of the Beacon Node HTTP endpoint
 	CcHttpUrl config.Parameter `yaml:"ccHttpUrl,omitempty"`
 
+	// The URLs of any extra Beacon Node HTTP endpoints to try after the fallback, in priority order
+	AdditionalCcHttpUrls config.Parameter `yaml:"additionalCcHttpUrls,omitempty"`
+
 	// The URL of the JSON-RPC endpoint for the Validator client
 	JsonRpcUrl config.Parameter `yaml:"jsonRpcUrl,omitempty"`
 }
@@ -57,6 +63,18 @@ func NewFallbackNormalConfig(cfg *RocketPoolConfig) *FallbackNormalConfig {
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
+
+		AdditionalCcHttpUrls: config.Parameter{
+			ID:                   "additionalCcHttpUrls",
+			Name:                 "Additional Beacon Node URLs",
+			Description:          "A comma-separated list of extra Beacon API endpoints for the Smartnode to fail over to, in order of priority, if both your primary and fallback Consensus clients are unavailable. The Smartnode periodically re-checks unavailable clients and switches back to the highest-priority one that is healthy again.\n\nThese are only used by the Smartnode itself; your Validator client will still only use the primary and fallback clients.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
 	}
 }
 
@@ -89,6 +107,18 @@ func NewFallbackPrysmConfig(cfg *RocketPoolConfig) *FallbackPrysmConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		AdditionalCcHttpUrls: config.Parameter{
+			ID:                   "additionalCcHttpUrls",
+			Name:                 "Additional Beacon Node URLs",
+			Description:          "A comma-separated list of extra Beacon API endpoints for the Smartnode to fail over to, in order of priority, if both your primary and fallback Consensus clients are unavailable. The Smartnode periodically re-checks unavailable clients and switches back to the highest-priority one that is healthy again.\n\nThese are only used by the Smartnode itself; your Validator client will still only use the primary and fallback clients.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
 		JsonRpcUrl: config.Parameter{
 			ID:                   "jsonRpcUrl",
 			Name:                 "Beacon Node JSON-RPC URL",
@@ -108,6 +138,7 @@ func (cfg *FallbackNormalConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.EcHttpUrl,
 		&cfg.CcHttpUrl,
+		&cfg.AdditionalCcHttpUrls,
 	}
 }
 
@@ -116,6 +147,7 @@ func (cfg *FallbackPrysmConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.EcHttpUrl,
 		&cfg.CcHttpUrl,
+		&cfg.AdditionalCcHttpUrls,
 		&cfg.JsonRpcUrl,
 	}
 }
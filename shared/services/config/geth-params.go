@@ -48,6 +48,7 @@ func NewGethConfig(cfg *RocketPoolConfig) *GethConfig {
 		UnsupportedCommonParams: []string{},
 
 		CompatibleConsensusClients: []config.ConsensusClient{
+			config.ConsensusClient_Grandine,
 			config.ConsensusClient_Lighthouse,
 			config.ConsensusClient_Lodestar,
 			config.ConsensusClient_Nimbus,
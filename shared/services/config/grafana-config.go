@@ -19,6 +19,12 @@ type GrafanaConfig struct {
 
 	// The Docker Hub tag for Grafana
 	ContainerTag config.Parameter `yaml:"containerTag,omitempty"`
+
+	// Toggle for pushing the Smartnode's dashboards and alert rules to Grafana when the service starts
+	ProvisionDashboards config.Parameter `yaml:"provisionDashboards,omitempty"`
+
+	// The service account token used to provision Grafana
+	ApiToken config.Parameter `yaml:"apiToken,omitempty"`
 }
 
 // Generates a new Grafana config
@@ -49,6 +55,30 @@ func NewGrafanaConfig(cfg *RocketPoolConfig) *GrafanaConfig {
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   true,
 		},
+
+		ProvisionDashboards: config.Parameter{
+			ID:                   "provisionDashboards",
+			Name:                 "Provision Dashboards",
+			Description:          "Push the Smartnode's dashboards and alert rules to Grafana every time the service starts, so they're updated along with the Smartnode instead of having to be imported by hand. Dashboards you've edited will be overwritten when a new version comes out; save a copy under a different name to keep your changes.\n\nThis requires a Grafana Service Account Token.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ApiToken: config.Parameter{
+			ID:                   "apiToken",
+			Name:                 "Service Account Token",
+			Description:          "The token of a Grafana service account with the Editor role, which the Smartnode uses to provision its dashboards and alert rules. You can create one in Grafana under Administration > Service accounts.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
 	}
 }
 
@@ -57,6 +87,8 @@ func (cfg *GrafanaConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.Port,
 		&cfg.ContainerTag,
+		&cfg.ProvisionDashboards,
+		&cfg.ApiToken,
 	}
 }
 
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+const (
+	grandineBnTagTest       string = "sifrai/grandine:0.4.1"
+	grandineBnTagProd       string = "sifrai/grandine:0.4.1"
+	defaultGrandineMaxPeers uint16 = 64
+
+	// The Validator Client that Grandine is paired with
+	grandineValidatorClient config.ConsensusClient = config.ConsensusClient_Lighthouse
+)
+
+// Configuration for Grandine.
+// Grandine only provides the Beacon Node; validator duties are handled by Lighthouse's Validator Client.
+type GrandineConfig struct {
+	Title string `yaml:"-"`
+
+	// The max number of P2P peers to connect to
+	MaxPeers config.Parameter `yaml:"maxPeers,omitempty"`
+
+	// Common parameters that Grandine doesn't support and should be hidden
+	UnsupportedCommonParams []string `yaml:"-"`
+
+	// The Docker Hub tag for the BN
+	BnContainerTag config.Parameter `yaml:"bnContainerTag,omitempty"`
+
+	// The Docker Hub tag for the VC
+	VcContainerTag config.Parameter `yaml:"containerTag,omitempty"`
+
+	// Custom command line flags for the BN
+	AdditionalBnFlags config.Parameter `yaml:"additionalBnFlags,omitempty"`
+
+	// Custom command line flags for the VC
+	AdditionalVcFlags config.Parameter `yaml:"additionalVcFlags,omitempty"`
+}
+
+// Generates a new Grandine configuration
+func NewGrandineConfig(cfg *RocketPoolConfig) *GrandineConfig {
+	return &GrandineConfig{
+		Title: "Grandine Settings",
+
+		MaxPeers: config.Parameter{
+			ID:                   "maxPeers",
+			Name:                 "Max Peers",
+			Description:          "The maximum number of peers your client should try to maintain. You can try lowering this if you have a low-resource system or a constrained network.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultGrandineMaxPeers},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Eth2},
+			EnvironmentVariables: []string{"BN_MAX_PEERS"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BnContainerTag: config.Parameter{
+			ID:          "bnContainerTag",
+			Name:        "Beacon Node Container Tag",
+			Description: "The tag name of the Grandine Beacon Node container you want to use on Docker Hub.",
+			Type:        config.ParameterType_String,
+			Default: map[config.Network]interface{}{
+				config.Network_Mainnet: grandineBnTagProd,
+				config.Network_Prater:  grandineBnTagTest,
+				config.Network_Devnet:  grandineBnTagTest,
+				config.Network_Holesky: grandineBnTagTest,
+			},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Eth2},
+			EnvironmentVariables: []string{"BN_CONTAINER_TAG"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
+
+		VcContainerTag: config.Parameter{
+			ID:          "containerTag",
+			Name:        "Validator Client Container Tag",
+			Description: "The tag name of the Lighthouse Validator Client container you want to use on Docker Hub. Grandine doesn't have a standalone Validator Client, so Lighthouse's is used with it.",
+			Type:        config.ParameterType_String,
+			Default: map[config.Network]interface{}{
+				config.Network_Mainnet: getLighthouseTagProd(),
+				config.Network_Prater:  getLighthouseTagTest(),
+				config.Network_Devnet:  getLighthouseTagTest(),
+				config.Network_Holesky: getLighthouseTagTest(),
+			},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator},
+			EnvironmentVariables: []string{"VC_CONTAINER_TAG"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
+
+		AdditionalBnFlags: config.Parameter{
+			ID:                   "additionalBnFlags",
+			Name:                 "Additional Beacon Client Flags",
+			Description:          "Additional custom command line flags you want to pass Grandine's Beacon Client, to take advantage of other settings that the Smartnode's configuration doesn't cover.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Eth2},
+			EnvironmentVariables: []string{"BN_ADDITIONAL_FLAGS"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AdditionalVcFlags: config.Parameter{
+			ID:                   "additionalVcFlags",
+			Name:                 "Additional Validator Client Flags",
+			Description:          "Additional custom command line flags you want to pass the Lighthouse Validator Client, to take advantage of other settings that the Smartnode's configuration doesn't cover.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator},
+			EnvironmentVariables: []string{"VC_ADDITIONAL_FLAGS"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *GrandineConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.MaxPeers,
+		&cfg.BnContainerTag,
+		&cfg.VcContainerTag,
+		&cfg.AdditionalBnFlags,
+		&cfg.AdditionalVcFlags,
+	}
+}
+
+// Get the common params that this client doesn't support
+func (cfg *GrandineConfig) GetUnsupportedCommonParams() []string {
+	return cfg.UnsupportedCommonParams
+}
+
+// Get the Docker container name of the validator client
+func (cfg *GrandineConfig) GetValidatorImage() string {
+	return cfg.VcContainerTag.Value.(string)
+}
+
+// Get the name of the client
+func (cfg *GrandineConfig) GetName() string {
+	return "Grandine"
+}
+
+// The the title for the config
+func (cfg *GrandineConfig) GetConfigTitle() string {
+	return cfg.Title
+}
+
+// Get the client whose Validator Client is run alongside the provided Consensus client
+func getValidatorClient(consensusClient config.ConsensusClient) config.ConsensusClient {
+	if consensusClient == config.ConsensusClient_Grandine {
+		return grandineValidatorClient
+	}
+	return consensusClient
+}
@@ -53,6 +53,15 @@ type MevBoostConfig struct {
 	// Aestus relay
 	AestusRelay config.Parameter `yaml:"aestusEnabled,omitempty"`
 
+	// How the daemon manages the relays based on their performance
+	RelayManagement config.Parameter `yaml:"relayManagement,omitempty"`
+
+	// How many checks in a row a relay can fail before it's dropped
+	RelayMaxFailures config.Parameter `yaml:"relayMaxFailures,omitempty"`
+
+	// The slowest a relay can respond before a check counts as failed, in milliseconds
+	RelayMaxLatency config.Parameter `yaml:"relayMaxLatency,omitempty"`
+
 	// The RPC port
 	Port config.Parameter `yaml:"port,omitempty"`
 
@@ -146,6 +155,55 @@ func NewMevBoostConfig(cfg *RocketPoolConfig) *MevBoostConfig {
 		UltrasoundRelay:         generateRelayParameter("ultrasoundEnabled", relayMap[config.MevRelayID_Ultrasound]),
 		AestusRelay:             generateRelayParameter("aestusEnabled", relayMap[config.MevRelayID_Aestus]),
 
+		RelayManagement: config.Parameter{
+			ID:                   "relayManagement",
+			Name:                 "Relay Management",
+			Description:          "Have the Smartnode track the latency, win rate, and missed slots of each enabled relay.\nIn Automatic mode, relays that fail too many checks in a row are dropped the next time the Smartnode starts, and restored once they pass that many checks in a row again.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.MevRelayManagement_Off},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Off",
+				Description: "Don't track the relays' performance.",
+				Value:       config.MevRelayManagement_Off,
+			}, {
+				Name:        "Monitor",
+				Description: "Track the relays' performance and alert you when one keeps failing, but leave them all enabled.",
+				Value:       config.MevRelayManagement_Monitor,
+			}, {
+				Name:        "Automatic",
+				Description: "Track the relays' performance and drop the ones that keep failing, as long as at least one relay is left.",
+				Value:       config.MevRelayManagement_Automatic,
+			}},
+		},
+
+		RelayMaxFailures: config.Parameter{
+			ID:                   "relayMaxFailures",
+			Name:                 "Relay Max Failures",
+			Description:          "How many checks in a row a relay can fail before it's considered unhealthy. A check fails if the relay is unreachable, responds slower than the Relay Max Latency, or delivered a block for one of your validators that didn't make it on-chain.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: uint16(3)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RelayMaxLatency: config.Parameter{
+			ID:                   "relayMaxLatency",
+			Name:                 "Relay Max Latency",
+			Description:          "The slowest a relay can respond to the Smartnode's checks, in milliseconds, before the check counts as failed.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: uint16(1000)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
 		Port: config.Parameter{
 			ID:                   "port",
 			Name:                 "Port",
@@ -225,6 +283,9 @@ func (cfg *MevBoostConfig) GetParameters() []*config.Parameter {
 		&cfg.EdenRelay,
 		&cfg.UltrasoundRelay,
 		&cfg.AestusRelay,
+		&cfg.RelayManagement,
+		&cfg.RelayMaxFailures,
+		&cfg.RelayMaxLatency,
 		&cfg.Port,
 		&cfg.OpenRpcPort,
 		&cfg.ContainerTag,
@@ -338,11 +399,19 @@ func (cfg *MevBoostConfig) GetEnabledMevRelays() []config.MevRelay {
 }
 
 func (cfg *MevBoostConfig) GetRelayString() string {
+	return cfg.getRelayString(nil)
+}
+
+// Get the relay string without the relays that were dropped by relay management
+func (cfg *MevBoostConfig) getRelayString(dropped map[config.MevRelayID]bool) string {
 	relayUrls := []string{}
 	currentNetwork := cfg.parentConfig.Smartnode.Network.Value.(config.Network)
 
 	relays := cfg.GetEnabledMevRelays()
 	for _, relay := range relays {
+		if dropped[relay.ID] {
+			continue
+		}
 		relayUrls = append(relayUrls, relay.Urls[currentNetwork])
 	}
 
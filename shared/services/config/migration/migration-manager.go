@@ -2,76 +2,180 @@ package migration
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/go-version"
 )
 
+// A step that upgrades a config made by the provided Smartnode version or older to the layout of the next version
 type ConfigUpgrader struct {
 	Version     *version.Version
+	Description string
 	UpgradeFunc func(serializedConfig map[string]map[string]string) error
 }
 
-func UpdateConfig(serializedConfig map[string]map[string]string) error {
+// A single setting that was changed by an upgrade step
+type SettingChange struct {
+	Section  string `json:"section"`
+	Setting  string `json:"setting"`
+	OldValue string `json:"oldValue"`
+	NewValue string `json:"newValue"`
+	Added    bool   `json:"added"`
+	Removed  bool   `json:"removed"`
+}
 
-	// Get the config's version
-	configVersion, err := getVersionFromConfig(serializedConfig)
+// The changes made by an upgrade step
+type UpgradeStep struct {
+	Version     string          `json:"version"`
+	Description string          `json:"description"`
+	Changes     []SettingChange `json:"changes"`
+}
+
+// A description of everything an upgrade changed
+type UpgradeReport struct {
+	FromVersion string        `json:"fromVersion"`
+	Steps       []UpgradeStep `json:"steps"`
+}
+
+// The registered upgraders, sorted by version
+var upgraders = []ConfigUpgrader{}
+
+// Register an upgrader for configs made by the provided Smartnode version or older
+func registerUpgrader(versionString string, description string, upgradeFunc func(serializedConfig map[string]map[string]string) error) {
+	upgraderVersion, err := parseVersion(versionString)
 	if err != nil {
-		return err
+		panic(err)
 	}
+	upgraders = append(upgraders, ConfigUpgrader{
+		Version:     upgraderVersion,
+		Description: description,
+		UpgradeFunc: upgradeFunc,
+	})
+	sort.SliceStable(upgraders, func(i, j int) bool {
+		return upgraders[i].Version.LessThan(upgraders[j].Version)
+	})
+}
 
-	// Create versions
-	v131, err := parseVersion("1.3.1")
+// Upgrade a serialized config to the latest layout
+func UpdateConfig(serializedConfig map[string]map[string]string) error {
+	_, err := UpgradeConfig(serializedConfig)
+	return err
+}
+
+// Upgrade a serialized config to the latest layout, and report what changed.
+// The upgrade steps are applied to a copy, so the config is left untouched if any of them fail.
+func UpgradeConfig(serializedConfig map[string]map[string]string) (*UpgradeReport, error) {
+	upgradedConfig, report, err := runUpgraders(serializedConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	v151, err := parseVersion("1.5.1")
-	if err != nil {
-		return err
+
+	for section := range serializedConfig {
+		if _, exists := upgradedConfig[section]; !exists {
+			delete(serializedConfig, section)
+		}
 	}
-	v198, err := parseVersion("1.9.8")
-	if err != nil {
-		return err
+	for section, settings := range upgradedConfig {
+		serializedConfig[section] = settings
 	}
+	return report, nil
+}
 
-	// Create the collection of upgraders
-	upgraders := []ConfigUpgrader{
-		{
-			Version:     v131,
-			UpgradeFunc: upgradeFromV131,
-		}, {
-			Version:     v151,
-			UpgradeFunc: upgradeFromV151,
-		}, {
-			Version:     v198,
-			UpgradeFunc: upgradeFromV198,
-		},
-	}
+// Report what upgrading a serialized config would change, without modifying it
+func PlanUpgrade(serializedConfig map[string]map[string]string) (*UpgradeReport, error) {
+	_, report, err := runUpgraders(serializedConfig)
+	return report, err
+}
 
-	// Find the index of the provided config's version
-	targetIndex := -1
-	for i, upgrader := range upgraders {
-		if configVersion.LessThanOrEqual(upgrader.Version) {
-			targetIndex = i
+// Check if an upgrade didn't change anything
+func (r *UpgradeReport) IsEmpty() bool {
+	for _, step := range r.Steps {
+		if len(step.Changes) > 0 {
+			return false
 		}
 	}
+	return true
+}
+
+// Apply every upgrader that covers the config's version to a copy of it, in order
+func runUpgraders(serializedConfig map[string]map[string]string) (map[string]map[string]string, *UpgradeReport, error) {
+
+	// Get the config's version
+	configVersion, err := getVersionFromConfig(serializedConfig)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	// If there are no upgrades to apply, return
-	if targetIndex == -1 {
-		return nil
+	report := &UpgradeReport{
+		FromVersion: configVersion.String(),
+		Steps:       []UpgradeStep{},
 	}
+	upgradedConfig := copyConfig(serializedConfig)
+	for _, upgrader := range upgraders {
+		if configVersion.GreaterThan(upgrader.Version) {
+			continue
+		}
 
-	// If there are upgrades, start at the first applicable index and apply them all in series
-	for i := targetIndex; i < len(upgraders); i++ {
-		upgrader := upgraders[i]
-		err = upgrader.UpgradeFunc(serializedConfig)
+		previousConfig := copyConfig(upgradedConfig)
+		err = upgrader.UpgradeFunc(upgradedConfig)
 		if err != nil {
-			return fmt.Errorf("error applying upgrade for config version %s: %w", upgrader.Version.String(), err)
+			return nil, nil, fmt.Errorf("error applying upgrade for config version %s: %w", upgrader.Version.String(), err)
+		}
+		report.Steps = append(report.Steps, UpgradeStep{
+			Version:     upgrader.Version.String(),
+			Description: upgrader.Description,
+			Changes:     getChanges(previousConfig, upgradedConfig),
+		})
+	}
+
+	return upgradedConfig, report, nil
+
+}
+
+// Get the settings that differ between two serialized configs
+func getChanges(oldConfig map[string]map[string]string, newConfig map[string]map[string]string) []SettingChange {
+	changes := []SettingChange{}
+	for section, newSettings := range newConfig {
+		oldSettings := oldConfig[section]
+		for setting, newValue := range newSettings {
+			oldValue, exists := oldSettings[setting]
+			if !exists {
+				changes = append(changes, SettingChange{Section: section, Setting: setting, NewValue: newValue, Added: true})
+			} else if oldValue != newValue {
+				changes = append(changes, SettingChange{Section: section, Setting: setting, OldValue: oldValue, NewValue: newValue})
+			}
+		}
+	}
+	for section, oldSettings := range oldConfig {
+		newSettings := newConfig[section]
+		for setting, oldValue := range oldSettings {
+			if _, exists := newSettings[setting]; !exists {
+				changes = append(changes, SettingChange{Section: section, Setting: setting, OldValue: oldValue, Removed: true})
+			}
 		}
 	}
 
-	return nil
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Section != changes[j].Section {
+			return changes[i].Section < changes[j].Section
+		}
+		return changes[i].Setting < changes[j].Setting
+	})
+	return changes
+}
 
+// Create a deep copy of a serialized config
+func copyConfig(serializedConfig map[string]map[string]string) map[string]map[string]string {
+	configCopy := make(map[string]map[string]string, len(serializedConfig))
+	for section, settings := range serializedConfig {
+		settingsCopy := make(map[string]string, len(settings))
+		for setting, value := range settings {
+			settingsCopy[setting] = value
+		}
+		configCopy[section] = settingsCopy
+	}
+	return configCopy
 }
 
 // Get the Smartnode version that the given config was built with
@@ -2,6 +2,10 @@ package migration
 
 import "fmt"
 
+func init() {
+	registerUpgrader("1.3.1", "Move the common Execution client settings out of the Geth section", upgradeFromV131)
+}
+
 func upgradeFromV131(serializedConfig map[string]map[string]string) error {
 	// v1.3.1 had some of the common EC parameters stored inside the Geth config
 	gethSettings, exists := serializedConfig["geth"]
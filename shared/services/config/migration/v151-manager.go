@@ -2,6 +2,10 @@ package migration
 
 import "fmt"
 
+func init() {
+	registerUpgrader("1.5.1", "Rename the Nimbus additional flags to additional Beacon Node flags", upgradeFromV151)
+}
+
 func upgradeFromV151(serializedConfig map[string]map[string]string) error {
 	// v1.5.1 had the Nimbus BN additional flags named differently
 	nimbusSettings, exists := serializedConfig["nimbus"]
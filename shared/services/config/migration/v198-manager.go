@@ -4,6 +4,10 @@ import (
 	"github.com/rocket-pool/smartnode/shared/types/config"
 )
 
+func init() {
+	registerUpgrader("1.9.8", "Convert the open RPC port settings from booleans to port modes", upgradeFromV198)
+}
+
 func upgradeFromV198(serializedConfig map[string]map[string]string) error {
 	// v1.9.8 had the BN API port mode as a boolean
 	if err := updateRPCPortConfig(serializedConfig, "consensusCommon", "openApiPort"); err != nil {
@@ -1,11 +1,24 @@
 package config
 
 import (
+	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/rocket-pool/smartnode/shared/types/config"
 )
 
+// The host the clients listen on when they're run as native services
+const nativeClientHost string = "127.0.0.1"
+
+// Environment variables that only apply to the Docker containers
+var dockerOnlyEnvVars = map[string]bool{
+	"SMARTNODE_IMAGE":         true,
+	"EC_STOP_SIGNAL":          true,
+	"EXPORTER_ROOTFS_COMMAND": true,
+	"EXPORTER_ROOTFS_VOLUME":  true,
+}
+
 // Configuration for Native mode
 type NativeConfig struct {
 	Title string `yaml:"-"`
@@ -13,6 +26,9 @@ type NativeConfig struct {
 	// The URL of the EC HTTP endpoint
 	EcHttpUrl config.Parameter `yaml:"ecHttpUrl,omitempty"`
 
+	// The URL of the EC websocket endpoint
+	EcWsUrl config.Parameter `yaml:"ecWsUrl,omitempty"`
+
 	// The selected CC
 	ConsensusClient config.Parameter `yaml:"consensusClient,omitempty"`
 
@@ -44,6 +60,18 @@ func NewNativeConfig(cfg *RocketPoolConfig) *NativeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		EcWsUrl: config.Parameter{
+			ID:                   "ecWsUrl",
+			Name:                 "Execution Client Websocket URL",
+			Description:          "The URL of the websocket RPC endpoint for your Execution client (e.g. ws://localhost:8546). This is optional, and only used if you've enabled the EC websocket in the Smartnode settings.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
 		ConsensusClient: config.Parameter{
 			ID:                   "consensusClient",
 			Name:                 "Consensus Client",
@@ -120,6 +148,7 @@ func NewNativeConfig(cfg *RocketPoolConfig) *NativeConfig {
 func (cfg *NativeConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.EcHttpUrl,
+		&cfg.EcWsUrl,
 		&cfg.ConsensusClient,
 		&cfg.CcHttpUrl,
 		&cfg.ValidatorRestartCommand,
@@ -139,3 +168,29 @@ func getDefaultValidatorStopCommand(config *RocketPoolConfig) string {
 func (cfg *NativeConfig) GetConfigTitle() string {
 	return cfg.Title
 }
+
+// Generates the environment variables for running the clients as native services instead of Docker containers.
+// The clients get the same settings they would have in Docker, but reach each other over localhost.
+func (cfg *RocketPoolConfig) GenerateNativeEnvironmentVariables() map[string]string {
+	envVars := cfg.GenerateEnvironmentVariables()
+	envVars[FeeRecipientFileEnvVar] = NativeFeeRecipientFilename
+
+	for name, value := range envVars {
+		// Remove the settings that only apply to Docker
+		if dockerOnlyEnvVars[name] || strings.HasSuffix(name, "_CONTAINER_TAG") || strings.Contains(name, "_OPEN_") {
+			delete(envVars, name)
+			continue
+		}
+
+		// Point the endpoints at localhost instead of the containers
+		for _, containerName := range []string{Eth1ContainerName, Eth2ContainerName, MevBoostContainerName} {
+			if value == containerName {
+				value = nativeClientHost
+			}
+			value = strings.ReplaceAll(value, fmt.Sprintf("://%s:", containerName), fmt.Sprintf("://%s:", nativeClientHost))
+		}
+		envVars[name] = value
+	}
+
+	return envVars
+}
@@ -61,6 +61,7 @@ func NewNethermindConfig(cfg *RocketPoolConfig) *NethermindConfig {
 		UnsupportedCommonParams: []string{},
 
 		CompatibleConsensusClients: []config.ConsensusClient{
+			config.ConsensusClient_Grandine,
 			config.ConsensusClient_Lighthouse,
 			config.ConsensusClient_Lodestar,
 			config.ConsensusClient_Nimbus,
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Constants
+const (
+	rethTagProd          string = "ghcr.io/paradigmxyz/reth:v0.1.0-alpha.10"
+	rethTagTest          string = "ghcr.io/paradigmxyz/reth:v0.1.0-alpha.10"
+	rethEventLogInterval int    = 1000
+	rethMaxPeers         uint16 = 50
+	rethStopSignal       string = "SIGINT"
+)
+
+// Configuration for Reth
+type RethConfig struct {
+	Title string `yaml:"-"`
+
+	// Common parameters that Reth doesn't support and should be hidden
+	UnsupportedCommonParams []string `yaml:"-"`
+
+	// Compatible consensus clients
+	CompatibleConsensusClients []config.ConsensusClient `yaml:"-"`
+
+	// The max number of events to query in a single event log query
+	EventLogInterval int `yaml:"-"`
+
+	// Toggle for keeping all historical state instead of running as a pruned full node
+	ArchiveMode config.Parameter `yaml:"archiveMode,omitempty"`
+
+	// Max number of P2P peers to connect to
+	MaxPeers config.Parameter `yaml:"maxPeers,omitempty"`
+
+	// The Docker Hub tag for Reth
+	ContainerTag config.Parameter `yaml:"containerTag,omitempty"`
+
+	// Custom command line flags
+	AdditionalFlags config.Parameter `yaml:"additionalFlags,omitempty"`
+}
+
+// Generates a new Reth configuration
+func NewRethConfig(cfg *RocketPoolConfig) *RethConfig {
+	return &RethConfig{
+		Title: "Reth Settings",
+
+		UnsupportedCommonParams: []string{},
+
+		CompatibleConsensusClients: []config.ConsensusClient{
+			config.ConsensusClient_Grandine,
+			config.ConsensusClient_Lighthouse,
+			config.ConsensusClient_Lodestar,
+			config.ConsensusClient_Nimbus,
+			config.ConsensusClient_Prysm,
+			config.ConsensusClient_Teku,
+		},
+
+		EventLogInterval: rethEventLogInterval,
+
+		ArchiveMode: config.Parameter{
+			ID:                   "archiveMode",
+			Name:                 "Archive Mode",
+			Description:          "By default, Reth runs as a full node and continuously prunes old state so its database stays small, which means it never needs to be pruned manually.\n\nEnable this to run Reth as an archive node instead, which keeps the state of every historical block. This requires significantly more disk space.\n\n[orange]NOTE: Changing this requires resyncing Reth from scratch with `rocketpool service resync-eth1`.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Eth1},
+			EnvironmentVariables: []string{"RETH_ARCHIVE_MODE"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		MaxPeers: config.Parameter{
+			ID:                   "maxPeers",
+			Name:                 "Max Peers",
+			Description:          "The maximum number of peers Reth should connect to. This can be lowered to improve performance on low-power systems or constrained networks. We recommend keeping it at 12 or higher.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: rethMaxPeers},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Eth1},
+			EnvironmentVariables: []string{"EC_MAX_PEERS"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ContainerTag: config.Parameter{
+			ID:          "containerTag",
+			Name:        "Container Tag",
+			Description: "The tag name of the Reth container you want to use from the GitHub Container Registry.",
+			Type:        config.ParameterType_String,
+			Default: map[config.Network]interface{}{
+				config.Network_Mainnet: rethTagProd,
+				config.Network_Prater:  rethTagTest,
+				config.Network_Devnet:  rethTagTest,
+				config.Network_Holesky: rethTagTest,
+			},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Eth1},
+			EnvironmentVariables: []string{"EC_CONTAINER_TAG"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
+
+		AdditionalFlags: config.Parameter{
+			ID:                   "additionalFlags",
+			Name:                 "Additional Flags",
+			Description:          "Additional custom command line flags you want to pass to Reth, to take advantage of other settings that the Smartnode's configuration doesn't cover.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Eth1},
+			EnvironmentVariables: []string{"EC_ADDITIONAL_FLAGS"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *RethConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.ArchiveMode,
+		&cfg.MaxPeers,
+		&cfg.ContainerTag,
+		&cfg.AdditionalFlags,
+	}
+}
+
+// The the title for the config
+func (cfg *RethConfig) GetConfigTitle() string {
+	return cfg.Title
+}
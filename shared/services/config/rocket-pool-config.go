@@ -10,20 +10,24 @@ import (
 	"strings"
 
 	"github.com/alessio/shellescape"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pbnjay/memory"
 	"github.com/rocket-pool/smartnode/addons"
 	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/services/config/migration"
 	addontypes "github.com/rocket-pool/smartnode/shared/types/addons"
 	"github.com/rocket-pool/smartnode/shared/types/config"
+	netutils "github.com/rocket-pool/smartnode/shared/utils/net"
 	"gopkg.in/yaml.v2"
 )
 
 // Constants
 const (
-	rootConfigName string = "root"
+	rootConfigName    string = "root"
+	aliasesConfigName string = "aliases"
 
 	ApiContainerName          string = "api"
+	CharonContainerName       string = "charon"
 	Eth1ContainerName         string = "eth1"
 	Eth1FallbackContainerName string = "eth1-fallback"
 	Eth2ContainerName         string = "eth2"
@@ -32,6 +36,7 @@ const (
 	MevBoostContainerName     string = "mev-boost"
 	NodeContainerName         string = "node"
 	PrometheusContainerName   string = "prometheus"
+	SsvContainerName          string = "ssv"
 	ValidatorContainerName    string = "validator"
 	WatchtowerContainerName   string = "watchtower"
 
@@ -47,6 +52,12 @@ const defaultExporterMetricsPort uint16 = 9103
 const defaultWatchtowerMetricsPort uint16 = 9104
 const defaultEcMetricsPort uint16 = 9105
 
+// A port used by one of the Smartnode's services
+type portSetting struct {
+	name string
+	port uint16
+}
+
 // The master configuration struct
 type RocketPoolConfig struct {
 	Title string `yaml:"-"`
@@ -57,6 +68,12 @@ type RocketPoolConfig struct {
 
 	IsNativeMode bool `yaml:"-"`
 
+	// Settings overridden by environment variables; these are never saved to the settings file
+	SettingOverrides []SettingOverride `yaml:"-"`
+
+	// The user's CLI command aliases, mapping a short name to the command and flags it runs (e.g. "node claim-rewards -a 50%")
+	Aliases map[string]string `yaml:"-"`
+
 	// Execution client settings
 	ExecutionClientMode config.Parameter `yaml:"executionClientMode,omitempty"`
 	ExecutionClient     config.Parameter `yaml:"executionClient,omitempty"`
@@ -89,10 +106,12 @@ type RocketPoolConfig struct {
 	Geth              *GethConfig              `yaml:"geth,omitempty"`
 	Nethermind        *NethermindConfig        `yaml:"nethermind,omitempty"`
 	Besu              *BesuConfig              `yaml:"besu,omitempty"`
+	Reth              *RethConfig              `yaml:"reth,omitempty"`
 	ExternalExecution *ExternalExecutionConfig `yaml:"externalExecution,omitempty"`
 
 	// Consensus client configurations
 	ConsensusCommon    *ConsensusCommonConfig    `yaml:"consensusCommon,omitempty"`
+	Grandine           *GrandineConfig           `yaml:"grandine,omitempty"`
 	Lighthouse         *LighthouseConfig         `yaml:"lighthouse,omitempty"`
 	Lodestar           *LodestarConfig           `yaml:"lodestar,omitempty"`
 	Nimbus             *NimbusConfig             `yaml:"nimbus,omitempty"`
@@ -121,6 +140,14 @@ type RocketPoolConfig struct {
 	EnableMevBoost config.Parameter `yaml:"enableMevBoost,omitempty"`
 	MevBoost       *MevBoostConfig  `yaml:"mevBoost,omitempty"`
 
+	// Web3Signer
+	EnableWeb3Signer config.Parameter  `yaml:"enableWeb3Signer,omitempty"`
+	Web3Signer       *Web3SignerConfig `yaml:"web3Signer,omitempty"`
+
+	// Distributed validators
+	EnableDistributedValidators config.Parameter            `yaml:"enableDistributedValidators,omitempty"`
+	DistributedValidator        *DistributedValidatorConfig `yaml:"distributedValidator,omitempty"`
+
 	// Addons
 	GraffitiWallWriter addontypes.SmartnodeAddon `yaml:"addon-gww,omitempty"`
 }
@@ -174,6 +201,7 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 		Title:               "Top-level Settings",
 		RocketPoolDirectory: rpDir,
 		IsNativeMode:        isNativeMode,
+		Aliases:             map[string]string{},
 
 		ExecutionClientMode: config.Parameter{
 			ID:                   "executionClientMode",
@@ -210,6 +238,10 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 				Name:        "Besu",
 				Description: getAugmentedEcDescription(config.ExecutionClient_Besu, "Hyperledger Besu is a robust full Ethereum protocol client. It uses a novel system called \"Bonsai Trees\" to store its chain data efficiently, which allows it to access block states from the past and does not require pruning. Besu is fully open source and written in Java."),
 				Value:       config.ExecutionClient_Besu,
+			}, {
+				Name:        "Reth",
+				Description: getAugmentedEcDescription(config.ExecutionClient_Reth, "Reth is a fast, modular Execution client focused on performance and contributor friendliness. It runs as a pruned full node by default, so it never needs to be pruned manually. Reth is fully open source and written in Rust."),
+				Value:       config.ExecutionClient_Reth,
 			}},
 		},
 
@@ -261,6 +293,10 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 			Options: []config.ParameterOption{{
+				Name:        "Grandine",
+				Description: "Grandine is a high-performance Consensus client written in Rust, designed around parallel processing to make full use of modern multi-core CPUs while keeping memory usage low. It is developed by Sifrai and released under a GPL-3.0 license. Grandine only provides the Beacon Node, so the Smartnode pairs it with Lighthouse's Validator Client.",
+				Value:       config.ConsensusClient_Grandine,
+			}, {
 				Name:        "Lighthouse",
 				Description: "Lighthouse is a Consensus client with a heavy focus on speed and security. The team behind it, Sigma Prime, is an information security and software engineering firm who have funded Lighthouse along with the Ethereum Foundation, Consensys, and private individuals. Lighthouse is built in Rust and offered under an Apache 2.0 License.",
 				Value:       config.ConsensusClient_Lighthouse,
@@ -435,6 +471,30 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   true,
 		},
+
+		EnableWeb3Signer: config.Parameter{
+			ID:                   "enableWeb3Signer",
+			Name:                 "Enable Web3Signer",
+			Description:          "Delegate validator signing to an external Web3Signer instance instead of storing your validator keystores on this machine. New validator keys will be registered with Web3Signer, and your Validator client will ask it to sign duties.\n\n[orange]NOTE: Run `rocketpool wallet rebuild` after enabling this to register your existing validator keys with Web3Signer. If they've been attesting elsewhere, set a Slashing Protection File first.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{"ENABLE_WEB3SIGNER"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableDistributedValidators: config.Parameter{
+			ID:                   "enableDistributedValidators",
+			Name:                 "Enable Distributed Validators",
+			Description:          "Run the Obol or SSV middleware alongside your Validator client so you can create minipools whose validators are distributed validators. Their keys are generated by the cluster's key generation ceremony instead of your node wallet; use `rocketpool node deposit --dv-deposit-data` to create a minipool for one.\n\n[orange]NOTE: The Smartnode can't exit distributed validators or recover their keys. Use your cluster's own tools for that.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator, config.ContainerID_Charon, config.ContainerID_Ssv},
+			EnvironmentVariables: []string{"ENABLE_DISTRIBUTED_VALIDATORS"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
 	}
 
 	// Set the defaults for choices
@@ -446,10 +506,12 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 	cfg.Geth = NewGethConfig(cfg)
 	cfg.Nethermind = NewNethermindConfig(cfg)
 	cfg.Besu = NewBesuConfig(cfg)
+	cfg.Reth = NewRethConfig(cfg)
 	cfg.ExternalExecution = NewExternalExecutionConfig(cfg)
 	cfg.FallbackNormal = NewFallbackNormalConfig(cfg)
 	cfg.FallbackPrysm = NewFallbackPrysmConfig(cfg)
 	cfg.ConsensusCommon = NewConsensusCommonConfig(cfg)
+	cfg.Grandine = NewGrandineConfig(cfg)
 	cfg.Lighthouse = NewLighthouseConfig(cfg)
 	cfg.Lodestar = NewLodestarConfig(cfg)
 	cfg.Nimbus = NewNimbusConfig(cfg)
@@ -466,6 +528,8 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 	cfg.BitflyNodeMetrics = NewBitflyNodeMetricsConfig(cfg)
 	cfg.Native = NewNativeConfig(cfg)
 	cfg.MevBoost = NewMevBoostConfig(cfg)
+	cfg.Web3Signer = NewWeb3SignerConfig(cfg)
+	cfg.DistributedValidator = NewDistributedValidatorConfig(cfg)
 
 	// Addons
 	cfg.GraffitiWallWriter = addons.NewGraffitiWallWriter()
@@ -514,6 +578,10 @@ func (cfg *RocketPoolConfig) CreateCopy() *RocketPoolConfig {
 			newParams[i].UpdateDescription(network)
 		}
 	}
+	newConfig.SettingOverrides = append([]SettingOverride{}, cfg.SettingOverrides...)
+	for name, expansion := range cfg.Aliases {
+		newConfig.Aliases[name] = expansion
+	}
 
 	return newConfig
 }
@@ -538,38 +606,44 @@ func (cfg *RocketPoolConfig) GetParameters() []*config.Parameter {
 		&cfg.ExporterMetricsPort,
 		&cfg.WatchtowerMetricsPort,
 		&cfg.EnableMevBoost,
+		&cfg.EnableWeb3Signer,
+		&cfg.EnableDistributedValidators,
 	}
 }
 
 // Get the subconfigurations for this config
 func (cfg *RocketPoolConfig) GetSubconfigs() map[string]config.Config {
 	return map[string]config.Config{
-		"smartnode":          cfg.Smartnode,
-		"executionCommon":    cfg.ExecutionCommon,
-		"geth":               cfg.Geth,
-		"nethermind":         cfg.Nethermind,
-		"besu":               cfg.Besu,
-		"externalExecution":  cfg.ExternalExecution,
-		"consensusCommon":    cfg.ConsensusCommon,
-		"lighthouse":         cfg.Lighthouse,
-		"lodestar":           cfg.Lodestar,
-		"nimbus":             cfg.Nimbus,
-		"prysm":              cfg.Prysm,
-		"teku":               cfg.Teku,
-		"externalLighthouse": cfg.ExternalLighthouse,
-		"externalLodestar":   cfg.ExternalLodestar,
-		"externalNimbus":     cfg.ExternalNimbus,
-		"externalPrysm":      cfg.ExternalPrysm,
-		"externalTeku":       cfg.ExternalTeku,
-		"fallbackNormal":     cfg.FallbackNormal,
-		"fallbackPrysm":      cfg.FallbackPrysm,
-		"grafana":            cfg.Grafana,
-		"prometheus":         cfg.Prometheus,
-		"exporter":           cfg.Exporter,
-		"bitflyNodeMetrics":  cfg.BitflyNodeMetrics,
-		"native":             cfg.Native,
-		"mevBoost":           cfg.MevBoost,
-		"addons-gww":         cfg.GraffitiWallWriter.GetConfig(),
+		"smartnode":            cfg.Smartnode,
+		"executionCommon":      cfg.ExecutionCommon,
+		"geth":                 cfg.Geth,
+		"nethermind":           cfg.Nethermind,
+		"besu":                 cfg.Besu,
+		"reth":                 cfg.Reth,
+		"externalExecution":    cfg.ExternalExecution,
+		"consensusCommon":      cfg.ConsensusCommon,
+		"grandine":             cfg.Grandine,
+		"lighthouse":           cfg.Lighthouse,
+		"lodestar":             cfg.Lodestar,
+		"nimbus":               cfg.Nimbus,
+		"prysm":                cfg.Prysm,
+		"teku":                 cfg.Teku,
+		"externalLighthouse":   cfg.ExternalLighthouse,
+		"externalLodestar":     cfg.ExternalLodestar,
+		"externalNimbus":       cfg.ExternalNimbus,
+		"externalPrysm":        cfg.ExternalPrysm,
+		"externalTeku":         cfg.ExternalTeku,
+		"fallbackNormal":       cfg.FallbackNormal,
+		"fallbackPrysm":        cfg.FallbackPrysm,
+		"grafana":              cfg.Grafana,
+		"prometheus":           cfg.Prometheus,
+		"exporter":             cfg.Exporter,
+		"bitflyNodeMetrics":    cfg.BitflyNodeMetrics,
+		"native":               cfg.Native,
+		"mevBoost":             cfg.MevBoost,
+		"web3Signer":           cfg.Web3Signer,
+		"distributedValidator": cfg.DistributedValidator,
+		"addons-gww":           cfg.GraffitiWallWriter.GetConfig(),
 	}
 }
 
@@ -619,6 +693,8 @@ func (cfg *RocketPoolConfig) GetEventLogInterval() (int, error) {
 			return cfg.Geth.EventLogInterval, nil
 		case config.ExecutionClient_Nethermind:
 			return cfg.Nethermind.EventLogInterval, nil
+		case config.ExecutionClient_Reth:
+			return cfg.Reth.EventLogInterval, nil
 		default:
 			return 0, fmt.Errorf("can't get event log interval of unknown execution client [%v]", client)
 		}
@@ -643,6 +719,19 @@ func (cfg *RocketPoolConfig) GetSelectedConsensusClient() (config.ConsensusClien
 	return cc, mode
 }
 
+// Get the URL of the Beacon node used to cross-check the checkpoint sync provider, falling back to the fallback CC if one is configured
+func (cfg *RocketPoolConfig) GetCheckpointSyncVerificationUrl() string {
+	verificationUrl := cfg.ConsensusCommon.CheckpointSyncVerificationUrl.Value.(string)
+	if verificationUrl != "" || cfg.UseFallbackClients.Value != true {
+		return verificationUrl
+	}
+	cc, _ := cfg.GetSelectedConsensusClient()
+	if cc == config.ConsensusClient_Prysm {
+		return cfg.FallbackPrysm.CcHttpUrl.Value.(string)
+	}
+	return cfg.FallbackNormal.CcHttpUrl.Value.(string)
+}
+
 // Get the configuration for the selected consensus client
 func (cfg *RocketPoolConfig) GetSelectedConsensusClientConfig() (config.ConsensusConfig, error) {
 	if cfg.IsNativeMode {
@@ -654,6 +743,8 @@ func (cfg *RocketPoolConfig) GetSelectedConsensusClientConfig() (config.Consensu
 	case config.Mode_Local:
 		client := cfg.ConsensusClient.Value.(config.ConsensusClient)
 		switch client {
+		case config.ConsensusClient_Grandine:
+			return cfg.Grandine, nil
 		case config.ConsensusClient_Lighthouse:
 			return cfg.Lighthouse, nil
 		case config.ConsensusClient_Lodestar:
@@ -701,7 +792,7 @@ func (cfg *RocketPoolConfig) IsDoppelgangerEnabled() (bool, error) {
 	case config.Mode_Local:
 		client := cfg.ConsensusClient.Value.(config.ConsensusClient)
 		switch client {
-		case config.ConsensusClient_Lighthouse, config.ConsensusClient_Lodestar, config.ConsensusClient_Nimbus, config.ConsensusClient_Prysm:
+		case config.ConsensusClient_Grandine, config.ConsensusClient_Lighthouse, config.ConsensusClient_Lodestar, config.ConsensusClient_Nimbus, config.ConsensusClient_Prysm:
 			return cfg.ConsensusCommon.DoppelgangerDetection.Value.(bool), nil
 		case config.ConsensusClient_Teku:
 			return false, nil
@@ -754,6 +845,16 @@ func (cfg *RocketPoolConfig) Serialize() map[string]map[string]string {
 		}
 		masterMap[name] = subconfigParams
 	}
+	cfg.removeSettingOverrides(masterMap)
+
+	// Serialize the aliases
+	if len(cfg.Aliases) > 0 {
+		aliases := map[string]string{}
+		for name, expansion := range cfg.Aliases {
+			aliases[name] = expansion
+		}
+		masterMap[aliasesConfigName] = aliases
+	}
 
 	return masterMap
 }
@@ -811,6 +912,12 @@ func (cfg *RocketPoolConfig) Deserialize(masterMap map[string]map[string]string)
 		}
 	}
 
+	// Deserialize the aliases
+	cfg.Aliases = map[string]string{}
+	for name, expansion := range masterMap[aliasesConfigName] {
+		cfg.Aliases[name] = expansion
+	}
+
 	return nil
 }
 
@@ -857,6 +964,9 @@ func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 		case config.ExecutionClient_Besu:
 			config.AddParametersToEnvVars(cfg.Besu.GetParameters(), envVars)
 			envVars["EC_STOP_SIGNAL"] = besuStopSignal
+		case config.ExecutionClient_Reth:
+			config.AddParametersToEnvVars(cfg.Reth.GetParameters(), envVars)
+			envVars["EC_STOP_SIGNAL"] = rethStopSignal
 		}
 	} else {
 		envVars["EC_CLIENT"] = "X" // X is for external / unknown
@@ -896,6 +1006,8 @@ func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 
 		// Client-specific params
 		switch consensusClient {
+		case config.ConsensusClient_Grandine:
+			config.AddParametersToEnvVars(cfg.Grandine.GetParameters(), envVars)
 		case config.ConsensusClient_Lighthouse:
 			config.AddParametersToEnvVars(cfg.Lighthouse.GetParameters(), envVars)
 		case config.ConsensusClient_Lodestar:
@@ -925,6 +1037,7 @@ func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 		}
 	}
 	envVars["CC_CLIENT"] = fmt.Sprint(consensusClient)
+	envVars["VC_CLIENT"] = fmt.Sprint(getValidatorClient(consensusClient))
 
 	// Graffiti
 	identifier := ""
@@ -959,6 +1072,12 @@ func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 		envVars["CC_HOSTNAME"] = ccUrl.Hostname()
 	}
 
+	// The Validator client talks to Charon instead of the Beacon Node when it's part of an Obol cluster
+	envVars[vcApiEndpointEnvVar] = envVars["CC_API_ENDPOINT"]
+	if cfg.EnableDistributedValidators.Value == true && cfg.DistributedValidator.Mode.Value == config.DistributedValidatorMode_Obol {
+		envVars[vcApiEndpointEnvVar] = fmt.Sprintf("http://%s:%d", CharonContainerName, charonValidatorApiPort)
+	}
+
 	// Fallback parameters
 	if cfg.UseFallbackClients.Value == true {
 		switch consensusClient {
@@ -1020,6 +1139,16 @@ func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 		}
 	}
 
+	// Web3Signer
+	if cfg.EnableWeb3Signer.Value == true {
+		config.AddParametersToEnvVars(cfg.Web3Signer.GetParameters(), envVars)
+	}
+
+	// Distributed validators
+	if cfg.EnableDistributedValidators.Value == true {
+		config.AddParametersToEnvVars(cfg.DistributedValidator.GetParameters(), envVars)
+	}
+
 	// Addons
 	cfg.GraffitiWallWriter.UpdateEnvVars(envVars)
 
@@ -1027,6 +1156,17 @@ func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 
 }
 
+// Leave the MEV-Boost relays that relay management dropped out of the environment variables, if it's in automatic mode
+func (cfg *RocketPoolConfig) RemoveDroppedMevRelays(envVars map[string]string, dropped map[config.MevRelayID]bool) {
+	if _, exists := envVars[mevBoostRelaysEnvVar]; !exists {
+		return
+	}
+	if cfg.MevBoost.RelayManagement.Value.(config.MevRelayManagement) != config.MevRelayManagement_Automatic {
+		return
+	}
+	envVars[mevBoostRelaysEnvVar] = cfg.MevBoost.getRelayString(dropped)
+}
+
 // The the title for the config
 func (cfg *RocketPoolConfig) GetConfigTitle() string {
 	return cfg.Title
@@ -1119,6 +1259,44 @@ func (cfg *RocketPoolConfig) Validate() []string {
 		}
 	}
 
+	// Grandine is paired with a Validator Client the Smartnode manages, so it can only be run locally
+	if cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_External && cfg.ExternalConsensusClient.Value.(config.ConsensusClient) == config.ConsensusClient_Grandine {
+		errors = append(errors, "You have Grandine selected as your externally-managed Consensus client, but it is only supported in locally-managed mode.\nIf you are running your own Grandine Beacon Node, please select Lighthouse as your external client instead; its Validator Client is compatible with Grandine.")
+	}
+
+	// The light client needs a block root to start from
+	if cfg.Smartnode.VerifyBeaconWithLightClient.Value == true && cfg.Smartnode.LightClientTrustedRoot.Value == "" {
+		errors = append(errors, "You have Beacon light client verification enabled, but don't have a trusted block root set. Please enter the root of a recent finalized block from a source you trust, or disable light client verification.")
+	}
+
+	// The node account can only be held in one place
+	if cfg.Smartnode.HardwareWallet.Value.(config.HardwareWallet) != config.HardwareWallet_None && cfg.Smartnode.KmsProvider.Value.(config.KmsProvider) != config.KmsProvider_None {
+		errors = append(errors, "You have both a hardware wallet and a key management service selected for your node account. Please select only one of them.")
+	}
+	if cfg.Smartnode.KmsProvider.Value.(config.KmsProvider) != config.KmsProvider_None && cfg.Smartnode.KmsKeyId.Value == "" {
+		errors = append(errors, "You have a key management service selected for your node account, but haven't entered the ID of its key. Please enter it, or select None.")
+	}
+
+	// Safe mode needs a valid Safe and a transaction service to propose to
+	if safeAddress := cfg.Smartnode.SafeAddress.Value.(string); safeAddress != "" {
+		if !common.IsHexAddress(safeAddress) {
+			errors = append(errors, fmt.Sprintf("Your node account Safe address (%s) is not a valid address.", safeAddress))
+		}
+		if cfg.Smartnode.SafeTransactionServiceUrl.Value == "" {
+			errors = append(errors, "You have a Safe set as your node account, but no Safe transaction service URL. Please enter the URL of the service for your network.")
+		}
+	}
+
+	// Watch-only mode needs a valid address
+	if watchOnlyAddress := cfg.Smartnode.WatchOnlyAddress.Value.(string); watchOnlyAddress != "" && !common.IsHexAddress(watchOnlyAddress) {
+		errors = append(errors, fmt.Sprintf("Your watch-only node address (%s) is not a valid address.", watchOnlyAddress))
+	}
+
+	// The daemons can only reach the OS keychain when they run directly on the host
+	if !cfg.IsNativeMode && cfg.Smartnode.UsePasswordKeychain.Value == true {
+		errors = append(errors, "You have OS keychain password storage enabled, but it is only supported in Native Mode because the Smartnode's containers can't access your host's keychain. Please disable it.")
+	}
+
 	// Force all Docker or all Hybrid
 	if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local && cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_External {
 		errors = append(errors, "You are using a locally-managed Execution client and an externally-managed Consensus client.\nThis configuration is not compatible with The Merge; please select either locally-managed or externally-managed for both the EC and CC.")
@@ -1145,9 +1323,103 @@ func (cfg *RocketPoolConfig) Validate() []string {
 		}
 	}
 
+	// Ensure there's a Web3Signer URL
+	if cfg.EnableWeb3Signer.Value == true {
+		web3SignerUrl := cfg.Web3Signer.Url.Value.(string)
+		if web3SignerUrl == "" {
+			errors = append(errors, "You have Web3Signer enabled but don't have a URL set. Please enter the URL of your Web3Signer instance to use it.")
+		} else if err := netutils.RequireTlsUnlessLoopback(web3SignerUrl); err != nil {
+			errors = append(errors, fmt.Sprintf("Your Web3Signer URL is invalid: %s. Your validator keys are sent to Web3Signer, so it must use https unless it runs on this machine.", err.Error()))
+		}
+	}
+
+	// The distributed validator middleware runs in Docker, and Obol needs a Validator client that can be pointed at Charon
+	if cfg.EnableDistributedValidators.Value == true {
+		if cfg.IsNativeMode {
+			errors = append(errors, "You have distributed validators enabled, but they are only supported in Docker Mode because the Smartnode runs the Obol or SSV middleware in a container. Please disable them.")
+		} else if cfg.DistributedValidator.Mode.Value == config.DistributedValidatorMode_Obol &&
+			cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local &&
+			cfg.ConsensusClient.Value.(config.ConsensusClient) == config.ConsensusClient_Nimbus {
+			errors = append(errors, "You have Obol distributed validators enabled, but Nimbus runs its Validator client inside its Beacon Node so it can't be connected to Charon. Please select a different Consensus client, or use SSV instead.")
+		}
+	}
+
+	// Two services can't listen on the same port
+	errors = append(errors, cfg.getPortConflicts()...)
+
 	return errors
 }
 
+// Get the ports of the services that will be run, with the names of the settings they come from
+func (cfg *RocketPoolConfig) getActivePorts() []portSetting {
+	ports := []portSetting{}
+	addPort := func(name string, param *config.Parameter) {
+		ports = append(ports, portSetting{name: name, port: param.Value.(uint16)})
+	}
+
+	isLocalEc := cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local
+	isLocalCc := cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local
+	if isLocalEc {
+		addPort("Execution client HTTP port", &cfg.ExecutionCommon.HttpPort)
+		addPort("Execution client Websocket port", &cfg.ExecutionCommon.WsPort)
+		addPort("Execution client Engine API port", &cfg.ExecutionCommon.EnginePort)
+		addPort("Execution client P2P port", &cfg.ExecutionCommon.P2pPort)
+	}
+	if isLocalCc {
+		addPort("Consensus client P2P port", &cfg.ConsensusCommon.P2pPort)
+		addPort("Consensus client HTTP API port", &cfg.ConsensusCommon.ApiPort)
+		if cfg.ConsensusClient.Value.(config.ConsensusClient) == config.ConsensusClient_Prysm {
+			addPort("Prysm RPC port", &cfg.Prysm.RpcPort)
+		}
+	}
+	if cfg.EnableMetrics.Value == true {
+		if isLocalEc {
+			addPort("Execution client metrics port", &cfg.EcMetricsPort)
+		}
+		if isLocalCc {
+			addPort("Beacon Node metrics port", &cfg.BnMetricsPort)
+		}
+		addPort("Validator client metrics port", &cfg.VcMetricsPort)
+		addPort("Node metrics port", &cfg.NodeMetricsPort)
+		addPort("Exporter metrics port", &cfg.ExporterMetricsPort)
+		addPort("Watchtower metrics port", &cfg.WatchtowerMetricsPort)
+		addPort("Grafana port", &cfg.Grafana.Port)
+		addPort("Prometheus port", &cfg.Prometheus.Port)
+	}
+	if cfg.EnableMevBoost.Value == true && cfg.MevBoost.Mode.Value.(config.Mode) == config.Mode_Local {
+		addPort("MEV-Boost port", &cfg.MevBoost.Port)
+	}
+	if cfg.EnableDistributedValidators.Value == true {
+		if cfg.DistributedValidator.Mode.Value == config.DistributedValidatorMode_Obol {
+			addPort("Charon P2P port", &cfg.DistributedValidator.CharonP2pPort)
+		} else {
+			addPort("SSV P2P TCP port", &cfg.DistributedValidator.SsvP2pTcpPort)
+			addPort("SSV P2P UDP port", &cfg.DistributedValidator.SsvP2pUdpPort)
+		}
+	}
+	if cfg.Smartnode.EnableHttpApi.Value == true {
+		addPort("Smartnode HTTP API port", &cfg.Smartnode.HttpApiPort)
+	}
+	if cfg.Smartnode.EnableGrpcApi.Value == true {
+		addPort("Smartnode gRPC API port", &cfg.Smartnode.GrpcApiPort)
+	}
+	return ports
+}
+
+// Get a description of each port that's used by more than one service
+func (cfg *RocketPoolConfig) getPortConflicts() []string {
+	conflicts := []string{}
+	ports := cfg.getActivePorts()
+	for i, setting := range ports {
+		for _, otherSetting := range ports[i+1:] {
+			if setting.port == otherSetting.port {
+				conflicts = append(conflicts, fmt.Sprintf("The %s and the %s are both set to %d. Please change one of them so they don't conflict.", setting.name, otherSetting.name, setting.port))
+			}
+		}
+	}
+	return conflicts
+}
+
 // Applies all of the defaults to all of the settings that have them defined
 func (cfg *RocketPoolConfig) applyAllDefaults() error {
 	for _, param := range cfg.GetParameters() {
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The prefix of the environment variables that override settings, e.g. ROCKETPOOL_SETTING_SMARTNODE_PRIORITY_FEE
+const SettingEnvVarPrefix string = "ROCKETPOOL_SETTING_"
+
+// A setting whose value was overridden by an environment variable
+type SettingOverride struct {
+	Section     string
+	ParameterID string
+	EnvVar      string
+	Value       interface{}
+	FileValue   interface{}
+}
+
+// Get the environment variable that overrides a setting; the section is blank for root settings
+func GetSettingEnvVar(section string, paramID string) string {
+	name := toEnvVarName(paramID)
+	if section != "" {
+		name = toEnvVarName(section) + "_" + name
+	}
+	return SettingEnvVarPrefix + name
+}
+
+// Override settings with the values of their environment variables.
+// The network is applied first so the other settings use its defaults.
+func (cfg *RocketPoolConfig) ApplySettingOverrides() error {
+	cfg.SettingOverrides = []SettingOverride{}
+
+	// Apply the network
+	networkOverride, err := overrideSetting("smartnode", &cfg.Smartnode.Network, cfg.Smartnode.Network.Value.(config.Network))
+	if err != nil {
+		return err
+	}
+	if networkOverride != nil {
+		newNetwork := cfg.Smartnode.Network.Value.(config.Network)
+		cfg.Smartnode.Network.Value = networkOverride.FileValue
+		cfg.ChangeNetwork(newNetwork)
+		cfg.SettingOverrides = append(cfg.SettingOverrides, *networkOverride)
+	}
+	network := cfg.Smartnode.Network.Value.(config.Network)
+
+	// Apply the root settings
+	for _, param := range cfg.GetParameters() {
+		override, err := overrideSetting("", param, network)
+		if err != nil {
+			return err
+		}
+		if override != nil {
+			cfg.SettingOverrides = append(cfg.SettingOverrides, *override)
+		}
+	}
+
+	// Apply the subconfig settings
+	for section, subconfig := range cfg.GetSubconfigs() {
+		for _, param := range subconfig.GetParameters() {
+			if param == &cfg.Smartnode.Network {
+				continue
+			}
+			override, err := overrideSetting(section, param, network)
+			if err != nil {
+				return err
+			}
+			if override != nil {
+				cfg.SettingOverrides = append(cfg.SettingOverrides, *override)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Get the environment variable override for a setting, if there is one
+func (cfg *RocketPoolConfig) GetSettingOverride(section string, paramID string) (SettingOverride, bool) {
+	for _, override := range cfg.SettingOverrides {
+		if override.Section == section && override.ParameterID == paramID {
+			return override, true
+		}
+	}
+	return SettingOverride{}, false
+}
+
+// Put the file values of overridden settings back into a serialized config, so overrides are never saved.
+// Settings that were changed after they were overridden keep their new values.
+func (cfg *RocketPoolConfig) removeSettingOverrides(masterMap map[string]map[string]string) {
+	params := map[string][]*config.Parameter{
+		rootConfigName: cfg.GetParameters(),
+	}
+	for section, subconfig := range cfg.GetSubconfigs() {
+		params[section] = subconfig.GetParameters()
+	}
+
+	for _, override := range cfg.SettingOverrides {
+		section := override.Section
+		if section == "" {
+			section = rootConfigName
+		}
+		for _, param := range params[section] {
+			if param.ID != override.ParameterID || param.Value != override.Value {
+				continue
+			}
+			if override.FileValue == nil {
+				masterMap[section][param.ID] = ""
+			} else {
+				masterMap[section][param.ID] = fmt.Sprint(override.FileValue)
+			}
+		}
+	}
+}
+
+// Set a parameter from its environment variable, if it's set
+func overrideSetting(section string, param *config.Parameter, network config.Network) (*SettingOverride, error) {
+	envVar := GetSettingEnvVar(section, param.ID)
+	value, exists := os.LookupEnv(envVar)
+	if !exists {
+		return nil, nil
+	}
+
+	fileValue := param.Value
+	if err := param.Deserialize(map[string]string{param.ID: value}, network); err != nil {
+		param.Value = fileValue
+		return nil, fmt.Errorf("invalid value for %s: %w", envVar, err)
+	}
+	if param.Type == config.ParameterType_Choice {
+		valid := false
+		for _, option := range param.Options {
+			if option.Value == param.Value {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			param.Value = fileValue
+			return nil, fmt.Errorf("invalid value for %s: [%s] is not one of the setting's options", envVar, value)
+		}
+	}
+
+	return &SettingOverride{
+		Section:     section,
+		ParameterID: param.ID,
+		EnvVar:      envVar,
+		Value:       param.Value,
+		FileValue:   fileValue,
+	}, nil
+}
+
+var envVarSeparatorRegex = regexp.MustCompile("[^A-Za-z0-9]+")
+
+// Convert a camelCase setting or section name to an environment variable name
+func toEnvVarName(name string) string {
+	var builder strings.Builder
+	runes := []rune(envVarSeparatorRegex.ReplaceAllString(name, "_"))
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])) {
+			builder.WriteRune('_')
+		}
+		builder.WriteRune(unicode.ToUpper(r))
+	}
+	return builder.String()
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared"
@@ -12,33 +13,78 @@ import (
 
 // Constants
 const (
-	smartnodeTag                       string = "rocketpool/smartnode:v" + shared.RocketPoolVersion
-	pruneProvisionerTag                string = "rocketpool/eth1-prune-provision:v0.0.1"
-	ecMigratorTag                      string = "rocketpool/ec-migrator:v1.0.0"
-	NetworkID                          string = "network"
-	ProjectNameID                      string = "projectName"
-	SnapshotID                         string = "rocketpool-dao.eth"
-	RewardsTreeFilenameFormat          string = "rp-rewards-%s-%d.json"
-	MinipoolPerformanceFilenameFormat  string = "rp-minipool-performance-%s-%d.json"
-	RewardsTreeIpfsExtension           string = ".zst"
-	RewardsTreesFolder                 string = "rewards-trees"
-	DaemonDataPath                     string = "/.rocketpool/data"
-	WatchtowerFolder                   string = "watchtower"
-	WatchtowerStateFile                string = "state.yml"
-	RegenerateRewardsTreeRequestSuffix string = ".request"
-	RegenerateRewardsTreeRequestFormat string = "%d" + RegenerateRewardsTreeRequestSuffix
-	PrimaryRewardsFileUrl              string = "https://%s.ipfs.dweb.link/%s"
-	SecondaryRewardsFileUrl            string = "https://ipfs.io/ipfs/%s/%s"
-	GithubRewardsFileUrl               string = "https://github.com/rocket-pool/rewards-trees/raw/main/%s/%s"
-	FeeRecipientFilename               string = "rp-fee-recipient.txt"
-	NativeFeeRecipientFilename         string = "rp-fee-recipient-env.txt"
+	smartnodeTag                         string = "rocketpool/smartnode:v" + shared.RocketPoolVersion
+	pruneProvisionerTag                  string = "rocketpool/eth1-prune-provision:v0.0.1"
+	ecMigratorTag                        string = "rocketpool/ec-migrator:v1.0.0"
+	NetworkID                            string = "network"
+	ProjectNameID                        string = "projectName"
+	ContainerRuntimeID                   string = "containerRuntime"
+	ContainerSocketPathID                string = "containerSocketPath"
+	SnapshotID                           string = "rocketpool-dao.eth"
+	RewardsTreeFilenameFormat            string = "rp-rewards-%s-%d.json"
+	MinipoolPerformanceFilenameFormat    string = "rp-minipool-performance-%s-%d.json"
+	RewardsTreeSszFilenameFormat         string = "rp-rewards-%s-%d.ssz"
+	MinipoolPerformanceSszFilenameFormat string = "rp-minipool-performance-%s-%d.ssz"
+	RewardsTreeIpfsExtension             string = ".zst"
+	RewardsTreesFolder                   string = "rewards-trees"
+	WalletsFolder                        string = "wallets"
+	KeyExportsFolder                     string = "key-exports"
+	ActiveWalletFilename                 string = "active"
+	DefaultWalletName                    string = "default"
+	DaemonDataPath                       string = "/.rocketpool/data"
+	WatchtowerFolder                     string = "watchtower"
+	WatchtowerStateFile                  string = "state.yml"
+	RegenerateRewardsTreeRequestSuffix   string = ".request"
+	RegenerateRewardsTreeRequestFormat   string = "%d" + RegenerateRewardsTreeRequestSuffix
+	DryRunRewardsTreeRequestSuffix       string = ".dryrun"
+	DryRunRewardsTreeRequestFormat       string = "%d" + DryRunRewardsTreeRequestSuffix
+	ArchiveRewardsTreeRequestSuffix      string = ".archive"
+	ArchiveRewardsTreeRequestFormat      string = "%d" + ArchiveRewardsTreeRequestSuffix
+	ArchiveRewardsTreeMaxSpan            uint64 = 24
+	RewardsTreesArchiveFolder            string = "archive"
+	ArchivedRewardsTreeFilenameFormat    string = "rp-rewards-%s-%d-v%d.json"
+	ArchivedMinipoolPerformanceFormat    string = "rp-minipool-performance-%s-%d-v%d.json"
+	TreegenCheckpointFilenameFormat      string = "rp-treegen-checkpoint-%s-%s-%d.json.zst"
+	RewardsPinRecordFilenameFormat       string = "rp-rewards-pins-%s-%d.json"
+	RewardsArweaveRecordFilenameFormat   string = "rp-rewards-arweave-%s-%d.json"
+	PrunedRewardsFileMarkerFormat        string = "rp-rewards-%s-%d.pruned"
+	MinipoolAttributionFilenameFormat    string = "rp-minipool-attribution-%s-%d.json"
+	PrimaryRewardsFileUrl                string = "https://{cid}.ipfs.dweb.link/{filename}.zst"
+	SecondaryRewardsFileUrl              string = "https://ipfs.io/ipfs/{cid}/{filename}.zst"
+	GithubRewardsFileUrl                 string = "https://github.com/rocket-pool/rewards-trees/raw/main/{network}/{filename}"
+	FeeRecipientFilename                 string = "rp-fee-recipient.txt"
+	SafeProposalsFilename                string = "safe-proposals.json"
+	UnsignedTxsFolder                    string = "unsigned-txs"
+	DryRunTxsFolder                      string = "dry-run-txs"
+	DeferredTxsFolder                    string = "deferred-txs"
+	PenaltyReportsFolder                 string = "penalty-reports"
+	MevRelayRecordFilename               string = "mev-relays.json"
+	ScheduledExitsFolder                 string = "scheduled-exits"
+	ExitVaultFilename                    string = "exit-vault.json"
+	HttpApiSocketFilename                string = "api.sock"
+	HttpApiTokenFilename                 string = "api-token"
+	HttpApiTlsCertFilename               string = "api-tls.crt"
+	HttpApiTlsKeyFilename                string = "api-tls.key"
+	ApiTokensFilename                    string = "api-tokens.yml"
+	GrpcApiSocketFilename                string = "grpc.sock"
+	DistributedValidatorsFolder          string = "distributed-validators"
+	NativeFeeRecipientFilename           string = "rp-fee-recipient-env.txt"
+	ChainMetadataCacheFilename           string = "chain-metadata.json"
+	WarmStartStateFilename               string = "warm-start-state.json.zst"
+	DaemonDatabaseFilename               string = "daemon.db"
 )
 
 // Defaults
 const (
-	defaultProjectName       string = "rocketpool"
-	WatchtowerMaxFeeDefault  uint64 = 200
-	WatchtowerPrioFeeDefault uint64 = 3
+	defaultProjectName        string = "rocketpool"
+	WatchtowerMaxFeeDefault   uint64 = 200
+	WatchtowerPrioFeeDefault  uint64 = 3
+	defaultHardwareWalletPath string = "m/44'/60'/0'/0/0"
+
+	DefaultNotificationTemplate string = "{{if .Resolved}}[resolved]{{else}}[{{.Severity}}]{{end}} {{.Summary}}"
+
+	// Fraction of the promotion scrub period a vacant minipool has to change its withdrawal credentials to 0x01 before the Oracle DAO scrubs it
+	SoloMigrationCheckThreshold float64 = 0.85
 )
 
 // Configuration for the Smartnode
@@ -55,6 +101,12 @@ type SmartnodeConfig struct {
 	// Docker container prefix
 	ProjectName config.Parameter `yaml:"projectName,omitempty"`
 
+	// The container runtime that runs the containers
+	ContainerRuntime config.Parameter `yaml:"containerRuntime,omitempty"`
+
+	// The path of the container runtime's API socket, if it isn't in the default location
+	ContainerSocketPath config.Parameter `yaml:"containerSocketPath,omitempty"`
+
 	// The path of the data folder where everything is stored
 	DataPath config.Parameter `yaml:"dataPath,omitempty"`
 
@@ -73,9 +125,159 @@ type SmartnodeConfig struct {
 	// Threshold for automatic transactions
 	AutoTxGasThreshold config.Parameter `yaml:"minipoolStakeGasThreshold,omitempty"`
 
+	// The first source to get gas price suggestions from
+	GasOracle config.Parameter `yaml:"gasOracle,omitempty"`
+
+	// The API key for Blocknative's gas price suggestions
+	BlocknativeApiKey config.Parameter `yaml:"blocknativeApiKey,omitempty"`
+
 	// The amount of ETH in a minipool's balance before auto-distribute kicks in
 	DistributeThreshold config.Parameter `yaml:"distributeThreshold,omitempty"`
 
+	// The amount of ETH in the node's fee distributor before auto-distribute kicks in
+	FeeDistributorThreshold config.Parameter `yaml:"feeDistributorThreshold,omitempty"`
+
+	// The max gas price for automatic distributions, overriding the automatic TX gas threshold
+	DistributeMaxFee config.Parameter `yaml:"distributeMaxFee,omitempty"`
+
+	// The daily window, in UTC, that automatic distributions are limited to
+	DistributeSchedule config.Parameter `yaml:"distributeSchedule,omitempty"`
+
+	// Toggle for claiming rewards automatically
+	AutoClaimRewards config.Parameter `yaml:"autoClaimRewards,omitempty"`
+
+	// The max gas price for automatic claims, overriding the automatic TX gas threshold
+	AutoClaimMaxFee config.Parameter `yaml:"autoClaimMaxFee,omitempty"`
+
+	// How much of the claimed RPL is restaked
+	AutoClaimRestakeMode config.Parameter `yaml:"autoClaimRestakeMode,omitempty"`
+
+	// The percent of the claimed RPL that's restaked in percent mode
+	AutoClaimRestakePercent config.Parameter `yaml:"autoClaimRestakePercent,omitempty"`
+
+	// The collateral ratio that's restaked up to in collateral mode
+	AutoClaimTargetCollateral config.Parameter `yaml:"autoClaimTargetCollateral,omitempty"`
+
+	// The address claimed ETH is swept to
+	AutoClaimSweepAddress config.Parameter `yaml:"autoClaimSweepAddress,omitempty"`
+
+	// How the node votes on Protocol DAO proposals automatically
+	AutoVotePolicy config.Parameter `yaml:"autoVotePolicy,omitempty"`
+
+	// The voter whose votes are copied in follow mode
+	AutoVoteDelegate config.Parameter `yaml:"autoVoteDelegate,omitempty"`
+
+	// The keyword rules that pick the node's vote in rules mode
+	AutoVoteRules config.Parameter `yaml:"autoVoteRules,omitempty"`
+
+	// Toggle for automatically beginning bond reductions on eligible 16-ETH minipools
+	AutoBeginBondReduction config.Parameter `yaml:"autoBeginBondReduction,omitempty"`
+
+	// Toggle for the node daemon's HTTP API
+	EnableHttpApi config.Parameter `yaml:"enableHttpApi,omitempty"`
+
+	// The TCP port for the HTTP API, in addition to its socket; 0 disables it
+	HttpApiPort config.Parameter `yaml:"httpApiPort,omitempty"`
+
+	// The address the HTTP API's TCP port is bound to
+	HttpApiAddress config.Parameter `yaml:"httpApiAddress,omitempty"`
+
+	// Toggle for the node daemon's gRPC API
+	EnableGrpcApi config.Parameter `yaml:"enableGrpcApi,omitempty"`
+
+	// The TCP port for the gRPC API, in addition to its socket; 0 disables it
+	GrpcApiPort config.Parameter `yaml:"grpcApiPort,omitempty"`
+
+	// The number of API requests each client can make per minute
+	ApiRateLimit config.Parameter `yaml:"apiRateLimit,omitempty"`
+
+	// The number of expensive API requests that can wait for their turn
+	ApiMaxQueuedRequests config.Parameter `yaml:"apiMaxQueuedRequests,omitempty"`
+
+	// Toggle for the node daemon's alerts
+	EnableAlerts config.Parameter `yaml:"enableAlerts,omitempty"`
+
+	// The number of epochs a validator can go without earning before it's considered offline
+	AlertValidatorOfflineEpochs config.Parameter `yaml:"alertValidatorOfflineEpochs,omitempty"`
+
+	// The collateral ratio (as a percent of borrowed ETH) to alert below
+	AlertMinCollateralRatio config.Parameter `yaml:"alertMinCollateralRatio,omitempty"`
+
+	// The node wallet balance (in ETH) to alert below
+	AlertMinWalletBalance config.Parameter `yaml:"alertMinWalletBalance,omitempty"`
+
+	// The free disk space (as a percent) to alert below
+	AlertMinFreeDiskSpace config.Parameter `yaml:"alertMinFreeDiskSpace,omitempty"`
+
+	// How far (in ETH) a validator's balance can fall below its peak before an alert is raised
+	AlertBalanceDrop config.Parameter `yaml:"alertBalanceDrop,omitempty"`
+
+	// The URL to post alerts to as JSON, if any
+	AlertWebhookUrl config.Parameter `yaml:"alertWebhookUrl,omitempty"`
+
+	// Toggle for sending notable events (such as claimed rewards) along with the alerts
+	EnableEventNotifications config.Parameter `yaml:"enableEventNotifications,omitempty"`
+
+	// The template for alert and event messages sent to chat services
+	NotificationTemplate config.Parameter `yaml:"notificationTemplate,omitempty"`
+
+	// The Discord webhook to send notifications to, if any
+	DiscordWebhookUrl config.Parameter `yaml:"discordWebhookUrl,omitempty"`
+
+	// The Slack webhook to send notifications to, if any
+	SlackWebhookUrl config.Parameter `yaml:"slackWebhookUrl,omitempty"`
+
+	// The Telegram bot that sends notifications, if any
+	TelegramBotToken config.Parameter `yaml:"telegramBotToken,omitempty"`
+
+	// The Telegram chat the bot sends notifications to
+	TelegramChatId config.Parameter `yaml:"telegramChatId,omitempty"`
+
+	// The URL the node daemon pings after every duty cycle, if any
+	HeartbeatUrl config.Parameter `yaml:"heartbeatUrl,omitempty"`
+
+	// The URL the node daemon pings after every duty cycle where all of its tasks succeeded, if any
+	NodeTasksHeartbeatUrl config.Parameter `yaml:"nodeTasksHeartbeatUrl,omitempty"`
+
+	// The URL the watchtower pings after every duty cycle where all of its tasks succeeded, if any
+	WatchtowerHeartbeatUrl config.Parameter `yaml:"watchtowerHeartbeatUrl,omitempty"`
+
+	// The lease file on shared storage that redundant watchtowers coordinate through, if any
+	WatchtowerLeasePath config.Parameter `yaml:"watchtowerLeasePath,omitempty"`
+
+	// How long the primary watchtower can go silent before a standby takes over its duties, in minutes
+	WatchtowerLeaseTimeout config.Parameter `yaml:"watchtowerLeaseTimeout,omitempty"`
+
+	// The hardware wallet that holds the node account, if any
+	HardwareWallet config.Parameter `yaml:"hardwareWallet,omitempty"`
+
+	// The derivation path of the node account on the hardware wallet
+	HardwareWalletPath config.Parameter `yaml:"hardwareWalletPath,omitempty"`
+
+	// The key management service that holds the node account, if any
+	KmsProvider config.Parameter `yaml:"kmsProvider,omitempty"`
+
+	// The ID of the node account's key in the key management service
+	KmsKeyId config.Parameter `yaml:"kmsKeyId,omitempty"`
+
+	// The AWS region of the node account's KMS key
+	KmsRegion config.Parameter `yaml:"kmsRegion,omitempty"`
+
+	// The path to the PKCS#11 module for the node account's token
+	Pkcs11Module config.Parameter `yaml:"pkcs11Module,omitempty"`
+
+	// The address of the Safe used as the node account, if any
+	SafeAddress config.Parameter `yaml:"safeAddress,omitempty"`
+
+	// The URL of the Safe transaction service that node transactions are proposed to
+	SafeTransactionServiceUrl config.Parameter `yaml:"safeTransactionServiceUrl,omitempty"`
+
+	// The node account's address, for building unsigned transactions on a machine without the node wallet
+	WatchOnlyAddress config.Parameter `yaml:"watchOnlyAddress,omitempty"`
+
+	// Toggle for storing the node wallet password in the OS keychain instead of a file
+	UsePasswordKeychain config.Parameter `yaml:"usePasswordKeychain,omitempty"`
+
 	// Mode for acquiring Merkle rewards trees
 	RewardsTreeMode config.Parameter `yaml:"rewardsTreeMode,omitempty"`
 
@@ -103,6 +305,78 @@ type SmartnodeConfig struct {
 	// The path of the records folder where snapshots of rolling record info is stored during a rewards interval
 	RecordsPath config.Parameter `yaml:"recordsPath,omitempty"`
 
+	// The toggle for streaming network state construction during tree generation
+	UseStreamingState config.Parameter `yaml:"useStreamingState,omitempty"`
+
+	// The number of epochs to retrieve concurrently during tree generation
+	TreegenWorkerCount config.Parameter `yaml:"treegenWorkerCount,omitempty"`
+
+	// Where to pin generated rewards files on IPFS
+	IpfsPinningMode config.Parameter `yaml:"ipfsPinningMode,omitempty"`
+
+	// The URL of the IPFS node's HTTP API, for pinning to a self-hosted node
+	IpfsApiUrl config.Parameter `yaml:"ipfsApiUrl,omitempty"`
+
+	// JWT for pinning rewards files to Pinata
+	PinataApiToken config.Parameter `yaml:"pinataApiToken,omitempty"`
+
+	// The number of past rewards intervals to keep files for
+	RewardsFileRetentionCount config.Parameter `yaml:"rewardsFileRetentionCount,omitempty"`
+
+	// The toggle for saving SSZ copies of rewards files
+	SaveSszRewardsFiles config.Parameter `yaml:"saveSszRewardsFiles,omitempty"`
+
+	// The toggle for mirroring generated rewards files to Arweave
+	EnableArweaveUpload config.Parameter `yaml:"enableArweaveUpload,omitempty"`
+
+	// The URL of the Bundlr node used to upload rewards files to Arweave
+	BundlrNodeUrl config.Parameter `yaml:"bundlrNodeUrl,omitempty"`
+
+	// The private key of the wallet that pays for Arweave uploads
+	BundlrPrivateKey config.Parameter `yaml:"bundlrPrivateKey,omitempty"`
+
+	// The toggle for generating a per-minipool rewards breakdown alongside each rewards tree
+	GenerateMinipoolAttribution config.Parameter `yaml:"generateMinipoolAttribution,omitempty"`
+
+	// The ordered list of URLs to download rewards files from
+	RewardsFileMirrors config.Parameter `yaml:"rewardsFileMirrors,omitempty"`
+
+	// Extra Execution client URLs to spread read-only calls across
+	ReadOnlyEcUrls config.Parameter `yaml:"readOnlyEcUrls,omitempty"`
+
+	// The maximum number of read-only requests per second to send to each Execution client
+	ReadOnlyEcRateLimit config.Parameter `yaml:"readOnlyEcRateLimit,omitempty"`
+
+	// The number of validators to request from the Beacon node at a time
+	BeaconValidatorChunkSize config.Parameter `yaml:"beaconValidatorChunkSize,omitempty"`
+
+	// The number of validator requests to send to the Beacon node at once
+	BeaconValidatorConcurrency config.Parameter `yaml:"beaconValidatorConcurrency,omitempty"`
+
+	// Toggle for requesting SSZ-encoded responses from the Beacon node
+	BeaconSszSupport config.Parameter `yaml:"beaconSszSupport,omitempty"`
+
+	// Toggle for using a websocket connection to the Execution client for new block notifications
+	UseEcWebsocket config.Parameter `yaml:"useEcWebsocket,omitempty"`
+
+	// Toggle for checking that the Beacon clients follow the chain a sync committee light client has verified
+	VerifyBeaconWithLightClient config.Parameter `yaml:"verifyBeaconWithLightClient,omitempty"`
+
+	// The trusted block root the light client is bootstrapped from
+	LightClientTrustedRoot config.Parameter `yaml:"lightClientTrustedRoot,omitempty"`
+
+	// The timeout and retries for quick client queries
+	FastCallTimeout config.Parameter `yaml:"fastCallTimeout,omitempty"`
+	FastCallRetries config.Parameter `yaml:"fastCallRetries,omitempty"`
+
+	// The timeout and retries for large state queries
+	HeavyCallTimeout config.Parameter `yaml:"heavyCallTimeout,omitempty"`
+	HeavyCallRetries config.Parameter `yaml:"heavyCallRetries,omitempty"`
+
+	// The timeout and retries for transaction and message submission
+	SubmitCallTimeout config.Parameter `yaml:"submitCallTimeout,omitempty"`
+	SubmitCallRetries config.Parameter `yaml:"submitCallRetries,omitempty"`
+
 	///////////////////////////
 	// Non-editable settings //
 	///////////////////////////
@@ -211,6 +485,39 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		ContainerRuntime: config.Parameter{
+			ID:                   ContainerRuntimeID,
+			Name:                 "Container Runtime",
+			Description:          "The container runtime that runs the Smartnode's containers.\n\nSelect Podman to run them with rootless Podman instead of a Docker daemon running as root. The Podman API socket must be enabled for your user (`systemctl --user enable --now podman.socket`), and Podman 4.7 or newer with `podman compose` is required.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.ContainerRuntime_Docker},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower, config.ContainerID_Eth1, config.ContainerID_Eth2, config.ContainerID_Validator, config.ContainerID_Grafana, config.ContainerID_Prometheus, config.ContainerID_Exporter, config.ContainerID_MevBoost},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Docker",
+				Description: "Run the containers with the Docker daemon.",
+				Value:       config.ContainerRuntime_Docker,
+			}, {
+				Name:        "Podman (rootless)",
+				Description: "Run the containers with rootless Podman, so no container daemon runs as root.",
+				Value:       config.ContainerRuntime_Podman,
+			}},
+		},
+
+		ContainerSocketPath: config.Parameter{
+			ID:                   ContainerSocketPathID,
+			Name:                 "Container Socket Path",
+			Description:          "The path of the container runtime's API socket, which the node daemon uses to restart your Validator Client.\n\nLeave this blank to detect it automatically (`/var/run/docker.sock` for Docker, `$XDG_RUNTIME_DIR/podman/podman.sock` for rootless Podman).",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
 		DataPath: config.Parameter{
 			ID:                   "dataPath",
 			Name:                 "Data Path",
@@ -286,130 +593,1148 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
-		DistributeThreshold: config.Parameter{
-			ID:                   "distributeThreshold",
-			Name:                 "Auto-Distribute Threshold",
-			Description:          "The Smartnode will regularly check the balance of each of your minipools on the Execution Layer (**not** the Beacon Chain).\nIf any of them have a balance greater than this threshold (in ETH), the Smartnode will automatically distribute the balance. This will send your share of the balance to your withdrawal address.\n\nMust be less than 8 ETH.\n\nSet this to 0 to disable automatic distributes.\n[orange]WARNING: if you disable automatic distribution, you **must** ensure you distribute your minipool's balance before it reaches 8 ETH or you will no longer be able to distribute your rewards until you exit the minipool!",
+		GasOracle: config.Parameter{
+			ID:                   "gasOracle",
+			Name:                 "Gas Oracle",
+			Description:          "The source the Smartnode asks first for the current gas price suggestions. If it's unavailable, the other sources are used as fallbacks.\n\nThis applies to automated transactions as well.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.GasOracle_Auto},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Auto",
+				Description: "Use Etherchain, then Etherscan, then your Execution client's fee history, then Blocknative if you've provided an API key.",
+				Value:       config.GasOracle_Auto,
+			}, {
+				Name:        "Etherchain",
+				Description: "Use Etherchain's gas price suggestions.",
+				Value:       config.GasOracle_Etherchain,
+			}, {
+				Name:        "Etherscan",
+				Description: "Use Etherscan's gas price suggestions.",
+				Value:       config.GasOracle_Etherscan,
+			}, {
+				Name:        "Fee History",
+				Description: "Derive suggestions from the base fees of recent blocks, as reported by your own Execution client. This doesn't depend on any third-party service.",
+				Value:       config.GasOracle_FeeHistory,
+			}, {
+				Name:        "Blocknative",
+				Description: "Use Blocknative's gas price suggestions, which are based on the probability of being included in the next block. Requires an API key.",
+				Value:       config.GasOracle_Blocknative,
+			}},
+		},
+
+		BlocknativeApiKey: config.Parameter{
+			ID:                   "blocknativeApiKey",
+			Name:                 "Blocknative API Key",
+			Description:          "The API key for your https://www.blocknative.com/ account, used to get gas price suggestions from Blocknative.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableHttpApi: config.Parameter{
+			ID:                   "enableHttpApi",
+			Name:                 "Enable HTTP API",
+			Description:          "Serve the Smartnode's API over HTTP from the node container, on the `api.sock` socket in your data folder. Dashboards and other tools can use it instead of running the CLI; see `/api/v1/openapi.json` for its specification. `/api/v1/events` pushes the node's events, such as minipool status changes and confirmed transactions, over a WebSocket.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		HttpApiPort: config.Parameter{
+			ID:                   "httpApiPort",
+			Name:                 "HTTP API Port",
+			Description:          "The TCP port to also serve the HTTP API on, inside the node container. Requests on this port must include an API token as a bearer token: either the admin token in the `api-token` file in your data folder, or one with a more limited role created with `rocketpool service api-tokens create`. Use 0 to only serve it on the socket.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: uint16(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		HttpApiAddress: config.Parameter{
+			ID:                   "httpApiAddress",
+			Name:                 "HTTP API Address",
+			Description:          "The address to bind the HTTP API's TCP port to, inside the node container. The default only accepts connections from the container itself. Any other address serves the API to the network, so it requires TLS: put the certificate and its key in your data folder as `api-tls.crt` and `api-tls.key`, and use a certificate your clients trust.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "127.0.0.1"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableGrpcApi: config.Parameter{
+			ID:                   "enableGrpcApi",
+			Name:                 "Enable gRPC API",
+			Description:          "Serve the Smartnode's gRPC API from the node container, on the `grpc.sock` socket in your data folder. It covers node status, minipool and wallet operations, and state queries, and can stream state updates and the daemon's logs. Clients for any language can be generated from `smartnode.proto`.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		GrpcApiPort: config.Parameter{
+			ID:                   "grpcApiPort",
+			Name:                 "gRPC API Port",
+			Description:          "The TCP port to also serve the gRPC API on, inside the node container. Calls on this port must include an API token as a bearer token in their `authorization` metadata: either the admin token in the `api-token` file in your data folder, or one with a more limited role created with `rocketpool service api-tokens create`. Use 0 to only serve it on the socket.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: uint16(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ApiRateLimit: config.Parameter{
+			ID:                   "apiRateLimit",
+			Name:                 "API Rate Limit",
+			Description:          "The number of requests each client can make to the HTTP and gRPC APIs per minute, so a misbehaving integration can't overload your clients. Use 0 to disable the limit.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(120)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ApiMaxQueuedRequests: config.Parameter{
+			ID:                   "apiMaxQueuedRequests",
+			Name:                 "API Queue Size",
+			Description:          "Expensive API requests, such as ones that build the full network state or a rewards tree, run one at a time so they don't starve the node's duties. This is how many of them can wait for their turn; any more are rejected until the queue clears.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(4)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableAlerts: config.Parameter{
+			ID:                   "enableAlerts",
+			Name:                 "Enable Alerts",
+			Description:          "Have the node daemon watch for problems with your node (validators going offline, being penalized or slashed, minipool penalties, low collateral, a low node wallet balance, clients falling out of sync, and the disk filling up) and raise an alert when one starts and when it's resolved. Alerts are always written to the node's logs, and can also be sent to a webhook, Discord, Slack or Telegram.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AlertValidatorOfflineEpochs: config.Parameter{
+			ID:                   "alertValidatorOfflineEpochs",
+			Name:                 "Offline Validator Epochs",
+			Description:          "Raise an alert when one of your active validators goes this many epochs without its balance going up, which usually means it's offline. Use 0 to disable this alert.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(3)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AlertMinCollateralRatio: config.Parameter{
+			ID:                   "alertMinCollateralRatio",
+			Name:                 "Collateral Alert Threshold",
+			Description:          "Raise an alert when the value of your staked RPL drops below this percent of the ETH your minipools have borrowed. Your node stops earning RPL rewards below 10%, so this gives you some warning before that happens. Use 0 to disable this alert.",
 			Type:                 config.ParameterType_Float,
-			Default:              map[config.Network]interface{}{config.Network_All: float64(1)},
+			Default:              map[config.Network]interface{}{config.Network_All: float64(12)},
 			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
 
-		RewardsTreeMode: config.Parameter{
-			ID:                   "rewardsTreeMode",
-			Name:                 "Rewards Tree Mode",
-			Description:          "Select how you want to acquire the Merkle Tree files for each rewards interval.",
-			Type:                 config.ParameterType_Choice,
-			Default:              map[config.Network]interface{}{config.Network_All: config.RewardsMode_Download},
+		AlertMinWalletBalance: config.Parameter{
+			ID:                   "alertMinWalletBalance",
+			Name:                 "Wallet Balance Alert Threshold",
+			Description:          "Raise an alert when your node wallet has less than this much ETH, since it needs ETH to pay for transactions such as staking minipools. Use 0 to disable this alert.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0.05)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AlertMinFreeDiskSpace: config.Parameter{
+			ID:                   "alertMinFreeDiskSpace",
+			Name:                 "Disk Space Alert Threshold",
+			Description:          "Raise an alert when the disk holding your data folder has less than this percent of its space free. Use 0 to disable this alert.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(10)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AlertBalanceDrop: config.Parameter{
+			ID:                   "alertBalanceDrop",
+			Name:                 "Balance Drop Alert Threshold",
+			Description:          "Raise an alert when one of your validators' balance falls this far (in ETH) below its highest point since its last withdrawal, which means it's being penalized heavily or has been slashed. Slashings and on-chain minipool penalties are always alerted on. Use 0 to disable this alert.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0.01)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AlertWebhookUrl: config.Parameter{
+			ID:                   "alertWebhookUrl",
+			Name:                 "Alert Webhook URL",
+			Description:          "A URL to send each alert to as a JSON POST request, for your own alerting or paging service. Leave this blank to only write alerts to the node's logs.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableEventNotifications: config.Parameter{
+			ID:                   "enableEventNotifications",
+			Name:                 "Enable Event Notifications",
+			Description:          "Along with alerts, send notable events to your notification services: rewards being claimed, minipools being promoted, and (for the Oracle DAO) rewards trees being submitted.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NotificationTemplate: config.Parameter{
+			ID:                   "notificationTemplate",
+			Name:                 "Notification Template",
+			Description:          "The template for the messages sent to Discord, Slack and Telegram, in Go's `text/template` format. It can use `.Rule`, `.Key`, `.Severity` (`info` for events, `warning` or `critical` for alerts), `.Summary`, `.Resolved`, `.StartTime` and `.Time`. For example, add your node's name in front so you can tell several nodes apart.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: DefaultNotificationTemplate},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DiscordWebhookUrl: config.Parameter{
+			ID:                   "discordWebhookUrl",
+			Name:                 "Discord Webhook URL",
+			Description:          "The URL of a Discord webhook to send alerts and events to. Create one in the channel's settings, under Integrations. Leave this blank to disable Discord notifications.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SlackWebhookUrl: config.Parameter{
+			ID:                   "slackWebhookUrl",
+			Name:                 "Slack Webhook URL",
+			Description:          "The URL of a Slack incoming webhook to send alerts and events to. Leave this blank to disable Slack notifications.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TelegramBotToken: config.Parameter{
+			ID:                   "telegramBotToken",
+			Name:                 "Telegram Bot Token",
+			Description:          "The token of the Telegram bot that sends your alerts and events, which you get from @BotFather when you create it. Leave this blank to disable Telegram notifications.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TelegramChatId: config.Parameter{
+			ID:                   "telegramChatId",
+			Name:                 "Telegram Chat ID",
+			Description:          "The ID of the Telegram chat or channel the bot sends your alerts and events to. The bot must be a member of it.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		HeartbeatUrl: config.Parameter{
+			ID:                   "heartbeatUrl",
+			Name:                 "Heartbeat URL",
+			Description:          "The URL of a dead man's switch (such as a healthchecks.io check) that the node daemon pings every time it finishes a duty cycle. If the pings stop, the daemon has stopped working (for example because it crashed or can't reach your clients) and the service can page you.\n\nLeave this blank to disable it.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NodeTasksHeartbeatUrl: config.Parameter{
+			ID:                   "nodeTasksHeartbeatUrl",
+			Name:                 "Node Tasks Heartbeat URL",
+			Description:          "The URL of a dead man's switch that the node daemon pings only when every one of its tasks (such as distributing balances and promoting minipools) succeeded in a duty cycle, so you're paged when a task keeps failing.\n\nLeave this blank to disable it.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerHeartbeatUrl: config.Parameter{
+			ID:                   "watchtowerHeartbeatUrl",
+			Name:                 "Watchtower Heartbeat URL",
+			Description:          "The URL of a dead man's switch that the watchtower pings when every one of its tasks succeeded in a duty cycle. Only useful for Oracle DAO members.\n\nLeave this blank to disable it.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerLeasePath: config.Parameter{
+			ID:                   "watchtowerLeasePath",
+			Name:                 "Watchtower Lease Path",
+			Description:          "Set this to run more than one watchtower for the same Oracle DAO node. Every instance must point to the same file on storage they all share (such as an NFS mount); the instance that holds the lease in it does the Oracle DAO duties, and the others stand by and take over if it goes silent.\n\nLeave this blank to run a single watchtower. Only useful for Oracle DAO members.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerLeaseTimeout: config.Parameter{
+			ID:                   "watchtowerLeaseTimeout",
+			Name:                 "Watchtower Lease Timeout",
+			Description:          "How long, in minutes, the primary watchtower can go without renewing its lease before a standby takes over its duties. It must be at least 12 minutes so it covers the longest wait between duty cycles plus the longest duty, and it should leave room for clock drift between the machines.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(15)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DistributeThreshold: config.Parameter{
+			ID:                   "distributeThreshold",
+			Name:                 "Auto-Distribute Threshold",
+			Description:          "The Smartnode will regularly check the balance of each of your minipools on the Execution Layer (**not** the Beacon Chain).\nIf any of them have a balance greater than this threshold (in ETH), the Smartnode will automatically distribute the balance. This will send your share of the balance to your withdrawal address.\n\nMust be less than 8 ETH.\n\nSet this to 0 to disable automatic distributes.\n[orange]WARNING: if you disable automatic distribution, you **must** ensure you distribute your minipool's balance before it reaches 8 ETH or you will no longer be able to distribute your rewards until you exit the minipool!",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(1)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		FeeDistributorThreshold: config.Parameter{
+			ID:                   "feeDistributorThreshold",
+			Name:                 "Fee Distributor Auto-Distribute Threshold",
+			Description:          "If your node's fee distributor has a balance greater than this threshold (in ETH), the Smartnode will automatically distribute it. This will send your share of the balance to your withdrawal address, and the rest to the rETH stakers.\n\nSet this to 0 to disable automatic distribution of your fee distributor.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DistributeMaxFee: config.Parameter{
+			ID:                   "distributeMaxFee",
+			Name:                 "Auto-Distribute Max Fee",
+			Description:          "The highest network gas price (in gwei) the Smartnode will pay to automatically distribute your minipools and fee distributor.\n\nSet this to 0 to use the Automatic TX Gas Threshold instead.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DistributeSchedule: config.Parameter{
+			ID:                   "distributeSchedule",
+			Name:                 "Auto-Distribute Schedule",
+			Description:          "The time of day, in UTC, that the Smartnode is allowed to automatically distribute your minipools and fee distributor, in the form `HH:MM-HH:MM` (for example, `02:00-06:00`). The window can wrap around midnight.\n\nLeave this blank to allow distributions at any time.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			Regex:                "^(([01][0-9]|2[0-3]):[0-5][0-9]-([01][0-9]|2[0-3]):[0-5][0-9])?$",
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoClaimRewards: config.Parameter{
+			ID:                   "autoClaimRewards",
+			Name:                 "Automatically Claim Rewards",
+			Description:          "Enable this to have the Smartnode claim your RPL and Smoothing Pool rewards automatically once each interval's rewards tree has been downloaded, following the restaking and sweeping policy below.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoClaimMaxFee: config.Parameter{
+			ID:                   "autoClaimMaxFee",
+			Name:                 "Auto-Claim Max Fee",
+			Description:          "The highest network gas price (in gwei) the Smartnode will pay to claim your rewards automatically. Claims wait until gas drops below it.\n\nSet this to 0 to use the Automatic TX Gas Threshold instead.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoClaimRestakeMode: config.Parameter{
+			ID:                   "autoClaimRestakeMode",
+			Name:                 "Auto-Claim Restaking",
+			Description:          "How much of the claimed RPL the Smartnode restakes when it claims your rewards automatically.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.RestakeMode_None},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "None",
+				Description: "Send all of the claimed RPL to your withdrawal address.",
+				Value:       config.RestakeMode_None,
+			}, {
+				Name:        "Percent",
+				Description: "Restake the Auto-Claim Restake Percent of the claimed RPL, and send the rest to your withdrawal address.",
+				Value:       config.RestakeMode_Percent,
+			}, {
+				Name:        "Collateral",
+				Description: "Restake as much of the claimed RPL as it takes to bring your collateral up to the Auto-Claim Target Collateral, and send the rest to your withdrawal address.",
+				Value:       config.RestakeMode_Collateral,
+			}},
+		},
+
+		AutoClaimRestakePercent: config.Parameter{
+			ID:                   "autoClaimRestakePercent",
+			Name:                 "Auto-Claim Restake Percent",
+			Description:          "The percent of the claimed RPL to restake when Auto-Claim Restaking is set to Percent.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(100)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoClaimTargetCollateral: config.Parameter{
+			ID:                   "autoClaimTargetCollateral",
+			Name:                 "Auto-Claim Target Collateral",
+			Description:          "When Auto-Claim Restaking is set to Collateral, restake claimed RPL until the value of your staked RPL reaches this percent of the ETH your minipools have borrowed. It's never staked past the maximum that earns rewards.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(15)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoClaimSweepAddress: config.Parameter{
+			ID:                   "autoClaimSweepAddress",
+			Name:                 "Auto-Claim Sweep Address",
+			Description:          "If this is set, the Smartnode sends the ETH it claims to this address. This only works when your withdrawal address is your node address, since that's where claimed ETH goes.\n\nLeave this blank to keep the claimed ETH in your withdrawal address.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			Regex:                "^(0x[0-9a-fA-F]{40})?$",
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoVotePolicy: config.Parameter{
+			ID:                   "autoVotePolicy",
+			Name:                 "Auto-Vote Policy",
+			Description:          "How the Smartnode votes on active Protocol DAO proposals for you. It only votes on single-choice proposals you haven't voted on yet, and it sends you a notification for every vote it casts.\n\nA vote cast by your node overrides your delegate's vote, so you'll also be notified when your node's vote differs from it.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.VotePolicy_None},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "None",
+				Description: "Don't vote automatically.",
+				Value:       config.VotePolicy_None,
+			}, {
+				Name:        "Follow",
+				Description: "Cast the same vote as the Auto-Vote Delegate once they've voted.",
+				Value:       config.VotePolicy_Follow,
+			}, {
+				Name:        "Abstain",
+				Description: "Vote Abstain on every proposal that has that choice.",
+				Value:       config.VotePolicy_Abstain,
+			}, {
+				Name:        "Rules",
+				Description: "Vote according to the Auto-Vote Rules, and skip the proposals that none of them match.",
+				Value:       config.VotePolicy_Rules,
+			}},
+		},
+
+		AutoVoteDelegate: config.Parameter{
+			ID:                   "autoVoteDelegate",
+			Name:                 "Auto-Vote Delegate",
+			Description:          "The address whose votes your node copies when the Auto-Vote Policy is set to Follow. This doesn't have to be your on-chain delegate.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			Regex:                "^(0x[0-9a-fA-F]{40})?$",
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoVoteRules: config.Parameter{
+			ID:                   "autoVoteRules",
+			Name:                 "Auto-Vote Rules",
+			Description:          "The rules used when the Auto-Vote Policy is set to Rules, in the form `keyword=choice;keyword=choice`. A proposal gets the choice of the first rule whose keyword is in its title, ignoring case; for example, `RPL inflation=Against;commission=Abstain`.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoBeginBondReduction: config.Parameter{
+			ID:                   "autoBeginBondReduction",
+			Name:                 "Automatically Reduce Bonds",
+			Description:          "Enable this to have the Smartnode begin reducing the bond of each of your 16-ETH minipools to 8 ETH as soon as it's eligible and your node has enough RPL staked to cover it. Once the Oracle DAO's scrub window has passed, the reduction is completed automatically.\n\nYou'll be alerted when a reduction begins, completes, is scrubbed by the Oracle DAO or times out. Transactions are only sent when gas is below the Automatic TX Gas Threshold.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		HardwareWallet: config.Parameter{
+			ID:                   "hardwareWallet",
+			Name:                 "Hardware Wallet",
+			Description:          "Select a hardware wallet to hold your node account instead of the node wallet on this machine. Every transaction your node sends will have to be approved on the device, so it must stay plugged into this machine.\n\nYour node wallet is still used for your validator keys.\n\n[orange]NOTE: The Smartnode can't approve transactions on its own while a hardware wallet is in use, so automatic transactions (such as staking prelaunch minipools and distributing minipool balances) are disabled. You'll need to run them yourself with the `rocketpool minipool` commands.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.HardwareWallet_None},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{"HARDWARE_WALLET"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "None",
+				Description: "Use the node wallet on this machine for your node account.",
+				Value:       config.HardwareWallet_None,
+			}, {
+				Name:        "Ledger",
+				Description: "Use a Ledger device for your node account. The Ethereum app must be open on the device when you send a transaction.",
+				Value:       config.HardwareWallet_Ledger,
+			}, {
+				Name:        "Trezor",
+				Description: "Use a Trezor device for your node account.",
+				Value:       config.HardwareWallet_Trezor,
+			}},
+		},
+
+		HardwareWalletPath: config.Parameter{
+			ID:                   "hardwareWalletPath",
+			Name:                 "Hardware Wallet Derivation Path",
+			Description:          "The derivation path of the account on your hardware wallet to use as your node account.\nThe default is the first account in Ledger Live and the Trezor Suite.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultHardwareWalletPath},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		KmsProvider: config.Parameter{
+			ID:                   "kmsProvider",
+			Name:                 "Node Account KMS",
+			Description:          "Select a key management service or HSM to hold your node account's key instead of the node wallet on this machine. The Smartnode will ask it to sign each transaction, so the key never leaves it.\nThe key must be a secp256k1 key, and your node account will be the address of that key.\n\nYour node wallet is still used for your validator keys.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.KmsProvider_None},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{"KMS_PROVIDER"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "None",
+				Description: "Use the node wallet on this machine for your node account.",
+				Value:       config.KmsProvider_None,
+			}, {
+				Name:        "PKCS#11",
+				Description: "Use a key on an HSM or other PKCS#11 token. OpenSC's `pkcs11-tool` must be installed, and the token's user PIN must be provided in the `PKCS11_PIN` environment variable.",
+				Value:       config.KmsProvider_Pkcs11,
+			}, {
+				Name:        "AWS KMS",
+				Description: "Use an ECC_SECG_P256K1 key in AWS KMS. Credentials are read from the `AWS_ACCESS_KEY_ID`, `AWS_SECRET_ACCESS_KEY` and (optionally) `AWS_SESSION_TOKEN` environment variables.",
+				Value:       config.KmsProvider_Aws,
+			}, {
+				Name:        "Google Cloud KMS",
+				Description: "Use an EC_SIGN_SECP256K1_SHA256 key in Google Cloud KMS. The access token is read from the `GOOGLE_OAUTH_ACCESS_TOKEN` environment variable if it's set, or requested from the machine's Google Cloud metadata server otherwise.",
+				Value:       config.KmsProvider_Gcp,
+			}},
+		},
+
+		KmsKeyId: config.Parameter{
+			ID:                   "kmsKeyId",
+			Name:                 "Node Account KMS Key ID",
+			Description:          "The ID of your node account's key.\n\nFor PKCS#11, this is the hex ID of the key on the token.\nFor AWS KMS, this is the key's ID or ARN.\nFor Google Cloud KMS, this is the key version's resource name (`projects/.../locations/.../keyRings/.../cryptoKeys/.../cryptoKeyVersions/...`).",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		KmsRegion: config.Parameter{
+			ID:                   "kmsRegion",
+			Name:                 "AWS KMS Region",
+			Description:          "The AWS region your node account's KMS key is in (for example, `us-east-1`). If this is blank, the `AWS_REGION` environment variable will be used.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Pkcs11Module: config.Parameter{
+			ID:                   "pkcs11Module",
+			Name:                 "PKCS#11 Module",
+			Description:          "The path to the PKCS#11 module (shared library) for your HSM or token, for example `/usr/lib/softhsm/libsofthsm2.so`.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{"PKCS11_MODULE"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SafeAddress: config.Parameter{
+			ID:                   "safeAddress",
+			Name:                 "Node Account Safe",
+			Description:          "The address of a Safe (formerly Gnosis Safe) multisig to use as your node account. Leave this blank to use your node wallet's account directly.\n\nWhen this is set, the Smartnode doesn't send any node transactions itself; it proposes them to the Safe with your node wallet's account as the proposer, and they only run once the Safe's owners approve and execute them. Your node wallet's account must be one of the Safe's owners or delegates.\n\nYour node wallet is still used for your validator keys.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SafeTransactionServiceUrl: config.Parameter{
+			ID:          "safeTransactionServiceUrl",
+			Name:        "Safe Transaction Service URL",
+			Description: "The URL of the Safe transaction service that node transactions are proposed to. Change this if you run your own instance of the service.",
+			Type:        config.ParameterType_String,
+			Default: map[config.Network]interface{}{
+				config.Network_Mainnet: "https://safe-transaction-mainnet.safe.global",
+				config.Network_Prater:  "https://safe-transaction-goerli.safe.global",
+				config.Network_Devnet:  "https://safe-transaction-goerli.safe.global",
+				config.Network_Holesky: "",
+			},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchOnlyAddress: config.Parameter{
+			ID:                   "watchOnlyAddress",
+			Name:                 "Watch-Only Node Address",
+			Description:          "The address of your node account, for a machine that doesn't have your node wallet. Leave this blank if your node wallet is on this machine.\n\nIf this is set and the node wallet hasn't been initialized here, the Smartnode uses this address as your node account so it can show your node's status and build transactions with `--unsigned` for you to sign on the machine that has your node wallet. It can't submit transactions or use your validator keys.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		UsePasswordKeychain: config.Parameter{
+			ID:                   "usePasswordKeychain",
+			Name:                 "Store Password in OS Keychain",
+			Description:          "[Native Mode only] Store your node wallet's password in your operating system's keychain (the macOS Keychain, the Windows Credential Manager, or the Secret Service keyring on Linux) instead of a plaintext file in your data folder.\nIf you already have a password file, it will be moved into the keychain and deleted the next time the Smartnode reads it.\n\n[orange]NOTE: On Linux, the daemons must run in a session with an unlocked Secret Service keyring (such as GNOME Keyring or KWallet) and the `secret-tool` command installed. Processes without a D-Bus session, such as daemons run by systemd, can't reach the keychain and will refuse to start rather than use a plaintext file; the units from `rocketpool service install --native` give them the password as an encrypted systemd credential instead.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsTreeMode: config.Parameter{
+			ID:                   "rewardsTreeMode",
+			Name:                 "Rewards Tree Mode",
+			Description:          "Select how you want to acquire the Merkle Tree files for each rewards interval.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.RewardsMode_Download},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Download",
+				Description: "Automatically download the Merkle Tree rewards files that were published by the Oracle DAO after a rewards checkpoint.",
+				Value:       config.RewardsMode_Download,
+			}, {
+				Name:        "Generate",
+				Description: "Use your node to automatically generate the Merkle Tree rewards file once a checkpoint has passed. This option lets you build and verify the file that the Oracle DAO created if you prefer not to trust it and want to generate the tree yourself.\n\n[orange]WARNING: Generating the tree can take a *very long time* if many node operators are opted into the Smoothing Pool, which could impact your attestation performance!",
+				Value:       config.RewardsMode_Generate,
+			}},
+		},
+
+		ArchiveECUrl: config.Parameter{
+			ID:                   "archiveECUrl",
+			Name:                 "Archive-Mode EC URL",
+			Description:          "[orange]**For manual Merkle rewards tree generation only.**[white]\n\nGenerating the Merkle rewards tree files for past rewards intervals typically requires an Execution client with Archive mode enabled, which is usually disabled on your primary and fallback Execution clients to save disk space.\nIf you want to generate your own rewards tree files for intervals from a long time ago, you may enter the URL of an Execution client with Archive access here.\n\nFor a free light client with Archive access, you may use https://www.alchemy.com/supernode.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Web3StorageApiToken: config.Parameter{
+			ID:                   "web3StorageApiToken",
+			Name:                 "Web3.Storage API Token",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The API token for your https://web3.storage/ account. This is required in order for you to upload Merkle rewards trees to Web3.Storage at each rewards interval.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerMaxFeeOverride: config.Parameter{
+			ID:                   "watchtowerMaxFeeOverride",
+			Name:                 "Watchtower Max Fee Override",
+			Description:          fmt.Sprintf("[orange]**For Oracle DAO members only.**\n\n[white]Use this to override the max fee (in gwei) for watchtower transactions. Note that if you set it below %d, the setting will be ignored; it can only be used to set the max fee higher than %d during times of extreme network stress.", WatchtowerMaxFeeDefault, WatchtowerMaxFeeDefault),
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(WatchtowerMaxFeeDefault)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
+
+		WatchtowerPrioFeeOverride: config.Parameter{
+			ID:                   "watchtowerPrioFeeOverride",
+			Name:                 "Watchtower Priority Fee Override",
+			Description:          fmt.Sprintf("[orange]**For Oracle DAO members only.**\n\n[white]Use this to override the priority fee (in gwei) for watchtower transactions. Note that if you set it below %d, the setting will be ignored; it can only be used to set the priority fee higher than %d during times of extreme network stress.", WatchtowerPrioFeeDefault, WatchtowerPrioFeeDefault),
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(WatchtowerPrioFeeDefault)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
+
+		UseRollingRecords: config.Parameter{
+			ID:                   "useRollingRecords",
+			Name:                 "Use Rolling Records",
+			Description:          "[orange]**WARNING: EXPERIMENTAL**\n\n[white]Enable this to use the new rolling records feature, which stores attestation records for the entire Rocket Pool network in real time instead of collecting them all after a rewards period during tree generation.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RecordCheckpointInterval: config.Parameter{
+			ID:                   "recordCheckpointInterval",
+			Name:                 "Record Checkpoint Interval",
+			Description:          "The number of epochs that should pass before saving a new rolling record checkpoint. Used if Rolling Records is enabled.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(45)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		CheckpointRetentionLimit: config.Parameter{
+			ID:                   "checkpointRetentionLimit",
+			Name:                 "Checkpoint Retention Limit",
+			Description:          "The number of checkpoint files to save on-disk before pruning old ones. Used if Rolling Records is enabled.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(200)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RecordsPath: config.Parameter{
+			ID:                   "recordsPath",
+			Name:                 "Records Path",
+			Description:          "The path of the folder to store rolling record checkpoints in during a rewards interval. Used if Rolling Records is enabled.\n\nOnly useful if you're an Oracle DAO member, or if you generate your own rewards trees.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: getDefaultRecordsDir(cfg)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		UseStreamingState: config.Parameter{
+			ID:                   "useStreamingState",
+			Name:                 "Use Streaming State",
+			Description:          "Enable this to build the network state used for rewards tree generation in small batches of nodes instead of loading every node and minipool at once. This keeps memory usage much lower on large networks, at the cost of a slower state snapshot.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TreegenWorkerCount: config.Parameter{
+			ID:                   "treegenWorkerCount",
+			Name:                 "Tree Generation Workers",
+			Description:          "The number of epochs to retrieve from your Beacon Node at the same time while scoring attestations during rewards tree generation. Higher values speed up generation on machines with spare CPU and a responsive Beacon Node, but put more load on it. The results are identical regardless of this setting.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(4)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		IpfsPinningMode: config.Parameter{
+			ID:                   "ipfsPinningMode",
+			Name:                 "IPFS Pinning Mode",
+			Description:          "Select where the watchtower should pin the rewards tree and minipool performance files after it generates them. The resulting CIDs are recorded next to the files in your rewards-trees folder.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees and want to help host them.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.IpfsPinningMode_Disabled},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Disabled",
+				Description: "Don't pin generated rewards files.",
+				Value:       config.IpfsPinningMode_Disabled,
+			}, {
+				Name:        "IPFS Node",
+				Description: "Pin the files to your own IPFS node (such as Kubo) using its HTTP API. Enter the API's URL in the `IPFS API URL` box.",
+				Value:       config.IpfsPinningMode_Kubo,
+			}, {
+				Name:        "Pinata",
+				Description: "Pin the files to https://pinata.cloud. Enter your Pinata JWT in the `Pinata API Token` box.",
+				Value:       config.IpfsPinningMode_Pinata,
+			}, {
+				Name:        "Web3.Storage",
+				Description: "Pin the files to https://web3.storage using the token in the `Web3.Storage API Token` box.",
+				Value:       config.IpfsPinningMode_Web3Storage,
+			}},
+		},
+
+		IpfsApiUrl: config.Parameter{
+			ID:                   "ipfsApiUrl",
+			Name:                 "IPFS API URL",
+			Description:          "The URL of your IPFS node's HTTP API, used when the IPFS Pinning Mode is set to `IPFS Node`.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "http://127.0.0.1:5001"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PinataApiToken: config.Parameter{
+			ID:                   "pinataApiToken",
+			Name:                 "Pinata API Token",
+			Description:          "The JWT for your https://pinata.cloud account, used when the IPFS Pinning Mode is set to `Pinata`.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsFileRetentionCount: config.Parameter{
+			ID:                   "rewardsFileRetentionCount",
+			Name:                 "Rewards File Retention",
+			Description:          "The number of the most recent rewards intervals to keep rewards tree and minipool performance files for. Files for older intervals will be deleted automatically to save disk space, except for intervals where your node still has unclaimed rewards.\n\nSet this to 0 to keep every file.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SaveSszRewardsFiles: config.Parameter{
+			ID:                   "saveSszRewardsFiles",
+			Name:                 "Save SSZ Rewards Files",
+			Description:          "Enable this to save an SSZ-encoded copy of each rewards tree (and minipool performance file, if you generate them) next to the JSON version when it's downloaded or generated. SSZ files are much smaller than JSON and far faster to load, so the Smartnode will use them instead of the JSON files when they're present.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableArweaveUpload: config.Parameter{
+			ID:                   "enableArweaveUpload",
+			Name:                 "Enable Arweave Upload",
+			Description:          "Enable this to upload the rewards tree and minipool performance files to Arweave through a Bundlr node after the watchtower generates them, as a permanent mirror alongside IPFS. The resulting transaction IDs are recorded next to the files in your rewards-trees folder.\n\nUploads are paid for by the wallet in the `Bundlr Private Key` box, which must have a funded balance on the Bundlr node.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BundlrNodeUrl: config.Parameter{
+			ID:                   "bundlrNodeUrl",
+			Name:                 "Bundlr Node URL",
+			Description:          "The URL of the Bundlr node to upload rewards files through when Arweave uploads are enabled.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "https://node1.bundlr.network"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BundlrPrivateKey: config.Parameter{
+			ID:                   "bundlrPrivateKey",
+			Name:                 "Bundlr Private Key",
+			Description:          "The hex-encoded private key of the Ethereum wallet that pays for Arweave uploads on the Bundlr node.\n\n[orange]WARNING: This key is stored in plain text in your configuration. Do NOT use your node wallet's key; use a separate wallet that only holds enough funds for uploads.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		GenerateMinipoolAttribution: config.Parameter{
+			ID:                   "generateMinipoolAttribution",
+			Name:                 "Generate Minipool Attribution Report",
+			Description:          "Enable this to save a per-minipool breakdown of the rewards next to each rewards tree the watchtower generates. It lists every minipool's bond, commission, attestation score, Smoothing Pool eligibility, and the share of its node's Smoothing Pool ETH and staking RPL it earned.\n\nThis is useful for nodes with a mix of minipool types that want to see which minipools earned what.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsFileMirrors: config.Parameter{
+			ID:                   "rewardsFileMirrors",
+			Name:                 "Rewards File Mirrors",
+			Description:          "A comma-separated list of URLs to download rewards tree files from, in the order they should be tried. If a download fails or the file doesn't match the Merkle root the Oracle DAO submitted, the next mirror is used.\n\nEach URL can use these placeholders:\n`{cid}`: the IPFS CID of the interval's files\n`{filename}`: the name of the rewards file, such as `rp-rewards-mainnet-10.json`\n`{network}`: the name of the network\n`{index}`: the interval number\n\nFiles from URLs ending in `.zst` (or starting with the zstd header) are decompressed automatically.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: strings.Join([]string{PrimaryRewardsFileUrl, SecondaryRewardsFileUrl, GithubRewardsFileUrl}, ",")},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ReadOnlyEcUrls: config.Parameter{
+			ID:                   "readOnlyEcUrls",
+			Name:                 "Read-Only EC URLs",
+			Description:          "A comma-separated list of extra Execution client URLs that the Smartnode can spread read-only calls across, along with your primary Execution client. This is useful for heavy tasks like building the network state, which make a large number of contract calls.\n\nOnly calls against a specific historical block are sent to these clients, so different sync heads can't produce an inconsistent view. Transactions and anything that depends on the latest block are always sent to your primary (or fallback) client.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ReadOnlyEcRateLimit: config.Parameter{
+			ID:                   "readOnlyEcRateLimit",
+			Name:                 "Read-Only EC Rate Limit",
+			Description:          "The maximum number of read-only requests per second the Smartnode will send to each Execution client in the read-only pool, including your primary client. Use this to stay within the limits of hosted providers.\n\nA value of 0 means there is no limit.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BeaconValidatorChunkSize: config.Parameter{
+			ID:                   "beaconValidatorChunkSize",
+			Name:                 "Beacon Validator Chunk Size",
+			Description:          "The number of validators the Smartnode will request from your Beacon node in a single call when looking up large sets of validators, such as when building the network state.\n\nLower this if your Beacon node rejects or times out on these requests.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(600)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BeaconValidatorConcurrency: config.Parameter{
+			ID:                   "beaconValidatorConcurrency",
+			Name:                 "Beacon Validator Concurrency",
+			Description:          "The maximum number of validator lookups the Smartnode will send to your Beacon node at the same time.\n\nLower this if your Beacon node struggles under load while the network state is being built.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(12)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BeaconSszSupport: config.Parameter{
+			ID:                   "beaconSszSupport",
+			Name:                 "Beacon Node Supports SSZ",
+			Description:          "Enable this if your Beacon node can serve blocks and states encoded with SSZ, which is much faster for the Smartnode to process than JSON.\n\nDisable it if your Beacon node only serves JSON, so the Smartnode doesn't waste a request trying SSZ first. `rocketpool service detect-external-clients` sets this automatically for externally-managed clients.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		UseEcWebsocket: config.Parameter{
+			ID:                   "useEcWebsocket",
+			Name:                 "Use EC Websocket",
+			Description:          "Enable this to have the Smartnode's daemons connect to your Execution client's websocket endpoint and subscribe to new blocks and logs, so they react to new blocks immediately instead of polling for them.\n\nIf the websocket isn't available, the daemons will fall back to polling over HTTP.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
 			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
-			Options: []config.ParameterOption{{
-				Name:        "Download",
-				Description: "Automatically download the Merkle Tree rewards files that were published by the Oracle DAO after a rewards checkpoint.",
-				Value:       config.RewardsMode_Download,
-			}, {
-				Name:        "Generate",
-				Description: "Use your node to automatically generate the Merkle Tree rewards file once a checkpoint has passed. This option lets you build and verify the file that the Oracle DAO created if you prefer not to trust it and want to generate the tree yourself.\n\n[orange]WARNING: Generating the tree can take a *very long time* if many node operators are opted into the Smoothing Pool, which could impact your attestation performance!",
-				Value:       config.RewardsMode_Generate,
-			}},
 		},
 
-		ArchiveECUrl: config.Parameter{
-			ID:                   "archiveECUrl",
-			Name:                 "Archive-Mode EC URL",
-			Description:          "[orange]**For manual Merkle rewards tree generation only.**[white]\n\nGenerating the Merkle rewards tree files for past rewards intervals typically requires an Execution client with Archive mode enabled, which is usually disabled on your primary and fallback Execution clients to save disk space.\nIf you want to generate your own rewards tree files for intervals from a long time ago, you may enter the URL of an Execution client with Archive access here.\n\nFor a free light client with Archive access, you may use https://www.alchemy.com/supernode.",
-			Type:                 config.ParameterType_String,
-			Default:              map[config.Network]interface{}{config.Network_All: ""},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+		VerifyBeaconWithLightClient: config.Parameter{
+			ID:                   "verifyBeaconWithLightClient",
+			Name:                 "Check Beacon Chain with Light Client",
+			Description:          "Enable this to have the Smartnode verify finalized Beacon headers with a sync committee light client, and only use a Consensus client if its state root at the latest verified finalized slot matches. Validator statuses and balances are only read from whole Beacon states whose roots match the verified ones. This is useful if you use a third-party Consensus client provider, since it catches a client that's following the wrong chain or serving false validator data.\n\n[orange]NOTE: Validators are read from the latest verified finalized state (about two epochs behind the head), and each new state is downloaded in full and hashed, which takes a lot more bandwidth, memory and time. Other responses such as blocks and duties are only covered by the state root check.[white]\n\nThis requires a trusted block root to start from, and your Consensus client must serve the light client API.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
-			CanBeBlank:           true,
+			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
 
-		Web3StorageApiToken: config.Parameter{
-			ID:                   "web3StorageApiToken",
-			Name:                 "Web3.Storage API Token",
-			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The API token for your https://web3.storage/ account. This is required in order for you to upload Merkle rewards trees to Web3.Storage at each rewards interval.",
+		LightClientTrustedRoot: config.Parameter{
+			ID:                   "lightClientTrustedRoot",
+			Name:                 "Light Client Trusted Block Root",
+			Description:          "The root of a recent finalized Beacon block, taken from a source you trust (such as a block explorer or a friend's node), that the light client starts verifying from. It must be within the weak subjectivity period, so update it if the Smartnode has been offline for a few months.",
 			Type:                 config.ParameterType_String,
 			Default:              map[config.Network]interface{}{config.Network_All: ""},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           true,
 			OverwriteOnUpgrade:   false,
 		},
 
-		WatchtowerMaxFeeOverride: config.Parameter{
-			ID:                   "watchtowerMaxFeeOverride",
-			Name:                 "Watchtower Max Fee Override",
-			Description:          fmt.Sprintf("[orange]**For Oracle DAO members only.**\n\n[white]Use this to override the max fee (in gwei) for watchtower transactions. Note that if you set it below %d, the setting will be ignored; it can only be used to set the max fee higher than %d during times of extreme network stress.", WatchtowerMaxFeeDefault, WatchtowerMaxFeeDefault),
-			Type:                 config.ParameterType_Float,
-			Default:              map[config.Network]interface{}{config.Network_All: float64(WatchtowerMaxFeeDefault)},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+		FastCallTimeout: config.Parameter{
+			ID:                   "fastCallTimeout",
+			Name:                 "Fast Call Timeout",
+			Description:          "The number of seconds to wait for quick client queries, such as sync status checks, the chain head, nonces and gas prices before giving up. Use 0 for no timeout.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(10)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
-			OverwriteOnUpgrade:   true,
+			OverwriteOnUpgrade:   false,
 		},
 
-		WatchtowerPrioFeeOverride: config.Parameter{
-			ID:                   "watchtowerPrioFeeOverride",
-			Name:                 "Watchtower Priority Fee Override",
-			Description:          fmt.Sprintf("[orange]**For Oracle DAO members only.**\n\n[white]Use this to override the priority fee (in gwei) for watchtower transactions. Note that if you set it below %d, the setting will be ignored; it can only be used to set the priority fee higher than %d during times of extreme network stress.", WatchtowerPrioFeeDefault, WatchtowerPrioFeeDefault),
-			Type:                 config.ParameterType_Float,
-			Default:              map[config.Network]interface{}{config.Network_All: float64(WatchtowerPrioFeeDefault)},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+		FastCallRetries: config.Parameter{
+			ID:                   "fastCallRetries",
+			Name:                 "Fast Call Retries",
+			Description:          "The number of times to retry quick client queries, such as sync status checks, the chain head, nonces and gas prices if the client couldn't be reached or timed out.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(1)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
-			OverwriteOnUpgrade:   true,
+			OverwriteOnUpgrade:   false,
 		},
 
-		UseRollingRecords: config.Parameter{
-			ID:                   "useRollingRecords",
-			Name:                 "Use Rolling Records",
-			Description:          "[orange]**WARNING: EXPERIMENTAL**\n\n[white]Enable this to use the new rolling records feature, which stores attestation records for the entire Rocket Pool network in real time instead of collecting them all after a rewards period during tree generation.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees.",
-			Type:                 config.ParameterType_Bool,
-			Default:              map[config.Network]interface{}{config.Network_All: false},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+		HeavyCallTimeout: config.Parameter{
+			ID:                   "heavyCallTimeout",
+			Name:                 "Heavy Call Timeout",
+			Description:          "The number of seconds to wait for large client queries, such as validator lists, committees, event logs and the contract calls made during rewards tree generation before giving up. Use 0 for no timeout.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(600)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
 
-		RecordCheckpointInterval: config.Parameter{
-			ID:                   "recordCheckpointInterval",
-			Name:                 "Record Checkpoint Interval",
-			Description:          "The number of epochs that should pass before saving a new rolling record checkpoint. Used if Rolling Records is enabled.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees.",
+		HeavyCallRetries: config.Parameter{
+			ID:                   "heavyCallRetries",
+			Name:                 "Heavy Call Retries",
+			Description:          "The number of times to retry large client queries, such as validator lists, committees, event logs and the contract calls made during rewards tree generation if the client couldn't be reached or timed out.",
 			Type:                 config.ParameterType_Uint,
-			Default:              map[config.Network]interface{}{config.Network_All: uint64(45)},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(2)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
 
-		CheckpointRetentionLimit: config.Parameter{
-			ID:                   "checkpointRetentionLimit",
-			Name:                 "Checkpoint Retention Limit",
-			Description:          "The number of checkpoint files to save on-disk before pruning old ones. Used if Rolling Records is enabled.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees.",
+		SubmitCallTimeout: config.Parameter{
+			ID:                   "submitCallTimeout",
+			Name:                 "Submit Call Timeout",
+			Description:          "The number of seconds to wait when submitting transactions and signed messages (such as voluntary exits) to your clients before giving up. Use 0 for no timeout.",
 			Type:                 config.ParameterType_Uint,
-			Default:              map[config.Network]interface{}{config.Network_All: uint64(200)},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(30)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
 
-		RecordsPath: config.Parameter{
-			ID:                   "recordsPath",
-			Name:                 "Records Path",
-			Description:          "The path of the folder to store rolling record checkpoints in during a rewards interval. Used if Rolling Records is enabled.\n\nOnly useful if you're an Oracle DAO member, or if you generate your own rewards trees.",
-			Type:                 config.ParameterType_String,
-			Default:              map[config.Network]interface{}{config.Network_All: getDefaultRecordsDir(cfg)},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+		SubmitCallRetries: config.Parameter{
+			ID:                   "submitCallRetries",
+			Name:                 "Submit Call Retries",
+			Description:          "The number of times to retry submitting transactions and signed messages (such as voluntary exits) to your clients if the client couldn't be reached or timed out. Resubmitting is safe because the same signed transaction is sent again, but it may be reported as already known.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
@@ -624,11 +1949,63 @@ func (cfg *SmartnodeConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.Network,
 		&cfg.ProjectName,
+		&cfg.ContainerRuntime,
+		&cfg.ContainerSocketPath,
 		&cfg.DataPath,
 		&cfg.ManualMaxFee,
 		&cfg.PriorityFee,
 		&cfg.AutoTxGasThreshold,
+		&cfg.GasOracle,
+		&cfg.BlocknativeApiKey,
+		&cfg.EnableHttpApi,
+		&cfg.HttpApiPort,
+		&cfg.HttpApiAddress,
+		&cfg.EnableGrpcApi,
+		&cfg.GrpcApiPort,
+		&cfg.ApiRateLimit,
+		&cfg.ApiMaxQueuedRequests,
+		&cfg.EnableAlerts,
+		&cfg.AlertValidatorOfflineEpochs,
+		&cfg.AlertMinCollateralRatio,
+		&cfg.AlertMinWalletBalance,
+		&cfg.AlertMinFreeDiskSpace,
+		&cfg.AlertBalanceDrop,
+		&cfg.AlertWebhookUrl,
+		&cfg.EnableEventNotifications,
+		&cfg.NotificationTemplate,
+		&cfg.DiscordWebhookUrl,
+		&cfg.SlackWebhookUrl,
+		&cfg.TelegramBotToken,
+		&cfg.TelegramChatId,
+		&cfg.HeartbeatUrl,
+		&cfg.NodeTasksHeartbeatUrl,
+		&cfg.WatchtowerHeartbeatUrl,
+		&cfg.WatchtowerLeasePath,
+		&cfg.WatchtowerLeaseTimeout,
 		&cfg.DistributeThreshold,
+		&cfg.FeeDistributorThreshold,
+		&cfg.DistributeMaxFee,
+		&cfg.DistributeSchedule,
+		&cfg.AutoClaimRewards,
+		&cfg.AutoClaimMaxFee,
+		&cfg.AutoClaimRestakeMode,
+		&cfg.AutoClaimRestakePercent,
+		&cfg.AutoClaimTargetCollateral,
+		&cfg.AutoClaimSweepAddress,
+		&cfg.AutoVotePolicy,
+		&cfg.AutoVoteDelegate,
+		&cfg.AutoVoteRules,
+		&cfg.AutoBeginBondReduction,
+		&cfg.HardwareWallet,
+		&cfg.HardwareWalletPath,
+		&cfg.KmsProvider,
+		&cfg.KmsKeyId,
+		&cfg.KmsRegion,
+		&cfg.Pkcs11Module,
+		&cfg.SafeAddress,
+		&cfg.SafeTransactionServiceUrl,
+		&cfg.WatchOnlyAddress,
+		&cfg.UsePasswordKeychain,
 		&cfg.RewardsTreeMode,
 		&cfg.ArchiveECUrl,
 		&cfg.Web3StorageApiToken,
@@ -638,6 +2015,32 @@ func (cfg *SmartnodeConfig) GetParameters() []*config.Parameter {
 		&cfg.RecordCheckpointInterval,
 		&cfg.CheckpointRetentionLimit,
 		&cfg.RecordsPath,
+		&cfg.UseStreamingState,
+		&cfg.TreegenWorkerCount,
+		&cfg.IpfsPinningMode,
+		&cfg.IpfsApiUrl,
+		&cfg.PinataApiToken,
+		&cfg.RewardsFileRetentionCount,
+		&cfg.SaveSszRewardsFiles,
+		&cfg.EnableArweaveUpload,
+		&cfg.BundlrNodeUrl,
+		&cfg.BundlrPrivateKey,
+		&cfg.GenerateMinipoolAttribution,
+		&cfg.RewardsFileMirrors,
+		&cfg.ReadOnlyEcUrls,
+		&cfg.ReadOnlyEcRateLimit,
+		&cfg.BeaconValidatorChunkSize,
+		&cfg.BeaconValidatorConcurrency,
+		&cfg.BeaconSszSupport,
+		&cfg.UseEcWebsocket,
+		&cfg.VerifyBeaconWithLightClient,
+		&cfg.LightClientTrustedRoot,
+		&cfg.FastCallTimeout,
+		&cfg.FastCallRetries,
+		&cfg.HeavyCallTimeout,
+		&cfg.HeavyCallRetries,
+		&cfg.SubmitCallTimeout,
+		&cfg.SubmitCallRetries,
 	}
 }
 
@@ -655,6 +2058,24 @@ func (cfg *SmartnodeConfig) GetChainID() uint {
 	return cfg.chainID[cfg.Network.Value.(config.Network)]
 }
 
+// Get the timeout and number of retries for each class of client call
+func (cfg *SmartnodeConfig) GetCallPolicies() map[config.CallClass]config.CallPolicy {
+	return map[config.CallClass]config.CallPolicy{
+		config.CallClass_Fast: {
+			Timeout: time.Duration(cfg.FastCallTimeout.Value.(uint64)) * time.Second,
+			Retries: int(cfg.FastCallRetries.Value.(uint64)),
+		},
+		config.CallClass_Heavy: {
+			Timeout: time.Duration(cfg.HeavyCallTimeout.Value.(uint64)) * time.Second,
+			Retries: int(cfg.HeavyCallRetries.Value.(uint64)),
+		},
+		config.CallClass_Submit: {
+			Timeout: time.Duration(cfg.SubmitCallTimeout.Value.(uint64)) * time.Second,
+			Retries: int(cfg.SubmitCallRetries.Value.(uint64)),
+		},
+	}
+}
+
 func (cfg *SmartnodeConfig) GetWalletPath() string {
 	if cfg.parent.IsNativeMode {
 		return filepath.Join(cfg.DataPath.Value.(string), "wallet")
@@ -671,6 +2092,14 @@ func (cfg *SmartnodeConfig) GetPasswordPath() string {
 	return filepath.Join(DaemonDataPath, "password")
 }
 
+func (cfg *SmartnodeConfig) GetWalletsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), WalletsFolder)
+	}
+
+	return filepath.Join(DaemonDataPath, WalletsFolder)
+}
+
 func (cfg *SmartnodeConfig) GetValidatorKeychainPath() string {
 	if cfg.parent.IsNativeMode {
 		return filepath.Join(cfg.DataPath.Value.(string), "validators")
@@ -707,6 +2136,14 @@ func (config *SmartnodeConfig) GetWatchtowerStatePath() string {
 	return filepath.Join(DaemonDataPath, WatchtowerFolder, "state.yml")
 }
 
+func (cfg *SmartnodeConfig) GetSafeProposalsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), SafeProposalsFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, SafeProposalsFilename)
+}
+
 func (cfg *SmartnodeConfig) GetCustomKeyPath() string {
 	if cfg.parent.IsNativeMode {
 		return filepath.Join(cfg.DataPath.Value.(string), "custom-keys")
@@ -715,6 +2152,182 @@ func (cfg *SmartnodeConfig) GetCustomKeyPath() string {
 	return filepath.Join(DaemonDataPath, "custom-keys")
 }
 
+func (cfg *SmartnodeConfig) GetKeyExportsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), KeyExportsFolder)
+	}
+
+	return filepath.Join(DaemonDataPath, KeyExportsFolder)
+}
+
+func (cfg *SmartnodeConfig) GetKeyExportsPathInCLI() string {
+	return filepath.Join(cfg.DataPath.Value.(string), KeyExportsFolder)
+}
+
+func (cfg *SmartnodeConfig) GetUnsignedTxsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), UnsignedTxsFolder)
+	}
+
+	return filepath.Join(DaemonDataPath, UnsignedTxsFolder)
+}
+
+func (cfg *SmartnodeConfig) GetUnsignedTxsPathInCLI() string {
+	return filepath.Join(cfg.DataPath.Value.(string), UnsignedTxsFolder)
+}
+
+func (cfg *SmartnodeConfig) GetDryRunTxsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), DryRunTxsFolder)
+	}
+
+	return filepath.Join(DaemonDataPath, DryRunTxsFolder)
+}
+
+func (cfg *SmartnodeConfig) GetDryRunTxsPathInCLI() string {
+	return filepath.Join(cfg.DataPath.Value.(string), DryRunTxsFolder)
+}
+
+func (cfg *SmartnodeConfig) GetDeferredTxsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), DeferredTxsFolder)
+	}
+
+	return filepath.Join(DaemonDataPath, DeferredTxsFolder)
+}
+
+func (cfg *SmartnodeConfig) GetMevRelayRecordPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), MevRelayRecordFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, MevRelayRecordFilename)
+}
+
+func (cfg *SmartnodeConfig) GetMevRelayRecordPathInCLI() string {
+	return filepath.Join(cfg.DataPath.Value.(string), MevRelayRecordFilename)
+}
+
+func (cfg *SmartnodeConfig) GetExitVaultPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), ExitVaultFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, ExitVaultFilename)
+}
+
+func (cfg *SmartnodeConfig) GetExitVaultPathInCLI() string {
+	return filepath.Join(cfg.DataPath.Value.(string), ExitVaultFilename)
+}
+
+func (cfg *SmartnodeConfig) GetScheduledExitsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), ScheduledExitsFolder)
+	}
+
+	return filepath.Join(DaemonDataPath, ScheduledExitsFolder)
+}
+
+func (cfg *SmartnodeConfig) GetPenaltyReportsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), PenaltyReportsFolder)
+	}
+
+	return filepath.Join(DaemonDataPath, PenaltyReportsFolder)
+}
+
+func (cfg *SmartnodeConfig) GetHttpApiSocketPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), HttpApiSocketFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, HttpApiSocketFilename)
+}
+
+func (cfg *SmartnodeConfig) GetGrpcApiSocketPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), GrpcApiSocketFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, GrpcApiSocketFilename)
+}
+
+func (cfg *SmartnodeConfig) GetHttpApiTokenPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), HttpApiTokenFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, HttpApiTokenFilename)
+}
+
+func (cfg *SmartnodeConfig) GetHttpApiTlsCertPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), HttpApiTlsCertFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, HttpApiTlsCertFilename)
+}
+
+func (cfg *SmartnodeConfig) GetHttpApiTlsKeyPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), HttpApiTlsKeyFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, HttpApiTlsKeyFilename)
+}
+
+func (cfg *SmartnodeConfig) GetApiTokensPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), ApiTokensFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, ApiTokensFilename)
+}
+
+func (cfg *SmartnodeConfig) GetChainMetadataCachePath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), ChainMetadataCacheFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, ChainMetadataCacheFilename)
+}
+
+func (cfg *SmartnodeConfig) GetWarmStartStatePath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), WarmStartStateFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, WarmStartStateFilename)
+}
+
+func (cfg *SmartnodeConfig) GetDaemonDatabasePath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), DaemonDatabaseFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, DaemonDatabaseFilename)
+}
+
+func (cfg *SmartnodeConfig) GetDaemonDataPath() string {
+	if cfg.parent.IsNativeMode {
+		return cfg.DataPath.Value.(string)
+	}
+
+	return DaemonDataPath
+}
+
+func (cfg *SmartnodeConfig) GetDistributedValidatorsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), DistributedValidatorsFolder)
+	}
+
+	return filepath.Join(DaemonDataPath, DistributedValidatorsFolder)
+}
+
+func (cfg *SmartnodeConfig) GetDistributedValidatorsPathInCLI() string {
+	return filepath.Join(cfg.DataPath.Value.(string), DistributedValidatorsFolder)
+}
+
 func (cfg *SmartnodeConfig) GetCustomKeyPasswordFilePath() string {
 	if cfg.parent.IsNativeMode {
 		return filepath.Join(cfg.DataPath.Value.(string), "custom-key-passwords")
@@ -800,6 +2413,30 @@ func (cfg *SmartnodeConfig) GetMinipoolPerformancePath(interval uint64, daemon b
 	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, fmt.Sprintf(MinipoolPerformanceFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
 }
 
+func (cfg *SmartnodeConfig) GetRewardsTreeSszPath(interval uint64, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, RewardsTreesFolder, fmt.Sprintf(RewardsTreeSszFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, fmt.Sprintf(RewardsTreeSszFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
+}
+
+func (cfg *SmartnodeConfig) GetMinipoolPerformanceSszPath(interval uint64, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, RewardsTreesFolder, fmt.Sprintf(MinipoolPerformanceSszFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, fmt.Sprintf(MinipoolPerformanceSszFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
+}
+
+func (cfg *SmartnodeConfig) GetPrunedRewardsFileMarkerPath(interval uint64, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, RewardsTreesFolder, fmt.Sprintf(PrunedRewardsFileMarkerFormat, string(cfg.Network.Value.(config.Network)), interval))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, fmt.Sprintf(PrunedRewardsFileMarkerFormat, string(cfg.Network.Value.(config.Network)), interval))
+}
+
 func (cfg *SmartnodeConfig) GetRegenerateRewardsTreeRequestPath(interval uint64, daemon bool) string {
 	if daemon && !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, WatchtowerFolder, fmt.Sprintf(RegenerateRewardsTreeRequestFormat, interval))
@@ -808,6 +2445,95 @@ func (cfg *SmartnodeConfig) GetRegenerateRewardsTreeRequestPath(interval uint64,
 	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder, fmt.Sprintf(RegenerateRewardsTreeRequestFormat, interval))
 }
 
+func (cfg *SmartnodeConfig) GetDryRunRewardsTreeRequestPath(interval uint64, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, WatchtowerFolder, fmt.Sprintf(DryRunRewardsTreeRequestFormat, interval))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder, fmt.Sprintf(DryRunRewardsTreeRequestFormat, interval))
+}
+
+func (cfg *SmartnodeConfig) GetArchiveRewardsTreeRequestPath(interval uint64, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, WatchtowerFolder, fmt.Sprintf(ArchiveRewardsTreeRequestFormat, interval))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder, fmt.Sprintf(ArchiveRewardsTreeRequestFormat, interval))
+}
+
+func (cfg *SmartnodeConfig) GetRewardsTreeArchiveFolder(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, RewardsTreesFolder, RewardsTreesArchiveFolder)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, RewardsTreesArchiveFolder)
+}
+
+func (cfg *SmartnodeConfig) GetArchivedRewardsTreePath(interval uint64, rulesetVersion uint64, daemon bool) string {
+	return filepath.Join(cfg.GetRewardsTreeArchiveFolder(daemon), fmt.Sprintf(ArchivedRewardsTreeFilenameFormat, string(cfg.Network.Value.(config.Network)), interval, rulesetVersion))
+}
+
+func (cfg *SmartnodeConfig) GetArchivedMinipoolPerformancePath(interval uint64, rulesetVersion uint64, daemon bool) string {
+	return filepath.Join(cfg.GetRewardsTreeArchiveFolder(daemon), fmt.Sprintf(ArchivedMinipoolPerformanceFormat, string(cfg.Network.Value.(config.Network)), interval, rulesetVersion))
+}
+
+// Get the path of the generation checkpoint for an interval; each generation flow gets its own file so concurrent generators don't clobber each other
+func (cfg *SmartnodeConfig) GetTreegenCheckpointPath(interval uint64, flow string, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, WatchtowerFolder, fmt.Sprintf(TreegenCheckpointFilenameFormat, string(cfg.Network.Value.(config.Network)), flow, interval))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder, fmt.Sprintf(TreegenCheckpointFilenameFormat, string(cfg.Network.Value.(config.Network)), flow, interval))
+}
+
+func (cfg *SmartnodeConfig) GetRewardsPinRecordPath(interval uint64, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, RewardsTreesFolder, fmt.Sprintf(RewardsPinRecordFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, fmt.Sprintf(RewardsPinRecordFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
+}
+
+func (cfg *SmartnodeConfig) GetRewardsArweaveRecordPath(interval uint64, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, RewardsTreesFolder, fmt.Sprintf(RewardsArweaveRecordFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, fmt.Sprintf(RewardsArweaveRecordFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
+}
+
+func (cfg *SmartnodeConfig) GetMinipoolAttributionPath(interval uint64, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, RewardsTreesFolder, fmt.Sprintf(MinipoolAttributionFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, fmt.Sprintf(MinipoolAttributionFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
+}
+
+// Get the URLs to download an interval's rewards file from, in the order they should be tried
+func (cfg *SmartnodeConfig) GetRewardsFileMirrorUrls(interval uint64, cid string) []string {
+	mirrors := cfg.RewardsFileMirrors.Value.(string)
+	if strings.TrimSpace(mirrors) == "" {
+		mirrors = cfg.RewardsFileMirrors.Default[config.Network_All].(string)
+	}
+
+	replacer := strings.NewReplacer(
+		"{cid}", cid,
+		"{filename}", fmt.Sprintf(RewardsTreeFilenameFormat, string(cfg.Network.Value.(config.Network)), interval),
+		"{network}", string(cfg.Network.Value.(config.Network)),
+		"{index}", fmt.Sprint(interval),
+	)
+	urls := []string{}
+	for _, mirror := range strings.Split(mirrors, ",") {
+		mirror = strings.TrimSpace(mirror)
+		if mirror == "" {
+			continue
+		}
+		urls = append(urls, replacer.Replace(mirror))
+	}
+	return urls
+}
+
 func (cfg *SmartnodeConfig) GetWatchtowerFolder(daemon bool) string {
 	if daemon && !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, WatchtowerFolder)
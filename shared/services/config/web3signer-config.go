@@ -0,0 +1,100 @@
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Constants
+const (
+	web3SignerUrlEnvVar string = "WEB3SIGNER_URL"
+)
+
+// Configuration for remote signing with Web3Signer
+type Web3SignerConfig struct {
+	Title string `yaml:"-"`
+
+	// The URL of the Web3Signer instance
+	Url config.Parameter `yaml:"url,omitempty"`
+
+	// The bearer token for Web3Signer's key manager API
+	AuthToken config.Parameter `yaml:"authToken,omitempty"`
+
+	// The name of an EIP-3076 slashing protection file in the data folder to submit with each key
+	SlashingProtectionFile config.Parameter `yaml:"slashingProtectionFile,omitempty"`
+
+	// The parent config
+	parentConfig *RocketPoolConfig `yaml:"-"`
+}
+
+// Generates a new Web3Signer configuration
+func NewWeb3SignerConfig(cfg *RocketPoolConfig) *Web3SignerConfig {
+	return &Web3SignerConfig{
+		Title: "Web3Signer Settings",
+
+		Url: config.Parameter{
+			ID:                   "url",
+			Name:                 "Web3Signer URL",
+			Description:          "The URL of your Web3Signer instance, including the port (for example: `https://192.168.1.50:9000`).\nIts key manager API must be enabled so the Smartnode can register your validator keys with it.\n\nYour validator keys are sent to this URL, so it must use `https` unless Web3Signer runs on this machine.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{web3SignerUrlEnvVar},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AuthToken: config.Parameter{
+			ID:                   "authToken",
+			Name:                 "Key Manager API Token",
+			Description:          "The bearer token for your Web3Signer's key manager API, if it requires one. The Smartnode sends it with every request that registers or lists your validator keys.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SlashingProtectionFile: config.Parameter{
+			ID:                   "slashingProtectionFile",
+			Name:                 "Slashing Protection File",
+			Description:          "If your validators have already been attesting with another Validator client, export its slashing protection database in the EIP-3076 interchange format and put it in your Smartnode's data folder, then enter its file name here (for example: `slashing-protection.json`).\nIt will be submitted to Web3Signer along with each key you register so Web3Signer refuses to sign anything that conflicts with your validators' history.\n\nLeave this blank for brand new validators.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		parentConfig: cfg,
+	}
+}
+
+// Get the parameters for this config
+func (cfg *Web3SignerConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Url,
+		&cfg.AuthToken,
+		&cfg.SlashingProtectionFile,
+	}
+}
+
+// The the title for the config
+func (cfg *Web3SignerConfig) GetConfigTitle() string {
+	return cfg.Title
+}
+
+// Get the path of the slashing protection file to submit with each key, or an empty string if there isn't one
+func (cfg *Web3SignerConfig) GetSlashingProtectionPath() string {
+	filename := cfg.SlashingProtectionFile.Value.(string)
+	if filename == "" {
+		return ""
+	}
+	if cfg.parentConfig.IsNativeMode {
+		return filepath.Join(cfg.parentConfig.Smartnode.DataPath.Value.(string), filename)
+	}
+	return filepath.Join(DaemonDataPath, filename)
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fatih/color"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Timing for the head watcher
+const (
+	// How often to poll for a new block when the websocket isn't available
+	ecHeadPollInterval time.Duration = 12 * time.Second
+
+	// How long to wait before resubscribing after the websocket subscription drops
+	ecHeadResubscribeDelay time.Duration = 15 * time.Second
+)
+
+// Watches the Execution client for new blocks and keeps track of the latest one.
+// It uses a newHeads subscription over the EC's websocket when one is configured, and polls over HTTP otherwise.
+type ExecutionHeadWatcher struct {
+	ec        *ExecutionClientManager
+	logger    log.ColorLogger
+	latest    *types.Header
+	lock      sync.Mutex
+	startOnce sync.Once
+}
+
+// Creates a new head watcher on top of an Execution client manager; it doesn't start watching until Start is called
+func NewExecutionHeadWatcher(ec *ExecutionClientManager) *ExecutionHeadWatcher {
+	return &ExecutionHeadWatcher{
+		ec:     ec,
+		logger: log.NewColorLogger(color.FgYellow),
+	}
+}
+
+// Start watching for new blocks, if the watcher isn't running already
+func (w *ExecutionHeadWatcher) Start() {
+	w.startOnce.Do(func() {
+		go w.run()
+	})
+}
+
+// Get the latest header the watcher has seen, or nil if it hasn't seen one yet
+func (w *ExecutionHeadWatcher) LatestHeader() *types.Header {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.latest
+}
+
+// Watch for new blocks, preferring the websocket subscription and falling back to polling
+func (w *ExecutionHeadWatcher) run() {
+	for {
+		if w.ec.HasWebsocket() {
+			err := w.subscribe()
+			w.logger.Printlnf("WARNING: Execution client newHeads subscription dropped (%s), polling for new blocks for %s before resubscribing.", err.Error(), ecHeadResubscribeDelay)
+			w.poll(time.Now().Add(ecHeadResubscribeDelay))
+		} else {
+			w.poll(time.Time{})
+		}
+	}
+}
+
+// Follow new blocks through the websocket subscription until it drops
+func (w *ExecutionHeadWatcher) subscribe() error {
+	headers := make(chan *types.Header, 16)
+	sub, err := w.ec.SubscribeNewHead(context.Background(), headers)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case header := <-headers:
+			w.publish(header)
+		case err := <-sub.Err():
+			return err
+		}
+	}
+}
+
+// Poll for new blocks over HTTP until the deadline, or forever if there isn't one
+func (w *ExecutionHeadWatcher) poll(deadline time.Time) {
+	for deadline.IsZero() || time.Now().Before(deadline) {
+		header, err := w.ec.HeaderByNumber(context.Background(), nil)
+		if err == nil {
+			w.publish(header)
+		}
+		time.Sleep(ecHeadPollInterval)
+	}
+}
+
+// Record a new header as the latest one
+func (w *ExecutionHeadWatcher) publish(header *types.Header) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.latest = header
+}
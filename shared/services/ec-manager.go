@@ -5,18 +5,22 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/fatih/color"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
+	netutils "github.com/rocket-pool/smartnode/shared/utils/net"
 )
 
 // This is a proxy for multiple ETH clients, providing natural fallback support if one of them fails.
@@ -29,10 +33,23 @@ type ExecutionClientManager struct {
 	primaryReady    bool
 	fallbackReady   bool
 	ignoreSyncCheck bool
+	readPool        *ecReadPool
+	wsEcUrl         string
+	wsEc            *ethclient.Client
+	primaryHealth   *clientHealth
+	fallbackHealth  *clientHealth
+	callPolicies    map[cfgtypes.CallClass]cfgtypes.CallPolicy
+	metadataCache   *chainMetadataCache
 }
 
-// This is a signature for a wrapped ethclient.Client function
-type ecFunction func(*ethclient.Client) (interface{}, error)
+// This is a signature for a wrapped ethclient.Client function; the context carries the call's timeout
+type ecFunction func(context.Context, *ethclient.Client) (interface{}, error)
+
+// How long to wait before retrying a failed call, multiplied by the attempt number
+const ecCallRetryDelay time.Duration = time.Second
+
+// Long-lived subscriptions, which never time out or retry
+const callClass_Stream cfgtypes.CallClass = "stream"
 
 // Creates a new ExecutionClientManager instance based on the Rocket Pool config
 func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientManager, error) {
@@ -64,28 +81,57 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 		}
 	}
 
-	primaryEc, err := ethclient.Dial(primaryEcUrl)
+	primaryEc, err := dialExecutionClient(primaryEcUrl, "Primary")
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to primary EC at [%s]: %w", primaryEcUrl, err)
 	}
 
 	var fallbackEc *ethclient.Client
 	if fallbackEcUrl != "" {
-		fallbackEc, err = ethclient.Dial(fallbackEcUrl)
+		fallbackEc, err = dialExecutionClient(fallbackEcUrl, "Fallback")
 		if err != nil {
 			return nil, fmt.Errorf("error connecting to fallback EC at [%s]: %w", fallbackEcUrl, err)
 		}
 	}
 
-	return &ExecutionClientManager{
-		primaryEcUrl:  primaryEcUrl,
-		fallbackEcUrl: fallbackEcUrl,
-		primaryEc:     primaryEc,
-		fallbackEc:    fallbackEc,
-		logger:        log.NewColorLogger(color.FgYellow),
-		primaryReady:  true,
-		fallbackReady: fallbackEc != nil,
-	}, nil
+	manager := &ExecutionClientManager{
+		primaryEcUrl:   primaryEcUrl,
+		fallbackEcUrl:  fallbackEcUrl,
+		primaryEc:      primaryEc,
+		fallbackEc:     fallbackEc,
+		logger:         log.NewColorLogger(color.FgYellow),
+		primaryReady:   true,
+		fallbackReady:  fallbackEc != nil,
+		primaryHealth:  newClientHealth(),
+		fallbackHealth: newClientHealth(),
+		callPolicies:   cfg.Smartnode.GetCallPolicies(),
+	}
+
+	// Set up the pool for spreading out read-only calls
+	manager.readPool, err = newEcReadPool(cfg.Smartnode.ReadOnlyEcUrls.Value.(string), cfg.Smartnode.ReadOnlyEcRateLimit.Value.(uint64), &manager.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Connect to the websocket for subscriptions if it's enabled; subscribers fall back to polling without it
+	if cfg.Smartnode.UseEcWebsocket.Value == true {
+		if cfg.IsNativeMode {
+			manager.wsEcUrl = cfg.Native.EcWsUrl.Value.(string)
+		} else if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+			manager.wsEcUrl = fmt.Sprintf("ws://%s:%d", config.Eth1ContainerName, cfg.ExecutionCommon.WsPort.Value)
+		} else {
+			manager.wsEcUrl = cfg.ExternalExecution.WsUrl.Value.(string)
+		}
+		if manager.wsEcUrl != "" {
+			manager.wsEc, err = ethclient.Dial(manager.wsEcUrl)
+			if err != nil {
+				manager.logger.Printlnf("WARNING: Couldn't connect to the Execution client's websocket at [%s] (%s), falling back to polling for new blocks.", manager.wsEcUrl, err.Error())
+				manager.wsEc = nil
+			}
+		}
+	}
+
+	return manager, nil
 
 }
 
@@ -96,7 +142,7 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 // CodeAt returns the code of the given account. This is needed to differentiate
 // between contract internal errors and the local chain being out of sync.
 func (p *ExecutionClientManager) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runReadOnlyFunction(ctx, cfgtypes.CallClass_Fast, blockNumber != nil, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.CodeAt(ctx, contract, blockNumber)
 	})
 	if err != nil {
@@ -108,12 +154,22 @@ func (p *ExecutionClientManager) CodeAt(ctx context.Context, contract common.Add
 // CallContract executes an Ethereum contract call with the specified data as the
 // input.
 func (p *ExecutionClientManager) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	// Contract addresses and ABIs that were looked up recently, possibly by another process, don't need to be looked up again
+	if p.metadataCache != nil {
+		if cached, exists := p.metadataCache.getStorageLookup(call, blockNumber); exists {
+			return cached, nil
+		}
+	}
+
+	result, err := p.runReadOnlyFunction(ctx, cfgtypes.CallClass_Heavy, blockNumber != nil, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.CallContract(ctx, call, blockNumber)
 	})
 	if err != nil {
 		return nil, err
 	}
+	if p.metadataCache != nil {
+		p.metadataCache.setStorageLookup(call, blockNumber, result.([]byte))
+	}
 	return result.([]byte), err
 }
 
@@ -123,7 +179,7 @@ func (p *ExecutionClientManager) CallContract(ctx context.Context, call ethereum
 
 // HeaderByHash returns the block header with the given hash.
 func (p *ExecutionClientManager) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runReadOnlyFunction(ctx, cfgtypes.CallClass_Fast, true, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.HeaderByHash(ctx, hash)
 	})
 	if err != nil {
@@ -135,7 +191,7 @@ func (p *ExecutionClientManager) HeaderByHash(ctx context.Context, hash common.H
 // HeaderByNumber returns a block header from the current canonical chain. If number is
 // nil, the latest known header is returned.
 func (p *ExecutionClientManager) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runReadOnlyFunction(ctx, cfgtypes.CallClass_Fast, number != nil, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.HeaderByNumber(ctx, number)
 	})
 	if err != nil {
@@ -146,7 +202,7 @@ func (p *ExecutionClientManager) HeaderByNumber(ctx context.Context, number *big
 
 // PendingCodeAt returns the code of the given account in the pending state.
 func (p *ExecutionClientManager) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, cfgtypes.CallClass_Fast, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.PendingCodeAt(ctx, account)
 	})
 	if err != nil {
@@ -157,7 +213,7 @@ func (p *ExecutionClientManager) PendingCodeAt(ctx context.Context, account comm
 
 // PendingNonceAt retrieves the current pending nonce associated with an account.
 func (p *ExecutionClientManager) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, cfgtypes.CallClass_Fast, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.PendingNonceAt(ctx, account)
 	})
 	if err != nil {
@@ -169,7 +225,7 @@ func (p *ExecutionClientManager) PendingNonceAt(ctx context.Context, account com
 // SuggestGasPrice retrieves the currently suggested gas price to allow a timely
 // execution of a transaction.
 func (p *ExecutionClientManager) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, cfgtypes.CallClass_Fast, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.SuggestGasPrice(ctx)
 	})
 	if err != nil {
@@ -181,7 +237,7 @@ func (p *ExecutionClientManager) SuggestGasPrice(ctx context.Context) (*big.Int,
 // SuggestGasTipCap retrieves the currently suggested 1559 priority fee to allow
 // a timely execution of a transaction.
 func (p *ExecutionClientManager) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, cfgtypes.CallClass_Fast, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.SuggestGasTipCap(ctx)
 	})
 	if err != nil {
@@ -190,13 +246,24 @@ func (p *ExecutionClientManager) SuggestGasTipCap(ctx context.Context) (*big.Int
 	return result.(*big.Int), err
 }
 
+// FeeHistory retrieves the base fees and priority fee percentiles of the most recent blocks, up to lastBlock (or the latest block if it's nil).
+func (p *ExecutionClientManager) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	result, err := p.runFunction(ctx, cfgtypes.CallClass_Fast, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
+		return client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*ethereum.FeeHistory), err
+}
+
 // EstimateGas tries to estimate the gas needed to execute a specific
 // transaction based on the current pending state of the backend blockchain.
 // There is no guarantee that this is the true gas limit requirement as other
 // transactions may be added or removed by miners, but it should provide a basis
 // for setting a reasonable default.
 func (p *ExecutionClientManager) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, cfgtypes.CallClass_Fast, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.EstimateGas(ctx, call)
 	})
 	if err != nil {
@@ -207,7 +274,7 @@ func (p *ExecutionClientManager) EstimateGas(ctx context.Context, call ethereum.
 
 // SendTransaction injects the transaction into the pending pool for execution.
 func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.Transaction) error {
-	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	_, err := p.runFunction(ctx, cfgtypes.CallClass_Submit, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return nil, client.SendTransaction(ctx, tx)
 	})
 	return err
@@ -222,7 +289,7 @@ func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.
 //
 // TODO(karalabe): Deprecate when the subscription one can return past data too.
 func (p *ExecutionClientManager) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runReadOnlyFunction(ctx, cfgtypes.CallClass_Heavy, query.BlockHash != nil || query.ToBlock != nil, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.FilterLogs(ctx, query)
 	})
 	if err != nil {
@@ -234,7 +301,11 @@ func (p *ExecutionClientManager) FilterLogs(ctx context.Context, query ethereum.
 // SubscribeFilterLogs creates a background log filtering operation, returning
 // a subscription immediately, which can be used to stream the found events.
 func (p *ExecutionClientManager) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	// Subscriptions need a persistent connection, so use the websocket if there is one
+	if p.wsEc != nil {
+		return p.wsEc.SubscribeFilterLogs(ctx, query, ch)
+	}
+	result, err := p.runFunction(ctx, callClass_Stream, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.SubscribeFilterLogs(ctx, query, ch)
 	})
 	if err != nil {
@@ -250,7 +321,7 @@ func (p *ExecutionClientManager) SubscribeFilterLogs(ctx context.Context, query
 // TransactionReceipt returns the receipt of a transaction by transaction hash.
 // Note that the receipt is not available for pending transactions.
 func (p *ExecutionClientManager) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, cfgtypes.CallClass_Fast, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.TransactionReceipt(ctx, txHash)
 	})
 	if err != nil {
@@ -265,7 +336,7 @@ func (p *ExecutionClientManager) TransactionReceipt(ctx context.Context, txHash
 
 // BlockNumber returns the most recent block number
 func (p *ExecutionClientManager) BlockNumber(ctx context.Context) (uint64, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, cfgtypes.CallClass_Fast, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.BlockNumber(ctx)
 	})
 	if err != nil {
@@ -277,7 +348,7 @@ func (p *ExecutionClientManager) BlockNumber(ctx context.Context) (uint64, error
 // BalanceAt returns the wei balance of the given account.
 // The block number can be nil, in which case the balance is taken from the latest known block.
 func (p *ExecutionClientManager) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runReadOnlyFunction(ctx, cfgtypes.CallClass_Fast, blockNumber != nil, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.BalanceAt(ctx, account, blockNumber)
 	})
 	if err != nil {
@@ -288,7 +359,7 @@ func (p *ExecutionClientManager) BalanceAt(ctx context.Context, account common.A
 
 // TransactionByHash returns the transaction with the given hash.
 func (p *ExecutionClientManager) TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, cfgtypes.CallClass_Fast, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		tx, isPending, err := client.TransactionByHash(ctx, hash)
 		result := []interface{}{tx, isPending}
 		return result, err
@@ -307,7 +378,7 @@ func (p *ExecutionClientManager) TransactionByHash(ctx context.Context, hash com
 // NonceAt returns the account nonce of the given account.
 // The block number can be nil, in which case the nonce is taken from the latest known block.
 func (p *ExecutionClientManager) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runReadOnlyFunction(ctx, cfgtypes.CallClass_Fast, blockNumber != nil, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.NonceAt(ctx, account, blockNumber)
 	})
 	if err != nil {
@@ -319,7 +390,7 @@ func (p *ExecutionClientManager) NonceAt(ctx context.Context, account common.Add
 // SyncProgress retrieves the current progress of the sync algorithm. If there's
 // no sync currently running, it returns nil.
 func (p *ExecutionClientManager) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, cfgtypes.CallClass_Fast, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.SyncProgress(ctx)
 	})
 	if err != nil {
@@ -328,10 +399,154 @@ func (p *ExecutionClientManager) SyncProgress(ctx context.Context) (*ethereum.Sy
 	return result.(*ethereum.SyncProgress), err
 }
 
+// ClientVersion returns the name and version of the active client, as reported by web3_clientVersion.
+func (p *ExecutionClientManager) ClientVersion(ctx context.Context) (string, error) {
+	if timeout := p.callPolicies[cfgtypes.CallClass_Fast].Timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	client, err := rpc.DialContext(ctx, p.GetActiveUrl())
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	var version string
+	err = client.CallContext(ctx, &version, "web3_clientVersion")
+	return version, err
+}
+
+// TraceCall runs debug_traceCall on the active client against the latest block, decoding the trace into result.
+// The tracer config is passed as-is, so it selects the tracer (e.g. the built-in callTracer) and its options.
+func (p *ExecutionClientManager) TraceCall(ctx context.Context, call ethereum.CallMsg, tracerConfig interface{}, result interface{}) error {
+	if timeout := p.callPolicies[cfgtypes.CallClass_Heavy].Timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	client, err := rpc.DialContext(ctx, p.GetActiveUrl())
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	arg := map[string]interface{}{
+		"from": call.From,
+		"to":   call.To,
+	}
+	if len(call.Data) > 0 {
+		arg["data"] = hexutil.Bytes(call.Data)
+	}
+	if call.Value != nil {
+		arg["value"] = (*hexutil.Big)(call.Value)
+	}
+	if call.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(call.Gas)
+	}
+	return client.CallContext(ctx, result, "debug_traceCall", arg, "latest", tracerConfig)
+}
+
+// TxPoolContentFrom returns the transactions from an account that are waiting in the active client's transaction pool, by nonce.
+// Pending transactions can be included in the next block; queued ones are waiting for a gap in the account's nonces to be filled.
+func (p *ExecutionClientManager) TxPoolContentFrom(ctx context.Context, account common.Address) (map[uint64]*types.Transaction, map[uint64]*types.Transaction, error) {
+	if timeout := p.callPolicies[cfgtypes.CallClass_Fast].Timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	client, err := rpc.DialContext(ctx, p.GetActiveUrl())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer client.Close()
+
+	// Clients without txpool_contentFrom may still provide the content of the whole pool
+	var content struct {
+		Pending map[string]*types.Transaction `json:"pending"`
+		Queued  map[string]*types.Transaction `json:"queued"`
+	}
+	err = client.CallContext(ctx, &content, "txpool_contentFrom", account)
+	if err != nil {
+		var allContent struct {
+			Pending map[string]map[string]*types.Transaction `json:"pending"`
+			Queued  map[string]map[string]*types.Transaction `json:"queued"`
+		}
+		if allErr := client.CallContext(ctx, &allContent, "txpool_content"); allErr != nil {
+			return nil, nil, fmt.Errorf("error getting the transaction pool; the client may not support the txpool API: %w", err)
+		}
+		for address, txs := range allContent.Pending {
+			if common.HexToAddress(address) == account {
+				content.Pending = txs
+			}
+		}
+		for address, txs := range allContent.Queued {
+			if common.HexToAddress(address) == account {
+				content.Queued = txs
+			}
+		}
+	}
+
+	pending, err := getTxPoolTransactionsByNonce(content.Pending)
+	if err != nil {
+		return nil, nil, err
+	}
+	queued, err := getTxPoolTransactionsByNonce(content.Queued)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pending, queued, nil
+}
+
 /// ==================
 /// Internal functions
 /// ==================
 
+// SubscribeNewHead subscribes to notifications about the current blockchain head.
+// This requires the websocket connection; without it, callers should poll for new headers instead.
+func (p *ExecutionClientManager) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	if p.wsEc == nil {
+		return nil, fmt.Errorf("the Execution client websocket is not configured")
+	}
+	return p.wsEc.SubscribeNewHead(ctx, ch)
+}
+
+// Check if the manager has a websocket connection for subscriptions
+func (p *ExecutionClientManager) HasWebsocket() bool {
+	return p.wsEc != nil
+}
+
+// Get the URL of the client that requests are currently being sent to
+func (p *ExecutionClientManager) GetActiveUrl() string {
+	if !p.primaryReady && p.fallbackReady {
+		return p.fallbackEcUrl
+	}
+	return p.primaryEcUrl
+}
+
+// Check if any client can currently be used, so callers can defer heavy work instead of sending it to clients that are down
+func (p *ExecutionClientManager) HasAvailableClient() bool {
+	return (p.primaryReady && p.primaryHealth.isAvailable()) ||
+		(p.fallbackReady && p.fallbackHealth.isAvailable())
+}
+
+// Get the health of each client, primary first
+func (p *ExecutionClientManager) GetClientHealth() []ClientHealthReport {
+	reports := []ClientHealthReport{
+		{
+			Name:   "Primary",
+			Status: p.primaryHealth.getStatus(),
+		},
+	}
+	if p.fallbackEc != nil {
+		reports = append(reports, ClientHealthReport{
+			Name:   "Fallback",
+			Status: p.fallbackHealth.getStatus(),
+		})
+	}
+	return reports
+}
+
 func (p *ExecutionClientManager) CheckStatus(cfg *config.RocketPoolConfig) *api.ClientManagerStatus {
 
 	status := &api.ClientManagerStatus{
@@ -342,22 +557,30 @@ func (p *ExecutionClientManager) CheckStatus(cfg *config.RocketPoolConfig) *api.
 	if p.ignoreSyncCheck {
 		status.PrimaryClientStatus.IsWorking = p.primaryReady
 		status.PrimaryClientStatus.IsSynced = p.primaryReady
+		status.PrimaryClientStatus.Health = p.primaryHealth.getStatus()
 		if status.FallbackEnabled {
 			status.FallbackClientStatus.IsWorking = p.fallbackReady
 			status.FallbackClientStatus.IsSynced = p.fallbackReady
+			status.FallbackClientStatus.Health = p.fallbackHealth.getStatus()
 		}
 		return status
 	}
 
 	// Get the primary EC status
-	status.PrimaryClientStatus = checkEcStatus(p.primaryEc)
+	start := time.Now()
+	status.PrimaryClientStatus = checkEcStatus(p.primaryEc, p.callPolicies[cfgtypes.CallClass_Fast].Timeout)
+	p.primaryHealth.recordProbe(status.PrimaryClientStatus.IsWorking, time.Since(start))
+	status.PrimaryClientStatus.Health = p.primaryHealth.getStatus()
 
 	// Flag if primary client is ready
 	p.primaryReady = (status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
 
 	// Get the fallback EC status if applicable
 	if status.FallbackEnabled {
-		status.FallbackClientStatus = checkEcStatus(p.fallbackEc)
+		start = time.Now()
+		status.FallbackClientStatus = checkEcStatus(p.fallbackEc, p.callPolicies[cfgtypes.CallClass_Fast].Timeout)
+		p.fallbackHealth.recordProbe(status.FallbackClientStatus.IsWorking, time.Since(start))
+		status.FallbackClientStatus.Health = p.fallbackHealth.getStatus()
 		// Check if fallback is using the expected network
 		expectedChainID := cfg.Smartnode.GetChainID()
 		if status.FallbackClientStatus.Error == "" && status.FallbackClientStatus.NetworkId != expectedChainID {
@@ -389,12 +612,20 @@ func getNetworkNameFromId(networkId uint) string {
 }
 
 // Check the client status
-func checkEcStatus(client *ethclient.Client) api.ClientStatus {
+func checkEcStatus(client *ethclient.Client, timeout time.Duration) api.ClientStatus {
 
 	status := api.ClientStatus{}
 
+	// Don't let a hung client block the status check
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
 	// Get the NetworkId
-	networkId, err := client.NetworkID(context.Background())
+	networkId, err := client.NetworkID(ctx)
 	if err != nil {
 		status.Error = fmt.Sprintf("Sync progress check failed with [%s]", err.Error())
 		status.IsSynced = false
@@ -407,7 +638,7 @@ func checkEcStatus(client *ethclient.Client) api.ClientStatus {
 	}
 
 	// Get the fallback's sync progress
-	progress, err := client.SyncProgress(context.Background())
+	progress, err := client.SyncProgress(ctx)
 	if err != nil {
 		status.Error = fmt.Sprintf("Sync progress check failed with [%s]", err.Error())
 		status.IsSynced = false
@@ -457,19 +688,34 @@ func checkEcStatus(client *ethclient.Client) api.ClientStatus {
 
 }
 
+// Runs a function with the timeout and retries of its call class.
+// Each attempt goes progressively through each client until one succeeds or they all fail; attempts are retried if the clients couldn't be reached or timed out.
+func (p *ExecutionClientManager) runFunction(ctx context.Context, class cfgtypes.CallClass, function ecFunction) (interface{}, error) {
+	policy := p.callPolicies[class]
+	for attempt := 0; ; attempt++ {
+		result, err := p.runFunctionOnce(ctx, policy, function)
+		if err == nil || attempt >= policy.Retries || ctx.Err() != nil || !isEndpointFailure(err) {
+			return result, err
+		}
+		time.Sleep(time.Duration(attempt+1) * ecCallRetryDelay)
+	}
+}
+
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
-func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{}, error) {
+func (p *ExecutionClientManager) runFunctionOnce(ctx context.Context, policy cfgtypes.CallPolicy, function ecFunction) (interface{}, error) {
 
 	// Check if we can use the primary
-	if p.primaryReady {
+	if p.primaryReady && p.primaryHealth.allow() {
 		// Try to run the function on the primary
-		result, err := function(p.primaryEc)
+		start := time.Now()
+		result, err := callWithTimeout(ctx, policy, p.primaryEc, function)
+		p.primaryHealth.record(err, time.Since(start))
 		if err != nil {
 			if p.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
 				p.logger.Printlnf("WARNING: Primary Execution client disconnected (%s), using fallback...", err.Error())
 				p.primaryReady = false
-				return p.runFunction(function)
+				return p.runFunctionOnce(ctx, policy, function)
 			}
 
 			// If it's a different error, just return it
@@ -480,9 +726,11 @@ func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{},
 		return result, nil
 	}
 
-	if p.fallbackReady {
+	if p.fallbackReady && p.fallbackHealth.allow() {
 		// Try to run the function on the fallback
-		result, err := function(p.fallbackEc)
+		start := time.Now()
+		result, err := callWithTimeout(ctx, policy, p.fallbackEc, function)
+		p.fallbackHealth.record(err, time.Since(start))
 		if err != nil {
 			if p.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
@@ -499,7 +747,79 @@ func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{},
 		return result, nil
 	}
 
-	return nil, fmt.Errorf("no Execution clients were ready")
+	return nil, fmt.Errorf("no Execution clients were ready (clients that keep failing are paused for %s)", clientBreakerCooldown)
+}
+
+// Runs a read-only function, spreading it across the read-only pool if the call is pinned to a specific block.
+// Calls against the latest block always go to the primary / fallback so clients with different heads can't give inconsistent results.
+// If a pool client fails for any reason (for example, it's been pruned past the target block), the call is retried on the primary / fallback.
+// Pool clients are taken out of rotation when they can't be reached or time out, or when the primary / fallback can serve a call they failed.
+func (p *ExecutionClientManager) runReadOnlyFunction(ctx context.Context, class cfgtypes.CallClass, isPinned bool, function ecFunction) (interface{}, error) {
+	if !isPinned || !p.readPool.isEnabled() {
+		return p.runFunction(ctx, class, function)
+	}
+
+	member := p.readPool.acquire()
+	if member.client == nil {
+		return p.runFunction(ctx, class, function)
+	}
+	result, err := callWithTimeout(ctx, p.callPolicies[class], member.client, function)
+	if err == nil {
+		return result, nil
+	}
+	if ctx.Err() != nil {
+		// The caller gave up, so this says nothing about the pool client
+		return nil, err
+	}
+	if isEndpointFailure(err) {
+		p.readPool.markDown(member, err)
+		return p.runFunction(ctx, class, function)
+	}
+
+	// Some other error; if the primary / fallback can serve the call then the pool client is the problem
+	result, fallbackErr := p.runFunction(ctx, class, function)
+	if fallbackErr == nil {
+		p.readPool.markDown(member, err)
+	}
+	return result, fallbackErr
+}
+
+// Runs a function on a client, cancelling it if it takes longer than the policy's timeout
+func callWithTimeout(ctx context.Context, policy cfgtypes.CallPolicy, client *ethclient.Client, function ecFunction) (interface{}, error) {
+	if policy.Timeout == 0 {
+		return function(ctx, client)
+	}
+	callCtx, cancel := context.WithTimeout(ctx, policy.Timeout)
+	defer cancel()
+	return function(callCtx, client)
+}
+
+// Index the transactions from a transaction pool listing, which are keyed by their nonce in decimal
+func getTxPoolTransactionsByNonce(txs map[string]*types.Transaction) (map[uint64]*types.Transaction, error) {
+	txsByNonce := map[uint64]*types.Transaction{}
+	for nonceString, tx := range txs {
+		nonce, err := strconv.ParseUint(nonceString, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing transaction pool nonce [%s]: %w", nonceString, err)
+		}
+		txsByNonce[nonce] = tx
+	}
+	return txsByNonce, nil
+}
+
+// Connect to an Execution client; HTTP endpoints share the tuned connection pool used by all of the client wrappers,
+// and their requests are recorded in the client request metrics under the provided name
+func dialExecutionClient(url string, name string) (*ethclient.Client, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return ethclient.Dial(url)
+	}
+	httpClient := netutils.NewHttpClient(0)
+	httpClient.Transport = newExecutionClientTransport(name)
+	rpcClient, err := rpc.DialHTTPWithClient(url, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(rpcClient), nil
 }
 
 // Returns true if the error was a connection failure and a backup client is available
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How long to wait before sending requests to a read-only Execution client that failed
+const ecReadPoolRetryInterval time.Duration = time.Minute
+
+// A pool of Execution clients that read-only calls against historical blocks are spread across.
+// The manager's active client is always the first member; the rest come from the read-only EC list in the config.
+type ecReadPool struct {
+	members []*ecReadPoolMember
+	next    uint32
+	logger  *log.ColorLogger
+}
+
+// A member of the read-only pool.
+// A nil client means the call should go through the manager's normal primary / fallback path.
+type ecReadPoolMember struct {
+	client      *ethclient.Client
+	url         string
+	minInterval time.Duration
+	nextSlot    time.Time
+	downUntil   time.Time
+	lock        sync.Mutex
+}
+
+// Creates a new read-only pool from a comma-separated list of URLs and a per-client rate limit (0 for unlimited)
+func newEcReadPool(urls string, rateLimit uint64, logger *log.ColorLogger) (*ecReadPool, error) {
+	var minInterval time.Duration
+	if rateLimit > 0 {
+		minInterval = time.Second / time.Duration(rateLimit)
+	}
+
+	pool := &ecReadPool{
+		members: []*ecReadPoolMember{
+			{minInterval: minInterval},
+		},
+		logger: logger,
+	}
+	for _, url := range strings.Split(urls, ",") {
+		url = strings.TrimSpace(url)
+		if url == "" {
+			continue
+		}
+		client, err := dialExecutionClient(url, fmt.Sprintf("Read-only %d", len(pool.members)))
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to read-only EC at [%s]: %w", url, err)
+		}
+		pool.members = append(pool.members, &ecReadPoolMember{
+			client:      client,
+			url:         url,
+			minInterval: minInterval,
+		})
+	}
+	return pool, nil
+}
+
+// Check if the pool has any clients beyond the manager's own
+func (p *ecReadPool) isEnabled() bool {
+	return p != nil && len(p.members) > 1
+}
+
+// Pick the next member to send a request to, rotating through the pool.
+// Members that are over their rate limit are skipped; if they all are, this waits for the first one in line.
+func (p *ecReadPool) acquire() *ecReadPoolMember {
+	start := int(atomic.AddUint32(&p.next, 1)) % len(p.members)
+	var first *ecReadPoolMember
+	for i := 0; i < len(p.members); i++ {
+		member := p.members[(start+i)%len(p.members)]
+		if !member.isAvailable() {
+			continue
+		}
+		if first == nil {
+			first = member
+		}
+		if member.tryReserve() {
+			return member
+		}
+	}
+
+	// Everything is busy, so wait for a slot on the first available member (the manager's client is always available)
+	if first == nil {
+		first = p.members[0]
+	}
+	first.reserve()
+	return first
+}
+
+// Take the member out of rotation for a while after it fails
+func (p *ecReadPool) markDown(member *ecReadPoolMember, err error) {
+	member.lock.Lock()
+	member.downUntil = time.Now().Add(ecReadPoolRetryInterval)
+	member.lock.Unlock()
+	p.logger.Printlnf("WARNING: Read-only Execution client at %s failed (%s), pausing its use for %s.", member.url, err.Error(), ecReadPoolRetryInterval)
+}
+
+// Check if the member can currently be used
+func (m *ecReadPoolMember) isAvailable() bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return time.Now().After(m.downUntil)
+}
+
+// Reserve a request slot if one is free right now
+func (m *ecReadPoolMember) tryReserve() bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	now := time.Now()
+	if now.Before(m.nextSlot) {
+		return false
+	}
+	m.nextSlot = now.Add(m.minInterval)
+	return true
+}
+
+// Reserve the next request slot, waiting until it arrives
+func (m *ecReadPoolMember) reserve() {
+	m.lock.Lock()
+	now := time.Now()
+	slot := m.nextSlot
+	if slot.Before(now) {
+		slot = now
+	}
+	m.nextSlot = slot.Add(m.minInterval)
+	m.lock.Unlock()
+	time.Sleep(time.Until(slot))
+}
@@ -0,0 +1,293 @@
+//go:build forktest
+
+package forktest
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// How long to wait for anvil to start serving requests; forking has to fetch the fork block first
+const anvilStartTimeout time.Duration = 2 * time.Minute
+
+// How long to wait for a transaction sent to the fork to be mined
+const forkTxTimeout time.Duration = 30 * time.Second
+
+// Settings for the forked network
+type ForkOptions struct {
+	// The anvil binary to run
+	AnvilPath string
+
+	// The RPC endpoint of the network to fork
+	ForkUrl string
+
+	// The block to fork from; 0 forks from the latest block
+	ForkBlock uint64
+
+	// The port anvil listens on; 0 picks a free one
+	Port uint16
+
+	// Where to send anvil's own output; nil discards it
+	LogFile *os.File
+}
+
+// A local fork of a network, run by anvil. Every account can send transactions without a key, so tests can act as
+// any node or contract on the real network.
+type Fork struct {
+	Url    string
+	Client *ethclient.Client
+	rpc    *rpc.Client
+	cmd    *exec.Cmd
+}
+
+// Start anvil and wait for it to serve the fork
+func StartFork(opts ForkOptions) (*Fork, error) {
+	if opts.AnvilPath == "" {
+		opts.AnvilPath = "anvil"
+	}
+	if opts.ForkUrl == "" {
+		return nil, fmt.Errorf("the URL of the network to fork is required")
+	}
+	port := opts.Port
+	if port == 0 {
+		var err error
+		port, err = getFreePort()
+		if err != nil {
+			return nil, fmt.Errorf("error finding a free port for anvil: %w", err)
+		}
+	}
+
+	args := []string{
+		"--fork-url", opts.ForkUrl,
+		"--port", fmt.Sprint(port),
+		"--auto-impersonate",
+		"--silent",
+	}
+	if opts.ForkBlock != 0 {
+		args = append(args, "--fork-block-number", fmt.Sprint(opts.ForkBlock))
+	}
+	cmd := exec.Command(opts.AnvilPath, args...)
+	if opts.LogFile != nil {
+		cmd.Stdout = opts.LogFile
+		cmd.Stderr = opts.LogFile
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("error starting anvil: %w", err)
+	}
+
+	fork := &Fork{
+		Url: fmt.Sprintf("http://127.0.0.1:%d", port),
+		cmd: cmd,
+	}
+	if err := fork.waitForStart(); err != nil {
+		fork.Close()
+		return nil, err
+	}
+
+	// Blocks mined on the fork are a slot apart, like they are on the real network, so they can be mapped to Beacon slots
+	if err := fork.rpc.Call(nil, "anvil_setBlockTimestampInterval", 12); err != nil {
+		fork.Close()
+		return nil, fmt.Errorf("error setting the fork's block time: %w", err)
+	}
+	return fork, nil
+}
+
+// Stop anvil
+func (f *Fork) Close() {
+	if f.Client != nil {
+		f.Client.Close()
+	}
+	if f.cmd.Process != nil {
+		_ = f.cmd.Process.Kill()
+		_ = f.cmd.Wait()
+	}
+}
+
+// Take a snapshot of the fork's state, which Revert can go back to
+func (f *Fork) Snapshot() (string, error) {
+	var id string
+	if err := f.rpc.Call(&id, "evm_snapshot"); err != nil {
+		return "", fmt.Errorf("error taking a snapshot of the fork: %w", err)
+	}
+	return id, nil
+}
+
+// Restore the fork's state to a snapshot
+func (f *Fork) Revert(id string) error {
+	var reverted bool
+	if err := f.rpc.Call(&reverted, "evm_revert", id); err != nil {
+		return fmt.Errorf("error reverting the fork to snapshot %s: %w", id, err)
+	}
+	if !reverted {
+		return fmt.Errorf("the fork couldn't be reverted to snapshot %s", id)
+	}
+	return nil
+}
+
+// Set an account's ETH balance
+func (f *Fork) SetBalance(address common.Address, balance *big.Int) error {
+	if err := f.rpc.Call(nil, "anvil_setBalance", address, (*hexutil.Big)(balance)); err != nil {
+		return fmt.Errorf("error setting the balance of %s: %w", address.Hex(), err)
+	}
+	return nil
+}
+
+// Send a transaction calling a contract method from any account, and wait for it to be mined.
+// Reverted transactions are returned as errors.
+func (f *Fork) Transact(from common.Address, contract *rocketpool.Contract, method string, params ...interface{}) (*types.Receipt, error) {
+	data, err := contract.ABI.Pack(method, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding %s call: %w", method, err)
+	}
+	tx := map[string]interface{}{
+		"from": from,
+		"to":   contract.Address,
+		"data": hexutil.Bytes(data),
+	}
+	var hash common.Hash
+	if err := f.rpc.Call(&hash, "eth_sendTransaction", tx); err != nil {
+		return nil, fmt.Errorf("error sending %s transaction from %s: %w", method, from.Hex(), err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), forkTxTimeout)
+	defer cancel()
+	for {
+		receipt, err := f.Client.TransactionReceipt(ctx, hash)
+		if err == nil {
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return receipt, fmt.Errorf("%s transaction %s from %s reverted", method, hash.Hex(), from.Hex())
+			}
+			return receipt, nil
+		}
+		if err != ethereum.NotFound {
+			return nil, fmt.Errorf("error getting receipt for %s transaction %s: %w", method, hash.Hex(), err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%s transaction %s wasn't mined in time", method, hash.Hex())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// Get an Execution client for the fork that sends every transaction as the given account, so daemon code that signs with
+// the node wallet can run as any node. See wallet.SetForkAccount.
+func (f *Fork) NewAccountClient(account common.Address) *AccountClient {
+	return &AccountClient{
+		Client:  f.Client,
+		fork:    f,
+		account: account,
+		hashes:  map[common.Hash]common.Hash{},
+	}
+}
+
+// An Execution client for the fork that sends transactions as one account. The transactions it's given are unsigned since
+// the account's key isn't available, so anvil sends them as the account instead; the hash of each one is mapped to the hash
+// anvil gives it, so callers can still look it up by the hash they have.
+type AccountClient struct {
+	*ethclient.Client
+	fork    *Fork
+	account common.Address
+	hashes  map[common.Hash]common.Hash
+	lock    sync.Mutex
+}
+
+// Have anvil send the transaction as the account
+func (c *AccountClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	forkTx := map[string]interface{}{
+		"from":                 c.account,
+		"to":                   tx.To(),
+		"data":                 hexutil.Bytes(tx.Data()),
+		"value":                (*hexutil.Big)(tx.Value()),
+		"gas":                  hexutil.Uint64(tx.Gas()),
+		"nonce":                hexutil.Uint64(tx.Nonce()),
+		"maxFeePerGas":         (*hexutil.Big)(tx.GasFeeCap()),
+		"maxPriorityFeePerGas": (*hexutil.Big)(tx.GasTipCap()),
+	}
+	var hash common.Hash
+	if err := c.fork.rpc.CallContext(ctx, &hash, "eth_sendTransaction", forkTx); err != nil {
+		return fmt.Errorf("error sending transaction from %s: %w", c.account.Hex(), err)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.hashes[tx.Hash()] = hash
+	return nil
+}
+
+// Get a transaction by its hash, or by the hash it had before anvil sent it
+func (c *AccountClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	return c.Client.TransactionByHash(ctx, c.getForkHash(hash))
+}
+
+// Get a transaction's receipt by its hash, or by the hash it had before anvil sent it
+func (c *AccountClient) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	return c.Client.TransactionReceipt(ctx, c.getForkHash(hash))
+}
+
+// Get the hash anvil gave a transaction this client sent, or the hash itself if it wasn't sent by this client
+func (c *AccountClient) getForkHash(hash common.Hash) common.Hash {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if forkHash, exists := c.hashes[hash]; exists {
+		return forkHash
+	}
+	return hash
+}
+
+// Wait until anvil answers requests, or fail if it exits first
+func (f *Fork) waitForStart() error {
+	exited := make(chan error, 1)
+	go func() {
+		exited <- f.cmd.Wait()
+	}()
+
+	deadline := time.After(anvilStartTimeout)
+	for {
+		select {
+		case err := <-exited:
+			f.cmd.Process = nil
+			return fmt.Errorf("anvil exited before the fork was ready: %v", err)
+		case <-deadline:
+			return fmt.Errorf("anvil didn't start serving the fork within %s", anvilStartTimeout)
+		case <-time.After(250 * time.Millisecond):
+		}
+
+		client, err := rpc.Dial(f.Url)
+		if err != nil {
+			continue
+		}
+		var blockNumber hexutil.Uint64
+		if err := client.Call(&blockNumber, "eth_blockNumber"); err != nil {
+			client.Close()
+			continue
+		}
+		f.rpc = client
+		f.Client = ethclient.NewClient(client)
+		return nil
+	}
+}
+
+// Ask the OS for a port nothing is listening on
+func getFreePort() (uint16, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return uint16(listener.Addr().(*net.TCPAddr).Port), nil
+}
@@ -0,0 +1,314 @@
+//go:build forktest
+
+package forktest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// The balance of every validator the mock reports, in gwei
+const mockValidatorBalance uint64 = 32e9
+
+// The Beacon config of mainnet
+var MainnetEth2Config = beacon.Eth2Config{
+	GenesisForkVersion:           common.FromHex("0x00000000"),
+	GenesisValidatorsRoot:        common.FromHex("0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"),
+	GenesisEpoch:                 0,
+	GenesisTime:                  1606824023,
+	SecondsPerSlot:               12,
+	SlotsPerEpoch:                32,
+	SecondsPerEpoch:              384,
+	EpochsPerSyncCommitteePeriod: 256,
+	CapellaForkVersion:           common.FromHex("0x03000000"),
+}
+
+// A Beacon client that answers from the Execution layer of a fork instead of a real Beacon node.
+// Slots are mapped to the Execution blocks with the same timestamp, and every validator is reported as active with a
+// 32 ETH balance unless a test overrides its status.
+type MockBeaconClient struct {
+	ec              *ethclient.Client
+	eth2Config      beacon.Eth2Config
+	depositContract beacon.Eth2DepositContract
+	validators      map[rptypes.ValidatorPubkey]beacon.ValidatorStatus
+	blocksBySlot    map[uint64]*types.Header
+	lock            sync.Mutex
+}
+
+// Create a mock Beacon client for a fork of mainnet
+func NewMockBeaconClient(ec *ethclient.Client) *MockBeaconClient {
+	return &MockBeaconClient{
+		ec:         ec,
+		eth2Config: MainnetEth2Config,
+		depositContract: beacon.Eth2DepositContract{
+			ChainID: 1,
+			Address: common.HexToAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa"),
+		},
+		validators:   map[rptypes.ValidatorPubkey]beacon.ValidatorStatus{},
+		blocksBySlot: map[uint64]*types.Header{},
+	}
+}
+
+// Override the status the mock reports for a validator
+func (c *MockBeaconClient) SetValidatorStatus(status beacon.ValidatorStatus) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.validators[status.Pubkey] = status
+}
+
+// Get the Beacon slot of an Execution block
+func (c *MockBeaconClient) GetSlotForBlock(header *types.Header) uint64 {
+	return (header.Time - c.eth2Config.GenesisTime) / c.eth2Config.SecondsPerSlot
+}
+
+// The mock stands in for a split-process client
+func (c *MockBeaconClient) GetClientType() (beacon.BeaconClientType, error) {
+	return beacon.SplitProcess, nil
+}
+
+// The mock is always synced
+func (c *MockBeaconClient) GetSyncStatus() (beacon.SyncStatus, error) {
+	return beacon.SyncStatus{
+		Syncing:  false,
+		Progress: 1,
+	}, nil
+}
+
+// Get the mock's version
+func (c *MockBeaconClient) GetNodeVersion() (beacon.NodeVersion, error) {
+	return beacon.NodeVersion{
+		Version: "forktest/mock",
+	}, nil
+}
+
+// Get the Beacon config
+func (c *MockBeaconClient) GetEth2Config() (beacon.Eth2Config, error) {
+	return c.eth2Config, nil
+}
+
+// Get the deposit contract
+func (c *MockBeaconClient) GetEth2DepositContract() (beacon.Eth2DepositContract, error) {
+	return c.depositContract, nil
+}
+
+// The mock doesn't track attestations, so every block has none
+func (c *MockBeaconClient) GetAttestations(blockId string) ([]beacon.AttestationInfo, bool, error) {
+	_, exists, err := c.getHeaderForBlockId(blockId)
+	return []beacon.AttestationInfo{}, exists, err
+}
+
+// Get the Beacon block for a slot from the Execution block with the same timestamp; slots without one were missed
+func (c *MockBeaconClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
+	header, exists, err := c.getHeaderForBlockId(blockId)
+	if err != nil || !exists {
+		return beacon.BeaconBlock{}, exists, err
+	}
+	return beacon.BeaconBlock{
+		Slot:                 c.GetSlotForBlock(header),
+		ProposerIndex:        "0",
+		HasExecutionPayload:  true,
+		Attestations:         []beacon.AttestationInfo{},
+		FeeRecipient:         header.Coinbase,
+		ExecutionBlockNumber: header.Number.Uint64(),
+	}, true, nil
+}
+
+// Get the head of the chain from the latest Execution block; the previous two epochs are treated as justified and finalized
+func (c *MockBeaconClient) GetBeaconHead() (beacon.BeaconHead, error) {
+	header, err := c.ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return beacon.BeaconHead{}, fmt.Errorf("error getting latest block: %w", err)
+	}
+	epoch := c.GetSlotForBlock(header) / c.eth2Config.SlotsPerEpoch
+	return beacon.BeaconHead{
+		Epoch:                  epoch,
+		FinalizedEpoch:         epoch - 2,
+		JustifiedEpoch:         epoch - 1,
+		PreviousJustifiedEpoch: epoch - 2,
+	}, nil
+}
+
+// Get a validator's status by its index; only validators that were already looked up by pubkey have one
+func (c *MockBeaconClient) GetValidatorStatusByIndex(index string, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, status := range c.validators {
+		if status.Index == index {
+			return status, nil
+		}
+	}
+	return beacon.ValidatorStatus{}, nil
+}
+
+// Get a validator's status
+func (c *MockBeaconClient) GetValidatorStatus(pubkey rptypes.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.getValidatorStatus(pubkey), nil
+}
+
+// Get the statuses of multiple validators
+func (c *MockBeaconClient) GetValidatorStatuses(pubkeys []rptypes.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[rptypes.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	statuses := make(map[rptypes.ValidatorPubkey]beacon.ValidatorStatus, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		statuses[pubkey] = c.getValidatorStatus(pubkey)
+	}
+	return statuses, nil
+}
+
+// Get a validator's index
+func (c *MockBeaconClient) GetValidatorIndex(pubkey rptypes.ValidatorPubkey) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.getValidatorStatus(pubkey).Index, nil
+}
+
+// The mock never assigns sync committee duties
+func (c *MockBeaconClient) GetValidatorSyncDuties(indices []string, epoch uint64) (map[string]bool, error) {
+	duties := make(map[string]bool, len(indices))
+	for _, index := range indices {
+		duties[index] = false
+	}
+	return duties, nil
+}
+
+// The mock never assigns proposals
+func (c *MockBeaconClient) GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error) {
+	duties := make(map[string]uint64, len(indices))
+	for _, index := range indices {
+		duties[index] = 0
+	}
+	return duties, nil
+}
+
+// Signing domains aren't supported by the mock
+func (c *MockBeaconClient) GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error) {
+	return nil, fmt.Errorf("signing domains aren't supported by the mock Beacon client")
+}
+
+// Exits aren't supported by the mock
+func (c *MockBeaconClient) ExitValidator(validatorIndex string, epoch uint64, signature rptypes.ValidatorSignature) error {
+	return fmt.Errorf("validator exits aren't supported by the mock Beacon client")
+}
+
+// The mock's exit queue is always empty
+func (c *MockBeaconClient) GetExitQueueLength() (uint64, error) {
+	return 0, nil
+}
+
+// Close the client
+func (c *MockBeaconClient) Close() error {
+	return nil
+}
+
+// Eth1 data isn't supported by the mock
+func (c *MockBeaconClient) GetEth1DataForEth2Block(blockId string) (beacon.Eth1Data, bool, error) {
+	return beacon.Eth1Data{}, false, fmt.Errorf("eth1 data isn't supported by the mock Beacon client")
+}
+
+// Committees aren't supported by the mock
+func (c *MockBeaconClient) GetCommitteesForEpoch(epoch *uint64) (beacon.Committees, error) {
+	return nil, fmt.Errorf("committees aren't supported by the mock Beacon client")
+}
+
+// Withdrawal credential changes aren't supported by the mock
+func (c *MockBeaconClient) ChangeWithdrawalCredentials(validatorIndex string, fromBlsPubkey rptypes.ValidatorPubkey, toExecutionAddress common.Address, signature rptypes.ValidatorSignature) error {
+	return fmt.Errorf("withdrawal credential changes aren't supported by the mock Beacon client")
+}
+
+// Get a validator's status, assigning it the next index and an active status the first time it's seen; the caller must hold the lock
+func (c *MockBeaconClient) getValidatorStatus(pubkey rptypes.ValidatorPubkey) beacon.ValidatorStatus {
+	status, exists := c.validators[pubkey]
+	if exists {
+		return status
+	}
+	status = beacon.ValidatorStatus{
+		Pubkey:                     pubkey,
+		Index:                      fmt.Sprint(len(c.validators)),
+		Balance:                    mockValidatorBalance,
+		Status:                     beacon.ValidatorState_ActiveOngoing,
+		EffectiveBalance:           mockValidatorBalance,
+		ActivationEligibilityEpoch: 0,
+		ActivationEpoch:            0,
+		ExitEpoch:                  math.MaxUint64,
+		WithdrawableEpoch:          math.MaxUint64,
+		Exists:                     true,
+	}
+	c.validators[pubkey] = status
+	return status
+}
+
+// Get the Execution block for a Beacon block ID, which can be a slot number or "head"
+func (c *MockBeaconClient) getHeaderForBlockId(blockId string) (*types.Header, bool, error) {
+	if blockId == "head" {
+		header, err := c.ec.HeaderByNumber(context.Background(), nil)
+		if err != nil {
+			return nil, false, fmt.Errorf("error getting latest block: %w", err)
+		}
+		return header, true, nil
+	}
+	slot, err := strconv.ParseUint(blockId, 10, 64)
+	if err != nil {
+		return nil, false, fmt.Errorf("block ID [%s] isn't supported by the mock Beacon client", blockId)
+	}
+	return c.getHeaderForSlot(slot)
+}
+
+// Find the Execution block with the timestamp of a slot, searching by timestamp since blocks are at most one per slot
+func (c *MockBeaconClient) getHeaderForSlot(slot uint64) (*types.Header, bool, error) {
+	c.lock.Lock()
+	header, exists := c.blocksBySlot[slot]
+	c.lock.Unlock()
+	if exists {
+		return header, header != nil, nil
+	}
+
+	slotTime := c.eth2Config.GenesisTime + slot*c.eth2Config.SecondsPerSlot
+	latest, err := c.ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("error getting latest block: %w", err)
+	}
+	if slotTime > latest.Time {
+		return nil, false, fmt.Errorf("slot %d (%s) is after the latest block", slot, time.Unix(int64(slotTime), 0).UTC())
+	}
+
+	// Find the first block at or after the slot's time
+	low := uint64(0)
+	high := latest.Number.Uint64()
+	header = latest
+	for low < high {
+		middle := low + (high-low)/2
+		candidate, err := c.ec.HeaderByNumber(context.Background(), new(big.Int).SetUint64(middle))
+		if err != nil {
+			return nil, false, fmt.Errorf("error getting block %d: %w", middle, err)
+		}
+		if candidate.Time < slotTime {
+			low = middle + 1
+		} else {
+			high = middle
+			header = candidate
+		}
+	}
+	if header.Time != slotTime {
+		header = nil
+	}
+
+	c.lock.Lock()
+	c.blocksBySlot[slot] = header
+	c.lock.Unlock()
+	return header, header != nil, nil
+}
@@ -0,0 +1,552 @@
+//go:build forktest
+
+package forktest
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fatih/color"
+	rpnode "github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	harnessColor   = color.FgHiCyan
+	autoClaimColor = color.FgGreen
+)
+
+// The amount of RPL the stake test stakes
+const stakeTestRpl float64 = 1000
+
+// The percent of the claimed RPL the claim test restakes
+const claimTestRestakePercent float64 = 50
+
+// The harness shared by every test, or nil if the fork isn't configured
+var harness *Harness
+
+// Start the fork the tests run against. It's configured with environment variables:
+//
+//	FORKTEST_FORK_URL:   the RPC endpoint of a mainnet Execution client (preferably an archive node) to fork; the tests are skipped without it
+//	FORKTEST_FORK_BLOCK: the block to fork from, so results are reproducible; defaults to the latest block
+//	FORKTEST_ANVIL:      the path to the anvil binary; defaults to anvil on the PATH
+//	FORKTEST_ANVIL_LOG:  a file to write anvil's output to
+//	FORKTEST_DATA_PATH:  a folder with the rewards tree files for the claim test, laid out like the Smartnode's data folder
+//	FORKTEST_NODE:       the node to run the node-specific tests as; defaults to the node with the most minipools
+func TestMain(m *testing.M) {
+	os.Exit(runTests(m))
+}
+
+// Start the fork if it's configured, then run the tests
+func runTests(m *testing.M) int {
+	forkUrl := os.Getenv("FORKTEST_FORK_URL")
+	if forkUrl == "" {
+		fmt.Println("FORKTEST_FORK_URL isn't set, so the fork tests will be skipped.")
+		return m.Run()
+	}
+
+	opts := HarnessOptions{
+		Fork: ForkOptions{
+			AnvilPath: os.Getenv("FORKTEST_ANVIL"),
+			ForkUrl:   forkUrl,
+		},
+		DataPath: os.Getenv("FORKTEST_DATA_PATH"),
+	}
+	if forkBlock := os.Getenv("FORKTEST_FORK_BLOCK"); forkBlock != "" {
+		block, err := strconv.ParseUint(forkBlock, 10, 64)
+		if err != nil {
+			fmt.Printf("Invalid FORKTEST_FORK_BLOCK [%s]: %s\n", forkBlock, err.Error())
+			return 1
+		}
+		opts.Fork.ForkBlock = block
+	}
+	if nodeAddress := os.Getenv("FORKTEST_NODE"); nodeAddress != "" {
+		if !common.IsHexAddress(nodeAddress) {
+			fmt.Printf("Invalid FORKTEST_NODE [%s]\n", nodeAddress)
+			return 1
+		}
+		opts.NodeAddress = common.HexToAddress(nodeAddress)
+	}
+	if anvilLog := os.Getenv("FORKTEST_ANVIL_LOG"); anvilLog != "" {
+		logFile, err := os.Create(anvilLog)
+		if err != nil {
+			fmt.Printf("Error creating anvil log file: %s\n", err.Error())
+			return 1
+		}
+		defer logFile.Close()
+		opts.Fork.LogFile = logFile
+	}
+
+	logger := log.NewColorLogger(harnessColor)
+	var err error
+	harness, err = NewHarness(opts, &logger)
+	if err != nil {
+		fmt.Printf("Error starting the fork: %s\n", err.Error())
+		return 1
+	}
+	defer harness.Close()
+	return m.Run()
+}
+
+// Run a test against the fork, or skip it if the fork isn't configured
+func runOnFork(t *testing.T, test func(t *testing.T, h *Harness)) {
+	if harness == nil {
+		t.Skip("FORKTEST_FORK_URL isn't set")
+	}
+	harness.Run(t, test)
+}
+
+// Build the state every way the daemons do, and check the results agree with each other and survive a warm-start snapshot
+func TestNetworkState(t *testing.T) {
+	runOnFork(t, func(t *testing.T, h *Harness) {
+		fullState, err := h.StateManager.GetHeadState()
+		if err != nil {
+			t.Fatalf("error building the state: %s", err.Error())
+		}
+		if len(fullState.NodeDetails) == 0 || len(fullState.MinipoolDetails) == 0 {
+			t.Fatalf("the state at block %d has %d nodes and %d minipools", fullState.ElBlockNumber, len(fullState.NodeDetails), len(fullState.MinipoolDetails))
+		}
+
+		// Streaming
+		streamedState, err := state.CreateNetworkStateStreaming(h.Cfg, h.RP, h.Fork.Client, h.BC, nil, fullState.BeaconSlotNumber, fullState.BeaconConfig)
+		if err != nil {
+			t.Fatalf("error building the streamed state: %s", err.Error())
+		}
+		if err := compareStates(fullState, streamedState); err != nil {
+			t.Fatalf("the streamed state doesn't match: %s", err.Error())
+		}
+
+		// Single node
+		nodeAddress, err := h.GetNodeAddress(fullState)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodeState, totalEffectiveStake, err := state.CreateNetworkStateForNode(h.Cfg, h.RP, h.Fork.Client, h.BC, nil, fullState.BeaconSlotNumber, fullState.BeaconConfig, nodeAddress, true)
+		if err != nil {
+			t.Fatalf("error building the state for node %s: %s", nodeAddress.Hex(), err.Error())
+		}
+		if err := compareNodes(fullState, nodeState, nodeAddress); err != nil {
+			t.Fatalf("the state for node %s doesn't match: %s", nodeAddress.Hex(), err.Error())
+		}
+		summedEffectiveStake := big.NewInt(0)
+		for _, nd := range fullState.NodeDetails {
+			summedEffectiveStake.Add(summedEffectiveStake, nd.EffectiveRPLStake)
+		}
+		if summedEffectiveStake.Cmp(totalEffectiveStake) != 0 {
+			t.Fatalf("the nodes' effective stakes add up to %s RPL, but the total effective stake is %s RPL", formatRpl(summedEffectiveStake), formatRpl(totalEffectiveStake))
+		}
+
+		// Warm-start snapshot
+		snapshotPath := filepath.Join(t.TempDir(), state.StateSnapshotFilename)
+		if err := state.NewStateSnapshot(nodeState, nodeAddress).Save(snapshotPath); err != nil {
+			t.Fatalf("error saving state snapshot: %s", err.Error())
+		}
+		snapshot, err := state.LoadStateSnapshot(snapshotPath)
+		if err != nil {
+			t.Fatalf("error loading state snapshot: %s", err.Error())
+		}
+		if err := compareNodes(nodeState, snapshot.GetNetworkState(), nodeAddress); err != nil {
+			t.Fatalf("the state loaded from the snapshot doesn't match: %s", err.Error())
+		}
+
+		t.Logf("block %d (slot %d): %d nodes, %d minipools, node %s has %d minipools", fullState.ElBlockNumber, fullState.BeaconSlotNumber,
+			len(fullState.NodeDetails), len(fullState.MinipoolDetails), nodeAddress.Hex(), len(nodeState.MinipoolDetails))
+	})
+}
+
+// Check the effective stakes the rewards tree would use against the ones the contracts report. With every validator active on
+// the mock, they can only differ for nodes with minipools the contracts count but the state doesn't, which are the ones that aren't staking.
+func TestEffectiveStakes(t *testing.T) {
+	runOnFork(t, func(t *testing.T, h *Harness) {
+		networkState, err := h.StateManager.GetHeadState()
+		if err != nil {
+			t.Fatalf("error building the state: %s", err.Error())
+		}
+		effectiveStakes, totalEffectiveStake, err := networkState.CalculateTrueEffectiveStakes(false, true)
+		if err != nil {
+			t.Fatalf("error calculating effective stakes: %s", err.Error())
+		}
+		scaledStakes, scaledTotal, err := networkState.CalculateTrueEffectiveStakes(true, true)
+		if err != nil {
+			t.Fatalf("error calculating participation-scaled effective stakes: %s", err.Error())
+		}
+		if scaledTotal.Cmp(totalEffectiveStake) > 0 {
+			t.Fatalf("the participation-scaled total effective stake (%s RPL) is more than the unscaled one (%s RPL)", formatRpl(scaledTotal), formatRpl(totalEffectiveStake))
+		}
+
+		matched := 0
+		excused := 0
+		for _, nd := range networkState.NodeDetails {
+			effectiveStake := effectiveStakes[nd.NodeAddress]
+			if effectiveStake.Cmp(nd.RplStake) > 0 {
+				t.Fatalf("node %s has an effective stake of %s RPL, but only %s RPL staked", nd.NodeAddress.Hex(), formatRpl(effectiveStake), formatRpl(nd.RplStake))
+			}
+			if scaledStakes[nd.NodeAddress].Cmp(effectiveStake) > 0 {
+				t.Fatalf("node %s has a participation-scaled effective stake of %s RPL, which is more than its unscaled one (%s RPL)", nd.NodeAddress.Hex(), formatRpl(scaledStakes[nd.NodeAddress]), formatRpl(effectiveStake))
+			}
+			if effectiveStake.Cmp(nd.EffectiveRPLStake) == 0 {
+				matched++
+				continue
+			}
+			if !hasUnstakedMinipools(networkState.MinipoolDetailsByNode[nd.NodeAddress]) {
+				t.Fatalf("node %s has an effective stake of %s RPL, but the contracts report %s RPL", nd.NodeAddress.Hex(), formatRpl(effectiveStake), formatRpl(nd.EffectiveRPLStake))
+			}
+			excused++
+		}
+
+		t.Logf("%d nodes match the contracts, %d differ only by minipools that aren't staking; total effective stake %s RPL (%s RPL scaled by participation)",
+			matched, excused, formatRpl(totalEffectiveStake), formatRpl(scaledTotal))
+	})
+}
+
+// Run the auto-claim task as the node with a restake policy, then check every interval with a tree file was claimed, the
+// restaked RPL was added to the node's stake, and the rest reached the withdrawal address
+func TestAutoClaimRewards(t *testing.T) {
+	runOnFork(t, func(t *testing.T, h *Harness) {
+		networkState, err := h.StateManager.GetHeadState()
+		if err != nil {
+			t.Fatalf("error building the state: %s", err.Error())
+		}
+		nodeAddress, err := h.GetNodeAddress(networkState)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Get the intervals the task should claim
+		unclaimed, _, err := rprewards.GetClaimStatus(h.RP, nodeAddress)
+		if err != nil {
+			t.Fatalf("error getting rewards claim status: %s", err.Error())
+		}
+		indices := []*big.Int{}
+		totalRpl := big.NewInt(0)
+		for _, index := range unclaimed {
+			info, err := rprewards.GetIntervalInfo(h.RP, h.Cfg, nodeAddress, index, nil)
+			if err != nil {
+				t.Fatalf("error getting info for interval %d: %s", index, err.Error())
+			}
+			if !info.TreeFileExists || !info.NodeExists {
+				continue
+			}
+			if !info.MerkleRootValid {
+				t.Fatalf("the rewards tree file for interval %d doesn't match the canonical merkle root", index)
+			}
+			indices = append(indices, big.NewInt(0).SetUint64(index))
+			totalRpl.Add(totalRpl, &info.CollateralRplAmount.Int)
+			totalRpl.Add(totalRpl, &info.ODaoRplAmount.Int)
+		}
+		if len(indices) == 0 {
+			t.Skipf("node %s has no unclaimed intervals with tree files in %s", nodeAddress.Hex(), h.Cfg.Smartnode.DataPath.Value)
+		}
+		restakeAmount := big.NewInt(0).Mul(totalRpl, big.NewInt(int64(claimTestRestakePercent*100)))
+		restakeAmount.Div(restakeAmount, big.NewInt(10000))
+
+		// Set the claim policy, with a max fee the fork will accept
+		header, err := h.Fork.Client.HeaderByNumber(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("error getting the fork's latest block: %s", err.Error())
+		}
+		maxFeeGwei := eth.WeiToGwei(header.BaseFee)*2 + 1
+		h.Cfg.Smartnode.AutoClaimRewards.Value = true
+		h.Cfg.Smartnode.AutoTxGasThreshold.Value = maxFeeGwei + 1
+		h.Cfg.Smartnode.AutoClaimMaxFee.Value = float64(0)
+		h.Cfg.Smartnode.ManualMaxFee.Value = maxFeeGwei
+		h.Cfg.Smartnode.PriorityFee.Value = float64(1)
+		h.Cfg.Smartnode.AutoClaimRestakeMode.Value = cfgtypes.RestakeMode_Percent
+		h.Cfg.Smartnode.AutoClaimRestakePercent.Value = claimTestRestakePercent
+		h.Cfg.Smartnode.AutoClaimSweepAddress.Value = ""
+
+		// Act as the node
+		w, err := newForkWallet(t, h, nodeAddress)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodeRp, err := rocketpool.NewRocketPool(h.Fork.NewAccountClient(nodeAddress), common.HexToAddress(h.Cfg.Smartnode.GetStorageAddress()))
+		if err != nil {
+			t.Fatalf("error creating Rocket Pool binding for node %s: %s", nodeAddress.Hex(), err.Error())
+		}
+		if err := h.Fork.SetBalance(nodeAddress, eth.EthToWei(10)); err != nil {
+			t.Fatal(err)
+		}
+
+		// Run the task
+		withdrawalAddress := networkState.NodeDetailsByAddress[nodeAddress].WithdrawalAddress
+		rplBefore, err := tokens.GetRPLBalance(h.RP, withdrawalAddress, nil)
+		if err != nil {
+			t.Fatalf("error getting RPL balance: %s", err.Error())
+		}
+		stakeBefore, err := rpnode.GetNodeRPLStake(h.RP, nodeAddress, nil)
+		if err != nil {
+			t.Fatalf("error getting RPL stake: %s", err.Error())
+		}
+		if err := node.RunAutoClaimRewards(log.NewColorLogger(autoClaimColor), h.Cfg, w, nodeRp, networkState); err != nil {
+			t.Fatalf("error running the auto-claim task: %s", err.Error())
+		}
+
+		// Check the results
+		for _, index := range indices {
+			claimed, err := rewards.IsClaimed(h.RP, index, nodeAddress, nil)
+			if err != nil {
+				t.Fatalf("error checking if interval %s was claimed: %s", index, err.Error())
+			}
+			if !claimed {
+				t.Fatalf("interval %s isn't marked as claimed", index)
+			}
+		}
+		stakeAfter, err := rpnode.GetNodeRPLStake(h.RP, nodeAddress, nil)
+		if err != nil {
+			t.Fatalf("error getting RPL stake: %s", err.Error())
+		}
+		restaked := big.NewInt(0).Sub(stakeAfter, stakeBefore)
+		if restaked.Cmp(restakeAmount) != 0 {
+			t.Fatalf("expected %s RPL to be restaked, but the node's stake went up by %s RPL", formatRpl(restakeAmount), formatRpl(restaked))
+		}
+		rplAfter, err := tokens.GetRPLBalance(h.RP, withdrawalAddress, nil)
+		if err != nil {
+			t.Fatalf("error getting RPL balance: %s", err.Error())
+		}
+		received := big.NewInt(0).Sub(rplAfter, rplBefore)
+		expectedReceived := big.NewInt(0).Sub(totalRpl, restakeAmount)
+		if received.Cmp(expectedReceived) != 0 {
+			t.Fatalf("expected the withdrawal address to receive %s RPL, but it received %s RPL", formatRpl(expectedReceived), formatRpl(received))
+		}
+
+		t.Logf("node %s claimed %s RPL for intervals %v and restaked %s RPL", nodeAddress.Hex(), formatRpl(totalRpl), indices, formatRpl(restaked))
+	})
+}
+
+// Stake RPL for the node, funding it from the vault, then check the contracts and a fresh state from the state manager agree
+func TestStakeRpl(t *testing.T) {
+	runOnFork(t, func(t *testing.T, h *Harness) {
+		networkState, err := h.StateManager.GetHeadState()
+		if err != nil {
+			t.Fatalf("error building the state: %s", err.Error())
+		}
+		nodeAddress, err := h.GetNodeAddress(networkState)
+		if err != nil {
+			t.Fatal(err)
+		}
+		amount := eth.EthToWei(stakeTestRpl)
+
+		stakeBefore, err := rpnode.GetNodeRPLStake(h.RP, nodeAddress, nil)
+		if err != nil {
+			t.Fatalf("error getting RPL stake: %s", err.Error())
+		}
+		contracts, err := h.RP.GetContracts(nil, "rocketTokenRPL", "rocketVault", "rocketNodeStaking")
+		if err != nil {
+			t.Fatal(err)
+		}
+		rpl := contracts[0]
+		vaultAddress := *contracts[1].Address
+		stakingAddress := *contracts[2].Address
+
+		// The vault holds everyone's staked RPL, so it can fund the node
+		if err := h.Fork.SetBalance(vaultAddress, eth.EthToWei(1)); err != nil {
+			t.Fatal(err)
+		}
+		if err := h.Fork.SetBalance(nodeAddress, eth.EthToWei(10)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := h.Fork.Transact(vaultAddress, rpl, "transfer", nodeAddress, amount); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := h.Fork.Transact(nodeAddress, rpl, "approve", stakingAddress, amount); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := h.Fork.Transact(nodeAddress, contracts[2], "stakeRPL", amount); err != nil {
+			t.Fatal(err)
+		}
+
+		// Check the results
+		stakeAfter, err := rpnode.GetNodeRPLStake(h.RP, nodeAddress, nil)
+		if err != nil {
+			t.Fatalf("error getting RPL stake: %s", err.Error())
+		}
+		expectedStake := big.NewInt(0).Add(stakeBefore, amount)
+		if stakeAfter.Cmp(expectedStake) != 0 {
+			t.Fatalf("expected a stake of %s RPL after staking, but the contracts report %s RPL", formatRpl(expectedStake), formatRpl(stakeAfter))
+		}
+		nodeState, _, err := h.StateManager.GetHeadStateForNode(nodeAddress, false)
+		if err != nil {
+			t.Fatalf("error building the state for node %s: %s", nodeAddress.Hex(), err.Error())
+		}
+		if nodeState.ElBlockNumber <= networkState.ElBlockNumber {
+			t.Fatalf("the state after staking is for block %d, which isn't after the one before it (%d)", nodeState.ElBlockNumber, networkState.ElBlockNumber)
+		}
+		nd := nodeState.NodeDetailsByAddress[nodeAddress]
+		if nd.RplStake.Cmp(stakeAfter) != 0 {
+			t.Fatalf("the state after staking has a stake of %s RPL, but the contracts report %s RPL", formatRpl(nd.RplStake), formatRpl(stakeAfter))
+		}
+
+		t.Logf("node %s staked %s RPL (%s -> %s RPL, effective %s RPL)", nodeAddress.Hex(), formatRpl(amount), formatRpl(stakeBefore), formatRpl(stakeAfter), formatRpl(nd.EffectiveRPLStake))
+	})
+}
+
+// Create a node wallet whose node account is the given node on the fork. The wallet itself is a throwaway one, since the
+// node account's transactions are sent by the fork instead of being signed.
+func newForkWallet(t *testing.T, h *Harness, nodeAddress common.Address) (*wallet.Wallet, error) {
+	walletDir := t.TempDir()
+	pm := passwords.NewPasswordManager(filepath.Join(walletDir, "password"))
+	if err := pm.SetPassword("forktest-password"); err != nil {
+		return nil, fmt.Errorf("error setting wallet password: %w", err)
+	}
+	w, err := wallet.NewWallet(filepath.Join(walletDir, "wallet"), h.Cfg.Smartnode.GetChainID(), nil, nil, 0, pm)
+	if err != nil {
+		return nil, fmt.Errorf("error creating wallet: %w", err)
+	}
+	if _, err := w.Initialize(wallet.DefaultNodeKeyPath, 0, ""); err != nil {
+		return nil, fmt.Errorf("error initializing wallet: %w", err)
+	}
+	w.SetForkAccount(nodeAddress)
+	return w, nil
+}
+
+// Check two states of the whole network have the same nodes and minipools
+func compareStates(expected *state.NetworkState, actual *state.NetworkState) error {
+	if expected.ElBlockNumber != actual.ElBlockNumber {
+		return fmt.Errorf("expected block %d, got %d", expected.ElBlockNumber, actual.ElBlockNumber)
+	}
+	if len(expected.NodeDetails) != len(actual.NodeDetails) {
+		return fmt.Errorf("expected %d nodes, got %d", len(expected.NodeDetails), len(actual.NodeDetails))
+	}
+	if len(expected.MinipoolDetails) != len(actual.MinipoolDetails) {
+		return fmt.Errorf("expected %d minipools, got %d", len(expected.MinipoolDetails), len(actual.MinipoolDetails))
+	}
+	for _, nd := range expected.NodeDetails {
+		if err := compareNodes(expected, actual, nd.NodeAddress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Check a node and its minipools are the same in two states
+func compareNodes(expected *state.NetworkState, actual *state.NetworkState, nodeAddress common.Address) error {
+	expectedNode, exists := expected.NodeDetailsByAddress[nodeAddress]
+	if !exists {
+		return fmt.Errorf("node %s is missing from the reference state", nodeAddress.Hex())
+	}
+	actualNode, exists := actual.NodeDetailsByAddress[nodeAddress]
+	if !exists {
+		return fmt.Errorf("node %s is missing", nodeAddress.Hex())
+	}
+	if err := compareNodeDetails(expectedNode, actualNode); err != nil {
+		return fmt.Errorf("node %s: %w", nodeAddress.Hex(), err)
+	}
+
+	expectedMinipools := expected.MinipoolDetailsByNode[nodeAddress]
+	actualMinipools := actual.MinipoolDetailsByNode[nodeAddress]
+	if len(expectedMinipools) != len(actualMinipools) {
+		return fmt.Errorf("node %s: expected %d minipools, got %d", nodeAddress.Hex(), len(expectedMinipools), len(actualMinipools))
+	}
+	for _, expectedMinipool := range expectedMinipools {
+		actualMinipool, exists := actual.MinipoolDetailsByAddress[expectedMinipool.MinipoolAddress]
+		if !exists {
+			return fmt.Errorf("minipool %s is missing", expectedMinipool.MinipoolAddress.Hex())
+		}
+		if err := compareMinipoolDetails(expectedMinipool, actualMinipool); err != nil {
+			return fmt.Errorf("minipool %s: %w", expectedMinipool.MinipoolAddress.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// Check the details of a node the rewards and collateral code rely on are the same
+func compareNodeDetails(expected *rpstate.NativeNodeDetails, actual *rpstate.NativeNodeDetails) error {
+	fields := []struct {
+		name     string
+		expected *big.Int
+		actual   *big.Int
+	}{
+		{"RPL stake", expected.RplStake, actual.RplStake},
+		{"effective RPL stake", expected.EffectiveRPLStake, actual.EffectiveRPLStake},
+		{"minimum RPL stake", expected.MinimumRPLStake, actual.MinimumRPLStake},
+		{"ETH matched", expected.EthMatched, actual.EthMatched},
+		{"minipool count", expected.MinipoolCount, actual.MinipoolCount},
+		{"registration time", expected.RegistrationTime, actual.RegistrationTime},
+		{"fee distributor balance", expected.DistributorBalance, actual.DistributorBalance},
+	}
+	for _, field := range fields {
+		if !bigEqual(field.expected, field.actual) {
+			return fmt.Errorf("expected %s %s, got %s", field.name, field.expected, field.actual)
+		}
+	}
+	if expected.SmoothingPoolRegistrationState != actual.SmoothingPoolRegistrationState {
+		return fmt.Errorf("expected smoothing pool registration %t, got %t", expected.SmoothingPoolRegistrationState, actual.SmoothingPoolRegistrationState)
+	}
+	if expected.WithdrawalAddress != actual.WithdrawalAddress {
+		return fmt.Errorf("expected withdrawal address %s, got %s", expected.WithdrawalAddress.Hex(), actual.WithdrawalAddress.Hex())
+	}
+	return nil
+}
+
+// Check the details of a minipool the rewards and collateral code rely on are the same
+func compareMinipoolDetails(expected *rpstate.NativeMinipoolDetails, actual *rpstate.NativeMinipoolDetails) error {
+	if expected.Status != actual.Status || expected.Finalised != actual.Finalised {
+		return fmt.Errorf("expected status %d (finalised: %t), got %d (finalised: %t)", expected.Status, expected.Finalised, actual.Status, actual.Finalised)
+	}
+	if expected.Pubkey != actual.Pubkey {
+		return fmt.Errorf("expected pubkey %s, got %s", expected.Pubkey.Hex(), actual.Pubkey.Hex())
+	}
+	fields := []struct {
+		name     string
+		expected *big.Int
+		actual   *big.Int
+	}{
+		{"node deposit balance", expected.NodeDepositBalance, actual.NodeDepositBalance},
+		{"user deposit balance", expected.UserDepositBalance, actual.UserDepositBalance},
+		{"node fee", expected.NodeFee, actual.NodeFee},
+		{"node share of balance", expected.NodeShareOfBalance, actual.NodeShareOfBalance},
+	}
+	for _, field := range fields {
+		if !bigEqual(field.expected, field.actual) {
+			return fmt.Errorf("expected %s %s, got %s", field.name, field.expected, field.actual)
+		}
+	}
+	return nil
+}
+
+// Check if a node has minipools the contracts count towards its collateral but the state doesn't, because they aren't staking yet or haven't been finalised
+func hasUnstakedMinipools(minipools []*rpstate.NativeMinipoolDetails) bool {
+	for _, mpd := range minipools {
+		if mpd.Exists && mpd.Status != types.Staking && !mpd.Finalised {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if two big ints are equal, treating nil as zero
+func bigEqual(a *big.Int, b *big.Int) bool {
+	if a == nil {
+		a = big.NewInt(0)
+	}
+	if b == nil {
+		b = big.NewInt(0)
+	}
+	return a.Cmp(b) == 0
+}
+
+// Format an amount of RPL in wei
+func formatRpl(amount *big.Int) string {
+	return fmt.Sprintf("%.6f", eth.WeiToEth(amount))
+}
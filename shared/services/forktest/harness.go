@@ -0,0 +1,133 @@
+//go:build forktest
+
+package forktest
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings for the harness
+type HarnessOptions struct {
+	Fork ForkOptions
+
+	// The folder holding the rewards tree files the claim test uses; a temporary folder is used if it's empty
+	DataPath string
+
+	// The node the node-specific tests act as; if it's empty, the node with the most minipools is used
+	NodeAddress common.Address
+}
+
+// Everything the tests need to run daemon code against a fork of mainnet: the fork itself, a native-mode config
+// pointed at it, the mock Beacon client, and a network state manager built on both
+type Harness struct {
+	Fork         *Fork
+	Cfg          *config.RocketPoolConfig
+	RP           *rocketpool.RocketPool
+	BC           *MockBeaconClient
+	StateManager *state.NetworkStateManager
+	NodeAddress  common.Address
+	Log          *log.ColorLogger
+	tempDataPath string
+}
+
+// Start the fork and set up the harness for it
+func NewHarness(opts HarnessOptions, logger *log.ColorLogger) (*Harness, error) {
+	h := &Harness{
+		NodeAddress: opts.NodeAddress,
+		Log:         logger,
+	}
+
+	// Make the config
+	dataPath := opts.DataPath
+	if dataPath == "" {
+		var err error
+		dataPath, err = os.MkdirTemp("", "rocketpool-forktest-")
+		if err != nil {
+			return nil, fmt.Errorf("error creating data folder: %w", err)
+		}
+		h.tempDataPath = dataPath
+	}
+	h.Cfg = config.NewRocketPoolConfig(dataPath, true)
+	h.Cfg.ChangeNetwork(cfgtypes.Network_Mainnet)
+	h.Cfg.Smartnode.DataPath.Value = dataPath
+
+	// Start the fork
+	var err error
+	h.Fork, err = StartFork(opts.Fork)
+	if err != nil {
+		h.Close()
+		return nil, err
+	}
+	h.RP, err = rocketpool.NewRocketPool(h.Fork.Client, common.HexToAddress(h.Cfg.Smartnode.GetStorageAddress()))
+	if err != nil {
+		h.Close()
+		return nil, fmt.Errorf("error creating Rocket Pool binding: %w", err)
+	}
+	h.BC = NewMockBeaconClient(h.Fork.Client)
+	h.StateManager, err = state.NewNetworkStateManager(h.RP, h.Cfg, h.Fork.Client, h.BC, logger)
+	if err != nil {
+		h.Close()
+		return nil, fmt.Errorf("error creating network state manager: %w", err)
+	}
+	return h, nil
+}
+
+// Stop the fork and delete the temporary data folder
+func (h *Harness) Close() {
+	if h.Fork != nil {
+		h.Fork.Close()
+	}
+	if h.tempDataPath != "" {
+		os.RemoveAll(h.tempDataPath)
+	}
+}
+
+// Run a test against the fork, restoring the fork's state when it finishes so tests don't affect each other
+func (h *Harness) Run(t *testing.T, test func(t *testing.T, h *Harness)) {
+	snapshot, err := h.Fork.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := h.Fork.Revert(snapshot); err != nil {
+			t.Error(err)
+		}
+	})
+	test(t, h)
+}
+
+// Get the node the node-specific tests act as
+func (h *Harness) GetNodeAddress(networkState *state.NetworkState) (common.Address, error) {
+	if h.NodeAddress != (common.Address{}) {
+		if _, exists := networkState.NodeDetailsByAddress[h.NodeAddress]; !exists {
+			return common.Address{}, fmt.Errorf("node %s isn't registered", h.NodeAddress.Hex())
+		}
+		return h.NodeAddress, nil
+	}
+
+	// Use the node with the most minipools, since it exercises the most code
+	var nodeAddress common.Address
+	mostMinipools := -1
+	for _, nd := range networkState.NodeDetails {
+		count := len(networkState.MinipoolDetailsByNode[nd.NodeAddress])
+		if count > mostMinipools {
+			nodeAddress = nd.NodeAddress
+			mostMinipools = count
+		}
+	}
+	if mostMinipools < 0 {
+		return common.Address{}, fmt.Errorf("there are no nodes to use")
+	}
+	h.NodeAddress = nodeAddress
+	return nodeAddress, nil
+}
@@ -0,0 +1,91 @@
+package blocknative
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/goccy/go-json"
+)
+
+const blockPricesUrl string = "https://api.blocknative.com/gasprices/blockprices"
+
+// Standard response
+type blockPricesResponse struct {
+	BlockPrices []struct {
+		BaseFeePerGas   float64 `json:"baseFeePerGas"`
+		EstimatedPrices []struct {
+			Confidence           int     `json:"confidence"`
+			Price                float64 `json:"price"`
+			MaxPriorityFeePerGas float64 `json:"maxPriorityFeePerGas"`
+			MaxFeePerGas         float64 `json:"maxFeePerGas"`
+		} `json:"estimatedPrices"`
+	} `json:"blockPrices"`
+}
+
+// A suggestion for the next block, with the confidence (in percent) that a transaction using it will be included
+type GasFeeEstimate struct {
+	Confidence int
+
+	// The max fee excluding the priority fee
+	MaxBaseFeeGwei float64
+}
+
+type GasFeeSuggestion struct {
+	BaseFeeGwei float64
+	Estimates   []GasFeeEstimate
+}
+
+// Get gas prices
+func GetGasPrices(apiKey string) (GasFeeSuggestion, error) {
+
+	// Send request
+	request, err := http.NewRequest(http.MethodGet, blockPricesUrl, nil)
+	if err != nil {
+		return GasFeeSuggestion{}, err
+	}
+	request.Header.Set("Authorization", apiKey)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return GasFeeSuggestion{}, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	// Check the response code
+	if response.StatusCode != http.StatusOK {
+		return GasFeeSuggestion{}, fmt.Errorf("request failed with code %d", response.StatusCode)
+	}
+
+	// Get response
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return GasFeeSuggestion{}, err
+	}
+
+	// Deserialize response
+	var bpResponse blockPricesResponse
+	if err := json.Unmarshal(body, &bpResponse); err != nil {
+		return GasFeeSuggestion{}, fmt.Errorf("Could not decode Blocknative block prices response: %w", err)
+	}
+	if len(bpResponse.BlockPrices) == 0 {
+		return GasFeeSuggestion{}, fmt.Errorf("Blocknative block prices response didn't include any blocks")
+	}
+
+	// Get the estimates for the next block, from the most to the least confident
+	nextBlock := bpResponse.BlockPrices[0]
+	suggestion := GasFeeSuggestion{
+		BaseFeeGwei: nextBlock.BaseFeePerGas,
+	}
+	for _, price := range nextBlock.EstimatedPrices {
+		suggestion.Estimates = append(suggestion.Estimates, GasFeeEstimate{
+			Confidence:     price.Confidence,
+			MaxBaseFeeGwei: price.MaxFeePerGas - price.MaxPriorityFeePerGas,
+		})
+	}
+
+	// Return
+	return suggestion, nil
+
+}
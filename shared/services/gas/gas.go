@@ -1,15 +1,17 @@
 package gas
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"strconv"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
-	"github.com/rocket-pool/smartnode/shared/services/gas/etherchain"
-	"github.com/rocket-pool/smartnode/shared/services/gas/etherscan"
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
@@ -20,6 +22,11 @@ const colorBlue string = "\033[36m"
 
 func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless bool) error {
 
+	// Dry runs don't submit anything and non-interactive runs can't be prompted, so don't prompt for a fee
+	if rp.IsDryRun() || cliutils.IsNonInteractive() {
+		headless = true
+	}
+
 	cfg, isNew, err := rp.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("Error getting Rocket Pool configuration: %w", err)
@@ -31,6 +38,18 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 	// Get the current settings from the CLI arguments
 	maxFeeGwei, maxPriorityFeeGwei, gasLimit := rp.GetGasSettings()
 
+	// Get the gas policy for this command, which applies to any setting that wasn't provided on the command line
+	policies, err := LoadGasPolicies(rp.GetConfigPath())
+	if err != nil {
+		return err
+	}
+	policy := policies.GetPolicy(rp.GetCommandName())
+
+	// Deferred transactions are signed with the requested fee and submitted by the daemon once the network's fees are low enough for it
+	if rp.IsDeferred() {
+		maxFeeGwei = rp.GetSubmitBelowFee()
+	}
+
 	// Get the max fee - prioritize the CLI arguments, default to the config file setting
 	if maxFeeGwei == 0 {
 		maxFee := eth.GweiToWei(cfg.Smartnode.ManualMaxFee.Value.(float64))
@@ -39,7 +58,10 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 		}
 	}
 
-	// Get the priority fee - prioritize the CLI arguments, default to the config file setting
+	// Get the priority fee - prioritize the CLI arguments, then the gas policy, default to the config file setting
+	if maxPriorityFeeGwei == 0 {
+		maxPriorityFeeGwei = policy.PriorityFee
+	}
 	if maxPriorityFeeGwei == 0 {
 		maxPriorityFee := eth.GweiToWei(cfg.Smartnode.PriorityFee.Value.(float64))
 		if maxPriorityFee == nil || maxPriorityFee.Uint64() == 0 {
@@ -66,30 +88,30 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 		fmt.Printf("Total cost: %.4f to %.4f ETH%s\n", lowLimit, highLimit, colorReset)
 
 	} else {
+		// Get the latest gas prices from the configured oracle, falling back to the others if it's unavailable
+		prices, err := GetGasPrices(GetGasOracles(cfg, apiFeeHistoryClient{rp: rp}))
+		if err != nil {
+			return err
+		}
+
+		// Use the policy's speed if it has one; headless transactions default to the fastest one so they don't get stuck
+		speed := policy.Speed
 		if headless {
-			maxFeeWei, err := GetHeadlessMaxFeeWei()
-			if err != nil {
-				return err
+			if speed == "" {
+				speed = SpeedRapid
 			}
-			maxFeeGwei = eth.WeiToGwei(maxFeeWei)
+			maxFeeGwei = eth.WeiToGwei(prices.GetTier(speed).MaxFeeWei)
 		} else {
-			// Try to get the latest gas prices from Etherchain
-			etherchainData, err := etherchain.GetGasPrices()
-			if err == nil {
-				// Print the Etherchain data and ask for an amount
-				maxFeeGwei = handleEtherchainGasPrices(etherchainData, gasInfo, maxPriorityFeeGwei, gasLimit)
-
-			} else {
-				// Fallback to Etherscan
-				fmt.Printf("%sWarning: couldn't get gas estimates from Etherchain - %s\nFalling back to Etherscan%s\n", colorYellow, err.Error(), colorReset)
-				etherscanData, err := etherscan.GetGasPrices()
-				if err == nil {
-					// Print the Etherscan data and ask for an amount
-					maxFeeGwei = handleEtherscanGasPrices(etherscanData, gasInfo, maxPriorityFeeGwei, gasLimit)
-				} else {
-					return fmt.Errorf("Error getting gas price suggestions: %w", err)
-				}
+			if speed == "" {
+				speed = SpeedFast
 			}
+			maxFeeGwei = handleGasPrices(prices, gasInfo, maxPriorityFeeGwei, gasLimit, speed)
+		}
+
+		// Cap the max fee at the policy's limit
+		if policy.MaxFee != 0 && maxFeeGwei > policy.MaxFee {
+			fmt.Printf("%sNOTE: capping the max fee at %.2f gwei, the limit in your gas policy for this command.%s\n", colorYellow, policy.MaxFee, colorReset)
+			maxFeeGwei = policy.MaxFee
 		}
 		fmt.Printf("%sUsing a max fee of %.2f gwei and a priority fee of %.2f gwei.\n%s", colorBlue, maxFeeGwei, maxPriorityFeeGwei, colorReset)
 	}
@@ -119,107 +141,77 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 	}
 
 	rp.AssignGasSettings(maxFeeGwei, maxPriorityFeeGwei, gasLimit)
-	return nil
-
-}
-
-// Get the suggested max fee for service operations
-func GetHeadlessMaxFeeWei() (*big.Int, error) {
-	etherchainData, err := etherchain.GetGasPrices()
-	if err == nil {
-		return etherchainData.RapidWei, nil
-	}
 
-	fmt.Printf("%sWarning: couldn't get gas estimates from Etherchain - %s\nFalling back to Etherscan%s\n", colorYellow, err.Error(), colorReset)
-	etherscanData, err := etherscan.GetGasPrices()
-	if err == nil {
-		return eth.GweiToWei(etherscanData.FastGwei), nil
+	// Let the user know the transaction has to be approved on their hardware wallet
+	if !headless {
+		switch cfg.Smartnode.HardwareWallet.Value.(cfgtypes.HardwareWallet) {
+		case cfgtypes.HardwareWallet_Ledger:
+			fmt.Printf("%sThis transaction will be sent to your Ledger for signing. Make sure the Ethereum app is open, then review and approve it on the device when prompted.%s\n", colorYellow, colorReset)
+		case cfgtypes.HardwareWallet_Trezor:
+			fmt.Printf("%sThis transaction will be sent to your Trezor for signing. Review and approve it on the device when prompted.%s\n", colorYellow, colorReset)
+		}
 	}
+	return nil
 
-	return nil, fmt.Errorf("Error getting gas price suggestions: %w", err)
 }
 
-func handleEtherchainGasPrices(gasSuggestion etherchain.GasFeeSuggestion, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) float64 {
-
-	rapidGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.RapidWei)+priorityFee, 0)
-	rapidEth := eth.WeiToEth(gasSuggestion.RapidWei)
-
-	var rapidLowLimit float64
-	var rapidHighLimit float64
-	if gasLimit == 0 {
-		rapidLowLimit = rapidEth * float64(gasInfo.EstGasLimit)
-		rapidHighLimit = rapidEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		rapidLowLimit = rapidEth * float64(gasLimit)
-		rapidHighLimit = rapidLowLimit
+// Get the suggested max fee for service operations.
+// The fee history oracle is available if the client provides the fee history.
+func GetHeadlessMaxFeeWei(cfg *config.RocketPoolConfig, client rocketpool.ExecutionClient) (*big.Int, error) {
+	feeHistoryClient, _ := client.(FeeHistoryClient)
+	prices, err := GetGasPrices(GetGasOracles(cfg, feeHistoryClient))
+	if err != nil {
+		return nil, err
 	}
+	return prices.GetTier(SpeedRapid).MaxFeeWei, nil
+}
 
-	fastGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.FastWei)+priorityFee, 0)
-	fastEth := eth.WeiToEth(gasSuggestion.FastWei)
-
-	var fastLowLimit float64
-	var fastHighLimit float64
-	if gasLimit == 0 {
-		fastLowLimit = fastEth * float64(gasInfo.EstGasLimit)
-		fastHighLimit = fastEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		fastLowLimit = fastEth * float64(gasLimit)
-		fastHighLimit = fastLowLimit
-	}
+// Print the suggested gas prices and ask for a max fee, with the given speed as the default
+func handleGasPrices(prices GasPrices, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64, defaultSpeed string) float64 {
 
-	standardGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.StandardWei)+priorityFee, 0)
-	standardEth := eth.WeiToEth(gasSuggestion.StandardWei)
+	defaultGwei := math.RoundUp(eth.WeiToGwei(prices.GetTier(defaultSpeed).MaxFeeWei)+priorityFee, 0)
 
-	var standardLowLimit float64
-	var standardHighLimit float64
-	if gasLimit == 0 {
-		standardLowLimit = standardEth * float64(gasInfo.EstGasLimit)
-		standardHighLimit = standardEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		standardLowLimit = standardEth * float64(gasLimit)
-		standardHighLimit = standardLowLimit
-	}
+	fmt.Printf("%s+==================== Suggested Gas Prices ====================+\n", colorBlue)
+	fmt.Println("|   Speed   |   Wait Time   |  Max Fee  |    Total Gas Cost    |")
+	for _, tier := range prices.Tiers {
+		tierGwei := math.RoundUp(eth.WeiToGwei(tier.MaxFeeWei)+priorityFee, 0)
+		tierEth := eth.WeiToEth(tier.MaxFeeWei)
 
-	slowGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.SlowWei)+priorityFee, 0)
-	slowEth := eth.WeiToEth(gasSuggestion.SlowWei)
+		var lowLimit float64
+		var highLimit float64
+		if gasLimit == 0 {
+			lowLimit = tierEth * float64(gasInfo.EstGasLimit)
+			highLimit = tierEth * float64(gasInfo.SafeGasLimit)
+		} else {
+			lowLimit = tierEth * float64(gasLimit)
+			highLimit = lowLimit
+		}
 
-	var slowLowLimit float64
-	var slowHighLimit float64
-	if gasLimit == 0 {
-		slowLowLimit = slowEth * float64(gasInfo.EstGasLimit)
-		slowHighLimit = slowEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		slowLowLimit = slowEth * float64(gasLimit)
-		slowHighLimit = slowLowLimit
+		waitTime := tier.WaitTime
+		if waitTime == "" {
+			waitTime = "---"
+		}
+		fmt.Printf("| %-9s | %-13s | %-9s | %.4f to %.4f ETH |\n",
+			tier.Speed, waitTime, fmt.Sprintf("%d gwei", int(tierGwei)), lowLimit, highLimit)
 	}
+	fmt.Printf("+==============================================================+\n\n%s", colorReset)
 
-	fmt.Printf("%s+============== Suggested Gas Prices ==============+\n", colorBlue)
-	fmt.Println("| Avg Wait Time |  Max Fee  |    Total Gas Cost    |")
-	fmt.Printf("| %-13s | %-9s | %.4f to %.4f ETH |\n",
-		gasSuggestion.RapidTime, fmt.Sprintf("%d gwei", int(rapidGwei)), rapidLowLimit, rapidHighLimit)
-	fmt.Printf("| %-13s | %-9s | %.4f to %.4f ETH |\n",
-		gasSuggestion.FastTime, fmt.Sprintf("%d gwei", int(fastGwei)), fastLowLimit, fastHighLimit)
-	fmt.Printf("| %-13s | %-9s | %.4f to %.4f ETH |\n",
-		gasSuggestion.StandardTime, fmt.Sprintf("%d gwei", int(standardGwei)), standardLowLimit, standardHighLimit)
-	fmt.Printf("| %-13s | %-9s | %.4f to %.4f ETH |\n",
-		gasSuggestion.SlowTime, fmt.Sprintf("%d gwei", int(slowGwei)), slowLowLimit, slowHighLimit)
-	fmt.Printf("+==================================================+\n\n%s", colorReset)
-
-	fmt.Printf("These prices include a maximum priority fee of %.2f gwei.\n", priorityFee)
+	fmt.Printf("These prices are from %s and include a maximum priority fee of %.2f gwei.\n", prices.Source, priorityFee)
 
 	for {
 		desiredPrice := cliutils.Prompt(
-			fmt.Sprintf("Please enter your max fee (including the priority fee) or leave blank for the default of %d gwei:", int(fastGwei)),
+			fmt.Sprintf("Please enter your max fee (including the priority fee) or leave blank for the default of %d gwei:", int(defaultGwei)),
 			"^(?:[1-9]\\d*|0)?(?:\\.\\d+)?$",
-			"Not a valid gas price, try again:")
+			"Not a valid gas price, try again:",
+			"ROCKETPOOL_MAX_FEE")
 
 		if desiredPrice == "" {
-			return fastGwei
+			return defaultGwei
 		}
 
 		desiredPriceFloat, err := strconv.ParseFloat(desiredPrice, 64)
 		if err != nil {
-			fmt.Println("Not a valid gas price (%s), try again.", err.Error())
+			fmt.Printf("Not a valid gas price (%s), try again.\n", err.Error())
 			continue
 		}
 		if desiredPriceFloat <= 0 {
@@ -232,80 +224,19 @@ func handleEtherchainGasPrices(gasSuggestion etherchain.GasFeeSuggestion, gasInf
 
 }
 
-func handleEtherscanGasPrices(gasSuggestion etherscan.GasFeeSuggestion, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) float64 {
-
-	fastGwei := math.RoundUp(gasSuggestion.FastGwei+priorityFee, 0)
-	fastEth := gasSuggestion.FastGwei / eth.WeiPerGwei
-
-	var fastLowLimit float64
-	var fastHighLimit float64
-	if gasLimit == 0 {
-		fastLowLimit = fastEth * float64(gasInfo.EstGasLimit)
-		fastHighLimit = fastEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		fastLowLimit = fastEth * float64(gasLimit)
-		fastHighLimit = fastLowLimit
-	}
-
-	standardGwei := math.RoundUp(gasSuggestion.StandardGwei+priorityFee, 0)
-	standardEth := gasSuggestion.StandardGwei / eth.WeiPerGwei
-
-	var standardLowLimit float64
-	var standardHighLimit float64
-	if gasLimit == 0 {
-		standardLowLimit = standardEth * float64(gasInfo.EstGasLimit)
-		standardHighLimit = standardEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		standardLowLimit = standardEth * float64(gasLimit)
-		standardHighLimit = standardLowLimit
-	}
-
-	slowGwei := math.RoundUp(gasSuggestion.SlowGwei+priorityFee, 0)
-	slowEth := gasSuggestion.SlowGwei / eth.WeiPerGwei
-
-	var slowLowLimit float64
-	var slowHighLimit float64
-	if gasLimit == 0 {
-		slowLowLimit = slowEth * float64(gasInfo.EstGasLimit)
-		slowHighLimit = slowEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		slowLowLimit = slowEth * float64(gasLimit)
-		slowHighLimit = slowLowLimit
-	}
-
-	fmt.Printf("%s+============ Suggested Gas Prices ============+\n", colorBlue)
-	fmt.Println("|   Speed   |  Max Fee  |    Total Gas Cost    |")
-	fmt.Printf("| Fast      | %-9s | %.4f to %.4f ETH |\n",
-		fmt.Sprintf("%d gwei", int(fastGwei)), fastLowLimit, fastHighLimit)
-	fmt.Printf("| Standard  | %-9s | %.4f to %.4f ETH |\n",
-		fmt.Sprintf("%d gwei", int(standardGwei)), standardLowLimit, standardHighLimit)
-	fmt.Printf("| Slow      | %-9s | %.4f to %.4f ETH |\n",
-		fmt.Sprintf("%d gwei", int(slowGwei)), slowLowLimit, slowHighLimit)
-	fmt.Printf("+==============================================+\n\n%s", colorReset)
-
-	fmt.Printf("These prices include a maximum priority fee of %.2f gwei.\n", priorityFee)
-
-	for {
-		desiredPrice := cliutils.Prompt(
-			fmt.Sprintf("Please enter your max fee (including the priority fee) or leave blank for the default of %d gwei:", int(fastGwei)),
-			"^(?:[1-9]\\d*|0)?(?:\\.\\d+)?$",
-			"Not a valid gas price, try again:")
-
-		if desiredPrice == "" {
-			return fastGwei
-		}
-
-		desiredPriceFloat, err := strconv.ParseFloat(desiredPrice, 64)
-		if err != nil {
-			fmt.Println("Not a valid gas price (%s), try again.", err.Error())
-			continue
-		}
-		if desiredPriceFloat <= 0 {
-			fmt.Println("Max fee must be greater than zero.")
-			continue
-		}
+// A fee history client that gets the fee history through the daemon's API
+type apiFeeHistoryClient struct {
+	rp *rpsvc.Client
+}
 
-		return desiredPriceFloat
+func (c apiFeeHistoryClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	response, err := c.rp.GetFeeHistory(blockCount)
+	if err != nil {
+		return nil, err
 	}
-
+	return &ethereum.FeeHistory{
+		OldestBlock:  response.OldestBlock,
+		BaseFee:      response.BaseFees,
+		GasUsedRatio: response.GasUsedRatios,
+	}, nil
 }
@@ -0,0 +1,237 @@
+package gas
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/gas/blocknative"
+	"github.com/rocket-pool/smartnode/shared/services/gas/etherchain"
+	"github.com/rocket-pool/smartnode/shared/services/gas/etherscan"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Speeds of gas price suggestions
+const (
+	SpeedRapid    string = "rapid"
+	SpeedFast     string = "fast"
+	SpeedStandard string = "standard"
+	SpeedSlow     string = "slow"
+)
+
+// Settings for the fee history oracle
+const (
+	feeHistoryBlockCount uint64 = 20
+)
+
+// A gas price suggestion for one speed
+type GasPriceTier struct {
+	Speed    string
+	WaitTime string
+
+	// The suggested max fee, excluding the priority fee
+	MaxFeeWei *big.Int
+}
+
+// The gas price suggestions from an oracle, from the fastest to the slowest
+type GasPrices struct {
+	Source string
+	Tiers  []GasPriceTier
+}
+
+// A source of gas price suggestions
+type GasOracle interface {
+	GetName() string
+	GetGasPrices() (GasPrices, error)
+}
+
+// A client that can provide the fee history of recent blocks
+type FeeHistoryClient interface {
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+}
+
+// Get the suggestion for a speed, or the fastest one if the oracle doesn't provide that speed
+func (p GasPrices) GetTier(speed string) GasPriceTier {
+	for _, tier := range p.Tiers {
+		if tier.Speed == speed {
+			return tier
+		}
+	}
+	return p.Tiers[0]
+}
+
+// Get the oracles to ask for gas prices, with the configured one first and the others as fallbacks.
+// The fee history oracle is only available if a client that provides it is given.
+func GetGasOracles(cfg *config.RocketPoolConfig, feeHistoryClient FeeHistoryClient) []GasOracle {
+
+	oraclesByType := map[cfgtypes.GasOracle]GasOracle{
+		cfgtypes.GasOracle_Etherchain: etherchainOracle{},
+		cfgtypes.GasOracle_Etherscan:  etherscanOracle{},
+	}
+	if feeHistoryClient != nil {
+		oraclesByType[cfgtypes.GasOracle_FeeHistory] = feeHistoryOracle{client: feeHistoryClient}
+	}
+	if apiKey := cfg.Smartnode.BlocknativeApiKey.Value.(string); apiKey != "" {
+		oraclesByType[cfgtypes.GasOracle_Blocknative] = blocknativeOracle{apiKey: apiKey}
+	}
+
+	oracles := []GasOracle{}
+	selected := cfg.Smartnode.GasOracle.Value.(cfgtypes.GasOracle)
+	if oracle, exists := oraclesByType[selected]; exists {
+		oracles = append(oracles, oracle)
+	}
+	for _, oracleType := range []cfgtypes.GasOracle{
+		cfgtypes.GasOracle_Etherchain,
+		cfgtypes.GasOracle_Etherscan,
+		cfgtypes.GasOracle_FeeHistory,
+		cfgtypes.GasOracle_Blocknative,
+	} {
+		if oracle, exists := oraclesByType[oracleType]; exists && oracleType != selected {
+			oracles = append(oracles, oracle)
+		}
+	}
+	return oracles
+
+}
+
+// Get gas prices from the first oracle that provides them
+func GetGasPrices(oracles []GasOracle) (GasPrices, error) {
+	var err error
+	for i, oracle := range oracles {
+		var prices GasPrices
+		prices, err = oracle.GetGasPrices()
+		if err == nil {
+			return prices, nil
+		}
+		if i < len(oracles)-1 {
+			fmt.Printf("%sWarning: couldn't get gas estimates from %s - %s\nFalling back to %s%s\n", colorYellow, oracle.GetName(), err.Error(), oracles[i+1].GetName(), colorReset)
+		}
+	}
+	return GasPrices{}, fmt.Errorf("Error getting gas price suggestions: %w", err)
+}
+
+// Etherchain (served by beaconcha.in)
+type etherchainOracle struct{}
+
+func (o etherchainOracle) GetName() string {
+	return "Etherchain"
+}
+
+func (o etherchainOracle) GetGasPrices() (GasPrices, error) {
+	suggestion, err := etherchain.GetGasPrices()
+	if err != nil {
+		return GasPrices{}, err
+	}
+	return GasPrices{
+		Source: o.GetName(),
+		Tiers: []GasPriceTier{
+			{Speed: SpeedRapid, WaitTime: suggestion.RapidTime, MaxFeeWei: suggestion.RapidWei},
+			{Speed: SpeedFast, WaitTime: suggestion.FastTime, MaxFeeWei: suggestion.FastWei},
+			{Speed: SpeedStandard, WaitTime: suggestion.StandardTime, MaxFeeWei: suggestion.StandardWei},
+			{Speed: SpeedSlow, WaitTime: suggestion.SlowTime, MaxFeeWei: suggestion.SlowWei},
+		},
+	}, nil
+}
+
+// Etherscan
+type etherscanOracle struct{}
+
+func (o etherscanOracle) GetName() string {
+	return "Etherscan"
+}
+
+func (o etherscanOracle) GetGasPrices() (GasPrices, error) {
+	suggestion, err := etherscan.GetGasPrices()
+	if err != nil {
+		return GasPrices{}, err
+	}
+	return GasPrices{
+		Source: o.GetName(),
+		Tiers: []GasPriceTier{
+			{Speed: SpeedFast, MaxFeeWei: eth.GweiToWei(suggestion.FastGwei)},
+			{Speed: SpeedStandard, MaxFeeWei: eth.GweiToWei(suggestion.StandardGwei)},
+			{Speed: SpeedSlow, MaxFeeWei: eth.GweiToWei(suggestion.SlowGwei)},
+		},
+	}, nil
+}
+
+// Blocknative, which rates its suggestions by the confidence of being included in the next block
+type blocknativeOracle struct {
+	apiKey string
+}
+
+func (o blocknativeOracle) GetName() string {
+	return "Blocknative"
+}
+
+func (o blocknativeOracle) GetGasPrices() (GasPrices, error) {
+	suggestion, err := blocknative.GetGasPrices(o.apiKey)
+	if err != nil {
+		return GasPrices{}, err
+	}
+	speedsByConfidence := map[int]string{
+		99: SpeedRapid,
+		95: SpeedFast,
+		80: SpeedStandard,
+		70: SpeedSlow,
+	}
+	prices := GasPrices{
+		Source: o.GetName(),
+		Tiers:  []GasPriceTier{},
+	}
+	for _, estimate := range suggestion.Estimates {
+		speed, exists := speedsByConfidence[estimate.Confidence]
+		if !exists {
+			continue
+		}
+		prices.Tiers = append(prices.Tiers, GasPriceTier{
+			Speed:     speed,
+			WaitTime:  fmt.Sprintf("%d%% chance", estimate.Confidence),
+			MaxFeeWei: eth.GweiToWei(estimate.MaxBaseFeeGwei),
+		})
+	}
+	if len(prices.Tiers) == 0 {
+		return GasPrices{}, fmt.Errorf("Blocknative didn't provide any of the expected estimates")
+	}
+	return prices, nil
+}
+
+// The base fees of recent blocks, from the node's own Execution client.
+// Each suggestion is the next block's base fee with headroom for it to rise over the next few blocks, since it can grow by 12.5% per block.
+type feeHistoryOracle struct {
+	client FeeHistoryClient
+}
+
+func (o feeHistoryOracle) GetName() string {
+	return "your Execution client's fee history"
+}
+
+func (o feeHistoryOracle) GetGasPrices() (GasPrices, error) {
+	history, err := o.client.FeeHistory(context.Background(), feeHistoryBlockCount, nil, []float64{})
+	if err != nil {
+		return GasPrices{}, err
+	}
+	if len(history.BaseFee) == 0 {
+		return GasPrices{}, fmt.Errorf("the fee history didn't include any base fees")
+	}
+
+	// The last base fee is the one for the next block
+	nextBaseFee := history.BaseFee[len(history.BaseFee)-1]
+	scale := func(numerator int64, denominator int64) *big.Int {
+		fee := big.NewInt(0).Mul(nextBaseFee, big.NewInt(numerator))
+		return fee.Div(fee, big.NewInt(denominator))
+	}
+	return GasPrices{
+		Source: "Fee History",
+		Tiers: []GasPriceTier{
+			{Speed: SpeedRapid, MaxFeeWei: scale(2, 1)},
+			{Speed: SpeedFast, MaxFeeWei: scale(3, 2)},
+			{Speed: SpeedStandard, MaxFeeWei: scale(9, 8)},
+			{Speed: SpeedSlow, MaxFeeWei: nextBaseFee},
+		},
+	}, nil
+}
@@ -0,0 +1,91 @@
+package gas
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v2"
+)
+
+// The file in the CLI's config directory with the gas policies for each command
+const GasPoliciesFile string = "gas-policies.yml"
+
+// The gas settings to use for a command, if none were provided on the command line.
+// Fees are in gwei; a zero value means the setting isn't constrained by the policy.
+type GasPolicy struct {
+	Speed       string  `yaml:"speed,omitempty"`
+	MaxFee      float64 `yaml:"maxFee,omitempty"`
+	PriorityFee float64 `yaml:"priorityFee,omitempty"`
+}
+
+// The default gas policy and the overrides for specific commands, keyed by the command's name (e.g. "node stake-rpl")
+type GasPolicies struct {
+	Default  GasPolicy            `yaml:"default,omitempty"`
+	Commands map[string]GasPolicy `yaml:"commands,omitempty"`
+}
+
+// Load the gas policies from the CLI's config directory; a missing file means there are no policies
+func LoadGasPolicies(configPath string) (GasPolicies, error) {
+	policiesPath, err := homedir.Expand(filepath.Join(configPath, GasPoliciesFile))
+	if err != nil {
+		return GasPolicies{}, fmt.Errorf("Error expanding gas policies path: %w", err)
+	}
+	bytes, err := os.ReadFile(policiesPath)
+	if os.IsNotExist(err) {
+		return GasPolicies{}, nil
+	}
+	if err != nil {
+		return GasPolicies{}, fmt.Errorf("Error reading gas policies file [%s]: %w", policiesPath, err)
+	}
+
+	var policies GasPolicies
+	if err := yaml.Unmarshal(bytes, &policies); err != nil {
+		return GasPolicies{}, fmt.Errorf("Error parsing gas policies file [%s]: %w", policiesPath, err)
+	}
+	for command, policy := range policies.Commands {
+		if err := policy.validate(); err != nil {
+			return GasPolicies{}, fmt.Errorf("Invalid gas policy for [%s]: %w", command, err)
+		}
+	}
+	if err := policies.Default.validate(); err != nil {
+		return GasPolicies{}, fmt.Errorf("Invalid default gas policy: %w", err)
+	}
+	return policies, nil
+}
+
+// Get the policy for a command, using the default policy for any setting the command doesn't override
+func (p GasPolicies) GetPolicy(command string) GasPolicy {
+	policy := p.Default
+	override, exists := p.Commands[command]
+	if !exists {
+		return policy
+	}
+	if override.Speed != "" {
+		policy.Speed = override.Speed
+	}
+	if override.MaxFee != 0 {
+		policy.MaxFee = override.MaxFee
+	}
+	if override.PriorityFee != 0 {
+		policy.PriorityFee = override.PriorityFee
+	}
+	return policy
+}
+
+// Check that the policy's settings are valid
+func (p GasPolicy) validate() error {
+	switch p.Speed {
+	case "", SpeedRapid, SpeedFast, SpeedStandard, SpeedSlow:
+	default:
+		return fmt.Errorf("unknown speed [%s], expected one of %s, %s, %s or %s", p.Speed, SpeedRapid, SpeedFast, SpeedStandard, SpeedSlow)
+	}
+	if p.MaxFee < 0 || p.PriorityFee < 0 {
+		return fmt.Errorf("fees can't be negative")
+	}
+	if p.MaxFee != 0 && p.PriorityFee > p.MaxFee {
+		return fmt.Errorf("the priority fee can't be greater than the max fee")
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package grafana
+
+// The rule group the alert rules are provisioned in
+const ruleGroup string = "Rocket Pool"
+
+// A Grafana alert rule that fires when a Prometheus query crosses a threshold
+type alertRule struct {
+	uid        string
+	title      string
+	summary    string
+	expr       string
+	comparison string
+	threshold  float64
+	duration   string
+}
+
+// Get the alert rules the Smartnode provisions
+func getAlertRules() []alertRule {
+	return []alertRule{
+		{
+			uid:        "rocketpool-rpl-below-minimum",
+			title:      "RPL stake below the minimum",
+			summary:    "The node's RPL stake is below the minimum, so it won't earn RPL rewards.",
+			expr:       `rocketpool_stake_rpl_above_minimum`,
+			comparison: "lt",
+			threshold:  0,
+			duration:   "10m",
+		},
+		{
+			uid:        "rocketpool-low-wallet-balance",
+			title:      "Low node wallet balance",
+			summary:    "The node wallet is running out of ETH for gas.",
+			expr:       `rocketpool_node_balance{Token="ETH"}`,
+			comparison: "lt",
+			threshold:  0.05,
+			duration:   "10m",
+		},
+		{
+			uid:        "rocketpool-client-circuit-open",
+			title:      "Client unavailable",
+			summary:    "The Smartnode stopped sending requests to one of its clients because too many of them failed.",
+			expr:       `max(rocketpool_client_health_circuit_open)`,
+			comparison: "gt",
+			threshold:  0,
+			duration:   "10m",
+		},
+	}
+}
+
+// Build the JSON model of an alert rule that Grafana's provisioning API accepts
+func (r alertRule) model(datasourceUid string) map[string]interface{} {
+	return map[string]interface{}{
+		"uid":       r.uid,
+		"title":     r.title,
+		"folderUID": FolderUid,
+		"ruleGroup": ruleGroup,
+		"condition": "B",
+		"data": []map[string]interface{}{
+			{
+				"refId":             "A",
+				"relativeTimeRange": map[string]int{"from": 600, "to": 0},
+				"datasourceUid":     datasourceUid,
+				"model": map[string]interface{}{
+					"refId":   "A",
+					"expr":    r.expr,
+					"instant": true,
+				},
+			},
+			{
+				"refId":             "B",
+				"relativeTimeRange": map[string]int{"from": 0, "to": 0},
+				"datasourceUid":     "__expr__",
+				"model": map[string]interface{}{
+					"refId":      "B",
+					"type":       "classic_conditions",
+					"datasource": map[string]string{"type": "__expr__", "uid": "__expr__"},
+					"conditions": []map[string]interface{}{
+						{
+							"type":      "query",
+							"evaluator": map[string]interface{}{"type": r.comparison, "params": []float64{r.threshold}},
+							"operator":  map[string]string{"type": "and"},
+							"query":     map[string][]string{"params": {"A"}},
+							"reducer":   map[string]string{"type": "last"},
+						},
+					},
+				},
+			},
+		},
+		"noDataState":  "NoData",
+		"execErrState": "Error",
+		"for":          r.duration,
+		"annotations":  map[string]string{"summary": r.summary},
+		"labels":       map[string]string{"source": provisionedTag},
+	}
+}
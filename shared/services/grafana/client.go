@@ -0,0 +1,182 @@
+package grafana
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// How long to wait for Grafana to respond to a request
+const requestTimeout time.Duration = 15 * time.Second
+
+// A client for Grafana's HTTP API, authenticated with a service account token
+type Client struct {
+	url    string
+	token  string
+	client http.Client
+}
+
+// A Grafana folder
+type folder struct {
+	Uid   string `json:"uid"`
+	Title string `json:"title"`
+}
+
+// A Grafana datasource
+type datasource struct {
+	Uid  string `json:"uid"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Create a new Grafana client
+func NewClient(url string, token string) *Client {
+	return &Client{
+		url:    strings.TrimSuffix(url, "/"),
+		token:  token,
+		client: http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Wait for Grafana to start responding, up to the timeout
+func (c *Client) WaitUntilReady(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		_, err := c.request(http.MethodGet, "/api/health", nil, nil)
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Grafana isn't responding: %w", err)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// Get the UID of the Prometheus datasource that the dashboards and alert rules query
+func (c *Client) GetPrometheusDatasourceUid() (string, error) {
+	datasources := []datasource{}
+	if _, err := c.request(http.MethodGet, "/api/datasources", nil, &datasources); err != nil {
+		return "", fmt.Errorf("error getting datasources: %w", err)
+	}
+	for _, ds := range datasources {
+		if ds.Type == "prometheus" {
+			return ds.Uid, nil
+		}
+	}
+	return "", fmt.Errorf("Grafana doesn't have a Prometheus datasource")
+}
+
+// Create a folder if it doesn't exist yet
+func (c *Client) EnsureFolder(uid string, title string) error {
+	status, err := c.request(http.MethodGet, "/api/folders/"+uid, nil, nil)
+	if status == http.StatusNotFound {
+		_, err = c.request(http.MethodPost, "/api/folders", folder{Uid: uid, Title: title}, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("error creating folder [%s]: %w", title, err)
+	}
+	return nil
+}
+
+// Get the tags of a dashboard, or nil if it doesn't exist
+func (c *Client) GetDashboardTags(uid string) ([]string, error) {
+	var response struct {
+		Dashboard struct {
+			Tags []string `json:"tags"`
+		} `json:"dashboard"`
+	}
+	status, err := c.request(http.MethodGet, "/api/dashboards/uid/"+uid, nil, &response)
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting dashboard [%s]: %w", uid, err)
+	}
+	if response.Dashboard.Tags == nil {
+		return []string{}, nil
+	}
+	return response.Dashboard.Tags, nil
+}
+
+// Create or replace a dashboard
+func (c *Client) SaveDashboard(model map[string]interface{}, folderUid string, message string) error {
+	body := map[string]interface{}{
+		"dashboard": model,
+		"folderUid": folderUid,
+		"overwrite": true,
+		"message":   message,
+	}
+	if _, err := c.request(http.MethodPost, "/api/dashboards/db", body, nil); err != nil {
+		return fmt.Errorf("error saving dashboard [%s]: %w", model["title"], err)
+	}
+	return nil
+}
+
+// Create or replace an alert rule
+func (c *Client) SaveAlertRule(rule map[string]interface{}) error {
+	path := fmt.Sprintf("/api/v1/provisioning/alert-rules/%s", rule["uid"])
+	status, err := c.request(http.MethodGet, path, nil, nil)
+	if status == http.StatusNotFound {
+		_, err = c.request(http.MethodPost, "/api/v1/provisioning/alert-rules", rule, nil)
+	} else if err == nil {
+		_, err = c.request(http.MethodPut, path, rule, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("error saving alert rule [%s]: %w", rule["title"], err)
+	}
+	return nil
+}
+
+// Send a request to the API, decode the response into out if it's provided, and get the response's status code
+func (c *Client) request(method string, path string, body interface{}, out interface{}) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return 0, fmt.Errorf("error serializing request: %w", err)
+		}
+		reader = bytes.NewReader(bodyBytes)
+	}
+	request, err := http.NewRequest(method, c.url+path, reader)
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		request.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	// Leave the provisioned alert rules editable in the UI
+	request.Header.Set("X-Disable-Provenance", "true")
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	responseBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return response.StatusCode, fmt.Errorf("error reading response: %w", err)
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		var apiError struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(responseBytes, &apiError) == nil && apiError.Message != "" {
+			return response.StatusCode, fmt.Errorf("%s: %s", response.Status, apiError.Message)
+		}
+		return response.StatusCode, fmt.Errorf("%s", response.Status)
+	}
+	if out != nil {
+		if err := json.Unmarshal(responseBytes, out); err != nil {
+			return response.StatusCode, fmt.Errorf("error deserializing response: %w", err)
+		}
+	}
+	return response.StatusCode, nil
+}
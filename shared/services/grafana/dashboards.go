@@ -0,0 +1,241 @@
+package grafana
+
+import (
+	"fmt"
+)
+
+// The version of the provisioned dashboards and alert rules; bump it whenever they change so nodes update them
+const DashboardsVersion uint = 4
+
+// The folder the dashboards and alert rules are provisioned in
+const (
+	FolderUid   string = "rocketpool"
+	folderTitle string = "Rocket Pool"
+)
+
+// The tag that marks a dashboard as provisioned by the Smartnode
+const provisionedTag string = "rocketpool"
+
+type panelType string
+
+const (
+	panelType_Stat       panelType = "stat"
+	panelType_TimeSeries panelType = "timeseries"
+)
+
+// A Prometheus query shown on a panel
+type query struct {
+	expr   string
+	legend string
+}
+
+// A panel on a dashboard, which is laid out left to right and wraps to the next line when the row is full
+type panel struct {
+	title       string
+	description string
+	kind        panelType
+	unit        string
+	width       int
+	queries     []query
+}
+
+// A titled row of panels
+type row struct {
+	title  string
+	panels []panel
+}
+
+// A dashboard built from the Smartnode's metrics
+type dashboard struct {
+	uid   string
+	title string
+	rows  []row
+}
+
+// Get the tag that marks a dashboard as being at the current version
+func getVersionTag() string {
+	return fmt.Sprintf("smartnode-dashboards-v%d", DashboardsVersion)
+}
+
+// Get the dashboards the Smartnode provisions
+func getDashboards() []dashboard {
+	return []dashboard{
+		{
+			uid:   "rocketpool-node",
+			title: "Rocket Pool Node",
+			rows: []row{
+				{
+					title: "Node",
+					panels: []panel{
+						{title: "Node Wallet", unit: "suffix: ETH", kind: panelType_Stat, width: 4, queries: []query{{expr: `rocketpool_node_balance{Token="ETH"}`}}},
+						{title: "Active Minipools", unit: "none", kind: panelType_Stat, width: 4, queries: []query{{expr: `rocketpool_node_active_minipool_count`}}},
+						{title: "Beacon Chain Balance", unit: "suffix: ETH", kind: panelType_Stat, width: 4, queries: []query{{expr: `rocketpool_node_beacon_balance`}}},
+						{title: "Node Share of Beacon Balance", unit: "suffix: ETH", kind: panelType_Stat, width: 4, queries: []query{{expr: `rocketpool_node_beacon_share`}}},
+						{title: "Unclaimed Smoothing Pool Rewards", unit: "suffix: ETH", kind: panelType_Stat, width: 4, queries: []query{{expr: `rocketpool_node_unclaimed_eth_rewards`}}},
+						{title: "Unclaimed RPL Rewards", unit: "suffix: RPL", kind: panelType_Stat, width: 4, queries: []query{{expr: `rocketpool_node_unclaimed_rewards`}}},
+					},
+				},
+				{
+					title: "Smoothing Pool",
+					panels: []panel{
+						{title: "Opted In", description: "Whether the node is opted into the Smoothing Pool", unit: "bool_yes_no", kind: panelType_Stat, width: 6, queries: []query{{expr: `rocketpool_smoothing_pool_node_opted_in`}}},
+						{title: "Opt In/Out Cooldown", description: "How long until the node can opt in or out of the Smoothing Pool again", unit: "s", kind: panelType_Stat, width: 6, queries: []query{{expr: `rocketpool_smoothing_pool_node_cooldown_remaining_seconds`}}},
+						{title: "Smoothing Pool Balance", unit: "suffix: ETH", kind: panelType_Stat, width: 6, queries: []query{{expr: `rocketpool_smoothing_pool_eth_balance`}}},
+						{title: "Estimated Node Share", description: "The node's estimated share of the Smoothing Pool at the end of the interval", unit: "suffix: ETH", kind: panelType_Stat, width: 6, queries: []query{{expr: `rocketpool_smoothing_pool_node_estimated_share`}}},
+						{title: "Smoothing Pool", unit: "suffix: ETH", kind: panelType_TimeSeries, width: 24, queries: []query{
+							{expr: `rocketpool_smoothing_pool_eth_balance`, legend: "Balance"},
+							{expr: `rocketpool_smoothing_pool_projected_eth_balance`, legend: "Projected balance"},
+							{expr: `rocketpool_smoothing_pool_node_estimated_share`, legend: "Estimated node share"},
+						}},
+					},
+				},
+				{
+					title: "RPL Stake",
+					panels: []panel{
+						{title: "Effective RPL Stake", unit: "suffix: RPL", kind: panelType_Stat, width: 4, queries: []query{{expr: `rocketpool_stake_effective_rpl`}}},
+						{title: "Collateral Ratio", description: "The value of the node's RPL stake as a fraction of its borrowed ETH", unit: "percentunit", kind: panelType_Stat, width: 4, queries: []query{{expr: `rocketpool_stake_collateral_ratio`}}},
+						{title: "Node Weight", description: "The node's share of the network's total effective RPL stake", unit: "percentunit", kind: panelType_Stat, width: 4, queries: []query{{expr: `rocketpool_stake_node_weight`}}},
+						{title: "Expected RPL Rewards", unit: "suffix: RPL", kind: panelType_Stat, width: 4, queries: []query{{expr: `rocketpool_node_expected_rpl_rewards`}}},
+						{title: "RPL Above Minimum", unit: "suffix: RPL", kind: panelType_Stat, width: 4, queries: []query{{expr: `rocketpool_stake_rpl_above_minimum`}}},
+						{title: "RPL Below Maximum", unit: "suffix: RPL", kind: panelType_Stat, width: 4, queries: []query{{expr: `rocketpool_stake_rpl_below_maximum`}}},
+						{title: "RPL Stake", unit: "suffix: RPL", kind: panelType_TimeSeries, width: 24, queries: []query{
+							{expr: `rocketpool_node_total_staked_rpl`, legend: "Staked"},
+							{expr: `rocketpool_stake_effective_rpl`, legend: "Effective"},
+							{expr: `rocketpool_stake_minimum_rpl`, legend: "Minimum"},
+							{expr: `rocketpool_stake_maximum_rpl`, legend: "Maximum"},
+						}},
+					},
+				},
+				{
+					title: "Duties",
+					panels: []panel{
+						{title: "Attestation Effectiveness", description: "The share of the validators' attestations that were included on chain since the daemon started", unit: "percentunit", kind: panelType_Stat, width: 6, queries: []query{{expr: `1 - rocketpool_duties_missed_attestations_total / rocketpool_duties_attestations_total`}}},
+						{title: "Missed Attestations", unit: "none", kind: panelType_Stat, width: 6, queries: []query{{expr: `rocketpool_duties_missed_attestations_total`}}},
+						{title: "Proposals", unit: "none", kind: panelType_Stat, width: 6, queries: []query{{expr: `rocketpool_duties_proposals_total`}}},
+						{title: "Missed Proposals", unit: "none", kind: panelType_Stat, width: 6, queries: []query{{expr: `rocketpool_duties_missed_proposals_total`}}},
+						{title: "Missed Attestations per Hour", unit: "none", kind: panelType_TimeSeries, width: 24, queries: []query{{expr: `increase(rocketpool_duties_missed_attestations_total[1h])`, legend: "Missed"}}},
+					},
+				},
+				{
+					title: "Clients",
+					panels: []panel{
+						{title: "Client Latency", unit: "ms", kind: panelType_TimeSeries, width: 12, queries: []query{{expr: `rocketpool_client_health_latency_ms`, legend: "{{layer}} {{client}}"}}},
+						{title: "Client Failure Rate", unit: "percentunit", kind: panelType_TimeSeries, width: 12, queries: []query{{expr: `rocketpool_client_health_failure_rate`, legend: "{{layer}} {{client}}"}}},
+						{title: "Request Latency (p95)", unit: "s", kind: panelType_TimeSeries, width: 12, queries: []query{{expr: `histogram_quantile(0.95, sum by (le, layer, client) (rate(rocketpool_client_request_duration_seconds_bucket[5m])))`, legend: "{{layer}} {{client}}"}}},
+						{title: "Request Error Rate", unit: "percentunit", kind: panelType_TimeSeries, width: 12, queries: []query{{expr: `sum by (layer, client) (rate(rocketpool_client_request_errors_total[5m])) / sum by (layer, client) (rate(rocketpool_client_requests_total[5m]))`, legend: "{{layer}} {{client}}"}}},
+						{title: "Slowest Requests (p95)", unit: "s", kind: panelType_TimeSeries, width: 24, queries: []query{{expr: `topk(10, histogram_quantile(0.95, sum by (le, layer, method) (rate(rocketpool_client_request_duration_seconds_bucket[5m]))))`, legend: "{{layer}} {{method}}"}}},
+					},
+				},
+			},
+		},
+		{
+			uid:   "rocketpool-network",
+			title: "Rocket Pool Network",
+			rows: []row{
+				{
+					title: "Network",
+					panels: []panel{
+						{title: "RPL Price", unit: "suffix: ETH", kind: panelType_Stat, width: 4, queries: []query{{expr: `rocketpool_rpl_rpl_price`}}},
+						{title: "Nodes", unit: "none", kind: panelType_Stat, width: 4, queries: []query{{expr: `rocketpool_supply_node_count`}}},
+						{title: "Minipools", unit: "none", kind: panelType_Stat, width: 4, queries: []query{{expr: `rocketpool_supply_total_minipools`}}},
+						{title: "Node Commission", unit: "percentunit", kind: panelType_Stat, width: 4, queries: []query{{expr: `rocketpool_supply_node_fee`}}},
+						{title: "Deposit Pool", unit: "suffix: ETH", kind: panelType_Stat, width: 4, queries: []query{{expr: `rocketpool_demand_deposit_pool_balance`}}},
+						{title: "Minipool Queue", unit: "none", kind: panelType_Stat, width: 4, queries: []query{{expr: `rocketpool_demand_queue_length`}}},
+						{title: "RPL Price", unit: "suffix: ETH", kind: panelType_TimeSeries, width: 24, queries: []query{{expr: `rocketpool_rpl_rpl_price`, legend: "RPL"}}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Build the JSON model of a dashboard that Grafana's API accepts
+func (d dashboard) model(datasourceUid string) map[string]interface{} {
+	datasource := map[string]interface{}{
+		"type": "prometheus",
+		"uid":  datasourceUid,
+	}
+
+	panels := []map[string]interface{}{}
+	id := 1
+	y := 0
+	for _, r := range d.rows {
+		panels = append(panels, map[string]interface{}{
+			"id":        id,
+			"type":      "row",
+			"title":     r.title,
+			"collapsed": false,
+			"gridPos":   map[string]int{"x": 0, "y": y, "w": 24, "h": 1},
+			"panels":    []interface{}{},
+		})
+		id++
+		y++
+
+		x := 0
+		rowHeight := 0
+		for _, p := range r.panels {
+			height := 4
+			if p.kind == panelType_TimeSeries {
+				height = 8
+			}
+			if x+p.width > 24 {
+				x = 0
+				y += rowHeight
+				rowHeight = 0
+			}
+			if height > rowHeight {
+				rowHeight = height
+			}
+
+			targets := []map[string]interface{}{}
+			for i, q := range p.queries {
+				targets = append(targets, map[string]interface{}{
+					"refId":        string(rune('A' + i)),
+					"datasource":   datasource,
+					"expr":         q.expr,
+					"legendFormat": q.legend,
+				})
+			}
+			model := map[string]interface{}{
+				"id":          id,
+				"type":        p.kind,
+				"title":       p.title,
+				"description": p.description,
+				"datasource":  datasource,
+				"gridPos":     map[string]int{"x": x, "y": y, "w": p.width, "h": height},
+				"fieldConfig": map[string]interface{}{
+					"defaults":  map[string]interface{}{"unit": p.unit},
+					"overrides": []interface{}{},
+				},
+				"targets": targets,
+			}
+			if p.kind == panelType_Stat {
+				model["options"] = map[string]interface{}{
+					"reduceOptions": map[string]interface{}{
+						"calcs":  []string{"lastNotNull"},
+						"fields": "",
+						"values": false,
+					},
+					"colorMode": "value",
+					"graphMode": "area",
+				}
+			}
+			panels = append(panels, model)
+			id++
+			x += p.width
+		}
+		y += rowHeight
+	}
+
+	return map[string]interface{}{
+		"uid":           d.uid,
+		"title":         d.title,
+		"tags":          []string{provisionedTag, getVersionTag()},
+		"editable":      true,
+		"timezone":      "browser",
+		"refresh":       "1m",
+		"schemaVersion": 37,
+		"time":          map[string]string{"from": "now-24h", "to": "now"},
+		"panels":        panels,
+	}
+}
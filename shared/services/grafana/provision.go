@@ -0,0 +1,60 @@
+package grafana
+
+import (
+	"fmt"
+)
+
+// Push the Smartnode's dashboards and alert rules to Grafana, and get whether anything was updated.
+// Nothing is pushed if every dashboard is already at the current version, unless force is set.
+func Provision(client *Client, force bool) (bool, error) {
+
+	dashboards := getDashboards()
+	if !force {
+		upToDate := true
+		for _, d := range dashboards {
+			tags, err := client.GetDashboardTags(d.uid)
+			if err != nil {
+				return false, err
+			}
+			if !hasTag(tags, getVersionTag()) {
+				upToDate = false
+				break
+			}
+		}
+		if upToDate {
+			return false, nil
+		}
+	}
+
+	datasourceUid, err := client.GetPrometheusDatasourceUid()
+	if err != nil {
+		return false, err
+	}
+	if err := client.EnsureFolder(FolderUid, folderTitle); err != nil {
+		return false, err
+	}
+
+	message := fmt.Sprintf("Provisioned by the Smartnode (dashboards v%d)", DashboardsVersion)
+	for _, d := range dashboards {
+		if err := client.SaveDashboard(d.model(datasourceUid), FolderUid, message); err != nil {
+			return false, err
+		}
+	}
+	for _, r := range getAlertRules() {
+		if err := client.SaveAlertRule(r.model(datasourceUid)); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+
+}
+
+// Check if a list of tags contains the provided one
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
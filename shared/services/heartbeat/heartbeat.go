@@ -0,0 +1,69 @@
+package heartbeat
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How long to wait for the heartbeat service to respond
+const pingTimeout time.Duration = 10 * time.Second
+
+// A dead man's switch (such as a healthchecks.io check) that's pinged after every successful duty cycle, so the
+// service can page the operator when the pings stop
+type Heartbeat struct {
+	name   string
+	url    string
+	client http.Client
+	log    *log.ColorLogger
+	failed bool
+}
+
+// Create a new heartbeat; it does nothing if the URL is blank
+func NewHeartbeat(name string, url string, logger *log.ColorLogger) *Heartbeat {
+	return &Heartbeat{
+		name:   name,
+		url:    url,
+		client: http.Client{Timeout: pingTimeout},
+		log:    logger,
+	}
+}
+
+// Mark the current duty cycle as failed, so it won't be pinged
+func (h *Heartbeat) Fail() {
+	h.failed = true
+}
+
+// Ping the heartbeat URL unless the current duty cycle failed, and start the next cycle
+func (h *Heartbeat) Ping() {
+	failed := h.failed
+	h.failed = false
+	if h.url == "" || failed {
+		return
+	}
+	if err := h.ping(); err != nil {
+		h.log.Printlnf("Could not send the %s heartbeat: %s", h.name, err.Error())
+	}
+}
+
+// Send a request to the heartbeat URL
+func (h *Heartbeat) ping() error {
+	response, err := h.client.Get(h.url)
+	if err != nil {
+		// Leave the URL out of the error, since it's what identifies the check
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("error sending request: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", response.Request.URL.Host, response.Status)
+	}
+	return nil
+}
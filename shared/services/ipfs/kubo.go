@@ -0,0 +1,71 @@
+package ipfs
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+// Pins files to an IPFS node (such as Kubo) through its HTTP API
+type KuboPinner struct {
+	apiUrl string
+	client *http.Client
+}
+
+// A single entry in the response of the /api/v0/add route
+type kuboAddResponse struct {
+	Name string `json:"Name"`
+	Hash string `json:"Hash"`
+}
+
+// Creates a new pinner for the IPFS node with the given API URL
+func NewKuboPinner(apiUrl string) *KuboPinner {
+	return &KuboPinner{
+		apiUrl: strings.TrimSuffix(apiUrl, "/"),
+		client: getHttpClient(),
+	}
+}
+
+// Get the name of the pinning service
+func (p *KuboPinner) GetName() string {
+	return "IPFS node"
+}
+
+// Adds and pins the file on the node
+func (p *KuboPinner) Pin(filename string, data []byte) (string, error) {
+	body, contentType, err := createMultipartFile(filename, data, nil)
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/api/v0/add?pin=true&wrap-with-directory=true&cid-version=1", p.apiUrl)
+	response, err := p.client.Post(url, contentType, body)
+	if err != nil {
+		return "", fmt.Errorf("error sending add request to IPFS node: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		responseBody, _ := io.ReadAll(response.Body)
+		return "", fmt.Errorf("IPFS node responded to add request with status %s: %s", response.Status, string(responseBody))
+	}
+
+	// The node streams one entry per added object; the wrapping directory is the one without a name
+	decoder := json.NewDecoder(response.Body)
+	for {
+		var entry kuboAddResponse
+		err = decoder.Decode(&entry)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("error decoding IPFS node add response: %w", err)
+		}
+		if entry.Name == "" {
+			return entry.Hash, nil
+		}
+	}
+	return "", fmt.Errorf("IPFS node add response did not include the wrapping directory for %s", filename)
+}
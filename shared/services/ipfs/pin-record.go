@@ -0,0 +1,68 @@
+package ipfs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/klauspost/compress/zstd"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// A record of where a rewards interval's files were pinned
+type PinRecord struct {
+	Index                      uint64    `json:"index"`
+	Service                    string    `json:"service"`
+	RewardsFileCID             string    `json:"rewardsFileCid"`
+	MinipoolPerformanceFileCID string    `json:"minipoolPerformanceFileCid"`
+	PinTime                    time.Time `json:"pinTime"`
+}
+
+// Compresses and pins the rewards tree and minipool performance files for an interval, then saves a record of the CIDs
+// next to them. The files are compressed the same way as the Oracle DAO's uploads so the pinned copies match the published ones.
+func PinRewardsFiles(pinner Pinner, cfg *config.RocketPoolConfig, index uint64, rewardsFileBytes []byte, minipoolPerformanceBytes []byte) (*PinRecord, error) {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBestCompression))
+	if err != nil {
+		return nil, fmt.Errorf("error creating zstd compressor: %w", err)
+	}
+	defer encoder.Close()
+
+	record := &PinRecord{
+		Index:   index,
+		Service: pinner.GetName(),
+	}
+
+	// Pin the minipool performance file
+	if minipoolPerformanceBytes != nil {
+		filename := filepath.Base(cfg.Smartnode.GetMinipoolPerformancePath(index, true)) + config.RewardsTreeIpfsExtension
+		compressedBytes := encoder.EncodeAll(minipoolPerformanceBytes, make([]byte, 0, len(minipoolPerformanceBytes)))
+		record.MinipoolPerformanceFileCID, err = pinner.Pin(filename, compressedBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error pinning minipool performance file: %w", err)
+		}
+	}
+
+	// Pin the rewards tree
+	filename := filepath.Base(cfg.Smartnode.GetRewardsTreePath(index, true)) + config.RewardsTreeIpfsExtension
+	compressedBytes := encoder.EncodeAll(rewardsFileBytes, make([]byte, 0, len(rewardsFileBytes)))
+	record.RewardsFileCID, err = pinner.Pin(filename, compressedBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error pinning rewards tree file: %w", err)
+	}
+	record.PinTime = time.Now().UTC()
+
+	// Save the record
+	recordBytes, err := json.MarshalIndent(record, "", "\t")
+	if err != nil {
+		return nil, fmt.Errorf("error serializing pin record: %w", err)
+	}
+	recordPath := cfg.Smartnode.GetRewardsPinRecordPath(index, true)
+	err = os.WriteFile(recordPath, recordBytes, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error saving pin record to %s: %w", recordPath, err)
+	}
+
+	return record, nil
+}
@@ -0,0 +1,79 @@
+package ipfs
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/goccy/go-json"
+)
+
+// Settings
+const (
+	pinataPinFileUrl string = "https://api.pinata.cloud/pinning/pinFileToIPFS"
+	pinataOptions    string = `{"cidVersion":1,"wrapWithDirectory":true}`
+)
+
+// Pins files to Pinata
+type PinataPinner struct {
+	token  string
+	client *http.Client
+}
+
+// The response of the pinFileToIPFS route
+type pinataPinResponse struct {
+	IpfsHash string `json:"IpfsHash"`
+}
+
+// Creates a new pinner using the given Pinata JWT
+func NewPinataPinner(token string) *PinataPinner {
+	return &PinataPinner{
+		token:  token,
+		client: getHttpClient(),
+	}
+}
+
+// Get the name of the pinning service
+func (p *PinataPinner) GetName() string {
+	return "Pinata"
+}
+
+// Uploads and pins the file on Pinata
+func (p *PinataPinner) Pin(filename string, data []byte) (string, error) {
+	body, contentType, err := createMultipartFile(filename, data, map[string]string{
+		"pinataOptions": pinataOptions,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Send the request
+	request, err := http.NewRequest(http.MethodPost, pinataPinFileUrl, body)
+	if err != nil {
+		return "", fmt.Errorf("error creating Pinata request: %w", err)
+	}
+	request.Header.Set("Content-Type", contentType)
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", p.token))
+	response, err := p.client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("error sending pin request to Pinata: %w", err)
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading Pinata response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Pinata responded to pin request with status %s: %s", response.Status, string(responseBody))
+	}
+
+	var pinResponse pinataPinResponse
+	err = json.Unmarshal(responseBody, &pinResponse)
+	if err != nil {
+		return "", fmt.Errorf("error decoding Pinata response: %w", err)
+	}
+	if pinResponse.IpfsHash == "" {
+		return "", fmt.Errorf("Pinata response did not include a CID")
+	}
+	return pinResponse.IpfsHash, nil
+}
@@ -0,0 +1,90 @@
+package ipfs
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings
+const (
+	pinRequestTimeout time.Duration = 5 * time.Minute
+)
+
+// A service that can pin files to IPFS
+type Pinner interface {
+	// Get the name of the pinning service, for logging
+	GetName() string
+
+	// Pins the data as a file with the given name, wrapped in a directory, and returns the CID of that directory
+	Pin(filename string, data []byte) (string, error)
+}
+
+// Creates the pinner selected in the Smartnode config; returns nil if pinning is disabled
+func NewPinner(cfg *config.RocketPoolConfig) (Pinner, error) {
+	mode := cfg.Smartnode.IpfsPinningMode.Value.(cfgtypes.IpfsPinningMode)
+	switch mode {
+	case cfgtypes.IpfsPinningMode_Disabled, cfgtypes.IpfsPinningMode_Unknown:
+		return nil, nil
+
+	case cfgtypes.IpfsPinningMode_Kubo:
+		apiUrl := cfg.Smartnode.IpfsApiUrl.Value.(string)
+		if apiUrl == "" {
+			return nil, fmt.Errorf("IPFS pinning to a local node is enabled but the IPFS API URL is not set")
+		}
+		return NewKuboPinner(apiUrl), nil
+
+	case cfgtypes.IpfsPinningMode_Pinata:
+		token := cfg.Smartnode.PinataApiToken.Value.(string)
+		if token == "" {
+			return nil, fmt.Errorf("IPFS pinning to Pinata is enabled but the Pinata API token is not set")
+		}
+		return NewPinataPinner(token), nil
+
+	case cfgtypes.IpfsPinningMode_Web3Storage:
+		token := cfg.Smartnode.Web3StorageApiToken.Value.(string)
+		if token == "" {
+			return nil, fmt.Errorf("IPFS pinning to Web3.Storage is enabled but the Web3.Storage API token is not set")
+		}
+		return NewWeb3StoragePinner(token)
+
+	default:
+		return nil, fmt.Errorf("unknown IPFS pinning mode [%s]", mode)
+	}
+}
+
+func getHttpClient() *http.Client {
+	return &http.Client{
+		Timeout: pinRequestTimeout,
+	}
+}
+
+// Creates a multipart form body holding the file in a "file" field, along with any extra fields
+func createMultipartFile(filename string, data []byte, fields map[string]string) (*bytes.Buffer, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, "", fmt.Errorf("error creating multipart form for %s: %w", filename, err)
+	}
+	_, err = part.Write(data)
+	if err != nil {
+		return nil, "", fmt.Errorf("error writing %s to multipart form: %w", filename, err)
+	}
+	for name, value := range fields {
+		err = writer.WriteField(name, value)
+		if err != nil {
+			return nil, "", fmt.Errorf("error writing %s to multipart form: %w", name, err)
+		}
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, "", fmt.Errorf("error closing multipart form for %s: %w", filename, err)
+	}
+	return body, writer.FormDataContentType(), nil
+}
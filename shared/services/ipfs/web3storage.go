@@ -0,0 +1,52 @@
+package ipfs
+
+import (
+	"context"
+	"fmt"
+	"testing/fstest"
+	"time"
+
+	"github.com/web3-storage/go-w3s-client"
+)
+
+// Pins files to Web3.Storage
+type Web3StoragePinner struct {
+	client w3s.Client
+}
+
+// Creates a new pinner using the given Web3.Storage API token
+func NewWeb3StoragePinner(token string) (*Web3StoragePinner, error) {
+	client, err := w3s.NewClient(w3s.WithToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("error creating new Web3.Storage client: %w", err)
+	}
+	return &Web3StoragePinner{
+		client: client,
+	}, nil
+}
+
+// Get the name of the pinning service
+func (p *Web3StoragePinner) GetName() string {
+	return "Web3.Storage"
+}
+
+// Uploads the file to Web3.Storage, which pins it automatically
+func (p *Web3StoragePinner) Pin(filename string, data []byte) (string, error) {
+	// Create an in-memory file so nothing extra needs to be written to disk
+	fsMap := fstest.MapFS{filename: &fstest.MapFile{
+		Data:    data,
+		Mode:    0644,
+		ModTime: time.Now(),
+	}}
+	file, err := fsMap.Open(filename)
+	if err != nil {
+		return "", fmt.Errorf("error opening memory-mapped file: %w", err)
+	}
+	defer file.Close()
+
+	cid, err := p.client.Put(context.Background(), file)
+	if err != nil {
+		return "", fmt.Errorf("error uploading %s to Web3.Storage: %w", filename, err)
+	}
+	return cid.String(), nil
+}
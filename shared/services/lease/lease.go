@@ -0,0 +1,182 @@
+package lease
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How long a lock file can exist before it's considered abandoned by an instance that crashed while holding it
+const staleLockAge time.Duration = 30 * time.Second
+
+// The record in the lease file
+type leaseRecord struct {
+	Holder  string    `json:"holder"`
+	Expires time.Time `json:"expires"`
+}
+
+// A lease on a file in shared storage that lets redundant instances of a daemon agree on which one of them does the
+// work; the holder renews it while its task loop is alive, and a standby takes it over once it expires
+type Lease struct {
+	path     string
+	instance string
+	timeout  time.Duration
+	log      *log.ColorLogger
+
+	lock        sync.Mutex
+	held        bool
+	expires     time.Time
+	lastCheckIn time.Time
+	lastHolder  string
+}
+
+// Create a new lease on the file at the provided path
+func NewLease(path string, timeout time.Duration, logger *log.ColorLogger) (*Lease, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, fmt.Errorf("error generating lease instance ID: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("error creating lease folder: %w", err)
+	}
+	return &Lease{
+		path:        path,
+		instance:    fmt.Sprintf("%s-%s", hostname, hex.EncodeToString(suffix)),
+		timeout:     timeout,
+		log:         logger,
+		lastCheckIn: time.Now(),
+	}, nil
+}
+
+// Get the ID that identifies this instance in the lease file
+func (l *Lease) Instance() string {
+	return l.instance
+}
+
+// Record that the task loop is still alive; the lease is only renewed while it keeps checking in
+func (l *Lease) CheckIn() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.lastCheckIn = time.Now()
+}
+
+// Check if this instance holds the lease, with enough time left on it to finish a task before a standby could take over
+func (l *Lease) IsHeld() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.held && time.Until(l.expires) > l.timeout/4
+}
+
+// Keep acquiring or renewing the lease for as long as the task loop checks in
+func (l *Lease) Run() {
+	for {
+		l.lock.Lock()
+		stalled := time.Since(l.lastCheckIn) > l.timeout
+		l.lock.Unlock()
+
+		if stalled {
+			l.log.Println("The task loop hasn't checked in recently, so the duty lease won't be renewed.")
+		} else if err := l.refresh(); err != nil {
+			l.log.Printlnf("Could not refresh the duty lease: %s", err.Error())
+		}
+		time.Sleep(l.timeout / 3)
+	}
+}
+
+// Take or renew the lease if nobody else holds it
+func (l *Lease) refresh() error {
+	lockPath := l.path + ".lock"
+	if err := l.takeLockFile(lockPath); err != nil {
+		return err
+	}
+	defer os.Remove(lockPath)
+
+	record, err := l.readRecord()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if record.Holder != "" && record.Holder != l.instance && now.Before(record.Expires) {
+		l.setHeld(false, time.Time{}, record.Holder)
+		return nil
+	}
+
+	// Write the new record to a temporary file first so readers never see a partial one
+	expires := now.Add(l.timeout)
+	bytes, err := json.Marshal(leaseRecord{Holder: l.instance, Expires: expires})
+	if err != nil {
+		return fmt.Errorf("error encoding lease: %w", err)
+	}
+	tempPath := fmt.Sprintf("%s.%s.tmp", l.path, l.instance)
+	if err := os.WriteFile(tempPath, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing lease: %w", err)
+	}
+	if err := os.Rename(tempPath, l.path); err != nil {
+		return fmt.Errorf("error saving lease: %w", err)
+	}
+	l.setHeld(true, expires, l.instance)
+	return nil
+}
+
+// Create the lock file that guards updates to the lease, removing it first if an instance abandoned it
+func (l *Lease) takeLockFile(lockPath string) error {
+	for attempt := 0; attempt < 2; attempt++ {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			file.WriteString(l.instance)
+			return file.Close()
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("error creating lease lock: %w", err)
+		}
+		info, err := os.Stat(lockPath)
+		if err != nil || time.Since(info.ModTime()) < staleLockAge {
+			break
+		}
+		os.Remove(lockPath)
+	}
+	return fmt.Errorf("another instance is updating the lease")
+}
+
+// Read the lease file; a missing file means nobody holds the lease
+func (l *Lease) readRecord() (leaseRecord, error) {
+	record := leaseRecord{}
+	bytes, err := os.ReadFile(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return record, nil
+	}
+	if err != nil {
+		return record, fmt.Errorf("error reading lease: %w", err)
+	}
+	if err := json.Unmarshal(bytes, &record); err != nil {
+		return record, fmt.Errorf("error decoding lease: %w", err)
+	}
+	return record, nil
+}
+
+// Update the lease state, logging when it changes hands
+func (l *Lease) setHeld(held bool, expires time.Time, holder string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if held && !l.held {
+		l.log.Printlnf("Acquired the duty lease; this instance (%s) is now the primary.", l.instance)
+	} else if !held && holder != l.lastHolder {
+		l.log.Printlnf("Instance %s holds the duty lease; this instance (%s) is standing by.", holder, l.instance)
+	}
+	l.held = held
+	l.expires = expires
+	l.lastHolder = holder
+}
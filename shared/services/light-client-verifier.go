@@ -0,0 +1,454 @@
+package services
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/beacon/client"
+	"github.com/rocket-pool/smartnode/shared/types/eth2"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// How often the light client checks for a new finalized header; finality only advances once per epoch
+const lightClientUpdateInterval time.Duration = time.Minute
+
+// Returned when a Beacon client's response doesn't match what the light client verified
+var ErrBeaconVerificationFailed = errors.New("Beacon client failed light client verification")
+
+// The positions of the light client proofs in the Beacon state, which moved when Electra added fields to it
+type lightClientProofLayout struct {
+	finalizedDepth        int
+	finalizedIndex        uint64
+	currentCommitteeDepth int
+	currentCommitteeIndex uint64
+	nextCommitteeDepth    int
+	nextCommitteeIndex    uint64
+}
+
+var altairProofLayout = lightClientProofLayout{
+	finalizedDepth:        6,
+	finalizedIndex:        41,
+	currentCommitteeDepth: 5,
+	currentCommitteeIndex: 22,
+	nextCommitteeDepth:    5,
+	nextCommitteeIndex:    23,
+}
+
+var electraProofLayout = lightClientProofLayout{
+	finalizedDepth:        7,
+	finalizedIndex:        41,
+	currentCommitteeDepth: 6,
+	currentCommitteeIndex: 22,
+	nextCommitteeDepth:    6,
+	nextCommitteeIndex:    23,
+}
+
+// Checks Beacon clients against a sync committee light client.
+// Starting from a trusted block root, it follows the chain's finalized headers by checking the sync committee's signatures over them,
+// then checks that each Beacon client reports the same state root at the latest verified finalized slot before its responses are used.
+// Validators are only read from whole states whose hash tree roots match a verified state root; other responses, such as blocks and duties,
+// are only covered by the state root check, which catches clients that follow a different chain.
+type LightClientVerifier struct {
+	trustedRoot     [32]byte
+	committee       *lightClientCommittee
+	finalized       *eth2.BeaconBlockHeader
+	lastUpdate      time.Time
+	verifiedStates  map[*client.StandardHttpClient]uint64
+	finalizedState  *provenBeaconState
+	historicalState *provenBeaconState
+	logger          log.ColorLogger
+	lock            sync.Mutex
+}
+
+// A Beacon state whose hash tree root matches a state root the light client verified
+type provenBeaconState struct {
+	slot uint64
+	root [32]byte
+	fork string
+	data []byte
+}
+
+// A sync committee the light client has verified
+type lightClientCommittee struct {
+	period  uint64
+	pubkeys []eth2types.PublicKey
+}
+
+// Creates a new light client verifier that bootstraps from the provided trusted block root
+func NewLightClientVerifier(trustedRoot string) (*LightClientVerifier, error) {
+	rootBytes, err := hex.DecodeString(hexutil.RemovePrefix(trustedRoot))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding light client trusted root [%s]: %w", trustedRoot, err)
+	}
+	if len(rootBytes) != 32 {
+		return nil, fmt.Errorf("light client trusted root [%s] is %d bytes but must be 32", trustedRoot, len(rootBytes))
+	}
+	if err := validator.InitializeBLS(); err != nil {
+		return nil, fmt.Errorf("error initializing BLS library: %w", err)
+	}
+
+	verifier := &LightClientVerifier{
+		verifiedStates: map[*client.StandardHttpClient]uint64{},
+		logger:         log.NewColorLogger(color.FgHiBlue),
+	}
+	copy(verifier.trustedRoot[:], rootBytes)
+	return verifier, nil
+}
+
+// Check that a Beacon client is serving the chain the light client has verified.
+// This brings the light client up to date if it's due, then compares the client's state root at the latest verified finalized slot.
+func (v *LightClientVerifier) VerifyClient(bc *client.StandardHttpClient) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.finalized == nil || time.Since(v.lastUpdate) >= lightClientUpdateInterval {
+		if err := v.update(bc); err != nil {
+			return fmt.Errorf("%w: %s", ErrBeaconVerificationFailed, err.Error())
+		}
+	}
+
+	// Only check the state root once per client for each new finalized header
+	if checkedSlot, exists := v.verifiedStates[bc]; exists && checkedSlot == v.finalized.Slot {
+		return nil
+	}
+	stateRoot, err := bc.GetStateRoot(strconv.FormatUint(v.finalized.Slot, 10))
+	if err != nil {
+		return fmt.Errorf("%w: error getting state root for finalized slot %d: %s", ErrBeaconVerificationFailed, v.finalized.Slot, err.Error())
+	}
+	if !bytes.Equal(stateRoot, v.finalized.StateRoot[:]) {
+		return fmt.Errorf("%w: the client's state root for finalized slot %d is %s, but the light client verified %s", ErrBeaconVerificationFailed, v.finalized.Slot, hexutil.AddPrefix(hex.EncodeToString(stateRoot)), hexutil.AddPrefix(hex.EncodeToString(v.finalized.StateRoot[:])))
+	}
+	v.verifiedStates[bc] = v.finalized.Slot
+	return nil
+}
+
+// Get an SSZ-encoded Beacon state once its hash tree root has been checked against a state root the light client verified.
+// Newer states can't be proven yet, so the head and any slot after the latest verified finalized slot are served from the finalized state.
+// Earlier slots are proven through the finalized state's state roots, which go back 8192 slots.
+func (v *LightClientVerifier) GetVerifiedStateSsz(bc *client.StandardHttpClient, stateId string) ([]byte, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.finalized == nil || time.Since(v.lastUpdate) >= lightClientUpdateInterval {
+		if err := v.update(bc); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrBeaconVerificationFailed, err.Error())
+		}
+	}
+
+	// Prove the finalized state first, since earlier states are proven through it
+	finalizedState, err := v.getProvenState(bc, v.finalized.Slot, v.finalized.StateRoot, v.finalizedState)
+	if err != nil {
+		return nil, err
+	}
+	v.finalizedState = finalizedState
+	if stateId == "head" || stateId == "finalized" {
+		return finalizedState.data, nil
+	}
+	slot, err := strconv.ParseUint(stateId, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: state '%s' can't be proven by the light client", ErrBeaconVerificationFailed, stateId)
+	}
+	if slot >= finalizedState.slot {
+		return finalizedState.data, nil
+	}
+
+	stateRoot, err := eth2.BeaconStateHistoricalStateRoot(finalizedState.fork, finalizedState.data, slot)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrBeaconVerificationFailed, err.Error())
+	}
+	historicalState, err := v.getProvenState(bc, slot, stateRoot, v.historicalState)
+	if err != nil {
+		return nil, err
+	}
+	v.historicalState = historicalState
+	return historicalState.data, nil
+}
+
+// Get the state at a slot from the client and check that its hash tree root is the verified one, reusing the provided state if it's already been proven
+func (v *LightClientVerifier) getProvenState(bc *client.StandardHttpClient, slot uint64, stateRoot [32]byte, cached *provenBeaconState) (*provenBeaconState, error) {
+	if cached != nil && cached.root == stateRoot {
+		return cached, nil
+	}
+	fork, data, err := bc.GetBeaconStateSsz(strconv.FormatUint(slot, 10))
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting the state at slot %d: %s", ErrBeaconVerificationFailed, slot, err.Error())
+	}
+	root, err := eth2.BeaconStateHashTreeRoot(fork, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error hashing the state at slot %d: %s", ErrBeaconVerificationFailed, slot, err.Error())
+	}
+	if root != stateRoot {
+		return nil, fmt.Errorf("%w: the client's state for slot %d has root %s, but the light client verified %s", ErrBeaconVerificationFailed, slot, hexutil.AddPrefix(hex.EncodeToString(root[:])), hexutil.AddPrefix(hex.EncodeToString(stateRoot[:])))
+	}
+	v.logger.Printlnf("Proved the Beacon state at slot %d against the light client's verified state root.", slot)
+	return &provenBeaconState{
+		slot: slot,
+		root: stateRoot,
+		fork: fork,
+		data: data,
+	}, nil
+}
+
+// Limit a Beacon head's finalized epoch to the latest one the light client has verified
+func (v *LightClientVerifier) VerifyBeaconHead(head beacon.BeaconHead, slotsPerEpoch uint64) beacon.BeaconHead {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.finalized == nil || slotsPerEpoch == 0 {
+		return head
+	}
+	verifiedEpoch := v.finalized.Slot / slotsPerEpoch
+	if head.FinalizedEpoch > verifiedEpoch {
+		head.FinalizedEpoch = verifiedEpoch
+	}
+	return head
+}
+
+// Bring the light client up to the latest finalized header the client can prove
+func (v *LightClientVerifier) update(bc *client.StandardHttpClient) error {
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return fmt.Errorf("error getting Beacon config: %w", err)
+	}
+	slotsPerPeriod := eth2Config.SlotsPerEpoch * eth2Config.EpochsPerSyncCommitteePeriod
+	if slotsPerPeriod == 0 {
+		return fmt.Errorf("Beacon config has no sync committee period")
+	}
+
+	// Start from the trusted root if this is the first update
+	if v.committee == nil {
+		if err := v.bootstrap(bc, slotsPerPeriod); err != nil {
+			return err
+		}
+	}
+
+	// Get the latest finality update and hand over to newer sync committees until the one that signed it
+	finalityUpdate, err := bc.GetLightClientFinalityUpdate()
+	if err != nil {
+		return err
+	}
+	signaturePeriod := uint64(finalityUpdate.Data.SignatureSlot) / slotsPerPeriod
+	for v.committee.period < signaturePeriod {
+		updates, err := bc.GetLightClientUpdates(v.committee.period, 1)
+		if err != nil {
+			return err
+		}
+		if len(updates) == 0 {
+			return fmt.Errorf("no light client update was available for sync committee period %d", v.committee.period)
+		}
+		update := updates[0]
+		if uint64(update.Data.SignatureSlot)/slotsPerPeriod != v.committee.period {
+			return fmt.Errorf("light client update for period %d was signed in a different period", v.committee.period)
+		}
+		finalizedHeader, nextCommittee, err := v.verifyUpdate(bc, update, eth2Config.SlotsPerEpoch, true)
+		if err != nil {
+			return fmt.Errorf("error verifying light client update for period %d: %w", v.committee.period, err)
+		}
+		pubkeys, err := parseSyncCommitteePubkeys(nextCommittee)
+		if err != nil {
+			return err
+		}
+		v.committee = &lightClientCommittee{
+			period:  v.committee.period + 1,
+			pubkeys: pubkeys,
+		}
+		v.setFinalized(finalizedHeader)
+	}
+	if signaturePeriod != v.committee.period {
+		return fmt.Errorf("light client finality update was signed in period %d, but the verified sync committee is for period %d", signaturePeriod, v.committee.period)
+	}
+
+	finalizedHeader, _, err := v.verifyUpdate(bc, finalityUpdate, eth2Config.SlotsPerEpoch, false)
+	if err != nil {
+		return fmt.Errorf("error verifying light client finality update: %w", err)
+	}
+	v.setFinalized(finalizedHeader)
+	v.lastUpdate = time.Now()
+	return nil
+}
+
+// Load the sync committee from the trusted root
+func (v *LightClientVerifier) bootstrap(bc *client.StandardHttpClient, slotsPerPeriod uint64) error {
+	bootstrap, err := bc.GetLightClientBootstrap(hexutil.AddPrefix(hex.EncodeToString(v.trustedRoot[:])))
+	if err != nil {
+		return err
+	}
+
+	header := getBeaconBlockHeader(bootstrap.Data.Header.Beacon)
+	headerRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return fmt.Errorf("error hashing bootstrap header: %w", err)
+	}
+	if headerRoot != v.trustedRoot {
+		return fmt.Errorf("light client bootstrap header doesn't match the trusted root")
+	}
+
+	committee := getSyncCommittee(bootstrap.Data.CurrentSyncCommittee)
+	committeeRoot, err := committee.HashTreeRoot()
+	if err != nil {
+		return fmt.Errorf("error hashing bootstrap sync committee: %w", err)
+	}
+	layout := getLightClientProofLayout(bootstrap.Version)
+	if !eth2.VerifyMerkleBranch(committeeRoot, bootstrap.Data.CurrentSyncCommitteeBranch.Nodes(), layout.currentCommitteeDepth, layout.currentCommitteeIndex, header.StateRoot) {
+		return fmt.Errorf("light client bootstrap sync committee isn't part of the trusted state")
+	}
+
+	pubkeys, err := parseSyncCommitteePubkeys(committee)
+	if err != nil {
+		return err
+	}
+	v.committee = &lightClientCommittee{
+		period:  header.Slot / slotsPerPeriod,
+		pubkeys: pubkeys,
+	}
+	v.setFinalized(header)
+	v.logger.Printlnf("Light client bootstrapped from trusted root at slot %d.", header.Slot)
+	return nil
+}
+
+// Verify a light client update against the current sync committee, returning its finalized header and (if requested) the next sync committee
+func (v *LightClientVerifier) verifyUpdate(bc *client.StandardHttpClient, response client.LightClientUpdateResponse, slotsPerEpoch uint64, requireNextCommittee bool) (eth2.BeaconBlockHeader, eth2.SyncCommittee, error) {
+	update := response.Data
+	attestedHeader := getBeaconBlockHeader(update.AttestedHeader.Beacon)
+	finalizedHeader := getBeaconBlockHeader(update.FinalizedHeader.Beacon)
+	signatureSlot := uint64(update.SignatureSlot)
+	if signatureSlot <= attestedHeader.Slot || attestedHeader.Slot < finalizedHeader.Slot {
+		return eth2.BeaconBlockHeader{}, eth2.SyncCommittee{}, fmt.Errorf("update slots are out of order (signature %d, attested %d, finalized %d)", signatureSlot, attestedHeader.Slot, finalizedHeader.Slot)
+	}
+	layout := getLightClientProofLayout(response.Version)
+
+	// Check that the finalized header is part of the attested state
+	finalizedRoot, err := finalizedHeader.HashTreeRoot()
+	if err != nil {
+		return eth2.BeaconBlockHeader{}, eth2.SyncCommittee{}, fmt.Errorf("error hashing finalized header: %w", err)
+	}
+	if !eth2.VerifyMerkleBranch(finalizedRoot, update.FinalityBranch.Nodes(), layout.finalizedDepth, layout.finalizedIndex, attestedHeader.StateRoot) {
+		return eth2.BeaconBlockHeader{}, eth2.SyncCommittee{}, fmt.Errorf("finalized header isn't part of the attested state")
+	}
+
+	// Check that the next sync committee is part of the attested state
+	var nextCommittee eth2.SyncCommittee
+	if requireNextCommittee {
+		if update.NextSyncCommittee == nil {
+			return eth2.BeaconBlockHeader{}, eth2.SyncCommittee{}, fmt.Errorf("update doesn't include the next sync committee")
+		}
+		nextCommittee = getSyncCommittee(*update.NextSyncCommittee)
+		nextCommitteeRoot, err := nextCommittee.HashTreeRoot()
+		if err != nil {
+			return eth2.BeaconBlockHeader{}, eth2.SyncCommittee{}, fmt.Errorf("error hashing next sync committee: %w", err)
+		}
+		if !eth2.VerifyMerkleBranch(nextCommitteeRoot, update.NextSyncCommitteeBranch.Nodes(), layout.nextCommitteeDepth, layout.nextCommitteeIndex, attestedHeader.StateRoot) {
+			return eth2.BeaconBlockHeader{}, eth2.SyncCommittee{}, fmt.Errorf("next sync committee isn't part of the attested state")
+		}
+	}
+
+	// Get the participating members, which must be a supermajority of the committee
+	bits := update.SyncAggregate.SyncCommitteeBits
+	if len(bits)*8 != eth2.SyncCommitteeSize {
+		return eth2.BeaconBlockHeader{}, eth2.SyncCommittee{}, fmt.Errorf("sync committee bits cover %d members but the committee has %d", len(bits)*8, eth2.SyncCommitteeSize)
+	}
+	participants := []eth2types.PublicKey{}
+	for i, pubkey := range v.committee.pubkeys {
+		if (bits[i/8]>>(i%8))&1 == 1 {
+			participants = append(participants, pubkey)
+		}
+	}
+	if len(participants)*3 < eth2.SyncCommitteeSize*2 {
+		return eth2.BeaconBlockHeader{}, eth2.SyncCommittee{}, fmt.Errorf("only %d of %d sync committee members signed the update", len(participants), eth2.SyncCommitteeSize)
+	}
+
+	// Check the committee's signature over the attested header
+	signatureEpoch := uint64(0)
+	if signatureSlot > 0 {
+		signatureEpoch = (signatureSlot - 1) / slotsPerEpoch
+	}
+	domain, err := bc.GetDomainData(eth2types.DomainSyncCommittee[:], signatureEpoch, false)
+	if err != nil {
+		return eth2.BeaconBlockHeader{}, eth2.SyncCommittee{}, fmt.Errorf("error getting sync committee domain: %w", err)
+	}
+	attestedRoot, err := attestedHeader.HashTreeRoot()
+	if err != nil {
+		return eth2.BeaconBlockHeader{}, eth2.SyncCommittee{}, fmt.Errorf("error hashing attested header: %w", err)
+	}
+	sr := eth2.SigningRoot{
+		ObjectRoot: attestedRoot[:],
+		Domain:     domain,
+	}
+	srHash, err := sr.HashTreeRoot()
+	if err != nil {
+		return eth2.BeaconBlockHeader{}, eth2.SyncCommittee{}, fmt.Errorf("error getting signing root: %w", err)
+	}
+	signature, err := eth2types.BLSSignatureFromBytes(update.SyncAggregate.SyncCommitteeSignature)
+	if err != nil {
+		return eth2.BeaconBlockHeader{}, eth2.SyncCommittee{}, fmt.Errorf("error decoding sync committee signature: %w", err)
+	}
+	if !signature.VerifyAggregateCommon(srHash[:], participants) {
+		return eth2.BeaconBlockHeader{}, eth2.SyncCommittee{}, fmt.Errorf("sync committee signature is invalid")
+	}
+
+	return finalizedHeader, nextCommittee, nil
+}
+
+// Move the verified finalized header forward
+func (v *LightClientVerifier) setFinalized(header eth2.BeaconBlockHeader) {
+	if v.finalized == nil || header.Slot > v.finalized.Slot {
+		v.finalized = &header
+	}
+}
+
+// Get the proof positions for a fork
+func getLightClientProofLayout(version string) lightClientProofLayout {
+	switch version {
+	case "electra", "fulu":
+		return electraProofLayout
+	default:
+		return altairProofLayout
+	}
+}
+
+// Convert a header from the light client API
+func getBeaconBlockHeader(header client.LightClientBeaconHeader) eth2.BeaconBlockHeader {
+	converted := eth2.BeaconBlockHeader{
+		Slot:          uint64(header.Slot),
+		ProposerIndex: uint64(header.ProposerIndex),
+	}
+	copy(converted.ParentRoot[:], header.ParentRoot)
+	copy(converted.StateRoot[:], header.StateRoot)
+	copy(converted.BodyRoot[:], header.BodyRoot)
+	return converted
+}
+
+// Convert a sync committee from the light client API
+func getSyncCommittee(committee client.SyncCommittee) eth2.SyncCommittee {
+	converted := eth2.SyncCommittee{
+		Pubkeys:         make([][]byte, len(committee.Pubkeys)),
+		AggregatePubkey: committee.AggregatePubkey,
+	}
+	for i, pubkey := range committee.Pubkeys {
+		converted.Pubkeys[i] = pubkey
+	}
+	return converted
+}
+
+// Parse the pubkeys of a sync committee so its signatures can be checked
+func parseSyncCommitteePubkeys(committee eth2.SyncCommittee) ([]eth2types.PublicKey, error) {
+	pubkeys := make([]eth2types.PublicKey, len(committee.Pubkeys))
+	for i, pubkey := range committee.Pubkeys {
+		parsed, err := eth2types.BLSPublicKeyFromBytes(pubkey)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing sync committee pubkey %d: %w", i, err)
+		}
+		pubkeys[i] = parsed
+	}
+	return pubkeys, nil
+}
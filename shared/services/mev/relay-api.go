@@ -0,0 +1,88 @@
+package mev
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	netutils "github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+// Relay API routes
+const (
+	relayStatusPath            string = "/eth/v1/builder/status"
+	relayPayloadsDeliveredPath string = "/relay/v1/data/bidtraces/proposer_payload_delivered"
+)
+
+// A block payload a relay delivered to a proposer
+type DeliveredPayload struct {
+	Slot           uint64 `json:"slot,string"`
+	BlockHash      string `json:"block_hash"`
+	BuilderPubkey  string `json:"builder_pubkey"`
+	ProposerPubkey string `json:"proposer_pubkey"`
+	Value          string `json:"value"`
+}
+
+// Check that a relay is up, returning how long it took to respond
+func CheckRelayStatus(relayUrl string, timeout time.Duration) (time.Duration, error) {
+	requestUrl, err := getRelayApiUrl(relayUrl, relayStatusPath, nil)
+	if err != nil {
+		return 0, err
+	}
+	client := netutils.NewHttpClient(timeout)
+	start := time.Now()
+	response, err := client.Get(requestUrl)
+	if err != nil {
+		return 0, fmt.Errorf("error checking relay status: %w", err)
+	}
+	defer response.Body.Close()
+	latency := time.Since(start)
+	io.Copy(io.Discard, response.Body)
+	if response.StatusCode != http.StatusOK {
+		return latency, fmt.Errorf("relay status check returned %s", response.Status)
+	}
+	return latency, nil
+}
+
+// Get the latest payloads a relay delivered to proposers, newest first
+func GetDeliveredPayloads(relayUrl string, limit int, timeout time.Duration) ([]DeliveredPayload, error) {
+	requestUrl, err := getRelayApiUrl(relayUrl, relayPayloadsDeliveredPath, url.Values{"limit": {strconv.Itoa(limit)}})
+	if err != nil {
+		return nil, err
+	}
+	client := netutils.NewHttpClient(timeout)
+	response, err := client.Get(requestUrl)
+	if err != nil {
+		return nil, fmt.Errorf("error getting delivered payloads: %w", err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading delivered payloads: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("getting delivered payloads returned %s: %s", response.Status, string(body))
+	}
+	payloads := []DeliveredPayload{}
+	if err := json.Unmarshal(body, &payloads); err != nil {
+		return nil, fmt.Errorf("error decoding delivered payloads: %w", err)
+	}
+	return payloads, nil
+}
+
+// Build the URL of a relay API route; relay URLs carry the relay's pubkey as the user, which the API doesn't need
+func getRelayApiUrl(relayUrl string, path string, query url.Values) (string, error) {
+	parsedUrl, err := url.Parse(relayUrl)
+	if err != nil {
+		return "", fmt.Errorf("error parsing relay URL: %w", err)
+	}
+	parsedUrl.User = nil
+	parsedUrl.Path = path
+	parsedUrl.RawQuery = query.Encode()
+	return parsedUrl.String(), nil
+}
@@ -0,0 +1,119 @@
+package mev
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// How much weight the latest check has in a relay's average latency
+const latencySmoothing float64 = 0.2
+
+// The performance of a relay, as seen by the node
+type RelayStats struct {
+	Name                 string    `json:"name"`
+	Checks               uint64    `json:"checks"`
+	FailedChecks         uint64    `json:"failedChecks"`
+	ConsecutiveFailures  uint64    `json:"consecutiveFailures"`
+	ConsecutiveSuccesses uint64    `json:"consecutiveSuccesses"`
+	LatencyMs            float64   `json:"latencyMs"`
+	LastError            string    `json:"lastError,omitempty"`
+	Wins                 uint64    `json:"wins"`
+	MissedSlots          uint64    `json:"missedSlots"`
+	Dropped              bool      `json:"dropped"`
+	DroppedReason        string    `json:"droppedReason,omitempty"`
+	DroppedTime          time.Time `json:"droppedTime,omitempty"`
+}
+
+// The performance of the node's relays, which the daemon keeps up to date and the CLI uses to leave dropped relays out
+type RelayRecord struct {
+	Relays        map[config.MevRelayID]*RelayStats `json:"relays"`
+	NodeProposals uint64                            `json:"nodeProposals"`
+	LastSlot      uint64                            `json:"lastSlot"`
+	Updated       time.Time                         `json:"updated"`
+}
+
+// Load the relay record from the provided path, or create an empty one if it doesn't exist yet
+func LoadRelayRecord(path string) (*RelayRecord, error) {
+	record := &RelayRecord{
+		Relays: map[config.MevRelayID]*RelayStats{},
+	}
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return record, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading relay record: %w", err)
+	}
+	if err := json.Unmarshal(bytes, record); err != nil {
+		return nil, fmt.Errorf("error decoding relay record: %w", err)
+	}
+	if record.Relays == nil {
+		record.Relays = map[config.MevRelayID]*RelayStats{}
+	}
+	return record, nil
+}
+
+// Save the relay record to the provided path
+func (r *RelayRecord) Save(path string) error {
+	r.Updated = time.Now().UTC()
+	bytes, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding relay record: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error saving relay record: %w", err)
+	}
+	return nil
+}
+
+// Get the relays that have been dropped
+func (r *RelayRecord) GetDroppedRelays() map[config.MevRelayID]bool {
+	dropped := map[config.MevRelayID]bool{}
+	for id, stats := range r.Relays {
+		if stats.Dropped {
+			dropped[id] = true
+		}
+	}
+	return dropped
+}
+
+// Get the share of the node's relay-built blocks that a relay delivered
+func (r *RelayRecord) GetWinRate(id config.MevRelayID) float64 {
+	stats, exists := r.Relays[id]
+	if !exists || r.NodeProposals == 0 {
+		return 0
+	}
+	return float64(stats.Wins) / float64(r.NodeProposals)
+}
+
+// Record the result of a check on a relay
+func (s *RelayStats) RecordCheck(latency time.Duration, err error) {
+	s.Checks++
+	if err != nil {
+		s.RecordFailure(err.Error())
+		return
+	}
+	latencyMs := float64(latency) / float64(time.Millisecond)
+	if s.Checks == 1 || s.LatencyMs == 0 {
+		s.LatencyMs = latencyMs
+	} else {
+		s.LatencyMs += latencySmoothing * (latencyMs - s.LatencyMs)
+	}
+	s.ConsecutiveFailures = 0
+	s.ConsecutiveSuccesses++
+	s.LastError = ""
+}
+
+// Record a failure of a relay, such as a failed check or a block it delivered that was missed
+func (s *RelayStats) RecordFailure(reason string) {
+	s.FailedChecks++
+	s.ConsecutiveFailures++
+	s.ConsecutiveSuccesses = 0
+	s.LastError = reason
+}
@@ -0,0 +1,101 @@
+package offline
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the details of a node and its minipools from a network state that includes it
+func GetNodeExportData(nodeState *state.NetworkState, nodeAddress common.Address) (*api.NodeExportResponse, error) {
+
+	// Response
+	response := api.NodeExportResponse{
+		Registered:       true,
+		ElBlockNumber:    nodeState.ElBlockNumber,
+		BeaconSlotNumber: nodeState.BeaconSlotNumber,
+		Minipools:        []api.MinipoolExportDetails{},
+	}
+
+	// Get the node details
+	nodeDetails, exists := nodeState.NodeDetailsByAddress[nodeAddress]
+	if !exists {
+		return nil, fmt.Errorf("node %s was missing from the network state", nodeAddress.Hex())
+	}
+	response.Node = api.NodeExportDetails{
+		Address:                 nodeDetails.NodeAddress,
+		WithdrawalAddress:       nodeDetails.WithdrawalAddress,
+		TimezoneLocation:        nodeDetails.TimezoneLocation,
+		RegistrationTime:        time.Unix(nodeDetails.RegistrationTime.Int64(), 0),
+		FeeDistributorAddress:   nodeDetails.FeeDistributorAddress,
+		FeeDistributorBalance:   nodeDetails.DistributorBalance,
+		SmoothingPoolRegistered: nodeDetails.SmoothingPoolRegistrationState,
+		EthBalance:              nodeDetails.BalanceETH,
+		RethBalance:             nodeDetails.BalanceRETH,
+		RplBalance:              nodeDetails.BalanceRPL,
+		RplStake:                nodeDetails.RplStake,
+		EffectiveRplStake:       nodeDetails.EffectiveRPLStake,
+		MinimumRplStake:         nodeDetails.MinimumRPLStake,
+		MaximumRplStake:         nodeDetails.MaximumRPLStake,
+		EthMatched:              nodeDetails.EthMatched,
+		EthMatchedLimit:         nodeDetails.EthMatchedLimit,
+		DepositCreditBalance:    nodeDetails.DepositCreditBalance,
+		MinipoolCount:           nodeDetails.MinipoolCount.Uint64(),
+		AverageNodeFee:          nodeDetails.AverageNodeFee,
+		CollateralisationRatio:  nodeDetails.CollateralisationRatio,
+	}
+
+	// Get the minipool details
+	for _, mpd := range nodeState.MinipoolDetailsByNode[nodeAddress] {
+		details := api.MinipoolExportDetails{
+			Address:                  mpd.MinipoolAddress,
+			Pubkey:                   mpd.Pubkey,
+			Status:                   mpd.Status,
+			StatusTime:               time.Unix(mpd.StatusTime.Int64(), 0),
+			DepositType:              mpd.DepositType,
+			Version:                  mpd.Version,
+			Finalised:                mpd.Finalised,
+			IsVacant:                 mpd.IsVacant,
+			NodeFee:                  mpd.NodeFee,
+			NodeDepositBalance:       mpd.NodeDepositBalance,
+			UserDepositBalance:       mpd.UserDepositBalance,
+			PenaltyCount:             mpd.PenaltyCount.Uint64(),
+			PenaltyRate:              mpd.PenaltyRate,
+			Balance:                  mpd.Balance,
+			NodeShareOfBalance:       mpd.NodeShareOfBalance,
+			NodeRefundBalance:        mpd.NodeRefundBalance,
+			BeaconBalance:            big.NewInt(0),
+			EffectiveBalance:         big.NewInt(0),
+			NodeShareOfBeaconBalance: mpd.NodeShareOfBeaconBalance,
+			Slashed:                  mpd.Slashed,
+			Delegate:                 mpd.EffectiveDelegate,
+			UseLatestDelegate:        mpd.UseLatestDelegate,
+		}
+
+		// Add the Beacon details if the validator has been seen there
+		validator := nodeState.ValidatorDetails[mpd.Pubkey]
+		if validator.Exists {
+			details.ValidatorIndex = validator.Index
+			details.ValidatorStatus = string(validator.Status)
+			details.BeaconBalance = gweiToWei(validator.Balance)
+			details.EffectiveBalance = gweiToWei(validator.EffectiveBalance)
+			details.Slashed = details.Slashed || validator.Slashed
+		}
+		response.Minipools = append(response.Minipools, details)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Converts a Beacon balance to wei without losing precision
+func gweiToWei(gwei uint64) *big.Int {
+	wei := big.NewInt(0).SetUint64(gwei)
+	return wei.Mul(wei, big.NewInt(1e9))
+}
@@ -0,0 +1,62 @@
+package offline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-json"
+
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// Builds the response for an API command from a state snapshot, with its status set the same way the daemon does
+type snapshotHandler func(snapshot *state.StateSnapshot) (interface{}, error)
+
+// The API commands that can be answered from a state snapshot, without any clients
+var snapshotHandlers = map[string]snapshotHandler{
+	"node get-export-data": func(snapshot *state.StateSnapshot) (interface{}, error) {
+		response, err := GetNodeExportData(snapshot.GetNetworkState(), snapshot.NodeAddress)
+		if err != nil {
+			return nil, err
+		}
+		response.Status = "success"
+		return response, nil
+	},
+}
+
+// Check if an API command can be answered from a state snapshot
+func IsSnapshotCommand(args string) bool {
+	_, exists := getSnapshotHandler(args)
+	return exists
+}
+
+// Runs an API command against a state snapshot instead of the daemon, returning the same response it would
+func CallAPI(snapshot *state.StateSnapshot, args string) ([]byte, error) {
+	if len(strings.Fields(args)) < 2 {
+		return nil, fmt.Errorf("invalid API command '%s'", args)
+	}
+	handler, exists := getSnapshotHandler(args)
+	if !exists {
+		return nil, fmt.Errorf("this command needs the Execution and Beacon clients, so it can't be run against a state snapshot")
+	}
+
+	response, err := handler(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing response: %w", err)
+	}
+	return responseBytes, nil
+}
+
+// Get the handler for an API command, based on its first two words
+func getSnapshotHandler(args string) (snapshotHandler, bool) {
+	fields := strings.Fields(args)
+	if len(fields) < 2 {
+		return nil, false
+	}
+	handler, exists := snapshotHandlers[fields[0]+" "+fields[1]]
+	return handler, exists
+}
@@ -0,0 +1,12 @@
+package passwords
+
+import "errors"
+
+// The service name the wallet password is stored under in the OS keychain
+const keychainService string = "rocketpool-smartnode"
+
+// The label shown for the wallet password in the OS keychain
+const keychainLabel string = "Rocket Pool node wallet password"
+
+// Returned by the keychain functions when there's no password stored for the account
+var errKeychainItemNotFound = errors.New("the password was not found in the OS keychain")
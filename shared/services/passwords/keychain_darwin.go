@@ -0,0 +1,66 @@
+//go:build darwin
+// +build darwin
+
+package passwords
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// The macOS Keychain CLI
+const securityBin string = "security"
+
+// The exit code `security` returns when there's no matching item
+const securityItemNotFoundCode int = 44
+
+// Check that the macOS Keychain can be reached
+func keychainAvailable() error {
+	if _, err := exec.LookPath(securityBin); err != nil {
+		return fmt.Errorf("the `%s` command is not installed", securityBin)
+	}
+	return nil
+}
+
+// Get a password from the macOS Keychain
+func keychainGet(account string) (string, error) {
+	output, err := exec.Command(securityBin, "find-generic-password", "-s", keychainService, "-a", account, "-w").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == securityItemNotFoundCode {
+			return "", errKeychainItemNotFound
+		}
+		return "", fmt.Errorf("error reading from the macOS Keychain: %w", err)
+	}
+	return strings.TrimSuffix(string(output), "\n"), nil
+}
+
+// Store a password in the macOS Keychain.
+// The command is sent over stdin in interactive mode so the password doesn't show up in the process list.
+func keychainSet(account string, password string) error {
+	cmd := exec.Command(securityBin, "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -l %s -X %s\n",
+		strconv.Quote(keychainService), strconv.Quote(account), strconv.Quote(keychainLabel), hex.EncodeToString([]byte(password))))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error writing to the macOS Keychain: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Delete a password from the macOS Keychain
+func keychainDelete(account string) error {
+	err := exec.Command(securityBin, "delete-generic-password", "-s", keychainService, "-a", account).Run()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == securityItemNotFoundCode {
+			return nil
+		}
+		return fmt.Errorf("error deleting from the macOS Keychain: %w", err)
+	}
+	return nil
+}
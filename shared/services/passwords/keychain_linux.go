@@ -0,0 +1,75 @@
+//go:build linux
+// +build linux
+
+package passwords
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// The Secret Service CLI used on Linux
+const secretToolBin string = "secret-tool"
+
+// Check that the Secret Service keyring can be reached.
+// secret-tool talks to the keyring over the D-Bus session bus, which headless and containerised processes usually don't have.
+func keychainAvailable() error {
+	if _, err := exec.LookPath(secretToolBin); err != nil {
+		return fmt.Errorf("the `%s` command is not installed", secretToolBin)
+	}
+	if os.Getenv("DBUS_SESSION_BUS_ADDRESS") != "" {
+		return nil
+	}
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir != "" {
+		if _, err := os.Stat(filepath.Join(runtimeDir, "bus")); err == nil {
+			return nil
+		}
+	}
+	return errors.New("there is no D-Bus session available to reach the Secret Service keyring")
+}
+
+// Get a password from the Secret Service keyring
+func keychainGet(account string) (string, error) {
+	cmd := exec.Command(secretToolBin, "lookup", "service", keychainService, "account", account)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() == 0 {
+			// secret-tool exits without an error message when there's no matching item
+			return "", errKeychainItemNotFound
+		}
+		return "", fmt.Errorf("error reading from the Secret Service keyring: %w (%s)", err, strings.TrimSpace(stderr.String()))
+	}
+	if len(output) == 0 {
+		return "", errKeychainItemNotFound
+	}
+	return strings.TrimSuffix(string(output), "\n"), nil
+}
+
+// Store a password in the Secret Service keyring
+func keychainSet(account string, password string) error {
+	cmd := exec.Command(secretToolBin, "store", "--label="+keychainLabel, "service", keychainService, "account", account)
+	cmd.Stdin = strings.NewReader(password)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error writing to the Secret Service keyring: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Delete a password from the Secret Service keyring
+func keychainDelete(account string) error {
+	output, err := exec.Command(secretToolBin, "clear", "service", keychainService, "account", account).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error deleting from the Secret Service keyring: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
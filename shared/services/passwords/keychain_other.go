@@ -0,0 +1,28 @@
+//go:build !linux && !darwin && !windows
+// +build !linux,!darwin,!windows
+
+package passwords
+
+import "errors"
+
+var errKeychainUnsupported = errors.New("storing the wallet password in the OS keychain is not supported on this operating system")
+
+// Check that the OS keychain can be reached
+func keychainAvailable() error {
+	return errKeychainUnsupported
+}
+
+// Get a password from the OS keychain
+func keychainGet(account string) (string, error) {
+	return "", errKeychainUnsupported
+}
+
+// Store a password in the OS keychain
+func keychainSet(account string, password string) error {
+	return errKeychainUnsupported
+}
+
+// Delete a password from the OS keychain
+func keychainDelete(account string) error {
+	return errKeychainUnsupported
+}
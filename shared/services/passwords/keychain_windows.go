@@ -0,0 +1,111 @@
+//go:build windows
+// +build windows
+
+package passwords
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// Windows Credential Manager constants
+const (
+	credTypeGeneric         uint32        = 1
+	credPersistLocalMachine uint32        = 2
+	errorNotFound           syscall.Errno = 1168
+)
+
+var (
+	advapi32       = syscall.NewLazyDLL("advapi32.dll")
+	procCredReadW  = advapi32.NewProc("CredReadW")
+	procCredWriteW = advapi32.NewProc("CredWriteW")
+	procCredDelete = advapi32.NewProc("CredDeleteW")
+	procCredFree   = advapi32.NewProc("CredFree")
+)
+
+// The CREDENTIALW struct used by the Credential Manager
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// Get the Credential Manager target name for an account
+func getCredentialTarget(account string) (*uint16, error) {
+	return syscall.UTF16PtrFromString(keychainService + ":" + account)
+}
+
+// Check that the Windows Credential Manager can be reached
+func keychainAvailable() error {
+	return advapi32.Load()
+}
+
+// Get a password from the Windows Credential Manager
+func keychainGet(account string) (string, error) {
+	target, err := getCredentialTarget(account)
+	if err != nil {
+		return "", err
+	}
+	var cred *credential
+	result, _, err := procCredReadW.Call(uintptr(unsafe.Pointer(target)), uintptr(credTypeGeneric), 0, uintptr(unsafe.Pointer(&cred)))
+	if result == 0 {
+		if errors.Is(err, errorNotFound) {
+			return "", errKeychainItemNotFound
+		}
+		return "", fmt.Errorf("error reading from the Windows Credential Manager: %w", err)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+	return string(unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)), nil
+}
+
+// Store a password in the Windows Credential Manager
+func keychainSet(account string, password string) error {
+	target, err := getCredentialTarget(account)
+	if err != nil {
+		return err
+	}
+	comment, err := syscall.UTF16PtrFromString(keychainLabel)
+	if err != nil {
+		return err
+	}
+	blob := []byte(password)
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		Comment:            comment,
+		CredentialBlobSize: uint32(len(blob)),
+		Persist:            credPersistLocalMachine,
+	}
+	if len(blob) > 0 {
+		cred.CredentialBlob = &blob[0]
+	}
+	result, _, err := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if result == 0 {
+		return fmt.Errorf("error writing to the Windows Credential Manager: %w", err)
+	}
+	return nil
+}
+
+// Delete a password from the Windows Credential Manager
+func keychainDelete(account string) error {
+	target, err := getCredentialTarget(account)
+	if err != nil {
+		return err
+	}
+	result, _, err := procCredDelete.Call(uintptr(unsafe.Pointer(target)), uintptr(credTypeGeneric), 0)
+	if result == 0 && !errors.Is(err, errorNotFound) {
+		return fmt.Errorf("error deleting from the Windows Credential Manager: %w", err)
+	}
+	return nil
+}
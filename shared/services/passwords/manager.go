@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 // Config
@@ -12,9 +14,14 @@ const (
 	FileMode          = 0600
 )
 
+// The name of the systemd credential daemons can be given the wallet password with when they can't reach the OS keychain
+const SystemdCredentialName string = "rocketpool-wallet-password"
+
 // Password manager
 type PasswordManager struct {
-	passwordPath string
+	passwordPath   string
+	useKeychain    bool
+	credentialPath string
 }
 
 // Create new password manager
@@ -24,8 +31,47 @@ func NewPasswordManager(passwordPath string) *PasswordManager {
 	}
 }
 
+// Create new password manager that stores the password in the OS keychain, keyed by the password path
+func NewKeychainPasswordManager(passwordPath string) *PasswordManager {
+	return &PasswordManager{
+		passwordPath: passwordPath,
+		useKeychain:  true,
+	}
+}
+
+// Create new password manager that reads the password from a systemd credential.
+// The credential is managed by systemd, so the password can't be set or deleted through it.
+func NewCredentialPasswordManager(passwordPath string, credentialPath string) *PasswordManager {
+	return &PasswordManager{
+		passwordPath:   passwordPath,
+		credentialPath: credentialPath,
+	}
+}
+
+// Check if the OS keychain can be used on this machine, returning the reason if it can't
+func KeychainAvailable() error {
+	return keychainAvailable()
+}
+
+// Get the path of the wallet password's systemd credential, if systemd passed one to this process
+func GetSystemdCredentialPath() (string, bool) {
+	credentialsDir := os.Getenv("CREDENTIALS_DIRECTORY")
+	if credentialsDir == "" {
+		return "", false
+	}
+	credentialPath := filepath.Join(credentialsDir, SystemdCredentialName)
+	if _, err := os.Stat(credentialPath); err != nil {
+		return "", false
+	}
+	return credentialPath, true
+}
+
 // Check if the password has been set
 func (pm *PasswordManager) IsPasswordSet() bool {
+	if pm.useKeychain || pm.credentialPath != "" {
+		_, err := pm.GetPassword()
+		return (err == nil)
+	}
 	_, err := os.ReadFile(pm.passwordPath)
 	return (err == nil)
 }
@@ -33,6 +79,20 @@ func (pm *PasswordManager) IsPasswordSet() bool {
 // Get the password
 func (pm *PasswordManager) GetPassword() (string, error) {
 
+	// Read from the keychain
+	if pm.useKeychain {
+		return pm.getKeychainPassword()
+	}
+
+	// Read from the systemd credential
+	if pm.credentialPath != "" {
+		password, err := os.ReadFile(pm.credentialPath)
+		if err != nil {
+			return "", fmt.Errorf("Could not read password from the systemd credential: %w", err)
+		}
+		return strings.TrimSuffix(string(password), "\n"), nil
+	}
+
 	// Read from disk
 	password, err := os.ReadFile(pm.passwordPath)
 	if err != nil {
@@ -47,6 +107,11 @@ func (pm *PasswordManager) GetPassword() (string, error) {
 // Set the password
 func (pm *PasswordManager) SetPassword(password string) error {
 
+	// The credential can only be changed through systemd
+	if pm.credentialPath != "" {
+		return errSystemdCredentialReadOnly
+	}
+
 	// Check password is not set
 	if pm.IsPasswordSet() {
 		return errors.New("Password is already set")
@@ -57,6 +122,11 @@ func (pm *PasswordManager) SetPassword(password string) error {
 		return fmt.Errorf("Password must be at least %d characters long", MinPasswordLength)
 	}
 
+	// Write to the keychain
+	if pm.useKeychain {
+		return keychainSet(pm.passwordPath, password)
+	}
+
 	// Write to disk
 	if err := os.WriteFile(pm.passwordPath, []byte(password), FileMode); err != nil {
 		return fmt.Errorf("Could not write password to disk: %w", err)
@@ -70,6 +140,18 @@ func (pm *PasswordManager) SetPassword(password string) error {
 // Delete the password
 func (pm *PasswordManager) DeletePassword() error {
 
+	// The credential can only be removed through systemd
+	if pm.credentialPath != "" {
+		return errSystemdCredentialReadOnly
+	}
+
+	// Delete it from the keychain, along with any leftover password file
+	if pm.useKeychain {
+		if err := keychainDelete(pm.passwordPath); err != nil {
+			return err
+		}
+	}
+
 	// Check if it exists
 	_, err := os.Stat(pm.passwordPath)
 	if os.IsNotExist(err) {
@@ -83,3 +165,38 @@ func (pm *PasswordManager) DeletePassword() error {
 	return err
 
 }
+
+// Returned when trying to change a password that comes from a systemd credential
+var errSystemdCredentialReadOnly = errors.New("the wallet password is provided by a systemd credential, so it can only be changed by updating the credential in the service's unit")
+
+// Get the password from the keychain, moving an existing password file into it first if there is one
+func (pm *PasswordManager) getKeychainPassword() (string, error) {
+
+	// Check the keychain
+	password, err := keychainGet(pm.passwordPath)
+	if err == nil {
+		return password, nil
+	}
+	if !errors.Is(err, errKeychainItemNotFound) {
+		return "", fmt.Errorf("Could not read password from the OS keychain: %w", err)
+	}
+
+	// Migrate the password file if it exists
+	passwordBytes, err := os.ReadFile(pm.passwordPath)
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("Could not read password from the OS keychain: %w", errKeychainItemNotFound)
+	} else if err != nil {
+		return "", fmt.Errorf("Could not read password from disk: %w", err)
+	}
+	password = string(passwordBytes)
+	if err := keychainSet(pm.passwordPath, password); err != nil {
+		return "", fmt.Errorf("Could not move password file into the OS keychain: %w", err)
+	}
+	if err := os.Remove(pm.passwordPath); err != nil {
+		return "", fmt.Errorf("Password was stored in the OS keychain but the password file could not be deleted: %w", err)
+	}
+
+	// Return
+	return password, nil
+
+}
@@ -44,6 +44,13 @@ func RequireNodePassword(c *cli.Context) error {
 }
 
 func RequireNodeWallet(c *cli.Context) error {
+	nodeWalletWatchOnly, err := getNodeWalletWatchOnly(c)
+	if err != nil {
+		return err
+	}
+	if nodeWalletWatchOnly {
+		return nil
+	}
 	if err := RequireNodePassword(c); err != nil {
 		return err
 	}
@@ -190,6 +197,26 @@ func WaitBeaconClientSynced(c *cli.Context, verbose bool) error {
 	return err
 }
 
+// Check that at least one Execution client and one Beacon client aren't paused by their circuit breakers.
+// Daemon loops use this to defer their tasks while every client is failing, instead of piling more calls onto them.
+func CheckClientsAvailable(c *cli.Context) error {
+	ec, err := GetEthClient(c)
+	if err != nil {
+		return err
+	}
+	bc, err := GetBeaconClient(c)
+	if err != nil {
+		return err
+	}
+	if !ec.HasAvailableClient() {
+		return fmt.Errorf("every Execution client has been failing recently; deferring tasks until one recovers")
+	}
+	if !bc.HasAvailableClient() {
+		return fmt.Errorf("every Beacon client has been failing recently; deferring tasks until one recovers")
+	}
+	return nil
+}
+
 func WaitRocketStorage(c *cli.Context, verbose bool) error {
 	if err := WaitEthClientSynced(c, verbose); err != nil {
 		return err
@@ -253,6 +280,15 @@ func getNodeWalletInitialized(c *cli.Context) (bool, error) {
 	return w.GetInitialized()
 }
 
+// Check if the node account is only a watch-only address because the node wallet isn't on this machine
+func getNodeWalletWatchOnly(c *cli.Context) (bool, error) {
+	w, err := GetWallet(c)
+	if err != nil {
+		return false, err
+	}
+	return w.IsWatchOnly(), nil
+}
+
 // Check if the RocketStorage contract is loaded
 func getRocketStorageLoaded(c *cli.Context) (bool, error) {
 	cfg, err := GetConfig(c)
@@ -369,12 +405,12 @@ func checkBeaconClientStatus(bcMgr *BeaconClientManager) (bool, error) {
 
 	// Check the BC status
 	mgrStatus := bcMgr.CheckStatus()
-	if bcMgr.primaryReady {
+	if bcMgr.isPrimaryReady() {
 		return true, nil
 	}
 
 	// If the primary isn't synced but there's a fallback and it is, return true
-	if bcMgr.fallbackReady {
+	if bcMgr.isFallbackReady() {
 		if mgrStatus.PrimaryClientStatus.Error != "" {
 			log.Printf("Primary consensus client is unavailable (%s), using fallback consensus client...\n", mgrStatus.PrimaryClientStatus.Error)
 		} else {
@@ -0,0 +1,66 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// A transaction that claimed rewards for one or more intervals
+type RewardsClaim struct {
+	TxHash      common.Hash `json:"txHash"`
+	BlockNumber uint64      `json:"blockNumber"`
+	Intervals   []uint64    `json:"intervals"`
+}
+
+// Get the transactions a node used to claim its rewards, keyed by the interval each one claimed.
+// Only blocks from fromBlock onward are searched, so this should be no later than the execution block of the oldest claimed interval.
+func GetNodeRewardsClaims(rp *rocketpool.RocketPool, nodeAddress common.Address, fromBlock *big.Int, intervalSize *big.Int) (map[uint64]RewardsClaim, error) {
+	rocketMerkleDistributorMainnet, err := rp.GetContract("rocketMerkleDistributorMainnet", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Merkle distributor contract: %w", err)
+	}
+	claimEvent, exists := rocketMerkleDistributorMainnet.ABI.Events["RewardsClaimed"]
+	if !exists {
+		return nil, fmt.Errorf("Merkle distributor ABI does not have a RewardsClaimed event")
+	}
+
+	// Get the claim events for the node from every version of the distributor
+	logs, err := eth.FilterContractLogs(rp, "rocketMerkleDistributorMainnet", eth.FilterQuery{
+		Topics:    [][]common.Hash{{claimEvent.ID}, {common.BytesToHash(nodeAddress.Bytes())}},
+		FromBlock: fromBlock,
+	}, intervalSize, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rewards claim events for node %s: %w", nodeAddress.Hex(), err)
+	}
+
+	claims := map[uint64]RewardsClaim{}
+	for _, log := range logs {
+		values := make(map[string]interface{})
+		err = claimEvent.Inputs.UnpackIntoMap(values, log.Data)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding rewards claim event in transaction %s: %w", log.TxHash.Hex(), err)
+		}
+		indices, ok := values["rewardIndex"].([]*big.Int)
+		if !ok {
+			return nil, fmt.Errorf("rewards claim event in transaction %s does not have any interval indices", log.TxHash.Hex())
+		}
+
+		claim := RewardsClaim{
+			TxHash:      log.TxHash,
+			BlockNumber: log.BlockNumber,
+			Intervals:   make([]uint64, len(indices)),
+		}
+		for i, index := range indices {
+			claim.Intervals[i] = index.Uint64()
+		}
+		for _, interval := range claim.Intervals {
+			claims[interval] = claim
+		}
+	}
+
+	return claims, nil
+}
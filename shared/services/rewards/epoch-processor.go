@@ -0,0 +1,121 @@
+package rewards
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"golang.org/x/sync/errgroup"
+)
+
+// The Beacon chain data needed to score the attestations in a single epoch
+type epochAttestationData struct {
+	epoch               uint64
+	committees          beacon.Committees
+	attestationsPerSlot [][]beacon.AttestationInfo
+}
+
+// The result of retrieving an epoch's data
+type epochAttestationResult struct {
+	data *epochAttestationData
+	err  error
+}
+
+// Get the attestation records for each slot in an epoch, along with its committees if requested
+func getEpochAttestationData(bc beacon.Client, slotsPerEpoch uint64, epoch uint64, getDuties bool) (*epochAttestationData, error) {
+	data := &epochAttestationData{
+		epoch:               epoch,
+		attestationsPerSlot: make([][]beacon.AttestationInfo, slotsPerEpoch),
+	}
+	var wg errgroup.Group
+
+	if getDuties {
+		wg.Go(func() error {
+			var err error
+			data.committees, err = bc.GetCommitteesForEpoch(&epoch)
+			return err
+		})
+	}
+
+	for i := uint64(0); i < slotsPerEpoch; i++ {
+		i := i
+		slot := epoch*slotsPerEpoch + i
+		wg.Go(func() error {
+			attestations, found, err := bc.GetAttestations(fmt.Sprint(slot))
+			if err != nil {
+				return err
+			}
+			if found {
+				data.attestationsPerSlot[i] = attestations
+			} else {
+				data.attestationsPerSlot[i] = []beacon.AttestationInfo{}
+			}
+			return nil
+		})
+	}
+	err := wg.Wait()
+	if err != nil {
+		return nil, fmt.Errorf("error getting committee and attestaion records for epoch %d: %w", epoch, err)
+	}
+
+	return data, nil
+}
+
+// Retrieves the committees and attestations for a range of epochs (inclusive) using a bounded pool of workers,
+// handing each one to the processor strictly in epoch order. Only the retrieval runs concurrently; since the processor
+// sees the epochs in the same order as a sequential run, the results are identical regardless of the worker count.
+func processEpochsInOrder(bc beacon.Client, slotsPerEpoch uint64, firstEpoch uint64, lastEpoch uint64, workerCount uint64, processor func(data *epochAttestationData) error) error {
+	if firstEpoch > lastEpoch {
+		return nil
+	}
+	if workerCount == 0 {
+		workerCount = 1
+	}
+
+	// Create a result channel per epoch so they can be consumed in order no matter when they finish
+	epochCount := lastEpoch - firstEpoch + 1
+	results := make([]chan epochAttestationResult, epochCount)
+	for i := range results {
+		results[i] = make(chan epochAttestationResult, 1)
+	}
+
+	// Start the workers, limiting the number of epochs that are in flight (or waiting to be processed) at once
+	workerSlots := make(chan struct{}, workerCount)
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for i := uint64(0); i < epochCount; i++ {
+			select {
+			case workerSlots <- struct{}{}:
+			case <-stop:
+				return
+			}
+			i := i
+			go func() {
+				data, err := getEpochAttestationData(bc, slotsPerEpoch, firstEpoch+i, true)
+				results[i] <- epochAttestationResult{
+					data: data,
+					err:  err,
+				}
+			}()
+		}
+	}()
+
+	// Process the epochs in order
+	for i := uint64(0); i < epochCount; i++ {
+		result := <-results[i]
+		<-workerSlots
+		if result.err != nil {
+			return result.err
+		}
+
+		err := processor(result.data)
+		if result.data.committees != nil {
+			result.data.committees.Release()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
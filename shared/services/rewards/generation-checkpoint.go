@@ -0,0 +1,233 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// The number of epochs to process between each generation checkpoint
+	GenerationCheckpointEpochInterval uint64 = 100
+)
+
+// The pipeline a tree generator is running in, used to keep each one's generation checkpoints separate
+type GenerationFlow string
+
+const (
+	GenerationFlow_Submission   GenerationFlow = "submission"
+	GenerationFlow_Regeneration GenerationFlow = "regeneration"
+	GenerationFlow_DryRun       GenerationFlow = "dry-run"
+	GenerationFlow_Verification GenerationFlow = "verification"
+	GenerationFlow_Balances     GenerationFlow = "balances"
+)
+
+// Check if generators in this flow save and resume from checkpoints; dry runs don't write anything to disk
+func (f GenerationFlow) UsesCheckpoints() bool {
+	return f != GenerationFlow_DryRun
+}
+
+// Snapshot of the attestation scoring progress of a tree generator, used to resume generation after a restart
+type GenerationCheckpoint struct {
+	RulesetVersion         uint64                                       `json:"rulesetVersion"`
+	Index                  uint64                                       `json:"index"`
+	ConsensusStartBlock    uint64                                       `json:"consensusStartBlock"`
+	ConsensusEndBlock      uint64                                       `json:"consensusEndBlock"`
+	LastCompletedEpoch     uint64                                       `json:"lastCompletedEpoch"`
+	TotalAttestationScore  *QuotedBigInt                                `json:"totalAttestationScore"`
+	SuccessfulAttestations uint64                                       `json:"successfulAttestations"`
+	Minipools              map[common.Address]*MinipoolCheckpoint       `json:"minipools"`
+	PendingDuties          map[uint64]map[uint64]map[int]common.Address `json:"pendingDuties"`
+}
+
+// The scoring progress of a single minipool
+type MinipoolCheckpoint struct {
+	CompletedAttestations   []uint64      `json:"completedAttestations"`
+	MissingAttestationSlots []uint64      `json:"missingAttestationSlots"`
+	AttestationScore        *QuotedBigInt `json:"attestationScore"`
+}
+
+// Creates a checkpoint from the current scoring progress
+func NewGenerationCheckpoint(rulesetVersion uint64, index uint64, consensusStartBlock uint64, consensusEndBlock uint64, lastCompletedEpoch uint64, totalAttestationScore *big.Int, successfulAttestations uint64, nodeDetails []*NodeSmoothingDetails, dutiesInfo *IntervalDutiesInfo) *GenerationCheckpoint {
+	checkpoint := &GenerationCheckpoint{
+		RulesetVersion:         rulesetVersion,
+		Index:                  index,
+		ConsensusStartBlock:    consensusStartBlock,
+		ConsensusEndBlock:      consensusEndBlock,
+		LastCompletedEpoch:     lastCompletedEpoch,
+		TotalAttestationScore:  &QuotedBigInt{},
+		SuccessfulAttestations: successfulAttestations,
+		Minipools:              map[common.Address]*MinipoolCheckpoint{},
+		PendingDuties:          map[uint64]map[uint64]map[int]common.Address{},
+	}
+	checkpoint.TotalAttestationScore.Set(totalAttestationScore)
+
+	// Record the progress of each minipool
+	for _, nodeInfo := range nodeDetails {
+		for _, minipoolInfo := range nodeInfo.Minipools {
+			if len(minipoolInfo.CompletedAttestations) == 0 && len(minipoolInfo.MissingAttestationSlots) == 0 {
+				continue
+			}
+			minipoolCheckpoint := &MinipoolCheckpoint{
+				CompletedAttestations:   make([]uint64, 0, len(minipoolInfo.CompletedAttestations)),
+				MissingAttestationSlots: make([]uint64, 0, len(minipoolInfo.MissingAttestationSlots)),
+				AttestationScore:        &QuotedBigInt{},
+			}
+			for slot := range minipoolInfo.CompletedAttestations {
+				minipoolCheckpoint.CompletedAttestations = append(minipoolCheckpoint.CompletedAttestations, slot)
+			}
+			for slot := range minipoolInfo.MissingAttestationSlots {
+				minipoolCheckpoint.MissingAttestationSlots = append(minipoolCheckpoint.MissingAttestationSlots, slot)
+			}
+			minipoolCheckpoint.AttestationScore.Set(&minipoolInfo.AttestationScore.Int)
+			checkpoint.Minipools[minipoolInfo.Address] = minipoolCheckpoint
+		}
+	}
+
+	// Record the duties that haven't been seen yet
+	for slotIndex, slotInfo := range dutiesInfo.Slots {
+		committees := map[uint64]map[int]common.Address{}
+		for committeeIndex, committeeInfo := range slotInfo.Committees {
+			positions := map[int]common.Address{}
+			for position, minipoolInfo := range committeeInfo.Positions {
+				positions[position] = minipoolInfo.Address
+			}
+			committees[committeeIndex] = positions
+		}
+		checkpoint.PendingDuties[slotIndex] = committees
+	}
+
+	return checkpoint
+}
+
+// Checks if the checkpoint was made for the same interval and ruleset
+func (c *GenerationCheckpoint) IsCompatible(rulesetVersion uint64, index uint64, consensusStartBlock uint64, consensusEndBlock uint64) bool {
+	return c.RulesetVersion == rulesetVersion &&
+		c.Index == index &&
+		c.ConsensusStartBlock == consensusStartBlock &&
+		c.ConsensusEndBlock == consensusEndBlock
+}
+
+// Restores the scoring progress in the checkpoint onto the provided node details and duties
+func (c *GenerationCheckpoint) Restore(nodeDetails []*NodeSmoothingDetails, dutiesInfo *IntervalDutiesInfo) (*big.Int, uint64, error) {
+	// Map the minipools by address
+	minipools := map[common.Address]*MinipoolInfo{}
+	for _, nodeInfo := range nodeDetails {
+		for _, minipoolInfo := range nodeInfo.Minipools {
+			minipools[minipoolInfo.Address] = minipoolInfo
+		}
+	}
+
+	// Restore each minipool's progress
+	for address, minipoolCheckpoint := range c.Minipools {
+		minipoolInfo, exists := minipools[address]
+		if !exists {
+			return nil, 0, fmt.Errorf("checkpoint has minipool %s which isn't part of this interval", address.Hex())
+		}
+		minipoolInfo.CompletedAttestations = map[uint64]bool{}
+		for _, slot := range minipoolCheckpoint.CompletedAttestations {
+			minipoolInfo.CompletedAttestations[slot] = true
+		}
+		minipoolInfo.MissingAttestationSlots = map[uint64]bool{}
+		for _, slot := range minipoolCheckpoint.MissingAttestationSlots {
+			minipoolInfo.MissingAttestationSlots[slot] = true
+		}
+		minipoolInfo.AttestationScore = &QuotedBigInt{}
+		minipoolInfo.AttestationScore.Set(&minipoolCheckpoint.AttestationScore.Int)
+	}
+
+	// Restore the pending duties
+	dutiesInfo.Slots = map[uint64]*SlotInfo{}
+	for slotIndex, committees := range c.PendingDuties {
+		slotInfo := &SlotInfo{
+			Index:      slotIndex,
+			Committees: map[uint64]*CommitteeInfo{},
+		}
+		for committeeIndex, positions := range committees {
+			committeeInfo := &CommitteeInfo{
+				Index:     committeeIndex,
+				Positions: map[int]*MinipoolInfo{},
+			}
+			for position, address := range positions {
+				minipoolInfo, exists := minipools[address]
+				if !exists {
+					return nil, 0, fmt.Errorf("checkpoint has a duty for minipool %s which isn't part of this interval", address.Hex())
+				}
+				committeeInfo.Positions[position] = minipoolInfo
+			}
+			slotInfo.Committees[committeeIndex] = committeeInfo
+		}
+		dutiesInfo.Slots[slotIndex] = slotInfo
+	}
+
+	totalAttestationScore := big.NewInt(0).Set(&c.TotalAttestationScore.Int)
+	return totalAttestationScore, c.SuccessfulAttestations, nil
+}
+
+// Saves the checkpoint to disk, replacing any existing checkpoint at the path
+func (c *GenerationCheckpoint) Save(path string) error {
+	bytes, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("error serializing generation checkpoint: %w", err)
+	}
+
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		return fmt.Errorf("error creating zstd compressor: %w", err)
+	}
+	defer encoder.Close()
+	compressedBytes := encoder.EncodeAll(bytes, make([]byte, 0, len(bytes)))
+
+	// Write to a temporary file first so a crash mid-write never leaves a corrupted checkpoint behind
+	tempPath := path + ".tmp"
+	err = os.WriteFile(tempPath, compressedBytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing generation checkpoint to [%s]: %w", tempPath, err)
+	}
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		return fmt.Errorf("error moving generation checkpoint to [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Loads a checkpoint from disk; returns nil if there isn't one at the path
+func LoadGenerationCheckpoint(path string) (*GenerationCheckpoint, error) {
+	compressedBytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading generation checkpoint [%s]: %w", path, err)
+	}
+
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating zstd decompressor: %w", err)
+	}
+	defer decoder.Close()
+	bytes, err := decoder.DecodeAll(compressedBytes, []byte{})
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing generation checkpoint [%s]: %w", path, err)
+	}
+
+	checkpoint := &GenerationCheckpoint{}
+	err = json.Unmarshal(bytes, checkpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing generation checkpoint [%s]: %w", path, err)
+	}
+	return checkpoint, nil
+}
+
+// Deletes the checkpoint at the path if there is one
+func DeleteGenerationCheckpoint(path string) error {
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error deleting generation checkpoint [%s]: %w", path, err)
+	}
+	return nil
+}
@@ -54,7 +54,7 @@ type treeGeneratorImpl_v1 struct {
 }
 
 // Get the version of the ruleset used by this generator
-func (r *treeGeneratorImpl_v1) getRulesetVersion() uint64 {
+func (r *treeGeneratorImpl_v1) GetRulesetVersion() uint64 {
 	return r.rewardsFile.RulesetVersion
 }
 
@@ -98,9 +98,15 @@ func newTreeGeneratorImpl_v1(log *log.ColorLogger, logPrefix string, index uint6
 	}
 }
 
-func (r *treeGeneratorImpl_v1) generateTree(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (IRewardsFile, error) {
+// Load the interval's details and determine which nodes and minipools are eligible for rewards and how they performed.
+// Without Beacon performance checks, every eligible minipool is scored as if it performed perfectly.
+func (r *treeGeneratorImpl_v1) DetermineEligibility(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, checkBeaconPerformance bool) error {
 
-	r.log.Printlnf("%s Generating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
+	if checkBeaconPerformance {
+		r.log.Printlnf("%s Generating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
+	} else {
+		r.log.Printlnf("%s Approximating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
+	}
 
 	// Provision some struct params
 	r.rp = rp
@@ -120,7 +126,7 @@ func (r *treeGeneratorImpl_v1) generateTree(rp *rocketpool.RocketPool, cfg *conf
 	}
 	nodeAddresses, err := node.GetNodeAddresses(rp, r.opts)
 	if err != nil {
-		return nil, fmt.Errorf("Error getting node addresses: %w", err)
+		return fmt.Errorf("Error getting node addresses: %w", err)
 	}
 	r.log.Printlnf("%s Creating tree for %d nodes", r.logPrefix, len(nodeAddresses))
 	r.nodeAddresses = nodeAddresses
@@ -128,27 +134,23 @@ func (r *treeGeneratorImpl_v1) generateTree(rp *rocketpool.RocketPool, cfg *conf
 	// Get the minipool count - this will be used for an error epsilon due to division truncation
 	minipoolCount, err := minipool.GetMinipoolCount(rp, r.opts)
 	if err != nil {
-		return nil, fmt.Errorf("Error getting minipool count: %w", err)
+		return fmt.Errorf("Error getting minipool count: %w", err)
 	}
 	r.epsilon = big.NewInt(int64(minipoolCount))
 
-	// Calculate the RPL rewards
-	err = r.calculateRplRewards()
-	if err != nil {
-		return nil, fmt.Errorf("Error calculating RPL rewards: %w", err)
-	}
+	// Determine which nodes are eligible for Smoothing Pool rewards and how their minipools performed
+	return r.determineEthEligibility(checkBeaconPerformance)
 
-	// Calculate the ETH rewards
-	err = r.calculateEthRewards(true)
-	if err != nil {
-		return nil, fmt.Errorf("Error calculating ETH rewards: %w", err)
-	}
+}
+
+// Arrange the calculated rewards into the network totals and the Merkle tree, completing the rewards file
+func (r *treeGeneratorImpl_v1) LayoutTree() (IRewardsFile, error) {
 
 	// Calculate the network reward map and the totals
 	r.updateNetworksAndTotals()
 
 	// Generate the Merkle Tree
-	err = r.generateMerkleTree()
+	err := r.generateMerkleTree()
 	if err != nil {
 		return nil, fmt.Errorf("Error generating Merkle tree: %w", err)
 	}
@@ -164,49 +166,6 @@ func (r *treeGeneratorImpl_v1) generateTree(rp *rocketpool.RocketPool, cfg *conf
 
 }
 
-// Quickly calculates an approximate of the staker's share of the smoothing pool balance without processing Beacon performance
-// Used for approximate returns in the rETH ratio update
-func (r *treeGeneratorImpl_v1) approximateStakerShareOfSmoothingPool(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (*big.Int, error) {
-	r.log.Printlnf("%s Approximating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
-
-	r.rp = rp
-	r.cfg = cfg
-	r.bc = bc
-	r.validNetworkCache = map[uint64]bool{
-		0: true,
-	}
-
-	// Set the network name
-	r.rewardsFile.Network = fmt.Sprint(cfg.Smartnode.Network.Value)
-	r.rewardsFile.MinipoolPerformanceFile.Network = r.rewardsFile.Network
-
-	// Get the addresses for all nodes
-	r.opts = &bind.CallOpts{
-		BlockNumber: r.elSnapshotHeader.Number,
-	}
-	nodeAddresses, err := node.GetNodeAddresses(rp, r.opts)
-	if err != nil {
-		return nil, fmt.Errorf("Error getting node addresses: %w", err)
-	}
-	r.log.Printlnf("%s Creating tree for %d nodes", r.logPrefix, len(nodeAddresses))
-	r.nodeAddresses = nodeAddresses
-
-	// Get the minipool count - this will be used for an error epsilon due to division truncation
-	minipoolCount, err := minipool.GetMinipoolCount(rp, r.opts)
-	if err != nil {
-		return nil, fmt.Errorf("Error getting minipool count: %w", err)
-	}
-	r.epsilon = big.NewInt(int64(minipoolCount))
-
-	// Calculate the ETH rewards
-	err = r.calculateEthRewards(false)
-	if err != nil {
-		return nil, fmt.Errorf("error calculating ETH rewards: %w", err)
-	}
-
-	return &r.rewardsFile.TotalRewards.PoolStakerSmoothingPoolEth.Int, nil
-}
-
 // Generates a merkle tree from the provided rewards map
 func (r *treeGeneratorImpl_v1) generateMerkleTree() error {
 
@@ -220,29 +179,9 @@ func (r *treeGeneratorImpl_v1) generateMerkleTree() error {
 		}
 
 		// Node data is address[20] :: network[32] :: RPL[32] :: ETH[32]
-		nodeData := make([]byte, 0, 20+32*3)
-
-		// Node address
-		addressBytes := address.Bytes()
-		nodeData = append(nodeData, addressBytes...)
-
-		// Node network
-		network := big.NewInt(0).SetUint64(rewardsForNode.RewardNetwork)
-		networkBytes := make([]byte, 32)
-		network.FillBytes(networkBytes)
-		nodeData = append(nodeData, networkBytes...)
-
-		// RPL rewards
 		rplRewards := big.NewInt(0)
 		rplRewards.Add(&rewardsForNode.CollateralRpl.Int, &rewardsForNode.OracleDaoRpl.Int)
-		rplRewardsBytes := make([]byte, 32)
-		rplRewards.FillBytes(rplRewardsBytes)
-		nodeData = append(nodeData, rplRewardsBytes...)
-
-		// ETH rewards
-		ethRewardsBytes := make([]byte, 32)
-		rewardsForNode.SmoothingPoolEth.FillBytes(ethRewardsBytes)
-		nodeData = append(nodeData, ethRewardsBytes...)
+		nodeData := GetNodeMerkleLeaf(address, rewardsForNode.RewardNetwork, rplRewards, &rewardsForNode.SmoothingPoolEth.Int)
 
 		// Assign it to the node rewards tracker and add it to the leaf data slice
 		rewardsForNode.MerkleData = nodeData
@@ -305,8 +244,8 @@ func (r *treeGeneratorImpl_v1) updateNetworksAndTotals() {
 
 }
 
-// Calculates the RPL rewards for the given interval
-func (r *treeGeneratorImpl_v1) calculateRplRewards() error {
+// Calculates the RPL rewards each node earned in the given interval
+func (r *treeGeneratorImpl_v1) CalculateRplRewards() error {
 
 	snapshotBlockTime := time.Unix(int64(r.elSnapshotHeader.Time), 0)
 	intervalDuration, err := state.GetClaimIntervalTime(r.cfg, r.rewardsFile.Index, r.rp, r.opts)
@@ -558,8 +497,8 @@ func (r *treeGeneratorImpl_v1) calculateRplRewards() error {
 
 }
 
-// Calculates the ETH rewards for the given interval
-func (r *treeGeneratorImpl_v1) calculateEthRewards(checkBeaconPerformance bool) error {
+// Determines which nodes are eligible for Smoothing Pool rewards in the given interval and how their minipools performed
+func (r *treeGeneratorImpl_v1) determineEthEligibility(checkBeaconPerformance bool) error {
 
 	// Get the Smoothing Pool contract's balance
 	smoothingPoolContract, err := r.rp.GetContract("rocketSmoothingPool", r.opts)
@@ -641,10 +580,22 @@ func (r *treeGeneratorImpl_v1) calculateEthRewards(checkBeaconPerformance bool)
 		}
 	}
 
+	return nil
+
+}
+
+// Calculates the Smoothing Pool ETH each eligible node earned in the given interval, returning the pool stakers' share
+func (r *treeGeneratorImpl_v1) CalculateEthRewards() (*big.Int, error) {
+
+	// Ignore the ETH calculation if there are no rewards or this is the first interval
+	if r.smoothingPoolBalance.Cmp(big.NewInt(0)) == 0 || r.rewardsFile.Index == 0 {
+		return &r.rewardsFile.TotalRewards.PoolStakerSmoothingPoolEth.Int, nil
+	}
+
 	// Determine how much ETH each node gets and how much the pool stakers get
 	poolStakerETH, nodeOpEth, err := r.calculateNodeRewards()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Update the rewards maps
@@ -655,7 +606,7 @@ func (r *treeGeneratorImpl_v1) calculateEthRewards(checkBeaconPerformance bool)
 				network := nodeInfo.RewardsNetwork
 				validNetwork, err := r.validateNetwork(network)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				if !validNetwork {
 					r.rewardsFile.InvalidNetworkNodes[nodeInfo.Address] = network
@@ -710,7 +661,7 @@ func (r *treeGeneratorImpl_v1) calculateEthRewards(checkBeaconPerformance bool)
 	r.rewardsFile.TotalRewards.PoolStakerSmoothingPoolEth.Int = *poolStakerETH
 	r.rewardsFile.TotalRewards.NodeOperatorSmoothingPoolEth.Int = *nodeOpEth
 	r.rewardsFile.TotalRewards.TotalSmoothingPoolEth.Int = *r.smoothingPoolBalance
-	return nil
+	return &r.rewardsFile.TotalRewards.PoolStakerSmoothingPoolEth.Int, nil
 
 }
 
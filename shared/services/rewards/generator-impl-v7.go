@@ -33,6 +33,7 @@ type treeGeneratorImpl_v7 struct {
 	elSnapshotHeader       *types.Header
 	log                    *log.ColorLogger
 	logPrefix              string
+	flow                   GenerationFlow
 	rp                     *rocketpool.RocketPool
 	cfg                    *config.RocketPoolConfig
 	bc                     beacon.Client
@@ -55,7 +56,7 @@ type treeGeneratorImpl_v7 struct {
 }
 
 // Create a new tree generator
-func newTreeGeneratorImpl_v7(log *log.ColorLogger, logPrefix string, index uint64, startTime time.Time, endTime time.Time, consensusBlock uint64, elSnapshotHeader *types.Header, intervalsPassed uint64, state *state.NetworkState) *treeGeneratorImpl_v7 {
+func newTreeGeneratorImpl_v7(log *log.ColorLogger, logPrefix string, flow GenerationFlow, index uint64, startTime time.Time, endTime time.Time, consensusBlock uint64, elSnapshotHeader *types.Header, intervalsPassed uint64, state *state.NetworkState) *treeGeneratorImpl_v7 {
 	return &treeGeneratorImpl_v7{
 		rewardsFile: &RewardsFile_v2{
 			RewardsFileHeader: &RewardsFileHeader{
@@ -93,19 +94,26 @@ func newTreeGeneratorImpl_v7(log *log.ColorLogger, logPrefix string, index uint6
 		elSnapshotHeader:      elSnapshotHeader,
 		log:                   log,
 		logPrefix:             logPrefix,
+		flow:                  flow,
 		totalAttestationScore: big.NewInt(0),
 		networkState:          state,
 	}
 }
 
 // Get the version of the ruleset used by this generator
-func (r *treeGeneratorImpl_v7) getRulesetVersion() uint64 {
+func (r *treeGeneratorImpl_v7) GetRulesetVersion() uint64 {
 	return r.rewardsFile.RulesetVersion
 }
 
-func (r *treeGeneratorImpl_v7) generateTree(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (IRewardsFile, error) {
+// Load the interval's details and determine which nodes and minipools are eligible for rewards and how they performed.
+// Without Beacon performance checks, every eligible minipool is scored as if it performed perfectly.
+func (r *treeGeneratorImpl_v7) DetermineEligibility(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, checkBeaconPerformance bool) error {
 
-	r.log.Printlnf("%s Generating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
+	if checkBeaconPerformance {
+		r.log.Printlnf("%s Generating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
+	} else {
+		r.log.Printlnf("%s Approximating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
+	}
 
 	// Provision some struct params
 	r.rp = rp
@@ -142,23 +150,19 @@ func (r *treeGeneratorImpl_v7) generateTree(rp *rocketpool.RocketPool, cfg *conf
 		r.epsilon = big.NewInt(int64(minipoolCount))
 	}
 
-	// Calculate the RPL rewards
-	err := r.calculateRplRewards()
-	if err != nil {
-		return nil, fmt.Errorf("error calculating RPL rewards: %w", err)
-	}
+	// Determine which nodes are eligible for Smoothing Pool rewards and how their minipools performed
+	return r.determineEthEligibility(checkBeaconPerformance)
 
-	// Calculate the ETH rewards
-	err = r.calculateEthRewards(true)
-	if err != nil {
-		return nil, fmt.Errorf("error calculating ETH rewards: %w", err)
-	}
+}
+
+// Arrange the calculated rewards into the network totals and the Merkle tree, completing the rewards file
+func (r *treeGeneratorImpl_v7) LayoutTree() (IRewardsFile, error) {
 
 	// Calculate the network reward map and the totals
 	r.updateNetworksAndTotals()
 
 	// Generate the Merkle Tree
-	err = r.generateMerkleTree()
+	err := r.generateMerkleTree()
 	if err != nil {
 		return nil, fmt.Errorf("error generating Merkle tree: %w", err)
 	}
@@ -174,54 +178,6 @@ func (r *treeGeneratorImpl_v7) generateTree(rp *rocketpool.RocketPool, cfg *conf
 
 }
 
-// Quickly calculates an approximate of the staker's share of the smoothing pool balance without processing Beacon performance
-// Used for approximate returns in the rETH ratio update
-func (r *treeGeneratorImpl_v7) approximateStakerShareOfSmoothingPool(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (*big.Int, error) {
-	r.log.Printlnf("%s Approximating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
-
-	r.rp = rp
-	r.cfg = cfg
-	r.bc = bc
-	r.validNetworkCache = map[uint64]bool{
-		0: true,
-	}
-
-	// Set the network name
-	r.rewardsFile.Network = fmt.Sprint(cfg.Smartnode.Network.Value)
-	r.rewardsFile.MinipoolPerformanceFile.Network = r.rewardsFile.Network
-	r.rewardsFile.MinipoolPerformanceFile.RewardsFileVersion = r.rewardsFile.RewardsFileVersion
-	r.rewardsFile.MinipoolPerformanceFile.RulesetVersion = r.rewardsFile.RulesetVersion
-
-	// Get the Beacon config
-	r.beaconConfig = r.networkState.BeaconConfig
-	r.slotsPerEpoch = r.beaconConfig.SlotsPerEpoch
-	r.genesisTime = time.Unix(int64(r.beaconConfig.GenesisTime), 0)
-
-	// Set the EL client call opts
-	r.opts = &bind.CallOpts{
-		BlockNumber: r.elSnapshotHeader.Number,
-	}
-
-	r.log.Printlnf("%s Creating tree for %d nodes", r.logPrefix, len(r.networkState.NodeDetails))
-
-	// Get the max of node count and minipool count - this will be used for an error epsilon due to division truncation
-	nodeCount := len(r.networkState.NodeDetails)
-	minipoolCount := len(r.networkState.MinipoolDetails)
-	if nodeCount > minipoolCount {
-		r.epsilon = big.NewInt(int64(nodeCount))
-	} else {
-		r.epsilon = big.NewInt(int64(minipoolCount))
-	}
-
-	// Calculate the ETH rewards
-	err := r.calculateEthRewards(false)
-	if err != nil {
-		return nil, fmt.Errorf("error calculating ETH rewards: %w", err)
-	}
-
-	return &r.rewardsFile.TotalRewards.PoolStakerSmoothingPoolEth.Int, nil
-}
-
 // Generates a merkle tree from the provided rewards map
 func (r *treeGeneratorImpl_v7) generateMerkleTree() error {
 
@@ -234,29 +190,9 @@ func (r *treeGeneratorImpl_v7) generateMerkleTree() error {
 		}
 
 		// Node data is address[20] :: network[32] :: RPL[32] :: ETH[32]
-		nodeData := make([]byte, 0, 20+32*3)
-
-		// Node address
-		addressBytes := address.Bytes()
-		nodeData = append(nodeData, addressBytes...)
-
-		// Node network
-		network := big.NewInt(0).SetUint64(rewardsForNode.RewardNetwork)
-		networkBytes := make([]byte, 32)
-		network.FillBytes(networkBytes)
-		nodeData = append(nodeData, networkBytes...)
-
-		// RPL rewards
 		rplRewards := big.NewInt(0)
 		rplRewards.Add(&rewardsForNode.CollateralRpl.Int, &rewardsForNode.OracleDaoRpl.Int)
-		rplRewardsBytes := make([]byte, 32)
-		rplRewards.FillBytes(rplRewardsBytes)
-		nodeData = append(nodeData, rplRewardsBytes...)
-
-		// ETH rewards
-		ethRewardsBytes := make([]byte, 32)
-		rewardsForNode.SmoothingPoolEth.FillBytes(ethRewardsBytes)
-		nodeData = append(nodeData, ethRewardsBytes...)
+		nodeData := GetNodeMerkleLeaf(address, rewardsForNode.RewardNetwork, rplRewards, &rewardsForNode.SmoothingPoolEth.Int)
 
 		// Assign it to the node rewards tracker and add it to the leaf data slice
 		rewardsForNode.MerkleData = nodeData
@@ -319,8 +255,8 @@ func (r *treeGeneratorImpl_v7) updateNetworksAndTotals() {
 
 }
 
-// Calculates the RPL rewards for the given interval
-func (r *treeGeneratorImpl_v7) calculateRplRewards() error {
+// Calculates the RPL rewards each node earned in the given interval
+func (r *treeGeneratorImpl_v7) CalculateRplRewards() error {
 	pendingRewards := r.networkState.NetworkDetails.PendingRPLRewards
 	r.log.Printlnf("%s Pending RPL rewards: %s (%.3f)", r.logPrefix, pendingRewards.String(), eth.WeiToEth(pendingRewards))
 	if pendingRewards.Cmp(common.Big0) == 0 {
@@ -515,8 +451,8 @@ func (r *treeGeneratorImpl_v7) calculateRplRewards() error {
 
 }
 
-// Calculates the ETH rewards for the given interval
-func (r *treeGeneratorImpl_v7) calculateEthRewards(checkBeaconPerformance bool) error {
+// Determines which nodes are eligible for Smoothing Pool rewards in the given interval and how their minipools performed
+func (r *treeGeneratorImpl_v7) determineEthEligibility(checkBeaconPerformance bool) error {
 
 	// Get the Smoothing Pool contract's balance
 	r.smoothingPoolBalance = r.networkState.NetworkDetails.SmoothingPoolBalance
@@ -600,10 +536,22 @@ func (r *treeGeneratorImpl_v7) calculateEthRewards(checkBeaconPerformance bool)
 		}
 	}
 
+	return nil
+
+}
+
+// Calculates the Smoothing Pool ETH each eligible node earned in the given interval, returning the pool stakers' share
+func (r *treeGeneratorImpl_v7) CalculateEthRewards() (*big.Int, error) {
+
+	// Ignore the ETH calculation if there are no rewards or this is the first interval
+	if r.smoothingPoolBalance.Cmp(common.Big0) == 0 || r.rewardsFile.Index == 0 {
+		return &r.rewardsFile.TotalRewards.PoolStakerSmoothingPoolEth.Int, nil
+	}
+
 	// Determine how much ETH each node gets and how much the pool stakers get
 	poolStakerETH, nodeOpEth, err := r.calculateNodeRewards()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Update the rewards maps
@@ -614,7 +562,7 @@ func (r *treeGeneratorImpl_v7) calculateEthRewards(checkBeaconPerformance bool)
 				network := nodeInfo.RewardsNetwork
 				validNetwork, err := r.validateNetwork(network)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				if !validNetwork {
 					r.rewardsFile.InvalidNetworkNodes[nodeInfo.Address] = network
@@ -671,7 +619,7 @@ func (r *treeGeneratorImpl_v7) calculateEthRewards(checkBeaconPerformance bool)
 	r.rewardsFile.TotalRewards.PoolStakerSmoothingPoolEth.Int = *poolStakerETH
 	r.rewardsFile.TotalRewards.NodeOperatorSmoothingPoolEth.Int = *nodeOpEth
 	r.rewardsFile.TotalRewards.TotalSmoothingPoolEth.Int = *r.smoothingPoolBalance
-	return nil
+	return &r.rewardsFile.TotalRewards.PoolStakerSmoothingPoolEth.Int, nil
 
 }
 
@@ -745,25 +693,42 @@ func (r *treeGeneratorImpl_v7) processAttestationsForInterval() error {
 		return err
 	}
 
+	// Resume from a previous run's checkpoint if there is one
+	firstEpoch, err := r.loadGenerationCheckpoint(startEpoch)
+	if err != nil {
+		return err
+	}
+
 	// Check all of the attestations for each epoch
-	r.log.Printlnf("%s Checking participation of %d minipools for epochs %d to %d", r.logPrefix, len(r.validatorIndexMap), startEpoch, endEpoch)
+	r.log.Printlnf("%s Checking participation of %d minipools for epochs %d to %d", r.logPrefix, len(r.validatorIndexMap), firstEpoch, endEpoch)
 	r.log.Printlnf("%s NOTE: this will take a long time, progress is reported every 100 epochs", r.logPrefix)
 
 	epochsDone := 0
 	reportStartTime := time.Now()
-	for epoch := startEpoch; epoch < endEpoch+1; epoch++ {
+	workerCount := r.cfg.Smartnode.TreegenWorkerCount.Value.(uint64)
+	err = processEpochsInOrder(r.bc, r.slotsPerEpoch, firstEpoch, endEpoch, workerCount, func(data *epochAttestationData) error {
+		epoch := data.epoch
 		if epochsDone == 100 {
 			timeTaken := time.Since(reportStartTime)
 			r.log.Printlnf("%s On Epoch %d of %d (%.2f%%)... (%s so far)", r.logPrefix, epoch, endEpoch, float64(epoch-startEpoch)/float64(endEpoch-startEpoch)*100.0, timeTaken)
 			epochsDone = 0
 		}
 
-		err := r.processEpoch(true, epoch)
+		err := r.processEpochData(true, data)
 		if err != nil {
 			return err
 		}
 
+		// Save a checkpoint periodically so a restart doesn't have to begin from scratch
+		if r.flow.UsesCheckpoints() && (epoch-startEpoch+1)%GenerationCheckpointEpochInterval == 0 {
+			r.saveGenerationCheckpoint(epoch)
+		}
+
 		epochsDone++
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// Check the epoch after the end of the interval for any lingering attestations
@@ -773,51 +738,85 @@ func (r *treeGeneratorImpl_v7) processAttestationsForInterval() error {
 		return err
 	}
 
+	// Generation finished so the checkpoint is no longer needed
+	if r.flow.UsesCheckpoints() {
+		err = DeleteGenerationCheckpoint(r.cfg.Smartnode.GetTreegenCheckpointPath(r.rewardsFile.Index, string(r.flow), true))
+		if err != nil {
+			r.log.Printlnf("%s WARNING: %s", r.logPrefix, err.Error())
+		}
+	}
+
 	r.log.Printlnf("%s Finished participation check (total time = %s)", r.logPrefix, time.Since(reportStartTime))
 	return nil
 
 }
 
-// Process an epoch, optionally getting the duties for all eligible minipools in it and checking each one's attestation performance
-func (r *treeGeneratorImpl_v7) processEpoch(getDuties bool, epoch uint64) error {
-
-	// Get the committee info and attestation records for this epoch
-	var committeeData beacon.Committees
-	attestationsPerSlot := make([][]beacon.AttestationInfo, r.slotsPerEpoch)
-	var wg errgroup.Group
+// Loads the generation checkpoint for this interval if one exists, restoring its progress and returning the next epoch to process
+func (r *treeGeneratorImpl_v7) loadGenerationCheckpoint(startEpoch uint64) (uint64, error) {
+	if !r.flow.UsesCheckpoints() {
+		return startEpoch, nil
+	}
+	checkpointPath := r.cfg.Smartnode.GetTreegenCheckpointPath(r.rewardsFile.Index, string(r.flow), true)
+	checkpoint, err := LoadGenerationCheckpoint(checkpointPath)
+	if err != nil {
+		r.log.Printlnf("%s WARNING: %s; starting from the beginning of the interval.", r.logPrefix, err.Error())
+		return startEpoch, nil
+	}
+	if checkpoint == nil {
+		return startEpoch, nil
+	}
+	if !checkpoint.IsCompatible(r.rewardsFile.RulesetVersion, r.rewardsFile.Index, r.rewardsFile.ConsensusStartBlock, r.rewardsFile.ConsensusEndBlock) {
+		r.log.Printlnf("%s Ignoring generation checkpoint at [%s] since it was made for a different interval or ruleset.", r.logPrefix, checkpointPath)
+		return startEpoch, nil
+	}
 
-	if getDuties {
-		wg.Go(func() error {
-			var err error
-			committeeData, err = r.bc.GetCommitteesForEpoch(&epoch)
-			return err
-		})
+	r.totalAttestationScore, r.successfulAttestations, err = checkpoint.Restore(r.nodeDetails, r.intervalDutiesInfo)
+	if err != nil {
+		// The minipool records may be partially restored at this point, so they can't be trusted; remove the checkpoint so the next attempt starts fresh
+		deleteErr := DeleteGenerationCheckpoint(checkpointPath)
+		if deleteErr != nil {
+			r.log.Printlnf("%s WARNING: %s", r.logPrefix, deleteErr.Error())
+		}
+		return 0, fmt.Errorf("error restoring generation checkpoint: %w", err)
 	}
 
-	for i := uint64(0); i < r.slotsPerEpoch; i++ {
-		i := i
-		slot := epoch*r.slotsPerEpoch + i
-		wg.Go(func() error {
-			attestations, found, err := r.bc.GetAttestations(fmt.Sprint(slot))
-			if err != nil {
-				return err
-			}
-			if found {
-				attestationsPerSlot[i] = attestations
-			} else {
-				attestationsPerSlot[i] = []beacon.AttestationInfo{}
-			}
-			return nil
-		})
+	r.log.Printlnf("%s Resuming from generation checkpoint after epoch %d.", r.logPrefix, checkpoint.LastCompletedEpoch)
+	return checkpoint.LastCompletedEpoch + 1, nil
+}
+
+// Saves the current attestation scoring progress to disk; failures are logged but don't stop generation
+func (r *treeGeneratorImpl_v7) saveGenerationCheckpoint(lastCompletedEpoch uint64) {
+	checkpointPath := r.cfg.Smartnode.GetTreegenCheckpointPath(r.rewardsFile.Index, string(r.flow), true)
+	checkpoint := NewGenerationCheckpoint(r.rewardsFile.RulesetVersion, r.rewardsFile.Index, r.rewardsFile.ConsensusStartBlock, r.rewardsFile.ConsensusEndBlock, lastCompletedEpoch, r.totalAttestationScore, r.successfulAttestations, r.nodeDetails, r.intervalDutiesInfo)
+	err := checkpoint.Save(checkpointPath)
+	if err != nil {
+		r.log.Printlnf("%s WARNING: couldn't save generation checkpoint: %s", r.logPrefix, err.Error())
 	}
-	err := wg.Wait()
+}
+
+// Process an epoch, optionally getting the duties for all eligible minipools in it and checking each one's attestation performance
+func (r *treeGeneratorImpl_v7) processEpoch(getDuties bool, epoch uint64) error {
+
+	// Get the committee info and attestation records for this epoch
+	data, err := getEpochAttestationData(r.bc, r.slotsPerEpoch, epoch, getDuties)
 	if err != nil {
-		return fmt.Errorf("error getting committee and attestaion records for epoch %d: %w", epoch, err)
+		return err
 	}
+	if data.committees != nil {
+		defer data.committees.Release()
+	}
+
+	return r.processEpochData(getDuties, data)
+
+}
+
+// Process the retrieved committees and attestation records for an epoch
+func (r *treeGeneratorImpl_v7) processEpochData(getDuties bool, data *epochAttestationData) error {
 
+	epoch := data.epoch
 	if getDuties {
 		// Get all of the expected duties for the epoch
-		err = r.getDutiesForEpoch(committeeData)
+		err := r.getDutiesForEpoch(data.committees)
 		if err != nil {
 			return fmt.Errorf("error getting duties for epoch %d: %w", epoch, err)
 		}
@@ -826,7 +825,7 @@ func (r *treeGeneratorImpl_v7) processEpoch(getDuties bool, epoch uint64) error
 	// Process all of the slots in the epoch
 	for i := uint64(0); i < r.slotsPerEpoch; i++ {
 		slot := epoch*r.slotsPerEpoch + i
-		attestations := attestationsPerSlot[i]
+		attestations := data.attestationsPerSlot[i]
 		if len(attestations) > 0 {
 			r.checkDutiesForSlot(attestations, slot)
 		}
@@ -48,6 +48,7 @@ type TreeGenerator struct {
 	rewardsIntervalInfos map[uint64]rewardsIntervalInfo
 	logger               *log.ColorLogger
 	logPrefix            string
+	flow                 GenerationFlow
 	rp                   *rocketpool.RocketPool
 	cfg                  *config.RocketPoolConfig
 	bc                   beacon.Client
@@ -57,20 +58,15 @@ type TreeGenerator struct {
 	consensusBlock       uint64
 	elSnapshotHeader     *types.Header
 	intervalsPassed      uint64
-	generatorImpl        treeGeneratorImpl
-	approximatorImpl     treeGeneratorImpl
+	generatorImpl        RulesetGenerator
+	approximatorImpl     RulesetGenerator
 }
 
-type treeGeneratorImpl interface {
-	generateTree(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (IRewardsFile, error)
-	approximateStakerShareOfSmoothingPool(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (*big.Int, error)
-	getRulesetVersion() uint64
-}
-
-func NewTreeGenerator(logger *log.ColorLogger, logPrefix string, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, index uint64, startTime time.Time, endTime time.Time, consensusBlock uint64, elSnapshotHeader *types.Header, intervalsPassed uint64, state *state.NetworkState, rollingRecord *RollingRecord) (*TreeGenerator, error) {
+func NewTreeGenerator(logger *log.ColorLogger, logPrefix string, flow GenerationFlow, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, index uint64, startTime time.Time, endTime time.Time, consensusBlock uint64, elSnapshotHeader *types.Header, intervalsPassed uint64, state *state.NetworkState, rollingRecord *RollingRecord) (*TreeGenerator, error) {
 	t := &TreeGenerator{
 		logger:           logger,
 		logPrefix:        logPrefix,
+		flow:             flow,
 		rp:               rp,
 		cfg:              cfg,
 		bc:               bc,
@@ -82,79 +78,29 @@ func NewTreeGenerator(logger *log.ColorLogger, logPrefix string, rp *rocketpool.
 		intervalsPassed:  intervalsPassed,
 	}
 
-	// v7
-	var v7_generator treeGeneratorImpl
-	if rollingRecord == nil {
-		v7_generator = newTreeGeneratorImpl_v7(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed, state)
-	} else {
-		v7_generator = newTreeGeneratorImpl_v7_rolling(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed, state, rollingRecord)
-	}
-
-	// v6
-	var v6_generator treeGeneratorImpl
-	if rollingRecord == nil {
-		v6_generator = newTreeGeneratorImpl_v6(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed, state)
-	} else {
-		v6_generator = newTreeGeneratorImpl_v6_rolling(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed, state, rollingRecord)
+	// Create a generator for each registered ruleset
+	params := &RulesetParameters{
+		Log:              t.logger,
+		LogPrefix:        t.logPrefix,
+		Flow:             t.flow,
+		Index:            t.index,
+		StartTime:        t.startTime,
+		EndTime:          t.endTime,
+		ConsensusBlock:   t.consensusBlock,
+		ElSnapshotHeader: t.elSnapshotHeader,
+		IntervalsPassed:  t.intervalsPassed,
+		State:            state,
+		RollingRecord:    rollingRecord,
 	}
-
-	// Create the interval wrappers
-	rewardsIntervalInfos := []rewardsIntervalInfo{
-		{
-			rewardsRulesetVersion: 7,
-			mainnetStartInterval:  MainnetV7Interval,
-			praterStartInterval:   PraterV7Interval,
-			holeskyStartInterval:  HoleskyV7Interval,
-			generator:             v7_generator,
-		}, {
-			rewardsRulesetVersion: 6,
-			mainnetStartInterval:  MainnetV6Interval,
-			praterStartInterval:   PraterV6Interval,
-			holeskyStartInterval:  HoleskyV6Interval,
-			generator:             v6_generator,
-		}, {
-			rewardsRulesetVersion: 5,
-			mainnetStartInterval:  MainnetV5Interval,
-			praterStartInterval:   PraterV5Interval,
-			holeskyStartInterval:  HoleskyV5Interval,
-			generator:             newTreeGeneratorImpl_v5(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed, state),
-		}, {
-			rewardsRulesetVersion: 4,
-			mainnetStartInterval:  MainnetV4Interval,
-			praterStartInterval:   PraterV4Interval,
-			holeskyStartInterval:  HoleskyV4Interval,
-			generator:             newTreeGeneratorImpl_v4(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed),
-		}, {
-			rewardsRulesetVersion: 3,
-			mainnetStartInterval:  MainnetV3Interval,
-			praterStartInterval:   PraterV3Interval,
-			holeskyStartInterval:  HoleskyV3Interval,
-			generator:             newTreeGeneratorImpl_v3(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed),
-		}, {
-			rewardsRulesetVersion: 2,
-			mainnetStartInterval:  MainnetV2Interval,
-			praterStartInterval:   PraterV2Interval,
-			holeskyStartInterval:  HoleskyV2Interval,
-			generator:             newTreeGeneratorImpl_v2(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed),
-		}, {
-			rewardsRulesetVersion: 1,
-			mainnetStartInterval:  0,
-			praterStartInterval:   0,
-			holeskyStartInterval:  0,
-			generator:             newTreeGeneratorImpl_v1(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed),
-		},
-	}
-
-	// Create the map of versions to infos
+	rulesets := GetRegisteredRulesets()
 	t.rewardsIntervalInfos = map[uint64]rewardsIntervalInfo{}
-	for _, info := range rewardsIntervalInfos {
-		// Sanity check to make sure there aren't multiple infos with the same version
-		_, exists := t.rewardsIntervalInfos[info.rewardsRulesetVersion]
-		if exists {
-			return nil, fmt.Errorf("multiple ruleset interval infos with ruleset v%d", info.rewardsRulesetVersion)
+	for _, ruleset := range rulesets {
+		t.rewardsIntervalInfos[ruleset.GetVersion()] = rewardsIntervalInfo{
+			generator: ruleset.NewGenerator(params),
 		}
-
-		t.rewardsIntervalInfos[info.rewardsRulesetVersion] = info
+	}
+	if _, exists := t.rewardsIntervalInfos[1]; !exists {
+		return nil, fmt.Errorf("ruleset v1 is not registered")
 	}
 
 	// Get the current network
@@ -164,18 +110,22 @@ func NewTreeGenerator(logger *log.ColorLogger, logPrefix string, rp *rocketpool.
 	// to interval 2 since interval 1 is the default
 	foundGenerator := false
 	foundApproximator := false
-	for i := uint64(len(t.rewardsIntervalInfos)); i > 1; i-- {
-		info := t.rewardsIntervalInfos[i]
-		startInterval, err := info.GetStartInterval(network)
+	for i := len(rulesets) - 1; i >= 0; i-- {
+		ruleset := rulesets[i]
+		version := ruleset.GetVersion()
+		if version <= 1 {
+			break
+		}
+		startInterval, err := ruleset.GetStartInterval(network)
 		if err != nil {
-			return nil, fmt.Errorf("error getting start interval for rewards period %d: %w", i, err)
+			return nil, fmt.Errorf("error getting start interval for rewards period %d: %w", version, err)
 		}
 		if !foundGenerator && t.index >= startInterval {
-			t.generatorImpl = info.generator
+			t.generatorImpl = t.rewardsIntervalInfos[version].generator
 			foundGenerator = true
 		}
 		if !foundApproximator && t.index > startInterval {
-			t.approximatorImpl = info.generator
+			t.approximatorImpl = t.rewardsIntervalInfos[version].generator
 			foundApproximator = true
 		}
 
@@ -196,19 +146,19 @@ func NewTreeGenerator(logger *log.ColorLogger, logPrefix string, rp *rocketpool.
 }
 
 func (t *TreeGenerator) GenerateTree() (IRewardsFile, error) {
-	return t.generatorImpl.generateTree(t.rp, t.cfg, t.bc)
+	return t.generateTree(t.generatorImpl)
 }
 
 func (t *TreeGenerator) ApproximateStakerShareOfSmoothingPool() (*big.Int, error) {
-	return t.approximatorImpl.approximateStakerShareOfSmoothingPool(t.rp, t.cfg, t.bc)
+	return approximateRulesetStakerShare(t.approximatorImpl, t.rp, t.cfg, t.bc)
 }
 
 func (t *TreeGenerator) GetGeneratorRulesetVersion() uint64 {
-	return t.generatorImpl.getRulesetVersion()
+	return t.generatorImpl.GetRulesetVersion()
 }
 
 func (t *TreeGenerator) GetApproximatorRulesetVersion() uint64 {
-	return t.approximatorImpl.getRulesetVersion()
+	return t.approximatorImpl.GetRulesetVersion()
 }
 
 func (t *TreeGenerator) GenerateTreeWithRuleset(ruleset uint64) (IRewardsFile, error) {
@@ -217,7 +167,7 @@ func (t *TreeGenerator) GenerateTreeWithRuleset(ruleset uint64) (IRewardsFile, e
 		return nil, fmt.Errorf("ruleset v%d does not exist", ruleset)
 	}
 
-	return info.generator.generateTree(t.rp, t.cfg, t.bc)
+	return t.generateTree(info.generator)
 }
 
 func (t *TreeGenerator) ApproximateStakerShareOfSmoothingPoolWithRuleset(ruleset uint64) (*big.Int, error) {
@@ -226,5 +176,16 @@ func (t *TreeGenerator) ApproximateStakerShareOfSmoothingPoolWithRuleset(ruleset
 		return nil, fmt.Errorf("ruleset v%d does not exist", ruleset)
 	}
 
-	return info.generator.approximateStakerShareOfSmoothingPool(t.rp, t.cfg, t.bc)
+	return approximateRulesetStakerShare(info.generator, t.rp, t.cfg, t.bc)
+}
+
+// Generate a tree with the provided generator and record how it was made in the file's header
+func (t *TreeGenerator) generateTree(generator RulesetGenerator) (IRewardsFile, error) {
+	startTime := time.Now()
+	rewardsFile, err := generateRulesetTree(generator, t.rp, t.cfg, t.bc)
+	if err != nil {
+		return nil, err
+	}
+	rewardsFile.GetHeader().Provenance = t.getProvenance(generator.GetRulesetVersion(), startTime)
+	return rewardsFile, nil
 }
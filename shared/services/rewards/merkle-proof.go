@@ -0,0 +1,159 @@
+package rewards
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wealdtech/go-merkletree"
+	"github.com/wealdtech/go-merkletree/keccak256"
+)
+
+// Everything needed to claim a node's rewards for an interval on-chain
+type MerkleProofBundle struct {
+	Index         uint64         `json:"index"`
+	MerkleRoot    common.Hash    `json:"merkleRoot"`
+	Claimer       common.Address `json:"claimer"`
+	RewardNetwork uint64         `json:"rewardNetwork"`
+	CollateralRpl *QuotedBigInt  `json:"collateralRpl"`
+	OracleDaoRpl  *QuotedBigInt  `json:"oracleDaoRpl"`
+	AmountRPL     *QuotedBigInt  `json:"amountRPL"`
+	AmountETH     *QuotedBigInt  `json:"amountETH"`
+	MerkleProof   []common.Hash  `json:"merkleProof"`
+}
+
+// Get the Merkle tree leaf data for a node's rewards. Every tree generator builds its leaves with this, so proofs
+// generated here always match the generated trees.
+// Node data is address[20] :: network[32] :: RPL[32] :: ETH[32]
+func GetNodeMerkleLeaf(address common.Address, rewardNetwork uint64, amountRpl *big.Int, amountEth *big.Int) []byte {
+	nodeData := make([]byte, 0, common.AddressLength+32*3)
+	nodeData = append(nodeData, address.Bytes()...)
+
+	networkBytes := make([]byte, 32)
+	big.NewInt(0).SetUint64(rewardNetwork).FillBytes(networkBytes)
+	nodeData = append(nodeData, networkBytes...)
+
+	rplBytes := make([]byte, 32)
+	amountRpl.FillBytes(rplBytes)
+	nodeData = append(nodeData, rplBytes...)
+
+	ethBytes := make([]byte, 32)
+	amountEth.FillBytes(ethBytes)
+	nodeData = append(nodeData, ethBytes...)
+
+	return nodeData
+}
+
+// Generates the proof a claimer needs to claim their rewards from the provided rewards file, rebuilding the Merkle tree from
+// the file's node rewards so the proofs stored in the file don't need to be trusted
+func GenerateMerkleProofBundle(rewardsFile IRewardsFile, claimer common.Address) (*MerkleProofBundle, error) {
+	header := rewardsFile.GetHeader()
+
+	// Get the claimer's rewards
+	claimerInfo, exists := rewardsFile.GetNodeRewardsInfo(claimer)
+	if !exists {
+		return nil, fmt.Errorf("%s does not have any rewards in interval %d", claimer.Hex(), header.Index)
+	}
+	bundle := &MerkleProofBundle{
+		Index:         header.Index,
+		Claimer:       claimer,
+		RewardNetwork: claimerInfo.GetRewardNetwork(),
+		CollateralRpl: copyQuotedBigInt(claimerInfo.GetCollateralRpl()),
+		OracleDaoRpl:  copyQuotedBigInt(claimerInfo.GetOracleDaoRpl()),
+		AmountETH:     copyQuotedBigInt(claimerInfo.GetSmoothingPoolEth()),
+		AmountRPL:     NewQuotedBigInt(0),
+	}
+	bundle.AmountRPL.Add(&bundle.CollateralRpl.Int, &bundle.OracleDaoRpl.Int)
+	if bundle.AmountRPL.Sign() == 0 && bundle.AmountETH.Sign() == 0 {
+		return nil, fmt.Errorf("%s does not have any rewards in interval %d", claimer.Hex(), header.Index)
+	}
+
+	// Rebuild the tree and make sure it matches the file
+	tree, err := buildMerkleTree(rewardsFile)
+	if err != nil {
+		return nil, err
+	}
+	bundle.MerkleRoot = common.BytesToHash(tree.Root())
+	if header.MerkleRoot != "" {
+		expectedRoot := common.HexToHash(header.MerkleRoot)
+		if bundle.MerkleRoot != expectedRoot {
+			return nil, fmt.Errorf("rebuilt Merkle root %s does not match the file's root %s", bundle.MerkleRoot.Hex(), expectedRoot.Hex())
+		}
+	}
+
+	// Get the claimer's proof
+	leaf := GetNodeMerkleLeaf(claimer, bundle.RewardNetwork, &bundle.AmountRPL.Int, &bundle.AmountETH.Int)
+	proof, err := tree.GenerateProof(leaf, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error generating proof for %s: %w", claimer.Hex(), err)
+	}
+	bundle.MerkleProof = make([]common.Hash, len(proof.Hashes))
+	for i, hash := range proof.Hashes {
+		bundle.MerkleProof[i] = common.BytesToHash(hash)
+	}
+
+	// Check the proof the same way the rewards contract will
+	if !VerifyMerkleProof(bundle.MerkleRoot, leaf, bundle.MerkleProof) {
+		return nil, fmt.Errorf("generated proof for %s failed verification against root %s", claimer.Hex(), bundle.MerkleRoot.Hex())
+	}
+
+	return bundle, nil
+}
+
+// Rebuilds the Merkle tree of a rewards file from its node rewards and returns the root
+func ComputeMerkleRoot(rewardsFile IRewardsFile) (common.Hash, error) {
+	tree, err := buildMerkleTree(rewardsFile)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(tree.Root()), nil
+}
+
+// Builds the Merkle tree of a rewards file from the rewards of every node in it
+func buildMerkleTree(rewardsFile IRewardsFile) (*merkletree.MerkleTree, error) {
+	totalData := [][]byte{}
+	for _, address := range rewardsFile.GetNodeAddresses() {
+		info, exists := rewardsFile.GetNodeRewardsInfo(address)
+		if !exists {
+			continue
+		}
+		amountRpl := big.NewInt(0)
+		amountEth := big.NewInt(0)
+		if info.GetCollateralRpl() != nil {
+			amountRpl.Add(amountRpl, &info.GetCollateralRpl().Int)
+		}
+		if info.GetOracleDaoRpl() != nil {
+			amountRpl.Add(amountRpl, &info.GetOracleDaoRpl().Int)
+		}
+		if info.GetSmoothingPoolEth() != nil {
+			amountEth.Set(&info.GetSmoothingPoolEth().Int)
+		}
+
+		// Ignore nodes that didn't receive any rewards
+		if amountRpl.Sign() == 0 && amountEth.Sign() == 0 {
+			continue
+		}
+		totalData = append(totalData, GetNodeMerkleLeaf(address, info.GetRewardNetwork(), amountRpl, amountEth))
+	}
+
+	tree, err := merkletree.NewUsing(totalData, keccak256.New(), false, true)
+	if err != nil {
+		return nil, fmt.Errorf("error generating Merkle tree: %w", err)
+	}
+	return tree, nil
+}
+
+// Checks a Merkle proof for a leaf against a root, hashing sorted pairs like the rewards contract does
+func VerifyMerkleProof(root common.Hash, leaf []byte, proof []common.Hash) bool {
+	computed := crypto.Keccak256(leaf)
+	for _, proofElement := range proof {
+		if bytes.Compare(computed, proofElement[:]) <= 0 {
+			computed = crypto.Keccak256(computed, proofElement[:])
+		} else {
+			computed = crypto.Keccak256(proofElement[:], computed)
+		}
+	}
+	return bytes.Equal(computed, root[:])
+}
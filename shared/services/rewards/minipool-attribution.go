@@ -0,0 +1,220 @@
+package rewards
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// Settings
+const (
+	// The fraction of a node's borrowed ETH, as a divisor, it needs to stake in RPL for its LEB8s to get the commission bonus
+	bonusCollateralDivisor int64 = 10
+)
+
+// The node deposit of a minipool that can get the commission bonus
+var bonusNodeDeposit *big.Int = eth.EthToWei(8)
+
+// A breakdown of an interval's rewards by minipool
+type MinipoolAttributionReport struct {
+	Index          uint64             `json:"index"`
+	RulesetVersion uint64             `json:"rulesetVersion"`
+	Network        string             `json:"network"`
+	StartTime      time.Time          `json:"startTime"`
+	EndTime        time.Time          `json:"endTime"`
+	Nodes          []*NodeAttribution `json:"nodes"`
+}
+
+// A node's rewards for the interval, along with how they break down across its minipools
+type NodeAttribution struct {
+	Address          common.Address         `json:"address"`
+	CollateralRpl    *QuotedBigInt          `json:"collateralRpl"`
+	OracleDaoRpl     *QuotedBigInt          `json:"oracleDaoRpl"`
+	SmoothingPoolEth *QuotedBigInt          `json:"smoothingPoolEth"`
+	Minipools        []*MinipoolAttribution `json:"minipools"`
+}
+
+// The portion of a node's rewards earned by one of its minipools.
+// Smoothing Pool ETH comes straight from the minipool performance file. Staking RPL is earned by the node as a whole,
+// so it's split across the node's staking minipools by how much ETH each one borrowed, since that's what the RPL
+// requirements are based on.
+// A minipool is bonus eligible if it's an 8 ETH minipool and its node has staked RPL worth at least 10% of the ETH it
+// borrowed, which is what the LEB8 commission bonus requires.
+type MinipoolAttribution struct {
+	Address                common.Address `json:"address"`
+	Pubkey                 string         `json:"pubkey"`
+	NodeDepositBalance     *QuotedBigInt  `json:"nodeDepositBalance"`
+	NodeFee                *QuotedBigInt  `json:"nodeFee"`
+	SmoothingPoolEligible  bool           `json:"smoothingPoolEligible"`
+	IneligibilityReason    string         `json:"ineligibilityReason,omitempty"`
+	BonusEligible          bool           `json:"bonusEligible"`
+	SuccessfulAttestations uint64         `json:"successfulAttestations"`
+	MissedAttestations     uint64         `json:"missedAttestations"`
+	AttestationScore       *QuotedBigInt  `json:"attestationScore,omitempty"`
+	SmoothingPoolEth       *QuotedBigInt  `json:"smoothingPoolEth"`
+	SmoothingPoolEthShare  float64        `json:"smoothingPoolEthShare"`
+	CollateralRpl          *QuotedBigInt  `json:"collateralRpl"`
+}
+
+// Builds the per-minipool breakdown of a rewards file, using the network state the file was generated from
+func GenerateMinipoolAttributionReport(rewardsFile IRewardsFile, networkState *state.NetworkState) (*MinipoolAttributionReport, error) {
+	header := rewardsFile.GetHeader()
+	performanceFile := rewardsFile.GetMinipoolPerformanceFile()
+	report := &MinipoolAttributionReport{
+		Index:          header.Index,
+		RulesetVersion: header.RulesetVersion,
+		Network:        header.Network,
+		StartTime:      header.StartTime,
+		EndTime:        header.EndTime,
+		Nodes:          []*NodeAttribution{},
+	}
+
+	for _, nodeAddress := range rewardsFile.GetNodeAddresses() {
+		rewardsInfo, exists := rewardsFile.GetNodeRewardsInfo(nodeAddress)
+		if !exists {
+			continue
+		}
+		nodeAttribution := &NodeAttribution{
+			Address:          nodeAddress,
+			CollateralRpl:    copyQuotedBigInt(rewardsInfo.GetCollateralRpl()),
+			OracleDaoRpl:     copyQuotedBigInt(rewardsInfo.GetOracleDaoRpl()),
+			SmoothingPoolEth: copyQuotedBigInt(rewardsInfo.GetSmoothingPoolEth()),
+			Minipools:        []*MinipoolAttribution{},
+		}
+
+		isOptedIn := false
+		nodeDetails, exists := networkState.NodeDetailsByAddress[nodeAddress]
+		if exists {
+			isOptedIn = nodeDetails.SmoothingPoolRegistrationState
+		}
+		isPenalized := false
+		for _, mpd := range networkState.MinipoolDetailsByNode[nodeAddress] {
+			if mpd.PenaltyCount != nil && mpd.PenaltyCount.Uint64() >= 3 {
+				isPenalized = true
+				break
+			}
+		}
+
+		// Check if the node has enough RPL staked for its LEB8s to get the commission bonus
+		hasBonusCollateral := false
+		if exists && nodeDetails.RplStake != nil && nodeDetails.EthMatched != nil && networkState.NetworkDetails.RplPrice != nil {
+			rplValue := big.NewInt(0).Mul(nodeDetails.RplStake, networkState.NetworkDetails.RplPrice)
+			rplValue.Div(rplValue, eth.EthToWei(1))
+			requiredValue := big.NewInt(0).Div(nodeDetails.EthMatched, big.NewInt(bonusCollateralDivisor))
+			hasBonusCollateral = rplValue.Cmp(requiredValue) >= 0
+		}
+
+		// Build the entries for the node's minipools
+		totalBorrowed := big.NewInt(0)
+		borrowed := map[common.Address]*big.Int{}
+		for _, mpd := range networkState.MinipoolDetailsByNode[nodeAddress] {
+			performance, hasPerformance := performanceFile.GetSmoothingPoolPerformance(mpd.MinipoolAddress)
+			isStaking := mpd.Exists && mpd.Status == rptypes.Staking && !mpd.Finalised
+			if !isStaking && !hasPerformance {
+				continue
+			}
+
+			minipoolAttribution := &MinipoolAttribution{
+				Address:            mpd.MinipoolAddress,
+				Pubkey:             mpd.Pubkey.Hex(),
+				NodeDepositBalance: &QuotedBigInt{Int: *big.NewInt(0).Set(mpd.NodeDepositBalance)},
+				NodeFee:            &QuotedBigInt{Int: *big.NewInt(0).Set(mpd.NodeFee)},
+				SmoothingPoolEth:   NewQuotedBigInt(0),
+				CollateralRpl:      NewQuotedBigInt(0),
+				BonusEligible:      hasBonusCollateral && mpd.NodeDepositBalance.Cmp(bonusNodeDeposit) == 0,
+			}
+			if hasPerformance {
+				minipoolAttribution.SmoothingPoolEligible = true
+				minipoolAttribution.SuccessfulAttestations = performance.GetSuccessfulAttestationCount()
+				minipoolAttribution.MissedAttestations = performance.GetMissedAttestationCount()
+				minipoolAttribution.SmoothingPoolEth.Set(performance.GetEthEarned())
+				score := performance.GetAttestationScore()
+				if score != nil {
+					minipoolAttribution.AttestationScore = &QuotedBigInt{Int: *big.NewInt(0).Set(score)}
+				}
+				if nodeAttribution.SmoothingPoolEth.Sign() > 0 {
+					share, _ := big.NewRat(0, 1).SetFrac(&minipoolAttribution.SmoothingPoolEth.Int, &nodeAttribution.SmoothingPoolEth.Int).Float64()
+					minipoolAttribution.SmoothingPoolEthShare = share
+				}
+			} else {
+				minipoolAttribution.IneligibilityReason = getIneligibilityReason(isOptedIn, isPenalized)
+			}
+			nodeAttribution.Minipools = append(nodeAttribution.Minipools, minipoolAttribution)
+
+			if isStaking && mpd.UserDepositBalance != nil {
+				borrowed[mpd.MinipoolAddress] = mpd.UserDepositBalance
+				totalBorrowed.Add(totalBorrowed, mpd.UserDepositBalance)
+			}
+		}
+
+		// Split the node's staking RPL across its minipools
+		if totalBorrowed.Sign() > 0 {
+			for _, minipoolAttribution := range nodeAttribution.Minipools {
+				minipoolBorrowed, exists := borrowed[minipoolAttribution.Address]
+				if !exists {
+					continue
+				}
+				minipoolAttribution.CollateralRpl.Mul(&nodeAttribution.CollateralRpl.Int, minipoolBorrowed)
+				minipoolAttribution.CollateralRpl.Div(&minipoolAttribution.CollateralRpl.Int, totalBorrowed)
+			}
+		}
+
+		sort.Slice(nodeAttribution.Minipools, func(i, j int) bool {
+			return bytes.Compare(nodeAttribution.Minipools[i].Address[:], nodeAttribution.Minipools[j].Address[:]) < 0
+		})
+		report.Nodes = append(report.Nodes, nodeAttribution)
+	}
+
+	sort.Slice(report.Nodes, func(i, j int) bool {
+		return bytes.Compare(report.Nodes[i].Address[:], report.Nodes[j].Address[:]) < 0
+	})
+	return report, nil
+}
+
+// Generates the per-minipool breakdown of a rewards file and saves it next to the file
+func SaveMinipoolAttributionReport(cfg *config.RocketPoolConfig, rewardsFile IRewardsFile, networkState *state.NetworkState, isDaemon bool) error {
+	index := rewardsFile.GetHeader().Index
+	report, err := GenerateMinipoolAttributionReport(rewardsFile, networkState)
+	if err != nil {
+		return fmt.Errorf("error generating minipool attribution report for interval %d: %w", index, err)
+	}
+	reportBytes, err := json.MarshalIndent(report, "", "\t")
+	if err != nil {
+		return fmt.Errorf("error serializing minipool attribution report for interval %d: %w", index, err)
+	}
+	path := cfg.Smartnode.GetMinipoolAttributionPath(index, isDaemon)
+	err = os.WriteFile(path, reportBytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error saving minipool attribution report for interval %d to %s: %w", index, path, err)
+	}
+	return nil
+}
+
+// Get the reason a staking minipool didn't earn anything from the Smoothing Pool
+func getIneligibilityReason(isOptedIn bool, isPenalized bool) string {
+	if !isOptedIn {
+		return "node is not opted into the Smoothing Pool"
+	}
+	if isPenalized {
+		return "node has a minipool with too many penalties"
+	}
+	return "no attestations were recorded for the minipool during the interval"
+}
+
+// Copies a quoted big int, treating nil as zero
+func copyQuotedBigInt(value *QuotedBigInt) *QuotedBigInt {
+	if value == nil {
+		return NewQuotedBigInt(0)
+	}
+	return &QuotedBigInt{Int: *big.NewInt(0).Set(&value.Int)}
+}
@@ -0,0 +1,192 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// An estimate of a node's rewards for the interval that's currently in progress
+type RewardsProjection struct {
+	Index                     uint64
+	IntervalStart             time.Time
+	IntervalEnd               time.Time
+	SnapshotTime              time.Time
+	ElapsedFraction           float64
+	PendingRpl                *big.Int
+	ProjectedTotalRpl         *big.Int
+	NodeEffectiveStake        *big.Int
+	TotalEffectiveStake       *big.Int
+	ProjectedCollateralRpl    *big.Int
+	ProjectedOracleDaoRpl     *big.Int
+	IsOptedIntoSmoothingPool  bool
+	SmoothingPoolBalance      *big.Int
+	ProjectedSmoothingBalance *big.Int
+	NodeSmoothingPoolScore    *big.Int
+	TotalSmoothingPoolScore   *big.Int
+	EligibleMinipoolCount     uint64
+	ProjectedSmoothingPoolEth *big.Int
+}
+
+// Projects the rewards a node will earn for the current interval from the provided network state.
+// The RPL and Smoothing Pool balances accumulated so far are extrapolated linearly to the end of the interval, the node's RPL
+// share comes from its effective stake, and its Smoothing Pool share assumes every eligible minipool attests perfectly for the
+// rest of the interval.
+func ProjectNodeRewards(networkState *state.NetworkState, nodeAddress common.Address) (*RewardsProjection, error) {
+	details := networkState.NetworkDetails
+	projection := &RewardsProjection{
+		Index:                     details.RewardIndex,
+		IntervalStart:             details.IntervalStart,
+		IntervalEnd:               details.IntervalStart.Add(details.IntervalDuration),
+		PendingRpl:                big.NewInt(0).Set(details.PendingRPLRewards),
+		SmoothingPoolBalance:      big.NewInt(0).Set(details.SmoothingPoolBalance),
+		NodeEffectiveStake:        big.NewInt(0),
+		ProjectedCollateralRpl:    big.NewInt(0),
+		ProjectedOracleDaoRpl:     big.NewInt(0),
+		NodeSmoothingPoolScore:    big.NewInt(0),
+		TotalSmoothingPoolScore:   big.NewInt(0),
+		ProjectedSmoothingPoolEth: big.NewInt(0),
+	}
+
+	// Get the time of the state's slot and how far into the interval it is
+	genesisTime := time.Unix(int64(networkState.BeaconConfig.GenesisTime), 0)
+	slotOffset := time.Duration(networkState.BeaconSlotNumber*networkState.BeaconConfig.SecondsPerSlot) * time.Second
+	projection.SnapshotTime = genesisTime.Add(slotOffset)
+	elapsed := projection.SnapshotTime.Sub(details.IntervalStart)
+	if elapsed < time.Second {
+		return nil, fmt.Errorf("state at slot %d is from before the start of interval %d", networkState.BeaconSlotNumber, details.RewardIndex)
+	}
+	if elapsed > details.IntervalDuration {
+		// The interval is overdue, so everything it will distribute has already accumulated
+		elapsed = details.IntervalDuration
+	}
+	projection.ElapsedFraction = elapsed.Seconds() / details.IntervalDuration.Seconds()
+
+	// Extrapolate the network totals to the end of the interval
+	projection.ProjectedTotalRpl = extrapolateToIntervalEnd(projection.PendingRpl, elapsed, details.IntervalDuration)
+	projection.ProjectedSmoothingBalance = extrapolateToIntervalEnd(projection.SmoothingPoolBalance, elapsed, details.IntervalDuration)
+
+	// Get the node's share of the collateral RPL
+	effectiveStakes, totalEffectiveStake, err := networkState.CalculateTrueEffectiveStakes(true, true)
+	if err != nil {
+		return nil, fmt.Errorf("error calculating effective RPL stakes: %w", err)
+	}
+	projection.TotalEffectiveStake = totalEffectiveStake
+	nodeEffectiveStake, exists := effectiveStakes[nodeAddress]
+	if exists {
+		projection.NodeEffectiveStake.Set(nodeEffectiveStake)
+	}
+	if totalEffectiveStake.Sign() > 0 {
+		collateralRpl := big.NewInt(0).Mul(projection.ProjectedTotalRpl, details.NodeOperatorRewardsPercent)
+		collateralRpl.Div(collateralRpl, eth.EthToWei(1))
+		collateralRpl.Mul(collateralRpl, projection.NodeEffectiveStake)
+		collateralRpl.Div(collateralRpl, totalEffectiveStake)
+		projection.ProjectedCollateralRpl = collateralRpl
+	}
+
+	// Get the node's share of the Oracle DAO RPL
+	odaoSize := int64(0)
+	isOdaoMember := false
+	for _, member := range networkState.OracleDaoMemberDetails {
+		if !member.Exists {
+			continue
+		}
+		odaoSize++
+		if member.Address == nodeAddress {
+			isOdaoMember = true
+		}
+	}
+	if isOdaoMember {
+		odaoRpl := big.NewInt(0).Mul(projection.ProjectedTotalRpl, details.TrustedNodeOperatorRewardsPercent)
+		odaoRpl.Div(odaoRpl, eth.EthToWei(1))
+		odaoRpl.Div(odaoRpl, big.NewInt(odaoSize))
+		projection.ProjectedOracleDaoRpl = odaoRpl
+	}
+
+	// Get the node's share of the Smoothing Pool
+	nodeDetails, exists := networkState.NodeDetailsByAddress[nodeAddress]
+	if !exists {
+		return projection, nil
+	}
+	projection.IsOptedIntoSmoothingPool = nodeDetails.SmoothingPoolRegistrationState
+	if !projection.IsOptedIntoSmoothingPool {
+		return projection, nil
+	}
+	for _, node := range networkState.NodeDetails {
+		if !node.SmoothingPoolRegistrationState {
+			continue
+		}
+		score, count := getSmoothingPoolScore(networkState, node.NodeAddress)
+		projection.TotalSmoothingPoolScore.Add(projection.TotalSmoothingPoolScore, score)
+		projection.EligibleMinipoolCount += count
+		if node.NodeAddress == nodeAddress {
+			projection.NodeSmoothingPoolScore.Set(score)
+		}
+	}
+	if projection.EligibleMinipoolCount > 0 {
+		// Each minipool's score is its share of its own rewards, so the node operators' portion of the pool is the average score
+		smoothingPoolEth := big.NewInt(0).Mul(projection.ProjectedSmoothingBalance, projection.NodeSmoothingPoolScore)
+		smoothingPoolEth.Div(smoothingPoolEth, eth.EthToWei(1))
+		smoothingPoolEth.Div(smoothingPoolEth, big.NewInt(int64(projection.EligibleMinipoolCount)))
+		projection.ProjectedSmoothingPoolEth = smoothingPoolEth
+	}
+
+	return projection, nil
+}
+
+// Scales an amount accumulated over the elapsed part of an interval up to the whole interval
+func extrapolateToIntervalEnd(amount *big.Int, elapsed time.Duration, intervalDuration time.Duration) *big.Int {
+	projected := big.NewInt(0).Mul(amount, big.NewInt(int64(intervalDuration.Seconds())))
+	projected.Div(projected, big.NewInt(int64(elapsed.Seconds())))
+	return projected
+}
+
+// Get the total Smoothing Pool score of a node's eligible minipools, along with how many there are
+func getSmoothingPoolScore(networkState *state.NetworkState, nodeAddress common.Address) (*big.Int, uint64) {
+	score := big.NewInt(0)
+	count := uint64(0)
+	validatorReq := eth.EthToWei(32)
+	one := eth.EthToWei(1)
+	for _, mpd := range networkState.MinipoolDetailsByNode[nodeAddress] {
+		if mpd.PenaltyCount != nil && mpd.PenaltyCount.Uint64() >= 3 {
+			// Cheaters don't get anything from the Smoothing Pool
+			return big.NewInt(0), 0
+		}
+		if !isSmoothingPoolEligible(networkState, mpd) {
+			continue
+		}
+
+		// Score = fee + (bond/32)(1 - fee)
+		minipoolScore := big.NewInt(0).Sub(one, mpd.NodeFee)
+		minipoolScore.Mul(minipoolScore, mpd.NodeDepositBalance)
+		minipoolScore.Div(minipoolScore, validatorReq)
+		minipoolScore.Add(minipoolScore, mpd.NodeFee)
+		score.Add(score, minipoolScore)
+		count++
+	}
+	return score, count
+}
+
+// Check if a minipool is currently attesting on behalf of its node
+func isSmoothingPoolEligible(networkState *state.NetworkState, mpd *rpstate.NativeMinipoolDetails) bool {
+	if !mpd.Exists || mpd.Status != rptypes.Staking || mpd.Finalised {
+		return false
+	}
+	validator, exists := networkState.ValidatorDetails[mpd.Pubkey]
+	if !exists || !validator.Exists {
+		return false
+	}
+	switch validator.Status {
+	case beacon.ValidatorState_ActiveOngoing, beacon.ValidatorState_ActiveExiting:
+		return true
+	default:
+		return false
+	}
+}
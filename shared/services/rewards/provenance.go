@@ -0,0 +1,106 @@
+package rewards
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Information about how a rewards file was generated, so divergent files can be traced back to the setup that made them.
+// This is only recorded in local copies of the file; the copy that gets uploaded for the Oracle DAO's consensus leaves it out,
+// since it differs from node to node and would change the file's CID.
+type RewardsFileProvenance struct {
+	GeneratorVersion          string           `json:"generatorVersion"`
+	RulesetVersion            uint64           `json:"rulesetVersion"`
+	ExecutionClient           ClientProvenance `json:"executionClient"`
+	ConsensusClient           ClientProvenance `json:"consensusClient"`
+	SnapshotSlot              uint64           `json:"snapshotSlot"`
+	SnapshotBlock             uint64           `json:"snapshotBlock"`
+	GeneratedAt               time.Time        `json:"generatedAt"`
+	GenerationDurationSeconds float64          `json:"generationDurationSeconds"`
+}
+
+// The client that served the data for a rewards file
+type ClientProvenance struct {
+	Endpoint string `json:"endpoint,omitempty"`
+	Version  string `json:"version,omitempty"`
+}
+
+// Clients that can report the URL they're currently connected to
+type activeUrlProvider interface {
+	GetActiveUrl() string
+}
+
+// Execution clients that can report their name and version
+type executionClientVersionProvider interface {
+	ClientVersion(ctx context.Context) (string, error)
+}
+
+// Serialize a rewards file without its provenance, which is the form that gets uploaded and submitted to the contracts
+func SerializeCanonicalRewardsFile(rewardsFile IRewardsFile) ([]byte, error) {
+	header := rewardsFile.GetHeader()
+	provenance := header.Provenance
+	header.Provenance = nil
+	defer func() {
+		header.Provenance = provenance
+	}()
+	return rewardsFile.Serialize()
+}
+
+// Create the provenance for a tree that was just generated
+func (t *TreeGenerator) getProvenance(rulesetVersion uint64, startTime time.Time) *RewardsFileProvenance {
+	provenance := &RewardsFileProvenance{
+		GeneratorVersion:          shared.RocketPoolVersion,
+		RulesetVersion:            rulesetVersion,
+		ExecutionClient:           getExecutionClientProvenance(t.rp.Client),
+		ConsensusClient:           getBeaconClientProvenance(t.bc),
+		SnapshotSlot:              t.consensusBlock,
+		GeneratedAt:               time.Now().UTC(),
+		GenerationDurationSeconds: time.Since(startTime).Seconds(),
+	}
+	if t.elSnapshotHeader != nil {
+		provenance.SnapshotBlock = t.elSnapshotHeader.Number.Uint64()
+	}
+	return provenance
+}
+
+// Get the endpoint and version of an Execution client, where it can report them
+func getExecutionClientProvenance(ec rocketpool.ExecutionClient) ClientProvenance {
+	provenance := ClientProvenance{}
+	if provider, ok := ec.(activeUrlProvider); ok {
+		provenance.Endpoint = sanitizeEndpoint(provider.GetActiveUrl())
+	}
+	if provider, ok := ec.(executionClientVersionProvider); ok {
+		version, err := provider.ClientVersion(context.Background())
+		if err == nil {
+			provenance.Version = version
+		}
+	}
+	return provenance
+}
+
+// Get the endpoint and version of a Beacon client, where it can report them
+func getBeaconClientProvenance(bc beacon.Client) ClientProvenance {
+	provenance := ClientProvenance{}
+	if provider, ok := bc.(activeUrlProvider); ok {
+		provenance.Endpoint = sanitizeEndpoint(provider.GetActiveUrl())
+	}
+	nodeVersion, err := bc.GetNodeVersion()
+	if err == nil {
+		provenance.Version = nodeVersion.Version
+	}
+	return provenance
+}
+
+// Reduce an endpoint to its scheme and host so credentials and API keys in the URL don't end up in the file
+func sanitizeEndpoint(endpoint string) string {
+	parsedUrl, err := url.Parse(endpoint)
+	if err != nil || parsedUrl.Host == "" {
+		return ""
+	}
+	return parsedUrl.Scheme + "://" + parsedUrl.Host
+}
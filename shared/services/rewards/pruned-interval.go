@@ -0,0 +1,66 @@
+package rewards
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// A summary of a node's rewards for an interval whose files were pruned, so its totals are still available
+type PrunedIntervalRecord struct {
+	Index                  uint64         `json:"index"`
+	NodeAddress            common.Address `json:"nodeAddress"`
+	NodeExists             bool           `json:"nodeExists"`
+	CollateralRplAmount    *QuotedBigInt  `json:"collateralRplAmount"`
+	ODaoRplAmount          *QuotedBigInt  `json:"oDaoRplAmount"`
+	SmoothingPoolEthAmount *QuotedBigInt  `json:"smoothingPoolEthAmount"`
+}
+
+// Saves the record for a pruned interval
+func SavePrunedIntervalRecord(cfg *config.RocketPoolConfig, info IntervalInfo, nodeAddress common.Address) error {
+	record := PrunedIntervalRecord{
+		Index:                  info.Index,
+		NodeAddress:            nodeAddress,
+		NodeExists:             info.NodeExists,
+		CollateralRplAmount:    info.CollateralRplAmount,
+		ODaoRplAmount:          info.ODaoRplAmount,
+		SmoothingPoolEthAmount: info.SmoothingPoolEthAmount,
+	}
+	for _, amount := range []**QuotedBigInt{&record.CollateralRplAmount, &record.ODaoRplAmount, &record.SmoothingPoolEthAmount} {
+		if *amount == nil {
+			*amount = NewQuotedBigInt(0)
+		}
+	}
+	bytes, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("error serializing pruned record for interval %d: %w", info.Index, err)
+	}
+	path := cfg.Smartnode.GetPrunedRewardsFileMarkerPath(info.Index, true)
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error saving pruned record for interval %d to %s: %w", info.Index, path, err)
+	}
+	return nil
+}
+
+// Loads the record for a pruned interval; returns nil if the interval wasn't pruned
+func LoadPrunedIntervalRecord(cfg *config.RocketPoolConfig, interval uint64) (*PrunedIntervalRecord, error) {
+	path := cfg.Smartnode.GetPrunedRewardsFileMarkerPath(interval, true)
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading pruned record for interval %d: %w", interval, err)
+	}
+
+	record := &PrunedIntervalRecord{}
+	err = json.Unmarshal(bytes, record)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing pruned record for interval %d: %w", interval, err)
+	}
+	return record, nil
+}
@@ -84,6 +84,10 @@ func (p *SmoothingPoolMinipoolPerformance_v1) GetMissingAttestationSlots() []uin
 func (p *SmoothingPoolMinipoolPerformance_v1) GetEthEarned() *big.Int {
 	return eth.EthToWei(p.EthEarned)
 }
+func (p *SmoothingPoolMinipoolPerformance_v1) GetAttestationScore() *big.Int {
+	// v1 files don't track attestation scores
+	return nil
+}
 
 // Node operator rewards
 type NodeRewardsInfo_v1 struct {
@@ -0,0 +1,529 @@
+package rewards
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Settings
+const (
+	sszNetworkRewardsSize int = sszUint64Size + 3*sszUint256Size
+)
+
+// Prefixes that identify SSZ-encoded files, since SSZ itself isn't self-describing
+var (
+	sszRewardsFileMagic             []byte = []byte("RPRF")
+	sszMinipoolPerformanceFileMagic []byte = []byte("RPMP")
+)
+
+// Checks if the bytes hold an SSZ-encoded rewards file
+func IsSszRewardsFile(data []byte) bool {
+	return bytes.HasPrefix(data, sszRewardsFileMagic)
+}
+
+// Checks if the bytes hold an SSZ-encoded minipool performance file
+func IsSszMinipoolPerformanceFile(data []byte) bool {
+	return bytes.HasPrefix(data, sszMinipoolPerformanceFileMagic)
+}
+
+// Serialize a rewards file into SSZ bytes
+func (f *RewardsFile_v2) SerializeSSZ() ([]byte, error) {
+	header := f.RewardsFileHeader
+	encoder := &sszEncoder{}
+	encoder.putUint64(header.RewardsFileVersion)
+	encoder.putUint64(header.RulesetVersion)
+	encoder.putUint64(header.Index)
+	encoder.putVariable([]byte(header.Network))
+	encoder.putUint64(encodeSszTime(header.StartTime))
+	encoder.putUint64(encodeSszTime(header.EndTime))
+	encoder.putUint64(header.ConsensusStartBlock)
+	encoder.putUint64(header.ConsensusEndBlock)
+	encoder.putUint64(header.ExecutionStartBlock)
+	encoder.putUint64(header.ExecutionEndBlock)
+	encoder.putUint64(header.IntervalsPassed)
+	encoder.putFixedBytes(common.HexToHash(header.MerkleRoot).Bytes())
+	encoder.putVariable([]byte(header.MinipoolPerformanceFileCID))
+
+	// Total rewards
+	totalRewards := header.TotalRewards
+	if totalRewards == nil {
+		totalRewards = &TotalRewards{}
+	}
+	for _, value := range []*QuotedBigInt{
+		totalRewards.ProtocolDaoRpl,
+		totalRewards.TotalCollateralRpl,
+		totalRewards.TotalOracleDaoRpl,
+		totalRewards.TotalSmoothingPoolEth,
+		totalRewards.PoolStakerSmoothingPoolEth,
+		totalRewards.NodeOperatorSmoothingPoolEth,
+	} {
+		err := encoder.putUint256(quotedToBig(value))
+		if err != nil {
+			return nil, fmt.Errorf("error encoding total rewards: %w", err)
+		}
+	}
+
+	// Network rewards, sorted by network
+	networks := make([]uint64, 0, len(header.NetworkRewards))
+	for network := range header.NetworkRewards {
+		networks = append(networks, network)
+	}
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i] < networks[j]
+	})
+	networkRewards := make([]byte, 0, len(networks)*sszNetworkRewardsSize)
+	for _, network := range networks {
+		info := header.NetworkRewards[network]
+		element := &sszEncoder{}
+		element.putUint64(network)
+		for _, value := range []*QuotedBigInt{info.CollateralRpl, info.OracleDaoRpl, info.SmoothingPoolEth} {
+			err := element.putUint256(quotedToBig(value))
+			if err != nil {
+				return nil, fmt.Errorf("error encoding rewards for network %d: %w", network, err)
+			}
+		}
+		networkRewards = append(networkRewards, element.finish()...)
+	}
+	encoder.putVariable(networkRewards)
+
+	// Node rewards, sorted by address
+	addresses := f.GetNodeAddresses()
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+	nodeRewards := make([][]byte, len(addresses))
+	for i, address := range addresses {
+		info := f.NodeRewards[address]
+		element := &sszEncoder{}
+		element.putFixedBytes(address.Bytes())
+		element.putUint64(info.RewardNetwork)
+		for _, value := range []*QuotedBigInt{info.CollateralRpl, info.OracleDaoRpl, info.SmoothingPoolEth} {
+			err := element.putUint256(quotedToBig(value))
+			if err != nil {
+				return nil, fmt.Errorf("error encoding rewards for node %s: %w", address.Hex(), err)
+			}
+		}
+		proof := make([]byte, 0, len(info.MerkleProof)*common.HashLength)
+		for _, proofLevel := range info.MerkleProof {
+			proof = append(proof, common.HexToHash(proofLevel).Bytes()...)
+		}
+		element.putVariable(proof)
+		nodeRewards[i] = element.finish()
+	}
+	encoder.putVariable(encodeSszVariableList(nodeRewards))
+
+	// Provenance, which is left empty for canonical files
+	provenance, err := encodeSszProvenance(header.Provenance)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding provenance: %w", err)
+	}
+	encoder.putVariable(provenance)
+
+	return append(append([]byte{}, sszRewardsFileMagic...), encoder.finish()...), nil
+}
+
+// Deserialize a rewards file from SSZ bytes
+func (f *RewardsFile_v2) DeserializeSSZ(data []byte) error {
+	if !IsSszRewardsFile(data) {
+		return fmt.Errorf("data is not an SSZ rewards file")
+	}
+	decoder := newSszDecoder(data[len(sszRewardsFileMagic):])
+	header := &RewardsFileHeader{
+		TotalRewards:   &TotalRewards{},
+		NetworkRewards: map[uint64]*NetworkRewardsInfo{},
+	}
+
+	// Read the fixed fields
+	var err error
+	var startTime, endTime uint64
+	var merkleRoot []byte
+	uint64Fields := []*uint64{&header.RewardsFileVersion, &header.RulesetVersion, &header.Index}
+	for _, field := range uint64Fields {
+		if *field, err = decoder.readUint64(); err != nil {
+			return fmt.Errorf("error reading rewards file header: %w", err)
+		}
+	}
+	if err = decoder.readOffset(); err != nil {
+		return fmt.Errorf("error reading network offset: %w", err)
+	}
+	uint64Fields = []*uint64{&startTime, &endTime, &header.ConsensusStartBlock, &header.ConsensusEndBlock, &header.ExecutionStartBlock, &header.ExecutionEndBlock, &header.IntervalsPassed}
+	for _, field := range uint64Fields {
+		if *field, err = decoder.readUint64(); err != nil {
+			return fmt.Errorf("error reading rewards file header: %w", err)
+		}
+	}
+	if merkleRoot, err = decoder.readFixedBytes(common.HashLength); err != nil {
+		return fmt.Errorf("error reading Merkle root: %w", err)
+	}
+	if err = decoder.readOffset(); err != nil {
+		return fmt.Errorf("error reading minipool performance file CID offset: %w", err)
+	}
+	totalRewards := []**QuotedBigInt{
+		&header.TotalRewards.ProtocolDaoRpl,
+		&header.TotalRewards.TotalCollateralRpl,
+		&header.TotalRewards.TotalOracleDaoRpl,
+		&header.TotalRewards.TotalSmoothingPoolEth,
+		&header.TotalRewards.PoolStakerSmoothingPoolEth,
+		&header.TotalRewards.NodeOperatorSmoothingPoolEth,
+	}
+	for _, field := range totalRewards {
+		value, err := decoder.readUint256()
+		if err != nil {
+			return fmt.Errorf("error reading total rewards: %w", err)
+		}
+		*field = bigToQuoted(value)
+	}
+	for i := 0; i < 2; i++ {
+		if err = decoder.readOffset(); err != nil {
+			return fmt.Errorf("error reading rewards list offset: %w", err)
+		}
+	}
+	if err = decoder.readOffset(); err != nil {
+		return fmt.Errorf("error reading provenance offset: %w", err)
+	}
+	header.StartTime = decodeSszTime(startTime)
+	header.EndTime = decodeSszTime(endTime)
+	if merkleRootHash := common.BytesToHash(merkleRoot); merkleRootHash != (common.Hash{}) {
+		header.MerkleRoot = merkleRootHash.Hex()
+	}
+
+	// Read the variable fields
+	network, err := decoder.variable(0)
+	if err != nil {
+		return fmt.Errorf("error reading network: %w", err)
+	}
+	header.Network = string(network)
+	cid, err := decoder.variable(1)
+	if err != nil {
+		return fmt.Errorf("error reading minipool performance file CID: %w", err)
+	}
+	header.MinipoolPerformanceFileCID = string(cid)
+
+	// Network rewards
+	networkRewardsBytes, err := decoder.variable(2)
+	if err != nil {
+		return fmt.Errorf("error reading network rewards: %w", err)
+	}
+	networkRewards, err := decodeSszFixedList(networkRewardsBytes, sszNetworkRewardsSize)
+	if err != nil {
+		return fmt.Errorf("error reading network rewards: %w", err)
+	}
+	for _, element := range networkRewards {
+		elementDecoder := newSszDecoder(element)
+		network, err := elementDecoder.readUint64()
+		if err != nil {
+			return fmt.Errorf("error reading network rewards: %w", err)
+		}
+		info := &NetworkRewardsInfo{}
+		for _, field := range []**QuotedBigInt{&info.CollateralRpl, &info.OracleDaoRpl, &info.SmoothingPoolEth} {
+			value, err := elementDecoder.readUint256()
+			if err != nil {
+				return fmt.Errorf("error reading rewards for network %d: %w", network, err)
+			}
+			*field = bigToQuoted(value)
+		}
+		header.NetworkRewards[network] = info
+	}
+
+	// Node rewards
+	nodeRewardsBytes, err := decoder.variable(3)
+	if err != nil {
+		return fmt.Errorf("error reading node rewards: %w", err)
+	}
+	nodeRewards, err := decodeSszVariableList(nodeRewardsBytes)
+	if err != nil {
+		return fmt.Errorf("error reading node rewards: %w", err)
+	}
+	f.NodeRewards = make(map[common.Address]*NodeRewardsInfo_v2, len(nodeRewards))
+	for _, element := range nodeRewards {
+		elementDecoder := newSszDecoder(element)
+		addressBytes, err := elementDecoder.readFixedBytes(common.AddressLength)
+		if err != nil {
+			return fmt.Errorf("error reading node address: %w", err)
+		}
+		address := common.BytesToAddress(addressBytes)
+		info := &NodeRewardsInfo_v2{}
+		if info.RewardNetwork, err = elementDecoder.readUint64(); err != nil {
+			return fmt.Errorf("error reading reward network for node %s: %w", address.Hex(), err)
+		}
+		for _, field := range []**QuotedBigInt{&info.CollateralRpl, &info.OracleDaoRpl, &info.SmoothingPoolEth} {
+			value, err := elementDecoder.readUint256()
+			if err != nil {
+				return fmt.Errorf("error reading rewards for node %s: %w", address.Hex(), err)
+			}
+			*field = bigToQuoted(value)
+		}
+		if err = elementDecoder.readOffset(); err != nil {
+			return fmt.Errorf("error reading Merkle proof offset for node %s: %w", address.Hex(), err)
+		}
+		proofBytes, err := elementDecoder.variable(0)
+		if err != nil {
+			return fmt.Errorf("error reading Merkle proof for node %s: %w", address.Hex(), err)
+		}
+		proof, err := decodeSszFixedList(proofBytes, common.HashLength)
+		if err != nil {
+			return fmt.Errorf("error reading Merkle proof for node %s: %w", address.Hex(), err)
+		}
+		info.MerkleProof = make([]string, len(proof))
+		for i, proofLevel := range proof {
+			info.MerkleProof[i] = common.BytesToHash(proofLevel).Hex()
+		}
+		f.NodeRewards[address] = info
+	}
+
+	// Provenance
+	provenanceBytes, err := decoder.variable(4)
+	if err != nil {
+		return fmt.Errorf("error reading provenance: %w", err)
+	}
+	header.Provenance, err = decodeSszProvenance(provenanceBytes)
+	if err != nil {
+		return fmt.Errorf("error reading provenance: %w", err)
+	}
+
+	f.RewardsFileHeader = header
+	return nil
+}
+
+// Serialize a minipool performance file into SSZ bytes
+func (f *MinipoolPerformanceFile_v2) SerializeSSZ() ([]byte, error) {
+	encoder := &sszEncoder{}
+	encoder.putUint64(f.RewardsFileVersion)
+	encoder.putUint64(f.RulesetVersion)
+	encoder.putUint64(f.Index)
+	encoder.putVariable([]byte(f.Network))
+	encoder.putUint64(encodeSszTime(f.StartTime))
+	encoder.putUint64(encodeSszTime(f.EndTime))
+	encoder.putUint64(f.ConsensusStartBlock)
+	encoder.putUint64(f.ConsensusEndBlock)
+	encoder.putUint64(f.ExecutionStartBlock)
+	encoder.putUint64(f.ExecutionEndBlock)
+
+	// Minipool performance, sorted by address
+	addresses := f.GetMinipoolAddresses()
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+	minipools := make([][]byte, len(addresses))
+	for i, address := range addresses {
+		perf := f.MinipoolPerformance[address]
+		pubkey, err := perf.GetPubkey()
+		if err != nil {
+			return nil, fmt.Errorf("error encoding pubkey for minipool %s: %w", address.Hex(), err)
+		}
+		element := &sszEncoder{}
+		element.putFixedBytes(address.Bytes())
+		element.putFixedBytes(pubkey.Bytes())
+		element.putUint64(perf.SuccessfulAttestations)
+		element.putUint64(perf.MissedAttestations)
+		for _, value := range []*QuotedBigInt{perf.AttestationScore, perf.EthEarned} {
+			err := element.putUint256(quotedToBig(value))
+			if err != nil {
+				return nil, fmt.Errorf("error encoding performance for minipool %s: %w", address.Hex(), err)
+			}
+		}
+		slots := &sszEncoder{}
+		for _, slot := range perf.MissingAttestationSlots {
+			slots.putUint64(slot)
+		}
+		element.putVariable(slots.finish())
+		minipools[i] = element.finish()
+	}
+	encoder.putVariable(encodeSszVariableList(minipools))
+
+	return append(append([]byte{}, sszMinipoolPerformanceFileMagic...), encoder.finish()...), nil
+}
+
+// Deserialize a minipool performance file from SSZ bytes
+func (f *MinipoolPerformanceFile_v2) DeserializeSSZ(data []byte) error {
+	if !IsSszMinipoolPerformanceFile(data) {
+		return fmt.Errorf("data is not an SSZ minipool performance file")
+	}
+	decoder := newSszDecoder(data[len(sszMinipoolPerformanceFileMagic):])
+
+	// Read the fixed fields
+	var err error
+	var startTime, endTime uint64
+	uint64Fields := []*uint64{&f.RewardsFileVersion, &f.RulesetVersion, &f.Index}
+	for _, field := range uint64Fields {
+		if *field, err = decoder.readUint64(); err != nil {
+			return fmt.Errorf("error reading minipool performance file header: %w", err)
+		}
+	}
+	if err = decoder.readOffset(); err != nil {
+		return fmt.Errorf("error reading network offset: %w", err)
+	}
+	uint64Fields = []*uint64{&startTime, &endTime, &f.ConsensusStartBlock, &f.ConsensusEndBlock, &f.ExecutionStartBlock, &f.ExecutionEndBlock}
+	for _, field := range uint64Fields {
+		if *field, err = decoder.readUint64(); err != nil {
+			return fmt.Errorf("error reading minipool performance file header: %w", err)
+		}
+	}
+	if err = decoder.readOffset(); err != nil {
+		return fmt.Errorf("error reading minipool performance offset: %w", err)
+	}
+	f.StartTime = decodeSszTime(startTime)
+	f.EndTime = decodeSszTime(endTime)
+
+	// Read the variable fields
+	network, err := decoder.variable(0)
+	if err != nil {
+		return fmt.Errorf("error reading network: %w", err)
+	}
+	f.Network = string(network)
+
+	minipoolsBytes, err := decoder.variable(1)
+	if err != nil {
+		return fmt.Errorf("error reading minipool performance: %w", err)
+	}
+	minipools, err := decodeSszVariableList(minipoolsBytes)
+	if err != nil {
+		return fmt.Errorf("error reading minipool performance: %w", err)
+	}
+	f.MinipoolPerformance = make(map[common.Address]*SmoothingPoolMinipoolPerformance_v2, len(minipools))
+	for _, element := range minipools {
+		elementDecoder := newSszDecoder(element)
+		addressBytes, err := elementDecoder.readFixedBytes(common.AddressLength)
+		if err != nil {
+			return fmt.Errorf("error reading minipool address: %w", err)
+		}
+		address := common.BytesToAddress(addressBytes)
+		pubkey, err := elementDecoder.readFixedBytes(types.ValidatorPubkeyLength)
+		if err != nil {
+			return fmt.Errorf("error reading pubkey for minipool %s: %w", address.Hex(), err)
+		}
+		perf := &SmoothingPoolMinipoolPerformance_v2{
+			Pubkey: types.BytesToValidatorPubkey(pubkey).Hex(),
+		}
+		for _, field := range []*uint64{&perf.SuccessfulAttestations, &perf.MissedAttestations} {
+			if *field, err = elementDecoder.readUint64(); err != nil {
+				return fmt.Errorf("error reading attestations for minipool %s: %w", address.Hex(), err)
+			}
+		}
+		for _, field := range []**QuotedBigInt{&perf.AttestationScore, &perf.EthEarned} {
+			value, err := elementDecoder.readUint256()
+			if err != nil {
+				return fmt.Errorf("error reading performance for minipool %s: %w", address.Hex(), err)
+			}
+			*field = bigToQuoted(value)
+		}
+		if err = elementDecoder.readOffset(); err != nil {
+			return fmt.Errorf("error reading missing attestation offset for minipool %s: %w", address.Hex(), err)
+		}
+		slotBytes, err := elementDecoder.variable(0)
+		if err != nil {
+			return fmt.Errorf("error reading missing attestations for minipool %s: %w", address.Hex(), err)
+		}
+		slots, err := decodeSszFixedList(slotBytes, sszUint64Size)
+		if err != nil {
+			return fmt.Errorf("error reading missing attestations for minipool %s: %w", address.Hex(), err)
+		}
+		perf.MissingAttestationSlots = make([]uint64, len(slots))
+		for i, slot := range slots {
+			perf.MissingAttestationSlots[i], _ = newSszDecoder(slot).readUint64()
+		}
+		f.MinipoolPerformance[address] = perf
+	}
+
+	return nil
+}
+
+// Encodes the provenance of a rewards file; a missing provenance is stored as an empty field
+func encodeSszProvenance(provenance *RewardsFileProvenance) ([]byte, error) {
+	if provenance == nil {
+		return []byte{}, nil
+	}
+	encoder := &sszEncoder{}
+	encoder.putVariable([]byte(provenance.GeneratorVersion))
+	encoder.putUint64(provenance.RulesetVersion)
+	encoder.putVariable([]byte(provenance.ExecutionClient.Endpoint))
+	encoder.putVariable([]byte(provenance.ExecutionClient.Version))
+	encoder.putVariable([]byte(provenance.ConsensusClient.Endpoint))
+	encoder.putVariable([]byte(provenance.ConsensusClient.Version))
+	encoder.putUint64(provenance.SnapshotSlot)
+	encoder.putUint64(provenance.SnapshotBlock)
+	encoder.putUint64(encodeSszTime(provenance.GeneratedAt))
+	encoder.putUint64(math.Float64bits(provenance.GenerationDurationSeconds))
+	return encoder.finish(), nil
+}
+
+// Decodes the provenance of a rewards file, returning nil if it was empty
+func decodeSszProvenance(data []byte) (*RewardsFileProvenance, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	decoder := newSszDecoder(data)
+	provenance := &RewardsFileProvenance{}
+
+	var err error
+	var generatedAt, duration uint64
+	if err = decoder.readOffset(); err != nil {
+		return nil, fmt.Errorf("error reading generator version offset: %w", err)
+	}
+	if provenance.RulesetVersion, err = decoder.readUint64(); err != nil {
+		return nil, fmt.Errorf("error reading ruleset version: %w", err)
+	}
+	for i := 0; i < 4; i++ {
+		if err = decoder.readOffset(); err != nil {
+			return nil, fmt.Errorf("error reading client offset: %w", err)
+		}
+	}
+	for _, field := range []*uint64{&provenance.SnapshotSlot, &provenance.SnapshotBlock, &generatedAt, &duration} {
+		if *field, err = decoder.readUint64(); err != nil {
+			return nil, fmt.Errorf("error reading snapshot details: %w", err)
+		}
+	}
+	provenance.GeneratedAt = decodeSszTime(generatedAt)
+	provenance.GenerationDurationSeconds = math.Float64frombits(duration)
+
+	strings := []*string{
+		&provenance.GeneratorVersion,
+		&provenance.ExecutionClient.Endpoint,
+		&provenance.ExecutionClient.Version,
+		&provenance.ConsensusClient.Endpoint,
+		&provenance.ConsensusClient.Version,
+	}
+	for i, field := range strings {
+		value, err := decoder.variable(i)
+		if err != nil {
+			return nil, err
+		}
+		*field = string(value)
+	}
+	return provenance, nil
+}
+
+// Times are stored as Unix nanoseconds, with 0 representing an unset time
+func encodeSszTime(value time.Time) uint64 {
+	if value.IsZero() {
+		return 0
+	}
+	return uint64(value.UnixNano())
+}
+
+func decodeSszTime(value uint64) time.Time {
+	if value == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(value)).UTC()
+}
+
+func quotedToBig(value *QuotedBigInt) *big.Int {
+	if value == nil {
+		return nil
+	}
+	return &value.Int
+}
+
+func bigToQuoted(value *big.Int) *QuotedBigInt {
+	quoted := &QuotedBigInt{}
+	quoted.Set(value)
+	return quoted
+}
@@ -0,0 +1,243 @@
+package rewards
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// A canonical v2 rewards file with nodes on several networks, including one without rewards
+var rewardsFileV2TestPath = filepath.Join("testdata", "rewards-file-v2.json")
+
+// Load the JSON test file and encode it as SSZ
+func loadSszTestFile(t *testing.T) (*RewardsFile_v2, []byte) {
+	t.Helper()
+	jsonBytes, err := os.ReadFile(rewardsFileV2TestPath)
+	if err != nil {
+		t.Fatalf("error reading %s: %s", rewardsFileV2TestPath, err.Error())
+	}
+	file, err := DeserializeRewardsFile(jsonBytes)
+	if err != nil {
+		t.Fatalf("error deserializing %s: %s", rewardsFileV2TestPath, err.Error())
+	}
+	jsonFile, ok := file.(*RewardsFile_v2)
+	if !ok {
+		t.Fatalf("%s deserialized to %T instead of a v2 rewards file", rewardsFileV2TestPath, file)
+	}
+	sszBytes, err := jsonFile.SerializeSSZ()
+	if err != nil {
+		t.Fatalf("error encoding SSZ: %s", err.Error())
+	}
+	return jsonFile, sszBytes
+}
+
+func TestRewardsFileSszRoundTrip(t *testing.T) {
+	jsonFile, sszBytes := loadSszTestFile(t)
+	checkSszRoundTrip(t, jsonFile, sszBytes)
+
+	// Provenance isn't in canonical files, but locally generated ones carry it
+	jsonFile.Provenance = &RewardsFileProvenance{
+		GeneratorVersion: "1.11.0",
+		RulesetVersion:   jsonFile.RulesetVersion,
+		ExecutionClient: ClientProvenance{
+			Endpoint: "http://eth1:8545",
+			Version:  "Geth/v1.12.0-stable",
+		},
+		ConsensusClient: ClientProvenance{
+			Endpoint: "http://eth2:5052",
+			Version:  "Lighthouse/v4.2.0",
+		},
+		SnapshotSlot:              jsonFile.ConsensusEndBlock,
+		SnapshotBlock:             jsonFile.ExecutionEndBlock,
+		GeneratedAt:               time.Date(2023, 6, 29, 13, 30, 0, 0, time.UTC),
+		GenerationDurationSeconds: 412.5,
+	}
+	sszBytes, err := jsonFile.SerializeSSZ()
+	if err != nil {
+		t.Fatalf("error encoding SSZ with provenance: %s", err.Error())
+	}
+	checkSszRoundTrip(t, jsonFile, sszBytes)
+}
+
+// Decode the SSZ bytes and make sure they hold the same rewards and Merkle root as the original file
+func checkSszRoundTrip(t *testing.T, original *RewardsFile_v2, sszBytes []byte) {
+	t.Helper()
+	if !IsSszRewardsFile(sszBytes) {
+		t.Fatalf("SSZ bytes don't start with the rewards file prefix")
+	}
+	file, err := DeserializeRewardsFile(sszBytes)
+	if err != nil {
+		t.Fatalf("error decoding SSZ: %s", err.Error())
+	}
+	decoded := file.(*RewardsFile_v2)
+
+	// Header
+	expected := original.RewardsFileHeader
+	header := decoded.RewardsFileHeader
+	if header.RewardsFileVersion != expected.RewardsFileVersion || header.RulesetVersion != expected.RulesetVersion || header.Index != expected.Index || header.Network != expected.Network {
+		t.Errorf("decoded header is version %d, ruleset %d, interval %d on %s; expected version %d, ruleset %d, interval %d on %s",
+			header.RewardsFileVersion, header.RulesetVersion, header.Index, header.Network, expected.RewardsFileVersion, expected.RulesetVersion, expected.Index, expected.Network)
+	}
+	if !header.StartTime.Equal(expected.StartTime) || !header.EndTime.Equal(expected.EndTime) {
+		t.Errorf("decoded interval is %s to %s; expected %s to %s", header.StartTime, header.EndTime, expected.StartTime, expected.EndTime)
+	}
+	if header.ConsensusEndBlock != expected.ConsensusEndBlock || header.ExecutionEndBlock != expected.ExecutionEndBlock || header.IntervalsPassed != expected.IntervalsPassed {
+		t.Errorf("decoded blocks or intervals passed don't match the original")
+	}
+	if header.MinipoolPerformanceFileCID != expected.MinipoolPerformanceFileCID {
+		t.Errorf("decoded minipool performance file CID is %s; expected %s", header.MinipoolPerformanceFileCID, expected.MinipoolPerformanceFileCID)
+	}
+	checkQuotedBigInt(t, "protocol DAO RPL", header.TotalRewards.ProtocolDaoRpl, expected.TotalRewards.ProtocolDaoRpl)
+	checkQuotedBigInt(t, "total collateral RPL", header.TotalRewards.TotalCollateralRpl, expected.TotalRewards.TotalCollateralRpl)
+	checkQuotedBigInt(t, "total Oracle DAO RPL", header.TotalRewards.TotalOracleDaoRpl, expected.TotalRewards.TotalOracleDaoRpl)
+	checkQuotedBigInt(t, "total Smoothing Pool ETH", header.TotalRewards.TotalSmoothingPoolEth, expected.TotalRewards.TotalSmoothingPoolEth)
+	checkQuotedBigInt(t, "pool staker Smoothing Pool ETH", header.TotalRewards.PoolStakerSmoothingPoolEth, expected.TotalRewards.PoolStakerSmoothingPoolEth)
+	checkQuotedBigInt(t, "node operator Smoothing Pool ETH", header.TotalRewards.NodeOperatorSmoothingPoolEth, expected.TotalRewards.NodeOperatorSmoothingPoolEth)
+
+	// Network rewards
+	if len(header.NetworkRewards) != len(expected.NetworkRewards) {
+		t.Errorf("decoded rewards for %d networks; expected %d", len(header.NetworkRewards), len(expected.NetworkRewards))
+	}
+	for network, expectedInfo := range expected.NetworkRewards {
+		info, exists := header.NetworkRewards[network]
+		if !exists {
+			t.Errorf("decoded file is missing the rewards for network %d", network)
+			continue
+		}
+		checkQuotedBigInt(t, "collateral RPL of a network", info.CollateralRpl, expectedInfo.CollateralRpl)
+		checkQuotedBigInt(t, "Oracle DAO RPL of a network", info.OracleDaoRpl, expectedInfo.OracleDaoRpl)
+		checkQuotedBigInt(t, "Smoothing Pool ETH of a network", info.SmoothingPoolEth, expectedInfo.SmoothingPoolEth)
+	}
+
+	// Node rewards
+	if len(decoded.NodeRewards) != len(original.NodeRewards) {
+		t.Errorf("decoded rewards for %d nodes; expected %d", len(decoded.NodeRewards), len(original.NodeRewards))
+	}
+	for address, expectedInfo := range original.NodeRewards {
+		info, exists := decoded.NodeRewards[address]
+		if !exists {
+			t.Errorf("decoded file is missing the rewards for node %s", address.Hex())
+			continue
+		}
+		if info.RewardNetwork != expectedInfo.RewardNetwork {
+			t.Errorf("node %s has reward network %d; expected %d", address.Hex(), info.RewardNetwork, expectedInfo.RewardNetwork)
+		}
+		checkQuotedBigInt(t, "collateral RPL of node "+address.Hex(), info.CollateralRpl, expectedInfo.CollateralRpl)
+		checkQuotedBigInt(t, "Oracle DAO RPL of node "+address.Hex(), info.OracleDaoRpl, expectedInfo.OracleDaoRpl)
+		checkQuotedBigInt(t, "Smoothing Pool ETH of node "+address.Hex(), info.SmoothingPoolEth, expectedInfo.SmoothingPoolEth)
+		if len(info.MerkleProof) != len(expectedInfo.MerkleProof) {
+			t.Errorf("node %s has a Merkle proof with %d levels; expected %d", address.Hex(), len(info.MerkleProof), len(expectedInfo.MerkleProof))
+			continue
+		}
+		for i := range info.MerkleProof {
+			if common.HexToHash(info.MerkleProof[i]) != common.HexToHash(expectedInfo.MerkleProof[i]) {
+				t.Errorf("level %d of node %s's Merkle proof is %s; expected %s", i, address.Hex(), info.MerkleProof[i], expectedInfo.MerkleProof[i])
+			}
+		}
+	}
+
+	// Provenance
+	if (header.Provenance == nil) != (expected.Provenance == nil) {
+		t.Errorf("decoded provenance is %v; expected %v", header.Provenance, expected.Provenance)
+	} else if header.Provenance != nil {
+		provenance, expectedProvenance := *header.Provenance, *expected.Provenance
+		if !provenance.GeneratedAt.Equal(expectedProvenance.GeneratedAt) {
+			t.Errorf("decoded provenance was generated at %s; expected %s", provenance.GeneratedAt, expectedProvenance.GeneratedAt)
+		}
+		provenance.GeneratedAt = expectedProvenance.GeneratedAt
+		if provenance != expectedProvenance {
+			t.Errorf("decoded provenance is %+v; expected %+v", provenance, expectedProvenance)
+		}
+	}
+
+	// The Merkle root has to survive the round trip, and the decoded rewards have to rebuild the same tree
+	if common.HexToHash(header.MerkleRoot) != common.HexToHash(expected.MerkleRoot) {
+		t.Errorf("decoded Merkle root is %s; expected %s", header.MerkleRoot, expected.MerkleRoot)
+	}
+	root, err := ComputeMerkleRoot(decoded)
+	if err != nil {
+		t.Fatalf("error rebuilding the Merkle tree of the decoded file: %s", err.Error())
+	}
+	if root != common.HexToHash(expected.MerkleRoot) {
+		t.Errorf("decoded rewards rebuild Merkle root %s; expected %s", root.Hex(), expected.MerkleRoot)
+	}
+
+	// Encoding is deterministic, so the decoded file encodes to the same bytes
+	reencoded, err := decoded.SerializeSSZ()
+	if err != nil {
+		t.Fatalf("error re-encoding the decoded file: %s", err.Error())
+	}
+	if !bytes.Equal(reencoded, sszBytes) {
+		t.Errorf("re-encoding the decoded file produced different bytes")
+	}
+}
+
+func checkQuotedBigInt(t *testing.T, name string, value *QuotedBigInt, expected *QuotedBigInt) {
+	t.Helper()
+	if value == nil || expected == nil {
+		if value != expected {
+			t.Errorf("%s is %v; expected %v", name, value, expected)
+		}
+		return
+	}
+	if value.Cmp(&expected.Int) != 0 {
+		t.Errorf("%s is %s; expected %s", name, value.String(), expected.String())
+	}
+}
+
+func TestRewardsFileSszTruncated(t *testing.T) {
+	_, sszBytes := loadSszTestFile(t)
+
+	// Every prefix of the file has to be rejected, including ones that cut the node rewards off at an element boundary
+	for length := 0; length < len(sszBytes); length++ {
+		file := &RewardsFile_v2{}
+		if err := file.DeserializeSSZ(sszBytes[:length]); err == nil {
+			t.Errorf("decoding the first %d of %d bytes succeeded", length, len(sszBytes))
+		}
+	}
+}
+
+func TestRewardsFileSszOversized(t *testing.T) {
+	_, sszBytes := loadSszTestFile(t)
+
+	// Canonical files have no provenance, so anything after the node rewards is read as a malformed provenance
+	for _, padding := range [][]byte{
+		{0x00},
+		bytes.Repeat([]byte{0x00}, 64),
+		bytes.Repeat([]byte{0xff}, 64),
+		bytes.Repeat([]byte{0xab}, 1024),
+	} {
+		file := &RewardsFile_v2{}
+		if err := file.DeserializeSSZ(append(append([]byte{}, sszBytes...), padding...)); err == nil {
+			t.Errorf("decoding the file with %d extra bytes succeeded", len(padding))
+		}
+	}
+
+	// Offsets that point past the end of the data are rejected: the network, minipool performance file CID,
+	// network rewards, node rewards and provenance offsets, in the order they're in the header
+	networkOffset := len(sszRewardsFileMagic) + 3*sszUint64Size
+	cidOffset := networkOffset + sszOffsetSize + 7*sszUint64Size + common.HashLength
+	networkRewardsOffset := cidOffset + sszOffsetSize + 6*sszUint256Size
+	for _, position := range []int{networkOffset, cidOffset, networkRewardsOffset, networkRewardsOffset + sszOffsetSize, networkRewardsOffset + 2*sszOffsetSize} {
+		corrupted := append([]byte{}, sszBytes...)
+		copy(corrupted[position:], []byte{0xff, 0xff, 0xff, 0x7f})
+		file := &RewardsFile_v2{}
+		if err := file.DeserializeSSZ(corrupted); err == nil {
+			t.Errorf("decoding the file with the offset at byte %d past the end of the data succeeded", position)
+		}
+	}
+
+	// Values that don't fit in a uint256 can't be encoded
+	jsonFile, _ := loadSszTestFile(t)
+	for _, info := range jsonFile.NodeRewards {
+		info.SmoothingPoolEth.Lsh(&info.SmoothingPoolEth.Int, 256)
+		break
+	}
+	if _, err := jsonFile.SerializeSSZ(); err == nil {
+		t.Errorf("encoding a node reward larger than a uint256 succeeded")
+	}
+}
@@ -82,6 +82,12 @@ func (p *SmoothingPoolMinipoolPerformance_v2) GetMissingAttestationSlots() []uin
 func (p *SmoothingPoolMinipoolPerformance_v2) GetEthEarned() *big.Int {
 	return &p.EthEarned.Int
 }
+func (p *SmoothingPoolMinipoolPerformance_v2) GetAttestationScore() *big.Int {
+	if p.AttestationScore == nil {
+		return nil
+	}
+	return &p.AttestationScore.Int
+}
 
 // Node operator rewards
 type NodeRewardsInfo_v2 struct {
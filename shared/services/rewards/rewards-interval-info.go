@@ -6,15 +6,25 @@ import (
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 )
 
+// The generator a registered ruleset created for the current interval
 type rewardsIntervalInfo struct {
+	generator RulesetGenerator
+}
+
+// A ruleset that ships with the Smartnode, activated at fixed intervals on each network
+type builtinRuleset struct {
 	rewardsRulesetVersion uint64
 	mainnetStartInterval  uint64
 	praterStartInterval   uint64
 	holeskyStartInterval  uint64
-	generator             treeGeneratorImpl
+	newGenerator          func(params *RulesetParameters) RulesetGenerator
 }
 
-func (r *rewardsIntervalInfo) GetStartInterval(network cfgtypes.Network) (uint64, error) {
+func (r *builtinRuleset) GetVersion() uint64 {
+	return r.rewardsRulesetVersion
+}
+
+func (r *builtinRuleset) GetStartInterval(network cfgtypes.Network) (uint64, error) {
 	switch network {
 	case cfgtypes.Network_Mainnet:
 		return r.mainnetStartInterval, nil
@@ -28,3 +38,82 @@ func (r *rewardsIntervalInfo) GetStartInterval(network cfgtypes.Network) (uint64
 		return 0, fmt.Errorf("unknown network: %s", string(network))
 	}
 }
+
+func (r *builtinRuleset) NewGenerator(params *RulesetParameters) RulesetGenerator {
+	return r.newGenerator(params)
+}
+
+// Get the rulesets that ship with the Smartnode
+func getBuiltinRulesets() []RewardsRuleset {
+	return []RewardsRuleset{
+		&builtinRuleset{
+			rewardsRulesetVersion: 7,
+			mainnetStartInterval:  MainnetV7Interval,
+			praterStartInterval:   PraterV7Interval,
+			holeskyStartInterval:  HoleskyV7Interval,
+			newGenerator: func(p *RulesetParameters) RulesetGenerator {
+				if p.RollingRecord == nil {
+					return newTreeGeneratorImpl_v7(p.Log, p.LogPrefix, p.Flow, p.Index, p.StartTime, p.EndTime, p.ConsensusBlock, p.ElSnapshotHeader, p.IntervalsPassed, p.State)
+				}
+				return newTreeGeneratorImpl_v7_rolling(p.Log, p.LogPrefix, p.Index, p.StartTime, p.EndTime, p.ConsensusBlock, p.ElSnapshotHeader, p.IntervalsPassed, p.State, p.RollingRecord)
+			},
+		},
+		&builtinRuleset{
+			rewardsRulesetVersion: 6,
+			mainnetStartInterval:  MainnetV6Interval,
+			praterStartInterval:   PraterV6Interval,
+			holeskyStartInterval:  HoleskyV6Interval,
+			newGenerator: func(p *RulesetParameters) RulesetGenerator {
+				if p.RollingRecord == nil {
+					return newTreeGeneratorImpl_v6(p.Log, p.LogPrefix, p.Index, p.StartTime, p.EndTime, p.ConsensusBlock, p.ElSnapshotHeader, p.IntervalsPassed, p.State)
+				}
+				return newTreeGeneratorImpl_v6_rolling(p.Log, p.LogPrefix, p.Index, p.StartTime, p.EndTime, p.ConsensusBlock, p.ElSnapshotHeader, p.IntervalsPassed, p.State, p.RollingRecord)
+			},
+		},
+		&builtinRuleset{
+			rewardsRulesetVersion: 5,
+			mainnetStartInterval:  MainnetV5Interval,
+			praterStartInterval:   PraterV5Interval,
+			holeskyStartInterval:  HoleskyV5Interval,
+			newGenerator: func(p *RulesetParameters) RulesetGenerator {
+				return newTreeGeneratorImpl_v5(p.Log, p.LogPrefix, p.Index, p.StartTime, p.EndTime, p.ConsensusBlock, p.ElSnapshotHeader, p.IntervalsPassed, p.State)
+			},
+		},
+		&builtinRuleset{
+			rewardsRulesetVersion: 4,
+			mainnetStartInterval:  MainnetV4Interval,
+			praterStartInterval:   PraterV4Interval,
+			holeskyStartInterval:  HoleskyV4Interval,
+			newGenerator: func(p *RulesetParameters) RulesetGenerator {
+				return newTreeGeneratorImpl_v4(p.Log, p.LogPrefix, p.Index, p.StartTime, p.EndTime, p.ConsensusBlock, p.ElSnapshotHeader, p.IntervalsPassed)
+			},
+		},
+		&builtinRuleset{
+			rewardsRulesetVersion: 3,
+			mainnetStartInterval:  MainnetV3Interval,
+			praterStartInterval:   PraterV3Interval,
+			holeskyStartInterval:  HoleskyV3Interval,
+			newGenerator: func(p *RulesetParameters) RulesetGenerator {
+				return newTreeGeneratorImpl_v3(p.Log, p.LogPrefix, p.Index, p.StartTime, p.EndTime, p.ConsensusBlock, p.ElSnapshotHeader, p.IntervalsPassed)
+			},
+		},
+		&builtinRuleset{
+			rewardsRulesetVersion: 2,
+			mainnetStartInterval:  MainnetV2Interval,
+			praterStartInterval:   PraterV2Interval,
+			holeskyStartInterval:  HoleskyV2Interval,
+			newGenerator: func(p *RulesetParameters) RulesetGenerator {
+				return newTreeGeneratorImpl_v2(p.Log, p.LogPrefix, p.Index, p.StartTime, p.EndTime, p.ConsensusBlock, p.ElSnapshotHeader, p.IntervalsPassed)
+			},
+		},
+		&builtinRuleset{
+			rewardsRulesetVersion: 1,
+			mainnetStartInterval:  0,
+			praterStartInterval:   0,
+			holeskyStartInterval:  0,
+			newGenerator: func(p *RulesetParameters) RulesetGenerator {
+				return newTreeGeneratorImpl_v1(p.Log, p.LogPrefix, p.Index, p.StartTime, p.EndTime, p.ConsensusBlock, p.ElSnapshotHeader, p.IntervalsPassed)
+			},
+		},
+	}
+}
@@ -0,0 +1,156 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// A set of rules for calculating the rewards of an interval, such as the ones defined by each rewards RPIP.
+// Rulesets are registered by version; the tree generator picks the newest one whose start interval has been reached.
+type RewardsRuleset interface {
+	// Get the version of this ruleset
+	GetVersion() uint64
+
+	// Get the first interval that uses this ruleset on the given network
+	GetStartInterval(network cfgtypes.Network) (uint64, error)
+
+	// Create a generator that applies this ruleset to the interval described by the parameters
+	NewGenerator(params *RulesetParameters) RulesetGenerator
+}
+
+// A generator that applies a ruleset to a single interval. Tree generation runs its stages in order: eligibility,
+// then RPL and ETH scoring, then tree layout. Each stage builds on the state left behind by the previous ones.
+type RulesetGenerator interface {
+	// Eligibility: load the interval's details and determine which nodes and minipools are eligible for rewards and how
+	// they performed. Without Beacon performance checks, every eligible minipool is scored as if it performed perfectly.
+	DetermineEligibility(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, checkBeaconPerformance bool) error
+
+	// Scoring: calculate the RPL rewards each node earned
+	CalculateRplRewards() error
+
+	// Scoring: calculate the Smoothing Pool ETH each eligible node earned, returning the pool stakers' share
+	CalculateEthRewards() (*big.Int, error)
+
+	// Tree layout: arrange the calculated rewards into the network totals and the Merkle tree, completing the rewards file
+	LayoutTree() (IRewardsFile, error)
+
+	// Get the version of the ruleset this generator applies
+	GetRulesetVersion() uint64
+}
+
+// The details of the interval a ruleset generator is created for
+type RulesetParameters struct {
+	Log              *log.ColorLogger
+	LogPrefix        string
+	Flow             GenerationFlow
+	Index            uint64
+	StartTime        time.Time
+	EndTime          time.Time
+	ConsensusBlock   uint64
+	ElSnapshotHeader *types.Header
+	IntervalsPassed  uint64
+	State            *state.NetworkState
+	RollingRecord    *RollingRecord
+}
+
+var (
+	rulesetRegistry     map[uint64]RewardsRuleset = createRulesetRegistry()
+	rulesetRegistryLock sync.RWMutex
+)
+
+// Register a ruleset so it can be used for tree generation. Each version can only be registered once, so a
+// proposed change to a ruleset has to be registered under a new version to be tested against real intervals.
+func RegisterRuleset(ruleset RewardsRuleset) error {
+	if ruleset == nil {
+		return fmt.Errorf("ruleset cannot be nil")
+	}
+	version := ruleset.GetVersion()
+	if version == 0 {
+		return fmt.Errorf("ruleset version must be at least 1")
+	}
+
+	rulesetRegistryLock.Lock()
+	defer rulesetRegistryLock.Unlock()
+	if _, exists := rulesetRegistry[version]; exists {
+		return fmt.Errorf("ruleset v%d is already registered", version)
+	}
+	rulesetRegistry[version] = ruleset
+	return nil
+}
+
+// Get a registered ruleset by its version
+func GetRegisteredRuleset(version uint64) (RewardsRuleset, bool) {
+	rulesetRegistryLock.RLock()
+	defer rulesetRegistryLock.RUnlock()
+	ruleset, exists := rulesetRegistry[version]
+	return ruleset, exists
+}
+
+// Get all of the registered rulesets, sorted by version in ascending order
+func GetRegisteredRulesets() []RewardsRuleset {
+	rulesetRegistryLock.RLock()
+	defer rulesetRegistryLock.RUnlock()
+	rulesets := make([]RewardsRuleset, 0, len(rulesetRegistry))
+	for _, ruleset := range rulesetRegistry {
+		rulesets = append(rulesets, ruleset)
+	}
+	sort.Slice(rulesets, func(i, j int) bool {
+		return rulesets[i].GetVersion() < rulesets[j].GetVersion()
+	})
+	return rulesets
+}
+
+// Create the registry with all of the built-in rulesets
+func createRulesetRegistry() map[uint64]RewardsRuleset {
+	registry := map[uint64]RewardsRuleset{}
+	for _, ruleset := range getBuiltinRulesets() {
+		registry[ruleset.GetVersion()] = ruleset
+	}
+	return registry
+}
+
+// Run each of a generator's stages in order to build the complete rewards file for its interval
+func generateRulesetTree(generator RulesetGenerator, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (IRewardsFile, error) {
+	err := generator.DetermineEligibility(rp, cfg, bc, true)
+	if err != nil {
+		return nil, fmt.Errorf("error determining rewards eligibility: %w", err)
+	}
+
+	err = generator.CalculateRplRewards()
+	if err != nil {
+		return nil, fmt.Errorf("error calculating RPL rewards: %w", err)
+	}
+
+	_, err = generator.CalculateEthRewards()
+	if err != nil {
+		return nil, fmt.Errorf("error calculating ETH rewards: %w", err)
+	}
+
+	return generator.LayoutTree()
+}
+
+// Quickly approximate the pool stakers' share of the Smoothing Pool by running a generator's eligibility and ETH scoring
+// stages without processing Beacon performance. Used for approximate returns in the rETH ratio update.
+func approximateRulesetStakerShare(generator RulesetGenerator, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (*big.Int, error) {
+	err := generator.DetermineEligibility(rp, cfg, bc, false)
+	if err != nil {
+		return nil, fmt.Errorf("error determining rewards eligibility: %w", err)
+	}
+
+	share, err := generator.CalculateEthRewards()
+	if err != nil {
+		return nil, fmt.Errorf("error calculating ETH rewards: %w", err)
+	}
+	return share, nil
+}
@@ -0,0 +1,227 @@
+package rewards
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// Settings for flagging outliers in a Smoothing Pool audit
+const (
+	// How far the ETH earned by a node's minipools can drift from the ETH the node received, relative to what it received
+	SmoothingPoolAuditShareTolerance float64 = 0.01
+
+	// How many (scaled) median absolute deviations a node's share ratio can be from the median before it's flagged.
+	// Share ratios naturally vary with each node's bond sizes and commission, so nodes are compared against the spread
+	// of the whole pool rather than against a perfect 1:1 ratio.
+	SmoothingPoolAuditOutlierThreshold float64 = 5
+
+	// Scales the median absolute deviation so it's comparable to a standard deviation
+	madScaleFactor float64 = 1.4826
+
+	// The number of penalties at which a minipool is considered heavily penalized
+	SmoothingPoolAuditPenaltyThreshold uint64 = 3
+)
+
+// A comparison of every Smoothing Pool node's attestation performance against the share of the pool it received
+type SmoothingPoolAudit struct {
+	Index            uint64                    `json:"index"`
+	RulesetVersion   uint64                    `json:"rulesetVersion"`
+	ScoreBasis       string                    `json:"scoreBasis"`
+	TotalEth         *big.Int                  `json:"totalEth"`
+	TotalScore       *big.Int                  `json:"totalScore"`
+	Nodes            []*NodeSmoothingPoolAudit `json:"nodes"`
+	MedianShareRatio float64                   `json:"medianShareRatio"`
+	ShareRatioMad    float64                   `json:"shareRatioMad"`
+	FlaggedNodes     int                       `json:"flaggedNodes"`
+	UnknownMinipools []common.Address          `json:"unknownMinipools"`
+}
+
+// A single node's Smoothing Pool performance and rewards for an interval
+type NodeSmoothingPoolAudit struct {
+	Address                common.Address `json:"address"`
+	MinipoolCount          int            `json:"minipoolCount"`
+	PenalizedMinipoolCount int            `json:"penalizedMinipoolCount"`
+	SuccessfulAttestations uint64         `json:"successfulAttestations"`
+	MissedAttestations     uint64         `json:"missedAttestations"`
+	ParticipationRate      float64        `json:"participationRate"`
+	Score                  *big.Int       `json:"score"`
+	SmoothingPoolEth       *big.Int       `json:"smoothingPoolEth"`
+	MinipoolEthEarned      *big.Int       `json:"minipoolEthEarned"`
+	EthShare               float64        `json:"ethShare"`
+	ScoreShare             float64        `json:"scoreShare"`
+	ShareRatio             float64        `json:"shareRatio"`
+	Flags                  []string       `json:"flags"`
+}
+
+// Audits the Smoothing Pool rewards of an interval, using the network state at the end of the interval to map minipools to
+// their nodes and check their penalties.
+// Each node's share of the pool is compared against its share of the attestation score (or of the successful attestations
+// for rulesets that didn't record scores), and nodes whose ratio is an outlier relative to the median of the pool are flagged.
+func AuditSmoothingPool(rewardsFile IRewardsFile, performanceFile IMinipoolPerformanceFile, networkState *state.NetworkState) (*SmoothingPoolAudit, error) {
+	header := rewardsFile.GetHeader()
+	audit := &SmoothingPoolAudit{
+		Index:            header.Index,
+		RulesetVersion:   header.RulesetVersion,
+		TotalEth:         big.NewInt(0),
+		TotalScore:       big.NewInt(0),
+		Nodes:            []*NodeSmoothingPoolAudit{},
+		UnknownMinipools: []common.Address{},
+	}
+
+	// Older rulesets don't record attestation scores, so fall back to attestation counts if any are missing
+	minipoolAddresses := performanceFile.GetMinipoolAddresses()
+	useScores := true
+	for _, address := range minipoolAddresses {
+		performance, _ := performanceFile.GetSmoothingPoolPerformance(address)
+		if performance.GetAttestationScore() == nil {
+			useScores = false
+			break
+		}
+	}
+	if useScores {
+		audit.ScoreBasis = "attestation score"
+	} else {
+		audit.ScoreBasis = "successful attestations"
+	}
+
+	// Aggregate the minipool performance by node
+	nodes := map[common.Address]*NodeSmoothingPoolAudit{}
+	getNode := func(address common.Address) *NodeSmoothingPoolAudit {
+		node, exists := nodes[address]
+		if !exists {
+			node = &NodeSmoothingPoolAudit{
+				Address:           address,
+				Score:             big.NewInt(0),
+				SmoothingPoolEth:  big.NewInt(0),
+				MinipoolEthEarned: big.NewInt(0),
+				Flags:             []string{},
+			}
+			nodes[address] = node
+		}
+		return node
+	}
+	for _, address := range minipoolAddresses {
+		performance, _ := performanceFile.GetSmoothingPoolPerformance(address)
+		mpd, exists := networkState.MinipoolDetailsByAddress[address]
+		if !exists {
+			audit.UnknownMinipools = append(audit.UnknownMinipools, address)
+			continue
+		}
+
+		node := getNode(mpd.NodeAddress)
+		node.MinipoolCount++
+		node.SuccessfulAttestations += performance.GetSuccessfulAttestationCount()
+		node.MissedAttestations += performance.GetMissedAttestationCount()
+		if useScores {
+			node.Score.Add(node.Score, performance.GetAttestationScore())
+		} else {
+			node.Score.Add(node.Score, big.NewInt(0).SetUint64(performance.GetSuccessfulAttestationCount()))
+		}
+		if performance.GetEthEarned() != nil {
+			node.MinipoolEthEarned.Add(node.MinipoolEthEarned, performance.GetEthEarned())
+		}
+		if mpd.PenaltyCount != nil && mpd.PenaltyCount.Uint64() >= SmoothingPoolAuditPenaltyThreshold {
+			node.PenalizedMinipoolCount++
+		}
+	}
+
+	// Add the ETH each node received, including nodes that received ETH without any recorded performance
+	for _, address := range rewardsFile.GetNodeAddresses() {
+		rewardsInfo, exists := rewardsFile.GetNodeRewardsInfo(address)
+		if !exists || rewardsInfo.GetSmoothingPoolEth() == nil || rewardsInfo.GetSmoothingPoolEth().Sign() == 0 {
+			continue
+		}
+		node := getNode(address)
+		node.SmoothingPoolEth.Set(&rewardsInfo.GetSmoothingPoolEth().Int)
+	}
+	for _, node := range nodes {
+		audit.TotalEth.Add(audit.TotalEth, node.SmoothingPoolEth)
+		audit.TotalScore.Add(audit.TotalScore, node.Score)
+	}
+
+	// Get each node's share of the ETH and of the score
+	ratios := []float64{}
+	for _, node := range nodes {
+		attestations := node.SuccessfulAttestations + node.MissedAttestations
+		if attestations > 0 {
+			node.ParticipationRate = float64(node.SuccessfulAttestations) / float64(attestations)
+		}
+		if audit.TotalEth.Sign() > 0 {
+			node.EthShare, _ = big.NewRat(0, 1).SetFrac(node.SmoothingPoolEth, audit.TotalEth).Float64()
+		}
+		if audit.TotalScore.Sign() > 0 {
+			node.ScoreShare, _ = big.NewRat(0, 1).SetFrac(node.Score, audit.TotalScore).Float64()
+		}
+		if node.MinipoolCount > 0 && node.ScoreShare > 0 {
+			node.ShareRatio = node.EthShare / node.ScoreShare
+			ratios = append(ratios, node.ShareRatio)
+		}
+	}
+
+	// Use the median and median absolute deviation of the ratios as the baseline, so a handful of outliers can't skew it
+	audit.MedianShareRatio = getMedian(ratios)
+	deviations := make([]float64, len(ratios))
+	for i, ratio := range ratios {
+		deviations[i] = math.Abs(ratio - audit.MedianShareRatio)
+	}
+	audit.ShareRatioMad = getMedian(deviations) * madScaleFactor
+	maxDeviation := math.Max(audit.ShareRatioMad*SmoothingPoolAuditOutlierThreshold, audit.MedianShareRatio*SmoothingPoolAuditShareTolerance)
+
+	// Flag the nodes that don't line up
+	for _, node := range nodes {
+		if node.MinipoolCount == 0 {
+			node.Flags = append(node.Flags, "received Smoothing Pool ETH without any recorded minipool performance")
+		} else if node.ScoreShare > 0 {
+			if math.Abs(node.ShareRatio-audit.MedianShareRatio) > maxDeviation {
+				node.Flags = append(node.Flags, fmt.Sprintf("received %.4f%% of the pool's ETH but has %.4f%% of the %s (a ratio of %.4f against the pool's median of %.4f)", node.EthShare*100, node.ScoreShare*100, audit.ScoreBasis, node.ShareRatio, audit.MedianShareRatio))
+			}
+		} else if node.SmoothingPoolEth.Sign() > 0 {
+			node.Flags = append(node.Flags, fmt.Sprintf("received Smoothing Pool ETH with no %s", audit.ScoreBasis))
+		}
+		if node.PenalizedMinipoolCount > 0 && node.SmoothingPoolEth.Sign() > 0 {
+			node.Flags = append(node.Flags, fmt.Sprintf("has %d minipool(s) with %d or more penalties but still received Smoothing Pool ETH", node.PenalizedMinipoolCount, SmoothingPoolAuditPenaltyThreshold))
+		}
+		if node.MinipoolCount > 0 && node.SmoothingPoolEth.Sign() > 0 {
+			// Older performance files store the ETH earned as a float, so allow for some rounding
+			difference := big.NewInt(0).Sub(node.MinipoolEthEarned, node.SmoothingPoolEth)
+			drift, _ := big.NewRat(0, 1).SetFrac(difference.Abs(difference), node.SmoothingPoolEth).Float64()
+			if drift > SmoothingPoolAuditShareTolerance {
+				node.Flags = append(node.Flags, "the ETH earned by its minipools doesn't add up to the ETH it received")
+			}
+		}
+
+		if len(node.Flags) > 0 {
+			audit.FlaggedNodes++
+		}
+		audit.Nodes = append(audit.Nodes, node)
+	}
+
+	sort.Slice(audit.Nodes, func(i, j int) bool {
+		return bytes.Compare(audit.Nodes[i].Address[:], audit.Nodes[j].Address[:]) < 0
+	})
+	sort.Slice(audit.UnknownMinipools, func(i, j int) bool {
+		return bytes.Compare(audit.UnknownMinipools[i][:], audit.UnknownMinipools[j][:]) < 0
+	})
+	return audit, nil
+}
+
+// Get the median of a set of values, or 0 if there aren't any
+func getMedian(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
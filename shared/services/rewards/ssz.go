@@ -0,0 +1,204 @@
+package rewards
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// Settings
+const (
+	sszOffsetSize  int = 4
+	sszUint64Size  int = 8
+	sszUint256Size int = 32
+)
+
+// Builds an SSZ container, writing the fixed-size fields in place and collecting the variable-size ones behind offsets
+type sszEncoder struct {
+	fixed           []byte
+	variable        [][]byte
+	offsetPositions []int
+}
+
+func (e *sszEncoder) putUint64(value uint64) {
+	e.fixed = binary.LittleEndian.AppendUint64(e.fixed, value)
+}
+
+func (e *sszEncoder) putFixedBytes(value []byte) {
+	e.fixed = append(e.fixed, value...)
+}
+
+func (e *sszEncoder) putUint256(value *big.Int) error {
+	encoded, err := encodeSszUint256(value)
+	if err != nil {
+		return err
+	}
+	e.fixed = append(e.fixed, encoded...)
+	return nil
+}
+
+func (e *sszEncoder) putVariable(value []byte) {
+	e.offsetPositions = append(e.offsetPositions, len(e.fixed))
+	e.fixed = append(e.fixed, make([]byte, sszOffsetSize)...)
+	e.variable = append(e.variable, value)
+}
+
+// Fills in the offsets and returns the encoded container
+func (e *sszEncoder) finish() []byte {
+	size := len(e.fixed)
+	for _, value := range e.variable {
+		size += len(value)
+	}
+	encoded := make([]byte, 0, size)
+	encoded = append(encoded, e.fixed...)
+
+	offset := len(e.fixed)
+	for i, value := range e.variable {
+		binary.LittleEndian.PutUint32(encoded[e.offsetPositions[i]:], uint32(offset))
+		encoded = append(encoded, value...)
+		offset += len(value)
+	}
+	return encoded
+}
+
+// Encodes a list of variable-size elements: an offset for each element followed by the elements themselves
+func encodeSszVariableList(elements [][]byte) []byte {
+	encoder := &sszEncoder{}
+	for _, element := range elements {
+		encoder.putVariable(element)
+	}
+	return encoder.finish()
+}
+
+// Encodes an unsigned integer as a little-endian uint256
+func encodeSszUint256(value *big.Int) ([]byte, error) {
+	encoded := make([]byte, sszUint256Size)
+	if value == nil {
+		return encoded, nil
+	}
+	if value.Sign() < 0 || value.BitLen() > 256 {
+		return nil, fmt.Errorf("value %s does not fit in a uint256", value.String())
+	}
+	value.FillBytes(encoded)
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return encoded, nil
+}
+
+// Reads an SSZ container; fixed-size fields are read in order, then the variable-size ones can be retrieved by index
+type sszDecoder struct {
+	data    []byte
+	pos     int
+	offsets []int
+}
+
+func newSszDecoder(data []byte) *sszDecoder {
+	return &sszDecoder{
+		data: data,
+	}
+}
+
+func (d *sszDecoder) readFixedBytes(size int) ([]byte, error) {
+	if d.pos+size > len(d.data) {
+		return nil, fmt.Errorf("unexpected end of data at byte %d", d.pos)
+	}
+	value := d.data[d.pos : d.pos+size]
+	d.pos += size
+	return value, nil
+}
+
+func (d *sszDecoder) readUint64() (uint64, error) {
+	value, err := d.readFixedBytes(sszUint64Size)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(value), nil
+}
+
+func (d *sszDecoder) readUint256() (*big.Int, error) {
+	value, err := d.readFixedBytes(sszUint256Size)
+	if err != nil {
+		return nil, err
+	}
+	bigEndian := make([]byte, sszUint256Size)
+	for i := range value {
+		bigEndian[sszUint256Size-1-i] = value[i]
+	}
+	return big.NewInt(0).SetBytes(bigEndian), nil
+}
+
+func (d *sszDecoder) readOffset() error {
+	value, err := d.readFixedBytes(sszOffsetSize)
+	if err != nil {
+		return err
+	}
+	offset := int(binary.LittleEndian.Uint32(value))
+	if offset > len(d.data) {
+		return fmt.Errorf("offset %d is past the end of the data", offset)
+	}
+	if len(d.offsets) > 0 && offset < d.offsets[len(d.offsets)-1] {
+		return fmt.Errorf("offset %d is before the previous offset", offset)
+	}
+	d.offsets = append(d.offsets, offset)
+	return nil
+}
+
+// Get the bytes of the variable-size field with the given index, once the fixed part has been read
+func (d *sszDecoder) variable(index int) ([]byte, error) {
+	if index >= len(d.offsets) {
+		return nil, fmt.Errorf("variable field %d does not exist", index)
+	}
+	if index == 0 && d.offsets[0] != d.pos {
+		return nil, fmt.Errorf("first offset %d does not match the fixed part size %d", d.offsets[0], d.pos)
+	}
+	end := len(d.data)
+	if index+1 < len(d.offsets) {
+		end = d.offsets[index+1]
+	}
+	return d.data[d.offsets[index]:end], nil
+}
+
+// Splits a list of fixed-size elements
+func decodeSszFixedList(data []byte, elementSize int) ([][]byte, error) {
+	if len(data)%elementSize != 0 {
+		return nil, fmt.Errorf("list size %d is not a multiple of the element size %d", len(data), elementSize)
+	}
+	elements := make([][]byte, len(data)/elementSize)
+	for i := range elements {
+		elements[i] = data[i*elementSize : (i+1)*elementSize]
+	}
+	return elements, nil
+}
+
+// Splits a list of variable-size elements
+func decodeSszVariableList(data []byte) ([][]byte, error) {
+	if len(data) == 0 {
+		return [][]byte{}, nil
+	}
+	if len(data) < sszOffsetSize {
+		return nil, fmt.Errorf("list is too small to hold an offset")
+	}
+	firstOffset := int(binary.LittleEndian.Uint32(data))
+	if firstOffset%sszOffsetSize != 0 || firstOffset == 0 {
+		return nil, fmt.Errorf("invalid first list offset %d", firstOffset)
+	}
+
+	decoder := newSszDecoder(data)
+	count := firstOffset / sszOffsetSize
+	for i := 0; i < count; i++ {
+		err := decoder.readOffset()
+		if err != nil {
+			return nil, fmt.Errorf("error reading list offset %d: %w", i, err)
+		}
+	}
+	elements := make([][]byte, count)
+	for i := range elements {
+		element, err := decoder.variable(i)
+		if err != nil {
+			return nil, err
+		}
+		elements[i] = element
+	}
+	return elements, nil
+}
@@ -0,0 +1,465 @@
+package rewards
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// How often memory usage is sampled while profiling
+const treegenMemorySampleInterval time.Duration = 250 * time.Millisecond
+
+// The measurements for a single stage of tree generation
+type TreegenStageProfile struct {
+	Name          string        `json:"name"`
+	Duration      time.Duration `json:"duration"`
+	EcCalls       uint64        `json:"ecCalls"`
+	BcCalls       uint64        `json:"bcCalls"`
+	PeakHeapBytes uint64        `json:"peakHeapBytes"`
+}
+
+// Measures the stages of a tree generation run, along with the client calls and memory they use.
+// Clients wrapped by the profiler refuse to send transactions, so nothing can be submitted during a dry run.
+type TreegenProfiler struct {
+	stages       []*TreegenStageProfile
+	currentStage *TreegenStageProfile
+	stageStart   time.Time
+	stageEcStart uint64
+	stageBcStart uint64
+	start        time.Time
+
+	callLock     sync.Mutex
+	ecCalls      map[string]uint64
+	bcCalls      map[string]uint64
+	totalEcCalls uint64
+	totalBcCalls uint64
+
+	memoryLock   sync.Mutex
+	stagePeak    uint64
+	overallPeak  uint64
+	stopSampling chan struct{}
+	samplingDone sync.WaitGroup
+}
+
+// Create a new profiler and start sampling memory usage
+func NewTreegenProfiler() *TreegenProfiler {
+	p := &TreegenProfiler{
+		stages:       []*TreegenStageProfile{},
+		start:        time.Now(),
+		ecCalls:      map[string]uint64{},
+		bcCalls:      map[string]uint64{},
+		stopSampling: make(chan struct{}),
+	}
+
+	p.samplingDone.Add(1)
+	go func() {
+		defer p.samplingDone.Done()
+		ticker := time.NewTicker(treegenMemorySampleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stopSampling:
+				return
+			case <-ticker.C:
+				p.sampleMemory()
+			}
+		}
+	}()
+
+	return p
+}
+
+// Wrap an Execution client so its calls are counted
+func (p *TreegenProfiler) WrapExecutionClient(ec rocketpool.ExecutionClient) rocketpool.ExecutionClient {
+	return &profiledExecutionClient{
+		ec:       ec,
+		profiler: p,
+	}
+}
+
+// Wrap a Beacon client so its calls are counted
+func (p *TreegenProfiler) WrapBeaconClient(bc beacon.Client) beacon.Client {
+	return &profiledBeaconClient{
+		bc:       bc,
+		profiler: p,
+	}
+}
+
+// Start measuring a new stage, ending the current one if there is one
+func (p *TreegenProfiler) StartStage(name string) {
+	p.EndStage()
+	p.sampleMemory()
+
+	p.callLock.Lock()
+	p.stageEcStart = p.totalEcCalls
+	p.stageBcStart = p.totalBcCalls
+	p.callLock.Unlock()
+
+	p.memoryLock.Lock()
+	p.stagePeak = 0
+	p.memoryLock.Unlock()
+
+	p.currentStage = &TreegenStageProfile{
+		Name: name,
+	}
+	p.stageStart = time.Now()
+}
+
+// End the current stage
+func (p *TreegenProfiler) EndStage() {
+	if p.currentStage == nil {
+		return
+	}
+	p.sampleMemory()
+
+	p.currentStage.Duration = time.Since(p.stageStart)
+	p.callLock.Lock()
+	p.currentStage.EcCalls = p.totalEcCalls - p.stageEcStart
+	p.currentStage.BcCalls = p.totalBcCalls - p.stageBcStart
+	p.callLock.Unlock()
+	p.memoryLock.Lock()
+	p.currentStage.PeakHeapBytes = p.stagePeak
+	p.memoryLock.Unlock()
+
+	p.stages = append(p.stages, p.currentStage)
+	p.currentStage = nil
+}
+
+// End the current stage and stop sampling memory usage
+func (p *TreegenProfiler) Stop() {
+	p.EndStage()
+	select {
+	case <-p.stopSampling:
+	default:
+		close(p.stopSampling)
+	}
+	p.samplingDone.Wait()
+}
+
+// Get the measurements of each finished stage
+func (p *TreegenProfiler) GetStages() []*TreegenStageProfile {
+	return p.stages
+}
+
+// Build a human-readable summary of the profile, one line per entry
+func (p *TreegenProfiler) GetReport() []string {
+	lines := []string{}
+	for _, stage := range p.stages {
+		lines = append(lines, fmt.Sprintf("%-32s %12s  %6d EC calls  %6d BC calls  peak heap %s", stage.Name, stage.Duration.Round(time.Millisecond), stage.EcCalls, stage.BcCalls, formatBytes(stage.PeakHeapBytes)))
+	}
+
+	p.callLock.Lock()
+	defer p.callLock.Unlock()
+	p.memoryLock.Lock()
+	defer p.memoryLock.Unlock()
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	lines = append(lines, fmt.Sprintf("Total: %s, %d EC calls, %d BC calls, peak heap %s, memory obtained from the OS %s", time.Since(p.start).Round(time.Millisecond), p.totalEcCalls, p.totalBcCalls, formatBytes(p.overallPeak), formatBytes(memStats.Sys)))
+	lines = append(lines, "EC calls by method: "+formatCallCounts(p.ecCalls))
+	lines = append(lines, "BC calls by method: "+formatCallCounts(p.bcCalls))
+	return lines
+}
+
+// Record the current heap size if it's a new peak
+func (p *TreegenProfiler) sampleMemory() {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	p.memoryLock.Lock()
+	defer p.memoryLock.Unlock()
+	if memStats.HeapAlloc > p.stagePeak {
+		p.stagePeak = memStats.HeapAlloc
+	}
+	if memStats.HeapAlloc > p.overallPeak {
+		p.overallPeak = memStats.HeapAlloc
+	}
+}
+
+// Count a call to the Execution client
+func (p *TreegenProfiler) countEcCall(method string) {
+	p.callLock.Lock()
+	defer p.callLock.Unlock()
+	p.ecCalls[method]++
+	p.totalEcCalls++
+}
+
+// Count a call to the Beacon client
+func (p *TreegenProfiler) countBcCall(method string) {
+	p.callLock.Lock()
+	defer p.callLock.Unlock()
+	p.bcCalls[method]++
+	p.totalBcCalls++
+}
+
+// Format a byte count with binary units
+func formatBytes(bytes uint64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := uint64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// Format per-method call counts, busiest first
+func formatCallCounts(counts map[string]uint64) string {
+	if len(counts) == 0 {
+		return "none"
+	}
+	methods := make([]string, 0, len(counts))
+	for method := range counts {
+		methods = append(methods, method)
+	}
+	sort.Slice(methods, func(i, j int) bool {
+		if counts[methods[i]] == counts[methods[j]] {
+			return methods[i] < methods[j]
+		}
+		return counts[methods[i]] > counts[methods[j]]
+	})
+	entries := make([]string, len(methods))
+	for i, method := range methods {
+		entries[i] = fmt.Sprintf("%s=%d", method, counts[method])
+	}
+	return strings.Join(entries, ", ")
+}
+
+// An Execution client that counts its calls and refuses to send transactions
+type profiledExecutionClient struct {
+	ec       rocketpool.ExecutionClient
+	profiler *TreegenProfiler
+}
+
+func (c *profiledExecutionClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	c.profiler.countEcCall("CodeAt")
+	return c.ec.CodeAt(ctx, contract, blockNumber)
+}
+
+func (c *profiledExecutionClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.profiler.countEcCall("CallContract")
+	return c.ec.CallContract(ctx, call, blockNumber)
+}
+
+func (c *profiledExecutionClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	c.profiler.countEcCall("HeaderByHash")
+	return c.ec.HeaderByHash(ctx, hash)
+}
+
+func (c *profiledExecutionClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.profiler.countEcCall("HeaderByNumber")
+	return c.ec.HeaderByNumber(ctx, number)
+}
+
+func (c *profiledExecutionClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	c.profiler.countEcCall("PendingCodeAt")
+	return c.ec.PendingCodeAt(ctx, account)
+}
+
+func (c *profiledExecutionClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	c.profiler.countEcCall("PendingNonceAt")
+	return c.ec.PendingNonceAt(ctx, account)
+}
+
+func (c *profiledExecutionClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	c.profiler.countEcCall("SuggestGasPrice")
+	return c.ec.SuggestGasPrice(ctx)
+}
+
+func (c *profiledExecutionClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	c.profiler.countEcCall("SuggestGasTipCap")
+	return c.ec.SuggestGasTipCap(ctx)
+}
+
+func (c *profiledExecutionClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	c.profiler.countEcCall("EstimateGas")
+	return c.ec.EstimateGas(ctx, call)
+}
+
+func (c *profiledExecutionClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return fmt.Errorf("sending transactions is disabled during a dry run")
+}
+
+func (c *profiledExecutionClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	c.profiler.countEcCall("FilterLogs")
+	return c.ec.FilterLogs(ctx, query)
+}
+
+func (c *profiledExecutionClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	c.profiler.countEcCall("SubscribeFilterLogs")
+	return c.ec.SubscribeFilterLogs(ctx, query, ch)
+}
+
+func (c *profiledExecutionClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	c.profiler.countEcCall("TransactionReceipt")
+	return c.ec.TransactionReceipt(ctx, txHash)
+}
+
+func (c *profiledExecutionClient) BlockNumber(ctx context.Context) (uint64, error) {
+	c.profiler.countEcCall("BlockNumber")
+	return c.ec.BlockNumber(ctx)
+}
+
+func (c *profiledExecutionClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	c.profiler.countEcCall("BalanceAt")
+	return c.ec.BalanceAt(ctx, account, blockNumber)
+}
+
+func (c *profiledExecutionClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	c.profiler.countEcCall("TransactionByHash")
+	return c.ec.TransactionByHash(ctx, hash)
+}
+
+func (c *profiledExecutionClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	c.profiler.countEcCall("NonceAt")
+	return c.ec.NonceAt(ctx, account, blockNumber)
+}
+
+func (c *profiledExecutionClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	c.profiler.countEcCall("SyncProgress")
+	return c.ec.SyncProgress(ctx)
+}
+
+func (c *profiledExecutionClient) GetActiveUrl() string {
+	if provider, ok := c.ec.(activeUrlProvider); ok {
+		return provider.GetActiveUrl()
+	}
+	return ""
+}
+
+func (c *profiledExecutionClient) ClientVersion(ctx context.Context) (string, error) {
+	if provider, ok := c.ec.(executionClientVersionProvider); ok {
+		c.profiler.countEcCall("ClientVersion")
+		return provider.ClientVersion(ctx)
+	}
+	return "", fmt.Errorf("client version is not available")
+}
+
+// A Beacon client that counts its calls and refuses to submit anything to the chain
+type profiledBeaconClient struct {
+	bc       beacon.Client
+	profiler *TreegenProfiler
+}
+
+func (c *profiledBeaconClient) GetClientType() (beacon.BeaconClientType, error) {
+	return c.bc.GetClientType()
+}
+
+func (c *profiledBeaconClient) GetSyncStatus() (beacon.SyncStatus, error) {
+	c.profiler.countBcCall("GetSyncStatus")
+	return c.bc.GetSyncStatus()
+}
+
+func (c *profiledBeaconClient) GetNodeVersion() (beacon.NodeVersion, error) {
+	c.profiler.countBcCall("GetNodeVersion")
+	return c.bc.GetNodeVersion()
+}
+
+func (c *profiledBeaconClient) GetEth2Config() (beacon.Eth2Config, error) {
+	c.profiler.countBcCall("GetEth2Config")
+	return c.bc.GetEth2Config()
+}
+
+func (c *profiledBeaconClient) GetEth2DepositContract() (beacon.Eth2DepositContract, error) {
+	c.profiler.countBcCall("GetEth2DepositContract")
+	return c.bc.GetEth2DepositContract()
+}
+
+func (c *profiledBeaconClient) GetAttestations(blockId string) ([]beacon.AttestationInfo, bool, error) {
+	c.profiler.countBcCall("GetAttestations")
+	return c.bc.GetAttestations(blockId)
+}
+
+func (c *profiledBeaconClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
+	c.profiler.countBcCall("GetBeaconBlock")
+	return c.bc.GetBeaconBlock(blockId)
+}
+
+func (c *profiledBeaconClient) GetBeaconHead() (beacon.BeaconHead, error) {
+	c.profiler.countBcCall("GetBeaconHead")
+	return c.bc.GetBeaconHead()
+}
+
+func (c *profiledBeaconClient) GetValidatorStatusByIndex(index string, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	c.profiler.countBcCall("GetValidatorStatusByIndex")
+	return c.bc.GetValidatorStatusByIndex(index, opts)
+}
+
+func (c *profiledBeaconClient) GetValidatorStatus(pubkey rptypes.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	c.profiler.countBcCall("GetValidatorStatus")
+	return c.bc.GetValidatorStatus(pubkey, opts)
+}
+
+func (c *profiledBeaconClient) GetValidatorStatuses(pubkeys []rptypes.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[rptypes.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	c.profiler.countBcCall("GetValidatorStatuses")
+	return c.bc.GetValidatorStatuses(pubkeys, opts)
+}
+
+func (c *profiledBeaconClient) GetValidatorIndex(pubkey rptypes.ValidatorPubkey) (string, error) {
+	c.profiler.countBcCall("GetValidatorIndex")
+	return c.bc.GetValidatorIndex(pubkey)
+}
+
+func (c *profiledBeaconClient) GetValidatorSyncDuties(indices []string, epoch uint64) (map[string]bool, error) {
+	c.profiler.countBcCall("GetValidatorSyncDuties")
+	return c.bc.GetValidatorSyncDuties(indices, epoch)
+}
+
+func (c *profiledBeaconClient) GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error) {
+	c.profiler.countBcCall("GetValidatorProposerDuties")
+	return c.bc.GetValidatorProposerDuties(indices, epoch)
+}
+
+func (c *profiledBeaconClient) GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error) {
+	c.profiler.countBcCall("GetDomainData")
+	return c.bc.GetDomainData(domainType, epoch, useGenesisFork)
+}
+
+func (c *profiledBeaconClient) ExitValidator(validatorIndex string, epoch uint64, signature rptypes.ValidatorSignature) error {
+	return fmt.Errorf("exiting validators is disabled during a dry run")
+}
+
+func (c *profiledBeaconClient) GetExitQueueLength() (uint64, error) {
+	c.profiler.countBcCall("GetExitQueueLength")
+	return c.bc.GetExitQueueLength()
+}
+
+func (c *profiledBeaconClient) Close() error {
+	return c.bc.Close()
+}
+
+func (c *profiledBeaconClient) GetEth1DataForEth2Block(blockId string) (beacon.Eth1Data, bool, error) {
+	c.profiler.countBcCall("GetEth1DataForEth2Block")
+	return c.bc.GetEth1DataForEth2Block(blockId)
+}
+
+func (c *profiledBeaconClient) GetCommitteesForEpoch(epoch *uint64) (beacon.Committees, error) {
+	c.profiler.countBcCall("GetCommitteesForEpoch")
+	return c.bc.GetCommitteesForEpoch(epoch)
+}
+
+func (c *profiledBeaconClient) ChangeWithdrawalCredentials(validatorIndex string, fromBlsPubkey rptypes.ValidatorPubkey, toExecutionAddress common.Address, signature rptypes.ValidatorSignature) error {
+	return fmt.Errorf("changing withdrawal credentials is disabled during a dry run")
+}
+
+func (c *profiledBeaconClient) GetActiveUrl() string {
+	if provider, ok := c.bc.(activeUrlProvider); ok {
+		return provider.GetActiveUrl()
+	}
+	return ""
+}
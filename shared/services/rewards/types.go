@@ -79,6 +79,7 @@ type ISmoothingPoolMinipoolPerformance interface {
 	GetMissedAttestationCount() uint64
 	GetMissingAttestationSlots() []uint64
 	GetEthEarned() *big.Int
+	GetAttestationScore() *big.Int
 }
 
 // Interface for version-agnostic node operator rewards
@@ -113,6 +114,7 @@ type RewardsFileHeader struct {
 	MinipoolPerformanceFileCID string                         `json:"minipoolPerformanceFileCid,omitempty"`
 	TotalRewards               *TotalRewards                  `json:"totalRewards"`
 	NetworkRewards             map[uint64]*NetworkRewardsInfo `json:"networkRewards"`
+	Provenance                 *RewardsFileProvenance         `json:"provenance,omitempty"`
 
 	// Non-serialized fields
 	MerkleTree          *merkletree.MerkleTree    `json:"-"`
@@ -124,8 +126,10 @@ type IntervalInfo struct {
 	Index                  uint64        `json:"index"`
 	TreeFilePath           string        `json:"treeFilePath"`
 	TreeFileExists         bool          `json:"treeFileExists"`
+	Pruned                 bool          `json:"pruned"`
 	MerkleRootValid        bool          `json:"merkleRootValid"`
 	CID                    string        `json:"cid"`
+	MerkleRoot             common.Hash   `json:"merkleRoot"`
 	StartTime              time.Time     `json:"startTime"`
 	EndTime                time.Time     `json:"endTime"`
 	NodeExists             bool          `json:"nodeExists"`
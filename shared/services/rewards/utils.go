@@ -8,7 +8,6 @@ import (
 	"math/big"
 	"net/http"
 	"os"
-	"path/filepath"
 	"strings"
 	"testing/fstest"
 	"time"
@@ -108,16 +107,34 @@ func GetIntervalInfo(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, no
 	}
 
 	info.CID = event.MerkleTreeCID
+	info.MerkleRoot = event.MerkleRoot
 	info.StartTime = event.IntervalStartTime
 	info.EndTime = event.IntervalEndTime
 	merkleRootCanon := event.MerkleRoot
 
-	// Check if the tree file exists
-	info.TreeFilePath = cfg.Smartnode.GetRewardsTreePath(interval, true)
+	// Check if the tree file exists, preferring the SSZ copy since it loads much faster
+	info.TreeFilePath = cfg.Smartnode.GetRewardsTreeSszPath(interval, true)
 	_, err = os.Stat(info.TreeFilePath)
+	if os.IsNotExist(err) {
+		info.TreeFilePath = cfg.Smartnode.GetRewardsTreePath(interval, true)
+		_, err = os.Stat(info.TreeFilePath)
+	}
 	if os.IsNotExist(err) {
 		info.TreeFileExists = false
 		err = nil
+
+		// Fall back to the pruned record if the files were pruned
+		var record *PrunedIntervalRecord
+		record, err = LoadPrunedIntervalRecord(cfg, interval)
+		if err != nil || record == nil || record.NodeAddress != nodeAddress {
+			return
+		}
+		info.Pruned = true
+		info.MerkleRootValid = true
+		info.NodeExists = record.NodeExists
+		info.CollateralRplAmount = record.CollateralRplAmount
+		info.ODaoRplAmount = record.ODaoRplAmount
+		info.SmoothingPoolEthAmount = record.SmoothingPoolEthAmount
 		return
 	}
 	info.TreeFileExists = true
@@ -257,71 +274,160 @@ func GetELBlockHeaderForTime(targetTime time.Time, rp *rocketpool.RocketPool) (*
 }
 
 // Downloads a single rewards file
-func DownloadRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid string, isDaemon bool) error {
+func DownloadRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid string, merkleRoot common.Hash, isDaemon bool) error {
 
 	// Determine file name and path
 	rewardsTreePath, err := homedir.Expand(cfg.Smartnode.GetRewardsTreePath(interval, isDaemon))
 	if err != nil {
 		return fmt.Errorf("error expanding rewards tree path: %w", err)
 	}
-	rewardsTreeFilename := filepath.Base(rewardsTreePath)
-	ipfsFilename := rewardsTreeFilename + config.RewardsTreeIpfsExtension
 
-	// Create URL list
-	urls := []string{
-		fmt.Sprintf(config.PrimaryRewardsFileUrl, cid, ipfsFilename),
-		fmt.Sprintf(config.SecondaryRewardsFileUrl, cid, ipfsFilename),
-		fmt.Sprintf(config.GithubRewardsFileUrl, string(cfg.Smartnode.Network.Value.(cfgtypes.Network)), rewardsTreeFilename),
+	// Download it
+	bytes, err := FetchRewardsFile(cfg, interval, cid, merkleRoot)
+	if err != nil {
+		return err
+	}
+
+	// Write the file
+	err = os.WriteFile(rewardsTreePath, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error saving interval %d file to %s: %w", interval, rewardsTreePath, err)
+	}
+
+	// Save an SSZ copy if requested
+	if cfg.Smartnode.SaveSszRewardsFiles.Value.(bool) {
+		rewardsFile, err := DeserializeRewardsFile(bytes)
+		if err != nil {
+			return fmt.Errorf("error deserializing interval %d file: %w", interval, err)
+		}
+		err = SaveSszRewardsFiles(cfg, interval, rewardsFile, false, isDaemon)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+
+}
+
+// Saves SSZ copies of a rewards file, and optionally its minipool performance file, next to the JSON versions.
+// Only v2 files have an SSZ encoding, so older files are skipped.
+func SaveSszRewardsFiles(cfg *config.RocketPoolConfig, interval uint64, rewardsFile IRewardsFile, includePerformance bool, isDaemon bool) error {
+	file, ok := rewardsFile.(*RewardsFile_v2)
+	if !ok {
+		return nil
+	}
+
+	if includePerformance {
+		minipoolPerformanceBytes, err := file.MinipoolPerformanceFile.SerializeSSZ()
+		if err != nil {
+			return fmt.Errorf("error serializing interval %d minipool performance file into SSZ: %w", interval, err)
+		}
+		minipoolPerformancePath, err := homedir.Expand(cfg.Smartnode.GetMinipoolPerformanceSszPath(interval, isDaemon))
+		if err != nil {
+			return fmt.Errorf("error expanding minipool performance SSZ path: %w", err)
+		}
+		err = os.WriteFile(minipoolPerformancePath, minipoolPerformanceBytes, 0644)
+		if err != nil {
+			return fmt.Errorf("error saving interval %d minipool performance SSZ file to %s: %w", interval, minipoolPerformancePath, err)
+		}
+	}
+
+	rewardsBytes, err := file.SerializeSSZ()
+	if err != nil {
+		return fmt.Errorf("error serializing interval %d rewards file into SSZ: %w", interval, err)
+	}
+	rewardsTreePath, err := homedir.Expand(cfg.Smartnode.GetRewardsTreeSszPath(interval, isDaemon))
+	if err != nil {
+		return fmt.Errorf("error expanding rewards tree SSZ path: %w", err)
+	}
+	err = os.WriteFile(rewardsTreePath, rewardsBytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error saving interval %d SSZ file to %s: %w", interval, rewardsTreePath, err)
 	}
+	return nil
+}
+
+// Downloads a single rewards file and returns its decompressed contents without saving it.
+// Each of the configured mirrors is tried in order until one provides a file matching the provided Merkle root.
+func FetchRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid string, merkleRoot common.Hash) ([]byte, error) {
 
 	// Attempt downloads
 	errBuilder := strings.Builder{}
-	for _, url := range urls {
-		resp, err := http.Get(url)
+	for _, url := range cfg.Smartnode.GetRewardsFileMirrorUrls(interval, cid) {
+		bytes, err := downloadRewardsFileFromMirror(url)
 		if err != nil {
 			errBuilder.WriteString(fmt.Sprintf("Downloading %s failed (%s)\n", url, err.Error()))
 			continue
 		}
-		defer resp.Body.Close()
 
-		if resp.StatusCode != http.StatusOK {
-			errBuilder.WriteString(fmt.Sprintf("Downloading %s failed with status %s\n", url, resp.Status))
+		// Make sure it's the canonical file
+		err = verifyRewardsFileBytes(bytes, merkleRoot)
+		if err != nil {
+			errBuilder.WriteString(fmt.Sprintf("The file from %s failed verification (%s)\n", url, err.Error()))
 			continue
-		} else {
-			// If we got here, we have a successful download
-			bytes, err := io.ReadAll(resp.Body)
-			if err != nil {
-				errBuilder.WriteString(fmt.Sprintf("Error reading response bytes from %s: %s\n", url, err.Error()))
-				continue
-			}
+		}
+		return bytes, nil
+	}
 
-			writeBytes := bytes
-			if strings.HasSuffix(url, config.RewardsTreeIpfsExtension) {
-				// Decompress it
-				writeBytes, err = decompressFile(bytes)
-				if err != nil {
-					errBuilder.WriteString(fmt.Sprintf("Error decompressing %s: %s\n", url, err.Error()))
-					continue
-				}
-			}
+	return nil, fmt.Errorf(errBuilder.String())
 
-			// Write the file
-			err = os.WriteFile(rewardsTreePath, writeBytes, 0644)
-			if err != nil {
-				return fmt.Errorf("error saving interval %d file to %s: %w", interval, rewardsTreePath, err)
-			}
-			return nil
+}
+
+// Downloads a rewards file from a single mirror, decompressing it if necessary
+func downloadRewardsFileFromMirror(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %s", resp.Status)
+	}
+	bytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response bytes: %w", err)
+	}
+
+	if strings.HasSuffix(url, config.RewardsTreeIpfsExtension) || isZstdCompressed(bytes) {
+		bytes, err = decompressFile(bytes)
+		if err != nil {
+			return nil, err
 		}
 	}
+	return bytes, nil
+}
 
-	return fmt.Errorf(errBuilder.String())
+// Checks that a downloaded rewards file has the expected Merkle root, both in its header and when its tree is rebuilt
+func verifyRewardsFileBytes(bytes []byte, merkleRoot common.Hash) error {
+	rewardsFile, err := DeserializeRewardsFile(bytes)
+	if err != nil {
+		return fmt.Errorf("error deserializing file: %w", err)
+	}
+	headerRoot := common.HexToHash(rewardsFile.GetHeader().MerkleRoot)
+	if headerRoot != merkleRoot {
+		return fmt.Errorf("file has Merkle root %s but the canonical root is %s", headerRoot.Hex(), merkleRoot.Hex())
+	}
+	computedRoot, err := ComputeMerkleRoot(rewardsFile)
+	if err != nil {
+		return err
+	}
+	if computedRoot != merkleRoot {
+		return fmt.Errorf("file's rewards produce Merkle root %s but the canonical root is %s", computedRoot.Hex(), merkleRoot.Hex())
+	}
+	return nil
+}
 
+// Check if a blob of data starts with the zstd frame header
+func isZstdCompressed(data []byte) bool {
+	zstdMagic := []byte{0x28, 0xb5, 0x2f, 0xfd}
+	return len(data) >= len(zstdMagic) && string(data[:len(zstdMagic)]) == string(zstdMagic)
 }
 
 // Get the IPFS CID for a blob of data
 func GetCidForRewardsFile(rewardsFile IRewardsFile, filename string) (cid.Cid, error) {
-	// Encode the rewards file in JSON
-	data, err := rewardsFile.Serialize()
+	// Encode the rewards file in JSON, without the local provenance
+	data, err := SerializeCanonicalRewardsFile(rewardsFile)
 	if err != nil {
 		return cid.Cid{}, fmt.Errorf("error serializing rewards file: %w", err)
 	}
@@ -406,6 +512,12 @@ func GetStartSlotForInterval(previousIntervalEvent rewards.RewardsEvent, bc beac
 
 // Deserializes a byte array into a rewards file interface
 func DeserializeRewardsFile(bytes []byte) (IRewardsFile, error) {
+	// SSZ files are always based on the v2 format
+	if IsSszRewardsFile(bytes) {
+		file := &RewardsFile_v2{}
+		return file, file.DeserializeSSZ(bytes)
+	}
+
 	var header RewardsFileHeader
 	err := json.Unmarshal(bytes, &header)
 	if err != nil {
@@ -426,6 +538,12 @@ func DeserializeRewardsFile(bytes []byte) (IRewardsFile, error) {
 
 // Deserializes a byte array into a rewards file interface
 func DeserializeMinipoolPerformanceFile(bytes []byte) (IMinipoolPerformanceFile, error) {
+	// SSZ files are always based on the v2 format
+	if IsSszMinipoolPerformanceFile(bytes) {
+		file := &MinipoolPerformanceFile_v2{}
+		return file, file.DeserializeSSZ(bytes)
+	}
+
 	var header VersionHeader
 	err := json.Unmarshal(bytes, &header)
 	if err != nil {
@@ -444,6 +562,44 @@ func DeserializeMinipoolPerformanceFile(bytes []byte) (IMinipoolPerformanceFile,
 	}
 }
 
+// Loads a rewards file from disk, decompressing it first if it's a compressed copy
+func LoadRewardsFile(path string) (IRewardsFile, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	if strings.HasSuffix(path, config.RewardsTreeIpfsExtension) {
+		fileBytes, err = decompressFile(fileBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing %s: %w", path, err)
+		}
+	}
+	rewardsFile, err := DeserializeRewardsFile(fileBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing %s: %w", path, err)
+	}
+	return rewardsFile, nil
+}
+
+// Loads a minipool performance file from disk, decompressing it first if it's a compressed copy
+func LoadMinipoolPerformanceFile(path string) (IMinipoolPerformanceFile, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	if strings.HasSuffix(path, config.RewardsTreeIpfsExtension) {
+		fileBytes, err = decompressFile(fileBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing %s: %w", path, err)
+		}
+	}
+	performanceFile, err := DeserializeMinipoolPerformanceFile(fileBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing %s: %w", path, err)
+	}
+	return performanceFile, nil
+}
+
 // Decompresses a rewards file
 func decompressFile(compressedBytes []byte) ([]byte, error) {
 	decoder, err := zstd.NewReader(nil)
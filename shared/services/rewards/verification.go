@@ -0,0 +1,81 @@
+package rewards
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// A disagreement between two rewards files about a single node's rewards
+type NodeRewardsDiff struct {
+	Address                   common.Address `json:"address"`
+	MissingFromLocal          bool           `json:"missingFromLocal"`
+	MissingFromCanonical      bool           `json:"missingFromCanonical"`
+	LocalRewardNetwork        uint64         `json:"localRewardNetwork"`
+	CanonicalRewardNetwork    uint64         `json:"canonicalRewardNetwork"`
+	LocalCollateralRpl        *big.Int       `json:"localCollateralRpl"`
+	CanonicalCollateralRpl    *big.Int       `json:"canonicalCollateralRpl"`
+	LocalOracleDaoRpl         *big.Int       `json:"localOracleDaoRpl"`
+	CanonicalOracleDaoRpl     *big.Int       `json:"canonicalOracleDaoRpl"`
+	LocalSmoothingPoolEth     *big.Int       `json:"localSmoothingPoolEth"`
+	CanonicalSmoothingPoolEth *big.Int       `json:"canonicalSmoothingPoolEth"`
+}
+
+// Compares the per-node rewards of a locally generated file against the canonical one, returning every node they disagree on
+// sorted by address
+func CompareRewardsFiles(local IRewardsFile, canonical IRewardsFile) []NodeRewardsDiff {
+	// Get the union of all node addresses
+	addresses := map[common.Address]bool{}
+	for _, address := range local.GetNodeAddresses() {
+		addresses[address] = true
+	}
+	for _, address := range canonical.GetNodeAddresses() {
+		addresses[address] = true
+	}
+
+	diffs := []NodeRewardsDiff{}
+	for address := range addresses {
+		localInfo, localExists := local.GetNodeRewardsInfo(address)
+		canonicalInfo, canonicalExists := canonical.GetNodeRewardsInfo(address)
+
+		diff := NodeRewardsDiff{
+			Address:                   address,
+			MissingFromLocal:          !localExists,
+			MissingFromCanonical:      !canonicalExists,
+			LocalCollateralRpl:        big.NewInt(0),
+			CanonicalCollateralRpl:    big.NewInt(0),
+			LocalOracleDaoRpl:         big.NewInt(0),
+			CanonicalOracleDaoRpl:     big.NewInt(0),
+			LocalSmoothingPoolEth:     big.NewInt(0),
+			CanonicalSmoothingPoolEth: big.NewInt(0),
+		}
+		if localExists {
+			diff.LocalRewardNetwork = localInfo.GetRewardNetwork()
+			diff.LocalCollateralRpl.Set(&localInfo.GetCollateralRpl().Int)
+			diff.LocalOracleDaoRpl.Set(&localInfo.GetOracleDaoRpl().Int)
+			diff.LocalSmoothingPoolEth.Set(&localInfo.GetSmoothingPoolEth().Int)
+		}
+		if canonicalExists {
+			diff.CanonicalRewardNetwork = canonicalInfo.GetRewardNetwork()
+			diff.CanonicalCollateralRpl.Set(&canonicalInfo.GetCollateralRpl().Int)
+			diff.CanonicalOracleDaoRpl.Set(&canonicalInfo.GetOracleDaoRpl().Int)
+			diff.CanonicalSmoothingPoolEth.Set(&canonicalInfo.GetSmoothingPoolEth().Int)
+		}
+
+		if localExists && canonicalExists &&
+			diff.LocalRewardNetwork == diff.CanonicalRewardNetwork &&
+			diff.LocalCollateralRpl.Cmp(diff.CanonicalCollateralRpl) == 0 &&
+			diff.LocalOracleDaoRpl.Cmp(diff.CanonicalOracleDaoRpl) == 0 &&
+			diff.LocalSmoothingPoolEth.Cmp(diff.CanonicalSmoothingPoolEth) == 0 {
+			continue
+		}
+		diffs = append(diffs, diff)
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return bytes.Compare(diffs[i].Address[:], diffs[j].Address[:]) < 0
+	})
+	return diffs
+}
@@ -0,0 +1,235 @@
+package rocketpool
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alessio/shellescape"
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Settings
+const BackupStagingFolder string = "backup-restore"
+
+// Archive the user settings and the given files from the data folder, piping the archive through an encryption command.
+// The archive is built with root privileges since the wallet files are owned by the daemons, but it's only ever written to disk after encryption.
+func (c *Client) CreateBackup(dataPath string, dataFiles []string, encryptCmd string) error {
+	rootCmd, err := c.getEscalationCommand()
+	if err != nil {
+		return fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+	configPath, err := homedir.Expand(c.configPath)
+	if err != nil {
+		return fmt.Errorf("error expanding settings path: %w", err)
+	}
+	dataPath, err = homedir.Expand(dataPath)
+	if err != nil {
+		return fmt.Errorf("error expanding data path: %w", err)
+	}
+
+	// Get the escalated permissions up front so the escalation prompt doesn't collide with the encryption prompt
+	if err := c.printOutput(fmt.Sprintf("%s true", rootCmd)); err != nil {
+		return fmt.Errorf("error getting root privileges: %w", err)
+	}
+
+	quotedFiles := make([]string, len(dataFiles))
+	for i, file := range dataFiles {
+		quotedFiles[i] = shellescape.Quote(file)
+	}
+	tarCmdText := fmt.Sprintf("%s tar -czf - -C %s %s -C %s %s", rootCmd, shellescape.Quote(configPath), SettingsFile, shellescape.Quote(dataPath), strings.Join(quotedFiles, " "))
+	return c.pipeCommands(tarCmdText, encryptCmd)
+}
+
+// Decrypt a backup and extract it into the staging folder, returning the settings it contains
+func (c *Client) ExtractBackup(decryptCmd string) (*config.RocketPoolConfig, error) {
+	return c.extractArchive(decryptCmd, BackupStagingFolder)
+}
+
+// Decrypt an archive and extract it into the given staging folder in the config folder, returning the settings it contains
+func (c *Client) extractArchive(decryptCmd string, stagingFolder string) (*config.RocketPoolConfig, error) {
+	rootCmd, err := c.getEscalationCommand()
+	if err != nil {
+		return nil, fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+	stagingPath, err := homedir.Expand(filepath.Join(c.configPath, stagingFolder))
+	if err != nil {
+		return nil, fmt.Errorf("error expanding staging path: %w", err)
+	}
+
+	// Get the escalated permissions up front so the escalation prompt doesn't collide with the decryption prompt
+	if err := c.printOutput(fmt.Sprintf("%s rm -rf %s && %s mkdir -p %s", rootCmd, shellescape.Quote(stagingPath), rootCmd, shellescape.Quote(stagingPath))); err != nil {
+		return nil, fmt.Errorf("error creating staging folder: %w", err)
+	}
+	if err := c.pipeCommands(decryptCmd, fmt.Sprintf("%s tar -xzf - -C %s", rootCmd, shellescape.Quote(stagingPath))); err != nil {
+		return nil, err
+	}
+
+	// Load the settings
+	cfg, err := rp.LoadConfigFromFile(filepath.Join(stagingPath, SettingsFile))
+	if err != nil {
+		return nil, fmt.Errorf("error loading settings from backup: %w", err)
+	}
+	if cfg == nil {
+		return nil, fmt.Errorf("the backup does not contain a settings file")
+	}
+	return cfg, nil
+}
+
+// Move the files extracted from a backup into the config and data folders, and delete the staging folder
+func (c *Client) RestoreBackupFiles(dataPath string) error {
+	return c.restoreStagedFiles(BackupStagingFolder, dataPath)
+}
+
+// Move the settings file from the given staging folder into the config folder and everything else into the data folder, then delete the staging folder
+func (c *Client) restoreStagedFiles(stagingFolder string, dataPath string) error {
+	rootCmd, err := c.getEscalationCommand()
+	if err != nil {
+		return fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+	configPath, err := homedir.Expand(c.configPath)
+	if err != nil {
+		return fmt.Errorf("error expanding settings path: %w", err)
+	}
+	stagingPath := filepath.Join(configPath, stagingFolder)
+	dataPath, err = homedir.Expand(dataPath)
+	if err != nil {
+		return fmt.Errorf("error expanding data path: %w", err)
+	}
+
+	// The settings file belongs to the user, regardless of who owned it on the original machine
+	settingsPath := shellescape.Quote(filepath.Join(configPath, SettingsFile))
+	cmd := fmt.Sprintf("%s mv %s %s && %s chown $(id -u):$(id -g) %s",
+		rootCmd, shellescape.Quote(filepath.Join(stagingPath, SettingsFile)), settingsPath, rootCmd, settingsPath)
+	if err := c.printOutput(cmd); err != nil {
+		return fmt.Errorf("error restoring settings file: %w", err)
+	}
+
+	// Everything else goes in the data folder
+	cmd = fmt.Sprintf("%s mkdir -p %s && %s cp -a %s/. %s/",
+		rootCmd, shellescape.Quote(dataPath), rootCmd, shellescape.Quote(stagingPath), shellescape.Quote(dataPath))
+	if err := c.printOutput(cmd); err != nil {
+		return fmt.Errorf("error restoring data folder: %w", err)
+	}
+	return c.deleteStaging(stagingFolder)
+}
+
+// Delete the staging folder used when restoring a backup
+func (c *Client) DeleteBackupStaging() error {
+	return c.deleteStaging(BackupStagingFolder)
+}
+
+// Delete the given staging folder in the config folder
+func (c *Client) deleteStaging(stagingFolder string) error {
+	rootCmd, err := c.getEscalationCommand()
+	if err != nil {
+		return fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+	stagingPath, err := homedir.Expand(filepath.Join(c.configPath, stagingFolder))
+	if err != nil {
+		return fmt.Errorf("error expanding staging path: %w", err)
+	}
+	if err := c.printOutput(fmt.Sprintf("%s rm -rf %s", rootCmd, shellescape.Quote(stagingPath))); err != nil {
+		return fmt.Errorf("error deleting staging folder: %w", err)
+	}
+	return nil
+}
+
+// Upload a backup to S3-compatible storage with a pre-signed PUT URL
+func UploadBackup(backupPath string, url string) error {
+	file, err := os.Open(backupPath)
+	if err != nil {
+		return fmt.Errorf("error opening backup: %w", err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("error getting backup size: %w", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPut, url, file)
+	if err != nil {
+		return fmt.Errorf("error creating upload request: %w", err)
+	}
+	request.ContentLength = info.Size()
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("error uploading backup: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("upload failed with status %s: %s", resp.Status, string(body))
+	}
+	return nil
+}
+
+// Download a backup from S3-compatible storage with a pre-signed GET URL
+func DownloadBackup(url string, backupPath string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return fmt.Errorf("error downloading backup: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("download failed with status %s: %s", resp.Status, string(body))
+	}
+
+	file, err := os.OpenFile(backupPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("error creating backup file: %w", err)
+	}
+	defer file.Close()
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		return fmt.Errorf("error saving backup: %w", err)
+	}
+	return nil
+}
+
+// Run two commands with the output of the first piped into the second, failing if either of them fails
+func (c *Client) pipeCommands(sourceCmdText string, sinkCmdText string) error {
+	sourceCmd, err := c.newCommand(sourceCmdText)
+	if err != nil {
+		return err
+	}
+	defer sourceCmd.Close()
+	sinkCmd, err := c.newCommand(sinkCmdText)
+	if err != nil {
+		return err
+	}
+	defer sinkCmd.Close()
+
+	pipe, err := sourceCmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	sourceCmd.SetStderr(os.Stderr)
+	sinkCmd.SetStdin(pipe)
+	sinkCmd.SetStdout(os.Stdout)
+	sinkCmd.SetStderr(os.Stderr)
+
+	if err := sourceCmd.Start(); err != nil {
+		return err
+	}
+	if err := sinkCmd.Start(); err != nil {
+		return err
+	}
+
+	// The sink has to finish reading before the source is waited on, since waiting closes the pipe
+	sinkErr := sinkCmd.Wait()
+	sourceErr := sourceCmd.Wait()
+	if sourceErr != nil {
+		return fmt.Errorf("error running [%s]: %w", sourceCmdText, sourceErr)
+	}
+	if sinkErr != nil {
+		return fmt.Errorf("error running [%s]: %w", sinkCmdText, sinkErr)
+	}
+	return nil
+}
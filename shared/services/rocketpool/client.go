@@ -19,6 +19,7 @@ import (
 
 	"github.com/a8m/envsubst"
 	"github.com/fatih/color"
+	"github.com/goccy/go-json"
 	"github.com/urfave/cli"
 	"golang.org/x/crypto/ssh"
 
@@ -28,6 +29,10 @@ import (
 	"github.com/mitchellh/go-homedir"
 	"github.com/rocket-pool/smartnode/addons/graffiti_wall_writer"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/config/migration"
+	"github.com/rocket-pool/smartnode/shared/services/mev"
+	"github.com/rocket-pool/smartnode/shared/services/offline"
+	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
@@ -46,6 +51,8 @@ const (
 	APIContainerSuffix string = "_api"
 	APIBinPath         string = "/go/bin/rocketpool"
 
+	ValidatorToolMountPath string = "/slashing-protection"
+
 	templatesDir                  string = "templates"
 	overrideDir                   string = "override"
 	runtimeDir                    string = "runtime"
@@ -93,13 +100,32 @@ type Client struct {
 	maxPrioFee         float64
 	gasLimit           uint64
 	customNonce        *big.Int
+	unsigned           bool
+	dryRun             bool
+	submitBelow        float64
+	commandName        string
+	stateFile          string
+	profile            string
+	snapshot           *state.StateSnapshot
 	client             *ssh.Client
 	originalMaxFee     float64
 	originalMaxPrioFee float64
 	originalGasLimit   uint64
 	debugPrint         bool
+	jsonOutput         bool
 	ignoreSyncCheck    bool
 	forceFallbacks     bool
+	containerRuntime   cfgtypes.ContainerRuntime
+}
+
+// The resource usage of a service container, as reported by `docker stats`
+type ContainerStats struct {
+	Name     string `json:"Name"`
+	CPUPerc  string `json:"CPUPerc"`
+	MemUsage string `json:"MemUsage"`
+	MemPerc  string `json:"MemPerc"`
+	NetIO    string `json:"NetIO"`
+	BlockIO  string `json:"BlockIO"`
 }
 
 func getClientStatusString(clientStatus api.ClientStatus) string {
@@ -114,6 +140,14 @@ func getClientStatusString(clientStatus api.ClientStatus) string {
 	return fmt.Sprintf("unavailable (%s)", clientStatus.Error)
 }
 
+// Get the writer for notices that aren't part of a command's output; they go to stderr in JSON mode so they don't break the JSON
+func (c *Client) noticeOutput() io.Writer {
+	if c.jsonOutput {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
 // Check the status of the Execution and Consensus client(s) and provision the API with them
 func checkClientStatus(rp *Client) (bool, error) {
 
@@ -143,18 +177,18 @@ func checkClientStatus(rp *Client) (bool, error) {
 
 		// Fallback EC and CC are good
 		if ecMgrStatus.FallbackClientStatus.IsSynced && bcMgrStatus.FallbackClientStatus.IsSynced {
-			fmt.Printf("%sNOTE: primary clients are not ready, using fallback clients...\n\tPrimary EC status: %s\n\tPrimary CC status: %s%s\n\n", colorYellow, primaryEcStatus, primaryBcStatus, colorReset)
+			fmt.Fprintf(rp.noticeOutput(), "%sNOTE: primary clients are not ready, using fallback clients...\n\tPrimary EC status: %s\n\tPrimary CC status: %s%s\n\n", colorYellow, primaryEcStatus, primaryBcStatus, colorReset)
 			rp.SetClientStatusFlags(true, true)
 			return true, nil
 		}
 
 		// Both pairs aren't ready
-		fmt.Printf("Error: neither primary nor fallback client pairs are ready.\n\tPrimary EC status: %s\n\tFallback EC status: %s\n\tPrimary CC status: %s\n\tFallback CC status: %s\n", primaryEcStatus, fallbackEcStatus, primaryBcStatus, fallbackBcStatus)
+		fmt.Fprintf(rp.noticeOutput(), "Error: neither primary nor fallback client pairs are ready.\n\tPrimary EC status: %s\n\tFallback EC status: %s\n\tPrimary CC status: %s\n\tFallback CC status: %s\n", primaryEcStatus, fallbackEcStatus, primaryBcStatus, fallbackBcStatus)
 		return false, nil
 	}
 
 	// Primary isn't ready and fallback isn't enabled
-	fmt.Printf("Error: primary client pair isn't ready and fallback clients aren't enabled.\n\tPrimary EC status: %s\n\tPrimary CC status: %s\n", primaryEcStatus, primaryBcStatus)
+	fmt.Fprintf(rp.noticeOutput(), "Error: primary client pair isn't ready and fallback clients aren't enabled.\n\tPrimary EC status: %s\n\tPrimary CC status: %s\n", primaryEcStatus, primaryBcStatus)
 	return false, nil
 }
 
@@ -174,6 +208,13 @@ func NewClientFromCtx(c *cli.Context) *Client {
 		originalMaxPrioFee: c.GlobalFloat64("maxPrioFee"),
 		originalGasLimit:   c.GlobalUint64("gasLimit"),
 		debugPrint:         c.GlobalBool("debug"),
+		unsigned:           c.GlobalBool("unsigned"),
+		dryRun:             c.GlobalBool("dry-run"),
+		submitBelow:        c.GlobalFloat64("submit-below"),
+		commandName:        c.Command.FullName(),
+		stateFile:          os.ExpandEnv(c.GlobalString("state-file")),
+		profile:            c.GlobalString("profile"),
+		jsonOutput:         c.GlobalBool("json"),
 		forceFallbacks:     false,
 		ignoreSyncCheck:    false,
 	}
@@ -189,6 +230,11 @@ func NewClientFromCtx(c *cli.Context) *Client {
 // Only use this function from commands that may work without the clients being synced-
 // most users should use WithReady instead
 func (c *Client) WithStatus() (*Client, bool, error) {
+	// Commands run against a state snapshot don't use the clients at all
+	if c.stateFile != "" {
+		return c, true, nil
+	}
+
 	ready, err := checkClientStatus(c)
 	if err != nil {
 		c.Close()
@@ -243,6 +289,12 @@ func (c *Client) LoadConfig() (*config.RocketPoolConfig, bool, error) {
 	if cfg == nil {
 		cfg = config.NewRocketPoolConfig(c.configPath, c.daemonPath != "")
 		isNew = true
+		if c.profile != "" {
+			applyProfileDefaults(cfg, c.profile)
+		}
+		if err := cfg.ApplySettingOverrides(); err != nil {
+			return nil, false, fmt.Errorf("error applying setting overrides: %w", err)
+		}
 	}
 	return cfg, isNew, nil
 }
@@ -258,6 +310,26 @@ func (c *Client) LoadBackupConfig() (*config.RocketPoolConfig, error) {
 	return rp.LoadConfigFromFile(expandedPath)
 }
 
+// Report what upgrading the config to the current Smartnode version would change, without modifying it
+func (c *Client) PlanConfigUpgrade() (*migration.UpgradeReport, error) {
+	settingsFilePath := filepath.Join(c.configPath, SettingsFile)
+	expandedPath, err := homedir.Expand(settingsFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("error expanding settings file path: %w", err)
+	}
+	return rp.PlanConfigUpgrade(expandedPath)
+}
+
+// Restore the config from before the last upgrade, returning the Smartnode version it was made by
+func (c *Client) RollbackConfig() (string, error) {
+	settingsFilePath := filepath.Join(c.configPath, SettingsFile)
+	expandedPath, err := homedir.Expand(settingsFilePath)
+	if err != nil {
+		return "", fmt.Errorf("error expanding settings file path: %w", err)
+	}
+	return rp.RollbackConfig(expandedPath)
+}
+
 // Save the config
 func (c *Client) SaveConfig(cfg *config.RocketPoolConfig) error {
 	settingsFilePath := filepath.Join(c.configPath, SettingsFile)
@@ -522,6 +594,15 @@ func (c *Client) StartService(composeFiles []string) error {
 	return c.printOutput(cmd)
 }
 
+// Create the Rocket Pool service's containers without starting them
+func (c *Client) CreateService(composeFiles []string) error {
+	cmd, err := c.compose(composeFiles, "up --no-start --remove-orphans --quiet-pull")
+	if err != nil {
+		return err
+	}
+	return c.printOutput(cmd)
+}
+
 // Pause the Rocket Pool service
 func (c *Client) PauseService(composeFiles []string) error {
 	cmd, err := c.compose(composeFiles, "stop")
@@ -584,19 +665,6 @@ func (c *Client) PrintServiceStatus(composeFiles []string) error {
 	return c.printOutput(cmd)
 }
 
-// Print the Rocket Pool service logs
-func (c *Client) PrintServiceLogs(composeFiles []string, tail string, serviceNames ...string) error {
-	sanitizedStrings := make([]string, len(serviceNames))
-	for i, serviceName := range serviceNames {
-		sanitizedStrings[i] = fmt.Sprintf("%s", shellescape.Quote(serviceName))
-	}
-	cmd, err := c.compose(composeFiles, fmt.Sprintf("logs -f --tail %s %s", shellescape.Quote(tail), strings.Join(sanitizedStrings, " ")))
-	if err != nil {
-		return err
-	}
-	return c.printOutput(cmd)
-}
-
 // Print the Rocket Pool service stats
 func (c *Client) PrintServiceStats(composeFiles []string) error {
 
@@ -612,7 +680,44 @@ func (c *Client) PrintServiceStats(composeFiles []string) error {
 	containerIds := strings.Split(strings.TrimSpace(string(containers)), "\n")
 
 	// Print stats
-	return c.printOutput(fmt.Sprintf("docker stats %s", strings.Join(containerIds, " ")))
+	return c.printOutput(fmt.Sprintf("%s stats %s", c.containerCommand(), strings.Join(containerIds, " ")))
+
+}
+
+// Get a snapshot of the resource usage of the Rocket Pool service containers
+func (c *Client) GetServiceStats(composeFiles []string) ([]ContainerStats, error) {
+
+	// Get service container IDs
+	cmd, err := c.compose(composeFiles, "ps -q")
+	if err != nil {
+		return nil, err
+	}
+	containers, err := c.readOutput(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("error getting service containers: %w", err)
+	}
+	containerIds := strings.Fields(string(containers))
+	if len(containerIds) == 0 {
+		return []ContainerStats{}, nil
+	}
+
+	// Get the stats, one JSON object per line
+	output, err := c.readOutput(fmt.Sprintf("%s stats --no-stream --format '{{json .}}' %s", c.containerCommand(), strings.Join(containerIds, " ")))
+	if err != nil {
+		return nil, fmt.Errorf("error getting service stats: %w", err)
+	}
+	stats := []ContainerStats{}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line == "" {
+			continue
+		}
+		var containerStats ContainerStats
+		if err := json.Unmarshal([]byte(line), &containerStats); err != nil {
+			return nil, fmt.Errorf("error decoding service stats: %w", err)
+		}
+		stats = append(stats, containerStats)
+	}
+	return stats, nil
 
 }
 
@@ -635,7 +740,7 @@ func (c *Client) GetServiceVersion() (string, error) {
 		if err != nil {
 			return "", err
 		}
-		cmd = fmt.Sprintf("docker exec %s %s --version", shellescape.Quote(containerName), shellescape.Quote(APIBinPath))
+		cmd = fmt.Sprintf("%s exec %s %s --version", c.containerCommand(), shellescape.Quote(containerName), shellescape.Quote(APIBinPath))
 	} else {
 		cmd = fmt.Sprintf("%s --version", shellescape.Quote(c.daemonPath))
 	}
@@ -669,10 +774,20 @@ func (c *Client) IncrementCustomNonce() {
 	c.customNonce.Add(c.customNonce, big.NewInt(1))
 }
 
+// Sets the custom nonce parameter, so the following transactions start from it.
+func (c *Client) SetCustomNonce(nonce uint64) {
+	c.customNonce = big.NewInt(0).SetUint64(nonce)
+}
+
+// Checks if a custom nonce has been set for the following transactions.
+func (c *Client) HasCustomNonce() bool {
+	return c.customNonce != nil
+}
+
 // Get the current Docker image used by the given container
 func (c *Client) GetDockerImage(container string) (string, error) {
 
-	cmd := fmt.Sprintf("docker container inspect --format={{.Config.Image}} %s", container)
+	cmd := fmt.Sprintf("%s container inspect --format={{.Config.Image}} %s", c.containerCommand(), container)
 	image, err := c.readOutput(cmd)
 	if err != nil {
 		return "", err
@@ -685,7 +800,7 @@ func (c *Client) GetDockerImage(container string) (string, error) {
 // Get the current Docker image used by the given container
 func (c *Client) GetDockerStatus(container string) (string, error) {
 
-	cmd := fmt.Sprintf("docker container inspect --format={{.State.Status}} %s", container)
+	cmd := fmt.Sprintf("%s container inspect --format={{.State.Status}} %s", c.containerCommand(), container)
 	status, err := c.readOutput(cmd)
 	if err != nil {
 		return "", err
@@ -698,7 +813,7 @@ func (c *Client) GetDockerStatus(container string) (string, error) {
 // Get the time that the given container shut down
 func (c *Client) GetDockerContainerShutdownTime(container string) (time.Time, error) {
 
-	cmd := fmt.Sprintf("docker container inspect --format={{.State.FinishedAt}} %s", container)
+	cmd := fmt.Sprintf("%s container inspect --format={{.State.FinishedAt}} %s", c.containerCommand(), container)
 	finishTimeBytes, err := c.readOutput(cmd)
 	if err != nil {
 		return time.Time{}, err
@@ -716,7 +831,7 @@ func (c *Client) GetDockerContainerShutdownTime(container string) (time.Time, er
 // Shut down a container
 func (c *Client) StopContainer(container string) (string, error) {
 
-	cmd := fmt.Sprintf("docker stop %s", container)
+	cmd := fmt.Sprintf("%s stop %s", c.containerCommand(), container)
 	output, err := c.readOutput(cmd)
 	if err != nil {
 		return "", err
@@ -728,7 +843,7 @@ func (c *Client) StopContainer(container string) (string, error) {
 // Start a container
 func (c *Client) StartContainer(container string) (string, error) {
 
-	cmd := fmt.Sprintf("docker start %s", container)
+	cmd := fmt.Sprintf("%s start %s", c.containerCommand(), container)
 	output, err := c.readOutput(cmd)
 	if err != nil {
 		return "", err
@@ -740,7 +855,7 @@ func (c *Client) StartContainer(container string) (string, error) {
 // Restart a container
 func (c *Client) RestartContainer(container string) (string, error) {
 
-	cmd := fmt.Sprintf("docker restart %s", container)
+	cmd := fmt.Sprintf("%s restart %s", c.containerCommand(), container)
 	output, err := c.readOutput(cmd)
 	if err != nil {
 		return "", err
@@ -752,7 +867,7 @@ func (c *Client) RestartContainer(container string) (string, error) {
 // Deletes a container
 func (c *Client) RemoveContainer(container string) (string, error) {
 
-	cmd := fmt.Sprintf("docker rm %s", container)
+	cmd := fmt.Sprintf("%s rm %s", c.containerCommand(), container)
 	output, err := c.readOutput(cmd)
 	if err != nil {
 		return "", err
@@ -764,7 +879,7 @@ func (c *Client) RemoveContainer(container string) (string, error) {
 // Deletes a container
 func (c *Client) DeleteVolume(volume string) (string, error) {
 
-	cmd := fmt.Sprintf("docker volume rm %s", volume)
+	cmd := fmt.Sprintf("%s volume rm %s", c.containerCommand(), volume)
 	output, err := c.readOutput(cmd)
 	if err != nil {
 		return "", err
@@ -776,7 +891,7 @@ func (c *Client) DeleteVolume(volume string) (string, error) {
 // Gets the absolute file path of the client volume
 func (c *Client) GetClientVolumeSource(container string, volumeTarget string) (string, error) {
 
-	cmd := fmt.Sprintf("docker container inspect --format='{{range .Mounts}}{{if eq \"%s\" .Destination}}{{.Source}}{{end}}{{end}}' %s", volumeTarget, container)
+	cmd := fmt.Sprintf("%s container inspect --format='{{range .Mounts}}{{if eq \"%s\" .Destination}}{{.Source}}{{end}}{{end}}' %s", c.containerCommand(), volumeTarget, container)
 	output, err := c.readOutput(cmd)
 	if err != nil {
 		return "", err
@@ -787,7 +902,7 @@ func (c *Client) GetClientVolumeSource(container string, volumeTarget string) (s
 // Gets the name of the client volume
 func (c *Client) GetClientVolumeName(container string, volumeTarget string) (string, error) {
 
-	cmd := fmt.Sprintf("docker container inspect --format='{{range .Mounts}}{{if eq \"%s\" .Destination}}{{.Name}}{{end}}{{end}}' %s", volumeTarget, container)
+	cmd := fmt.Sprintf("%s container inspect --format='{{range .Mounts}}{{if eq \"%s\" .Destination}}{{.Name}}{{end}}{{end}}' %s", c.containerCommand(), volumeTarget, container)
 	output, err := c.readOutput(cmd)
 	if err != nil {
 		return "", err
@@ -798,7 +913,7 @@ func (c *Client) GetClientVolumeName(container string, volumeTarget string) (str
 // Gets the disk usage of the given volume
 func (c *Client) GetVolumeSize(volumeName string) (string, error) {
 
-	cmd := fmt.Sprintf("docker system df -v --format='{{range .Volumes}}{{if eq \"%s\" .Name}}{{.Size}}{{end}}{{end}}'", volumeName)
+	cmd := fmt.Sprintf("%s system df -v --format='{{range .Volumes}}{{if eq \"%s\" .Name}}{{.Size}}{{end}}{{end}}'", c.containerCommand(), volumeName)
 	output, err := c.readOutput(cmd)
 	if err != nil {
 		return "", err
@@ -810,7 +925,7 @@ func (c *Client) GetVolumeSize(volumeName string) (string, error) {
 func (c *Client) RunPruneProvisioner(container string, volume string, image string) error {
 
 	// Run the prune provisioner
-	cmd := fmt.Sprintf("docker run --rm --name %s -v %s:/ethclient %s", container, volume, image)
+	cmd := fmt.Sprintf("%s run --rm --name %s -v %s:/ethclient %s", c.containerCommand(), container, volume, image)
 	output, err := c.readOutput(cmd)
 	if err != nil {
 		return err
@@ -827,7 +942,7 @@ func (c *Client) RunPruneProvisioner(container string, volume string, image stri
 
 // Runs the prune provisioner
 func (c *Client) RunNethermindPruneStarter(container string) error {
-	cmd := fmt.Sprintf("docker exec %s %s %s", container, nethermindPruneStarterCommand, nethermindAdminUrl)
+	cmd := fmt.Sprintf("%s exec %s %s %s", c.containerCommand(), container, nethermindPruneStarterCommand, nethermindAdminUrl)
 	err := c.printOutput(cmd)
 	if err != nil {
 		return err
@@ -837,7 +952,7 @@ func (c *Client) RunNethermindPruneStarter(container string) error {
 
 // Runs the EC migrator
 func (c *Client) RunEcMigrator(container string, volume string, targetDir string, mode string, image string) error {
-	cmd := fmt.Sprintf("docker run --rm --name %s -v %s:/ethclient -v %s:/mnt/external -e EC_MIGRATE_MODE='%s' %s", container, volume, targetDir, mode, image)
+	cmd := fmt.Sprintf("%s run --rm --name %s -v %s:/ethclient -v %s:/mnt/external -e EC_MIGRATE_MODE='%s' %s", c.containerCommand(), container, volume, targetDir, mode, image)
 	err := c.printOutput(cmd)
 	if err != nil {
 		return err
@@ -846,9 +961,36 @@ func (c *Client) RunEcMigrator(container string, volume string, targetDir string
 	return nil
 }
 
+// Get the user a container runs as, or an empty string if it uses its image's default
+func (c *Client) GetContainerUser(container string) (string, error) {
+	cmd := fmt.Sprintf("%s container inspect --format={{.Config.User}} %s", c.containerCommand(), container)
+	output, err := c.readOutput(cmd)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Run one of the Validator client's own tools in a temporary container that shares the Validator client's volumes.
+// The host folder is mounted at ValidatorToolMountPath so files can be passed in and out of the tool.
+func (c *Client) RunValidatorTool(validatorContainer string, image string, user string, network string, hostDir string, args []string) error {
+	cmd := fmt.Sprintf("%s run --rm --volumes-from %s -v %s:%s", c.containerCommand(), shellescape.Quote(validatorContainer), shellescape.Quote(hostDir), ValidatorToolMountPath)
+	if user != "" {
+		cmd += fmt.Sprintf(" --user %s", shellescape.Quote(user))
+	}
+	if network != "" {
+		cmd += fmt.Sprintf(" --network %s", shellescape.Quote(network))
+	}
+	cmd += " " + shellescape.Quote(image)
+	for _, arg := range args {
+		cmd += " " + shellescape.Quote(arg)
+	}
+	return c.printOutput(cmd)
+}
+
 // Gets the size of the target directory via the EC migrator for importing, which should have the same permissions as exporting
 func (c *Client) GetDirSizeViaEcMigrator(container string, targetDir string, image string) (uint64, error) {
-	cmd := fmt.Sprintf("docker run --rm --name %s -v %s:/mnt/external -e OPERATION='size' %s", container, targetDir, image)
+	cmd := fmt.Sprintf("%s run --rm --name %s -v %s:/mnt/external -e OPERATION='size' %s", c.containerCommand(), container, targetDir, image)
 	output, err := c.readOutput(cmd)
 	if err != nil {
 		return 0, fmt.Errorf("Error getting source directory size: %w", err)
@@ -954,6 +1096,22 @@ func (c *Client) AssignGasSettings(maxFee float64, maxPrioFee float64, gasLimit
 	c.gasLimit = gasLimit
 }
 
+// Use the synced client pair from a sync status that was just fetched, so the API doesn't have to check it again.
+// Returns false if neither the primary nor the fallback pair is synced.
+func (c *Client) SetSyncedClients(ecMgrStatus api.ClientManagerStatus, bcMgrStatus api.ClientManagerStatus) bool {
+	if ecMgrStatus.PrimaryClientStatus.IsSynced && bcMgrStatus.PrimaryClientStatus.IsSynced {
+		c.SetClientStatusFlags(true, false)
+		return true
+	}
+	if ecMgrStatus.FallbackEnabled && bcMgrStatus.FallbackEnabled &&
+		ecMgrStatus.FallbackClientStatus.IsSynced && bcMgrStatus.FallbackClientStatus.IsSynced {
+		c.SetClientStatusFlags(true, true)
+		return true
+	}
+	c.SetClientStatusFlags(false, false)
+	return false
+}
+
 // Set the flags for ignoring EC and CC sync checks and forcing fallbacks to prevent unnecessary duplication of effort by the API during CLI commands
 func (c *Client) SetClientStatusFlags(ignoreSyncCheck bool, forceFallbacks bool) {
 	c.ignoreSyncCheck = ignoreSyncCheck
@@ -1062,17 +1220,39 @@ func (c *Client) compose(composeFiles []string, args string) (string, error) {
 		settings["EXTERNAL_IP"] = shellescape.Quote(externalIP)
 	}
 
+	// Leave out the MEV-Boost relays that relay management dropped
+	relayRecord, err := mev.LoadRelayRecord(cfg.Smartnode.GetMevRelayRecordPathInCLI())
+	if err != nil {
+		fmt.Printf("Warning: couldn't load the MEV-Boost relay record, so all of the enabled relays will be used: %s\n", err.Error())
+	} else {
+		cfg.RemoveDroppedMevRelays(settings, relayRecord.GetDroppedRelays())
+	}
+
 	// Deploy the templates and run environment variable substitution on them
 	deployedContainers, err := c.deployTemplates(cfg, expandedConfigPath, settings)
 	if err != nil {
 		return "", fmt.Errorf("error deploying Docker templates: %w", err)
 	}
 
+	// Adapt them to the container runtime
+	err = c.checkContainerRuntime(cfg)
+	if err != nil {
+		return "", err
+	}
+	err = translateComposeFiles(cfg, filepath.Join(expandedConfigPath, runtimeDir), deployedContainers)
+	if err != nil {
+		return "", fmt.Errorf("error adapting compose files to the container runtime: %w", err)
+	}
+
 	// Set up all of the environment variables to pass to the run command
 	env := []string{}
 	for key, value := range settings {
 		env = append(env, fmt.Sprintf("%s=%s", key, shellescape.Quote(value)))
 	}
+	if c.getContainerRuntime() == cfgtypes.ContainerRuntime_Podman {
+		// Point the compose provider at Podman's socket instead of Docker's
+		env = append(env, fmt.Sprintf("DOCKER_HOST=%s", shellescape.Quote("unix://"+GetContainerSocketPath(cfg))))
+	}
 
 	// Include all of the relevant docker compose definition files
 	composeFileFlags := []string{}
@@ -1084,7 +1264,7 @@ func (c *Client) compose(composeFiles []string, args string) (string, error) {
 	}
 
 	// Return command
-	return fmt.Sprintf("%s docker compose --project-directory %s %s %s", strings.Join(env, " "), shellescape.Quote(expandedConfigPath), strings.Join(composeFileFlags, " "), args), nil
+	return fmt.Sprintf("%s %s compose --project-directory %s %s %s", strings.Join(env, " "), c.containerCommand(), shellescape.Quote(expandedConfigPath), strings.Join(composeFileFlags, " "), args), nil
 
 }
 
@@ -1269,6 +1449,42 @@ func (c *Client) deployTemplates(cfg *config.RocketPoolConfig, rocketpoolDir str
 		deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, config.MevBoostContainerName+composeFileSuffix))
 	}
 
+	// Check the distributed validator middleware
+	if cfg.EnableDistributedValidators.Value == true {
+		var dvContainerName string
+		var dvDescription string
+		switch cfg.DistributedValidator.Mode.Value.(cfgtypes.DistributedValidatorMode) {
+		case cfgtypes.DistributedValidatorMode_Obol:
+			dvContainerName = config.CharonContainerName
+			dvDescription = "Charon"
+		case cfgtypes.DistributedValidatorMode_Ssv:
+			dvContainerName = config.SsvContainerName
+			dvDescription = "SSV"
+		default:
+			return []string{}, fmt.Errorf("unknown distributed validator mode [%v]", cfg.DistributedValidator.Mode.Value)
+		}
+		contents, err = envsubst.ReadFile(filepath.Join(templatesFolder, dvContainerName+templateSuffix))
+		if err != nil {
+			return []string{}, fmt.Errorf("error reading and substituting %s container template: %w", dvDescription, err)
+		}
+		dvComposePath := filepath.Join(runtimeFolder, dvContainerName+composeFileSuffix)
+		err = os.WriteFile(dvComposePath, contents, 0664)
+		if err != nil {
+			return []string{}, fmt.Errorf("could not write %s container file to %s: %w", dvDescription, dvComposePath, err)
+		}
+		deployedContainers = append(deployedContainers, dvComposePath)
+		deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, dvContainerName+composeFileSuffix))
+
+		// Create the folder for the cluster's files
+		dvDir, err := homedir.Expand(filepath.Join(cfg.Smartnode.GetDistributedValidatorsPathInCLI(), dvContainerName))
+		if err == nil {
+			err = os.MkdirAll(dvDir, 0775)
+		}
+		if err != nil {
+			fmt.Printf("%sWARNING: Couldn't create the distributed validator folder (%s). %s will not start until you create it manually and put your cluster's files in it.%s\n", colorYellow, err.Error(), dvDescription, colorReset)
+		}
+	}
+
 	// Create the custom keys dir
 	customKeyDir, err := homedir.Expand(filepath.Join(cfg.Smartnode.DataPath.Value.(string), "custom-keys"))
 	if err != nil {
@@ -1292,7 +1508,11 @@ func (c *Client) deployTemplates(cfg *config.RocketPoolConfig, rocketpoolDir str
 		fmt.Printf("%sWARNING: Couldn't create the rewards tree file directory (%s). You will not be able to view or claim your rewards until you create the folder [%s] manually.%s\n", colorYellow, err.Error(), rewardsFileDir, colorReset)
 	}
 
-	return c.composeAddons(cfg, rocketpoolDir, settings, deployedContainers)
+	deployedContainers, err = c.composeAddons(cfg, rocketpoolDir, settings, deployedContainers)
+	if err != nil {
+		return []string{}, err
+	}
+	return c.composeCustomFiles(rocketpoolDir, deployedContainers)
 
 }
 
@@ -1330,6 +1550,10 @@ func (c *Client) composeAddons(cfg *config.RocketPoolConfig, rocketpoolDir strin
 
 // Call the Rocket Pool API
 func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
+	if c.stateFile != "" {
+		return c.callSnapshotAPI(args)
+	}
+
 	// Sanitize and parse the args
 	ignoreSyncCheckFlag, forceFallbackECFlag, args := c.getApiCallArgs(args, otherArgs...)
 
@@ -1340,15 +1564,16 @@ func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
 		if err != nil {
 			return []byte{}, err
 		}
-		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s api %s", shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), args)
+		cmd = fmt.Sprintf("%s exec %s %s %s %s %s %s %s api %s", c.containerCommand(), shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), c.getTransactionModeFlag(), args)
 	} else {
-		cmd = fmt.Sprintf("%s --settings %s %s %s %s %s api %s",
+		cmd = fmt.Sprintf("%s --settings %s %s %s %s %s %s api %s",
 			c.daemonPath,
 			shellescape.Quote(fmt.Sprintf("%s/%s", c.configPath, SettingsFile)),
 			ignoreSyncCheckFlag,
 			forceFallbackECFlag,
 			c.getGasOpts(),
 			c.getCustomNonce(),
+			c.getTransactionModeFlag(),
 			args)
 	}
 
@@ -1358,6 +1583,10 @@ func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
 
 // Call the Rocket Pool API with some custom environment variables
 func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, otherArgs ...string) ([]byte, error) {
+	if c.stateFile != "" {
+		return c.callSnapshotAPI(args)
+	}
+
 	// Sanitize and parse the args
 	ignoreSyncCheckFlag, forceFallbackECFlag, args := c.getApiCallArgs(args, otherArgs...)
 
@@ -1373,13 +1602,13 @@ func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, othe
 		if err != nil {
 			return []byte{}, err
 		}
-		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s %s api %s", envArgs, shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), args)
+		cmd = fmt.Sprintf("%s exec %s %s %s %s %s %s %s %s api %s", c.containerCommand(), envArgs, shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), c.getTransactionModeFlag(), args)
 	} else {
 		envArgs := ""
 		for key, value := range envVars {
 			envArgs += fmt.Sprintf("%s=%s ", key, shellescape.Quote(value))
 		}
-		cmd = fmt.Sprintf("%s %s --settings %s %s %s %s %s api %s",
+		cmd = fmt.Sprintf("%s %s --settings %s %s %s %s %s %s api %s",
 			envArgs,
 			c.daemonPath,
 			shellescape.Quote(fmt.Sprintf("%s/%s", c.configPath, SettingsFile)),
@@ -1387,6 +1616,7 @@ func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, othe
 			forceFallbackECFlag,
 			c.getGasOpts(),
 			c.getCustomNonce(),
+			c.getTransactionModeFlag(),
 			args)
 	}
 
@@ -1394,6 +1624,32 @@ func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, othe
 	return c.runApiCall(cmd)
 }
 
+// Run an arbitrary API command, such as ["node", "status"], on behalf of a CLI plugin
+func (c *Client) CallAPI(args []string) ([]byte, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no API command was provided")
+	}
+	return c.callAPI(args[0], args[1:]...)
+}
+
+// Answer an API call from the state snapshot instead of the daemon
+func (c *Client) callSnapshotAPI(args string) ([]byte, error) {
+	if c.snapshot == nil {
+		snapshot, err := state.LoadStateSnapshot(c.stateFile)
+		if err != nil {
+			return nil, err
+		}
+		c.snapshot = snapshot
+
+		// Print this to stderr so it doesn't end up in exported data
+		fmt.Fprintf(os.Stderr, "%sNOTE: using the state snapshot of node %s from block %d (created %s), not live data.%s\n\n", colorYellow, snapshot.NodeAddress.Hex(), snapshot.ElBlockNumber, snapshot.Created.UTC().Format(time.RFC1123), colorReset)
+	}
+	if c.debugPrint {
+		fmt.Printf("To API (from %s): %s\n", c.stateFile, args)
+	}
+	return offline.CallAPI(c.snapshot, args)
+}
+
 func (c *Client) getApiCallArgs(args string, otherArgs ...string) (string, string, string) {
 	// Sanitize arguments
 	var sanitizedArgs []string
@@ -1478,6 +1734,50 @@ func (c *Client) getCustomNonce() string {
 	return nonce
 }
 
+// Get the flag that has the API simulate transactions or save them unsigned instead of submitting them
+func (c *Client) getTransactionModeFlag() string {
+	if c.dryRun {
+		return "--dry-run"
+	}
+	if c.unsigned {
+		return "--unsigned"
+	}
+	if c.submitBelow > 0 {
+		return "--deferred"
+	}
+	return ""
+}
+
+// Check if transactions are being saved unsigned instead of being submitted
+func (c *Client) IsUnsigned() bool {
+	return c.unsigned && !c.dryRun
+}
+
+// Check if transactions are only being simulated instead of being submitted
+func (c *Client) IsDryRun() bool {
+	return c.dryRun
+}
+
+// Check if transactions are being saved for the daemon to submit once fees drop below their max fee, instead of being submitted now
+func (c *Client) IsDeferred() bool {
+	return c.submitBelow > 0 && !c.unsigned && !c.dryRun
+}
+
+// Get the max fee (in gwei) that deferred transactions are submitted below
+func (c *Client) GetSubmitBelowFee() float64 {
+	return c.submitBelow
+}
+
+// Get the name of the command this client was created for (e.g. "node stake-rpl")
+func (c *Client) GetCommandName() string {
+	return c.commandName
+}
+
+// Get the path of the CLI's config directory
+func (c *Client) GetConfigPath() string {
+	return c.configPath
+}
+
 // Run a command and print its output
 func (c *Client) printOutput(cmdText string) error {
 
@@ -0,0 +1,122 @@
+package rocketpool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/a8m/envsubst"
+	"gopkg.in/yaml.v2"
+)
+
+// Config
+const (
+	customComposeDir        string = "custom"
+	customComposeReadmeFile string = "README.md"
+	customComposeReadme     string = `# Custom Docker Compose files
+
+Any .yml or .yaml file in this folder is merged into the Smartnode's Docker Compose project
+after the standard files (and the per-container override files one folder up), in alphabetical order.
+Use them to add volumes or resource limits to the Smartnode's containers, or to run extra sidecar containers.
+
+Environment variables such as ${ROCKETPOOL_FOLDER} are substituted the same way they are in the templates.
+Smartnode upgrades never modify this folder.
+
+Example (limit the Execution client's memory):
+
+    services:
+      eth1:
+        deploy:
+          resources:
+            limits:
+              memory: 16g
+`
+)
+
+// The top-level keys a Compose file can have; keys starting with "x-" are extensions and are always allowed
+var composeTopLevelKeys = map[string]bool{
+	"version":  true,
+	"name":     true,
+	"services": true,
+	"volumes":  true,
+	"networks": true,
+	"configs":  true,
+	"secrets":  true,
+}
+
+// Deploy the user's custom compose files, which are merged after all of the Smartnode's own files
+func (c *Client) composeCustomFiles(rocketpoolDir string, deployedContainers []string) ([]string, error) {
+
+	// Make sure the folder exists, so users know where to put their files
+	customFolder := filepath.Join(rocketpoolDir, overrideDir, customComposeDir)
+	err := os.MkdirAll(customFolder, 0775)
+	if err != nil {
+		return []string{}, fmt.Errorf("error creating custom compose folder [%s]: %w", customFolder, err)
+	}
+	readmePath := filepath.Join(customFolder, customComposeReadmeFile)
+	if _, err := os.Stat(readmePath); os.IsNotExist(err) {
+		_ = os.WriteFile(readmePath, []byte(customComposeReadme), 0664)
+	}
+
+	// Get the custom files in order
+	entries, err := os.ReadDir(customFolder)
+	if err != nil {
+		return []string{}, fmt.Errorf("error reading custom compose folder [%s]: %w", customFolder, err)
+	}
+	filenames := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		extension := strings.ToLower(filepath.Ext(name))
+		if entry.IsDir() || strings.HasPrefix(name, ".") || (extension != ".yml" && extension != ".yaml") {
+			continue
+		}
+		filenames = append(filenames, name)
+	}
+	if len(filenames) == 0 {
+		return deployedContainers, nil
+	}
+	sort.Strings(filenames)
+
+	// Substitute and check each one
+	runtimeFolder := filepath.Join(rocketpoolDir, runtimeDir, customComposeDir)
+	err = os.MkdirAll(runtimeFolder, 0775)
+	if err != nil {
+		return []string{}, fmt.Errorf("error creating custom compose runtime folder [%s]: %w", runtimeFolder, err)
+	}
+	for _, filename := range filenames {
+		customPath := filepath.Join(customFolder, filename)
+		contents, err := envsubst.ReadFile(customPath)
+		if err != nil {
+			return []string{}, fmt.Errorf("error reading and substituting custom compose file [%s]: %w", customPath, err)
+		}
+		if err := checkComposeFile(contents); err != nil {
+			return []string{}, fmt.Errorf("custom compose file [%s] is invalid: %w", customPath, err)
+		}
+
+		composePath := filepath.Join(runtimeFolder, filename)
+		err = os.WriteFile(composePath, contents, 0664)
+		if err != nil {
+			return []string{}, fmt.Errorf("could not write custom compose file to %s: %w", composePath, err)
+		}
+		deployedContainers = append(deployedContainers, composePath)
+	}
+
+	return deployedContainers, nil
+
+}
+
+// Make sure a compose file is valid YAML with only the top-level keys Compose understands
+func checkComposeFile(contents []byte) error {
+	var composeFile map[string]interface{}
+	if err := yaml.Unmarshal(contents, &composeFile); err != nil {
+		return fmt.Errorf("error parsing YAML: %w", err)
+	}
+	for key := range composeFile {
+		if !composeTopLevelKeys[key] && !strings.HasPrefix(key, "x-") {
+			return fmt.Errorf("unknown top-level key [%s]", key)
+		}
+	}
+	return nil
+}
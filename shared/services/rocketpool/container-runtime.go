@@ -0,0 +1,123 @@
+package rocketpool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Config
+const (
+	dockerSocketPath        string = "/var/run/docker.sock"
+	podmanSocketSubpath     string = "podman/podman.sock"
+	dockerHostGateway       string = "host.docker.internal"
+	podmanHostGateway       string = "host.containers.internal"
+	podmanCniNetworkBackend string = "cni"
+)
+
+// Get the container runtime that runs the Smartnode's containers, loading it from the config the first time
+func (c *Client) getContainerRuntime() cfgtypes.ContainerRuntime {
+	if c.containerRuntime != cfgtypes.ContainerRuntime_Unknown {
+		return c.containerRuntime
+	}
+
+	c.containerRuntime = cfgtypes.ContainerRuntime_Docker
+	cfg, isNew, err := c.LoadConfig()
+	if err == nil && !isNew {
+		runtime, ok := cfg.Smartnode.ContainerRuntime.Value.(cfgtypes.ContainerRuntime)
+		if ok && runtime != cfgtypes.ContainerRuntime_Unknown {
+			c.containerRuntime = runtime
+		}
+	}
+	return c.containerRuntime
+}
+
+// Get the command that runs the container runtime's CLI
+func (c *Client) containerCommand() string {
+	return string(c.getContainerRuntime())
+}
+
+// Get the path of the container runtime's API socket on the host
+func GetContainerSocketPath(cfg *config.RocketPoolConfig) string {
+	customPath := cfg.Smartnode.ContainerSocketPath.Value.(string)
+	if customPath != "" {
+		return os.ExpandEnv(customPath)
+	}
+	if cfg.Smartnode.ContainerRuntime.Value != cfgtypes.ContainerRuntime_Podman {
+		return dockerSocketPath
+	}
+
+	// Rootless Podman serves its socket from the user's runtime folder
+	containerHost := os.Getenv("CONTAINER_HOST")
+	if strings.HasPrefix(containerHost, "unix://") {
+		return strings.TrimPrefix(containerHost, "unix://")
+	}
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = fmt.Sprintf("/run/user/%d", os.Getuid())
+	}
+	return filepath.Join(runtimeDir, podmanSocketSubpath)
+}
+
+// Make sure the container runtime is set up to run the Smartnode's containers
+func (c *Client) checkContainerRuntime(cfg *config.RocketPoolConfig) error {
+	if c.getContainerRuntime() != cfgtypes.ContainerRuntime_Podman {
+		return nil
+	}
+
+	// The node daemon needs the API socket to restart the Validator Client
+	socketPath := GetContainerSocketPath(cfg)
+	if _, err := os.Stat(socketPath); os.IsNotExist(err) {
+		return fmt.Errorf("The Podman API socket [%s] doesn't exist. Please enable it with `systemctl --user enable --now podman.socket`, or set its path in the Smartnode settings.", socketPath)
+	}
+
+	// The containers find each other by name, which needs the Netavark network backend's DNS server
+	backend, err := c.readOutput(fmt.Sprintf("%s info --format '{{.Host.NetworkBackend}}'", c.containerCommand()))
+	if err != nil {
+		return fmt.Errorf("error getting Podman's network backend: %w", err)
+	}
+	if strings.TrimSpace(string(backend)) == podmanCniNetworkBackend {
+		fmt.Println("Warning: Podman is using the CNI network backend, so the Smartnode's containers can't find each other by name unless the dnsname plugin is installed. Switching to the Netavark backend is recommended.")
+	}
+	return nil
+}
+
+// Adapt the deployed compose files to the container runtime; the user's override files are left alone
+func translateComposeFiles(cfg *config.RocketPoolConfig, runtimeFolder string, composeFiles []string) error {
+	socketPath := GetContainerSocketPath(cfg)
+	isPodman := cfg.Smartnode.ContainerRuntime.Value == cfgtypes.ContainerRuntime_Podman
+	if socketPath == dockerSocketPath && !isPodman {
+		return nil
+	}
+
+	for _, composePath := range composeFiles {
+		if !strings.HasPrefix(composePath, runtimeFolder+string(filepath.Separator)) {
+			continue
+		}
+		contents, err := os.ReadFile(composePath)
+		if err != nil {
+			return fmt.Errorf("error reading compose file [%s]: %w", composePath, err)
+		}
+
+		// Mount the runtime's socket where the daemon expects Docker's to be
+		translated := strings.ReplaceAll(string(contents), dockerSocketPath+":/", socketPath+":/")
+
+		// Podman has its own name for the host
+		if isPodman {
+			translated = strings.ReplaceAll(translated, dockerHostGateway, podmanHostGateway)
+		}
+
+		if translated == string(contents) {
+			continue
+		}
+		err = os.WriteFile(composePath, []byte(translated), 0664)
+		if err != nil {
+			return fmt.Errorf("error writing compose file [%s]: %w", composePath, err)
+		}
+	}
+	return nil
+}
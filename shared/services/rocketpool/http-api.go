@@ -0,0 +1,145 @@
+package rocketpool
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/goccy/go-json"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The gas and transaction settings for an HTTP API call
+type HttpApiOptions struct {
+	MaxFee     float64 `json:"maxFee,omitempty"`
+	MaxPrioFee float64 `json:"maxPrioFee,omitempty"`
+	GasLimit   uint64  `json:"gasLimit,omitempty"`
+	Nonce      string  `json:"nonce,omitempty"`
+	Mode       string  `json:"mode,omitempty"`
+}
+
+// A client for the node daemon's HTTP API, for tools that don't want to run the CLI
+type HttpApiClient struct {
+	baseUrl string
+	token   string
+	client  *http.Client
+}
+
+// Create a client that connects to the HTTP API over the daemon's socket
+func NewHttpApiSocketClient(socketPath string) *HttpApiClient {
+	return &HttpApiClient{
+		baseUrl: "http://rocketpool",
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var dialer net.Dialer
+					return dialer.DialContext(ctx, "unix", socketPath)
+				},
+			},
+		},
+	}
+}
+
+// Create a client that connects to the HTTP API over TCP (e.g. http://localhost:8280) with the daemon's API token
+func NewHttpApiClient(baseUrl string, token string) *HttpApiClient {
+	return &HttpApiClient{
+		baseUrl: strings.TrimSuffix(baseUrl, "/"),
+		token:   token,
+		client:  http.DefaultClient,
+	}
+}
+
+// Get the OpenAPI spec describing the API's routes
+func (c *HttpApiClient) GetOpenApiSpec() ([]byte, error) {
+	return c.send(http.MethodGet, "/api/v1/openapi.json", nil)
+}
+
+// Run an API command and deserialize its response into the provided struct, which should be the command's response
+// type from the api package
+func (c *HttpApiClient) Call(group string, command string, options HttpApiOptions, response interface{}, args ...string) error {
+	body, err := json.Marshal(struct {
+		HttpApiOptions
+		Args []string `json:"args"`
+	}{
+		HttpApiOptions: options,
+		Args:           args,
+	})
+	if err != nil {
+		return fmt.Errorf("Could not serialize the request: %w", err)
+	}
+	responseBytes, err := c.send(http.MethodPost, fmt.Sprintf("/api/v1/%s/%s", group, command), body)
+	if err != nil {
+		return err
+	}
+	return decodeHttpApiResponse(group, command, responseBytes, response)
+}
+
+// Run a read-only API command with GET and deserialize its response into the provided struct
+func (c *HttpApiClient) Get(group string, command string, response interface{}, args ...string) error {
+	query := url.Values{"arg": args}
+	path := fmt.Sprintf("/api/v1/%s/%s", group, command)
+	if len(args) > 0 {
+		path += "?" + query.Encode()
+	}
+	responseBytes, err := c.send(http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	return decodeHttpApiResponse(group, command, responseBytes, response)
+}
+
+// Send a request to the API and get the response body; error responses are returned as well so they can be decoded
+func (c *HttpApiClient) send(method string, path string, body []byte) ([]byte, error) {
+	request, err := http.NewRequest(method, c.baseUrl+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("Could not create the request: %w", err)
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		request.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("Could not reach the HTTP API: %w", err)
+	}
+	defer response.Body.Close()
+	responseBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the HTTP API response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusBadRequest {
+		var apiResponse api.APIResponse
+		if json.Unmarshal(responseBytes, &apiResponse) == nil && apiResponse.Error != "" {
+			return nil, fmt.Errorf("HTTP API returned %s: %s", response.Status, apiResponse.Error)
+		}
+		return nil, fmt.Errorf("HTTP API returned %s", response.Status)
+	}
+	return responseBytes, nil
+}
+
+// Deserialize a command's response and surface its error
+func decodeHttpApiResponse(group string, command string, responseBytes []byte, response interface{}) error {
+	var apiResponse api.APIResponse
+	if err := json.Unmarshal(responseBytes, &apiResponse); err != nil {
+		return fmt.Errorf("Could not decode the %s %s response: %w", group, command, err)
+	}
+	if apiResponse.Error != "" {
+		return fmt.Errorf("Could not run %s %s: %s", group, command, apiResponse.Error)
+	}
+	if response == nil {
+		return nil
+	}
+	if err := json.Unmarshal(responseBytes, response); err != nil {
+		return fmt.Errorf("Could not decode the %s %s response: %w", group, command, err)
+	}
+	return nil
+}
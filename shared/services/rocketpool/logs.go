@@ -0,0 +1,201 @@
+package rocketpool
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/fatih/color"
+)
+
+// The colors used for each container's prefix, in order
+var logPrefixColors = []color.Attribute{
+	color.FgCyan,
+	color.FgGreen,
+	color.FgYellow,
+	color.FgMagenta,
+	color.FgBlue,
+	color.FgHiCyan,
+	color.FgHiGreen,
+	color.FgHiYellow,
+	color.FgHiMagenta,
+	color.FgHiBlue,
+}
+
+// The options for printing the service logs
+type LogOptions struct {
+	Tail   string         // The number of lines to show from the end of each log, or "all"
+	Since  string         // Only show lines after this timestamp or relative duration (e.g. "10m")
+	Filter *regexp.Regexp // Only show lines that match this, if set
+	Follow bool           // Keep printing new lines until interrupted
+}
+
+// A container whose logs are being printed
+type logContainer struct {
+	id      string
+	service string
+	tty     bool
+}
+
+// Print the logs of the provided services (or all of them if none are provided), interleaving their lines with a
+// color-coded prefix for each service
+func (c *Client) PrintServiceLogs(options LogOptions, serviceNames ...string) error {
+
+	cfg, _, err := c.LoadConfig()
+	if err != nil {
+		return err
+	}
+	prefix := cfg.Smartnode.ProjectName.Value.(string) + "_"
+
+	opts := []client.Opt{client.FromEnv, client.WithAPIVersionNegotiation()}
+	if socketPath := GetContainerSocketPath(cfg); socketPath != dockerSocketPath {
+		opts = append(opts, client.WithHost("unix://"+socketPath))
+	}
+	d, err := client.NewClientWithOpts(opts...)
+	if err != nil {
+		return fmt.Errorf("Error connecting to Docker: %w", err)
+	}
+	defer d.Close()
+
+	// Stop following the logs on Ctrl+C
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	// Find the containers
+	containers, err := getLogContainers(ctx, d, prefix, serviceNames)
+	if err != nil {
+		return err
+	}
+	width := 0
+	for _, container := range containers {
+		if len(container.service) > width {
+			width = len(container.service)
+		}
+	}
+
+	// Print each container's logs as they come in
+	var outputLock sync.Mutex
+	var wg sync.WaitGroup
+	errs := make([]error, len(containers))
+	for i, container := range containers {
+		wg.Add(1)
+		go func(i int, container logContainer) {
+			defer wg.Done()
+			logPrefix := color.New(logPrefixColors[i%len(logPrefixColors)]).Sprintf("%-*s |", width, container.service)
+			errs[i] = printContainerLogs(ctx, d, container, options, func(line string) {
+				outputLock.Lock()
+				defer outputLock.Unlock()
+				fmt.Printf("%s %s\n", logPrefix, line)
+			})
+		}(i, container)
+	}
+	wg.Wait()
+
+	// Being interrupted isn't an error
+	if ctx.Err() != nil {
+		return nil
+	}
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("Error reading the logs of %s: %w", containers[i].service, err)
+		}
+	}
+	return nil
+
+}
+
+// Get the containers for the provided services, or all of the project's containers if none are provided
+func getLogContainers(ctx context.Context, d *client.Client, prefix string, serviceNames []string) ([]logContainer, error) {
+	containerList, err := d.ContainerList(ctx, types.ContainerListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("Could not get docker containers: %w", err)
+	}
+
+	// Map the project's containers by service name
+	projectContainers := map[string]string{}
+	for _, container := range containerList {
+		for _, name := range container.Names {
+			if strings.HasPrefix(name, "/"+prefix) {
+				projectContainers[strings.TrimPrefix(name, "/"+prefix)] = container.ID
+			}
+		}
+	}
+
+	if len(serviceNames) == 0 {
+		for service := range projectContainers {
+			serviceNames = append(serviceNames, service)
+		}
+		sort.Strings(serviceNames)
+	}
+	if len(serviceNames) == 0 {
+		return nil, fmt.Errorf("No Rocket Pool containers were found; has the service been started?")
+	}
+
+	containers := []logContainer{}
+	for _, service := range serviceNames {
+		id, exists := projectContainers[service]
+		if !exists {
+			return nil, fmt.Errorf("The %s container was not found.", service)
+		}
+		info, err := d.ContainerInspect(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("Could not inspect the %s container: %w", service, err)
+		}
+		containers = append(containers, logContainer{
+			id:      id,
+			service: service,
+			tty:     info.Config != nil && info.Config.Tty,
+		})
+	}
+	return containers, nil
+}
+
+// Print the matching lines of a container's logs until they end or the context is cancelled
+func printContainerLogs(ctx context.Context, d *client.Client, container logContainer, options LogOptions, printLine func(string)) error {
+	reader, err := d.ContainerLogs(ctx, container.id, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Since:      options.Since,
+		Tail:       options.Tail,
+		Follow:     options.Follow,
+	})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	// Containers without a TTY multiplex stdout and stderr into one stream
+	var stream io.Reader = reader
+	if !container.tty {
+		pipeReader, pipeWriter := io.Pipe()
+		go func() {
+			_, err := stdcopy.StdCopy(pipeWriter, pipeWriter, reader)
+			pipeWriter.CloseWithError(err)
+		}()
+		stream = pipeReader
+	}
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if options.Filter == nil || options.Filter.MatchString(line) {
+			printLine(line)
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return scanner.Err()
+}
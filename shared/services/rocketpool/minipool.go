@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
 
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
@@ -120,6 +121,22 @@ func (c *Client) StakeMinipool(address common.Address) (api.StakeMinipoolRespons
 	return response, nil
 }
 
+// Get the progress of a solo validator's migration into a vacant minipool
+func (c *Client) GetMinipoolMigrationStatus(address common.Address) (api.MinipoolMigrationStatusResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool get-migration-status %s", address.Hex()))
+	if err != nil {
+		return api.MinipoolMigrationStatusResponse{}, fmt.Errorf("Could not get minipool migration status: %w", err)
+	}
+	var response api.MinipoolMigrationStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolMigrationStatusResponse{}, fmt.Errorf("Could not decode minipool migration status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolMigrationStatusResponse{}, fmt.Errorf("Could not get minipool migration status: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether a minipool is eligible for promotion
 func (c *Client) CanPromoteMinipool(address common.Address) (api.CanPromoteMinipoolResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-promote %s", address.Hex()))
@@ -216,6 +233,86 @@ func (c *Client) ExitMinipool(address common.Address) (api.ExitMinipoolResponse,
 	return response, nil
 }
 
+// Schedule a minipool's exit for the daemon to broadcast
+func (c *Client) ScheduleMinipoolExit(address common.Address, epoch uint64, maxExitQueue uint64) (api.ScheduleMinipoolExitResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool schedule-exit %s %d %d", address.Hex(), epoch, maxExitQueue))
+	if err != nil {
+		return api.ScheduleMinipoolExitResponse{}, fmt.Errorf("Could not schedule minipool exit: %w", err)
+	}
+	var response api.ScheduleMinipoolExitResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ScheduleMinipoolExitResponse{}, fmt.Errorf("Could not decode schedule minipool exit response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ScheduleMinipoolExitResponse{}, fmt.Errorf("Could not schedule minipool exit: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's scheduled minipool exits
+func (c *Client) GetScheduledExits() (api.GetScheduledExitsResponse, error) {
+	responseBytes, err := c.callAPI("minipool get-scheduled-exits")
+	if err != nil {
+		return api.GetScheduledExitsResponse{}, fmt.Errorf("Could not get scheduled exits: %w", err)
+	}
+	var response api.GetScheduledExitsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetScheduledExitsResponse{}, fmt.Errorf("Could not decode scheduled exits response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetScheduledExitsResponse{}, fmt.Errorf("Could not get scheduled exits: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Cancel a minipool's scheduled exit
+func (c *Client) CancelScheduledExit(address common.Address) (api.CancelScheduledExitResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool cancel-scheduled-exit %s", address.Hex()))
+	if err != nil {
+		return api.CancelScheduledExitResponse{}, fmt.Errorf("Could not cancel scheduled exit: %w", err)
+	}
+	var response api.CancelScheduledExitResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CancelScheduledExitResponse{}, fmt.Errorf("Could not decode cancel scheduled exit response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CancelScheduledExitResponse{}, fmt.Errorf("Could not cancel scheduled exit: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Sign voluntary exits for all of the node's validators and save them in an encrypted vault
+func (c *Client) PresignExits(password string) (api.PresignExitsResponse, error) {
+	responseBytes, err := c.callAPI("minipool presign-exits", password)
+	if err != nil {
+		return api.PresignExitsResponse{}, fmt.Errorf("Could not pre-sign exits: %w", err)
+	}
+	var response api.PresignExitsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PresignExitsResponse{}, fmt.Errorf("Could not decode pre-sign exits response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PresignExitsResponse{}, fmt.Errorf("Could not pre-sign exits: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Broadcast a pre-signed voluntary exit
+func (c *Client) BroadcastPresignedExit(validatorIndex string, epoch uint64, signature types.ValidatorSignature) (api.BroadcastPresignedExitResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool broadcast-presigned-exit %s %d %s", validatorIndex, epoch, signature.Hex()))
+	if err != nil {
+		return api.BroadcastPresignedExitResponse{}, fmt.Errorf("Could not broadcast pre-signed exit: %w", err)
+	}
+	var response api.BroadcastPresignedExitResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.BroadcastPresignedExitResponse{}, fmt.Errorf("Could not decode broadcast pre-signed exit response: %w", err)
+	}
+	if response.Error != "" {
+		return api.BroadcastPresignedExitResponse{}, fmt.Errorf("Could not broadcast pre-signed exit: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check all of the node's minipools for closure eligibility, and return the details of the closeable ones
 func (c *Client) GetMinipoolCloseDetailsForNode() (api.GetMinipoolCloseDetailsForNodeResponse, error) {
 	responseBytes, err := c.callAPI("minipool get-minipool-close-details-for-node")
@@ -24,6 +24,22 @@ func (c *Client) NodeFee() (api.NodeFeeResponse, error) {
 	return response, nil
 }
 
+// Get the base fees of the latest blocks
+func (c *Client) GetFeeHistory(blockCount uint64) (api.NetworkFeeHistoryResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network fee-history %d", blockCount))
+	if err != nil {
+		return api.NetworkFeeHistoryResponse{}, fmt.Errorf("Could not get fee history: %w", err)
+	}
+	var response api.NetworkFeeHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkFeeHistoryResponse{}, fmt.Errorf("Could not decode fee history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkFeeHistoryResponse{}, fmt.Errorf("Could not get fee history: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get network RPL price
 func (c *Client) RplPrice() (api.RplPriceResponse, error) {
 	responseBytes, err := c.callAPI("network rpl-price")
@@ -119,6 +135,70 @@ func (c *Client) GenerateRewardsTree(index uint64) (api.NetworkGenerateRewardsTr
 	return response, nil
 }
 
+// Set a request marker for the watchtower to profile a dry run of rewards tree generation for the given interval
+func (c *Client) DryRunRewardsTree(index uint64) (api.NetworkGenerateRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network dry-run-rewards-tree %d", index))
+	if err != nil {
+		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree dry run: %w", err)
+	}
+	var response api.NetworkGenerateRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not decode rewards tree dry run response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree dry run: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Set request markers for the watchtower to regenerate and archive the rewards trees for a range of intervals
+func (c *Client) ArchiveRewardsTrees(startIndex uint64, endIndex uint64) (api.NetworkGenerateRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network archive-rewards-trees %d %d", startIndex, endIndex))
+	if err != nil {
+		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree archiving: %w", err)
+	}
+	var response api.NetworkGenerateRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not decode rewards tree archiving response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree archiving: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Compare each node's attestation performance against the share of the Smoothing Pool it received for the given interval
+func (c *Client) AuditSmoothingPool(index uint64) (api.NetworkSmoothingPoolAuditResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network audit-smoothing-pool %d", index))
+	if err != nil {
+		return api.NetworkSmoothingPoolAuditResponse{}, fmt.Errorf("Could not audit the Smoothing Pool: %w", err)
+	}
+	var response api.NetworkSmoothingPoolAuditResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkSmoothingPoolAuditResponse{}, fmt.Errorf("Could not decode Smoothing Pool audit response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkSmoothingPoolAuditResponse{}, fmt.Errorf("Could not audit the Smoothing Pool: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Regenerate the rewards tree for the given interval and compare it against the canonical one
+func (c *Client) VerifyRewardsTree(index uint64) (api.NetworkVerifyRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network verify-rewards-tree %d", index))
+	if err != nil {
+		return api.NetworkVerifyRewardsTreeResponse{}, fmt.Errorf("Could not verify rewards tree: %w", err)
+	}
+	var response api.NetworkVerifyRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkVerifyRewardsTreeResponse{}, fmt.Errorf("Could not decode rewards tree verification response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkVerifyRewardsTreeResponse{}, fmt.Errorf("Could not verify rewards tree: %s", response.Error)
+	}
+	return response, nil
+}
+
 // GetActiveDAOProposals fetches information about active DAO proposals
 func (c *Client) GetActiveDAOProposals() (api.NetworkDAOProposalsResponse, error) {
 	responseBytes, err := c.callAPI("network dao-proposals")
@@ -0,0 +1,227 @@
+package rocketpool
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alessio/shellescape"
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Settings
+const (
+	NodeExportStagingFolder          string = "node-export"
+	NodeExportManifestFile           string = "manifest.json"
+	NodeExportChecksumsFile          string = "checksums.sha256"
+	NodeExportSlashingProtectionFile string = "slashing-protection.json"
+)
+
+// Details about a node export, used to verify it when it's imported
+type NodeExportManifest struct {
+	SmartnodeVersion      string    `json:"smartnodeVersion"`
+	Network               string    `json:"network"`
+	NodeAddress           string    `json:"nodeAddress"`
+	CreatedAt             time.Time `json:"createdAt"`
+	HasSlashingProtection bool      `json:"hasSlashingProtection"`
+	FileCount             int       `json:"fileCount"`
+}
+
+// The contents of a node export that has been extracted and verified
+type NodeExport struct {
+	Config             *config.RocketPoolConfig
+	Manifest           NodeExportManifest
+	SlashingProtection []byte
+}
+
+// A folder and the files in it that go into a node export
+type nodeExportSource struct {
+	path  string
+	files []string
+}
+
+// Create a fresh staging folder for a node export, copying the slashing protection file into it if one is provided
+func (c *Client) PrepareNodeExport(slashingProtectionPath string) error {
+	rootCmd, err := c.getEscalationCommand()
+	if err != nil {
+		return fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+	stagingPath, err := homedir.Expand(filepath.Join(c.configPath, NodeExportStagingFolder))
+	if err != nil {
+		return fmt.Errorf("error expanding staging path: %w", err)
+	}
+
+	// The folder belongs to the user so the manifest can be written to it; the slashing protection file is owned by the Validator client
+	cmd := fmt.Sprintf("%s rm -rf %s && mkdir -p %s", rootCmd, shellescape.Quote(stagingPath), shellescape.Quote(stagingPath))
+	if slashingProtectionPath != "" {
+		cmd += fmt.Sprintf(" && %s cp %s %s", rootCmd, shellescape.Quote(slashingProtectionPath), shellescape.Quote(filepath.Join(stagingPath, NodeExportSlashingProtectionFile)))
+	}
+	if err := c.printOutput(cmd); err != nil {
+		return fmt.Errorf("error preparing staging folder: %w", err)
+	}
+	return nil
+}
+
+// Archive the user settings, custom overrides, the given files from the data folder, and the staged slashing protection, piping the archive through an encryption command.
+// A manifest and the checksums of every file are included so the export can be verified when it's imported.
+func (c *Client) CreateNodeExport(dataPath string, dataFiles []string, manifest NodeExportManifest, encryptCmd string) error {
+	rootCmd, err := c.getEscalationCommand()
+	if err != nil {
+		return fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+	configPath, err := homedir.Expand(c.configPath)
+	if err != nil {
+		return fmt.Errorf("error expanding settings path: %w", err)
+	}
+	dataPath, err = homedir.Expand(dataPath)
+	if err != nil {
+		return fmt.Errorf("error expanding data path: %w", err)
+	}
+	stagingPath := filepath.Join(configPath, NodeExportStagingFolder)
+	defer func() {
+		_ = c.deleteStaging(NodeExportStagingFolder)
+	}()
+
+	// Get the escalated permissions up front so the escalation prompt doesn't collide with the encryption prompt
+	if err := c.printOutput(fmt.Sprintf("%s true", rootCmd)); err != nil {
+		return fmt.Errorf("error getting root privileges: %w", err)
+	}
+
+	// Get the files from each folder
+	configFiles := []string{SettingsFile}
+	if _, err := os.Stat(filepath.Join(configPath, overrideDir)); err == nil {
+		configFiles = append(configFiles, overrideDir)
+	}
+	stagedFiles := []string{}
+	if manifest.HasSlashingProtection {
+		stagedFiles = append(stagedFiles, NodeExportSlashingProtectionFile)
+	}
+	sources := []nodeExportSource{
+		{path: configPath, files: configFiles},
+		{path: dataPath, files: dataFiles},
+		{path: stagingPath, files: stagedFiles},
+	}
+
+	// Get the checksums of every file, relative to the root of the archive
+	checksums := ""
+	for _, source := range sources {
+		if len(source.files) == 0 {
+			continue
+		}
+		findCmd := fmt.Sprintf("cd %s && find %s -type f -exec sha256sum {} +", shellescape.Quote(source.path), shellescape.QuoteCommand(source.files))
+		output, err := c.readOutput(fmt.Sprintf("%s sh -c %s", rootCmd, shellescape.Quote(findCmd)))
+		if err != nil {
+			return fmt.Errorf("error getting checksums of the files in [%s]: %w", source.path, err)
+		}
+		checksums += string(output)
+	}
+	manifest.FileCount = strings.Count(checksums, "\n")
+
+	// Save the manifest and checksums alongside the staged files
+	manifestBytes, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error serializing manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(stagingPath, NodeExportManifestFile), manifestBytes, 0600); err != nil {
+		return fmt.Errorf("error saving manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(stagingPath, NodeExportChecksumsFile), []byte(checksums), 0600); err != nil {
+		return fmt.Errorf("error saving checksums: %w", err)
+	}
+	sources[2].files = append(sources[2].files, NodeExportManifestFile, NodeExportChecksumsFile)
+
+	// Archive and encrypt everything
+	tarCmdText := fmt.Sprintf("%s tar -czf -", rootCmd)
+	for _, source := range sources {
+		if len(source.files) > 0 {
+			tarCmdText += fmt.Sprintf(" -C %s %s", shellescape.Quote(source.path), shellescape.QuoteCommand(source.files))
+		}
+	}
+	return c.pipeCommands(tarCmdText, encryptCmd)
+}
+
+// Decrypt a node export, extract it into the staging folder, and verify every file against its checksum
+func (c *Client) ExtractNodeExport(decryptCmd string) (*NodeExport, error) {
+	cfg, err := c.extractArchive(decryptCmd, NodeExportStagingFolder)
+	if err != nil {
+		return nil, err
+	}
+	rootCmd, err := c.getEscalationCommand()
+	if err != nil {
+		return nil, fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+	stagingPath, err := homedir.Expand(filepath.Join(c.configPath, NodeExportStagingFolder))
+	if err != nil {
+		return nil, fmt.Errorf("error expanding staging path: %w", err)
+	}
+
+	// Verify the files
+	checkCmd := fmt.Sprintf("cd %s && sha256sum --quiet --strict -c %s", shellescape.Quote(stagingPath), NodeExportChecksumsFile)
+	if err := c.printOutput(fmt.Sprintf("%s sh -c %s", rootCmd, shellescape.Quote(checkCmd))); err != nil {
+		return nil, fmt.Errorf("the export is corrupted or incomplete; some of its files don't match their checksums: %w", err)
+	}
+
+	// Read the manifest and slashing protection
+	export := &NodeExport{
+		Config: cfg,
+	}
+	manifestBytes, err := c.readOutput(fmt.Sprintf("%s cat %s", rootCmd, shellescape.Quote(filepath.Join(stagingPath, NodeExportManifestFile))))
+	if err != nil {
+		return nil, fmt.Errorf("error reading manifest: %w", err)
+	}
+	if err := json.Unmarshal(manifestBytes, &export.Manifest); err != nil {
+		return nil, fmt.Errorf("error parsing manifest: %w", err)
+	}
+	if export.Manifest.HasSlashingProtection {
+		export.SlashingProtection, err = c.readOutput(fmt.Sprintf("%s cat %s", rootCmd, shellescape.Quote(filepath.Join(stagingPath, NodeExportSlashingProtectionFile))))
+		if err != nil {
+			return nil, fmt.Errorf("error reading slashing protection: %w", err)
+		}
+	}
+	return export, nil
+}
+
+// Move the files extracted from a node export into the config and data folders, and delete the staging folder
+func (c *Client) RestoreNodeExportFiles(dataPath string) error {
+	rootCmd, err := c.getEscalationCommand()
+	if err != nil {
+		return fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+	configPath, err := homedir.Expand(c.configPath)
+	if err != nil {
+		return fmt.Errorf("error expanding settings path: %w", err)
+	}
+	stagingPath := filepath.Join(configPath, NodeExportStagingFolder)
+
+	// The custom overrides belong to the user, regardless of who owned them on the original machine
+	stagedOverridePath := filepath.Join(stagingPath, overrideDir)
+	if _, err := os.Stat(stagedOverridePath); err == nil {
+		overridePath := shellescape.Quote(filepath.Join(configPath, overrideDir))
+		cmd := fmt.Sprintf("%s mkdir -p %s && %s cp -a %s/. %s/ && %s chown -R $(id -u):$(id -g) %s && %s rm -rf %s",
+			rootCmd, overridePath, rootCmd, shellescape.Quote(stagedOverridePath), overridePath, rootCmd, overridePath, rootCmd, shellescape.Quote(stagedOverridePath))
+		if err := c.printOutput(cmd); err != nil {
+			return fmt.Errorf("error restoring custom overrides: %w", err)
+		}
+	}
+
+	// The export's own files don't go anywhere
+	exportFiles := []string{NodeExportManifestFile, NodeExportChecksumsFile, NodeExportSlashingProtectionFile}
+	for i, file := range exportFiles {
+		exportFiles[i] = shellescape.Quote(filepath.Join(stagingPath, file))
+	}
+	if err := c.printOutput(fmt.Sprintf("%s rm -f %s", rootCmd, strings.Join(exportFiles, " "))); err != nil {
+		return fmt.Errorf("error cleaning up staging folder: %w", err)
+	}
+
+	return c.restoreStagedFiles(NodeExportStagingFolder, dataPath)
+}
+
+// Delete the staging folder used when importing a node export
+func (c *Client) DeleteNodeExportStaging() error {
+	return c.deleteStaging(NodeExportStagingFolder)
+}
@@ -436,8 +436,8 @@ func (c *Client) NodeWithdrawRpl(amountWei *big.Int) (api.NodeWithdrawRplRespons
 }
 
 // Check whether the node can make a deposit
-func (c *Client) CanNodeDeposit(amountWei *big.Int, minFee float64, salt *big.Int) (api.CanNodeDepositResponse, error) {
-	responseBytes, err := c.callAPI(fmt.Sprintf("node can-deposit %s %f %s", amountWei.String(), minFee, salt.String()))
+func (c *Client) CanNodeDeposit(amountWei *big.Int, minFee float64, salt *big.Int, dvPubkey *types.ValidatorPubkey) (api.CanNodeDepositResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node can-deposit %s%s %f %s", getDistributedValidatorPubkeyFlag(dvPubkey), amountWei.String(), minFee, salt.String()))
 	if err != nil {
 		return api.CanNodeDepositResponse{}, fmt.Errorf("Could not get can node deposit status: %w", err)
 	}
@@ -452,8 +452,8 @@ func (c *Client) CanNodeDeposit(amountWei *big.Int, minFee float64, salt *big.In
 }
 
 // Make a node deposit
-func (c *Client) NodeDeposit(amountWei *big.Int, minFee float64, salt *big.Int, useCreditBalance bool, submit bool) (api.NodeDepositResponse, error) {
-	responseBytes, err := c.callAPI(fmt.Sprintf("node deposit %s %f %s %t %t", amountWei.String(), minFee, salt.String(), useCreditBalance, submit))
+func (c *Client) NodeDeposit(amountWei *big.Int, minFee float64, salt *big.Int, dvPubkey *types.ValidatorPubkey, useCreditBalance bool, submit bool) (api.NodeDepositResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node deposit %s%s %f %s %t %t", getDistributedValidatorPubkeyFlag(dvPubkey), amountWei.String(), minFee, salt.String(), useCreditBalance, submit))
 	if err != nil {
 		return api.NodeDepositResponse{}, fmt.Errorf("Could not make node deposit: %w", err)
 	}
@@ -467,6 +467,30 @@ func (c *Client) NodeDeposit(amountWei *big.Int, minFee float64, salt *big.Int,
 	return response, nil
 }
 
+// Get the address and withdrawal credentials a new minipool will have with the given salt
+func (c *Client) GetExpectedMinipoolAddress(salt *big.Int) (api.GetExpectedMinipoolAddressResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node get-minipool-address %s", salt.String()))
+	if err != nil {
+		return api.GetExpectedMinipoolAddressResponse{}, fmt.Errorf("Could not get expected minipool address: %w", err)
+	}
+	var response api.GetExpectedMinipoolAddressResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetExpectedMinipoolAddressResponse{}, fmt.Errorf("Could not decode expected minipool address response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetExpectedMinipoolAddressResponse{}, fmt.Errorf("Could not get expected minipool address: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the flag that makes a deposit use a distributed validator instead of a new key from the node wallet
+func getDistributedValidatorPubkeyFlag(dvPubkey *types.ValidatorPubkey) string {
+	if dvPubkey == nil {
+		return ""
+	}
+	return fmt.Sprintf("--dv-pubkey 0x%s ", dvPubkey.Hex())
+}
+
 // Check whether the node can send tokens
 func (c *Client) CanNodeSend(amountWei *big.Int, token string, toAddress common.Address) (api.CanNodeSendResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("node can-send %s %s %s", amountWei.String(), token, toAddress.Hex()))
@@ -547,6 +571,22 @@ func (c *Client) NodeSync() (api.NodeSyncProgressResponse, error) {
 	return response, nil
 }
 
+// Get the upcoming duties of the node's validators
+func (c *Client) NodeDuties() (api.NodeDutiesResponse, error) {
+	responseBytes, err := c.callAPI("node get-duties")
+	if err != nil {
+		return api.NodeDutiesResponse{}, fmt.Errorf("Could not get node duties: %w", err)
+	}
+	var response api.NodeDutiesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeDutiesResponse{}, fmt.Errorf("Could not decode node duties response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeDutiesResponse{}, fmt.Errorf("Could not get node duties: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node has RPL rewards available to claim
 func (c *Client) CanNodeClaimRpl() (api.CanNodeClaimRplResponse, error) {
 	responseBytes, err := c.callAPI("node can-claim-rpl-rewards")
@@ -595,6 +635,118 @@ func (c *Client) NodeRewards() (api.NodeRewardsResponse, error) {
 	return response, nil
 }
 
+// Get the projected rewards for the current interval
+func (c *Client) NodeProjectedRewards() (api.NodeProjectedRewardsResponse, error) {
+	responseBytes, err := c.callAPI("node get-projected-rewards")
+	if err != nil {
+		return api.NodeProjectedRewardsResponse{}, fmt.Errorf("Could not get projected rewards: %w", err)
+	}
+	var response api.NodeProjectedRewardsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeProjectedRewardsResponse{}, fmt.Errorf("Could not decode projected rewards response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeProjectedRewardsResponse{}, fmt.Errorf("Could not get projected rewards: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's rewards and claims for every past interval
+func (c *Client) NodeRewardsHistory() (api.NodeRewardsHistoryResponse, error) {
+	responseBytes, err := c.callAPI("node get-rewards-history")
+	if err != nil {
+		return api.NodeRewardsHistoryResponse{}, fmt.Errorf("Could not get rewards history: %w", err)
+	}
+	var response api.NodeRewardsHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeRewardsHistoryResponse{}, fmt.Errorf("Could not decode rewards history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeRewardsHistoryResponse{}, fmt.Errorf("Could not get rewards history: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's most recent transactions that the daemon saw included in a block
+func (c *Client) NodeTransactionHistory(limit uint64) (api.NodeTransactionHistoryResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node get-tx-history %d", limit))
+	if err != nil {
+		return api.NodeTransactionHistoryResponse{}, fmt.Errorf("Could not get transaction history: %w", err)
+	}
+	var response api.NodeTransactionHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeTransactionHistoryResponse{}, fmt.Errorf("Could not decode transaction history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeTransactionHistoryResponse{}, fmt.Errorf("Could not get transaction history: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the alerts and notifications the daemon sent in the given number of days
+func (c *Client) NodeAlertHistory(days uint64, limit uint64) (api.NodeAlertHistoryResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node get-alert-history %d %d", days, limit))
+	if err != nil {
+		return api.NodeAlertHistoryResponse{}, fmt.Errorf("Could not get alert history: %w", err)
+	}
+	var response api.NodeAlertHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeAlertHistoryResponse{}, fmt.Errorf("Could not decode alert history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeAlertHistoryResponse{}, fmt.Errorf("Could not get alert history: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the duties of the node's validators in the most recent epochs the daemon checked
+func (c *Client) NodeDutyHistory(limit uint64) (api.NodeDutyHistoryResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node get-duty-history %d", limit))
+	if err != nil {
+		return api.NodeDutyHistoryResponse{}, fmt.Errorf("Could not get duty history: %w", err)
+	}
+	var response api.NodeDutyHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeDutyHistoryResponse{}, fmt.Errorf("Could not decode duty history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeDutyHistoryResponse{}, fmt.Errorf("Could not get duty history: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's balances and minipool details from the latest network state
+func (c *Client) NodeExportData() (api.NodeExportResponse, error) {
+	responseBytes, err := c.callAPI("node get-export-data")
+	if err != nil {
+		return api.NodeExportResponse{}, fmt.Errorf("Could not get node export data: %w", err)
+	}
+	var response api.NodeExportResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeExportResponse{}, fmt.Errorf("Could not decode node export data response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeExportResponse{}, fmt.Errorf("Could not get node export data: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get a snapshot of the latest network state for the node
+func (c *Client) NodeStateSnapshot() (api.NodeStateSnapshotResponse, error) {
+	responseBytes, err := c.callAPI("node get-state-snapshot")
+	if err != nil {
+		return api.NodeStateSnapshotResponse{}, fmt.Errorf("Could not get state snapshot: %w", err)
+	}
+	var response api.NodeStateSnapshotResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeStateSnapshotResponse{}, fmt.Errorf("Could not decode state snapshot response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeStateSnapshotResponse{}, fmt.Errorf("Could not get state snapshot: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get the deposit contract info for Rocket Pool and the Beacon Client
 func (c *Client) DepositContractInfo() (api.DepositContractInfoResponse, error) {
 	responseBytes, err := c.callAPI("node deposit-contract-info")
@@ -963,6 +1115,22 @@ func (c *Client) CanCreateVacantMinipool(amountWei *big.Int, minFee float64, sal
 	return response, nil
 }
 
+// Check whether a solo validator can be migrated into a vacant minipool
+func (c *Client) CanMigrateSoloValidator(pubkey types.ValidatorPubkey) (api.CanMigrateSoloValidatorResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node can-migrate-solo-validator %s", pubkey.Hex()))
+	if err != nil {
+		return api.CanMigrateSoloValidatorResponse{}, fmt.Errorf("Could not get can migrate solo validator status: %w", err)
+	}
+	var response api.CanMigrateSoloValidatorResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanMigrateSoloValidatorResponse{}, fmt.Errorf("Could not decode can migrate solo validator response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanMigrateSoloValidatorResponse{}, fmt.Errorf("Could not get can migrate solo validator status: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Create a vacant minipool, which can be used to migrate a solo staker
 func (c *Client) CreateVacantMinipool(amountWei *big.Int, minFee float64, salt *big.Int, pubkey types.ValidatorPubkey) (api.CreateVacantMinipoolResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("node create-vacant-minipool %s %f %s %s", amountWei.String(), minFee, salt.String(), pubkey.Hex()))
@@ -1011,6 +1179,102 @@ func (c *Client) GetEthBalance() (api.NodeEthBalanceResponse, error) {
 	return response, nil
 }
 
+// Get the nonce the node's next transaction will use
+func (c *Client) GetNodeNonce() (api.NodeGetNonceResponse, error) {
+	responseBytes, err := c.callAPI("node get-nonce")
+	if err != nil {
+		return api.NodeGetNonceResponse{}, fmt.Errorf("Could not get node nonce: %w", err)
+	}
+	var response api.NodeGetNonceResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeGetNonceResponse{}, fmt.Errorf("Could not decode node nonce response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeGetNonceResponse{}, fmt.Errorf("Could not get node nonce: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's transactions that are waiting in the transaction pool
+func (c *Client) GetPendingTransactions() (api.NodePendingTransactionsResponse, error) {
+	responseBytes, err := c.callAPI("node get-pending-txs")
+	if err != nil {
+		return api.NodePendingTransactionsResponse{}, fmt.Errorf("Could not get pending transactions: %w", err)
+	}
+	var response api.NodePendingTransactionsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodePendingTransactionsResponse{}, fmt.Errorf("Could not decode pending transactions response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodePendingTransactionsResponse{}, fmt.Errorf("Could not get pending transactions: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node's pending transaction with the given nonce can be replaced
+func (c *Client) CanReplaceTransaction(nonce uint64) (api.CanReplaceTransactionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node can-replace-tx %d", nonce))
+	if err != nil {
+		return api.CanReplaceTransactionResponse{}, fmt.Errorf("Could not get can replace transaction status: %w", err)
+	}
+	var response api.CanReplaceTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanReplaceTransactionResponse{}, fmt.Errorf("Could not decode can replace transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanReplaceTransactionResponse{}, fmt.Errorf("Could not get can replace transaction status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Replace the node's pending transaction with the given nonce
+func (c *Client) ReplaceTransaction(nonce uint64) (api.ReplaceTransactionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node replace-tx %d", nonce))
+	if err != nil {
+		return api.ReplaceTransactionResponse{}, fmt.Errorf("Could not replace transaction: %w", err)
+	}
+	var response api.ReplaceTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ReplaceTransactionResponse{}, fmt.Errorf("Could not decode replace transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ReplaceTransactionResponse{}, fmt.Errorf("Could not replace transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node's pending transaction with the given nonce can be cancelled
+func (c *Client) CanCancelTransaction(nonce uint64) (api.CanReplaceTransactionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node can-cancel-tx %d", nonce))
+	if err != nil {
+		return api.CanReplaceTransactionResponse{}, fmt.Errorf("Could not get can cancel transaction status: %w", err)
+	}
+	var response api.CanReplaceTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanReplaceTransactionResponse{}, fmt.Errorf("Could not decode can cancel transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanReplaceTransactionResponse{}, fmt.Errorf("Could not get can cancel transaction status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Cancel the node's pending transaction with the given nonce
+func (c *Client) CancelTransaction(nonce uint64) (api.ReplaceTransactionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node cancel-tx %d", nonce))
+	if err != nil {
+		return api.ReplaceTransactionResponse{}, fmt.Errorf("Could not cancel transaction: %w", err)
+	}
+	var response api.ReplaceTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ReplaceTransactionResponse{}, fmt.Errorf("Could not decode cancel transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ReplaceTransactionResponse{}, fmt.Errorf("Could not cancel transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Estimates the gas for sending a zero-value message with a payload
 func (c *Client) CanSendMessage(address common.Address, message []byte) (api.CanNodeSendMessageResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("node can-send-message %s %s", address.Hex(), hex.EncodeToString(message)))
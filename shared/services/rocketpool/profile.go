@@ -0,0 +1,36 @@
+package rocketpool
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The folder in the base config directory that holds the config directory of each named profile
+const ProfilesFolder string = "profiles"
+
+// Profile names are used in paths and Docker project names, so they're kept simple
+var profileNamePattern = regexp.MustCompile("^[a-z0-9][a-z0-9_-]*$")
+
+// Get the config directory of a named profile, which lives under the base config directory
+func GetProfileConfigPath(baseConfigPath string, profile string) (string, error) {
+	if !profileNamePattern.MatchString(profile) {
+		return "", fmt.Errorf("Invalid profile name '%s'; profile names can only contain lowercase letters, numbers, dashes and underscores.", profile)
+	}
+	return filepath.Join(baseConfigPath, ProfilesFolder, profile), nil
+}
+
+// Give a new profile's config its own Docker project so its containers don't clash with other profiles, and select the
+// network the profile is named after (if any)
+func applyProfileDefaults(cfg *config.RocketPoolConfig, profile string) {
+	cfg.Smartnode.ProjectName.Value = fmt.Sprintf("rocketpool-%s", profile)
+	for _, option := range cfg.Smartnode.Network.Options {
+		if network := option.Value.(cfgtypes.Network); string(network) == profile {
+			cfg.ChangeNetwork(network)
+			return
+		}
+	}
+}
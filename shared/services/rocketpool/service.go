@@ -41,6 +41,22 @@ func (c *Client) GetClientStatus() (api.ClientStatusResponse, error) {
 	return response, nil
 }
 
+// Checks the clients' connectivity and the settings that depend on the node's on-chain state
+func (c *Client) CheckConfig() (api.CheckConfigResponse, error) {
+	responseBytes, err := c.callAPI("service check-config")
+	if err != nil {
+		return api.CheckConfigResponse{}, fmt.Errorf("Could not check config: %w", err)
+	}
+	var response api.CheckConfigResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CheckConfigResponse{}, fmt.Errorf("Could not decode check config response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CheckConfigResponse{}, fmt.Errorf("Could not check config: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Restarts the Validator client
 func (c *Client) RestartVc() (api.RestartVcResponse, error) {
 	responseBytes, err := c.callAPI("service restart-vc")
@@ -56,3 +72,19 @@ func (c *Client) RestartVc() (api.RestartVcResponse, error) {
 	}
 	return response, nil
 }
+
+// Replaces the Engine API JWT secret and restarts the Execution and Consensus clients
+func (c *Client) RotateJwtSecret() (api.RotateJwtSecretResponse, error) {
+	responseBytes, err := c.callAPI("service rotate-jwt-secret")
+	if err != nil {
+		return api.RotateJwtSecretResponse{}, fmt.Errorf("Could not rotate JWT secret: %w", err)
+	}
+	var response api.RotateJwtSecretResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RotateJwtSecretResponse{}, fmt.Errorf("Could not decode rotate JWT secret response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RotateJwtSecretResponse{}, fmt.Errorf("Could not rotate JWT secret: %s", response.Error)
+	}
+	return response, nil
+}
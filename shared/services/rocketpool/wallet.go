@@ -2,9 +2,11 @@ package rocketpool
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
 
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
@@ -41,9 +43,101 @@ func (c *Client) SetPassword(password string) (api.SetPasswordResponse, error) {
 	return response, nil
 }
 
+// List the stored node wallets
+func (c *Client) ListWallets() (api.ListWalletsResponse, error) {
+	responseBytes, err := c.callAPI("wallet list")
+	if err != nil {
+		return api.ListWalletsResponse{}, fmt.Errorf("Could not list wallets: %w", err)
+	}
+	var response api.ListWalletsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ListWalletsResponse{}, fmt.Errorf("Could not decode list wallets response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ListWalletsResponse{}, fmt.Errorf("Could not list wallets: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Store the active node wallet and switch to another one
+func (c *Client) SwitchWallet(name string) (api.SwitchWalletResponse, error) {
+	responseBytes, err := c.callAPI("wallet switch", name)
+	if err != nil {
+		return api.SwitchWalletResponse{}, fmt.Errorf("Could not switch wallet: %w", err)
+	}
+	var response api.SwitchWalletResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SwitchWalletResponse{}, fmt.Errorf("Could not decode switch wallet response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SwitchWalletResponse{}, fmt.Errorf("Could not switch wallet: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Export validator keys as EIP-2335 keystores along with an EIP-3076 slashing protection file
+func (c *Client) ExportKeys(password string, pubkeys []types.ValidatorPubkey) (api.ExportKeysResponse, error) {
+	command := "wallet export-keys "
+	if len(pubkeys) > 0 {
+		pubkeyStrings := make([]string, len(pubkeys))
+		for i, pubkey := range pubkeys {
+			pubkeyStrings[i] = pubkey.Hex()
+		}
+		command += fmt.Sprintf("--pubkeys %s ", strings.Join(pubkeyStrings, ","))
+	}
+	responseBytes, err := c.callAPI(command, password)
+	if err != nil {
+		return api.ExportKeysResponse{}, fmt.Errorf("Could not export validator keys: %w", err)
+	}
+	var response api.ExportKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ExportKeysResponse{}, fmt.Errorf("Could not decode export validator keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ExportKeysResponse{}, fmt.Errorf("Could not export validator keys: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Export an EIP-3076 slashing protection file for all of the node's validators
+func (c *Client) ExportSlashingProtection() (api.ExportSlashingProtectionResponse, error) {
+	responseBytes, err := c.callAPI("wallet export-slashing-protection")
+	if err != nil {
+		return api.ExportSlashingProtectionResponse{}, fmt.Errorf("Could not export slashing protection: %w", err)
+	}
+	var response api.ExportSlashingProtectionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ExportSlashingProtectionResponse{}, fmt.Errorf("Could not decode export slashing protection response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ExportSlashingProtectionResponse{}, fmt.Errorf("Could not export slashing protection: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Load the validator keys for the given pubkeys from the custom keystores folder
+func (c *Client) ImportKeys(pubkeys []types.ValidatorPubkey) (api.ImportKeysResponse, error) {
+	pubkeyStrings := make([]string, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		pubkeyStrings[i] = pubkey.Hex()
+	}
+	responseBytes, err := c.callAPI("wallet import-keys", strings.Join(pubkeyStrings, ","))
+	if err != nil {
+		return api.ImportKeysResponse{}, fmt.Errorf("Could not import validator keys: %w", err)
+	}
+	var response api.ImportKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ImportKeysResponse{}, fmt.Errorf("Could not decode import validator keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ImportKeysResponse{}, fmt.Errorf("Could not import validator keys: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Initialize wallet
-func (c *Client) InitWallet(derivationPath string) (api.InitWalletResponse, error) {
-	responseBytes, err := c.callAPI("wallet init --derivation-path", derivationPath)
+func (c *Client) InitWallet(derivationPath string, passphrase string) (api.InitWalletResponse, error) {
+	responseBytes, err := c.callAPI("wallet init", append(getMnemonicPassphraseArgs(passphrase), "--derivation-path", derivationPath)...)
 	if err != nil {
 		return api.InitWalletResponse{}, fmt.Errorf("Could not initialize wallet: %w", err)
 	}
@@ -58,7 +152,7 @@ func (c *Client) InitWallet(derivationPath string) (api.InitWalletResponse, erro
 }
 
 // Recover wallet
-func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, derivationPath string, walletIndex uint) (api.RecoverWalletResponse, error) {
+func (c *Client) RecoverWallet(mnemonic string, passphrase string, skipValidatorKeyRecovery bool, validatorKeyPath string, validatorKeyPathOverrides string, derivationPath string, walletIndex uint) (api.RecoverWalletResponse, error) {
 	command := "wallet recover "
 	if skipValidatorKeyRecovery {
 		command += "--skip-validator-key-recovery "
@@ -66,9 +160,8 @@ func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, d
 	if walletIndex != 0 {
 		command += fmt.Sprintf("--wallet-index %d ", walletIndex)
 	}
-	command += "--derivation-path"
 
-	responseBytes, err := c.callAPI(command, derivationPath, mnemonic)
+	responseBytes, err := c.callAPI(command, append(append(getMnemonicPassphraseArgs(passphrase), getValidatorKeyPathArgs(validatorKeyPath, validatorKeyPathOverrides)...), "--derivation-path", derivationPath, mnemonic)...)
 	if err != nil {
 		return api.RecoverWalletResponse{}, fmt.Errorf("Could not recover wallet: %w", err)
 	}
@@ -83,13 +176,13 @@ func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, d
 }
 
 // Search and recover wallet
-func (c *Client) SearchAndRecoverWallet(mnemonic string, address common.Address, skipValidatorKeyRecovery bool) (api.SearchAndRecoverWalletResponse, error) {
+func (c *Client) SearchAndRecoverWallet(mnemonic string, passphrase string, address common.Address, skipValidatorKeyRecovery bool, validatorKeyPath string, validatorKeyPathOverrides string) (api.SearchAndRecoverWalletResponse, error) {
 	command := "wallet search-and-recover "
 	if skipValidatorKeyRecovery {
 		command += "--skip-validator-key-recovery "
 	}
 
-	responseBytes, err := c.callAPI(command, mnemonic, address.Hex())
+	responseBytes, err := c.callAPI(command, append(append(getMnemonicPassphraseArgs(passphrase), getValidatorKeyPathArgs(validatorKeyPath, validatorKeyPathOverrides)...), mnemonic, address.Hex())...)
 	if err != nil {
 		return api.SearchAndRecoverWalletResponse{}, fmt.Errorf("Could not search and recover wallet: %w", err)
 	}
@@ -104,7 +197,7 @@ func (c *Client) SearchAndRecoverWallet(mnemonic string, address common.Address,
 }
 
 // Recover wallet
-func (c *Client) TestRecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, derivationPath string, walletIndex uint) (api.RecoverWalletResponse, error) {
+func (c *Client) TestRecoverWallet(mnemonic string, passphrase string, skipValidatorKeyRecovery bool, validatorKeyPath string, validatorKeyPathOverrides string, derivationPath string, walletIndex uint) (api.RecoverWalletResponse, error) {
 	command := "wallet test-recovery "
 	if skipValidatorKeyRecovery {
 		command += "--skip-validator-key-recovery "
@@ -112,9 +205,8 @@ func (c *Client) TestRecoverWallet(mnemonic string, skipValidatorKeyRecovery boo
 	if walletIndex != 0 {
 		command += fmt.Sprintf("--wallet-index %d ", walletIndex)
 	}
-	command += "--derivation-path"
 
-	responseBytes, err := c.callAPI(command, derivationPath, mnemonic)
+	responseBytes, err := c.callAPI(command, append(append(getMnemonicPassphraseArgs(passphrase), getValidatorKeyPathArgs(validatorKeyPath, validatorKeyPathOverrides)...), "--derivation-path", derivationPath, mnemonic)...)
 	if err != nil {
 		return api.RecoverWalletResponse{}, fmt.Errorf("Could not test recover wallet: %w", err)
 	}
@@ -129,13 +221,13 @@ func (c *Client) TestRecoverWallet(mnemonic string, skipValidatorKeyRecovery boo
 }
 
 // Search and recover wallet
-func (c *Client) TestSearchAndRecoverWallet(mnemonic string, address common.Address, skipValidatorKeyRecovery bool) (api.SearchAndRecoverWalletResponse, error) {
+func (c *Client) TestSearchAndRecoverWallet(mnemonic string, passphrase string, address common.Address, skipValidatorKeyRecovery bool, validatorKeyPath string, validatorKeyPathOverrides string) (api.SearchAndRecoverWalletResponse, error) {
 	command := "wallet test-search-and-recover "
 	if skipValidatorKeyRecovery {
 		command += "--skip-validator-key-recovery "
 	}
 
-	responseBytes, err := c.callAPI(command, mnemonic, address.Hex())
+	responseBytes, err := c.callAPI(command, append(append(getMnemonicPassphraseArgs(passphrase), getValidatorKeyPathArgs(validatorKeyPath, validatorKeyPathOverrides)...), mnemonic, address.Hex())...)
 	if err != nil {
 		return api.SearchAndRecoverWalletResponse{}, fmt.Errorf("Could not test search and recover wallet: %w", err)
 	}
@@ -165,6 +257,22 @@ func (c *Client) RebuildWallet() (api.RebuildWalletResponse, error) {
 	return response, nil
 }
 
+// Audit the Validator client's keys against the node's minipools and the Beacon chain
+func (c *Client) AuditValidatorKeys() (api.AuditValidatorKeysResponse, error) {
+	responseBytes, err := c.callAPI("wallet audit-keys")
+	if err != nil {
+		return api.AuditValidatorKeysResponse{}, fmt.Errorf("Could not audit validator keys: %w", err)
+	}
+	var response api.AuditValidatorKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.AuditValidatorKeysResponse{}, fmt.Errorf("Could not decode audit validator keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.AuditValidatorKeysResponse{}, fmt.Errorf("Could not audit validator keys: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Estimate the gas required to set an ENS reverse record to a name
 func (c *Client) EstimateGasSetEnsName(name string) (api.SetEnsNameResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("wallet estimate-gas-set-ens-name %s", name))
@@ -212,3 +320,55 @@ func (c *Client) ExportWallet() (api.ExportWalletResponse, error) {
 	}
 	return response, nil
 }
+
+// Get the API args for a BIP-39 mnemonic passphrase; they're passed separately from the command so the passphrase is quoted
+func getMnemonicPassphraseArgs(passphrase string) []string {
+	if passphrase == "" {
+		return []string{}
+	}
+	return []string{"--mnemonic-passphrase", passphrase}
+}
+
+// Get the API args for non-standard validator key derivation paths
+func getValidatorKeyPathArgs(keyPath string, overrides string) []string {
+	args := []string{}
+	if keyPath != "" {
+		args = append(args, "--validator-key-path", keyPath)
+	}
+	if overrides != "" {
+		args = append(args, "--validator-key-path-overrides", overrides)
+	}
+	return args
+}
+
+// Sign a serialized transaction with the node account
+func (c *Client) SignTransaction(serializedTx string) (api.SignTransactionResponse, error) {
+	responseBytes, err := c.callAPI("wallet sign-tx", serializedTx)
+	if err != nil {
+		return api.SignTransactionResponse{}, fmt.Errorf("Could not sign transaction: %w", err)
+	}
+	var response api.SignTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SignTransactionResponse{}, fmt.Errorf("Could not decode sign-tx response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SignTransactionResponse{}, fmt.Errorf("Could not sign transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Submit a signed, serialized transaction to the network
+func (c *Client) SendRawTransaction(signedTx string) (api.SendRawTransactionResponse, error) {
+	responseBytes, err := c.callAPI("wallet send-raw", signedTx)
+	if err != nil {
+		return api.SendRawTransactionResponse{}, fmt.Errorf("Could not submit transaction: %w", err)
+	}
+	var response api.SendRawTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SendRawTransactionResponse{}, fmt.Errorf("Could not decode send-raw response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SendRawTransactionResponse{}, fmt.Errorf("Could not submit transaction: %s", response.Error)
+	}
+	return response, nil
+}
@@ -0,0 +1,210 @@
+package safe
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/goccy/go-json"
+)
+
+// Settings
+const (
+	requestTimeout time.Duration = 30 * time.Second
+	proposalOrigin string        = "Rocket Pool Smartnode"
+)
+
+// EIP-712 type hashes used by Safe v1.3.0 and later
+var (
+	domainTypeHash = crypto.Keccak256([]byte("EIP712Domain(uint256 chainId,address verifyingContract)"))
+	safeTxTypeHash = crypto.Keccak256([]byte("SafeTx(address to,uint256 value,bytes data,uint8 operation,uint256 safeTxGas,uint256 baseGas,uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)"))
+)
+
+// A client for the Safe transaction service, scoped to a single Safe
+type Client struct {
+	serviceUrl string
+	address    common.Address
+	chainID    *big.Int
+	client     *http.Client
+}
+
+// The details of a Safe
+type SafeInfo struct {
+	Nonce     uint64           `json:"nonce"`
+	Threshold uint64           `json:"threshold"`
+	Owners    []common.Address `json:"owners"`
+}
+
+// A multisig transaction known to the transaction service
+type Transaction struct {
+	SafeTxHash            common.Hash    `json:"safeTxHash"`
+	Nonce                 uint64         `json:"nonce"`
+	To                    common.Address `json:"to"`
+	IsExecuted            bool           `json:"isExecuted"`
+	IsSuccessful          *bool          `json:"isSuccessful"`
+	TransactionHash       *common.Hash   `json:"transactionHash"`
+	ConfirmationsRequired uint64         `json:"confirmationsRequired"`
+	Confirmations         []struct {
+		Owner common.Address `json:"owner"`
+	} `json:"confirmations"`
+}
+
+// A page of multisig transactions
+type transactionList struct {
+	Results []Transaction `json:"results"`
+}
+
+// Create a new transaction service client
+func NewClient(serviceUrl string, address common.Address, chainID *big.Int) *Client {
+	return &Client{
+		serviceUrl: strings.TrimSuffix(serviceUrl, "/"),
+		address:    address,
+		chainID:    chainID,
+		client:     &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Get the address of the Safe
+func (c *Client) GetAddress() common.Address {
+	return c.address
+}
+
+// Get the Safe's current nonce, threshold and owners
+func (c *Client) GetSafeInfo() (SafeInfo, error) {
+	var info SafeInfo
+	err := c.call(http.MethodGet, fmt.Sprintf("/api/v1/safes/%s/", c.address.Hex()), nil, &info)
+	if err != nil {
+		return SafeInfo{}, fmt.Errorf("error getting Safe %s: %w", c.address.Hex(), err)
+	}
+	return info, nil
+}
+
+// Get the nonce for a new proposal, which comes after every transaction that's already queued
+func (c *Client) GetNextNonce() (uint64, error) {
+	info, err := c.GetSafeInfo()
+	if err != nil {
+		return 0, err
+	}
+	var queued transactionList
+	err = c.call(http.MethodGet, fmt.Sprintf("/api/v1/safes/%s/multisig-transactions/?executed=false&nonce__gte=%d&ordering=-nonce&limit=1", c.address.Hex(), info.Nonce), nil, &queued)
+	if err != nil {
+		return 0, fmt.Errorf("error getting queued Safe transactions: %w", err)
+	}
+	if len(queued.Results) > 0 && queued.Results[0].Nonce >= info.Nonce {
+		return queued.Results[0].Nonce + 1, nil
+	}
+	return info.Nonce, nil
+}
+
+// Get the EIP-712 hash of a Safe transaction that the owners sign
+func (c *Client) GetSafeTxHash(to common.Address, value *big.Int, data []byte, nonce uint64) common.Hash {
+	domainSeparator := crypto.Keccak256(
+		domainTypeHash,
+		math.U256Bytes(new(big.Int).Set(c.chainID)),
+		common.LeftPadBytes(c.address.Bytes(), 32),
+	)
+	structHash := crypto.Keccak256(
+		safeTxTypeHash,
+		common.LeftPadBytes(to.Bytes(), 32),
+		math.U256Bytes(new(big.Int).Set(value)),
+		crypto.Keccak256(data),
+		make([]byte, 32), // operation: call
+		make([]byte, 32), // safeTxGas
+		make([]byte, 32), // baseGas
+		make([]byte, 32), // gasPrice
+		make([]byte, 32), // gasToken
+		make([]byte, 32), // refundReceiver
+		math.U256Bytes(new(big.Int).SetUint64(nonce)),
+	)
+	return common.BytesToHash(crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, structHash))
+}
+
+// Propose a transaction to the Safe, with the proposer's signature of its Safe transaction hash
+func (c *Client) ProposeTransaction(to common.Address, value *big.Int, data []byte, nonce uint64, safeTxHash common.Hash, sender common.Address, signature []byte) error {
+	var encodedData *string
+	if len(data) > 0 {
+		hexData := hexutil.Encode(data)
+		encodedData = &hexData
+	}
+	request := map[string]interface{}{
+		"safe":                    c.address.Hex(),
+		"to":                      to.Hex(),
+		"value":                   value.String(),
+		"data":                    encodedData,
+		"operation":               0,
+		"safeTxGas":               "0",
+		"baseGas":                 "0",
+		"gasPrice":                "0",
+		"gasToken":                common.Address{}.Hex(),
+		"refundReceiver":          common.Address{}.Hex(),
+		"nonce":                   nonce,
+		"contractTransactionHash": safeTxHash.Hex(),
+		"sender":                  sender.Hex(),
+		"signature":               hexutil.Encode(signature),
+		"origin":                  proposalOrigin,
+	}
+	err := c.call(http.MethodPost, fmt.Sprintf("/api/v1/safes/%s/multisig-transactions/", c.address.Hex()), request, nil)
+	if err != nil {
+		return fmt.Errorf("error proposing transaction to Safe %s: %w", c.address.Hex(), err)
+	}
+	return nil
+}
+
+// Get a multisig transaction by its Safe transaction hash
+func (c *Client) GetTransaction(safeTxHash common.Hash) (Transaction, error) {
+	var tx Transaction
+	err := c.call(http.MethodGet, fmt.Sprintf("/api/v1/multisig-transactions/%s/", safeTxHash.Hex()), nil, &tx)
+	if err != nil {
+		return Transaction{}, fmt.Errorf("error getting Safe transaction %s: %w", safeTxHash.Hex(), err)
+	}
+	return tx, nil
+}
+
+// Call a transaction service endpoint
+func (c *Client) call(method string, path string, request interface{}, response interface{}) error {
+
+	var body io.Reader
+	if request != nil {
+		requestBytes, err := json.Marshal(request)
+		if err != nil {
+			return fmt.Errorf("error serializing request: %w", err)
+		}
+		body = bytes.NewReader(requestBytes)
+	}
+	httpRequest, err := http.NewRequest(method, c.serviceUrl+path, body)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	if request != nil {
+		httpRequest.Header.Set("Content-Type", "application/json")
+	}
+
+	httpResponse, err := c.client.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("error calling the Safe transaction service: %w", err)
+	}
+	defer httpResponse.Body.Close()
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return fmt.Errorf("error reading the Safe transaction service response: %w", err)
+	}
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		return fmt.Errorf("the Safe transaction service returned status %s: %s", httpResponse.Status, string(responseBody))
+	}
+	if response == nil {
+		return nil
+	}
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return fmt.Errorf("error decoding the Safe transaction service response: %w", err)
+	}
+	return nil
+
+}
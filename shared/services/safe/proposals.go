@@ -0,0 +1,91 @@
+package safe
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/goccy/go-json"
+)
+
+// Settings
+const (
+	proposalsFileMode = 0644
+)
+
+// A node transaction that was proposed to the Safe and hasn't been executed yet
+type Proposal struct {
+	SafeTxHash common.Hash    `json:"safeTxHash"`
+	Nonce      uint64         `json:"nonce"`
+	To         common.Address `json:"to"`
+	Value      *big.Int       `json:"value"`
+	Data       hexutil.Bytes  `json:"data"`
+	ProposedAt time.Time      `json:"proposedAt"`
+
+	// The hashes the Smartnode reported for this transaction before it was proposed, so waiting on them can follow the Safe transaction instead
+	TxHashes []common.Hash `json:"txHashes"`
+}
+
+// Check if a proposal is for the same call as another transaction
+func (p *Proposal) Matches(to common.Address, value *big.Int, data []byte) bool {
+	return p.To == to && p.Value.Cmp(value) == 0 && bytes.Equal(p.Data, data)
+}
+
+// Check if a proposal was reported with the given transaction hash
+func (p *Proposal) HasTxHash(hash common.Hash) bool {
+	for _, txHash := range p.TxHashes {
+		if txHash == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// Load the pending proposals from disk
+func LoadProposals(path string) ([]Proposal, error) {
+	proposals := []Proposal{}
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return proposals, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading Safe proposals file [%s]: %w", path, err)
+	}
+	err = json.Unmarshal(bytes, &proposals)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing Safe proposals file [%s]: %w", path, err)
+	}
+	return proposals, nil
+}
+
+// Save the pending proposals to disk
+func SaveProposals(path string, proposals []Proposal) error {
+	bytes, err := json.Marshal(proposals)
+	if err != nil {
+		return fmt.Errorf("error serializing Safe proposals: %w", err)
+	}
+	err = os.WriteFile(path, bytes, proposalsFileMode)
+	if err != nil {
+		return fmt.Errorf("error writing Safe proposals file [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Remove a proposal from the pending proposals on disk
+func RemoveProposal(path string, safeTxHash common.Hash) error {
+	proposals, err := LoadProposals(path)
+	if err != nil {
+		return err
+	}
+	remaining := []Proposal{}
+	for _, proposal := range proposals {
+		if proposal.SafeTxHash != safeTxHash {
+			remaining = append(remaining, proposal)
+		}
+	}
+	return SaveProposals(path, remaining)
+}
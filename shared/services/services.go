@@ -13,16 +13,20 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/store"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	lokeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lodestar"
 	nmkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	prkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
 	tkkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
+	w3skeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/web3signer"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -45,7 +49,12 @@ var (
 	rplFaucet          *contracts.RPLFaucet
 	snapshotDelegation *contracts.SnapshotDelegation
 	beaconClient       beacon.Client
+	beaconEventStream  *BeaconEventStream
+	ecHeadWatcher      *ExecutionHeadWatcher
 	docker             *client.Client
+	alertManager       *alerting.Manager
+	metadataCache      *chainMetadataCache
+	daemonStore        *store.Store
 
 	initCfg                sync.Once
 	initPasswordManager    sync.Once
@@ -57,7 +66,12 @@ var (
 	initRplFaucet          sync.Once
 	initSnapshotDelegation sync.Once
 	initBeaconClient       sync.Once
+	initBeaconEventStream  sync.Once
+	initEcHeadWatcher      sync.Once
 	initDocker             sync.Once
+	initAlertManager       sync.Once
+	initMetadataCache      sync.Once
+	initDaemonStore        sync.Once
 )
 
 //
@@ -73,7 +87,7 @@ func GetPasswordManager(c *cli.Context) (*passwords.PasswordManager, error) {
 	if err != nil {
 		return nil, err
 	}
-	return getPasswordManager(cfg), nil
+	return getPasswordManager(cfg)
 }
 
 func GetWallet(c *cli.Context) (*wallet.Wallet, error) {
@@ -81,7 +95,10 @@ func GetWallet(c *cli.Context) (*wallet.Wallet, error) {
 	if err != nil {
 		return nil, err
 	}
-	pm := getPasswordManager(cfg)
+	pm, err := getPasswordManager(cfg)
+	if err != nil {
+		return nil, err
+	}
 	return getWallet(c, cfg, pm)
 }
 
@@ -148,10 +165,50 @@ func GetBeaconClient(c *cli.Context) (*BeaconClientManager, error) {
 	return getBeaconClient(c, cfg)
 }
 
+func GetBeaconEventStream(c *cli.Context) (*BeaconEventStream, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := getBeaconClient(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return getBeaconEventStream(bc), nil
+}
+
+func GetExecutionHeadWatcher(c *cli.Context) (*ExecutionHeadWatcher, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := getEthClient(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return getExecutionHeadWatcher(ec), nil
+}
+
 func GetDocker(c *cli.Context) (*client.Client, error) {
 	return getDocker()
 }
 
+func GetAlertManager(c *cli.Context) (*alerting.Manager, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getAlertManager(cfg)
+}
+
+func GetStore(c *cli.Context) (*store.Store, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getStore(cfg)
+}
+
 //
 // Service instance getters
 //
@@ -168,11 +225,36 @@ func getConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
 	return cfg, err
 }
 
-func getPasswordManager(cfg *config.RocketPoolConfig) *passwords.PasswordManager {
+func getPasswordManager(cfg *config.RocketPoolConfig) (*passwords.PasswordManager, error) {
+	var err error
 	initPasswordManager.Do(func() {
-		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()))
+		passwordPath := os.ExpandEnv(cfg.Smartnode.GetPasswordPath())
+		if cfg.IsNativeMode && cfg.Smartnode.UsePasswordKeychain.Value == true {
+			// Daemons run by systemd have no D-Bus session to reach the keychain with, so their units pass the password as a credential
+			if credentialPath, exists := passwords.GetSystemdCredentialPath(); exists {
+				passwordManager = passwords.NewCredentialPasswordManager(passwordPath, credentialPath)
+				return
+			}
+
+			// Never fall back to the plaintext password file, since the user opted out of it
+			if keychainErr := passwords.KeychainAvailable(); keychainErr != nil {
+				err = fmt.Errorf("OS keychain password storage is enabled, but the keychain can't be used: %w\n"+
+					"Run this in a session that can reach the keychain, give the daemon the password as the '%s' systemd credential (`rocketpool service install --native` sets this up), "+
+					"or disable OS keychain password storage in the Smartnode settings.", keychainErr, passwords.SystemdCredentialName)
+				return
+			}
+			passwordManager = passwords.NewKeychainPasswordManager(passwordPath)
+			return
+		}
+		passwordManager = passwords.NewPasswordManager(passwordPath)
 	})
-	return passwordManager
+	if err != nil {
+		return nil, err
+	}
+	if passwordManager == nil {
+		return nil, fmt.Errorf("the password manager could not be created")
+	}
+	return passwordManager, nil
 }
 
 func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.PasswordManager) (*wallet.Wallet, error) {
@@ -203,7 +285,73 @@ func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.Passw
 			return
 		}
 
-		// Keystores
+		// Hardware wallet for the node account
+		hardwareWallet := cfg.Smartnode.HardwareWallet.Value.(cfgtypes.HardwareWallet)
+		if hardwareWallet != cfgtypes.HardwareWallet_None {
+			err = nodeWallet.SetHardwareWallet(string(hardwareWallet), cfg.Smartnode.HardwareWalletPath.Value.(string))
+			if err != nil {
+				return
+			}
+		}
+
+		// Key management service for the node account
+		kmsProvider := cfg.Smartnode.KmsProvider.Value.(cfgtypes.KmsProvider)
+		if kmsProvider != cfgtypes.KmsProvider_None {
+			err = nodeWallet.SetKmsSigner(string(kmsProvider), cfg.Smartnode.KmsKeyId.Value.(string), cfg.Smartnode.KmsRegion.Value.(string), cfg.Smartnode.Pkcs11Module.Value.(string))
+			if err != nil {
+				return
+			}
+		}
+
+		// Safe used as the node account
+		safeAddress := cfg.Smartnode.SafeAddress.Value.(string)
+		if safeAddress != "" {
+			nodeWallet.SetSafe(common.HexToAddress(safeAddress), cfg.Smartnode.SafeTransactionServiceUrl.Value.(string), os.ExpandEnv(cfg.Smartnode.GetSafeProposalsPath()))
+		}
+
+		// Node account address for machines without the node wallet
+		watchOnlyAddress := cfg.Smartnode.WatchOnlyAddress.Value.(string)
+		if watchOnlyAddress != "" {
+			nodeWallet.SetWatchOnlyAddress(common.HexToAddress(watchOnlyAddress))
+		}
+
+		// Transactions saved unsigned so they can be signed on an offline machine
+		if c.GlobalBool("unsigned") {
+			nodeWallet.SetUnsigned(os.ExpandEnv(cfg.Smartnode.GetUnsignedTxsPath()))
+		}
+
+		// Transactions only simulated, so their effects can be checked without submitting them
+		if c.GlobalBool("dry-run") {
+			var ec *ExecutionClientManager
+			ec, err = getEthClient(c, cfg)
+			if err != nil {
+				return
+			}
+			nodeWallet.SetDryRun(os.ExpandEnv(cfg.Smartnode.GetDryRunTxsPath()), ec)
+		}
+
+		// Transactions signed and saved for the daemon to submit once fees are low enough; until then, they hold the node's next nonces
+		var ec *ExecutionClientManager
+		ec, err = getEthClient(c, cfg)
+		if err != nil {
+			return
+		}
+		if c.GlobalBool("deferred") {
+			nodeWallet.SetDeferred(os.ExpandEnv(cfg.Smartnode.GetDeferredTxsPath()), ec)
+		} else {
+			nodeWallet.SetDeferredTxsFolder(os.ExpandEnv(cfg.Smartnode.GetDeferredTxsPath()), ec)
+		}
+
+		// Keystores; with Web3Signer, keys are only imported into the remote signer and never written to disk
+		if cfg.EnableWeb3Signer.Value == true {
+			var web3SignerKeystore *w3skeystore.Keystore
+			web3SignerKeystore, err = w3skeystore.NewKeystore(cfg.Web3Signer.Url.Value.(string), cfg.Web3Signer.AuthToken.Value.(string), cfg.Web3Signer.GetSlashingProtectionPath())
+			if err != nil {
+				return
+			}
+			nodeWallet.AddKeystore("web3signer", web3SignerKeystore)
+			return
+		}
 		lighthouseKeystore := lhkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
 		lodestarKeystore := lokeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
 		nimbusKeystore := nmkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
@@ -224,6 +372,9 @@ func getEthClient(c *cli.Context, cfg *config.RocketPoolConfig) (*ExecutionClien
 		// Create a new client manager
 		ecManager, err = NewExecutionClientManager(cfg)
 		if err == nil {
+			// Reuse the contract addresses and ABIs that were looked up recently
+			ecManager.metadataCache = getChainMetadataCache(cfg)
+
 			// Check if the manager should ignore sync checks and/or default to using the fallback (used by the API container when driven by the CLI)
 			if c.GlobalBool("ignore-sync-check") {
 				ecManager.ignoreSyncCheck = true
@@ -269,18 +420,58 @@ func getBeaconClient(c *cli.Context, cfg *config.RocketPoolConfig) (*BeaconClien
 		// Create a new client manager
 		bcManager, err = NewBeaconClientManager(cfg)
 		if err == nil {
+			// Reuse the Beacon config and deposit contract from earlier runs
+			bcManager.metadataCache = getChainMetadataCache(cfg)
+
 			// Check if the manager should ignore sync checks and/or default to using the fallback (used by the API container when driven by the CLI)
 			if c.GlobalBool("ignore-sync-check") {
 				bcManager.ignoreSyncCheck = true
 			}
 			if c.GlobalBool("force-fallbacks") {
-				bcManager.primaryReady = false
+				bcManager.ForceFallbacks()
 			}
 		}
 	})
 	return bcManager, err
 }
 
+func getBeaconEventStream(bc *BeaconClientManager) *BeaconEventStream {
+	initBeaconEventStream.Do(func() {
+		beaconEventStream = NewBeaconEventStream(bc)
+	})
+	return beaconEventStream
+}
+
+func getExecutionHeadWatcher(ec *ExecutionClientManager) *ExecutionHeadWatcher {
+	initEcHeadWatcher.Do(func() {
+		ecHeadWatcher = NewExecutionHeadWatcher(ec)
+	})
+	return ecHeadWatcher
+}
+
+func getAlertManager(cfg *config.RocketPoolConfig) (*alerting.Manager, error) {
+	var err error
+	initAlertManager.Do(func() {
+		alertManager, err = alerting.NewManagerFromConfig(cfg)
+	})
+	return alertManager, err
+}
+
+func getStore(cfg *config.RocketPoolConfig) (*store.Store, error) {
+	var err error
+	initDaemonStore.Do(func() {
+		daemonStore, err = store.Open(cfg.Smartnode.GetDaemonDatabasePath())
+	})
+	return daemonStore, err
+}
+
+func getChainMetadataCache(cfg *config.RocketPoolConfig) *chainMetadataCache {
+	initMetadataCache.Do(func() {
+		metadataCache = newChainMetadataCache(cfg)
+	})
+	return metadataCache
+}
+
 func getDocker() (*client.Client, error) {
 	var err error
 	initDocker.Do(func() {
@@ -75,6 +75,15 @@ func (m *NetworkStateManager) GetStateForSlot(slotNumber uint64) (*NetworkState,
 	return m.getState(slotNumber)
 }
 
+// Get the state of the network at the provided Beacon slot for rewards tree generation.
+// If streaming state construction is enabled, the node and minipool details are built incrementally to bound peak memory usage.
+func (m *NetworkStateManager) GetTreegenStateForSlot(slotNumber uint64) (*NetworkState, error) {
+	if m.cfg.Smartnode.UseStreamingState.Value.(bool) {
+		return m.getStreamingState(slotNumber)
+	}
+	return m.getState(slotNumber)
+}
+
 // Gets the latest valid block
 func (m *NetworkStateManager) GetLatestBeaconBlock() (beacon.BeaconBlock, error) {
 	targetSlot, err := m.GetHeadSlot()
@@ -138,6 +147,15 @@ func (m *NetworkStateManager) getState(slotNumber uint64) (*NetworkState, error)
 	return state, nil
 }
 
+// Get the state of the network at the provided Beacon slot, streaming the node and minipool details
+func (m *NetworkStateManager) getStreamingState(slotNumber uint64) (*NetworkState, error) {
+	state, err := CreateNetworkStateStreaming(m.cfg, m.rp, m.ec, m.bc, m.log, slotNumber, m.BeaconConfig)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
 // Get the state of the network for a specific node only at the provided Beacon slot
 func (m *NetworkStateManager) getStateForNode(nodeAddress common.Address, slotNumber uint64, calculateTotalEffectiveStake bool) (*NetworkState, *big.Int, error) {
 	state, totalEffectiveStake, err := CreateNetworkStateForNode(m.cfg, m.rp, m.ec, m.bc, m.log, slotNumber, m.BeaconConfig, nodeAddress, calculateTotalEffectiveStake)
@@ -0,0 +1,135 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/klauspost/compress/zstd"
+	"github.com/rocket-pool/rocketpool-go/types"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+const (
+	// The default filename for exported state snapshots
+	StateSnapshotFilename string = "state.json.zst"
+)
+
+// A portable copy of the network state for a single node, which can be inspected without access to any clients
+type StateSnapshot struct {
+	SmartnodeVersion string                          `json:"smartnodeVersion"`
+	Created          time.Time                       `json:"created"`
+	NodeAddress      common.Address                  `json:"nodeAddress"`
+	ElBlockNumber    uint64                          `json:"elBlockNumber"`
+	BeaconSlotNumber uint64                          `json:"beaconSlotNumber"`
+	BeaconConfig     beacon.Eth2Config               `json:"beaconConfig"`
+	NetworkDetails   *rpstate.NetworkDetails         `json:"networkDetails"`
+	NodeDetails      []rpstate.NativeNodeDetails     `json:"nodeDetails"`
+	MinipoolDetails  []rpstate.NativeMinipoolDetails `json:"minipoolDetails"`
+	ValidatorDetails []beacon.ValidatorStatus        `json:"validatorDetails"`
+}
+
+// Creates a snapshot of a state that was created for the provided node
+func NewStateSnapshot(state *NetworkState, nodeAddress common.Address) *StateSnapshot {
+	snapshot := &StateSnapshot{
+		SmartnodeVersion: shared.RocketPoolVersion,
+		Created:          time.Now().UTC(),
+		NodeAddress:      nodeAddress,
+		ElBlockNumber:    state.ElBlockNumber,
+		BeaconSlotNumber: state.BeaconSlotNumber,
+		BeaconConfig:     state.BeaconConfig,
+		NetworkDetails:   state.NetworkDetails,
+		NodeDetails:      state.NodeDetails,
+		MinipoolDetails:  state.MinipoolDetails,
+		ValidatorDetails: make([]beacon.ValidatorStatus, 0, len(state.ValidatorDetails)),
+	}
+
+	// Validators are stored as a list since their pubkeys can't be used as JSON keys
+	for _, validator := range state.ValidatorDetails {
+		snapshot.ValidatorDetails = append(snapshot.ValidatorDetails, validator)
+	}
+	return snapshot
+}
+
+// Rebuilds the network state from the snapshot, including all of its lookups
+func (s *StateSnapshot) GetNetworkState() *NetworkState {
+	state := &NetworkState{
+		ElBlockNumber:            s.ElBlockNumber,
+		BeaconSlotNumber:         s.BeaconSlotNumber,
+		BeaconConfig:             s.BeaconConfig,
+		NetworkDetails:           s.NetworkDetails,
+		NodeDetails:              s.NodeDetails,
+		NodeDetailsByAddress:     map[common.Address]*rpstate.NativeNodeDetails{},
+		MinipoolDetails:          s.MinipoolDetails,
+		MinipoolDetailsByAddress: map[common.Address]*rpstate.NativeMinipoolDetails{},
+		MinipoolDetailsByNode:    map[common.Address][]*rpstate.NativeMinipoolDetails{},
+		ValidatorDetails:         map[types.ValidatorPubkey]beacon.ValidatorStatus{},
+	}
+
+	for i, details := range state.NodeDetails {
+		state.NodeDetailsByAddress[details.NodeAddress] = &state.NodeDetails[i]
+	}
+	for i, details := range state.MinipoolDetails {
+		state.MinipoolDetailsByAddress[details.MinipoolAddress] = &state.MinipoolDetails[i]
+		state.MinipoolDetailsByNode[details.NodeAddress] = append(state.MinipoolDetailsByNode[details.NodeAddress], &state.MinipoolDetails[i])
+	}
+	for _, validator := range s.ValidatorDetails {
+		state.ValidatorDetails[validator.Pubkey] = validator
+	}
+	return state
+}
+
+// Saves the snapshot to a zstd-compressed JSON file
+func (s *StateSnapshot) Save(path string) error {
+	bytes, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("error serializing state snapshot: %w", err)
+	}
+
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBestCompression))
+	if err != nil {
+		return fmt.Errorf("error creating compression encoder: %w", err)
+	}
+	compressedBytes := encoder.EncodeAll(bytes, make([]byte, 0, len(bytes)))
+
+	err = os.WriteFile(path, compressedBytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing state snapshot to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Loads a snapshot from a file, which may or may not be zstd-compressed
+func LoadStateSnapshot(path string) (*StateSnapshot, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading state snapshot %s: %w", path, err)
+	}
+
+	// Decompress it if it starts with the zstd frame header
+	zstdMagic := []byte{0x28, 0xb5, 0x2f, 0xfd}
+	if len(bytes) >= len(zstdMagic) && string(bytes[:len(zstdMagic)]) == string(zstdMagic) {
+		decoder, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating compression decoder: %w", err)
+		}
+		bytes, err = decoder.DecodeAll(bytes, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing state snapshot %s: %w", path, err)
+		}
+	}
+
+	snapshot := new(StateSnapshot)
+	err = json.Unmarshal(bytes, snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing state snapshot %s: %w", path, err)
+	}
+	if len(snapshot.NodeDetails) == 0 {
+		return nil, fmt.Errorf("state snapshot %s does not contain any node details", path)
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,239 @@
+package state
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"golang.org/x/sync/errgroup"
+)
+
+// Settings
+const (
+	StreamingNodeBatchSize uint64 = 100
+)
+
+// Handler for a batch of streamed node details; minipools[i] holds the minipools belonging to nodes[i].
+// The slices are owned by the handler once it's called, so it may keep references to them.
+type NodeDetailsBatchHandler func(nodes []rpstate.NativeNodeDetails, minipools [][]rpstate.NativeMinipoolDetails) error
+
+// Retrieves the details of every node and its minipools in fixed-size batches, passing each batch to the handler
+// before moving on to the next one. Nodes within a batch are fetched in parallel. Unlike GetAllNativeNodeDetails /
+// GetAllNativeMinipoolDetails, this never holds more than one batch of intermediate data at a time, so callers that
+// don't retain the batches run in roughly constant memory.
+func StreamAllNativeNodeDetails(rp *rocketpool.RocketPool, contracts *rpstate.NetworkContracts, batchSize uint64, handler NodeDetailsBatchHandler) error {
+	opts := &bind.CallOpts{
+		BlockNumber: contracts.ElBlockNumber,
+	}
+	if batchSize == 0 {
+		batchSize = StreamingNodeBatchSize
+	}
+
+	// Get the node count
+	nodeCount, err := node.GetNodeCount(rp, opts)
+	if err != nil {
+		return fmt.Errorf("error getting node count: %w", err)
+	}
+
+	for batchStart := uint64(0); batchStart < nodeCount; batchStart += batchSize {
+		batchEnd := batchStart + batchSize
+		if batchEnd > nodeCount {
+			batchEnd = nodeCount
+		}
+
+		// Get the address, details and minipool details for each node in the batch. The network multicaller can't be
+		// shared between goroutines, so each worker gets its own copy of the contracts with a dedicated multicaller.
+		nodes := make([]rpstate.NativeNodeDetails, batchEnd-batchStart)
+		minipools := make([][]rpstate.NativeMinipoolDetails, batchEnd-batchStart)
+		var wg errgroup.Group
+		wg.SetLimit(threadLimit)
+		for i := batchStart; i < batchEnd; i++ {
+			i := i
+			wg.Go(func() error {
+				address, err := node.GetNodeAt(rp, i, opts)
+				if err != nil {
+					return fmt.Errorf("error getting address of node %d: %w", i, err)
+				}
+				mc, err := multicall.NewMultiCaller(rp.Client, contracts.Multicaller.ContractAddress)
+				if err != nil {
+					return fmt.Errorf("error creating multicaller for node %s: %w", address.Hex(), err)
+				}
+				workerContracts := *contracts
+				workerContracts.Multicaller = mc
+				nodeDetails, err := rpstate.GetNativeNodeDetails(rp, &workerContracts, address)
+				if err != nil {
+					return fmt.Errorf("error getting details for node %s: %w", address.Hex(), err)
+				}
+				minipoolDetails, err := rpstate.GetNodeNativeMinipoolDetails(rp, &workerContracts, address)
+				if err != nil {
+					return fmt.Errorf("error getting minipool details for node %s: %w", address.Hex(), err)
+				}
+				nodes[i-batchStart] = nodeDetails
+				minipools[i-batchStart] = minipoolDetails
+				return nil
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return err
+		}
+
+		// Hand the batch off
+		err = handler(nodes, minipools)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Creates a snapshot of the entire Rocket Pool network state like CreateNetworkState, but builds the node and minipool
+// lookups incrementally from streamed batches instead of loading every node and minipool up front and indexing afterwards.
+// The returned state still holds every node and minipool, but the intermediate data (address lists, multicall results,
+// Beacon responses) is bounded by the batch size rather than by the size of the network.
+func CreateNetworkStateStreaming(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, ec rocketpool.ExecutionClient, bc beacon.Client, log *log.ColorLogger, slotNumber uint64, beaconConfig beacon.Eth2Config) (*NetworkState, error) {
+	// Get the relevant network contracts
+	multicallerAddress := common.HexToAddress(cfg.Smartnode.GetMulticallAddress())
+	balanceBatcherAddress := common.HexToAddress(cfg.Smartnode.GetBalanceBatcherAddress())
+
+	// Get the execution block for the given slot
+	beaconBlock, exists, err := bc.GetBeaconBlock(fmt.Sprintf("%d", slotNumber))
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon block for slot %d: %w", slotNumber, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("slot %d did not have a Beacon block", slotNumber)
+	}
+
+	// Get the corresponding block on the EL
+	elBlockNumber := beaconBlock.ExecutionBlockNumber
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(elBlockNumber),
+	}
+
+	// Create the state wrapper
+	state := &NetworkState{
+		NodeDetailsByAddress:     map[common.Address]*rpstate.NativeNodeDetails{},
+		MinipoolDetailsByAddress: map[common.Address]*rpstate.NativeMinipoolDetails{},
+		MinipoolDetailsByNode:    map[common.Address][]*rpstate.NativeMinipoolDetails{},
+		BeaconSlotNumber:         slotNumber,
+		ElBlockNumber:            elBlockNumber,
+		BeaconConfig:             beaconConfig,
+		log:                      log,
+	}
+
+	state.logLine("Getting network state for EL block %d, Beacon slot %d (streaming)", elBlockNumber, slotNumber)
+	start := time.Now()
+
+	// Network contracts and details
+	contracts, err := rpstate.NewNetworkContracts(rp, multicallerAddress, balanceBatcherAddress, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network contracts: %w", err)
+	}
+	state.NetworkDetails, err = rpstate.NewNetworkDetails(rp, contracts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network details: %w", err)
+	}
+	state.logLine("1/3 - Retrieved network details (%s so far)", time.Since(start))
+
+	// Size the backing slices up front so the lookup pointers stay valid while they're filled in
+	nodeCount, err := node.GetNodeCount(rp, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting node count: %w", err)
+	}
+	minipoolCount, err := minipool.GetMinipoolCount(rp, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool count: %w", err)
+	}
+	state.NodeDetails = make([]rpstate.NativeNodeDetails, 0, nodeCount)
+	state.MinipoolDetails = make([]rpstate.NativeMinipoolDetails, 0, minipoolCount)
+
+	// Stream the node and minipool details, indexing each batch as it arrives. The validator statuses and complete
+	// shares are resolved per batch too, so the Beacon responses and share calls never cover more than one batch.
+	state.ValidatorDetails = map[types.ValidatorPubkey]beacon.ValidatorStatus{}
+	emptyPubkey := types.ValidatorPubkey{}
+	err = StreamAllNativeNodeDetails(rp, contracts, StreamingNodeBatchSize, func(nodes []rpstate.NativeNodeDetails, minipools [][]rpstate.NativeMinipoolDetails) error {
+		batchMpds := []*rpstate.NativeMinipoolDetails{}
+		pubkeys := []types.ValidatorPubkey{}
+		for i, nodeDetails := range nodes {
+			if len(state.NodeDetails) == cap(state.NodeDetails) {
+				return fmt.Errorf("node count exceeded the expected total of %d", nodeCount)
+			}
+			state.NodeDetails = append(state.NodeDetails, nodeDetails)
+			nodePtr := &state.NodeDetails[len(state.NodeDetails)-1]
+			state.NodeDetailsByAddress[nodeDetails.NodeAddress] = nodePtr
+
+			nodeList := make([]*rpstate.NativeMinipoolDetails, 0, len(minipools[i]))
+			for _, mpd := range minipools[i] {
+				if len(state.MinipoolDetails) == cap(state.MinipoolDetails) {
+					return fmt.Errorf("minipool count exceeded the expected total of %d", minipoolCount)
+				}
+				state.MinipoolDetails = append(state.MinipoolDetails, mpd)
+				mpdPtr := &state.MinipoolDetails[len(state.MinipoolDetails)-1]
+				state.MinipoolDetailsByAddress[mpd.MinipoolAddress] = mpdPtr
+				if mpd.Pubkey != emptyPubkey {
+					pubkeys = append(pubkeys, mpd.Pubkey)
+				}
+				nodeList = append(nodeList, mpdPtr)
+				batchMpds = append(batchMpds, mpdPtr)
+			}
+			state.MinipoolDetailsByNode[nodeDetails.NodeAddress] = nodeList
+
+			// Calculate avg node fees and distributor shares
+			rpstate.CalculateAverageFeeAndDistributorShares(rp, contracts, *nodePtr, nodeList)
+		}
+
+		// Get the validator stats for this batch from Beacon
+		if len(pubkeys) > 0 {
+			statusMap, err := bc.GetValidatorStatuses(pubkeys, &beacon.ValidatorStatusOptions{
+				Slot: &slotNumber,
+			})
+			if err != nil {
+				return fmt.Errorf("error getting validator statuses: %w", err)
+			}
+			for pubkey, status := range statusMap {
+				state.ValidatorDetails[pubkey] = status
+			}
+		}
+
+		// Get the complete node and user shares for this batch
+		if len(batchMpds) == 0 {
+			return nil
+		}
+		beaconBalances := make([]*big.Int, len(batchMpds))
+		for i, mpd := range batchMpds {
+			validator := state.ValidatorDetails[mpd.Pubkey]
+			if !validator.Exists {
+				beaconBalances[i] = big.NewInt(0)
+			} else {
+				beaconBalances[i] = eth.GweiToWei(float64(validator.Balance))
+			}
+		}
+		return rpstate.CalculateCompleteMinipoolShares(rp, contracts, batchMpds, beaconBalances)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error streaming node and minipool details: %w", err)
+	}
+	state.logLine("2/3 - Retrieved node, minipool and validator details and shares (%s so far)", time.Since(start))
+
+	// Oracle DAO member details
+	state.OracleDaoMemberDetails, err = rpstate.GetAllOracleDaoMemberDetails(rp, contracts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Oracle DAO details: %w", err)
+	}
+	state.logLine("3/3 - Retrieved Oracle DAO details (total time: %s)", time.Since(start))
+
+	return state, nil
+}
@@ -0,0 +1,32 @@
+package store
+
+import (
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Records alerts in the database, so they can be reviewed after they've scrolled out of the logs
+type AlertSink struct {
+	store *Store
+}
+
+// Create a sink that records alerts in the database
+func NewAlertSink(store *Store) *AlertSink {
+	return &AlertSink{store: store}
+}
+
+func (s *AlertSink) Name() string {
+	return "database"
+}
+
+func (s *AlertSink) Send(alert alerting.Alert) error {
+	return s.store.AddAlert(api.NodeAlertRecord{
+		Rule:      alert.Rule,
+		Key:       alert.Key,
+		Severity:  string(alert.Severity),
+		Summary:   alert.Summary,
+		Resolved:  alert.Resolved,
+		StartTime: alert.StartTime,
+		Time:      alert.Time,
+	})
+}
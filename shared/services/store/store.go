@@ -0,0 +1,274 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// How long to wait for the other process to finish writing before giving up, since the daemon and API commands share the database
+const busyTimeoutMs int = 5000
+
+// The schema changes, applied in order; the database's user_version is the number of them that have been applied
+var migrations = []string{
+	`CREATE TABLE transactions (
+		node_address TEXT NOT NULL,
+		nonce INTEGER NOT NULL,
+		tx_hash TEXT NOT NULL,
+		tx_status TEXT NOT NULL,
+		block_number INTEGER NOT NULL,
+		time INTEGER NOT NULL,
+		PRIMARY KEY (node_address, nonce)
+	);
+	CREATE TABLE alerts (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		rule TEXT NOT NULL,
+		alert_key TEXT NOT NULL,
+		severity TEXT NOT NULL,
+		summary TEXT NOT NULL,
+		resolved INTEGER NOT NULL,
+		start_time INTEGER NOT NULL,
+		time INTEGER NOT NULL
+	);
+	CREATE INDEX alerts_time ON alerts (time);
+	CREATE TABLE duties (
+		node_address TEXT NOT NULL,
+		epoch INTEGER NOT NULL,
+		validators INTEGER NOT NULL,
+		missed_attestations INTEGER NOT NULL,
+		proposals INTEGER NOT NULL,
+		missed_proposals INTEGER NOT NULL,
+		time INTEGER NOT NULL,
+		PRIMARY KEY (node_address, epoch)
+	);
+	CREATE TABLE rewards_claims (
+		node_address TEXT NOT NULL,
+		interval_index INTEGER NOT NULL,
+		tx_hash TEXT NOT NULL,
+		block_number INTEGER NOT NULL,
+		time INTEGER NOT NULL,
+		PRIMARY KEY (node_address, interval_index)
+	);`,
+	`CREATE TABLE watchtower_submissions (
+		duty TEXT NOT NULL,
+		target TEXT NOT NULL,
+		tx_hash TEXT NOT NULL,
+		status TEXT NOT NULL,
+		time INTEGER NOT NULL,
+		PRIMARY KEY (duty, target)
+	);
+	CREATE TABLE watchtower_progress (
+		duty TEXT NOT NULL PRIMARY KEY,
+		position INTEGER NOT NULL,
+		time INTEGER NOT NULL
+	);`,
+}
+
+// A rewards claim transaction, and the interval it claimed
+type RewardsClaim struct {
+	Interval    uint64
+	TxHash      common.Hash
+	BlockNumber uint64
+	Time        time.Time
+}
+
+// An embedded database for the daemon's operational history: the node's transactions, the alerts it sent, its validators'
+// duties, and its rewards claims. The daemon writes to it as things happen, and API commands read from it instead of
+// rebuilding the history from the chain. The watchtower keeps its progress with its duties in it too, so a restart doesn't
+// repeat or skip any of them.
+type Store struct {
+	db *sql.DB
+}
+
+// Open the database at the given path, creating it and bringing its schema up to date if necessary
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("error creating database folder: %w", err)
+	}
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_journal_mode=WAL&_busy_timeout=%d", path, busyTimeoutMs))
+	if err != nil {
+		return nil, fmt.Errorf("error opening database %s: %w", path, err)
+	}
+	store := &Store{db: db}
+	if err := store.migrate(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error updating database %s: %w", path, err)
+	}
+	return store, nil
+}
+
+// Close the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Apply the schema changes the database doesn't have yet
+func (s *Store) migrate() error {
+	var version int
+	if err := s.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("error getting schema version: %w", err)
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("the database schema (version %d) is newer than this Smartnode supports (version %d)", version, len(migrations))
+	}
+	for i := version; i < len(migrations); i++ {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("error applying schema version %d: %w", i+1, err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("error setting schema version %d: %w", i+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("error committing schema version %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Record one of the node's transactions being included in a block
+func (s *Store) AddTransaction(nodeAddress common.Address, tx api.NodeTransactionRecord) error {
+	txHash := ""
+	if tx.TxHash != nil {
+		txHash = tx.TxHash.Hex()
+	}
+	_, err := s.db.Exec("INSERT OR REPLACE INTO transactions (node_address, nonce, tx_hash, tx_status, block_number, time) VALUES (?, ?, ?, ?, ?, ?)",
+		nodeAddress.Hex(), tx.Nonce, txHash, tx.TxStatus, tx.ElBlockNumber, tx.Time.Unix())
+	if err != nil {
+		return fmt.Errorf("error recording transaction with nonce %d: %w", tx.Nonce, err)
+	}
+	return nil
+}
+
+// Get the node's most recent transactions, newest first
+func (s *Store) GetTransactions(nodeAddress common.Address, limit int) ([]api.NodeTransactionRecord, error) {
+	rows, err := s.db.Query("SELECT nonce, tx_hash, tx_status, block_number, time FROM transactions WHERE node_address = ? ORDER BY nonce DESC LIMIT ?", nodeAddress.Hex(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting transactions: %w", err)
+	}
+	defer rows.Close()
+
+	txs := []api.NodeTransactionRecord{}
+	for rows.Next() {
+		var tx api.NodeTransactionRecord
+		var txHash string
+		var timestamp int64
+		if err := rows.Scan(&tx.Nonce, &txHash, &tx.TxStatus, &tx.ElBlockNumber, &timestamp); err != nil {
+			return nil, fmt.Errorf("error reading transaction: %w", err)
+		}
+		if txHash != "" {
+			hash := common.HexToHash(txHash)
+			tx.TxHash = &hash
+		}
+		tx.Time = time.Unix(timestamp, 0).UTC()
+		txs = append(txs, tx)
+	}
+	return txs, rows.Err()
+}
+
+// Record an alert or notification that was sent
+func (s *Store) AddAlert(alert api.NodeAlertRecord) error {
+	_, err := s.db.Exec("INSERT INTO alerts (rule, alert_key, severity, summary, resolved, start_time, time) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		alert.Rule, alert.Key, alert.Severity, alert.Summary, alert.Resolved, alert.StartTime.Unix(), alert.Time.Unix())
+	if err != nil {
+		return fmt.Errorf("error recording alert: %w", err)
+	}
+	return nil
+}
+
+// Get the alerts and notifications sent since the given time, newest first
+func (s *Store) GetAlerts(since time.Time, limit int) ([]api.NodeAlertRecord, error) {
+	rows, err := s.db.Query("SELECT rule, alert_key, severity, summary, resolved, start_time, time FROM alerts WHERE time >= ? ORDER BY id DESC LIMIT ?", since.Unix(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []api.NodeAlertRecord{}
+	for rows.Next() {
+		var alert api.NodeAlertRecord
+		var startTime int64
+		var timestamp int64
+		if err := rows.Scan(&alert.Rule, &alert.Key, &alert.Severity, &alert.Summary, &alert.Resolved, &startTime, &timestamp); err != nil {
+			return nil, fmt.Errorf("error reading alert: %w", err)
+		}
+		alert.StartTime = time.Unix(startTime, 0).UTC()
+		alert.Time = time.Unix(timestamp, 0).UTC()
+		alerts = append(alerts, alert)
+	}
+	return alerts, rows.Err()
+}
+
+// Record how the node's validators did with their duties in an epoch
+func (s *Store) AddDutyEpoch(nodeAddress common.Address, epoch api.NodeDutyEpochRecord) error {
+	_, err := s.db.Exec("INSERT OR REPLACE INTO duties (node_address, epoch, validators, missed_attestations, proposals, missed_proposals, time) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		nodeAddress.Hex(), epoch.Epoch, epoch.Validators, epoch.MissedAttestations, epoch.Proposals, epoch.MissedProposals, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("error recording duties for epoch %d: %w", epoch.Epoch, err)
+	}
+	return nil
+}
+
+// Get the duties of the node's validators in the most recent epochs that were checked, newest first
+func (s *Store) GetDutyEpochs(nodeAddress common.Address, limit int) ([]api.NodeDutyEpochRecord, error) {
+	rows, err := s.db.Query("SELECT epoch, validators, missed_attestations, proposals, missed_proposals FROM duties WHERE node_address = ? ORDER BY epoch DESC LIMIT ?", nodeAddress.Hex(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting duties: %w", err)
+	}
+	defer rows.Close()
+
+	epochs := []api.NodeDutyEpochRecord{}
+	for rows.Next() {
+		var epoch api.NodeDutyEpochRecord
+		if err := rows.Scan(&epoch.Epoch, &epoch.Validators, &epoch.MissedAttestations, &epoch.Proposals, &epoch.MissedProposals); err != nil {
+			return nil, fmt.Errorf("error reading duties: %w", err)
+		}
+		epochs = append(epochs, epoch)
+	}
+	return epochs, rows.Err()
+}
+
+// Record the transaction the node used to claim its rewards for an interval
+func (s *Store) AddRewardsClaim(nodeAddress common.Address, claim RewardsClaim) error {
+	_, err := s.db.Exec("INSERT OR REPLACE INTO rewards_claims (node_address, interval_index, tx_hash, block_number, time) VALUES (?, ?, ?, ?, ?)",
+		nodeAddress.Hex(), claim.Interval, claim.TxHash.Hex(), claim.BlockNumber, claim.Time.Unix())
+	if err != nil {
+		return fmt.Errorf("error recording rewards claim for interval %d: %w", claim.Interval, err)
+	}
+	return nil
+}
+
+// Get the node's recorded rewards claims, keyed by interval
+func (s *Store) GetRewardsClaims(nodeAddress common.Address) (map[uint64]RewardsClaim, error) {
+	rows, err := s.db.Query("SELECT interval_index, tx_hash, block_number, time FROM rewards_claims WHERE node_address = ?", nodeAddress.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("error getting rewards claims: %w", err)
+	}
+	defer rows.Close()
+
+	claims := map[uint64]RewardsClaim{}
+	for rows.Next() {
+		var claim RewardsClaim
+		var txHash string
+		var timestamp int64
+		if err := rows.Scan(&claim.Interval, &txHash, &claim.BlockNumber, &timestamp); err != nil {
+			return nil, fmt.Errorf("error reading rewards claim: %w", err)
+		}
+		claim.TxHash = common.HexToHash(txHash)
+		claim.Time = time.Unix(timestamp, 0)
+		claims[claim.Interval] = claim
+	}
+	return claims, rows.Err()
+}
@@ -0,0 +1,90 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The state of a transaction the watchtower sent for one of its duties
+type SubmissionStatus string
+
+const (
+	SubmissionStatus_Pending   SubmissionStatus = "pending"
+	SubmissionStatus_Succeeded SubmissionStatus = "succeeded"
+	SubmissionStatus_Failed    SubmissionStatus = "failed"
+	SubmissionStatus_Dropped   SubmissionStatus = "dropped"
+)
+
+// The latest transaction the watchtower sent for one of its duties, such as the network balances for a block
+type WatchtowerSubmission struct {
+	Duty   string
+	Target string
+	TxHash common.Hash
+	Status SubmissionStatus
+	Time   time.Time
+}
+
+// Record a transaction the watchtower just sent for a duty, replacing the previous one for the same target
+func (s *Store) AddWatchtowerSubmission(duty string, target string, txHash common.Hash) error {
+	_, err := s.db.Exec("INSERT OR REPLACE INTO watchtower_submissions (duty, target, tx_hash, status, time) VALUES (?, ?, ?, ?, ?)",
+		duty, target, txHash.Hex(), SubmissionStatus_Pending, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("error recording %s submission for %s: %w", duty, target, err)
+	}
+	return nil
+}
+
+// Update the status of the transaction the watchtower sent for a duty
+func (s *Store) SetWatchtowerSubmissionStatus(duty string, target string, status SubmissionStatus) error {
+	_, err := s.db.Exec("UPDATE watchtower_submissions SET status = ? WHERE duty = ? AND target = ?", status, duty, target)
+	if err != nil {
+		return fmt.Errorf("error updating %s submission for %s: %w", duty, target, err)
+	}
+	return nil
+}
+
+// Get the latest transaction the watchtower sent for a duty, or nil if it hasn't sent one for the target
+func (s *Store) GetWatchtowerSubmission(duty string, target string) (*WatchtowerSubmission, error) {
+	submission := WatchtowerSubmission{
+		Duty:   duty,
+		Target: target,
+	}
+	var txHash string
+	var timestamp int64
+	err := s.db.QueryRow("SELECT tx_hash, status, time FROM watchtower_submissions WHERE duty = ? AND target = ?", duty, target).Scan(&txHash, &submission.Status, &timestamp)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting %s submission for %s: %w", duty, target, err)
+	}
+	submission.TxHash = common.HexToHash(txHash)
+	submission.Time = time.Unix(timestamp, 0)
+	return &submission, nil
+}
+
+// Get how far the watchtower has gotten with a duty that works through a range, such as the slots scanned for penalties
+func (s *Store) GetWatchtowerProgress(duty string) (uint64, bool, error) {
+	var position uint64
+	err := s.db.QueryRow("SELECT position FROM watchtower_progress WHERE duty = ?", duty).Scan(&position)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("error getting %s progress: %w", duty, err)
+	}
+	return position, true, nil
+}
+
+// Save how far the watchtower has gotten with a duty that works through a range
+func (s *Store) SetWatchtowerProgress(duty string, position uint64) error {
+	_, err := s.db.Exec("INSERT OR REPLACE INTO watchtower_progress (duty, position, time) VALUES (?, ?, ?)", duty, position, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("error saving %s progress: %w", duty, err)
+	}
+	return nil
+}
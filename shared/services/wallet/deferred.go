@@ -0,0 +1,207 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const (
+	deferredTxsDirMode  = 0755
+	deferredTxsFileMode = 0600
+
+	// How long a deferred transaction waits for low enough fees before the daemon drops it and releases its nonce
+	DeferredTxTimeout = 72 * time.Hour
+)
+
+// A client that can provide the node's next nonce
+type DeferredNonceReader interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// Set the folder that deferred transactions wait in, so transactions that are submitted directly don't take the nonces they hold
+func (w *Wallet) SetDeferredTxsFolder(deferredTxsPath string, nonceReader DeferredNonceReader) {
+	w.deferredTxsPath = deferredTxsPath
+	w.nonceReader = nonceReader
+}
+
+// Sign node transactions and save them to the given folder for the daemon to submit once fees are low enough, instead of submitting them
+func (w *Wallet) SetDeferred(deferredTxsPath string, nonceReader DeferredNonceReader) {
+	w.SetDeferredTxsFolder(deferredTxsPath, nonceReader)
+	w.deferTxs = true
+}
+
+// Check if node transactions are being deferred instead of being submitted
+func (w *Wallet) IsDeferred() bool {
+	return w.deferTxs
+}
+
+// Make sure no deferred transactions are waiting to be submitted from the given account.
+// They hold the account's next nonces, so a transaction submitted now would either replace one of them or be stuck behind them.
+// Transactions past their deadline are about to be dropped by the daemon, so they don't hold anything.
+func (w *Wallet) checkDeferredNonces(from common.Address) error {
+	if w.deferredTxsPath == "" {
+		return nil
+	}
+	deferredTxs, err := LoadDeferredTransactions(w.deferredTxsPath)
+	if err != nil {
+		return err
+	}
+	waiting := []api.DeferredTransaction{}
+	now := time.Now()
+	for _, deferredTx := range deferredTxs {
+		if deferredTx.From == from && now.Before(GetDeferredTransactionDeadline(deferredTx)) {
+			waiting = append(waiting, deferredTx)
+		}
+	}
+	if len(waiting) == 0 {
+		return nil
+	}
+
+	// Deferred transactions whose nonces have already been used will be discarded by the daemon, so they don't hold anything
+	nonce, err := w.nonceReader.PendingNonceAt(context.Background(), from)
+	if err != nil {
+		return fmt.Errorf("error getting the node's next nonce: %w", err)
+	}
+	for _, deferredTx := range waiting {
+		if deferredTx.Nonce >= nonce {
+			return fmt.Errorf("The node has deferred transactions waiting for low enough fees, starting with %s at nonce %d. They hold the node's next nonces, so no other transactions can be submitted until the node daemon submits them, until they pass their deadline at %s, or until they're removed from %s", deferredTx.Hash.Hex(), deferredTx.Nonce, GetDeferredTransactionDeadline(deferredTx).Format(time.RFC1123), w.deferredTxsPath)
+		}
+	}
+	return nil
+}
+
+// Get the time after which the daemon stops waiting for low enough fees for a deferred transaction and drops it
+func GetDeferredTransactionDeadline(deferredTx api.DeferredTransaction) time.Time {
+	if deferredTx.Deadline.IsZero() {
+		return deferredTx.Created.Add(DeferredTxTimeout)
+	}
+	return deferredTx.Deadline
+}
+
+// Get a transactor that signs transactions and saves them for the daemon instead of submitting them.
+// Transactions that are already waiting to be submitted hold their nonces, so new ones are queued after them.
+func (w *Wallet) getDeferredTransactor() (*bind.TransactOpts, error) {
+	if w.safe != nil {
+		return nil, fmt.Errorf("The node account is a Safe, so its transactions are proposed to the Safe and can't be deferred")
+	}
+	opts, err := w.getSigningTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the next nonce that isn't held by a deferred transaction
+	nonce, err := w.nonceReader.PendingNonceAt(context.Background(), opts.From)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the node's next nonce: %w", err)
+	}
+	deferredTxs, err := LoadDeferredTransactions(w.deferredTxsPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, deferredTx := range deferredTxs {
+		if deferredTx.From == opts.From && deferredTx.Nonce >= nonce {
+			nonce = deferredTx.Nonce + 1
+		}
+	}
+	opts.Nonce = big.NewInt(0).SetUint64(nonce)
+
+	// Save the transaction once it's signed
+	signer := opts.Signer
+	opts.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		signedTx, err := signer(address, tx)
+		if err != nil {
+			return nil, err
+		}
+		err = w.saveDeferredTransaction(address, signedTx)
+		if err != nil {
+			return nil, err
+		}
+		return signedTx, nil
+	}
+	opts.NoSend = true
+	return opts, nil
+}
+
+// Save a signed transaction to disk for the daemon to submit
+func (w *Wallet) saveDeferredTransaction(from common.Address, tx *types.Transaction) error {
+	signedTx, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("error serializing signed transaction: %w", err)
+	}
+	now := time.Now()
+	bytes, err := json.MarshalIndent(api.DeferredTransaction{
+		Hash:           tx.Hash(),
+		From:           from,
+		Nonce:          tx.Nonce(),
+		MaxFeePerGas:   tx.GasFeeCap(),
+		MaxPriorityFee: tx.GasTipCap(),
+		SignedTx:       signedTx,
+		Created:        now,
+		Deadline:       now.Add(DeferredTxTimeout),
+	}, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error serializing deferred transaction: %w", err)
+	}
+	err = os.MkdirAll(w.deferredTxsPath, deferredTxsDirMode)
+	if err != nil {
+		return fmt.Errorf("error creating deferred transactions folder [%s]: %w", w.deferredTxsPath, err)
+	}
+	path := filepath.Join(w.deferredTxsPath, api.GetDeferredTransactionFilename(tx.Hash()))
+	err = os.WriteFile(path, bytes, deferredTxsFileMode)
+	if err != nil {
+		return fmt.Errorf("error writing deferred transaction [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Load the deferred transactions that are waiting to be submitted
+func LoadDeferredTransactions(deferredTxsPath string) ([]api.DeferredTransaction, error) {
+	entries, err := os.ReadDir(deferredTxsPath)
+	if os.IsNotExist(err) {
+		return []api.DeferredTransaction{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading deferred transactions folder [%s]: %w", deferredTxsPath, err)
+	}
+
+	deferredTxs := []api.DeferredTransaction{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(deferredTxsPath, entry.Name())
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading deferred transaction [%s]: %w", path, err)
+		}
+		var deferredTx api.DeferredTransaction
+		if err := json.Unmarshal(bytes, &deferredTx); err != nil {
+			return nil, fmt.Errorf("error deserializing deferred transaction [%s]: %w", path, err)
+		}
+		deferredTxs = append(deferredTxs, deferredTx)
+	}
+	return deferredTxs, nil
+}
+
+// Remove a deferred transaction once it has been submitted or can no longer be
+func RemoveDeferredTransaction(deferredTxsPath string, hash common.Hash) error {
+	path := filepath.Join(deferredTxsPath, api.GetDeferredTransactionFilename(hash))
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing deferred transaction [%s]: %w", path, err)
+	}
+	return nil
+}
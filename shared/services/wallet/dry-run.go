@@ -0,0 +1,177 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const (
+	dryRunTxsDirMode  = 0755
+	dryRunTxsFileMode = 0644
+)
+
+// A client that can simulate transactions without submitting them
+type TransactionSimulator interface {
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	TraceCall(ctx context.Context, call ethereum.CallMsg, tracerConfig interface{}, result interface{}) error
+}
+
+// A call frame produced by the callTracer
+type callFrame struct {
+	Type         string            `json:"type"`
+	From         common.Address    `json:"from"`
+	To           common.Address    `json:"to"`
+	Value        *hexutil.Big      `json:"value"`
+	GasUsed      hexutil.Uint64    `json:"gasUsed"`
+	Error        string            `json:"error"`
+	RevertReason string            `json:"revertReason"`
+	Calls        []callFrame       `json:"calls"`
+	Logs         []api.DryRunEvent `json:"logs"`
+}
+
+// Simulate node transactions and save the results to the given folder instead of signing and submitting them
+func (w *Wallet) SetDryRun(dryRunTxsPath string, simulator TransactionSimulator) {
+	w.dryRunTxsPath = dryRunTxsPath
+	w.simulator = simulator
+}
+
+// Check if node transactions are only being simulated
+func (w *Wallet) IsDryRun() bool {
+	return w.dryRunTxsPath != ""
+}
+
+// Get a transactor that simulates transactions instead of signing them.
+// It never sends them, so the hash it reports is the hash of the unsigned transaction.
+func (w *Wallet) getDryRunTransactor() (*bind.TransactOpts, error) {
+	var from common.Address
+	if w.safe != nil {
+		from = w.safe.client.GetAddress()
+	} else {
+		account, err := w.getSignerAccount()
+		if err != nil {
+			return nil, err
+		}
+		from = account.Address
+	}
+	return &bind.TransactOpts{
+		From: from,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != from {
+				return nil, bind.ErrNotAuthorized
+			}
+			dryRun, err := w.simulateTransaction(address, tx)
+			if err != nil {
+				return nil, err
+			}
+			err = w.saveDryRunTransaction(tx.Hash(), dryRun)
+			if err != nil {
+				return nil, err
+			}
+			return tx, nil
+		},
+		GasFeeCap: w.maxFee,
+		GasTipCap: w.maxPriorityFee,
+		GasLimit:  w.gasLimit,
+		Context:   context.Background(),
+		NoSend:    true,
+	}, nil
+}
+
+// Simulate a transaction against the latest block, tracing its effects if the client supports it
+func (w *Wallet) simulateTransaction(from common.Address, tx *types.Transaction) (api.DryRunTransaction, error) {
+	unsignedTx, err := api.NewUnsignedTransaction(tx, from)
+	if err != nil {
+		return api.DryRunTransaction{}, err
+	}
+	dryRun := api.DryRunTransaction{
+		Transaction: unsignedTx,
+		Transfers:   []api.DryRunTransfer{},
+		Events:      []api.DryRunEvent{},
+	}
+	call := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+
+	// Run the call to see if it reverts
+	_, err = w.simulator.CallContract(context.Background(), call, nil)
+	if err != nil {
+		dryRun.Reverted = true
+		dryRun.RevertReason = err.Error()
+	}
+
+	// Trace it for the gas used, ETH transfers and events; not every client has the debug API enabled
+	var trace callFrame
+	err = w.simulator.TraceCall(context.Background(), call, map[string]interface{}{
+		"tracer":       "callTracer",
+		"tracerConfig": map[string]interface{}{"withLog": true},
+	}, &trace)
+	if err != nil {
+		dryRun.TraceError = err.Error()
+		return dryRun, nil
+	}
+	dryRun.TraceAvailable = true
+	dryRun.GasUsed = uint64(trace.GasUsed)
+	if trace.Error != "" {
+		dryRun.Reverted = true
+		dryRun.RevertReason = trace.Error
+		if trace.RevertReason != "" {
+			dryRun.RevertReason = trace.RevertReason
+		}
+		return dryRun, nil
+	}
+	addCallFrameEffects(&dryRun, trace)
+	return dryRun, nil
+}
+
+// Add the ETH transfers and events of a call and its successful subcalls
+func addCallFrameEffects(dryRun *api.DryRunTransaction, frame callFrame) {
+	if frame.Error != "" {
+		return
+	}
+	if frame.Value != nil && frame.Value.ToInt().Sign() > 0 && frame.Type != "DELEGATECALL" {
+		dryRun.Transfers = append(dryRun.Transfers, api.DryRunTransfer{
+			From:  frame.From,
+			To:    frame.To,
+			Value: frame.Value.ToInt(),
+		})
+	}
+	dryRun.Events = append(dryRun.Events, frame.Logs...)
+	for _, call := range frame.Calls {
+		addCallFrameEffects(dryRun, call)
+	}
+}
+
+// Save a simulated transaction to disk
+func (w *Wallet) saveDryRunTransaction(hash common.Hash, dryRun api.DryRunTransaction) error {
+	bytes, err := json.MarshalIndent(dryRun, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error serializing dry run: %w", err)
+	}
+	err = os.MkdirAll(w.dryRunTxsPath, dryRunTxsDirMode)
+	if err != nil {
+		return fmt.Errorf("error creating dry run folder [%s]: %w", w.dryRunTxsPath, err)
+	}
+	path := filepath.Join(w.dryRunTxsPath, api.GetDryRunTransactionFilename(hash))
+	err = os.WriteFile(path, bytes, dryRunTxsFileMode)
+	if err != nil {
+		return fmt.Errorf("error writing dry run [%s]: %w", path, err)
+	}
+	return nil
+}
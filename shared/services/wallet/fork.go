@@ -0,0 +1,43 @@
+//go:build forktest
+
+package wallet
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// An account on a local fork of the network, which the fork sends transactions for without its key
+type forkAccount struct {
+	address common.Address
+}
+
+// Use an account on a local fork of the network as the node account, so the fork tests can run daemon tasks as any node.
+// Its transactions are left unsigned, so they have to be sent with an Execution client that has the fork send them as the account.
+func (w *Wallet) SetForkAccount(address common.Address) {
+	w.external = &forkAccount{
+		address: address,
+	}
+}
+
+func (fa *forkAccount) getName() string {
+	return "local fork"
+}
+
+func (fa *forkAccount) getAccount() (accounts.Account, error) {
+	return accounts.Account{
+		Address: fa.address,
+	}, nil
+}
+
+func (fa *forkAccount) signTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return tx, nil
+}
+
+func (fa *forkAccount) signText(message []byte) ([]byte, error) {
+	return nil, fmt.Errorf("messages can't be signed for an account on a local fork")
+}
@@ -0,0 +1,139 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Supported hardware wallets
+const (
+	HardwareWalletLedger = "ledger"
+	HardwareWalletTrezor = "trezor"
+)
+
+// A hardware wallet that holds the node account
+type hardwareWallet struct {
+	name    string
+	path    accounts.DerivationPath
+	hub     *usbwallet.Hub
+	wallet  accounts.Wallet
+	account accounts.Account
+}
+
+// Use a hardware wallet for the node account instead of the key derived from the wallet seed.
+// The device isn't opened until the node account is first needed.
+func (w *Wallet) SetHardwareWallet(walletType string, derivationPath string) error {
+
+	// Parse derivation path
+	path, err := accounts.ParseDerivationPath(derivationPath)
+	if err != nil {
+		return fmt.Errorf("Invalid hardware wallet derivation path '%s': %w", derivationPath, err)
+	}
+
+	// Create the USB hub for the device type
+	var hub *usbwallet.Hub
+	var name string
+	switch walletType {
+	case HardwareWalletLedger:
+		name = "Ledger"
+		hub, err = usbwallet.NewLedgerHub()
+	case HardwareWalletTrezor:
+		name = "Trezor"
+		hub, err = usbwallet.NewTrezorHubWithHID()
+	default:
+		return fmt.Errorf("Unknown hardware wallet type '%s'", walletType)
+	}
+	if err != nil {
+		return fmt.Errorf("Could not start %s USB hub: %w", name, err)
+	}
+
+	w.external = &hardwareWallet{
+		name: name,
+		path: path,
+		hub:  hub,
+	}
+	return nil
+
+}
+
+// Check if the node account is on a hardware wallet
+func (w *Wallet) IsHardwareWallet() bool {
+	_, isHardwareWallet := w.external.(*hardwareWallet)
+	return isHardwareWallet
+}
+
+// Get the node account from the hardware wallet, opening the device if necessary
+func (hw *hardwareWallet) getAccount() (accounts.Account, error) {
+
+	// Check for a cached account
+	if hw.wallet != nil {
+		return hw.account, nil
+	}
+
+	// Find the device
+	wallets := hw.hub.Wallets()
+	if len(wallets) == 0 {
+		return accounts.Account{}, fmt.Errorf("No %s was found; please make sure it's plugged in and unlocked", hw.name)
+	}
+	if len(wallets) > 1 {
+		return accounts.Account{}, fmt.Errorf("Found %d %s devices; please make sure only one is plugged in", len(wallets), hw.name)
+	}
+	wallet := wallets[0]
+
+	// Open it
+	err := wallet.Open("")
+	if errors.Is(err, usbwallet.ErrTrezorPINNeeded) || errors.Is(err, usbwallet.ErrTrezorPassphraseNeeded) {
+		return accounts.Account{}, fmt.Errorf("Your %s asked for its PIN or passphrase to be entered on this machine, which isn't supported; please unlock it on the device itself", hw.name)
+	} else if err != nil {
+		return accounts.Account{}, fmt.Errorf("Could not open %s (if it's a Ledger, make sure the Ethereum app is open): %w", hw.name, err)
+	}
+
+	// Get the node account
+	account, err := wallet.Derive(hw.path, true)
+	if err != nil {
+		wallet.Close()
+		return accounts.Account{}, fmt.Errorf("Could not derive node account from %s: %w", hw.name, err)
+	}
+
+	// Cache it
+	hw.wallet = wallet
+	hw.account = account
+	return account, nil
+
+}
+
+// Sign a transaction with the hardware wallet; this blocks until the user approves or rejects it on the device
+func (hw *hardwareWallet) signTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	account, err := hw.getAccount()
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := hw.wallet.SignTx(account, tx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("%s did not sign the transaction: %w", hw.name, err)
+	}
+	return signedTx, nil
+}
+
+// Sign a message with the hardware wallet
+func (hw *hardwareWallet) signText(message []byte) ([]byte, error) {
+	account, err := hw.getAccount()
+	if err != nil {
+		return nil, err
+	}
+	signedMessage, err := hw.wallet.SignText(account, message)
+	if err != nil {
+		return nil, fmt.Errorf("%s did not sign the message: %w", hw.name, err)
+	}
+	return signedMessage, nil
+}
+
+// Get the name of the hardware wallet
+func (hw *hardwareWallet) getName() string {
+	return hw.name
+}
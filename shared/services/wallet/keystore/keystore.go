@@ -1,9 +1,16 @@
 package keystore
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/sethvargo/go-password/password"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
 )
 
 // Generates a random password
@@ -18,9 +25,78 @@ func GenerateRandomPassword() (string, error) {
 	return password, nil
 }
 
+// Decrypts the crypto section of an EIP-2335 keystore and verifies it holds the private key for the pubkey
+func DecryptValidatorKey(pubkey types.ValidatorPubkey, crypto map[string]interface{}, password string) (*eth2types.BLSPrivateKey, error) {
+
+	// Get the encryption function it uses
+	kdf, exists := crypto["kdf"]
+	if !exists {
+		return nil, fmt.Errorf("\"crypto\" didn't contain a subkey named \"kdf\"")
+	}
+	kdfMap, ok := kdf.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("\"crypto.kdf\" was not an object")
+	}
+	function, exists := kdfMap["function"]
+	if !exists {
+		return nil, fmt.Errorf("\"crypto.kdf\" didn't contain a subkey named \"function\"")
+	}
+	functionString, ok := function.(string)
+	if !ok {
+		return nil, fmt.Errorf("\"crypto.kdf.function\" was not a string")
+	}
+
+	// Decrypt the private key
+	encryptor := eth2ks.New(eth2ks.WithCipher(functionString))
+	decryptedKey, err := encryptor.Decrypt(crypto, password)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting keystore for validator %s: %w", pubkey.Hex(), err)
+	}
+	privateKey, err := eth2types.BLSPrivateKeyFromBytes(decryptedKey)
+	if err != nil {
+		return nil, fmt.Errorf("error recreating private key for validator %s: %w", pubkey.Hex(), err)
+	}
+
+	// Verify the private key matches the public key
+	reconstructedPubkey := types.BytesToValidatorPubkey(privateKey.PublicKey().Marshal())
+	if reconstructedPubkey != pubkey {
+		return nil, fmt.Errorf("keystore claims to be for validator %s but it's for validator %s", pubkey.Hex(), reconstructedPubkey.Hex())
+	}
+	return privateKey, nil
+
+}
+
+// Gets the pubkeys of the keys in a folder that has one entry per key named after its pubkey, ignoring any other entries
+func GetPubkeysFromDir(dir string, suffix string) ([]types.ValidatorPubkey, error) {
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []types.ValidatorPubkey{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading validator key folder %s: %w", dir, err)
+	}
+
+	pubkeys := []types.ValidatorPubkey{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		pubkey, err := types.HexToValidatorPubkey(hexutil.RemovePrefix(strings.TrimSuffix(name, suffix)))
+		if err != nil {
+			continue
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+
+}
+
 // Validator keystore interface
 type Keystore interface {
 	StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error
 	LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error)
+	GetValidatorPubkeys() ([]types.ValidatorPubkey, error)
 	GetKeystoreDir() string
 }
@@ -235,6 +235,23 @@ func (ks *Keystore) initialize() error {
 
 }
 
+// Get the pubkeys of the stored validator keys
+func (ks *Keystore) GetValidatorPubkeys() ([]types.ValidatorPubkey, error) {
+
+	// Initialize the account store
+	err := ks.initialize()
+	if err != nil {
+		return nil, err
+	}
+
+	pubkeys := make([]types.ValidatorPubkey, len(ks.as.PublicKeys))
+	for i, pubkey := range ks.as.PublicKeys {
+		pubkeys[i] = types.BytesToValidatorPubkey(pubkey)
+	}
+	return pubkeys, nil
+
+}
+
 // Load a private key
 func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
 
@@ -119,6 +119,11 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 
 }
 
+// Get the pubkeys of the stored validator keys
+func (ks *Keystore) GetValidatorPubkeys() ([]types.ValidatorPubkey, error) {
+	return keystore.GetPubkeysFromDir(filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir), ".json")
+}
+
 // Load a private key
 func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
 
@@ -0,0 +1,220 @@
+package web3signer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+	netutils "github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+// Config
+const (
+	KeystoresPath  = "/eth/v1/keystores"
+	RequestTimeout = 30 * time.Second
+
+	StatusImported  = "imported"
+	StatusDuplicate = "duplicate"
+)
+
+// Web3Signer keystore; keys are imported into a remote Web3Signer instance through its key manager API and never written to disk
+type Keystore struct {
+	url                    string
+	authToken              string
+	slashingProtectionPath string
+	client                 *http.Client
+	encryptor              *eth2ks.Encryptor
+}
+
+// Encrypted validator key store
+type validatorKey struct {
+	Crypto  map[string]interface{} `json:"crypto"`
+	Version uint                   `json:"version"`
+	UUID    uuid.UUID              `json:"uuid"`
+	Path    string                 `json:"path"`
+	Pubkey  types.ValidatorPubkey  `json:"pubkey"`
+}
+
+// Key manager API import request
+type importKeystoresRequest struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection,omitempty"`
+}
+
+// Key manager API list response
+type listKeystoresResponse struct {
+	Data []struct {
+		ValidatingPubkey string `json:"validating_pubkey"`
+	} `json:"data"`
+}
+
+// Key manager API import response
+type importKeystoresResponse struct {
+	Data []struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	} `json:"data"`
+}
+
+// Create new Web3Signer keystore.
+// Keystores and their passwords are sent to Web3Signer, so its URL must use https unless it's on this machine.
+func NewKeystore(url string, authToken string, slashingProtectionPath string) (*Keystore, error) {
+	if err := netutils.RequireTlsUnlessLoopback(url); err != nil {
+		return nil, fmt.Errorf("Invalid Web3Signer URL: %w", err)
+	}
+	return &Keystore{
+		url:                    strings.TrimSuffix(url, "/"),
+		authToken:              authToken,
+		slashingProtectionPath: slashingProtectionPath,
+		client:                 netutils.NewHttpClient(RequestTimeout),
+		encryptor:              eth2ks.New(eth2ks.WithCipher("scrypt")),
+	}, nil
+}
+
+// Get the keystore directory; Web3Signer doesn't store anything locally
+func (ks *Keystore) GetKeystoreDir() string {
+	return ""
+}
+
+// Store a validator key by importing it into Web3Signer
+func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+
+	// Get validator pubkey
+	pubkey := types.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+	// Create a new password; it's only used to protect the key in transit
+	password, err := keystore.GenerateRandomPassword()
+	if err != nil {
+		return fmt.Errorf("Could not generate random password: %w", err)
+	}
+
+	// Encrypt key
+	encryptedKey, err := ks.encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return fmt.Errorf("Could not encrypt validator key: %w", err)
+	}
+
+	// Encode key store
+	keyStoreBytes, err := json.Marshal(validatorKey{
+		Crypto:  encryptedKey,
+		Version: ks.encryptor.Version(),
+		UUID:    uuid.New(),
+		Path:    derivationPath,
+		Pubkey:  pubkey,
+	})
+	if err != nil {
+		return fmt.Errorf("Could not encode validator key: %w", err)
+	}
+
+	// Build the request, including the slashing protection history if there is one
+	request := importKeystoresRequest{
+		Keystores: []string{string(keyStoreBytes)},
+		Passwords: []string{password},
+	}
+	if ks.slashingProtectionPath != "" {
+		slashingProtection, err := os.ReadFile(ks.slashingProtectionPath)
+		if err != nil {
+			return fmt.Errorf("Could not read slashing protection file %s: %w", ks.slashingProtectionPath, err)
+		}
+		request.SlashingProtection = string(slashingProtection)
+	}
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("Could not encode Web3Signer import request: %w", err)
+	}
+
+	// Import the key
+	response, err := ks.sendRequest(http.MethodPost, bytes.NewReader(requestBytes))
+	if err != nil {
+		return fmt.Errorf("Could not import validator key %s into Web3Signer: %w", pubkey.Hex(), err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("Could not read Web3Signer response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("Could not import validator key %s into Web3Signer: HTTP status %d; response body: '%s'", pubkey.Hex(), response.StatusCode, string(body))
+	}
+
+	// Check the import status
+	var importResponse importKeystoresResponse
+	if err := json.Unmarshal(body, &importResponse); err != nil {
+		return fmt.Errorf("Could not decode Web3Signer response: %w", err)
+	}
+	if len(importResponse.Data) != 1 {
+		return fmt.Errorf("Web3Signer returned %d import statuses for validator key %s", len(importResponse.Data), pubkey.Hex())
+	}
+	status := importResponse.Data[0]
+	if status.Status != StatusImported && status.Status != StatusDuplicate {
+		return fmt.Errorf("Web3Signer could not import validator key %s: %s (%s)", pubkey.Hex(), status.Status, status.Message)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the pubkeys of the keys imported into Web3Signer
+func (ks *Keystore) GetValidatorPubkeys() ([]types.ValidatorPubkey, error) {
+
+	response, err := ks.sendRequest(http.MethodGet, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Could not list Web3Signer's validator keys: %w", err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read Web3Signer response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Could not list Web3Signer's validator keys: HTTP status %d; response body: '%s'", response.StatusCode, string(body))
+	}
+
+	var listResponse listKeystoresResponse
+	if err := json.Unmarshal(body, &listResponse); err != nil {
+		return nil, fmt.Errorf("Could not decode Web3Signer response: %w", err)
+	}
+	pubkeys := make([]types.ValidatorPubkey, len(listResponse.Data))
+	for i, key := range listResponse.Data {
+		pubkeys[i], err = types.HexToValidatorPubkey(hexutil.RemovePrefix(key.ValidatingPubkey))
+		if err != nil {
+			return nil, fmt.Errorf("Web3Signer returned an invalid validator pubkey: %w", err)
+		}
+	}
+	return pubkeys, nil
+
+}
+
+// Send a request to Web3Signer's key manager API, authenticating with the token if there is one
+func (ks *Keystore) sendRequest(method string, body io.Reader) (*http.Response, error) {
+	request, err := http.NewRequest(method, ks.url+KeystoresPath, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if ks.authToken != "" {
+		request.Header.Set("Authorization", "Bearer "+ks.authToken)
+	}
+	return ks.client.Do(request)
+}
+
+// Load a private key; keys imported into Web3Signer can't be retrieved, so this never finds one
+func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
+	return nil, nil
+}
@@ -0,0 +1,173 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// AWS KMS settings
+const (
+	awsKmsService          string        = "kms"
+	awsKmsTargetPrefix     string        = "TrentService."
+	awsKmsContentType      string        = "application/x-amz-json-1.1"
+	awsKmsSigningAlgorithm string        = "ECDSA_SHA_256"
+	awsKmsRequestTimeout   time.Duration = 30 * time.Second
+)
+
+// A secp256k1 key (ECC_SECG_P256K1) in AWS KMS.
+// Credentials are read from the standard AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables.
+type awsKmsBackend struct {
+	region          string
+	keyId           string
+	accessKeyId     string
+	secretAccessKey string
+	sessionToken    string
+	client          *http.Client
+}
+
+// Create an AWS KMS backend
+func newAwsKmsBackend(region string, keyId string) (*awsKmsBackend, error) {
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	if region == "" {
+		return nil, fmt.Errorf("no AWS region was provided")
+	}
+	backend := &awsKmsBackend{
+		region:          region,
+		keyId:           keyId,
+		accessKeyId:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		secretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		sessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		client:          &http.Client{Timeout: awsKmsRequestTimeout},
+	}
+	if backend.accessKeyId == "" || backend.secretAccessKey == "" {
+		return nil, fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set")
+	}
+	return backend, nil
+}
+
+// Get the key's public key
+func (b *awsKmsBackend) getPublicKey() (*ecdsa.PublicKey, error) {
+	var response struct {
+		PublicKey []byte `json:"PublicKey"`
+	}
+	err := b.call("GetPublicKey", map[string]string{"KeyId": b.keyId}, &response)
+	if err != nil {
+		return nil, err
+	}
+	return parseSecp256k1PublicKey(response.PublicKey)
+}
+
+// Sign a 32-byte digest
+func (b *awsKmsBackend) signDigest(digest []byte) ([]byte, error) {
+	request := struct {
+		KeyId            string `json:"KeyId"`
+		Message          []byte `json:"Message"`
+		MessageType      string `json:"MessageType"`
+		SigningAlgorithm string `json:"SigningAlgorithm"`
+	}{
+		KeyId:            b.keyId,
+		Message:          digest,
+		MessageType:      "DIGEST",
+		SigningAlgorithm: awsKmsSigningAlgorithm,
+	}
+	var response struct {
+		Signature []byte `json:"Signature"`
+	}
+	err := b.call("Sign", request, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Signature, nil
+}
+
+// Call an AWS KMS action, signing the request with AWS Signature Version 4
+func (b *awsKmsBackend) call(action string, request interface{}, response interface{}) error {
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("error serializing %s request: %w", action, err)
+	}
+	host := fmt.Sprintf("%s.%s.amazonaws.com", awsKmsService, b.region)
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+
+	// Build the canonical request; the headers have to be sorted by name
+	headers := [][2]string{
+		{"content-type", awsKmsContentType},
+		{"host", host},
+		{"x-amz-date", amzDate},
+	}
+	if b.sessionToken != "" {
+		headers = append(headers, [2]string{"x-amz-security-token", b.sessionToken})
+	}
+	headers = append(headers, [2]string{"x-amz-target", awsKmsTargetPrefix + action})
+	canonicalHeaders := ""
+	signedHeaderNames := []string{}
+	for _, header := range headers {
+		canonicalHeaders += header[0] + ":" + header[1] + "\n"
+		signedHeaderNames = append(signedHeaderNames, header[0])
+	}
+	signedHeaders := strings.Join(signedHeaderNames, ";")
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{http.MethodPost, "/", "", canonicalHeaders, signedHeaders, hex.EncodeToString(payloadHash[:])}, "\n")
+
+	// Sign it
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", dateStamp, b.region, awsKmsService)
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(canonicalRequestHash[:])}, "\n")
+	signingKey := hmacSha256([]byte("AWS4"+b.secretAccessKey), dateStamp)
+	signingKey = hmacSha256(signingKey, b.region)
+	signingKey = hmacSha256(signingKey, awsKmsService)
+	signingKey = hmacSha256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSha256(signingKey, stringToSign))
+
+	// Send the request
+	httpRequest, err := http.NewRequest(http.MethodPost, "https://"+host+"/", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating %s request: %w", action, err)
+	}
+	for _, header := range headers {
+		if header[0] != "host" {
+			httpRequest.Header.Set(header[0], header[1])
+		}
+	}
+	httpRequest.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", b.accessKeyId, scope, signedHeaders, signature))
+	httpResponse, err := b.client.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("error calling %s: %w", action, err)
+	}
+	defer httpResponse.Body.Close()
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return fmt.Errorf("error reading %s response: %w", action, err)
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s failed with status %s: %s", action, httpResponse.Status, string(responseBody))
+	}
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return fmt.Errorf("error decoding %s response: %w", action, err)
+	}
+	return nil
+
+}
+
+// Compute an HMAC-SHA256
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,157 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// Google Cloud KMS settings
+const (
+	gcpKmsApiUrl          string        = "https://cloudkms.googleapis.com/v1/"
+	gcpMetadataTokenUrl   string        = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+	gcpAccessTokenEnvVar  string        = "GOOGLE_OAUTH_ACCESS_TOKEN"
+	gcpKmsRequestTimeout  time.Duration = 30 * time.Second
+	gcpTokenRefreshMargin time.Duration = time.Minute
+)
+
+// An EC_SIGN_SECP256K1_SHA256 key version in Google Cloud KMS, such as
+// projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>.
+// The access token is read from GOOGLE_OAUTH_ACCESS_TOKEN if it's set, otherwise it's requested from the instance's metadata server.
+type gcpKmsBackend struct {
+	keyVersion string
+	client     *http.Client
+
+	token       string
+	tokenExpiry time.Time
+	tokenLock   sync.Mutex
+}
+
+// Create a Google Cloud KMS backend
+func newGcpKmsBackend(keyVersion string) *gcpKmsBackend {
+	return &gcpKmsBackend{
+		keyVersion: keyVersion,
+		client:     &http.Client{Timeout: gcpKmsRequestTimeout},
+	}
+}
+
+// Get the key's public key
+func (b *gcpKmsBackend) getPublicKey() (*ecdsa.PublicKey, error) {
+	var response struct {
+		Pem string `json:"pem"`
+	}
+	err := b.call(http.MethodGet, b.keyVersion+"/publicKey", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode([]byte(response.Pem))
+	if block == nil {
+		return nil, fmt.Errorf("the public key was not in PEM format")
+	}
+	return parseSecp256k1PublicKey(block.Bytes)
+}
+
+// Sign a 32-byte digest
+func (b *gcpKmsBackend) signDigest(digest []byte) ([]byte, error) {
+	request := map[string]map[string][]byte{
+		"digest": {"sha256": digest},
+	}
+	var response struct {
+		Signature []byte `json:"signature"`
+	}
+	err := b.call(http.MethodPost, b.keyVersion+":asymmetricSign", request, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Signature, nil
+}
+
+// Call a Cloud KMS API method
+func (b *gcpKmsBackend) call(method string, path string, request interface{}, response interface{}) error {
+
+	token, err := b.getAccessToken()
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if request != nil {
+		requestBytes, err := json.Marshal(request)
+		if err != nil {
+			return fmt.Errorf("error serializing request: %w", err)
+		}
+		body = bytes.NewReader(requestBytes)
+	}
+	httpRequest, err := http.NewRequest(method, gcpKmsApiUrl+path, body)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	httpRequest.Header.Set("Authorization", "Bearer "+token)
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := b.client.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("error calling Cloud KMS: %w", err)
+	}
+	defer httpResponse.Body.Close()
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return fmt.Errorf("error reading Cloud KMS response: %w", err)
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("Cloud KMS request failed with status %s: %s", httpResponse.Status, string(responseBody))
+	}
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return fmt.Errorf("error decoding Cloud KMS response: %w", err)
+	}
+	return nil
+
+}
+
+// Get an OAuth access token for Cloud KMS, refreshing it from the metadata server if it's about to expire
+func (b *gcpKmsBackend) getAccessToken() (string, error) {
+
+	if token := os.Getenv(gcpAccessTokenEnvVar); token != "" {
+		return token, nil
+	}
+
+	b.tokenLock.Lock()
+	defer b.tokenLock.Unlock()
+	if b.token != "" && time.Now().Add(gcpTokenRefreshMargin).Before(b.tokenExpiry) {
+		return b.token, nil
+	}
+
+	request, err := http.NewRequest(http.MethodGet, gcpMetadataTokenUrl, nil)
+	if err != nil {
+		return "", fmt.Errorf("error creating access token request: %w", err)
+	}
+	request.Header.Set("Metadata-Flavor", "Google")
+	response, err := b.client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("error getting access token from the metadata server (set %s if this machine isn't on Google Cloud): %w", gcpAccessTokenEnvVar, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("the metadata server returned status %s when requesting an access token", response.Status)
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("error decoding access token: %w", err)
+	}
+
+	b.token = token.AccessToken
+	b.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	return b.token, nil
+
+}
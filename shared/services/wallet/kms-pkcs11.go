@@ -0,0 +1,70 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// PKCS#11 settings
+const (
+	pkcs11ToolBin   string = "pkcs11-tool"
+	pkcs11PinEnvVar string = "PKCS11_PIN"
+)
+
+// A secp256k1 key on a PKCS#11 token (such as an HSM), accessed with OpenSC's pkcs11-tool.
+// The key ID is the hex ID of the key's objects on the token, and the user PIN is read from the PKCS11_PIN environment variable.
+type pkcs11Backend struct {
+	module string
+	keyId  string
+}
+
+// Create a PKCS#11 backend
+func newPkcs11Backend(module string, keyId string) (*pkcs11Backend, error) {
+	if module == "" {
+		return nil, fmt.Errorf("no PKCS#11 module was provided")
+	}
+	if _, err := exec.LookPath(pkcs11ToolBin); err != nil {
+		return nil, fmt.Errorf("%s (from OpenSC) is required to use a PKCS#11 token but it isn't installed", pkcs11ToolBin)
+	}
+	if os.Getenv(pkcs11PinEnvVar) == "" {
+		return nil, fmt.Errorf("%s must be set to the token's user PIN", pkcs11PinEnvVar)
+	}
+	return &pkcs11Backend{
+		module: module,
+		keyId:  keyId,
+	}, nil
+}
+
+// Get the key's public key
+func (b *pkcs11Backend) getPublicKey() (*ecdsa.PublicKey, error) {
+	output, err := b.run(nil, "--read-object", "--type", "pubkey", "--id", b.keyId)
+	if err != nil {
+		return nil, err
+	}
+	return parseSecp256k1PublicKey(output)
+}
+
+// Sign a 32-byte digest
+func (b *pkcs11Backend) signDigest(digest []byte) ([]byte, error) {
+	// The PIN is passed through the environment so it doesn't show up in the process list
+	return b.run(digest, "--login", "--pin", "env:"+pkcs11PinEnvVar, "--sign", "--mechanism", "ECDSA", "--signature-format", "openssl", "--id", b.keyId)
+}
+
+// Run pkcs11-tool against the module
+func (b *pkcs11Backend) run(input []byte, args ...string) ([]byte, error) {
+	cmd := exec.Command(pkcs11ToolBin, append([]string{"--module", b.module}, args...)...)
+	if input != nil {
+		cmd.Stdin = bytes.NewReader(input)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("error running %s: %w (%s)", pkcs11ToolBin, err, strings.TrimSpace(stderr.String()))
+	}
+	return output, nil
+}
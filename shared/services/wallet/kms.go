@@ -0,0 +1,192 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Supported key management services
+const (
+	KmsProviderPkcs11 = "pkcs11"
+	KmsProviderAws    = "aws"
+	KmsProviderGcp    = "gcp"
+)
+
+// A key management service that holds a secp256k1 key and signs digests with it
+type kmsBackend interface {
+	// Get the key's public key
+	getPublicKey() (*ecdsa.PublicKey, error)
+
+	// Sign a 32-byte digest, returning a DER-encoded ECDSA signature
+	signDigest(digest []byte) ([]byte, error)
+}
+
+// A node account held in a key management service
+type kmsSigner struct {
+	name    string
+	backend kmsBackend
+	account *accounts.Account
+}
+
+// A DER-encoded ECDSA signature
+type ecdsaSignature struct {
+	R *big.Int
+	S *big.Int
+}
+
+// A DER-encoded SubjectPublicKeyInfo
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// Use a key management service for the node account instead of the key derived from the wallet seed.
+// The service isn't contacted until the node account is first needed.
+func (w *Wallet) SetKmsSigner(provider string, keyId string, region string, pkcs11Module string) error {
+
+	if keyId == "" {
+		return fmt.Errorf("No key ID was provided for the node account's key management service")
+	}
+
+	// Create the backend for the provider
+	var backend kmsBackend
+	var name string
+	var err error
+	switch provider {
+	case KmsProviderPkcs11:
+		name = "PKCS#11 token"
+		backend, err = newPkcs11Backend(pkcs11Module, keyId)
+	case KmsProviderAws:
+		name = "AWS KMS key"
+		backend, err = newAwsKmsBackend(region, keyId)
+	case KmsProviderGcp:
+		name = "Google Cloud KMS key"
+		backend = newGcpKmsBackend(keyId)
+	default:
+		return fmt.Errorf("Unknown key management service '%s'", provider)
+	}
+	if err != nil {
+		return fmt.Errorf("Could not set up %s: %w", name, err)
+	}
+
+	w.external = &kmsSigner{
+		name:    name,
+		backend: backend,
+	}
+	return nil
+
+}
+
+// Get the name of the key management service
+func (ks *kmsSigner) getName() string {
+	return ks.name
+}
+
+// Get the node account from the key management service's public key
+func (ks *kmsSigner) getAccount() (accounts.Account, error) {
+
+	// Check for a cached account
+	if ks.account != nil {
+		return *ks.account, nil
+	}
+
+	// Get the public key
+	publicKey, err := ks.backend.getPublicKey()
+	if err != nil {
+		return accounts.Account{}, fmt.Errorf("Could not get the node account's public key from the %s: %w", ks.name, err)
+	}
+
+	// Cache it
+	ks.account = &accounts.Account{
+		Address: crypto.PubkeyToAddress(*publicKey),
+	}
+	return *ks.account, nil
+
+}
+
+// Sign a transaction with the key management service
+func (ks *kmsSigner) signTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signer := types.LatestSignerForChainID(chainID)
+	signature, err := ks.signHash(signer.Hash(tx).Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(signer, signature)
+}
+
+// Sign a message with the key management service
+func (ks *kmsSigner) signText(message []byte) ([]byte, error) {
+	signedMessage, err := ks.signHash(accounts.TextHash(message))
+	if err != nil {
+		return nil, err
+	}
+
+	// fix the ECDSA 'v', the same way as messages signed with the node wallet
+	signedMessage[crypto.RecoveryIDOffset] += 27
+	return signedMessage, nil
+}
+
+// Have the key management service sign a hash, and convert its signature to Ethereum's [R || S || V] format
+func (ks *kmsSigner) signHash(hash []byte) ([]byte, error) {
+
+	account, err := ks.getAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign the hash
+	der, err := ks.backend.signDigest(hash)
+	if err != nil {
+		return nil, fmt.Errorf("%s did not sign the hash: %w", ks.name, err)
+	}
+	var sig ecdsaSignature
+	if _, err := asn1.Unmarshal(der, &sig); err != nil {
+		return nil, fmt.Errorf("Could not decode signature from the %s: %w", ks.name, err)
+	}
+
+	// Ethereum only accepts signatures in the lower half of the curve order
+	curveOrder := crypto.S256().Params().N
+	halfOrder := new(big.Int).Rsh(curveOrder, 1)
+	if sig.S.Cmp(halfOrder) > 0 {
+		sig.S = new(big.Int).Sub(curveOrder, sig.S)
+	}
+
+	// The service doesn't provide the recovery ID, so find the one that recovers the node account
+	signature := make([]byte, crypto.SignatureLength)
+	sig.R.FillBytes(signature[0:32])
+	sig.S.FillBytes(signature[32:64])
+	for recoveryId := byte(0); recoveryId < 2; recoveryId++ {
+		signature[crypto.RecoveryIDOffset] = recoveryId
+		publicKey, err := crypto.SigToPub(hash, signature)
+		if err != nil {
+			continue
+		}
+		recoveredAddress := crypto.PubkeyToAddress(*publicKey)
+		if bytes.Equal(recoveredAddress[:], account.Address[:]) {
+			return signature, nil
+		}
+	}
+	return nil, fmt.Errorf("The signature from the %s doesn't match the node account", ks.name)
+
+}
+
+// Parse a DER-encoded SubjectPublicKeyInfo holding a secp256k1 key
+func parseSecp256k1PublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	var spki subjectPublicKeyInfo
+	if _, err := asn1.Unmarshal(der, &spki); err != nil {
+		return nil, fmt.Errorf("error decoding public key: %w", err)
+	}
+	publicKey, err := crypto.UnmarshalPubkey(spki.PublicKey.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("the key is not a secp256k1 key: %w", err)
+	}
+	return publicKey, nil
+}
@@ -17,10 +17,32 @@ import (
 func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
 	// Check wallet is initialized
-	if !w.IsInitialized() {
+	if !w.IsInitialized() && !w.IsWatchOnly() {
 		return accounts.Account{}, errors.New("Wallet is not initialized")
 	}
 
+	// The Safe is the node account if there is one
+	if w.safe != nil {
+		return w.getSafeAccount(), nil
+	}
+
+	return w.getSignerAccount()
+
+}
+
+// Get the account that signs for the node, which is the node account unless it's a Safe
+func (w *Wallet) getSignerAccount() (accounts.Account, error) {
+
+	// Get the account from the external signer if there is one
+	if w.external != nil {
+		return w.external.getAccount()
+	}
+
+	// Use the watch-only address if this machine doesn't have the node wallet
+	if w.IsWatchOnly() {
+		return w.getWatchOnlyAccount(), nil
+	}
+
 	// Get private key
 	privateKey, path, err := w.getNodePrivateKey()
 	if err != nil {
@@ -47,12 +69,71 @@ func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
 // Get a transactor for the node account
 func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
+	return w.getNodeAccountTransactor(true)
+}
+
+// Get a transactor for the node account for transactions that can't wait behind deferred transactions.
+// It takes the node's next nonce even if a deferred transaction holds it; the daemon then discards that deferred transaction.
+func (w *Wallet) GetUrgentNodeAccountTransactor() (*bind.TransactOpts, error) {
+	return w.getNodeAccountTransactor(false)
+}
+
+// Get a transactor for the node account, optionally making sure deferred transactions don't hold its next nonce
+func (w *Wallet) getNodeAccountTransactor(checkDeferredNonces bool) (*bind.TransactOpts, error) {
 
 	// Check wallet is initialized
-	if !w.IsInitialized() {
+	if !w.IsInitialized() && !w.IsWatchOnly() {
 		return nil, errors.New("Wallet is not initialized")
 	}
 
+	// Only simulate transactions in dry runs
+	if w.dryRunTxsPath != "" {
+		return w.getDryRunTransactor()
+	}
+
+	// Save transactions unsigned so they can be signed offline if requested
+	if w.unsignedTxsPath != "" {
+		return w.getUnsignedTransactor()
+	}
+
+	// Transactions can't be signed without the node wallet
+	if w.IsWatchOnly() {
+		return nil, errWatchOnly
+	}
+
+	// Save signed transactions for the daemon to submit once fees are low enough if requested
+	if w.deferTxs {
+		return w.getDeferredTransactor()
+	}
+
+	// Propose transactions to the Safe if there is one
+	if w.safe != nil {
+		return w.getSafeTransactor()
+	}
+
+	opts, err := w.getSigningTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Deferred transactions hold the node's next nonces until the daemon submits them
+	if checkDeferredNonces {
+		if err := w.checkDeferredNonces(opts.From); err != nil {
+			return nil, err
+		}
+	}
+	return opts, nil
+
+}
+
+// Get a transactor that signs and submits transactions with the account that signs for the node
+func (w *Wallet) getSigningTransactor() (*bind.TransactOpts, error) {
+
+	// Sign with the external signer if there is one
+	if w.external != nil {
+		return w.getExternalTransactor()
+	}
+
 	// Get private key
 	privateKey, _, err := w.getNodePrivateKey()
 	if err != nil {
@@ -77,6 +158,11 @@ func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 		return nil, errors.New("Wallet is not initialized")
 	}
 
+	// External signers never expose their keys
+	if w.external != nil {
+		return nil, fmt.Errorf("The node account is on a %s, so its private key can't be exported", w.external.getName())
+	}
+
 	// Get private key
 	privateKey, _, err := w.getNodePrivateKey()
 	if err != nil {
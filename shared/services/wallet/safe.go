@@ -0,0 +1,234 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/smartnode/shared/services/safe"
+)
+
+// Settings
+const (
+	safePollInterval time.Duration = 15 * time.Second
+)
+
+// A Safe that is used as the node account, with the node wallet's account proposing its transactions
+type safeAccount struct {
+	client        *safe.Client
+	proposalsPath string
+}
+
+// Use a Safe as the node account; node transactions will be proposed to it instead of being sent
+func (w *Wallet) SetSafe(address common.Address, serviceUrl string, proposalsPath string) {
+	w.safe = &safeAccount{
+		client:        safe.NewClient(serviceUrl, address, w.chainID),
+		proposalsPath: proposalsPath,
+	}
+}
+
+// Check if the node account is a Safe
+func (w *Wallet) IsSafe() bool {
+	return w.safe != nil
+}
+
+// Propose a transaction from the node account to its Safe, returning the Safe transaction hash
+func (w *Wallet) ProposeSafeTransaction(to common.Address, value *big.Int, data []byte) (common.Hash, error) {
+	if w.safe == nil {
+		return common.Hash{}, fmt.Errorf("the node account is not a Safe")
+	}
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	return w.proposeToSafe(to, value, data, nil)
+}
+
+// Get a transactor that proposes transactions to the Safe instead of sending them
+func (w *Wallet) getSafeTransactor() (*bind.TransactOpts, error) {
+	safeAddress := w.safe.client.GetAddress()
+	return &bind.TransactOpts{
+		From: safeAddress,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != safeAddress {
+				return nil, bind.ErrNotAuthorized
+			}
+			if tx.To() == nil {
+				return nil, fmt.Errorf("contract deployments can't be proposed to a Safe")
+			}
+			txHash := tx.Hash()
+			_, err := w.proposeToSafe(*tx.To(), tx.Value(), tx.Data(), &txHash)
+			if err != nil {
+				return nil, err
+			}
+			return tx, nil
+		},
+		GasFeeCap: w.maxFee,
+		GasTipCap: w.maxPriorityFee,
+		GasLimit:  w.gasLimit,
+		Context:   context.Background(),
+		NoSend:    true,
+	}, nil
+}
+
+// Propose a transaction to the Safe, unless an identical one is already waiting for approval
+func (w *Wallet) proposeToSafe(to common.Address, value *big.Int, data []byte, txHash *common.Hash) (common.Hash, error) {
+
+	proposals, err := safe.LoadProposals(w.safe.proposalsPath)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	// Reuse a pending proposal for the same call so retries don't queue duplicates
+	for i, proposal := range proposals {
+		if proposal.Matches(to, value, data) {
+			if txHash != nil && !proposal.HasTxHash(*txHash) {
+				proposals[i].TxHashes = append(proposals[i].TxHashes, *txHash)
+				err = safe.SaveProposals(w.safe.proposalsPath, proposals)
+			}
+			return proposal.SafeTxHash, err
+		}
+	}
+
+	// Sign the Safe transaction hash with the proposer account
+	nonce, err := w.safe.client.GetNextNonce()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	safeTxHash := w.safe.client.GetSafeTxHash(to, value, data, nonce)
+	proposer, err := w.getSignerAccount()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	signature, err := w.signSafeTxHash(safeTxHash)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error signing Safe transaction: %w", err)
+	}
+
+	// Propose it and keep track of it
+	err = w.safe.client.ProposeTransaction(to, value, data, nonce, safeTxHash, proposer.Address, signature)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	proposal := safe.Proposal{
+		SafeTxHash: safeTxHash,
+		Nonce:      nonce,
+		To:         to,
+		Value:      value,
+		Data:       data,
+		ProposedAt: time.Now(),
+		TxHashes:   []common.Hash{safeTxHash},
+	}
+	if txHash != nil {
+		proposal.TxHashes = append(proposal.TxHashes, *txHash)
+	}
+	proposals = append(proposals, proposal)
+	err = safe.SaveProposals(w.safe.proposalsPath, proposals)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return safeTxHash, nil
+
+}
+
+// Sign a Safe transaction hash in the format the Safe contract expects from an owner
+func (w *Wallet) signSafeTxHash(safeTxHash common.Hash) ([]byte, error) {
+
+	// External signers can only sign prefixed messages, which the Safe accepts with v offset by 4
+	if w.external != nil {
+		signature, err := w.external.signText(safeTxHash.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		if signature[crypto.RecoveryIDOffset] < 27 {
+			signature[crypto.RecoveryIDOffset] += 27
+		}
+		signature[crypto.RecoveryIDOffset] += 4
+		return signature, nil
+	}
+
+	privateKey, _, err := w.getNodePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := crypto.Sign(safeTxHash.Bytes(), privateKey)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+
+}
+
+// Get the account of the Safe
+func (w *Wallet) getSafeAccount() accounts.Account {
+	return accounts.Account{
+		Address: w.safe.client.GetAddress(),
+		URL: accounts.URL{
+			Scheme: "safe",
+			Path:   w.safe.client.GetAddress().Hex(),
+		},
+	}
+}
+
+// Wait for the Safe to execute a transaction that was proposed to it, returning the hash of the transaction that executed it.
+// Returns false if the hash isn't one the Smartnode reported for a pending proposal.
+func (w *Wallet) WaitForSafeTransaction(txHash common.Hash) (common.Hash, bool, error) {
+
+	if w.safe == nil {
+		return common.Hash{}, false, nil
+	}
+	proposals, err := safe.LoadProposals(w.safe.proposalsPath)
+	if err != nil {
+		return common.Hash{}, false, err
+	}
+	var proposal *safe.Proposal
+	for i := range proposals {
+		if proposals[i].HasTxHash(txHash) {
+			proposal = &proposals[i]
+			break
+		}
+	}
+	if proposal == nil {
+		return common.Hash{}, false, nil
+	}
+
+	for {
+		tx, err := w.safe.client.GetTransaction(proposal.SafeTxHash)
+		if err != nil {
+			return common.Hash{}, true, err
+		}
+		if tx.IsExecuted && tx.TransactionHash != nil {
+			err = safe.RemoveProposal(w.safe.proposalsPath, proposal.SafeTxHash)
+			if err != nil {
+				return common.Hash{}, true, err
+			}
+			if tx.IsSuccessful != nil && !*tx.IsSuccessful {
+				return common.Hash{}, true, fmt.Errorf("the Safe executed transaction %s but it reverted (execution transaction %s)", proposal.SafeTxHash.Hex(), tx.TransactionHash.Hex())
+			}
+			return *tx.TransactionHash, true, nil
+		}
+
+		// Stop if another transaction with the same nonce was executed instead
+		info, err := w.safe.client.GetSafeInfo()
+		if err != nil {
+			return common.Hash{}, true, err
+		}
+		if info.Nonce > proposal.Nonce {
+			err = safe.RemoveProposal(w.safe.proposalsPath, proposal.SafeTxHash)
+			if err != nil {
+				return common.Hash{}, true, err
+			}
+			return common.Hash{}, true, fmt.Errorf("Safe transaction %s was replaced by another transaction with nonce %d", proposal.SafeTxHash.Hex(), proposal.Nonce)
+		}
+
+		time.Sleep(safePollInterval)
+	}
+
+}
@@ -0,0 +1,47 @@
+package wallet
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// A hardware wallet or key management service that holds the node account's key instead of the node wallet
+type externalSigner interface {
+	// The name of the signer, for messages
+	getName() string
+
+	// Get the node account
+	getAccount() (accounts.Account, error)
+
+	// Sign a transaction from the node account
+	signTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+
+	// Sign a message from the node account, with the same format as personal_sign
+	signText(message []byte) ([]byte, error)
+}
+
+// Get a transactor that signs with the external signer
+func (w *Wallet) getExternalTransactor() (*bind.TransactOpts, error) {
+	account, err := w.external.getAccount()
+	if err != nil {
+		return nil, err
+	}
+	return &bind.TransactOpts{
+		From: account.Address,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != account.Address {
+				return nil, bind.ErrNotAuthorized
+			}
+			return w.external.signTx(tx, w.chainID)
+		},
+		GasFeeCap: w.maxFee,
+		GasTipCap: w.maxPriorityFee,
+		GasLimit:  w.gasLimit,
+		Context:   context.Background(),
+	}, nil
+}
@@ -0,0 +1,83 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const (
+	unsignedTxsDirMode  = 0755
+	unsignedTxsFileMode = 0644
+)
+
+// Save node transactions unsigned to the given folder instead of signing and submitting them
+func (w *Wallet) SetUnsigned(unsignedTxsPath string) {
+	w.unsignedTxsPath = unsignedTxsPath
+}
+
+// Check if node transactions are being saved unsigned instead of being submitted
+func (w *Wallet) IsUnsigned() bool {
+	return w.unsignedTxsPath != ""
+}
+
+// Get a transactor that saves transactions unsigned instead of signing them.
+// It never sends them, so the hash it reports is the hash of the unsigned transaction.
+func (w *Wallet) getUnsignedTransactor() (*bind.TransactOpts, error) {
+	if w.safe != nil {
+		return nil, fmt.Errorf("The node account is a Safe, so its transactions are proposed to the Safe and can't be saved unsigned")
+	}
+	account, err := w.getSignerAccount()
+	if err != nil {
+		return nil, err
+	}
+	return &bind.TransactOpts{
+		From: account.Address,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != account.Address {
+				return nil, bind.ErrNotAuthorized
+			}
+			err := w.saveUnsignedTransaction(address, tx)
+			if err != nil {
+				return nil, err
+			}
+			return tx, nil
+		},
+		GasFeeCap: w.maxFee,
+		GasTipCap: w.maxPriorityFee,
+		GasLimit:  w.gasLimit,
+		Context:   context.Background(),
+		NoSend:    true,
+	}, nil
+}
+
+// Save an unsigned transaction and its summary to disk
+func (w *Wallet) saveUnsignedTransaction(from common.Address, tx *types.Transaction) error {
+	unsignedTx, err := api.NewUnsignedTransaction(tx, from)
+	if err != nil {
+		return err
+	}
+	bytes, err := json.MarshalIndent(unsignedTx, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error serializing unsigned transaction: %w", err)
+	}
+	err = os.MkdirAll(w.unsignedTxsPath, unsignedTxsDirMode)
+	if err != nil {
+		return fmt.Errorf("error creating unsigned transactions folder [%s]: %w", w.unsignedTxsPath, err)
+	}
+	path := filepath.Join(w.unsignedTxsPath, api.GetUnsignedTransactionFilename(tx.Hash()))
+	err = os.WriteFile(path, bytes, unsignedTxsFileMode)
+	if err != nil {
+		return fmt.Errorf("error writing unsigned transaction [%s]: %w", path, err)
+	}
+	return nil
+}
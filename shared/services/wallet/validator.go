@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/rocket-pool/rocketpool-go/types"
@@ -136,6 +137,24 @@ func (w *Wallet) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSP
 
 }
 
+// Gets the pubkeys of the validator keys in one of the wallet's keystores
+func (w *Wallet) GetKeystoreValidatorPubkeys(name string) ([]types.ValidatorPubkey, error) {
+	ks, exists := w.keystores[name]
+	if !exists {
+		return nil, fmt.Errorf("the wallet doesn't have a %s keystore", name)
+	}
+	return ks.GetValidatorPubkeys()
+}
+
+// Loads a validator key from one of the wallet's keystores; returns nil if the keystore doesn't have it
+func (w *Wallet) LoadValidatorKeyFromKeystore(name string, pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
+	ks, exists := w.keystores[name]
+	if !exists {
+		return nil, fmt.Errorf("the wallet doesn't have a %s keystore", name)
+	}
+	return ks.LoadValidatorKey(pubkey)
+}
+
 // Deletes all of the keystore directories and persistent VC storage
 func (w *Wallet) DeleteValidatorStores() error {
 
@@ -297,6 +316,100 @@ func (w *Wallet) TestRecoverValidatorKey(pubkey rptypes.ValidatorPubkey, startIn
 
 }
 
+// Set the non-standard derivation paths to search when recovering validator keys.
+// keyPath is a path pattern with a single %d for the key index, and overrides map validator pubkeys to the exact path of their key.
+func (w *Wallet) SetValidatorKeyPaths(keyPath string, overrides map[rptypes.ValidatorPubkey]string) error {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return errors.New("Wallet is not initialized")
+	}
+
+	// Check the paths
+	if keyPath != "" {
+		if err := checkValidatorKeyPath(keyPath, true); err != nil {
+			return err
+		}
+	}
+	storedOverrides := map[string]string{}
+	for pubkey, derivationPath := range overrides {
+		if err := checkValidatorKeyPath(derivationPath, false); err != nil {
+			return fmt.Errorf("invalid derivation path for validator %s: %w", pubkey.Hex(), err)
+		}
+		storedOverrides[pubkey.Hex()] = derivationPath
+	}
+
+	// Update the wallet store
+	w.ws.ValidatorKeyPath = keyPath
+	w.ws.ValidatorKeyPathOverrides = storedOverrides
+	return nil
+
+}
+
+// Get the non-standard validator key derivation path pattern, or an empty string if there isn't one
+func (w *Wallet) GetValidatorKeyPath() string {
+	if w.ws == nil {
+		return ""
+	}
+	return w.ws.ValidatorKeyPath
+}
+
+// Get the exact derivation paths of validator keys that don't follow any path pattern
+func (w *Wallet) GetValidatorKeyPathOverrides() (map[rptypes.ValidatorPubkey]string, error) {
+	overrides := map[rptypes.ValidatorPubkey]string{}
+	if w.ws == nil {
+		return overrides, nil
+	}
+	for pubkeyString, derivationPath := range w.ws.ValidatorKeyPathOverrides {
+		pubkey, err := rptypes.HexToValidatorPubkey(pubkeyString)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validator pubkey [%s] in derivation path overrides: %w", pubkeyString, err)
+		}
+		overrides[pubkey] = derivationPath
+	}
+	return overrides, nil
+}
+
+// Get a set of validator keys derived with a non-standard path pattern
+func (w *Wallet) GetValidatorKeysAtPath(keyPath string, startIndex uint, length uint) ([]ValidatorKey, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, errors.New("Wallet is not initialized")
+	}
+
+	validatorKeys := make([]ValidatorKey, 0, length)
+	for index := startIndex; index < startIndex+length; index++ {
+		derivationPath := fmt.Sprintf(keyPath, index)
+		key, err := w.getValidatorPrivateKeyAtPath(derivationPath)
+		if err != nil {
+			return nil, err
+		}
+		validatorKey := ValidatorKey{
+			PublicKey:      types.BytesToValidatorPubkey(key.PublicKey().Marshal()),
+			PrivateKey:     key,
+			DerivationPath: derivationPath,
+			WalletIndex:    index,
+		}
+		validatorKeys = append(validatorKeys, validatorKey)
+	}
+
+	return validatorKeys, nil
+
+}
+
+// Get a validator key by its exact derivation path
+func (w *Wallet) GetValidatorKeyAtPath(derivationPath string) (*eth2types.BLSPrivateKey, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, errors.New("Wallet is not initialized")
+	}
+
+	return w.getValidatorPrivateKeyAtPath(derivationPath)
+
+}
+
 // Get a validator private key by index
 func (w *Wallet) getValidatorPrivateKey(index uint) (*eth2types.BLSPrivateKey, string, error) {
 
@@ -308,15 +421,10 @@ func (w *Wallet) getValidatorPrivateKey(index uint) (*eth2types.BLSPrivateKey, s
 		return validatorKey, derivationPath, nil
 	}
 
-	// Initialize BLS support
-	if err := validator.InitializeBLS(); err != nil {
-		return nil, "", fmt.Errorf("Could not initialize BLS library: %w", err)
-	}
-
 	// Get private key
-	privateKey, err := eth2util.PrivateKeyFromSeedAndPath(w.seed, derivationPath)
+	privateKey, err := w.getValidatorPrivateKeyAtPath(derivationPath)
 	if err != nil {
-		return nil, "", fmt.Errorf("Could not get validator %d private key: %w", index, err)
+		return nil, "", err
 	}
 
 	// Cache validator key
@@ -326,3 +434,42 @@ func (w *Wallet) getValidatorPrivateKey(index uint) (*eth2types.BLSPrivateKey, s
 	return privateKey, derivationPath, nil
 
 }
+
+// Get a validator private key by derivation path
+func (w *Wallet) getValidatorPrivateKeyAtPath(derivationPath string) (*eth2types.BLSPrivateKey, error) {
+
+	// Initialize BLS support
+	if err := validator.InitializeBLS(); err != nil {
+		return nil, fmt.Errorf("Could not initialize BLS library: %w", err)
+	}
+
+	// Get private key
+	privateKey, err := eth2util.PrivateKeyFromSeedAndPath(w.seed, derivationPath)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get validator private key at %s: %w", derivationPath, err)
+	}
+	return privateKey, nil
+
+}
+
+// Check that a validator key derivation path is well-formed, optionally as a pattern with a single %d for the key index
+func checkValidatorKeyPath(derivationPath string, isPattern bool) error {
+	components := strings.Split(derivationPath, "/")
+	if len(components) < 2 || components[0] != "m" {
+		return fmt.Errorf("derivation path [%s] must start with m/", derivationPath)
+	}
+	indexCount := 0
+	for _, component := range components[1:] {
+		if isPattern && component == "%d" {
+			indexCount++
+			continue
+		}
+		if _, err := strconv.ParseUint(component, 10, 32); err != nil {
+			return fmt.Errorf("derivation path [%s] has an invalid component [%s]; EIP-2334 paths only contain numbers", derivationPath, component)
+		}
+	}
+	if isPattern && indexCount != 1 {
+		return fmt.Errorf("derivation path [%s] must contain exactly one %%d for the key index", derivationPath)
+	}
+	return nil
+}
@@ -10,8 +10,10 @@ import (
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/goccy/go-json"
 	"github.com/google/uuid"
 	"github.com/tyler-smith/go-bip39"
@@ -51,6 +53,27 @@ type Wallet struct {
 	nodeKey     *ecdsa.PrivateKey
 	nodeKeyPath string
 
+	// Hardware wallet or key management service that holds the node account
+	external externalSigner
+
+	// Safe that is used as the node account, if any
+	safe *safeAccount
+
+	// Node account address used when this machine doesn't have the node wallet
+	watchOnlyAddress *common.Address
+
+	// Folder that node transactions are saved to unsigned, instead of being submitted
+	unsignedTxsPath string
+
+	// Folder that simulated node transactions are saved to in dry runs, and the client that simulates them
+	dryRunTxsPath string
+	simulator     TransactionSimulator
+
+	// Folder that signed node transactions are saved to for the daemon to submit once fees are low enough, and the client used to pick their nonces
+	deferredTxsPath string
+	nonceReader     DeferredNonceReader
+	deferTxs        bool
+
 	// Validator key caches
 	validatorKeys map[uint]*eth2types.BLSPrivateKey
 
@@ -72,6 +95,10 @@ type walletStore struct {
 	DerivationPath string                 `json:"derivationPath,omitempty"`
 	WalletIndex    uint                   `json:"walletIndex,omitempty"`
 	NextAccount    uint                   `json:"next_account"`
+
+	// Non-standard validator key derivation paths, for keys that were created by other tools
+	ValidatorKeyPath          string            `json:"validatorKeyPath,omitempty"`
+	ValidatorKeyPathOverrides map[string]string `json:"validatorKeyPathOverrides,omitempty"`
 }
 
 // Create new wallet
@@ -143,8 +170,8 @@ func (w *Wallet) String() (string, error) {
 
 }
 
-// Initialize the wallet from a random seed
-func (w *Wallet) Initialize(derivationPath string, walletIndex uint) (string, error) {
+// Initialize the wallet from a random seed, with an optional BIP-39 mnemonic passphrase
+func (w *Wallet) Initialize(derivationPath string, walletIndex uint, passphrase string) (string, error) {
 
 	// Check wallet is not initialized
 	if w.IsInitialized() {
@@ -164,7 +191,7 @@ func (w *Wallet) Initialize(derivationPath string, walletIndex uint) (string, er
 	}
 
 	// Initialize wallet store
-	if err := w.initializeStore(derivationPath, walletIndex, mnemonic); err != nil {
+	if err := w.initializeStore(derivationPath, walletIndex, mnemonic, passphrase); err != nil {
 		return "", err
 	}
 
@@ -173,8 +200,8 @@ func (w *Wallet) Initialize(derivationPath string, walletIndex uint) (string, er
 
 }
 
-// Recover a wallet from a mnemonic
-func (w *Wallet) Recover(derivationPath string, walletIndex uint, mnemonic string) error {
+// Recover a wallet from a mnemonic and its optional BIP-39 passphrase
+func (w *Wallet) Recover(derivationPath string, walletIndex uint, mnemonic string, passphrase string) error {
 
 	// Check wallet is not initialized
 	if w.IsInitialized() {
@@ -187,7 +214,7 @@ func (w *Wallet) Recover(derivationPath string, walletIndex uint, mnemonic strin
 	}
 
 	// Initialize wallet store
-	if err := w.initializeStore(derivationPath, walletIndex, mnemonic); err != nil {
+	if err := w.initializeStore(derivationPath, walletIndex, mnemonic, passphrase); err != nil {
 		return err
 	}
 
@@ -197,7 +224,7 @@ func (w *Wallet) Recover(derivationPath string, walletIndex uint, mnemonic strin
 }
 
 // Recover a wallet from a mnemonic - only used for testing mnemonics
-func (w *Wallet) TestRecovery(derivationPath string, walletIndex uint, mnemonic string) error {
+func (w *Wallet) TestRecovery(derivationPath string, walletIndex uint, mnemonic string, passphrase string) error {
 
 	// Check mnemonic
 	if !bip39.IsMnemonicValid(mnemonic) {
@@ -205,7 +232,7 @@ func (w *Wallet) TestRecovery(derivationPath string, walletIndex uint, mnemonic
 	}
 
 	// Generate seed
-	w.seed = bip39.NewSeed(mnemonic, "")
+	w.seed = bip39.NewSeed(mnemonic, passphrase)
 
 	// Create master key
 	var err error
@@ -272,22 +299,35 @@ func (w *Wallet) Delete() error {
 
 // Signs a serialized TX using the wallet's private key
 func (w *Wallet) Sign(serializedTx []byte) ([]byte, error) {
-	// Get private key
-	privateKey, _, err := w.getNodePrivateKey()
-	if err != nil {
-		return nil, err
+	if w.safe != nil {
+		return nil, fmt.Errorf("The node account is a Safe, so transactions must be approved by its owners instead of being signed")
 	}
 
 	tx := types.Transaction{}
-	err = tx.UnmarshalBinary(serializedTx)
+	err := tx.UnmarshalBinary(serializedTx)
 	if err != nil {
 		return nil, fmt.Errorf("Error unmarshalling TX: %w", err)
 	}
 
-	signer := types.NewLondonSigner(w.chainID)
-	signedTx, err := types.SignTx(&tx, signer, privateKey)
-	if err != nil {
-		return nil, fmt.Errorf("Error signing TX: %w", err)
+	var signedTx *types.Transaction
+	if w.external != nil {
+		// Have the external signer sign it
+		signedTx, err = w.external.signTx(&tx, w.chainID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Get private key
+		privateKey, _, err := w.getNodePrivateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		signer := types.NewLondonSigner(w.chainID)
+		signedTx, err = types.SignTx(&tx, signer, privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("Error signing TX: %w", err)
+		}
 	}
 
 	signedData, err := signedTx.MarshalBinary()
@@ -300,6 +340,15 @@ func (w *Wallet) Sign(serializedTx []byte) ([]byte, error) {
 
 // Signs an arbitrary message using the wallet's private key
 func (w *Wallet) SignMessage(message string) ([]byte, error) {
+	if w.safe != nil {
+		return nil, fmt.Errorf("The node account is a Safe, so it can't sign messages")
+	}
+
+	// Have the external signer sign it if there is one
+	if w.external != nil {
+		return w.external.signText([]byte(message))
+	}
+
 	// Get the wallet's private key
 	privateKey, _, err := w.getNodePrivateKey()
 	if err != nil {
@@ -317,6 +366,41 @@ func (w *Wallet) SignMessage(message string) ([]byte, error) {
 	return signedMessage, nil
 }
 
+// Signs EIP-712 typed data with the node account
+func (w *Wallet) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	if w.safe != nil {
+		return nil, fmt.Errorf("The node account is a Safe, so it can't sign typed data")
+	}
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("Error hashing typed data: %w", err)
+	}
+
+	// Key management services sign the hash directly; hardware wallets can't sign arbitrary typed data
+	var signedData []byte
+	if ks, isKms := w.external.(*kmsSigner); isKms {
+		signedData, err = ks.signHash(hash)
+		if err != nil {
+			return nil, err
+		}
+	} else if w.external != nil {
+		return nil, fmt.Errorf("The node account is on the %s, which can't sign typed data", w.external.getName())
+	} else {
+		privateKey, _, err := w.getNodePrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		signedData, err = crypto.Sign(hash, privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("Error signing typed data: %w", err)
+		}
+	}
+
+	// fix the ECDSA 'v', the same way as signed messages
+	signedData[crypto.RecoveryIDOffset] += 27
+	return signedData, nil
+}
+
 // Reloads wallet from disk
 func (w *Wallet) Reload() error {
 	_, err := w.loadStore()
@@ -366,11 +450,12 @@ func (w *Wallet) loadStore() (bool, error) {
 
 }
 
-// Initialize the encrypted wallet store from a mnemonic
-func (w *Wallet) initializeStore(derivationPath string, walletIndex uint, mnemonic string) error {
+// Initialize the encrypted wallet store from a mnemonic.
+// The passphrase only affects the seed, which is what gets encrypted, so it never needs to be stored.
+func (w *Wallet) initializeStore(derivationPath string, walletIndex uint, mnemonic string, passphrase string) error {
 
 	// Generate seed
-	w.seed = bip39.NewSeed(mnemonic, "")
+	w.seed = bip39.NewSeed(mnemonic, passphrase)
 
 	// Create master key
 	var err error
@@ -0,0 +1,28 @@
+package wallet
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Returned when a transaction needs to be signed on a machine that only has the node account's address
+var errWatchOnly = errors.New("This machine only has your node's watch-only address, so its transactions can't be signed here. Use --unsigned to save them for signing on the machine that has your node wallet.")
+
+// Use an address as the node account when this machine doesn't have the node wallet, so transactions can be built unsigned for another machine to sign
+func (w *Wallet) SetWatchOnlyAddress(address common.Address) {
+	w.watchOnlyAddress = &address
+}
+
+// Check if the node account is only a watch-only address, because the node wallet hasn't been initialized on this machine
+func (w *Wallet) IsWatchOnly() bool {
+	return w.watchOnlyAddress != nil && !w.IsInitialized()
+}
+
+// Get the watch-only node account
+func (w *Wallet) getWatchOnlyAccount() accounts.Account {
+	return accounts.Account{
+		Address: *w.watchOnlyAddress,
+	}
+}
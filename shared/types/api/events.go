@@ -0,0 +1,36 @@
+package api
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// The kinds of events the daemon pushes to its event stream
+type NodeEventType string
+
+const (
+	NodeEventType_MinipoolStatusChanged NodeEventType = "minipoolStatusChanged"
+	NodeEventType_ValidatorOffline      NodeEventType = "validatorOffline"
+	NodeEventType_ValidatorOnline       NodeEventType = "validatorOnline"
+	NodeEventType_RewardsIntervalEnded  NodeEventType = "rewardsIntervalEnded"
+	NodeEventType_RewardsClaimed        NodeEventType = "rewardsClaimed"
+	NodeEventType_TransactionConfirmed  NodeEventType = "transactionConfirmed"
+)
+
+// An event pushed to the daemon's event stream; only the fields that apply to its type are set
+type NodeEvent struct {
+	Type           NodeEventType          `json:"type"`
+	Time           time.Time              `json:"time"`
+	ElBlockNumber  uint64                 `json:"elBlockNumber"`
+	Minipool       *common.Address        `json:"minipool,omitempty"`
+	Pubkey         *types.ValidatorPubkey `json:"pubkey,omitempty"`
+	PreviousStatus string                 `json:"previousStatus,omitempty"`
+	NewStatus      string                 `json:"newStatus,omitempty"`
+	BalanceGwei    uint64                 `json:"balanceGwei,omitempty"`
+	RewardIndex    uint64                 `json:"rewardIndex,omitempty"`
+	TxHash         *common.Hash           `json:"txHash,omitempty"`
+	Nonce          *uint64                `json:"nonce,omitempty"`
+	TxStatus       string                 `json:"txStatus,omitempty"`
+}
@@ -101,6 +101,60 @@ type ExitMinipoolResponse struct {
 	Error  string `json:"error"`
 }
 
+// A voluntary exit the daemon will broadcast once its conditions are met, and then track until the validator is withdrawn
+type ScheduledExit struct {
+	Minipool          common.Address        `json:"minipool"`
+	Pubkey            types.ValidatorPubkey `json:"pubkey"`
+	Epoch             uint64                `json:"epoch"`
+	MaxExitQueue      uint64                `json:"maxExitQueue"`
+	Created           time.Time             `json:"created"`
+	Broadcast         bool                  `json:"broadcast"`
+	BroadcastEpoch    uint64                `json:"broadcastEpoch"`
+	ExitEpoch         uint64                `json:"exitEpoch"`
+	WithdrawableEpoch uint64                `json:"withdrawableEpoch"`
+	Withdrawn         bool                  `json:"withdrawn"`
+	Error             string                `json:"error,omitempty"`
+}
+
+// A voluntary exit signed ahead of time, with the message and signature in the Beacon API's format so any Beacon node can broadcast it
+type PresignedExit struct {
+	Minipool  common.Address        `json:"minipool"`
+	Pubkey    types.ValidatorPubkey `json:"pubkey"`
+	Message   PresignedExitMessage  `json:"message"`
+	Signature string                `json:"signature"`
+}
+type PresignedExitMessage struct {
+	Epoch          string `json:"epoch"`
+	ValidatorIndex string `json:"validator_index"`
+}
+type PresignExitsResponse struct {
+	Status    string           `json:"status"`
+	Error     string           `json:"error"`
+	Epoch     uint64           `json:"epoch"`
+	Count     int              `json:"count"`
+	Skipped   []common.Address `json:"skipped"`
+	VaultFile string           `json:"vaultFile"`
+}
+type BroadcastPresignedExitResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+type ScheduleMinipoolExitResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+type GetScheduledExitsResponse struct {
+	Status       string          `json:"status"`
+	Error        string          `json:"error"`
+	CurrentEpoch uint64          `json:"currentEpoch"`
+	ExitQueue    uint64          `json:"exitQueue"`
+	Exits        []ScheduledExit `json:"exits"`
+}
+type CancelScheduledExitResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
 type CanChangeWithdrawalCredentialsResponse struct {
 	Status    string `json:"status"`
 	Error     string `json:"error"`
@@ -252,6 +306,25 @@ type StakeMinipoolResponse struct {
 	TxHash common.Hash `json:"txHash"`
 }
 
+type MinipoolMigrationStatusResponse struct {
+	Status                        string                `json:"status"`
+	Error                         string                `json:"error"`
+	IsVacant                      bool                  `json:"isVacant"`
+	MinipoolStatus                types.MinipoolStatus  `json:"minipoolStatus"`
+	Pubkey                        types.ValidatorPubkey `json:"pubkey"`
+	CreationTime                  time.Time             `json:"creationTime"`
+	PromotionTime                 time.Time             `json:"promotionTime"`
+	CredentialsDeadline           time.Time             `json:"credentialsDeadline"`
+	LatestBlockTime               time.Time             `json:"latestBlockTime"`
+	ExpectedWithdrawalCredentials common.Hash           `json:"expectedWithdrawalCredentials"`
+	WithdrawalCredentials         common.Hash           `json:"withdrawalCredentials"`
+	CredentialsChanged            bool                  `json:"credentialsChanged"`
+	ValidatorExists               bool                  `json:"validatorExists"`
+	ValidatorStatus               beacon.ValidatorState `json:"validatorStatus"`
+	ValidatorIndex                string                `json:"validatorIndex"`
+	Balance                       uint64                `json:"balance"`
+	CanPromote                    bool                  `json:"canPromote"`
+}
 type CanPromoteMinipoolResponse struct {
 	Status     string             `json:"status"`
 	Error      string             `json:"error"`
@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
 )
 
 type NodeFeeResponse struct {
@@ -70,6 +71,21 @@ type NetworkGenerateRewardsTreeResponse struct {
 	Error  string `json:"error"`
 }
 
+type NetworkVerifyRewardsTreeResponse struct {
+	Status              string                    `json:"status"`
+	Error               string                    `json:"error"`
+	CanonicalMerkleRoot common.Hash               `json:"canonicalMerkleRoot"`
+	LocalMerkleRoot     common.Hash               `json:"localMerkleRoot"`
+	RootsMatch          bool                      `json:"rootsMatch"`
+	NodeDiffs           []rewards.NodeRewardsDiff `json:"nodeDiffs"`
+}
+
+type NetworkSmoothingPoolAuditResponse struct {
+	Status string                      `json:"status"`
+	Error  string                      `json:"error"`
+	Audit  *rewards.SmoothingPoolAudit `json:"audit"`
+}
+
 type NetworkDAOProposalsResponse struct {
 	Status                  string                 `json:"status"`
 	Error                   string                 `json:"error"`
@@ -95,3 +111,11 @@ type GetLatestDelegateResponse struct {
 	Error   string         `json:"error"`
 	Address common.Address `json:"address"`
 }
+
+type NetworkFeeHistoryResponse struct {
+	Status        string     `json:"status"`
+	Error         string     `json:"error"`
+	OldestBlock   *big.Int   `json:"oldestBlock"`
+	BaseFees      []*big.Int `json:"baseFees"`
+	GasUsedRatios []float64  `json:"gasUsedRatios"`
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -242,15 +244,39 @@ type NodeDepositResponse struct {
 	ScrubPeriod     time.Duration           `json:"scrubPeriod"`
 }
 
+type GetExpectedMinipoolAddressResponse struct {
+	Status                string         `json:"status"`
+	Error                 string         `json:"error"`
+	MinipoolAddress       common.Address `json:"minipoolAddress"`
+	WithdrawalCredentials common.Hash    `json:"withdrawalCredentials"`
+}
+
 type CanCreateVacantMinipoolResponse struct {
-	Status               string             `json:"status"`
-	Error                string             `json:"error"`
-	CanDeposit           bool               `json:"canDeposit"`
-	InsufficientRplStake bool               `json:"insufficientRplStake"`
-	InvalidAmount        bool               `json:"invalidAmount"`
-	DepositDisabled      bool               `json:"depositDisabled"`
-	MinipoolAddress      common.Address     `json:"minipoolAddress"`
-	GasInfo              rocketpool.GasInfo `json:"gasInfo"`
+	Status               string               `json:"status"`
+	Error                string               `json:"error"`
+	CanDeposit           bool                 `json:"canDeposit"`
+	InsufficientRplStake bool                 `json:"insufficientRplStake"`
+	InvalidAmount        bool                 `json:"invalidAmount"`
+	DepositDisabled      bool                 `json:"depositDisabled"`
+	Validator            SoloValidatorDetails `json:"validator"`
+	MinipoolAddress      common.Address       `json:"minipoolAddress"`
+	GasInfo              rocketpool.GasInfo   `json:"gasInfo"`
+}
+type CanMigrateSoloValidatorResponse struct {
+	Status     string               `json:"status"`
+	Error      string               `json:"error"`
+	CanMigrate bool                 `json:"canMigrate"`
+	Validator  SoloValidatorDetails `json:"validator"`
+}
+type SoloValidatorDetails struct {
+	ValidatorNotFound            bool                  `json:"validatorNotFound"`
+	ValidatorNotActive           bool                  `json:"validatorNotActive"`
+	InvalidWithdrawalCredentials bool                  `json:"invalidWithdrawalCredentials"`
+	InsufficientBalance          bool                  `json:"insufficientBalance"`
+	ValidatorStatus              beacon.ValidatorState `json:"validatorStatus"`
+	ValidatorIndex               string                `json:"validatorIndex"`
+	Balance                      uint64                `json:"balance"`
+	WithdrawalCredentials        common.Hash           `json:"withdrawalCredentials"`
 }
 type CreateVacantMinipoolResponse struct {
 	Status                string         `json:"status"`
@@ -309,6 +335,21 @@ type NodeSyncProgressResponse struct {
 	BcStatus ClientManagerStatus `json:"bcStatus"`
 }
 
+type NodeDutiesResponse struct {
+	Status               string         `json:"status"`
+	Error                string         `json:"error"`
+	Epoch                uint64         `json:"epoch"`
+	NextSyncPeriodEpoch  uint64         `json:"nextSyncPeriodEpoch"`
+	Proposals            []MinipoolDuty `json:"proposals"`
+	CurrentSyncCommittee []MinipoolDuty `json:"currentSyncCommittee"`
+	NextSyncCommittee    []MinipoolDuty `json:"nextSyncCommittee"`
+}
+type MinipoolDuty struct {
+	Minipool       common.Address `json:"minipool"`
+	ValidatorIndex string         `json:"validatorIndex"`
+	Count          uint64         `json:"count"`
+}
+
 type CanNodeClaimRplResponse struct {
 	Status    string             `json:"status"`
 	Error     string             `json:"error"`
@@ -344,6 +385,154 @@ type NodeRewardsResponse struct {
 	TxHash                      common.Hash   `json:"txHash"`
 }
 
+type NodeProjectedRewardsResponse struct {
+	Status                    string    `json:"status"`
+	Error                     string    `json:"error"`
+	Index                     uint64    `json:"index"`
+	IntervalStart             time.Time `json:"intervalStart"`
+	IntervalEnd               time.Time `json:"intervalEnd"`
+	SnapshotTime              time.Time `json:"snapshotTime"`
+	ElapsedFraction           float64   `json:"elapsedFraction"`
+	PendingRpl                *big.Int  `json:"pendingRpl"`
+	ProjectedTotalRpl         *big.Int  `json:"projectedTotalRpl"`
+	NodeEffectiveStake        *big.Int  `json:"nodeEffectiveStake"`
+	TotalEffectiveStake       *big.Int  `json:"totalEffectiveStake"`
+	ProjectedCollateralRpl    *big.Int  `json:"projectedCollateralRpl"`
+	ProjectedOracleDaoRpl     *big.Int  `json:"projectedOracleDaoRpl"`
+	IsOptedIntoSmoothingPool  bool      `json:"isOptedIntoSmoothingPool"`
+	SmoothingPoolBalance      *big.Int  `json:"smoothingPoolBalance"`
+	ProjectedSmoothingBalance *big.Int  `json:"projectedSmoothingBalance"`
+	EligibleMinipoolCount     uint64    `json:"eligibleMinipoolCount"`
+	ProjectedSmoothingPoolEth *big.Int  `json:"projectedSmoothingPoolEth"`
+}
+
+type NodeRewardsHistoryResponse struct {
+	Status     string                       `json:"status"`
+	Error      string                       `json:"error"`
+	Registered bool                         `json:"registered"`
+	Intervals  []NodeRewardsHistoryInterval `json:"intervals"`
+}
+type NodeRewardsHistoryInterval struct {
+	Index            uint64      `json:"index"`
+	StartTime        time.Time   `json:"startTime"`
+	EndTime          time.Time   `json:"endTime"`
+	RewardsAvailable bool        `json:"rewardsAvailable"`
+	CollateralRpl    *big.Int    `json:"collateralRpl"`
+	OracleDaoRpl     *big.Int    `json:"oracleDaoRpl"`
+	SmoothingPoolEth *big.Int    `json:"smoothingPoolEth"`
+	Claimed          bool        `json:"claimed"`
+	ClaimTxHash      common.Hash `json:"claimTxHash"`
+	ClaimBlock       uint64      `json:"claimBlock"`
+	ClaimTime        time.Time   `json:"claimTime"`
+	RplPriceAtClaim  *big.Int    `json:"rplPriceAtClaim"`
+}
+
+type NodeTransactionHistoryResponse struct {
+	Status       string                  `json:"status"`
+	Error        string                  `json:"error"`
+	Transactions []NodeTransactionRecord `json:"transactions"`
+}
+type NodeTransactionRecord struct {
+	Nonce         uint64       `json:"nonce"`
+	TxHash        *common.Hash `json:"txHash,omitempty"`
+	TxStatus      string       `json:"txStatus,omitempty"`
+	ElBlockNumber uint64       `json:"elBlockNumber"`
+	Time          time.Time    `json:"time"`
+}
+
+type NodeAlertHistoryResponse struct {
+	Status string            `json:"status"`
+	Error  string            `json:"error"`
+	Alerts []NodeAlertRecord `json:"alerts"`
+}
+type NodeAlertRecord struct {
+	Rule      string    `json:"rule"`
+	Key       string    `json:"key,omitempty"`
+	Severity  string    `json:"severity"`
+	Summary   string    `json:"summary"`
+	Resolved  bool      `json:"resolved"`
+	StartTime time.Time `json:"startTime"`
+	Time      time.Time `json:"time"`
+}
+
+type NodeDutyHistoryResponse struct {
+	Status string                `json:"status"`
+	Error  string                `json:"error"`
+	Epochs []NodeDutyEpochRecord `json:"epochs"`
+}
+type NodeDutyEpochRecord struct {
+	Epoch              uint64 `json:"epoch"`
+	Validators         uint64 `json:"validators"`
+	MissedAttestations uint64 `json:"missedAttestations"`
+	Proposals          uint64 `json:"proposals"`
+	MissedProposals    uint64 `json:"missedProposals"`
+}
+
+type NodeExportResponse struct {
+	Status           string                  `json:"status"`
+	Error            string                  `json:"error"`
+	Registered       bool                    `json:"registered"`
+	ElBlockNumber    uint64                  `json:"elBlockNumber"`
+	BeaconSlotNumber uint64                  `json:"beaconSlotNumber"`
+	Node             NodeExportDetails       `json:"node"`
+	Minipools        []MinipoolExportDetails `json:"minipools"`
+}
+type NodeExportDetails struct {
+	Address                 common.Address `json:"address"`
+	WithdrawalAddress       common.Address `json:"withdrawalAddress"`
+	TimezoneLocation        string         `json:"timezoneLocation"`
+	RegistrationTime        time.Time      `json:"registrationTime"`
+	FeeDistributorAddress   common.Address `json:"feeDistributorAddress"`
+	FeeDistributorBalance   *big.Int       `json:"feeDistributorBalance"`
+	SmoothingPoolRegistered bool           `json:"smoothingPoolRegistered"`
+	EthBalance              *big.Int       `json:"ethBalance"`
+	RethBalance             *big.Int       `json:"rethBalance"`
+	RplBalance              *big.Int       `json:"rplBalance"`
+	RplStake                *big.Int       `json:"rplStake"`
+	EffectiveRplStake       *big.Int       `json:"effectiveRplStake"`
+	MinimumRplStake         *big.Int       `json:"minimumRplStake"`
+	MaximumRplStake         *big.Int       `json:"maximumRplStake"`
+	EthMatched              *big.Int       `json:"ethMatched"`
+	EthMatchedLimit         *big.Int       `json:"ethMatchedLimit"`
+	DepositCreditBalance    *big.Int       `json:"depositCreditBalance"`
+	MinipoolCount           uint64         `json:"minipoolCount"`
+	AverageNodeFee          *big.Int       `json:"averageNodeFee"`
+	CollateralisationRatio  *big.Int       `json:"collateralisationRatio"`
+}
+type MinipoolExportDetails struct {
+	Address                  common.Address          `json:"address"`
+	Pubkey                   rptypes.ValidatorPubkey `json:"pubkey"`
+	ValidatorIndex           string                  `json:"validatorIndex"`
+	Status                   rptypes.MinipoolStatus  `json:"status"`
+	StatusTime               time.Time               `json:"statusTime"`
+	DepositType              rptypes.MinipoolDeposit `json:"depositType"`
+	Version                  uint8                   `json:"version"`
+	Finalised                bool                    `json:"finalised"`
+	IsVacant                 bool                    `json:"isVacant"`
+	NodeFee                  *big.Int                `json:"nodeFee"`
+	NodeDepositBalance       *big.Int                `json:"nodeDepositBalance"`
+	UserDepositBalance       *big.Int                `json:"userDepositBalance"`
+	PenaltyCount             uint64                  `json:"penaltyCount"`
+	PenaltyRate              *big.Int                `json:"penaltyRate"`
+	Balance                  *big.Int                `json:"balance"`
+	NodeShareOfBalance       *big.Int                `json:"nodeShareOfBalance"`
+	NodeRefundBalance        *big.Int                `json:"nodeRefundBalance"`
+	ValidatorStatus          string                  `json:"validatorStatus"`
+	BeaconBalance            *big.Int                `json:"beaconBalance"`
+	EffectiveBalance         *big.Int                `json:"effectiveBalance"`
+	NodeShareOfBeaconBalance *big.Int                `json:"nodeShareOfBeaconBalance"`
+	Slashed                  bool                    `json:"slashed"`
+	Delegate                 common.Address          `json:"delegate"`
+	UseLatestDelegate        bool                    `json:"useLatestDelegate"`
+}
+
+type NodeStateSnapshotResponse struct {
+	Status     string               `json:"status"`
+	Error      string               `json:"error"`
+	Registered bool                 `json:"registered"`
+	Snapshot   *state.StateSnapshot `json:"snapshot"`
+}
+
 type DepositContractInfoResponse struct {
 	Status                string         `json:"status"`
 	Error                 string         `json:"error"`
@@ -480,6 +669,7 @@ type ResolveEnsNameResponse struct {
 type SnapshotProposal struct {
 	Id            string    `json:"id"`
 	Title         string    `json:"title"`
+	Type          string    `json:"type"`
 	Start         int64     `json:"start"`
 	End           int64     `json:"end"`
 	State         string    `json:"state"`
@@ -541,3 +731,49 @@ type NodeEthBalanceResponse struct {
 	Error   string   `json:"error"`
 	Balance *big.Int `json:"balance"`
 }
+
+type NodeGetNonceResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Nonce  uint64 `json:"nonce"`
+}
+
+type NodePendingTransactionsResponse struct {
+	Status       string               `json:"status"`
+	Error        string               `json:"error"`
+	IsSafe       bool                 `json:"isSafe"`
+	LatestNonce  uint64               `json:"latestNonce"`
+	PendingNonce uint64               `json:"pendingNonce"`
+	BaseFee      *big.Int             `json:"baseFee"`
+	TxPoolError  string               `json:"txPoolError"`
+	Transactions []PendingTransaction `json:"transactions"`
+}
+type PendingTransaction struct {
+	Hash                 common.Hash     `json:"hash"`
+	Nonce                uint64          `json:"nonce"`
+	To                   *common.Address `json:"to"`
+	Value                *big.Int        `json:"value"`
+	GasLimit             uint64          `json:"gasLimit"`
+	MaxFeePerGas         *big.Int        `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *big.Int        `json:"maxPriorityFeePerGas"`
+	Queued               bool            `json:"queued"`
+}
+
+type CanReplaceTransactionResponse struct {
+	Status                  string             `json:"status"`
+	Error                   string             `json:"error"`
+	CanReplace              bool               `json:"canReplace"`
+	IsSafe                  bool               `json:"isSafe"`
+	NotFound                bool               `json:"notFound"`
+	Transaction             PendingTransaction `json:"transaction"`
+	MinMaxFeePerGas         *big.Int           `json:"minMaxFeePerGas"`
+	MinMaxPriorityFeePerGas *big.Int           `json:"minMaxPriorityFeePerGas"`
+	GasInfo                 rocketpool.GasInfo `json:"gasInfo"`
+}
+type ReplaceTransactionResponse struct {
+	Status               string      `json:"status"`
+	Error                string      `json:"error"`
+	TxHash               common.Hash `json:"txHash"`
+	MaxFeePerGas         *big.Int    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *big.Int    `json:"maxPriorityFeePerGas"`
+}
@@ -21,6 +21,17 @@ type ClientStatus struct {
 	SyncProgress float64 `json:"syncProgress"`
 	NetworkId    uint    `json:"networkId"`
 	Error        string  `json:"error"`
+
+	Health ClientHealthStatus `json:"health"`
+}
+
+// This is a summary of a client's recent call history and circuit breaker
+type ClientHealthStatus struct {
+	Requests            int     `json:"requests"`
+	FailureRate         float64 `json:"failureRate"`
+	AverageLatencyMs    float64 `json:"averageLatencyMs"`
+	ConsecutiveFailures int     `json:"consecutiveFailures"`
+	CircuitState        string  `json:"circuitState"`
 }
 
 // This is a wrapper for the manager's overall status report
@@ -28,6 +39,8 @@ type ClientManagerStatus struct {
 	PrimaryClientStatus  ClientStatus `json:"primaryEcStatus"`
 	FallbackEnabled      bool         `json:"fallbackEnabled"`
 	FallbackClientStatus ClientStatus `json:"fallbackEcStatus"`
+
+	AdditionalFallbackStatuses []ClientStatus `json:"additionalFallbackStatuses,omitempty"`
 }
 
 type ClientStatusResponse struct {
@@ -37,7 +50,22 @@ type ClientStatusResponse struct {
 	BcManagerStatus ClientManagerStatus `json:"bcManagerStatus"`
 }
 
+type ConfigProblem struct {
+	IsError bool   `json:"isError"`
+	Message string `json:"message"`
+}
+type CheckConfigResponse struct {
+	Status   string          `json:"status"`
+	Error    string          `json:"error"`
+	Problems []ConfigProblem `json:"problems"`
+}
+
 type RestartVcResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+type RotateJwtSecretResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
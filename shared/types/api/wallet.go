@@ -1,10 +1,18 @@
 package api
 
 import (
+	"fmt"
+	"math/big"
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/google/uuid"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
 )
 
 // Encrypted validator keystore following the EIP-2335 standard
@@ -22,6 +30,7 @@ type WalletStatusResponse struct {
 	Error             string         `json:"error"`
 	PasswordSet       bool           `json:"passwordSet"`
 	WalletInitialized bool           `json:"walletInitialized"`
+	IsWatchOnly       bool           `json:"isWatchOnly"`
 	AccountAddress    common.Address `json:"accountAddress"`
 }
 
@@ -30,6 +39,20 @@ type SetPasswordResponse struct {
 	Error  string `json:"error"`
 }
 
+type ListWalletsResponse struct {
+	Status       string   `json:"status"`
+	Error        string   `json:"error"`
+	ActiveWallet string   `json:"activeWallet"`
+	Wallets      []string `json:"wallets"`
+}
+
+type SwitchWalletResponse struct {
+	Status         string `json:"status"`
+	Error          string `json:"error"`
+	PreviousWallet string `json:"previousWallet"`
+	NewWallet      bool   `json:"newWallet"`
+}
+
 type InitWalletResponse struct {
 	Status         string         `json:"status"`
 	Error          string         `json:"error"`
@@ -60,6 +83,48 @@ type RebuildWalletResponse struct {
 	ValidatorKeys []types.ValidatorPubkey `json:"validatorKeys"`
 }
 
+type AuditValidatorKeysResponse struct {
+	Status                 string                   `json:"status"`
+	Error                  string                   `json:"error"`
+	Keystore               string                   `json:"keystore"`
+	Keys                   []ValidatorKeyAuditEntry `json:"keys"`
+	MissingKeysRecoverable bool                     `json:"missingKeysRecoverable"`
+	RecoveryError          string                   `json:"recoveryError"`
+}
+type ValidatorKeyAuditEntry struct {
+	Pubkey         types.ValidatorPubkey `json:"pubkey"`
+	IsMinipool     bool                  `json:"isMinipool"`
+	IsDistributed  bool                  `json:"isDistributed"`
+	IsLoaded       bool                  `json:"isLoaded"`
+	LoadError      string                `json:"loadError"`
+	ExistsOnBeacon bool                  `json:"existsOnBeacon"`
+	BeaconIndex    string                `json:"beaconIndex"`
+	BeaconStatus   beacon.ValidatorState `json:"beaconStatus"`
+	HasDuties      bool                  `json:"hasDuties"`
+}
+
+type ExportKeysResponse struct {
+	Status          string                  `json:"status"`
+	Error           string                  `json:"error"`
+	ExportFolder    string                  `json:"exportFolder"`
+	ExportedKeys    []types.ValidatorPubkey `json:"exportedKeys"`
+	ProtectionEpoch uint64                  `json:"protectionEpoch"`
+}
+
+type ExportSlashingProtectionResponse struct {
+	Status          string `json:"status"`
+	Error           string `json:"error"`
+	ExportFile      string `json:"exportFile"`
+	ValidatorCount  int    `json:"validatorCount"`
+	ProtectionEpoch uint64 `json:"protectionEpoch"`
+}
+
+type ImportKeysResponse struct {
+	Status       string                  `json:"status"`
+	Error        string                  `json:"error"`
+	ImportedKeys []types.ValidatorPubkey `json:"importedKeys"`
+}
+
 type ExportWalletResponse struct {
 	Status            string `json:"status"`
 	Error             string `json:"error"`
@@ -88,3 +153,104 @@ type PurgeResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+type SignTransactionResponse struct {
+	Status   string         `json:"status"`
+	Error    string         `json:"error"`
+	From     common.Address `json:"from"`
+	SignedTx string         `json:"signedTx"`
+	TxHash   common.Hash    `json:"txHash"`
+}
+
+type SendRawTransactionResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+// A node transaction that was created without being signed, so it can be signed on an offline machine
+type UnsignedTransaction struct {
+	ChainID              *big.Int        `json:"chainId"`
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to"`
+	Value                *big.Int        `json:"value"`
+	Nonce                uint64          `json:"nonce"`
+	GasLimit             uint64          `json:"gasLimit"`
+	MaxFeePerGas         *big.Int        `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *big.Int        `json:"maxPriorityFeePerGas"`
+	Data                 hexutil.Bytes   `json:"data"`
+	SerializedTx         string          `json:"serializedTx"`
+}
+
+// Get the name of the file an unsigned transaction is saved to, by the hash of the unsigned transaction
+func GetUnsignedTransactionFilename(hash common.Hash) string {
+	return fmt.Sprintf("%s.json", hash.Hex())
+}
+
+// Describe an unsigned transaction from the given account
+func NewUnsignedTransaction(tx *ethtypes.Transaction, from common.Address) (UnsignedTransaction, error) {
+	serializedTx, err := tx.MarshalBinary()
+	if err != nil {
+		return UnsignedTransaction{}, fmt.Errorf("error serializing transaction: %w", err)
+	}
+	return UnsignedTransaction{
+		ChainID:              tx.ChainId(),
+		From:                 from,
+		To:                   tx.To(),
+		Value:                tx.Value(),
+		Nonce:                tx.Nonce(),
+		GasLimit:             tx.Gas(),
+		MaxFeePerGas:         tx.GasFeeCap(),
+		MaxPriorityFeePerGas: tx.GasTipCap(),
+		Data:                 tx.Data(),
+		SerializedTx:         hexutil.Encode(serializedTx),
+	}, nil
+}
+
+// Get the name of the file a dry run of a transaction is saved to, by the hash of the simulated transaction
+func GetDryRunTransactionFilename(hash common.Hash) string {
+	return fmt.Sprintf("%s.json", hash.Hex())
+}
+
+// A node transaction that was simulated against the latest block instead of being submitted
+type DryRunTransaction struct {
+	Transaction    UnsignedTransaction `json:"transaction"`
+	Reverted       bool                `json:"reverted"`
+	RevertReason   string              `json:"revertReason"`
+	TraceAvailable bool                `json:"traceAvailable"`
+	TraceError     string              `json:"traceError"`
+	GasUsed        uint64              `json:"gasUsed"`
+	Transfers      []DryRunTransfer    `json:"transfers"`
+	Events         []DryRunEvent       `json:"events"`
+}
+
+// ETH moved by a simulated transaction or one of its internal calls
+type DryRunTransfer struct {
+	From  common.Address `json:"from"`
+	To    common.Address `json:"to"`
+	Value *big.Int       `json:"value"`
+}
+
+// An event emitted by a simulated transaction
+type DryRunEvent struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+// Get the name of the file a deferred transaction is saved to, by the hash of the signed transaction
+func GetDeferredTransactionFilename(hash common.Hash) string {
+	return fmt.Sprintf("%s.json", hash.Hex())
+}
+
+// A signed node transaction that the node daemon will submit once the network's fees are low enough for its max fee
+type DeferredTransaction struct {
+	Hash           common.Hash    `json:"hash"`
+	From           common.Address `json:"from"`
+	Nonce          uint64         `json:"nonce"`
+	MaxFeePerGas   *big.Int       `json:"maxFeePerGas"`
+	MaxPriorityFee *big.Int       `json:"maxPriorityFee"`
+	SignedTx       hexutil.Bytes  `json:"signedTx"`
+	Created        time.Time      `json:"created"`
+	Deadline       time.Time      `json:"deadline"`
+}
@@ -0,0 +1,24 @@
+package config
+
+import "time"
+
+type CallClass string
+
+// Enum to describe how expensive a client call is, so it can be given a suitable timeout and number of retries
+const (
+	// Quick queries like the chain head, sync status, and nonces
+	CallClass_Fast CallClass = "fast"
+
+	// Large state queries like validator lists, committees, logs and contract calls during tree generation
+	CallClass_Heavy CallClass = "heavy"
+
+	// Submitting transactions and signed messages
+	CallClass_Submit CallClass = "submit"
+)
+
+// The timeout and number of retries for a class of client calls.
+// A timeout of 0 means the call can take as long as it needs.
+type CallPolicy struct {
+	Timeout time.Duration
+	Retries int
+}
@@ -7,9 +7,18 @@ type ParameterType string
 type ExecutionClient string
 type ConsensusClient string
 type RewardsMode string
+type IpfsPinningMode string
 type MevRelayID string
 type MevSelectionMode string
 type NimbusPruningMode string
+type HardwareWallet string
+type KmsProvider string
+type DistributedValidatorMode string
+type GasOracle string
+type RestakeMode string
+type VotePolicy string
+type MevRelayManagement string
+type ContainerRuntime string
 
 // Enum to describe which container(s) a parameter impacts, so the Smartnode knows which
 // ones to restart upon a settings change
@@ -25,6 +34,8 @@ const (
 	ContainerID_Prometheus ContainerID = "prometheus"
 	ContainerID_Exporter   ContainerID = "exporter"
 	ContainerID_MevBoost   ContainerID = "mev-boost"
+	ContainerID_Charon     ContainerID = "charon"
+	ContainerID_Ssv        ContainerID = "ssv"
 )
 
 // Enum to describe which network the system is on
@@ -63,6 +74,7 @@ const (
 	ExecutionClient_Geth       ExecutionClient = "geth"
 	ExecutionClient_Nethermind ExecutionClient = "nethermind"
 	ExecutionClient_Besu       ExecutionClient = "besu"
+	ExecutionClient_Reth       ExecutionClient = "reth"
 	ExecutionClient_Obs_Infura ExecutionClient = "infura"
 	ExecutionClient_Obs_Pocket ExecutionClient = "pocket"
 )
@@ -70,6 +82,7 @@ const (
 // Enum to describe the Consensus client options
 const (
 	ConsensusClient_Unknown    ConsensusClient = ""
+	ConsensusClient_Grandine   ConsensusClient = "grandine"
 	ConsensusClient_Lighthouse ConsensusClient = "lighthouse"
 	ConsensusClient_Lodestar   ConsensusClient = "lodestar"
 	ConsensusClient_Nimbus     ConsensusClient = "nimbus"
@@ -84,6 +97,81 @@ const (
 	RewardsMode_Generate RewardsMode = "generate"
 )
 
+// Enum to describe where generated rewards files get pinned on IPFS
+const (
+	IpfsPinningMode_Unknown     IpfsPinningMode = ""
+	IpfsPinningMode_Disabled    IpfsPinningMode = "disabled"
+	IpfsPinningMode_Kubo        IpfsPinningMode = "kubo"
+	IpfsPinningMode_Pinata      IpfsPinningMode = "pinata"
+	IpfsPinningMode_Web3Storage IpfsPinningMode = "web3storage"
+)
+
+// Enum to describe which hardware wallet, if any, holds the node account
+const (
+	HardwareWallet_Unknown HardwareWallet = ""
+	HardwareWallet_None    HardwareWallet = "none"
+	HardwareWallet_Ledger  HardwareWallet = "ledger"
+	HardwareWallet_Trezor  HardwareWallet = "trezor"
+)
+
+// Enum to describe which key management service, if any, holds the node account
+const (
+	KmsProvider_Unknown KmsProvider = ""
+	KmsProvider_None    KmsProvider = "none"
+	KmsProvider_Pkcs11  KmsProvider = "pkcs11"
+	KmsProvider_Aws     KmsProvider = "aws"
+	KmsProvider_Gcp     KmsProvider = "gcp"
+)
+
+// Enum to describe which source the Smartnode gets its gas price suggestions from first
+const (
+	GasOracle_Unknown     GasOracle = ""
+	GasOracle_Auto        GasOracle = "auto"
+	GasOracle_Etherchain  GasOracle = "etherchain"
+	GasOracle_Etherscan   GasOracle = "etherscan"
+	GasOracle_FeeHistory  GasOracle = "feeHistory"
+	GasOracle_Blocknative GasOracle = "blocknative"
+)
+
+// Enum to describe how much of the claimed RPL the daemon restakes when it claims rewards automatically
+const (
+	RestakeMode_Unknown    RestakeMode = ""
+	RestakeMode_None       RestakeMode = "none"
+	RestakeMode_Percent    RestakeMode = "percent"
+	RestakeMode_Collateral RestakeMode = "collateral"
+)
+
+// Enum to describe how the daemon votes on Protocol DAO proposals automatically
+const (
+	VotePolicy_Unknown VotePolicy = ""
+	VotePolicy_None    VotePolicy = "none"
+	VotePolicy_Follow  VotePolicy = "follow"
+	VotePolicy_Abstain VotePolicy = "abstain"
+	VotePolicy_Rules   VotePolicy = "rules"
+)
+
+// Enum to describe how the daemon manages the MEV-Boost relays based on their performance
+const (
+	MevRelayManagement_Unknown   MevRelayManagement = ""
+	MevRelayManagement_Off       MevRelayManagement = "off"
+	MevRelayManagement_Monitor   MevRelayManagement = "monitor"
+	MevRelayManagement_Automatic MevRelayManagement = "automatic"
+)
+
+// Enum to describe which distributed validator middleware runs the node's distributed validators
+const (
+	DistributedValidatorMode_Unknown DistributedValidatorMode = ""
+	DistributedValidatorMode_Obol    DistributedValidatorMode = "obol"
+	DistributedValidatorMode_Ssv     DistributedValidatorMode = "ssv"
+)
+
+// Enum to describe which container runtime runs the Smartnode's containers in Docker Mode
+const (
+	ContainerRuntime_Unknown ContainerRuntime = ""
+	ContainerRuntime_Docker  ContainerRuntime = "docker"
+	ContainerRuntime_Podman  ContainerRuntime = "podman"
+)
+
 // Enum to identify MEV-boost relays
 const (
 	MevRelayID_Unknown            MevRelayID = ""
@@ -0,0 +1,401 @@
+package eth2
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Beacon state limits (mainnet preset), which all of the supported networks use
+const (
+	slotsPerHistoricalRoot        uint64 = 8192
+	historicalRootsLimit          uint64 = 1 << 24
+	eth1DataVotesLimit            uint64 = 2048
+	validatorRegistryLimit        uint64 = 1 << 40
+	epochsPerHistoricalVector     int    = 65536
+	epochsPerSlashingsVector      int    = 8192
+	maxExtraDataBytes             uint64 = 32
+	bytesPerLogsBloom             int    = 256
+	pendingDepositsLimit          uint64 = 1 << 27
+	pendingPartialWithdrawalLimit uint64 = 1 << 27
+	pendingConsolidationsLimit    uint64 = 1 << 18
+	proposerLookaheadSize         int    = 64
+
+	// The position of state_roots in the Beacon state's fields
+	stateRootsFieldIndex int = 6
+)
+
+// A field of an SSZ container: its size if it's fixed, or 0 if it's variable-size, and how to get its hash tree root
+type sszField struct {
+	size int
+	root func(data []byte) ([32]byte, error)
+}
+
+// Roots of all-zero subtrees, by depth
+var zeroHashes [65][32]byte
+
+func init() {
+	for i := 1; i < len(zeroHashes); i++ {
+		zeroHashes[i] = hashPair(zeroHashes[i-1], zeroHashes[i-1])
+	}
+}
+
+// Get the hash tree root of an SSZ-encoded Beacon state for the given fork
+func BeaconStateHashTreeRoot(fork string, state []byte) ([32]byte, error) {
+	fields, err := getBeaconStateFields(fork)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return hashSszContainer(fields, state)
+}
+
+// Get the root of the state at an earlier slot from the state_roots of an SSZ-encoded Beacon state.
+// The slot must be one of the last 8192 before the state's own slot.
+func BeaconStateHistoricalStateRoot(fork string, state []byte, slot uint64) ([32]byte, error) {
+	fields, err := getBeaconStateFields(fork)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	parts, err := splitSszContainer(fields, state)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	stateSlot := binary.LittleEndian.Uint64(parts[2])
+	if slot >= stateSlot || stateSlot-slot > slotsPerHistoricalRoot {
+		return [32]byte{}, fmt.Errorf("slot %d isn't in the state roots of the state at slot %d", slot, stateSlot)
+	}
+	var root [32]byte
+	position := (slot % slotsPerHistoricalRoot) * 32
+	copy(root[:], parts[stateRootsFieldIndex][position:position+32])
+	return root, nil
+}
+
+// Get the fields of the Beacon state for a fork
+func getBeaconStateFields(fork string) ([]sszField, error) {
+	root := bytesField(32)
+	checkpoint := containerField(uint64Field(), root)
+	eth1Data := containerField(root, uint64Field(), root)
+	pubkey := byteVectorField(48)
+	validator := containerField(pubkey, root, uint64Field(), bytesField(1), uint64Field(), uint64Field(), uint64Field(), uint64Field())
+	syncCommittee := containerField(compositeVectorField(pubkey, SyncCommitteeSize), pubkey)
+
+	// Altair
+	fields := []sszField{
+		uint64Field(), // genesis_time
+		root,          // genesis_validators_root
+		uint64Field(), // slot
+		containerField(bytesField(4), bytesField(4), uint64Field()),    // fork
+		containerField(uint64Field(), uint64Field(), root, root, root), // latest_block_header
+		packedVectorField(int(slotsPerHistoricalRoot) * 32),            // block_roots
+		packedVectorField(int(slotsPerHistoricalRoot) * 32),            // state_roots
+		packedListField(32, historicalRootsLimit),                      // historical_roots
+		eth1Data, // eth1_data
+		compositeListField(eth1Data, eth1DataVotesLimit), // eth1_data_votes
+		uint64Field(), // eth1_deposit_index
+		compositeListField(validator, validatorRegistryLimit), // validators
+		packedListField(8, validatorRegistryLimit),            // balances
+		packedVectorField(epochsPerHistoricalVector * 32),     // randao_mixes
+		packedVectorField(epochsPerSlashingsVector * 8),       // slashings
+		packedListField(1, validatorRegistryLimit),            // previous_epoch_participation
+		packedListField(1, validatorRegistryLimit),            // current_epoch_participation
+		bytesField(1), // justification_bits
+		checkpoint,    // previous_justified_checkpoint
+		checkpoint,    // current_justified_checkpoint
+		checkpoint,    // finalized_checkpoint
+		packedListField(8, validatorRegistryLimit), // inactivity_scores
+		syncCommittee, // current_sync_committee
+		syncCommittee, // next_sync_committee
+	}
+	if fork == "altair" {
+		return fields, nil
+	}
+
+	// Bellatrix
+	payloadHeader := []sszField{
+		root,                                  // parent_hash
+		bytesField(20),                        // fee_recipient
+		root,                                  // state_root
+		root,                                  // receipts_root
+		byteVectorField(bytesPerLogsBloom),    // logs_bloom
+		root,                                  // prev_randao
+		uint64Field(),                         // block_number
+		uint64Field(),                         // gas_limit
+		uint64Field(),                         // gas_used
+		uint64Field(),                         // timestamp
+		packedListField(1, maxExtraDataBytes), // extra_data
+		bytesField(32),                        // base_fee_per_gas
+		root,                                  // block_hash
+		root,                                  // transactions_root
+	}
+	if fork == "bellatrix" {
+		return append(fields, variableContainerField(payloadHeader...)), nil
+	}
+
+	// Capella
+	payloadHeader = append(payloadHeader, root) // withdrawals_root
+	capellaFields := []sszField{
+		uint64Field(), // next_withdrawal_index
+		uint64Field(), // next_withdrawal_validator_index
+		compositeListField(containerField(root, root), historicalRootsLimit), // historical_summaries
+	}
+	if fork == "capella" {
+		fields = append(fields, variableContainerField(payloadHeader...))
+		return append(fields, capellaFields...), nil
+	}
+
+	// Deneb
+	payloadHeader = append(payloadHeader, uint64Field(), uint64Field()) // blob_gas_used, excess_blob_gas
+	fields = append(fields, variableContainerField(payloadHeader...))
+	fields = append(fields, capellaFields...)
+	if fork == "deneb" {
+		return fields, nil
+	}
+
+	// Electra
+	fields = append(fields,
+		uint64Field(), // deposit_requests_start_index
+		uint64Field(), // deposit_balance_to_consume
+		uint64Field(), // exit_balance_to_consume
+		uint64Field(), // earliest_exit_epoch
+		uint64Field(), // consolidation_balance_to_consume
+		uint64Field(), // earliest_consolidation_epoch
+		compositeListField(containerField(pubkey, root, uint64Field(), byteVectorField(96), uint64Field()), pendingDepositsLimit), // pending_deposits
+		compositeListField(containerField(uint64Field(), uint64Field(), uint64Field()), pendingPartialWithdrawalLimit),            // pending_partial_withdrawals
+		compositeListField(containerField(uint64Field(), uint64Field()), pendingConsolidationsLimit),                              // pending_consolidations
+	)
+	if fork == "electra" {
+		return fields, nil
+	}
+
+	// Fulu
+	if fork == "fulu" {
+		return append(fields, packedVectorField(proposerLookaheadSize*8)), nil // proposer_lookahead
+	}
+	return nil, fmt.Errorf("Beacon states for the '%s' fork can't be hashed", fork)
+}
+
+// A uint64
+func uint64Field() sszField {
+	return bytesField(8)
+}
+
+// A basic value or byte vector that fits in a single chunk
+func bytesField(size int) sszField {
+	return sszField{
+		size: size,
+		root: func(data []byte) ([32]byte, error) {
+			var chunk [32]byte
+			copy(chunk[:], data)
+			return chunk, nil
+		},
+	}
+}
+
+// A byte vector that spans multiple chunks
+func byteVectorField(size int) sszField {
+	return packedVectorField(size)
+}
+
+// A vector of basic values or roots, packed into chunks
+func packedVectorField(size int) sszField {
+	return sszField{
+		size: size,
+		root: func(data []byte) ([32]byte, error) {
+			return merkleize(packChunks(data)), nil
+		},
+	}
+}
+
+// A list of basic values or roots, packed into chunks
+func packedListField(elementSize int, limit uint64) sszField {
+	chunkLimit := (limit*uint64(elementSize) + 31) / 32
+	return sszField{
+		size: 0,
+		root: func(data []byte) ([32]byte, error) {
+			if len(data)%elementSize != 0 {
+				return [32]byte{}, fmt.Errorf("list is %d bytes, which isn't a multiple of its element size %d", len(data), elementSize)
+			}
+			count := uint64(len(data) / elementSize)
+			if count > limit {
+				return [32]byte{}, fmt.Errorf("list has %d elements but its limit is %d", count, limit)
+			}
+			return mixInLength(merkleizeWithLimit(packChunks(data), chunkLimit), count), nil
+		},
+	}
+}
+
+// A vector of fixed-size containers or byte vectors
+func compositeVectorField(element sszField, count int) sszField {
+	return sszField{
+		size: element.size * count,
+		root: func(data []byte) ([32]byte, error) {
+			roots, err := getElementRoots(element, data)
+			if err != nil {
+				return [32]byte{}, err
+			}
+			return merkleize(roots), nil
+		},
+	}
+}
+
+// A list of fixed-size containers
+func compositeListField(element sszField, limit uint64) sszField {
+	return sszField{
+		size: 0,
+		root: func(data []byte) ([32]byte, error) {
+			roots, err := getElementRoots(element, data)
+			if err != nil {
+				return [32]byte{}, err
+			}
+			if uint64(len(roots)) > limit {
+				return [32]byte{}, fmt.Errorf("list has %d elements but its limit is %d", len(roots), limit)
+			}
+			return mixInLength(merkleizeWithLimit(roots, limit), uint64(len(roots))), nil
+		},
+	}
+}
+
+// A container whose fields are all fixed-size
+func containerField(fields ...sszField) sszField {
+	size := 0
+	for _, field := range fields {
+		size += field.size
+	}
+	return sszField{
+		size: size,
+		root: func(data []byte) ([32]byte, error) {
+			return hashSszContainer(fields, data)
+		},
+	}
+}
+
+// A container with variable-size fields
+func variableContainerField(fields ...sszField) sszField {
+	return sszField{
+		size: 0,
+		root: func(data []byte) ([32]byte, error) {
+			return hashSszContainer(fields, data)
+		},
+	}
+}
+
+// Get the roots of the fixed-size elements of a vector or list
+func getElementRoots(element sszField, data []byte) ([][32]byte, error) {
+	if len(data)%element.size != 0 {
+		return nil, fmt.Errorf("collection is %d bytes, which isn't a multiple of its element size %d", len(data), element.size)
+	}
+	roots := make([][32]byte, len(data)/element.size)
+	for i := range roots {
+		root, err := element.root(data[i*element.size : (i+1)*element.size])
+		if err != nil {
+			return nil, fmt.Errorf("error hashing element %d: %w", i, err)
+		}
+		roots[i] = root
+	}
+	return roots, nil
+}
+
+// Get the hash tree root of an SSZ-encoded container
+func hashSszContainer(fields []sszField, data []byte) ([32]byte, error) {
+	parts, err := splitSszContainer(fields, data)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	roots := make([][32]byte, len(fields))
+	for i, field := range fields {
+		roots[i], err = field.root(parts[i])
+		if err != nil {
+			return [32]byte{}, fmt.Errorf("error hashing field %d: %w", i, err)
+		}
+	}
+	return merkleize(roots), nil
+}
+
+// Split an SSZ-encoded container into the encodings of its fields.
+// Fixed-size fields are inline, and variable-size fields are found through the offsets that take their place.
+func splitSszContainer(fields []sszField, data []byte) ([][]byte, error) {
+	fixedSize := 0
+	for _, field := range fields {
+		if field.size == 0 {
+			fixedSize += 4
+		} else {
+			fixedSize += field.size
+		}
+	}
+	if len(data) < fixedSize {
+		return nil, fmt.Errorf("container is %d bytes but its fixed part is %d", len(data), fixedSize)
+	}
+
+	parts := make([][]byte, len(fields))
+	variableFields := []int{}
+	offsets := []int{}
+	position := 0
+	for i, field := range fields {
+		if field.size > 0 {
+			parts[i] = data[position : position+field.size]
+			position += field.size
+			continue
+		}
+		variableFields = append(variableFields, i)
+		offsets = append(offsets, int(binary.LittleEndian.Uint32(data[position:position+4])))
+		position += 4
+	}
+	if len(variableFields) == 0 {
+		if len(data) != fixedSize {
+			return nil, fmt.Errorf("container is %d bytes but must be %d", len(data), fixedSize)
+		}
+		return parts, nil
+	}
+	if offsets[0] != fixedSize {
+		return nil, fmt.Errorf("first offset is %d but the fixed part is %d bytes", offsets[0], fixedSize)
+	}
+	for j, i := range variableFields {
+		start := offsets[j]
+		end := len(data)
+		if j+1 < len(offsets) {
+			end = offsets[j+1]
+		}
+		if start > end || end > len(data) {
+			return nil, fmt.Errorf("invalid range [%d, %d) for field %d", start, end, i)
+		}
+		parts[i] = data[start:end]
+	}
+	return parts, nil
+}
+
+// Split data into 32-byte chunks, padding the last one with zeros
+func packChunks(data []byte) [][32]byte {
+	chunks := make([][32]byte, (len(data)+31)/32)
+	for i := range chunks {
+		copy(chunks[i][:], data[i*32:])
+	}
+	return chunks
+}
+
+// Merkleize a list of chunks into a tree wide enough for the limit, using the zero subtree roots for the empty part
+func merkleizeWithLimit(chunks [][32]byte, limit uint64) [32]byte {
+	depth := 0
+	for (uint64(1) << depth) < limit {
+		depth++
+	}
+	if len(chunks) == 0 {
+		return zeroHashes[depth]
+	}
+	layer := make([][32]byte, len(chunks))
+	copy(layer, chunks)
+	for i := 0; i < depth; i++ {
+		if len(layer)%2 == 1 {
+			layer = append(layer, zeroHashes[i])
+		}
+		next := make([][32]byte, len(layer)/2)
+		for j := range next {
+			next[j] = hashPair(layer[2*j], layer[2*j+1])
+		}
+		layer = next
+	}
+	return layer[0]
+}
+
+// Mix the length of a list into its root
+func mixInLength(root [32]byte, length uint64) [32]byte {
+	return hashPair(root, uint64Chunk(length))
+}
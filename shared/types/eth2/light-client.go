@@ -0,0 +1,119 @@
+package eth2
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// The number of validators in a sync committee (mainnet preset)
+const SyncCommitteeSize int = 512
+
+// Beacon block header
+type BeaconBlockHeader struct {
+	Slot          uint64   `json:"slot"`
+	ProposerIndex uint64   `json:"proposer_index"`
+	ParentRoot    [32]byte `json:"parent_root" ssz-size:"32"`
+	StateRoot     [32]byte `json:"state_root" ssz-size:"32"`
+	BodyRoot      [32]byte `json:"body_root" ssz-size:"32"`
+}
+
+// Sync committee
+type SyncCommittee struct {
+	Pubkeys         [][]byte `json:"pubkeys" ssz-size:"512,48"`
+	AggregatePubkey []byte   `json:"aggregate_pubkey" ssz-size:"48"`
+}
+
+// HashTreeRoot ssz hashes the BeaconBlockHeader object
+func (h *BeaconBlockHeader) HashTreeRoot() ([32]byte, error) {
+	return merkleize([][32]byte{
+		uint64Chunk(h.Slot),
+		uint64Chunk(h.ProposerIndex),
+		h.ParentRoot,
+		h.StateRoot,
+		h.BodyRoot,
+	}), nil
+}
+
+// HashTreeRoot ssz hashes the SyncCommittee object
+func (s *SyncCommittee) HashTreeRoot() ([32]byte, error) {
+	if len(s.Pubkeys) != SyncCommitteeSize {
+		return [32]byte{}, fmt.Errorf("sync committee has %d pubkeys but must have %d", len(s.Pubkeys), SyncCommitteeSize)
+	}
+	pubkeyRoots := make([][32]byte, len(s.Pubkeys))
+	for i, pubkey := range s.Pubkeys {
+		root, err := pubkeyRoot(pubkey)
+		if err != nil {
+			return [32]byte{}, fmt.Errorf("error hashing sync committee pubkey %d: %w", i, err)
+		}
+		pubkeyRoots[i] = root
+	}
+	aggregateRoot, err := pubkeyRoot(s.AggregatePubkey)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("error hashing sync committee aggregate pubkey: %w", err)
+	}
+	return merkleize([][32]byte{
+		merkleize(pubkeyRoots),
+		aggregateRoot,
+	}), nil
+}
+
+// Check that a leaf is included in a tree with the provided root, at the given depth and index
+func VerifyMerkleBranch(leaf [32]byte, branch [][32]byte, depth int, index uint64, root [32]byte) bool {
+	if len(branch) != depth {
+		return false
+	}
+	value := leaf
+	for i := 0; i < depth; i++ {
+		if (index>>i)&1 == 1 {
+			value = hashPair(branch[i], value)
+		} else {
+			value = hashPair(value, branch[i])
+		}
+	}
+	return value == root
+}
+
+// Get the root of a BLS pubkey, which spans two chunks
+func pubkeyRoot(pubkey []byte) ([32]byte, error) {
+	if len(pubkey) != 48 {
+		return [32]byte{}, fmt.Errorf("pubkey is %d bytes but must be 48", len(pubkey))
+	}
+	var chunks [2][32]byte
+	copy(chunks[0][:], pubkey[:32])
+	copy(chunks[1][:], pubkey[32:])
+	return hashPair(chunks[0], chunks[1]), nil
+}
+
+// Pack a uint64 into a chunk
+func uint64Chunk(value uint64) [32]byte {
+	var chunk [32]byte
+	binary.LittleEndian.PutUint64(chunk[:8], value)
+	return chunk
+}
+
+// Merkleize a list of chunks, padding it with zero chunks up to the next power of two
+func merkleize(chunks [][32]byte) [32]byte {
+	width := 1
+	for width < len(chunks) {
+		width *= 2
+	}
+	layer := make([][32]byte, width)
+	copy(layer, chunks)
+	for len(layer) > 1 {
+		next := make([][32]byte, len(layer)/2)
+		for i := range next {
+			next[i] = hashPair(layer[2*i], layer[2*i+1])
+		}
+		layer = next
+	}
+	return layer[0]
+}
+
+// Hash two nodes together
+func hashPair(left [32]byte, right [32]byte) [32]byte {
+	var data [64]byte
+	copy(data[:32], left[:])
+	copy(data[32:], right[:])
+	return sha256.Sum256(data[:])
+}
@@ -0,0 +1,128 @@
+package output
+
+// The status of one node in a fleet; Error is set instead of Status if the node couldn't be queried
+type FleetNodeStatus struct {
+	Name   string      `json:"name"`
+	Error  string      `json:"error,omitempty"`
+	Status *NodeStatus `json:"status,omitempty"`
+}
+
+type FleetStatus struct {
+	Nodes  []FleetNodeStatus `json:"nodes"`
+	Totals struct {
+		Nodes               int     `json:"nodes"`
+		Unreachable         int     `json:"unreachable"`
+		ActiveMinipools     int     `json:"activeMinipools"`
+		StakingMinipools    int     `json:"stakingMinipools"`
+		Undercollateralized int     `json:"undercollateralized"`
+		EthBalance          float64 `json:"ethBalance"`
+		RplBalance          float64 `json:"rplBalance"`
+		RplStake            float64 `json:"rplStake"`
+		EffectiveRplStake   float64 `json:"effectiveRplStake"`
+	} `json:"totals"`
+}
+
+// The rewards of one node in a fleet; Error is set instead of Rewards if the node couldn't be queried
+type FleetNodeRewards struct {
+	Name    string       `json:"name"`
+	Error   string       `json:"error,omitempty"`
+	Rewards *NodeRewards `json:"rewards,omitempty"`
+}
+
+type FleetRewards struct {
+	Nodes  []FleetNodeRewards `json:"nodes"`
+	Totals struct {
+		Nodes                  int     `json:"nodes"`
+		Unreachable            int     `json:"unreachable"`
+		BeaconRewards          float64 `json:"beaconRewards"`
+		ClaimedSmoothingPool   float64 `json:"claimedSmoothingPool"`
+		UnclaimedSmoothingPool float64 `json:"unclaimedSmoothingPool"`
+		RplStake               float64 `json:"rplStake"`
+		EffectiveRplStake      float64 `json:"effectiveRplStake"`
+		UnclaimedRpl           float64 `json:"unclaimedRpl"`
+		EstimatedRpl           float64 `json:"estimatedRpl"`
+		CumulativeRpl          float64 `json:"cumulativeRpl"`
+	} `json:"totals"`
+}
+
+// The minipools of one node in a fleet; Error is set instead of Minipools if the node couldn't be queried
+type FleetNodeMinipools struct {
+	Name      string        `json:"name"`
+	Error     string        `json:"error,omitempty"`
+	Minipools *MinipoolList `json:"minipools,omitempty"`
+}
+
+type FleetMinipools struct {
+	Nodes  []FleetNodeMinipools `json:"nodes"`
+	Totals struct {
+		Nodes              int            `json:"nodes"`
+		Unreachable        int            `json:"unreachable"`
+		Minipools          int            `json:"minipools"`
+		Statuses           map[string]int `json:"statuses"`
+		Balance            float64        `json:"balance"`
+		NodeShareOfBalance float64        `json:"nodeShareOfBalance"`
+	} `json:"totals"`
+}
+
+// Add up the totals of a fleet's node statuses
+func NewFleetStatus(nodes []FleetNodeStatus) FleetStatus {
+	output := FleetStatus{Nodes: nodes}
+	output.Totals.Nodes = len(nodes)
+	for _, node := range nodes {
+		if node.Status == nil {
+			output.Totals.Unreachable++
+			continue
+		}
+		output.Totals.ActiveMinipools += node.Status.MinipoolCounts.Active
+		output.Totals.StakingMinipools += node.Status.MinipoolCounts.Staking
+		if node.Status.RplStake.Undercollateralized {
+			output.Totals.Undercollateralized++
+		}
+		output.Totals.EthBalance += node.Status.AccountBalances.Eth
+		output.Totals.RplBalance += node.Status.AccountBalances.Rpl
+		output.Totals.RplStake += node.Status.RplStake.Total
+		output.Totals.EffectiveRplStake += node.Status.RplStake.Effective
+	}
+	return output
+}
+
+// Add up the totals of a fleet's node rewards
+func NewFleetRewards(nodes []FleetNodeRewards) FleetRewards {
+	output := FleetRewards{Nodes: nodes}
+	output.Totals.Nodes = len(nodes)
+	for _, node := range nodes {
+		if node.Rewards == nil {
+			output.Totals.Unreachable++
+			continue
+		}
+		output.Totals.BeaconRewards += node.Rewards.Eth.BeaconRewards
+		output.Totals.ClaimedSmoothingPool += node.Rewards.Eth.ClaimedSmoothingPool
+		output.Totals.UnclaimedSmoothingPool += node.Rewards.Eth.UnclaimedSmoothingPool
+		output.Totals.RplStake += node.Rewards.Rpl.TotalStake
+		output.Totals.EffectiveRplStake += node.Rewards.Rpl.EffectiveStake
+		output.Totals.UnclaimedRpl += node.Rewards.Rpl.Unclaimed
+		output.Totals.EstimatedRpl += node.Rewards.Rpl.EstimatedRewards
+		output.Totals.CumulativeRpl += node.Rewards.Rpl.Cumulative
+	}
+	return output
+}
+
+// Add up the totals of a fleet's minipools
+func NewFleetMinipools(nodes []FleetNodeMinipools) FleetMinipools {
+	output := FleetMinipools{Nodes: nodes}
+	output.Totals.Nodes = len(nodes)
+	output.Totals.Statuses = map[string]int{}
+	for _, node := range nodes {
+		if node.Minipools == nil {
+			output.Totals.Unreachable++
+			continue
+		}
+		for _, mp := range node.Minipools.Minipools {
+			output.Totals.Minipools++
+			output.Totals.Statuses[mp.Status]++
+			output.Totals.Balance += mp.Validator.Balance
+			output.Totals.NodeShareOfBalance += mp.Validator.NodeBalance
+		}
+	}
+	return output
+}
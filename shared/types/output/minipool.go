@@ -0,0 +1,107 @@
+package output
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+type Minipool struct {
+	Address             common.Address        `json:"address"`
+	Status              string                `json:"status"`
+	StatusTime          time.Time             `json:"statusTime"`
+	Finalized           bool                  `json:"finalized"`
+	DepositType         string                `json:"depositType"`
+	Penalties           uint64                `json:"penalties"`
+	NodeFee             float64               `json:"nodeFee"`
+	NodeDeposit         float64               `json:"nodeDeposit"`
+	UserDeposit         float64               `json:"userDeposit"`
+	UserDepositTime     *time.Time            `json:"userDepositTime"`
+	QueuePosition       int64                 `json:"queuePosition"`
+	Balance             float64               `json:"balance"`
+	NodeShareOfBalance  float64               `json:"nodeShareOfBalance"`
+	RefundBalance       float64               `json:"refundBalance"`
+	RefundAvailable     bool                  `json:"refundAvailable"`
+	WithdrawalAvailable bool                  `json:"withdrawalAvailable"`
+	CloseAvailable      bool                  `json:"closeAvailable"`
+	ValidatorPubkey     types.ValidatorPubkey `json:"validatorPubkey"`
+	Validator           struct {
+		Exists      bool    `json:"exists"`
+		Active      bool    `json:"active"`
+		Index       string  `json:"index"`
+		Balance     float64 `json:"balance"`
+		NodeBalance float64 `json:"nodeBalance"`
+	} `json:"validator"`
+	Delegate struct {
+		UseLatest   bool           `json:"useLatest"`
+		Current     common.Address `json:"current"`
+		Rollback    common.Address `json:"rollback"`
+		Effective   common.Address `json:"effective"`
+		Upgradeable bool           `json:"upgradeable"`
+	} `json:"delegate"`
+}
+
+type MinipoolList struct {
+	LatestDelegate common.Address `json:"latestDelegate"`
+	Minipools      []Minipool     `json:"minipools"`
+}
+
+// Create the output for a node's minipools, leaving out the finalized ones unless includeFinalized is set
+func NewMinipoolList(status *api.MinipoolStatusResponse, includeFinalized bool) MinipoolList {
+	output := MinipoolList{
+		LatestDelegate: status.LatestDelegate,
+		Minipools:      []Minipool{},
+	}
+	for _, mp := range status.Minipools {
+		if mp.Finalised && !includeFinalized {
+			continue
+		}
+		output.Minipools = append(output.Minipools, NewMinipool(&mp, status.LatestDelegate))
+	}
+	return output
+}
+
+// Create the output for a single minipool
+func NewMinipool(mp *api.MinipoolDetails, latestDelegate common.Address) Minipool {
+
+	output := Minipool{
+		Address:             mp.Address,
+		Status:              mp.Status.Status.String(),
+		StatusTime:          mp.Status.StatusTime,
+		Finalized:           mp.Finalised,
+		DepositType:         mp.DepositType.String(),
+		Penalties:           mp.Penalties,
+		NodeFee:             mp.Node.Fee,
+		NodeDeposit:         weiToEth(mp.Node.DepositBalance),
+		UserDeposit:         weiToEth(mp.User.DepositBalance),
+		QueuePosition:       mp.Queue.Position,
+		Balance:             weiToEth(mp.Balances.ETH),
+		NodeShareOfBalance:  weiToEth(mp.NodeShareOfETHBalance),
+		RefundBalance:       weiToEth(mp.Node.RefundBalance),
+		RefundAvailable:     mp.RefundAvailable,
+		WithdrawalAvailable: mp.WithdrawalAvailable,
+		CloseAvailable:      mp.CloseAvailable,
+		ValidatorPubkey:     mp.ValidatorPubkey,
+	}
+	if mp.User.DepositAssigned {
+		output.UserDepositTime = &mp.User.DepositAssignedTime
+	}
+
+	output.Validator.Exists = mp.Validator.Exists
+	output.Validator.Active = mp.Validator.Active
+	output.Validator.Index = mp.Validator.Index
+	output.Validator.Balance = weiToEth(mp.Validator.Balance)
+	output.Validator.NodeBalance = weiToEth(mp.Validator.NodeBalance)
+
+	output.Delegate.UseLatest = mp.UseLatestDelegate
+	output.Delegate.Current = mp.Delegate
+	output.Delegate.Rollback = mp.PreviousDelegate
+	output.Delegate.Effective = mp.EffectiveDelegate
+	output.Delegate.Upgradeable = mp.EffectiveDelegate != latestDelegate
+
+	return output
+
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+type NetworkStats struct {
+	TotalValueLocked    float64 `json:"totalValueLocked"`
+	DepositPoolBalance  float64 `json:"depositPoolBalance"`
+	MinipoolQueueDemand float64 `json:"minipoolQueueDemand"`
+	StakerUtilization   float64 `json:"stakerUtilization"`
+	NodeFee             float64 `json:"nodeFee"`
+	NodeCount           uint64  `json:"nodeCount"`
+	MinipoolCounts      struct {
+		Active       uint64 `json:"active"`
+		Initialized  uint64 `json:"initialized"`
+		Prelaunch    uint64 `json:"prelaunch"`
+		Staking      uint64 `json:"staking"`
+		Withdrawable uint64 `json:"withdrawable"`
+		Dissolved    uint64 `json:"dissolved"`
+		Finalized    uint64 `json:"finalized"`
+	} `json:"minipoolCounts"`
+	SmoothingPool struct {
+		Address        common.Address `json:"address"`
+		NodesOptedIn   uint64         `json:"nodesOptedIn"`
+		PendingBalance float64        `json:"pendingBalance"`
+	} `json:"smoothingPool"`
+	RethPrice          float64 `json:"rethPrice"`
+	RplPrice           float64 `json:"rplPrice"`
+	TotalRplStaked     float64 `json:"totalRplStaked"`
+	EffectiveRplStaked float64 `json:"effectiveRplStaked"`
+}
+
+// Create the output for the network's stats
+func NewNetworkStats(stats *api.NetworkStatsResponse) NetworkStats {
+
+	output := NetworkStats{
+		TotalValueLocked:    stats.TotalValueLocked,
+		DepositPoolBalance:  stats.DepositPoolBalance,
+		MinipoolQueueDemand: stats.MinipoolCapacity,
+		StakerUtilization:   stats.StakerUtilization,
+		NodeFee:             stats.NodeFee,
+		NodeCount:           stats.NodeCount,
+		RethPrice:           stats.RethPrice,
+		RplPrice:            stats.RplPrice,
+		TotalRplStaked:      stats.TotalRplStaked,
+		EffectiveRplStaked:  stats.EffectiveRplStaked,
+	}
+
+	output.MinipoolCounts.Initialized = stats.InitializedMinipoolCount
+	output.MinipoolCounts.Prelaunch = stats.PrelaunchMinipoolCount
+	output.MinipoolCounts.Staking = stats.StakingMinipoolCount
+	output.MinipoolCounts.Withdrawable = stats.WithdrawableMinipoolCount
+	output.MinipoolCounts.Dissolved = stats.DissolvedMinipoolCount
+	output.MinipoolCounts.Finalized = stats.FinalizedMinipoolCount
+	output.MinipoolCounts.Active = stats.InitializedMinipoolCount +
+		stats.PrelaunchMinipoolCount +
+		stats.StakingMinipoolCount +
+		stats.WithdrawableMinipoolCount +
+		stats.DissolvedMinipoolCount
+
+	output.SmoothingPool.Address = stats.SmoothingPoolAddress
+	output.SmoothingPool.NodesOptedIn = stats.SmoothingPoolNodes
+	output.SmoothingPool.PendingBalance = stats.SmoothingPoolBalance
+
+	return output
+
+}
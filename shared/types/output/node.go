@@ -0,0 +1,271 @@
+package output
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The number of penalties a minipool can get before they count as infractions
+const maxStrikes uint64 = 2
+
+type Balances struct {
+	Eth            float64 `json:"eth"`
+	Rpl            float64 `json:"rpl"`
+	FixedSupplyRpl float64 `json:"fixedSupplyRpl"`
+}
+
+type MinipoolPenalty struct {
+	Minipool    common.Address `json:"minipool"`
+	Strikes     uint64         `json:"strikes"`
+	Infractions uint64         `json:"infractions"`
+}
+
+type NodeStatus struct {
+	Network                  cfgtypes.Network  `json:"network"`
+	AccountAddress           common.Address    `json:"accountAddress"`
+	AccountBalances          Balances          `json:"accountBalances"`
+	CreditBalance            float64           `json:"creditBalance"`
+	Registered               bool              `json:"registered"`
+	Trusted                  bool              `json:"trusted"`
+	TimezoneLocation         string            `json:"timezoneLocation"`
+	WithdrawalAddress        common.Address    `json:"withdrawalAddress"`
+	WithdrawalBalances       Balances          `json:"withdrawalBalances"`
+	PendingWithdrawalAddress *common.Address   `json:"pendingWithdrawalAddress"`
+	VotingDelegate           *common.Address   `json:"votingDelegate"`
+	Penalties                []MinipoolPenalty `json:"penalties"`
+	Governance               struct {
+		Error                  string `json:"error,omitempty"`
+		ActiveProposals        int    `json:"activeProposals"`
+		VotedOnActiveProposals int    `json:"votedOnActiveProposals"`
+	} `json:"governance"`
+	SmoothingPool struct {
+		Address             common.Address `json:"address"`
+		OptedIn             bool           `json:"optedIn"`
+		OptOutInProgress    bool           `json:"optOutInProgress"`
+		OptOutCompleteEpoch uint64         `json:"optOutCompleteEpoch"`
+	} `json:"smoothingPool"`
+	FeeDistributor struct {
+		Address     common.Address `json:"address"`
+		Balance     float64        `json:"balance"`
+		Initialized bool           `json:"initialized"`
+	} `json:"feeDistributor"`
+	RplStake struct {
+		Total                   float64 `json:"total"`
+		Effective               float64 `json:"effective"`
+		Minimum                 float64 `json:"minimum"`
+		Maximum                 float64 `json:"maximum"`
+		RewardsEligible         float64 `json:"rewardsEligible"`
+		BorrowedCollateralRatio float64 `json:"borrowedCollateralRatio"`
+		BondedCollateralRatio   float64 `json:"bondedCollateralRatio"`
+		Undercollateralized     bool    `json:"undercollateralized"`
+	} `json:"rplStake"`
+	RemainingMinipools struct {
+		Bond8Eth  int `json:"bond8Eth"`
+		Bond16Eth int `json:"bond16Eth"`
+	} `json:"remainingMinipools"`
+	MinipoolCounts struct {
+		Active              int `json:"active"`
+		Initialized         int `json:"initialized"`
+		Prelaunch           int `json:"prelaunch"`
+		Staking             int `json:"staking"`
+		Withdrawable        int `json:"withdrawable"`
+		Dissolved           int `json:"dissolved"`
+		Finalized           int `json:"finalized"`
+		RefundAvailable     int `json:"refundAvailable"`
+		WithdrawalAvailable int `json:"withdrawalAvailable"`
+		CloseAvailable      int `json:"closeAvailable"`
+	} `json:"minipoolCounts"`
+}
+
+type NodeRewards struct {
+	Registered bool `json:"registered"`
+	// Intervals with rewards whose tree files are missing or don't match the canonical ones; the rewards are incomplete until they're downloaded
+	IntervalsMissingTrees []uint64 `json:"intervalsMissingTrees"`
+	Eth                   struct {
+		BeaconRewards          float64 `json:"beaconRewards"`
+		ClaimedSmoothingPool   float64 `json:"claimedSmoothingPool"`
+		UnclaimedSmoothingPool float64 `json:"unclaimedSmoothingPool"`
+	} `json:"eth"`
+	Rpl struct {
+		CycleStart       time.Time `json:"cycleStart"`
+		CycleEnd         time.Time `json:"cycleEnd"`
+		TotalStake       float64   `json:"totalStake"`
+		EffectiveStake   float64   `json:"effectiveStake"`
+		Unclaimed        float64   `json:"unclaimed"`
+		EstimatedRewards float64   `json:"estimatedRewards"`
+		EstimatedApr     float64   `json:"estimatedApr"`
+		Cumulative       float64   `json:"cumulative"`
+	} `json:"rpl"`
+	OracleDao *OracleDaoRewards `json:"oracleDao,omitempty"`
+}
+
+type OracleDaoRewards struct {
+	Bond             float64 `json:"bond"`
+	Unclaimed        float64 `json:"unclaimed"`
+	EstimatedRewards float64 `json:"estimatedRewards"`
+	EstimatedApr     float64 `json:"estimatedApr"`
+	Cumulative       float64 `json:"cumulative"`
+}
+
+// Create the output for a node's status
+func NewNodeStatus(status *api.NodeStatusResponse, network cfgtypes.Network) NodeStatus {
+
+	output := NodeStatus{
+		Network:            network,
+		AccountAddress:     status.AccountAddress,
+		AccountBalances:    newBalances(status.AccountBalances.ETH, status.AccountBalances.RPL, status.AccountBalances.FixedSupplyRPL),
+		CreditBalance:      weiToEth(status.CreditBalance),
+		Registered:         status.Registered,
+		Trusted:            status.Trusted,
+		TimezoneLocation:   status.TimezoneLocation,
+		WithdrawalAddress:  status.WithdrawalAddress,
+		WithdrawalBalances: newBalances(status.WithdrawalBalances.ETH, status.WithdrawalBalances.RPL, status.WithdrawalBalances.FixedSupplyRPL),
+		Penalties:          []MinipoolPenalty{},
+	}
+	if !status.Registered {
+		return output
+	}
+
+	blankAddress := common.Address{}
+	if status.PendingWithdrawalAddress != blankAddress {
+		output.PendingWithdrawalAddress = &status.PendingWithdrawalAddress
+	}
+	if status.VotingDelegate != blankAddress {
+		output.VotingDelegate = &status.VotingDelegate
+	}
+
+	// Penalties
+	for mp, count := range status.PenalizedMinipools {
+		penalty := MinipoolPenalty{Minipool: mp}
+		if count <= maxStrikes {
+			penalty.Strikes = count
+		} else {
+			penalty.Strikes = maxStrikes
+			penalty.Infractions = count - maxStrikes
+		}
+		output.Penalties = append(output.Penalties, penalty)
+	}
+	sort.Slice(output.Penalties, func(i, j int) bool {
+		return output.Penalties[i].Minipool.Hex() < output.Penalties[j].Minipool.Hex()
+	})
+
+	// Governance
+	output.Governance.Error = status.SnapshotResponse.Error
+	output.Governance.ActiveProposals = len(status.SnapshotResponse.ActiveSnapshotProposals)
+	for _, activeProposal := range status.SnapshotResponse.ActiveSnapshotProposals {
+		for _, votedProposal := range status.SnapshotResponse.ProposalVotes {
+			if votedProposal.Proposal.Id == activeProposal.Id {
+				output.Governance.VotedOnActiveProposals++
+				break
+			}
+		}
+	}
+
+	// Smoothing pool and fee distributor
+	output.SmoothingPool.Address = status.FeeRecipientInfo.SmoothingPoolAddress
+	output.SmoothingPool.OptedIn = status.FeeRecipientInfo.IsInSmoothingPool
+	output.SmoothingPool.OptOutInProgress = status.FeeRecipientInfo.IsInOptOutCooldown
+	output.SmoothingPool.OptOutCompleteEpoch = status.FeeRecipientInfo.OptOutEpoch
+	output.FeeDistributor.Address = status.FeeRecipientInfo.FeeDistributorAddress
+	output.FeeDistributor.Balance = weiToEth(status.FeeDistributorBalance)
+	output.FeeDistributor.Initialized = status.IsFeeDistributorInitialized
+
+	// RPL stake
+	output.RplStake.Total = weiToEth(status.RplStake)
+	output.RplStake.Effective = weiToEth(status.EffectiveRplStake)
+	output.RplStake.Minimum = weiToEth(status.MinimumRplStake)
+	output.RplStake.Maximum = weiToEth(status.MaximumRplStake)
+	output.RplStake.RewardsEligible = weiToEth(status.PendingEffectiveRplStake)
+	output.RplStake.BorrowedCollateralRatio = status.BorrowedCollateralRatio
+	output.RplStake.BondedCollateralRatio = status.BondedCollateralRatio
+	if status.BorrowedCollateralRatio > 0 && status.RplStake != nil && status.MinimumRplStake != nil {
+		output.RplStake.Undercollateralized = status.RplStake.Cmp(status.MinimumRplStake) < 0
+	}
+
+	// Minipools the node can still make with its current stake
+	if status.EthMatchedLimit != nil && status.EthMatched != nil && status.PendingMatchAmount != nil {
+		remainingAmount := big.NewInt(0).Sub(status.EthMatchedLimit, status.EthMatched)
+		remainingAmount.Sub(remainingAmount, status.PendingMatchAmount)
+		remainingAmountEth := int(eth.WeiToEth(remainingAmount))
+		if remainingAmountEth > 0 {
+			output.RemainingMinipools.Bond8Eth = remainingAmountEth / 24
+			output.RemainingMinipools.Bond16Eth = remainingAmountEth / 16
+		}
+	}
+
+	// Minipool counts
+	output.MinipoolCounts.Active = status.MinipoolCounts.Total - status.MinipoolCounts.Finalised
+	output.MinipoolCounts.Initialized = status.MinipoolCounts.Initialized
+	output.MinipoolCounts.Prelaunch = status.MinipoolCounts.Prelaunch
+	output.MinipoolCounts.Staking = status.MinipoolCounts.Staking
+	output.MinipoolCounts.Withdrawable = status.MinipoolCounts.Withdrawable
+	output.MinipoolCounts.Dissolved = status.MinipoolCounts.Dissolved
+	output.MinipoolCounts.Finalized = status.MinipoolCounts.Finalised
+	output.MinipoolCounts.RefundAvailable = status.MinipoolCounts.RefundAvailable
+	output.MinipoolCounts.WithdrawalAvailable = status.MinipoolCounts.WithdrawalAvailable
+	output.MinipoolCounts.CloseAvailable = status.MinipoolCounts.CloseAvailable
+
+	return output
+
+}
+
+// Create the output for a node's rewards; missingIntervals are the intervals whose tree files aren't available locally
+func NewNodeRewards(rewards *api.NodeRewardsResponse, missingIntervals []uint64) NodeRewards {
+
+	output := NodeRewards{
+		Registered:            rewards.Registered,
+		IntervalsMissingTrees: missingIntervals,
+	}
+	if output.IntervalsMissingTrees == nil {
+		output.IntervalsMissingTrees = []uint64{}
+	}
+
+	output.Eth.BeaconRewards = rewards.BeaconRewards
+	output.Eth.ClaimedSmoothingPool = rewards.CumulativeEthRewards
+	output.Eth.UnclaimedSmoothingPool = rewards.UnclaimedEthRewards
+
+	output.Rpl.CycleStart = rewards.LastCheckpoint
+	output.Rpl.CycleEnd = rewards.LastCheckpoint.Add(rewards.RewardsInterval)
+	output.Rpl.TotalStake = rewards.TotalRplStake
+	output.Rpl.EffectiveStake = rewards.EffectiveRplStake
+	output.Rpl.Unclaimed = rewards.UnclaimedRplRewards
+	output.Rpl.EstimatedRewards = rewards.EstimatedRewards
+	output.Rpl.EstimatedApr = getApr(rewards.EstimatedRewards, rewards.TotalRplStake, rewards.RewardsInterval)
+	output.Rpl.Cumulative = rewards.CumulativeRplRewards
+
+	if rewards.Trusted {
+		output.OracleDao = &OracleDaoRewards{
+			Bond:             rewards.TrustedRplBond,
+			Unclaimed:        rewards.UnclaimedTrustedRplRewards,
+			EstimatedRewards: rewards.EstimatedTrustedRplRewards,
+			EstimatedApr:     getApr(rewards.EstimatedTrustedRplRewards, rewards.TrustedRplBond, rewards.RewardsInterval),
+			Cumulative:       rewards.CumulativeTrustedRplRewards,
+		}
+	}
+
+	return output
+
+}
+
+func newBalances(ethBalance *big.Int, rplBalance *big.Int, fixedSupplyRplBalance *big.Int) Balances {
+	return Balances{
+		Eth:            weiToEth(ethBalance),
+		Rpl:            weiToEth(rplBalance),
+		FixedSupplyRpl: weiToEth(fixedSupplyRplBalance),
+	}
+}
+
+// Get the APR (as a fraction) of the rewards earned on a stake during one rewards interval, assuming 365 days in a year
+func getApr(rewards float64, stake float64, interval time.Duration) float64 {
+	if stake == 0 || interval == 0 {
+		return 0
+	}
+	return rewards / stake / interval.Hours() * (24 * 365)
+}
@@ -0,0 +1,29 @@
+// Package output holds the schemas the CLI uses for its machine-readable (--json) output.
+// Unlike the API response types, these are a stable interface for scripts: fields may be added,
+// but renaming or removing one (or changing what it means) requires bumping SchemaVersion.
+// All ETH and RPL amounts are given in ETH / RPL, not wei.
+package output
+
+import (
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The version of the output schemas
+const SchemaVersion int = 1
+
+// The document every command prints in JSON mode
+type Document struct {
+	SchemaVersion int         `json:"schemaVersion"`
+	Data          interface{} `json:"data,omitempty"`
+	Error         string      `json:"error,omitempty"`
+}
+
+// Convert a wei amount to ETH, treating a missing amount as zero
+func weiToEth(wei *big.Int) float64 {
+	if wei == nil {
+		return 0
+	}
+	return eth.WeiToEth(wei)
+}
@@ -57,6 +57,12 @@ func PrintAndCheckGasInfo(gasInfo rocketpool.GasInfo, checkThreshold bool, gasTh
 // Print a TX's details to the logger and waits for it to validated.
 func PrintAndWaitForTransaction(cfg *config.RocketPoolConfig, hash common.Hash, ec rocketpool.ExecutionClient, logger *log.ColorLogger) error {
 
+	// Proposals to a Safe only run once its owners approve them, so don't block the caller on them
+	if safeAddress := cfg.Smartnode.SafeAddress.Value.(string); safeAddress != "" {
+		logger.Printlnf("Transaction has been proposed to the node's Safe (%s). It will run once the Safe's owners approve and execute it.", safeAddress)
+		return nil
+	}
+
 	txWatchUrl := cfg.Smartnode.GetTxWatchUrl()
 	hashString := hash.String()
 
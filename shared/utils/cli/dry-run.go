@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Signatures of the ERC-20 events used by RPL and rETH
+var (
+	erc20TransferTopic = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	erc20ApprovalTopic = common.HexToHash("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925")
+)
+
+// Set when the CLI is run with --dry-run, so transaction confirmation prompts are answered automatically
+var dryRun bool
+
+// Answer transaction confirmation prompts automatically, since dry runs never submit anything
+func SetDryRun(enabled bool) {
+	dryRun = enabled
+}
+
+// Load a simulated transaction that was saved by a command run with --dry-run
+func LoadDryRunTransaction(path string) (api.DryRunTransaction, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return api.DryRunTransaction{}, fmt.Errorf("error reading dry run [%s]: %w", path, err)
+	}
+	var dryRunTx api.DryRunTransaction
+	err = json.Unmarshal(bytes, &dryRunTx)
+	if err != nil {
+		return api.DryRunTransaction{}, fmt.Errorf("error decoding dry run [%s]: %w", path, err)
+	}
+	return dryRunTx, nil
+}
+
+func printDryRunConfirmation(initialPrompt string) {
+	fmt.Printf("%s [y/n]\n(dry run: answering 'y')\n\n", initialPrompt)
+}
+
+// Print a transaction that was simulated by a command run with --dry-run, and what it would have done
+func printDryRunTransaction(cfg *config.RocketPoolConfig, hash common.Hash) {
+
+	dryRunTxsPath, err := homedir.Expand(cfg.Smartnode.GetDryRunTxsPathInCLI())
+	if err != nil {
+		fmt.Printf("Error expanding dry run path: %s\n", err.Error())
+		return
+	}
+	path := filepath.Join(dryRunTxsPath, api.GetDryRunTransactionFilename(hash))
+	dryRunTx, err := LoadDryRunTransaction(path)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	tx, err := DecodeTransaction(dryRunTx.Transaction.SerializedTx)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	// The file is only needed to hand the results to the CLI
+	_ = os.Remove(path)
+
+	fmt.Println("DRY RUN: the transaction has been simulated against the latest block, but it has NOT been signed or submitted:")
+	PrintTransactionSummary(tx, &dryRunTx.Transaction.From)
+	fmt.Println()
+
+	if dryRunTx.Reverted {
+		fmt.Printf("%sThe transaction would fail: %s%s\n", colorRed, dryRunTx.RevertReason, colorReset)
+	} else {
+		fmt.Printf("%sThe transaction would succeed.%s\n", colorGreen, colorReset)
+	}
+
+	if !dryRunTx.TraceAvailable {
+		fmt.Printf("%sYour Execution client couldn't trace the transaction (%s), so its gas usage and effects can't be shown. Enable its `debug` API to see them.%s\n", colorYellow, dryRunTx.TraceError, colorReset)
+		return
+	}
+
+	gasUsed := big.NewInt(0).SetUint64(dryRunTx.GasUsed)
+	maxCost := gasUsed.Mul(gasUsed, tx.GasFeeCap())
+	fmt.Printf("Gas used:                 %d (at most %.6f ETH at the max fee)\n", dryRunTx.GasUsed, eth.WeiToEth(maxCost))
+	if dryRunTx.Reverted {
+		return
+	}
+
+	if len(dryRunTx.Transfers) > 0 {
+		fmt.Println("\nETH transfers:")
+		for _, transfer := range dryRunTx.Transfers {
+			fmt.Printf("\t%.6f ETH from %s to %s\n", eth.WeiToEth(transfer.Value), transfer.From.Hex(), transfer.To.Hex())
+		}
+	}
+	if len(dryRunTx.Events) > 0 {
+		fmt.Println("\nEvents:")
+		for _, event := range dryRunTx.Events {
+			fmt.Printf("\t%s\n", getDryRunEventDescription(event))
+		}
+	}
+	if len(dryRunTx.Transfers) == 0 && len(dryRunTx.Events) == 0 {
+		fmt.Println("\nThe transaction wouldn't transfer any ETH or emit any events.")
+	}
+	fmt.Printf("\n%sIf this command has more steps, they depend on this transaction and weren't simulated.%s\n", colorYellow, colorReset)
+
+}
+
+// Describe an event, decoding token transfers and approvals
+func getDryRunEventDescription(event api.DryRunEvent) string {
+	if len(event.Topics) == 3 && len(event.Data) == 32 {
+		from := common.BytesToAddress(event.Topics[1].Bytes())
+		to := common.BytesToAddress(event.Topics[2].Bytes())
+		amount := eth.WeiToEth(big.NewInt(0).SetBytes(event.Data))
+		switch event.Topics[0] {
+		case erc20TransferTopic:
+			return fmt.Sprintf("Transfer of %.6f tokens (%s) from %s to %s", amount, event.Address.Hex(), from.Hex(), to.Hex())
+		case erc20ApprovalTopic:
+			return fmt.Sprintf("Approval for %s to spend %.6f tokens (%s) of %s", to.Hex(), amount, event.Address.Hex(), from.Hex())
+		}
+	}
+	if len(event.Topics) == 0 {
+		return fmt.Sprintf("Anonymous event from %s", event.Address.Hex())
+	}
+	return fmt.Sprintf("Event %s from %s", event.Topics[0].Hex(), event.Address.Hex())
+}
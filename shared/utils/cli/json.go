@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/types/output"
+)
+
+// Check if the CLI should print machine-readable JSON instead of formatted text
+func IsJsonOutput(c *cli.Context) bool {
+	return c.GlobalBool("json")
+}
+
+// Check if the --json flag was given on the command line; use this before the CLI context exists
+func IsJsonOutputRequested(args []string) bool {
+	for _, arg := range args {
+		if arg == "--json" {
+			return true
+		}
+	}
+	return false
+}
+
+// Print a command's output as a JSON document
+func PrintJson(data interface{}) error {
+	return printJsonDocument(output.Document{
+		SchemaVersion: output.SchemaVersion,
+		Data:          data,
+	})
+}
+
+// Print an error as a JSON document
+func PrintJsonError(err error) {
+	printErr := printJsonDocument(output.Document{
+		SchemaVersion: output.SchemaVersion,
+		Error:         getPrettyErrorMessage(err),
+	})
+	if printErr != nil {
+		fmt.Fprintf(os.Stderr, "Error printing JSON output: %s\n", printErr.Error())
+	}
+}
+
+func printJsonDocument(document output.Document) error {
+	bytes, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing output: %w", err)
+	}
+	fmt.Println(string(bytes))
+	return nil
+}
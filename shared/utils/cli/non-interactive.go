@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Whether prompts must be answered without reading from the terminal
+var nonInteractive bool
+
+// Set whether prompts must be answered without reading from the terminal.
+// In this mode confirmations are accepted, and every other prompt is answered from its environment variable.
+func SetNonInteractive(enabled bool) {
+	nonInteractive = enabled
+}
+
+// Check if prompts must be answered without reading from the terminal
+func IsNonInteractive() bool {
+	return nonInteractive
+}
+
+// Get the answer to a prompt from its environment variable, if it has one and it's set.
+// Stops the CLI if the answer doesn't have the expected format, or if there is no answer in non-interactive mode.
+func getInjectedAnswer(envVar string, initialPrompt string, expectedFormat string) (string, bool) {
+	answer, isSet := "", false
+	if envVar != "" {
+		answer, isSet = os.LookupEnv(envVar)
+	}
+	if !isSet {
+		if nonInteractive {
+			if envVar == "" {
+				exitNonInteractive("This prompt can't be answered in non-interactive mode:\n%s", initialPrompt)
+			}
+			exitNonInteractive("Set %s to answer this prompt in non-interactive mode:\n%s", envVar, initialPrompt)
+		}
+		return "", false
+	}
+	if !regexp.MustCompile(expectedFormat).MatchString(answer) {
+		exitNonInteractive("The value of %s isn't a valid answer to this prompt:\n%s", envVar, initialPrompt)
+	}
+	return answer, true
+}
+
+// Get the option selected by a prompt's environment variable, by its number or by its text (or the start of it).
+// Stops the CLI if the answer doesn't match exactly one option, or if there is no answer in non-interactive mode.
+func getInjectedSelection(envVar string, initialPrompt string, options []string) (int, bool) {
+	answer, isSet := getInjectedAnswer(envVar, initialPrompt, "^.+$")
+	if !isSet {
+		return 0, false
+	}
+
+	if number, err := strconv.Atoi(answer); err == nil && number >= 1 && number <= len(options) {
+		return number - 1, true
+	}
+	selectedIndex := -1
+	for i, option := range options {
+		if strings.EqualFold(option, answer) {
+			return i, true
+		}
+		if strings.HasPrefix(strings.ToLower(option), strings.ToLower(answer)) {
+			if selectedIndex != -1 {
+				exitNonInteractive("The value of %s matches more than one option for this prompt:\n%s", envVar, initialPrompt)
+			}
+			selectedIndex = i
+		}
+	}
+	if selectedIndex == -1 {
+		exitNonInteractive("The value of %s doesn't match any of the options for this prompt:\n%s\nOptions:\n- %s", envVar, initialPrompt, strings.Join(options, "\n- "))
+	}
+	return selectedIndex, true
+}
+
+// Print an error about a prompt that can't be answered and stop the CLI
+func exitNonInteractive(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -9,8 +9,13 @@ import (
 	"strings"
 )
 
-// Prompt for user input
-func Prompt(initialPrompt string, expectedFormat string, incorrectFormatPrompt string) string {
+// Prompt for user input, using the value of the environment variable instead if it's set
+func Prompt(initialPrompt string, expectedFormat string, incorrectFormatPrompt string, envVar string) string {
+
+	// Use the injected answer if there is one
+	if answer, isSet := getInjectedAnswer(envVar, initialPrompt, expectedFormat); isSet {
+		return answer
+	}
 
 	// Print initial prompt
 	fmt.Println(initialPrompt)
@@ -30,18 +35,59 @@ func Prompt(initialPrompt string, expectedFormat string, incorrectFormatPrompt s
 
 // Prompt for confirmation
 func Confirm(initialPrompt string) bool {
-	response := Prompt(fmt.Sprintf("%s [y/n]", initialPrompt), "(?i)^(y|yes|n|no)$", "Please answer 'y' or 'n'")
+	if nonInteractive {
+		printNonInteractiveConfirmation(initialPrompt)
+		return true
+	}
+	return confirm(initialPrompt)
+}
+
+// Prompt for confirmation of a transaction; dry runs only simulate transactions, so they're confirmed automatically
+func ConfirmTransaction(initialPrompt string) bool {
+	if dryRun {
+		printDryRunConfirmation(initialPrompt)
+		return true
+	}
+	return Confirm(initialPrompt)
+}
+
+// Prompt for confirmation, even in non-interactive mode
+func confirm(initialPrompt string) bool {
+	response := Prompt(fmt.Sprintf("%s [y/n]", initialPrompt), "(?i)^(y|yes|n|no)$", "Please answer 'y' or 'n'", "")
 	return (strings.ToLower(response[:1]) == "y")
 }
 
 // Prompt for 'I agree' confirmation (used on important questions to avoid a quick 'y' response from the user)
 func ConfirmWithIAgree(initialPrompt string) bool {
-	response := Prompt(fmt.Sprintf("%s [Type 'I agree' or 'n']", initialPrompt), "(?i)^(i agree|n|no)$", "Please answer 'I agree' or 'n'")
+	if nonInteractive {
+		printNonInteractiveConfirmation(initialPrompt)
+		return true
+	}
+	response := Prompt(fmt.Sprintf("%s [Type 'I agree' or 'n']", initialPrompt), "(?i)^(i agree|n|no)$", "Please answer 'I agree' or 'n'", "")
 	return (len(response) == 7 && strings.ToLower(response[:7]) == "i agree")
 }
 
-// Prompt for user selection
-func Select(initialPrompt string, options []string) (int, string) {
+// Prompt for 'I agree' confirmation of a transaction; dry runs only simulate transactions, so they're confirmed automatically
+func ConfirmTransactionWithIAgree(initialPrompt string) bool {
+	if dryRun {
+		printDryRunConfirmation(initialPrompt)
+		return true
+	}
+	return ConfirmWithIAgree(initialPrompt)
+}
+
+// Print a confirmation prompt that was accepted by non-interactive mode
+func printNonInteractiveConfirmation(initialPrompt string) {
+	fmt.Printf("%s\n%sAccepted automatically in non-interactive mode.%s\n\n", initialPrompt, colorYellow, colorReset)
+}
+
+// Prompt for user selection, using the option chosen by the environment variable instead if it's set
+func Select(initialPrompt string, options []string, envVar string) (int, string) {
+
+	// Use the injected selection if there is one
+	if selectedIndex, isSet := getInjectedSelection(envVar, initialPrompt, options); isSet {
+		return selectedIndex, options[selectedIndex]
+	}
 
 	// Get prompt
 	prompt := initialPrompt
@@ -57,7 +103,7 @@ func Select(initialPrompt string, options []string) (int, string) {
 	expectedFormat := fmt.Sprintf("^(%s)$", strings.Join(optionNumbers, "|"))
 
 	// Prompt user
-	response := Prompt(prompt, expectedFormat, "Please enter a number corresponding to an option")
+	response := Prompt(prompt, expectedFormat, "Please enter a number corresponding to an option", "")
 
 	// Get selected option
 	index, _ := strconv.Atoi(response)
@@ -71,7 +117,12 @@ func Select(initialPrompt string, options []string) (int, string) {
 
 // Prompts the user to verify that there is nobody looking over their shoulder before printing sensitive information.
 func ConfirmSecureSession(warning string) bool {
-	if !Confirm(fmt.Sprintf("%s%s%s\nAre you sure you want to continue?", colorYellow, warning, colorReset)) {
+	// Always ask, even in dry runs, since this protects sensitive information rather than a transaction
+	if nonInteractive {
+		fmt.Printf("%s%s%s\nUse --secure-session to allow this in non-interactive mode.\n", colorYellow, warning, colorReset)
+		return false
+	}
+	if !confirm(fmt.Sprintf("%s%s%s\nAre you sure you want to continue?", colorYellow, warning, colorReset)) {
 		fmt.Println("Cancelled.")
 		return false
 	}
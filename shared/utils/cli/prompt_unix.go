@@ -11,8 +11,13 @@ import (
 	"golang.org/x/term"
 )
 
-// Prompt for password input
-func PromptPassword(initialPrompt string, expectedFormat string, incorrectFormatPrompt string) string {
+// Prompt for password input, using the value of the environment variable instead if it's set
+func PromptPassword(initialPrompt string, expectedFormat string, incorrectFormatPrompt string, envVar string) string {
+
+	// Use the injected answer if there is one
+	if answer, isSet := getInjectedAnswer(envVar, initialPrompt, expectedFormat); isSet {
+		return answer
+	}
 
 	// Print initial prompt
 	fmt.Println(initialPrompt)
@@ -3,7 +3,7 @@
 
 package cli
 
-// Prompt for password input
-func PromptPassword(initialPrompt string, expectedFormat string, incorrectFormatPrompt string) string {
-	return Prompt(initialPrompt, expectedFormat, incorrectFormatPrompt)
+// Prompt for password input, using the value of the environment variable instead if it's set
+func PromptPassword(initialPrompt string, expectedFormat string, incorrectFormatPrompt string, envVar string) string {
+	return Prompt(initialPrompt, expectedFormat, incorrectFormatPrompt, envVar)
 }
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Load an unsigned transaction that was saved by a command run with --unsigned
+func LoadUnsignedTransaction(path string) (api.UnsignedTransaction, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return api.UnsignedTransaction{}, fmt.Errorf("error reading unsigned transaction [%s]: %w", path, err)
+	}
+	var unsignedTx api.UnsignedTransaction
+	err = json.Unmarshal(bytes, &unsignedTx)
+	if err != nil {
+		return api.UnsignedTransaction{}, fmt.Errorf("error decoding unsigned transaction [%s]: %w", path, err)
+	}
+	return unsignedTx, nil
+}
+
+// Decode a hex-encoded, serialized transaction
+func DecodeTransaction(serializedTx string) (*types.Transaction, error) {
+	txBytes, err := hexutil.Decode(serializedTx)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing transaction bytes: %w", err)
+	}
+	tx := new(types.Transaction)
+	err = tx.UnmarshalBinary(txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// Print a human-readable summary of a transaction; the sender is optional since unsigned transactions don't include it
+func PrintTransactionSummary(tx *types.Transaction, from *common.Address) {
+	fmt.Printf("Chain ID:                 %s\n", tx.ChainId().String())
+	if from != nil {
+		fmt.Printf("From:                     %s\n", from.Hex())
+	}
+	if tx.To() != nil {
+		fmt.Printf("To:                       %s\n", tx.To().Hex())
+	} else {
+		fmt.Println("To:                       <contract deployment>")
+	}
+	fmt.Printf("Value:                    %.6f ETH\n", eth.WeiToEth(tx.Value()))
+	fmt.Printf("Nonce:                    %d\n", tx.Nonce())
+	fmt.Printf("Gas limit:                %d\n", tx.Gas())
+	fmt.Printf("Max fee:                  %.6f gwei\n", eth.WeiToGwei(tx.GasFeeCap()))
+	fmt.Printf("Max priority fee:         %.6f gwei\n", eth.WeiToGwei(tx.GasTipCap()))
+	fmt.Printf("Data:                     %d bytes\n", len(tx.Data()))
+	if len(tx.Data()) >= 4 {
+		fmt.Printf("Function selector:        %s\n", hexutil.Encode(tx.Data()[:4]))
+	}
+}
+
+// Print an unsigned transaction that was saved by a command run with --unsigned, and how to sign and submit it
+func printUnsignedTransaction(cfg *config.RocketPoolConfig, hash common.Hash) {
+
+	unsignedTxsPath, err := homedir.Expand(cfg.Smartnode.GetUnsignedTxsPathInCLI())
+	if err != nil {
+		fmt.Printf("Error expanding unsigned transactions path: %s\n", err.Error())
+		return
+	}
+	path := filepath.Join(unsignedTxsPath, api.GetUnsignedTransactionFilename(hash))
+	unsignedTx, err := LoadUnsignedTransaction(path)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	tx, err := DecodeTransaction(unsignedTx.SerializedTx)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+
+	fmt.Println("The transaction has been created, but it has NOT been signed or submitted:")
+	PrintTransactionSummary(tx, &unsignedTx.From)
+	fmt.Printf("\nIt has been saved to %s. Its serialized form is:\n%s\n\n", path, unsignedTx.SerializedTx)
+	fmt.Println("To submit it:")
+	fmt.Println("1. Copy the file to the offline machine that holds your node wallet, and sign it with `rocketpool wallet sign-tx <file>`.")
+	fmt.Println("2. Copy the signed transaction back to this machine and submit it with `rocketpool wallet send-raw <file>`.")
+	fmt.Printf("%sThe transaction uses nonce %d and the fees shown above, so sign and submit it before creating any other transactions from your node account.\nIf this command has more steps, run it again with --unsigned once this transaction has been included in a block.%s\n", colorYellow, tx.Nonce(), colorReset)
+
+}
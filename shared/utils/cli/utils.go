@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -16,19 +17,25 @@ const colorGreen string = "\033[32m"
 const colorYellow string = "\033[33m"
 const colorLightBlue string = "\033[36m"
 
+// Returned when a transaction was simulated, saved unsigned or deferred instead of being submitted, so it won't be included in a block yet.
+// Commands stop at the transaction when they get it; it isn't reported as an error.
+var ErrTransactionNotSubmitted = errors.New("the transaction was not submitted")
+
 // Print a TX's details to the console.
-func PrintTransactionHash(rp *rocketpool.Client, hash common.Hash) {
+// Returns ErrTransactionNotSubmitted if the transaction wasn't submitted, so the command shouldn't wait for it.
+func PrintTransactionHash(rp *rocketpool.Client, hash common.Hash) error {
 
 	finalMessage := "Waiting for the transaction to be included in a block... you may wait here for it, or press CTRL+C to exit and return to the terminal.\n\n"
-	printTransactionHashImpl(rp, hash, finalMessage)
+	return printTransactionHashImpl(rp, hash, finalMessage)
 
 }
 
 // Print a TX's details to the console, but inform the user NOT to cancel it.
-func PrintTransactionHashNoCancel(rp *rocketpool.Client, hash common.Hash) {
+// Returns ErrTransactionNotSubmitted if the transaction wasn't submitted, so the command shouldn't wait for it.
+func PrintTransactionHashNoCancel(rp *rocketpool.Client, hash common.Hash) error {
 
 	finalMessage := "Waiting for the transaction to be included in a block... **DO NOT EXIT!** This transaction is one of several that must be completed.\n\n"
-	printTransactionHashImpl(rp, hash, finalMessage)
+	return printTransactionHashImpl(rp, hash, finalMessage)
 
 }
 
@@ -43,17 +50,44 @@ func PrintMultiTransactionNonceWarning() {
 }
 
 // Implementation of PrintTransactionHash and PrintTransactionHashNoCancel
-func printTransactionHashImpl(rp *rocketpool.Client, hash common.Hash, finalMessage string) {
+func printTransactionHashImpl(rp *rocketpool.Client, hash common.Hash, finalMessage string) error {
 
 	cfg, isNew, err := rp.LoadConfig()
 	if err != nil {
 		fmt.Printf("Warning: couldn't read config file so the transaction URL will be unavailable (%s).\n", err)
-		return
+		return nil
 	}
 
 	if isNew {
 		fmt.Print("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
-		return
+		return nil
+	}
+
+	// Nothing was submitted in a dry run, so show what the transaction would have done and stop here
+	if rp.IsDryRun() {
+		printDryRunTransaction(cfg, hash)
+		return ErrTransactionNotSubmitted
+	}
+
+	// Nothing was submitted, and the rest of the command depends on this transaction being included, so stop here
+	if rp.IsUnsigned() {
+		printUnsignedTransaction(cfg, hash)
+		return ErrTransactionNotSubmitted
+	}
+
+	// The daemon submits deferred transactions later, and the rest of the command depends on this one being included, so stop here
+	if rp.IsDeferred() {
+		fmt.Printf("Transaction has been signed with hash %s and saved for the node daemon.\n", hash.String())
+		fmt.Printf("The daemon will submit it once the network's fees are low enough for its max fee of %.2f gwei; it will log when it does.\n", rp.GetSubmitBelowFee())
+		fmt.Println("Other deferred transactions will be queued after it, but if you submit a transaction normally before then it will take this one's nonce and this one will be dropped.")
+		return ErrTransactionNotSubmitted
+	}
+
+	if safeAddress := cfg.Smartnode.SafeAddress.Value.(string); safeAddress != "" {
+		fmt.Printf("Transaction has been proposed to your node's Safe (%s).\n", safeAddress)
+		fmt.Println("It will only run once the Safe's owners approve and execute it, which you can do from the Safe's transaction queue.")
+		fmt.Print("Waiting for the Safe to execute the transaction... you may wait here for it, or press CTRL+C to exit and return to the terminal; the node daemon will log when it's executed.\n\n")
+		return nil
 	}
 
 	txWatchUrl := cfg.Smartnode.GetTxWatchUrl()
@@ -65,6 +99,7 @@ func printTransactionHashImpl(rp *rocketpool.Client, hash common.Hash, finalMess
 		fmt.Printf("%s/%s\n\n", txWatchUrl, hashString)
 	}
 	fmt.Print(finalMessage)
+	return nil
 
 }
 
@@ -94,6 +129,11 @@ var errorMap = map[string]string{
 // Prints an error in a prettier format, removing the "stack trace" if it represents
 // a contract revert message
 func PrettyPrintError(err error) {
+	fmt.Println(getPrettyErrorMessage(err))
+}
+
+// Get the message for an error, replacing contract revert messages with friendlier explanations where possible
+func getPrettyErrorMessage(err error) string {
 	errorMessage := err.Error()
 	prettyErr := errorMessage
 	if strings.Contains(errorMessage, "execution reverted:") {
@@ -108,7 +148,7 @@ func PrettyPrintError(err error) {
 			prettyErr = replacementMessage
 		}
 	}
-	fmt.Println(prettyErr)
+	return prettyErr
 }
 
 // Prints an error message when the Beacon client is not using the deposit contract address that Rocket Pool expects
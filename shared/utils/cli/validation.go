@@ -225,6 +225,14 @@ func ValidateWalletMnemonic(name, value string) (string, error) {
 	return value, nil
 }
 
+// Validate the name of a stored node wallet
+func ValidateWalletName(name, value string) (string, error) {
+	if !regexp.MustCompile("^[a-zA-Z0-9_-]{1,32}$").MatchString(value) {
+		return "", fmt.Errorf("Invalid %s '%s' - must be 1 to 32 letters, numbers, dashes or underscores", name, value)
+	}
+	return value, nil
+}
+
 // Validate a timezone location
 func ValidateTimezoneLocation(name, value string) (string, error) {
 	if !regexp.MustCompile("^([a-zA-Z_]{2,}\\/)+[a-zA-Z_]{2,}$").MatchString(value) {
@@ -275,6 +283,45 @@ func ValidatePubkey(name, value string) (types.ValidatorPubkey, error) {
 	return pubkey, nil
 }
 
+// Validate a comma-separated list of validator pubkeys
+func ValidatePubkeys(name, value string) ([]types.ValidatorPubkey, error) {
+	pubkeys := []types.ValidatorPubkey{}
+	for _, element := range strings.Split(value, ",") {
+		pubkey, err := ValidatePubkey(name, strings.TrimSpace(element))
+		if err != nil {
+			return nil, err
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+}
+
+// Validate a validator signature
+func ValidateValidatorSignature(name, value string) (types.ValidatorSignature, error) {
+	signature, err := types.HexToValidatorSignature(hexutils.RemovePrefix(value))
+	if err != nil {
+		return types.ValidatorSignature{}, fmt.Errorf("Invalid %s '%s': %w", name, value, err)
+	}
+	return signature, nil
+}
+
+// Validate a comma-separated list of pubkey=path pairs
+func ValidateValidatorKeyPathOverrides(name, value string) (map[types.ValidatorPubkey]string, error) {
+	overrides := map[types.ValidatorPubkey]string{}
+	for _, element := range strings.Split(value, ",") {
+		pubkeyString, derivationPath, found := strings.Cut(strings.TrimSpace(element), "=")
+		if !found || derivationPath == "" {
+			return nil, fmt.Errorf("Invalid %s '%s' - must be in the form pubkey=path", name, element)
+		}
+		pubkey, err := ValidatePubkey(name, strings.TrimSpace(pubkeyString))
+		if err != nil {
+			return nil, err
+		}
+		overrides[pubkey] = strings.TrimSpace(derivationPath)
+	}
+	return overrides, nil
+}
+
 // Validate a hex-encoded byte array
 func ValidateByteArray(name, value string) ([]byte, error) {
 	// Remove a 0x prefix if present
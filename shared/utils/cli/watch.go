@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const (
+	clearScreen      string = "\033[H\033[2J"
+	progressBarWidth int    = 40
+)
+
+// The flags for commands that can re-render their output on an interval
+var WatchFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "watch",
+		Usage: "Keep the output on screen and refresh it on an interval until you press CTRL+C",
+	},
+	cli.Uint64Flag{
+		Name:  "watch-interval",
+		Usage: "How often to refresh the output with --watch, in seconds",
+		Value: 15,
+	},
+}
+
+// Get the refresh interval requested with --watch, or zero if the output shouldn't be watched
+func GetWatchInterval(c *cli.Context) (time.Duration, error) {
+	if !c.Bool("watch") {
+		return 0, nil
+	}
+	if IsJsonOutput(c) {
+		return 0, fmt.Errorf("--watch can't be used with --json.")
+	}
+	interval := time.Duration(c.Uint64("watch-interval")) * time.Second
+	if interval < time.Second {
+		return 0, fmt.Errorf("The watch interval must be at least 1 second.")
+	}
+	return interval, nil
+}
+
+// Clear the screen and render the output every interval until the user presses CTRL+C.
+// Errors from a render are printed instead of stopping the loop, since they're usually transient.
+func Watch(interval time.Duration, render func() error) error {
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		fmt.Print(clearScreen)
+		if err := render(); err != nil {
+			fmt.Printf("%s%s%s\n", colorRed, getPrettyErrorMessage(err), colorReset)
+		}
+		fmt.Printf("\nLast updated %s, refreshing every %s. Press CTRL+C to exit.\n", time.Now().Format("15:04:05"), interval)
+
+		select {
+		case <-interrupt:
+			fmt.Println()
+			return nil
+		case <-ticker.C:
+		}
+	}
+
+}
+
+// Print a progress bar for the sync status of each of the managed clients
+func PrintSyncProgressBars(status api.NodeSyncProgressResponse) {
+	fmt.Printf("%s=== Sync Status ===%s\n", colorGreen, colorReset)
+	printClientManagerProgressBars(status.EcStatus, "Execution")
+	printClientManagerProgressBars(status.BcStatus, "Consensus")
+	fmt.Println()
+}
+
+// Print a progress bar for a client manager's primary client and its fallbacks
+func printClientManagerProgressBars(status api.ClientManagerStatus, name string) {
+	printClientProgressBar(status.PrimaryClientStatus, name)
+	if !status.FallbackEnabled {
+		return
+	}
+	printClientProgressBar(status.FallbackClientStatus, fmt.Sprintf("%s (fallback)", name))
+	for i, fallbackStatus := range status.AdditionalFallbackStatuses {
+		printClientProgressBar(fallbackStatus, fmt.Sprintf("%s (fallback %d)", name, i+2))
+	}
+}
+
+// Print a progress bar for a client's sync status
+func printClientProgressBar(status api.ClientStatus, name string) {
+	label := fmt.Sprintf("%-24s", name+":")
+	if status.Error != "" {
+		fmt.Printf("%s %sunavailable (%s)%s\n", label, colorRed, status.Error, colorReset)
+		return
+	}
+
+	progress := status.SyncProgress
+	color := colorYellow
+	if status.IsSynced {
+		progress = 1
+		color = colorGreen
+	}
+	if progress < 0 {
+		progress = 0
+	} else if progress > 1 {
+		progress = 1
+	}
+	filled := int(progress * float64(progressBarWidth))
+	bar := strings.Repeat("#", filled) + strings.Repeat("-", progressBarWidth-filled)
+	fmt.Printf("%s %s[%s] %6.2f%%%s\n", label, color, bar, rocketpool.SyncRatioToPercent(progress), colorReset)
+}
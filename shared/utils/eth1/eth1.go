@@ -8,14 +8,33 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/urfave/cli"
 )
 
+// An execution client that drops transactions instead of submitting them
+type noSendClient struct {
+	rocketpool.ExecutionClient
+}
+
+func (c noSendClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return nil
+}
+
+// Send a transaction to an address like eth.SendTransaction, but only sign it without submitting it if the options have NoSend set
+func SendTransaction(client rocketpool.ExecutionClient, toAddress common.Address, chainID *big.Int, data []byte, useSafeGasLimit bool, opts *bind.TransactOpts) (common.Hash, error) {
+	if opts.NoSend {
+		client = noSendClient{ExecutionClient: client}
+	}
+	return eth.SendTransaction(client, toAddress, chainID, data, useSafeGasLimit, opts)
+}
+
 // Sets the nonce of the provided transaction options to the latest nonce if requested
 func CheckForNonceOverride(c *cli.Context, opts *bind.TransactOpts) error {
 
@@ -33,14 +52,14 @@ func CheckForNonceOverride(c *cli.Context, opts *bind.TransactOpts) error {
 			return fmt.Errorf("Could not retrieve ETH1 client: %w", err)
 		}
 
-		// Make sure it's not higher than the next available nonce
+		// Make sure it's not higher than the next available nonce; unsigned transactions are broadcast later, so they can be queued after each other
 		nextNonceUint, err := ec.PendingNonceAt(context.Background(), opts.From)
 		if err != nil {
 			return fmt.Errorf("Could not get next available nonce: %w", err)
 		}
 
 		nextNonce := big.NewInt(0).SetUint64(nextNonceUint)
-		if customNonce.Cmp(nextNonce) == 1 {
+		if customNonce.Cmp(nextNonce) == 1 && !c.GlobalBool("unsigned") {
 			return fmt.Errorf("Can't use nonce %s because it's greater than the next available nonce (%d).", customNonceString, nextNonceUint)
 		}
 
@@ -77,7 +96,8 @@ func GetBestApiClient(primary *rocketpool.RocketPool, cfg *config.RocketPoolConf
 		printMessage(fmt.Sprintf("Error getting state for block %d: %s", blockNumber.Uint64(), errMessage))
 		if strings.Contains(errMessage, "missing trie node") || // Geth
 			strings.Contains(errMessage, "No state available for block") || // Nethermind
-			strings.Contains(errMessage, "Internal error") { // Besu
+			strings.Contains(errMessage, "Internal error") || // Besu
+			strings.Contains(errMessage, "is pruned") { // Reth
 
 			// The state was missing so fall back to the archive node
 			archiveEcUrl := cfg.Smartnode.ArchiveECUrl.Value.(string)
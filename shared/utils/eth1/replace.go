@@ -0,0 +1,22 @@
+package eth1
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// The percentage by which a replacement transaction must raise both fees of the one it replaces, which is the default in Geth's transaction pool
+const ReplacementFeeBumpPercent int64 = 10
+
+// Get the lowest fees a transaction with the same nonce must pay for transaction pools to accept it as a replacement
+func GetMinimumReplacementFees(tx *types.Transaction) (*big.Int, *big.Int) {
+	return bumpFee(tx.GasFeeCap()), bumpFee(tx.GasTipCap())
+}
+
+// Raise a fee by the replacement percentage, rounding up
+func bumpFee(fee *big.Int) *big.Int {
+	bumped := big.NewInt(0).Mul(fee, big.NewInt(100+ReplacementFeeBumpPercent))
+	bumped.Div(bumped, big.NewInt(100))
+	return bumped.Add(bumped, big.NewInt(1))
+}
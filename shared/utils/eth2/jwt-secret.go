@@ -0,0 +1,20 @@
+package eth2
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// The number of random bytes in an Engine API JWT secret
+const JwtSecretLength int = 32
+
+// Generate a random, hex-encoded Engine API JWT secret
+func GenerateJwtSecret() ([]byte, error) {
+	secretBytes := make([]byte, JwtSecretLength)
+	_, err := rand.Read(secretBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error generating new JWT secret: %w", err)
+	}
+	return []byte(hex.EncodeToString(secretBytes)), nil
+}
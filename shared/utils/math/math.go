@@ -2,6 +2,8 @@ package math
 
 import (
 	"math"
+	"math/big"
+	"strings"
 )
 
 // Round a float64 down to a number of places
@@ -13,3 +15,21 @@ func RoundDown(val float64, places int) float64 {
 func RoundUp(val float64, places int) float64 {
 	return math.Ceil(val*math.Pow10(places)) / math.Pow10(places)
 }
+
+// Formats a wei amount as an exact decimal number of ETH (or RPL), without trailing zeros
+func WeiToDecimalString(wei *big.Int) string {
+	if wei == nil {
+		return ""
+	}
+	value := new(big.Rat).SetFrac(wei, big.NewInt(1e18)).FloatString(18)
+	value = strings.TrimRight(value, "0")
+	return strings.TrimSuffix(value, ".")
+}
+
+// Formats a fraction stored in wei (where 1e18 is 100%) as an exact percentage
+func WeiToPercentString(fraction *big.Int) string {
+	if fraction == nil {
+		return ""
+	}
+	return WeiToDecimalString(big.NewInt(0).Mul(fraction, big.NewInt(100)))
+}
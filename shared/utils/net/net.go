@@ -2,7 +2,10 @@ package net
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"regexp"
+	"strings"
 )
 
 // Add a default port to a host address
@@ -12,3 +15,24 @@ func DefaultPort(host string, port string) string {
 	}
 	return host
 }
+
+// Check if a hostname (without a port) refers to this machine's loopback interface
+func IsLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Make sure a URL either uses TLS or only points at this machine, so secrets sent to it can't be read on the network
+func RequireTlsUnlessLoopback(rawUrl string) error {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return fmt.Errorf("error parsing URL [%s]: %w", rawUrl, err)
+	}
+	if parsedUrl.Scheme == "https" || IsLoopbackHost(parsedUrl.Hostname()) {
+		return nil
+	}
+	return fmt.Errorf("[%s] is not on this machine, so it must use https", rawUrl)
+}
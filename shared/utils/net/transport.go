@@ -0,0 +1,55 @@
+package net
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Connection pool settings for the Execution and Beacon client wrappers.
+// State builds send hundreds of concurrent requests to the same couple of hosts, so the pool keeps far more idle
+// connections per host than Go's default of 2; otherwise each burst opens and tears down new sockets and can run out of ephemeral ports.
+const (
+	clientMaxIdleConns        int           = 512
+	clientMaxIdleConnsPerHost int           = 128
+	clientMaxConnsPerHost     int           = 256
+	clientIdleConnTimeout     time.Duration = 90 * time.Second
+	clientDialTimeout         time.Duration = 30 * time.Second
+	clientKeepAlive           time.Duration = 30 * time.Second
+	clientTlsHandshakeTimeout time.Duration = 10 * time.Second
+)
+
+var clientTransport *http.Transport
+var clientTransportOnce sync.Once
+
+// Get the connection pool shared by every client wrapper.
+// It negotiates HTTP/2 with endpoints that support it over TLS, so requests to third-party providers share a single connection.
+func GetClientTransport() *http.Transport {
+	clientTransportOnce.Do(func() {
+		dialer := &net.Dialer{
+			Timeout:   clientDialTimeout,
+			KeepAlive: clientKeepAlive,
+		}
+		clientTransport = &http.Transport{
+			Proxy:                 http.ProxyFromEnvironment,
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          clientMaxIdleConns,
+			MaxIdleConnsPerHost:   clientMaxIdleConnsPerHost,
+			MaxConnsPerHost:       clientMaxConnsPerHost,
+			IdleConnTimeout:       clientIdleConnTimeout,
+			TLSHandshakeTimeout:   clientTlsHandshakeTimeout,
+			ExpectContinueTimeout: time.Second,
+		}
+	})
+	return clientTransport
+}
+
+// Create an HTTP client on the shared connection pool, with the provided timeout (or none if it's 0)
+func NewHttpClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Transport: GetClientTransport(),
+		Timeout:   timeout,
+	}
+}
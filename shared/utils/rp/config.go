@@ -6,12 +6,17 @@ import (
 	"path/filepath"
 
 	"github.com/alessio/shellescape"
+	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/config/migration"
 	"gopkg.in/yaml.v2"
 )
 
 const (
 	upgradeFlagFile string = ".firstrun"
+
+	// The settings file from before the last upgrade, kept so the upgrade can be rolled back
+	RollbackSettingsFile string = "user-settings-rollback.yml"
 )
 
 // Loads a config without updating it if it exists
@@ -26,6 +31,11 @@ func LoadConfigFromFile(path string) (*config.RocketPoolConfig, error) {
 		return nil, err
 	}
 
+	// Apply the environment variable overrides
+	if err := cfg.ApplySettingOverrides(); err != nil {
+		return nil, fmt.Errorf("error applying setting overrides: %w", err)
+	}
+
 	return cfg, nil
 }
 
@@ -38,6 +48,11 @@ func SaveConfig(cfg *config.RocketPoolConfig, path string) error {
 		return fmt.Errorf("could not serialize settings file: %w", err)
 	}
 
+	// Keep the old settings file if this upgrades it
+	if err := saveRollbackFile(path); err != nil {
+		return err
+	}
+
 	if err := os.WriteFile(path, configBytes, 0664); err != nil {
 		return fmt.Errorf("could not write Rocket Pool config to %s: %w", shellescape.Quote(path), err)
 	}
@@ -46,6 +61,66 @@ func SaveConfig(cfg *config.RocketPoolConfig, path string) error {
 
 }
 
+// Report what upgrading a settings file to the current Smartnode version would change, without modifying it
+func PlanConfigUpgrade(path string) (*migration.UpgradeReport, error) {
+	settings, err := loadSerializedConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return migration.PlanUpgrade(settings)
+}
+
+// Restore the settings file from before the last upgrade, returning the Smartnode version it was made by
+func RollbackConfig(path string) (string, error) {
+	rollbackPath := filepath.Join(filepath.Dir(path), RollbackSettingsFile)
+	settings, err := loadSerializedConfig(rollbackPath)
+	if err != nil {
+		return "", err
+	}
+	configBytes, err := os.ReadFile(rollbackPath)
+	if err != nil {
+		return "", fmt.Errorf("could not read rollback settings file at %s: %w", shellescape.Quote(rollbackPath), err)
+	}
+	if err := os.WriteFile(path, configBytes, 0664); err != nil {
+		return "", fmt.Errorf("could not write Rocket Pool config to %s: %w", shellescape.Quote(path), err)
+	}
+	return settings["root"]["version"], nil
+}
+
+// Copy the settings file to the rollback file if it was made by a different Smartnode version (or can't be read)
+func saveRollbackFile(path string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+	settings, err := loadSerializedConfig(path)
+	if err == nil && settings["root"]["version"] == fmt.Sprintf("v%s", shared.RocketPoolVersion) {
+		return nil
+	}
+
+	configBytes, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read Rocket Pool config at %s: %w", shellescape.Quote(path), err)
+	}
+	rollbackPath := filepath.Join(filepath.Dir(path), RollbackSettingsFile)
+	if err := os.WriteFile(rollbackPath, configBytes, 0664); err != nil {
+		return fmt.Errorf("could not write rollback settings file to %s: %w", shellescape.Quote(rollbackPath), err)
+	}
+	return nil
+}
+
+// Read a settings file without deserializing or upgrading it
+func loadSerializedConfig(path string) (map[string]map[string]string, error) {
+	configBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read settings file at %s: %w", shellescape.Quote(path), err)
+	}
+	var settings map[string]map[string]string
+	if err := yaml.Unmarshal(configBytes, &settings); err != nil {
+		return nil, fmt.Errorf("could not parse settings file at %s: %w", shellescape.Quote(path), err)
+	}
+	return settings, nil
+}
+
 // Checks if this is the first run of the configurator after an install
 func IsFirstRun(configDir string) bool {
 	upgradeFilePath := filepath.Join(configDir, upgradeFlagFile)
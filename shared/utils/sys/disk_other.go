@@ -0,0 +1,14 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package sys
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Get the free and total space, in bytes, of the filesystem holding the provided path
+func GetDiskSpace(path string) (uint64, uint64, error) {
+	return 0, 0, fmt.Errorf("checking disk space is not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,19 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package sys
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// Get the free and total space, in bytes, of the filesystem holding the provided path
+func GetDiskSpace(path string) (uint64, uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, fmt.Errorf("error getting disk space for %s: %w", path, err)
+	}
+	blockSize := uint64(stat.Bsize)
+	return uint64(stat.Bavail) * blockSize, uint64(stat.Blocks) * blockSize, nil
+}
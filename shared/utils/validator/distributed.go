@@ -0,0 +1,166 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
+	prdeposit "github.com/prysmaticlabs/prysm/v3/contracts/deposit"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/eth2"
+	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Settings
+const (
+	distributedDepositDataDirMode  = 0755
+	distributedDepositDataFileMode = 0644
+)
+
+// A deposit data entry produced by a distributed validator cluster's key generation ceremony, in the staking-deposit-cli format
+type DistributedDepositData struct {
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+	Signature             string `json:"signature"`
+	DepositDataRoot       string `json:"deposit_data_root"`
+}
+
+// Get the validator pubkey of a deposit data entry
+func (d *DistributedDepositData) GetPubkey() (rptypes.ValidatorPubkey, error) {
+	return rptypes.HexToValidatorPubkey(hexutils.RemovePrefix(d.Pubkey))
+}
+
+// Parse the deposit data file of a distributed validator cluster
+func ParseDistributedDepositData(bytes []byte) ([]DistributedDepositData, error) {
+	entries := []DistributedDepositData{}
+	err := json.Unmarshal(bytes, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing deposit data: %w", err)
+	}
+	return entries, nil
+}
+
+// Get the path of the deposit data for a distributed validator
+func GetDistributedDepositDataPath(dir string, pubkey rptypes.ValidatorPubkey) string {
+	return filepath.Join(dir, fmt.Sprintf("0x%s.json", pubkey.Hex()))
+}
+
+// Check if a validator is run by a distributed validator cluster instead of a key in the node wallet
+func IsDistributedValidator(dir string, pubkey rptypes.ValidatorPubkey) (bool, error) {
+	_, err := os.Stat(GetDistributedDepositDataPath(dir, pubkey))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error checking for distributed validator deposit data: %w", err)
+	}
+	return true, nil
+}
+
+// Save the deposit data entries of a distributed validator
+func SaveDistributedDepositData(dir string, pubkey rptypes.ValidatorPubkey, entries []DistributedDepositData) error {
+	bytes, err := json.MarshalIndent(entries, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error serializing deposit data: %w", err)
+	}
+	err = os.MkdirAll(dir, distributedDepositDataDirMode)
+	if err != nil {
+		return fmt.Errorf("error creating distributed validator folder [%s]: %w", dir, err)
+	}
+	path := GetDistributedDepositDataPath(dir, pubkey)
+	err = os.WriteFile(path, bytes, distributedDepositDataFileMode)
+	if err != nil {
+		return fmt.Errorf("error writing deposit data [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Load the deposit data entries of a distributed validator
+func LoadDistributedDepositData(dir string, pubkey rptypes.ValidatorPubkey) ([]DistributedDepositData, error) {
+	path := GetDistributedDepositDataPath(dir, pubkey)
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading deposit data for distributed validator %s: %w", pubkey.Hex(), err)
+	}
+	return ParseDistributedDepositData(bytes)
+}
+
+// Get the deposit data & root of a distributed validator for the given withdrawal credentials and amount.
+// The signature is checked since it was made by the cluster rather than the node wallet.
+func GetDistributedDepositData(dir string, pubkey rptypes.ValidatorPubkey, withdrawalCredentials common.Hash, eth2Config beacon.Eth2Config, depositAmount uint64) (eth2.DepositData, common.Hash, error) {
+
+	entries, err := LoadDistributedDepositData(dir, pubkey)
+	if err != nil {
+		return eth2.DepositData{}, common.Hash{}, err
+	}
+	for _, entry := range entries {
+		if entry.Amount != depositAmount {
+			continue
+		}
+		entryPubkey, err := entry.GetPubkey()
+		if err != nil {
+			return eth2.DepositData{}, common.Hash{}, err
+		}
+		if entryPubkey != pubkey {
+			continue
+		}
+		return verifyDistributedDepositData(entry, pubkey, withdrawalCredentials, eth2Config)
+	}
+	return eth2.DepositData{}, common.Hash{}, fmt.Errorf("distributed validator %s has no deposit data for %d gwei", pubkey.Hex(), depositAmount)
+
+}
+
+// Build the deposit data from an entry and make sure it's valid for the minipool
+func verifyDistributedDepositData(entry DistributedDepositData, pubkey rptypes.ValidatorPubkey, withdrawalCredentials common.Hash, eth2Config beacon.Eth2Config) (eth2.DepositData, common.Hash, error) {
+
+	entryCredentials := common.HexToHash(entry.WithdrawalCredentials)
+	if entryCredentials != withdrawalCredentials {
+		return eth2.DepositData{}, common.Hash{}, fmt.Errorf("the deposit data for distributed validator %s has withdrawal credentials %s, but the minipool's are %s", pubkey.Hex(), entryCredentials.Hex(), withdrawalCredentials.Hex())
+	}
+	signature, err := rptypes.HexToValidatorSignature(hexutils.RemovePrefix(entry.Signature))
+	if err != nil {
+		return eth2.DepositData{}, common.Hash{}, fmt.Errorf("invalid signature in the deposit data for distributed validator %s: %w", pubkey.Hex(), err)
+	}
+	depositData := eth2.DepositData{
+		PublicKey:             pubkey.Bytes(),
+		WithdrawalCredentials: withdrawalCredentials.Bytes(),
+		Amount:                entry.Amount,
+		Signature:             signature.Bytes(),
+	}
+
+	// Check the signature against the deposit domain
+	depositDomain, err := signing.ComputeDomain(eth2types.DomainDeposit, eth2Config.GenesisForkVersion, eth2types.ZeroGenesisValidatorsRoot)
+	if err != nil {
+		return eth2.DepositData{}, common.Hash{}, err
+	}
+	err = prdeposit.VerifyDepositSignature(&ethpb.Deposit_Data{
+		PublicKey:             depositData.PublicKey,
+		WithdrawalCredentials: depositData.WithdrawalCredentials,
+		Amount:                depositData.Amount,
+		Signature:             depositData.Signature,
+	}, depositDomain)
+	if err != nil {
+		return eth2.DepositData{}, common.Hash{}, fmt.Errorf("the deposit data for distributed validator %s has an invalid signature for this network: %w", pubkey.Hex(), err)
+	}
+
+	// Check the root
+	depositDataRoot, err := depositData.HashTreeRoot()
+	if err != nil {
+		return eth2.DepositData{}, common.Hash{}, err
+	}
+	if entry.DepositDataRoot != "" && !bytes.Equal(common.FromHex(entry.DepositDataRoot), depositDataRoot[:]) {
+		return eth2.DepositData{}, common.Hash{}, fmt.Errorf("the deposit data root for distributed validator %s is %s, but its deposit data hashes to %s", pubkey.Hex(), entry.DepositDataRoot, common.Hash(depositDataRoot).Hex())
+	}
+
+	return depositData, depositDataRoot, nil
+
+}
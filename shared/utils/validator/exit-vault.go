@@ -0,0 +1,90 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const (
+	exitVaultVersion  = 1
+	exitVaultFileMode = 0600
+)
+
+// A set of pre-signed voluntary exits, encrypted with a password the same way validator keystores are
+type ExitVault struct {
+	Version     int                    `json:"version"`
+	Created     time.Time              `json:"created"`
+	NodeAddress common.Address         `json:"nodeAddress"`
+	Epoch       uint64                 `json:"epoch"`
+	Count       int                    `json:"count"`
+	Crypto      map[string]interface{} `json:"crypto"`
+}
+
+// Encrypt a set of pre-signed exits into a vault
+func NewExitVault(nodeAddress common.Address, epoch uint64, exits []api.PresignedExit, password string) (*ExitVault, error) {
+	bytes, err := json.Marshal(exits)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing pre-signed exits: %w", err)
+	}
+	crypto, err := eth2ks.New().Encrypt(bytes, password)
+	if err != nil {
+		return nil, fmt.Errorf("error encrypting pre-signed exits: %w", err)
+	}
+	return &ExitVault{
+		Version:     exitVaultVersion,
+		Created:     time.Now().UTC(),
+		NodeAddress: nodeAddress,
+		Epoch:       epoch,
+		Count:       len(exits),
+		Crypto:      crypto,
+	}, nil
+}
+
+// Decrypt the pre-signed exits in a vault
+func (v *ExitVault) Decrypt(password string) ([]api.PresignedExit, error) {
+	bytes, err := eth2ks.New().Decrypt(v.Crypto, password)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting exit vault; is the password correct? %w", err)
+	}
+	exits := []api.PresignedExit{}
+	if err := json.Unmarshal(bytes, &exits); err != nil {
+		return nil, fmt.Errorf("error deserializing pre-signed exits: %w", err)
+	}
+	return exits, nil
+}
+
+// Load an exit vault from disk
+func LoadExitVault(path string) (*ExitVault, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading exit vault [%s]: %w", path, err)
+	}
+	vault := &ExitVault{}
+	if err := json.Unmarshal(bytes, vault); err != nil {
+		return nil, fmt.Errorf("error deserializing exit vault [%s]: %w", path, err)
+	}
+	if vault.Version != exitVaultVersion {
+		return nil, fmt.Errorf("exit vault [%s] has unsupported version %d", path, vault.Version)
+	}
+	return vault, nil
+}
+
+// Save an exit vault to disk
+func (v *ExitVault) Save(path string) error {
+	bytes, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error serializing exit vault: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, exitVaultFileMode); err != nil {
+		return fmt.Errorf("error writing exit vault [%s]: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const (
+	scheduledExitsDirMode  = 0755
+	scheduledExitsFileMode = 0644
+)
+
+// Get the path of a minipool's scheduled exit
+func GetScheduledExitPath(dir string, minipoolAddress common.Address) string {
+	return filepath.Join(dir, fmt.Sprintf("%s.json", minipoolAddress.Hex()))
+}
+
+// Save a scheduled exit, replacing the minipool's existing one
+func SaveScheduledExit(dir string, exit api.ScheduledExit) error {
+	bytes, err := json.MarshalIndent(exit, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error serializing scheduled exit: %w", err)
+	}
+	err = os.MkdirAll(dir, scheduledExitsDirMode)
+	if err != nil {
+		return fmt.Errorf("error creating scheduled exits folder [%s]: %w", dir, err)
+	}
+	path := GetScheduledExitPath(dir, exit.Minipool)
+	err = os.WriteFile(path, bytes, scheduledExitsFileMode)
+	if err != nil {
+		return fmt.Errorf("error writing scheduled exit [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Load the scheduled exits, in the order they'll be broadcast
+func LoadScheduledExits(dir string) ([]api.ScheduledExit, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []api.ScheduledExit{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading scheduled exits folder [%s]: %w", dir, err)
+	}
+
+	exits := []api.ScheduledExit{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading scheduled exit [%s]: %w", path, err)
+		}
+		var exit api.ScheduledExit
+		if err := json.Unmarshal(bytes, &exit); err != nil {
+			return nil, fmt.Errorf("error deserializing scheduled exit [%s]: %w", path, err)
+		}
+		exits = append(exits, exit)
+	}
+	sort.SliceStable(exits, func(i, j int) bool {
+		if exits[i].Epoch != exits[j].Epoch {
+			return exits[i].Epoch < exits[j].Epoch
+		}
+		return exits[i].Created.Before(exits[j].Created)
+	})
+	return exits, nil
+}
+
+// Remove a minipool's scheduled exit
+func RemoveScheduledExit(dir string, minipoolAddress common.Address) error {
+	path := GetScheduledExitPath(dir, minipoolAddress)
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing scheduled exit [%s]: %w", path, err)
+	}
+	return nil
+}
@@ -5,10 +5,60 @@ import (
 	"strconv"
 
 	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/types/eth2"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 )
 
+// Sign a voluntary exit for a validator at the current epoch and broadcast it, returning the epoch
+func BroadcastExitMessage(bc beacon.Client, validatorKey *eth2types.BLSPrivateKey, validatorPubkey types.ValidatorPubkey) (uint64, error) {
+
+	// Get beacon head
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return 0, err
+	}
+
+	// Get voluntary exit signature domain
+	signatureDomain, err := GetVoluntaryExitDomain(bc)
+	if err != nil {
+		return 0, err
+	}
+
+	// Get validator index
+	validatorIndex, err := bc.GetValidatorIndex(validatorPubkey)
+	if err != nil {
+		return 0, err
+	}
+
+	// Get signed voluntary exit message
+	signature, err := GetSignedExitMessage(validatorKey, validatorIndex, head.Epoch, signatureDomain)
+	if err != nil {
+		return 0, err
+	}
+
+	// Broadcast voluntary exit message
+	if err := bc.ExitValidator(validatorIndex, head.Epoch, signature); err != nil {
+		return 0, err
+	}
+	return head.Epoch, nil
+
+}
+
+// Get the voluntary exit signature domain.
+// Since EIP-7044 (Deneb), exits are always verified against the Capella fork version instead of the current one,
+// so exits signed with it stay valid through every later network upgrade.
+func GetVoluntaryExitDomain(bc beacon.Client) ([]byte, error) {
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+	if len(eth2Config.CapellaForkVersion) == 0 {
+		return nil, fmt.Errorf("the Beacon node didn't report the Capella fork version")
+	}
+	return eth2types.Domain(eth2types.DomainVoluntaryExit, eth2Config.CapellaForkVersion, eth2Config.GenesisValidatorsRoot), nil
+}
+
 // Get a voluntary exit message signature for a given validator key and index
 func GetSignedExitMessage(validatorKey *eth2types.BLSPrivateKey, validatorIndex string, epoch uint64, signatureDomain []byte) (types.ValidatorSignature, error) {
 
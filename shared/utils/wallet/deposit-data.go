@@ -0,0 +1,33 @@
+package wallet
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/eth2"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Get the deposit data & root for a minipool's validator.
+// Distributed validators use the deposit data from their cluster; all others are signed with the key in the node wallet.
+func GetMinipoolDepositData(cfg *config.RocketPoolConfig, w *wallet.Wallet, pubkey types.ValidatorPubkey, withdrawalCredentials common.Hash, eth2Config beacon.Eth2Config, depositAmount uint64) (eth2.DepositData, common.Hash, error) {
+
+	dvPath := cfg.Smartnode.GetDistributedValidatorsPath()
+	isDistributed, err := validator.IsDistributedValidator(dvPath, pubkey)
+	if err != nil {
+		return eth2.DepositData{}, common.Hash{}, err
+	}
+	if isDistributed {
+		return validator.GetDistributedDepositData(dvPath, pubkey, withdrawalCredentials, eth2Config, depositAmount)
+	}
+
+	validatorKey, err := w.GetValidatorKeyByPubkey(pubkey)
+	if err != nil {
+		return eth2.DepositData{}, common.Hash{}, err
+	}
+	return validator.GetDepositData(validatorKey, withdrawalCredentials, eth2Config, depositAmount)
+
+}
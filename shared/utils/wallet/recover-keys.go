@@ -15,11 +15,12 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	wkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
 	"github.com/urfave/cli"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
-	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 	"gopkg.in/yaml.v2"
 )
 
@@ -51,9 +52,16 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 	}
 	pubkeys = filteredPubkeys
 
+	// Distributed validators are run by their cluster, so they don't have keys to recover
 	pubkeyMap := map[types.ValidatorPubkey]bool{}
 	for _, pubkey := range pubkeys {
-		pubkeyMap[pubkey] = true
+		isDistributed, err := validator.IsDistributedValidator(cfg.Smartnode.GetDistributedValidatorsPath(), pubkey)
+		if err != nil {
+			return nil, err
+		}
+		if !isDistributed {
+			pubkeyMap[pubkey] = true
+		}
 	}
 
 	pubkeyMap, err = CheckForAndRecoverCustomMinipoolKeys(cfg, pubkeyMap, w, testOnly)
@@ -61,7 +69,16 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 		return nil, fmt.Errorf("error checking for or recovering custom validator keys: %w", err)
 	}
 
-	// Recover conventionally generated keys
+	pubkeyMap, err = recoverMinipoolKeysAtPathOverrides(pubkeyMap, w, testOnly)
+	if err != nil {
+		return nil, fmt.Errorf("error recovering validator keys with derivation path overrides: %w", err)
+	}
+	if len(pubkeyMap) == 0 {
+		return pubkeys, nil
+	}
+
+	// Recover conventionally generated keys, along with keys generated by other tools at a non-standard path
+	customKeyPath := w.GetValidatorKeyPath()
 	bucketStart := uint(0)
 	for {
 		if bucketStart >= bucketLimit {
@@ -91,6 +108,26 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 			}
 		}
 
+		if customKeyPath != "" {
+			keys, err := w.GetValidatorKeysAtPath(customKeyPath, bucketStart, bucketEnd-bucketStart)
+			if err != nil {
+				return nil, err
+			}
+			for _, validatorKey := range keys {
+				_, exists := pubkeyMap[validatorKey.PublicKey]
+				if exists {
+					// Don't move the wallet's next account index, since these keys weren't made at the standard path
+					delete(pubkeyMap, validatorKey.PublicKey)
+					if !testOnly {
+						err := w.StoreValidatorKey(validatorKey.PrivateKey, validatorKey.DerivationPath)
+						if err != nil {
+							return nil, fmt.Errorf("error recovering validator keys: %w", err)
+						}
+					}
+				}
+			}
+		}
+
 		if len(pubkeyMap) == 0 {
 			// All keys recovered!
 			break
@@ -104,6 +141,42 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 
 }
 
+// Recover the keys for any validators that have an exact derivation path set in the wallet
+func recoverMinipoolKeysAtPathOverrides(pubkeyMap map[types.ValidatorPubkey]bool, w *wallet.Wallet, testOnly bool) (map[types.ValidatorPubkey]bool, error) {
+
+	overrides, err := w.GetValidatorKeyPathOverrides()
+	if err != nil {
+		return nil, err
+	}
+	for pubkey, derivationPath := range overrides {
+		_, exists := pubkeyMap[pubkey]
+		if !exists {
+			// This pubkey isn't for any of this node's minipools so ignore it
+			continue
+		}
+
+		// Make sure the path actually produces the expected key
+		key, err := w.GetValidatorKeyAtPath(derivationPath)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(pubkey.Bytes(), key.PublicKey().Marshal()) {
+			return nil, fmt.Errorf("the key at derivation path %s does not match validator %s", derivationPath, pubkey.Hex())
+		}
+
+		if !testOnly {
+			err = w.StoreValidatorKey(key, derivationPath)
+			if err != nil {
+				return nil, fmt.Errorf("error storing validator key for %s: %w", pubkey.Hex(), err)
+			}
+		}
+		delete(pubkeyMap, pubkey)
+	}
+
+	return pubkeyMap, nil
+
+}
+
 func CheckForAndRecoverCustomMinipoolKeys(cfg *config.RocketPoolConfig, pubkeyMap map[types.ValidatorPubkey]bool, w *wallet.Wallet, testOnly bool) (map[types.ValidatorPubkey]bool, error) {
 
 	// Load custom validator keys
@@ -166,45 +239,22 @@ func CheckForAndRecoverCustomMinipoolKeys(cfg *config.RocketPoolConfig, pubkeyMa
 					return nil, fmt.Errorf("custom keystore for pubkey %s needs a password, but none was provided", keystore.Pubkey.Hex())
 				}
 
-				// Get the encryption function it uses
-				kdf, exists := keystore.Crypto["kdf"]
-				if !exists {
-					return nil, fmt.Errorf("error processing custom keystore %s: \"crypto\" didn't contain a subkey named \"kdf\"", file.Name())
-				}
-				kdfMap := kdf.(map[string]interface{})
-				function, exists := kdfMap["function"]
-				if !exists {
-					return nil, fmt.Errorf("error processing custom keystore %s: \"crypto.kdf\" didn't contain a subkey named \"function\"", file.Name())
-				}
-				functionString := function.(string)
-
 				// Decrypt the private key
-				encryptor := eth2ks.New(eth2ks.WithCipher(functionString))
-				decryptedKey, err := encryptor.Decrypt(keystore.Crypto, password)
-				if err != nil {
-					return nil, fmt.Errorf("error decrypting keystore for validator %s: %w", keystore.Pubkey.Hex(), err)
-				}
-				privateKey, err := eth2types.BLSPrivateKeyFromBytes(decryptedKey)
+				privateKey, err := wkeystore.DecryptValidatorKey(keystore.Pubkey, keystore.Crypto, password)
 				if err != nil {
-					return nil, fmt.Errorf("error recreating private key for validator %s: %w", keystore.Pubkey.Hex(), err)
-				}
-
-				// Verify the private key matches the public key
-				reconstructedPubkey := types.BytesToValidatorPubkey(privateKey.PublicKey().Marshal())
-				if reconstructedPubkey != keystore.Pubkey {
-					return nil, fmt.Errorf("private keystore file %s claims to be for validator %s but it's for validator %s", file.Name(), keystore.Pubkey.Hex(), reconstructedPubkey.Hex())
+					return nil, fmt.Errorf("error processing custom keystore %s: %w", file.Name(), err)
 				}
 
 				// Store the key
 				if !testOnly {
 					err = w.StoreValidatorKey(privateKey, keystore.Path)
 					if err != nil {
-						return nil, fmt.Errorf("error storing private keystore for %s: %w", reconstructedPubkey.Hex(), err)
+						return nil, fmt.Errorf("error storing private keystore for %s: %w", keystore.Pubkey.Hex(), err)
 					}
 				}
 
 				// Remove the pubkey from pending minipools to handle
-				delete(pubkeyMap, reconstructedPubkey)
+				delete(pubkeyMap, keystore.Pubkey)
 			}
 		}
 	}